@@ -2,6 +2,7 @@ package v1alpha1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -136,6 +137,9 @@ const (
 )
 
 // HostedClusterSpec is the desired behavior of a HostedCluster.
+// +kubebuilder:validation:XValidation:rule="!has(self.secondaryIssuerURL) || !has(self.issuerURL) || self.secondaryIssuerURL != self.issuerURL", message="secondaryIssuerURL must be different from issuerURL"
+// +kubebuilder:validation:XValidation:rule="!has(self.storage) || self.platform.type in ['AWS', 'Azure', 'IBMCloud', 'PowerVS']", message="storage is only supported on the AWS, Azure, IBMCloud and PowerVS platforms"
+// +kubebuilder:validation:XValidation:rule="!has(self.imageVerification) || self.release.image.contains('@sha256:')", message="release.image must be referenced by digest when imageVerification is set"
 type HostedClusterSpec struct {
 	// Release specifies the desired OCP release payload for the hosted cluster.
 	//
@@ -262,12 +266,26 @@ type HostedClusterSpec struct {
 	// default value is kubernetes.default.svc, which only works for in-cluster
 	// validation.
 	//
+	// Changing the IssuerURL invalidates the ServiceAccount tokens issued
+	// under the previous one, unless it is kept as the SecondaryIssuerURL
+	// until those tokens are rotated.
+	//
 	// +kubebuilder:default:="https://kubernetes.default.svc"
-	// +immutable
 	// +optional
 	// +kubebuilder:validation:Format=uri
 	IssuerURL string `json:"issuerURL,omitempty"`
 
+	// SecondaryIssuerURL is an OIDC issuer URL the control plane API server
+	// accepts ServiceAccount tokens from in addition to IssuerURL, without
+	// issuing tokens under it. It allows migrating to a new IssuerURL, e.g. a
+	// vanity domain, without breaking the tokens issued under the previous
+	// one: set it to the previous IssuerURL until all those tokens have been
+	// rotated, then unset it.
+	//
+	// +optional
+	// +kubebuilder:validation:Format=uri
+	SecondaryIssuerURL string `json:"secondaryIssuerURL,omitempty"`
+
 	// ServiceAccountSigningKey is a reference to a secret containing the private key
 	// used by the service account token issuer. The secret is expected to contain
 	// a single key named "key". If not specified, a service account signing key will
@@ -332,6 +350,17 @@ type HostedClusterSpec struct {
 	// +optional
 	PausedUntil *string `json:"pausedUntil,omitempty"`
 
+	// PauseScope restricts what PausedUntil pauses while it's in effect. All pauses
+	// the reconciliation of the HostedCluster and its HostedControlPlane.
+	// NodePoolRollouts only holds the version and config rollouts of the NodePools
+	// of the cluster, and ControlPlaneUpgrades only holds control plane upgrades to
+	// a new release; everything else keeps being reconciled. An event is emitted on
+	// the HostedCluster when a pause ends.
+	// +kubebuilder:validation:Enum=All;NodePoolRollouts;ControlPlaneUpgrades
+	// +kubebuilder:default=All
+	// +optional
+	PauseScope PauseScope `json:"pauseScope,omitempty"`
+
 	// OLMCatalogPlacement specifies the placement of OLM catalog components. By default,
 	// this is set to management and OLM catalog components are deployed onto the management
 	// cluster. If set to guest, the OLM catalog components will be deployed onto the guest
@@ -342,12 +371,111 @@ type HostedClusterSpec struct {
 	// +immutable
 	OLMCatalogPlacement OLMCatalogPlacement `json:"olmCatalogPlacement,omitempty"`
 
+	// OLMCatalogs configures the OLM catalogs of the cluster: custom catalog sources can be added, e.g. to use
+	// catalogs mirrored to a disconnected registry, and the default catalogs can be disabled. Custom catalog sources
+	// are placed according to OLMCatalogPlacement.
+	//
+	// +optional
+	OLMCatalogs *OLMCatalogs `json:"olmCatalogs,omitempty"`
+
 	// NodeSelector when specified, must be true for the pods managed by the HostedCluster to be scheduled.
 	//
 	// +optional
 	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Labels when specified, are added to the control plane namespace and to the pods managed by the HostedCluster,
+	// e.g. so that policy engines or cost reporting on the management cluster can select hosted control planes
+	// uniformly. The labels HyperShift sets on the namespace and the labels that select the pods of a workload take
+	// precedence over these.
+	//
+	// +kubebuilder:validation:MaxProperties=20
+	// +kubebuilder:validation:XValidation:rule="self.all(key, !key.contains('hypershift.openshift.io/'))", message="labels in the hypershift.openshift.io domain are reserved"
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations when specified, are added to the control plane namespace and to the pods managed by the
+	// HostedCluster. The annotations HyperShift sets on the namespace take precedence over these.
+	//
+	// +kubebuilder:validation:MaxProperties=20
+	// +kubebuilder:validation:XValidation:rule="self.all(key, !key.contains('hypershift.openshift.io/'))", message="annotations in the hypershift.openshift.io domain are reserved"
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// DataPlaneInfrastructurePlacement specifies the default node selector and tolerations of the infrastructure
+	// components of the hosted cluster which run in the data plane, e.g. the konnectivity agent, the default ingress
+	// controller and the CSI driver node pods, so they can be scheduled on fully tainted special purpose NodePools.
+	//
+	// +optional
+	DataPlaneInfrastructurePlacement *DataPlaneInfrastructurePlacement `json:"dataPlaneInfrastructurePlacement,omitempty"`
+
+	// DefaultIngressController configures the default IngressController of the hosted cluster, which serves its
+	// routes, so that it's set up as needed when the cluster is created rather than edited in the guest cluster.
+	// The fields that are set are also reconciled on the existing IngressController.
+	//
+	// +optional
+	DefaultIngressController *DefaultIngressControllerSpec `json:"defaultIngressController,omitempty"`
+
+	// ImageVerification configures the verification of the signatures of the release payload of the HostedCluster,
+	// the release payloads of its NodePools and the RHCOS bootimages they reference. Rollouts of images whose
+	// signatures can't be verified are blocked. The verified images must be referenced by digest, so that the image
+	// that is rolled out is the one that was verified. When unset, signatures aren't verified.
+	//
+	// +optional
+	ImageVerification *ImageVerificationPolicy `json:"imageVerification,omitempty"`
+
+	// DeletionPolicy controls what happens when the HostedCluster is deleted: whether the deletion is allowed,
+	// whether the cloud infrastructure of the cluster is cleaned up, and how long the cleanup may block the
+	// deletion. When unset, the HostedCluster and its cloud infrastructure are deleted.
+	//
+	// +optional
+	DeletionPolicy *DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// Lifetime limits how long the HostedCluster lives, e.g. for CI or demo clusters: the HostedCluster is deleted
+	// once its TTL elapsed since its creation, and reported as expiring ahead of the deletion by the Expiring
+	// condition, an event and a lifecycle notification. The deletion is subject to the deletion policy. Changing the
+	// TTL extends or shortens the lifetime. When unset, the HostedCluster lives until it's deleted.
+	//
+	// +optional
+	Lifetime *HostedClusterLifetime `json:"lifetime,omitempty"`
+
+	// KubeconfigPublishing publishes the admin kubeconfig of the cluster, and optionally custom kubeconfigs, to an
+	// external secret store in addition to the Secret referenced by status.kubeconfig. The published kubeconfigs are
+	// updated when they're rotated.
+	//
+	// +optional
+	KubeconfigPublishing *KubeconfigPublishingSpec `json:"kubeconfigPublishing,omitempty"`
+
+	// SecretsBackup periodically exports a sealed bundle of the secrets required to recover the control plane of the
+	// cluster, its root CAs and signers, service account signing key and etcd encryption keys, to an external object
+	// store. Along with an etcd backup, the bundle allows to recover the cluster even when the management cluster is
+	// lost.
+	//
+	// +optional
+	SecretsBackup *SecretsBackupSpec `json:"secretsBackup,omitempty"`
+
+	// Storage configures the storage provided by the CSI driver of the platform in the hosted cluster: the state of
+	// the StorageClasses of the CSI driver operator, and additional StorageClasses and VolumeSnapshotClasses, so
+	// that storage is standardized when the cluster is created rather than configured in the guest cluster. It is
+	// supported on the AWS, Azure, IBMCloud and PowerVS platforms.
+	//
+	// +optional
+	Storage *ClusterStorageSpec `json:"storage,omitempty"`
 }
 
+// PauseScope specifies what is paused by the PausedUntil field of a HostedCluster.
+type PauseScope string
+
+const (
+	// PauseScopeAll pauses the reconciliation of the HostedCluster and its HostedControlPlane.
+	PauseScopeAll PauseScope = "All"
+
+	// PauseScopeNodePoolRollouts holds the version and config rollouts of the NodePools of the HostedCluster.
+	PauseScopeNodePoolRollouts PauseScope = "NodePoolRollouts"
+
+	// PauseScopeControlPlaneUpgrades holds the upgrades of the control plane to a new release.
+	PauseScopeControlPlaneUpgrades PauseScope = "ControlPlaneUpgrades"
+)
+
 // OLMCatalogPlacement is an enum specifying the placement of OLM catalog components.
 // +kubebuilder:validation:Enum=management;guest
 type OLMCatalogPlacement string
@@ -362,6 +490,544 @@ const (
 	GuestOLMCatalogPlacement OLMCatalogPlacement = "guest"
 )
 
+// DataPlaneInfrastructurePlacement specifies the scheduling of the infrastructure components of a hosted cluster
+// which run in the data plane.
+type DataPlaneInfrastructurePlacement struct {
+	// NodeSelector when specified, must be true for the data plane infrastructure pods to be scheduled.
+	//
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations are added to the data plane infrastructure pods, e.g. so they can be scheduled on nodes with
+	// taints.
+	//
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+}
+
+// IngressLoadBalancerScope is the scope of the load balancer of an IngressController.
+// +kubebuilder:validation:Enum=External;Internal
+type IngressLoadBalancerScope string
+
+const (
+	// ExternalIngressLoadBalancer is a load balancer reachable from the internet.
+	ExternalIngressLoadBalancer IngressLoadBalancerScope = "External"
+	// InternalIngressLoadBalancer is a load balancer only reachable from the network of the cluster.
+	InternalIngressLoadBalancer IngressLoadBalancerScope = "Internal"
+)
+
+// DefaultIngressControllerSpec configures the default IngressController of a hosted cluster.
+type DefaultIngressControllerSpec struct {
+	// Replicas is the number of routers. When unset, it is 2 with the HighlyAvailable infrastructure availability
+	// policy and 1 otherwise.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// NodePool is the name of the NodePool whose nodes run the routers. The node selector and tolerations of
+	// DataPlaneInfrastructurePlacement still apply, e.g. to tolerate the taints of the NodePool.
+	//
+	// +optional
+	NodePool string `json:"nodePool,omitempty"`
+
+	// LoadBalancerScope is whether the load balancer of the routers is reachable from the internet or only from the
+	// network of the cluster, on the platforms publishing the routers with a load balancer. When unset, the load
+	// balancer is internal for private clusters and external otherwise.
+	//
+	// +optional
+	LoadBalancerScope IngressLoadBalancerScope `json:"loadBalancerScope,omitempty"`
+}
+
+// CSIStorageClassState determines whether the CSI driver operator of the platform manages the StorageClasses it
+// ships with in the hosted cluster.
+// +kubebuilder:validation:Enum=Managed;Unmanaged;Removed
+type CSIStorageClassState string
+
+const (
+	// ManagedCSIStorageClassState makes the CSI driver operator create and reconcile its StorageClasses.
+	ManagedCSIStorageClassState CSIStorageClassState = "Managed"
+	// UnmanagedCSIStorageClassState makes the CSI driver operator leave its existing StorageClasses alone.
+	UnmanagedCSIStorageClassState CSIStorageClassState = "Unmanaged"
+	// RemovedCSIStorageClassState makes the CSI driver operator delete the StorageClasses it created.
+	RemovedCSIStorageClassState CSIStorageClassState = "Removed"
+)
+
+// ClusterStorageSpec configures the storage provided by the CSI driver of the platform in the hosted cluster.
+type ClusterStorageSpec struct {
+	// StorageClassState determines whether the CSI driver operator of the platform manages the StorageClasses it
+	// ships with. It is passed through to the ClusterCSIDriver of the platform in the hosted cluster. When unset,
+	// the StorageClasses are managed.
+	//
+	// +optional
+	StorageClassState CSIStorageClassState `json:"storageClassState,omitempty"`
+
+	// StorageClasses are StorageClasses of the CSI driver of the platform created and reconciled in the hosted
+	// cluster, in addition to the ones the CSI driver operator ships with.
+	//
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=16
+	// +kubebuilder:validation:XValidation:rule="self.filter(c, has(c.default) && c.default).size() <= 1", message="at most one StorageClass can be the default"
+	// +optional
+	StorageClasses []CSIStorageClass `json:"storageClasses,omitempty"`
+
+	// VolumeSnapshotClasses are VolumeSnapshotClasses of the CSI driver of the platform created and reconciled in
+	// the hosted cluster.
+	//
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=16
+	// +kubebuilder:validation:XValidation:rule="self.filter(c, has(c.default) && c.default).size() <= 1", message="at most one VolumeSnapshotClass can be the default"
+	// +optional
+	VolumeSnapshotClasses []CSIVolumeSnapshotClass `json:"volumeSnapshotClasses,omitempty"`
+}
+
+// CSIStorageClass is a StorageClass of the CSI driver of the platform.
+type CSIStorageClass struct {
+	// Name is the name of the StorageClass.
+	//
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf", message="name is immutable"
+	Name string `json:"name"`
+
+	// Default makes the StorageClass the default StorageClass of the hosted cluster. The other StorageClasses,
+	// including the ones of the CSI driver operator, are no longer marked as the default.
+	//
+	// +optional
+	Default bool `json:"default,omitempty"`
+
+	// Parameters are the parameters of the CSI driver for the volumes provisioned with the StorageClass, e.g. the
+	// type, IOPS or encryption key of the volumes. Parameters of a StorageClass can't be changed once it's created.
+	//
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf", message="parameters are immutable"
+	// +optional
+	Parameters map[string]string `json:"parameters,omitempty"`
+
+	// ReclaimPolicy is the reclaim policy of the volumes provisioned with the StorageClass. When unset, they are
+	// deleted. The reclaim policy of a StorageClass can't be changed once it's created.
+	//
+	// +kubebuilder:validation:Enum=Delete;Retain
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf", message="reclaimPolicy is immutable"
+	// +optional
+	ReclaimPolicy *corev1.PersistentVolumeReclaimPolicy `json:"reclaimPolicy,omitempty"`
+
+	// VolumeBindingMode is when the volumes are provisioned and bound. When unset, they are bound when the first
+	// pod using them is scheduled.
+	//
+	// +kubebuilder:validation:Enum=Immediate;WaitForFirstConsumer
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf", message="volumeBindingMode is immutable"
+	// +optional
+	VolumeBindingMode *storagev1.VolumeBindingMode `json:"volumeBindingMode,omitempty"`
+
+	// AllowVolumeExpansion allows the volumes provisioned with the StorageClass to be expanded. When unset, they
+	// can be expanded.
+	//
+	// +optional
+	AllowVolumeExpansion *bool `json:"allowVolumeExpansion,omitempty"`
+}
+
+// CSIVolumeSnapshotClass is a VolumeSnapshotClass of the CSI driver of the platform.
+type CSIVolumeSnapshotClass struct {
+	// Name is the name of the VolumeSnapshotClass.
+	//
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`
+	Name string `json:"name"`
+
+	// Default makes the VolumeSnapshotClass the default VolumeSnapshotClass of the CSI driver in the hosted
+	// cluster. The other VolumeSnapshotClasses of the CSI driver are no longer marked as the default.
+	//
+	// +optional
+	Default bool `json:"default,omitempty"`
+
+	// Parameters are the parameters of the CSI driver for the snapshots taken with the VolumeSnapshotClass.
+	// Parameters of a VolumeSnapshotClass can't be changed once it's created.
+	//
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf", message="parameters are immutable"
+	// +optional
+	Parameters map[string]string `json:"parameters,omitempty"`
+
+	// DeletionPolicy is whether the snapshots taken with the VolumeSnapshotClass are deleted from the storage
+	// backend when their VolumeSnapshotContent is deleted. When unset, they are deleted.
+	//
+	// +kubebuilder:validation:Enum=Delete;Retain
+	// +optional
+	DeletionPolicy string `json:"deletionPolicy,omitempty"`
+}
+
+// ImageVerificationType is the format of the image signatures verified by an ImageVerificationPolicy.
+// +kubebuilder:validation:Enum=Cosign;GPG
+type ImageVerificationType string
+
+const (
+	// ImageVerificationTypeCosign verifies cosign signatures, stored next to the signed image in its registry
+	// under the sha256-<digest>.sig tag.
+	ImageVerificationTypeCosign ImageVerificationType = "Cosign"
+
+	// ImageVerificationTypeGPG verifies GPG signatures of the atomic container signature format, served by
+	// signature stores, as used for OpenShift release payloads.
+	ImageVerificationTypeGPG ImageVerificationType = "GPG"
+)
+
+// ImageVerificationPolicy specifies how the signatures of images are verified.
+// +kubebuilder:validation:XValidation:rule="self.type != 'GPG' || (has(self.signatureStores) && size(self.signatureStores) > 0)", message="signatureStores are required with the GPG type"
+type ImageVerificationPolicy struct {
+	// Type is the format of the signatures to verify.
+	//
+	// +kubebuilder:validation:Required
+	Type ImageVerificationType `json:"type"`
+
+	// PublicKeys references a ConfigMap in the HostedCluster namespace whose values are the keys trusted to sign
+	// the images: PEM encoded public keys with the Cosign type, or ASCII armored keyrings with the GPG type. An
+	// image is verified when it's signed by any of the keys.
+	//
+	// +kubebuilder:validation:Required
+	PublicKeys corev1.LocalObjectReference `json:"publicKeys"`
+
+	// SignatureStores are the base URLs of the stores serving the GPG signatures of the images, e.g.
+	// https://mirror.openshift.com/pub/openshift-v4/signatures/openshift/release. Required with the GPG type.
+	//
+	// +kubebuilder:validation:MaxItems=5
+	// +optional
+	SignatureStores []string `json:"signatureStores,omitempty"`
+}
+
+// DeletionPolicyType is the behavior of the deletion of a HostedCluster.
+// +kubebuilder:validation:Enum=Delete;Protect;OrphanInfra;Force
+type DeletionPolicyType string
+
+const (
+	// DeletionPolicyDelete deletes the HostedCluster and waits for its resources, including the cloud
+	// infrastructure of its NodePools, to be cleaned up before it goes away.
+	DeletionPolicyDelete DeletionPolicyType = "Delete"
+
+	// DeletionPolicyProtect denies the deletion of the HostedCluster unless it has the
+	// hypershift.openshift.io/allow-deletion annotation set to "true".
+	DeletionPolicyProtect DeletionPolicyType = "Protect"
+
+	// DeletionPolicyOrphanInfra deletes the HostedCluster but leaves its cloud infrastructure intact: the cloud
+	// resources created by the guest cluster aren't cleaned up, and on AWS the instances of its NodePools and its
+	// private link endpoints are orphaned instead of terminated.
+	DeletionPolicyOrphanInfra DeletionPolicyType = "OrphanInfra"
+
+	// DeletionPolicyForce deletes the HostedCluster like Delete, but removes the finalizers blocking the deletion
+	// once the cleanup takes longer than the force timeout. Resources not cleaned up by then are orphaned.
+	DeletionPolicyForce DeletionPolicyType = "Force"
+)
+
+// DeletionPolicy specifies the behavior of the deletion of a HostedCluster.
+// +kubebuilder:validation:XValidation:rule="!has(self.forceTimeout) || self.type == 'Force'", message="forceTimeout is only allowed with the Force type"
+type DeletionPolicy struct {
+	// Type is the behavior of the deletion.
+	//
+	// +kubebuilder:default=Delete
+	// +optional
+	Type DeletionPolicyType `json:"type,omitempty"`
+
+	// ForceTimeout is how long the cleanup of a deleted HostedCluster may take with the Force type, before the
+	// finalizers blocking its deletion are removed. Defaults to 30m.
+	//
+	// +optional
+	ForceTimeout *metav1.Duration `json:"forceTimeout,omitempty"`
+}
+
+// HostedClusterLifetime limits how long a HostedCluster lives.
+// +kubebuilder:validation:XValidation:rule="duration(self.ttl) > duration('0s')", message="ttl must be positive"
+// +kubebuilder:validation:XValidation:rule="!has(self.expiryWarning) || duration(self.expiryWarning) >= duration('0s')", message="expiryWarning must not be negative"
+type HostedClusterLifetime struct {
+	// TTL is how long after its creation the HostedCluster is deleted, e.g. 8h.
+	//
+	// +kubebuilder:validation:Required
+	TTL metav1.Duration `json:"ttl"`
+
+	// ExpiryWarning is how long before its deletion the HostedCluster is reported as expiring. The HostedCluster is
+	// not deleted before it was reported as expiring for this long, which postpones the deletion when a lifetime that
+	// already elapsed is set or the TTL is shortened. Defaults to 1h.
+	//
+	// +optional
+	ExpiryWarning *metav1.Duration `json:"expiryWarning,omitempty"`
+}
+
+// KubeconfigStoreType is the type of an external secret store kubeconfigs are published to.
+// +kubebuilder:validation:Enum=AWSSecretsManager;AzureKeyVault;Vault
+type KubeconfigStoreType string
+
+const (
+	// AWSSecretsManagerKubeconfigStore publishes the kubeconfigs as AWS Secrets Manager secrets.
+	AWSSecretsManagerKubeconfigStore KubeconfigStoreType = "AWSSecretsManager"
+
+	// AzureKeyVaultKubeconfigStore publishes the kubeconfigs as Azure Key Vault secrets.
+	AzureKeyVaultKubeconfigStore KubeconfigStoreType = "AzureKeyVault"
+
+	// VaultKubeconfigStore publishes the kubeconfigs as secrets of a HashiCorp Vault KV version 2 secrets engine.
+	VaultKubeconfigStore KubeconfigStoreType = "Vault"
+)
+
+// KubeconfigPublishingSpec specifies the external secret store the kubeconfigs of a HostedCluster are published to.
+type KubeconfigPublishingSpec struct {
+	// Store is the external secret store the kubeconfigs are published to.
+	//
+	// +kubebuilder:validation:Required
+	Store KubeconfigStore `json:"store"`
+
+	// Credentials references a Secret in the namespace of the HostedCluster with the credentials of the store:
+	// aws_access_key_id and aws_secret_access_key for AWSSecretsManager, AZURE_TENANT_ID, AZURE_CLIENT_ID and
+	// AZURE_CLIENT_SECRET for AzureKeyVault, and token for Vault.
+	//
+	// +kubebuilder:validation:Required
+	Credentials corev1.LocalObjectReference `json:"credentials"`
+
+	// AdminKubeconfigName is the name of the secret the admin kubeconfig is published as in the store: the name of
+	// the AWS Secrets Manager or Azure Key Vault secret, or the path of the Vault secret.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=512
+	AdminKubeconfigName string `json:"adminKubeconfigName"`
+
+	// AdditionalKubeconfigs are custom kubeconfigs published along the admin kubeconfig, e.g. kubeconfigs of service
+	// accounts used by provisioning pipelines.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=10
+	AdditionalKubeconfigs []PublishedKubeconfig `json:"additionalKubeconfigs,omitempty"`
+}
+
+// KubeconfigStore is an external secret store.
+// +kubebuilder:validation:XValidation:rule="self.type == 'AWSSecretsManager' ? has(self.awsSecretsManager) : !has(self.awsSecretsManager)", message="awsSecretsManager is required with the AWSSecretsManager type, and forbidden otherwise"
+// +kubebuilder:validation:XValidation:rule="self.type == 'AzureKeyVault' ? has(self.azureKeyVault) : !has(self.azureKeyVault)", message="azureKeyVault is required with the AzureKeyVault type, and forbidden otherwise"
+// +kubebuilder:validation:XValidation:rule="self.type == 'Vault' ? has(self.vault) : !has(self.vault)", message="vault is required with the Vault type, and forbidden otherwise"
+type KubeconfigStore struct {
+	// Type is the type of the store.
+	//
+	// +kubebuilder:validation:Required
+	Type KubeconfigStoreType `json:"type"`
+
+	// AWSSecretsManager configures the AWS Secrets Manager store.
+	//
+	// +optional
+	AWSSecretsManager *AWSSecretsManagerKubeconfigStoreSpec `json:"awsSecretsManager,omitempty"`
+
+	// AzureKeyVault configures the Azure Key Vault store.
+	//
+	// +optional
+	AzureKeyVault *AzureKeyVaultKubeconfigStoreSpec `json:"azureKeyVault,omitempty"`
+
+	// Vault configures the HashiCorp Vault store.
+	//
+	// +optional
+	Vault *VaultKubeconfigStoreSpec `json:"vault,omitempty"`
+}
+
+// AWSSecretsManagerKubeconfigStoreSpec configures the publishing of kubeconfigs to AWS Secrets Manager.
+type AWSSecretsManagerKubeconfigStoreSpec struct {
+	// Region is the AWS region of the secrets.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Region string `json:"region"`
+}
+
+// AzureKeyVaultKubeconfigStoreSpec configures the publishing of kubeconfigs to Azure Key Vault.
+type AzureKeyVaultKubeconfigStoreSpec struct {
+	// VaultURL is the URL of the key vault, e.g. https://myvault.vault.azure.net.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^https://`
+	VaultURL string `json:"vaultURL"`
+}
+
+// VaultKubeconfigStoreSpec configures the publishing of kubeconfigs to a HashiCorp Vault KV version 2 secrets engine.
+type VaultKubeconfigStoreSpec struct {
+	// Address is the URL of the Vault server, e.g. https://vault.example.com:8200.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^https?://`
+	Address string `json:"address"`
+
+	// MountPath is the path the KV secrets engine is mounted at.
+	//
+	// +kubebuilder:default=secret
+	// +optional
+	MountPath string `json:"mountPath,omitempty"`
+
+	// Namespace is the Vault Enterprise namespace of the secrets engine.
+	//
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// PublishedKubeconfig is a custom kubeconfig published to an external secret store.
+type PublishedKubeconfig struct {
+	// Name is the name of the secret the kubeconfig is published as in the store.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=512
+	Name string `json:"name"`
+
+	// Secret references a Secret in the namespace of the HostedCluster with the kubeconfig in its kubeconfig key.
+	//
+	// +kubebuilder:validation:Required
+	Secret corev1.LocalObjectReference `json:"secret"`
+}
+
+// PublishedKubeconfigStatus is the state of a kubeconfig published to an external secret store.
+type PublishedKubeconfigStatus struct {
+	// Name is the name of the secret the kubeconfig is published as in the store.
+	Name string `json:"name"`
+
+	// Hash is the hash of the published kubeconfig, compared to the kubeconfig to publish it again once it's rotated.
+	Hash string `json:"hash"`
+
+	// LastPublishedTime is when the kubeconfig was last published.
+	LastPublishedTime metav1.Time `json:"lastPublishedTime"`
+}
+
+// SecretsBackupStoreType is the type of an external object store control plane secrets are backed up to.
+// +kubebuilder:validation:Enum=S3
+type SecretsBackupStoreType string
+
+const (
+	// S3SecretsBackupStore backs up the secrets as AWS S3 objects, sealed with an AWS KMS key.
+	S3SecretsBackupStore SecretsBackupStoreType = "S3"
+)
+
+// SecretsBackupSpec specifies the external object store the control plane secrets of a HostedCluster are backed up
+// to.
+type SecretsBackupSpec struct {
+	// Store is the external object store the bundles are written to.
+	//
+	// +kubebuilder:validation:Required
+	Store SecretsBackupStore `json:"store"`
+
+	// Credentials references a Secret in the namespace of the HostedCluster with the credentials of the store:
+	// aws_access_key_id and aws_secret_access_key for S3. They must allow to write the objects of the bucket and to
+	// generate data keys with the KMS key.
+	//
+	// +kubebuilder:validation:Required
+	Credentials corev1.LocalObjectReference `json:"credentials"`
+
+	// Interval is how often a bundle is exported. A bundle is also exported as soon as one of the backed up secrets
+	// changes, e.g. when it's rotated.
+	//
+	// +kubebuilder:default="24h"
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+}
+
+// SecretsBackupStore is an external object store.
+// +kubebuilder:validation:XValidation:rule="self.type == 'S3' ? has(self.s3) : !has(self.s3)", message="s3 is required with the S3 type, and forbidden otherwise"
+type SecretsBackupStore struct {
+	// Type is the type of the store.
+	//
+	// +kubebuilder:validation:Required
+	Type SecretsBackupStoreType `json:"type"`
+
+	// S3 configures the AWS S3 store.
+	//
+	// +optional
+	S3 *S3SecretsBackupStoreSpec `json:"s3,omitempty"`
+}
+
+// S3SecretsBackupStoreSpec configures the backup of control plane secrets to AWS S3.
+type S3SecretsBackupStoreSpec struct {
+	// Bucket is the name of the bucket the bundles are written to.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=3
+	// +kubebuilder:validation:MaxLength=63
+	Bucket string `json:"bucket"`
+
+	// Region is the AWS region of the bucket and of the KMS key.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Region string `json:"region"`
+
+	// KeyPrefix is prepended to the keys of the objects of the bundles, which are
+	// <keyPrefix><namespace>/<name>/<timestamp>.json.
+	//
+	// +kubebuilder:validation:MaxLength=512
+	// +optional
+	KeyPrefix string `json:"keyPrefix,omitempty"`
+
+	// KMSKeyARN is the ARN of the AWS KMS key the bundles are sealed with. The bundle is encrypted with a data key
+	// generated by the KMS key, and the object is encrypted at rest with the KMS key too.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^arn:`
+	KMSKeyARN string `json:"kmsKeyARN"`
+}
+
+// SecretsBackupStatus is the state of the last bundle of control plane secrets exported to an external object store.
+type SecretsBackupStatus struct {
+	// Key is the key of the object of the last bundle in the store.
+	Key string `json:"key"`
+
+	// Hash is the hash of the secrets of the last bundle, compared to the secrets to export a bundle as soon as they
+	// change.
+	Hash string `json:"hash"`
+
+	// LastBackupTime is when the last bundle was exported.
+	LastBackupTime metav1.Time `json:"lastBackupTime"`
+}
+
+// OLMCatalogs configures the OLM catalogs of a cluster.
+type OLMCatalogs struct {
+	// DisableDefaultCatalogs disables the default catalogs (certified-operators, community-operators,
+	// redhat-marketplace and redhat-operators), regardless of the OperatorHub configuration.
+	//
+	// +optional
+	DisableDefaultCatalogs bool `json:"disableDefaultCatalogs,omitempty"`
+
+	// CatalogSources are custom catalog sources made available to the cluster, in the openshift-marketplace
+	// namespace. A catalog source named after a default catalog replaces it, so existing subscriptions keep working
+	// with a mirrored catalog.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=20
+	CatalogSources []OLMCatalogSource `json:"catalogSources,omitempty"`
+}
+
+// OLMCatalogSource is a custom OLM catalog source served from a catalog image.
+type OLMCatalogSource struct {
+	// Name is the name of the CatalogSource.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=55
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// Image is the pull spec of the catalog image.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Image string `json:"image"`
+
+	// DisplayName is the name of the catalog source displayed in the console.
+	//
+	// +optional
+	DisplayName string `json:"displayName,omitempty"`
+
+	// Publisher is the publisher of the catalog source displayed in the console.
+	//
+	// +optional
+	Publisher string `json:"publisher,omitempty"`
+
+	// Priority of the catalog source when resolving dependencies, catalog sources with a higher priority are
+	// preferred.
+	//
+	// +optional
+	Priority int `json:"priority,omitempty"`
+}
+
 // ImageContentSource specifies image mirrors that can be used by cluster nodes
 // to pull content. For cluster workloads, if a container image registry host of
 // the pullspec matches Source then one of the Mirrors are substituted as hosts
@@ -409,6 +1075,13 @@ type ServicePublishingStrategy struct {
 
 	// Route configures exposing a service using a Route.
 	Route *RoutePublishingStrategy `json:"route,omitempty"`
+
+	// CustomDomain configures publishing the service under a customer provided
+	// domain that is served by the customer's own load balancer or Azure Front Door
+	// in front of the service load balancer. It is only supported for the APIServer
+	// service with the LoadBalancer publishing strategy on the Azure platform.
+	// +optional
+	CustomDomain *CustomDomainPublishingStrategy `json:"customDomain,omitempty"`
 }
 
 // PublishingStrategyType defines publishing strategies for services.
@@ -475,6 +1148,29 @@ type RoutePublishingStrategy struct {
 	Hostname string `json:"hostname,omitempty"`
 }
 
+// CustomDomainPublishingStrategy specifies options for publishing a service
+// under a customer provided domain.
+type CustomDomainPublishingStrategy struct {
+	// Hostname is the custom domain name clients use to reach the service. It is
+	// added to the serving certificate SANs and used in the generated kubeconfig.
+	// +kubebuilder:validation:MinLength=1
+	Hostname string `json:"hostname"`
+
+	// Target is the DNS name of the customer load balancer or Azure Front Door
+	// endpoint that serves the custom domain. When set, an external-dns record
+	// pointing Hostname to Target is rendered on the service. When empty, the
+	// customer is responsible for managing the DNS record.
+	// +optional
+	Target string `json:"target,omitempty"`
+
+	// Port is the port the custom domain is served on. Defaults to the port of
+	// the published service.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Port int32 `json:"port,omitempty"`
+}
+
 // DNSSpec specifies the DNS configuration in the cluster.
 type DNSSpec struct {
 	// BaseDomain is the base domain of the cluster.
@@ -502,8 +1198,47 @@ type DNSSpec struct {
 	// +optional
 	// +immutable
 	PrivateZoneID string `json:"privateZoneID,omitempty"`
+
+	// ClusterDNSDomain is the private DNS domain the control plane endpoints of
+	// the cluster are published under for in-cluster and private access, as
+	// api.<cluster name>.<clusterDNSDomain> and
+	// <service>.apps.<cluster name>.<clusterDNSDomain>.
+	// Defaults to hypershift.local when not set.
+	//
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^([a-z0-9]([-a-z0-9]*[a-z0-9])?\.)*[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf", message="clusterDNSDomain is immutable"
+	// +optional
+	// +immutable
+	ClusterDNSDomain string `json:"clusterDNSDomain,omitempty"`
+
+	// Publishing is how the DNS records of the cluster are published.
+	// External publishes the records of the public endpoints in the public
+	// zone. Internal creates no public zone records: the ingress of the cluster
+	// and its control plane endpoints are only reachable privately.
+	// Defaults to External when not set.
+	//
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf", message="publishing is immutable"
+	// +optional
+	// +immutable
+	Publishing DNSPublishingMode `json:"publishing,omitempty"`
 }
 
+// DNSPublishingMode is how the DNS records of a cluster are published.
+//
+// +kubebuilder:validation:Enum=External;Internal
+type DNSPublishingMode string
+
+const (
+	// ExternalDNSPublishing publishes the records of the public endpoints of
+	// the cluster in the public zone.
+	ExternalDNSPublishing DNSPublishingMode = "External"
+
+	// InternalDNSPublishing creates no public zone records, the cluster is only
+	// reachable privately.
+	InternalDNSPublishing DNSPublishingMode = "Internal"
+)
+
 // ClusterNetworking specifies network configuration for a cluster.
 type ClusterNetworking struct {
 	// Deprecated
@@ -539,9 +1274,13 @@ type ClusterNetworking struct {
 	MachineNetwork []MachineNetworkEntry `json:"machineNetwork,omitempty"`
 
 	// ClusterNetwork is the list of IP address pools for pods.
+	// Existing entries are immutable. With the OVNKubernetes network type, new
+	// entries of an IP family the cluster network already has can be appended
+	// to expand the cluster network of a running cluster; the
+	// ClusterNetworkApplied condition reports when the guest cluster network
+	// uses them. Only one expansion can be in progress at a time.
 	// TODO: make this required in the next version of the API
 	//
-	// +immutable
 	// +optional
 	// +kubebuilder:default:={{cidr: "10.132.0.0/14"}}
 	ClusterNetwork []ClusterNetworkEntry `json:"clusterNetwork,omitempty"`
@@ -1570,6 +2309,27 @@ type AzurePlatformSpec struct {
 	SubscriptionID    string `json:"subscriptionID"`
 	MachineIdentityID string `json:"machineIdentityID"`
 	SecurityGroupID   string `json:"securityGroupID"`
+
+	// ResourceTags is a list of additional tags to apply to the Azure resources of the cluster in ResourceGroupName:
+	// its virtual machines, disks, network interfaces and load balancers.
+	//
+	// +kubebuilder:validation:MaxItems=50
+	// +optional
+	ResourceTags []AzureResourceTag `json:"resourceTags,omitempty"`
+}
+
+// AzureResourceTag is a tag to apply to the Azure resources of the cluster.
+type AzureResourceTag struct {
+	// Key is the key of the tag.
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=512
+	// +kubebuilder:validation:Pattern=`^[^<>%&\\?/]+$`
+	Key string `json:"key"`
+	// Value is the value of the tag.
+	//
+	// +kubebuilder:validation:MaxLength=256
+	Value string `json:"value"`
 }
 
 // Release represents the metadata for an OCP release payload image.
@@ -1655,6 +2415,76 @@ type EtcdSpec struct {
 type ManagedEtcdSpec struct {
 	// Storage specifies how etcd data is persisted.
 	Storage ManagedEtcdStorageSpec `json:"storage"`
+
+	// Disruption configures how etcd members respond to voluntary disruptions,
+	// such as the drain of the management cluster node they run on.
+	//
+	// +optional
+	Disruption *ManagedEtcdDisruptionSpec `json:"disruption,omitempty"`
+}
+
+// EtcdPodDisruptionBudgetPolicy is a policy for the PodDisruptionBudget of the
+// etcd members.
+//
+// +kubebuilder:validation:Enum=QuorumGuard;AllowEviction
+type EtcdPodDisruptionBudgetPolicy string
+
+const (
+	// QuorumGuardEtcdPodDisruptionBudget only allows evictions which keep the
+	// etcd quorum: one member at a time on highly available control planes,
+	// none on single replica control planes.
+	QuorumGuardEtcdPodDisruptionBudget EtcdPodDisruptionBudgetPolicy = "QuorumGuard"
+
+	// AllowEvictionEtcdPodDisruptionBudget additionally allows the member of
+	// single replica control planes to be evicted, making the control plane
+	// unavailable until it is rescheduled.
+	AllowEvictionEtcdPodDisruptionBudget EtcdPodDisruptionBudgetPolicy = "AllowEviction"
+)
+
+// EtcdPreStopCheck is a check an etcd member runs before it stops.
+//
+// +kubebuilder:validation:Enum=DataSync;MemberHealth
+type EtcdPreStopCheck string
+
+const (
+	// DataSyncEtcdPreStopCheck waits for the member to apply all the committed
+	// entries of its log and, when it is the leader, transfers the leadership
+	// to another member before the member stops.
+	DataSyncEtcdPreStopCheck EtcdPreStopCheck = "DataSync"
+
+	// MemberHealthEtcdPreStopCheck waits for all the other members to be
+	// healthy before the member stops, so stopping it doesn't lose the quorum.
+	MemberHealthEtcdPreStopCheck EtcdPreStopCheck = "MemberHealth"
+)
+
+// ManagedEtcdDisruptionSpec configures how etcd members respond to voluntary
+// disruptions.
+type ManagedEtcdDisruptionSpec struct {
+	// PodDisruptionBudget is the policy of the PodDisruptionBudget of the etcd
+	// members. QuorumGuard only allows evictions which keep the quorum, so
+	// management node drains wait on the member of single replica control
+	// planes. AllowEviction lets drains evict it, at the cost of the control
+	// plane being unavailable until it is rescheduled.
+	//
+	// +optional
+	// +kubebuilder:default=QuorumGuard
+	PodDisruptionBudget EtcdPodDisruptionBudgetPolicy `json:"podDisruptionBudget,omitempty"`
+
+	// TerminationGracePeriodSeconds is how long an etcd member has to run its
+	// pre-stop checks and stop before it is killed. Defaults to 30 seconds.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=30
+	// +kubebuilder:validation:Maximum=600
+	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
+
+	// PreStopChecks are the checks an etcd member runs before it stops. They
+	// delay the stop until they pass or the termination grace period expires.
+	//
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:MaxItems=2
+	PreStopChecks []EtcdPreStopCheck `json:"preStopChecks,omitempty"`
 }
 
 // ManagedEtcdStorageType is a storage type for an etcd cluster.
@@ -2018,12 +2848,103 @@ type HostedClusterStatus struct {
 	// Platform contains platform-specific status of the HostedCluster
 	// +optional
 	Platform *PlatformStatus `json:"platform,omitempty"`
+
+	// ComponentStatuses summarizes the availability of the main components of
+	// the HostedCluster so that consumers can watch a single list instead of
+	// the individual conditions of the HostedCluster, HostedControlPlane and
+	// NodePools. The aggregated result is reported in the Healthy condition.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	ComponentStatuses []HostedClusterComponentStatus `json:"componentStatuses,omitempty"`
+
+	// PublishedKubeconfigs are the kubeconfigs published to the external secret store of spec.kubeconfigPublishing.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	PublishedKubeconfigs []PublishedKubeconfigStatus `json:"publishedKubeconfigs,omitempty"`
+
+	// SecretsBackup is the state of the last bundle exported to the object store of spec.secretsBackup.
+	// +optional
+	SecretsBackup *SecretsBackupStatus `json:"secretsBackup,omitempty"`
+
+	// ExpirationTime is when the HostedCluster is deleted because its spec.lifetime expires.
+	// +optional
+	ExpirationTime *metav1.Time `json:"expirationTime,omitempty"`
+}
+
+// HostedClusterComponentName is the name of a component summarized in the
+// HostedCluster status.
+// +kubebuilder:validation:Enum=Etcd;KubeAPIServer;KubeScheduler;OAuthServer;Ingress;Nodes
+type HostedClusterComponentName string
+
+const (
+	// EtcdComponent is the etcd cluster backing the hosted control plane.
+	EtcdComponent HostedClusterComponentName = "Etcd"
+	// KubeAPIServerComponent is the kube-apiserver of the hosted control plane.
+	KubeAPIServerComponent HostedClusterComponentName = "KubeAPIServer"
+	// KubeSchedulerComponent is the kube-scheduler of the hosted control plane.
+	KubeSchedulerComponent HostedClusterComponentName = "KubeScheduler"
+	// OAuthServerComponent is the OAuth server of the hosted control plane.
+	OAuthServerComponent HostedClusterComponentName = "OAuthServer"
+	// IngressComponent is the default ingress of the hosted cluster data plane.
+	IngressComponent HostedClusterComponentName = "Ingress"
+	// NodesComponent summarizes the nodes that joined the hosted cluster.
+	NodesComponent HostedClusterComponentName = "Nodes"
+)
+
+// HostedClusterComponentStatus reports the availability of a single component
+// of a HostedCluster.
+type HostedClusterComponentStatus struct {
+	// Name is the name of the component.
+	// +kubebuilder:validation:Required
+	Name HostedClusterComponentName `json:"name"`
+
+	// Available is True when the component is available, False when it is
+	// not and Unknown when its availability can't be determined.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=True;False;Unknown
+	Available metav1.ConditionStatus `json:"available"`
+
+	// Reason is a machine-readable CamelCase reason for the availability of
+	// the component.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Message is a human-readable message with details about the availability
+	// of the component.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// DesiredReplicas is the desired number of instances of the component.
+	// It is only reported for Nodes, where it is the sum of the replicas of
+	// all NodePools of the HostedCluster.
+	// +optional
+	DesiredReplicas *int32 `json:"desiredReplicas,omitempty"`
+
+	// ReadyReplicas is the number of ready instances of the component.
+	// It is only reported for Nodes, where it is the number of nodes that
+	// joined the hosted cluster.
+	// +optional
+	ReadyReplicas *int32 `json:"readyReplicas,omitempty"`
 }
 
 // PlatformStatus contains platform-specific status
 type PlatformStatus struct {
 	// +optional
 	AWS *AWSPlatformStatus `json:"aws,omitempty"`
+
+	// +optional
+	Kubevirt *KubevirtPlatformStatus `json:"kubevirt,omitempty"`
+}
+
+// KubevirtPlatformStatus contains status specific to the KubeVirt platform
+type KubevirtPlatformStatus struct {
+	// InfraNamespaces lists the namespaces of the infra cluster in which the KubeVirt virtual machines of the
+	// NodePools of the HostedCluster are created.
+	// +listType=set
+	// +optional
+	InfraNamespaces []string `json:"infraNamespaces,omitempty"`
 }
 
 // AWSPlatformStatus contains status specific to the AWS platform
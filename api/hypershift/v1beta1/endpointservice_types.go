@@ -64,7 +64,8 @@ type AWSEndpointServiceStatus struct {
 
 	// Conditions contains details for the current state of the Endpoint Service
 	// request If there is an error processing the request e.g. the NLB doesn't
-	// exist, then the Available condition will be false, reason AWSErrorReason,
+	// exist, then the Available condition will be false, with a reason
+	// classifying the error (InvalidCredentials, QuotaExceeded or InfraFailure),
 	// and the error reported in the message.
 	//
 	// Current condition types are: "Available"
@@ -64,6 +64,12 @@ type HostedControlPlaneSpec struct {
 	// validation.
 	IssuerURL string `json:"issuerURL"`
 
+	// SecondaryIssuerURL is an OIDC issuer URL the control plane API server
+	// accepts ServiceAccount tokens from in addition to IssuerURL.
+	//
+	// +optional
+	SecondaryIssuerURL string `json:"secondaryIssuerURL,omitempty"`
+
 	// Networking specifies network configuration for the cluster.
 	// Temporarily optional for backward compatibility, required in future releases.
 	// +optional
@@ -167,6 +173,13 @@ type HostedControlPlaneSpec struct {
 	// +immutable
 	OLMCatalogPlacement OLMCatalogPlacement `json:"olmCatalogPlacement,omitempty"`
 
+	// OLMCatalogs configures the OLM catalogs of the cluster: custom catalog sources can be added, e.g. to use
+	// catalogs mirrored to a disconnected registry, and the default catalogs can be disabled. Custom catalog sources
+	// are placed according to OLMCatalogPlacement.
+	//
+	// +optional
+	OLMCatalogs *OLMCatalogs `json:"olmCatalogs,omitempty"`
+
 	// Autoscaling specifies auto-scaling behavior that applies to all NodePools
 	// associated with the control plane.
 	//
@@ -177,6 +190,43 @@ type HostedControlPlaneSpec struct {
 	//
 	// +optional
 	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Labels when specified, are added to the control plane namespace and to the pods managed by the HostedCluster,
+	// e.g. so that policy engines or cost reporting on the management cluster can select hosted control planes
+	// uniformly. The labels HyperShift sets on the namespace and the labels that select the pods of a workload take
+	// precedence over these.
+	//
+	// +kubebuilder:validation:MaxProperties=20
+	// +kubebuilder:validation:XValidation:rule="self.all(key, !key.contains('hypershift.openshift.io/'))", message="labels in the hypershift.openshift.io domain are reserved"
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations when specified, are added to the control plane namespace and to the pods managed by the
+	// HostedCluster. The annotations HyperShift sets on the namespace take precedence over these.
+	//
+	// +kubebuilder:validation:MaxProperties=20
+	// +kubebuilder:validation:XValidation:rule="self.all(key, !key.contains('hypershift.openshift.io/'))", message="annotations in the hypershift.openshift.io domain are reserved"
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// DataPlaneInfrastructurePlacement specifies the default node selector and tolerations of the infrastructure
+	// components of the hosted cluster which run in the data plane, e.g. the konnectivity agent, the default ingress
+	// controller and the CSI driver node pods, so they can be scheduled on fully tainted special purpose NodePools.
+	//
+	// +optional
+	DataPlaneInfrastructurePlacement *DataPlaneInfrastructurePlacement `json:"dataPlaneInfrastructurePlacement,omitempty"`
+
+	// DefaultIngressController configures the default IngressController of the hosted cluster, which serves its
+	// routes, so that it's set up as needed when the cluster is created rather than edited in the guest cluster.
+	// The fields that are set are also reconciled on the existing IngressController.
+	//
+	// +optional
+	DefaultIngressController *DefaultIngressControllerSpec `json:"defaultIngressController,omitempty"`
+
+	// Storage configures the storage provided by the CSI driver of the platform in the hosted cluster.
+	//
+	// +optional
+	Storage *ClusterStorageSpec `json:"storage,omitempty"`
 }
 
 // AvailabilityPolicy specifies a high level availability policy for components.
@@ -298,6 +348,13 @@ type HostedControlPlaneStatus struct {
 
 	// NodeCount tracks the number of nodes in the HostedControlPlane.
 	NodeCount *int `json:"nodeCount,omitempty"`
+
+	// controlPlaneStartupDuration is how long the control plane took to start, from the creation of the
+	// HostedControlPlane until all its components were ready for the first time. It is unset while the control
+	// plane is starting, and for control planes which had already started when the control plane operator began
+	// tracking it.
+	// +optional
+	ControlPlaneStartupDuration *metav1.Duration `json:"controlPlaneStartupDuration,omitempty"`
 }
 
 type APIEndpoint struct {
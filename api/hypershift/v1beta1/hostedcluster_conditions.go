@@ -17,6 +17,11 @@ const (
 	// HostedClusterDegraded indicates whether the HostedCluster is encountering
 	// an error that may require user intervention to resolve.
 	HostedClusterDegraded ConditionType = "Degraded"
+	// HostedClusterHealthy rolls up the availability of the components reported in
+	// hostedCluster.status.componentStatuses and the Degraded condition into a single signal.
+	// When this is false the "Reason" identifies the unavailable component, or MultipleComponentsNotAvailable
+	// when more than one component is unavailable, and the "Message" aggregates their messages.
+	HostedClusterHealthy ConditionType = "Healthy"
 
 	// Bubble up from HCP.
 
@@ -30,6 +35,32 @@ const (
 	// EtcdAvailable bubbles up the same condition from HCP. It signals if etcd is available.
 	// A failure here often means a software bug or a non-stable cluster.
 	EtcdAvailable ConditionType = "EtcdAvailable"
+	// EtcdQuorumAtRisk bubbles up the same condition from HCP. It signals if a managed etcd member has failed, so the
+	// failure of another member would lose quorum. Permanently failed members are replaced automatically while quorum
+	// is kept; when quorum is lost, etcd must be restored from a backup.
+	EtcdQuorumAtRisk ConditionType = "EtcdQuorumAtRisk"
+	// ClusterNetworkApplied bubbles up the same condition from HCP. It signals if all the cluster network CIDRs of
+	// the spec are in use by the network of the guest cluster. It is False while CIDRs appended to the cluster network
+	// are rolled out.
+	ClusterNetworkApplied ConditionType = "ClusterNetworkApplied"
+	// AWSResourceTagsApplied bubbles up the same condition from HCP. It signals if the resourceTags of the AWS
+	// platform are applied to the EC2 instances, volumes, security groups and load balancers owned by the cluster. It
+	// is False when some of them couldn't be tagged.
+	AWSResourceTagsApplied ConditionType = "AWSResourceTagsApplied"
+	// AzureResourceTagsApplied signals if the resourceTags of the Azure platform are applied to the virtual machines,
+	// disks, network interfaces and load balancers in the resource group of the cluster. It is False when some of them
+	// couldn't be tagged.
+	AzureResourceTagsApplied ConditionType = "AzureResourceTagsApplied"
+	// KubeconfigPublished signals if the kubeconfigs of spec.kubeconfigPublishing are published to the external
+	// secret store. It is False when some of them couldn't be published.
+	KubeconfigPublished ConditionType = "KubeconfigPublished"
+	// SecretsBackedUp signals if the control plane secrets are backed up to the external object store of
+	// spec.secretsBackup. It is False when the last bundle couldn't be exported.
+	SecretsBackedUp ConditionType = "SecretsBackedUp"
+	// KubeAPIServerReachable signals if the synthetic requests the HyperShift operator sends to the kube-apiserver of
+	// the HostedCluster succeed, both through its published endpoint and through konnectivity to a node. It is only
+	// set when the operator probes the kube-apiservers.
+	KubeAPIServerReachable ConditionType = "KubeAPIServerReachable"
 	// ValidHostedControlPlaneConfiguration bubbles up the same condition from HCP. It signals if the hostedControlPlane input is valid and
 	// supported by the underlying management cluster.
 	// A failure here is unlikely to resolve without the changing user input.
@@ -49,6 +80,10 @@ const (
 	// ValidReleaseInfo bubbles up the same condition from HCP. It indicates if the release contains all the images used by hypershift
 	// and reports missing images if any.
 	ValidReleaseInfo ConditionType = "ValidReleaseInfo"
+	// IngressAvailable bubbles up the same condition from HCP, which reflects the Available condition of the
+	// ingress ClusterOperator in the hosted cluster. It signals if the default ingress of the data plane is serving.
+	// A failure here may require external user intervention to resolve. E.g. there are no nodes to run the router.
+	IngressAvailable ConditionType = "IngressAvailable"
 
 	// Bubble up from HCP which bubbles up from CVO.
 
@@ -106,6 +141,26 @@ const (
 	// A failure here is unlikely to resolve without the changing user input.
 	ValidReleaseImage ConditionType = "ValidReleaseImage"
 
+	// ReleaseImageVerified indicates if the signature of the release image set in the spec is verified by the
+	// HostedCluster image verification policy. The condition is only set when a policy is configured.
+	// A failure here is unlikely to resolve without the changing user input.
+	ReleaseImageVerified ConditionType = "ReleaseImageVerified"
+
+	// DeletionProtected indicates that the HostedCluster was deleted while its deletion policy is Protect, and that
+	// the cleanup of its resources is blocked until the deletion is allowed with the
+	// hypershift.openshift.io/allow-deletion annotation. The condition is only set on deleted HostedClusters.
+	DeletionProtected ConditionType = "DeletionProtected"
+
+	// Expiring indicates that the spec.lifetime of the HostedCluster expires soon, after which the HostedCluster is
+	// deleted. The condition is only set when spec.lifetime is set.
+	Expiring ConditionType = "Expiring"
+
+	// ValidFIPSConfiguration indicates if the HostedCluster can run in FIPS mode: the management cluster runs in
+	// FIPS mode with FIPS capable operator binaries, and the release image has bootimages for a FIPS capable
+	// architecture. The condition is only set when spec.fips is enabled.
+	// A failure here may require external user intervention to resolve. E.g. enabling FIPS mode on the management cluster.
+	ValidFIPSConfiguration ConditionType = "ValidFIPSConfiguration"
+
 	// ValidKubeVirtInfraNetworkMTU indicates if the MTU configured on an infra cluster
 	// hosting a guest cluster utilizing kubevirt platform is a sufficient value that will avoid
 	// performance degradation due to fragmentation of the double encapsulation in ovn-kubernetes
@@ -140,6 +195,17 @@ const (
 	// A failure here indicates that the input is invalid, or permissions are missing to use the encryption key.
 	ValidAzureKMSConfig ConditionType = "ValidAzureKMSConfig"
 
+	// ValidIBMCloudKMSConfig indicates whether the IBM Cloud KMS key list and credentials are valid and operational
+	// A failure here indicates that the key list is invalid, or the credentials can't be used to wrap data with the
+	// active key.
+	ValidIBMCloudKMSConfig ConditionType = "ValidIBMCloudKMSConfig"
+
+	// KMSDataReencrypted indicates whether the encrypted resources of the hosted cluster have been re-encrypted with the
+	// active KMS key, after it was rotated. The message of the condition names the active key.
+	// A failure here indicates that resources may still be encrypted with a previous key, which must not be removed
+	// from the key list yet.
+	KMSDataReencrypted ConditionType = "KMSDataReencrypted"
+
 	// AWSDefaultSecurityGroupCreated indicates whether the default security group
 	// for AWS workers has been created.
 	// A failure here indicates that NodePools without a security group will be
@@ -157,6 +223,23 @@ const (
 	// A failure here is unlikely to resolve without the changing user input.
 	PlatformCredentialsFound ConditionType = "PlatformCredentialsFound"
 
+	// PlatformCredentialsRotated indicates if the control plane components using the platform credentials were
+	// restarted with the credentials of the last rotation and are available. The condition is only set when the
+	// platform credentials were rotated, see PlatformCredentialsRotationAnnotation.
+	// A failure here may require rolling back to the previous credentials.
+	PlatformCredentialsRotated ConditionType = "PlatformCredentialsRotated"
+
+	// ZoneSpreadAchieved indicates if the pods of every replicated control plane component run in at least two
+	// zones of the management cluster. The condition is only set for HighlyAvailable control planes.
+	// A failure here may block upgrades, see BlockUpgradeOnZoneSpreadViolationAnnotation.
+	ZoneSpreadAchieved ConditionType = "ZoneSpreadAchieved"
+
+	// ValidControlPlaneExtensions bubbles up the same condition from HCP. It indicates if the control plane
+	// extensions configured by the ControlPlaneExtensionsAnnotation are valid and applied. The condition is only set
+	// when control plane extensions are configured.
+	// A failure here requires fixing the extensions ConfigMap.
+	ValidControlPlaneExtensions ConditionType = "ValidControlPlaneExtensions"
+
 	// ReconciliationActive indicates if reconciliation of the HostedCluster is
 	// active or paused hostedCluster.spec.pausedUntil.
 	ReconciliationActive ConditionType = "ReconciliationActive"
@@ -193,10 +276,17 @@ const (
 	EtcdWaitingForQuorumReason    = "EtcdWaitingForQuorum"
 	EtcdStatefulSetNotFoundReason = "StatefulSetNotFound"
 
-	UnmanagedEtcdMisconfiguredReason = "UnmanagedEtcdMisconfigured"
-	UnmanagedEtcdAsExpected          = "UnmanagedEtcdAsExpected"
+	EtcdMemberUnhealthyReason             = "EtcdMemberUnhealthy"
+	EtcdMemberReplacementInProgressReason = "EtcdMemberReplacementInProgress"
+	EtcdQuorumLostReason                  = "EtcdQuorumLost"
 
-	FromClusterVersionReason = "FromClusterVersion"
+	UnmanagedEtcdMisconfiguredReason   = "UnmanagedEtcdMisconfigured"
+	UnmanagedEtcdAsExpected            = "UnmanagedEtcdAsExpected"
+	EtcdEndpointUnreachableReason      = "EtcdEndpointUnreachable"
+	EtcdServerCertificateInvalidReason = "EtcdServerCertificateInvalid"
+
+	FromClusterVersionReason  = "FromClusterVersion"
+	FromClusterOperatorReason = "FromClusterOperator"
 
 	InvalidConfigurationReason            = "InvalidConfiguration"
 	KubeconfigWaitingForCreateReason      = "KubeconfigWaitingForCreate"
@@ -204,24 +294,58 @@ const (
 	InsufficientClusterCapabilitiesReason = "InsufficientClusterCapabilities"
 	OIDCConfigurationInvalidReason        = "OIDCConfigurationInvalid"
 	PlatformCredentialsNotFoundReason     = "PlatformCredentialsNotFound"
+	RotationInProgressReason              = "RotationInProgress"
+	RotationFailedReason                  = "RotationFailed"
+	ZoneSpreadViolatedReason              = "ZoneSpreadViolated"
 	InvalidImageReason                    = "InvalidImage"
+	ImageVerificationFailedReason         = "ImageVerificationFailed"
+	ImageVerificationErrorReason          = "ImageVerificationError"
+	FIPSUnsupportedReason                 = "FIPSUnsupported"
+	DeletionNotAllowedReason              = "DeletionNotAllowed"
+	LifetimeExpiringReason                = "LifetimeExpiring"
+	LifetimeExpiredReason                 = "LifetimeExpired"
+	KubeconfigPublishFailedReason         = "KubeconfigPublishFailed"
+	SecretsBackupFailedReason             = "SecretsBackupFailed"
+	SecretsBackupWaitingForSecretsReason  = "SecretsBackupWaitingForSecrets"
+	KubeAPIServerProbeFailedReason        = "KubeAPIServerProbeFailed"
 	InvalidIdentityProvider               = "InvalidIdentityProvider"
 
+	// InvalidCredentialsReason, QuotaExceededReason and InfraFailureReason classify the failed cloud provider API
+	// calls behind the conditions of HostedClusters, HostedControlPlanes and NodePools, so automation can branch on
+	// them: the credentials or their permissions were rejected, a quota or limit of the account was reached, or the
+	// call failed otherwise. The message of the condition contains the error code of the provider.
+	// UnsupportedCombinationReason reports options that can't be used together, or not with the platform, release
+	// or management cluster.
+	InvalidCredentialsReason     = "InvalidCredentials"
+	QuotaExceededReason          = "QuotaExceeded"
+	InfraFailureReason           = "InfraFailure"
+	UnsupportedCombinationReason = "UnsupportedCombination"
+
 	InvalidIAMRoleReason = "InvalidIAMRole"
 
 	InvalidAzureCredentialsReason = "InvalidAzureCredentials"
 	AzureErrorReason              = "AzureError"
 
+	InvalidIBMCloudCredentialsReason = "InvalidIBMCloudCredentials"
+
 	ExternalDNSHostNotReachableReason = "ExternalDNSHostNotReachable"
 
 	KASLoadBalancerNotReachableReason = "KASLoadBalancerNotReachable"
 
-	MissingReleaseImagesReason = "MissingReleaseImages"
+	MissingReleaseImagesReason     = "MissingReleaseImages"
+	UnreachableReleaseImagesReason = "UnreachableReleaseImages"
 
 	ReconciliationPausedConditionReason             = "ReconciliationPaused"
 	ReconciliationInvalidPausedUntilConditionReason = "InvalidPausedUntilValue"
 
 	KubeVirtSuboptimalMTUReason = "KubeVirtSuboptimalMTUDetected"
+
+	ClusterNetworkExpansionInProgressReason = "ClusterNetworkExpansionInProgress"
+
+	ComponentNotAvailableReasonSuffix    = "NotAvailable"
+	MultipleComponentsNotAvailableReason = "MultipleComponentsNotAvailable"
+	DeploymentNotFoundReason             = "DeploymentNotFound"
+	WaitingForNodesReason                = "WaitingForNodes"
 )
 
 // Messages.
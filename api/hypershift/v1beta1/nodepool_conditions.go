@@ -9,9 +9,27 @@ const (
 	// If the image is direct user input then this condition is meaningless.
 	// A failure here is unlikely to resolve without the changing user input.
 	NodePoolValidPlatformImageType = "ValidPlatformImage"
+	// NodePoolValidOSImageConditionType signals if the layered OS image in nodePool.spec.osImage was resolved to a
+	// digest. The condition is only set when a layered OS image is specified.
+	// A failure here may resolve on its own if the registry is temporarily unavailable.
+	NodePoolValidOSImageConditionType = "ValidOSImage"
 	// NodePoolValidReleaseImageConditionType signals if the input in nodePool.spec.release.image is valid.
 	// A failure here is unlikely to resolve without the changing user input.
 	NodePoolValidReleaseImageConditionType = "ValidReleaseImage"
+	// NodePoolImageVerifiedConditionType signals if the signatures of the release image and the bootimage of the
+	// NodePool are verified by the image verification policy of its HostedCluster. The condition is only set when a
+	// policy is configured.
+	// A failure here is unlikely to resolve without the changing user input.
+	NodePoolImageVerifiedConditionType = "ImageVerified"
+	// NodePoolValidFIPSConfigurationConditionType signals if the nodes of the NodePool can run in FIPS mode as
+	// required by its HostedCluster: the NodePool architecture supports FIPS mode and the release image has
+	// bootimages for it. The condition is only set when the HostedCluster has spec.fips enabled.
+	// A failure here is unlikely to resolve without the changing user input.
+	NodePoolValidFIPSConfigurationConditionType = "ValidFIPSConfiguration"
+	// NodePoolValidReleaseImageArchConditionType signals if the release image of the NodePool provides images for
+	// the NodePool CPU architecture, regardless of the CPU architecture of the management cluster.
+	// A failure here is unlikely to resolve without the changing user input.
+	NodePoolValidReleaseImageArchConditionType = "ValidReleaseImageArch"
 	// NodePoolValidMachineConfigConditionType signals if the content within nodePool.spec.config is valid.
 	// A failure here is unlikely to resolve without the changing user input.
 	NodePoolValidMachineConfigConditionType = "ValidMachineConfig"
@@ -68,20 +86,63 @@ const (
 	// NodePoolClusterNetworkCIDRConflictType signals if a NodePool's machine objects are colliding with the
 	// cluster network's CIDR range. This can indicate why some network functionality might be degraded.
 	NodePoolClusterNetworkCIDRConflictType = "ClusterNetworkCIDRConflict"
+
+	// NodePoolSSHKeyPropagatedConditionType signals if the SSH key in hostedCluster.spec.sshKey is authorized on all the Nodes of the NodePool.
+	// For Replace NodePools the key is written onto the existing Nodes without replacing them, for InPlace NodePools it is applied as part of the config update.
+	NodePoolSSHKeyPropagatedConditionType = "SSHKeyPropagated"
+
+	// NodePoolRolloutPausedConditionType signals if changes to the config or release of the NodePool are held back
+	// from its Nodes, because nodePool.spec.management.rollout is paused or the change is awaiting approval.
+	NodePoolRolloutPausedConditionType = "RolloutPaused"
+
+	// NodePoolAdditionalTrustBundlePropagatedConditionType signals if the additionalTrustBundle of the HostedCluster is in
+	// the CA trust of all the Nodes of the NodePool. The condition is only set when the HostedCluster has an
+	// additionalTrustBundle.
+	NodePoolAdditionalTrustBundlePropagatedConditionType = "AdditionalTrustBundlePropagated"
+
+	// NodePoolBootImageUpdatedConditionType signals if the boot image in the platform spec of the NodePool is the one
+	// of its release. The condition is only set when nodePool.spec.bootImageUpdatePolicy is Automatic.
+	// A failure here may require external user intervention to resolve. E.g. missing permissions to copy images.
+	NodePoolBootImageUpdatedConditionType = "BootImageUpdated"
+
+	// NodePoolUpdatingBootImageConditionType signals if the version rollout of the NodePool is held back until its boot
+	// image is updated for its release, so the release and the boot image are rolled out together. The NodePool keeps
+	// scaling in the meantime. The condition is only set when nodePool.spec.bootImageUpdatePolicy is Automatic.
+	NodePoolUpdatingBootImageConditionType = "UpdatingBootImage"
+
+	// NodePoolScaleUpBlockedConditionType signals if an increase of the replicas of the NodePool is held back because
+	// its preflight checks found that the new machines wouldn't be provisioned, e.g. because their subnet has no free
+	// IP addresses or the instance quota of the account is exhausted. The machines are created once the checks pass.
+	// The condition is not set on autoscaled NodePools.
+	NodePoolScaleUpBlockedConditionType = "ScaleUpBlocked"
 )
 
 // Reasons
 const (
-	NodePoolValidationFailedReason        = "ValidationFailed"
-	NodePoolInplaceUpgradeFailedReason    = "InplaceUpgradeFailed"
-	NodePoolNotFoundReason                = "NotFound"
-	NodePoolFailedToGetReason             = "FailedToGet"
-	IgnitionEndpointMissingReason         = "IgnitionEndpointMissing"
-	IgnitionCACertMissingReason           = "IgnitionCACertMissing"
-	IgnitionNotReached                    = "ignitionNotReached"
-	DefaultAWSSecurityGroupNotReadyReason = "DefaultSGNotReady"
-	NodePoolValidArchPlatform             = "ValidArchPlatform"
-	NodePoolInvalidArchPlatform           = "InvalidArchPlatform"
-	InvalidKubevirtMachineTemplate        = "InvalidKubevirtMachineTemplate"
-	CIDRConflictReason                    = "CIDRConflict"
+	NodePoolValidationFailedReason          = "ValidationFailed"
+	NodePoolInplaceUpgradeFailedReason      = "InplaceUpgradeFailed"
+	NodePoolNotFoundReason                  = "NotFound"
+	NodePoolFailedToGetReason               = "FailedToGet"
+	IgnitionEndpointMissingReason           = "IgnitionEndpointMissing"
+	IgnitionCACertMissingReason             = "IgnitionCACertMissing"
+	IgnitionNotReached                      = "ignitionNotReached"
+	DefaultAWSSecurityGroupNotReadyReason   = "DefaultSGNotReady"
+	DedicatedAWSSecurityGroupNotReadyReason = "DedicatedSGNotReady"
+	NodePoolValidArchPlatform               = "ValidArchPlatform"
+	NodePoolInvalidArchPlatform             = "InvalidArchPlatform"
+	InvalidKubevirtMachineTemplate          = "InvalidKubevirtMachineTemplate"
+	InvalidAzureMachineTemplate             = "InvalidAzureMachineTemplate"
+	CIDRConflictReason                      = "CIDRConflict"
+	SSHKeyPropagatingReason                 = "SSHKeyPropagating"
+	RolloutPausedReason                     = "RolloutPaused"
+	RolloutAwaitingApprovalReason           = "AwaitingApproval"
+	TrustBundlePropagatingReason            = "TrustBundlePropagating"
+	TrustBundleDistributionDisabledReason   = "TrustBundleDistributionDisabled"
+	BootImageUpdatingReason                 = "BootImageUpdating"
+	BootImageUpdateFailedReason             = "BootImageUpdateFailed"
+	BootImageUpdatePendingReason            = "BootImageUpdatePending"
+	ReleaseImageArchMismatchReason          = "ReleaseImageArchMismatch"
+	InsufficientSubnetIPsReason             = "InsufficientSubnetIPs"
+	InstanceQuotaExceededReason             = "InstanceQuotaExceeded"
+	InsufficientZoneCapacityReason          = "InsufficientZoneCapacity"
 )
@@ -21,9 +21,33 @@ const (
 	// NodePoolLabel is used to label Nodes.
 	NodePoolLabel = "hypershift.openshift.io/nodePool"
 
+	// NodePoolPullSecretLabel is used to label the Secrets in the control plane
+	// namespace holding the pull secret for the Nodes of a NodePool.
+	NodePoolPullSecretLabel = "hypershift.openshift.io/nodepool-pull-secret"
+
+	// NodePoolSSHKeyLabel is used to label the Secrets in the control plane
+	// namespace holding the SSH key to be propagated to the existing Nodes of a NodePool.
+	NodePoolSSHKeyLabel = "hypershift.openshift.io/nodepool-ssh-key"
+
+	// NodePoolSSHKeyPropagatedHashAnnotation is set on the NodePool SSH key Secrets in the control plane namespace
+	// with the hash of the SSH key once it has been written onto all the Nodes of the NodePool.
+	NodePoolSSHKeyPropagatedHashAnnotation = "hypershift.openshift.io/ssh-key-propagated-hash"
+
 	// IgnitionServerTokenExpirationTimestampAnnotation holds the time that a ignition token expires and should be
 	// removed from the cluster.
 	IgnitionServerTokenExpirationTimestampAnnotation = "hypershift.openshift.io/ignition-token-expiration-timestamp"
+
+	// NodePoolApprovedRolloutAnnotation approves the rollout of a change to a NodePool with Manual rollout approval.
+	// Its value is the target config version of the change, as reported by the RolloutPaused condition.
+	NodePoolApprovedRolloutAnnotation = "hypershift.openshift.io/approved-rollout"
+
+	// NodePoolBootImageReleaseAnnotation is set on NodePools with an Automatic boot image update policy to the
+	// release image the boot image in the platform spec was last updated for.
+	NodePoolBootImageReleaseAnnotation = "hypershift.openshift.io/boot-image-release"
+
+	// NodePoolSkipScaleUpPreflightAnnotation disables the preflight checks run before the replicas of a NodePool are
+	// increased, when set to "true".
+	NodePoolSkipScaleUpPreflightAnnotation = "hypershift.openshift.io/skip-scale-up-preflight"
 )
 
 var (
@@ -152,6 +176,18 @@ type NodePoolSpec struct {
 	// +optional
 	Taints []Taint `json:"taints,omitempty"`
 
+	// MachineDeletionHooks are registered on the Machines of the NodePool, so external systems, e.g. storage
+	// operators, can delay the deletion of a Machine until they have migrated their workloads off its Node.
+	// A Machine being deleted waits for each hook to be removed from its annotations by its owner, before draining
+	// its Node for PreDrain hooks and before terminating its instance for PreTerminate hooks.
+	// Changes are propagated to existing Machines without replacing them.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +listMapKey=phase
+	// +kubebuilder:validation:MaxItems=10
+	MachineDeletionHooks []MachineDeletionHook `json:"machineDeletionHooks,omitempty"`
+
 	// PausedUntil is a field that can be used to pause reconciliation on a resource.
 	// Either a date can be provided in RFC3339 format or a boolean. If a date is
 	// provided: reconciliation is paused on the resource until that date. If the boolean true is
@@ -173,6 +209,71 @@ type NodePoolSpec struct {
 	// +kubebuilder:validation:Optional
 	TuningConfig []corev1.LocalObjectReference `json:"tuningConfig,omitempty"`
 
+	// AdditionalPullSecrets is a list of references to Secrets in the NodePool
+	// namespace containing registry credentials, with a single key named
+	// ".dockerconfigjson". The credentials are merged with the HostedCluster
+	// pull secret on the nodes of the NodePool, and take precedence for
+	// registries present in both. Changes to these Secrets and to the
+	// HostedCluster pull secret are propagated to existing nodes without
+	// replacing or rebooting them.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=10
+	AdditionalPullSecrets []corev1.LocalObjectReference `json:"additionalPullSecrets,omitempty"`
+
+	// NTPServers is a list of NTP servers, as hostnames or IP addresses, the
+	// nodes in the NodePool synchronize their clocks with. When set, the
+	// servers replace the default time sources of the chrony configuration of
+	// the nodes. Changes are rolled out with the NodePool upgrade strategy.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=16
+	NTPServers []string `json:"ntpServers,omitempty"`
+
+	// ContainerRuntime configures the container runtime of the nodes in the
+	// NodePool. It's rendered into a ContainerRuntimeConfig, and a MachineConfig
+	// setting the kernel arguments of the cgroup mode, so no MCO resources need
+	// to be written by hand in .spec.config. Changes are rolled out with the
+	// NodePool upgrade strategy.
+	// +optional
+	ContainerRuntime *NodePoolContainerRuntime `json:"containerRuntime,omitempty"`
+
+	// AdditionalTrustBundleDistribution controls whether the additionalTrustBundle
+	// of the HostedCluster is added to the CA trust of the nodes in the NodePool.
+	// When Enabled, the bundle is written into the CA trust anchors of the nodes
+	// and changes are rolled out with the NodePool upgrade strategy. Disabled opts
+	// the NodePool out of the distribution.
+	// +kubebuilder:validation:Enum=Enabled;Disabled
+	// +kubebuilder:default=Enabled
+	// +optional
+	AdditionalTrustBundleDistribution AdditionalTrustBundleDistribution `json:"additionalTrustBundleDistribution,omitempty"`
+
+	// Bootstrap configures how the machines of the NodePool reach the ignition
+	// server on their first boot, for NodePools whose machines egress through a
+	// different path than the rest of the cluster. It's rendered into the
+	// bootstrap user data of the machines, and only applies to the machines
+	// created after it's changed, without rolling out the NodePool.
+	// +optional
+	Bootstrap *NodePoolBootstrap `json:"bootstrap,omitempty"`
+
+	// BootImageUpdatePolicy controls whether the boot image of the NodePool
+	// platform (the AMI on AWS, the image ID on Azure) follows the RHCOS boot
+	// image of the NodePool release. When Automatic, the boot image reference in
+	// the platform spec is replaced on every release change, copying the AMI into
+	// the region of the HostedCluster when the release has none for it on AWS and
+	// publishing a gallery image version on Azure, and the release is only rolled
+	// out once the boot image is available. Manual leaves the boot image untouched.
+	// +kubebuilder:validation:Enum=Manual;Automatic
+	// +kubebuilder:default=Manual
+	// +optional
+	BootImageUpdatePolicy BootImageUpdatePolicy `json:"bootImageUpdatePolicy,omitempty"`
+
+	// OSImage boots the nodes of the NodePool from a layered bootable
+	// container image, i.e. an ostree native container built on top of the
+	// RHEL CoreOS image of the NodePool release, instead of the RHEL CoreOS
+	// image of the release itself. When unset, the nodes run the RHEL CoreOS
+	// image of the release.
+	// +optional
+	OSImage *NodePoolOSImage `json:"osImage,omitempty"`
+
 	// Arch is the preferred processor architecture for the NodePool (currently only supported on AWS)
 	// NOTE: This is set as optional to prevent validation from failing due to a limitation on client side validation with open API machinery:
 	//	https://github.com/kubernetes/kubernetes/issues/108768#issuecomment-1253912215
@@ -185,6 +286,126 @@ type NodePoolSpec struct {
 	Arch string `json:"arch,omitempty"`
 }
 
+// NodePoolContainerRuntime configures the container runtime of the nodes in a
+// NodePool.
+type NodePoolContainerRuntime struct {
+	// CgroupMode is the cgroup version of the nodes. When omitted, the default
+	// of the NodePool release is used.
+	// +kubebuilder:validation:Enum=v1;v2
+	// +optional
+	CgroupMode CgroupMode `json:"cgroupMode,omitempty"`
+
+	// DefaultRuntime is the OCI runtime CRI-O uses to run containers. When
+	// omitted, the default of the NodePool release is used.
+	// +kubebuilder:validation:Enum=runc;crun
+	// +optional
+	DefaultRuntime ContainerRuntimeName `json:"defaultRuntime,omitempty"`
+
+	// PidsLimit is the maximum number of processes allowed in a container.
+	// +kubebuilder:validation:Minimum=20
+	// +optional
+	PidsLimit *int64 `json:"pidsLimit,omitempty"`
+
+	// LogSizeMax is the maximum size of the log file of a container. A
+	// negative value means no limit, a positive value must be at least 8Ki.
+	// +optional
+	LogSizeMax *resource.Quantity `json:"logSizeMax,omitempty"`
+}
+
+// CgroupMode is the cgroup version of the nodes of a NodePool.
+type CgroupMode string
+
+const (
+	// CgroupModeV1 runs the nodes with the legacy cgroup v1 hierarchy.
+	CgroupModeV1 CgroupMode = "v1"
+
+	// CgroupModeV2 runs the nodes with the unified cgroup v2 hierarchy.
+	CgroupModeV2 CgroupMode = "v2"
+)
+
+// ContainerRuntimeName is the name of an OCI runtime.
+type ContainerRuntimeName string
+
+const (
+	// ContainerRuntimeRunc is the runc OCI runtime.
+	ContainerRuntimeRunc ContainerRuntimeName = "runc"
+
+	// ContainerRuntimeCrun is the crun OCI runtime.
+	ContainerRuntimeCrun ContainerRuntimeName = "crun"
+)
+
+// AdditionalTrustBundleDistribution specifies whether the HostedCluster
+// additionalTrustBundle is distributed to the nodes of a NodePool.
+type AdditionalTrustBundleDistribution string
+
+const (
+	// AdditionalTrustBundleDistributionEnabled adds the additionalTrustBundle to the CA trust of the nodes.
+	AdditionalTrustBundleDistributionEnabled AdditionalTrustBundleDistribution = "Enabled"
+
+	// AdditionalTrustBundleDistributionDisabled leaves the CA trust of the nodes untouched.
+	AdditionalTrustBundleDistributionDisabled AdditionalTrustBundleDistribution = "Disabled"
+)
+
+// NodePoolBootstrap configures the ignition fetch of the machines of a NodePool.
+type NodePoolBootstrap struct {
+	// Proxy is the proxy the machines fetch their ignition payload through. It
+	// replaces the proxy of the HostedCluster configuration for the ignition
+	// fetch; the nodes use the HostedCluster proxy once they have booted.
+	// +optional
+	Proxy *NodePoolBootstrapProxy `json:"proxy,omitempty"`
+
+	// AdditionalTrustBundle references a ConfigMap in the NodePool namespace
+	// with a single key named "ca-bundle.crt" containing PEM encoded CA
+	// certificates, trusted in addition to the ignition server CA when fetching
+	// the ignition payload, e.g. the CA of a TLS inspecting proxy.
+	// +optional
+	AdditionalTrustBundle *corev1.LocalObjectReference `json:"additionalTrustBundle,omitempty"`
+}
+
+// NodePoolBootstrapProxy is the proxy of the ignition fetch of the machines of a NodePool.
+// +kubebuilder:validation:XValidation:rule="has(self.httpProxy) || has(self.httpsProxy)",message="at least one of httpProxy and httpsProxy must be set"
+type NodePoolBootstrapProxy struct {
+	// HTTPProxy is the URL of the proxy for HTTP requests.
+	// +kubebuilder:validation:Pattern=`^https?://`
+	// +optional
+	HTTPProxy string `json:"httpProxy,omitempty"`
+
+	// HTTPSProxy is the URL of the proxy for HTTPS requests.
+	// +kubebuilder:validation:Pattern=`^https?://`
+	// +optional
+	HTTPSProxy string `json:"httpsProxy,omitempty"`
+
+	// NoProxy is a list of hostnames, domains and CIDRs for which the proxy
+	// must not be used.
+	// +kubebuilder:validation:MaxItems=64
+	// +optional
+	NoProxy []string `json:"noProxy,omitempty"`
+}
+
+// BootImageUpdatePolicy specifies whether the boot image of a NodePool is
+// updated with its release.
+type BootImageUpdatePolicy string
+
+const (
+	// BootImageUpdatePolicyManual leaves the boot image of the NodePool to the user.
+	BootImageUpdatePolicyManual BootImageUpdatePolicy = "Manual"
+
+	// BootImageUpdatePolicyAutomatic updates the boot image of the NodePool to the one of its release.
+	BootImageUpdatePolicyAutomatic BootImageUpdatePolicy = "Automatic"
+)
+
+// NodePoolOSImage specifies the layered OS image of a NodePool.
+type NodePoolOSImage struct {
+	// Image is the pull spec of the layered bootable container image. An
+	// image referenced by tag is pinned to the digest the tag points to, and
+	// the tag is resolved again periodically: when it points to a new digest,
+	// the new image is rolled out with the NodePool upgrade strategy. An image
+	// referenced by digest is never updated. The image is pulled with the pull
+	// secret of the HostedCluster.
+	// +kubebuilder:validation:MinLength=1
+	Image string `json:"image"`
+}
+
 // NodePoolStatus is the latest observed status of a NodePool.
 type NodePoolStatus struct {
 	// Replicas is the latest observed number of nodes in the pool.
@@ -201,6 +422,26 @@ type NodePoolStatus struct {
 	// Platform hols the specific statuses
 	Platform *NodePoolPlatformStatus `json:"platform,omitempty"`
 
+	// Capacity is the aggregate capacity and estimated cost of the machines of
+	// the NodePool, as seen from the management cluster.
+	// +optional
+	Capacity *NodePoolCapacityStatus `json:"capacity,omitempty"`
+
+	// OSImage is the pull spec, pinned by digest, of the layered OS image the
+	// nodes of the NodePool boot when spec.osImage is set.
+	// +optional
+	OSImage string `json:"osImage,omitempty"`
+
+	// Machines summarizes the machines of the NodePool, so that stuck rollouts
+	// can be diagnosed without access to the CAPI Machines in the control plane
+	// namespace. At most 100 machines are listed, the ones which aren't running
+	// or have an error first.
+	// +kubebuilder:validation:MaxItems=100
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Machines []NodePoolMachineStatus `json:"machines,omitempty"`
+
 	// Conditions represents the latest available observations of the node pool's
 	// current state.
 	// +optional
@@ -348,6 +589,8 @@ type InPlaceUpgrade struct {
 
 // NodePoolManagement specifies behavior for managing nodes in a NodePool, such
 // as upgrade strategies and auto-repair behaviors.
+//
+// +kubebuilder:validation:XValidation:rule="!has(self.maxNodeLifetime) || self.upgradeType == 'Replace'",message="maxNodeLifetime is only supported with the Replace upgradeType"
 type NodePoolManagement struct {
 	// UpgradeType specifies the type of strategy for handling upgrades.
 	//
@@ -372,6 +615,53 @@ type NodePoolManagement struct {
 	// +optional
 	// +kubebuilder:default=false
 	AutoRepair bool `json:"autoRepair"`
+
+	// Rollout specifies how changes to the config and release of the NodePool are rolled out to its Nodes.
+	//
+	// +optional
+	Rollout *NodePoolRollout `json:"rollout,omitempty"`
+
+	// MaxNodeLifetime is the maximum age of the Nodes of the NodePool. Once its oldest Node reaches it, all the Nodes
+	// are replaced with a rolling update following the Replace strategy, which drains the Nodes while respecting their
+	// PodDisruptionBudgets. Nodes aren't replaced while rollouts are paused. It is only supported with the Replace
+	// upgradeType. The minimum is 1h.
+	//
+	// Example: 720h
+	//
+	// +kubebuilder:validation:XValidation:rule="duration(self) >= duration('1h')",message="maxNodeLifetime must be at least 1h"
+	// +optional
+	MaxNodeLifetime *metav1.Duration `json:"maxNodeLifetime,omitempty"`
+}
+
+// RolloutApproval is the policy for approving the rollout of changes to a NodePool.
+type RolloutApproval string
+
+const (
+	// RolloutApprovalAutomatic rolls out changes as soon as they are made.
+	RolloutApprovalAutomatic = RolloutApproval("Automatic")
+
+	// RolloutApprovalManual rolls out changes only once they are approved.
+	RolloutApprovalManual = RolloutApproval("Manual")
+)
+
+// NodePoolRollout specifies how changes to the config and release of a NodePool are rolled out. While a rollout
+// is paused or awaiting approval, the existing Nodes are kept as they are and the NodePool can still be scaled,
+// with new Nodes getting the config and release the existing Nodes are running.
+type NodePoolRollout struct {
+	// Paused indicates that changes to the config and release of the NodePool are not rolled out until it is unset.
+	//
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+
+	// Approval specifies whether changes are rolled out automatically or only once approved.
+	// With Manual approval, a change is rolled out once the NodePool is annotated with
+	// hypershift.openshift.io/approved-rollout set to the target config version reported by the
+	// RolloutPaused condition.
+	//
+	// +kubebuilder:validation:Enum=Automatic;Manual
+	// +kubebuilder:default=Automatic
+	// +optional
+	Approval RolloutApproval `json:"approval,omitempty"`
 }
 
 // NodePoolAutoScaling specifies auto-scaling behavior for a NodePool.
@@ -740,6 +1030,17 @@ type KubevirtNodePoolPlatform struct {
 	//
 	// +optional
 	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// InfraNamespace is the namespace of the external infra cluster in which the KubeVirt virtual machines of the
+	// NodePool are created, e.g. so that the virtual machines of a HostedCluster count against the quotas of the
+	// teams owning them. It requires the HostedCluster to use external infra cluster credentials, which must grant
+	// access to this namespace. When not set, the infra namespace of the HostedCluster credentials is used.
+	//
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf", message="infraNamespace is immutable"
+	// +optional
+	InfraNamespace string `json:"infraNamespace,omitempty"`
 }
 
 // KubevirtNetwork specifies the configuration for a virtual machine
@@ -753,6 +1054,8 @@ type KubevirtNetwork struct {
 
 // AWSNodePoolPlatform specifies the configuration of a NodePool when operating
 // on AWS.
+//
+// +kubebuilder:validation:XValidation:rule="!(has(self.ami) && has(self.amiLookup))", message="only one of ami or amiLookup may be set"
 type AWSNodePoolPlatform struct {
 	// InstanceType is an ec2 instance type for node instances (e.g. m5.large).
 	InstanceType string `json:"instanceType"`
@@ -772,12 +1075,33 @@ type AWSNodePoolPlatform struct {
 	// +optional
 	AMI string `json:"ami,omitempty"`
 
+	// AMILookup looks up the image to use for node instances in the region of
+	// the HostedCluster instead of specifying its id, so the same NodePool can be
+	// used in any region. The newest available image matching the lookup and the
+	// architecture of the NodePool is used. The lookup is resolved again when it
+	// or the release of the NodePool changes, rolling out the image it resolves
+	// to; images published in between are not rolled out on their own.
+	// Mutually exclusive with AMI.
+	//
+	// +optional
+	AMILookup *AWSAMILookup `json:"amiLookup,omitempty"`
+
 	// SecurityGroups is an optional set of security groups to associate with node
 	// instances.
 	//
 	// +optional
 	SecurityGroups []AWSResourceReference `json:"securityGroups,omitempty"`
 
+	// DedicatedSecurityGroup, when set, creates a security group dedicated to
+	// the NodePool with the given ingress rules and associates it with node
+	// instances, in addition to the default security group of the HostedCluster
+	// and SecurityGroups. It lets NodePools hosting special workloads, e.g.
+	// databases or ingress, be firewalled apart from the other NodePools. The
+	// security group is deleted once unset or once the NodePool is deleted.
+	//
+	// +optional
+	DedicatedSecurityGroup *AWSNodePoolSecurityGroup `json:"dedicatedSecurityGroup,omitempty"`
+
 	// RootVolume specifies configuration for the root volume of node instances.
 	//
 	// +optional
@@ -799,6 +1123,83 @@ type AWSNodePoolPlatform struct {
 	ResourceTags []AWSResourceTag `json:"resourceTags,omitempty"`
 }
 
+// AWSNodePoolSecurityGroup is a security group dedicated to a NodePool.
+type AWSNodePoolSecurityGroup struct {
+	// IngressRules are the ingress rules of the security group. Egress is not
+	// restricted.
+	//
+	// +kubebuilder:validation:MaxItems=50
+	// +optional
+	IngressRules []AWSSecurityGroupIngressRule `json:"ingressRules,omitempty"`
+}
+
+// AWSSecurityGroupProtocol is the IP protocol of a security group rule.
+//
+// +kubebuilder:validation:Enum=tcp;udp;icmp;all
+type AWSSecurityGroupProtocol string
+
+const (
+	AWSSecurityGroupProtocolTCP  AWSSecurityGroupProtocol = "tcp"
+	AWSSecurityGroupProtocolUDP  AWSSecurityGroupProtocol = "udp"
+	AWSSecurityGroupProtocolICMP AWSSecurityGroupProtocol = "icmp"
+	AWSSecurityGroupProtocolAll  AWSSecurityGroupProtocol = "all"
+)
+
+// AWSSecurityGroupIngressRule allows traffic from CIDR blocks to a range of
+// ports.
+//
+// +kubebuilder:validation:XValidation:rule="self.protocol in ['tcp', 'udp'] ? has(self.fromPort) && has(self.toPort) && self.fromPort <= self.toPort : !has(self.fromPort) && !has(self.toPort)", message="fromPort and toPort are required for tcp and udp, with fromPort <= toPort, and not allowed otherwise"
+type AWSSecurityGroupIngressRule struct {
+	// Description of the rule.
+	//
+	// +kubebuilder:validation:MaxLength=255
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Protocol is the IP protocol of the rule: tcp, udp, icmp or all.
+	Protocol AWSSecurityGroupProtocol `json:"protocol"`
+
+	// FromPort is the first port of the range the rule allows, for tcp and
+	// udp.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	FromPort *int32 `json:"fromPort,omitempty"`
+
+	// ToPort is the last port of the range the rule allows, for tcp and udp.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	ToPort *int32 `json:"toPort,omitempty"`
+
+	// CIDRBlocks are the IPv4 CIDR blocks the rule allows traffic from.
+	//
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=20
+	CIDRBlocks []string `json:"cidrBlocks"`
+}
+
+// AWSAMILookup identifies AMIs by their owner, name and tags.
+type AWSAMILookup struct {
+	// Owners are the account IDs or aliases (e.g. amazon, self) of the owners
+	// of the AMI.
+	//
+	// +kubebuilder:validation:MinItems=1
+	Owners []string `json:"owners"`
+
+	// Name is the name of the AMI. It may contain the * and ? wildcards.
+	//
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Tags are tags the AMI must have.
+	//
+	// +optional
+	Tags []AWSResourceTag `json:"tags,omitempty"`
+}
+
 // AWSResourceReference is a reference to a specific AWS resource by ID or filters.
 // Only one of ID or Filters may be specified. Specifying more than one will result in
 // a validation error.
@@ -860,8 +1261,33 @@ type AgentNodePoolPlatform struct {
 	// be selected for a Machine.
 	// +optional
 	AgentLabelSelector *metav1.LabelSelector `json:"agentLabelSelector,omitempty"`
+
+	// HostReprovisioning determines how the hosts released by the NodePool, e.g. when Machines are replaced to
+	// roll out a configuration change, are made available again.
+	// With Manual, the hosts must be booted back into the discovery image by hand before they can be reused.
+	// With Automatic, the hosts managed by a BareMetalHost are rebooted into the discovery image as soon as they
+	// are released, and Machines are replaced one at a time, so that the NodePool can be rolled out by reinstalling
+	// its own hosts without spare ones.
+	//
+	// +kubebuilder:validation:Enum=Manual;Automatic
+	// +kubebuilder:default=Manual
+	// +optional
+	HostReprovisioning AgentHostReprovisioning `json:"hostReprovisioning,omitempty"`
 }
 
+// AgentHostReprovisioning is how the hosts released by an Agent NodePool are made available again.
+type AgentHostReprovisioning string
+
+const (
+	// AgentHostReprovisioningManual means released hosts must be booted back into the discovery image by hand.
+	AgentHostReprovisioningManual AgentHostReprovisioning = "Manual"
+
+	// AgentHostReprovisioningAutomatic means released hosts managed by a BareMetalHost are rebooted into the
+	// discovery image automatically, and Machines are replaced one at a time.
+	AgentHostReprovisioningAutomatic AgentHostReprovisioning = "Automatic"
+)
+
+// +kubebuilder:validation:XValidation:rule="!has(self.securityProfile) || !has(self.securityProfile.confidentialDiskEncryption) || self.securityProfile.confidentialDiskEncryption != 'DiskWithVMGuestState' || !has(self.diskEncryptionSetID)",message="diskEncryptionSetID can't be combined with the DiskWithVMGuestState confidentialDiskEncryption"
 type AzureNodePoolPlatform struct {
 	// VMSize is the Azure VM instance type to use for the nodes being created in the nodepool.
 	//
@@ -919,6 +1345,24 @@ type AzureNodePoolPlatform struct {
 	// +optional
 	EnableEphemeralOSDisk bool `json:"enableEphemeralOSDisk,omitempty"`
 
+	// EnableAcceleratedNetworking enables accelerated networking on the network interface of the VMs, which bypasses
+	// the host for lower latency and jitter. The VM size must support it, which is validated against the capabilities
+	// of the VM size in the location of the HostedCluster. When false, accelerated networking is enabled whenever the
+	// VM size supports it.
+	//
+	// +optional
+	EnableAcceleratedNetworking bool `json:"enableAcceleratedNetworking,omitempty"`
+
+	// SecurityProfile requires hardware-backed protections on the VMs of the NodePool, such as secure boot, a
+	// virtual trusted platform module and memory encryption. The VM size must support the security type in the
+	// location of the HostedCluster, which is validated against the capabilities of the VM size. These security
+	// types require VMs booted from a Generation 2 image, so either ImageID refers to a Generation 2 gallery image
+	// supporting the security type, or the BootImageUpdatePolicy of the NodePool is Automatic, in which case a
+	// compatible gallery image is published for the release of the NodePool.
+	//
+	// +optional
+	SecurityProfile *AzureVMSecurityProfile `json:"securityProfile,omitempty"`
+
 	// SubnetID is the subnet ID of an existing subnet where the nodes in the nodepool will be created. This can be a
 	// different subnet than the one listed in the HostedCluster, hcluster.Spec.Platform.Azure.SubnetID, but must exist
 	// in the same hcluster.Spec.Platform.Azure.VnetID and must exist under the same subscription ID,
@@ -931,6 +1375,67 @@ type AzureNodePoolPlatform struct {
 	SubnetID string `json:"subnetID"`
 }
 
+// AzureVMSecurityType is the security type of Azure VMs.
+type AzureVMSecurityType string
+
+const (
+	// AzureVMSecurityTypeTrustedLaunch protects the VMs against boot kits and kernel level malware with secure boot
+	// and a virtual trusted platform module.
+	AzureVMSecurityTypeTrustedLaunch AzureVMSecurityType = "TrustedLaunch"
+
+	// AzureVMSecurityTypeConfidentialVM additionally isolates the VMs from the host and encrypts their memory with
+	// keys generated by the hardware. It requires a confidential VM size, e.g. of the DCasv5 or ECasv5 series.
+	AzureVMSecurityTypeConfidentialVM AzureVMSecurityType = "ConfidentialVM"
+)
+
+// AzureConfidentialDiskEncryption is the encryption of the OS disk of confidential VMs.
+type AzureConfidentialDiskEncryption string
+
+const (
+	// AzureConfidentialDiskEncryptionVMGuestStateOnly encrypts the VM guest state only.
+	AzureConfidentialDiskEncryptionVMGuestStateOnly AzureConfidentialDiskEncryption = "VMGuestStateOnly"
+
+	// AzureConfidentialDiskEncryptionDiskWithVMGuestState encrypts the OS disk along with the VM guest state, with
+	// keys bound to the virtual trusted platform module of the VM.
+	AzureConfidentialDiskEncryptionDiskWithVMGuestState AzureConfidentialDiskEncryption = "DiskWithVMGuestState"
+)
+
+// AzureVMSecurityProfile specifies the security type of the VMs of a NodePool.
+//
+// +kubebuilder:validation:XValidation:rule="self.securityType == 'ConfidentialVM' || !has(self.confidentialDiskEncryption)",message="confidentialDiskEncryption is only supported with the ConfidentialVM securityType"
+// +kubebuilder:validation:XValidation:rule="self.securityType != 'ConfidentialVM' || !has(self.vTPM) || self.vTPM",message="vTPM is required with the ConfidentialVM securityType"
+// +kubebuilder:validation:XValidation:rule="!has(self.confidentialDiskEncryption) || self.confidentialDiskEncryption != 'DiskWithVMGuestState' || !has(self.secureBoot) || self.secureBoot",message="secureBoot is required with the DiskWithVMGuestState confidentialDiskEncryption"
+type AzureVMSecurityProfile struct {
+	// SecurityType is the security type of the VMs, either TrustedLaunch or ConfidentialVM.
+	//
+	// +kubebuilder:validation:Enum=TrustedLaunch;ConfidentialVM
+	// +required
+	SecurityType AzureVMSecurityType `json:"securityType"`
+
+	// SecureBoot enables secure boot, which verifies the signatures of the boot components of the VMs and stops
+	// the boot when the verification fails. Defaults to true.
+	//
+	// +kubebuilder:default=true
+	// +optional
+	SecureBoot *bool `json:"secureBoot,omitempty"`
+
+	// VTPM enables the virtual trusted platform module of the VMs, which measures their boot chain. Defaults to
+	// true, and is required by confidential VMs.
+	//
+	// +kubebuilder:default=true
+	// +optional
+	VTPM *bool `json:"vTPM,omitempty"`
+
+	// ConfidentialDiskEncryption is the encryption of the OS disk of confidential VMs, either VMGuestStateOnly or
+	// DiskWithVMGuestState. It can only be set with the ConfidentialVM security type, and defaults to
+	// VMGuestStateOnly. DiskWithVMGuestState requires secure boot and can't be combined with DiskEncryptionSetID,
+	// which enables encryption at host.
+	//
+	// +kubebuilder:validation:Enum=VMGuestStateOnly;DiskWithVMGuestState
+	// +optional
+	ConfidentialDiskEncryption AzureConfidentialDiskEncryption `json:"confidentialDiskEncryption,omitempty"`
+}
+
 // We define our own condition type since metav1.Condition has validation
 // for Reason that might be broken by what we bubble up from CAPI.
 // NodePoolCondition defines an observation of NodePool resource operational state.
@@ -969,11 +1474,109 @@ type NodePoolCondition struct {
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
+// NodePoolMachineStatus summarizes a machine of a NodePool.
+type NodePoolMachineStatus struct {
+	// Name is the name of the CAPI Machine.
+	Name string `json:"name"`
+
+	// Phase is the phase of the machine, e.g. Provisioning, Running or
+	// Deleting.
+	// +optional
+	Phase string `json:"phase,omitempty"`
+
+	// ProviderID is the ID of the machine in the cloud provider, once it is
+	// provisioned.
+	// +optional
+	ProviderID string `json:"providerID,omitempty"`
+
+	// NodeName is the name of the node of the machine, once it joined the
+	// cluster.
+	// +optional
+	NodeName string `json:"nodeName,omitempty"`
+
+	// Version is the release version the machine is created with.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// LastError is the last error reported for the machine, e.g. why it
+	// couldn't be provisioned or why its node isn't healthy.
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+}
+
+// NodePoolCapacityStatus is the aggregate capacity and estimated cost of the
+// machines of a NodePool.
+type NodePoolCapacityStatus struct {
+	// Machines is the number of machines the capacity and cost are aggregated
+	// over.
+	Machines int32 `json:"machines"`
+
+	// MachineType is the instance type, VM size or equivalent of the machines,
+	// if the platform has one.
+	// +optional
+	MachineType string `json:"machineType,omitempty"`
+
+	// CPU is the aggregate number of vCPUs of the machines. Unset when the vCPUs
+	// of the machine type can't be resolved.
+	// +optional
+	CPU *resource.Quantity `json:"cpu,omitempty"`
+
+	// Memory is the aggregate memory of the machines. Unset when the memory of
+	// the machine type can't be resolved.
+	// +optional
+	Memory *resource.Quantity `json:"memory,omitempty"`
+
+	// EstimatedHourlyCost is the estimated cost per hour of the machines, as a
+	// decimal number in Currency, from the machine price source of the HyperShift
+	// operator. Unset when there is no price for the machine type.
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?$`
+	// +optional
+	EstimatedHourlyCost string `json:"estimatedHourlyCost,omitempty"`
+
+	// Currency is the ISO 4217 code of the currency of EstimatedHourlyCost.
+	// +optional
+	Currency string `json:"currency,omitempty"`
+}
+
 // NodePoolPlatformStatus contains specific platform statuses
 type NodePoolPlatformStatus struct {
 	// KubeVirt contains the KubeVirt platform statuses
 	// +optional
 	KubeVirt *KubeVirtNodePoolStatus `json:"kubeVirt,omitempty"`
+
+	// AWS contains the AWS platform statuses
+	// +optional
+	AWS *AWSNodePoolStatus `json:"aws,omitempty"`
+
+	// Azure contains the Azure platform statuses
+	// +optional
+	Azure *AzureNodePoolStatus `json:"azure,omitempty"`
+}
+
+// AzureNodePoolStatus contains the Azure platform statuses
+type AzureNodePoolStatus struct {
+	// AvailabilitySetName is the name of the availability set the VMs of the NodePool are placed in, to spread them
+	// across fault domains when the NodePool has no availability zone, e.g. in locations without zones. Each NodePool
+	// has its own availability set, created in the resource group of the HostedCluster along with its first VM.
+	// +optional
+	AvailabilitySetName string `json:"availabilitySetName,omitempty"`
+}
+
+// AWSNodePoolStatus contains the AWS platform statuses
+type AWSNodePoolStatus struct {
+	// AMI is the image id the AMILookup of the NodePool resolved to.
+	// +optional
+	AMI string `json:"ami,omitempty"`
+
+	// AMILookupHash identifies the lookup, release, architecture and region the
+	// AMI was resolved for. The lookup is resolved again once it changes.
+	// +optional
+	AMILookupHash string `json:"amiLookupHash,omitempty"`
+
+	// DedicatedSecurityGroupID is the id of the security group dedicated to
+	// the NodePool, once created.
+	// +optional
+	DedicatedSecurityGroupID string `json:"dedicatedSecurityGroupID,omitempty"`
 }
 
 // KubeVirtNodePoolStatus contains the KubeVirt platform statuses
@@ -990,6 +1593,43 @@ type KubeVirtNodePoolStatus struct {
 	// the same cluster and namespace as the Hosted Control Plane.
 	// +optional
 	Credentials *KubevirtPlatformCredentials `json:"credentials,omitempty"`
+
+	// InfraNamespace is the namespace of the infra cluster in which the KubeVirt virtual machines of the NodePool
+	// are created.
+	// +optional
+	InfraNamespace string `json:"infraNamespace,omitempty"`
+}
+
+// MachineDeletionHookPhase is the phase of the deletion of a Machine a hook delays.
+type MachineDeletionHookPhase string
+
+const (
+	// MachineDeletionHookPhasePreDrain delays draining the Node of the Machine.
+	MachineDeletionHookPhasePreDrain MachineDeletionHookPhase = "PreDrain"
+
+	// MachineDeletionHookPhasePreTerminate delays terminating the instance of the Machine, after its Node is drained
+	// and its volumes are detached.
+	MachineDeletionHookPhasePreTerminate MachineDeletionHookPhase = "PreTerminate"
+)
+
+// MachineDeletionHook is a Cluster API Machine deletion hook.
+// https://cluster-api.sigs.k8s.io/tasks/experimental-features/machine-deletion-phase-hooks
+type MachineDeletionHook struct {
+	// Name identifies the hook. It is the name of the hook annotation on the Machines of the NodePool,
+	// which its owner removes to let the deletion of a Machine proceed.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern:=`^([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]$`
+	Name string `json:"name"`
+
+	// Phase is the phase of the deletion of a Machine the hook delays.
+	// +kubebuilder:validation:Enum=PreDrain;PreTerminate
+	Phase MachineDeletionHookPhase `json:"phase"`
+
+	// Owner identifies the system responsible for removing the hook from a Machine being deleted.
+	// It is the value of the hook annotation.
+	// +kubebuilder:validation:MinLength=1
+	Owner string `json:"owner"`
 }
 
 // Taint is as v1 Core but without TimeAdded.
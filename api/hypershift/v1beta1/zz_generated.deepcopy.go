@@ -23,6 +23,7 @@ package v1beta1
 import (
 	configv1 "github.com/openshift/api/config/v1"
 	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -94,6 +95,31 @@ func (in *APIServerNetworking) DeepCopy() *APIServerNetworking {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSAMILookup) DeepCopyInto(out *AWSAMILookup) {
+	*out = *in
+	if in.Owners != nil {
+		in, out := &in.Owners, &out.Owners
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]AWSResourceTag, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSAMILookup.
+func (in *AWSAMILookup) DeepCopy() *AWSAMILookup {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSAMILookup)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AWSCloudProviderConfig) DeepCopyInto(out *AWSCloudProviderConfig) {
 	*out = *in
@@ -281,6 +307,11 @@ func (in *AWSKMSSpec) DeepCopy() *AWSKMSSpec {
 func (in *AWSNodePoolPlatform) DeepCopyInto(out *AWSNodePoolPlatform) {
 	*out = *in
 	in.Subnet.DeepCopyInto(&out.Subnet)
+	if in.AMILookup != nil {
+		in, out := &in.AMILookup, &out.AMILookup
+		*out = new(AWSAMILookup)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.SecurityGroups != nil {
 		in, out := &in.SecurityGroups, &out.SecurityGroups
 		*out = make([]AWSResourceReference, len(*in))
@@ -288,6 +319,11 @@ func (in *AWSNodePoolPlatform) DeepCopyInto(out *AWSNodePoolPlatform) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DedicatedSecurityGroup != nil {
+		in, out := &in.DedicatedSecurityGroup, &out.DedicatedSecurityGroup
+		*out = new(AWSNodePoolSecurityGroup)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.RootVolume != nil {
 		in, out := &in.RootVolume, &out.RootVolume
 		*out = new(Volume)
@@ -310,6 +346,43 @@ func (in *AWSNodePoolPlatform) DeepCopy() *AWSNodePoolPlatform {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSNodePoolSecurityGroup) DeepCopyInto(out *AWSNodePoolSecurityGroup) {
+	*out = *in
+	if in.IngressRules != nil {
+		in, out := &in.IngressRules, &out.IngressRules
+		*out = make([]AWSSecurityGroupIngressRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSNodePoolSecurityGroup.
+func (in *AWSNodePoolSecurityGroup) DeepCopy() *AWSNodePoolSecurityGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSNodePoolSecurityGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSNodePoolStatus) DeepCopyInto(out *AWSNodePoolStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSNodePoolStatus.
+func (in *AWSNodePoolStatus) DeepCopy() *AWSNodePoolStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSNodePoolStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AWSPlatformSpec) DeepCopyInto(out *AWSPlatformSpec) {
 	*out = *in
@@ -433,6 +506,51 @@ func (in *AWSRolesRef) DeepCopy() *AWSRolesRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSSecretsManagerKubeconfigStoreSpec) DeepCopyInto(out *AWSSecretsManagerKubeconfigStoreSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSSecretsManagerKubeconfigStoreSpec.
+func (in *AWSSecretsManagerKubeconfigStoreSpec) DeepCopy() *AWSSecretsManagerKubeconfigStoreSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSSecretsManagerKubeconfigStoreSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSSecurityGroupIngressRule) DeepCopyInto(out *AWSSecurityGroupIngressRule) {
+	*out = *in
+	if in.FromPort != nil {
+		in, out := &in.FromPort, &out.FromPort
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ToPort != nil {
+		in, out := &in.ToPort, &out.ToPort
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CIDRBlocks != nil {
+		in, out := &in.CIDRBlocks, &out.CIDRBlocks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSSecurityGroupIngressRule.
+func (in *AWSSecurityGroupIngressRule) DeepCopy() *AWSSecurityGroupIngressRule {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSSecurityGroupIngressRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AWSServiceEndpoint) DeepCopyInto(out *AWSServiceEndpoint) {
 	*out = *in
@@ -519,9 +637,29 @@ func (in *AzureKMSSpec) DeepCopy() *AzureKMSSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureKeyVaultKubeconfigStoreSpec) DeepCopyInto(out *AzureKeyVaultKubeconfigStoreSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureKeyVaultKubeconfigStoreSpec.
+func (in *AzureKeyVaultKubeconfigStoreSpec) DeepCopy() *AzureKeyVaultKubeconfigStoreSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureKeyVaultKubeconfigStoreSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AzureNodePoolPlatform) DeepCopyInto(out *AzureNodePoolPlatform) {
 	*out = *in
+	if in.SecurityProfile != nil {
+		in, out := &in.SecurityProfile, &out.SecurityProfile
+		*out = new(AzureVMSecurityProfile)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureNodePoolPlatform.
@@ -534,10 +672,30 @@ func (in *AzureNodePoolPlatform) DeepCopy() *AzureNodePoolPlatform {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureNodePoolStatus) DeepCopyInto(out *AzureNodePoolStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureNodePoolStatus.
+func (in *AzureNodePoolStatus) DeepCopy() *AzureNodePoolStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureNodePoolStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AzurePlatformSpec) DeepCopyInto(out *AzurePlatformSpec) {
 	*out = *in
 	out.Credentials = in.Credentials
+	if in.ResourceTags != nil {
+		in, out := &in.ResourceTags, &out.ResourceTags
+		*out = make([]AzureResourceTag, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzurePlatformSpec.
@@ -550,6 +708,105 @@ func (in *AzurePlatformSpec) DeepCopy() *AzurePlatformSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureResourceTag) DeepCopyInto(out *AzureResourceTag) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureResourceTag.
+func (in *AzureResourceTag) DeepCopy() *AzureResourceTag {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureResourceTag)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureVMSecurityProfile) DeepCopyInto(out *AzureVMSecurityProfile) {
+	*out = *in
+	if in.SecureBoot != nil {
+		in, out := &in.SecureBoot, &out.SecureBoot
+		*out = new(bool)
+		**out = **in
+	}
+	if in.VTPM != nil {
+		in, out := &in.VTPM, &out.VTPM
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureVMSecurityProfile.
+func (in *AzureVMSecurityProfile) DeepCopy() *AzureVMSecurityProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureVMSecurityProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CSIStorageClass) DeepCopyInto(out *CSIStorageClass) {
+	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ReclaimPolicy != nil {
+		in, out := &in.ReclaimPolicy, &out.ReclaimPolicy
+		*out = new(corev1.PersistentVolumeReclaimPolicy)
+		**out = **in
+	}
+	if in.VolumeBindingMode != nil {
+		in, out := &in.VolumeBindingMode, &out.VolumeBindingMode
+		*out = new(storagev1.VolumeBindingMode)
+		**out = **in
+	}
+	if in.AllowVolumeExpansion != nil {
+		in, out := &in.AllowVolumeExpansion, &out.AllowVolumeExpansion
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CSIStorageClass.
+func (in *CSIStorageClass) DeepCopy() *CSIStorageClass {
+	if in == nil {
+		return nil
+	}
+	out := new(CSIStorageClass)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CSIVolumeSnapshotClass) DeepCopyInto(out *CSIVolumeSnapshotClass) {
+	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CSIVolumeSnapshotClass.
+func (in *CSIVolumeSnapshotClass) DeepCopy() *CSIVolumeSnapshotClass {
+	if in == nil {
+		return nil
+	}
+	out := new(CSIVolumeSnapshotClass)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CertificateSigningRequestApproval) DeepCopyInto(out *CertificateSigningRequestApproval) {
 	*out = *in
@@ -791,6 +1048,35 @@ func (in *ClusterNetworking) DeepCopy() *ClusterNetworking {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterStorageSpec) DeepCopyInto(out *ClusterStorageSpec) {
+	*out = *in
+	if in.StorageClasses != nil {
+		in, out := &in.StorageClasses, &out.StorageClasses
+		*out = make([]CSIStorageClass, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VolumeSnapshotClasses != nil {
+		in, out := &in.VolumeSnapshotClasses, &out.VolumeSnapshotClasses
+		*out = make([]CSIVolumeSnapshotClass, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStorageSpec.
+func (in *ClusterStorageSpec) DeepCopy() *ClusterStorageSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterStorageSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterVersionStatus) DeepCopyInto(out *ClusterVersionStatus) {
 	*out = *in
@@ -828,6 +1114,21 @@ func (in *ClusterVersionStatus) DeepCopy() *ClusterVersionStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomDomainPublishingStrategy) DeepCopyInto(out *CustomDomainPublishingStrategy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomDomainPublishingStrategy.
+func (in *CustomDomainPublishingStrategy) DeepCopy() *CustomDomainPublishingStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomDomainPublishingStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DNSSpec) DeepCopyInto(out *DNSSpec) {
 	*out = *in
@@ -848,6 +1149,75 @@ func (in *DNSSpec) DeepCopy() *DNSSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataPlaneInfrastructurePlacement) DeepCopyInto(out *DataPlaneInfrastructurePlacement) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataPlaneInfrastructurePlacement.
+func (in *DataPlaneInfrastructurePlacement) DeepCopy() *DataPlaneInfrastructurePlacement {
+	if in == nil {
+		return nil
+	}
+	out := new(DataPlaneInfrastructurePlacement)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DefaultIngressControllerSpec) DeepCopyInto(out *DefaultIngressControllerSpec) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DefaultIngressControllerSpec.
+func (in *DefaultIngressControllerSpec) DeepCopy() *DefaultIngressControllerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DefaultIngressControllerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeletionPolicy) DeepCopyInto(out *DeletionPolicy) {
+	*out = *in
+	if in.ForceTimeout != nil {
+		in, out := &in.ForceTimeout, &out.ForceTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeletionPolicy.
+func (in *DeletionPolicy) DeepCopy() *DeletionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(DeletionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EtcdSpec) DeepCopyInto(out *EtcdSpec) {
 	*out = *in
@@ -937,7 +1307,53 @@ func (in *HostedCluster) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HostedClusterList) DeepCopyInto(out *HostedClusterList) {
+func (in *HostedClusterComponentStatus) DeepCopyInto(out *HostedClusterComponentStatus) {
+	*out = *in
+	if in.DesiredReplicas != nil {
+		in, out := &in.DesiredReplicas, &out.DesiredReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ReadyReplicas != nil {
+		in, out := &in.ReadyReplicas, &out.ReadyReplicas
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostedClusterComponentStatus.
+func (in *HostedClusterComponentStatus) DeepCopy() *HostedClusterComponentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HostedClusterComponentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostedClusterLifetime) DeepCopyInto(out *HostedClusterLifetime) {
+	*out = *in
+	out.TTL = in.TTL
+	if in.ExpiryWarning != nil {
+		in, out := &in.ExpiryWarning, &out.ExpiryWarning
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostedClusterLifetime.
+func (in *HostedClusterLifetime) DeepCopy() *HostedClusterLifetime {
+	if in == nil {
+		return nil
+	}
+	out := new(HostedClusterLifetime)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostedClusterList) DeepCopyInto(out *HostedClusterList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
@@ -1028,6 +1444,11 @@ func (in *HostedClusterSpec) DeepCopyInto(out *HostedClusterSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.OLMCatalogs != nil {
+		in, out := &in.OLMCatalogs, &out.OLMCatalogs
+		*out = new(OLMCatalogs)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.NodeSelector != nil {
 		in, out := &in.NodeSelector, &out.NodeSelector
 		*out = make(map[string]string, len(*in))
@@ -1035,6 +1456,60 @@ func (in *HostedClusterSpec) DeepCopyInto(out *HostedClusterSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.DataPlaneInfrastructurePlacement != nil {
+		in, out := &in.DataPlaneInfrastructurePlacement, &out.DataPlaneInfrastructurePlacement
+		*out = new(DataPlaneInfrastructurePlacement)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DefaultIngressController != nil {
+		in, out := &in.DefaultIngressController, &out.DefaultIngressController
+		*out = new(DefaultIngressControllerSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ImageVerification != nil {
+		in, out := &in.ImageVerification, &out.ImageVerification
+		*out = new(ImageVerificationPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DeletionPolicy != nil {
+		in, out := &in.DeletionPolicy, &out.DeletionPolicy
+		*out = new(DeletionPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Lifetime != nil {
+		in, out := &in.Lifetime, &out.Lifetime
+		*out = new(HostedClusterLifetime)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KubeconfigPublishing != nil {
+		in, out := &in.KubeconfigPublishing, &out.KubeconfigPublishing
+		*out = new(KubeconfigPublishingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretsBackup != nil {
+		in, out := &in.SecretsBackup, &out.SecretsBackup
+		*out = new(SecretsBackupSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		*out = new(ClusterStorageSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostedClusterSpec.
@@ -1078,6 +1553,29 @@ func (in *HostedClusterStatus) DeepCopyInto(out *HostedClusterStatus) {
 		*out = new(PlatformStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ComponentStatuses != nil {
+		in, out := &in.ComponentStatuses, &out.ComponentStatuses
+		*out = make([]HostedClusterComponentStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PublishedKubeconfigs != nil {
+		in, out := &in.PublishedKubeconfigs, &out.PublishedKubeconfigs
+		*out = make([]PublishedKubeconfigStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SecretsBackup != nil {
+		in, out := &in.SecretsBackup, &out.SecretsBackup
+		*out = new(SecretsBackupStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExpirationTime != nil {
+		in, out := &in.ExpirationTime, &out.ExpirationTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostedClusterStatus.
@@ -1212,6 +1710,11 @@ func (in *HostedControlPlaneSpec) DeepCopyInto(out *HostedControlPlaneSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.OLMCatalogs != nil {
+		in, out := &in.OLMCatalogs, &out.OLMCatalogs
+		*out = new(OLMCatalogs)
+		(*in).DeepCopyInto(*out)
+	}
 	in.Autoscaling.DeepCopyInto(&out.Autoscaling)
 	if in.NodeSelector != nil {
 		in, out := &in.NodeSelector, &out.NodeSelector
@@ -1220,6 +1723,35 @@ func (in *HostedControlPlaneSpec) DeepCopyInto(out *HostedControlPlaneSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.DataPlaneInfrastructurePlacement != nil {
+		in, out := &in.DataPlaneInfrastructurePlacement, &out.DataPlaneInfrastructurePlacement
+		*out = new(DataPlaneInfrastructurePlacement)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DefaultIngressController != nil {
+		in, out := &in.DefaultIngressController, &out.DefaultIngressController
+		*out = new(DefaultIngressControllerSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		*out = new(ClusterStorageSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostedControlPlaneSpec.
@@ -1277,6 +1809,11 @@ func (in *HostedControlPlaneStatus) DeepCopyInto(out *HostedControlPlaneStatus)
 		*out = new(int)
 		**out = **in
 	}
+	if in.ControlPlaneStartupDuration != nil {
+		in, out := &in.ControlPlaneStartupDuration, &out.ControlPlaneStartupDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostedControlPlaneStatus.
@@ -1416,6 +1953,27 @@ func (in *ImageContentSource) DeepCopy() *ImageContentSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageVerificationPolicy) DeepCopyInto(out *ImageVerificationPolicy) {
+	*out = *in
+	out.PublicKeys = in.PublicKeys
+	if in.SignatureStores != nil {
+		in, out := &in.SignatureStores, &out.SignatureStores
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageVerificationPolicy.
+func (in *ImageVerificationPolicy) DeepCopy() *ImageVerificationPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageVerificationPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InPlaceUpgrade) DeepCopyInto(out *InPlaceUpgrade) {
 	*out = *in
@@ -1486,6 +2044,28 @@ func (in *KubeVirtNodePoolStatus) DeepCopy() *KubeVirtNodePoolStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeconfigPublishingSpec) DeepCopyInto(out *KubeconfigPublishingSpec) {
+	*out = *in
+	in.Store.DeepCopyInto(&out.Store)
+	out.Credentials = in.Credentials
+	if in.AdditionalKubeconfigs != nil {
+		in, out := &in.AdditionalKubeconfigs, &out.AdditionalKubeconfigs
+		*out = make([]PublishedKubeconfig, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeconfigPublishingSpec.
+func (in *KubeconfigPublishingSpec) DeepCopy() *KubeconfigPublishingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeconfigPublishingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubeconfigSecretRef) DeepCopyInto(out *KubeconfigSecretRef) {
 	*out = *in
@@ -1501,6 +2081,36 @@ func (in *KubeconfigSecretRef) DeepCopy() *KubeconfigSecretRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeconfigStore) DeepCopyInto(out *KubeconfigStore) {
+	*out = *in
+	if in.AWSSecretsManager != nil {
+		in, out := &in.AWSSecretsManager, &out.AWSSecretsManager
+		*out = new(AWSSecretsManagerKubeconfigStoreSpec)
+		**out = **in
+	}
+	if in.AzureKeyVault != nil {
+		in, out := &in.AzureKeyVault, &out.AzureKeyVault
+		*out = new(AzureKeyVaultKubeconfigStoreSpec)
+		**out = **in
+	}
+	if in.Vault != nil {
+		in, out := &in.Vault, &out.Vault
+		*out = new(VaultKubeconfigStoreSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeconfigStore.
+func (in *KubeconfigStore) DeepCopy() *KubeconfigStore {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeconfigStore)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubevirtCachingStrategy) DeepCopyInto(out *KubevirtCachingStrategy) {
 	*out = *in
@@ -1738,6 +2348,26 @@ func (in *KubevirtPlatformSpec) DeepCopy() *KubevirtPlatformSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubevirtPlatformStatus) DeepCopyInto(out *KubevirtPlatformStatus) {
+	*out = *in
+	if in.InfraNamespaces != nil {
+		in, out := &in.InfraNamespaces, &out.InfraNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubevirtPlatformStatus.
+func (in *KubevirtPlatformStatus) DeepCopy() *KubevirtPlatformStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KubevirtPlatformStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubevirtRootVolume) DeepCopyInto(out *KubevirtRootVolume) {
 	*out = *in
@@ -1849,6 +2479,21 @@ func (in *LoadBalancerPublishingStrategy) DeepCopy() *LoadBalancerPublishingStra
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineDeletionHook) DeepCopyInto(out *MachineDeletionHook) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineDeletionHook.
+func (in *MachineDeletionHook) DeepCopy() *MachineDeletionHook {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineDeletionHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MachineNetworkEntry) DeepCopyInto(out *MachineNetworkEntry) {
 	*out = *in
@@ -1865,10 +2510,40 @@ func (in *MachineNetworkEntry) DeepCopy() *MachineNetworkEntry {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedEtcdDisruptionSpec) DeepCopyInto(out *ManagedEtcdDisruptionSpec) {
+	*out = *in
+	if in.TerminationGracePeriodSeconds != nil {
+		in, out := &in.TerminationGracePeriodSeconds, &out.TerminationGracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.PreStopChecks != nil {
+		in, out := &in.PreStopChecks, &out.PreStopChecks
+		*out = make([]EtcdPreStopCheck, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedEtcdDisruptionSpec.
+func (in *ManagedEtcdDisruptionSpec) DeepCopy() *ManagedEtcdDisruptionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedEtcdDisruptionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ManagedEtcdSpec) DeepCopyInto(out *ManagedEtcdSpec) {
 	*out = *in
 	in.Storage.DeepCopyInto(&out.Storage)
+	if in.Disruption != nil {
+		in, out := &in.Disruption, &out.Disruption
+		*out = new(ManagedEtcdDisruptionSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedEtcdSpec.
@@ -1948,6 +2623,76 @@ func (in *NodePoolAutoScaling) DeepCopy() *NodePoolAutoScaling {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePoolBootstrap) DeepCopyInto(out *NodePoolBootstrap) {
+	*out = *in
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(NodePoolBootstrapProxy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdditionalTrustBundle != nil {
+		in, out := &in.AdditionalTrustBundle, &out.AdditionalTrustBundle
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePoolBootstrap.
+func (in *NodePoolBootstrap) DeepCopy() *NodePoolBootstrap {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePoolBootstrap)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePoolBootstrapProxy) DeepCopyInto(out *NodePoolBootstrapProxy) {
+	*out = *in
+	if in.NoProxy != nil {
+		in, out := &in.NoProxy, &out.NoProxy
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePoolBootstrapProxy.
+func (in *NodePoolBootstrapProxy) DeepCopy() *NodePoolBootstrapProxy {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePoolBootstrapProxy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePoolCapacityStatus) DeepCopyInto(out *NodePoolCapacityStatus) {
+	*out = *in
+	if in.CPU != nil {
+		in, out := &in.CPU, &out.CPU
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Memory != nil {
+		in, out := &in.Memory, &out.Memory
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePoolCapacityStatus.
+func (in *NodePoolCapacityStatus) DeepCopy() *NodePoolCapacityStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePoolCapacityStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodePoolCondition) DeepCopyInto(out *NodePoolCondition) {
 	*out = *in
@@ -1964,6 +2709,31 @@ func (in *NodePoolCondition) DeepCopy() *NodePoolCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePoolContainerRuntime) DeepCopyInto(out *NodePoolContainerRuntime) {
+	*out = *in
+	if in.PidsLimit != nil {
+		in, out := &in.PidsLimit, &out.PidsLimit
+		*out = new(int64)
+		**out = **in
+	}
+	if in.LogSizeMax != nil {
+		in, out := &in.LogSizeMax, &out.LogSizeMax
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePoolContainerRuntime.
+func (in *NodePoolContainerRuntime) DeepCopy() *NodePoolContainerRuntime {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePoolContainerRuntime)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodePoolList) DeepCopyInto(out *NodePoolList) {
 	*out = *in
@@ -1996,6 +2766,21 @@ func (in *NodePoolList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePoolMachineStatus) DeepCopyInto(out *NodePoolMachineStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePoolMachineStatus.
+func (in *NodePoolMachineStatus) DeepCopy() *NodePoolMachineStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePoolMachineStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodePoolManagement) DeepCopyInto(out *NodePoolManagement) {
 	*out = *in
@@ -2009,6 +2794,16 @@ func (in *NodePoolManagement) DeepCopyInto(out *NodePoolManagement) {
 		*out = new(InPlaceUpgrade)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(NodePoolRollout)
+		**out = **in
+	}
+	if in.MaxNodeLifetime != nil {
+		in, out := &in.MaxNodeLifetime, &out.MaxNodeLifetime
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePoolManagement.
@@ -2021,6 +2816,21 @@ func (in *NodePoolManagement) DeepCopy() *NodePoolManagement {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePoolOSImage) DeepCopyInto(out *NodePoolOSImage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePoolOSImage.
+func (in *NodePoolOSImage) DeepCopy() *NodePoolOSImage {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePoolOSImage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodePoolPlatform) DeepCopyInto(out *NodePoolPlatform) {
 	*out = *in
@@ -2047,7 +2857,7 @@ func (in *NodePoolPlatform) DeepCopyInto(out *NodePoolPlatform) {
 	if in.Azure != nil {
 		in, out := &in.Azure, &out.Azure
 		*out = new(AzureNodePoolPlatform)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.PowerVS != nil {
 		in, out := &in.PowerVS, &out.PowerVS
@@ -2074,6 +2884,16 @@ func (in *NodePoolPlatformStatus) DeepCopyInto(out *NodePoolPlatformStatus) {
 		*out = new(KubeVirtNodePoolStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AWS != nil {
+		in, out := &in.AWS, &out.AWS
+		*out = new(AWSNodePoolStatus)
+		**out = **in
+	}
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(AzureNodePoolStatus)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePoolPlatformStatus.
@@ -2086,6 +2906,21 @@ func (in *NodePoolPlatformStatus) DeepCopy() *NodePoolPlatformStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePoolRollout) DeepCopyInto(out *NodePoolRollout) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePoolRollout.
+func (in *NodePoolRollout) DeepCopy() *NodePoolRollout {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePoolRollout)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodePoolSpec) DeepCopyInto(out *NodePoolSpec) {
 	*out = *in
@@ -2124,6 +2959,11 @@ func (in *NodePoolSpec) DeepCopyInto(out *NodePoolSpec) {
 		*out = make([]Taint, len(*in))
 		copy(*out, *in)
 	}
+	if in.MachineDeletionHooks != nil {
+		in, out := &in.MachineDeletionHooks, &out.MachineDeletionHooks
+		*out = make([]MachineDeletionHook, len(*in))
+		copy(*out, *in)
+	}
 	if in.PausedUntil != nil {
 		in, out := &in.PausedUntil, &out.PausedUntil
 		*out = new(string)
@@ -2134,6 +2974,31 @@ func (in *NodePoolSpec) DeepCopyInto(out *NodePoolSpec) {
 		*out = make([]corev1.LocalObjectReference, len(*in))
 		copy(*out, *in)
 	}
+	if in.AdditionalPullSecrets != nil {
+		in, out := &in.AdditionalPullSecrets, &out.AdditionalPullSecrets
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.NTPServers != nil {
+		in, out := &in.NTPServers, &out.NTPServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ContainerRuntime != nil {
+		in, out := &in.ContainerRuntime, &out.ContainerRuntime
+		*out = new(NodePoolContainerRuntime)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Bootstrap != nil {
+		in, out := &in.Bootstrap, &out.Bootstrap
+		*out = new(NodePoolBootstrap)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OSImage != nil {
+		in, out := &in.OSImage, &out.OSImage
+		*out = new(NodePoolOSImage)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePoolSpec.
@@ -2154,6 +3019,16 @@ func (in *NodePoolStatus) DeepCopyInto(out *NodePoolStatus) {
 		*out = new(NodePoolPlatformStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Capacity != nil {
+		in, out := &in.Capacity, &out.Capacity
+		*out = new(NodePoolCapacityStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Machines != nil {
+		in, out := &in.Machines, &out.Machines
+		*out = make([]NodePoolMachineStatus, len(*in))
+		copy(*out, *in)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]NodePoolCondition, len(*in))
@@ -2188,6 +3063,41 @@ func (in *NodePortPublishingStrategy) DeepCopy() *NodePortPublishingStrategy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OLMCatalogSource) DeepCopyInto(out *OLMCatalogSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OLMCatalogSource.
+func (in *OLMCatalogSource) DeepCopy() *OLMCatalogSource {
+	if in == nil {
+		return nil
+	}
+	out := new(OLMCatalogSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OLMCatalogs) DeepCopyInto(out *OLMCatalogs) {
+	*out = *in
+	if in.CatalogSources != nil {
+		in, out := &in.CatalogSources, &out.CatalogSources
+		*out = make([]OLMCatalogSource, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OLMCatalogs.
+func (in *OLMCatalogs) DeepCopy() *OLMCatalogs {
+	if in == nil {
+		return nil
+	}
+	out := new(OLMCatalogs)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PersistentVolumeEtcdStorageSpec) DeepCopyInto(out *PersistentVolumeEtcdStorageSpec) {
 	*out = *in
@@ -2234,7 +3144,7 @@ func (in *PlatformSpec) DeepCopyInto(out *PlatformSpec) {
 	if in.Azure != nil {
 		in, out := &in.Azure, &out.Azure
 		*out = new(AzurePlatformSpec)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.PowerVS != nil {
 		in, out := &in.PowerVS, &out.PowerVS
@@ -2266,6 +3176,11 @@ func (in *PlatformStatus) DeepCopyInto(out *PlatformStatus) {
 		*out = new(AWSPlatformStatus)
 		**out = **in
 	}
+	if in.Kubevirt != nil {
+		in, out := &in.Kubevirt, &out.Kubevirt
+		*out = new(KubevirtPlatformStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlatformStatus.
@@ -2369,6 +3284,38 @@ func (in *PowerVSVPC) DeepCopy() *PowerVSVPC {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PublishedKubeconfig) DeepCopyInto(out *PublishedKubeconfig) {
+	*out = *in
+	out.Secret = in.Secret
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublishedKubeconfig.
+func (in *PublishedKubeconfig) DeepCopy() *PublishedKubeconfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PublishedKubeconfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PublishedKubeconfigStatus) DeepCopyInto(out *PublishedKubeconfigStatus) {
+	*out = *in
+	in.LastPublishedTime.DeepCopyInto(&out.LastPublishedTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublishedKubeconfigStatus.
+func (in *PublishedKubeconfigStatus) DeepCopy() *PublishedKubeconfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PublishedKubeconfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Release) DeepCopyInto(out *Release) {
 	*out = *in
@@ -2444,6 +3391,21 @@ func (in *RoutePublishingStrategy) DeepCopy() *RoutePublishingStrategy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *S3SecretsBackupStoreSpec) DeepCopyInto(out *S3SecretsBackupStoreSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3SecretsBackupStoreSpec.
+func (in *S3SecretsBackupStoreSpec) DeepCopy() *S3SecretsBackupStoreSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(S3SecretsBackupStoreSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecretEncryptionSpec) DeepCopyInto(out *SecretEncryptionSpec) {
 	*out = *in
@@ -2469,6 +3431,64 @@ func (in *SecretEncryptionSpec) DeepCopy() *SecretEncryptionSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretsBackupSpec) DeepCopyInto(out *SecretsBackupSpec) {
+	*out = *in
+	in.Store.DeepCopyInto(&out.Store)
+	out.Credentials = in.Credentials
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretsBackupSpec.
+func (in *SecretsBackupSpec) DeepCopy() *SecretsBackupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretsBackupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretsBackupStatus) DeepCopyInto(out *SecretsBackupStatus) {
+	*out = *in
+	in.LastBackupTime.DeepCopyInto(&out.LastBackupTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretsBackupStatus.
+func (in *SecretsBackupStatus) DeepCopy() *SecretsBackupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretsBackupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretsBackupStore) DeepCopyInto(out *SecretsBackupStore) {
+	*out = *in
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(S3SecretsBackupStoreSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretsBackupStore.
+func (in *SecretsBackupStore) DeepCopy() *SecretsBackupStore {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretsBackupStore)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceNetworkEntry) DeepCopyInto(out *ServiceNetworkEntry) {
 	*out = *in
@@ -2503,6 +3523,11 @@ func (in *ServicePublishingStrategy) DeepCopyInto(out *ServicePublishingStrategy
 		*out = new(RoutePublishingStrategy)
 		**out = **in
 	}
+	if in.CustomDomain != nil {
+		in, out := &in.CustomDomain, &out.CustomDomain
+		*out = new(CustomDomainPublishingStrategy)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServicePublishingStrategy.
@@ -2562,6 +3587,21 @@ func (in *UnmanagedEtcdSpec) DeepCopy() *UnmanagedEtcdSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultKubeconfigStoreSpec) DeepCopyInto(out *VaultKubeconfigStoreSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultKubeconfigStoreSpec.
+func (in *VaultKubeconfigStoreSpec) DeepCopy() *VaultKubeconfigStoreSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultKubeconfigStoreSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Volume) DeepCopyInto(out *Volume) {
 	*out = *in
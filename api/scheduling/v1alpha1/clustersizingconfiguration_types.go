@@ -1,6 +1,7 @@
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -112,6 +113,18 @@ type Effects struct {
 	// KASGoMemLimit is the value to set for the $GOMEMLIMIT of the Kube APIServer container
 	KASGoMemLimit *resource.Quantity `json:"kasGoMemLimit,omitempty"`
 
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+
+	// KASMaxRequestsInflight is the limit of concurrent non-mutating requests of the Kube APIServer
+	KASMaxRequestsInflight *int32 `json:"kasMaxRequestsInflight,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+
+	// KASMaxMutatingRequestsInflight is the limit of concurrent mutating requests of the Kube APIServer
+	KASMaxMutatingRequestsInflight *int32 `json:"kasMaxMutatingRequestsInflight,omitempty"`
+
 	// +kubebuilder:validation:Optional
 
 	// ControlPlanePriorityClassName is the priority class to use for most control plane pods
@@ -149,6 +162,37 @@ type Management struct {
 	// a single node. The total number of nodes needed per HostedCluster is this number multiplied by 3
 	// (number of zones).
 	NonRequestServingNodesPerZone *resource.Quantity `json:"nonRequestServingNodesPerZone,omitempty"`
+
+	// +kubebuilder:validation:Optional
+
+	// ControlPlaneResourceQuota configures a ResourceQuota in the control plane namespace of every
+	// HostedCluster in this size class, bounding the aggregate resources its control plane may consume
+	// on the management cluster. When unset, no ResourceQuota is managed.
+	ControlPlaneResourceQuota *ControlPlaneResourceQuota `json:"controlPlaneResourceQuota,omitempty"`
+
+	// +kubebuilder:validation:Optional
+
+	// ControlPlaneLimitRange configures a LimitRange in the control plane namespace of every
+	// HostedCluster in this size class, bounding and defaulting the resources of individual
+	// control plane containers. When unset, no LimitRange is managed.
+	ControlPlaneLimitRange *ControlPlaneLimitRange `json:"controlPlaneLimitRange,omitempty"`
+}
+
+// ControlPlaneResourceQuota defines the ResourceQuota enforced in a control plane namespace.
+type ControlPlaneResourceQuota struct {
+	// +kubebuilder:validation:Required
+
+	// Hard is the set of desired hard limits for each named resource.
+	Hard corev1.ResourceList `json:"hard"`
+}
+
+// ControlPlaneLimitRange defines the LimitRange enforced in a control plane namespace.
+type ControlPlaneLimitRange struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+
+	// Limits is the list of LimitRangeItem objects that are enforced.
+	Limits []corev1.LimitRangeItem `json:"limits"`
 }
 
 // ConcurrencyConfiguration defines bounds for the concurrency of clusters transitioning between states.
@@ -21,7 +21,8 @@ limitations under the License.
 package v1alpha1
 
 import (
-	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -118,7 +119,7 @@ func (in *ClusterSizingConfigurationStatus) DeepCopyInto(out *ClusterSizingConfi
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
+		*out = make([]metav1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -151,6 +152,50 @@ func (in *ConcurrencyConfiguration) DeepCopy() *ConcurrencyConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneLimitRange) DeepCopyInto(out *ControlPlaneLimitRange) {
+	*out = *in
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = make([]v1.LimitRangeItem, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneLimitRange.
+func (in *ControlPlaneLimitRange) DeepCopy() *ControlPlaneLimitRange {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlaneLimitRange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneResourceQuota) DeepCopyInto(out *ControlPlaneResourceQuota) {
+	*out = *in
+	if in.Hard != nil {
+		in, out := &in.Hard, &out.Hard
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneResourceQuota.
+func (in *ControlPlaneResourceQuota) DeepCopy() *ControlPlaneResourceQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlaneResourceQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Effects) DeepCopyInto(out *Effects) {
 	*out = *in
@@ -159,6 +204,16 @@ func (in *Effects) DeepCopyInto(out *Effects) {
 		x := (*in).DeepCopy()
 		*out = &x
 	}
+	if in.KASMaxRequestsInflight != nil {
+		in, out := &in.KASMaxRequestsInflight, &out.KASMaxRequestsInflight
+		*out = new(int32)
+		**out = **in
+	}
+	if in.KASMaxMutatingRequestsInflight != nil {
+		in, out := &in.KASMaxMutatingRequestsInflight, &out.KASMaxMutatingRequestsInflight
+		*out = new(int32)
+		**out = **in
+	}
 	if in.ControlPlanePriorityClassName != nil {
 		in, out := &in.ControlPlanePriorityClassName, &out.ControlPlanePriorityClassName
 		*out = new(string)
@@ -201,6 +256,16 @@ func (in *Management) DeepCopyInto(out *Management) {
 		x := (*in).DeepCopy()
 		*out = &x
 	}
+	if in.ControlPlaneResourceQuota != nil {
+		in, out := &in.ControlPlaneResourceQuota, &out.ControlPlaneResourceQuota
+		*out = new(ControlPlaneResourceQuota)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ControlPlaneLimitRange != nil {
+		in, out := &in.ControlPlaneLimitRange, &out.ControlPlaneLimitRange
+		*out = new(ControlPlaneLimitRange)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Management.
@@ -20,14 +20,16 @@ package v1alpha1
 // AzureNodePoolPlatformApplyConfiguration represents an declarative configuration of the AzureNodePoolPlatform type for use
 // with apply.
 type AzureNodePoolPlatformApplyConfiguration struct {
-	VMSize                 *string `json:"vmsize,omitempty"`
-	ImageID                *string `json:"imageID,omitempty"`
-	DiskSizeGB             *int32  `json:"diskSizeGB,omitempty"`
-	DiskStorageAccountType *string `json:"diskStorageAccountType,omitempty"`
-	AvailabilityZone       *string `json:"availabilityZone,omitempty"`
-	DiskEncryptionSetID    *string `json:"diskEncryptionSetID,omitempty"`
-	EnableEphemeralOSDisk  *bool   `json:"enableEphemeralOSDisk,omitempty"`
-	SubnetID               *string `json:"subnetID,omitempty"`
+	VMSize                      *string                                   `json:"vmsize,omitempty"`
+	ImageID                     *string                                   `json:"imageID,omitempty"`
+	DiskSizeGB                  *int32                                    `json:"diskSizeGB,omitempty"`
+	DiskStorageAccountType      *string                                   `json:"diskStorageAccountType,omitempty"`
+	AvailabilityZone            *string                                   `json:"availabilityZone,omitempty"`
+	DiskEncryptionSetID         *string                                   `json:"diskEncryptionSetID,omitempty"`
+	EnableEphemeralOSDisk       *bool                                     `json:"enableEphemeralOSDisk,omitempty"`
+	EnableAcceleratedNetworking *bool                                     `json:"enableAcceleratedNetworking,omitempty"`
+	SecurityProfile             *AzureVMSecurityProfileApplyConfiguration `json:"securityProfile,omitempty"`
+	SubnetID                    *string                                   `json:"subnetID,omitempty"`
 }
 
 // AzureNodePoolPlatformApplyConfiguration constructs an declarative configuration of the AzureNodePoolPlatform type for use with
@@ -92,6 +94,22 @@ func (b *AzureNodePoolPlatformApplyConfiguration) WithEnableEphemeralOSDisk(valu
 	return b
 }
 
+// WithEnableAcceleratedNetworking sets the EnableAcceleratedNetworking field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the EnableAcceleratedNetworking field is set to the value of the last call.
+func (b *AzureNodePoolPlatformApplyConfiguration) WithEnableAcceleratedNetworking(value bool) *AzureNodePoolPlatformApplyConfiguration {
+	b.EnableAcceleratedNetworking = &value
+	return b
+}
+
+// WithSecurityProfile sets the SecurityProfile field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SecurityProfile field is set to the value of the last call.
+func (b *AzureNodePoolPlatformApplyConfiguration) WithSecurityProfile(value *AzureVMSecurityProfileApplyConfiguration) *AzureNodePoolPlatformApplyConfiguration {
+	b.SecurityProfile = value
+	return b
+}
+
 // WithSubnetID sets the SubnetID field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the SubnetID field is set to the value of the last call.
@@ -24,15 +24,16 @@ import (
 // AzurePlatformSpecApplyConfiguration represents an declarative configuration of the AzurePlatformSpec type for use
 // with apply.
 type AzurePlatformSpecApplyConfiguration struct {
-	Credentials       *v1.LocalObjectReference `json:"credentials,omitempty"`
-	Cloud             *string                  `json:"cloud,omitempty"`
-	Location          *string                  `json:"location,omitempty"`
-	ResourceGroupName *string                  `json:"resourceGroup,omitempty"`
-	VnetID            *string                  `json:"vnetID,omitempty"`
-	SubnetID          *string                  `json:"subnetID,omitempty"`
-	SubscriptionID    *string                  `json:"subscriptionID,omitempty"`
-	MachineIdentityID *string                  `json:"machineIdentityID,omitempty"`
-	SecurityGroupID   *string                  `json:"securityGroupID,omitempty"`
+	Credentials       *v1.LocalObjectReference             `json:"credentials,omitempty"`
+	Cloud             *string                              `json:"cloud,omitempty"`
+	Location          *string                              `json:"location,omitempty"`
+	ResourceGroupName *string                              `json:"resourceGroup,omitempty"`
+	VnetID            *string                              `json:"vnetID,omitempty"`
+	SubnetID          *string                              `json:"subnetID,omitempty"`
+	SubscriptionID    *string                              `json:"subscriptionID,omitempty"`
+	MachineIdentityID *string                              `json:"machineIdentityID,omitempty"`
+	SecurityGroupID   *string                              `json:"securityGroupID,omitempty"`
+	ResourceTags      []AzureResourceTagApplyConfiguration `json:"resourceTags,omitempty"`
 }
 
 // AzurePlatformSpecApplyConfiguration constructs an declarative configuration of the AzurePlatformSpec type for use with
@@ -112,3 +113,16 @@ func (b *AzurePlatformSpecApplyConfiguration) WithSecurityGroupID(value string)
 	b.SecurityGroupID = &value
 	return b
 }
+
+// WithResourceTags adds the given value to the ResourceTags field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ResourceTags field.
+func (b *AzurePlatformSpecApplyConfiguration) WithResourceTags(values ...*AzureResourceTagApplyConfiguration) *AzurePlatformSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithResourceTags")
+		}
+		b.ResourceTags = append(b.ResourceTags, *values[i])
+	}
+	return b
+}
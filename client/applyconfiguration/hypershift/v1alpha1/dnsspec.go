@@ -17,13 +17,19 @@ limitations under the License.
 
 package v1alpha1
 
+import (
+	v1alpha1 "github.com/openshift/hypershift/api/hypershift/v1alpha1"
+)
+
 // DNSSpecApplyConfiguration represents an declarative configuration of the DNSSpec type for use
 // with apply.
 type DNSSpecApplyConfiguration struct {
-	BaseDomain       *string `json:"baseDomain,omitempty"`
-	BaseDomainPrefix *string `json:"baseDomainPrefix,omitempty"`
-	PublicZoneID     *string `json:"publicZoneID,omitempty"`
-	PrivateZoneID    *string `json:"privateZoneID,omitempty"`
+	BaseDomain       *string                     `json:"baseDomain,omitempty"`
+	BaseDomainPrefix *string                     `json:"baseDomainPrefix,omitempty"`
+	PublicZoneID     *string                     `json:"publicZoneID,omitempty"`
+	PrivateZoneID    *string                     `json:"privateZoneID,omitempty"`
+	ClusterDNSDomain *string                     `json:"clusterDNSDomain,omitempty"`
+	Publishing       *v1alpha1.DNSPublishingMode `json:"publishing,omitempty"`
 }
 
 // DNSSpecApplyConfiguration constructs an declarative configuration of the DNSSpec type for use with
@@ -63,3 +69,19 @@ func (b *DNSSpecApplyConfiguration) WithPrivateZoneID(value string) *DNSSpecAppl
 	b.PrivateZoneID = &value
 	return b
 }
+
+// WithClusterDNSDomain sets the ClusterDNSDomain field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ClusterDNSDomain field is set to the value of the last call.
+func (b *DNSSpecApplyConfiguration) WithClusterDNSDomain(value string) *DNSSpecApplyConfiguration {
+	b.ClusterDNSDomain = &value
+	return b
+}
+
+// WithPublishing sets the Publishing field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Publishing field is set to the value of the last call.
+func (b *DNSSpecApplyConfiguration) WithPublishing(value v1alpha1.DNSPublishingMode) *DNSSpecApplyConfiguration {
+	b.Publishing = &value
+	return b
+}
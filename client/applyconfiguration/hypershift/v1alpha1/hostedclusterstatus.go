@@ -19,20 +19,25 @@ package v1alpha1
 
 import (
 	v1 "k8s.io/api/core/v1"
+	apismetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	metav1 "k8s.io/client-go/applyconfigurations/meta/v1"
 )
 
 // HostedClusterStatusApplyConfiguration represents an declarative configuration of the HostedClusterStatus type for use
 // with apply.
 type HostedClusterStatusApplyConfiguration struct {
-	Version                  *ClusterVersionStatusApplyConfiguration `json:"version,omitempty"`
-	KubeConfig               *v1.LocalObjectReference                `json:"kubeconfig,omitempty"`
-	KubeadminPassword        *v1.LocalObjectReference                `json:"kubeadminPassword,omitempty"`
-	IgnitionEndpoint         *string                                 `json:"ignitionEndpoint,omitempty"`
-	ControlPlaneEndpoint     *APIEndpointApplyConfiguration          `json:"controlPlaneEndpoint,omitempty"`
-	OAuthCallbackURLTemplate *string                                 `json:"oauthCallbackURLTemplate,omitempty"`
-	Conditions               []metav1.ConditionApplyConfiguration    `json:"conditions,omitempty"`
-	Platform                 *PlatformStatusApplyConfiguration       `json:"platform,omitempty"`
+	Version                  *ClusterVersionStatusApplyConfiguration          `json:"version,omitempty"`
+	KubeConfig               *v1.LocalObjectReference                         `json:"kubeconfig,omitempty"`
+	KubeadminPassword        *v1.LocalObjectReference                         `json:"kubeadminPassword,omitempty"`
+	IgnitionEndpoint         *string                                          `json:"ignitionEndpoint,omitempty"`
+	ControlPlaneEndpoint     *APIEndpointApplyConfiguration                   `json:"controlPlaneEndpoint,omitempty"`
+	OAuthCallbackURLTemplate *string                                          `json:"oauthCallbackURLTemplate,omitempty"`
+	Conditions               []metav1.ConditionApplyConfiguration             `json:"conditions,omitempty"`
+	Platform                 *PlatformStatusApplyConfiguration                `json:"platform,omitempty"`
+	ComponentStatuses        []HostedClusterComponentStatusApplyConfiguration `json:"componentStatuses,omitempty"`
+	PublishedKubeconfigs     []PublishedKubeconfigStatusApplyConfiguration    `json:"publishedKubeconfigs,omitempty"`
+	SecretsBackup            *SecretsBackupStatusApplyConfiguration           `json:"secretsBackup,omitempty"`
+	ExpirationTime           *apismetav1.Time                                 `json:"expirationTime,omitempty"`
 }
 
 // HostedClusterStatusApplyConfiguration constructs an declarative configuration of the HostedClusterStatus type for use with
@@ -109,3 +114,45 @@ func (b *HostedClusterStatusApplyConfiguration) WithPlatform(value *PlatformStat
 	b.Platform = value
 	return b
 }
+
+// WithComponentStatuses adds the given value to the ComponentStatuses field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ComponentStatuses field.
+func (b *HostedClusterStatusApplyConfiguration) WithComponentStatuses(values ...*HostedClusterComponentStatusApplyConfiguration) *HostedClusterStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithComponentStatuses")
+		}
+		b.ComponentStatuses = append(b.ComponentStatuses, *values[i])
+	}
+	return b
+}
+
+// WithPublishedKubeconfigs adds the given value to the PublishedKubeconfigs field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the PublishedKubeconfigs field.
+func (b *HostedClusterStatusApplyConfiguration) WithPublishedKubeconfigs(values ...*PublishedKubeconfigStatusApplyConfiguration) *HostedClusterStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithPublishedKubeconfigs")
+		}
+		b.PublishedKubeconfigs = append(b.PublishedKubeconfigs, *values[i])
+	}
+	return b
+}
+
+// WithSecretsBackup sets the SecretsBackup field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SecretsBackup field is set to the value of the last call.
+func (b *HostedClusterStatusApplyConfiguration) WithSecretsBackup(value *SecretsBackupStatusApplyConfiguration) *HostedClusterStatusApplyConfiguration {
+	b.SecretsBackup = value
+	return b
+}
+
+// WithExpirationTime sets the ExpirationTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ExpirationTime field is set to the value of the last call.
+func (b *HostedClusterStatusApplyConfiguration) WithExpirationTime(value apismetav1.Time) *HostedClusterStatusApplyConfiguration {
+	b.ExpirationTime = &value
+	return b
+}
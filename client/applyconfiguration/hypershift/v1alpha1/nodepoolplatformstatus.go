@@ -21,6 +21,8 @@ package v1alpha1
 // with apply.
 type NodePoolPlatformStatusApplyConfiguration struct {
 	KubeVirt *KubeVirtNodePoolStatusApplyConfiguration `json:"kubeVirt,omitempty"`
+	AWS      *AWSNodePoolStatusApplyConfiguration      `json:"aws,omitempty"`
+	Azure    *AzureNodePoolStatusApplyConfiguration    `json:"azure,omitempty"`
 }
 
 // NodePoolPlatformStatusApplyConfiguration constructs an declarative configuration of the NodePoolPlatformStatus type for use with
@@ -36,3 +38,19 @@ func (b *NodePoolPlatformStatusApplyConfiguration) WithKubeVirt(value *KubeVirtN
 	b.KubeVirt = value
 	return b
 }
+
+// WithAWS sets the AWS field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AWS field is set to the value of the last call.
+func (b *NodePoolPlatformStatusApplyConfiguration) WithAWS(value *AWSNodePoolStatusApplyConfiguration) *NodePoolPlatformStatusApplyConfiguration {
+	b.AWS = value
+	return b
+}
+
+// WithAzure sets the Azure field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Azure field is set to the value of the last call.
+func (b *NodePoolPlatformStatusApplyConfiguration) WithAzure(value *AzureNodePoolStatusApplyConfiguration) *NodePoolPlatformStatusApplyConfiguration {
+	b.Azure = value
+	return b
+}
@@ -23,6 +23,9 @@ type NodePoolStatusApplyConfiguration struct {
 	Replicas   *int32                                    `json:"replicas,omitempty"`
 	Version    *string                                   `json:"version,omitempty"`
 	Platform   *NodePoolPlatformStatusApplyConfiguration `json:"platform,omitempty"`
+	Capacity   *NodePoolCapacityStatusApplyConfiguration `json:"capacity,omitempty"`
+	OSImage    *string                                   `json:"osImage,omitempty"`
+	Machines   []NodePoolMachineStatusApplyConfiguration `json:"machines,omitempty"`
 	Conditions []NodePoolConditionApplyConfiguration     `json:"conditions,omitempty"`
 }
 
@@ -56,6 +59,35 @@ func (b *NodePoolStatusApplyConfiguration) WithPlatform(value *NodePoolPlatformS
 	return b
 }
 
+// WithCapacity sets the Capacity field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Capacity field is set to the value of the last call.
+func (b *NodePoolStatusApplyConfiguration) WithCapacity(value *NodePoolCapacityStatusApplyConfiguration) *NodePoolStatusApplyConfiguration {
+	b.Capacity = value
+	return b
+}
+
+// WithOSImage sets the OSImage field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the OSImage field is set to the value of the last call.
+func (b *NodePoolStatusApplyConfiguration) WithOSImage(value string) *NodePoolStatusApplyConfiguration {
+	b.OSImage = &value
+	return b
+}
+
+// WithMachines adds the given value to the Machines field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Machines field.
+func (b *NodePoolStatusApplyConfiguration) WithMachines(values ...*NodePoolMachineStatusApplyConfiguration) *NodePoolStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithMachines")
+		}
+		b.Machines = append(b.Machines, *values[i])
+	}
+	return b
+}
+
 // WithConditions adds the given value to the Conditions field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, values provided by each call will be appended to the Conditions field.
@@ -20,7 +20,8 @@ package v1alpha1
 // PlatformStatusApplyConfiguration represents an declarative configuration of the PlatformStatus type for use
 // with apply.
 type PlatformStatusApplyConfiguration struct {
-	AWS *AWSPlatformStatusApplyConfiguration `json:"aws,omitempty"`
+	AWS      *AWSPlatformStatusApplyConfiguration      `json:"aws,omitempty"`
+	Kubevirt *KubevirtPlatformStatusApplyConfiguration `json:"kubevirt,omitempty"`
 }
 
 // PlatformStatusApplyConfiguration constructs an declarative configuration of the PlatformStatus type for use with
@@ -36,3 +37,11 @@ func (b *PlatformStatusApplyConfiguration) WithAWS(value *AWSPlatformStatusApply
 	b.AWS = value
 	return b
 }
+
+// WithKubevirt sets the Kubevirt field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Kubevirt field is set to the value of the last call.
+func (b *PlatformStatusApplyConfiguration) WithKubevirt(value *KubevirtPlatformStatusApplyConfiguration) *PlatformStatusApplyConfiguration {
+	b.Kubevirt = value
+	return b
+}
@@ -28,6 +28,7 @@ type ServicePublishingStrategyApplyConfiguration struct {
 	NodePort     *NodePortPublishingStrategyApplyConfiguration     `json:"nodePort,omitempty"`
 	LoadBalancer *LoadBalancerPublishingStrategyApplyConfiguration `json:"loadBalancer,omitempty"`
 	Route        *RoutePublishingStrategyApplyConfiguration        `json:"route,omitempty"`
+	CustomDomain *CustomDomainPublishingStrategyApplyConfiguration `json:"customDomain,omitempty"`
 }
 
 // ServicePublishingStrategyApplyConfiguration constructs an declarative configuration of the ServicePublishingStrategy type for use with
@@ -67,3 +68,11 @@ func (b *ServicePublishingStrategyApplyConfiguration) WithRoute(value *RoutePubl
 	b.Route = value
 	return b
 }
+
+// WithCustomDomain sets the CustomDomain field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CustomDomain field is set to the value of the last call.
+func (b *ServicePublishingStrategyApplyConfiguration) WithCustomDomain(value *CustomDomainPublishingStrategyApplyConfiguration) *ServicePublishingStrategyApplyConfiguration {
+	b.CustomDomain = value
+	return b
+}
@@ -78,6 +78,15 @@ func (b *ServicePublishingStrategyMappingApplyConfiguration) WithRoute(value *Ro
 	return b
 }
 
+// WithCustomDomain sets the CustomDomain field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CustomDomain field is set to the value of the last call.
+func (b *ServicePublishingStrategyMappingApplyConfiguration) WithCustomDomain(value *CustomDomainPublishingStrategyApplyConfiguration) *ServicePublishingStrategyMappingApplyConfiguration {
+	b.ensureServicePublishingStrategyApplyConfigurationExists()
+	b.CustomDomain = value
+	return b
+}
+
 func (b *ServicePublishingStrategyMappingApplyConfiguration) ensureServicePublishingStrategyApplyConfigurationExists() {
 	if b.ServicePublishingStrategyApplyConfiguration == nil {
 		b.ServicePublishingStrategyApplyConfiguration = &ServicePublishingStrategyApplyConfiguration{}
@@ -18,13 +18,15 @@ limitations under the License.
 package v1beta1
 
 import (
+	v1beta1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // AgentNodePoolPlatformApplyConfiguration represents an declarative configuration of the AgentNodePoolPlatform type for use
 // with apply.
 type AgentNodePoolPlatformApplyConfiguration struct {
-	AgentLabelSelector *v1.LabelSelector `json:"agentLabelSelector,omitempty"`
+	AgentLabelSelector *v1.LabelSelector                `json:"agentLabelSelector,omitempty"`
+	HostReprovisioning *v1beta1.AgentHostReprovisioning `json:"hostReprovisioning,omitempty"`
 }
 
 // AgentNodePoolPlatformApplyConfiguration constructs an declarative configuration of the AgentNodePoolPlatform type for use with
@@ -40,3 +42,11 @@ func (b *AgentNodePoolPlatformApplyConfiguration) WithAgentLabelSelector(value v
 	b.AgentLabelSelector = &value
 	return b
 }
+
+// WithHostReprovisioning sets the HostReprovisioning field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the HostReprovisioning field is set to the value of the last call.
+func (b *AgentNodePoolPlatformApplyConfiguration) WithHostReprovisioning(value v1beta1.AgentHostReprovisioning) *AgentNodePoolPlatformApplyConfiguration {
+	b.HostReprovisioning = &value
+	return b
+}
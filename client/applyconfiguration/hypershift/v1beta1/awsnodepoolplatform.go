@@ -20,13 +20,15 @@ package v1beta1
 // AWSNodePoolPlatformApplyConfiguration represents an declarative configuration of the AWSNodePoolPlatform type for use
 // with apply.
 type AWSNodePoolPlatformApplyConfiguration struct {
-	InstanceType    *string                                  `json:"instanceType,omitempty"`
-	InstanceProfile *string                                  `json:"instanceProfile,omitempty"`
-	Subnet          *AWSResourceReferenceApplyConfiguration  `json:"subnet,omitempty"`
-	AMI             *string                                  `json:"ami,omitempty"`
-	SecurityGroups  []AWSResourceReferenceApplyConfiguration `json:"securityGroups,omitempty"`
-	RootVolume      *VolumeApplyConfiguration                `json:"rootVolume,omitempty"`
-	ResourceTags    []AWSResourceTagApplyConfiguration       `json:"resourceTags,omitempty"`
+	InstanceType           *string                                     `json:"instanceType,omitempty"`
+	InstanceProfile        *string                                     `json:"instanceProfile,omitempty"`
+	Subnet                 *AWSResourceReferenceApplyConfiguration     `json:"subnet,omitempty"`
+	AMI                    *string                                     `json:"ami,omitempty"`
+	AMILookup              *AWSAMILookupApplyConfiguration             `json:"amiLookup,omitempty"`
+	SecurityGroups         []AWSResourceReferenceApplyConfiguration    `json:"securityGroups,omitempty"`
+	DedicatedSecurityGroup *AWSNodePoolSecurityGroupApplyConfiguration `json:"dedicatedSecurityGroup,omitempty"`
+	RootVolume             *VolumeApplyConfiguration                   `json:"rootVolume,omitempty"`
+	ResourceTags           []AWSResourceTagApplyConfiguration          `json:"resourceTags,omitempty"`
 }
 
 // AWSNodePoolPlatformApplyConfiguration constructs an declarative configuration of the AWSNodePoolPlatform type for use with
@@ -67,6 +69,14 @@ func (b *AWSNodePoolPlatformApplyConfiguration) WithAMI(value string) *AWSNodePo
 	return b
 }
 
+// WithAMILookup sets the AMILookup field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AMILookup field is set to the value of the last call.
+func (b *AWSNodePoolPlatformApplyConfiguration) WithAMILookup(value *AWSAMILookupApplyConfiguration) *AWSNodePoolPlatformApplyConfiguration {
+	b.AMILookup = value
+	return b
+}
+
 // WithSecurityGroups adds the given value to the SecurityGroups field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, values provided by each call will be appended to the SecurityGroups field.
@@ -80,6 +90,14 @@ func (b *AWSNodePoolPlatformApplyConfiguration) WithSecurityGroups(values ...*AW
 	return b
 }
 
+// WithDedicatedSecurityGroup sets the DedicatedSecurityGroup field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DedicatedSecurityGroup field is set to the value of the last call.
+func (b *AWSNodePoolPlatformApplyConfiguration) WithDedicatedSecurityGroup(value *AWSNodePoolSecurityGroupApplyConfiguration) *AWSNodePoolPlatformApplyConfiguration {
+	b.DedicatedSecurityGroup = value
+	return b
+}
+
 // WithRootVolume sets the RootVolume field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the RootVolume field is set to the value of the last call.
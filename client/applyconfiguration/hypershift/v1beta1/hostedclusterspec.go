@@ -43,6 +43,7 @@ type HostedClusterSpecApplyConfiguration struct {
 	PullSecret                       *corev1.LocalObjectReference                         `json:"pullSecret,omitempty"`
 	SSHKey                           *corev1.LocalObjectReference                         `json:"sshKey,omitempty"`
 	IssuerURL                        *string                                              `json:"issuerURL,omitempty"`
+	SecondaryIssuerURL               *string                                              `json:"secondaryIssuerURL,omitempty"`
 	ServiceAccountSigningKey         *corev1.LocalObjectReference                         `json:"serviceAccountSigningKey,omitempty"`
 	Configuration                    *ClusterConfigurationApplyConfiguration              `json:"configuration,omitempty"`
 	AuditWebhook                     *corev1.LocalObjectReference                         `json:"auditWebhook,omitempty"`
@@ -51,8 +52,20 @@ type HostedClusterSpecApplyConfiguration struct {
 	SecretEncryption                 *SecretEncryptionSpecApplyConfiguration              `json:"secretEncryption,omitempty"`
 	FIPS                             *bool                                                `json:"fips,omitempty"`
 	PausedUntil                      *string                                              `json:"pausedUntil,omitempty"`
+	PauseScope                       *hypershiftv1beta1.PauseScope                        `json:"pauseScope,omitempty"`
 	OLMCatalogPlacement              *hypershiftv1beta1.OLMCatalogPlacement               `json:"olmCatalogPlacement,omitempty"`
+	OLMCatalogs                      *OLMCatalogsApplyConfiguration                       `json:"olmCatalogs,omitempty"`
 	NodeSelector                     map[string]string                                    `json:"nodeSelector,omitempty"`
+	Labels                           map[string]string                                    `json:"labels,omitempty"`
+	Annotations                      map[string]string                                    `json:"annotations,omitempty"`
+	DataPlaneInfrastructurePlacement *DataPlaneInfrastructurePlacementApplyConfiguration  `json:"dataPlaneInfrastructurePlacement,omitempty"`
+	DefaultIngressController         *DefaultIngressControllerSpecApplyConfiguration      `json:"defaultIngressController,omitempty"`
+	ImageVerification                *ImageVerificationPolicyApplyConfiguration           `json:"imageVerification,omitempty"`
+	DeletionPolicy                   *DeletionPolicyApplyConfiguration                    `json:"deletionPolicy,omitempty"`
+	Lifetime                         *HostedClusterLifetimeApplyConfiguration             `json:"lifetime,omitempty"`
+	KubeconfigPublishing             *KubeconfigPublishingSpecApplyConfiguration          `json:"kubeconfigPublishing,omitempty"`
+	SecretsBackup                    *SecretsBackupSpecApplyConfiguration                 `json:"secretsBackup,omitempty"`
+	Storage                          *ClusterStorageSpecApplyConfiguration                `json:"storage,omitempty"`
 }
 
 // HostedClusterSpecApplyConfiguration constructs an declarative configuration of the HostedClusterSpec type for use with
@@ -202,6 +215,14 @@ func (b *HostedClusterSpecApplyConfiguration) WithIssuerURL(value string) *Hoste
 	return b
 }
 
+// WithSecondaryIssuerURL sets the SecondaryIssuerURL field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SecondaryIssuerURL field is set to the value of the last call.
+func (b *HostedClusterSpecApplyConfiguration) WithSecondaryIssuerURL(value string) *HostedClusterSpecApplyConfiguration {
+	b.SecondaryIssuerURL = &value
+	return b
+}
+
 // WithServiceAccountSigningKey sets the ServiceAccountSigningKey field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the ServiceAccountSigningKey field is set to the value of the last call.
@@ -271,6 +292,14 @@ func (b *HostedClusterSpecApplyConfiguration) WithPausedUntil(value string) *Hos
 	return b
 }
 
+// WithPauseScope sets the PauseScope field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PauseScope field is set to the value of the last call.
+func (b *HostedClusterSpecApplyConfiguration) WithPauseScope(value hypershiftv1beta1.PauseScope) *HostedClusterSpecApplyConfiguration {
+	b.PauseScope = &value
+	return b
+}
+
 // WithOLMCatalogPlacement sets the OLMCatalogPlacement field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the OLMCatalogPlacement field is set to the value of the last call.
@@ -279,6 +308,14 @@ func (b *HostedClusterSpecApplyConfiguration) WithOLMCatalogPlacement(value hype
 	return b
 }
 
+// WithOLMCatalogs sets the OLMCatalogs field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the OLMCatalogs field is set to the value of the last call.
+func (b *HostedClusterSpecApplyConfiguration) WithOLMCatalogs(value *OLMCatalogsApplyConfiguration) *HostedClusterSpecApplyConfiguration {
+	b.OLMCatalogs = value
+	return b
+}
+
 // WithNodeSelector puts the entries into the NodeSelector field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, the entries provided by each call will be put on the NodeSelector field,
@@ -292,3 +329,95 @@ func (b *HostedClusterSpecApplyConfiguration) WithNodeSelector(entries map[strin
 	}
 	return b
 }
+
+// WithLabels puts the entries into the Labels field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the Labels field,
+// overwriting an existing map entries in Labels field with the same key.
+func (b *HostedClusterSpecApplyConfiguration) WithLabels(entries map[string]string) *HostedClusterSpecApplyConfiguration {
+	if b.Labels == nil && len(entries) > 0 {
+		b.Labels = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.Labels[k] = v
+	}
+	return b
+}
+
+// WithAnnotations puts the entries into the Annotations field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the Annotations field,
+// overwriting an existing map entries in Annotations field with the same key.
+func (b *HostedClusterSpecApplyConfiguration) WithAnnotations(entries map[string]string) *HostedClusterSpecApplyConfiguration {
+	if b.Annotations == nil && len(entries) > 0 {
+		b.Annotations = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.Annotations[k] = v
+	}
+	return b
+}
+
+// WithDataPlaneInfrastructurePlacement sets the DataPlaneInfrastructurePlacement field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DataPlaneInfrastructurePlacement field is set to the value of the last call.
+func (b *HostedClusterSpecApplyConfiguration) WithDataPlaneInfrastructurePlacement(value *DataPlaneInfrastructurePlacementApplyConfiguration) *HostedClusterSpecApplyConfiguration {
+	b.DataPlaneInfrastructurePlacement = value
+	return b
+}
+
+// WithDefaultIngressController sets the DefaultIngressController field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DefaultIngressController field is set to the value of the last call.
+func (b *HostedClusterSpecApplyConfiguration) WithDefaultIngressController(value *DefaultIngressControllerSpecApplyConfiguration) *HostedClusterSpecApplyConfiguration {
+	b.DefaultIngressController = value
+	return b
+}
+
+// WithImageVerification sets the ImageVerification field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ImageVerification field is set to the value of the last call.
+func (b *HostedClusterSpecApplyConfiguration) WithImageVerification(value *ImageVerificationPolicyApplyConfiguration) *HostedClusterSpecApplyConfiguration {
+	b.ImageVerification = value
+	return b
+}
+
+// WithDeletionPolicy sets the DeletionPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DeletionPolicy field is set to the value of the last call.
+func (b *HostedClusterSpecApplyConfiguration) WithDeletionPolicy(value *DeletionPolicyApplyConfiguration) *HostedClusterSpecApplyConfiguration {
+	b.DeletionPolicy = value
+	return b
+}
+
+// WithLifetime sets the Lifetime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Lifetime field is set to the value of the last call.
+func (b *HostedClusterSpecApplyConfiguration) WithLifetime(value *HostedClusterLifetimeApplyConfiguration) *HostedClusterSpecApplyConfiguration {
+	b.Lifetime = value
+	return b
+}
+
+// WithKubeconfigPublishing sets the KubeconfigPublishing field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the KubeconfigPublishing field is set to the value of the last call.
+func (b *HostedClusterSpecApplyConfiguration) WithKubeconfigPublishing(value *KubeconfigPublishingSpecApplyConfiguration) *HostedClusterSpecApplyConfiguration {
+	b.KubeconfigPublishing = value
+	return b
+}
+
+// WithSecretsBackup sets the SecretsBackup field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SecretsBackup field is set to the value of the last call.
+func (b *HostedClusterSpecApplyConfiguration) WithSecretsBackup(value *SecretsBackupSpecApplyConfiguration) *HostedClusterSpecApplyConfiguration {
+	b.SecretsBackup = value
+	return b
+}
+
+// WithStorage sets the Storage field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Storage field is set to the value of the last call.
+func (b *HostedClusterSpecApplyConfiguration) WithStorage(value *ClusterStorageSpecApplyConfiguration) *HostedClusterSpecApplyConfiguration {
+	b.Storage = value
+	return b
+}
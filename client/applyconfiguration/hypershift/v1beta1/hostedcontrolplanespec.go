@@ -32,6 +32,7 @@ type HostedControlPlaneSpecApplyConfiguration struct {
 	Channel                          *string                                              `json:"channel,omitempty"`
 	PullSecret                       *corev1.LocalObjectReference                         `json:"pullSecret,omitempty"`
 	IssuerURL                        *string                                              `json:"issuerURL,omitempty"`
+	SecondaryIssuerURL               *string                                              `json:"secondaryIssuerURL,omitempty"`
 	Networking                       *ClusterNetworkingApplyConfiguration                 `json:"networking,omitempty"`
 	SSHKey                           *corev1.LocalObjectReference                         `json:"sshKey,omitempty"`
 	ClusterID                        *string                                              `json:"clusterID,omitempty"`
@@ -52,8 +53,14 @@ type HostedControlPlaneSpecApplyConfiguration struct {
 	SecretEncryption                 *SecretEncryptionSpecApplyConfiguration              `json:"secretEncryption,omitempty"`
 	PausedUntil                      *string                                              `json:"pausedUntil,omitempty"`
 	OLMCatalogPlacement              *hypershiftv1beta1.OLMCatalogPlacement               `json:"olmCatalogPlacement,omitempty"`
+	OLMCatalogs                      *OLMCatalogsApplyConfiguration                       `json:"olmCatalogs,omitempty"`
 	Autoscaling                      *ClusterAutoscalingApplyConfiguration                `json:"autoscaling,omitempty"`
 	NodeSelector                     map[string]string                                    `json:"nodeSelector,omitempty"`
+	Labels                           map[string]string                                    `json:"labels,omitempty"`
+	Annotations                      map[string]string                                    `json:"annotations,omitempty"`
+	DataPlaneInfrastructurePlacement *DataPlaneInfrastructurePlacementApplyConfiguration  `json:"dataPlaneInfrastructurePlacement,omitempty"`
+	DefaultIngressController         *DefaultIngressControllerSpecApplyConfiguration      `json:"defaultIngressController,omitempty"`
+	Storage                          *ClusterStorageSpecApplyConfiguration                `json:"storage,omitempty"`
 }
 
 // HostedControlPlaneSpecApplyConfiguration constructs an declarative configuration of the HostedControlPlaneSpec type for use with
@@ -110,6 +117,14 @@ func (b *HostedControlPlaneSpecApplyConfiguration) WithIssuerURL(value string) *
 	return b
 }
 
+// WithSecondaryIssuerURL sets the SecondaryIssuerURL field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SecondaryIssuerURL field is set to the value of the last call.
+func (b *HostedControlPlaneSpecApplyConfiguration) WithSecondaryIssuerURL(value string) *HostedControlPlaneSpecApplyConfiguration {
+	b.SecondaryIssuerURL = &value
+	return b
+}
+
 // WithNetworking sets the Networking field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Networking field is set to the value of the last call.
@@ -280,6 +295,14 @@ func (b *HostedControlPlaneSpecApplyConfiguration) WithOLMCatalogPlacement(value
 	return b
 }
 
+// WithOLMCatalogs sets the OLMCatalogs field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the OLMCatalogs field is set to the value of the last call.
+func (b *HostedControlPlaneSpecApplyConfiguration) WithOLMCatalogs(value *OLMCatalogsApplyConfiguration) *HostedControlPlaneSpecApplyConfiguration {
+	b.OLMCatalogs = value
+	return b
+}
+
 // WithAutoscaling sets the Autoscaling field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Autoscaling field is set to the value of the last call.
@@ -301,3 +324,55 @@ func (b *HostedControlPlaneSpecApplyConfiguration) WithNodeSelector(entries map[
 	}
 	return b
 }
+
+// WithLabels puts the entries into the Labels field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the Labels field,
+// overwriting an existing map entries in Labels field with the same key.
+func (b *HostedControlPlaneSpecApplyConfiguration) WithLabels(entries map[string]string) *HostedControlPlaneSpecApplyConfiguration {
+	if b.Labels == nil && len(entries) > 0 {
+		b.Labels = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.Labels[k] = v
+	}
+	return b
+}
+
+// WithAnnotations puts the entries into the Annotations field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the Annotations field,
+// overwriting an existing map entries in Annotations field with the same key.
+func (b *HostedControlPlaneSpecApplyConfiguration) WithAnnotations(entries map[string]string) *HostedControlPlaneSpecApplyConfiguration {
+	if b.Annotations == nil && len(entries) > 0 {
+		b.Annotations = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.Annotations[k] = v
+	}
+	return b
+}
+
+// WithDataPlaneInfrastructurePlacement sets the DataPlaneInfrastructurePlacement field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DataPlaneInfrastructurePlacement field is set to the value of the last call.
+func (b *HostedControlPlaneSpecApplyConfiguration) WithDataPlaneInfrastructurePlacement(value *DataPlaneInfrastructurePlacementApplyConfiguration) *HostedControlPlaneSpecApplyConfiguration {
+	b.DataPlaneInfrastructurePlacement = value
+	return b
+}
+
+// WithDefaultIngressController sets the DefaultIngressController field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DefaultIngressController field is set to the value of the last call.
+func (b *HostedControlPlaneSpecApplyConfiguration) WithDefaultIngressController(value *DefaultIngressControllerSpecApplyConfiguration) *HostedControlPlaneSpecApplyConfiguration {
+	b.DefaultIngressController = value
+	return b
+}
+
+// WithStorage sets the Storage field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Storage field is set to the value of the last call.
+func (b *HostedControlPlaneSpecApplyConfiguration) WithStorage(value *ClusterStorageSpecApplyConfiguration) *HostedControlPlaneSpecApplyConfiguration {
+	b.Storage = value
+	return b
+}
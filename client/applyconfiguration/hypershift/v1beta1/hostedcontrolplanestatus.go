@@ -40,6 +40,7 @@ type HostedControlPlaneStatusApplyConfiguration struct {
 	Conditions                     []metav1.ConditionApplyConfiguration    `json:"conditions,omitempty"`
 	Platform                       *PlatformStatusApplyConfiguration       `json:"platform,omitempty"`
 	NodeCount                      *int                                    `json:"nodeCount,omitempty"`
+	ControlPlaneStartupDuration    *v1.Duration                            `json:"controlPlaneStartupDuration,omitempty"`
 }
 
 // HostedControlPlaneStatusApplyConfiguration constructs an declarative configuration of the HostedControlPlaneStatus type for use with
@@ -164,3 +165,11 @@ func (b *HostedControlPlaneStatusApplyConfiguration) WithNodeCount(value int) *H
 	b.NodeCount = &value
 	return b
 }
+
+// WithControlPlaneStartupDuration sets the ControlPlaneStartupDuration field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ControlPlaneStartupDuration field is set to the value of the last call.
+func (b *HostedControlPlaneStatusApplyConfiguration) WithControlPlaneStartupDuration(value v1.Duration) *HostedControlPlaneStatusApplyConfiguration {
+	b.ControlPlaneStartupDuration = &value
+	return b
+}
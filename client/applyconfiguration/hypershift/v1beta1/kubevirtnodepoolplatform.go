@@ -30,6 +30,7 @@ type KubevirtNodePoolPlatformApplyConfiguration struct {
 	AdditionalNetworks         []KubevirtNetworkApplyConfiguration   `json:"additionalNetworks,omitempty"`
 	AttachDefaultNetwork       *bool                                 `json:"attachDefaultNetwork,omitempty"`
 	NodeSelector               map[string]string                     `json:"nodeSelector,omitempty"`
+	InfraNamespace             *string                               `json:"infraNamespace,omitempty"`
 }
 
 // KubevirtNodePoolPlatformApplyConfiguration constructs an declarative configuration of the KubevirtNodePoolPlatform type for use with
@@ -96,3 +97,11 @@ func (b *KubevirtNodePoolPlatformApplyConfiguration) WithNodeSelector(entries ma
 	}
 	return b
 }
+
+// WithInfraNamespace sets the InfraNamespace field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the InfraNamespace field is set to the value of the last call.
+func (b *KubevirtNodePoolPlatformApplyConfiguration) WithInfraNamespace(value string) *KubevirtNodePoolPlatformApplyConfiguration {
+	b.InfraNamespace = &value
+	return b
+}
@@ -20,8 +20,9 @@ package v1beta1
 // KubeVirtNodePoolStatusApplyConfiguration represents an declarative configuration of the KubeVirtNodePoolStatus type for use
 // with apply.
 type KubeVirtNodePoolStatusApplyConfiguration struct {
-	CacheName   *string                                        `json:"cacheName,omitempty"`
-	Credentials *KubevirtPlatformCredentialsApplyConfiguration `json:"credentials,omitempty"`
+	CacheName      *string                                        `json:"cacheName,omitempty"`
+	Credentials    *KubevirtPlatformCredentialsApplyConfiguration `json:"credentials,omitempty"`
+	InfraNamespace *string                                        `json:"infraNamespace,omitempty"`
 }
 
 // KubeVirtNodePoolStatusApplyConfiguration constructs an declarative configuration of the KubeVirtNodePoolStatus type for use with
@@ -45,3 +46,11 @@ func (b *KubeVirtNodePoolStatusApplyConfiguration) WithCredentials(value *Kubevi
 	b.Credentials = value
 	return b
 }
+
+// WithInfraNamespace sets the InfraNamespace field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the InfraNamespace field is set to the value of the last call.
+func (b *KubeVirtNodePoolStatusApplyConfiguration) WithInfraNamespace(value string) *KubeVirtNodePoolStatusApplyConfiguration {
+	b.InfraNamespace = &value
+	return b
+}
@@ -20,7 +20,8 @@ package v1beta1
 // ManagedEtcdSpecApplyConfiguration represents an declarative configuration of the ManagedEtcdSpec type for use
 // with apply.
 type ManagedEtcdSpecApplyConfiguration struct {
-	Storage *ManagedEtcdStorageSpecApplyConfiguration `json:"storage,omitempty"`
+	Storage    *ManagedEtcdStorageSpecApplyConfiguration    `json:"storage,omitempty"`
+	Disruption *ManagedEtcdDisruptionSpecApplyConfiguration `json:"disruption,omitempty"`
 }
 
 // ManagedEtcdSpecApplyConfiguration constructs an declarative configuration of the ManagedEtcdSpec type for use with
@@ -36,3 +37,11 @@ func (b *ManagedEtcdSpecApplyConfiguration) WithStorage(value *ManagedEtcdStorag
 	b.Storage = value
 	return b
 }
+
+// WithDisruption sets the Disruption field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Disruption field is set to the value of the last call.
+func (b *ManagedEtcdSpecApplyConfiguration) WithDisruption(value *ManagedEtcdDisruptionSpecApplyConfiguration) *ManagedEtcdSpecApplyConfiguration {
+	b.Disruption = value
+	return b
+}
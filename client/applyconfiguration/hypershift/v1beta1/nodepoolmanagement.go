@@ -19,15 +19,18 @@ package v1beta1
 
 import (
 	v1beta1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // NodePoolManagementApplyConfiguration represents an declarative configuration of the NodePoolManagement type for use
 // with apply.
 type NodePoolManagementApplyConfiguration struct {
-	UpgradeType *v1beta1.UpgradeType              `json:"upgradeType,omitempty"`
-	Replace     *ReplaceUpgradeApplyConfiguration `json:"replace,omitempty"`
-	InPlace     *InPlaceUpgradeApplyConfiguration `json:"inPlace,omitempty"`
-	AutoRepair  *bool                             `json:"autoRepair,omitempty"`
+	UpgradeType     *v1beta1.UpgradeType               `json:"upgradeType,omitempty"`
+	Replace         *ReplaceUpgradeApplyConfiguration  `json:"replace,omitempty"`
+	InPlace         *InPlaceUpgradeApplyConfiguration  `json:"inPlace,omitempty"`
+	AutoRepair      *bool                              `json:"autoRepair,omitempty"`
+	Rollout         *NodePoolRolloutApplyConfiguration `json:"rollout,omitempty"`
+	MaxNodeLifetime *v1.Duration                       `json:"maxNodeLifetime,omitempty"`
 }
 
 // NodePoolManagementApplyConfiguration constructs an declarative configuration of the NodePoolManagement type for use with
@@ -67,3 +70,19 @@ func (b *NodePoolManagementApplyConfiguration) WithAutoRepair(value bool) *NodeP
 	b.AutoRepair = &value
 	return b
 }
+
+// WithRollout sets the Rollout field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Rollout field is set to the value of the last call.
+func (b *NodePoolManagementApplyConfiguration) WithRollout(value *NodePoolRolloutApplyConfiguration) *NodePoolManagementApplyConfiguration {
+	b.Rollout = value
+	return b
+}
+
+// WithMaxNodeLifetime sets the MaxNodeLifetime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxNodeLifetime field is set to the value of the last call.
+func (b *NodePoolManagementApplyConfiguration) WithMaxNodeLifetime(value v1.Duration) *NodePoolManagementApplyConfiguration {
+	b.MaxNodeLifetime = &value
+	return b
+}
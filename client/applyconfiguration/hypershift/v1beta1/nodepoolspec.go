@@ -18,6 +18,7 @@ limitations under the License.
 package v1beta1
 
 import (
+	hypershiftv1beta1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -25,19 +26,27 @@ import (
 // NodePoolSpecApplyConfiguration represents an declarative configuration of the NodePoolSpec type for use
 // with apply.
 type NodePoolSpecApplyConfiguration struct {
-	ClusterName      *string                                `json:"clusterName,omitempty"`
-	Release          *ReleaseApplyConfiguration             `json:"release,omitempty"`
-	Platform         *NodePoolPlatformApplyConfiguration    `json:"platform,omitempty"`
-	Replicas         *int32                                 `json:"replicas,omitempty"`
-	Management       *NodePoolManagementApplyConfiguration  `json:"management,omitempty"`
-	AutoScaling      *NodePoolAutoScalingApplyConfiguration `json:"autoScaling,omitempty"`
-	Config           []v1.LocalObjectReference              `json:"config,omitempty"`
-	NodeDrainTimeout *metav1.Duration                       `json:"nodeDrainTimeout,omitempty"`
-	NodeLabels       map[string]string                      `json:"nodeLabels,omitempty"`
-	Taints           []TaintApplyConfiguration              `json:"taints,omitempty"`
-	PausedUntil      *string                                `json:"pausedUntil,omitempty"`
-	TuningConfig     []v1.LocalObjectReference              `json:"tuningConfig,omitempty"`
-	Arch             *string                                `json:"arch,omitempty"`
+	ClusterName                       *string                                              `json:"clusterName,omitempty"`
+	Release                           *ReleaseApplyConfiguration                           `json:"release,omitempty"`
+	Platform                          *NodePoolPlatformApplyConfiguration                  `json:"platform,omitempty"`
+	Replicas                          *int32                                               `json:"replicas,omitempty"`
+	Management                        *NodePoolManagementApplyConfiguration                `json:"management,omitempty"`
+	AutoScaling                       *NodePoolAutoScalingApplyConfiguration               `json:"autoScaling,omitempty"`
+	Config                            []v1.LocalObjectReference                            `json:"config,omitempty"`
+	NodeDrainTimeout                  *metav1.Duration                                     `json:"nodeDrainTimeout,omitempty"`
+	NodeLabels                        map[string]string                                    `json:"nodeLabels,omitempty"`
+	Taints                            []TaintApplyConfiguration                            `json:"taints,omitempty"`
+	MachineDeletionHooks              []MachineDeletionHookApplyConfiguration              `json:"machineDeletionHooks,omitempty"`
+	PausedUntil                       *string                                              `json:"pausedUntil,omitempty"`
+	TuningConfig                      []v1.LocalObjectReference                            `json:"tuningConfig,omitempty"`
+	AdditionalPullSecrets             []v1.LocalObjectReference                            `json:"additionalPullSecrets,omitempty"`
+	NTPServers                        []string                                             `json:"ntpServers,omitempty"`
+	ContainerRuntime                  *NodePoolContainerRuntimeApplyConfiguration          `json:"containerRuntime,omitempty"`
+	AdditionalTrustBundleDistribution *hypershiftv1beta1.AdditionalTrustBundleDistribution `json:"additionalTrustBundleDistribution,omitempty"`
+	Bootstrap                         *NodePoolBootstrapApplyConfiguration                 `json:"bootstrap,omitempty"`
+	BootImageUpdatePolicy             *hypershiftv1beta1.BootImageUpdatePolicy             `json:"bootImageUpdatePolicy,omitempty"`
+	OSImage                           *NodePoolOSImageApplyConfiguration                   `json:"osImage,omitempty"`
+	Arch                              *string                                              `json:"arch,omitempty"`
 }
 
 // NodePoolSpecApplyConfiguration constructs an declarative configuration of the NodePoolSpec type for use with
@@ -139,6 +148,19 @@ func (b *NodePoolSpecApplyConfiguration) WithTaints(values ...*TaintApplyConfigu
 	return b
 }
 
+// WithMachineDeletionHooks adds the given value to the MachineDeletionHooks field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the MachineDeletionHooks field.
+func (b *NodePoolSpecApplyConfiguration) WithMachineDeletionHooks(values ...*MachineDeletionHookApplyConfiguration) *NodePoolSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithMachineDeletionHooks")
+		}
+		b.MachineDeletionHooks = append(b.MachineDeletionHooks, *values[i])
+	}
+	return b
+}
+
 // WithPausedUntil sets the PausedUntil field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the PausedUntil field is set to the value of the last call.
@@ -157,6 +179,66 @@ func (b *NodePoolSpecApplyConfiguration) WithTuningConfig(values ...v1.LocalObje
 	return b
 }
 
+// WithAdditionalPullSecrets adds the given value to the AdditionalPullSecrets field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the AdditionalPullSecrets field.
+func (b *NodePoolSpecApplyConfiguration) WithAdditionalPullSecrets(values ...v1.LocalObjectReference) *NodePoolSpecApplyConfiguration {
+	for i := range values {
+		b.AdditionalPullSecrets = append(b.AdditionalPullSecrets, values[i])
+	}
+	return b
+}
+
+// WithNTPServers adds the given value to the NTPServers field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the NTPServers field.
+func (b *NodePoolSpecApplyConfiguration) WithNTPServers(values ...string) *NodePoolSpecApplyConfiguration {
+	for i := range values {
+		b.NTPServers = append(b.NTPServers, values[i])
+	}
+	return b
+}
+
+// WithContainerRuntime sets the ContainerRuntime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ContainerRuntime field is set to the value of the last call.
+func (b *NodePoolSpecApplyConfiguration) WithContainerRuntime(value *NodePoolContainerRuntimeApplyConfiguration) *NodePoolSpecApplyConfiguration {
+	b.ContainerRuntime = value
+	return b
+}
+
+// WithAdditionalTrustBundleDistribution sets the AdditionalTrustBundleDistribution field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AdditionalTrustBundleDistribution field is set to the value of the last call.
+func (b *NodePoolSpecApplyConfiguration) WithAdditionalTrustBundleDistribution(value hypershiftv1beta1.AdditionalTrustBundleDistribution) *NodePoolSpecApplyConfiguration {
+	b.AdditionalTrustBundleDistribution = &value
+	return b
+}
+
+// WithBootstrap sets the Bootstrap field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Bootstrap field is set to the value of the last call.
+func (b *NodePoolSpecApplyConfiguration) WithBootstrap(value *NodePoolBootstrapApplyConfiguration) *NodePoolSpecApplyConfiguration {
+	b.Bootstrap = value
+	return b
+}
+
+// WithBootImageUpdatePolicy sets the BootImageUpdatePolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the BootImageUpdatePolicy field is set to the value of the last call.
+func (b *NodePoolSpecApplyConfiguration) WithBootImageUpdatePolicy(value hypershiftv1beta1.BootImageUpdatePolicy) *NodePoolSpecApplyConfiguration {
+	b.BootImageUpdatePolicy = &value
+	return b
+}
+
+// WithOSImage sets the OSImage field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the OSImage field is set to the value of the last call.
+func (b *NodePoolSpecApplyConfiguration) WithOSImage(value *NodePoolOSImageApplyConfiguration) *NodePoolSpecApplyConfiguration {
+	b.OSImage = value
+	return b
+}
+
 // WithArch sets the Arch field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Arch field is set to the value of the last call.
@@ -24,11 +24,13 @@ import (
 // EffectsApplyConfiguration represents an declarative configuration of the Effects type for use
 // with apply.
 type EffectsApplyConfiguration struct {
-	KASGoMemLimit                 *resource.Quantity                  `json:"kasGoMemLimit,omitempty"`
-	ControlPlanePriorityClassName *string                             `json:"controlPlanePriorityClassName,omitempty"`
-	EtcdPriorityClassName         *string                             `json:"etcdPriorityClassName,omitempty"`
-	APICriticalPriorityClassName  *string                             `json:"APICriticalPriorityClassName,omitempty"`
-	ResourceRequests              []ResourceRequestApplyConfiguration `json:"resourceRequests,omitempty"`
+	KASGoMemLimit                  *resource.Quantity                  `json:"kasGoMemLimit,omitempty"`
+	KASMaxRequestsInflight         *int32                              `json:"kasMaxRequestsInflight,omitempty"`
+	KASMaxMutatingRequestsInflight *int32                              `json:"kasMaxMutatingRequestsInflight,omitempty"`
+	ControlPlanePriorityClassName  *string                             `json:"controlPlanePriorityClassName,omitempty"`
+	EtcdPriorityClassName          *string                             `json:"etcdPriorityClassName,omitempty"`
+	APICriticalPriorityClassName   *string                             `json:"APICriticalPriorityClassName,omitempty"`
+	ResourceRequests               []ResourceRequestApplyConfiguration `json:"resourceRequests,omitempty"`
 }
 
 // EffectsApplyConfiguration constructs an declarative configuration of the Effects type for use with
@@ -45,6 +47,22 @@ func (b *EffectsApplyConfiguration) WithKASGoMemLimit(value resource.Quantity) *
 	return b
 }
 
+// WithKASMaxRequestsInflight sets the KASMaxRequestsInflight field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the KASMaxRequestsInflight field is set to the value of the last call.
+func (b *EffectsApplyConfiguration) WithKASMaxRequestsInflight(value int32) *EffectsApplyConfiguration {
+	b.KASMaxRequestsInflight = &value
+	return b
+}
+
+// WithKASMaxMutatingRequestsInflight sets the KASMaxMutatingRequestsInflight field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the KASMaxMutatingRequestsInflight field is set to the value of the last call.
+func (b *EffectsApplyConfiguration) WithKASMaxMutatingRequestsInflight(value int32) *EffectsApplyConfiguration {
+	b.KASMaxMutatingRequestsInflight = &value
+	return b
+}
+
 // WithControlPlanePriorityClassName sets the ControlPlanePriorityClassName field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the ControlPlanePriorityClassName field is set to the value of the last call.
@@ -24,8 +24,10 @@ import (
 // ManagementApplyConfiguration represents an declarative configuration of the Management type for use
 // with apply.
 type ManagementApplyConfiguration struct {
-	Placeholders                  *int               `json:"placeholders,omitempty"`
-	NonRequestServingNodesPerZone *resource.Quantity `json:"nonRequestServingNodesPerZone,omitempty"`
+	Placeholders                  *int                                         `json:"placeholders,omitempty"`
+	NonRequestServingNodesPerZone *resource.Quantity                           `json:"nonRequestServingNodesPerZone,omitempty"`
+	ControlPlaneResourceQuota     *ControlPlaneResourceQuotaApplyConfiguration `json:"controlPlaneResourceQuota,omitempty"`
+	ControlPlaneLimitRange        *ControlPlaneLimitRangeApplyConfiguration    `json:"controlPlaneLimitRange,omitempty"`
 }
 
 // ManagementApplyConfiguration constructs an declarative configuration of the Management type for use with
@@ -49,3 +51,19 @@ func (b *ManagementApplyConfiguration) WithNonRequestServingNodesPerZone(value r
 	b.NonRequestServingNodesPerZone = &value
 	return b
 }
+
+// WithControlPlaneResourceQuota sets the ControlPlaneResourceQuota field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ControlPlaneResourceQuota field is set to the value of the last call.
+func (b *ManagementApplyConfiguration) WithControlPlaneResourceQuota(value *ControlPlaneResourceQuotaApplyConfiguration) *ManagementApplyConfiguration {
+	b.ControlPlaneResourceQuota = value
+	return b
+}
+
+// WithControlPlaneLimitRange sets the ControlPlaneLimitRange field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ControlPlaneLimitRange field is set to the value of the last call.
+func (b *ManagementApplyConfiguration) WithControlPlaneLimitRange(value *ControlPlaneLimitRangeApplyConfiguration) *ManagementApplyConfiguration {
+	b.ControlPlaneLimitRange = value
+	return b
+}
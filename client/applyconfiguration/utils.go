@@ -54,6 +54,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &applyconfigurationhypershiftv1alpha1.APIEndpointApplyConfiguration{}
 	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("APIServerNetworking"):
 		return &applyconfigurationhypershiftv1alpha1.APIServerNetworkingApplyConfiguration{}
+	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("AWSAMILookup"):
+		return &applyconfigurationhypershiftv1alpha1.AWSAMILookupApplyConfiguration{}
 	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("AWSCloudProviderConfig"):
 		return &applyconfigurationhypershiftv1alpha1.AWSCloudProviderConfigApplyConfiguration{}
 	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("AWSKMSAuthSpec"):
@@ -64,6 +66,10 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &applyconfigurationhypershiftv1alpha1.AWSKMSSpecApplyConfiguration{}
 	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("AWSNodePoolPlatform"):
 		return &applyconfigurationhypershiftv1alpha1.AWSNodePoolPlatformApplyConfiguration{}
+	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("AWSNodePoolSecurityGroup"):
+		return &applyconfigurationhypershiftv1alpha1.AWSNodePoolSecurityGroupApplyConfiguration{}
+	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("AWSNodePoolStatus"):
+		return &applyconfigurationhypershiftv1alpha1.AWSNodePoolStatusApplyConfiguration{}
 	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("AWSPlatformSpec"):
 		return &applyconfigurationhypershiftv1alpha1.AWSPlatformSpecApplyConfiguration{}
 	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("AWSPlatformStatus"):
@@ -76,16 +82,28 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &applyconfigurationhypershiftv1alpha1.AWSRoleCredentialsApplyConfiguration{}
 	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("AWSRolesRef"):
 		return &applyconfigurationhypershiftv1alpha1.AWSRolesRefApplyConfiguration{}
+	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("AWSSecretsManagerKubeconfigStoreSpec"):
+		return &applyconfigurationhypershiftv1alpha1.AWSSecretsManagerKubeconfigStoreSpecApplyConfiguration{}
+	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("AWSSecurityGroupIngressRule"):
+		return &applyconfigurationhypershiftv1alpha1.AWSSecurityGroupIngressRuleApplyConfiguration{}
 	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("AWSServiceEndpoint"):
 		return &applyconfigurationhypershiftv1alpha1.AWSServiceEndpointApplyConfiguration{}
+	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("AzureKeyVaultKubeconfigStoreSpec"):
+		return &applyconfigurationhypershiftv1alpha1.AzureKeyVaultKubeconfigStoreSpecApplyConfiguration{}
 	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("AzureKMSKey"):
 		return &applyconfigurationhypershiftv1alpha1.AzureKMSKeyApplyConfiguration{}
 	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("AzureKMSSpec"):
 		return &applyconfigurationhypershiftv1alpha1.AzureKMSSpecApplyConfiguration{}
 	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("AzureNodePoolPlatform"):
 		return &applyconfigurationhypershiftv1alpha1.AzureNodePoolPlatformApplyConfiguration{}
+	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("AzureNodePoolStatus"):
+		return &applyconfigurationhypershiftv1alpha1.AzureNodePoolStatusApplyConfiguration{}
 	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("AzurePlatformSpec"):
 		return &applyconfigurationhypershiftv1alpha1.AzurePlatformSpecApplyConfiguration{}
+	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("AzureResourceTag"):
+		return &applyconfigurationhypershiftv1alpha1.AzureResourceTagApplyConfiguration{}
+	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("AzureVMSecurityProfile"):
+		return &applyconfigurationhypershiftv1alpha1.AzureVMSecurityProfileApplyConfiguration{}
 	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("ClusterAutoscaling"):
 		return &applyconfigurationhypershiftv1alpha1.ClusterAutoscalingApplyConfiguration{}
 	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("ClusterConfiguration"):
@@ -94,8 +112,22 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &applyconfigurationhypershiftv1alpha1.ClusterNetworkEntryApplyConfiguration{}
 	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("ClusterNetworking"):
 		return &applyconfigurationhypershiftv1alpha1.ClusterNetworkingApplyConfiguration{}
+	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("ClusterStorageSpec"):
+		return &applyconfigurationhypershiftv1alpha1.ClusterStorageSpecApplyConfiguration{}
 	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("ClusterVersionStatus"):
 		return &applyconfigurationhypershiftv1alpha1.ClusterVersionStatusApplyConfiguration{}
+	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("CSIStorageClass"):
+		return &applyconfigurationhypershiftv1alpha1.CSIStorageClassApplyConfiguration{}
+	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("CSIVolumeSnapshotClass"):
+		return &applyconfigurationhypershiftv1alpha1.CSIVolumeSnapshotClassApplyConfiguration{}
+	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("CustomDomainPublishingStrategy"):
+		return &applyconfigurationhypershiftv1alpha1.CustomDomainPublishingStrategyApplyConfiguration{}
+	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("DataPlaneInfrastructurePlacement"):
+		return &applyconfigurationhypershiftv1alpha1.DataPlaneInfrastructurePlacementApplyConfiguration{}
+	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("DefaultIngressControllerSpec"):
+		return &applyconfigurationhypershiftv1alpha1.DefaultIngressControllerSpecApplyConfiguration{}
+	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("DeletionPolicy"):
+		return &applyconfigurationhypershiftv1alpha1.DeletionPolicyApplyConfiguration{}
 	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("DNSSpec"):
 		return &applyconfigurationhypershiftv1alpha1.DNSSpecApplyConfiguration{}
 	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("EtcdSpec"):
@@ -106,6 +138,10 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &applyconfigurationhypershiftv1alpha1.FilterApplyConfiguration{}
 	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("HostedCluster"):
 		return &applyconfigurationhypershiftv1alpha1.HostedClusterApplyConfiguration{}
+	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("HostedClusterComponentStatus"):
+		return &applyconfigurationhypershiftv1alpha1.HostedClusterComponentStatusApplyConfiguration{}
+	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("HostedClusterLifetime"):
+		return &applyconfigurationhypershiftv1alpha1.HostedClusterLifetimeApplyConfiguration{}
 	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("HostedClusterSpec"):
 		return &applyconfigurationhypershiftv1alpha1.HostedClusterSpecApplyConfiguration{}
 	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("HostedClusterStatus"):
@@ -122,12 +158,18 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &applyconfigurationhypershiftv1alpha1.IBMCloudPlatformSpecApplyConfiguration{}
 	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("ImageContentSource"):
 		return &applyconfigurationhypershiftv1alpha1.ImageContentSourceApplyConfiguration{}
+	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("ImageVerificationPolicy"):
+		return &applyconfigurationhypershiftv1alpha1.ImageVerificationPolicyApplyConfiguration{}
 	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("InPlaceUpgrade"):
 		return &applyconfigurationhypershiftv1alpha1.InPlaceUpgradeApplyConfiguration{}
 	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("KMSSpec"):
 		return &applyconfigurationhypershiftv1alpha1.KMSSpecApplyConfiguration{}
+	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("KubeconfigPublishingSpec"):
+		return &applyconfigurationhypershiftv1alpha1.KubeconfigPublishingSpecApplyConfiguration{}
 	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("KubeconfigSecretRef"):
 		return &applyconfigurationhypershiftv1alpha1.KubeconfigSecretRefApplyConfiguration{}
+	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("KubeconfigStore"):
+		return &applyconfigurationhypershiftv1alpha1.KubeconfigStoreApplyConfiguration{}
 	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("KubevirtCachingStrategy"):
 		return &applyconfigurationhypershiftv1alpha1.KubevirtCachingStrategyApplyConfiguration{}
 	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("KubevirtCompute"):
@@ -148,6 +190,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &applyconfigurationhypershiftv1alpha1.KubevirtPlatformCredentialsApplyConfiguration{}
 	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("KubevirtPlatformSpec"):
 		return &applyconfigurationhypershiftv1alpha1.KubevirtPlatformSpecApplyConfiguration{}
+	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("KubevirtPlatformStatus"):
+		return &applyconfigurationhypershiftv1alpha1.KubevirtPlatformStatusApplyConfiguration{}
 	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("KubevirtRootVolume"):
 		return &applyconfigurationhypershiftv1alpha1.KubevirtRootVolumeApplyConfiguration{}
 	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("KubevirtStorageClassMapping"):
@@ -160,8 +204,12 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &applyconfigurationhypershiftv1alpha1.KubevirtVolumeSnapshotClassMappingApplyConfiguration{}
 	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("LoadBalancerPublishingStrategy"):
 		return &applyconfigurationhypershiftv1alpha1.LoadBalancerPublishingStrategyApplyConfiguration{}
+	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("MachineDeletionHook"):
+		return &applyconfigurationhypershiftv1alpha1.MachineDeletionHookApplyConfiguration{}
 	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("MachineNetworkEntry"):
 		return &applyconfigurationhypershiftv1alpha1.MachineNetworkEntryApplyConfiguration{}
+	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("ManagedEtcdDisruptionSpec"):
+		return &applyconfigurationhypershiftv1alpha1.ManagedEtcdDisruptionSpecApplyConfiguration{}
 	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("ManagedEtcdSpec"):
 		return &applyconfigurationhypershiftv1alpha1.ManagedEtcdSpecApplyConfiguration{}
 	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("ManagedEtcdStorageSpec"):
@@ -170,20 +218,38 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &applyconfigurationhypershiftv1alpha1.NodePoolApplyConfiguration{}
 	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("NodePoolAutoScaling"):
 		return &applyconfigurationhypershiftv1alpha1.NodePoolAutoScalingApplyConfiguration{}
+	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("NodePoolBootstrap"):
+		return &applyconfigurationhypershiftv1alpha1.NodePoolBootstrapApplyConfiguration{}
+	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("NodePoolBootstrapProxy"):
+		return &applyconfigurationhypershiftv1alpha1.NodePoolBootstrapProxyApplyConfiguration{}
+	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("NodePoolCapacityStatus"):
+		return &applyconfigurationhypershiftv1alpha1.NodePoolCapacityStatusApplyConfiguration{}
 	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("NodePoolCondition"):
 		return &applyconfigurationhypershiftv1alpha1.NodePoolConditionApplyConfiguration{}
+	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("NodePoolContainerRuntime"):
+		return &applyconfigurationhypershiftv1alpha1.NodePoolContainerRuntimeApplyConfiguration{}
+	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("NodePoolMachineStatus"):
+		return &applyconfigurationhypershiftv1alpha1.NodePoolMachineStatusApplyConfiguration{}
 	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("NodePoolManagement"):
 		return &applyconfigurationhypershiftv1alpha1.NodePoolManagementApplyConfiguration{}
+	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("NodePoolOSImage"):
+		return &applyconfigurationhypershiftv1alpha1.NodePoolOSImageApplyConfiguration{}
 	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("NodePoolPlatform"):
 		return &applyconfigurationhypershiftv1alpha1.NodePoolPlatformApplyConfiguration{}
 	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("NodePoolPlatformStatus"):
 		return &applyconfigurationhypershiftv1alpha1.NodePoolPlatformStatusApplyConfiguration{}
+	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("NodePoolRollout"):
+		return &applyconfigurationhypershiftv1alpha1.NodePoolRolloutApplyConfiguration{}
 	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("NodePoolSpec"):
 		return &applyconfigurationhypershiftv1alpha1.NodePoolSpecApplyConfiguration{}
 	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("NodePoolStatus"):
 		return &applyconfigurationhypershiftv1alpha1.NodePoolStatusApplyConfiguration{}
 	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("NodePortPublishingStrategy"):
 		return &applyconfigurationhypershiftv1alpha1.NodePortPublishingStrategyApplyConfiguration{}
+	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("OLMCatalogs"):
+		return &applyconfigurationhypershiftv1alpha1.OLMCatalogsApplyConfiguration{}
+	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("OLMCatalogSource"):
+		return &applyconfigurationhypershiftv1alpha1.OLMCatalogSourceApplyConfiguration{}
 	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("PersistentVolumeEtcdStorageSpec"):
 		return &applyconfigurationhypershiftv1alpha1.PersistentVolumeEtcdStorageSpecApplyConfiguration{}
 	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("PlatformSpec"):
@@ -198,6 +264,10 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &applyconfigurationhypershiftv1alpha1.PowerVSResourceReferenceApplyConfiguration{}
 	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("PowerVSVPC"):
 		return &applyconfigurationhypershiftv1alpha1.PowerVSVPCApplyConfiguration{}
+	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("PublishedKubeconfig"):
+		return &applyconfigurationhypershiftv1alpha1.PublishedKubeconfigApplyConfiguration{}
+	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("PublishedKubeconfigStatus"):
+		return &applyconfigurationhypershiftv1alpha1.PublishedKubeconfigStatusApplyConfiguration{}
 	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("Release"):
 		return &applyconfigurationhypershiftv1alpha1.ReleaseApplyConfiguration{}
 	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("ReplaceUpgrade"):
@@ -206,8 +276,16 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &applyconfigurationhypershiftv1alpha1.RollingUpdateApplyConfiguration{}
 	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("RoutePublishingStrategy"):
 		return &applyconfigurationhypershiftv1alpha1.RoutePublishingStrategyApplyConfiguration{}
+	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("S3SecretsBackupStoreSpec"):
+		return &applyconfigurationhypershiftv1alpha1.S3SecretsBackupStoreSpecApplyConfiguration{}
 	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("SecretEncryptionSpec"):
 		return &applyconfigurationhypershiftv1alpha1.SecretEncryptionSpecApplyConfiguration{}
+	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("SecretsBackupSpec"):
+		return &applyconfigurationhypershiftv1alpha1.SecretsBackupSpecApplyConfiguration{}
+	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("SecretsBackupStatus"):
+		return &applyconfigurationhypershiftv1alpha1.SecretsBackupStatusApplyConfiguration{}
+	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("SecretsBackupStore"):
+		return &applyconfigurationhypershiftv1alpha1.SecretsBackupStoreApplyConfiguration{}
 	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("ServiceNetworkEntry"):
 		return &applyconfigurationhypershiftv1alpha1.ServiceNetworkEntryApplyConfiguration{}
 	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("ServicePublishingStrategy"):
@@ -218,6 +296,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &applyconfigurationhypershiftv1alpha1.TaintApplyConfiguration{}
 	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("UnmanagedEtcdSpec"):
 		return &applyconfigurationhypershiftv1alpha1.UnmanagedEtcdSpecApplyConfiguration{}
+	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("VaultKubeconfigStoreSpec"):
+		return &applyconfigurationhypershiftv1alpha1.VaultKubeconfigStoreSpecApplyConfiguration{}
 	case hypershiftv1alpha1.SchemeGroupVersion.WithKind("Volume"):
 		return &applyconfigurationhypershiftv1alpha1.VolumeApplyConfiguration{}
 
@@ -232,6 +312,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &hypershiftv1beta1.APIEndpointApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("APIServerNetworking"):
 		return &hypershiftv1beta1.APIServerNetworkingApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("AWSAMILookup"):
+		return &hypershiftv1beta1.AWSAMILookupApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("AWSCloudProviderConfig"):
 		return &hypershiftv1beta1.AWSCloudProviderConfigApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("AWSKMSAuthSpec"):
@@ -242,6 +324,10 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &hypershiftv1beta1.AWSKMSSpecApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("AWSNodePoolPlatform"):
 		return &hypershiftv1beta1.AWSNodePoolPlatformApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("AWSNodePoolSecurityGroup"):
+		return &hypershiftv1beta1.AWSNodePoolSecurityGroupApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("AWSNodePoolStatus"):
+		return &hypershiftv1beta1.AWSNodePoolStatusApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("AWSPlatformSpec"):
 		return &hypershiftv1beta1.AWSPlatformSpecApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("AWSPlatformStatus"):
@@ -252,16 +338,28 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &hypershiftv1beta1.AWSResourceTagApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("AWSRolesRef"):
 		return &hypershiftv1beta1.AWSRolesRefApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("AWSSecretsManagerKubeconfigStoreSpec"):
+		return &hypershiftv1beta1.AWSSecretsManagerKubeconfigStoreSpecApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("AWSSecurityGroupIngressRule"):
+		return &hypershiftv1beta1.AWSSecurityGroupIngressRuleApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("AWSServiceEndpoint"):
 		return &hypershiftv1beta1.AWSServiceEndpointApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("AzureKeyVaultKubeconfigStoreSpec"):
+		return &hypershiftv1beta1.AzureKeyVaultKubeconfigStoreSpecApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("AzureKMSKey"):
 		return &hypershiftv1beta1.AzureKMSKeyApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("AzureKMSSpec"):
 		return &hypershiftv1beta1.AzureKMSSpecApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("AzureNodePoolPlatform"):
 		return &hypershiftv1beta1.AzureNodePoolPlatformApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("AzureNodePoolStatus"):
+		return &hypershiftv1beta1.AzureNodePoolStatusApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("AzurePlatformSpec"):
 		return &hypershiftv1beta1.AzurePlatformSpecApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("AzureResourceTag"):
+		return &hypershiftv1beta1.AzureResourceTagApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("AzureVMSecurityProfile"):
+		return &hypershiftv1beta1.AzureVMSecurityProfileApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("CertificateSigningRequestApproval"):
 		return &hypershiftv1beta1.CertificateSigningRequestApprovalApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ClusterAutoscaling"):
@@ -272,8 +370,22 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &hypershiftv1beta1.ClusterNetworkEntryApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ClusterNetworking"):
 		return &hypershiftv1beta1.ClusterNetworkingApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("ClusterStorageSpec"):
+		return &hypershiftv1beta1.ClusterStorageSpecApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ClusterVersionStatus"):
 		return &hypershiftv1beta1.ClusterVersionStatusApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("CSIStorageClass"):
+		return &hypershiftv1beta1.CSIStorageClassApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("CSIVolumeSnapshotClass"):
+		return &hypershiftv1beta1.CSIVolumeSnapshotClassApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("CustomDomainPublishingStrategy"):
+		return &hypershiftv1beta1.CustomDomainPublishingStrategyApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("DataPlaneInfrastructurePlacement"):
+		return &hypershiftv1beta1.DataPlaneInfrastructurePlacementApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("DefaultIngressControllerSpec"):
+		return &hypershiftv1beta1.DefaultIngressControllerSpecApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("DeletionPolicy"):
+		return &hypershiftv1beta1.DeletionPolicyApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("DNSSpec"):
 		return &hypershiftv1beta1.DNSSpecApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("EtcdSpec"):
@@ -284,6 +396,10 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &hypershiftv1beta1.FilterApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("HostedCluster"):
 		return &hypershiftv1beta1.HostedClusterApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("HostedClusterComponentStatus"):
+		return &hypershiftv1beta1.HostedClusterComponentStatusApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("HostedClusterLifetime"):
+		return &hypershiftv1beta1.HostedClusterLifetimeApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("HostedClusterSpec"):
 		return &hypershiftv1beta1.HostedClusterSpecApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("HostedClusterStatus"):
@@ -306,12 +422,18 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &hypershiftv1beta1.IBMCloudPlatformSpecApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ImageContentSource"):
 		return &hypershiftv1beta1.ImageContentSourceApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("ImageVerificationPolicy"):
+		return &hypershiftv1beta1.ImageVerificationPolicyApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("InPlaceUpgrade"):
 		return &hypershiftv1beta1.InPlaceUpgradeApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("KMSSpec"):
 		return &hypershiftv1beta1.KMSSpecApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("KubeconfigPublishingSpec"):
+		return &hypershiftv1beta1.KubeconfigPublishingSpecApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("KubeconfigSecretRef"):
 		return &hypershiftv1beta1.KubeconfigSecretRefApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("KubeconfigStore"):
+		return &hypershiftv1beta1.KubeconfigStoreApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("KubevirtCachingStrategy"):
 		return &hypershiftv1beta1.KubevirtCachingStrategyApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("KubevirtCompute"):
@@ -332,6 +454,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &hypershiftv1beta1.KubevirtPlatformCredentialsApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("KubevirtPlatformSpec"):
 		return &hypershiftv1beta1.KubevirtPlatformSpecApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("KubevirtPlatformStatus"):
+		return &hypershiftv1beta1.KubevirtPlatformStatusApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("KubevirtRootVolume"):
 		return &hypershiftv1beta1.KubevirtRootVolumeApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("KubevirtStorageClassMapping"):
@@ -344,8 +468,12 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &hypershiftv1beta1.KubevirtVolumeSnapshotClassMappingApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("LoadBalancerPublishingStrategy"):
 		return &hypershiftv1beta1.LoadBalancerPublishingStrategyApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("MachineDeletionHook"):
+		return &hypershiftv1beta1.MachineDeletionHookApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("MachineNetworkEntry"):
 		return &hypershiftv1beta1.MachineNetworkEntryApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("ManagedEtcdDisruptionSpec"):
+		return &hypershiftv1beta1.ManagedEtcdDisruptionSpecApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ManagedEtcdSpec"):
 		return &hypershiftv1beta1.ManagedEtcdSpecApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ManagedEtcdStorageSpec"):
@@ -354,20 +482,38 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &hypershiftv1beta1.NodePoolApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("NodePoolAutoScaling"):
 		return &hypershiftv1beta1.NodePoolAutoScalingApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("NodePoolBootstrap"):
+		return &hypershiftv1beta1.NodePoolBootstrapApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("NodePoolBootstrapProxy"):
+		return &hypershiftv1beta1.NodePoolBootstrapProxyApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("NodePoolCapacityStatus"):
+		return &hypershiftv1beta1.NodePoolCapacityStatusApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("NodePoolCondition"):
 		return &hypershiftv1beta1.NodePoolConditionApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("NodePoolContainerRuntime"):
+		return &hypershiftv1beta1.NodePoolContainerRuntimeApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("NodePoolMachineStatus"):
+		return &hypershiftv1beta1.NodePoolMachineStatusApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("NodePoolManagement"):
 		return &hypershiftv1beta1.NodePoolManagementApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("NodePoolOSImage"):
+		return &hypershiftv1beta1.NodePoolOSImageApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("NodePoolPlatform"):
 		return &hypershiftv1beta1.NodePoolPlatformApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("NodePoolPlatformStatus"):
 		return &hypershiftv1beta1.NodePoolPlatformStatusApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("NodePoolRollout"):
+		return &hypershiftv1beta1.NodePoolRolloutApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("NodePoolSpec"):
 		return &hypershiftv1beta1.NodePoolSpecApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("NodePoolStatus"):
 		return &hypershiftv1beta1.NodePoolStatusApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("NodePortPublishingStrategy"):
 		return &hypershiftv1beta1.NodePortPublishingStrategyApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("OLMCatalogs"):
+		return &hypershiftv1beta1.OLMCatalogsApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("OLMCatalogSource"):
+		return &hypershiftv1beta1.OLMCatalogSourceApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("PersistentVolumeEtcdStorageSpec"):
 		return &hypershiftv1beta1.PersistentVolumeEtcdStorageSpecApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("PlatformSpec"):
@@ -382,6 +528,10 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &hypershiftv1beta1.PowerVSResourceReferenceApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("PowerVSVPC"):
 		return &hypershiftv1beta1.PowerVSVPCApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("PublishedKubeconfig"):
+		return &hypershiftv1beta1.PublishedKubeconfigApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("PublishedKubeconfigStatus"):
+		return &hypershiftv1beta1.PublishedKubeconfigStatusApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("Release"):
 		return &hypershiftv1beta1.ReleaseApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ReplaceUpgrade"):
@@ -390,8 +540,16 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &hypershiftv1beta1.RollingUpdateApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("RoutePublishingStrategy"):
 		return &hypershiftv1beta1.RoutePublishingStrategyApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("S3SecretsBackupStoreSpec"):
+		return &hypershiftv1beta1.S3SecretsBackupStoreSpecApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("SecretEncryptionSpec"):
 		return &hypershiftv1beta1.SecretEncryptionSpecApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("SecretsBackupSpec"):
+		return &hypershiftv1beta1.SecretsBackupSpecApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("SecretsBackupStatus"):
+		return &hypershiftv1beta1.SecretsBackupStatusApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("SecretsBackupStore"):
+		return &hypershiftv1beta1.SecretsBackupStoreApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ServiceNetworkEntry"):
 		return &hypershiftv1beta1.ServiceNetworkEntryApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ServicePublishingStrategy"):
@@ -402,6 +560,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &hypershiftv1beta1.TaintApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("UnmanagedEtcdSpec"):
 		return &hypershiftv1beta1.UnmanagedEtcdSpecApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("VaultKubeconfigStoreSpec"):
+		return &hypershiftv1beta1.VaultKubeconfigStoreSpecApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("Volume"):
 		return &hypershiftv1beta1.VolumeApplyConfiguration{}
 
@@ -414,6 +574,10 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &applyconfigurationschedulingv1alpha1.ClusterSizingConfigurationStatusApplyConfiguration{}
 	case schedulingv1alpha1.SchemeGroupVersion.WithKind("ConcurrencyConfiguration"):
 		return &applyconfigurationschedulingv1alpha1.ConcurrencyConfigurationApplyConfiguration{}
+	case schedulingv1alpha1.SchemeGroupVersion.WithKind("ControlPlaneLimitRange"):
+		return &applyconfigurationschedulingv1alpha1.ControlPlaneLimitRangeApplyConfiguration{}
+	case schedulingv1alpha1.SchemeGroupVersion.WithKind("ControlPlaneResourceQuota"):
+		return &applyconfigurationschedulingv1alpha1.ControlPlaneResourceQuotaApplyConfiguration{}
 	case schedulingv1alpha1.SchemeGroupVersion.WithKind("Effects"):
 		return &applyconfigurationschedulingv1alpha1.EffectsApplyConfiguration{}
 	case schedulingv1alpha1.SchemeGroupVersion.WithKind("Management"):
@@ -0,0 +1,17 @@
+package analyze
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "analyze",
+		Short:        "Commands for analyzing HyperShift diagnostic data offline",
+		SilenceUsage: true,
+	}
+
+	cmd.AddCommand(NewDumpCommand())
+
+	return cmd
+}
@@ -0,0 +1,412 @@
+package analyze
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
+
+	"github.com/openshift/hypershift/cmd/cluster/core"
+	"github.com/openshift/hypershift/cmd/log"
+)
+
+const (
+	SeverityCritical = "Critical"
+	SeverityWarning  = "Warning"
+
+	// maxLogLineLength bounds the lines of the log files which are scanned, longer lines are truncated.
+	maxLogLineLength = 1024 * 1024
+	// maxEvidenceLength bounds the log line quoted in a finding.
+	maxEvidenceLength = 300
+)
+
+type DumpOptions struct {
+	// Path is the archive created by 'hypershift dump cluster', or its artifact directory.
+	Path   string
+	Output string
+
+	Log logr.Logger
+}
+
+// Report is the outcome of the analysis of a dump.
+type Report struct {
+	Source string `json:"source"`
+	// DumpTime is the time the dump was taken, the expiry of certificates is checked against it.
+	DumpTime time.Time `json:"dumpTime"`
+	Objects  int       `json:"objects"`
+	LogFiles int       `json:"logFiles"`
+	Findings []Finding `json:"findings"`
+}
+
+// Finding is a problem found by a rule, with the action to take.
+type Finding struct {
+	Rule     string `json:"rule"`
+	Severity string `json:"severity"`
+	// Object is the object or log file of the dump the finding is about.
+	Object  string `json:"object"`
+	Message string `json:"message"`
+	Action  string `json:"action,omitempty"`
+}
+
+func NewDumpCommand() *cobra.Command {
+	opts := &DumpOptions{
+		Output: core.StatusOutputText,
+		Log:    log.Log,
+	}
+
+	cmd := &cobra.Command{
+		Use:          "dump",
+		Short:        "Analyzes a dump created by 'hypershift dump cluster' for known problems",
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringVar(&opts.Path, "dump", opts.Path, "Path to the archive created by 'hypershift dump cluster', or to its artifact directory (required)")
+	cmd.Flags().StringVarP(&opts.Output, "output", "o", opts.Output, "Output format. Supported options: text, json")
+
+	_ = cmd.MarkFlagRequired("dump")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if err := opts.Run(cmd.OutOrStdout()); err != nil {
+			opts.Log.Error(err, "Failed to analyze dump")
+			return err
+		}
+		return nil
+	}
+
+	return cmd
+}
+
+func (o *DumpOptions) Run(out io.Writer) error {
+	if o.Output != core.StatusOutputText && o.Output != core.StatusOutputJSON {
+		return fmt.Errorf("unsupported output format %q", o.Output)
+	}
+	d, err := loadDump(o.Path)
+	if err != nil {
+		return err
+	}
+	report := Analyze(d)
+	report.Source = o.Path
+	if o.Output == core.StatusOutputJSON {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+	return PrintReport(out, report)
+}
+
+// dump is the content of a dump the rules analyze.
+type dump struct {
+	objects []dumpObject
+	// logMatches are the known signatures found in the log files.
+	logMatches []logMatch
+	logFiles   int
+	// time is the modification time of the newest file of the dump.
+	time time.Time
+}
+
+// dumpObject is an object of a dump.
+type dumpObject struct {
+	// scope is the part of the path of the file the object is in before its namespace or cluster scoped directory,
+	// e.g. hostedcluster-example for the objects of the guest cluster, empty for the management cluster.
+	scope  string
+	object *unstructured.Unstructured
+}
+
+// String identifies the object in findings.
+func (o dumpObject) String() string {
+	name := o.object.GetName()
+	if o.object.GetNamespace() != "" {
+		name = o.object.GetNamespace() + "/" + name
+	}
+	s := fmt.Sprintf("%s %s", o.object.GetKind(), name)
+	if o.scope != "" {
+		s = o.scope + ": " + s
+	}
+	return s
+}
+
+// logMatch is a known signature found in a log file.
+type logMatch struct {
+	signature *logSignature
+	file      string
+	// line is the first line matching the signature.
+	line  string
+	count int
+}
+
+// loadDump reads the objects of a dump and scans its log files, from a directory or a tar archive, optionally gzipped.
+func loadDump(path string) (*dump, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dump: %w", err)
+	}
+	loader := &dumpLoader{byKey: map[string]dumpObject{}}
+	if info.IsDir() {
+		err = filepath.WalkDir(path, func(name string, entry fs.DirEntry, err error) error {
+			if err != nil || entry.IsDir() {
+				return err
+			}
+			info, err := entry.Info()
+			if err != nil {
+				return err
+			}
+			f, err := os.Open(name)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			rel, err := filepath.Rel(path, name)
+			if err != nil {
+				return err
+			}
+			return loader.add(filepath.ToSlash(rel), info.ModTime(), f)
+		})
+	} else {
+		err = loadArchive(path, loader)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dump %s: %w", path, err)
+	}
+	if len(loader.byKey) == 0 && loader.logFiles == 0 {
+		return nil, fmt.Errorf("%s contains no objects nor logs, it isn't a dump created by 'hypershift dump cluster'", path)
+	}
+	return loader.result(), nil
+}
+
+func loadArchive(path string, loader *dumpLoader) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	r := bufio.NewReader(f)
+	var archive io.Reader = r
+	if magic, err := r.Peek(2); err == nil && bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		archive = gz
+	}
+	tr := tar.NewReader(archive)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		if err := loader.add(strings.TrimPrefix(header.Name, "./"), header.ModTime, tr); err != nil {
+			return err
+		}
+	}
+}
+
+type dumpLoader struct {
+	dump
+	// byKey are the objects keyed by scope, group, kind, namespace and name, the same object can be in several files.
+	byKey map[string]dumpObject
+}
+
+func (l *dumpLoader) add(name string, modTime time.Time, r io.Reader) error {
+	if modTime.After(l.time) {
+		l.time = modTime
+	}
+	switch filepath.Ext(name) {
+	case ".yaml", ".yml", ".json":
+		l.addObjects(name, r)
+	case ".log":
+		l.logFiles++
+		return l.scanLog(name, r)
+	}
+	return nil
+}
+
+// addObjects adds the objects of a file, expanding lists. Files which aren't Kubernetes objects are ignored.
+func (l *dumpLoader) addObjects(name string, r io.Reader) {
+	scope := objectScope(name)
+	decoder := yaml.NewYAMLOrJSONDecoder(r, 4096)
+	for {
+		object := &unstructured.Unstructured{}
+		if err := decoder.Decode(&object.Object); err != nil {
+			return
+		}
+		if object.GetKind() == "" {
+			continue
+		}
+		if object.IsList() {
+			_ = object.EachListItem(func(item runtime.Object) error {
+				u := item.(*unstructured.Unstructured)
+				// The items of typed lists can omit their kind.
+				if u.GetKind() == "" {
+					u.SetAPIVersion(object.GetAPIVersion())
+					u.SetKind(strings.TrimSuffix(object.GetKind(), "List"))
+				}
+				l.addObject(scope, u)
+				return nil
+			})
+			continue
+		}
+		l.addObject(scope, object)
+	}
+}
+
+func (l *dumpLoader) addObject(scope string, object *unstructured.Unstructured) {
+	if object.GetKind() == "" || object.GetName() == "" {
+		return
+	}
+	gvk := object.GroupVersionKind()
+	key := strings.Join([]string{scope, gvk.Group, gvk.Kind, object.GetNamespace(), object.GetName()}, "/")
+	l.byKey[key] = dumpObject{scope: scope, object: object}
+}
+
+// objectScope returns the part of the path of a file before the namespaces or cluster-scoped-resources directory
+// written by oc adm inspect.
+func objectScope(name string) string {
+	parts := strings.Split(name, "/")
+	for i, part := range parts {
+		if part == "namespaces" || part == "cluster-scoped-resources" {
+			return strings.Join(parts[:i], "/")
+		}
+	}
+	return ""
+}
+
+// scanLog records the known signatures found in a log file, once per signature with the first matching line.
+func (l *dumpLoader) scanLog(name string, r io.Reader) error {
+	matches := map[*logSignature]*logMatch{}
+	var order []*logSignature
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxLogLineLength)
+	for scanner.Scan() {
+		line := scanner.Text()
+		for i := range logSignatures {
+			signature := &logSignatures[i]
+			if !signature.matches(line) {
+				continue
+			}
+			match, ok := matches[signature]
+			if !ok {
+				match = &logMatch{signature: signature, file: name, line: truncate(strings.TrimSpace(line), maxEvidenceLength)}
+				matches[signature] = match
+				order = append(order, signature)
+			}
+			match.count++
+		}
+	}
+	// Lines longer than the limit end the scan of the file, the signatures found until then are still reported.
+	if err := scanner.Err(); err != nil && !errors.Is(err, bufio.ErrTooLong) {
+		return fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	for _, signature := range order {
+		l.logMatches = append(l.logMatches, *matches[signature])
+	}
+	return nil
+}
+
+func (l *dumpLoader) result() *dump {
+	keys := make([]string, 0, len(l.byKey))
+	for key := range l.byKey {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	d := l.dump
+	for _, key := range keys {
+		d.objects = append(d.objects, l.byKey[key])
+	}
+	if d.time.IsZero() {
+		d.time = time.Now()
+	}
+	return &d
+}
+
+func truncate(s string, length int) string {
+	if len(s) <= length {
+		return s
+	}
+	return s[:length] + "..."
+}
+
+// Analyze runs the rules over the dump and returns their findings, the most severe first.
+func Analyze(d *dump) *Report {
+	report := &Report{
+		DumpTime: d.time,
+		Objects:  len(d.objects),
+		LogFiles: d.logFiles,
+		Findings: []Finding{},
+	}
+	for _, rule := range rules {
+		report.Findings = append(report.Findings, rule(d)...)
+	}
+	severityOrder := map[string]int{SeverityCritical: 0, SeverityWarning: 1}
+	sort.SliceStable(report.Findings, func(i, j int) bool {
+		a, b := report.Findings[i], report.Findings[j]
+		if a.Severity != b.Severity {
+			return severityOrder[a.Severity] < severityOrder[b.Severity]
+		}
+		if a.Rule != b.Rule {
+			return a.Rule < b.Rule
+		}
+		return a.Object < b.Object
+	})
+	return report
+}
+
+func PrintReport(out io.Writer, report *Report) error {
+	fmt.Fprintf(out, "Analyzed %d objects and %d log files of %s, dumped at %s\n\n", report.Objects, report.LogFiles, report.Source, report.DumpTime.UTC().Format(time.RFC3339))
+	if len(report.Findings) == 0 {
+		fmt.Fprintf(out, "No known problem found\n")
+		return nil
+	}
+
+	counts := map[string]int{}
+	for _, finding := range report.Findings {
+		counts[finding.Severity]++
+	}
+	fmt.Fprintf(out, "%d critical and %d warning findings:\n", counts[SeverityCritical], counts[SeverityWarning])
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(w, "  SEVERITY\tRULE\tOBJECT\tMESSAGE\n")
+	for _, finding := range report.Findings {
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", finding.Severity, finding.Rule, finding.Object, finding.Message)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	// The same rule usually suggests the same action for every object it finds, it is printed once.
+	printed := map[string]bool{}
+	var actions []string
+	for _, finding := range report.Findings {
+		if finding.Action == "" || printed[finding.Rule+finding.Action] {
+			continue
+		}
+		printed[finding.Rule+finding.Action] = true
+		actions = append(actions, fmt.Sprintf("  %s: %s", finding.Rule, finding.Action))
+	}
+	if len(actions) > 0 {
+		fmt.Fprintf(out, "\nSuggested actions:\n%s\n", strings.Join(actions, "\n"))
+	}
+	return nil
+}
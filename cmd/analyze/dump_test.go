@@ -0,0 +1,338 @@
+package analyze
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func testCertificate(t *testing.T, commonName string, notAfter time.Time) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:     notAfter,
+		IsCA:         true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func indent(s string, spaces int) string {
+	prefix := strings.Repeat(" ", spaces)
+	return prefix + strings.ReplaceAll(strings.TrimSpace(s), "\n", "\n"+prefix)
+}
+
+// testDumpFiles returns the files of a dump laid out as 'hypershift dump cluster' writes them, taken at dumpTime.
+func testDumpFiles(t *testing.T, dumpTime time.Time) map[string]string {
+	return map[string]string{
+		"namespaces/clusters/hypershift.openshift.io/hostedclusters/example.yaml": `
+apiVersion: hypershift.openshift.io/v1beta1
+kind: HostedCluster
+metadata:
+  namespace: clusters
+  name: example
+status:
+  conditions:
+  - type: ValidConfiguration
+    status: "False"
+    reason: InvalidConfiguration
+    message: the service CIDR overlaps the cluster CIDR
+  - type: ValidReleaseImage
+    status: "True"
+    reason: AsExpected
+  - type: Degraded
+    status: "False"
+    reason: AsExpected
+  - type: ReconciliationActive
+    status: Unknown
+    reason: StatusUnknown
+`,
+		"namespaces/clusters/hypershift.openshift.io/nodepools/example.yaml": `
+apiVersion: hypershift.openshift.io/v1beta1
+kind: NodePool
+metadata:
+  namespace: clusters
+  name: example
+status:
+  conditions:
+  - type: ValidMachineConfig
+    status: "True"
+  - type: AllMachinesReady
+    status: "False"
+    reason: WaitingForNodeRef
+    message: 2 of 3 machines are not ready
+`,
+		// oc adm inspect writes the resources of a type as a list, whose items can omit their kind.
+		"namespaces/clusters-example/core/pods.yaml": `
+apiVersion: v1
+kind: PodList
+items:
+- metadata:
+    namespace: clusters-example
+    name: kube-apiserver-0
+  status:
+    containerStatuses:
+    - name: kube-apiserver
+      restartCount: 12
+      state:
+        waiting:
+          reason: CrashLoopBackOff
+      lastState:
+        terminated:
+          reason: Error
+          exitCode: 1
+    - name: konnectivity-server
+      restartCount: 0
+      state:
+        running: {}
+- metadata:
+    namespace: clusters-example
+    name: etcd-0
+  status:
+    containerStatuses:
+    - name: etcd
+      restartCount: 3
+      state:
+        running: {}
+      lastState:
+        terminated:
+          reason: OOMKilled
+          exitCode: 137
+- metadata:
+    namespace: clusters-example
+    name: ignition-server-1
+  status:
+    phase: Pending
+    conditions:
+    - type: PodScheduled
+      status: "False"
+      reason: Unschedulable
+      message: 0/3 nodes are available
+- metadata:
+    namespace: clusters-example
+    name: cluster-api-1
+  status:
+    containerStatuses:
+    - name: manager
+      image: registry.example.com/capi:latest
+      restartCount: 0
+      state:
+        waiting:
+          reason: ImagePullBackOff
+          message: manifest unknown
+`,
+		// The same pod can also be written on its own.
+		"namespaces/clusters-example/pods/etcd-0/etcd-0.yaml": `
+apiVersion: v1
+kind: Pod
+metadata:
+  namespace: clusters-example
+  name: etcd-0
+status:
+  containerStatuses:
+  - name: etcd
+    restartCount: 3
+    state:
+      running: {}
+    lastState:
+      terminated:
+        reason: OOMKilled
+        exitCode: 137
+`,
+		"namespaces/clusters-example/core/configmaps.yaml": `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  namespace: clusters-example
+  name: root-ca
+data:
+  ca.crt: |
+` + indent(testCertificate(t, "root-ca", dumpTime.Add(24*time.Hour))+testCertificate(t, "next-root-ca", dumpTime.Add(365*24*time.Hour)), 4) + `
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  namespace: clusters-example
+  name: client-ca
+data:
+  ca.crt: |
+` + indent(testCertificate(t, "client-ca", dumpTime.Add(-time.Hour)), 4) + `
+`,
+		"hostedcluster-example/namespaces/openshift-config/core/configmaps.yaml": `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  namespace: openshift-config
+  name: valid-ca
+data:
+  ca.crt: |
+` + indent(testCertificate(t, "valid-ca", dumpTime.Add(365*24*time.Hour)), 4) + `
+`,
+		"namespaces/clusters-example/core/pods/logs/etcd-0-etcd.log": strings.Join([]string{
+			`{"level":"info","msg":"starting etcd"}`,
+			`{"level":"warn","msg":"slow fdatasync","took":"1.2s"}`,
+			`{"level":"warn","msg":"alarm raised","alarm":"alarm:NOSPACE"}`,
+			`{"level":"error","msg":"rejected request","error":"etcdserver: mvcc: database space exceeded"}`,
+		}, "\n"),
+		"namespaces/hypershift/core/pods/logs/operator-7f9-operator.log": "E1015 reconciler error: InvalidClientTokenId: The security token included in the request is invalid\n",
+		"event-filter.html": "<html></html>",
+		"timestamp":         "2026-10-15 12:00:00 +0000 UTC",
+	}
+}
+
+func writeTestDumpDir(t *testing.T, files map[string]string, dumpTime time.Time) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, dumpTime, dumpTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func writeTestDumpArchive(t *testing.T, files map[string]string, dumpTime time.Time) string {
+	buf := &bytes.Buffer{}
+	gz := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: "./" + name, Mode: 0644, Size: int64(len(content)), ModTime: dumpTime, Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "hypershift-dump.tar.gz")
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestAnalyzeDump(t *testing.T) {
+	dumpTime := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	files := testDumpFiles(t, dumpTime)
+
+	type finding struct {
+		rule, severity, object string
+	}
+	expected := []finding{
+		{"CertificateExpiry", SeverityCritical, "ConfigMap clusters-example/client-ca"},
+		{"CloudCredentials", SeverityCritical, "namespaces/hypershift/core/pods/logs/operator-7f9-operator.log"},
+		{"CrashLoopingContainers", SeverityCritical, "Pod clusters-example/kube-apiserver-0"},
+		{"EtcdQuota", SeverityCritical, "namespaces/clusters-example/core/pods/logs/etcd-0-etcd.log"},
+		{"HostedClusterConditions", SeverityCritical, "HostedCluster clusters/example"},
+		{"ImagePullFailures", SeverityCritical, "Pod clusters-example/cluster-api-1"},
+		{"CertificateExpiry", SeverityWarning, "ConfigMap clusters-example/root-ca"},
+		{"EtcdSlowDisk", SeverityWarning, "namespaces/clusters-example/core/pods/logs/etcd-0-etcd.log"},
+		{"NodePoolConditions", SeverityWarning, "NodePool clusters/example"},
+		{"OOMKilledContainers", SeverityWarning, "Pod clusters-example/etcd-0"},
+		{"UnschedulablePods", SeverityWarning, "Pod clusters-example/ignition-server-1"},
+	}
+
+	for _, tc := range []struct {
+		name string
+		path string
+	}{
+		{name: "When the dump is an artifact directory it should report the known problems", path: writeTestDumpDir(t, files, dumpTime)},
+		{name: "When the dump is a gzipped archive it should report the known problems", path: writeTestDumpArchive(t, files, dumpTime)},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			d, err := loadDump(tc.path)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(d.time).To(BeTemporally("==", dumpTime))
+			g.Expect(d.logFiles).To(Equal(2))
+
+			report := Analyze(d)
+			// The HostedCluster, the NodePool, 4 pods and 3 ConfigMaps.
+			g.Expect(report.Objects).To(Equal(9))
+			var actual []finding
+			for _, f := range report.Findings {
+				actual = append(actual, finding{f.Rule, f.Severity, f.Object})
+			}
+			g.Expect(actual).To(Equal(expected))
+
+			g.Expect(report.Findings[4].Message).To(Equal("ValidConfiguration is False (InvalidConfiguration): the service CIDR overlaps the cluster CIDR"))
+			g.Expect(report.Findings[2].Message).To(Equal("Container kube-apiserver is crashlooping after 12 restarts, last terminated with Error (exit code 1)"))
+			g.Expect(report.Findings[2].Action).To(ContainSubstring("namespaces/clusters-example/core/pods/logs/kube-apiserver-0-kube-apiserver-previous.log"))
+			g.Expect(report.Findings[3].Message).To(ContainSubstring("2 lines like: {\"level\":\"warn\",\"msg\":\"alarm raised\",\"alarm\":\"alarm:NOSPACE\"}"))
+			g.Expect(report.Findings[6].Message).To(ContainSubstring(`Certificate "root-ca" in key ca.crt expires at 2026-10-16T12:00:00Z, 1 days after the dump`))
+		})
+	}
+
+	t.Run("When the path isn't a dump it should fail", func(t *testing.T) {
+		g := NewWithT(t)
+		_, err := loadDump(writeTestDumpDir(t, map[string]string{"README.md": "not a dump"}, dumpTime))
+		g.Expect(err).To(MatchError(ContainSubstring("contains no objects nor logs")))
+	})
+}
+
+func TestPrintReport(t *testing.T) {
+	g := NewWithT(t)
+	report := &Report{
+		Source:   "hypershift-dump.tar.gz",
+		DumpTime: time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC),
+		Objects:  10,
+		LogFiles: 2,
+		Findings: []Finding{
+			{Rule: "CrashLoopingContainers", Severity: SeverityCritical, Object: "Pod ns/a", Message: "Container a is crashlooping", Action: "Check the logs of a"},
+			{Rule: "OOMKilledContainers", Severity: SeverityWarning, Object: "Pod ns/b", Message: "Container b was OOM killed", Action: "Check the memory"},
+			{Rule: "OOMKilledContainers", Severity: SeverityWarning, Object: "Pod ns/c", Message: "Container c was OOM killed", Action: "Check the memory"},
+		},
+	}
+	out := &bytes.Buffer{}
+	g.Expect(PrintReport(out, report)).To(Succeed())
+	g.Expect(out.String()).To(Equal(`Analyzed 10 objects and 2 log files of hypershift-dump.tar.gz, dumped at 2026-10-15T12:00:00Z
+
+1 critical and 2 warning findings:
+  SEVERITY  RULE                    OBJECT    MESSAGE
+  Critical  CrashLoopingContainers  Pod ns/a  Container a is crashlooping
+  Warning   OOMKilledContainers     Pod ns/b  Container b was OOM killed
+  Warning   OOMKilledContainers     Pod ns/c  Container c was OOM killed
+
+Suggested actions:
+  CrashLoopingContainers: Check the logs of a
+  OOMKilledContainers: Check the memory
+`))
+
+	out.Reset()
+	report.Findings = nil
+	g.Expect(PrintReport(out, report)).To(Succeed())
+	g.Expect(out.String()).To(HaveSuffix("No known problem found\n"))
+}
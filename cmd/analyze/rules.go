@@ -0,0 +1,361 @@
+package analyze
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+)
+
+const (
+	// restartThreshold is the number of restarts from which a container which isn't crashlooping anymore is reported.
+	restartThreshold = 5
+	// certificateExpiryWarningPeriod is how long before their expiry certificates are reported.
+	certificateExpiryWarningPeriod = 30 * 24 * time.Hour
+)
+
+// rules are run in order over a dump, each returns the problems it found.
+var rules = []func(d *dump) []Finding{
+	checkHostedClusterConditions,
+	checkNodePoolConditions,
+	checkPods,
+	checkCertificates,
+	checkLogSignatures,
+}
+
+// expectedCondition is a condition whose status, when it isn't the expected one, points at a problem.
+type expectedCondition struct {
+	conditionType string
+	status        string
+	severity      string
+	action        string
+}
+
+var hostedClusterExpectedConditions = []expectedCondition{
+	{conditionType: string(hyperv1.ValidHostedClusterConfiguration), status: "True", severity: SeverityCritical,
+		action: "Fix the configuration of the HostedCluster the message points at"},
+	{conditionType: string(hyperv1.SupportedHostedCluster), status: "True", severity: SeverityCritical,
+		action: "The HyperShift operator doesn't support the HostedCluster, check the supported versions and platforms of the operator"},
+	{conditionType: string(hyperv1.ValidReleaseImage), status: "True", severity: SeverityCritical,
+		action: "Use a release image supported by the HyperShift operator, and check that the pull secret can pull it"},
+	{conditionType: string(hyperv1.ValidReleaseInfo), status: "True", severity: SeverityCritical,
+		action: "Check that the release image can be pulled from the management cluster with the pull secret of the HostedCluster"},
+	{conditionType: string(hyperv1.ValidHostedControlPlaneConfiguration), status: "True", severity: SeverityCritical,
+		action: "Check the logs of the control plane operator in the control plane namespace"},
+	{conditionType: string(hyperv1.ValidOIDCConfiguration), status: "True", severity: SeverityCritical,
+		action: "Check the OIDC bucket configured for the HyperShift operator and its permissions"},
+	{conditionType: string(hyperv1.ValidAWSIdentityProvider), status: "True", severity: SeverityCritical,
+		action: "Check that the OIDC provider of the HostedCluster exists in IAM and that the roles of the HostedCluster trust it"},
+	{conditionType: string(hyperv1.ValidAWSKMSConfig), status: "True", severity: SeverityCritical,
+		action: "Check that the KMS keys exist and that the KMS role of the HostedCluster is allowed to use them"},
+	{conditionType: string(hyperv1.ValidAzureKMSConfig), status: "True", severity: SeverityCritical,
+		action: "Check that the Key Vault keys exist and that the KMS identity of the HostedCluster is allowed to use them"},
+	{conditionType: string(hyperv1.PlatformCredentialsFound), status: "True", severity: SeverityCritical,
+		action: "Check that the cloud credentials referenced by the HostedCluster exist and are valid"},
+	{conditionType: string(hyperv1.InfrastructureReady), status: "True", severity: SeverityCritical,
+		action: "Check the services and their publishing strategies in the control plane namespace"},
+	{conditionType: string(hyperv1.EtcdAvailable), status: "True", severity: SeverityCritical,
+		action: "Check the etcd pods, their logs and their persistent volumes in the control plane namespace"},
+	{conditionType: string(hyperv1.EtcdQuorumAtRisk), status: "False", severity: SeverityCritical,
+		action: "Don't drain or restart other etcd members until the unavailable ones are back"},
+	{conditionType: string(hyperv1.KubeAPIServerAvailable), status: "True", severity: SeverityCritical,
+		action: "Check the kube-apiserver pods and their logs in the control plane namespace"},
+	{conditionType: string(hyperv1.HostedClusterDegraded), status: "False", severity: SeverityCritical,
+		action: "Check the Deployments and StatefulSets of the control plane namespace the message points at"},
+	{conditionType: string(hyperv1.HostedClusterAvailable), status: "True", severity: SeverityCritical},
+	{conditionType: string(hyperv1.IgnitionEndpointAvailable), status: "True", severity: SeverityWarning,
+		action: "Check the ignition-server Deployment and its service publishing strategy, nodes can't join without it"},
+	{conditionType: string(hyperv1.ReconciliationActive), status: "True", severity: SeverityWarning,
+		action: "The reconciliation is paused, unset spec.pausedUntil to resume it"},
+}
+
+var nodePoolExpectedConditions = []expectedCondition{
+	{conditionType: hyperv1.NodePoolValidReleaseImageConditionType, status: "True", severity: SeverityCritical,
+		action: "Use a release image supported by the HostedCluster, NodePools can't be newer than their control plane"},
+	{conditionType: hyperv1.NodePoolValidMachineConfigConditionType, status: "True", severity: SeverityCritical,
+		action: "Fix the ConfigMaps referenced by spec.config of the NodePool"},
+	{conditionType: hyperv1.NodePoolValidTuningConfigConditionType, status: "True", severity: SeverityCritical,
+		action: "Fix the ConfigMaps referenced by spec.tuningConfig of the NodePool"},
+	{conditionType: hyperv1.NodePoolValidPlatformImageType, status: "True", severity: SeverityCritical,
+		action: "Check that the release image provides a boot image for the platform, region and architecture of the NodePool"},
+	{conditionType: hyperv1.NodePoolValidReleaseImageArchConditionType, status: "True", severity: SeverityCritical,
+		action: "Use a multi-architecture release image, or the architecture of the release image for the NodePool"},
+	{conditionType: hyperv1.NodePoolValidGeneratedPayloadConditionType, status: "True", severity: SeverityCritical,
+		action: "Check the logs of the ignition-server Deployment in the control plane namespace"},
+	{conditionType: hyperv1.NodePoolValidMachineTemplateConditionType, status: "True", severity: SeverityCritical,
+		action: "Fix the platform configuration of the NodePool"},
+	{conditionType: hyperv1.NodePoolClusterNetworkCIDRConflictType, status: "False", severity: SeverityCritical,
+		action: "The cluster network of the HostedCluster overlaps the network of the machines, recreate the HostedCluster with non overlapping networks"},
+	{conditionType: hyperv1.NodePoolAllMachinesReadyConditionType, status: "True", severity: SeverityWarning,
+		action: "Check the Machines of the NodePool and the logs of the cluster-api provider in the control plane namespace"},
+	{conditionType: hyperv1.NodePoolAllNodesHealthyConditionType, status: "True", severity: SeverityWarning,
+		action: "Check the nodes of the NodePool in the guest cluster dump"},
+	{conditionType: hyperv1.NodePoolReconciliationActiveConditionType, status: "True", severity: SeverityWarning,
+		action: "The reconciliation is paused, unset spec.pausedUntil to resume it"},
+}
+
+func checkHostedClusterConditions(d *dump) []Finding {
+	return checkConditions(d.objectsOfKind(hyperv1.GroupVersion.Group, "HostedCluster"), "HostedClusterConditions", hostedClusterExpectedConditions)
+}
+
+func checkNodePoolConditions(d *dump) []Finding {
+	return checkConditions(d.objectsOfKind(hyperv1.GroupVersion.Group, "NodePool"), "NodePoolConditions", nodePoolExpectedConditions)
+}
+
+// checkConditions reports the conditions of the objects whose status isn't the expected one. Unknown conditions are
+// not reported, they are usually not computed yet.
+func checkConditions(objects []dumpObject, rule string, expected []expectedCondition) []Finding {
+	var findings []Finding
+	for _, object := range objects {
+		conditions, _, _ := unstructured.NestedSlice(object.object.Object, "status", "conditions")
+		byType := map[string]map[string]interface{}{}
+		for _, condition := range conditions {
+			if condition, ok := condition.(map[string]interface{}); ok {
+				conditionType, _ := condition["type"].(string)
+				byType[conditionType] = condition
+			}
+		}
+		for _, e := range expected {
+			condition, ok := byType[e.conditionType]
+			if !ok {
+				continue
+			}
+			status, _ := condition["status"].(string)
+			if status == e.status || status == "Unknown" {
+				continue
+			}
+			reason, _ := condition["reason"].(string)
+			message, _ := condition["message"].(string)
+			findings = append(findings, Finding{
+				Rule:     rule,
+				Severity: e.severity,
+				Object:   object.String(),
+				Message:  fmt.Sprintf("%s is %s (%s): %s", e.conditionType, status, reason, message),
+				Action:   e.action,
+			})
+		}
+	}
+	return findings
+}
+
+// checkPods reports the containers which crashloop, can't pull their image, were OOM killed or restarted often, and
+// the pods which can't be scheduled.
+func checkPods(d *dump) []Finding {
+	var findings []Finding
+	for _, object := range d.objectsOfKind("", "Pod") {
+		pod := &corev1.Pod{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(object.object.Object, pod); err != nil {
+			continue
+		}
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == corev1.PodScheduled && condition.Status == corev1.ConditionFalse && condition.Reason == corev1.PodReasonUnschedulable {
+				findings = append(findings, Finding{
+					Rule:     "UnschedulablePods",
+					Severity: SeverityWarning,
+					Object:   object.String(),
+					Message:  fmt.Sprintf("The pod can't be scheduled: %s", condition.Message),
+					Action:   "Check the capacity, taints and labels of the nodes against the resource requests, tolerations and affinity of the pod",
+				})
+			}
+		}
+
+		statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+		for _, status := range statuses {
+			finding := Finding{Object: object.String()}
+			lastTermination := ""
+			if terminated := status.LastTerminationState.Terminated; terminated != nil {
+				lastTermination = fmt.Sprintf(", last terminated with %s (exit code %d)", terminated.Reason, terminated.ExitCode)
+			}
+			waiting := status.State.Waiting
+			switch {
+			case waiting != nil && waiting.Reason == "CrashLoopBackOff":
+				finding.Rule = "CrashLoopingContainers"
+				finding.Severity = SeverityCritical
+				finding.Message = fmt.Sprintf("Container %s is crashlooping after %d restarts%s", status.Name, status.RestartCount, lastTermination)
+				finding.Action = fmt.Sprintf("Check the logs of the previous run of the container, in namespaces/%s/core/pods/logs/%s-%s-previous.log", pod.Namespace, pod.Name, status.Name)
+			case waiting != nil && (waiting.Reason == "ImagePullBackOff" || waiting.Reason == "ErrImagePull" || waiting.Reason == "InvalidImageName"):
+				finding.Rule = "ImagePullFailures"
+				finding.Severity = SeverityCritical
+				finding.Message = fmt.Sprintf("Container %s can't pull image %s: %s", status.Name, status.Image, waiting.Message)
+				finding.Action = "Check the pull secret of the HostedCluster, and the image content sources and mirrors of disconnected clusters"
+			case status.LastTerminationState.Terminated != nil && status.LastTerminationState.Terminated.Reason == "OOMKilled":
+				finding.Rule = "OOMKilledContainers"
+				finding.Severity = SeverityWarning
+				finding.Message = fmt.Sprintf("Container %s was OOM killed, it restarted %d times", status.Name, status.RestartCount)
+				finding.Action = "Check the memory limit of the container and the memory available on its node"
+			case status.RestartCount >= restartThreshold:
+				finding.Rule = "RestartingContainers"
+				finding.Severity = SeverityWarning
+				finding.Message = fmt.Sprintf("Container %s restarted %d times%s", status.Name, status.RestartCount, lastTermination)
+				finding.Action = fmt.Sprintf("Check the logs of the previous run of the container, in namespaces/%s/core/pods/logs/%s-%s-previous.log", pod.Namespace, pod.Name, status.Name)
+			default:
+				continue
+			}
+			findings = append(findings, finding)
+		}
+	}
+	return findings
+}
+
+// checkCertificates reports the certificates of the ConfigMaps, e.g. the CA bundles of the control plane and the
+// trust bundles, which expired or expire soon after the dump was taken. Secrets are not part of dumps.
+func checkCertificates(d *dump) []Finding {
+	var findings []Finding
+	for _, object := range d.objectsOfKind("", "ConfigMap") {
+		data, _, _ := unstructured.NestedStringMap(object.object.Object, "data")
+		keys := make([]string, 0, len(data))
+		for key := range data {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			certificate := firstExpiringCertificate([]byte(data[key]))
+			if certificate == nil {
+				continue
+			}
+			finding := Finding{Rule: "CertificateExpiry", Object: object.String()}
+			switch {
+			case !certificate.NotAfter.After(d.time):
+				finding.Severity = SeverityCritical
+				finding.Message = fmt.Sprintf("Certificate %q in key %s expired at %s, before the dump", certificate.Subject.CommonName, key, certificate.NotAfter.UTC().Format(time.RFC3339))
+				finding.Action = "Connections verified with expired certificates fail, check that the control plane operator is running to rotate them, or replace the bundle the ConfigMap is created from"
+			case certificate.NotAfter.Sub(d.time) < certificateExpiryWarningPeriod:
+				finding.Severity = SeverityWarning
+				finding.Message = fmt.Sprintf("Certificate %q in key %s expires at %s, %d days after the dump", certificate.Subject.CommonName, key, certificate.NotAfter.UTC().Format(time.RFC3339), int(certificate.NotAfter.Sub(d.time).Hours()/24))
+				finding.Action = "Check that the control plane operator is running to rotate the certificate, or replace the bundle the ConfigMap is created from before it expires"
+			default:
+				continue
+			}
+			findings = append(findings, finding)
+		}
+	}
+	return findings
+}
+
+// firstExpiringCertificate returns the certificate of the PEM data which expires first, nil when it has none.
+func firstExpiringCertificate(data []byte) *x509.Certificate {
+	var first *x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return first
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			continue
+		}
+		if first == nil || certificate.NotAfter.Before(first.NotAfter) {
+			first = certificate
+		}
+	}
+}
+
+// logSignature is a log line which points at a known problem.
+type logSignature struct {
+	rule     string
+	severity string
+	// patterns are the substrings of the lines matching the signature, any of them matches.
+	patterns []string
+	message  string
+	action   string
+}
+
+func (s *logSignature) matches(line string) bool {
+	for _, pattern := range s.patterns {
+		if strings.Contains(line, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+var logSignatures = []logSignature{
+	{
+		rule:     "EtcdQuota",
+		severity: SeverityCritical,
+		patterns: []string{"mvcc: database space exceeded", "alarm:NOSPACE"},
+		message:  "etcd reached its space quota and only accepts reads and deletes",
+		action:   "Compact and defragment etcd, then disarm the NOSPACE alarm; increase spec.etcd.managed.storage of the HostedCluster if the data keeps growing",
+	},
+	{
+		rule:     "EtcdSlowDisk",
+		severity: SeverityWarning,
+		patterns: []string{"slow fdatasync", "apply request took too long"},
+		message:  "etcd is slowed down by its disk",
+		action:   "Use a storage class with lower latency for the etcd volumes, e.g. one backed by SSDs with provisioned IOPS",
+	},
+	{
+		rule:     "ExpiredCertificates",
+		severity: SeverityCritical,
+		patterns: []string{"x509: certificate has expired or is not yet valid"},
+		message:  "a connection failed because of an expired certificate",
+		action:   "Find which certificate expired from the log line and check that the control plane operator rotates it",
+	},
+	{
+		rule:     "UnknownAuthority",
+		severity: SeverityWarning,
+		patterns: []string{"x509: certificate signed by unknown authority"},
+		message:  "a connection failed because the certificate of the server isn't trusted",
+		action:   "Add the CA of the server, e.g. of a proxy or registry, to the additional trust bundle of the HostedCluster",
+	},
+	{
+		rule:     "DiskFull",
+		severity: SeverityCritical,
+		patterns: []string{"no space left on device"},
+		message:  "a volume or the disk of a node is full",
+		action:   "Free space on, or expand, the volume the log line points at",
+	},
+	{
+		rule:     "TooManyOpenFiles",
+		severity: SeverityWarning,
+		patterns: []string{"too many open files"},
+		message:  "a process reached its open files limit",
+		action:   "Check the inotify and open files limits of the nodes running the pod",
+	},
+	{
+		rule:     "CloudCredentials",
+		severity: SeverityCritical,
+		patterns: []string{"InvalidClientTokenId", "UnauthorizedOperation", "AuthorizationFailed", "ExpiredToken"},
+		message:  "a cloud API call was denied",
+		action:   "Check that the credentials or roles of the HostedCluster exist, aren't expired and have the permissions of the denied call",
+	},
+}
+
+// checkLogSignatures reports the known signatures found in the log files, once per log file.
+func checkLogSignatures(d *dump) []Finding {
+	var findings []Finding
+	for _, match := range d.logMatches {
+		findings = append(findings, Finding{
+			Rule:     match.signature.rule,
+			Severity: match.signature.severity,
+			Object:   match.file,
+			Message:  fmt.Sprintf("%s, %d lines like: %s", match.signature.message, match.count, match.line),
+			Action:   match.signature.action,
+		})
+	}
+	return findings
+}
+
+// objectsOfKind returns the objects of the dump of a group and kind.
+func (d *dump) objectsOfKind(group, kind string) []dumpObject {
+	var objects []dumpObject
+	for _, object := range d.objects {
+		gvk := object.object.GroupVersionKind()
+		if gvk.Group == group && gvk.Kind == kind {
+			objects = append(objects, object)
+		}
+	}
+	return objects
+}
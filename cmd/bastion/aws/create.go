@@ -3,7 +3,6 @@ package aws
 import (
 	"context"
 	"fmt"
-	"os"
 	"regexp"
 	"time"
 
@@ -12,14 +11,13 @@ import (
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/openshift/hypershift/cmd/log"
 	"github.com/spf13/cobra"
-	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/util/retry"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/cmd/bastion/common"
 	awsutil "github.com/openshift/hypershift/cmd/infra/aws/util"
-	"github.com/openshift/hypershift/cmd/util"
 )
 
 type CreateBastionOpts struct {
@@ -98,49 +96,25 @@ func (o *CreateBastionOpts) Validate() error {
 func (o *CreateBastionOpts) Run(ctx context.Context) (string, string, error) {
 
 	var infraID, region string
-	var sshPublicKey []byte
+	var c crclient.Client
+	var hostedCluster *hyperv1.HostedCluster
 
 	if len(o.Name) > 0 {
-		// Find HostedCluster and get AWS creds
-		c, err := util.GetClient()
+		var err error
+		c, hostedCluster, err = common.GetHostedCluster(ctx, o.Namespace, o.Name, hyperv1.AWSPlatform)
 		if err != nil {
 			return "", "", err
 		}
-
-		var hostedCluster hyperv1.HostedCluster
-		if err := c.Get(ctx, types.NamespacedName{Namespace: o.Namespace, Name: o.Name}, &hostedCluster); err != nil {
-			return "", "", fmt.Errorf("failed to get hostedcluster: %w", err)
-		}
-		if hostedCluster.Spec.Platform.AWS == nil {
-			return "", "", fmt.Errorf("hosted cluster's platform is not AWS")
-		}
-
 		infraID = hostedCluster.Spec.InfraID
 		region = hostedCluster.Spec.Platform.AWS.Region
-		log.Log.Info("Found hosted cluster", "namespace", hostedCluster.Namespace, "name", hostedCluster.Name, "infraID", infraID, "region", region)
-
-		if len(o.SSHKeyFile) == 0 {
-			if len(hostedCluster.Spec.SSHKey.Name) == 0 {
-				return "", "", fmt.Errorf("hosted cluster does not have a public SSH key and no SSH key file was specified")
-			}
-			sshKeySecret := &corev1.Secret{}
-			if err := c.Get(ctx, types.NamespacedName{Name: hostedCluster.Spec.SSHKey.Name, Namespace: o.Namespace}, sshKeySecret); err != nil {
-				return "", "", fmt.Errorf("cannot get secret with SSH key (%s/%s): %w", o.Namespace, hostedCluster.Spec.SSHKey.Name, err)
-			}
-			sshPublicKey = sshKeySecret.Data["id_rsa.pub"]
-		}
 	} else {
 		infraID = o.InfraID
 		region = o.Region
 	}
 
-	// Read SSH public key
-	if len(o.SSHKeyFile) > 0 {
-		var err error
-		sshPublicKey, err = os.ReadFile(o.SSHKeyFile)
-		if err != nil {
-			return "", "", fmt.Errorf("cannot read SSH public key from %s: %v", o.SSHKeyFile, err)
-		}
+	sshPublicKey, err := common.SSHPublicKey(ctx, c, hostedCluster, o.SSHKeyFile)
+	if err != nil {
+		return "", "", err
 	}
 
 	awsSession := awsutil.NewSession("cli-create-bastion", o.AWSCredentialsFile, o.AWSKey, o.AWSSecretKey, region)
@@ -548,7 +522,7 @@ func waitForInstanceRunning(ctx context.Context, ec2Client *ec2.EC2, instanceID
 }
 
 func securityGroupName(infraID string) string {
-	return fmt.Sprintf("%s-bastion-sg", infraID)
+	return common.ResourceName(infraID, "sg")
 }
 
 func vpcName(infraID string) string {
@@ -556,9 +530,9 @@ func vpcName(infraID string) string {
 }
 
 func keyPairName(infraID string) string {
-	return fmt.Sprintf("%s-bastion", infraID)
+	return common.ResourceName(infraID, "")
 }
 
 func instanceName(infraID string) string {
-	return fmt.Sprintf("%s-bastion", infraID)
+	return common.ResourceName(infraID, "")
 }
@@ -9,12 +9,11 @@ import (
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/openshift/hypershift/cmd/log"
 	"github.com/spf13/cobra"
-	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
 
 	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/cmd/bastion/common"
 	awsutil "github.com/openshift/hypershift/cmd/infra/aws/util"
-	"github.com/openshift/hypershift/cmd/util"
 )
 
 type DestroyBastionOpts struct {
@@ -85,23 +84,12 @@ func (o *DestroyBastionOpts) Run(ctx context.Context) error {
 	var infraID, region string
 
 	if len(o.Name) > 0 {
-		// Find HostedCluster and get AWS creds
-		c, err := util.GetClient()
+		_, hostedCluster, err := common.GetHostedCluster(ctx, o.Namespace, o.Name, hyperv1.AWSPlatform)
 		if err != nil {
 			return err
 		}
-
-		var hostedCluster hyperv1.HostedCluster
-		if err := c.Get(ctx, types.NamespacedName{Namespace: o.Namespace, Name: o.Name}, &hostedCluster); err != nil {
-			return fmt.Errorf("failed to get hostedcluster: %w", err)
-		}
-		if hostedCluster.Spec.Platform.AWS == nil {
-			return fmt.Errorf("hosted cluster's platform is not AWS")
-		}
-
 		infraID = hostedCluster.Spec.InfraID
 		region = hostedCluster.Spec.Platform.AWS.Region
-		log.Log.Info("Found hosted cluster", "namespace", hostedCluster.Namespace, "name", hostedCluster.Name, "infraID", infraID, "region", region)
 	} else {
 		infraID = o.InfraID
 		region = o.Region
@@ -0,0 +1,419 @@
+package azure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v5"
+	"github.com/spf13/cobra"
+	"k8s.io/utils/ptr"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/cmd/bastion/common"
+	"github.com/openshift/hypershift/cmd/log"
+	"github.com/openshift/hypershift/cmd/util"
+	"github.com/openshift/hypershift/support/azureutil"
+)
+
+const (
+	adminUsername = "azureuser"
+	vmSize        = "Standard_B1s"
+
+	// The SSH rule of the bastion is added to the network security group of the cluster with the first free priority
+	// from sshRuleMinPriority.
+	sshRuleMinPriority = 1000
+	sshRuleMaxPriority = 4096
+)
+
+type CreateBastionOpts struct {
+	Namespace              string
+	Name                   string
+	InfraID                string
+	Location               string
+	ResourceGroupName      string
+	SubnetID               string
+	NetworkSecurityGroupID string
+	SSHKeyFile             string
+	CredentialsFile        string
+	Wait                   bool
+}
+
+func NewCreateCommand() *cobra.Command {
+	opts := &CreateBastionOpts{
+		Namespace: "clusters",
+		Wait:      true,
+	}
+
+	cmd := &cobra.Command{
+		Use:          "azure",
+		Short:        "Creates Azure bastion virtual machine",
+		SilenceUsage: true,
+	}
+	cmd.Flags().StringVar(&opts.Namespace, "namespace", opts.Namespace, "The namespace of the hostedcluster")
+	cmd.Flags().StringVar(&opts.Name, "name", opts.Name, "The name of the hostedcluster")
+	cmd.Flags().StringVar(&opts.InfraID, "infra-id", opts.InfraID, "The infra ID to use for creating the bastion")
+	cmd.Flags().StringVar(&opts.Location, "location", opts.Location, "The location to use for creating the bastion")
+	cmd.Flags().StringVar(&opts.ResourceGroupName, "resource-group-name", opts.ResourceGroupName, "The resource group of the cluster to create the bastion in")
+	cmd.Flags().StringVar(&opts.SubnetID, "subnet-id", opts.SubnetID, "The ID of the subnet of the cluster to create the bastion in")
+	cmd.Flags().StringVar(&opts.NetworkSecurityGroupID, "network-security-group-id", opts.NetworkSecurityGroupID, "The ID of the network security group of the subnet, SSH access to the bastion is allowed in it")
+	cmd.Flags().StringVar(&opts.SSHKeyFile, "ssh-key-file", opts.SSHKeyFile, "File with public SSH key to use for bastion virtual machine")
+	cmd.Flags().StringVar(&opts.CredentialsFile, "azure-creds", opts.CredentialsFile, "Path to an Azure credentials file")
+	cmd.Flags().BoolVar(&opts.Wait, "wait", opts.Wait, "Wait for the virtual machine to be running")
+
+	_ = cmd.MarkFlagRequired("azure-creds")
+
+	_ = cmd.MarkFlagFilename("ssh-key-file")
+	_ = cmd.MarkFlagFilename("azure-creds")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if err := opts.Validate(); err != nil {
+			log.Log.Error(err, "Invalid arguments")
+			_ = cmd.Usage()
+			return nil
+		}
+
+		if vmName, publicIP, err := opts.Run(cmd.Context()); err != nil {
+			log.Log.Error(err, "Failed to create bastion")
+			return err
+		} else {
+			log.Log.Info("Successfully created bastion", "name", vmName, "publicIP", publicIP, "user", adminUsername)
+		}
+		return nil
+	}
+	return cmd
+}
+
+func (o *CreateBastionOpts) Validate() error {
+	if len(o.Name) > 0 {
+		if len(o.Namespace) == 0 {
+			return fmt.Errorf("a namespace must be specified if specifying a hosted cluster name")
+		}
+		if len(o.InfraID) > 0 || len(o.Location) > 0 || len(o.ResourceGroupName) > 0 || len(o.SubnetID) > 0 || len(o.NetworkSecurityGroupID) > 0 {
+			return fmt.Errorf("infra id, location, resource group name, subnet id and network security group id cannot be specified when specifying a hosted cluster name")
+		}
+	} else {
+		if len(o.InfraID) == 0 || len(o.Location) == 0 || len(o.ResourceGroupName) == 0 || len(o.SubnetID) == 0 || len(o.NetworkSecurityGroupID) == 0 {
+			return fmt.Errorf("infra id, location, resource group name, subnet id and network security group id must be specified when not specifying a hosted cluster name")
+		}
+		if len(o.SSHKeyFile) == 0 {
+			return fmt.Errorf("ssh-key-file must be specified when not specifying a hosted cluster name")
+		}
+	}
+	return nil
+}
+
+// bastionInfra is the infrastructure of the cluster the bastion is created in.
+type bastionInfra struct {
+	infraID                string
+	location               string
+	resourceGroupName      string
+	subnetID               string
+	networkSecurityGroupID string
+}
+
+func infraFromHostedCluster(hostedCluster *hyperv1.HostedCluster) (bastionInfra, error) {
+	if hostedCluster.Spec.Platform.Azure.SecurityGroupID == "" {
+		return bastionInfra{}, fmt.Errorf("hosted cluster has no network security group")
+	}
+	return bastionInfra{
+		infraID:                hostedCluster.Spec.InfraID,
+		location:               hostedCluster.Spec.Platform.Azure.Location,
+		resourceGroupName:      hostedCluster.Spec.Platform.Azure.ResourceGroupName,
+		subnetID:               hostedCluster.Spec.Platform.Azure.SubnetID,
+		networkSecurityGroupID: hostedCluster.Spec.Platform.Azure.SecurityGroupID,
+	}, nil
+}
+
+func (o *CreateBastionOpts) Run(ctx context.Context) (string, string, error) {
+	var infra bastionInfra
+	var c crclient.Client
+	var hostedCluster *hyperv1.HostedCluster
+
+	if len(o.Name) > 0 {
+		var err error
+		c, hostedCluster, err = common.GetHostedCluster(ctx, o.Namespace, o.Name, hyperv1.AzurePlatform)
+		if err != nil {
+			return "", "", err
+		}
+		if infra, err = infraFromHostedCluster(hostedCluster); err != nil {
+			return "", "", err
+		}
+	} else {
+		infra = bastionInfra{
+			infraID:                o.InfraID,
+			location:               o.Location,
+			resourceGroupName:      o.ResourceGroupName,
+			subnetID:               o.SubnetID,
+			networkSecurityGroupID: o.NetworkSecurityGroupID,
+		}
+	}
+
+	sshPublicKey, err := common.SSHPublicKey(ctx, c, hostedCluster, o.SSHKeyFile)
+	if err != nil {
+		return "", "", err
+	}
+
+	subscriptionID, azureCreds, err := util.SetupAzureCredentials(log.Log, nil, o.CredentialsFile)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to setup Azure credentials: %w", err)
+	}
+
+	publicIP, err := ensurePublicIPAddress(ctx, subscriptionID, infra, azureCreds)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to ensure public IP address for bastion: %w", err)
+	}
+
+	nic, err := ensureNetworkInterface(ctx, subscriptionID, infra, publicIP, azureCreds)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to ensure network interface for bastion: %w", err)
+	}
+
+	if err := ensureSSHRule(ctx, subscriptionID, infra, nic, azureCreds); err != nil {
+		return "", "", fmt.Errorf("failed to allow ssh access to bastion: %w", err)
+	}
+
+	vmName, err := runBastionVM(ctx, subscriptionID, infra, nic, string(sshPublicKey), o.Wait, azureCreds)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to run bastion virtual machine: %w", err)
+	}
+
+	return vmName, ptr.Deref(publicIP.Properties.IPAddress, ""), nil
+}
+
+func ensurePublicIPAddress(ctx context.Context, subscriptionID string, infra bastionInfra, azureCreds azcore.TokenCredential) (*armnetwork.PublicIPAddress, error) {
+	publicIPAddressClient, err := armnetwork.NewPublicIPAddressesClient(subscriptionID, azureCreds, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create public IP address client: %w", err)
+	}
+	name := publicIPAddressName(infra.infraID)
+	poller, err := publicIPAddressClient.BeginCreateOrUpdate(ctx, infra.resourceGroupName, name, armnetwork.PublicIPAddress{
+		Location: ptr.To(infra.location),
+		Properties: &armnetwork.PublicIPAddressPropertiesFormat{
+			PublicIPAddressVersion:   ptr.To(armnetwork.IPVersionIPv4),
+			PublicIPAllocationMethod: ptr.To(armnetwork.IPAllocationMethodStatic),
+		},
+		SKU: &armnetwork.PublicIPAddressSKU{
+			Name: ptr.To(armnetwork.PublicIPAddressSKUNameStandard),
+		},
+	}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create public IP address: %w", err)
+	}
+	resp, err := poller.PollUntilDone(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wait for public IP address creation: %w", err)
+	}
+	log.Log.Info("Ensured public IP address", "name", name, "address", ptr.Deref(resp.Properties.IPAddress, ""))
+	return &resp.PublicIPAddress, nil
+}
+
+func ensureNetworkInterface(ctx context.Context, subscriptionID string, infra bastionInfra, publicIP *armnetwork.PublicIPAddress, azureCreds azcore.TokenCredential) (*armnetwork.Interface, error) {
+	interfacesClient, err := armnetwork.NewInterfacesClient(subscriptionID, azureCreds, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create network interfaces client: %w", err)
+	}
+	name := networkInterfaceName(infra.infraID)
+	poller, err := interfacesClient.BeginCreateOrUpdate(ctx, infra.resourceGroupName, name, armnetwork.Interface{
+		Location: ptr.To(infra.location),
+		Properties: &armnetwork.InterfacePropertiesFormat{
+			IPConfigurations: []*armnetwork.InterfaceIPConfiguration{{
+				Name: ptr.To("ipconfig"),
+				Properties: &armnetwork.InterfaceIPConfigurationPropertiesFormat{
+					PrivateIPAllocationMethod: ptr.To(armnetwork.IPAllocationMethodDynamic),
+					Subnet:                    &armnetwork.Subnet{ID: ptr.To(infra.subnetID)},
+					PublicIPAddress:           &armnetwork.PublicIPAddress{ID: publicIP.ID},
+				},
+			}},
+		},
+	}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create network interface: %w", err)
+	}
+	resp, err := poller.PollUntilDone(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wait for network interface creation: %w", err)
+	}
+	if privateIPAddress(&resp.Interface) == "" {
+		return nil, fmt.Errorf("network interface %s has no private IP address", name)
+	}
+	log.Log.Info("Ensured network interface", "name", name, "privateIP", privateIPAddress(&resp.Interface))
+	return &resp.Interface, nil
+}
+
+func privateIPAddress(nic *armnetwork.Interface) string {
+	if nic.Properties == nil || len(nic.Properties.IPConfigurations) == 0 || nic.Properties.IPConfigurations[0].Properties == nil {
+		return ""
+	}
+	return ptr.Deref(nic.Properties.IPConfigurations[0].Properties.PrivateIPAddress, "")
+}
+
+// ensureSSHRule allows SSH access to the bastion in the network security group of the cluster, which otherwise
+// denies inbound traffic from the internet.
+func ensureSSHRule(ctx context.Context, subscriptionID string, infra bastionInfra, nic *armnetwork.Interface, azureCreds azcore.TokenCredential) error {
+	nsgName, nsgResourceGroupName, err := azureutil.GetNameAndResourceGroupFromNetworkSecurityGroupID(infra.networkSecurityGroupID)
+	if err != nil {
+		return err
+	}
+	securityGroupsClient, err := armnetwork.NewSecurityGroupsClient(subscriptionID, azureCreds, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create security groups client: %w", err)
+	}
+	nsg, err := securityGroupsClient.Get(ctx, nsgResourceGroupName, nsgName, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get network security group %s: %w", nsgName, err)
+	}
+	var rules []*armnetwork.SecurityRule
+	if nsg.Properties != nil {
+		rules = nsg.Properties.SecurityRules
+	}
+	name := sshRuleName(infra.infraID)
+	priority, err := sshRulePriority(rules, name)
+	if err != nil {
+		return err
+	}
+
+	securityRulesClient, err := armnetwork.NewSecurityRulesClient(subscriptionID, azureCreds, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create security rules client: %w", err)
+	}
+	poller, err := securityRulesClient.BeginCreateOrUpdate(ctx, nsgResourceGroupName, nsgName, name, armnetwork.SecurityRule{
+		Properties: &armnetwork.SecurityRulePropertiesFormat{
+			Access:                   ptr.To(armnetwork.SecurityRuleAccessAllow),
+			Direction:                ptr.To(armnetwork.SecurityRuleDirectionInbound),
+			Priority:                 ptr.To(priority),
+			Protocol:                 ptr.To(armnetwork.SecurityRuleProtocolTCP),
+			Description:              ptr.To("SSH access to the bastion of the hosted cluster"),
+			SourceAddressPrefix:      ptr.To("*"),
+			SourcePortRange:          ptr.To("*"),
+			DestinationAddressPrefix: ptr.To(privateIPAddress(nic)),
+			DestinationPortRange:     ptr.To("22"),
+		},
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create security rule: %w", err)
+	}
+	if _, err := poller.PollUntilDone(ctx, nil); err != nil {
+		return fmt.Errorf("failed to wait for security rule creation: %w", err)
+	}
+	log.Log.Info("Ensured ssh security rule", "networkSecurityGroup", nsgName, "name", name, "priority", priority)
+	return nil
+}
+
+// sshRulePriority returns the priority of the SSH rule of the bastion: its current priority if it already exists,
+// otherwise the first priority not used by another inbound rule.
+func sshRulePriority(rules []*armnetwork.SecurityRule, name string) (int32, error) {
+	used := map[int32]bool{}
+	for _, rule := range rules {
+		if rule == nil || rule.Properties == nil || rule.Properties.Priority == nil {
+			continue
+		}
+		if rule.Name != nil && strings.EqualFold(*rule.Name, name) {
+			return *rule.Properties.Priority, nil
+		}
+		if ptr.Deref(rule.Properties.Direction, "") == armnetwork.SecurityRuleDirectionInbound {
+			used[*rule.Properties.Priority] = true
+		}
+	}
+	for priority := int32(sshRuleMinPriority); priority <= sshRuleMaxPriority; priority++ {
+		if !used[priority] {
+			return priority, nil
+		}
+	}
+	return 0, fmt.Errorf("no free inbound security rule priority between %d and %d", sshRuleMinPriority, sshRuleMaxPriority)
+}
+
+func runBastionVM(ctx context.Context, subscriptionID string, infra bastionInfra, nic *armnetwork.Interface, sshPublicKey string, wait bool, azureCreds azcore.TokenCredential) (string, error) {
+	vmClient, err := armcompute.NewVirtualMachinesClient(subscriptionID, azureCreds, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create virtual machines client: %w", err)
+	}
+	name := vmName(infra.infraID)
+	if _, err := vmClient.Get(ctx, infra.resourceGroupName, name, nil); err == nil {
+		log.Log.Info("Found existing virtual machine", "name", name)
+		return name, nil
+	} else if !isNotFound(err) {
+		return "", fmt.Errorf("cannot check for existing virtual machine: %w", err)
+	}
+
+	poller, err := vmClient.BeginCreateOrUpdate(ctx, infra.resourceGroupName, name, armcompute.VirtualMachine{
+		Location: ptr.To(infra.location),
+		Properties: &armcompute.VirtualMachineProperties{
+			HardwareProfile: &armcompute.HardwareProfile{
+				VMSize: ptr.To(armcompute.VirtualMachineSizeTypes(vmSize)),
+			},
+			StorageProfile: &armcompute.StorageProfile{
+				ImageReference: &armcompute.ImageReference{
+					Publisher: ptr.To("Canonical"),
+					Offer:     ptr.To("0001-com-ubuntu-server-jammy"),
+					SKU:       ptr.To("22_04-lts-gen2"),
+					Version:   ptr.To("latest"),
+				},
+				OSDisk: &armcompute.OSDisk{
+					CreateOption: ptr.To(armcompute.DiskCreateOptionTypesFromImage),
+					DeleteOption: ptr.To(armcompute.DiskDeleteOptionTypesDelete),
+					ManagedDisk: &armcompute.ManagedDiskParameters{
+						StorageAccountType: ptr.To(armcompute.StorageAccountTypesStandardLRS),
+					},
+				},
+			},
+			OSProfile: &armcompute.OSProfile{
+				ComputerName:  ptr.To(name),
+				AdminUsername: ptr.To(adminUsername),
+				LinuxConfiguration: &armcompute.LinuxConfiguration{
+					DisablePasswordAuthentication: ptr.To(true),
+					SSH: &armcompute.SSHConfiguration{
+						PublicKeys: []*armcompute.SSHPublicKey{{
+							Path:    ptr.To(fmt.Sprintf("/home/%s/.ssh/authorized_keys", adminUsername)),
+							KeyData: ptr.To(sshPublicKey),
+						}},
+					},
+				},
+			},
+			NetworkProfile: &armcompute.NetworkProfile{
+				NetworkInterfaces: []*armcompute.NetworkInterfaceReference{{
+					ID: nic.ID,
+					Properties: &armcompute.NetworkInterfaceReferenceProperties{
+						Primary: ptr.To(true),
+					},
+				}},
+			},
+		},
+	}, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create virtual machine: %w", err)
+	}
+	log.Log.Info("Created virtual machine", "name", name)
+	if wait {
+		if _, err := poller.PollUntilDone(ctx, nil); err != nil {
+			return "", fmt.Errorf("failed to wait for virtual machine to be running: %w", err)
+		}
+	}
+	return name, nil
+}
+
+func isNotFound(err error) bool {
+	var responseErr *azcore.ResponseError
+	return errors.As(err, &responseErr) && responseErr.StatusCode == http.StatusNotFound
+}
+
+func vmName(infraID string) string {
+	return common.ResourceName(infraID, "")
+}
+
+func publicIPAddressName(infraID string) string {
+	return common.ResourceName(infraID, "pip")
+}
+
+func networkInterfaceName(infraID string) string {
+	return common.ResourceName(infraID, "nic")
+}
+
+func sshRuleName(infraID string) string {
+	return common.ResourceName(infraID, "ssh")
+}
@@ -0,0 +1,74 @@
+package azure
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v5"
+	"k8s.io/utils/ptr"
+)
+
+func TestSSHRulePriority(t *testing.T) {
+	rule := func(name string, priority int32, direction armnetwork.SecurityRuleDirection) *armnetwork.SecurityRule {
+		return &armnetwork.SecurityRule{
+			Name: ptr.To(name),
+			Properties: &armnetwork.SecurityRulePropertiesFormat{
+				Priority:  ptr.To(priority),
+				Direction: ptr.To(direction),
+			},
+		}
+	}
+
+	testCases := []struct {
+		name             string
+		rules            []*armnetwork.SecurityRule
+		expectedPriority int32
+		expectError      bool
+	}{
+		{
+			name:             "When there are no rules it should use the minimum priority",
+			expectedPriority: sshRuleMinPriority,
+		},
+		{
+			name: "When the ssh rule already exists it should keep its priority",
+			rules: []*armnetwork.SecurityRule{
+				rule("other", 1000, armnetwork.SecurityRuleDirectionInbound),
+				rule("infra-bastion-ssh", 1500, armnetwork.SecurityRuleDirectionInbound),
+			},
+			expectedPriority: 1500,
+		},
+		{
+			name: "When inbound priorities are taken it should use the first free one",
+			rules: []*armnetwork.SecurityRule{
+				rule("first", 1000, armnetwork.SecurityRuleDirectionInbound),
+				rule("second", 1001, armnetwork.SecurityRuleDirectionInbound),
+				rule("outbound", 1002, armnetwork.SecurityRuleDirectionOutbound),
+			},
+			expectedPriority: 1002,
+		},
+		{
+			name: "When all inbound priorities are taken it should fail",
+			rules: func() []*armnetwork.SecurityRule {
+				var rules []*armnetwork.SecurityRule
+				for priority := int32(sshRuleMinPriority); priority <= sshRuleMaxPriority; priority++ {
+					rules = append(rules, rule("rule", priority, armnetwork.SecurityRuleDirectionInbound))
+				}
+				return rules
+			}(),
+			expectError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			priority, err := sshRulePriority(tc.rules, sshRuleName("infra"))
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(priority).To(Equal(tc.expectedPriority))
+		})
+	}
+}
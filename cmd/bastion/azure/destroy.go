@@ -0,0 +1,202 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v5"
+	"github.com/spf13/cobra"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/cmd/bastion/common"
+	"github.com/openshift/hypershift/cmd/log"
+	"github.com/openshift/hypershift/cmd/util"
+	"github.com/openshift/hypershift/support/azureutil"
+)
+
+type DestroyBastionOpts struct {
+	Namespace              string
+	Name                   string
+	InfraID                string
+	ResourceGroupName      string
+	NetworkSecurityGroupID string
+	CredentialsFile        string
+}
+
+func NewDestroyCommand() *cobra.Command {
+	opts := DestroyBastionOpts{
+		Namespace: "clusters",
+	}
+	cmd := &cobra.Command{
+		Use:          "azure",
+		Short:        "Destroys Azure bastion virtual machine",
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringVar(&opts.Namespace, "namespace", opts.Namespace, "The namespace of the hostedcluster")
+	cmd.Flags().StringVar(&opts.Name, "name", opts.Name, "The name of the hostedcluster")
+	cmd.Flags().StringVar(&opts.InfraID, "infra-id", opts.InfraID, "The infra ID the bastion was created with")
+	cmd.Flags().StringVar(&opts.ResourceGroupName, "resource-group-name", opts.ResourceGroupName, "The resource group the bastion was created in")
+	cmd.Flags().StringVar(&opts.NetworkSecurityGroupID, "network-security-group-id", opts.NetworkSecurityGroupID, "The ID of the network security group SSH access to the bastion was allowed in")
+	cmd.Flags().StringVar(&opts.CredentialsFile, "azure-creds", opts.CredentialsFile, "Path to an Azure credentials file")
+
+	_ = cmd.MarkFlagRequired("azure-creds")
+	_ = cmd.MarkFlagFilename("azure-creds")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if err := opts.Validate(); err != nil {
+			log.Log.Error(err, "Invalid arguments")
+			_ = cmd.Usage()
+			return nil
+		}
+		if err := opts.Run(cmd.Context()); err != nil {
+			log.Log.Error(err, "Failed to destroy bastion")
+			return err
+		} else {
+			log.Log.Info("Successfully destroyed bastion")
+		}
+		return nil
+	}
+
+	return cmd
+}
+
+func (o *DestroyBastionOpts) Validate() error {
+	if len(o.Name) > 0 {
+		if len(o.Namespace) == 0 {
+			return fmt.Errorf("a namespace must be specified if specifying a hosted cluster name")
+		}
+		if len(o.InfraID) > 0 || len(o.ResourceGroupName) > 0 || len(o.NetworkSecurityGroupID) > 0 {
+			return fmt.Errorf("infra id, resource group name and network security group id cannot be specified when specifying a hosted cluster name")
+		}
+	} else {
+		if len(o.InfraID) == 0 || len(o.ResourceGroupName) == 0 || len(o.NetworkSecurityGroupID) == 0 {
+			return fmt.Errorf("infra id, resource group name and network security group id must be specified when not specifying a hosted cluster name")
+		}
+	}
+	return nil
+}
+
+func (o *DestroyBastionOpts) Run(ctx context.Context) error {
+	var infra bastionInfra
+
+	if len(o.Name) > 0 {
+		_, hostedCluster, err := common.GetHostedCluster(ctx, o.Namespace, o.Name, hyperv1.AzurePlatform)
+		if err != nil {
+			return err
+		}
+		if infra, err = infraFromHostedCluster(hostedCluster); err != nil {
+			return err
+		}
+	} else {
+		infra = bastionInfra{
+			infraID:                o.InfraID,
+			resourceGroupName:      o.ResourceGroupName,
+			networkSecurityGroupID: o.NetworkSecurityGroupID,
+		}
+	}
+
+	subscriptionID, azureCreds, err := util.SetupAzureCredentials(log.Log, nil, o.CredentialsFile)
+	if err != nil {
+		return fmt.Errorf("failed to setup Azure credentials: %w", err)
+	}
+
+	// The network interface can only be deleted once the virtual machine is, and the public IP address once the
+	// network interface is.
+	if err := destroyVM(ctx, subscriptionID, infra, azureCreds); err != nil {
+		return err
+	}
+	if err := destroyNetworkInterface(ctx, subscriptionID, infra, azureCreds); err != nil {
+		return err
+	}
+	if err := destroyPublicIPAddress(ctx, subscriptionID, infra, azureCreds); err != nil {
+		return err
+	}
+	return destroySSHRule(ctx, subscriptionID, infra, azureCreds)
+}
+
+func destroyVM(ctx context.Context, subscriptionID string, infra bastionInfra, azureCreds azcore.TokenCredential) error {
+	vmClient, err := armcompute.NewVirtualMachinesClient(subscriptionID, azureCreds, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create virtual machines client: %w", err)
+	}
+	name := vmName(infra.infraID)
+	poller, err := vmClient.BeginDelete(ctx, infra.resourceGroupName, name, nil)
+	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("error deleting virtual machine: %w", err)
+	}
+	if _, err := poller.PollUntilDone(ctx, nil); err != nil {
+		return fmt.Errorf("failed to wait for virtual machine deletion: %w", err)
+	}
+	log.Log.Info("Deleted bastion virtual machine", "name", name)
+	return nil
+}
+
+func destroyNetworkInterface(ctx context.Context, subscriptionID string, infra bastionInfra, azureCreds azcore.TokenCredential) error {
+	interfacesClient, err := armnetwork.NewInterfacesClient(subscriptionID, azureCreds, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create network interfaces client: %w", err)
+	}
+	name := networkInterfaceName(infra.infraID)
+	poller, err := interfacesClient.BeginDelete(ctx, infra.resourceGroupName, name, nil)
+	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("error deleting network interface: %w", err)
+	}
+	if _, err := poller.PollUntilDone(ctx, nil); err != nil {
+		return fmt.Errorf("failed to wait for network interface deletion: %w", err)
+	}
+	log.Log.Info("Deleted network interface", "name", name)
+	return nil
+}
+
+func destroyPublicIPAddress(ctx context.Context, subscriptionID string, infra bastionInfra, azureCreds azcore.TokenCredential) error {
+	publicIPAddressClient, err := armnetwork.NewPublicIPAddressesClient(subscriptionID, azureCreds, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create public IP address client: %w", err)
+	}
+	name := publicIPAddressName(infra.infraID)
+	poller, err := publicIPAddressClient.BeginDelete(ctx, infra.resourceGroupName, name, nil)
+	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("error deleting public IP address: %w", err)
+	}
+	if _, err := poller.PollUntilDone(ctx, nil); err != nil {
+		return fmt.Errorf("failed to wait for public IP address deletion: %w", err)
+	}
+	log.Log.Info("Deleted public IP address", "name", name)
+	return nil
+}
+
+func destroySSHRule(ctx context.Context, subscriptionID string, infra bastionInfra, azureCreds azcore.TokenCredential) error {
+	nsgName, nsgResourceGroupName, err := azureutil.GetNameAndResourceGroupFromNetworkSecurityGroupID(infra.networkSecurityGroupID)
+	if err != nil {
+		return err
+	}
+	securityRulesClient, err := armnetwork.NewSecurityRulesClient(subscriptionID, azureCreds, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create security rules client: %w", err)
+	}
+	name := sshRuleName(infra.infraID)
+	poller, err := securityRulesClient.BeginDelete(ctx, nsgResourceGroupName, nsgName, name, nil)
+	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("error deleting security rule: %w", err)
+	}
+	if _, err := poller.PollUntilDone(ctx, nil); err != nil {
+		return fmt.Errorf("failed to wait for security rule deletion: %w", err)
+	}
+	log.Log.Info("Deleted ssh security rule", "networkSecurityGroup", nsgName, "name", name)
+	return nil
+}
@@ -0,0 +1,66 @@
+// Package common implements the parts of the bastion commands shared by all platforms: looking up the hosted cluster
+// a bastion is created for, its SSH key and the names of the bastion resources.
+package common
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/cmd/log"
+	"github.com/openshift/hypershift/cmd/util"
+)
+
+// GetHostedCluster returns the hosted cluster a bastion is created for, along with the client it was read with. It
+// fails if the platform of the hosted cluster isn't the platform of the bastion.
+func GetHostedCluster(ctx context.Context, namespace, name string, platform hyperv1.PlatformType) (crclient.Client, *hyperv1.HostedCluster, error) {
+	c, err := util.GetClient()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	hostedCluster := &hyperv1.HostedCluster{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, hostedCluster); err != nil {
+		return nil, nil, fmt.Errorf("failed to get hostedcluster: %w", err)
+	}
+	if hostedCluster.Spec.Platform.Type != platform {
+		return nil, nil, fmt.Errorf("hosted cluster's platform is not %s", platform)
+	}
+	log.Log.Info("Found hosted cluster", "namespace", hostedCluster.Namespace, "name", hostedCluster.Name, "infraID", hostedCluster.Spec.InfraID)
+	return c, hostedCluster, nil
+}
+
+// SSHPublicKey returns the public SSH key authorized on a bastion: the content of sshKeyFile when set, otherwise the
+// public SSH key of the hosted cluster.
+func SSHPublicKey(ctx context.Context, c crclient.Client, hostedCluster *hyperv1.HostedCluster, sshKeyFile string) ([]byte, error) {
+	if len(sshKeyFile) > 0 {
+		sshPublicKey, err := os.ReadFile(sshKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read SSH public key from %s: %v", sshKeyFile, err)
+		}
+		return sshPublicKey, nil
+	}
+
+	if hostedCluster == nil || len(hostedCluster.Spec.SSHKey.Name) == 0 {
+		return nil, fmt.Errorf("hosted cluster does not have a public SSH key and no SSH key file was specified")
+	}
+	sshKeySecret := &corev1.Secret{}
+	if err := c.Get(ctx, types.NamespacedName{Name: hostedCluster.Spec.SSHKey.Name, Namespace: hostedCluster.Namespace}, sshKeySecret); err != nil {
+		return nil, fmt.Errorf("cannot get secret with SSH key (%s/%s): %w", hostedCluster.Namespace, hostedCluster.Spec.SSHKey.Name, err)
+	}
+	return sshKeySecret.Data["id_rsa.pub"], nil
+}
+
+// ResourceName returns the name of a bastion resource of the cluster with the given infra ID. The bastion itself has
+// no suffix.
+func ResourceName(infraID, suffix string) string {
+	if suffix == "" {
+		return fmt.Sprintf("%s-bastion", infraID)
+	}
+	return fmt.Sprintf("%s-bastion-%s", infraID, suffix)
+}
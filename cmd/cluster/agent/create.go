@@ -3,10 +3,13 @@ package agent
 import (
 	"context"
 	"fmt"
+	"net"
+	"strings"
 
 	"github.com/spf13/cobra"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	crclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/openshift/hypershift/cmd/cluster/core"
@@ -29,6 +32,13 @@ func NewCreateCommand(opts *core.CreateOptions) *cobra.Command {
 	cmd.Flags().StringVar(&opts.AgentPlatform.APIServerAddress, "api-server-address", opts.AgentPlatform.APIServerAddress, "The IP address to be used for the hosted cluster's Kubernetes API communication. Requires management cluster connectivity if left unset.")
 	cmd.Flags().StringVar(&opts.AgentPlatform.AgentNamespace, "agent-namespace", opts.AgentPlatform.AgentNamespace, "The namespace in which to search for Agents")
 	cmd.Flags().StringVar(&opts.AgentPlatform.AgentLabelSelector, "agentLabelSelector", opts.AgentPlatform.AgentLabelSelector, "A LabelSelector for selecting Agents according to their labels, e.g., 'size=large,zone notin (az1,az2)'")
+	cmd.Flags().StringVar(&opts.AgentPlatform.HTTPProxy, "http-proxy", opts.AgentPlatform.HTTPProxy, "The HTTP proxy used by the discovery image of the generated InfraEnv and by the hosted cluster")
+	cmd.Flags().StringVar(&opts.AgentPlatform.HTTPSProxy, "https-proxy", opts.AgentPlatform.HTTPSProxy, "The HTTPS proxy used by the discovery image of the generated InfraEnv and by the hosted cluster")
+	cmd.Flags().StringVar(&opts.AgentPlatform.NoProxy, "no-proxy", opts.AgentPlatform.NoProxy, "A comma separated list of destinations excluded from proxying, e.g. '.example.com,10.0.0.0/16'")
+	cmd.Flags().StringArrayVar(&opts.AgentPlatform.StaticNetworkHosts, "static-network-host", opts.AgentPlatform.StaticNetworkHosts, "The static network configuration of a host in DHCP-less environments, in the form of 'mac=<mac>[,mac=<mac>...],ip=<address>/<prefix>'. Hosts with more than one mac get their NICs bonded. Can be specified multiple times, once per host.")
+	cmd.Flags().StringVar(&opts.AgentPlatform.StaticNetworkGateway, "static-network-gateway", opts.AgentPlatform.StaticNetworkGateway, "The default gateway of the hosts configured with --static-network-host")
+	cmd.Flags().StringSliceVar(&opts.AgentPlatform.StaticNetworkDNS, "static-network-dns", opts.AgentPlatform.StaticNetworkDNS, "The DNS servers of the hosts configured with --static-network-host")
+	cmd.Flags().StringVar(&opts.AgentPlatform.BondMode, "bond-mode", fixtures.DefaultAgentBondMode, "The bonding mode of the hosts configured with more than one mac in --static-network-host")
 	_ = cmd.MarkFlagRequired("agent-namespace")
 	_ = cmd.MarkPersistentFlagRequired("pull-secret")
 
@@ -55,6 +65,10 @@ func createCluster(ctx context.Context, opts *core.CreateOptions) error {
 }
 
 func ApplyPlatformSpecificsValues(ctx context.Context, exampleOptions *fixtures.ExampleOptions, opts *core.CreateOptions) (err error) {
+	if err := validateStaticNetwork(opts.AgentPlatform); err != nil {
+		return err
+	}
+
 	if opts.AgentPlatform.APIServerAddress == "" {
 		opts.AgentPlatform.APIServerAddress, err = core.GetAPIServerAddressByNode(ctx, opts.Log)
 		if err != nil {
@@ -70,9 +84,22 @@ func ApplyPlatformSpecificsValues(ctx context.Context, exampleOptions *fixtures.
 	}
 
 	exampleOptions.Agent = &fixtures.ExampleAgentOptions{
-		APIServerAddress:   opts.AgentPlatform.APIServerAddress,
-		AgentNamespace:     opts.AgentPlatform.AgentNamespace,
-		AgentLabelSelector: opts.AgentPlatform.AgentLabelSelector,
+		APIServerAddress:     opts.AgentPlatform.APIServerAddress,
+		AgentNamespace:       opts.AgentPlatform.AgentNamespace,
+		AgentLabelSelector:   opts.AgentPlatform.AgentLabelSelector,
+		HTTPProxy:            opts.AgentPlatform.HTTPProxy,
+		HTTPSProxy:           opts.AgentPlatform.HTTPSProxy,
+		NoProxy:              opts.AgentPlatform.NoProxy,
+		StaticNetworkGateway: opts.AgentPlatform.StaticNetworkGateway,
+		StaticNetworkDNS:     opts.AgentPlatform.StaticNetworkDNS,
+		BondMode:             opts.AgentPlatform.BondMode,
+	}
+	for _, host := range opts.AgentPlatform.StaticNetworkHosts {
+		staticNetworkHost, err := fixtures.ParseAgentStaticNetworkHost(host)
+		if err != nil {
+			return fmt.Errorf("invalid --static-network-host: %w", err)
+		}
+		exampleOptions.Agent.StaticNetworkHosts = append(exampleOptions.Agent.StaticNetworkHosts, staticNetworkHost)
 	}
 
 	// Validate that the agent namespace exists
@@ -91,3 +118,29 @@ func ApplyPlatformSpecificsValues(ctx context.Context, exampleOptions *fixtures.
 
 	return nil
 }
+
+// bondModes are the bonding modes supported by nmstate.
+var bondModes = sets.New("balance-rr", "active-backup", "balance-xor", "broadcast", "802.3ad", "balance-tlb", "balance-alb")
+
+// validateStaticNetwork validates the static network flags of the hosts, which
+// are only meaningful along with --static-network-host.
+func validateStaticNetwork(opts core.AgentPlatformCreateOptions) error {
+	if len(opts.StaticNetworkHosts) == 0 {
+		if opts.StaticNetworkGateway != "" || len(opts.StaticNetworkDNS) > 0 {
+			return fmt.Errorf("--static-network-gateway and --static-network-dns require --static-network-host")
+		}
+		return nil
+	}
+	if opts.StaticNetworkGateway != "" && net.ParseIP(opts.StaticNetworkGateway) == nil {
+		return fmt.Errorf("invalid --static-network-gateway %q, must be an IP address", opts.StaticNetworkGateway)
+	}
+	for _, server := range opts.StaticNetworkDNS {
+		if net.ParseIP(server) == nil {
+			return fmt.Errorf("invalid --static-network-dns %q, must be an IP address", server)
+		}
+	}
+	if opts.BondMode != "" && !bondModes.Has(opts.BondMode) {
+		return fmt.Errorf("invalid --bond-mode %q, must be one of %s", opts.BondMode, strings.Join(sets.List(bondModes), ", "))
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package agent
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/hypershift/cmd/cluster/core"
+)
+
+func TestValidateStaticNetwork(t *testing.T) {
+	testCases := []struct {
+		name        string
+		opts        core.AgentPlatformCreateOptions
+		expectError bool
+	}{
+		{
+			name: "When no static network flags are set it should be valid",
+		},
+		{
+			name: "When hosts are set with a gateway, DNS servers and a bond mode it should be valid",
+			opts: core.AgentPlatformCreateOptions{
+				StaticNetworkHosts:   []string{"mac=52:54:00:aa:bb:01,ip=192.168.122.10/24"},
+				StaticNetworkGateway: "192.168.122.1",
+				StaticNetworkDNS:     []string{"192.168.122.1"},
+				BondMode:             "802.3ad",
+			},
+		},
+		{
+			name: "When a gateway is set without hosts it should fail",
+			opts: core.AgentPlatformCreateOptions{
+				StaticNetworkGateway: "192.168.122.1",
+			},
+			expectError: true,
+		},
+		{
+			name: "When the gateway is not an IP address it should fail",
+			opts: core.AgentPlatformCreateOptions{
+				StaticNetworkHosts:   []string{"mac=52:54:00:aa:bb:01,ip=192.168.122.10/24"},
+				StaticNetworkGateway: "gateway.example.com",
+			},
+			expectError: true,
+		},
+		{
+			name: "When the bond mode is unknown it should fail",
+			opts: core.AgentPlatformCreateOptions{
+				StaticNetworkHosts: []string{"mac=52:54:00:aa:bb:01,ip=192.168.122.10/24"},
+				BondMode:           "lacp",
+			},
+			expectError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := validateStaticNetwork(tc.opts)
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+		})
+	}
+}
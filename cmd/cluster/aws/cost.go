@@ -0,0 +1,229 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/cmd/cluster/core"
+	awsutil "github.com/openshift/hypershift/cmd/infra/aws/util"
+	"github.com/openshift/hypershift/cmd/util"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/pricing"
+	"github.com/aws/aws-sdk-go/service/pricing/pricingiface"
+)
+
+// pricingRegion is the region of the AWS Price List API endpoint, which serves the prices of every region.
+const pricingRegion = "us-east-1"
+
+// EstimateCost prints the estimated monthly cost of the AWS resources of the cluster the options would create.
+func EstimateCost(ctx context.Context, opts *core.CreateOptions, out io.Writer) error {
+	awsKey, awsSecretKey := "", ""
+	if len(opts.CredentialSecretName) > 0 && len(opts.AWSPlatform.AWSCredentialsFile) == 0 {
+		client, err := util.GetClient()
+		if err != nil {
+			return err
+		}
+		_, awsKey, awsSecretKey, err = util.ExtractOptionsFromSecret(client, opts.CredentialSecretName, opts.Namespace, opts.BaseDomain)
+		if err != nil {
+			return err
+		}
+	}
+	awsSession := awsutil.NewSession("cli-estimate-cost", opts.AWSPlatform.AWSCredentialsFile, awsKey, awsSecretKey, pricingRegion)
+	pricingClient := pricing.New(awsSession, awsutil.NewConfig())
+
+	estimate, err := estimateAWSCost(ctx, pricingClient, opts)
+	if err != nil {
+		return fmt.Errorf("failed to estimate the cost of the cluster: %w", err)
+	}
+	return core.PrintCostEstimate(out, estimate, opts.EstimateCostOutput)
+}
+
+// estimateAWSCost estimates the monthly cost of the EC2 instances, root volumes, NAT gateways and load balancers of the
+// cluster the options would create, with the on-demand prices of the region.
+func estimateAWSCost(ctx context.Context, pricingClient pricingiface.PricingAPI, opts *core.CreateOptions) (*core.CostEstimate, error) {
+	region := opts.AWSPlatform.Region
+	estimate := &core.CostEstimate{
+		Platform: "AWS",
+		Region:   region,
+		Currency: "USD",
+		Notes: []string{
+			"Prices are on-demand list prices, excluding taxes, discounts, savings plans and reserved instances.",
+			"Data transfer, load balancer capacity units, provisioned IOPS and throughput, Route53 and S3 are not included.",
+			"The control plane runs on the management cluster and is not included.",
+		},
+	}
+
+	// The infrastructure has a subnet per zone, and a NodePool is created in each of them.
+	zones := len(opts.AWSPlatform.Zones)
+	if zones == 0 {
+		zones = 1
+	}
+	replicas := int(opts.NodePoolReplicas)
+	if replicas < 0 {
+		replicas = 0
+	}
+
+	instanceTypes := map[string]string{}
+	instanceType := opts.AWSPlatform.InstanceType
+	if instanceType == "" {
+		instanceType = defaultInstanceType(opts.Arch)
+	}
+	instanceTypes[opts.Arch] = instanceType
+	if opts.AWSPlatform.MultiArch {
+		otherArch := otherMultiArchArchitecture(opts.Arch)
+		instanceTypes[otherArch] = defaultInstanceType(otherArch)
+	}
+	instances := 0
+	for _, arch := range []string{hyperv1.ArchitectureAMD64, hyperv1.ArchitectureARM64} {
+		instanceType, ok := instanceTypes[arch]
+		if !ok {
+			continue
+		}
+		count := replicas * zones
+		if count == 0 {
+			continue
+		}
+		price, err := awsPrice(ctx, pricingClient, "AmazonEC2", "Hrs", map[string]string{
+			"regionCode":      region,
+			"instanceType":    instanceType,
+			"operatingSystem": "Linux",
+			"tenancy":         "Shared",
+			"preInstalledSw":  "NA",
+			"capacitystatus":  "Used",
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the price of instance type %s: %w", instanceType, err)
+		}
+		estimate.Add("Worker instances", instanceType, float64(count), "instances", price*core.HoursPerMonth)
+		instances += count
+	}
+
+	if instances > 0 && opts.AWSPlatform.RootVolumeSize > 0 {
+		price, err := awsPrice(ctx, pricingClient, "AmazonEC2", "GB-Mo", map[string]string{
+			"regionCode":    region,
+			"productFamily": "Storage",
+			"volumeApiName": opts.AWSPlatform.RootVolumeType,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the price of volume type %s: %w", opts.AWSPlatform.RootVolumeType, err)
+		}
+		estimate.Add("Worker root volumes", fmt.Sprintf("%s, %d GiB", opts.AWSPlatform.RootVolumeType, opts.AWSPlatform.RootVolumeSize), float64(int64(instances)*opts.AWSPlatform.RootVolumeSize), "GiB", price)
+	}
+
+	natGateways := zones
+	switch {
+	case opts.AWSPlatform.EnableProxy:
+		natGateways = 0
+	case opts.AWSPlatform.SingleNATGateway:
+		natGateways = 1
+	}
+	if natGateways > 0 {
+		price, err := awsPrice(ctx, pricingClient, "AmazonEC2", "Hrs", map[string]string{
+			"regionCode":    region,
+			"productFamily": "NAT Gateway",
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the price of NAT gateways: %w", err)
+		}
+		estimate.Add("NAT gateways", "NAT gateway", float64(natGateways), "gateways", price*core.HoursPerMonth)
+	}
+
+	// The control plane is published through a network load balancer for public access and one for private access,
+	// and the default ingress controller of the guest cluster creates a classic load balancer.
+	networkLoadBalancers := 0
+	switch hyperv1.AWSEndpointAccessType(opts.AWSPlatform.EndpointAccess) {
+	case hyperv1.Public, hyperv1.Private:
+		networkLoadBalancers = 1
+	case hyperv1.PublicAndPrivate:
+		networkLoadBalancers = 2
+	}
+	if networkLoadBalancers > 0 {
+		price, err := awsPrice(ctx, pricingClient, "AmazonEC2", "Hrs", map[string]string{
+			"regionCode":    region,
+			"productFamily": "Load Balancer-Network",
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the price of network load balancers: %w", err)
+		}
+		estimate.Add("Control plane load balancers", "Network Load Balancer", float64(networkLoadBalancers), "load balancers", price*core.HoursPerMonth)
+	}
+	if replicas > 0 {
+		price, err := awsPrice(ctx, pricingClient, "AmazonEC2", "Hrs", map[string]string{
+			"regionCode":    region,
+			"productFamily": "Load Balancer",
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the price of classic load balancers: %w", err)
+		}
+		estimate.Add("Ingress load balancers", "Classic Load Balancer", 1, "load balancers", price*core.HoursPerMonth)
+	}
+
+	return estimate, nil
+}
+
+// awsPrice returns the on-demand price in USD per unit of the first product matching the filters with a non-zero price.
+func awsPrice(ctx context.Context, pricingClient pricingiface.PricingAPI, serviceCode, unit string, filters map[string]string) (float64, error) {
+	input := &pricing.GetProductsInput{
+		ServiceCode: aws.String(serviceCode),
+	}
+	fields := make([]string, 0, len(filters))
+	for field := range filters {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		input.Filters = append(input.Filters, &pricing.Filter{
+			Type:  aws.String(pricing.FilterTypeTermMatch),
+			Field: aws.String(field),
+			Value: aws.String(filters[field]),
+		})
+	}
+
+	var price float64
+	found := false
+	err := pricingClient.GetProductsPagesWithContext(ctx, input, func(output *pricing.GetProductsOutput, _ bool) bool {
+		for _, product := range output.PriceList {
+			if p, ok := onDemandPrice(product, unit); ok {
+				price, found = p, true
+				return false
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return 0, err
+	}
+	if !found {
+		return 0, fmt.Errorf("no on-demand price found for %v", filters)
+	}
+	return price, nil
+}
+
+// onDemandPrice returns the first non-zero on-demand price in USD per unit of a product of the price list.
+func onDemandPrice(product aws.JSONValue, unit string) (float64, bool) {
+	terms, _ := product["terms"].(map[string]interface{})
+	onDemand, _ := terms["OnDemand"].(map[string]interface{})
+	for _, term := range onDemand {
+		term, _ := term.(map[string]interface{})
+		dimensions, _ := term["priceDimensions"].(map[string]interface{})
+		for _, dimension := range dimensions {
+			dimension, _ := dimension.(map[string]interface{})
+			if dimension["unit"] != unit {
+				continue
+			}
+			pricePerUnit, _ := dimension["pricePerUnit"].(map[string]interface{})
+			usd, _ := pricePerUnit["USD"].(string)
+			price, err := strconv.ParseFloat(usd, 64)
+			if err != nil || price == 0 {
+				continue
+			}
+			return price, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,173 @@
+package aws
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/cmd/cluster/core"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/pricing"
+	"github.com/aws/aws-sdk-go/service/pricing/pricingiface"
+)
+
+// fakePricing returns a price list product per filtered product family or instance type.
+type fakePricing struct {
+	pricingiface.PricingAPI
+	prices map[string]float64
+}
+
+func (f *fakePricing) GetProductsPagesWithContext(_ aws.Context, input *pricing.GetProductsInput, fn func(*pricing.GetProductsOutput, bool) bool, _ ...request.Option) error {
+	key := ""
+	for _, filter := range input.Filters {
+		if aws.StringValue(filter.Field) == "instanceType" || aws.StringValue(filter.Field) == "productFamily" {
+			key = aws.StringValue(filter.Value)
+		}
+	}
+	price, ok := f.prices[key]
+	if !ok {
+		fn(&pricing.GetProductsOutput{}, true)
+		return nil
+	}
+	unit := "Hrs"
+	if key == "Storage" {
+		unit = "GB-Mo"
+	}
+	fn(&pricing.GetProductsOutput{PriceList: []aws.JSONValue{
+		priceListProduct("0.0000000000", unit),
+		priceListProduct("0.0200000000", "LCU-Hrs"),
+		priceListProduct(strconv.FormatFloat(price, 'f', 10, 64), unit),
+	}}, true)
+	return nil
+}
+
+func priceListProduct(usd, unit string) aws.JSONValue {
+	return aws.JSONValue{
+		"terms": map[string]interface{}{
+			"OnDemand": map[string]interface{}{
+				"SKU.TERM": map[string]interface{}{
+					"priceDimensions": map[string]interface{}{
+						"SKU.TERM.DIM": map[string]interface{}{
+							"unit":         unit,
+							"pricePerUnit": map[string]interface{}{"USD": usd},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestEstimateAWSCost(t *testing.T) {
+	prices := map[string]float64{
+		"m5.large":              0.1,
+		"m6g.large":             0.08,
+		"Storage":               0.1,
+		"NAT Gateway":           0.05,
+		"Load Balancer-Network": 0.02,
+		"Load Balancer":         0.025,
+	}
+	options := func(mutate func(*core.CreateOptions)) *core.CreateOptions {
+		opts := &core.CreateOptions{
+			NodePoolReplicas: 2,
+			Arch:             hyperv1.ArchitectureAMD64,
+			AWSPlatform: core.AWSPlatformOptions{
+				Region:         "us-east-1",
+				RootVolumeType: "gp3",
+				RootVolumeSize: 120,
+				EndpointAccess: string(hyperv1.Public),
+			},
+		}
+		if mutate != nil {
+			mutate(opts)
+		}
+		return opts
+	}
+
+	testCases := []struct {
+		name          string
+		opts          *core.CreateOptions
+		expectedItems []core.CostEstimateItem
+		expectedTotal float64
+		expectedErr   bool
+	}{
+		{
+			name: "When the cluster has the default options, it should estimate the instances, volumes, NAT gateway and load balancers",
+			opts: options(nil),
+			expectedItems: []core.CostEstimateItem{
+				{Category: "Worker instances", Resource: "m5.large", Quantity: 2, Unit: "instances", UnitMonthlyPrice: 73, MonthlyCost: 146},
+				{Category: "Worker root volumes", Resource: "gp3, 120 GiB", Quantity: 240, Unit: "GiB", UnitMonthlyPrice: 0.1, MonthlyCost: 24},
+				{Category: "NAT gateways", Resource: "NAT gateway", Quantity: 1, Unit: "gateways", UnitMonthlyPrice: 36.5, MonthlyCost: 36.5},
+				{Category: "Control plane load balancers", Resource: "Network Load Balancer", Quantity: 1, Unit: "load balancers", UnitMonthlyPrice: 14.6, MonthlyCost: 14.6},
+				{Category: "Ingress load balancers", Resource: "Classic Load Balancer", Quantity: 1, Unit: "load balancers", UnitMonthlyPrice: 18.25, MonthlyCost: 18.25},
+			},
+			expectedTotal: 239.35,
+		},
+		{
+			name: "When the cluster is multi-arch in several zones with a proxy and private endpoints, it should estimate both architectures per zone without NAT gateways",
+			opts: options(func(opts *core.CreateOptions) {
+				opts.NodePoolReplicas = 1
+				opts.Arch = hyperv1.ArchitectureARM64
+				opts.AWSPlatform.MultiArch = true
+				opts.AWSPlatform.Zones = []string{"us-east-1a", "us-east-1b"}
+				opts.AWSPlatform.EnableProxy = true
+				opts.AWSPlatform.EndpointAccess = string(hyperv1.PublicAndPrivate)
+			}),
+			expectedItems: []core.CostEstimateItem{
+				{Category: "Worker instances", Resource: "m5.large", Quantity: 2, Unit: "instances", UnitMonthlyPrice: 73, MonthlyCost: 146},
+				{Category: "Worker instances", Resource: "m6g.large", Quantity: 2, Unit: "instances", UnitMonthlyPrice: 58.4, MonthlyCost: 116.8},
+				{Category: "Worker root volumes", Resource: "gp3, 120 GiB", Quantity: 480, Unit: "GiB", UnitMonthlyPrice: 0.1, MonthlyCost: 48},
+				{Category: "Control plane load balancers", Resource: "Network Load Balancer", Quantity: 2, Unit: "load balancers", UnitMonthlyPrice: 14.6, MonthlyCost: 29.2},
+				{Category: "Ingress load balancers", Resource: "Classic Load Balancer", Quantity: 1, Unit: "load balancers", UnitMonthlyPrice: 18.25, MonthlyCost: 18.25},
+			},
+			expectedTotal: 358.25,
+		},
+		{
+			name: "When the cluster has no NodePool, it should only estimate the NAT gateways and control plane load balancers",
+			opts: options(func(opts *core.CreateOptions) {
+				opts.NodePoolReplicas = -1
+				opts.AWSPlatform.SingleNATGateway = true
+				opts.AWSPlatform.Zones = []string{"us-east-1a", "us-east-1b"}
+			}),
+			expectedItems: []core.CostEstimateItem{
+				{Category: "NAT gateways", Resource: "NAT gateway", Quantity: 1, Unit: "gateways", UnitMonthlyPrice: 36.5, MonthlyCost: 36.5},
+				{Category: "Control plane load balancers", Resource: "Network Load Balancer", Quantity: 1, Unit: "load balancers", UnitMonthlyPrice: 14.6, MonthlyCost: 14.6},
+			},
+			expectedTotal: 51.1,
+		},
+		{
+			name: "When the instance type has no price in the region, it should fail",
+			opts: options(func(opts *core.CreateOptions) {
+				opts.AWSPlatform.InstanceType = "x9.unknown"
+			}),
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			estimate, err := estimateAWSCost(context.Background(), &fakePricing{prices: prices}, tc.opts)
+			if tc.expectedErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(estimate.Items).To(HaveLen(len(tc.expectedItems)))
+			for i, item := range estimate.Items {
+				expected := tc.expectedItems[i]
+				g.Expect(item.Category).To(Equal(expected.Category))
+				g.Expect(item.Resource).To(Equal(expected.Resource))
+				g.Expect(item.Quantity).To(Equal(expected.Quantity))
+				g.Expect(item.Unit).To(Equal(expected.Unit))
+				g.Expect(item.UnitMonthlyPrice).To(BeNumerically("~", expected.UnitMonthlyPrice, 0.001))
+				g.Expect(item.MonthlyCost).To(BeNumerically("~", expected.MonthlyCost, 0.001))
+			}
+			g.Expect(estimate.MonthlyTotal).To(BeNumerically("~", tc.expectedTotal, 0.001))
+		})
+	}
+}
@@ -5,15 +5,21 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"slices"
 	"strings"
 
 	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
 	"github.com/openshift/hypershift/cmd/cluster/core"
 	awsinfra "github.com/openshift/hypershift/cmd/infra/aws"
+	awsutil "github.com/openshift/hypershift/cmd/infra/aws/util"
+	"github.com/openshift/hypershift/cmd/instancetypes"
 	"github.com/openshift/hypershift/cmd/util"
 	apifixtures "github.com/openshift/hypershift/examples/fixtures"
+	supportawsutil "github.com/openshift/hypershift/support/awsutil"
 	"github.com/openshift/hypershift/support/releaseinfo/registryclient"
+	supportutil "github.com/openshift/hypershift/support/util"
 
+	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/spf13/cobra"
 )
 
@@ -34,6 +40,7 @@ func NewCreateCommand(opts *core.CreateOptions) *cobra.Command {
 		EndpointAccess:     string(hyperv1.Public),
 		MultiArch:          false,
 	}
+	opts.EstimateCostOutput = core.CostEstimateOutputTable
 
 	cmd.Flags().StringVar(&opts.AWSPlatform.AWSCredentialsFile, "aws-creds", opts.AWSPlatform.AWSCredentialsFile, "Path to an AWS credentials file (required)")
 	cmd.Flags().StringVar(&opts.AWSPlatform.IAMJSON, "iam-json", opts.AWSPlatform.IAMJSON, "Path to file containing IAM information for the cluster. If not specified, IAM will be created")
@@ -51,7 +58,10 @@ func NewCreateCommand(opts *core.CreateOptions) *cobra.Command {
 	cmd.Flags().StringVar(&opts.CredentialSecretName, "secret-creds", opts.CredentialSecretName, "A Kubernetes secret with needed AWS platform credentials: aws-creds, pull-secret, and a base-domain value. The secret must exist in the supplied \"--namespace\". If a value is provided through the flag '--pull-secret', that value will override the pull-secret value in 'secret-creds'.")
 	cmd.Flags().StringVar(&opts.AWSPlatform.IssuerURL, "oidc-issuer-url", "", "The OIDC provider issuer URL")
 	cmd.Flags().BoolVar(&opts.AWSPlatform.SingleNATGateway, "single-nat-gateway", opts.AWSPlatform.SingleNATGateway, "If enabled, only a single NAT gateway is created, even if multiple zones are specified")
-	cmd.PersistentFlags().BoolVar(&opts.AWSPlatform.MultiArch, "multi-arch", opts.AWSPlatform.MultiArch, "If true, this flag indicates the Hosted Cluster will support multi-arch NodePools and will perform additional validation checks to ensure a multi-arch release image or stream was used.")
+	cmd.Flags().StringToStringVar(&opts.AWSPlatform.EndpointOverrides, "aws-endpoint-overrides", opts.AWSPlatform.EndpointOverrides, "A comma separated list of service=url pairs overriding the endpoints of the AWS services, e.g. ec2=https://ec2.example.com,sts=https://sts.example.com. The overrides are also used by the AWS clients of the hosted cluster")
+	cmd.Flags().BoolVar(&opts.EstimateCost, "estimate-cost", opts.EstimateCost, "If true, prints the estimated monthly cost of the AWS resources of the cluster from the AWS Price List API instead of creating it")
+	cmd.Flags().StringVar(&opts.EstimateCostOutput, "estimate-cost-output", opts.EstimateCostOutput, "The output format of --estimate-cost (table, json)")
+	cmd.PersistentFlags().BoolVar(&opts.AWSPlatform.MultiArch, "multi-arch", opts.AWSPlatform.MultiArch, "If true, this flag indicates the Hosted Cluster will support multi-arch NodePools and will perform additional validation checks to ensure a multi-arch release image or stream was used. NodePools are created for both the arm64 and amd64 architectures, and --arch defaults to arm64 (AWS Graviton) for the NodePools named after the zones; the other architecture gets NodePools suffixed with its name.")
 
 	cmd.RunE = func(cmd *cobra.Command, args []string) error {
 		ctx := cmd.Context()
@@ -61,6 +71,15 @@ func NewCreateCommand(opts *core.CreateOptions) *cobra.Command {
 			defer cancel()
 		}
 
+		// Multi-arch clusters default to Graviton instances, with amd64 NodePools alongside.
+		if opts.AWSPlatform.MultiArch && !cmd.Flags().Changed("arch") {
+			opts.Arch = hyperv1.ArchitectureARM64
+		}
+
+		if opts.EstimateCost {
+			return EstimateCost(ctx, opts, cmd.OutOrStdout())
+		}
+
 		err := validateAWSOptions(ctx, opts)
 		if err != nil {
 			return err
@@ -127,6 +146,13 @@ func applyPlatformSpecificsValues(ctx context.Context, exampleOptions *apifixtur
 		}
 	}
 	if infra == nil {
+		// Fail before creating the infrastructure rather than with machines that never become nodes.
+		if opts.AWSPlatform.InstanceType != "" {
+			awsSession := awsutil.NewSession("cli-create-cluster", opts.AWSPlatform.AWSCredentialsFile, AWSKey, AWSSecretKey, opts.AWSPlatform.Region)
+			if err := instancetypes.ValidateAWSInstanceType(ctx, ec2.New(awsSession, awsutil.NewConfig()), opts.AWSPlatform.InstanceType, opts.Arch); err != nil {
+				return err
+			}
+		}
 		opt := awsinfra.CreateInfraOptions{
 			Region:             opts.AWSPlatform.Region,
 			InfraID:            opts.InfraID,
@@ -141,6 +167,14 @@ func applyPlatformSpecificsValues(ctx context.Context, exampleOptions *apifixtur
 			EnableProxy:        opts.AWSPlatform.EnableProxy,
 			SSHKeyFile:         opts.SSHKeyFile,
 			SingleNATGateway:   opts.AWSPlatform.SingleNATGateway,
+			EndpointOverrides:  opts.AWSPlatform.EndpointOverrides,
+			ClusterDNSDomain:   opts.ClusterDNSDomain,
+			DNSPublishing:      opts.DNSPublishing,
+			Owner:              opts.Owner,
+			Lifetime:           opts.Lifetime,
+		}
+		if opt.ClusterDNSDomain == "" {
+			opt.ClusterDNSDomain = supportutil.DefaultClusterDNSDomain
 		}
 		infra, err = opt.CreateInfra(ctx, opts.Log)
 		if err != nil {
@@ -171,6 +205,7 @@ func applyPlatformSpecificsValues(ctx context.Context, exampleOptions *apifixtur
 			PublicZoneID:       infra.PublicZoneID,
 			LocalZoneID:        infra.LocalZoneID,
 			KMSKeyARN:          opts.AWSPlatform.EtcdKMSKeyARN,
+			EndpointOverrides:  opts.AWSPlatform.EndpointOverrides,
 		}
 		iamInfo, err = opt.CreateIAM(ctx, client)
 		if err != nil {
@@ -187,17 +222,18 @@ func applyPlatformSpecificsValues(ctx context.Context, exampleOptions *apifixtur
 		tags = append(tags, hyperv1.AWSResourceTag{Key: k, Value: v})
 	}
 
-	var instanceType string
-	if opts.AWSPlatform.InstanceType != "" {
-		instanceType = opts.AWSPlatform.InstanceType
-	} else {
-		// Aligning with AWS IPI instance type defaults
-		switch opts.Arch {
-		case hyperv1.ArchitectureAMD64:
-			instanceType = "m5.large"
-		case hyperv1.ArchitectureARM64:
-			instanceType = "m6g.large"
-		}
+	instanceType := opts.AWSPlatform.InstanceType
+	if instanceType == "" {
+		instanceType = defaultInstanceType(opts.Arch)
+	}
+	var additionalArchitectures []apifixtures.ExampleAWSArchitecture
+	if opts.AWSPlatform.MultiArch {
+		// --instance-type only applies to the NodePools of --arch, the other architecture can't use it.
+		otherArch := otherMultiArchArchitecture(opts.Arch)
+		additionalArchitectures = append(additionalArchitectures, apifixtures.ExampleAWSArchitecture{
+			Arch:         otherArch,
+			InstanceType: defaultInstanceType(otherArch),
+		})
 	}
 
 	exampleOptions.BaseDomain = infra.BaseDomain
@@ -236,10 +272,33 @@ func applyPlatformSpecificsValues(ctx context.Context, exampleOptions *apifixtur
 		EndpointAccess:          opts.AWSPlatform.EndpointAccess,
 		ProxyAddress:            infra.ProxyAddr,
 		MultiArch:               opts.AWSPlatform.MultiArch,
+		AdditionalArchitectures: additionalArchitectures,
+		ServiceEndpoints:        supportawsutil.ServiceEndpoints(opts.AWSPlatform.EndpointOverrides),
 	}
 	return nil
 }
 
+// defaultInstanceType returns the default instance type of the NodePools of an architecture, aligned with the AWS IPI
+// defaults.
+func defaultInstanceType(arch string) string {
+	switch arch {
+	case hyperv1.ArchitectureAMD64:
+		return "m5.large"
+	case hyperv1.ArchitectureARM64:
+		return "m6g.large"
+	}
+	return ""
+}
+
+// otherMultiArchArchitecture returns the architecture of the additional NodePools of a multi-arch cluster whose default
+// NodePools have the given architecture.
+func otherMultiArchArchitecture(arch string) string {
+	if arch == hyperv1.ArchitectureARM64 {
+		return hyperv1.ArchitectureAMD64
+	}
+	return hyperv1.ArchitectureARM64
+}
+
 // IsRequiredOption returns a cobra style error message when the flag value is empty
 func IsRequiredOption(flag string, value string) error {
 	if len(value) == 0 {
@@ -278,13 +337,12 @@ func validateMultiArchRelease(ctx context.Context, opts *core.CreateOptions) err
 			return fmt.Errorf("failed to read pull secret file: %w", err)
 		}
 
-		validMultiArchRelease, err := registryclient.IsMultiArchManifestList(ctx, opts.ReleaseImage, pullSecret)
+		architectures, err := registryclient.GetImageArchitectures(ctx, opts.ReleaseImage, pullSecret)
 		if err != nil {
 			return err
 		}
-
-		if !validMultiArchRelease {
-			return fmt.Errorf("release image is not a multi-arch image")
+		if err := validateMultiArchArchitectures(architectures); err != nil {
+			return err
 		}
 	}
 
@@ -296,6 +354,20 @@ func validateMultiArchRelease(ctx context.Context, opts *core.CreateOptions) err
 	return nil
 }
 
+// validateMultiArchArchitectures validates a multi-arch release image provides the architectures of the NodePools
+// created with the multi-arch flag, so that their AMIs can be resolved from it.
+func validateMultiArchArchitectures(architectures []string) error {
+	if len(architectures) < 2 {
+		return fmt.Errorf("release image is not a multi-arch image")
+	}
+	for _, arch := range []string{hyperv1.ArchitectureAMD64, hyperv1.ArchitectureARM64} {
+		if !slices.Contains(architectures, arch) {
+			return fmt.Errorf("multi-arch release image doesn't provide the %s architecture, only %s", arch, strings.Join(architectures, ", "))
+		}
+	}
+	return nil
+}
+
 // validateAWSOptions validates different AWS flag parameters
 func validateAWSOptions(ctx context.Context, opts *core.CreateOptions) error {
 	if err := ValidateCreateCredentialInfo(opts); err != nil {
@@ -306,5 +378,13 @@ func validateAWSOptions(ctx context.Context, opts *core.CreateOptions) error {
 		return err
 	}
 
+	if err := supportawsutil.ValidateEndpointOverrides(opts.AWSPlatform.EndpointOverrides); err != nil {
+		return err
+	}
+
+	if opts.DNSPublishing == string(hyperv1.InternalDNSPublishing) && opts.AWSPlatform.EndpointAccess != string(hyperv1.Private) {
+		return fmt.Errorf("--dns-publishing=%s requires --endpoint-access=%s", hyperv1.InternalDNSPublishing, hyperv1.Private)
+	}
+
 	return nil
 }
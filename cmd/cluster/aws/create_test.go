@@ -120,3 +120,33 @@ func TestValidateMultiArchRelease(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateMultiArchArchitectures(t *testing.T) {
+	tests := map[string]struct {
+		architectures []string
+		expectedError string
+	}{
+		"when the release image provides amd64 and arm64 it should succeed": {
+			architectures: []string{"amd64", "arm64", "ppc64le", "s390x"},
+		},
+		"when the release image is single-arch it should fail": {
+			architectures: []string{"arm64"},
+			expectedError: "release image is not a multi-arch image",
+		},
+		"when the release image doesn't provide arm64 it should fail": {
+			architectures: []string{"amd64", "ppc64le", "s390x"},
+			expectedError: "multi-arch release image doesn't provide the arm64 architecture, only amd64, ppc64le, s390x",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			err := validateMultiArchArchitectures(test.architectures)
+			if test.expectedError != "" {
+				g.Expect(err).To(MatchError(test.expectedError))
+			} else {
+				g.Expect(err).To(BeNil())
+			}
+		})
+	}
+}
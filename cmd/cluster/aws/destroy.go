@@ -10,6 +10,7 @@ import (
 	awsinfra "github.com/openshift/hypershift/cmd/infra/aws"
 	"github.com/openshift/hypershift/cmd/log"
 	"github.com/openshift/hypershift/cmd/util"
+	supportawsutil "github.com/openshift/hypershift/support/awsutil"
 )
 
 func NewDestroyCommand(opts *core.DestroyOptions) *cobra.Command {
@@ -32,6 +33,7 @@ func NewDestroyCommand(opts *core.DestroyOptions) *cobra.Command {
 	cmd.Flags().StringVar(&opts.AWSPlatform.BaseDomainPrefix, "base-domain-prefix", opts.AWSPlatform.BaseDomainPrefix, "Cluster's base domain prefix; inferred from the hosted cluster by default")
 	cmd.Flags().StringVar(&opts.CredentialSecretName, "secret-creds", opts.CredentialSecretName, "A Kubernetes secret with a platform credential, pull-secret and base-domain. The secret must exist in the supplied \"--namespace\"")
 	cmd.Flags().DurationVar(&opts.AWSPlatform.AwsInfraGracePeriod, "aws-infra-grace-period", opts.AWSPlatform.AwsInfraGracePeriod, "Timeout for destroying infrastructure in minutes")
+	cmd.Flags().StringToStringVar(&opts.AWSPlatform.EndpointOverrides, "aws-endpoint-overrides", opts.AWSPlatform.EndpointOverrides, "A comma separated list of service=url pairs overriding the endpoints of the AWS services, e.g. ec2=https://ec2.example.com,sts=https://sts.example.com; inferred from the hosted cluster by default")
 
 	cmd.RunE = func(cmd *cobra.Command, args []string) error {
 		err := ValidateCredentialInfo(opts)
@@ -59,14 +61,9 @@ func destroyPlatformSpecifics(ctx context.Context, o *core.DestroyOptions) error
 	baseDomainPrefix := o.AWSPlatform.BaseDomainPrefix
 	region := o.AWSPlatform.Region
 
-	// Override the credentialSecret with credentialFile
-	var awsKeyID, awsSecretKey string
-	var err error
-	if len(o.AWSPlatform.AWSCredentialsFile) == 0 && len(o.CredentialSecretName) > 0 {
-		_, awsKeyID, awsSecretKey, err = util.ExtractOptionsFromSecret(nil, o.CredentialSecretName, o.Namespace, "")
-		if err != nil {
-			return err
-		}
+	awsKeyID, awsSecretKey, err := secretCredentials(o)
+	if err != nil {
+		return err
 	}
 	o.Log.Info("Destroying infrastructure", "infraID", infraID)
 	destroyInfraOpts := awsinfra.DestroyInfraOptions{
@@ -80,6 +77,7 @@ func destroyPlatformSpecifics(ctx context.Context, o *core.DestroyOptions) error
 		BaseDomainPrefix:    baseDomainPrefix,
 		AwsInfraGracePeriod: o.AWSPlatform.AwsInfraGracePeriod,
 		Log:                 o.Log,
+		EndpointOverrides:   o.AWSPlatform.EndpointOverrides,
 	}
 	if err := destroyInfraOpts.Run(ctx); err != nil {
 		return fmt.Errorf("failed to destroy infrastructure: %w", err)
@@ -94,6 +92,7 @@ func destroyPlatformSpecifics(ctx context.Context, o *core.DestroyOptions) error
 			AWSSecretKey:       awsSecretKey,
 			InfraID:            infraID,
 			Log:                o.Log,
+			EndpointOverrides:  o.AWSPlatform.EndpointOverrides,
 		}
 		if err := destroyOpts.Run(ctx); err != nil {
 			return fmt.Errorf("failed to destroy IAM: %w", err)
@@ -102,6 +101,62 @@ func destroyPlatformSpecifics(ctx context.Context, o *core.DestroyOptions) error
 	return nil
 }
 
+// secretCredentials returns the AWS credentials of the credentials secret, unless an AWS credentials file is set.
+func secretCredentials(o *core.DestroyOptions) (string, string, error) {
+	var awsKeyID, awsSecretKey string
+	var err error
+	if len(o.AWSPlatform.AWSCredentialsFile) == 0 && len(o.CredentialSecretName) > 0 {
+		_, awsKeyID, awsSecretKey, err = util.ExtractOptionsFromSecret(nil, o.CredentialSecretName, o.Namespace, "")
+		if err != nil {
+			return "", "", err
+		}
+	}
+	return awsKeyID, awsSecretKey, nil
+}
+
+func inventoryPlatformSpecifics(ctx context.Context, o *core.DestroyOptions) ([]core.InventoryResource, error) {
+	awsKeyID, awsSecretKey, err := secretCredentials(o)
+	if err != nil {
+		return nil, err
+	}
+	resources, err := (&awsinfra.DestroyInfraOptions{
+		Region:             o.AWSPlatform.Region,
+		InfraID:            o.InfraID,
+		AWSCredentialsFile: o.AWSPlatform.AWSCredentialsFile,
+		AWSKey:             awsKeyID,
+		AWSSecretKey:       awsSecretKey,
+		Name:               o.Name,
+		BaseDomain:         o.AWSPlatform.BaseDomain,
+		BaseDomainPrefix:   o.AWSPlatform.BaseDomainPrefix,
+		Log:                o.Log,
+		EndpointOverrides:  o.AWSPlatform.EndpointOverrides,
+	}).Inventory(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !o.AWSPlatform.PreserveIAM {
+		iamResources, err := (&awsinfra.DestroyIAMOptions{
+			Region:             o.AWSPlatform.Region,
+			AWSCredentialsFile: o.AWSPlatform.AWSCredentialsFile,
+			AWSKey:             awsKeyID,
+			AWSSecretKey:       awsSecretKey,
+			InfraID:            o.InfraID,
+			Log:                o.Log,
+			EndpointOverrides:  o.AWSPlatform.EndpointOverrides,
+		}).Inventory(ctx)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, iamResources...)
+	}
+
+	var inventory []core.InventoryResource
+	for _, resource := range resources {
+		inventory = append(inventory, core.InventoryResource{Source: "AWS", Type: resource.Type, Name: resource.ID})
+	}
+	return inventory, nil
+}
+
 func DestroyCluster(ctx context.Context, o *core.DestroyOptions) error {
 	hostedCluster, err := core.GetCluster(ctx, o)
 	if err != nil {
@@ -111,6 +166,9 @@ func DestroyCluster(ctx context.Context, o *core.DestroyOptions) error {
 		o.InfraID = hostedCluster.Spec.InfraID
 		o.AWSPlatform.Region = hostedCluster.Spec.Platform.AWS.Region
 		o.AWSPlatform.BaseDomain = hostedCluster.Spec.DNS.BaseDomain
+		if len(o.AWSPlatform.EndpointOverrides) == 0 {
+			o.AWSPlatform.EndpointOverrides = supportawsutil.EndpointOverrides(hostedCluster.Spec.Platform.AWS.ServiceEndpoints)
+		}
 
 		if hostedCluster.Spec.DNS.BaseDomainPrefix != nil {
 			if *hostedCluster.Spec.DNS.BaseDomainPrefix == "" {
@@ -134,8 +192,11 @@ func DestroyCluster(ctx context.Context, o *core.DestroyOptions) error {
 	if err := errors.NewAggregate(inputErrors); err != nil {
 		return fmt.Errorf("required inputs are missing: %w", err)
 	}
+	if err := supportawsutil.ValidateEndpointOverrides(o.AWSPlatform.EndpointOverrides); err != nil {
+		return err
+	}
 
-	return core.DestroyCluster(ctx, hostedCluster, o, destroyPlatformSpecifics)
+	return core.DestroyCluster(ctx, hostedCluster, o, destroyPlatformSpecifics, inventoryPlatformSpecifics)
 }
 
 // ValidateCredentialInfo validates if the credentials secret name is empty, the aws-creds is not empty; validates if
@@ -0,0 +1,218 @@
+package azure
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/openshift/hypershift/cmd/cluster/core"
+	"github.com/openshift/hypershift/cmd/infra/cloudapi"
+)
+
+// retailPricesEndpoint is the Azure Retail Prices API, which doesn't require authentication.
+const retailPricesEndpoint = "https://prices.azure.com/api/retail/prices"
+
+// EstimateCost prints the estimated monthly cost of the Azure resources of the cluster the options would create.
+func EstimateCost(ctx context.Context, opts *core.CreateOptions, out io.Writer) error {
+	httpClient := cloudapi.FromContext(ctx).HTTPClient(cloudapi.ProviderAzure)
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	prices := &retailPricesClient{httpClient: httpClient, endpoint: retailPricesEndpoint}
+
+	estimate, err := estimateAzureCost(ctx, prices, opts)
+	if err != nil {
+		return fmt.Errorf("failed to estimate the cost of the cluster: %w", err)
+	}
+	return core.PrintCostEstimate(out, estimate, opts.EstimateCostOutput)
+}
+
+// estimateAzureCost estimates the monthly cost of the VMs, OS disks, load balancers and public IPs of the cluster the
+// options would create, with the pay-as-you-go prices of the location.
+func estimateAzureCost(ctx context.Context, prices *retailPricesClient, opts *core.CreateOptions) (*core.CostEstimate, error) {
+	location := opts.AzurePlatform.Location
+	estimate := &core.CostEstimate{
+		Platform: "Azure",
+		Region:   location,
+		Currency: "USD",
+		Notes: []string{
+			"Prices are pay-as-you-go list prices, excluding taxes, discounts, savings plans and reservations.",
+			"Data transfer, disk transactions, load balancer data processing and DNS are not included.",
+			"The control plane runs on the management cluster and is not included.",
+		},
+	}
+
+	// A NodePool is created per availability zone, or a single one when no zone is set.
+	nodePools := len(opts.AzurePlatform.AvailabilityZones)
+	if nodePools == 0 {
+		nodePools = 1
+	}
+	replicas := int(opts.NodePoolReplicas)
+	if replicas < 0 {
+		replicas = 0
+	}
+	vms := replicas * nodePools
+
+	if vms > 0 {
+		filter := fmt.Sprintf("serviceName eq 'Virtual Machines' and armRegionName eq '%s' and armSkuName eq '%s' and priceType eq 'Consumption'", location, opts.AzurePlatform.InstanceType)
+		price, err := prices.price(ctx, filter, func(item retailPrice) bool {
+			return item.UnitOfMeasure == "1 Hour" && !strings.Contains(item.ProductName, "Windows") &&
+				!strings.Contains(item.SkuName, "Spot") && !strings.Contains(item.SkuName, "Low Priority")
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the price of VM size %s: %w", opts.AzurePlatform.InstanceType, err)
+		}
+		estimate.Add("Worker VMs", opts.AzurePlatform.InstanceType, float64(vms), "VMs", price*core.HoursPerMonth)
+
+		if opts.AzurePlatform.EnableEphemeralOSDisk {
+			estimate.Notes = append(estimate.Notes, "The OS disks are ephemeral and included in the price of the VMs.")
+		} else {
+			storageAccountType := opts.AzurePlatform.DiskStorageAccountType
+			if storageAccountType == "" {
+				storageAccountType = "Premium_LRS"
+			}
+			diskSKU, ok := managedDiskSKU(storageAccountType, opts.AzurePlatform.DiskSizeGB)
+			if ok {
+				filter := fmt.Sprintf("serviceName eq 'Storage' and armRegionName eq '%s' and skuName eq '%s' and priceType eq 'Consumption'", location, diskSKU)
+				price, err := prices.price(ctx, filter, func(item retailPrice) bool {
+					return item.UnitOfMeasure == "1/Month" && strings.HasSuffix(item.MeterName, " Disk")
+				})
+				if err != nil {
+					return nil, fmt.Errorf("failed to get the price of disk %s: %w", diskSKU, err)
+				}
+				estimate.Add("Worker OS disks", fmt.Sprintf("%s (%d GiB)", diskSKU, opts.AzurePlatform.DiskSizeGB), float64(vms), "disks", price)
+			} else {
+				estimate.Notes = append(estimate.Notes, fmt.Sprintf("The %s OS disks are not included.", storageAccountType))
+			}
+		}
+	}
+
+	// The infrastructure has a load balancer with a public IP for the outbound traffic of the VMs, unless the cluster
+	// uses an existing network, and the default ingress controller of the guest cluster creates another one.
+	loadBalancers := 0
+	if !usesExistingNetwork(&opts.AzurePlatform) {
+		loadBalancers++
+	}
+	if vms > 0 {
+		loadBalancers++
+	}
+	if loadBalancers > 0 {
+		filter := fmt.Sprintf("serviceName eq 'Load Balancer' and armRegionName eq '%s' and meterName eq 'Standard Included LB Rules and Outbound Rules' and priceType eq 'Consumption'", location)
+		price, err := prices.price(ctx, filter, func(item retailPrice) bool {
+			return item.UnitOfMeasure == "1 Hour"
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the price of load balancers: %w", err)
+		}
+		estimate.Add("Load balancers", "Standard Load Balancer", float64(loadBalancers), "load balancers", price*core.HoursPerMonth)
+
+		filter = fmt.Sprintf("serviceName eq 'Virtual Network' and armRegionName eq '%s' and meterName eq 'Standard IPv4 Static Public IP' and priceType eq 'Consumption'", location)
+		price, err = prices.price(ctx, filter, func(item retailPrice) bool {
+			return item.UnitOfMeasure == "1 Hour"
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the price of public IPs: %w", err)
+		}
+		estimate.Add("Public IPs", "Standard static IPv4", float64(loadBalancers), "IPs", price*core.HoursPerMonth)
+	}
+
+	return estimate, nil
+}
+
+// managedDiskTiers are the sizes in GiB of the managed disk tiers, managed disks are billed for the smallest tier they
+// fit in.
+var managedDiskTiers = []struct {
+	sizeGB int32
+	tier   int
+}{
+	{4, 1}, {8, 2}, {16, 3}, {32, 4}, {64, 6}, {128, 10}, {256, 15}, {512, 20}, {1024, 30}, {2048, 40},
+	{4096, 50}, {8192, 60}, {16384, 70}, {32767, 80},
+}
+
+// managedDiskSKU returns the SKU name of the retail price of a managed disk of a storage account type and size, e.g.
+// P10 LRS for a 128 GiB Premium_LRS disk. It returns false when the disk isn't billed by tier.
+func managedDiskSKU(storageAccountType string, sizeGB int32) (string, bool) {
+	kind, redundancy, _ := strings.Cut(storageAccountType, "_")
+	prefix := ""
+	minTier := 0
+	switch kind {
+	case "Premium":
+		prefix = "P"
+	case "StandardSSD":
+		prefix = "E"
+	case "Standard":
+		// Standard HDD disks start at the S4 tier.
+		prefix = "S"
+		minTier = 4
+	default:
+		return "", false
+	}
+	for _, t := range managedDiskTiers {
+		if sizeGB <= t.sizeGB && t.tier >= minTier {
+			return fmt.Sprintf("%s%d %s", prefix, t.tier, redundancy), true
+		}
+	}
+	return "", false
+}
+
+// retailPrice is an item of the Azure Retail Prices API.
+type retailPrice struct {
+	RetailPrice   float64 `json:"retailPrice"`
+	ProductName   string  `json:"productName"`
+	SkuName       string  `json:"skuName"`
+	MeterName     string  `json:"meterName"`
+	UnitOfMeasure string  `json:"unitOfMeasure"`
+}
+
+type retailPricesPage struct {
+	Items        []retailPrice `json:"Items"`
+	NextPageLink string        `json:"NextPageLink"`
+}
+
+// retailPricesClient queries the Azure Retail Prices API.
+type retailPricesClient struct {
+	httpClient *http.Client
+	endpoint   string
+}
+
+// price returns the first non-zero retail price in USD of the items matching the filter and match.
+func (c *retailPricesClient) price(ctx context.Context, filter string, match func(retailPrice) bool) (float64, error) {
+	next := c.endpoint + "?" + url.Values{"currencyCode": {"USD"}, "$filter": {filter}}.Encode()
+	for next != "" {
+		page, err := c.page(ctx, next)
+		if err != nil {
+			return 0, err
+		}
+		for _, item := range page.Items {
+			if item.RetailPrice > 0 && match(item) {
+				return item.RetailPrice, nil
+			}
+		}
+		next = page.NextPageLink
+	}
+	return 0, fmt.Errorf("no retail price found for %s", filter)
+}
+
+func (c *retailPricesClient) page(ctx context.Context, pageURL string) (*retailPricesPage, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s from %s", resp.Status, c.endpoint)
+	}
+	page := &retailPricesPage{}
+	if err := json.NewDecoder(resp.Body).Decode(page); err != nil {
+		return nil, fmt.Errorf("failed to decode retail prices: %w", err)
+	}
+	return page, nil
+}
@@ -0,0 +1,178 @@
+package azure
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/openshift/hypershift/cmd/cluster/core"
+)
+
+// fakeRetailPrices serves the retail prices of the filtered VM size, disk SKU or meter, with a page of prices that must
+// be skipped before them.
+func fakeRetailPrices(t *testing.T) *httptest.Server {
+	prices := []struct {
+		match string
+		item  retailPrice
+	}{
+		{match: "armSkuName eq 'Standard_D4s_v4'", item: retailPrice{RetailPrice: 0.2, ProductName: "Virtual Machines Dsv4 Series", SkuName: "D4s v4", UnitOfMeasure: "1 Hour"}},
+		{match: "skuName eq 'P10 LRS'", item: retailPrice{RetailPrice: 19.71, SkuName: "P10 LRS", MeterName: "P10 LRS Disk", UnitOfMeasure: "1/Month"}},
+		{match: "skuName eq 'E4 LRS'", item: retailPrice{RetailPrice: 2.4, SkuName: "E4 LRS", MeterName: "E4 LRS Disk", UnitOfMeasure: "1/Month"}},
+		{match: "meterName eq 'Standard Included LB Rules and Outbound Rules'", item: retailPrice{RetailPrice: 0.025, UnitOfMeasure: "1 Hour"}},
+		{match: "meterName eq 'Standard IPv4 Static Public IP'", item: retailPrice{RetailPrice: 0.005, UnitOfMeasure: "1 Hour"}},
+	}
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		filter := r.URL.Query().Get("$filter")
+		page := retailPricesPage{}
+		if r.URL.Query().Get("page") == "" {
+			switch {
+			case strings.Contains(filter, "serviceName eq 'Virtual Machines'"):
+				page.Items = []retailPrice{
+					{RetailPrice: 0.1, ProductName: "Virtual Machines Dsv4 Series Windows", SkuName: "D4s v4", UnitOfMeasure: "1 Hour"},
+					{RetailPrice: 0.05, ProductName: "Virtual Machines Dsv4 Series", SkuName: "D4s v4 Spot", UnitOfMeasure: "1 Hour"},
+				}
+			case strings.Contains(filter, "serviceName eq 'Storage'"):
+				page.Items = []retailPrice{{RetailPrice: 1.5, SkuName: "P10 LRS", MeterName: "Disk Operations", UnitOfMeasure: "10K"}}
+			}
+			page.NextPageLink = server.URL + "?" + r.URL.RawQuery + "&page=2"
+		} else {
+			for _, price := range prices {
+				if strings.Contains(filter, price.match) {
+					page.Items = append(page.Items, price.item)
+				}
+			}
+		}
+		if err := json.NewEncoder(w).Encode(page); err != nil {
+			t.Errorf("failed to encode retail prices: %v", err)
+		}
+	}))
+	return server
+}
+
+func TestEstimateAzureCost(t *testing.T) {
+	server := fakeRetailPrices(t)
+	defer server.Close()
+
+	options := func(mutate func(*core.CreateOptions)) *core.CreateOptions {
+		opts := &core.CreateOptions{
+			NodePoolReplicas: 2,
+			AzurePlatform: core.AzurePlatformOptions{
+				Location:     "eastus",
+				InstanceType: "Standard_D4s_v4",
+				DiskSizeGB:   120,
+			},
+		}
+		if mutate != nil {
+			mutate(opts)
+		}
+		return opts
+	}
+
+	testCases := []struct {
+		name          string
+		opts          *core.CreateOptions
+		expectedItems []core.CostEstimateItem
+		expectedTotal float64
+		expectedErr   bool
+	}{
+		{
+			name: "When the cluster has the default options, it should estimate the VMs, premium disks, load balancers and public IPs",
+			opts: options(nil),
+			expectedItems: []core.CostEstimateItem{
+				{Category: "Worker VMs", Resource: "Standard_D4s_v4", Quantity: 2, Unit: "VMs", UnitMonthlyPrice: 146, MonthlyCost: 292},
+				{Category: "Worker OS disks", Resource: "P10 LRS (120 GiB)", Quantity: 2, Unit: "disks", UnitMonthlyPrice: 19.71, MonthlyCost: 39.42},
+				{Category: "Load balancers", Resource: "Standard Load Balancer", Quantity: 2, Unit: "load balancers", UnitMonthlyPrice: 18.25, MonthlyCost: 36.5},
+				{Category: "Public IPs", Resource: "Standard static IPv4", Quantity: 2, Unit: "IPs", UnitMonthlyPrice: 3.65, MonthlyCost: 7.3},
+			},
+			expectedTotal: 375.22,
+		},
+		{
+			name: "When the cluster has NodePools in several zones with small standard SSDs, it should estimate the VMs and disks of every zone",
+			opts: options(func(opts *core.CreateOptions) {
+				opts.NodePoolReplicas = 1
+				opts.AzurePlatform.AvailabilityZones = []string{"1", "2", "3"}
+				opts.AzurePlatform.DiskStorageAccountType = "StandardSSD_LRS"
+				opts.AzurePlatform.DiskSizeGB = 30
+			}),
+			expectedItems: []core.CostEstimateItem{
+				{Category: "Worker VMs", Resource: "Standard_D4s_v4", Quantity: 3, Unit: "VMs", UnitMonthlyPrice: 146, MonthlyCost: 438},
+				{Category: "Worker OS disks", Resource: "E4 LRS (30 GiB)", Quantity: 3, Unit: "disks", UnitMonthlyPrice: 2.4, MonthlyCost: 7.2},
+				{Category: "Load balancers", Resource: "Standard Load Balancer", Quantity: 2, Unit: "load balancers", UnitMonthlyPrice: 18.25, MonthlyCost: 36.5},
+				{Category: "Public IPs", Resource: "Standard static IPv4", Quantity: 2, Unit: "IPs", UnitMonthlyPrice: 3.65, MonthlyCost: 7.3},
+			},
+			expectedTotal: 489,
+		},
+		{
+			name: "When the cluster uses ephemeral OS disks in an existing network, it should not estimate the disks and the outbound load balancer",
+			opts: options(func(opts *core.CreateOptions) {
+				opts.AzurePlatform.EnableEphemeralOSDisk = true
+				opts.AzurePlatform.NetworkSecurityGroupID = testNSGID
+			}),
+			expectedItems: []core.CostEstimateItem{
+				{Category: "Worker VMs", Resource: "Standard_D4s_v4", Quantity: 2, Unit: "VMs", UnitMonthlyPrice: 146, MonthlyCost: 292},
+				{Category: "Load balancers", Resource: "Standard Load Balancer", Quantity: 1, Unit: "load balancers", UnitMonthlyPrice: 18.25, MonthlyCost: 18.25},
+				{Category: "Public IPs", Resource: "Standard static IPv4", Quantity: 1, Unit: "IPs", UnitMonthlyPrice: 3.65, MonthlyCost: 3.65},
+			},
+			expectedTotal: 313.9,
+		},
+		{
+			name: "When the VM size has no price in the location, it should fail",
+			opts: options(func(opts *core.CreateOptions) {
+				opts.AzurePlatform.InstanceType = "Standard_Unknown"
+			}),
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			prices := &retailPricesClient{httpClient: server.Client(), endpoint: server.URL}
+			estimate, err := estimateAzureCost(context.Background(), prices, tc.opts)
+			if tc.expectedErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(estimate.Items).To(HaveLen(len(tc.expectedItems)))
+			for i, item := range estimate.Items {
+				expected := tc.expectedItems[i]
+				g.Expect(item.Category).To(Equal(expected.Category))
+				g.Expect(item.Resource).To(Equal(expected.Resource))
+				g.Expect(item.Quantity).To(Equal(expected.Quantity))
+				g.Expect(item.Unit).To(Equal(expected.Unit))
+				g.Expect(item.UnitMonthlyPrice).To(BeNumerically("~", expected.UnitMonthlyPrice, 0.001))
+				g.Expect(item.MonthlyCost).To(BeNumerically("~", expected.MonthlyCost, 0.001))
+			}
+			g.Expect(estimate.MonthlyTotal).To(BeNumerically("~", tc.expectedTotal, 0.001))
+		})
+	}
+}
+
+func TestManagedDiskSKU(t *testing.T) {
+	testCases := []struct {
+		name               string
+		storageAccountType string
+		sizeGB             int32
+		expectedSKU        string
+		expectedOK         bool
+	}{
+		{name: "When the disk is premium, it should be billed for the smallest tier it fits in", storageAccountType: "Premium_LRS", sizeGB: 128, expectedSKU: "P10 LRS", expectedOK: true},
+		{name: "When the disk is a small standard HDD, it should be billed for the S4 tier", storageAccountType: "Standard_LRS", sizeGB: 16, expectedSKU: "S4 LRS", expectedOK: true},
+		{name: "When the disk is a zone redundant standard SSD, it should keep the redundancy", storageAccountType: "StandardSSD_ZRS", sizeGB: 200, expectedSKU: "E15 ZRS", expectedOK: true},
+		{name: "When the disk is an ultra disk, it should not be billed by tier", storageAccountType: "UltraSSD_LRS", sizeGB: 128},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			sku, ok := managedDiskSKU(tc.storageAccountType, tc.sizeGB)
+			g.Expect(ok).To(Equal(tc.expectedOK))
+			g.Expect(sku).To(Equal(tc.expectedSKU))
+		})
+	}
+}
@@ -10,6 +10,9 @@ import (
 	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
 	"github.com/openshift/hypershift/cmd/cluster/core"
 	azureinfra "github.com/openshift/hypershift/cmd/infra/azure"
+	"github.com/openshift/hypershift/cmd/infra/cloudapi"
+	"github.com/openshift/hypershift/cmd/instancetypes"
+	"github.com/openshift/hypershift/cmd/util"
 	apifixtures "github.com/openshift/hypershift/examples/fixtures"
 	"github.com/openshift/hypershift/support/releaseinfo"
 
@@ -28,21 +31,33 @@ func NewCreateCommand(opts *core.CreateOptions) *cobra.Command {
 	opts.AzurePlatform.Location = "eastus"
 	opts.AzurePlatform.InstanceType = "Standard_D4s_v4"
 	opts.AzurePlatform.DiskSizeGB = 120
+	opts.EstimateCostOutput = core.CostEstimateOutputTable
 
 	cmd.Flags().StringVar(&opts.AzurePlatform.CredentialsFile, "azure-creds", opts.AzurePlatform.CredentialsFile, "Path to an Azure credentials file (required)")
 	cmd.Flags().StringVar(&opts.AzurePlatform.Location, "location", opts.AzurePlatform.Location, "Location for the cluster")
 	cmd.Flags().StringVar(&opts.AzurePlatform.EncryptionKeyID, "encryption-key-id", opts.AzurePlatform.EncryptionKeyID, "etcd encryption key identifier in the form of https://<vaultName>.vault.azure.net/keys/<keyName>/<keyVersion>")
 	cmd.Flags().StringVar(&opts.AzurePlatform.InstanceType, "instance-type", opts.AzurePlatform.InstanceType, "The instance type to use for nodes")
 	cmd.Flags().Int32Var(&opts.AzurePlatform.DiskSizeGB, "root-disk-size", opts.AzurePlatform.DiskSizeGB, "The size of the root disk for machines in the NodePool (minimum 16)")
-	cmd.Flags().StringSliceVar(&opts.AzurePlatform.AvailabilityZones, "availability-zones", opts.AzurePlatform.AvailabilityZones, "The availability zones in which NodePools will be created. Must be left unspecified if the region does not support AZs. If set, one nodepool per zone will be created.")
+	cmd.Flags().StringSliceVar(&opts.AzurePlatform.AvailabilityZones, "availability-zones", opts.AzurePlatform.AvailabilityZones, "The availability zones in which NodePools will be created. Must be left unspecified if the region does not support AZs, in which case the VMs of each NodePool are spread across the fault domains of an availability set. If set, one nodepool per zone will be created.")
 	cmd.Flags().StringVar(&opts.AzurePlatform.ResourceGroupName, "resource-group-name", opts.AzurePlatform.ResourceGroupName, "A resource group name to create the HostedCluster infrastructure resources under.")
 	cmd.Flags().StringVar(&opts.AzurePlatform.VnetID, "vnet-id", opts.AzurePlatform.VnetID, "An existing VNET ID.")
 	cmd.Flags().StringVar(&opts.AzurePlatform.DiskEncryptionSetID, "disk-encryption-set-id", opts.AzurePlatform.DiskEncryptionSetID, "The Disk Encryption Set ID to use to encrypt the OS disks for the VMs.")
 	cmd.Flags().StringVar(&opts.AzurePlatform.NetworkSecurityGroup, "network-security-group", opts.AzurePlatform.NetworkSecurityGroup, "The name of the Network Security Group to use in Virtual Network created for HostedCluster.")
 	cmd.Flags().BoolVar(&opts.AzurePlatform.EnableEphemeralOSDisk, "enable-ephemeral-disk", opts.AzurePlatform.EnableEphemeralOSDisk, "If enabled, the Azure VMs in the default NodePool will be setup with ephemeral OS disks")
+	cmd.Flags().BoolVar(&opts.AzurePlatform.EnableAcceleratedNetworking, "enable-accelerated-networking", opts.AzurePlatform.EnableAcceleratedNetworking, "If enabled, the Azure VMs in the default NodePool will be setup with accelerated networking. The instance type must support it")
 	cmd.Flags().StringVar(&opts.AzurePlatform.DiskStorageAccountType, "disk-storage-account-type", opts.AzurePlatform.DiskStorageAccountType, "The disk storage account type for the OS disks for the VMs.")
 	cmd.Flags().StringToStringVarP(&opts.AzurePlatform.ResourceGroupTags, "resource-group-tags", "t", opts.AzurePlatform.ResourceGroupTags, "Additional tags to apply to the resource group created (e.g. 'key1=value1,key2=value2')")
 	cmd.Flags().StringVar(&opts.AzurePlatform.SubnetID, "subnet-id", opts.AzurePlatform.SubnetID, "The subnet ID where the VMs will be placed.")
+	cmd.Flags().StringVar(&opts.AzurePlatform.NetworkSecurityGroupID, "network-security-group-id", opts.AzurePlatform.NetworkSecurityGroupID, "The ID of the Network Security Group of --subnet-id in an existing network. When set, no infrastructure is created and the existing network resources are validated instead.")
+	cmd.Flags().StringVar(&opts.AzurePlatform.PublicDNSZoneID, "public-dns-zone-id", opts.AzurePlatform.PublicDNSZoneID, "The ID of an existing public DNS zone of the base domain, when creating the cluster in an existing network.")
+	cmd.Flags().StringVar(&opts.AzurePlatform.PrivateDNSZoneID, "private-dns-zone-id", opts.AzurePlatform.PrivateDNSZoneID, "The ID of an existing private DNS zone linked to --vnet-id, when creating the cluster in an existing network.")
+	cmd.Flags().StringVar(&opts.AzurePlatform.MachineIdentityID, "machine-identity-id", opts.AzurePlatform.MachineIdentityID, "The ID of an existing managed identity of the VMs, when creating the cluster in an existing network.")
+	cmd.Flags().StringVar(&opts.AzurePlatform.BootImageID, "boot-image-id", opts.AzurePlatform.BootImageID, "The ID of an existing RHCOS image of the VMs, when creating the cluster in an existing network.")
+	cmd.Flags().StringVar(&opts.AzurePlatform.APIServerCustomDomain, "api-server-custom-domain", opts.AzurePlatform.APIServerCustomDomain, "A custom domain to publish the API server under, served by a customer provided load balancer or Azure Front Door in front of the API server load balancer.")
+	cmd.Flags().StringVar(&opts.AzurePlatform.APIServerCustomDomainTarget, "api-server-custom-domain-target", opts.AzurePlatform.APIServerCustomDomainTarget, "The DNS name of the load balancer or Azure Front Door endpoint serving --api-server-custom-domain. When set, external-dns creates the record for the custom domain.")
+	cmd.Flags().Int32Var(&opts.AzurePlatform.APIServerCustomDomainPort, "api-server-custom-domain-port", opts.AzurePlatform.APIServerCustomDomainPort, "The port --api-server-custom-domain is served on. Defaults to the API server port.")
+	cmd.Flags().BoolVar(&opts.EstimateCost, "estimate-cost", opts.EstimateCost, "If true, prints the estimated monthly cost of the Azure resources of the cluster from the Azure Retail Prices API instead of creating it")
+	cmd.Flags().StringVar(&opts.EstimateCostOutput, "estimate-cost-output", opts.EstimateCostOutput, "The output format of --estimate-cost (table, json)")
 
 	_ = cmd.MarkFlagRequired("azure-creds")
 	_ = cmd.MarkPersistentFlagRequired("pull-secret")
@@ -55,6 +70,10 @@ func NewCreateCommand(opts *core.CreateOptions) *cobra.Command {
 			defer cancel()
 		}
 
+		if opts.EstimateCost {
+			return EstimateCost(ctx, opts, cmd.OutOrStdout())
+		}
+
 		if err := CreateCluster(ctx, opts); err != nil {
 			opts.Log.Error(err, "Failed to create cluster")
 			return err
@@ -69,6 +88,14 @@ func CreateCluster(ctx context.Context, opts *core.CreateOptions) error {
 	if err := core.Validate(ctx, opts); err != nil {
 		return err
 	}
+	if opts.AzurePlatform.APIServerCustomDomain != "" && opts.ExternalDNSDomain != "" {
+		return fmt.Errorf("flag --api-server-custom-domain can't be used with --external-dns-domain, the API server is published through a Route when using external DNS")
+	}
+	if usesExistingNetwork(&opts.AzurePlatform) {
+		if err := validateExistingNetworkOptions(opts); err != nil {
+			return err
+		}
+	}
 	return core.CreateCluster(ctx, opts, applyPlatformSpecificsValues)
 }
 
@@ -83,7 +110,21 @@ func applyPlatformSpecificsValues(ctx context.Context, exampleOptions *apifixtur
 		if err := yaml.Unmarshal(rawInfra, &infra); err != nil {
 			return fmt.Errorf("failed to deserialize infra json file: %w", err)
 		}
+	} else if usesExistingNetwork(&opts.AzurePlatform) {
+		infra, err = existingNetworkInfra(ctx, opts)
+		if err != nil {
+			return fmt.Errorf("failed to validate existing network: %w", err)
+		}
 	} else {
+		// Fail before creating the infrastructure rather than with machines that never become nodes.
+		subscriptionID, azureCreds, err := util.SetupAzureCredentialsWithOptions(opts.Log, nil, opts.AzurePlatform.CredentialsFile, cloudapi.FromContext(ctx).AzureCredentialOptions())
+		if err != nil {
+			return fmt.Errorf("failed to set up Azure credentials: %w", err)
+		}
+		if err := instancetypes.ValidateAzureVMSize(ctx, subscriptionID, azureCreds, opts.AzurePlatform.Location, opts.AzurePlatform.InstanceType, opts.Arch); err != nil {
+			return err
+		}
+
 		rhcosImage, err := lookupRHCOSImage(ctx, opts.Arch, opts.ReleaseImage, opts.PullSecretFile)
 		if err != nil {
 			return fmt.Errorf("failed to retrieve RHCOS image: %w", err)
@@ -101,12 +142,18 @@ func applyPlatformSpecificsValues(ctx context.Context, exampleOptions *apifixtur
 			NetworkSecurityGroup: opts.AzurePlatform.NetworkSecurityGroup,
 			ResourceGroupTags:    opts.AzurePlatform.ResourceGroupTags,
 			SubnetID:             opts.AzurePlatform.SubnetID,
+			Owner:                opts.Owner,
+			Lifetime:             opts.Lifetime,
 		}).Run(ctx, opts.Log)
 		if err != nil {
 			return fmt.Errorf("failed to create infra: %w", err)
 		}
 	}
 
+	if infra.AvailabilityMode == azureinfra.AvailabilityModeAvailabilitySet && len(opts.AzurePlatform.AvailabilityZones) > 0 {
+		return fmt.Errorf("location %s has no availability zones, --availability-zones can't be used: the VMs of each NodePool are spread across the fault domains of its availability set instead", infra.Location)
+	}
+
 	exampleOptions.BaseDomain = infra.BaseDomain
 	exampleOptions.PublicZoneID = infra.PublicZoneID
 	exampleOptions.PrivateZoneID = infra.PrivateZoneID
@@ -126,6 +173,16 @@ func applyPlatformSpecificsValues(ctx context.Context, exampleOptions *apifixtur
 		DiskEncryptionSetID:    opts.AzurePlatform.DiskEncryptionSetID,
 		EnableEphemeralOSDisk:  opts.AzurePlatform.EnableEphemeralOSDisk,
 		DiskStorageAccountType: opts.AzurePlatform.DiskStorageAccountType,
+
+		EnableAcceleratedNetworking: opts.AzurePlatform.EnableAcceleratedNetworking,
+	}
+
+	if opts.AzurePlatform.APIServerCustomDomain != "" {
+		exampleOptions.Azure.APIServerCustomDomain = &hyperv1.CustomDomainPublishingStrategy{
+			Hostname: opts.AzurePlatform.APIServerCustomDomain,
+			Target:   opts.AzurePlatform.APIServerCustomDomainTarget,
+			Port:     opts.AzurePlatform.APIServerCustomDomainPort,
+		}
 	}
 
 	if opts.AzurePlatform.EncryptionKeyID != "" {
@@ -211,5 +268,26 @@ func validateFlags(cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("resource-group-name is required when using disk-encryption-set-id")
 	}
 
+	// Validate the API server custom domain flags
+	customDomain, err := cmd.Flags().GetString("api-server-custom-domain")
+	if err != nil {
+		return err
+	}
+	customDomainTarget, err := cmd.Flags().GetString("api-server-custom-domain-target")
+	if err != nil {
+		return err
+	}
+	customDomainPort, err := cmd.Flags().GetInt32("api-server-custom-domain-port")
+	if err != nil {
+		return err
+	}
+
+	if customDomain == "" && (customDomainTarget != "" || customDomainPort != 0) {
+		return fmt.Errorf("flag --api-server-custom-domain is required when using --api-server-custom-domain-target or --api-server-custom-domain-port")
+	}
+	if customDomainPort < 0 || customDomainPort > 65535 {
+		return fmt.Errorf("flag --api-server-custom-domain-port must be a valid port")
+	}
+
 	return nil
 }
@@ -93,7 +93,7 @@ func DestroyCluster(ctx context.Context, o *core.DestroyOptions) error {
 		o.AzurePlatform.ResourceGroupName = o.Name + "-" + o.InfraID
 	}
 
-	return core.DestroyCluster(ctx, hostedCluster, o, destroyPlatformSpecifics)
+	return core.DestroyCluster(ctx, hostedCluster, o, destroyPlatformSpecifics, inventoryPlatformSpecifics)
 }
 
 func destroyPlatformSpecifics(ctx context.Context, o *core.DestroyOptions) error {
@@ -105,3 +105,21 @@ func destroyPlatformSpecifics(ctx context.Context, o *core.DestroyOptions) error
 		ResourceGroupName: o.AzurePlatform.ResourceGroupName,
 	}).Run(ctx)
 }
+
+func inventoryPlatformSpecifics(ctx context.Context, o *core.DestroyOptions) ([]core.InventoryResource, error) {
+	resources, err := (&azureinfra.DestroyInfraOptions{
+		Name:              o.Name,
+		Location:          o.AzurePlatform.Location,
+		InfraID:           o.InfraID,
+		CredentialsFile:   o.AzurePlatform.CredentialsFile,
+		ResourceGroupName: o.AzurePlatform.ResourceGroupName,
+	}).Inventory(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var inventory []core.InventoryResource
+	for _, resource := range resources {
+		inventory = append(inventory, core.InventoryResource{Source: "Azure", Type: resource.Type, Name: resource.Name})
+	}
+	return inventory, nil
+}
@@ -0,0 +1,370 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/dns/armdns"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v5"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/privatedns/armprivatedns"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/cmd/cluster/core"
+	azureinfra "github.com/openshift/hypershift/cmd/infra/azure"
+	"github.com/openshift/hypershift/cmd/util"
+	"github.com/openshift/hypershift/support/azureutil"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+const (
+	serviceTagInternet       = "Internet"
+	serviceTagVirtualNetwork = "VirtualNetwork"
+)
+
+// requiredFlow is a flow the NSG of an existing network must allow for the nodes of the cluster to work.
+type requiredFlow struct {
+	description string
+	direction   armnetwork.SecurityRuleDirection
+	protocol    armnetwork.SecurityRuleProtocol
+	port        int
+	// peer is the service tag of the source of an inbound flow or of the destination of an outbound flow.
+	peer string
+}
+
+var requiredFlows = []requiredFlow{
+	{
+		description: "nodes reaching the API server, the ignition server and the image registries",
+		direction:   armnetwork.SecurityRuleDirectionOutbound,
+		protocol:    armnetwork.SecurityRuleProtocolTCP,
+		port:        443,
+		peer:        serviceTagInternet,
+	},
+	{
+		description: "nodes reaching the API server load balancer",
+		direction:   armnetwork.SecurityRuleDirectionOutbound,
+		protocol:    armnetwork.SecurityRuleProtocolTCP,
+		port:        6443,
+		peer:        serviceTagInternet,
+	},
+	{
+		description: "the kubelet API",
+		direction:   armnetwork.SecurityRuleDirectionInbound,
+		protocol:    armnetwork.SecurityRuleProtocolTCP,
+		port:        10250,
+		peer:        serviceTagVirtualNetwork,
+	},
+	{
+		description: "the Geneve overlay of the pod network",
+		direction:   armnetwork.SecurityRuleDirectionInbound,
+		protocol:    armnetwork.SecurityRuleProtocolUDP,
+		port:        6081,
+		peer:        serviceTagVirtualNetwork,
+	},
+}
+
+// usesExistingNetwork returns true when the cluster is created in an existing network, in which case no
+// infrastructure is created.
+func usesExistingNetwork(opts *core.AzurePlatformOptions) bool {
+	return opts.NetworkSecurityGroupID != "" || opts.PrivateDNSZoneID != "" || opts.PublicDNSZoneID != ""
+}
+
+// validateExistingNetworkOptions validates that all the resources infra creation would otherwise create are
+// provided when the cluster is created in an existing network.
+func validateExistingNetworkOptions(opts *core.CreateOptions) error {
+	if opts.InfrastructureJSON != "" {
+		return fmt.Errorf("flag --infra-json can't be used with --network-security-group-id, --private-dns-zone-id or --public-dns-zone-id")
+	}
+	if opts.AzurePlatform.NetworkSecurityGroup != "" {
+		return fmt.Errorf("flag --network-security-group can't be used with --network-security-group-id")
+	}
+	type requiredFlag struct {
+		flag  string
+		value string
+	}
+	required := []requiredFlag{
+		{"--resource-group-name", opts.AzurePlatform.ResourceGroupName},
+		{"--vnet-id", opts.AzurePlatform.VnetID},
+		{"--subnet-id", opts.AzurePlatform.SubnetID},
+		{"--network-security-group-id", opts.AzurePlatform.NetworkSecurityGroupID},
+		{"--private-dns-zone-id", opts.AzurePlatform.PrivateDNSZoneID},
+		{"--machine-identity-id", opts.AzurePlatform.MachineIdentityID},
+		{"--boot-image-id", opts.AzurePlatform.BootImageID},
+	}
+	if opts.DNSPublishing != string(hyperv1.InternalDNSPublishing) {
+		required = append(required, requiredFlag{"--public-dns-zone-id", opts.AzurePlatform.PublicDNSZoneID})
+	}
+	var missing []string
+	for _, r := range required {
+		if r.value == "" {
+			missing = append(missing, r.flag)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("flags %s are required to create a cluster in an existing network", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// existingNetworkInfra validates the existing network resources of the cluster and returns them as the
+// infrastructure of the cluster.
+func existingNetworkInfra(ctx context.Context, opts *core.CreateOptions) (*azureinfra.CreateInfraOutput, error) {
+	subscriptionID, azureCreds, err := util.SetupAzureCredentials(opts.Log, nil, opts.AzurePlatform.CredentialsFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to setup Azure credentials: %w", err)
+	}
+
+	vnet, err := azureutil.GetVnetInfoFromVnetID(ctx, opts.AzurePlatform.VnetID, subscriptionID, azureCreds)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateVnet(&vnet.VirtualNetwork, opts.AzurePlatform.SubnetID, opts.AzurePlatform.NetworkSecurityGroupID); err != nil {
+		return nil, fmt.Errorf("invalid virtual network %s: %w", opts.AzurePlatform.VnetID, err)
+	}
+
+	nsg, err := getSecurityGroup(ctx, subscriptionID, opts.AzurePlatform.NetworkSecurityGroupID, azureCreds)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateSecurityRules(nsg); err != nil {
+		return nil, fmt.Errorf("invalid network security group %s: %w", opts.AzurePlatform.NetworkSecurityGroupID, err)
+	}
+
+	if err := validatePrivateDNSZone(ctx, subscriptionID, opts.AzurePlatform.PrivateDNSZoneID, *vnet.ID, azureCreds); err != nil {
+		return nil, err
+	}
+	if opts.AzurePlatform.PublicDNSZoneID != "" {
+		if err := validatePublicDNSZone(ctx, subscriptionID, opts.AzurePlatform.PublicDNSZoneID, opts.BaseDomain, azureCreds); err != nil {
+			return nil, err
+		}
+	}
+	opts.Log.Info("Successfully validated existing network", "vnet", *vnet.Name)
+
+	return &azureinfra.CreateInfraOutput{
+		BaseDomain:        opts.BaseDomain,
+		PublicZoneID:      opts.AzurePlatform.PublicDNSZoneID,
+		PrivateZoneID:     opts.AzurePlatform.PrivateDNSZoneID,
+		Location:          opts.AzurePlatform.Location,
+		ResourceGroupName: opts.AzurePlatform.ResourceGroupName,
+		VNetID:            *vnet.ID,
+		VnetName:          *vnet.Name,
+		SubnetID:          opts.AzurePlatform.SubnetID,
+		BootImageID:       opts.AzurePlatform.BootImageID,
+		InfraID:           opts.InfraID,
+		MachineIdentityID: opts.AzurePlatform.MachineIdentityID,
+		SecurityGroupID:   opts.AzurePlatform.NetworkSecurityGroupID,
+	}, nil
+}
+
+// validateVnet validates that the subnet belongs to the VNET and is associated with the NSG, and that all the
+// peerings of the VNET are connected.
+func validateVnet(vnet *armnetwork.VirtualNetwork, subnetID, nsgID string) error {
+	var subnet *armnetwork.Subnet
+	if vnet.Properties != nil {
+		for _, s := range vnet.Properties.Subnets {
+			if s.ID != nil && strings.EqualFold(*s.ID, subnetID) {
+				subnet = s
+				break
+			}
+		}
+	}
+	if subnet == nil {
+		return fmt.Errorf("subnet %s not found", subnetID)
+	}
+	if subnet.Properties == nil || subnet.Properties.NetworkSecurityGroup == nil || subnet.Properties.NetworkSecurityGroup.ID == nil {
+		return fmt.Errorf("subnet %s has no network security group, expected %s", subnetID, nsgID)
+	}
+	if !strings.EqualFold(*subnet.Properties.NetworkSecurityGroup.ID, nsgID) {
+		return fmt.Errorf("subnet %s is associated with network security group %s, expected %s", subnetID, *subnet.Properties.NetworkSecurityGroup.ID, nsgID)
+	}
+
+	var errs []error
+	for _, peering := range vnet.Properties.VirtualNetworkPeerings {
+		if peering.Properties == nil || peering.Properties.PeeringState == nil {
+			continue
+		}
+		if state := *peering.Properties.PeeringState; state != armnetwork.VirtualNetworkPeeringStateConnected {
+			name := ""
+			if peering.Name != nil {
+				name = *peering.Name
+			}
+			errs = append(errs, fmt.Errorf("peering %s is %s, expected %s", name, state, armnetwork.VirtualNetworkPeeringStateConnected))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+func getSecurityGroup(ctx context.Context, subscriptionID, nsgID string, azureCreds azcore.TokenCredential) (*armnetwork.SecurityGroup, error) {
+	nsgName, nsgResourceGroupName, err := azureutil.GetNameAndResourceGroupFromNetworkSecurityGroupID(nsgID)
+	if err != nil {
+		return nil, err
+	}
+	securityGroupClient, err := armnetwork.NewSecurityGroupsClient(subscriptionID, azureCreds, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create security group client: %w", err)
+	}
+	nsg, err := securityGroupClient.Get(ctx, nsgResourceGroupName, nsgName, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get network security group %s: %w", nsgID, err)
+	}
+	return &nsg.SecurityGroup, nil
+}
+
+// validateSecurityRules validates that the rules of the NSG, default rules included, allow the required flows.
+// Rules scoped to address prefixes other than service tags and wildcards are assumed not to apply to the flows.
+func validateSecurityRules(nsg *armnetwork.SecurityGroup) error {
+	var rules []*armnetwork.SecurityRule
+	if nsg.Properties != nil {
+		rules = append(rules, nsg.Properties.SecurityRules...)
+		rules = append(rules, nsg.Properties.DefaultSecurityRules...)
+	}
+	rules = sortedByPriority(rules)
+
+	var errs []error
+	for _, flow := range requiredFlows {
+		if rule := firstMatchingRule(rules, flow); rule != nil && *rule.Properties.Access == armnetwork.SecurityRuleAccessDeny {
+			name := ""
+			if rule.Name != nil {
+				name = *rule.Name
+			}
+			errs = append(errs, fmt.Errorf("rule %s denies %s %s port %d for %s", name, flow.direction, flow.protocol, flow.port, flow.description))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+func sortedByPriority(rules []*armnetwork.SecurityRule) []*armnetwork.SecurityRule {
+	var sorted []*armnetwork.SecurityRule
+	for _, rule := range rules {
+		if rule != nil && rule.Properties != nil && rule.Properties.Priority != nil && rule.Properties.Access != nil && rule.Properties.Direction != nil {
+			sorted = append(sorted, rule)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return *sorted[i].Properties.Priority < *sorted[j].Properties.Priority
+	})
+	return sorted
+}
+
+func firstMatchingRule(rules []*armnetwork.SecurityRule, flow requiredFlow) *armnetwork.SecurityRule {
+	for _, rule := range rules {
+		p := rule.Properties
+		if *p.Direction != flow.direction {
+			continue
+		}
+		if p.Protocol != nil && *p.Protocol != armnetwork.SecurityRuleProtocolAsterisk && *p.Protocol != flow.protocol {
+			continue
+		}
+		if !portRangesContain(p.DestinationPortRange, p.DestinationPortRanges, flow.port) {
+			continue
+		}
+		peerPrefix, peerPrefixes := p.SourceAddressPrefix, p.SourceAddressPrefixes
+		if flow.direction == armnetwork.SecurityRuleDirectionOutbound {
+			peerPrefix, peerPrefixes = p.DestinationAddressPrefix, p.DestinationAddressPrefixes
+		}
+		if !addressPrefixesMatch(peerPrefix, peerPrefixes, flow.peer) {
+			continue
+		}
+		return rule
+	}
+	return nil
+}
+
+func portRangesContain(portRange *string, portRanges []*string, port int) bool {
+	for _, r := range append([]*string{portRange}, portRanges...) {
+		if r == nil {
+			continue
+		}
+		if *r == "*" {
+			return true
+		}
+		from, to, found := strings.Cut(*r, "-")
+		if !found {
+			to = from
+		}
+		fromPort, err := strconv.Atoi(strings.TrimSpace(from))
+		if err != nil {
+			continue
+		}
+		toPort, err := strconv.Atoi(strings.TrimSpace(to))
+		if err != nil {
+			continue
+		}
+		if fromPort <= port && port <= toPort {
+			return true
+		}
+	}
+	return false
+}
+
+func addressPrefixesMatch(prefix *string, prefixes []*string, serviceTag string) bool {
+	for _, p := range append([]*string{prefix}, prefixes...) {
+		if p == nil {
+			continue
+		}
+		switch {
+		case *p == "*", strings.EqualFold(*p, "Any"), strings.EqualFold(*p, serviceTag):
+			return true
+		case serviceTag == serviceTagInternet && *p == "0.0.0.0/0":
+			return true
+		}
+	}
+	return false
+}
+
+// validatePrivateDNSZone validates that the private DNS zone is linked to the VNET, so that the nodes resolve its
+// records.
+func validatePrivateDNSZone(ctx context.Context, subscriptionID, zoneID, vnetID string, azureCreds azcore.TokenCredential) error {
+	zone, err := arm.ParseResourceID(zoneID)
+	if err != nil {
+		return fmt.Errorf("failed to parse private DNS zone ID %q: %w", zoneID, err)
+	}
+	if !strings.EqualFold(zone.ResourceType.Type, "privateDnsZones") {
+		return fmt.Errorf("invalid resource type '%s', expected 'privateDnsZones'", zone.ResourceType.Type)
+	}
+
+	linksClient, err := armprivatedns.NewVirtualNetworkLinksClient(subscriptionID, azureCreds, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create new virtual network links client: %w", err)
+	}
+	pager := linksClient.NewListPager(zone.ResourceGroupName, zone.Name, nil)
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list virtual network links of private DNS zone %s: %w", zone.Name, err)
+		}
+		for _, link := range page.Value {
+			if link.Properties != nil && link.Properties.VirtualNetwork != nil && link.Properties.VirtualNetwork.ID != nil && strings.EqualFold(*link.Properties.VirtualNetwork.ID, vnetID) {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("private DNS zone %s is not linked to virtual network %s", zone.Name, vnetID)
+}
+
+// validatePublicDNSZone validates that the public DNS zone exists and serves the base domain.
+func validatePublicDNSZone(ctx context.Context, subscriptionID, zoneID, baseDomain string, azureCreds azcore.TokenCredential) error {
+	zone, err := arm.ParseResourceID(zoneID)
+	if err != nil {
+		return fmt.Errorf("failed to parse public DNS zone ID %q: %w", zoneID, err)
+	}
+	if !strings.EqualFold(zone.ResourceType.Type, "dnszones") {
+		return fmt.Errorf("invalid resource type '%s', expected 'dnszones'", zone.ResourceType.Type)
+	}
+
+	zonesClient, err := armdns.NewZonesClient(subscriptionID, azureCreds, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create new DNS zones client: %w", err)
+	}
+	if _, err := zonesClient.Get(ctx, zone.ResourceGroupName, zone.Name, nil); err != nil {
+		return fmt.Errorf("failed to get public DNS zone %s: %w", zone.Name, err)
+	}
+	if !strings.EqualFold(zone.Name, baseDomain) {
+		return fmt.Errorf("public DNS zone %s doesn't match the base domain %s", zone.Name, baseDomain)
+	}
+	return nil
+}
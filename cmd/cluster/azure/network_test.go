@@ -0,0 +1,262 @@
+package azure
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v5"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/cmd/cluster/core"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	testVnetID   = "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/virtualNetworks/vnet"
+	testSubnetID = testVnetID + "/subnets/default"
+	testNSGID    = "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/networkSecurityGroups/nsg"
+)
+
+func TestValidateExistingNetworkOptions(t *testing.T) {
+	existingNetwork := func(mutate func(*core.CreateOptions)) *core.CreateOptions {
+		opts := &core.CreateOptions{
+			AzurePlatform: core.AzurePlatformOptions{
+				ResourceGroupName:      "rg",
+				VnetID:                 testVnetID,
+				SubnetID:               testSubnetID,
+				NetworkSecurityGroupID: testNSGID,
+				PublicDNSZoneID:        "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/dnszones/example.com",
+				PrivateDNSZoneID:       "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/privateDnsZones/example.example.com",
+				MachineIdentityID:      "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.ManagedIdentity/userAssignedIdentities/identity",
+				BootImageID:            "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/images/rhcos",
+			},
+		}
+		if mutate != nil {
+			mutate(opts)
+		}
+		return opts
+	}
+
+	testCases := []struct {
+		name        string
+		opts        *core.CreateOptions
+		expectError bool
+	}{
+		{
+			name: "When all the existing network resources are set it should be valid",
+			opts: existingNetwork(nil),
+		},
+		{
+			name: "When the public DNS zone is not set with internal DNS publishing it should be valid",
+			opts: existingNetwork(func(opts *core.CreateOptions) {
+				opts.AzurePlatform.PublicDNSZoneID = ""
+				opts.DNSPublishing = string(hyperv1.InternalDNSPublishing)
+			}),
+		},
+		{
+			name: "When the public DNS zone is not set with external DNS publishing it should be invalid",
+			opts: existingNetwork(func(opts *core.CreateOptions) {
+				opts.AzurePlatform.PublicDNSZoneID = ""
+			}),
+			expectError: true,
+		},
+		{
+			name: "When the boot image is not set it should be invalid",
+			opts: existingNetwork(func(opts *core.CreateOptions) {
+				opts.AzurePlatform.BootImageID = ""
+			}),
+			expectError: true,
+		},
+		{
+			name: "When the infra json is set it should be invalid",
+			opts: existingNetwork(func(opts *core.CreateOptions) {
+				opts.InfrastructureJSON = "infra.json"
+			}),
+			expectError: true,
+		},
+		{
+			name: "When the network security group name is set it should be invalid",
+			opts: existingNetwork(func(opts *core.CreateOptions) {
+				opts.AzurePlatform.NetworkSecurityGroup = "nsg"
+			}),
+			expectError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := validateExistingNetworkOptions(tc.opts)
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestValidateVnet(t *testing.T) {
+	vnet := func(nsgID string, peeringStates ...armnetwork.VirtualNetworkPeeringState) *armnetwork.VirtualNetwork {
+		subnet := &armnetwork.Subnet{ID: ptr.To(testSubnetID), Properties: &armnetwork.SubnetPropertiesFormat{}}
+		if nsgID != "" {
+			subnet.Properties.NetworkSecurityGroup = &armnetwork.SecurityGroup{ID: ptr.To(nsgID)}
+		}
+		vnet := &armnetwork.VirtualNetwork{
+			ID: ptr.To(testVnetID),
+			Properties: &armnetwork.VirtualNetworkPropertiesFormat{
+				Subnets: []*armnetwork.Subnet{subnet},
+			},
+		}
+		for _, state := range peeringStates {
+			vnet.Properties.VirtualNetworkPeerings = append(vnet.Properties.VirtualNetworkPeerings, &armnetwork.VirtualNetworkPeering{
+				Name:       ptr.To("peering"),
+				Properties: &armnetwork.VirtualNetworkPeeringPropertiesFormat{PeeringState: ptr.To(state)},
+			})
+		}
+		return vnet
+	}
+
+	testCases := []struct {
+		name        string
+		vnet        *armnetwork.VirtualNetwork
+		subnetID    string
+		expectError bool
+	}{
+		{
+			name:     "When the subnet is associated with the network security group it should be valid",
+			vnet:     vnet(testNSGID),
+			subnetID: testSubnetID,
+		},
+		{
+			name:     "When the peerings are connected it should be valid",
+			vnet:     vnet(testNSGID, armnetwork.VirtualNetworkPeeringStateConnected),
+			subnetID: testSubnetID,
+		},
+		{
+			name:        "When the subnet is not in the vnet it should be invalid",
+			vnet:        vnet(testNSGID),
+			subnetID:    testVnetID + "/subnets/other",
+			expectError: true,
+		},
+		{
+			name:        "When the subnet has no network security group it should be invalid",
+			vnet:        vnet(""),
+			subnetID:    testSubnetID,
+			expectError: true,
+		},
+		{
+			name:        "When the subnet is associated with another network security group it should be invalid",
+			vnet:        vnet("/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/networkSecurityGroups/other"),
+			subnetID:    testSubnetID,
+			expectError: true,
+		},
+		{
+			name:        "When a peering is not connected it should be invalid",
+			vnet:        vnet(testNSGID, armnetwork.VirtualNetworkPeeringStateConnected, armnetwork.VirtualNetworkPeeringStateInitiated),
+			subnetID:    testSubnetID,
+			expectError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := validateVnet(tc.vnet, tc.subnetID, testNSGID)
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestValidateSecurityRules(t *testing.T) {
+	rule := func(name string, priority int32, direction armnetwork.SecurityRuleDirection, access armnetwork.SecurityRuleAccess, protocol armnetwork.SecurityRuleProtocol, port, peer string) *armnetwork.SecurityRule {
+		r := &armnetwork.SecurityRule{
+			Name: ptr.To(name),
+			Properties: &armnetwork.SecurityRulePropertiesFormat{
+				Priority:                 ptr.To(priority),
+				Direction:                ptr.To(direction),
+				Access:                   ptr.To(access),
+				Protocol:                 ptr.To(protocol),
+				DestinationPortRange:     ptr.To(port),
+				SourceAddressPrefix:      ptr.To("*"),
+				DestinationAddressPrefix: ptr.To("*"),
+			},
+		}
+		if direction == armnetwork.SecurityRuleDirectionInbound {
+			r.Properties.SourceAddressPrefix = ptr.To(peer)
+		} else {
+			r.Properties.DestinationAddressPrefix = ptr.To(peer)
+		}
+		return r
+	}
+	defaultRules := []*armnetwork.SecurityRule{
+		rule("AllowVnetInBound", 65000, armnetwork.SecurityRuleDirectionInbound, armnetwork.SecurityRuleAccessAllow, armnetwork.SecurityRuleProtocolAsterisk, "*", "VirtualNetwork"),
+		rule("DenyAllInBound", 65500, armnetwork.SecurityRuleDirectionInbound, armnetwork.SecurityRuleAccessDeny, armnetwork.SecurityRuleProtocolAsterisk, "*", "*"),
+		rule("AllowInternetOutBound", 65001, armnetwork.SecurityRuleDirectionOutbound, armnetwork.SecurityRuleAccessAllow, armnetwork.SecurityRuleProtocolAsterisk, "*", "Internet"),
+		rule("DenyAllOutBound", 65500, armnetwork.SecurityRuleDirectionOutbound, armnetwork.SecurityRuleAccessDeny, armnetwork.SecurityRuleProtocolAsterisk, "*", "*"),
+	}
+
+	testCases := []struct {
+		name        string
+		rules       []*armnetwork.SecurityRule
+		expectError bool
+	}{
+		{
+			name: "When there are only the default rules it should be valid",
+		},
+		{
+			name: "When a deny rule doesn't match the required flows it should be valid",
+			rules: []*armnetwork.SecurityRule{
+				rule("deny-ssh", 100, armnetwork.SecurityRuleDirectionInbound, armnetwork.SecurityRuleAccessDeny, armnetwork.SecurityRuleProtocolTCP, "22", "*"),
+				rule("deny-subnet", 110, armnetwork.SecurityRuleDirectionOutbound, armnetwork.SecurityRuleAccessDeny, armnetwork.SecurityRuleProtocolAsterisk, "*", "192.168.0.0/24"),
+			},
+		},
+		{
+			name: "When a deny rule has a lower priority than an allow rule it should be valid",
+			rules: []*armnetwork.SecurityRule{
+				rule("deny-https", 200, armnetwork.SecurityRuleDirectionOutbound, armnetwork.SecurityRuleAccessDeny, armnetwork.SecurityRuleProtocolTCP, "443", "Internet"),
+				rule("allow-https", 100, armnetwork.SecurityRuleDirectionOutbound, armnetwork.SecurityRuleAccessAllow, armnetwork.SecurityRuleProtocolTCP, "443", "Internet"),
+			},
+		},
+		{
+			name: "When a rule denies outbound https to the internet it should be invalid",
+			rules: []*armnetwork.SecurityRule{
+				rule("deny-https", 100, armnetwork.SecurityRuleDirectionOutbound, armnetwork.SecurityRuleAccessDeny, armnetwork.SecurityRuleProtocolTCP, "400-500", "Internet"),
+			},
+			expectError: true,
+		},
+		{
+			name: "When a rule denies the kubelet port within the vnet it should be invalid",
+			rules: []*armnetwork.SecurityRule{
+				rule("deny-kubelet", 100, armnetwork.SecurityRuleDirectionInbound, armnetwork.SecurityRuleAccessDeny, armnetwork.SecurityRuleProtocolAsterisk, "10250", "VirtualNetwork"),
+			},
+			expectError: true,
+		},
+		{
+			name: "When a rule denies the geneve port from anywhere it should be invalid",
+			rules: []*armnetwork.SecurityRule{
+				rule("deny-geneve", 100, armnetwork.SecurityRuleDirectionInbound, armnetwork.SecurityRuleAccessDeny, armnetwork.SecurityRuleProtocolUDP, "6081", "*"),
+			},
+			expectError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			nsg := &armnetwork.SecurityGroup{
+				Properties: &armnetwork.SecurityGroupPropertiesFormat{
+					SecurityRules:        tc.rules,
+					DefaultSecurityRules: defaultRules,
+				},
+			}
+			err := validateSecurityRules(nsg)
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+		})
+	}
+}
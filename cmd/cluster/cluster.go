@@ -57,6 +57,8 @@ func NewCreateCommands() *cobra.Command {
 	cmd.PersistentFlags().StringVar(&opts.BaseDomain, "base-domain", opts.BaseDomain, "The ingress base domain for the cluster")
 	cmd.PersistentFlags().StringVar(&opts.BaseDomainPrefix, "base-domain-prefix", opts.BaseDomainPrefix, "The ingress base domain prefix for the cluster, defaults to cluster name. Use 'none' for an empty prefix")
 	cmd.PersistentFlags().StringVar(&opts.ExternalDNSDomain, "external-dns-domain", opts.ExternalDNSDomain, "Sets hostname to opinionated values in the specificed domain for services with publishing type LoadBalancer or Route.")
+	cmd.PersistentFlags().StringVar(&opts.ClusterDNSDomain, "cluster-dns-domain", opts.ClusterDNSDomain, "The private DNS domain of the control plane endpoints of the cluster, defaults to hypershift.local")
+	cmd.PersistentFlags().StringVar(&opts.DNSPublishing, "dns-publishing", opts.DNSPublishing, "How the DNS records of the cluster are published (External, Internal). Internal creates no public DNS records, the ingress and control plane endpoints of the cluster are only reachable privately")
 	cmd.PersistentFlags().StringVar(&opts.NetworkType, "network-type", opts.NetworkType, "Enum specifying the cluster SDN provider. Supports either Calico, OVNKubernetes, OpenShiftSDN or Other.")
 	cmd.PersistentFlags().StringVar(&opts.ReleaseImage, "release-image", opts.ReleaseImage, "The OCP release image for the cluster")
 	cmd.PersistentFlags().StringVar(&opts.ReleaseStream, "release-stream", opts.ReleaseStream, "The OCP release stream for the cluster (e.g. 4.15.0-0.nightly), this flag is ignored if release-image is set")
@@ -81,14 +83,35 @@ func NewCreateCommands() *cobra.Command {
 	cmd.PersistentFlags().StringArrayVar(&opts.ClusterCIDR, "cluster-cidr", opts.ClusterCIDR, "The CIDR of the cluster network. Can be specified multiple times.")
 	cmd.PersistentFlags().BoolVar(&opts.DefaultDual, "default-dual", opts.DefaultDual, "Defines the Service and Cluster CIDRs as dual-stack default values. Cannot be defined with service-cidr or cluster-cidr flag.")
 	cmd.PersistentFlags().StringToStringVar(&opts.NodeSelector, "node-selector", opts.NodeSelector, "A comma separated list of key=value to use as node selector for the Hosted Control Plane pods to stick to. E.g. role=cp,disk=fast")
-	cmd.PersistentFlags().BoolVar(&opts.Wait, "wait", opts.Wait, "If the create command should block until the cluster is up. Requires at least one node.")
-	cmd.PersistentFlags().DurationVar(&opts.Timeout, "timeout", opts.Timeout, "If the --wait flag is set, set the optional timeout to limit the waiting duration. The format is duration; e.g. 30s or 1h30m45s; 0 means no timeout; default = 0")
+	cmd.PersistentFlags().BoolVar(&opts.Wait, "wait", opts.Wait, "If the create command should block until the cluster is up, reporting the condition transitions and rollout milestones along the way. Requires at least one node.")
+	cmd.PersistentFlags().DurationVar(&opts.Timeout, "timeout", opts.Timeout, "If the --wait flag is set, set the optional timeout to limit the waiting duration. The command exits with an error listing the pending rollout milestones when it expires. The format is duration; e.g. 30s or 1h30m45s; 0 means no timeout; default = 0")
 	cmd.PersistentFlags().Var(&opts.NodeUpgradeType, "node-upgrade-type", "The NodePool upgrade strategy for how nodes should behave when upgraded. Supported options: Replace, InPlace")
 	cmd.PersistentFlags().Var(&opts.OLMCatalogPlacement, "olm-catalog-placement", "The OLM Catalog Placement for the HostedCluster. Supported options: Management, Guest")
 	cmd.PersistentFlags().BoolVar(&opts.OLMDisableDefaultSources, "olm-disable-default-sources", opts.OLMDisableDefaultSources, "Disables the OLM default catalog sources for the HostedCluster.")
 	cmd.PersistentFlags().StringVar(&opts.Arch, "arch", opts.Arch, "The default processor architecture for the NodePool (e.g. arm64, amd64)")
+	cmd.PersistentFlags().DurationVar(&opts.Lifetime, "lifetime", opts.Lifetime, "If set, the HostedCluster is deleted once this duration elapsed since its creation, e.g. 8h. Useful for CI and demo clusters.")
+	cmd.PersistentFlags().StringVar(&opts.Owner, "owner", opts.Owner, "The owner of the cluster, e.g. a team or an email address. The cloud resources of the cluster are tagged with it")
 	cmd.PersistentFlags().StringVar(&opts.PausedUntil, "pausedUntil", opts.PausedUntil, "If a date is provided in RFC3339 format, HostedCluster creation is paused until that date. If the boolean true is provided, HostedCluster creation is paused until the field is removed.")
 
+	cmd.Flags().StringVar(&opts.FromExport, "from-export", opts.FromExport, "Path to a cluster export written by 'hypershift export cluster' to create the cluster from. The name and namespace of the exported cluster are used unless --name and --namespace are set. The pull secret is created from --pull-secret when set, the other secrets the cluster references must already exist")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if opts.FromExport == "" {
+			return cmd.Help()
+		}
+		if !cmd.Flags().Changed("name") {
+			opts.Name = ""
+		}
+		if !cmd.Flags().Changed("namespace") {
+			opts.Namespace = ""
+		}
+		if err := core.CreateClusterFromExport(cmd.Context(), opts); err != nil {
+			opts.Log.Error(err, "Failed to create cluster from export")
+			return err
+		}
+		return nil
+	}
+
 	cmd.MarkFlagsMutuallyExclusive("service-cidr", "default-dual")
 	cmd.MarkFlagsMutuallyExclusive("cluster-cidr", "default-dual")
 
@@ -110,6 +133,7 @@ func NewDestroyCommands() *cobra.Command {
 		ClusterGracePeriod:    10 * time.Minute,
 		Log:                   log.Log,
 		DestroyCloudResources: true,
+		Output:                core.DestroyOutputTable,
 	}
 
 	cmd := &cobra.Command{
@@ -122,6 +146,8 @@ func NewDestroyCommands() *cobra.Command {
 	cmd.PersistentFlags().DurationVar(&opts.ClusterGracePeriod, "cluster-grace-period", opts.ClusterGracePeriod, "How long to wait for the cluster to be deleted before forcibly destroying its infra")
 	cmd.PersistentFlags().StringVar(&opts.InfraID, "infra-id", opts.InfraID, "Infrastructure ID; inferred from the hosted cluster by default")
 	cmd.PersistentFlags().BoolVar(&opts.DestroyCloudResources, "destroy-cloud-resources", opts.DestroyCloudResources, "If true, cloud resources such as load balancers and persistent storage disks created by the cluster during its lifetime are removed")
+	cmd.PersistentFlags().BoolVar(&opts.DryRun, "dry-run", opts.DryRun, "If true, print the inventory of the Kubernetes and cloud resources that would be deleted without deleting anything")
+	cmd.PersistentFlags().StringVarP(&opts.Output, "output", "o", opts.Output, "Output format of the --dry-run inventory. Supported options: table, json")
 
 	cmd.MarkPersistentFlagRequired("name")
 
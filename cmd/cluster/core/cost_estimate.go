@@ -0,0 +1,93 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+)
+
+const (
+	CostEstimateOutputTable = "table"
+	CostEstimateOutputJSON  = "json"
+
+	// HoursPerMonth is the number of hours cloud providers bill hourly prices for in a month.
+	HoursPerMonth = 730
+)
+
+// CostEstimate is the estimated monthly cost of the cloud resources of a cluster, before it's created.
+type CostEstimate struct {
+	// Platform is the cloud the prices are from, e.g. AWS.
+	Platform string `json:"platform"`
+	// Region is the region or location the prices are from.
+	Region string `json:"region"`
+	// Currency is the currency of the prices, e.g. USD.
+	Currency string             `json:"currency"`
+	Items    []CostEstimateItem `json:"items"`
+	// MonthlyTotal is the sum of the monthly costs of the items.
+	MonthlyTotal float64 `json:"monthlyTotal"`
+
+	// Notes describe the assumptions of the estimate and the costs it doesn't include.
+	Notes []string `json:"notes,omitempty"`
+}
+
+// CostEstimateItem is the estimated monthly cost of one kind of cloud resource of a cluster.
+type CostEstimateItem struct {
+	// Category is what the resources are for, e.g. Worker instances.
+	Category string `json:"category"`
+	// Resource is the type, SKU or size of the resources, e.g. m5.large.
+	Resource string `json:"resource"`
+	// Quantity is the number of units the resources are billed for, e.g. instances or GiB.
+	Quantity float64 `json:"quantity"`
+	// Unit is the unit of Quantity.
+	Unit string `json:"unit"`
+	// UnitMonthlyPrice is the monthly price of one unit.
+	UnitMonthlyPrice float64 `json:"unitMonthlyPrice"`
+	// MonthlyCost is the monthly price of all the units.
+	MonthlyCost float64 `json:"monthlyCost"`
+}
+
+// Add adds an item to the estimate, computing its monthly cost.
+func (e *CostEstimate) Add(category, resource string, quantity float64, unit string, unitMonthlyPrice float64) {
+	if quantity <= 0 {
+		return
+	}
+	item := CostEstimateItem{
+		Category:         category,
+		Resource:         resource,
+		Quantity:         quantity,
+		Unit:             unit,
+		UnitMonthlyPrice: unitMonthlyPrice,
+		MonthlyCost:      quantity * unitMonthlyPrice,
+	}
+	e.Items = append(e.Items, item)
+	e.MonthlyTotal += item.MonthlyCost
+}
+
+// PrintCostEstimate writes the estimate as a table or as JSON.
+func PrintCostEstimate(out io.Writer, estimate *CostEstimate, output string) error {
+	switch output {
+	case CostEstimateOutputJSON:
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(estimate)
+	case CostEstimateOutputTable:
+	default:
+		return fmt.Errorf("unsupported output format %q", output)
+	}
+
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(w, "Estimated monthly cost of the cluster on %s in %s (%s, list prices):\n\n", estimate.Platform, estimate.Region, estimate.Currency)
+	fmt.Fprintf(w, "CATEGORY\tRESOURCE\tQUANTITY\tUNIT PRICE\tMONTHLY COST\n")
+	for _, item := range estimate.Items {
+		fmt.Fprintf(w, "%s\t%s\t%g %s\t%.2f\t%.2f\n", item.Category, item.Resource, item.Quantity, item.Unit, item.UnitMonthlyPrice, item.MonthlyCost)
+	}
+	fmt.Fprintf(w, "TOTAL\t\t\t\t%.2f\n", estimate.MonthlyTotal)
+	if len(estimate.Notes) > 0 {
+		fmt.Fprintf(w, "\nNotes:\n")
+		for _, note := range estimate.Notes {
+			fmt.Fprintf(w, "  %s\n", note)
+		}
+	}
+	return w.Flush()
+}
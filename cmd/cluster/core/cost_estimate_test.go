@@ -0,0 +1,63 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestPrintCostEstimate(t *testing.T) {
+	estimate := &CostEstimate{Platform: "AWS", Region: "us-east-1", Currency: "USD", Notes: []string{"Data transfer is not included."}}
+	estimate.Add("Worker instances", "m5.large", 2, "instances", 70.08)
+	estimate.Add("NAT gateways", "NAT gateway", 0, "gateways", 32.85)
+	estimate.Add("NAT gateways", "NAT gateway", 1, "gateways", 32.85)
+
+	testCases := []struct {
+		name        string
+		output      string
+		validate    func(*WithT, string)
+		expectedErr bool
+	}{
+		{
+			name:   "When the output is a table, it should print the items, the total and the notes",
+			output: CostEstimateOutputTable,
+			validate: func(g *WithT, out string) {
+				g.Expect(out).To(ContainSubstring("Estimated monthly cost of the cluster on AWS in us-east-1 (USD, list prices)"))
+				g.Expect(out).To(MatchRegexp(`Worker instances\s+m5\.large\s+2 instances\s+70\.08\s+140\.16`))
+				g.Expect(out).To(MatchRegexp(`NAT gateways\s+NAT gateway\s+1 gateways\s+32\.85\s+32\.85`))
+				g.Expect(out).To(MatchRegexp(`TOTAL\s+173\.01`))
+				g.Expect(out).To(ContainSubstring("Notes:\n  Data transfer is not included."))
+			},
+		},
+		{
+			name:   "When the output is JSON, it should print the estimate without the items of no quantity",
+			output: CostEstimateOutputJSON,
+			validate: func(g *WithT, out string) {
+				printed := &CostEstimate{}
+				g.Expect(json.Unmarshal([]byte(out), printed)).To(Succeed())
+				g.Expect(printed.Items).To(HaveLen(2))
+				g.Expect(printed.MonthlyTotal).To(BeNumerically("~", 173.01, 0.001))
+			},
+		},
+		{
+			name:        "When the output is unknown, it should fail",
+			output:      "yaml",
+			expectedErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			out := &bytes.Buffer{}
+			err := PrintCostEstimate(out, estimate, tc.output)
+			if tc.expectedErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			tc.validate(g, out.String())
+		})
+	}
+}
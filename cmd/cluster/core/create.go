@@ -18,7 +18,6 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation"
-	"k8s.io/apimachinery/pkg/util/wait"
 	kubeclient "k8s.io/client-go/kubernetes"
 	crclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/yaml"
@@ -31,7 +30,6 @@ import (
 	hyperapi "github.com/openshift/hypershift/support/api"
 	"github.com/openshift/hypershift/support/globalconfig"
 	"github.com/openshift/hypershift/support/infraid"
-	hyperutil "github.com/openshift/hypershift/support/util"
 )
 
 // ApplyPlatformSpecifics can be used to create platform specific values as well as enriching the fixture with additional values
@@ -45,6 +43,7 @@ type CreateOptions struct {
 	ControlPlaneOperatorImage        string
 	EtcdStorageClass                 string
 	FIPS                             bool
+	FromExport                       string
 	GenerateSSH                      bool
 	ImageContentSources              string
 	InfrastructureAvailabilityPolicy string
@@ -66,6 +65,8 @@ type CreateOptions struct {
 	ClusterCIDR                      []string
 	DefaultDual                      bool
 	ExternalDNSDomain                string
+	ClusterDNSDomain                 string
+	DNSPublishing                    string
 	Arch                             string
 	NodeSelector                     map[string]string
 	NonePlatform                     NonePlatformCreateOptions
@@ -81,8 +82,14 @@ type CreateOptions struct {
 	CredentialSecretName             string
 	NodeUpgradeType                  hyperv1.UpgradeType
 	PausedUntil                      string
-	OLMCatalogPlacement              hyperv1.OLMCatalogPlacement
-	OLMDisableDefaultSources         bool
+	Lifetime                         time.Duration
+	Owner                            string
+	// EstimateCost prints the estimated monthly cost of the cloud resources of the cluster, in the EstimateCostOutput
+	// format, instead of creating it.
+	EstimateCost             bool
+	EstimateCostOutput       string
+	OLMCatalogPlacement      hyperv1.OLMCatalogPlacement
+	OLMDisableDefaultSources bool
 
 	// BeforeApply is called immediately before resources are applied to the
 	// server, giving the user an opportunity to inspect or mutate the resources.
@@ -137,11 +144,20 @@ type AgentPlatformCreateOptions struct {
 	APIServerAddress   string
 	AgentNamespace     string
 	AgentLabelSelector string
+
+	HTTPProxy            string
+	HTTPSProxy           string
+	NoProxy              string
+	StaticNetworkHosts   []string
+	StaticNetworkGateway string
+	StaticNetworkDNS     []string
+	BondMode             string
 }
 
 type NonePlatformCreateOptions struct {
 	APIServerAddress          string
 	ExposeThroughLoadBalancer bool
+	IssuerURL                 string
 }
 
 type KubevirtPlatformCreateOptions struct {
@@ -185,6 +201,7 @@ type AWSPlatformOptions struct {
 	EnableProxy             bool
 	SingleNATGateway        bool
 	MultiArch               bool
+	EndpointOverrides       map[string]string
 }
 
 type AzurePlatformOptions struct {
@@ -202,6 +219,22 @@ type AzurePlatformOptions struct {
 	DiskStorageAccountType string
 	ResourceGroupTags      map[string]string
 	SubnetID               string
+
+	EnableAcceleratedNetworking bool
+
+	// NetworkSecurityGroupID, PublicDNSZoneID and PrivateDNSZoneID are the
+	// IDs of the resources of an existing network to create the cluster in,
+	// along with VnetID and SubnetID. Infrastructure creation is skipped, so
+	// the other resources it creates must be provided as well.
+	NetworkSecurityGroupID string
+	PublicDNSZoneID        string
+	PrivateDNSZoneID       string
+	MachineIdentityID      string
+	BootImageID            string
+
+	APIServerCustomDomain       string
+	APIServerCustomDomainTarget string
+	APIServerCustomDomainPort   int32
 }
 
 func createCommonFixture(ctx context.Context, opts *CreateOptions) (*apifixtures.ExampleOptions, error) {
@@ -223,6 +256,9 @@ func createCommonFixture(ctx context.Context, opts *CreateOptions) (*apifixtures
 		k, v := pair[0], pair[1]
 		annotations[k] = v
 	}
+	if opts.Owner != "" {
+		annotations[hyperv1.ClusterOwnerAnnotation] = opts.Owner
+	}
 
 	if len(opts.ControlPlaneOperatorImage) > 0 {
 		annotations[hyperv1.ControlPlaneOperatorImageAnnotation] = opts.ControlPlaneOperatorImage
@@ -317,6 +353,10 @@ func createCommonFixture(ctx context.Context, opts *CreateOptions) (*apifixtures
 		}
 	}
 
+	if opts.Lifetime < 0 {
+		return nil, fmt.Errorf("invalid lifetime %s, it must be positive", opts.Lifetime)
+	}
+
 	var operatorHub *configv1.OperatorHubSpec
 	if opts.OLMDisableDefaultSources {
 		operatorHub = &configv1.OperatorHubSpec{
@@ -353,8 +393,11 @@ func createCommonFixture(ctx context.Context, opts *CreateOptions) (*apifixtures
 		NodeSelector:                     opts.NodeSelector,
 		UpgradeType:                      opts.NodeUpgradeType,
 		PausedUntil:                      opts.PausedUntil,
+		Lifetime:                         opts.Lifetime,
 		OLMCatalogPlacement:              opts.OLMCatalogPlacement,
 		OperatorHub:                      operatorHub,
+		ClusterDNSDomain:                 opts.ClusterDNSDomain,
+		DNSPublishing:                    hyperv1.DNSPublishingMode(opts.DNSPublishing),
 	}, nil
 }
 
@@ -417,18 +460,7 @@ func apply(ctx context.Context, l logr.Logger, exampleOptions *apifixtures.Examp
 	}
 
 	if waitForRollout {
-		l.Info("Waiting for cluster rollout")
-		return wait.PollInfiniteWithContext(ctx, 30*time.Second, func(ctx context.Context) (bool, error) {
-			hostedCluster := hostedCluster.DeepCopy()
-			if err := client.Get(ctx, crclient.ObjectKeyFromObject(hostedCluster), hostedCluster); err != nil {
-				return false, fmt.Errorf("failed to get hostedcluster %s: %w", crclient.ObjectKeyFromObject(hostedCluster), err)
-			}
-			rolledOut := hostedCluster.Status.Version != nil && len(hostedCluster.Status.Version.History) > 0 && hostedCluster.Status.Version.History[0].CompletionTime != nil
-			if !rolledOut {
-				l.Info("Cluster rollout not finished yet, checking again in 30 seconds...")
-			}
-			return rolledOut, nil
-		})
+		return waitForClusterRollout(ctx, l, client, hostedCluster)
 	}
 
 	return nil
@@ -496,16 +528,18 @@ func Validate(ctx context.Context, opts *CreateOptions) error {
 		return fmt.Errorf("HostedCluster name failed RFC1123 validation: %s", strings.Join(errs[:], " "))
 	}
 
-	// Validate if mgmt cluster and NodePool CPU arches don't match, a multi-arch release image or stream was used
-	// Exception for ppc64le arch since management cluster would be in x86 and node pools are going to be in ppc64le arch
-	if !opts.AWSPlatform.MultiArch && !opts.Render && opts.Arch != hyperv1.ArchitecturePPC64LE {
-		mgmtClusterCPUArch, err := hyperutil.GetMgmtClusterCPUArch(ctx)
-		if err != nil {
-			return err
+	switch hyperv1.DNSPublishingMode(opts.DNSPublishing) {
+	case "", hyperv1.ExternalDNSPublishing:
+	case hyperv1.InternalDNSPublishing:
+		if opts.ExternalDNSDomain != "" {
+			return fmt.Errorf("--external-dns-domain can't be used with --dns-publishing=%s, no public DNS records are created", hyperv1.InternalDNSPublishing)
 		}
-
-		if err = hyperutil.DoesMgmtClusterAndNodePoolCPUArchMatch(mgmtClusterCPUArch, opts.Arch); err != nil {
-			opts.Log.Info(fmt.Sprintf("WARNING: %v", err))
+	default:
+		return fmt.Errorf("unsupported DNS publishing mode %q, must be one of %s, %s", opts.DNSPublishing, hyperv1.ExternalDNSPublishing, hyperv1.InternalDNSPublishing)
+	}
+	if opts.ClusterDNSDomain != "" {
+		if errs := validation.IsDNS1123Subdomain(opts.ClusterDNSDomain); len(errs) > 0 {
+			return fmt.Errorf("--cluster-dns-domain failed RFC1123 validation: %s", strings.Join(errs, " "))
 		}
 	}
 
@@ -3,12 +3,14 @@ package core
 import (
 	"context"
 	"fmt"
+	"os"
 	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
 	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
 	"github.com/openshift/hypershift/cmd/util"
+	hyperutil "github.com/openshift/hypershift/support/util"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -39,6 +41,11 @@ type DestroyOptions struct {
 	DestroyCloudResources bool
 	Log                   logr.Logger
 	CredentialSecretName  string
+
+	// DryRun prints the inventory of the resources that would be deleted instead of destroying the cluster.
+	DryRun bool
+	// Output is the format of the dry run inventory, table or json.
+	Output string
 }
 
 type AWSPlatformDestroyOptions struct {
@@ -49,6 +56,7 @@ type AWSPlatformDestroyOptions struct {
 	Region              string
 	PostDeleteAction    func()
 	AwsInfraGracePeriod time.Duration
+	EndpointOverrides   map[string]string
 }
 
 type AzurePlatformDestroyOptions struct {
@@ -93,9 +101,26 @@ func GetCluster(ctx context.Context, o *DestroyOptions) (*hyperv1.HostedCluster,
 	return &hostedCluster, nil
 }
 
-func DestroyCluster(ctx context.Context, hostedCluster *hyperv1.HostedCluster, o *DestroyOptions, destroyPlatformSpecifics DestroyPlatformSpecifics) error {
+func DestroyCluster(ctx context.Context, hostedCluster *hyperv1.HostedCluster, o *DestroyOptions, destroyPlatformSpecifics DestroyPlatformSpecifics, inventoryPlatformSpecifics InventoryPlatformSpecifics) error {
+	if o.DryRun {
+		c, err := util.GetClient()
+		if err != nil {
+			return err
+		}
+		inventory, err := GetDestroyInventory(ctx, c, hostedCluster, o, destroyPlatformSpecifics, inventoryPlatformSpecifics)
+		if err != nil {
+			return err
+		}
+		return PrintDestroyInventory(os.Stdout, inventory, o.Output)
+	}
+
 	hostedClusterExists := hostedCluster != nil
 	shouldDestroyPlatformSpecifics := destroyPlatformSpecifics != nil
+	// The infrastructure of clusters with the OrphanInfra deletion policy is left intact.
+	if hostedClusterExists && shouldDestroyPlatformSpecifics && hyperutil.HCDeletionPolicyType(hostedCluster) == hyperv1.DeletionPolicyOrphanInfra {
+		o.Log.Info("Skipping destroy of infrastructure because of the hosted cluster deletion policy", "namespace", o.Namespace, "name", o.Name, "deletionPolicy", hyperv1.DeletionPolicyOrphanInfra)
+		shouldDestroyPlatformSpecifics = false
+	}
 	c, err := util.GetClient()
 	if err != nil {
 		return err
@@ -0,0 +1,143 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/cmd/util"
+	"github.com/openshift/hypershift/hypershift-operator/controllers/manifests"
+	hyperutil "github.com/openshift/hypershift/support/util"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	DestroyOutputTable = "table"
+	DestroyOutputJSON  = "json"
+
+	// InventorySourceKubernetes is the source of the Kubernetes resources of a DestroyInventory.
+	InventorySourceKubernetes = "Kubernetes"
+)
+
+// InventoryPlatformSpecifics lists the platform specific resources which would be destroyed by the matching
+// DestroyPlatformSpecifics.
+type InventoryPlatformSpecifics = func(ctx context.Context, options *DestroyOptions) ([]InventoryResource, error)
+
+// DestroyInventory is the report of the resources that would be deleted by destroying a cluster.
+type DestroyInventory struct {
+	Namespace string              `json:"namespace"`
+	Name      string              `json:"name"`
+	InfraID   string              `json:"infraID,omitempty"`
+	Resources []InventoryResource `json:"resources"`
+
+	// Notes describe what is deleted without being enumerated in Resources.
+	Notes []string `json:"notes,omitempty"`
+}
+
+// InventoryResource is a resource that would be deleted by destroying a cluster.
+type InventoryResource struct {
+	// Source is where the resource lives, e.g. Kubernetes or AWS.
+	Source string `json:"source"`
+	// Type is the kind of the Kubernetes resource or the type of the cloud resource.
+	Type      string `json:"type"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+}
+
+// GetDestroyInventory enumerates the Kubernetes resources deleted by destroying the cluster, and the platform
+// specific resources listed by inventoryPlatformSpecifics.
+func GetDestroyInventory(ctx context.Context, c client.Client, hostedCluster *hyperv1.HostedCluster, o *DestroyOptions, destroyPlatformSpecifics DestroyPlatformSpecifics, inventoryPlatformSpecifics InventoryPlatformSpecifics) (*DestroyInventory, error) {
+	inventory := &DestroyInventory{
+		Namespace: o.Namespace,
+		Name:      o.Name,
+		InfraID:   o.InfraID,
+	}
+
+	if hostedCluster != nil {
+		inventory.Resources = append(inventory.Resources, InventoryResource{Source: InventorySourceKubernetes, Type: "HostedCluster", Namespace: hostedCluster.Namespace, Name: hostedCluster.Name})
+
+		nodePools := &hyperv1.NodePoolList{}
+		if err := c.List(ctx, nodePools, client.InNamespace(hostedCluster.Namespace)); err != nil {
+			return nil, fmt.Errorf("failed to list nodepools: %w", err)
+		}
+		for _, nodePool := range nodePools.Items {
+			if nodePool.Spec.ClusterName == hostedCluster.Name {
+				inventory.Resources = append(inventory.Resources, InventoryResource{Source: InventorySourceKubernetes, Type: "NodePool", Namespace: nodePool.Namespace, Name: nodePool.Name})
+			}
+		}
+
+		controlPlaneNamespace := &corev1.Namespace{}
+		if err := c.Get(ctx, client.ObjectKey{Name: manifests.HostedControlPlaneNamespace(hostedCluster.Namespace, hostedCluster.Name)}, controlPlaneNamespace); err == nil {
+			inventory.Resources = append(inventory.Resources, InventoryResource{Source: InventorySourceKubernetes, Type: "Namespace", Name: controlPlaneNamespace.Name})
+		} else if !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get control plane namespace: %w", err)
+		}
+
+		if o.DestroyCloudResources {
+			inventory.Notes = append(inventory.Notes, "Cloud resources created by the cluster during its lifetime, such as load balancers and persistent storage disks, are removed by the HostedCluster controllers")
+		}
+	}
+
+	secrets := &corev1.SecretList{}
+	if err := c.List(ctx, secrets, client.InNamespace(o.Namespace), client.MatchingLabels{util.AutoInfraLabelName: o.InfraID}); err != nil {
+		return nil, fmt.Errorf("failed to list secrets: %w", err)
+	}
+	for _, secret := range secrets.Items {
+		inventory.Resources = append(inventory.Resources, InventoryResource{Source: InventorySourceKubernetes, Type: "Secret", Namespace: secret.Namespace, Name: secret.Name})
+	}
+
+	switch {
+	case destroyPlatformSpecifics == nil:
+	case hostedCluster != nil && hyperutil.HCDeletionPolicyType(hostedCluster) == hyperv1.DeletionPolicyOrphanInfra:
+		inventory.Notes = append(inventory.Notes, fmt.Sprintf("The infrastructure is left intact because of the %s deletion policy", hyperv1.DeletionPolicyOrphanInfra))
+	case inventoryPlatformSpecifics == nil:
+		inventory.Notes = append(inventory.Notes, fmt.Sprintf("The infrastructure with infra ID %s is destroyed but can't be enumerated on this platform", o.InfraID))
+	default:
+		resources, err := inventoryPlatformSpecifics(ctx, o)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list infrastructure: %w", err)
+		}
+		sort.SliceStable(resources, func(i, j int) bool {
+			if resources[i].Type != resources[j].Type {
+				return resources[i].Type < resources[j].Type
+			}
+			return resources[i].Name < resources[j].Name
+		})
+		inventory.Resources = append(inventory.Resources, resources...)
+	}
+
+	return inventory, nil
+}
+
+// PrintDestroyInventory writes the inventory as a table or as JSON.
+func PrintDestroyInventory(out io.Writer, inventory *DestroyInventory, output string) error {
+	switch output {
+	case DestroyOutputJSON:
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(inventory)
+	case DestroyOutputTable:
+	default:
+		return fmt.Errorf("unsupported output format %q", output)
+	}
+
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(w, "Destroying cluster %s/%s (infra ID %s) would delete:\n\n", inventory.Namespace, inventory.Name, inventory.InfraID)
+	fmt.Fprintf(w, "SOURCE\tTYPE\tNAMESPACE\tNAME\n")
+	for _, resource := range inventory.Resources {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", resource.Source, resource.Type, resource.Namespace, resource.Name)
+	}
+	if len(inventory.Notes) > 0 {
+		fmt.Fprintf(w, "\nNotes:\n")
+		for _, note := range inventory.Notes {
+			fmt.Fprintf(w, "  %s\n", note)
+		}
+	}
+	return w.Flush()
+}
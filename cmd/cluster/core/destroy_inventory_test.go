@@ -0,0 +1,139 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/cmd/util"
+	"github.com/openshift/hypershift/support/api"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestGetDestroyInventory(t *testing.T) {
+	hostedCluster := func(policy hyperv1.DeletionPolicyType) *hyperv1.HostedCluster {
+		hc := &hyperv1.HostedCluster{ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "example"}}
+		if policy != "" {
+			hc.Spec.DeletionPolicy = &hyperv1.DeletionPolicy{Type: policy}
+		}
+		return hc
+	}
+	objects := []client.Object{
+		&hyperv1.NodePool{ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "example-workers"}, Spec: hyperv1.NodePoolSpec{ClusterName: "example"}},
+		&hyperv1.NodePool{ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "other-workers"}, Spec: hyperv1.NodePoolSpec{ClusterName: "other"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "clusters-example"}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "example-pull-secret", Labels: map[string]string{util.AutoInfraLabelName: "example-abcde"}}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "other-pull-secret", Labels: map[string]string{util.AutoInfraLabelName: "other-fghij"}}},
+	}
+	destroyPlatformSpecifics := func(context.Context, *DestroyOptions) error { return nil }
+	inventoryPlatformSpecifics := func(context.Context, *DestroyOptions) ([]InventoryResource, error) {
+		return []InventoryResource{
+			{Source: "AWS", Type: "ec2/vpc", Name: "vpc-2"},
+			{Source: "AWS", Type: "ec2/subnet", Name: "subnet-1"},
+			{Source: "AWS", Type: "ec2/vpc", Name: "vpc-1"},
+		}, nil
+	}
+	kubernetesResources := []InventoryResource{
+		{Source: InventorySourceKubernetes, Type: "HostedCluster", Namespace: "clusters", Name: "example"},
+		{Source: InventorySourceKubernetes, Type: "NodePool", Namespace: "clusters", Name: "example-workers"},
+		{Source: InventorySourceKubernetes, Type: "Namespace", Name: "clusters-example"},
+		{Source: InventorySourceKubernetes, Type: "Secret", Namespace: "clusters", Name: "example-pull-secret"},
+	}
+
+	testCases := []struct {
+		name                       string
+		hostedCluster              *hyperv1.HostedCluster
+		inventoryPlatformSpecifics InventoryPlatformSpecifics
+		expectedResources          []InventoryResource
+		expectedNotes              int
+	}{
+		{
+			name:                       "When the cluster exists it should list its Kubernetes resources and its sorted infrastructure",
+			hostedCluster:              hostedCluster(""),
+			inventoryPlatformSpecifics: inventoryPlatformSpecifics,
+			expectedResources: append(append([]InventoryResource{}, kubernetesResources...),
+				InventoryResource{Source: "AWS", Type: "ec2/subnet", Name: "subnet-1"},
+				InventoryResource{Source: "AWS", Type: "ec2/vpc", Name: "vpc-1"},
+				InventoryResource{Source: "AWS", Type: "ec2/vpc", Name: "vpc-2"},
+			),
+		},
+		{
+			name:                       "When the cluster has the OrphanInfra deletion policy it should not list its infrastructure",
+			hostedCluster:              hostedCluster(hyperv1.DeletionPolicyOrphanInfra),
+			inventoryPlatformSpecifics: inventoryPlatformSpecifics,
+			expectedResources:          kubernetesResources,
+			expectedNotes:              1,
+		},
+		{
+			name:              "When the platform can't enumerate its infrastructure it should add a note",
+			hostedCluster:     hostedCluster(""),
+			expectedResources: kubernetesResources,
+			expectedNotes:     1,
+		},
+		{
+			name:                       "When the cluster doesn't exist it should list the remaining secrets and infrastructure",
+			inventoryPlatformSpecifics: inventoryPlatformSpecifics,
+			expectedResources: []InventoryResource{
+				{Source: InventorySourceKubernetes, Type: "Secret", Namespace: "clusters", Name: "example-pull-secret"},
+				{Source: "AWS", Type: "ec2/subnet", Name: "subnet-1"},
+				{Source: "AWS", Type: "ec2/vpc", Name: "vpc-1"},
+				{Source: "AWS", Type: "ec2/vpc", Name: "vpc-2"},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			c := fake.NewClientBuilder().WithScheme(api.Scheme).WithObjects(objects...).Build()
+			o := &DestroyOptions{Namespace: "clusters", Name: "example", InfraID: "example-abcde"}
+
+			inventory, err := GetDestroyInventory(context.Background(), c, tc.hostedCluster, o, destroyPlatformSpecifics, tc.inventoryPlatformSpecifics)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(inventory.Resources).To(Equal(tc.expectedResources))
+			g.Expect(inventory.Notes).To(HaveLen(tc.expectedNotes))
+		})
+	}
+}
+
+func TestPrintDestroyInventory(t *testing.T) {
+	inventory := &DestroyInventory{
+		Namespace: "clusters",
+		Name:      "example",
+		InfraID:   "example-abcde",
+		Resources: []InventoryResource{
+			{Source: InventorySourceKubernetes, Type: "HostedCluster", Namespace: "clusters", Name: "example"},
+			{Source: "AWS", Type: "ec2/vpc", Name: "vpc-1"},
+		},
+		Notes: []string{"a note"},
+	}
+
+	t.Run("When the output is a table it should list every resource and note", func(t *testing.T) {
+		g := NewWithT(t)
+		out := &bytes.Buffer{}
+		g.Expect(PrintDestroyInventory(out, inventory, DestroyOutputTable)).To(Succeed())
+		g.Expect(out.String()).To(ContainSubstring("clusters/example (infra ID example-abcde)"))
+		g.Expect(out.String()).To(MatchRegexp(`Kubernetes\s+HostedCluster\s+clusters\s+example`))
+		g.Expect(out.String()).To(MatchRegexp(`AWS\s+ec2/vpc\s+vpc-1`))
+		g.Expect(out.String()).To(ContainSubstring("a note"))
+	})
+
+	t.Run("When the output is JSON it should round trip", func(t *testing.T) {
+		g := NewWithT(t)
+		out := &bytes.Buffer{}
+		g.Expect(PrintDestroyInventory(out, inventory, DestroyOutputJSON)).To(Succeed())
+		decoded := &DestroyInventory{}
+		g.Expect(json.Unmarshal(out.Bytes(), decoded)).To(Succeed())
+		g.Expect(decoded).To(Equal(inventory))
+	})
+
+	t.Run("When the output format is unknown it should fail", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(PrintDestroyInventory(&bytes.Buffer{}, inventory, "yaml")).ToNot(Succeed())
+	})
+}
@@ -164,20 +164,9 @@ func dumpGuestCluster(ctx context.Context, opts *DumpOptions) error {
 
 	target := opts.ArtifactDir + "/hostedcluster-" + opts.Name
 
-	kubeAPIServerPodList := &corev1.PodList{}
-	if err := c.List(ctx, kubeAPIServerPodList, client.InNamespace(cpNamespace), client.MatchingLabels{"app": "kube-apiserver", hyperv1.ControlPlaneComponent: "kube-apiserver"}); err != nil {
-		return fmt.Errorf("failed to list kube-apiserver pods in control plane namespace: %w", err)
-	}
-	var podToForward *corev1.Pod
-	for i := range kubeAPIServerPodList.Items {
-		pod := &kubeAPIServerPodList.Items[i]
-		if pod.Status.Phase == corev1.PodRunning {
-			podToForward = pod
-			break
-		}
-	}
-	if podToForward == nil {
-		return fmt.Errorf("did not find running kube-apiserver pod for guest cluster")
+	podToForward, err := runningPod(ctx, c, cpNamespace, client.MatchingLabels{"app": "kube-apiserver", hyperv1.ControlPlaneComponent: "kube-apiserver"})
+	if err != nil {
+		return fmt.Errorf("did not find running kube-apiserver pod for guest cluster: %w", err)
 	}
 	restConfig, err := util.GetConfig()
 	if err != nil {
@@ -219,8 +208,23 @@ func dumpGuestCluster(ctx context.Context, opts *DumpOptions) error {
 	return nil
 }
 
-func createGuestKubeconfig(ctx context.Context, c client.Client, cpNamespace string, localPort int, log logr.Logger) (string, error) {
+// runningPod returns the first running pod in the namespace matching the given labels.
+func runningPod(ctx context.Context, c client.Client, namespace string, labels client.MatchingLabels) (*corev1.Pod, error) {
+	podList := &corev1.PodList{}
+	if err := c.List(ctx, podList, client.InNamespace(namespace), labels); err != nil {
+		return nil, fmt.Errorf("failed to list pods in namespace %s: %w", namespace, err)
+	}
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if pod.Status.Phase == corev1.PodRunning {
+			return pod, nil
+		}
+	}
+	return nil, fmt.Errorf("no running pod found in namespace %s", namespace)
+}
 
+// localhostKubeconfig returns the hosted cluster's localhost kubeconfig with its server pointed at the given local port.
+func localhostKubeconfig(ctx context.Context, c client.Client, cpNamespace string, localPort int) ([]byte, error) {
 	localhostKubeconfigSecret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "localhost-kubeconfig",
@@ -228,26 +232,14 @@ func createGuestKubeconfig(ctx context.Context, c client.Client, cpNamespace str
 		},
 	}
 	if err := c.Get(ctx, client.ObjectKeyFromObject(localhostKubeconfigSecret), localhostKubeconfigSecret); err != nil {
-		return "", fmt.Errorf("failed to get hostedcluster localhost kubeconfig: %w", err)
-	}
-	kubeconfigFile, err := os.CreateTemp(os.TempDir(), "kubeconfig-")
-	if err != nil {
-		return "", fmt.Errorf("failed to create tempfile for kubeconfig: %w", err)
+		return nil, fmt.Errorf("failed to get hostedcluster localhost kubeconfig: %w", err)
 	}
-	defer func() {
-		if err := kubeconfigFile.Sync(); err != nil {
-			log.Error(err, "Failed to sync temporary kubeconfig file")
-		}
-		if err := kubeconfigFile.Close(); err != nil {
-			log.Error(err, "Failed to close temporary kubeconfig file")
-		}
-	}()
 	localhostKubeconfig, err := clientcmd.Load(localhostKubeconfigSecret.Data["kubeconfig"])
 	if err != nil {
-		return "", fmt.Errorf("failed to parse localhost kubeconfig: %w", err)
+		return nil, fmt.Errorf("failed to parse localhost kubeconfig: %w", err)
 	}
 	if len(localhostKubeconfig.Clusters) == 0 {
-		return "", fmt.Errorf("no clusters found in localhost kubeconfig")
+		return nil, fmt.Errorf("no clusters found in localhost kubeconfig")
 	}
 
 	for k := range localhostKubeconfig.Clusters {
@@ -255,8 +247,28 @@ func createGuestKubeconfig(ctx context.Context, c client.Client, cpNamespace str
 	}
 	localhostKubeconfigYaml, err := clientcmd.Write(*localhostKubeconfig)
 	if err != nil {
-		return "", fmt.Errorf("failed to serialize localhost kubeconfig: %w", err)
+		return nil, fmt.Errorf("failed to serialize localhost kubeconfig: %w", err)
+	}
+	return localhostKubeconfigYaml, nil
+}
+
+func createGuestKubeconfig(ctx context.Context, c client.Client, cpNamespace string, localPort int, log logr.Logger) (string, error) {
+	localhostKubeconfigYaml, err := localhostKubeconfig(ctx, c, cpNamespace, localPort)
+	if err != nil {
+		return "", err
+	}
+	kubeconfigFile, err := os.CreateTemp(os.TempDir(), "kubeconfig-")
+	if err != nil {
+		return "", fmt.Errorf("failed to create tempfile for kubeconfig: %w", err)
 	}
+	defer func() {
+		if err := kubeconfigFile.Sync(); err != nil {
+			log.Error(err, "Failed to sync temporary kubeconfig file")
+		}
+		if err := kubeconfigFile.Close(); err != nil {
+			log.Error(err, "Failed to close temporary kubeconfig file")
+		}
+	}()
 	if _, err := kubeconfigFile.Write(localhostKubeconfigYaml); err != nil {
 		return "", fmt.Errorf("failed to write kubeconfig data: %w", err)
 	}
@@ -0,0 +1,475 @@
+package core
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/api/util/configrefs"
+	"github.com/openshift/hypershift/cmd/log"
+	"github.com/openshift/hypershift/cmd/util"
+	hyperapi "github.com/openshift/hypershift/support/api"
+	"github.com/openshift/hypershift/support/infraid"
+)
+
+// nodePoolStateAnnotationPrefix prefixes the annotations the NodePool controller keeps its state in.
+const nodePoolStateAnnotationPrefix = "hypershift.openshift.io/nodePool"
+
+type ExportOptions struct {
+	Namespace string
+	Name      string
+	Output    string
+
+	Log logr.Logger
+}
+
+// ClusterExport is a sanitized copy of the spec of a HostedCluster, its NodePools and the ConfigMaps they reference,
+// from which a new cluster can be created. Secrets aren't exported, only the names of the ones the cluster references
+// are, and the identity of the exported cluster (its cluster and infra IDs) and everything its controllers manage
+// (status, controller annotations, ownership) are removed.
+type ClusterExport struct {
+	HostedCluster *hyperv1.HostedCluster
+	NodePools     []hyperv1.NodePool
+	ConfigMaps    []corev1.ConfigMap
+	// Secrets are the names of the Secrets the cluster references, which must exist in the namespace of a cluster
+	// created from the export.
+	Secrets []string
+}
+
+func NewExportCommand() *cobra.Command {
+	opts := &ExportOptions{
+		Namespace: "clusters",
+		Log:       log.Log,
+	}
+
+	cmd := &cobra.Command{
+		Use:          "cluster",
+		Short:        "Exports the spec of a HostedCluster, its NodePools and their configuration, without secrets, so the cluster can be re-created with 'hypershift create cluster --from-export'",
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringVar(&opts.Namespace, "namespace", opts.Namespace, "The namespace of the HostedCluster")
+	cmd.Flags().StringVar(&opts.Name, "name", opts.Name, "The name of the HostedCluster (required)")
+	cmd.Flags().StringVarP(&opts.Output, "output", "o", opts.Output, "The file the export is written to, defaults to stdout")
+
+	_ = cmd.MarkFlagRequired("name")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if err := opts.Run(cmd.Context()); err != nil {
+			opts.Log.Error(err, "Failed to export cluster")
+			return err
+		}
+		return nil
+	}
+
+	return cmd
+}
+
+func (o *ExportOptions) Run(ctx context.Context) error {
+	client, err := util.GetClient()
+	if err != nil {
+		return err
+	}
+	export, err := ExportCluster(ctx, client, o.Namespace, o.Name)
+	if err != nil {
+		return err
+	}
+
+	out := io.Writer(os.Stdout)
+	if o.Output != "" {
+		f, err := os.Create(o.Output)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", o.Output, err)
+		}
+		defer f.Close()
+		out = f
+	}
+	if err := WriteClusterExport(out, export); err != nil {
+		return err
+	}
+	if o.Output != "" {
+		o.Log.Info("Exported cluster", "namespace", o.Namespace, "name", o.Name, "file", o.Output, "requiredSecrets", export.Secrets)
+	}
+	return nil
+}
+
+// ExportCluster exports the HostedCluster, its NodePools and the ConfigMaps they reference.
+func ExportCluster(ctx context.Context, client crclient.Client, namespace, name string) (*ClusterExport, error) {
+	hostedCluster := &hyperv1.HostedCluster{}
+	if err := client.Get(ctx, crclient.ObjectKey{Namespace: namespace, Name: name}, hostedCluster); err != nil {
+		return nil, fmt.Errorf("failed to get hostedcluster %s/%s: %w", namespace, name, err)
+	}
+	nodePoolList := &hyperv1.NodePoolList{}
+	if err := client.List(ctx, nodePoolList, crclient.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list nodepools: %w", err)
+	}
+	var nodePools []hyperv1.NodePool
+	for i := range nodePoolList.Items {
+		if nodePoolList.Items[i].Spec.ClusterName == name {
+			nodePools = append(nodePools, nodePoolList.Items[i])
+		}
+	}
+	sort.Slice(nodePools, func(i, j int) bool { return nodePools[i].Name < nodePools[j].Name })
+
+	export := &ClusterExport{
+		HostedCluster: sanitizeHostedCluster(hostedCluster),
+		Secrets:       referencedSecrets(hostedCluster, nodePools),
+	}
+	for i := range nodePools {
+		export.NodePools = append(export.NodePools, *sanitizeNodePool(&nodePools[i]))
+	}
+	for _, configMapName := range referencedConfigMaps(hostedCluster, nodePools) {
+		configMap := &corev1.ConfigMap{}
+		if err := client.Get(ctx, crclient.ObjectKey{Namespace: namespace, Name: configMapName}, configMap); err != nil {
+			return nil, fmt.Errorf("failed to get referenced configmap %s/%s: %w", namespace, configMapName, err)
+		}
+		export.ConfigMaps = append(export.ConfigMaps, corev1.ConfigMap{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+			ObjectMeta: sanitizeObjectMeta(configMap.ObjectMeta, nil),
+			Data:       configMap.Data,
+			BinaryData: configMap.BinaryData,
+		})
+	}
+	return export, nil
+}
+
+// sanitizeObjectMeta keeps the namespace, name, labels and annotations of an object, without the annotations matching
+// dropAnnotation and what the CLI and kubectl record on the objects they create.
+func sanitizeObjectMeta(objectMeta metav1.ObjectMeta, dropAnnotation func(string) bool) metav1.ObjectMeta {
+	sanitized := metav1.ObjectMeta{Namespace: objectMeta.Namespace, Name: objectMeta.Name}
+	for key, value := range objectMeta.Labels {
+		if key == util.AutoInfraLabelName {
+			continue
+		}
+		if sanitized.Labels == nil {
+			sanitized.Labels = map[string]string{}
+		}
+		sanitized.Labels[key] = value
+	}
+	for key, value := range objectMeta.Annotations {
+		if key == corev1.LastAppliedConfigAnnotation || (dropAnnotation != nil && dropAnnotation(key)) {
+			continue
+		}
+		if sanitized.Annotations == nil {
+			sanitized.Annotations = map[string]string{}
+		}
+		sanitized.Annotations[key] = value
+	}
+	return sanitized
+}
+
+func sanitizeHostedCluster(hostedCluster *hyperv1.HostedCluster) *hyperv1.HostedCluster {
+	sanitized := &hyperv1.HostedCluster{
+		TypeMeta:   metav1.TypeMeta{APIVersion: hyperv1.GroupVersion.String(), Kind: "HostedCluster"},
+		ObjectMeta: sanitizeObjectMeta(hostedCluster.ObjectMeta, nil),
+		Spec:       *hostedCluster.Spec.DeepCopy(),
+	}
+	// The cluster and infra IDs identify the exported cluster, the clone gets its own.
+	sanitized.Spec.ClusterID = ""
+	sanitized.Spec.InfraID = ""
+	sanitized.Spec.PausedUntil = nil
+	return sanitized
+}
+
+func sanitizeNodePool(nodePool *hyperv1.NodePool) *hyperv1.NodePool {
+	return &hyperv1.NodePool{
+		TypeMeta: metav1.TypeMeta{APIVersion: hyperv1.GroupVersion.String(), Kind: "NodePool"},
+		ObjectMeta: sanitizeObjectMeta(nodePool.ObjectMeta, func(key string) bool {
+			return strings.HasPrefix(key, nodePoolStateAnnotationPrefix) || key == hyperv1.NodePoolApprovedRolloutAnnotation
+		}),
+		Spec: *nodePool.Spec.DeepCopy(),
+	}
+}
+
+// referencedConfigMaps returns the names of the ConfigMaps referenced by the HostedCluster and its NodePools.
+func referencedConfigMaps(hostedCluster *hyperv1.HostedCluster, nodePools []hyperv1.NodePool) []string {
+	names := sets.New[string]()
+	if hostedCluster.Spec.AdditionalTrustBundle != nil {
+		names.Insert(hostedCluster.Spec.AdditionalTrustBundle.Name)
+	}
+	if hostedCluster.Spec.Configuration != nil {
+		names.Insert(configrefs.ConfigMapRefs(hostedCluster.Spec.Configuration)...)
+	}
+	for _, nodePool := range nodePools {
+		for _, ref := range nodePool.Spec.Config {
+			names.Insert(ref.Name)
+		}
+		for _, ref := range nodePool.Spec.TuningConfig {
+			names.Insert(ref.Name)
+		}
+	}
+	names.Delete("")
+	return sets.List(names)
+}
+
+// referencedSecrets returns the names of the Secrets referenced by the HostedCluster and its NodePools, apart from
+// the ones the HostedCluster controller generates.
+func referencedSecrets(hostedCluster *hyperv1.HostedCluster, nodePools []hyperv1.NodePool) []string {
+	names := sets.New[string](hostedCluster.Spec.PullSecret.Name, hostedCluster.Spec.SSHKey.Name)
+	if hostedCluster.Spec.ServiceAccountSigningKey != nil {
+		names.Insert(hostedCluster.Spec.ServiceAccountSigningKey.Name)
+	}
+	if hostedCluster.Spec.AuditWebhook != nil {
+		names.Insert(hostedCluster.Spec.AuditWebhook.Name)
+	}
+	if encryption := hostedCluster.Spec.SecretEncryption; encryption != nil && encryption.AESCBC != nil {
+		names.Insert(encryption.AESCBC.ActiveKey.Name)
+		if encryption.AESCBC.BackupKey != nil {
+			names.Insert(encryption.AESCBC.BackupKey.Name)
+		}
+	}
+	if hostedCluster.Spec.Configuration != nil {
+		names.Insert(configrefs.SecretRefs(hostedCluster.Spec.Configuration)...)
+	}
+	for _, nodePool := range nodePools {
+		for _, ref := range nodePool.Spec.AdditionalPullSecrets {
+			names.Insert(ref.Name)
+		}
+	}
+	names.Delete("")
+	return sets.List(names)
+}
+
+// WriteClusterExport writes the export as a YAML stream, preceded by a comment listing the Secrets it requires.
+func WriteClusterExport(w io.Writer, export *ClusterExport) error {
+	if len(export.Secrets) > 0 {
+		if _, err := fmt.Fprintf(w, "# Secrets required in the namespace of the cluster, which are not exported: %s\n", strings.Join(export.Secrets, ", ")); err != nil {
+			return err
+		}
+	}
+	objects := []crclient.Object{export.HostedCluster}
+	for i := range export.NodePools {
+		objects = append(objects, &export.NodePools[i])
+	}
+	for i := range export.ConfigMaps {
+		objects = append(objects, &export.ConfigMaps[i])
+	}
+	for _, object := range objects {
+		if _, err := fmt.Fprintln(w, "---"); err != nil {
+			return err
+		}
+		if err := hyperapi.YamlSerializer.Encode(object, w); err != nil {
+			return fmt.Errorf("failed to encode %s: %w", object.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// ReadClusterExport reads an export written by WriteClusterExport.
+func ReadClusterExport(r io.Reader) (*ClusterExport, error) {
+	export := &ClusterExport{}
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(r))
+	for {
+		document, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read export: %w", err)
+		}
+		if len(bytes.TrimSpace(stripComments(document))) == 0 {
+			continue
+		}
+		object, _, err := hyperapi.YamlSerializer.Decode(document, nil, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode export: %w", err)
+		}
+		switch o := object.(type) {
+		case *hyperv1.HostedCluster:
+			if export.HostedCluster != nil {
+				return nil, fmt.Errorf("the export contains more than one hostedcluster")
+			}
+			export.HostedCluster = o
+		case *hyperv1.NodePool:
+			export.NodePools = append(export.NodePools, *o)
+		case *corev1.ConfigMap:
+			export.ConfigMaps = append(export.ConfigMaps, *o)
+		default:
+			return nil, fmt.Errorf("unexpected %s in export", object.GetObjectKind().GroupVersionKind().Kind)
+		}
+	}
+	if export.HostedCluster == nil {
+		return nil, fmt.Errorf("the export contains no hostedcluster")
+	}
+	export.Secrets = referencedSecrets(export.HostedCluster, export.NodePools)
+	return export, nil
+}
+
+func stripComments(document []byte) []byte {
+	var lines [][]byte
+	for _, line := range bytes.Split(document, []byte("\n")) {
+		if !bytes.HasPrefix(bytes.TrimSpace(line), []byte("#")) {
+			lines = append(lines, line)
+		}
+	}
+	return bytes.Join(lines, []byte("\n"))
+}
+
+// Clone returns the objects of a cluster created from the export in the namespace, with the name and infra ID. The
+// NodePools named after the exported cluster are renamed after the new one.
+func (e *ClusterExport) Clone(namespace, name, infraID string) (*hyperv1.HostedCluster, []*hyperv1.NodePool, []*corev1.ConfigMap) {
+	exportedName := e.HostedCluster.Name
+	hostedCluster := e.HostedCluster.DeepCopy()
+	hostedCluster.Namespace = namespace
+	hostedCluster.Name = name
+	hostedCluster.Spec.InfraID = infraID
+
+	var nodePools []*hyperv1.NodePool
+	for i := range e.NodePools {
+		nodePool := e.NodePools[i].DeepCopy()
+		nodePool.Namespace = namespace
+		if suffix, found := strings.CutPrefix(nodePool.Name, exportedName); found {
+			nodePool.Name = name + suffix
+		}
+		nodePool.Spec.ClusterName = name
+		nodePools = append(nodePools, nodePool)
+	}
+
+	var configMaps []*corev1.ConfigMap
+	for i := range e.ConfigMaps {
+		configMap := e.ConfigMaps[i].DeepCopy()
+		configMap.Namespace = namespace
+		configMaps = append(configMaps, configMap)
+	}
+	return hostedCluster, nodePools, configMaps
+}
+
+// CreateClusterFromExport creates a cluster from the export in opts.FromExport. The name and namespace of the
+// exported cluster are used unless opts.Name and opts.Namespace are set, and the pull secret is created from
+// opts.PullSecretFile if it's set. The other Secrets the cluster references must already exist.
+func CreateClusterFromExport(ctx context.Context, opts *CreateOptions) error {
+	f, err := os.Open(opts.FromExport)
+	if err != nil {
+		return fmt.Errorf("failed to open export: %w", err)
+	}
+	defer f.Close()
+	export, err := ReadClusterExport(f)
+	if err != nil {
+		return err
+	}
+
+	name, namespace := opts.Name, opts.Namespace
+	if name == "" {
+		name = export.HostedCluster.Name
+	}
+	if namespace == "" {
+		namespace = export.HostedCluster.Namespace
+	}
+	infraID := opts.InfraID
+	if infraID == "" {
+		infraID = infraid.New(name)
+	}
+	hostedCluster, nodePools, configMaps := export.Clone(namespace, name, infraID)
+	if opts.ReleaseImage != "" {
+		hostedCluster.Spec.Release.Image = opts.ReleaseImage
+		for _, nodePool := range nodePools {
+			nodePool.Spec.Release.Image = opts.ReleaseImage
+		}
+	}
+
+	var pullSecret *corev1.Secret
+	if opts.PullSecretFile != "" {
+		pullSecretBytes, err := os.ReadFile(opts.PullSecretFile)
+		if err != nil {
+			return fmt.Errorf("failed to read pull secret file: %w", err)
+		}
+		pullSecret = &corev1.Secret{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: hostedCluster.Spec.PullSecret.Name},
+			Data:       map[string][]byte{corev1.DockerConfigJsonKey: pullSecretBytes},
+		}
+	}
+
+	var objects []crclient.Object
+	if pullSecret != nil {
+		objects = append(objects, pullSecret)
+	}
+	for _, configMap := range configMaps {
+		objects = append(objects, configMap)
+	}
+	objects = append(objects, hostedCluster)
+	for _, nodePool := range nodePools {
+		objects = append(objects, nodePool)
+	}
+	if opts.BeforeApply != nil {
+		for _, object := range objects {
+			opts.BeforeApply(object)
+		}
+	}
+
+	if opts.Render {
+		for _, object := range objects {
+			if err := hyperapi.YamlSerializer.Encode(object, os.Stdout); err != nil {
+				return fmt.Errorf("failed to encode objects: %w", err)
+			}
+			fmt.Println("---")
+		}
+		return nil
+	}
+
+	client, err := util.GetClient()
+	if err != nil {
+		return err
+	}
+	if err := client.Get(ctx, crclient.ObjectKeyFromObject(hostedCluster), &hyperv1.HostedCluster{}); err == nil {
+		return fmt.Errorf("hostedcluster %s already exists", crclient.ObjectKeyFromObject(hostedCluster))
+	} else if !apierrors.IsNotFound(err) {
+		return fmt.Errorf("hostedcluster doesn't exist validation failed with error: %w", err)
+	}
+	if missing, err := missingSecrets(ctx, client, namespace, export.Secrets, pullSecret); err != nil {
+		return err
+	} else if len(missing) > 0 {
+		return fmt.Errorf("the cluster references secrets which don't exist in namespace %s: %s", namespace, strings.Join(missing, ", "))
+	}
+
+	for _, object := range objects {
+		key := crclient.ObjectKeyFromObject(object)
+		if _, isHostedCluster := object.(*hyperv1.HostedCluster); isHostedCluster {
+			err = client.Create(ctx, object)
+		} else {
+			err = client.Patch(ctx, object, crclient.Apply, crclient.ForceOwnership, crclient.FieldOwner("hypershift-cli"))
+		}
+		if err != nil {
+			return fmt.Errorf("failed to apply object %q: %w", key, err)
+		}
+		opts.Log.Info("Applied Kube resource", "kind", object.GetObjectKind().GroupVersionKind().Kind, "namespace", key.Namespace, "name", key.Name)
+	}
+
+	if opts.Wait {
+		return waitForClusterRollout(ctx, opts.Log, client, hostedCluster)
+	}
+	return nil
+}
+
+// missingSecrets returns the Secrets which don't exist in the namespace, apart from the pull secret being created.
+func missingSecrets(ctx context.Context, client crclient.Client, namespace string, names []string, pullSecret *corev1.Secret) ([]string, error) {
+	var missing []string
+	for _, name := range names {
+		if pullSecret != nil && pullSecret.Name == name {
+			continue
+		}
+		if err := client.Get(ctx, crclient.ObjectKey{Namespace: namespace, Name: name}, &corev1.Secret{}); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return nil, fmt.Errorf("failed to get secret %s/%s: %w", namespace, name, err)
+			}
+			missing = append(missing, name)
+		}
+	}
+	return missing, nil
+}
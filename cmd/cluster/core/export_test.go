@@ -0,0 +1,147 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	configv1 "github.com/openshift/api/config/v1"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/cmd/util"
+	"github.com/openshift/hypershift/support/api"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestExportCluster(t *testing.T) {
+	hostedCluster := &hyperv1.HostedCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       "clusters",
+			Name:            "example",
+			ResourceVersion: "42",
+			Finalizers:      []string{"hypershift.openshift.io/finalizer"},
+			Labels:          map[string]string{"team": "payments", util.AutoInfraLabelName: "example-abcde"},
+			Annotations:     map[string]string{corev1.LastAppliedConfigAnnotation: "{}", "hypershift.openshift.io/cluster-size-override": "large"},
+		},
+		Spec: hyperv1.HostedClusterSpec{
+			ClusterID:             "2a4b6c8d-0000-0000-0000-000000000000",
+			InfraID:               "example-abcde",
+			Release:               hyperv1.Release{Image: "release:4.16.0"},
+			PullSecret:            corev1.LocalObjectReference{Name: "example-pull-secret"},
+			SSHKey:                corev1.LocalObjectReference{Name: "example-ssh-key"},
+			AdditionalTrustBundle: &corev1.LocalObjectReference{Name: "user-ca-bundle"},
+			Configuration: &hyperv1.ClusterConfiguration{
+				Proxy: &configv1.ProxySpec{TrustedCA: configv1.ConfigMapNameReference{Name: "proxy-ca"}},
+			},
+		},
+		Status: hyperv1.HostedClusterStatus{KubeConfig: &corev1.LocalObjectReference{Name: "example-admin-kubeconfig"}},
+	}
+	nodePool := &hyperv1.NodePool{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "clusters",
+			Name:      "example-workers",
+			Annotations: map[string]string{
+				"hypershift.openshift.io/nodePoolCurrentConfig": "abc",
+				hyperv1.NodePoolApprovedRolloutAnnotation:       "abc",
+				"example.com/owner":                             "payments",
+			},
+		},
+		Spec: hyperv1.NodePoolSpec{
+			ClusterName:           "example",
+			Replicas:              ptr.To[int32](2),
+			Release:               hyperv1.Release{Image: "release:4.16.0"},
+			Config:                []corev1.LocalObjectReference{{Name: "machine-config"}},
+			AdditionalPullSecrets: []corev1.LocalObjectReference{{Name: "mirror-pull-secret"}},
+		},
+		Status: hyperv1.NodePoolStatus{Replicas: 2, Version: "4.16.0"},
+	}
+	otherNodePool := &hyperv1.NodePool{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "other"},
+		Spec:       hyperv1.NodePoolSpec{ClusterName: "other"},
+	}
+	configMaps := []*corev1.ConfigMap{
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "user-ca-bundle", ResourceVersion: "7"}, Data: map[string]string{"ca-bundle.crt": "ca"}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "proxy-ca"}, Data: map[string]string{"ca-bundle.crt": "proxy"}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "machine-config"}, Data: map[string]string{"config": "kind: MachineConfig"}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "unreferenced"}},
+	}
+	client := fake.NewClientBuilder().WithScheme(api.Scheme).WithObjects(hostedCluster, nodePool, otherNodePool, configMaps[0], configMaps[1], configMaps[2], configMaps[3]).Build()
+
+	t.Run("When a cluster is exported it should only keep its spec and referenced configmaps", func(t *testing.T) {
+		g := NewWithT(t)
+		export, err := ExportCluster(context.Background(), client, "clusters", "example")
+		g.Expect(err).ToNot(HaveOccurred())
+
+		g.Expect(export.HostedCluster.ObjectMeta).To(Equal(metav1.ObjectMeta{
+			Namespace:   "clusters",
+			Name:        "example",
+			Labels:      map[string]string{"team": "payments"},
+			Annotations: map[string]string{"hypershift.openshift.io/cluster-size-override": "large"},
+		}))
+		g.Expect(export.HostedCluster.Spec.ClusterID).To(BeEmpty())
+		g.Expect(export.HostedCluster.Spec.InfraID).To(BeEmpty())
+		g.Expect(export.HostedCluster.Spec.Release.Image).To(Equal("release:4.16.0"))
+		g.Expect(export.HostedCluster.Status).To(Equal(hyperv1.HostedClusterStatus{}))
+
+		g.Expect(export.NodePools).To(HaveLen(1))
+		g.Expect(export.NodePools[0].Annotations).To(Equal(map[string]string{"example.com/owner": "payments"}))
+		g.Expect(export.NodePools[0].Status).To(Equal(hyperv1.NodePoolStatus{}))
+
+		var configMapNames []string
+		for _, configMap := range export.ConfigMaps {
+			g.Expect(configMap.ResourceVersion).To(BeEmpty())
+			configMapNames = append(configMapNames, configMap.Name)
+		}
+		g.Expect(configMapNames).To(Equal([]string{"machine-config", "proxy-ca", "user-ca-bundle"}))
+		g.Expect(export.Secrets).To(Equal([]string{"example-pull-secret", "example-ssh-key", "mirror-pull-secret"}))
+	})
+
+	t.Run("When an export is written it should be read back and cloned under a new name", func(t *testing.T) {
+		g := NewWithT(t)
+		export, err := ExportCluster(context.Background(), client, "clusters", "example")
+		g.Expect(err).ToNot(HaveOccurred())
+
+		out := &bytes.Buffer{}
+		g.Expect(WriteClusterExport(out, export)).To(Succeed())
+		g.Expect(out.String()).To(HavePrefix("# Secrets required in the namespace of the cluster, which are not exported: example-pull-secret, example-ssh-key, mirror-pull-secret\n"))
+
+		read, err := ReadClusterExport(out)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(read.HostedCluster.Spec).To(Equal(export.HostedCluster.Spec))
+		g.Expect(read.NodePools).To(HaveLen(1))
+		g.Expect(read.ConfigMaps).To(HaveLen(3))
+		g.Expect(read.Secrets).To(Equal(export.Secrets))
+
+		clonedCluster, clonedNodePools, clonedConfigMaps := read.Clone("staging", "clone", "clone-fghij")
+		g.Expect(clonedCluster.Namespace).To(Equal("staging"))
+		g.Expect(clonedCluster.Name).To(Equal("clone"))
+		g.Expect(clonedCluster.Spec.InfraID).To(Equal("clone-fghij"))
+		g.Expect(clonedNodePools[0].Namespace).To(Equal("staging"))
+		g.Expect(clonedNodePools[0].Name).To(Equal("clone-workers"))
+		g.Expect(clonedNodePools[0].Spec.ClusterName).To(Equal("clone"))
+		for _, configMap := range clonedConfigMaps {
+			g.Expect(configMap.Namespace).To(Equal("staging"))
+		}
+	})
+
+	t.Run("When the cluster doesn't exist it should fail", func(t *testing.T) {
+		g := NewWithT(t)
+		_, err := ExportCluster(context.Background(), client, "clusters", "missing")
+		g.Expect(err).To(HaveOccurred())
+	})
+}
+
+func TestMissingSecrets(t *testing.T) {
+	g := NewWithT(t)
+	client := fake.NewClientBuilder().WithScheme(api.Scheme).WithObjects(
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "staging", Name: "ssh-key"}},
+	).Build()
+	pullSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "staging", Name: "pull-secret"}}
+
+	missing, err := missingSecrets(context.Background(), client, "staging", []string{"pull-secret", "ssh-key", "etcd-encryption-key"}, pullSecret)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(missing).To(Equal([]string{"etcd-encryption-key"}))
+}
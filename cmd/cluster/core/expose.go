@@ -0,0 +1,261 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	kubeclient "k8s.io/client-go/kubernetes"
+	restclient "k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/cmd/log"
+	"github.com/openshift/hypershift/cmd/util"
+	cpomanifests "github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/manifests"
+	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/pki"
+	"github.com/openshift/hypershift/hypershift-operator/controllers/manifests"
+	"github.com/openshift/hypershift/hypershift-operator/controllers/manifests/ignitionserver"
+	"github.com/openshift/hypershift/support/certs"
+	supportutil "github.com/openshift/hypershift/support/util"
+)
+
+const (
+	ExposeComponentKubeAPIServer  = "kube-apiserver"
+	ExposeComponentEtcd           = "etcd"
+	ExposeComponentIgnitionServer = "ignition-server"
+)
+
+type ExposeOptions struct {
+	Namespace               string
+	Name                    string
+	ImpersonateAs           string
+	Components              []string
+	OutputDir               string
+	KubeAPIServerLocalPort  int
+	EtcdLocalPort           int
+	IgnitionServerLocalPort int
+	Log                     logr.Logger
+}
+
+// exposeTarget is a control plane component endpoint forwarded to a local port, along with the files needed to
+// connect to it.
+type exposeTarget struct {
+	component string
+	labels    client.MatchingLabels
+	podPort   int32
+	localPort int
+	files     map[string][]byte
+	usage     string
+}
+
+func NewExposeCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "cluster",
+		Short:        "Forwards control plane endpoints of a hostedcluster to local ports through the management cluster",
+		SilenceUsage: true,
+	}
+
+	opts := &ExposeOptions{
+		Namespace:               "clusters",
+		Name:                    "example",
+		Components:              []string{ExposeComponentKubeAPIServer},
+		KubeAPIServerLocalPort:  6443,
+		EtcdLocalPort:           2379,
+		IgnitionServerLocalPort: 9090,
+		Log:                     log.Log,
+	}
+
+	cmd.Flags().StringVar(&opts.Namespace, "namespace", opts.Namespace, "The namespace of the hostedcluster")
+	cmd.Flags().StringVar(&opts.Name, "name", opts.Name, "The name of the hostedcluster")
+	cmd.Flags().StringVar(&opts.ImpersonateAs, "as", opts.ImpersonateAs, "The user or service account to impersonate when reading credentials and forwarding ports in the management cluster")
+	cmd.Flags().StringSliceVar(&opts.Components, "components", opts.Components, fmt.Sprintf("The control plane components to expose. Supported options: %s, %s, %s", ExposeComponentKubeAPIServer, ExposeComponentEtcd, ExposeComponentIgnitionServer))
+	cmd.Flags().StringVar(&opts.OutputDir, "output-dir", opts.OutputDir, "Directory to write the kubeconfig and certificates for the exposed endpoints to. A temporary directory is used if not set")
+	cmd.Flags().IntVar(&opts.KubeAPIServerLocalPort, "kube-apiserver-local-port", opts.KubeAPIServerLocalPort, "Local port to forward the kube-apiserver to")
+	cmd.Flags().IntVar(&opts.EtcdLocalPort, "etcd-local-port", opts.EtcdLocalPort, "Local port to forward etcd to")
+	cmd.Flags().IntVar(&opts.IgnitionServerLocalPort, "ignition-server-local-port", opts.IgnitionServerLocalPort, "Local port to forward the ignition server to")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if err := opts.Run(cmd.Context(), os.Stdout); err != nil {
+			opts.Log.Error(err, "Error")
+			return err
+		}
+		return nil
+	}
+	return cmd
+}
+
+func (o *ExposeOptions) Validate() error {
+	if len(o.Components) == 0 {
+		return fmt.Errorf("at least one component must be specified")
+	}
+	for _, component := range o.Components {
+		switch component {
+		case ExposeComponentKubeAPIServer, ExposeComponentEtcd, ExposeComponentIgnitionServer:
+		default:
+			return fmt.Errorf("unsupported component %q", component)
+		}
+	}
+	return nil
+}
+
+// Run forwards the requested components to local ports and blocks until the context is cancelled.
+func (o *ExposeOptions) Run(ctx context.Context, out io.Writer) error {
+	if err := o.Validate(); err != nil {
+		return err
+	}
+
+	var c client.Client
+	var err error
+	if len(o.ImpersonateAs) > 0 {
+		c, err = util.GetImpersonatedClient(o.ImpersonateAs)
+	} else {
+		c, err = util.GetClient()
+	}
+	if err != nil {
+		return err
+	}
+
+	hostedCluster := &hyperv1.HostedCluster{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: o.Namespace, Name: o.Name}, hostedCluster); err != nil {
+		return fmt.Errorf("failed to get hosted cluster %s/%s: %w", o.Namespace, o.Name, err)
+	}
+	cpNamespace := manifests.HostedControlPlaneNamespace(o.Namespace, o.Name)
+
+	outputDir := o.OutputDir
+	if len(outputDir) == 0 {
+		if outputDir, err = os.MkdirTemp(os.TempDir(), fmt.Sprintf("hypershift-expose-%s-", o.Name)); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+	} else if err := os.MkdirAll(outputDir, 0700); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	targets, err := o.exposeTargets(ctx, c, hostedCluster, cpNamespace, outputDir)
+	if err != nil {
+		return err
+	}
+
+	restConfig, err := util.GetConfig()
+	if err != nil {
+		return fmt.Errorf("failed to get a config for management cluster: %w", err)
+	}
+	if len(o.ImpersonateAs) > 0 {
+		restConfig.Impersonate = restclient.ImpersonationConfig{
+			UserName: o.ImpersonateAs,
+		}
+	}
+	kubeClient, err := kubeclient.NewForConfig(restConfig)
+	if err != nil {
+		return fmt.Errorf("failed to get a kubernetes client: %w", err)
+	}
+
+	forwarderStop := make(chan struct{})
+	defer close(forwarderStop)
+	for _, target := range targets {
+		for name, content := range target.files {
+			if err := os.WriteFile(filepath.Join(outputDir, name), content, 0600); err != nil {
+				return fmt.Errorf("failed to write %s: %w", name, err)
+			}
+		}
+
+		pod, err := runningPod(ctx, c, cpNamespace, target.labels)
+		if err != nil {
+			return fmt.Errorf("did not find running %s pod: %w", target.component, err)
+		}
+		forwarderOutput := &bytes.Buffer{}
+		forwarder := portForwarder{
+			Namespace: pod.Namespace,
+			PodName:   pod.Name,
+			Config:    restConfig,
+			Client:    kubeClient,
+			Out:       forwarderOutput,
+			ErrOut:    forwarderOutput,
+		}
+		if err := forwarder.ForwardPorts([]string{fmt.Sprintf("%d:%d", target.localPort, target.podPort)}, forwarderStop); err != nil {
+			return fmt.Errorf("cannot forward %s port: %w, output: %s", target.component, err, forwarderOutput.String())
+		}
+		o.Log.Info("Forwarding port", "component", target.component, "pod", pod.Name, "localPort", target.localPort)
+		fmt.Fprintf(out, "%s is available at localhost:%d\n  %s\n", target.component, target.localPort, target.usage)
+	}
+	fmt.Fprintf(out, "Files for the exposed endpoints were written to %s. Press Ctrl+C to stop forwarding.\n", outputDir)
+
+	<-ctx.Done()
+	return nil
+}
+
+func (o *ExposeOptions) exposeTargets(ctx context.Context, c client.Client, hostedCluster *hyperv1.HostedCluster, cpNamespace, outputDir string) ([]exposeTarget, error) {
+	var targets []exposeTarget
+	for _, component := range o.Components {
+		switch component {
+		case ExposeComponentKubeAPIServer:
+			kubeconfig, err := localhostKubeconfig(ctx, c, cpNamespace, o.KubeAPIServerLocalPort)
+			if err != nil {
+				return nil, err
+			}
+			targets = append(targets, exposeTarget{
+				component: component,
+				labels:    client.MatchingLabels{"app": "kube-apiserver", hyperv1.ControlPlaneComponent: "kube-apiserver"},
+				podPort:   supportutil.KASPodPortFromHostedCluster(hostedCluster),
+				localPort: o.KubeAPIServerLocalPort,
+				files:     map[string][]byte{"kubeconfig": kubeconfig},
+				usage:     fmt.Sprintf("export KUBECONFIG=%s", filepath.Join(outputDir, "kubeconfig")),
+			})
+
+		case ExposeComponentEtcd:
+			if hostedCluster.Spec.Etcd.ManagementType != hyperv1.Managed {
+				return nil, fmt.Errorf("etcd can only be exposed for hosted clusters with managed etcd")
+			}
+			clientSecret := cpomanifests.EtcdClientSecret(cpNamespace)
+			if err := c.Get(ctx, client.ObjectKeyFromObject(clientSecret), clientSecret); err != nil {
+				return nil, fmt.Errorf("failed to get etcd client secret: %w", err)
+			}
+			caConfigMap := cpomanifests.EtcdSignerCAConfigMap(cpNamespace)
+			if err := c.Get(ctx, client.ObjectKeyFromObject(caConfigMap), caConfigMap); err != nil {
+				return nil, fmt.Errorf("failed to get etcd CA configmap: %w", err)
+			}
+			targets = append(targets, exposeTarget{
+				component: component,
+				labels:    client.MatchingLabels{"app": "etcd"},
+				podPort:   2379,
+				localPort: o.EtcdLocalPort,
+				files: map[string][]byte{
+					"etcd-ca.crt":     []byte(caConfigMap.Data[certs.CASignerCertMapKey]),
+					"etcd-client.crt": clientSecret.Data[pki.EtcdClientCrtKey],
+					"etcd-client.key": clientSecret.Data[pki.EtcdClientKeyKey],
+				},
+				usage: fmt.Sprintf("etcdctl --endpoints=https://localhost:%d --cacert=%s --cert=%s --key=%s endpoint health", o.EtcdLocalPort,
+					filepath.Join(outputDir, "etcd-ca.crt"), filepath.Join(outputDir, "etcd-client.crt"), filepath.Join(outputDir, "etcd-client.key")),
+			})
+
+		case ExposeComponentIgnitionServer:
+			caSecret := ignitionserver.IgnitionCACertSecret(cpNamespace)
+			if err := c.Get(ctx, client.ObjectKeyFromObject(caSecret), caSecret); err != nil {
+				return nil, fmt.Errorf("failed to get ignition server CA secret: %w", err)
+			}
+			// The ignition server serving certificate is only valid for its external address, so requests need
+			// to be sent to that address and redirected to the local port.
+			usage := fmt.Sprintf("curl --cacert %s https://localhost:%d/healthz", filepath.Join(outputDir, "ignition-ca.crt"), o.IgnitionServerLocalPort)
+			if endpoint := hostedCluster.Status.IgnitionEndpoint; len(endpoint) > 0 {
+				host := strings.Split(endpoint, ":")[0]
+				usage = fmt.Sprintf("curl --cacert %s --connect-to %s:443:localhost:%d https://%s/healthz", filepath.Join(outputDir, "ignition-ca.crt"), host, o.IgnitionServerLocalPort, host)
+			}
+			targets = append(targets, exposeTarget{
+				component: component,
+				labels:    client.MatchingLabels{"app": ignitionserver.ResourceName},
+				podPort:   9090,
+				localPort: o.IgnitionServerLocalPort,
+				files:     map[string][]byte{"ignition-ca.crt": caSecret.Data[corev1.TLSCertKey]},
+				usage:     usage,
+			})
+		}
+	}
+	return targets, nil
+}
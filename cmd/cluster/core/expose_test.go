@@ -0,0 +1,118 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/support/api"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestExposeTargets(t *testing.T) {
+	const cpNamespace = "clusters-example"
+	kubeconfig := []byte(`apiVersion: v1
+kind: Config
+clusters:
+- name: cluster
+  cluster:
+    server: https://kube-apiserver:6443
+`)
+	objects := []*corev1.Secret{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: cpNamespace, Name: "localhost-kubeconfig"},
+			Data:       map[string][]byte{"kubeconfig": kubeconfig},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: cpNamespace, Name: "etcd-client-tls"},
+			Data:       map[string][]byte{"etcd-client.crt": []byte("client-cert"), "etcd-client.key": []byte("client-key")},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: cpNamespace, Name: "ignition-server-ca-cert"},
+			Data:       map[string][]byte{corev1.TLSCertKey: []byte("ignition-ca")},
+		},
+	}
+	etcdCA := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: cpNamespace, Name: "etcd-ca"},
+		Data:       map[string]string{"ca.crt": "etcd-ca"},
+	}
+
+	testCases := []struct {
+		name               string
+		components         []string
+		etcdManagementType hyperv1.EtcdManagementType
+		expectedFiles      map[string]map[string]string
+		expectError        bool
+	}{
+		{
+			name:          "When the kube-apiserver is exposed it should point the kubeconfig at the local port",
+			components:    []string{ExposeComponentKubeAPIServer},
+			expectedFiles: map[string]map[string]string{ExposeComponentKubeAPIServer: nil},
+		},
+		{
+			name:               "When etcd and the ignition server are exposed it should write their certificates",
+			components:         []string{ExposeComponentEtcd, ExposeComponentIgnitionServer},
+			etcdManagementType: hyperv1.Managed,
+			expectedFiles: map[string]map[string]string{
+				ExposeComponentEtcd: {
+					"etcd-ca.crt":     "etcd-ca",
+					"etcd-client.crt": "client-cert",
+					"etcd-client.key": "client-key",
+				},
+				ExposeComponentIgnitionServer: {
+					"ignition-ca.crt": "ignition-ca",
+				},
+			},
+		},
+		{
+			name:               "When etcd is unmanaged it should fail to expose it",
+			components:         []string{ExposeComponentEtcd},
+			etcdManagementType: hyperv1.Unmanaged,
+			expectError:        true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			builder := fake.NewClientBuilder().WithScheme(api.Scheme).WithObjects(etcdCA)
+			for _, secret := range objects {
+				builder = builder.WithObjects(secret.DeepCopy())
+			}
+			c := builder.Build()
+			hostedCluster := &hyperv1.HostedCluster{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "example"},
+				Spec: hyperv1.HostedClusterSpec{
+					Etcd: hyperv1.EtcdSpec{ManagementType: tc.etcdManagementType},
+				},
+			}
+			opts := &ExposeOptions{
+				Components:              tc.components,
+				KubeAPIServerLocalPort:  16443,
+				EtcdLocalPort:           12379,
+				IgnitionServerLocalPort: 19090,
+			}
+
+			targets, err := opts.exposeTargets(context.Background(), c, hostedCluster, cpNamespace, "/tmp/expose")
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(targets).To(HaveLen(len(tc.components)))
+			for _, target := range targets {
+				g.Expect(tc.expectedFiles).To(HaveKey(target.component))
+				if target.component == ExposeComponentKubeAPIServer {
+					g.Expect(string(target.files["kubeconfig"])).To(ContainSubstring("server: https://localhost:16443"))
+					continue
+				}
+				g.Expect(target.files).To(HaveLen(len(tc.expectedFiles[target.component])))
+				for name, content := range tc.expectedFiles[target.component] {
+					g.Expect(string(target.files[name])).To(Equal(content))
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,260 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/cobra"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	kubeclient "k8s.io/client-go/kubernetes"
+	restclient "k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/cmd/log"
+	"github.com/openshift/hypershift/cmd/util"
+	"github.com/openshift/hypershift/hypershift-operator/controllers/manifests"
+	"github.com/openshift/hypershift/hypershift-operator/controllers/manifests/ignitionserver"
+	"github.com/openshift/hypershift/support/config"
+)
+
+const (
+	ProfileComponentControlPlaneOperator        = "control-plane-operator"
+	ProfileComponentHostedClusterConfigOperator = "hosted-cluster-config-operator"
+	ProfileComponentIgnitionServer              = "ignition-server"
+	ProfileComponentHyperShiftOperator          = "hypershift-operator"
+)
+
+type DumpProfilesOptions struct {
+	Namespace          string
+	Name               string
+	ImpersonateAs      string
+	ArtifactDir        string
+	Components         []string
+	OperatorNamespace  string
+	CPUProfileDuration time.Duration
+	Log                logr.Logger
+}
+
+// profileTarget is a component whose pprof endpoints are collected from one of its running pods.
+type profileTarget struct {
+	component string
+	namespace string
+	labels    client.MatchingLabels
+}
+
+// pprofProfile is a profile collected from the pprof endpoints of a component, written to file.
+type pprofProfile struct {
+	file string
+	path string
+}
+
+func NewDumpProfilesCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "profiles",
+		Short:        "Collects CPU, heap and goroutine profiles from the control plane operators of a hostedcluster",
+		SilenceUsage: true,
+	}
+
+	opts := &DumpProfilesOptions{
+		Namespace:          "clusters",
+		Name:               "example",
+		Components:         []string{ProfileComponentControlPlaneOperator, ProfileComponentHostedClusterConfigOperator, ProfileComponentIgnitionServer},
+		OperatorNamespace:  hypershiftNamespace,
+		CPUProfileDuration: 30 * time.Second,
+		Log:                log.Log,
+	}
+
+	cmd.Flags().StringVar(&opts.Namespace, "namespace", opts.Namespace, "The namespace of the hostedcluster")
+	cmd.Flags().StringVar(&opts.Name, "name", opts.Name, "The name of the hostedcluster")
+	cmd.Flags().StringVar(&opts.ImpersonateAs, "as", opts.ImpersonateAs, "The user or service account to impersonate when forwarding ports in the management cluster")
+	cmd.Flags().StringVar(&opts.ArtifactDir, "artifact-dir", opts.ArtifactDir, "Destination directory for the profiles")
+	cmd.Flags().StringSliceVar(&opts.Components, "components", opts.Components, fmt.Sprintf("The components to profile. Supported options: %s, %s, %s, %s", ProfileComponentControlPlaneOperator, ProfileComponentHostedClusterConfigOperator, ProfileComponentIgnitionServer, ProfileComponentHyperShiftOperator))
+	cmd.Flags().StringVar(&opts.OperatorNamespace, "operator-namespace", opts.OperatorNamespace, "The namespace of the hypershift operator, when it is profiled")
+	cmd.Flags().DurationVar(&opts.CPUProfileDuration, "cpu-profile-duration", opts.CPUProfileDuration, "How long the CPU profile of each component is collected for")
+
+	cmd.MarkFlagRequired("artifact-dir")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if err := opts.Run(cmd.Context()); err != nil {
+			opts.Log.Error(err, "Error")
+			return err
+		}
+		return nil
+	}
+	return cmd
+}
+
+func (o *DumpProfilesOptions) Validate() error {
+	if len(o.Components) == 0 {
+		return fmt.Errorf("at least one component must be specified")
+	}
+	for _, component := range o.Components {
+		switch component {
+		case ProfileComponentControlPlaneOperator, ProfileComponentHostedClusterConfigOperator, ProfileComponentIgnitionServer, ProfileComponentHyperShiftOperator:
+		default:
+			return fmt.Errorf("unsupported component %q", component)
+		}
+	}
+	if o.CPUProfileDuration < time.Second {
+		return fmt.Errorf("the CPU profile duration must be at least 1s")
+	}
+	return nil
+}
+
+// profileTargets returns the components to profile and where their pods run.
+func (o *DumpProfilesOptions) profileTargets(cpNamespace string) []profileTarget {
+	var targets []profileTarget
+	for _, component := range o.Components {
+		switch component {
+		case ProfileComponentControlPlaneOperator:
+			targets = append(targets, profileTarget{component: component, namespace: cpNamespace, labels: client.MatchingLabels{"name": "control-plane-operator"}})
+		case ProfileComponentHostedClusterConfigOperator:
+			targets = append(targets, profileTarget{component: component, namespace: cpNamespace, labels: client.MatchingLabels{"app": "hosted-cluster-config-operator"}})
+		case ProfileComponentIgnitionServer:
+			targets = append(targets, profileTarget{component: component, namespace: cpNamespace, labels: client.MatchingLabels{"app": ignitionserver.ResourceName}})
+		case ProfileComponentHyperShiftOperator:
+			targets = append(targets, profileTarget{component: component, namespace: o.OperatorNamespace, labels: client.MatchingLabels{"name": "operator"}})
+		}
+	}
+	return targets
+}
+
+// profiles returns the profiles collected from every component.
+func (o *DumpProfilesOptions) profiles() []pprofProfile {
+	return []pprofProfile{
+		{file: "cpu.pprof", path: fmt.Sprintf("/debug/pprof/profile?seconds=%d", int(o.CPUProfileDuration.Seconds()))},
+		{file: "heap.pprof", path: "/debug/pprof/heap"},
+		{file: "goroutine.pprof", path: "/debug/pprof/goroutine"},
+	}
+}
+
+// Run collects the profiles of the components into the artifact directory, one directory per component. A component
+// which can't be profiled doesn't prevent the others from being profiled.
+func (o *DumpProfilesOptions) Run(ctx context.Context) error {
+	if err := o.Validate(); err != nil {
+		return err
+	}
+
+	var c client.Client
+	var err error
+	if len(o.ImpersonateAs) > 0 {
+		c, err = util.GetImpersonatedClient(o.ImpersonateAs)
+	} else {
+		c, err = util.GetClient()
+	}
+	if err != nil {
+		return err
+	}
+
+	hostedCluster := &hyperv1.HostedCluster{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: o.Namespace, Name: o.Name}, hostedCluster); err != nil {
+		return fmt.Errorf("failed to get hosted cluster %s/%s: %w", o.Namespace, o.Name, err)
+	}
+	if until, enabled := config.PprofEnabledUntil(hostedCluster.Annotations, time.Now()); enabled {
+		o.Log.Info("The pprof endpoints of the control plane are enabled", "until", until.Format(time.RFC3339))
+	} else {
+		o.Log.Info(fmt.Sprintf("WARNING: the pprof endpoints of the control plane aren't enabled, set the %s annotation of the hostedcluster to enable them", hyperv1.EnablePprofUntilAnnotation))
+	}
+	cpNamespace := manifests.HostedControlPlaneNamespace(o.Namespace, o.Name)
+
+	restConfig, err := util.GetConfig()
+	if err != nil {
+		return fmt.Errorf("failed to get a config for management cluster: %w", err)
+	}
+	if len(o.ImpersonateAs) > 0 {
+		restConfig.Impersonate = restclient.ImpersonationConfig{
+			UserName: o.ImpersonateAs,
+		}
+	}
+	kubeClient, err := kubeclient.NewForConfig(restConfig)
+	if err != nil {
+		return fmt.Errorf("failed to get a kubernetes client: %w", err)
+	}
+
+	var errs []error
+	for _, target := range o.profileTargets(cpNamespace) {
+		if err := o.collectProfiles(ctx, c, kubeClient, restConfig, target); err != nil {
+			errs = append(errs, fmt.Errorf("failed to profile %s: %w", target.component, err))
+			continue
+		}
+		o.Log.Info("Collected profiles", "component", target.component, "dir", filepath.Join(o.ArtifactDir, target.component))
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+func (o *DumpProfilesOptions) collectProfiles(ctx context.Context, c client.Client, kubeClient kubeclient.Interface, restConfig *restclient.Config, target profileTarget) error {
+	pod, err := runningPod(ctx, c, target.namespace, target.labels)
+	if err != nil {
+		return err
+	}
+	localPort, err := freeLocalPort()
+	if err != nil {
+		return err
+	}
+	forwarderOutput := &bytes.Buffer{}
+	forwarder := portForwarder{
+		Namespace: pod.Namespace,
+		PodName:   pod.Name,
+		Config:    restConfig,
+		Client:    kubeClient,
+		Out:       forwarderOutput,
+		ErrOut:    forwarderOutput,
+	}
+	forwarderStop := make(chan struct{})
+	defer close(forwarderStop)
+	if err := forwarder.ForwardPorts([]string{fmt.Sprintf("%d:%d", localPort, config.DefaultPprofPort)}, forwarderStop); err != nil {
+		return fmt.Errorf("cannot forward pprof port of pod %s: %w, output: %s", pod.Name, err, forwarderOutput.String())
+	}
+	o.Log.Info("Collecting profiles", "component", target.component, "pod", pod.Name)
+	return fetchProfiles(ctx, fmt.Sprintf("http://127.0.0.1:%d", localPort), o.profiles(), o.CPUProfileDuration, filepath.Join(o.ArtifactDir, target.component))
+}
+
+// fetchProfiles downloads the profiles from the pprof endpoints at the given URL into the directory.
+func fetchProfiles(ctx context.Context, baseURL string, profiles []pprofProfile, cpuProfileDuration time.Duration, dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+	// The CPU profile is only returned once it was collected for its whole duration.
+	httpClient := &http.Client{Timeout: cpuProfileDuration + 30*time.Second}
+	for _, profile := range profiles {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+profile.path, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return fmt.Errorf("failed to get %s: %w", profile.path, err)
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", profile.path, err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("failed to get %s: %s: %s", profile.path, resp.Status, string(body))
+		}
+		if err := os.WriteFile(filepath.Join(dir, profile.file), body, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", profile.file, err)
+		}
+	}
+	return nil
+}
+
+// freeLocalPort returns a local port nothing listens on.
+func freeLocalPort() (int, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, fmt.Errorf("failed to find a free local port: %w", err)
+	}
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
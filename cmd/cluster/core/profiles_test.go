@@ -0,0 +1,105 @@
+package core
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestDumpProfilesValidate(t *testing.T) {
+	testCases := []struct {
+		name        string
+		opts        DumpProfilesOptions
+		expectError bool
+	}{
+		{
+			name: "When the components are supported it should succeed",
+			opts: DumpProfilesOptions{
+				Components:         []string{ProfileComponentControlPlaneOperator, ProfileComponentHyperShiftOperator},
+				CPUProfileDuration: 30 * time.Second,
+			},
+		},
+		{
+			name: "When a component isn't supported it should fail",
+			opts: DumpProfilesOptions{
+				Components:         []string{"kube-apiserver"},
+				CPUProfileDuration: 30 * time.Second,
+			},
+			expectError: true,
+		},
+		{
+			name: "When the CPU profile duration is shorter than a second it should fail",
+			opts: DumpProfilesOptions{
+				Components:         []string{ProfileComponentIgnitionServer},
+				CPUProfileDuration: 100 * time.Millisecond,
+			},
+			expectError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := tc.opts.Validate()
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestProfileTargets(t *testing.T) {
+	g := NewWithT(t)
+	opts := DumpProfilesOptions{
+		Components:        []string{ProfileComponentHostedClusterConfigOperator, ProfileComponentHyperShiftOperator},
+		OperatorNamespace: "hypershift",
+	}
+	targets := opts.profileTargets("clusters-example")
+	g.Expect(targets).To(HaveLen(2))
+	g.Expect(targets[0].namespace).To(Equal("clusters-example"))
+	g.Expect(targets[0].labels).To(HaveKeyWithValue("app", "hosted-cluster-config-operator"))
+	g.Expect(targets[1].namespace).To(Equal("hypershift"))
+	g.Expect(targets[1].labels).To(HaveKeyWithValue("name", "operator"))
+}
+
+func TestFetchProfiles(t *testing.T) {
+	opts := DumpProfilesOptions{CPUProfileDuration: 5 * time.Second}
+
+	t.Run("When the endpoints serve the profiles it should write them to the directory", func(t *testing.T) {
+		g := NewWithT(t)
+		var requested []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requested = append(requested, r.URL.String())
+			_, _ = w.Write([]byte(r.URL.Path))
+		}))
+		defer server.Close()
+		dir := filepath.Join(t.TempDir(), ProfileComponentControlPlaneOperator)
+
+		err := fetchProfiles(context.Background(), server.URL, opts.profiles(), opts.CPUProfileDuration, dir)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(requested).To(ConsistOf("/debug/pprof/profile?seconds=5", "/debug/pprof/heap", "/debug/pprof/goroutine"))
+		heap, err := os.ReadFile(filepath.Join(dir, "heap.pprof"))
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(string(heap)).To(Equal("/debug/pprof/heap"))
+		g.Expect(filepath.Join(dir, "cpu.pprof")).To(BeAnExistingFile())
+		g.Expect(filepath.Join(dir, "goroutine.pprof")).To(BeAnExistingFile())
+	})
+
+	t.Run("When an endpoint fails it should return an error", func(t *testing.T) {
+		g := NewWithT(t)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.NotFound(w, r)
+		}))
+		defer server.Close()
+
+		err := fetchProfiles(context.Background(), server.URL, opts.profiles(), opts.CPUProfileDuration, t.TempDir())
+		g.Expect(err).To(HaveOccurred())
+	})
+}
@@ -0,0 +1,443 @@
+package core
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/go-logr/logr"
+	routev1 "github.com/openshift/api/route/v1"
+	"github.com/spf13/cobra"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/cmd/log"
+	"github.com/openshift/hypershift/cmd/util"
+	hyperapi "github.com/openshift/hypershift/support/api"
+)
+
+const (
+	SmokeTestAPIAvailability = "APIAvailability"
+	SmokeTestSampleWorkload  = "SampleWorkload"
+	SmokeTestIngressRoute    = "IngressRoute"
+	SmokeTestPVCBinding      = "PVCBinding"
+	SmokeTestLoadBalancer    = "LoadBalancer"
+
+	smokeTestAppName = "hypershift-smoke-test"
+)
+
+type SmokeTestOptions struct {
+	Namespace     string
+	Name          string
+	JUnitOutput   string
+	CheckTimeout  time.Duration
+	WorkloadImage string
+	Skip          []string
+
+	Log logr.Logger
+}
+
+// SmokeTestCheck is a single check run against the guest cluster. Checks run in order and share the
+// test namespace, so later checks can rely on the resources created by earlier ones.
+type SmokeTestCheck struct {
+	Name string
+	Run  func(ctx context.Context, c client.Client, namespace string) error
+}
+
+// smokeTestSkip is returned by a check that does not apply to the guest cluster.
+type smokeTestSkip struct {
+	reason string
+}
+
+func (s *smokeTestSkip) Error() string {
+	return s.reason
+}
+
+// SmokeTestResult is the outcome of a SmokeTestCheck.
+type SmokeTestResult struct {
+	Name     string
+	Duration time.Duration
+	Skipped  string
+	Err      error
+}
+
+func NewTestCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "cluster",
+		Short:        "Runs a smoke test suite against a hosted cluster",
+		SilenceUsage: true,
+	}
+
+	opts := &SmokeTestOptions{
+		Namespace:     "clusters",
+		Name:          "example",
+		CheckTimeout:  5 * time.Minute,
+		WorkloadImage: "registry.k8s.io/e2e-test-images/agnhost:2.47",
+		Log:           log.Log,
+	}
+
+	cmd.Flags().StringVar(&opts.Namespace, "namespace", opts.Namespace, "The namespace of the hostedcluster")
+	cmd.Flags().StringVar(&opts.Name, "name", opts.Name, "The name of the hostedcluster")
+	cmd.Flags().StringVar(&opts.JUnitOutput, "junit-output", opts.JUnitOutput, "If set, path of a file where the results are written in JUnit format")
+	cmd.Flags().DurationVar(&opts.CheckTimeout, "check-timeout", opts.CheckTimeout, "How long to wait for each check to succeed")
+	cmd.Flags().StringVar(&opts.WorkloadImage, "workload-image", opts.WorkloadImage, "Image used for the sample workload. It must serve HTTP on port 8080 when run with the 'netexec' argument")
+	cmd.Flags().StringSliceVar(&opts.Skip, "skip", opts.Skip, fmt.Sprintf("Checks to skip. Supported options: %s, %s, %s, %s, %s", SmokeTestAPIAvailability, SmokeTestSampleWorkload, SmokeTestIngressRoute, SmokeTestPVCBinding, SmokeTestLoadBalancer))
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if err := opts.Run(cmd.Context()); err != nil {
+			opts.Log.Error(err, "Error")
+			return err
+		}
+		return nil
+	}
+	return cmd
+}
+
+func (o *SmokeTestOptions) Run(ctx context.Context) error {
+	guestClient, err := o.guestClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{GenerateName: smokeTestAppName + "-"}}
+	if err := guestClient.Create(ctx, namespace); err != nil {
+		return fmt.Errorf("failed to create test namespace: %w", err)
+	}
+	defer func() {
+		if err := guestClient.Delete(context.Background(), namespace); err != nil {
+			o.Log.Error(err, "Failed to delete test namespace", "namespace", namespace.Name)
+		}
+	}()
+
+	results := RunSmokeTestChecks(ctx, o.Log, guestClient, namespace.Name, o.Checks(), sets.New(o.Skip...), o.CheckTimeout)
+
+	if o.JUnitOutput != "" {
+		if err := WriteSmokeTestJUnit(o.JUnitOutput, fmt.Sprintf("%s/%s", o.Namespace, o.Name), results); err != nil {
+			return err
+		}
+	}
+
+	var failed []string
+	for _, result := range results {
+		if result.Err != nil {
+			failed = append(failed, result.Name)
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("smoke test checks failed: %v", failed)
+	}
+	o.Log.Info("All smoke test checks passed")
+	return nil
+}
+
+func (o *SmokeTestOptions) guestClient(ctx context.Context) (client.Client, error) {
+	c, err := util.GetClient()
+	if err != nil {
+		return nil, err
+	}
+	hostedCluster := &hyperv1.HostedCluster{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: o.Namespace, Name: o.Name}, hostedCluster); err != nil {
+		return nil, fmt.Errorf("failed to get hostedcluster %s/%s: %w", o.Namespace, o.Name, err)
+	}
+	if hostedCluster.Status.KubeConfig == nil {
+		return nil, fmt.Errorf("hostedcluster %s/%s has no kubeconfig published yet", o.Namespace, o.Name)
+	}
+	kubeconfigSecret := &corev1.Secret{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: o.Namespace, Name: hostedCluster.Status.KubeConfig.Name}, kubeconfigSecret); err != nil {
+		return nil, fmt.Errorf("failed to get kubeconfig secret: %w", err)
+	}
+	restConfig, err := clientcmd.RESTConfigFromKubeConfig(kubeconfigSecret.Data["kubeconfig"])
+	if err != nil {
+		return nil, fmt.Errorf("failed to load guest kubeconfig: %w", err)
+	}
+	guestClient, err := client.New(restConfig, client.Options{Scheme: hyperapi.Scheme})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create guest cluster client: %w", err)
+	}
+	return guestClient, nil
+}
+
+// Checks returns the checks of the smoke test suite in the order they run.
+func (o *SmokeTestOptions) Checks() []SmokeTestCheck {
+	return []SmokeTestCheck{
+		{Name: SmokeTestAPIAvailability, Run: checkAPIAvailability},
+		{Name: SmokeTestSampleWorkload, Run: func(ctx context.Context, c client.Client, namespace string) error {
+			return checkSampleWorkload(ctx, c, namespace, o.WorkloadImage)
+		}},
+		{Name: SmokeTestIngressRoute, Run: checkIngressRoute},
+		{Name: SmokeTestPVCBinding, Run: func(ctx context.Context, c client.Client, namespace string) error {
+			return checkPVCBinding(ctx, c, namespace, o.WorkloadImage)
+		}},
+		{Name: SmokeTestLoadBalancer, Run: checkLoadBalancer},
+	}
+}
+
+// RunSmokeTestChecks runs every check that is not skipped. A check is retried until it succeeds or the timeout expires.
+// Checks that depend on the sample workload are skipped when the workload check did not pass.
+func RunSmokeTestChecks(ctx context.Context, log logr.Logger, c client.Client, namespace string, checks []SmokeTestCheck, skip sets.Set[string], timeout time.Duration) []SmokeTestResult {
+	var results []SmokeTestResult
+	workloadAvailable := false
+	for _, check := range checks {
+		result := SmokeTestResult{Name: check.Name}
+		switch {
+		case skip.Has(check.Name):
+			result.Skipped = "skipped by user"
+		case check.Name == SmokeTestIngressRoute && !workloadAvailable:
+			result.Skipped = fmt.Sprintf("requires %s to pass", SmokeTestSampleWorkload)
+		}
+		if result.Skipped != "" {
+			log.Info("Skipping check", "check", check.Name, "reason", result.Skipped)
+			results = append(results, result)
+			continue
+		}
+
+		log.Info("Running check", "check", check.Name)
+		start := time.Now()
+		var lastErr error
+		checkCtx, cancel := context.WithTimeout(ctx, timeout)
+		err := wait.PollUntilContextCancel(checkCtx, 5*time.Second, true, func(ctx context.Context) (bool, error) {
+			lastErr = check.Run(ctx, c, namespace)
+			var skip *smokeTestSkip
+			if errors.As(lastErr, &skip) {
+				return false, skip
+			}
+			return lastErr == nil, nil
+		})
+		cancel()
+		result.Duration = time.Since(start)
+		var skip *smokeTestSkip
+		if errors.As(err, &skip) {
+			result.Skipped = skip.reason
+			log.Info("Skipping check", "check", check.Name, "reason", result.Skipped)
+		} else if err != nil {
+			result.Err = err
+			if lastErr != nil {
+				result.Err = lastErr
+			}
+			log.Info("Check failed", "check", check.Name, "duration", result.Duration.Round(time.Second).String(), "error", result.Err.Error())
+		} else {
+			log.Info("Check passed", "check", check.Name, "duration", result.Duration.Round(time.Second).String())
+			if check.Name == SmokeTestSampleWorkload {
+				workloadAvailable = true
+			}
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+func checkAPIAvailability(ctx context.Context, c client.Client, _ string) error {
+	namespaces := &corev1.NamespaceList{}
+	if err := c.List(ctx, namespaces, client.Limit(1)); err != nil {
+		return fmt.Errorf("failed to list namespaces: %w", err)
+	}
+	return nil
+}
+
+func checkSampleWorkload(ctx context.Context, c client.Client, namespace, image string) error {
+	labels := map[string]string{"app": smokeTestAppName}
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: smokeTestAppName}}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(deployment), deployment); err != nil {
+		deployment.Spec = appsv1.DeploymentSpec{
+			Replicas: ptr.To[int32](1),
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name:  "app",
+						Image: image,
+						Args:  []string{"netexec", "--http-port=8080"},
+						Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: 8080}},
+					}},
+				},
+			},
+		}
+		if err := c.Create(ctx, deployment); err != nil {
+			return fmt.Errorf("failed to create deployment: %w", err)
+		}
+		service := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: smokeTestAppName},
+			Spec: corev1.ServiceSpec{
+				Selector: labels,
+				Ports:    []corev1.ServicePort{{Name: "http", Port: 8080, TargetPort: intstr.FromString("http")}},
+			},
+		}
+		if err := c.Create(ctx, service); err != nil {
+			return fmt.Errorf("failed to create service: %w", err)
+		}
+	}
+	for _, cond := range deployment.Status.Conditions {
+		if cond.Type == appsv1.DeploymentAvailable && cond.Status == corev1.ConditionTrue {
+			return nil
+		}
+	}
+	return fmt.Errorf("deployment %s is not available", deployment.Name)
+}
+
+func checkIngressRoute(ctx context.Context, c client.Client, namespace string) error {
+	route := &routev1.Route{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: smokeTestAppName}}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(route), route); err != nil {
+		route.Spec = routev1.RouteSpec{
+			To:   routev1.RouteTargetReference{Kind: "Service", Name: smokeTestAppName},
+			Port: &routev1.RoutePort{TargetPort: intstr.FromString("http")},
+		}
+		if err := c.Create(ctx, route); err != nil {
+			return fmt.Errorf("failed to create route: %w", err)
+		}
+	}
+	if route.Spec.Host == "" {
+		return fmt.Errorf("route %s has no host yet", route.Name)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("http://%s/hostname", route.Spec.Host), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach route %s: %w", route.Spec.Host, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code from route %s: %d", route.Spec.Host, resp.StatusCode)
+	}
+	return nil
+}
+
+func checkPVCBinding(ctx context.Context, c client.Client, namespace, image string) error {
+	storageClasses := &storagev1.StorageClassList{}
+	if err := c.List(ctx, storageClasses); err != nil {
+		return fmt.Errorf("failed to list storage classes: %w", err)
+	}
+	hasDefault := false
+	for _, storageClass := range storageClasses.Items {
+		if storageClass.Annotations["storageclass.kubernetes.io/is-default-class"] == "true" {
+			hasDefault = true
+			break
+		}
+	}
+	if !hasDefault {
+		return &smokeTestSkip{reason: "there is no default storage class"}
+	}
+
+	pvc := &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: smokeTestAppName}}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(pvc), pvc); err != nil {
+		pvc.Spec = corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")},
+			},
+		}
+		if err := c.Create(ctx, pvc); err != nil {
+			return fmt.Errorf("failed to create pvc: %w", err)
+		}
+		// Storage classes with WaitForFirstConsumer binding mode only bind once a pod uses the claim.
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: smokeTestAppName + "-pvc"},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{
+					Name:         "app",
+					Image:        image,
+					Args:         []string{"pause"},
+					VolumeMounts: []corev1.VolumeMount{{Name: "data", MountPath: "/data"}},
+				}},
+				Volumes: []corev1.Volume{{
+					Name:         "data",
+					VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: pvc.Name}},
+				}},
+			},
+		}
+		if err := c.Create(ctx, pod); err != nil {
+			return fmt.Errorf("failed to create pod using the pvc: %w", err)
+		}
+	}
+	if pvc.Status.Phase != corev1.ClaimBound {
+		return fmt.Errorf("pvc %s is not bound", pvc.Name)
+	}
+	return nil
+}
+
+func checkLoadBalancer(ctx context.Context, c client.Client, namespace string) error {
+	service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: smokeTestAppName + "-lb"}}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(service), service); err != nil {
+		service.Spec = corev1.ServiceSpec{
+			Type:     corev1.ServiceTypeLoadBalancer,
+			Selector: map[string]string{"app": smokeTestAppName},
+			Ports:    []corev1.ServicePort{{Name: "http", Port: 8080, TargetPort: intstr.FromString("http")}},
+		}
+		if err := c.Create(ctx, service); err != nil {
+			return fmt.Errorf("failed to create load balancer service: %w", err)
+		}
+	}
+	if len(service.Status.LoadBalancer.Ingress) == 0 {
+		return fmt.Errorf("load balancer for service %s is not provisioned", service.Name)
+	}
+	return nil
+}
+
+type junitTestSuite struct {
+	XMLName   xml.Name        `xml:"testsuite"`
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      float64         `xml:"time,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      float64       `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+}
+
+// WriteSmokeTestJUnit writes the results to path as a JUnit test suite.
+func WriteSmokeTestJUnit(path, suiteName string, results []SmokeTestResult) error {
+	suite := junitTestSuite{Name: suiteName}
+	for _, result := range results {
+		testCase := junitTestCase{
+			Name:      result.Name,
+			ClassName: "hypershift.smoke",
+			Time:      result.Duration.Seconds(),
+		}
+		switch {
+		case result.Skipped != "":
+			testCase.Skipped = &junitMessage{Message: result.Skipped}
+			suite.Skipped++
+		case result.Err != nil:
+			testCase.Failure = &junitMessage{Message: result.Err.Error()}
+			suite.Failures++
+		}
+		suite.Tests++
+		suite.Time += testCase.Time
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+	out, err := xml.MarshalIndent(suite, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal junit results: %w", err)
+	}
+	if err := os.WriteFile(path, append([]byte(xml.Header), out...), 0644); err != nil {
+		return fmt.Errorf("failed to write junit results: %w", err)
+	}
+	return nil
+}
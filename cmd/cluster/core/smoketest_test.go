@@ -0,0 +1,48 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestRunSmokeTestChecks(t *testing.T) {
+	g := NewWithT(t)
+
+	pass := func(context.Context, client.Client, string) error { return nil }
+	fail := func(context.Context, client.Client, string) error { return errors.New("boom") }
+	notApplicable := func(context.Context, client.Client, string) error { return &smokeTestSkip{reason: "not applicable"} }
+
+	checks := []SmokeTestCheck{
+		{Name: SmokeTestAPIAvailability, Run: pass},
+		{Name: SmokeTestSampleWorkload, Run: fail},
+		{Name: SmokeTestIngressRoute, Run: pass},
+		{Name: SmokeTestPVCBinding, Run: notApplicable},
+		{Name: SmokeTestLoadBalancer, Run: pass},
+	}
+	results := RunSmokeTestChecks(context.Background(), logr.Discard(), nil, "test", checks, sets.New(SmokeTestLoadBalancer), 100*time.Millisecond)
+	g.Expect(results).To(HaveLen(5))
+
+	g.Expect(results[0].Err).ToNot(HaveOccurred())
+	g.Expect(results[0].Skipped).To(BeEmpty())
+	g.Expect(results[1].Err).To(MatchError("boom"))
+	g.Expect(results[2].Skipped).To(ContainSubstring(SmokeTestSampleWorkload))
+	g.Expect(results[3].Skipped).To(Equal("not applicable"))
+	g.Expect(results[3].Err).ToNot(HaveOccurred())
+	g.Expect(results[4].Skipped).To(Equal("skipped by user"))
+
+	path := filepath.Join(t.TempDir(), "junit.xml")
+	g.Expect(WriteSmokeTestJUnit(path, "clusters/example", results)).To(Succeed())
+	out, err := os.ReadFile(path)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(string(out)).To(ContainSubstring(`<testsuite name="clusters/example" tests="5" failures="1" skipped="3"`))
+	g.Expect(string(out)).To(ContainSubstring(`<failure message="boom"></failure>`))
+}
@@ -0,0 +1,323 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/go-logr/logr"
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/cmd/log"
+	"github.com/openshift/hypershift/cmd/util"
+	"github.com/openshift/hypershift/hypershift-operator/controllers/manifests"
+	"github.com/openshift/hypershift/support/conditions"
+)
+
+const (
+	StatusOutputText = "text"
+	StatusOutputJSON = "json"
+
+	colorReset  = "\033[0m"
+	colorRed    = "\033[31m"
+	colorGreen  = "\033[32m"
+	colorYellow = "\033[33m"
+)
+
+type StatusOptions struct {
+	Namespace  string
+	Name       string
+	Output     string
+	NoColor    bool
+	EventLimit int
+
+	Log logr.Logger
+}
+
+// ClusterStatusReport is a summary of the health of a HostedCluster and its NodePools.
+type ClusterStatusReport struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Version   string `json:"version,omitempty"`
+	Healthy   bool   `json:"healthy"`
+
+	// UnexpectedConditions are the HostedCluster conditions that don't have their expected status.
+	UnexpectedConditions []ConditionSummary                     `json:"unexpectedConditions,omitempty"`
+	Components           []hyperv1.HostedClusterComponentStatus `json:"components,omitempty"`
+	NodePools            []NodePoolStatusSummary                `json:"nodePools,omitempty"`
+	PendingRollouts      []string                               `json:"pendingRollouts,omitempty"`
+	Events               []EventSummary                         `json:"events,omitempty"`
+}
+
+type ConditionSummary struct {
+	Type    string `json:"type"`
+	Status  string `json:"status"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type NodePoolStatusSummary struct {
+	Name                 string             `json:"name"`
+	Version              string             `json:"version,omitempty"`
+	DesiredReplicas      *int32             `json:"desiredReplicas,omitempty"`
+	Replicas             int32              `json:"replicas"`
+	UnexpectedConditions []ConditionSummary `json:"unexpectedConditions,omitempty"`
+}
+
+type EventSummary struct {
+	LastTimestamp metav1.Time `json:"lastTimestamp"`
+	Type          string      `json:"type"`
+	Object        string      `json:"object"`
+	Reason        string      `json:"reason"`
+	Message       string      `json:"message"`
+}
+
+func NewStatusCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "cluster",
+		Short:        "Reports the health of a HostedCluster and its NodePools",
+		SilenceUsage: true,
+	}
+
+	opts := &StatusOptions{
+		Namespace:  "clusters",
+		Name:       "example",
+		Output:     StatusOutputText,
+		EventLimit: 10,
+		Log:        log.Log,
+	}
+
+	cmd.Flags().StringVar(&opts.Namespace, "namespace", opts.Namespace, "The namespace of the hostedcluster")
+	cmd.Flags().StringVar(&opts.Name, "name", opts.Name, "The name of the hostedcluster")
+	cmd.Flags().StringVarP(&opts.Output, "output", "o", opts.Output, "Output format. Supported options: text, json")
+	cmd.Flags().BoolVar(&opts.NoColor, "no-color", opts.NoColor, "Disable colored output")
+	cmd.Flags().IntVar(&opts.EventLimit, "event-limit", opts.EventLimit, "Maximum number of recent warning events to report")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if err := opts.Run(cmd.Context(), os.Stdout); err != nil {
+			opts.Log.Error(err, "Error")
+			return err
+		}
+		return nil
+	}
+	return cmd
+}
+
+func (o *StatusOptions) Run(ctx context.Context, out io.Writer) error {
+	if o.Output != StatusOutputText && o.Output != StatusOutputJSON {
+		return fmt.Errorf("unsupported output format %q", o.Output)
+	}
+	c, err := util.GetClient()
+	if err != nil {
+		return err
+	}
+	report, err := GetClusterStatusReport(ctx, c, o.Namespace, o.Name, o.EventLimit)
+	if err != nil {
+		return err
+	}
+	if o.Output == StatusOutputJSON {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+	return PrintClusterStatusReport(out, report, !o.NoColor)
+}
+
+// GetClusterStatusReport aggregates the HostedCluster and NodePool conditions, the recent warning events of the
+// cluster and control plane namespaces and any pending rollouts into a ClusterStatusReport.
+func GetClusterStatusReport(ctx context.Context, c client.Client, namespace, name string, eventLimit int) (*ClusterStatusReport, error) {
+	hostedCluster := &hyperv1.HostedCluster{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, hostedCluster); err != nil {
+		return nil, fmt.Errorf("failed to get hostedcluster %s/%s: %w", namespace, name, err)
+	}
+
+	report := &ClusterStatusReport{
+		Namespace:  namespace,
+		Name:       name,
+		Components: hostedCluster.Status.ComponentStatuses,
+	}
+	if hostedCluster.Status.Version != nil {
+		report.Version = hostedCluster.Status.Version.Desired.Version
+		if len(hostedCluster.Status.Version.History) > 0 && hostedCluster.Status.Version.History[0].State != configv1.CompletedUpdate {
+			report.PendingRollouts = append(report.PendingRollouts, fmt.Sprintf("HostedCluster is rolling out version %s", hostedCluster.Status.Version.History[0].Version))
+		}
+	}
+
+	expectedHCConditions := conditions.ExpectedHCConditions()
+	for _, condition := range hostedCluster.Status.Conditions {
+		expected, known := expectedHCConditions[hyperv1.ConditionType(condition.Type)]
+		if !known || expected == condition.Status {
+			continue
+		}
+		report.UnexpectedConditions = append(report.UnexpectedConditions, ConditionSummary{
+			Type:    condition.Type,
+			Status:  string(condition.Status),
+			Reason:  condition.Reason,
+			Message: condition.Message,
+		})
+	}
+	if healthy := meta.FindStatusCondition(hostedCluster.Status.Conditions, string(hyperv1.HostedClusterHealthy)); healthy != nil {
+		report.Healthy = healthy.Status == metav1.ConditionTrue
+	} else {
+		report.Healthy = meta.IsStatusConditionTrue(hostedCluster.Status.Conditions, string(hyperv1.HostedClusterAvailable))
+	}
+
+	nodePoolList := &hyperv1.NodePoolList{}
+	if err := c.List(ctx, nodePoolList, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list nodepools: %w", err)
+	}
+	expectedNodePoolConditions := conditions.ExpectedNodePoolConditions()
+	for _, nodePool := range nodePoolList.Items {
+		if nodePool.Spec.ClusterName != name {
+			continue
+		}
+		summary := NodePoolStatusSummary{
+			Name:            nodePool.Name,
+			Version:         nodePool.Status.Version,
+			DesiredReplicas: nodePool.Spec.Replicas,
+			Replicas:        nodePool.Status.Replicas,
+		}
+		for _, condition := range nodePool.Status.Conditions {
+			expected, known := expectedNodePoolConditions[condition.Type]
+			if !known || expected == condition.Status {
+				continue
+			}
+			summary.UnexpectedConditions = append(summary.UnexpectedConditions, ConditionSummary{
+				Type:    condition.Type,
+				Status:  string(condition.Status),
+				Reason:  condition.Reason,
+				Message: condition.Message,
+			})
+			switch condition.Type {
+			case hyperv1.NodePoolUpdatingVersionConditionType, hyperv1.NodePoolUpdatingConfigConditionType, hyperv1.NodePoolUpdatingPlatformMachineTemplateConditionType:
+				report.PendingRollouts = append(report.PendingRollouts, fmt.Sprintf("NodePool %s: %s: %s", nodePool.Name, condition.Type, condition.Message))
+			}
+		}
+		if len(summary.UnexpectedConditions) > 0 {
+			report.Healthy = false
+		}
+		report.NodePools = append(report.NodePools, summary)
+	}
+
+	controlPlaneNamespace := manifests.HostedControlPlaneNamespace(namespace, name)
+	for _, ns := range []string{namespace, controlPlaneNamespace} {
+		eventList := &corev1.EventList{}
+		if err := c.List(ctx, eventList, client.InNamespace(ns)); err != nil {
+			return nil, fmt.Errorf("failed to list events in namespace %s: %w", ns, err)
+		}
+		for _, event := range eventList.Items {
+			if event.Type != corev1.EventTypeWarning {
+				continue
+			}
+			report.Events = append(report.Events, EventSummary{
+				LastTimestamp: event.LastTimestamp,
+				Type:          event.Type,
+				Object:        fmt.Sprintf("%s/%s", strings.ToLower(event.InvolvedObject.Kind), event.InvolvedObject.Name),
+				Reason:        event.Reason,
+				Message:       event.Message,
+			})
+		}
+	}
+	sort.SliceStable(report.Events, func(i, j int) bool {
+		return report.Events[j].LastTimestamp.Before(&report.Events[i].LastTimestamp)
+	})
+	if eventLimit >= 0 && len(report.Events) > eventLimit {
+		report.Events = report.Events[:eventLimit]
+	}
+
+	return report, nil
+}
+
+// PrintClusterStatusReport writes a human-readable version of the report.
+func PrintClusterStatusReport(out io.Writer, report *ClusterStatusReport, color bool) error {
+	colorize := func(c, s string) string {
+		if !color {
+			return s
+		}
+		return c + s + colorReset
+	}
+	statusColor := func(status string) string {
+		switch status {
+		case string(metav1.ConditionTrue):
+			return colorize(colorGreen, status)
+		case string(metav1.ConditionFalse):
+			return colorize(colorRed, status)
+		default:
+			return colorize(colorYellow, status)
+		}
+	}
+
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	health := colorize(colorGreen, "Healthy")
+	if !report.Healthy {
+		health = colorize(colorRed, "Unhealthy")
+	}
+	fmt.Fprintf(w, "HostedCluster %s/%s: %s\n", report.Namespace, report.Name, health)
+	if report.Version != "" {
+		fmt.Fprintf(w, "Version: %s\n", report.Version)
+	}
+
+	if len(report.Components) > 0 {
+		fmt.Fprintf(w, "\nComponents:\n")
+		fmt.Fprintf(w, "  NAME\tAVAILABLE\tREASON\tMESSAGE\n")
+		for _, component := range report.Components {
+			fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", component.Name, statusColor(string(component.Available)), component.Reason, component.Message)
+		}
+	}
+
+	if len(report.UnexpectedConditions) > 0 {
+		fmt.Fprintf(w, "\nUnexpected conditions:\n")
+		fmt.Fprintf(w, "  TYPE\tSTATUS\tREASON\tMESSAGE\n")
+		for _, condition := range report.UnexpectedConditions {
+			fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", condition.Type, statusColor(condition.Status), condition.Reason, condition.Message)
+		}
+	}
+
+	if len(report.NodePools) > 0 {
+		fmt.Fprintf(w, "\nNodePools:\n")
+		fmt.Fprintf(w, "  NAME\tVERSION\tREADY\tPROBLEMS\n")
+		for _, nodePool := range report.NodePools {
+			desired := "autoscaling"
+			if nodePool.DesiredReplicas != nil {
+				desired = fmt.Sprintf("%d", *nodePool.DesiredReplicas)
+			}
+			var problems []string
+			for _, condition := range nodePool.UnexpectedConditions {
+				problems = append(problems, fmt.Sprintf("%s=%s", condition.Type, condition.Status))
+			}
+			problemsText := colorize(colorGreen, "none")
+			if len(problems) > 0 {
+				problemsText = colorize(colorRed, strings.Join(problems, ","))
+			}
+			fmt.Fprintf(w, "  %s\t%s\t%d/%s\t%s\n", nodePool.Name, nodePool.Version, nodePool.Replicas, desired, problemsText)
+		}
+	}
+
+	if len(report.PendingRollouts) > 0 {
+		fmt.Fprintf(w, "\nPending rollouts:\n")
+		for _, rollout := range report.PendingRollouts {
+			fmt.Fprintf(w, "  %s\n", colorize(colorYellow, rollout))
+		}
+	}
+
+	if len(report.Events) > 0 {
+		fmt.Fprintf(w, "\nRecent warning events:\n")
+		fmt.Fprintf(w, "  LAST SEEN\tOBJECT\tREASON\tMESSAGE\n")
+		for _, event := range report.Events {
+			fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", event.LastTimestamp.Format("2006-01-02T15:04:05Z07:00"), event.Object, colorize(colorYellow, event.Reason), strings.TrimSpace(event.Message))
+		}
+	}
+
+	return w.Flush()
+}
@@ -0,0 +1,88 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/support/api"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestGetClusterStatusReport(t *testing.T) {
+	g := NewWithT(t)
+
+	hostedCluster := &hyperv1.HostedCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "example"},
+		Status: hyperv1.HostedClusterStatus{
+			Conditions: []metav1.Condition{
+				{Type: string(hyperv1.HostedClusterAvailable), Status: metav1.ConditionTrue, Reason: hyperv1.AsExpectedReason},
+				{Type: string(hyperv1.HostedClusterHealthy), Status: metav1.ConditionFalse, Reason: "NodesNotAvailable"},
+			},
+			ComponentStatuses: []hyperv1.HostedClusterComponentStatus{
+				{Name: hyperv1.NodesComponent, Available: metav1.ConditionFalse, Reason: hyperv1.WaitingForNodesReason},
+			},
+		},
+	}
+	nodePool := &hyperv1.NodePool{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "example-us-east-1a"},
+		Spec: hyperv1.NodePoolSpec{
+			ClusterName: "example",
+			Replicas:    ptr.To[int32](2),
+		},
+		Status: hyperv1.NodePoolStatus{
+			Replicas: 1,
+			Conditions: []hyperv1.NodePoolCondition{
+				{Type: hyperv1.NodePoolReadyConditionType, Status: corev1.ConditionFalse},
+				{Type: hyperv1.NodePoolUpdatingVersionConditionType, Status: corev1.ConditionTrue, Message: "Updating version to 4.16.0"},
+			},
+		},
+	}
+	otherNodePool := &hyperv1.NodePool{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "other"},
+		Spec:       hyperv1.NodePoolSpec{ClusterName: "other"},
+	}
+	now := time.Now()
+	oldEvent := &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Namespace: "clusters-example", Name: "old"},
+		Type:           corev1.EventTypeWarning,
+		Reason:         "Old",
+		LastTimestamp:  metav1.NewTime(now.Add(-time.Hour)),
+		InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "kube-apiserver"},
+	}
+	newEvent := &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Namespace: "clusters", Name: "new"},
+		Type:           corev1.EventTypeWarning,
+		Reason:         "New",
+		LastTimestamp:  metav1.NewTime(now),
+		InvolvedObject: corev1.ObjectReference{Kind: "NodePool", Name: "example-us-east-1a"},
+	}
+	normalEvent := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "normal"},
+		Type:       corev1.EventTypeNormal,
+	}
+
+	c := fake.NewClientBuilder().WithScheme(api.Scheme).WithObjects(hostedCluster, nodePool, otherNodePool, oldEvent, newEvent, normalEvent).Build()
+	report, err := GetClusterStatusReport(context.Background(), c, "clusters", "example", 10)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	g.Expect(report.Healthy).To(BeFalse())
+	g.Expect(report.UnexpectedConditions).To(HaveLen(1))
+	g.Expect(report.NodePools).To(HaveLen(1))
+	g.Expect(report.NodePools[0].UnexpectedConditions).To(HaveLen(2))
+	g.Expect(report.PendingRollouts).To(ConsistOf(ContainSubstring("Updating version to 4.16.0")))
+	g.Expect(report.Events).To(HaveLen(2))
+	g.Expect(report.Events[0].Reason).To(Equal("New"))
+	g.Expect(report.Events[1].Object).To(Equal("pod/kube-apiserver"))
+
+	out := &bytes.Buffer{}
+	g.Expect(PrintClusterStatusReport(out, report, false)).To(Succeed())
+	g.Expect(out.String()).To(ContainSubstring("HostedCluster clusters/example: Unhealthy"))
+	g.Expect(out.String()).To(ContainSubstring("example-us-east-1a"))
+}
@@ -0,0 +1,195 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/support/conditions"
+)
+
+const rolloutPollInterval = 15 * time.Second
+
+// rolloutMilestone is a step of the rollout of a new HostedCluster reported while waiting for it.
+type rolloutMilestone struct {
+	name    string
+	reached func(hostedCluster *hyperv1.HostedCluster, nodes nodeProgress) bool
+}
+
+// rolloutMilestones are the steps of the rollout of a new HostedCluster, in the order they are usually reached.
+var rolloutMilestones = []rolloutMilestone{
+	{name: "Infrastructure ready", reached: conditionReached(hyperv1.InfrastructureReady)},
+	{name: "Etcd available", reached: conditionReached(hyperv1.EtcdAvailable)},
+	{name: "Kube API server available", reached: conditionReached(hyperv1.KubeAPIServerAvailable)},
+	{name: "Nodes joined", reached: func(_ *hyperv1.HostedCluster, nodes nodeProgress) bool {
+		return nodes.desired > 0 && nodes.ready >= nodes.desired
+	}},
+	{name: "Cluster operators done", reached: func(hostedCluster *hyperv1.HostedCluster, _ nodeProgress) bool {
+		return hostedCluster.Status.Version != nil && len(hostedCluster.Status.Version.History) > 0 && hostedCluster.Status.Version.History[0].CompletionTime != nil
+	}},
+}
+
+func conditionReached(conditionType hyperv1.ConditionType) func(*hyperv1.HostedCluster, nodeProgress) bool {
+	return func(hostedCluster *hyperv1.HostedCluster, _ nodeProgress) bool {
+		return meta.IsStatusConditionTrue(hostedCluster.Status.Conditions, string(conditionType))
+	}
+}
+
+// nodeProgress is the number of nodes of the NodePools of a HostedCluster.
+type nodeProgress struct {
+	ready   int32
+	desired int32
+}
+
+func nodeProgressOf(clusterName string, nodePools []hyperv1.NodePool) nodeProgress {
+	var progress nodeProgress
+	for _, nodePool := range nodePools {
+		if nodePool.Spec.ClusterName != clusterName {
+			continue
+		}
+		switch {
+		case nodePool.Spec.Replicas != nil:
+			progress.desired += *nodePool.Spec.Replicas
+		case nodePool.Spec.AutoScaling != nil:
+			progress.desired += nodePool.Spec.AutoScaling.Min
+		}
+		progress.ready += nodePool.Status.Replicas
+	}
+	return progress
+}
+
+// rolloutProgress tracks the rollout of a HostedCluster across polls: the transitions of its conditions, its nodes
+// and when each milestone was reached.
+type rolloutProgress struct {
+	start      time.Time
+	reachedAt  map[string]time.Duration
+	conditions map[string]metav1.Condition
+	nodes      nodeProgress
+}
+
+func newRolloutProgress(start time.Time) *rolloutProgress {
+	return &rolloutProgress{
+		start:      start,
+		reachedAt:  map[string]time.Duration{},
+		conditions: map[string]metav1.Condition{},
+	}
+}
+
+// update records the state of the HostedCluster and its NodePools and returns the messages describing what changed
+// since the previous update.
+func (p *rolloutProgress) update(hostedCluster *hyperv1.HostedCluster, nodePools []hyperv1.NodePool, now time.Time) []string {
+	var messages []string
+
+	expected := conditions.ExpectedHCConditions()
+	for _, condition := range hostedCluster.Status.Conditions {
+		expectedStatus, known := expected[hyperv1.ConditionType(condition.Type)]
+		if !known {
+			continue
+		}
+		previous, seen := p.conditions[condition.Type]
+		p.conditions[condition.Type] = condition
+		if seen && previous.Status == condition.Status && previous.Reason == condition.Reason {
+			continue
+		}
+		// Don't report the conditions already as expected when first seen, only the ones still to come.
+		if !seen && condition.Status == expectedStatus {
+			continue
+		}
+		message := fmt.Sprintf("Condition %s is %s", condition.Type, condition.Status)
+		if condition.Reason != "" {
+			message += fmt.Sprintf(" (%s)", condition.Reason)
+		}
+		if condition.Status != expectedStatus && condition.Message != "" {
+			message += ": " + condition.Message
+		}
+		messages = append(messages, message)
+	}
+
+	nodes := nodeProgressOf(hostedCluster.Name, nodePools)
+	if nodes != p.nodes {
+		messages = append(messages, fmt.Sprintf("Nodes ready: %d/%d", nodes.ready, nodes.desired))
+		p.nodes = nodes
+	}
+
+	for _, milestone := range rolloutMilestones {
+		if _, reached := p.reachedAt[milestone.name]; reached || !milestone.reached(hostedCluster, nodes) {
+			continue
+		}
+		elapsed := now.Sub(p.start).Round(time.Second)
+		p.reachedAt[milestone.name] = elapsed
+		messages = append(messages, fmt.Sprintf("%s after %s", milestone.name, elapsed))
+	}
+	return messages
+}
+
+// done returns whether all the milestones were reached.
+func (p *rolloutProgress) done() bool {
+	return len(p.pending()) == 0
+}
+
+// pending returns the milestones that weren't reached yet.
+func (p *rolloutProgress) pending() []string {
+	var pending []string
+	for _, milestone := range rolloutMilestones {
+		if _, reached := p.reachedAt[milestone.name]; !reached {
+			pending = append(pending, milestone.name)
+		}
+	}
+	return pending
+}
+
+// summary returns a line per milestone with when it was reached, or that it is still pending.
+func (p *rolloutProgress) summary() []string {
+	var lines []string
+	for _, milestone := range rolloutMilestones {
+		if elapsed, reached := p.reachedAt[milestone.name]; reached {
+			lines = append(lines, fmt.Sprintf("%s: done after %s", milestone.name, elapsed))
+		} else {
+			lines = append(lines, fmt.Sprintf("%s: pending", milestone.name))
+		}
+	}
+	return lines
+}
+
+// waitForClusterRollout polls the HostedCluster and its NodePools until all the rollout milestones are reached,
+// logging the condition transitions and milestones as they happen and a summary at the end. It returns an error if
+// the context is done first, e.g. because the --timeout expired.
+func waitForClusterRollout(ctx context.Context, l logr.Logger, c crclient.Client, hostedCluster *hyperv1.HostedCluster) error {
+	l.Info("Waiting for cluster rollout")
+	progress := newRolloutProgress(time.Now())
+	err := wait.PollUntilContextCancel(ctx, rolloutPollInterval, true, func(ctx context.Context) (bool, error) {
+		hostedCluster := hostedCluster.DeepCopy()
+		if err := c.Get(ctx, crclient.ObjectKeyFromObject(hostedCluster), hostedCluster); err != nil {
+			return false, fmt.Errorf("failed to get hostedcluster %s: %w", crclient.ObjectKeyFromObject(hostedCluster), err)
+		}
+		nodePoolList := &hyperv1.NodePoolList{}
+		if err := c.List(ctx, nodePoolList, crclient.InNamespace(hostedCluster.Namespace)); err != nil {
+			return false, fmt.Errorf("failed to list nodepools: %w", err)
+		}
+		for _, message := range progress.update(hostedCluster, nodePoolList.Items, time.Now()) {
+			l.Info(message)
+		}
+		return progress.done(), nil
+	})
+
+	l.Info("Cluster rollout summary")
+	for _, line := range progress.summary() {
+		l.Info("  " + line)
+	}
+	if ctx.Err() != nil {
+		return fmt.Errorf("timed out waiting for cluster rollout after %s, pending: %s", time.Since(progress.start).Round(time.Second), strings.Join(progress.pending(), ", "))
+	}
+	if err != nil {
+		return err
+	}
+	l.Info("Cluster rollout finished", "duration", time.Since(progress.start).Round(time.Second).String())
+	return nil
+}
@@ -0,0 +1,113 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	configv1 "github.com/openshift/api/config/v1"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/support/api"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRolloutProgress(t *testing.T) {
+	g := NewWithT(t)
+	start := time.Now()
+	progress := newRolloutProgress(start)
+
+	hostedCluster := &hyperv1.HostedCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "example"},
+		Status: hyperv1.HostedClusterStatus{
+			Conditions: []metav1.Condition{
+				{Type: string(hyperv1.InfrastructureReady), Status: metav1.ConditionTrue, Reason: hyperv1.AsExpectedReason},
+				{Type: string(hyperv1.EtcdAvailable), Status: metav1.ConditionFalse, Reason: hyperv1.EtcdWaitingForQuorumReason, Message: "Waiting for etcd quorum"},
+			},
+		},
+	}
+	nodePools := []hyperv1.NodePool{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "example"},
+			Spec:       hyperv1.NodePoolSpec{ClusterName: "example", Replicas: ptr.To[int32](2)},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "other"},
+			Spec:       hyperv1.NodePoolSpec{ClusterName: "other", Replicas: ptr.To[int32](3)},
+			Status:     hyperv1.NodePoolStatus{Replicas: 3},
+		},
+	}
+
+	messages := progress.update(hostedCluster, nodePools, start.Add(time.Minute))
+	g.Expect(messages).To(Equal([]string{
+		"Condition EtcdAvailable is False (EtcdWaitingForQuorum): Waiting for etcd quorum",
+		"Nodes ready: 0/2",
+		"Infrastructure ready after 1m0s",
+	}))
+	g.Expect(progress.done()).To(BeFalse())
+
+	// Nothing changed, nothing is reported.
+	g.Expect(progress.update(hostedCluster, nodePools, start.Add(2*time.Minute))).To(BeEmpty())
+
+	hostedCluster.Status.Conditions[1] = metav1.Condition{Type: string(hyperv1.EtcdAvailable), Status: metav1.ConditionTrue, Reason: hyperv1.EtcdQuorumAvailableReason}
+	hostedCluster.Status.Conditions = append(hostedCluster.Status.Conditions, metav1.Condition{Type: string(hyperv1.KubeAPIServerAvailable), Status: metav1.ConditionTrue, Reason: hyperv1.AsExpectedReason})
+	nodePools[0].Status.Replicas = 2
+	hostedCluster.Status.Version = &hyperv1.ClusterVersionStatus{
+		History: []configv1.UpdateHistory{{State: configv1.CompletedUpdate, CompletionTime: &metav1.Time{Time: start}}},
+	}
+	messages = progress.update(hostedCluster, nodePools, start.Add(10*time.Minute))
+	g.Expect(messages).To(Equal([]string{
+		"Condition EtcdAvailable is True (QuorumAvailable)",
+		"Nodes ready: 2/2",
+		"Etcd available after 10m0s",
+		"Kube API server available after 10m0s",
+		"Nodes joined after 10m0s",
+		"Cluster operators done after 10m0s",
+	}))
+	g.Expect(progress.done()).To(BeTrue())
+	g.Expect(progress.summary()).To(ContainElement("Infrastructure ready: done after 1m0s"))
+}
+
+func TestWaitForClusterRollout(t *testing.T) {
+	hostedCluster := &hyperv1.HostedCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "example"},
+		Status: hyperv1.HostedClusterStatus{
+			Conditions: []metav1.Condition{
+				{Type: string(hyperv1.InfrastructureReady), Status: metav1.ConditionTrue},
+				{Type: string(hyperv1.EtcdAvailable), Status: metav1.ConditionTrue},
+				{Type: string(hyperv1.KubeAPIServerAvailable), Status: metav1.ConditionTrue},
+			},
+		},
+	}
+	nodePool := &hyperv1.NodePool{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "example"},
+		Spec:       hyperv1.NodePoolSpec{ClusterName: "example", Replicas: ptr.To[int32](1)},
+		Status:     hyperv1.NodePoolStatus{Replicas: 1},
+	}
+
+	t.Run("When the cluster has rolled out it should return", func(t *testing.T) {
+		g := NewWithT(t)
+		rolledOut := hostedCluster.DeepCopy()
+		rolledOut.Status.Version = &hyperv1.ClusterVersionStatus{
+			History: []configv1.UpdateHistory{{State: configv1.CompletedUpdate, CompletionTime: &metav1.Time{Time: time.Now()}}},
+		}
+		c := fake.NewClientBuilder().WithScheme(api.Scheme).WithObjects(rolledOut, nodePool).Build()
+
+		err := waitForClusterRollout(context.Background(), logr.Discard(), c, &hyperv1.HostedCluster{ObjectMeta: rolledOut.ObjectMeta})
+		g.Expect(err).ToNot(HaveOccurred())
+	})
+
+	t.Run("When the timeout expires before the cluster has rolled out it should fail with the pending milestones", func(t *testing.T) {
+		g := NewWithT(t)
+		c := fake.NewClientBuilder().WithScheme(api.Scheme).WithObjects(hostedCluster, nodePool).Build()
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		err := waitForClusterRollout(ctx, logr.Discard(), c, &hyperv1.HostedCluster{ObjectMeta: hostedCluster.ObjectMeta})
+		g.Expect(err).To(MatchError(ContainSubstring("timed out waiting for cluster rollout")))
+		g.Expect(err).To(MatchError(ContainSubstring("pending: Cluster operators done")))
+	})
+}
@@ -2,11 +2,19 @@ package none
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/openshift/hypershift/cmd/cluster/core"
+	"github.com/openshift/hypershift/cmd/util"
 	apifixtures "github.com/openshift/hypershift/examples/fixtures"
+	"github.com/openshift/hypershift/support/oidc"
 )
 
 func NewCreateCommand(opts *core.CreateOptions) *cobra.Command {
@@ -22,6 +30,7 @@ func NewCreateCommand(opts *core.CreateOptions) *cobra.Command {
 
 	cmd.Flags().StringVar(&opts.NonePlatform.APIServerAddress, "external-api-server-address", opts.NonePlatform.APIServerAddress, "The external API Server Address when using platform none")
 	cmd.Flags().BoolVar(&opts.NonePlatform.ExposeThroughLoadBalancer, "expose-through-load-balancer", opts.NonePlatform.ExposeThroughLoadBalancer, "If the services should be exposed through LoadBalancer. If not set, nodeports will be used instead")
+	cmd.Flags().StringVar(&opts.NonePlatform.IssuerURL, "oidc-issuer-url", opts.NonePlatform.IssuerURL, "The OIDC provider issuer URL. Defaults to the URL the OIDC discovery documents are served from by the HyperShift operator when it is installed with the ManagementCluster OIDC storage provider")
 
 	cmd.MarkPersistentFlagRequired("pull-secret")
 
@@ -67,5 +76,35 @@ func applyPlatformSpecificsValues(ctx context.Context, exampleOptions *apifixtur
 	exampleOptions.None = &apifixtures.ExampleNoneOptions{
 		APIServerAddress: opts.NonePlatform.APIServerAddress,
 	}
+
+	exampleOptions.IssuerURL = opts.NonePlatform.IssuerURL
+	if exampleOptions.IssuerURL == "" && !opts.Render {
+		client, err := util.GetClient()
+		if err != nil {
+			return err
+		}
+		if exampleOptions.IssuerURL, err = discoverIssuerURL(ctx, client, infraID); err != nil {
+			return err
+		}
+	}
 	return nil
 }
+
+// discoverIssuerURL returns the issuer URL of the cluster with the given infra ID when the HyperShift operator serves
+// the OIDC discovery documents from the management cluster, as published by the install in 'kube-public', and an
+// empty issuer URL otherwise.
+func discoverIssuerURL(ctx context.Context, client crclient.Client, infraID string) (string, error) {
+	storageConfig := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kube-public", Name: "oidc-storage-provider-config"},
+	}
+	if err := client.Get(ctx, crclient.ObjectKeyFromObject(storageConfig), storageConfig); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to discover OIDC storage configuration: failed to get the %s/%s configmap: %w", storageConfig.Namespace, storageConfig.Name, err)
+	}
+	if storageConfig.Data["provider"] != oidc.DocumentStoreManagementCluster || storageConfig.Data["issuerURLBase"] == "" {
+		return "", nil
+	}
+	return strings.TrimSuffix(storageConfig.Data["issuerURLBase"], "/") + "/" + infraID, nil
+}
@@ -0,0 +1,52 @@
+package none
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/hypershift/support/api"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestDiscoverIssuerURL(t *testing.T) {
+	testCases := []struct {
+		name     string
+		config   map[string]string
+		expected string
+	}{
+		{
+			name:     "When the operator serves the OIDC documents from the management cluster it should derive the issuer URL",
+			config:   map[string]string{"provider": "ManagementCluster", "issuerURLBase": "https://oidc.apps.example.com/"},
+			expected: "https://oidc.apps.example.com/infra-id",
+		},
+		{
+			name:   "When the OIDC documents are stored elsewhere it should not set an issuer URL",
+			config: map[string]string{"provider": "GCS", "issuerURLBase": "https://storage.googleapis.com/bucket"},
+		},
+		{
+			name: "When the OIDC storage configuration isn't published it should not set an issuer URL",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			var objects []crclient.Object
+			if tc.config != nil {
+				objects = append(objects, &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "kube-public", Name: "oidc-storage-provider-config"},
+					Data:       tc.config,
+				})
+			}
+			c := fake.NewClientBuilder().WithScheme(api.Scheme).WithObjects(objects...).Build()
+
+			issuerURL, err := discoverIssuerURL(context.Background(), c, "infra-id")
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(issuerURL).To(Equal(tc.expected))
+		})
+	}
+}
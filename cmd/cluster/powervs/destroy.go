@@ -114,7 +114,7 @@ func DestroyCluster(ctx context.Context, o *core.DestroyOptions) error {
 		return fmt.Errorf("required inputs are missing: %w", err)
 	}
 
-	return core.DestroyCluster(ctx, hostedCluster, o, destroyPlatformSpecifics)
+	return core.DestroyCluster(ctx, hostedCluster, o, destroyPlatformSpecifics, nil)
 }
 
 func destroyPlatformSpecifics(ctx context.Context, o *core.DestroyOptions) error {
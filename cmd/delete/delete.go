@@ -0,0 +1,18 @@
+package delete
+
+import (
+	"github.com/openshift/hypershift/cmd/nodepool"
+	"github.com/spf13/cobra"
+)
+
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "delete",
+		Short:        "Commands for deleting HyperShift resources",
+		SilenceUsage: true,
+	}
+
+	cmd.AddCommand(nodepool.NewDeleteCommand())
+
+	return cmd
+}
@@ -0,0 +1,18 @@
+package diagnose
+
+import (
+	"github.com/openshift/hypershift/cmd/nodepool"
+	"github.com/spf13/cobra"
+)
+
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "diagnose",
+		Short:        "Commands for diagnosing HyperShift resources",
+		SilenceUsage: true,
+	}
+
+	cmd.AddCommand(nodepool.NewDiagnoseCommand())
+
+	return cmd
+}
@@ -13,6 +13,7 @@ func NewCommand() *cobra.Command {
 	}
 
 	cmd.AddCommand(core.NewDumpCommand())
+	cmd.AddCommand(core.NewDumpProfilesCommand())
 
 	return cmd
 }
@@ -0,0 +1,18 @@
+package export
+
+import (
+	"github.com/openshift/hypershift/cmd/cluster/core"
+	"github.com/spf13/cobra"
+)
+
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "export",
+		Short:        "Commands for exporting resources so they can be re-created",
+		SilenceUsage: true,
+	}
+
+	cmd.AddCommand(core.NewExportCommand())
+
+	return cmd
+}
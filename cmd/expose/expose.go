@@ -0,0 +1,18 @@
+package expose
+
+import (
+	"github.com/openshift/hypershift/cmd/cluster/core"
+	"github.com/spf13/cobra"
+)
+
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "expose",
+		Short:        "Commands for forwarding hosted control plane endpoints to local ports for debugging",
+		SilenceUsage: true,
+	}
+
+	cmd.AddCommand(core.NewExposeCommand())
+
+	return cmd
+}
@@ -17,8 +17,12 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/spf13/cobra"
 
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
 	awsutil "github.com/openshift/hypershift/cmd/infra/aws/util"
+	"github.com/openshift/hypershift/cmd/infra/cloudapi"
 	"github.com/openshift/hypershift/cmd/log"
+	supportawsutil "github.com/openshift/hypershift/support/awsutil"
+	"github.com/openshift/hypershift/support/util"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/util/retry"
 )
@@ -38,6 +42,19 @@ type CreateInfraOptions struct {
 	EnableProxy        bool
 	SSHKeyFile         string
 	SingleNATGateway   bool
+	// ClusterDNSDomain is the private DNS domain of the control plane endpoints of the cluster.
+	ClusterDNSDomain string
+	// DNSPublishing is how the DNS records of the cluster are published. The public zone isn't looked up when it is
+	// Internal.
+	DNSPublishing string
+	// EndpointOverrides overrides the endpoints of the AWS services, keyed by the endpoint ID of the service.
+	EndpointOverrides map[string]string
+	// CloudAPI configures the logging, rate limiting and audit trail of the AWS API calls.
+	CloudAPI cloudapi.Options
+	// Owner and Lifetime are the owner and lifetime of the cluster the resources are tagged with, along with its
+	// infra ID and name.
+	Owner    string
+	Lifetime time.Duration
 
 	additionalEC2Tags []*ec2.Tag
 }
@@ -68,8 +85,7 @@ const (
 	basePrivateSubnetCIDR = "10.0.128.0/20"
 	basePublicSubnetCIDR  = "10.0.0.0/20"
 
-	clusterTagValue         = "owned"
-	hypershiftLocalZoneName = "hypershift.local"
+	clusterTagValue = "owned"
 )
 
 func NewCreateCommand() *cobra.Command {
@@ -80,8 +96,10 @@ func NewCreateCommand() *cobra.Command {
 	}
 
 	opts := CreateInfraOptions{
-		Region: "us-east-1",
-		Name:   "example",
+		Region:           "us-east-1",
+		Name:             "example",
+		ClusterDNSDomain: util.DefaultClusterDNSDomain,
+		DNSPublishing:    string(hyperv1.ExternalDNSPublishing),
 	}
 
 	cmd.Flags().StringVar(&opts.InfraID, "infra-id", opts.InfraID, "Cluster ID with which to tag AWS resources (required)")
@@ -95,6 +113,12 @@ func NewCreateCommand() *cobra.Command {
 	cmd.Flags().StringSliceVar(&opts.Zones, "zones", opts.Zones, "The availability zones in which NodePool can be created")
 	cmd.Flags().BoolVar(&opts.EnableProxy, "enable-proxy", opts.EnableProxy, "If a proxy should be set up, rather than allowing direct internet access from the nodes")
 	cmd.Flags().BoolVar(&opts.SingleNATGateway, "single-nat-gateway", opts.SingleNATGateway, "If enabled, only a single NAT gateway is created, even if multiple zones are specified")
+	cmd.Flags().StringVar(&opts.ClusterDNSDomain, "cluster-dns-domain", opts.ClusterDNSDomain, "The private DNS domain of the control plane endpoints of the cluster, a private zone <name>.<cluster-dns-domain> is created for it")
+	cmd.Flags().StringVar(&opts.DNSPublishing, "dns-publishing", opts.DNSPublishing, "How the DNS records of the cluster are published (External, Internal). The public zone of the base domain isn't looked up when Internal")
+	cmd.Flags().StringToStringVar(&opts.EndpointOverrides, "aws-endpoint-overrides", opts.EndpointOverrides, "A comma separated list of service=url pairs overriding the endpoints of the AWS services, e.g. ec2=https://ec2.example.com,sts=https://sts.example.com")
+	cmd.Flags().StringVar(&opts.Owner, "owner", opts.Owner, "The owner of the cluster, e.g. a team or an email address, the AWS resources are tagged with")
+	cmd.Flags().DurationVar(&opts.Lifetime, "lifetime", opts.Lifetime, "The lifetime of the cluster, e.g. 8h. The AWS resources are tagged with the time it expires")
+	opts.CloudAPI.BindFlags(cmd.Flags())
 
 	cmd.MarkFlagRequired("infra-id")
 	cmd.MarkFlagRequired("aws-creds")
@@ -102,7 +126,15 @@ func NewCreateCommand() *cobra.Command {
 
 	l := log.Log
 	cmd.RunE = func(cmd *cobra.Command, args []string) error {
-		if err := opts.Run(cmd.Context(), l); err != nil {
+		if err := supportawsutil.ValidateEndpointOverrides(opts.EndpointOverrides); err != nil {
+			return err
+		}
+		ctx, done, err := opts.CloudAPI.Start(cmd.Context(), l)
+		if err != nil {
+			return err
+		}
+		defer done()
+		if err := opts.Run(ctx, l); err != nil {
 			l.Error(err, "Failed to create infrastructure")
 			return err
 		}
@@ -141,7 +173,8 @@ func (o *CreateInfraOptions) Run(ctx context.Context, l logr.Logger) error {
 func (o *CreateInfraOptions) CreateInfra(ctx context.Context, l logr.Logger) (*CreateInfraOutput, error) {
 	l.Info("Creating infrastructure", "id", o.InfraID)
 
-	awsSession := awsutil.NewSession("cli-create-infra", o.AWSCredentialsFile, o.AWSKey, o.AWSSecretKey, o.Region)
+	awsSession := awsutil.NewSessionWithEndpointOverrides("cli-create-infra", o.AWSCredentialsFile, o.AWSKey, o.AWSSecretKey, o.Region, o.EndpointOverrides)
+	cloudapi.FromContext(ctx).InstrumentAWSSession(awsSession)
 	ec2Client := ec2.New(awsSession, awsutil.NewConfig())
 	route53Client := route53.New(awsSession, awsutil.NewAWSRoute53Config())
 
@@ -229,16 +262,18 @@ func (o *CreateInfraOptions) CreateInfra(ctx context.Context, l logr.Logger) (*C
 	if err != nil {
 		return nil, err
 	}
-	result.PublicZoneID, err = o.LookupPublicZone(ctx, route53Client)
-	if err != nil {
-		return nil, err
+	if o.DNSPublishing != string(hyperv1.InternalDNSPublishing) {
+		result.PublicZoneID, err = o.LookupPublicZone(ctx, route53Client)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	result.PrivateZoneID, err = o.CreatePrivateZone(ctx, route53Client, ZoneName(o.Name, o.BaseDomainPrefix, o.BaseDomain), result.VPCID)
 	if err != nil {
 		return nil, err
 	}
-	result.LocalZoneID, err = o.CreatePrivateZone(ctx, route53Client, fmt.Sprintf("%s.%s", o.Name, hypershiftLocalZoneName), result.VPCID)
+	result.LocalZoneID, err = o.CreatePrivateZone(ctx, route53Client, util.InternalDNSDomain(o.Name, hyperv1.DNSSpec{ClusterDNSDomain: o.ClusterDNSDomain}), result.VPCID)
 	if err != nil {
 		return nil, err
 	}
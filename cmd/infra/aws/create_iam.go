@@ -11,6 +11,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/spf13/cobra"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 
@@ -18,8 +19,10 @@ import (
 
 	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
 	awsutil "github.com/openshift/hypershift/cmd/infra/aws/util"
+	"github.com/openshift/hypershift/cmd/infra/cloudapi"
 	"github.com/openshift/hypershift/cmd/log"
 	"github.com/openshift/hypershift/cmd/util"
+	supportawsutil "github.com/openshift/hypershift/support/awsutil"
 )
 
 type CreateIAMOptions struct {
@@ -29,14 +32,21 @@ type CreateIAMOptions struct {
 	AWSSecretKey                    string
 	OIDCStorageProviderS3BucketName string
 	OIDCStorageProviderS3Region     string
-	PublicZoneID                    string
-	PrivateZoneID                   string
-	LocalZoneID                     string
-	InfraID                         string
-	IssuerURL                       string
-	OutputFile                      string
-	KMSKeyARN                       string
-	AdditionalTags                  []string
+	// OIDCStorageProviderIssuerURLBase is the public URL the OIDC discovery documents are served from when they are
+	// stored in Azure Blob or GCS storage rather than an S3 bucket.
+	OIDCStorageProviderIssuerURLBase string
+	PublicZoneID                     string
+	PrivateZoneID                    string
+	LocalZoneID                      string
+	InfraID                          string
+	IssuerURL                        string
+	OutputFile                       string
+	KMSKeyARN                        string
+	AdditionalTags                   []string
+	// EndpointOverrides overrides the endpoints of the AWS services, keyed by the endpoint ID of the service.
+	EndpointOverrides map[string]string
+	// CloudAPI configures the logging, rate limiting and audit trail of the AWS API calls.
+	CloudAPI cloudapi.Options
 
 	additionalIAMTags []*iam.Tag
 }
@@ -68,6 +78,7 @@ func NewCreateIAMCommand() *cobra.Command {
 	cmd.Flags().StringVar(&opts.InfraID, "infra-id", opts.InfraID, "Infrastructure ID to use for AWS resources.")
 	cmd.Flags().StringVar(&opts.OIDCStorageProviderS3BucketName, "oidc-storage-provider-s3-bucket-name", "", "The name of the bucket in which the OIDC discovery document is stored")
 	cmd.Flags().StringVar(&opts.OIDCStorageProviderS3Region, "oidc-storage-provider-s3-region", "", "The region of the bucket in which the OIDC discovery document is stored")
+	cmd.Flags().StringVar(&opts.OIDCStorageProviderIssuerURLBase, "oidc-storage-provider-issuer-url-base", "", "The public URL the OIDC discovery document is served from when it is stored in Azure Blob or GCS storage")
 	cmd.Flags().StringVar(&opts.IssuerURL, "oidc-issuer-url", "", "The OIDC provider issuer URL")
 	cmd.Flags().StringVar(&opts.Region, "region", opts.Region, "Region where cluster infra should be created")
 	cmd.Flags().StringVar(&opts.OutputFile, "output-file", opts.OutputFile, "Path to file that will contain output information from infra resources (optional)")
@@ -76,6 +87,8 @@ func NewCreateIAMCommand() *cobra.Command {
 	cmd.Flags().StringVar(&opts.LocalZoneID, "local-zone-id", opts.LocalZoneID, "The id of the clusters local route53 zone")
 	cmd.Flags().StringVar(&opts.KMSKeyARN, "kms-key-arn", opts.KMSKeyARN, "The ARN of the KMS key to use for Etcd encryption. If not supplied, etcd encryption will default to using a generated AESCBC key.")
 	cmd.Flags().StringSliceVar(&opts.AdditionalTags, "additional-tags", opts.AdditionalTags, "Additional tags to set on AWS resources")
+	cmd.Flags().StringToStringVar(&opts.EndpointOverrides, "aws-endpoint-overrides", opts.EndpointOverrides, "A comma separated list of service=url pairs overriding the endpoints of the AWS services, e.g. ec2=https://ec2.example.com,sts=https://sts.example.com")
+	opts.CloudAPI.BindFlags(cmd.Flags())
 
 	cmd.MarkFlagRequired("aws-creds")
 	cmd.MarkFlagRequired("infra-id")
@@ -86,12 +99,20 @@ func NewCreateIAMCommand() *cobra.Command {
 	cmd.MarkFlagRequired("oidc-bucket-region")
 
 	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if err := supportawsutil.ValidateEndpointOverrides(opts.EndpointOverrides); err != nil {
+			return err
+		}
+		ctx, done, err := opts.CloudAPI.Start(cmd.Context(), log.Log)
+		if err != nil {
+			return err
+		}
+		defer done()
 		client, err := util.GetClient()
 		if err != nil {
 			log.Log.Error(err, "failed to create client")
 			return err
 		}
-		if err := opts.Run(cmd.Context(), client); err != nil {
+		if err := opts.Run(ctx, client); err != nil {
 			log.Log.Error(err, "Failed to create infrastructure")
 			return err
 		}
@@ -132,30 +153,27 @@ func (o *CreateIAMOptions) CreateIAM(ctx context.Context, client crclient.Client
 	if err = o.ParseAdditionalTags(); err != nil {
 		return nil, err
 	}
-	if o.OIDCStorageProviderS3BucketName == "" || o.OIDCStorageProviderS3Region == "" {
-		cm := &corev1.ConfigMap{
-			ObjectMeta: metav1.ObjectMeta{Namespace: "kube-public", Name: "oidc-storage-provider-s3-config"},
-		}
-		if err := client.Get(ctx, crclient.ObjectKeyFromObject(cm), cm); err != nil {
-			return nil, fmt.Errorf("failed to discover OIDC bucket configuration: failed to get the %s/%s configmap: %w", cm.Namespace, cm.Name, err)
+	if o.IssuerURL == "" && o.OIDCStorageProviderIssuerURLBase == "" && (o.OIDCStorageProviderS3BucketName == "" || o.OIDCStorageProviderS3Region == "") {
+		if err := o.discoverOIDCStorageProvider(ctx, client); err != nil {
+			return nil, err
 		}
-		// Set both, doesn't make sense to only get one from the configmap
-		o.OIDCStorageProviderS3BucketName = cm.Data["name"]
-		o.OIDCStorageProviderS3Region = cm.Data["region"]
 	}
 
 	var errs []error
-	if o.OIDCStorageProviderS3BucketName == "" {
-		errs = append(errs, errors.New("mandatory --oidc-storage-provider-s3-bucket-name could not be discovered from the cluster's ConfigMap in 'kube-public' and wasn't excplicitly passed either"))
-	}
-	if o.OIDCStorageProviderS3Region == "" {
-		errs = append(errs, errors.New("mandatory --oidc-storage-provider-s3-region could not be discovered from cluster's  ConfigMap in 'kube-public' and wasn't explicitly passed either"))
+	if o.IssuerURL == "" && o.OIDCStorageProviderIssuerURLBase == "" {
+		if o.OIDCStorageProviderS3BucketName == "" {
+			errs = append(errs, errors.New("mandatory --oidc-storage-provider-s3-bucket-name could not be discovered from the cluster's ConfigMap in 'kube-public' and wasn't excplicitly passed either"))
+		}
+		if o.OIDCStorageProviderS3Region == "" {
+			errs = append(errs, errors.New("mandatory --oidc-storage-provider-s3-region could not be discovered from cluster's  ConfigMap in 'kube-public' and wasn't explicitly passed either"))
+		}
 	}
 	if err := utilerrors.NewAggregate(errs); err != nil {
 		return nil, err
 	}
 
-	awsSession := awsutil.NewSession("cli-create-iam", o.AWSCredentialsFile, o.AWSKey, o.AWSSecretKey, o.Region)
+	awsSession := awsutil.NewSessionWithEndpointOverrides("cli-create-iam", o.AWSCredentialsFile, o.AWSKey, o.AWSSecretKey, o.Region, o.EndpointOverrides)
+	cloudapi.FromContext(ctx).InstrumentAWSSession(awsSession)
 	awsConfig := awsutil.NewConfig()
 	iamClient := iam.New(awsSession, awsConfig)
 
@@ -175,6 +193,33 @@ func (o *CreateIAMOptions) CreateIAM(ctx context.Context, client crclient.Client
 	return results, nil
 }
 
+// discoverOIDCStorageProvider discovers where the OIDC discovery documents are hosted from the ConfigMaps the
+// HyperShift install writes to 'kube-public': the S3 bucket, or the public URL of the Azure Blob or GCS storage.
+func (o *CreateIAMOptions) discoverOIDCStorageProvider(ctx context.Context, client crclient.Client) error {
+	s3Config := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kube-public", Name: "oidc-storage-provider-s3-config"},
+	}
+	err := client.Get(ctx, crclient.ObjectKeyFromObject(s3Config), s3Config)
+	if err == nil {
+		// Set both, doesn't make sense to only get one from the configmap
+		o.OIDCStorageProviderS3BucketName = s3Config.Data["name"]
+		o.OIDCStorageProviderS3Region = s3Config.Data["region"]
+		return nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to discover OIDC bucket configuration: failed to get the %s/%s configmap: %w", s3Config.Namespace, s3Config.Name, err)
+	}
+
+	storageConfig := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kube-public", Name: "oidc-storage-provider-config"},
+	}
+	if err := client.Get(ctx, crclient.ObjectKeyFromObject(storageConfig), storageConfig); err != nil {
+		return fmt.Errorf("failed to discover OIDC storage configuration: failed to get the %s/%s or %s/%s configmaps: %w", s3Config.Namespace, s3Config.Name, storageConfig.Namespace, storageConfig.Name, err)
+	}
+	o.OIDCStorageProviderIssuerURLBase = storageConfig.Data["issuerURLBase"]
+	return nil
+}
+
 func (o *CreateIAMOptions) ParseAdditionalTags() error {
 	parsed, err := util.ParseAWSTags(o.AdditionalTags)
 	if err != nil {
@@ -193,5 +238,5 @@ func oidcDiscoveryURL(bucketName, region, infraID string) string {
 	if bucketName == "" || region == "" || infraID == "" {
 		panic(fmt.Sprintf("bucket: %q, region: %q, infraID: %q", bucketName, region, infraID))
 	}
-	return fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", bucketName, region, infraID)
+	return fmt.Sprintf("https://%s.s3.%s.%s/%s", bucketName, region, supportawsutil.DNSSuffix(region), infraID)
 }
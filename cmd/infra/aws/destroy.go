@@ -26,7 +26,9 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 
 	awsutil "github.com/openshift/hypershift/cmd/infra/aws/util"
+	"github.com/openshift/hypershift/cmd/infra/cloudapi"
 	"github.com/openshift/hypershift/cmd/log"
+	supportawsutil "github.com/openshift/hypershift/support/awsutil"
 )
 
 type DestroyInfraOptions struct {
@@ -40,6 +42,10 @@ type DestroyInfraOptions struct {
 	BaseDomainPrefix    string
 	AwsInfraGracePeriod time.Duration
 	Log                 logr.Logger
+	// EndpointOverrides overrides the endpoints of the AWS services, keyed by the endpoint ID of the service.
+	EndpointOverrides map[string]string
+	// CloudAPI configures the logging, rate limiting and audit trail of the AWS API calls.
+	CloudAPI cloudapi.Options
 }
 
 func NewDestroyCommand() *cobra.Command {
@@ -62,13 +68,23 @@ func NewDestroyCommand() *cobra.Command {
 	cmd.Flags().StringVar(&opts.BaseDomain, "base-domain", opts.BaseDomain, "The ingress base domain for the cluster")
 	cmd.Flags().StringVar(&opts.BaseDomainPrefix, "base-domain-prefix", opts.BaseDomainPrefix, "The ingress base domain prefix for the cluster, defaults to cluster name. se 'none' for an empty prefix")
 	cmd.Flags().DurationVar(&opts.AwsInfraGracePeriod, "aws-infra-grace-period", opts.AwsInfraGracePeriod, "Timeout for destroying infrastructure in minutes")
+	cmd.Flags().StringToStringVar(&opts.EndpointOverrides, "aws-endpoint-overrides", opts.EndpointOverrides, "A comma separated list of service=url pairs overriding the endpoints of the AWS services, e.g. ec2=https://ec2.example.com,sts=https://sts.example.com")
+	opts.CloudAPI.BindFlags(cmd.Flags())
 
 	cmd.MarkFlagRequired("infra-id")
 	cmd.MarkFlagRequired("aws-creds")
 	cmd.MarkFlagRequired("base-domain")
 
 	cmd.RunE = func(cmd *cobra.Command, args []string) error {
-		if err := opts.Run(cmd.Context()); err != nil {
+		if err := supportawsutil.ValidateEndpointOverrides(opts.EndpointOverrides); err != nil {
+			return err
+		}
+		ctx, done, err := opts.CloudAPI.Start(cmd.Context(), opts.Log)
+		if err != nil {
+			return err
+		}
+		defer done()
+		if err := opts.Run(ctx); err != nil {
 			opts.Log.Error(err, "Failed to destroy infrastructure")
 			return err
 		}
@@ -106,7 +122,8 @@ func (o *DestroyInfraOptions) Run(ctx context.Context) error {
 }
 
 func (o *DestroyInfraOptions) DestroyInfra(ctx context.Context) error {
-	awsSession := awsutil.NewSession("cli-destroy-infra", o.AWSCredentialsFile, o.AWSKey, o.AWSSecretKey, o.Region)
+	awsSession := awsutil.NewSessionWithEndpointOverrides("cli-destroy-infra", o.AWSCredentialsFile, o.AWSKey, o.AWSSecretKey, o.Region, o.EndpointOverrides)
+	cloudapi.FromContext(ctx).InstrumentAWSSession(awsSession)
 	awsConfig := awsutil.NewConfig()
 	ec2Client := ec2.New(awsSession, awsConfig)
 	elbClient := elb.New(awsSession, awsConfig)
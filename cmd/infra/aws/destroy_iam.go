@@ -15,7 +15,9 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 
 	awsutil "github.com/openshift/hypershift/cmd/infra/aws/util"
+	"github.com/openshift/hypershift/cmd/infra/cloudapi"
 	"github.com/openshift/hypershift/cmd/log"
+	supportawsutil "github.com/openshift/hypershift/support/awsutil"
 )
 
 type DestroyIAMOptions struct {
@@ -25,6 +27,10 @@ type DestroyIAMOptions struct {
 	AWSSecretKey       string
 	InfraID            string
 	Log                logr.Logger
+	// EndpointOverrides overrides the endpoints of the AWS services, keyed by the endpoint ID of the service.
+	EndpointOverrides map[string]string
+	// CloudAPI configures the logging, rate limiting and audit trail of the AWS API calls.
+	CloudAPI cloudapi.Options
 }
 
 func NewDestroyIAMCommand() *cobra.Command {
@@ -44,12 +50,22 @@ func NewDestroyIAMCommand() *cobra.Command {
 	cmd.Flags().StringVar(&opts.AWSCredentialsFile, "aws-creds", opts.AWSCredentialsFile, "Path to an AWS credentials file (required)")
 	cmd.Flags().StringVar(&opts.InfraID, "infra-id", opts.InfraID, "Infrastructure ID to use for AWS resources.")
 	cmd.Flags().StringVar(&opts.Region, "region", opts.Region, "Region where cluster infra lives")
+	cmd.Flags().StringToStringVar(&opts.EndpointOverrides, "aws-endpoint-overrides", opts.EndpointOverrides, "A comma separated list of service=url pairs overriding the endpoints of the AWS services, e.g. ec2=https://ec2.example.com,sts=https://sts.example.com")
+	opts.CloudAPI.BindFlags(cmd.Flags())
 
 	cmd.MarkFlagRequired("aws-creds")
 	cmd.MarkFlagRequired("infra-id")
 
 	cmd.RunE = func(cmd *cobra.Command, args []string) error {
-		if err := opts.DestroyIAM(cmd.Context()); err != nil {
+		if err := supportawsutil.ValidateEndpointOverrides(opts.EndpointOverrides); err != nil {
+			return err
+		}
+		ctx, done, err := opts.CloudAPI.Start(cmd.Context(), opts.Log)
+		if err != nil {
+			return err
+		}
+		defer done()
+		if err := opts.DestroyIAM(ctx); err != nil {
 			return err
 		}
 		opts.Log.Info("Successfully destroyed IAM infra")
@@ -74,7 +90,8 @@ func (o *DestroyIAMOptions) Run(ctx context.Context) error {
 }
 
 func (o *DestroyIAMOptions) DestroyIAM(ctx context.Context) error {
-	awsSession := awsutil.NewSession("cli-destroy-iam", o.AWSCredentialsFile, o.AWSKey, o.AWSSecretKey, o.Region)
+	awsSession := awsutil.NewSessionWithEndpointOverrides("cli-destroy-iam", o.AWSCredentialsFile, o.AWSKey, o.AWSSecretKey, o.Region, o.EndpointOverrides)
+	cloudapi.FromContext(ctx).InstrumentAWSSession(awsSession)
 	awsConfig := awsutil.NewConfig()
 	iamClient := iam.New(awsSession, awsConfig)
 
@@ -90,6 +107,18 @@ func (o *DestroyIAMOptions) DestroyIAM(ctx context.Context) error {
 	return nil
 }
 
+// oidcRoleNames are the names, without the infra ID prefix, of the OIDC roles of a cluster.
+var oidcRoleNames = []string{
+	"openshift-ingress",
+	"openshift-image-registry",
+	"aws-ebs-csi-driver-controller",
+	"cloud-controller",
+	"node-pool",
+	"control-plane-operator",
+	"cloud-network-config-controller",
+	"kms-provider",
+}
+
 func (o *DestroyIAMOptions) DestroyOIDCResources(ctx context.Context, iamClient iamiface.IAMAPI) error {
 	oidcProviderList, err := iamClient.ListOpenIDConnectProviders(&iam.ListOpenIDConnectProvidersInput{})
 	if err != nil {
@@ -117,29 +146,10 @@ func (o *DestroyIAMOptions) DestroyOIDCResources(ctx context.Context, iamClient
 			break
 		}
 	}
-	if err = o.DestroyOIDCRole(iamClient, "openshift-ingress"); err != nil {
-		return err
-	}
-	if err = o.DestroyOIDCRole(iamClient, "openshift-image-registry"); err != nil {
-		return err
-	}
-	if err = o.DestroyOIDCRole(iamClient, "aws-ebs-csi-driver-controller"); err != nil {
-		return err
-	}
-	if err = o.DestroyOIDCRole(iamClient, "cloud-controller"); err != nil {
-		return err
-	}
-	if err = o.DestroyOIDCRole(iamClient, "node-pool"); err != nil {
-		return err
-	}
-	if err = o.DestroyOIDCRole(iamClient, "control-plane-operator"); err != nil {
-		return err
-	}
-	if err := o.DestroyOIDCRole(iamClient, "cloud-network-config-controller"); err != nil {
-		return err
-	}
-	if err := o.DestroyOIDCRole(iamClient, "kms-provider"); err != nil {
-		return err
+	for _, name := range oidcRoleNames {
+		if err := o.DestroyOIDCRole(iamClient, name); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -11,6 +11,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/go-logr/logr"
 	"github.com/openshift/hypershift/cmd/util"
+	"github.com/openshift/hypershift/support/clusteridentity"
 
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/util/retry"
@@ -614,6 +615,15 @@ func (o *CreateInfraOptions) parseAdditionalTags() error {
 			Value: aws.String(v),
 		})
 	}
+	for _, tag := range clusteridentity.ForInfrastructure(o.InfraID, o.Name, o.Owner, o.Lifetime).Tags(nil) {
+		if _, isSet := parsed[tag.Key]; isSet {
+			continue
+		}
+		o.additionalEC2Tags = append(o.additionalEC2Tags, &ec2.Tag{
+			Key:   aws.String(tag.Key),
+			Value: aws.String(tag.Value),
+		})
+	}
 	return nil
 }
 
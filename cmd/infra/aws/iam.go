@@ -11,6 +11,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/aws/aws-sdk-go/service/iam/iamiface"
 	"github.com/openshift/hypershift/cmd/log"
+	supportawsutil "github.com/openshift/hypershift/support/awsutil"
 )
 
 const (
@@ -295,7 +296,7 @@ const (
 }`
 )
 
-func ingressPermPolicy(publicZone, privateZone string) string {
+func ingressPermPolicy(partition, publicZone, privateZone string) string {
 	publicZone = ensureHostedZonePrefix(publicZone)
 	privateZone = ensureHostedZonePrefix(privateZone)
 	return fmt.Sprintf(`{
@@ -316,15 +317,15 @@ func ingressPermPolicy(publicZone, privateZone string) string {
 				"route53:ChangeResourceRecordSets"
 			],
 			"Resource": [
-				"arn:aws:route53:::%s",
-				"arn:aws:route53:::%s"
+				"arn:%[1]s:route53:::%[2]s",
+				"arn:%[1]s:route53:::%[3]s"
 			]
 		}
 	]
-}`, publicZone, privateZone)
+}`, partition, publicZone, privateZone)
 }
 
-func controlPlaneOperatorPolicy(hostedZone string) string {
+func controlPlaneOperatorPolicy(partition, hostedZone string) string {
 	hostedZone = ensureHostedZonePrefix(hostedZone)
 	return fmt.Sprintf(`{
 	"Version": "2012-10-17",
@@ -345,7 +346,10 @@ func controlPlaneOperatorPolicy(hostedZone string) string {
 				"ec2:RevokeSecurityGroupIngress",
 				"ec2:RevokeSecurityGroupEgress",
 				"ec2:DescribeSecurityGroups",
-				"ec2:DescribeVpcs"
+				"ec2:DescribeVpcs",
+				"elasticloadbalancing:AddTags",
+				"tag:GetResources",
+				"tag:TagResources"
 			],
 			"Resource": "*"
 		},
@@ -355,10 +359,10 @@ func controlPlaneOperatorPolicy(hostedZone string) string {
 				"route53:ChangeResourceRecordSets",
 				"route53:ListResourceRecordSets"
 			],
-			"Resource": "arn:aws:route53:::%s"
+			"Resource": "arn:%s:route53:::%s"
 		}
 	]
-}`, hostedZone)
+}`, partition, hostedZone)
 }
 
 func kmsProviderPolicy(kmsKeyARN string) string {
@@ -397,7 +401,11 @@ func (o *CreateIAMOptions) CreateOIDCResources(iamClient iamiface.IAMAPI) (*Crea
 	var providerName string
 	var providerARN string
 	if o.IssuerURL == "" {
-		o.IssuerURL = oidcDiscoveryURL(o.OIDCStorageProviderS3BucketName, o.OIDCStorageProviderS3Region, o.InfraID)
+		if o.OIDCStorageProviderIssuerURLBase != "" {
+			o.IssuerURL = strings.TrimSuffix(o.OIDCStorageProviderIssuerURLBase, "/") + "/" + o.InfraID
+		} else {
+			o.IssuerURL = oidcDiscoveryURL(o.OIDCStorageProviderS3BucketName, o.OIDCStorageProviderS3Region, o.InfraID)
+		}
 		log.Log.Info("Detected Issuer URL", "issuer", o.IssuerURL)
 
 		providerName = strings.TrimPrefix(o.IssuerURL, "https://")
@@ -436,7 +444,7 @@ func (o *CreateIAMOptions) CreateOIDCResources(iamClient iamiface.IAMAPI) (*Crea
 	// TODO: The policies and secrets for these roles can be extracted from the
 	// release payload, avoiding this current hardcoding.
 	ingressTrustPolicy := oidcTrustPolicy(providerARN, providerName, "system:serviceaccount:openshift-ingress-operator:ingress-operator")
-	arn, err := o.CreateOIDCRole(iamClient, "openshift-ingress", ingressTrustPolicy, ingressPermPolicy(o.PublicZoneID, o.PrivateZoneID))
+	arn, err := o.CreateOIDCRole(iamClient, "openshift-ingress", ingressTrustPolicy, ingressPermPolicy(supportawsutil.Partition(o.Region), o.PublicZoneID, o.PrivateZoneID))
 	if err != nil {
 		return nil, err
 	}
@@ -473,7 +481,7 @@ func (o *CreateIAMOptions) CreateOIDCResources(iamClient iamiface.IAMAPI) (*Crea
 	output.Roles.NodePoolManagementARN = arn
 
 	controlPlaneOperatorTrustPolicy := oidcTrustPolicy(providerARN, providerName, "system:serviceaccount:kube-system:control-plane-operator")
-	arn, err = o.CreateOIDCRole(iamClient, "control-plane-operator", controlPlaneOperatorTrustPolicy, controlPlaneOperatorPolicy(o.LocalZoneID))
+	arn, err = o.CreateOIDCRole(iamClient, "control-plane-operator", controlPlaneOperatorTrustPolicy, controlPlaneOperatorPolicy(supportawsutil.Partition(o.Region), o.LocalZoneID))
 	if err != nil {
 		return nil, err
 	}
@@ -593,7 +601,7 @@ func (o *CreateIAMOptions) CreateWorkerInstanceProfile(client iamiface.IAMAPI, p
         {
             "Action": "sts:AssumeRole",
             "Principal": {
-                "Service": "ec2.amazonaws.com"
+                "Service": "ec2.%s"
             },
             "Effect": "Allow",
             "Sid": ""
@@ -621,7 +629,7 @@ func (o *CreateIAMOptions) CreateWorkerInstanceProfile(client iamiface.IAMAPI, p
 	}
 	if role == nil {
 		_, err := client.CreateRole(&iam.CreateRoleInput{
-			AssumeRolePolicyDocument: aws.String(assumeRolePolicy),
+			AssumeRolePolicyDocument: aws.String(fmt.Sprintf(assumeRolePolicy, supportawsutil.DNSSuffix(o.Region))),
 			Path:                     aws.String("/"),
 			RoleName:                 aws.String(roleName),
 			Tags:                     o.additionalIAMTags,
@@ -0,0 +1,154 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi/resourcegroupstaggingapiiface"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go/service/route53/route53iface"
+
+	awsutil "github.com/openshift/hypershift/cmd/infra/aws/util"
+	"github.com/openshift/hypershift/cmd/infra/cloudapi"
+)
+
+// InventoryResource is an AWS resource that would be deleted by destroying the infrastructure or the IAM of a
+// cluster.
+type InventoryResource struct {
+	// Type is the service and type of the resource, e.g. ec2:vpc.
+	Type string
+	// ID is the identifier of the resource, e.g. the VPC ID or the IAM role name.
+	ID string
+}
+
+// Inventory lists the resources DestroyInfra would delete: the resources tagged with the cluster tag of the infra
+// ID, and the wildcard ingress record of the public zone.
+func (o *DestroyInfraOptions) Inventory(ctx context.Context) ([]InventoryResource, error) {
+	awsSession := awsutil.NewSessionWithEndpointOverrides("cli-destroy-infra", o.AWSCredentialsFile, o.AWSKey, o.AWSSecretKey, o.Region, o.EndpointOverrides)
+	cloudapi.FromContext(ctx).InstrumentAWSSession(awsSession)
+	awsConfig := awsutil.NewConfig()
+	taggingClient := resourcegroupstaggingapi.New(awsSession, awsConfig)
+	route53Client := route53.New(awsSession, awsutil.NewAWSRoute53Config())
+
+	resources, err := o.taggedResources(ctx, taggingClient)
+	if err != nil {
+		return nil, err
+	}
+	record, err := o.publicZoneRecord(ctx, route53Client)
+	if err != nil {
+		return nil, err
+	}
+	if record != nil {
+		resources = append(resources, *record)
+	}
+	return resources, nil
+}
+
+func (o *DestroyInfraOptions) taggedResources(ctx context.Context, client resourcegroupstaggingapiiface.ResourceGroupsTaggingAPIAPI) ([]InventoryResource, error) {
+	var resources []InventoryResource
+	var parseErr error
+	err := client.GetResourcesPagesWithContext(ctx, &resourcegroupstaggingapi.GetResourcesInput{
+		TagFilters: []*resourcegroupstaggingapi.TagFilter{
+			{
+				Key:    aws.String(clusterTag(o.InfraID)),
+				Values: []*string{aws.String(clusterTagValue)},
+			},
+		},
+	}, func(page *resourcegroupstaggingapi.GetResourcesOutput, lastPage bool) bool {
+		for _, mapping := range page.ResourceTagMappingList {
+			resource, err := inventoryResourceFromARN(aws.StringValue(mapping.ResourceARN))
+			if err != nil {
+				parseErr = err
+				return false
+			}
+			resources = append(resources, resource)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list resources tagged with %s: %w", clusterTag(o.InfraID), err)
+	}
+	if parseErr != nil {
+		return nil, parseErr
+	}
+	return resources, nil
+}
+
+func (o *DestroyInfraOptions) publicZoneRecord(ctx context.Context, client route53iface.Route53API) (*InventoryResource, error) {
+	if o.BaseDomain == "" {
+		return nil, nil
+	}
+	id, err := lookupZone(ctx, client, o.BaseDomain, false)
+	if err != nil {
+		return nil, nil
+	}
+	recordName := fmt.Sprintf("*.apps.%s.%s", o.Name, o.BaseDomain)
+	if _, err := findRecord(ctx, client, id, recordName, "A"); err != nil {
+		return nil, nil
+	}
+	return &InventoryResource{Type: "route53:record", ID: fmt.Sprintf("%s/%s", id, recordName)}, nil
+}
+
+// inventoryResourceFromARN splits the ARN of a resource into its service and resource type, and its ID.
+func inventoryResourceFromARN(resourceARN string) (InventoryResource, error) {
+	parsed, err := arn.Parse(resourceARN)
+	if err != nil {
+		return InventoryResource{}, fmt.Errorf("failed to parse resource ARN %q: %w", resourceARN, err)
+	}
+	if i := strings.IndexAny(parsed.Resource, "/:"); i > 0 {
+		return InventoryResource{Type: parsed.Service + ":" + parsed.Resource[:i], ID: parsed.Resource[i+1:]}, nil
+	}
+	return InventoryResource{Type: parsed.Service, ID: parsed.Resource}, nil
+}
+
+// Inventory lists the IAM resources DestroyIAM would delete.
+func (o *DestroyIAMOptions) Inventory(ctx context.Context) ([]InventoryResource, error) {
+	awsSession := awsutil.NewSessionWithEndpointOverrides("cli-destroy-iam", o.AWSCredentialsFile, o.AWSKey, o.AWSSecretKey, o.Region, o.EndpointOverrides)
+	cloudapi.FromContext(ctx).InstrumentAWSSession(awsSession)
+	awsConfig := awsutil.NewConfig()
+	return o.iamInventory(ctx, iam.New(awsSession, awsConfig))
+}
+
+func (o *DestroyIAMOptions) iamInventory(ctx context.Context, client iamiface.IAMAPI) ([]InventoryResource, error) {
+	var resources []InventoryResource
+	oidcProviderList, err := client.ListOpenIDConnectProvidersWithContext(ctx, &iam.ListOpenIDConnectProvidersInput{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list OIDC providers: %w", err)
+	}
+	for _, provider := range oidcProviderList.OpenIDConnectProviderList {
+		if strings.Contains(aws.StringValue(provider.Arn), o.InfraID) {
+			resources = append(resources, InventoryResource{Type: "iam:oidc-provider", ID: aws.StringValue(provider.Arn)})
+			break
+		}
+	}
+
+	profileName := DefaultProfileName(o.InfraID)
+	roleNames := []string{fmt.Sprintf("%s-role", profileName)}
+	for _, name := range oidcRoleNames {
+		roleNames = append(roleNames, fmt.Sprintf("%s-%s", o.InfraID, name))
+	}
+	for _, roleName := range roleNames {
+		role, err := existingRole(client, roleName)
+		if err != nil {
+			return nil, err
+		}
+		if role != nil {
+			resources = append(resources, InventoryResource{Type: "iam:role", ID: roleName})
+		}
+	}
+
+	instanceProfile, err := existingInstanceProfile(client, profileName)
+	if err != nil {
+		return nil, fmt.Errorf("cannot check for existing instance profile: %w", err)
+	}
+	if instanceProfile != nil {
+		resources = append(resources, InventoryResource{Type: "iam:instance-profile", ID: profileName})
+	}
+	return resources, nil
+}
@@ -0,0 +1,160 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi/resourcegroupstaggingapiiface"
+	"github.com/go-logr/logr"
+	"github.com/spf13/cobra"
+
+	awsutil "github.com/openshift/hypershift/cmd/infra/aws/util"
+	"github.com/openshift/hypershift/cmd/infra/cloudapi"
+	"github.com/openshift/hypershift/cmd/infra/inventory"
+	"github.com/openshift/hypershift/cmd/log"
+	"github.com/openshift/hypershift/cmd/util"
+)
+
+const clusterTagPrefix = "kubernetes.io/cluster/"
+
+type ListInfraOptions struct {
+	AWSCredentialsFile string
+	AWSKey             string
+	AWSSecretKey       string
+	// Regions are the regions to search, all the regions enabled for the account when empty.
+	Regions []string
+	// Output is the format of the report, table or json.
+	Output string
+	Log    logr.Logger
+	// CloudAPI configures the logging, rate limiting and audit trail of the AWS API calls.
+	CloudAPI cloudapi.Options
+}
+
+func NewListCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "aws",
+		Short:        "Lists the AWS infrastructure of all clusters across regions and maps it to HostedClusters",
+		SilenceUsage: true,
+	}
+
+	opts := ListInfraOptions{
+		Output: inventory.OutputTable,
+		Log:    log.Log,
+	}
+
+	cmd.Flags().StringVar(&opts.AWSCredentialsFile, "aws-creds", opts.AWSCredentialsFile, "Path to an AWS credentials file (required)")
+	cmd.Flags().StringSliceVar(&opts.Regions, "regions", opts.Regions, "The regions to search for infrastructure, defaults to all the regions enabled for the account")
+	cmd.Flags().StringVarP(&opts.Output, "output", "o", opts.Output, "The format of the report, table or json")
+	opts.CloudAPI.BindFlags(cmd.Flags())
+
+	_ = cmd.MarkFlagRequired("aws-creds")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		ctx, done, err := opts.CloudAPI.Start(cmd.Context(), opts.Log)
+		if err != nil {
+			return err
+		}
+		defer done()
+		if err := opts.Run(ctx); err != nil {
+			opts.Log.Error(err, "Failed to list infrastructure")
+			return err
+		}
+		return nil
+	}
+
+	return cmd
+}
+
+func (o *ListInfraOptions) Run(ctx context.Context) error {
+	c, err := util.GetClient()
+	if err != nil {
+		return err
+	}
+	resources, err := o.Resources(ctx)
+	if err != nil {
+		return err
+	}
+	report, err := inventory.Build(ctx, c, resources)
+	if err != nil {
+		return err
+	}
+	return inventory.Print(os.Stdout, report, o.Output)
+}
+
+// Resources lists the resources tagged with the cluster tag of any infra ID in the regions to search.
+func (o *ListInfraOptions) Resources(ctx context.Context) ([]inventory.Resource, error) {
+	regions := o.Regions
+	if len(regions) == 0 {
+		awsSession := awsutil.NewSession("cli-list-infra", o.AWSCredentialsFile, o.AWSKey, o.AWSSecretKey, "us-east-1")
+		cloudapi.FromContext(ctx).InstrumentAWSSession(awsSession)
+		var err error
+		if regions, err = enabledRegions(ctx, ec2.New(awsSession, awsutil.NewConfig())); err != nil {
+			return nil, err
+		}
+	}
+
+	var resources []inventory.Resource
+	for _, region := range regions {
+		awsSession := awsutil.NewSession("cli-list-infra", o.AWSCredentialsFile, o.AWSKey, o.AWSSecretKey, region)
+		cloudapi.FromContext(ctx).InstrumentAWSSession(awsSession)
+		regionResources, err := clusterTaggedResources(ctx, resourcegroupstaggingapi.New(awsSession, awsutil.NewConfig()), region)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, regionResources...)
+	}
+	return resources, nil
+}
+
+func enabledRegions(ctx context.Context, client ec2iface.EC2API) ([]string, error) {
+	output, err := client.DescribeRegionsWithContext(ctx, &ec2.DescribeRegionsInput{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list regions: %w", err)
+	}
+	var regions []string
+	for _, region := range output.Regions {
+		regions = append(regions, aws.StringValue(region.RegionName))
+	}
+	return regions, nil
+}
+
+// clusterTaggedResources lists the resources of the region tagged as owned by the infrastructure of a cluster. The
+// tagging API can't filter on a tag key prefix, so all the tagged resources of the region are listed.
+func clusterTaggedResources(ctx context.Context, client resourcegroupstaggingapiiface.ResourceGroupsTaggingAPIAPI, region string) ([]inventory.Resource, error) {
+	var resources []inventory.Resource
+	var parseErr error
+	err := client.GetResourcesPagesWithContext(ctx, &resourcegroupstaggingapi.GetResourcesInput{}, func(page *resourcegroupstaggingapi.GetResourcesOutput, lastPage bool) bool {
+		for _, mapping := range page.ResourceTagMappingList {
+			infraID := ""
+			for _, tag := range mapping.Tags {
+				if key := aws.StringValue(tag.Key); strings.HasPrefix(key, clusterTagPrefix) && aws.StringValue(tag.Value) == clusterTagValue {
+					infraID = strings.TrimPrefix(key, clusterTagPrefix)
+					break
+				}
+			}
+			if infraID == "" {
+				continue
+			}
+			resource, err := inventoryResourceFromARN(aws.StringValue(mapping.ResourceARN))
+			if err != nil {
+				parseErr = err
+				return false
+			}
+			resources = append(resources, inventory.Resource{InfraID: infraID, Location: region, Type: resource.Type, ID: resource.ID})
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tagged resources in region %s: %w", region, err)
+	}
+	if parseErr != nil {
+		return nil, parseErr
+	}
+	return resources, nil
+}
@@ -1,6 +1,8 @@
 package util
 
 import (
+	"fmt"
+	"os"
 	"time"
 
 	utilpointer "k8s.io/utils/pointer"
@@ -10,10 +12,37 @@ import (
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
+
+	supportawsutil "github.com/openshift/hypershift/support/awsutil"
 )
 
 func NewSession(agent string, credentialsFile string, credKey string, credSecretKey string, region string) *session.Session {
+	return NewSessionWithEndpointOverrides(agent, credentialsFile, credKey, credSecretKey, region, nil)
+}
+
+// NewSessionWithEndpointOverrides creates a session whose clients reach the AWS services with an override, keyed by
+// the endpoint ID of the service, through the URL of the override. The overrides of the HYPERSHIFT_AWS_ENDPOINT_OVERRIDES
+// environment variable apply to every session, unless overridden themselves.
+func NewSessionWithEndpointOverrides(agent string, credentialsFile string, credKey string, credSecretKey string, region string, endpointOverrides map[string]string) *session.Session {
+	envOverrides, err := supportawsutil.ParseEndpointOverrides(os.Getenv(supportawsutil.EndpointOverridesEnvVar))
+	if err != nil {
+		panic(fmt.Sprintf("invalid %s: %v", supportawsutil.EndpointOverridesEnvVar, err))
+	}
+	if len(envOverrides) > 0 || len(endpointOverrides) > 0 {
+		overrides := map[string]string{}
+		for service, override := range envOverrides {
+			overrides[service] = override
+		}
+		for service, override := range endpointOverrides {
+			overrides[service] = override
+		}
+		endpointOverrides = overrides
+	}
+
 	sessionOpts := session.Options{}
+	if len(endpointOverrides) > 0 {
+		sessionOpts.Config.EndpointResolver = supportawsutil.EndpointResolver(endpointOverrides)
+	}
 	if credentialsFile != "" {
 		sessionOpts.SharedConfigFiles = append(sessionOpts.SharedConfigFiles, credentialsFile)
 	}
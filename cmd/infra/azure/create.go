@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -12,12 +13,16 @@ import (
 	"github.com/hashicorp/go-uuid"
 	"github.com/spf13/cobra"
 
+	"github.com/openshift/hypershift/cmd/infra/cloudapi"
 	"github.com/openshift/hypershift/cmd/log"
 	"github.com/openshift/hypershift/cmd/util"
 	"github.com/openshift/hypershift/support/azureutil"
+	"github.com/openshift/hypershift/support/clusteridentity"
 
 	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/utils/ptr"
+	capiazure "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 	"sigs.k8s.io/yaml"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
@@ -56,6 +61,12 @@ type CreateInfraOptions struct {
 	NetworkSecurityGroup string
 	ResourceGroupTags    map[string]string
 	SubnetID             string
+	// CloudAPI configures the logging, rate limiting and audit trail of the Azure API calls.
+	CloudAPI cloudapi.Options
+	// Owner and Lifetime are the owner and lifetime of the cluster the resource group is tagged with, along with its
+	// infra ID and name.
+	Owner    string
+	Lifetime time.Duration
 }
 
 type CreateInfraOutput struct {
@@ -71,8 +82,27 @@ type CreateInfraOutput struct {
 	InfraID           string `json:"infraID"`
 	MachineIdentityID string `json:"machineIdentityID"`
 	SecurityGroupID   string `json:"securityGroupID"`
+	// AvailabilityMode is how the VMs of the cluster are spread across failure domains in its location, either
+	// across availability zones, or across the fault domains of availability sets when the location has no zones.
+	AvailabilityMode AvailabilityMode `json:"availabilityMode,omitempty"`
+	// AvailabilityZones are the availability zones of the location, when AvailabilityMode is Zones.
+	AvailabilityZones []string `json:"availabilityZones,omitempty"`
+	// AvailabilitySetName is the availability set created for the default NodePool, when AvailabilityMode is
+	// AvailabilitySet.
+	AvailabilitySetName string `json:"availabilitySetName,omitempty"`
 }
 
+// AvailabilityMode is how VMs are spread across failure domains.
+type AvailabilityMode string
+
+const (
+	// AvailabilityModeZones spreads VMs across the availability zones of the location, with one NodePool per zone.
+	AvailabilityModeZones AvailabilityMode = "Zones"
+	// AvailabilityModeAvailabilitySet spreads the VMs of each NodePool across the fault domains of an availability
+	// set, for locations without availability zones.
+	AvailabilityModeAvailabilitySet AvailabilityMode = "AvailabilitySet"
+)
+
 func NewCreateCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:          "azure",
@@ -105,6 +135,9 @@ func NewCreateCommand() *cobra.Command {
 	cmd.Flags().StringVar(&opts.SubnetID, "subnet-id", opts.SubnetID, "The subnet ID where the VMs will be placed.")
 	cmd.Flags().StringVar(&opts.RHCOSImage, "rhcos-image", opts.RHCOSImage, `RHCOS image to be used for the NodePool. Could be obtained using podman run --rm -it --entrypoint cat $RELEASE_IMAGE release-manifests/0000_50_installer_coreos-bootimages.yaml | yq .data.stream -r | yq '.architectures.x86_64["rhel-coreos-extensions"]["azure-disk"].url'`)
 	cmd.Flags().StringToStringVarP(&opts.ResourceGroupTags, "resource-group-tags", "t", opts.ResourceGroupTags, "Additional tags to apply to the resource group created (e.g. 'key1=value1,key2=value2')")
+	cmd.Flags().StringVar(&opts.Owner, "owner", opts.Owner, "The owner of the cluster, e.g. a team or an email address, the resource group created is tagged with")
+	cmd.Flags().DurationVar(&opts.Lifetime, "lifetime", opts.Lifetime, "The lifetime of the cluster, e.g. 8h. The resource group created is tagged with the time it expires")
+	opts.CloudAPI.BindFlags(cmd.Flags())
 
 	_ = cmd.MarkFlagRequired("infra-id")
 	_ = cmd.MarkFlagRequired("azure-creds")
@@ -113,7 +146,12 @@ func NewCreateCommand() *cobra.Command {
 
 	l := log.Log
 	cmd.RunE = func(cmd *cobra.Command, args []string) error {
-		if _, err := opts.Run(cmd.Context(), l); err != nil {
+		ctx, done, err := opts.CloudAPI.Start(cmd.Context(), l)
+		if err != nil {
+			return err
+		}
+		defer done()
+		if _, err := opts.Run(ctx, l); err != nil {
 			l.Error(err, "Failed to create infrastructure")
 			return err
 		}
@@ -132,7 +170,7 @@ func (o *CreateInfraOptions) Run(ctx context.Context, l logr.Logger) (*CreateInf
 	}
 
 	// Setup subscription ID and Azure credential information
-	subscriptionID, azureCreds, err := util.SetupAzureCredentials(l, o.Credentials, o.CredentialsFile)
+	subscriptionID, azureCreds, err := util.SetupAzureCredentialsWithOptions(l, o.Credentials, o.CredentialsFile, cloudapi.FromContext(ctx).AzureCredentialOptions())
 	if err != nil {
 		return nil, fmt.Errorf("failed to setup Azure credentials: %w", err)
 	}
@@ -145,6 +183,25 @@ func (o *CreateInfraOptions) Run(ctx context.Context, l logr.Logger) (*CreateInf
 	result.ResourceGroupName = resourceGroupName
 	l.Info(msg, "name", resourceGroupName)
 
+	// Spread the VMs across availability zones, or across the fault domains of an availability set when the location
+	// has none
+	zones, faultDomains, err := locationAvailability(ctx, subscriptionID, o.Location, azureCreds)
+	if err != nil {
+		return nil, err
+	}
+	if len(zones) > 0 {
+		result.AvailabilityMode = AvailabilityModeZones
+		result.AvailabilityZones = zones
+		l.Info("Location supports availability zones", "location", o.Location, "zones", zones)
+	} else {
+		result.AvailabilityMode = AvailabilityModeAvailabilitySet
+		result.AvailabilitySetName, err = createAvailabilitySet(ctx, subscriptionID, resourceGroupName, azureutil.AvailabilitySetName(o.InfraID, o.Name), o.InfraID, o.Location, faultDomains, azureCreds)
+		if err != nil {
+			return nil, err
+		}
+		l.Info("Location has no availability zones, successfully created availability set for the default NodePool", "location", o.Location, "name", result.AvailabilitySetName, "faultDomains", faultDomains)
+	}
+
 	// Capture the base DNS zone's resource group's ID
 	result.PublicZoneID, err = getBaseDomainID(ctx, subscriptionID, azureCreds, o.BaseDomain)
 	if err != nil {
@@ -265,7 +322,7 @@ func createResourceGroup(ctx context.Context, o *CreateInfraOptions, azureCreds
 	existingRGSuccessMsg := "Successfully found existing resource group"
 	createdRGSuccessMsg := "Successfully created resource group"
 
-	resourceGroupClient, err := armresources.NewResourceGroupsClient(subscriptionID, azureCreds, nil)
+	resourceGroupClient, err := armresources.NewResourceGroupsClient(subscriptionID, azureCreds, cloudapi.FromContext(ctx).ARMClientOptions())
 	if err != nil {
 		return "", "", "", fmt.Errorf("failed to create new resource groups client: %w", err)
 	}
@@ -280,7 +337,12 @@ func createResourceGroup(ctx context.Context, o *CreateInfraOptions, azureCreds
 		return *response.ID, *response.Name, existingRGSuccessMsg, nil
 	} else {
 
-		resourceGroupTags := map[string]*string{}
+		resourceGroupTags := map[string]*string{
+			clusterTag(o.InfraID): ptr.To(clusterTagValue),
+		}
+		for _, tag := range clusteridentity.ForInfrastructure(o.InfraID, o.Name, o.Owner, o.Lifetime).Tags(nil) {
+			resourceGroupTags[tag.Key] = ptr.To(tag.Value)
+		}
 		for key, value := range o.ResourceGroupTags {
 			resourceGroupTags[key] = ptr.To(value)
 		}
@@ -300,9 +362,90 @@ func createResourceGroup(ctx context.Context, o *CreateInfraOptions, azureCreds
 	}
 }
 
+// locationAvailability returns the availability zones of the location, and the number of fault domains of the
+// availability sets in the location.
+func locationAvailability(ctx context.Context, subscriptionID string, location string, azureCreds azcore.TokenCredential) ([]string, int32, error) {
+	skusClient, err := armcompute.NewResourceSKUsClient(subscriptionID, azureCreds, cloudapi.FromContext(ctx).ARMClientOptions())
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create resource SKUs client: %w", err)
+	}
+	var skus []*armcompute.ResourceSKU
+	pager := skusClient.NewListPager(&armcompute.ResourceSKUsClientListOptions{Filter: ptr.To(fmt.Sprintf("location eq '%s'", location))})
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to list resource SKUs of location %s: %w", location, err)
+		}
+		skus = append(skus, page.Value...)
+	}
+	zones, faultDomains := availabilityFromSKUs(skus, location)
+	return zones, faultDomains, nil
+}
+
+// availabilityFromSKUs returns the availability zones the virtual machine SKUs of the location are offered in, and the
+// maximum number of fault domains of the aligned availability sets of the location, 2 if it is not advertised.
+func availabilityFromSKUs(skus []*armcompute.ResourceSKU, location string) ([]string, int32) {
+	zones := sets.New[string]()
+	faultDomains := int32(2)
+	for _, sku := range skus {
+		if sku == nil {
+			continue
+		}
+		switch ptr.Deref(sku.ResourceType, "") {
+		case "virtualMachines":
+			for _, info := range sku.LocationInfo {
+				if info == nil || !strings.EqualFold(ptr.Deref(info.Location, ""), location) {
+					continue
+				}
+				for _, zone := range info.Zones {
+					zones.Insert(ptr.Deref(zone, ""))
+				}
+			}
+		case "availabilitySets":
+			if ptr.Deref(sku.Name, "") != string(armcompute.AvailabilitySetSKUTypesAligned) {
+				continue
+			}
+			for _, capability := range sku.Capabilities {
+				if capability == nil || ptr.Deref(capability.Name, "") != "MaximumPlatformFaultDomainCount" {
+					continue
+				}
+				if count, err := strconv.ParseInt(ptr.Deref(capability.Value, ""), 10, 32); err == nil && count > 0 {
+					faultDomains = int32(count)
+				}
+			}
+		}
+	}
+	zones.Delete("")
+	return sets.List(zones), faultDomains
+}
+
+// createAvailabilitySet creates the availability set of a NodePool, tagged as owned by the cluster for the cluster-api
+// provider to place the VMs of the NodePool in it and delete it along with them.
+func createAvailabilitySet(ctx context.Context, subscriptionID string, resourceGroupName string, name string, infraID string, location string, faultDomains int32, azureCreds azcore.TokenCredential) (string, error) {
+	availabilitySetsClient, err := armcompute.NewAvailabilitySetsClient(subscriptionID, azureCreds, cloudapi.FromContext(ctx).ARMClientOptions())
+	if err != nil {
+		return "", fmt.Errorf("failed to create availability sets client: %w", err)
+	}
+	availabilitySet, err := availabilitySetsClient.CreateOrUpdate(ctx, resourceGroupName, name, armcompute.AvailabilitySet{
+		Location: ptr.To(location),
+		SKU:      &armcompute.SKU{Name: ptr.To(string(armcompute.AvailabilitySetSKUTypesAligned))},
+		Properties: &armcompute.AvailabilitySetProperties{
+			PlatformFaultDomainCount:  ptr.To(faultDomains),
+			PlatformUpdateDomainCount: ptr.To(int32(5)),
+		},
+		Tags: map[string]*string{
+			capiazure.ClusterTagKey(infraID): ptr.To(string(capiazure.ResourceLifecycleOwned)),
+		},
+	}, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create availability set %s: %w", name, err)
+	}
+	return *availabilitySet.Name, nil
+}
+
 // getBaseDomainID gets the resource group ID for the resource group containing the base domain
 func getBaseDomainID(ctx context.Context, subscriptionID string, azureCreds azcore.TokenCredential, baseDomain string) (string, error) {
-	zonesClient, err := armdns.NewZonesClient(subscriptionID, azureCreds, nil)
+	zonesClient, err := armdns.NewZonesClient(subscriptionID, azureCreds, cloudapi.FromContext(ctx).ARMClientOptions())
 	if err != nil {
 		return "", fmt.Errorf("failed to create dns zone %s: %w", baseDomain, err)
 	}
@@ -325,7 +468,7 @@ func getBaseDomainID(ctx context.Context, subscriptionID string, azureCreds azco
 
 // createManagedIdentity creates a managed identity
 func createManagedIdentity(ctx context.Context, subscriptionID string, resourceGroupName string, name string, infraID string, location string, azureCreds azcore.TokenCredential) (string, string, error) {
-	identityClient, err := armmsi.NewUserAssignedIdentitiesClient(subscriptionID, azureCreds, nil)
+	identityClient, err := armmsi.NewUserAssignedIdentitiesClient(subscriptionID, azureCreds, cloudapi.FromContext(ctx).ARMClientOptions())
 	if err != nil {
 		return "", "", fmt.Errorf("failed to create new identity client: %w", err)
 	}
@@ -338,7 +481,7 @@ func createManagedIdentity(ctx context.Context, subscriptionID string, resourceG
 
 // setManagedIdentityRole sets the managed identity's principal role to 'Contributor'
 func setManagedIdentityRole(ctx context.Context, subscriptionID string, resourceGroupID string, identityRolePrincipalID string, azureCreds azcore.TokenCredential) error {
-	roleDefinitionClient, err := armauthorization.NewRoleDefinitionsClient(azureCreds, nil)
+	roleDefinitionClient, err := armauthorization.NewRoleDefinitionsClient(azureCreds, cloudapi.FromContext(ctx).ARMClientOptions())
 	if err != nil {
 		return fmt.Errorf("failed to create new role definitions client: %w", err)
 	}
@@ -365,7 +508,7 @@ func setManagedIdentityRole(ctx context.Context, subscriptionID string, resource
 		return fmt.Errorf("didn't find the 'Contributor' role")
 	}
 
-	roleAssignmentClient, err := armauthorization.NewRoleAssignmentsClient(subscriptionID, azureCreds, nil)
+	roleAssignmentClient, err := armauthorization.NewRoleAssignmentsClient(subscriptionID, azureCreds, cloudapi.FromContext(ctx).ARMClientOptions())
 	if err != nil {
 		return fmt.Errorf("failed to create new role assignments client: %w", err)
 	}
@@ -397,7 +540,7 @@ func setManagedIdentityRole(ctx context.Context, subscriptionID string, resource
 
 // createSecurityGroup creates the security group the virtual network will use
 func createSecurityGroup(ctx context.Context, subscriptionID string, resourceGroupName string, name string, infraID string, location string, azureCreds azcore.TokenCredential) (string, string, error) {
-	securityGroupClient, err := armnetwork.NewSecurityGroupsClient(subscriptionID, azureCreds, nil)
+	securityGroupClient, err := armnetwork.NewSecurityGroupsClient(subscriptionID, azureCreds, cloudapi.FromContext(ctx).ARMClientOptions())
 	if err != nil {
 		return "", "", fmt.Errorf("failed to create security group client: %w", err)
 	}
@@ -417,7 +560,7 @@ func createSecurityGroup(ctx context.Context, subscriptionID string, resourceGro
 func createVirtualNetwork(ctx context.Context, subscriptionID string, resourceGroupName string, name string, infraID string, location string, securityGroupID string, azureCreds azcore.TokenCredential) (armnetwork.VirtualNetworksClientCreateOrUpdateResponse, error) {
 	subnetName := "default"
 
-	networksClient, err := armnetwork.NewVirtualNetworksClient(subscriptionID, azureCreds, nil)
+	networksClient, err := armnetwork.NewVirtualNetworksClient(subscriptionID, azureCreds, cloudapi.FromContext(ctx).ARMClientOptions())
 	if err != nil {
 		return armnetwork.VirtualNetworksClientCreateOrUpdateResponse{}, fmt.Errorf("failed to create new virtual networks client: %w", err)
 	}
@@ -464,7 +607,7 @@ func createVirtualNetwork(ctx context.Context, subscriptionID string, resourceGr
 
 // createPrivateDNSZone creates the private DNS zone
 func createPrivateDNSZone(ctx context.Context, subscriptionID string, resourceGroupName string, name string, baseDomain string, azureCreds azcore.TokenCredential) (string, string, error) {
-	privateZoneClient, err := armprivatedns.NewPrivateZonesClient(subscriptionID, azureCreds, nil)
+	privateZoneClient, err := armprivatedns.NewPrivateZonesClient(subscriptionID, azureCreds, cloudapi.FromContext(ctx).ARMClientOptions())
 	if err != nil {
 		return "", "", fmt.Errorf("failed to create new private zones client: %w", err)
 	}
@@ -485,7 +628,7 @@ func createPrivateDNSZone(ctx context.Context, subscriptionID string, resourceGr
 
 // createPrivateDNSZoneLink creates the private DNS Zone network link
 func createPrivateDNSZoneLink(ctx context.Context, subscriptionID string, resourceGroupName string, name string, infraID string, vnetID string, privateDNSZoneName string, azureCreds azcore.TokenCredential) error {
-	privateZoneLinkClient, err := armprivatedns.NewVirtualNetworkLinksClient(subscriptionID, azureCreds, nil)
+	privateZoneLinkClient, err := armprivatedns.NewVirtualNetworkLinksClient(subscriptionID, azureCreds, cloudapi.FromContext(ctx).ARMClientOptions())
 	if err != nil {
 		return fmt.Errorf("failed to create new virtual network links client: %w", err)
 	}
@@ -511,7 +654,7 @@ func createPrivateDNSZoneLink(ctx context.Context, subscriptionID string, resour
 
 // createRhcosImages uploads the RHCOS image and creates a bootable image
 func createRhcosImages(ctx context.Context, l logr.Logger, o *CreateInfraOptions, subscriptionID string, resourceGroupName string, azureCreds azcore.TokenCredential) (string, error) {
-	storageAccountClient, err := armstorage.NewAccountsClient(subscriptionID, azureCreds, nil)
+	storageAccountClient, err := armstorage.NewAccountsClient(subscriptionID, azureCreds, cloudapi.FromContext(ctx).ARMClientOptions())
 	if err != nil {
 		return "", fmt.Errorf("failed to create new accounts client for storage: %w", err)
 	}
@@ -534,7 +677,7 @@ func createRhcosImages(ctx context.Context, l logr.Logger, o *CreateInfraOptions
 	}
 	l.Info("Successfully created storage account", "name", *storageAccount.Name)
 
-	blobContainersClient, err := armstorage.NewBlobContainersClient(subscriptionID, azureCreds, nil)
+	blobContainersClient, err := armstorage.NewBlobContainersClient(subscriptionID, azureCreds, cloudapi.FromContext(ctx).ARMClientOptions())
 	if err != nil {
 		return "", fmt.Errorf("failed to create blob containers client: %w", err)
 	}
@@ -554,7 +697,7 @@ func createRhcosImages(ctx context.Context, l logr.Logger, o *CreateInfraOptions
 	}
 
 	// storage object access has its own authentication system: https://github.com/hashicorp/terraform-provider-azurerm/blob/b0c897055329438be6a3a159f6ffac4e1ce958f2/internal/services/storage/client/client.go#L133
-	accountsClient, err := armstorage.NewAccountsClient(subscriptionID, azureCreds, nil)
+	accountsClient, err := armstorage.NewAccountsClient(subscriptionID, azureCreds, cloudapi.FromContext(ctx).ARMClientOptions())
 	if err != nil {
 		return "", fmt.Errorf("failed to create new accounts client: %w", err)
 	}
@@ -584,7 +727,7 @@ func createRhcosImages(ctx context.Context, l logr.Logger, o *CreateInfraOptions
 	}
 	l.Info("Successfully uploaded rhcos image")
 
-	imagesClient, err := armcompute.NewImagesClient(subscriptionID, azureCreds, nil)
+	imagesClient, err := armcompute.NewImagesClient(subscriptionID, azureCreds, cloudapi.FromContext(ctx).ARMClientOptions())
 	if err != nil {
 		return "", fmt.Errorf("failed to create images client: %w", err)
 	}
@@ -619,7 +762,7 @@ func createRhcosImages(ctx context.Context, l logr.Logger, o *CreateInfraOptions
 
 // createPublicIPAddressForLB creates a public IP address to use for the outbound rule in the load balancer
 func createPublicIPAddressForLB(ctx context.Context, subscriptionID string, resourceGroupName string, infraID string, location string, azureCreds azcore.TokenCredential) (*armnetwork.PublicIPAddress, error) {
-	publicIPAddressClient, err := armnetwork.NewPublicIPAddressesClient(subscriptionID, azureCreds, nil)
+	publicIPAddressClient, err := armnetwork.NewPublicIPAddressesClient(subscriptionID, azureCreds, cloudapi.FromContext(ctx).ARMClientOptions())
 	if err != nil {
 		return nil, fmt.Errorf("failed to create public IP address client, %w", err)
 	}
@@ -658,7 +801,7 @@ func createLoadBalancer(ctx context.Context, subscriptionID string, resourceGrou
 	idPrefix := fmt.Sprintf("subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/loadBalancers", subscriptionID, resourceGroupName)
 	loadBalancerName := infraID
 
-	loadBalancerClient, err := armnetwork.NewLoadBalancersClient(subscriptionID, azureCreds, nil)
+	loadBalancerClient, err := armnetwork.NewLoadBalancersClient(subscriptionID, azureCreds, cloudapi.FromContext(ctx).ARMClientOptions())
 	if err != nil {
 		return fmt.Errorf("failed to create load balancer client, %w", err)
 	}
@@ -0,0 +1,65 @@
+package azure
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/ptr"
+)
+
+func TestAvailabilityFromSKUs(t *testing.T) {
+	vmSKU := func(location string, zones ...string) *armcompute.ResourceSKU {
+		var zonePtrs []*string
+		for _, zone := range zones {
+			zonePtrs = append(zonePtrs, ptr.To(zone))
+		}
+		return &armcompute.ResourceSKU{
+			ResourceType: ptr.To("virtualMachines"),
+			LocationInfo: []*armcompute.ResourceSKULocationInfo{{Location: ptr.To(location), Zones: zonePtrs}},
+		}
+	}
+	availabilitySetSKU := func(name, faultDomains string) *armcompute.ResourceSKU {
+		return &armcompute.ResourceSKU{
+			ResourceType: ptr.To("availabilitySets"),
+			Name:         ptr.To(name),
+			Capabilities: []*armcompute.ResourceSKUCapabilities{{Name: ptr.To("MaximumPlatformFaultDomainCount"), Value: ptr.To(faultDomains)}},
+		}
+	}
+
+	testCases := []struct {
+		name                 string
+		skus                 []*armcompute.ResourceSKU
+		expectedZones        []string
+		expectedFaultDomains int32
+	}{
+		{
+			name:                 "When the VM SKUs are offered in zones it should return the zones of the location",
+			skus:                 []*armcompute.ResourceSKU{vmSKU("eastus", "2", "1"), vmSKU("EastUS", "3", "1"), vmSKU("westus", "4")},
+			expectedZones:        []string{"1", "2", "3"},
+			expectedFaultDomains: 2,
+		},
+		{
+			name:                 "When the location has no zones it should return the fault domains of aligned availability sets",
+			skus:                 []*armcompute.ResourceSKU{vmSKU("eastus"), availabilitySetSKU("Classic", "3"), availabilitySetSKU("Aligned", "3")},
+			expectedFaultDomains: 3,
+		},
+		{
+			name:                 "When the fault domains are not advertised it should default to 2",
+			skus:                 []*armcompute.ResourceSKU{vmSKU("eastus"), availabilitySetSKU("Aligned", "")},
+			expectedFaultDomains: 2,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			zones, faultDomains := availabilityFromSKUs(tc.skus, "eastus")
+			if tc.expectedZones == nil {
+				g.Expect(zones).To(BeEmpty())
+			} else {
+				g.Expect(zones).To(Equal(tc.expectedZones))
+			}
+			g.Expect(faultDomains).To(Equal(tc.expectedFaultDomains))
+		})
+	}
+}
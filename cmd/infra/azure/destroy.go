@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/openshift/hypershift/cmd/infra/cloudapi"
 	"github.com/openshift/hypershift/cmd/log"
 	"github.com/openshift/hypershift/cmd/util"
 
@@ -20,6 +21,8 @@ type DestroyInfraOptions struct {
 	CredentialsFile   string
 	Credentials       *util.AzureCreds
 	ResourceGroupName string
+	// CloudAPI configures the logging, rate limiting and audit trail of the Azure API calls.
+	CloudAPI cloudapi.Options
 }
 
 func NewDestroyCommand() *cobra.Command {
@@ -38,13 +41,19 @@ func NewDestroyCommand() *cobra.Command {
 	cmd.Flags().StringVar(&opts.Location, "location", opts.Location, "Location where cluster infra should be created")
 	cmd.Flags().StringVar(&opts.Name, "name", opts.Name, "A name for the cluster")
 	cmd.Flags().StringVar(&opts.ResourceGroupName, "resource-group-name", opts.ResourceGroupName, "The name of the resource group containing the HostedCluster infrastructure resources that need to be destroyed.")
+	opts.CloudAPI.BindFlags(cmd.Flags())
 
 	_ = cmd.MarkFlagRequired("infra-id")
 	_ = cmd.MarkFlagRequired("azure-creds")
 	_ = cmd.MarkFlagRequired("name")
 
 	cmd.RunE = func(cmd *cobra.Command, args []string) error {
-		if err := opts.Run(cmd.Context()); err != nil {
+		ctx, done, err := opts.CloudAPI.Start(cmd.Context(), log.Log)
+		if err != nil {
+			return err
+		}
+		defer done()
+		if err := opts.Run(ctx); err != nil {
 			log.Log.Error(err, "Failed to destroy infrastructure")
 			return err
 		}
@@ -60,13 +69,13 @@ func (o *DestroyInfraOptions) Run(ctx context.Context) error {
 	var destroyFuture *runtime.Poller[armresources.ResourceGroupsClientDeleteResponse]
 
 	// Setup subscription ID and Azure credential information
-	subscriptionID, azureCreds, err := util.SetupAzureCredentials(log.Log, o.Credentials, o.CredentialsFile)
+	subscriptionID, azureCreds, err := util.SetupAzureCredentialsWithOptions(log.Log, o.Credentials, o.CredentialsFile, cloudapi.FromContext(ctx).AzureCredentialOptions())
 	if err != nil {
 		return fmt.Errorf("failed to setup Azure credentials: %w", err)
 	}
 
 	// Setup Azure resource group client
-	resourceGroupClient, err := armresources.NewResourceGroupsClient(subscriptionID, azureCreds, nil)
+	resourceGroupClient, err := armresources.NewResourceGroupsClient(subscriptionID, azureCreds, cloudapi.FromContext(ctx).ARMClientOptions())
 	if err != nil {
 		return fmt.Errorf("failed to create new resource groups client: %w", err)
 	}
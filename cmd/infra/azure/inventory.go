@@ -0,0 +1,56 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+	"k8s.io/utils/ptr"
+
+	"github.com/openshift/hypershift/cmd/infra/cloudapi"
+	"github.com/openshift/hypershift/cmd/log"
+	"github.com/openshift/hypershift/cmd/util"
+)
+
+// InventoryResource is an Azure resource that would be deleted by destroying the infrastructure of a cluster.
+type InventoryResource struct {
+	// Type is the resource type, e.g. Microsoft.Network/virtualNetworks.
+	Type string
+	// Name is the name of the resource.
+	Name string
+}
+
+// Inventory lists the resources Run would delete: the resource group of the cluster and the resources in it.
+func (o *DestroyInfraOptions) Inventory(ctx context.Context) ([]InventoryResource, error) {
+	subscriptionID, azureCreds, err := util.SetupAzureCredentialsWithOptions(log.Log, o.Credentials, o.CredentialsFile, cloudapi.FromContext(ctx).AzureCredentialOptions())
+	if err != nil {
+		return nil, fmt.Errorf("failed to setup Azure credentials: %w", err)
+	}
+	resourceGroupClient, err := armresources.NewResourceGroupsClient(subscriptionID, azureCreds, cloudapi.FromContext(ctx).ARMClientOptions())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new resource groups client: %w", err)
+	}
+	resourcesClient, err := armresources.NewClient(subscriptionID, azureCreds, cloudapi.FromContext(ctx).ARMClientOptions())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new resources client: %w", err)
+	}
+
+	resourceGroupName := o.GetResourceGroupName()
+	resourceGroup, err := resourceGroupClient.Get(ctx, resourceGroupName, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get resource group %s: %w", resourceGroupName, err)
+	}
+	resources := []InventoryResource{{Type: ptr.Deref(resourceGroup.Type, "Microsoft.Resources/resourceGroups"), Name: resourceGroupName}}
+
+	pager := resourcesClient.NewListByResourceGroupPager(resourceGroupName, nil)
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list resources of resource group %s: %w", resourceGroupName, err)
+		}
+		for _, resource := range page.Value {
+			resources = append(resources, InventoryResource{Type: ptr.Deref(resource.Type, ""), Name: ptr.Deref(resource.Name, "")})
+		}
+	}
+	return resources, nil
+}
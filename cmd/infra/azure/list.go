@@ -0,0 +1,152 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+	"github.com/spf13/cobra"
+	"k8s.io/utils/ptr"
+
+	"github.com/openshift/hypershift/cmd/infra/cloudapi"
+	"github.com/openshift/hypershift/cmd/infra/inventory"
+	"github.com/openshift/hypershift/cmd/log"
+	"github.com/openshift/hypershift/cmd/util"
+)
+
+const (
+	// clusterTagPrefix is the prefix of the tag set on the resource groups created for the infrastructure of a
+	// cluster, followed by its infra ID. Azure tag names can't contain slashes.
+	clusterTagPrefix = "kubernetes.io_cluster."
+	clusterTagValue  = "owned"
+)
+
+func clusterTag(infraID string) string {
+	return clusterTagPrefix + infraID
+}
+
+type ListInfraOptions struct {
+	CredentialsFile string
+	Credentials     *util.AzureCreds
+	// Output is the format of the report, table or json.
+	Output string
+	// CloudAPI configures the logging, rate limiting and audit trail of the Azure API calls.
+	CloudAPI cloudapi.Options
+}
+
+func NewListCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "azure",
+		Short:        "Lists the Azure infrastructure of all clusters across resource groups and maps it to HostedClusters",
+		SilenceUsage: true,
+	}
+
+	opts := ListInfraOptions{
+		Output: inventory.OutputTable,
+	}
+
+	cmd.Flags().StringVar(&opts.CredentialsFile, "azure-creds", opts.CredentialsFile, "Path to a credentials file (required)")
+	cmd.Flags().StringVarP(&opts.Output, "output", "o", opts.Output, "The format of the report, table or json")
+	opts.CloudAPI.BindFlags(cmd.Flags())
+
+	_ = cmd.MarkFlagRequired("azure-creds")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		ctx, done, err := opts.CloudAPI.Start(cmd.Context(), log.Log)
+		if err != nil {
+			return err
+		}
+		defer done()
+		if err := opts.Run(ctx); err != nil {
+			log.Log.Error(err, "Failed to list infrastructure")
+			return err
+		}
+		return nil
+	}
+
+	return cmd
+}
+
+func (o *ListInfraOptions) Run(ctx context.Context) error {
+	c, err := util.GetClient()
+	if err != nil {
+		return err
+	}
+	resources, err := o.Resources(ctx)
+	if err != nil {
+		return err
+	}
+	report, err := inventory.Build(ctx, c, resources)
+	if err != nil {
+		return err
+	}
+	return inventory.Print(os.Stdout, report, o.Output)
+}
+
+// Resources lists the resource groups of the subscription tagged with the cluster tag of any infra ID, and the
+// resources in them.
+func (o *ListInfraOptions) Resources(ctx context.Context) ([]inventory.Resource, error) {
+	subscriptionID, azureCreds, err := util.SetupAzureCredentialsWithOptions(log.Log, o.Credentials, o.CredentialsFile, cloudapi.FromContext(ctx).AzureCredentialOptions())
+	if err != nil {
+		return nil, fmt.Errorf("failed to setup Azure credentials: %w", err)
+	}
+	resourceGroupClient, err := armresources.NewResourceGroupsClient(subscriptionID, azureCreds, cloudapi.FromContext(ctx).ARMClientOptions())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new resource groups client: %w", err)
+	}
+	resourcesClient, err := armresources.NewClient(subscriptionID, azureCreds, cloudapi.FromContext(ctx).ARMClientOptions())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new resources client: %w", err)
+	}
+
+	var resources []inventory.Resource
+	resourceGroupPager := resourceGroupClient.NewListPager(nil)
+	for resourceGroupPager.More() {
+		page, err := resourceGroupPager.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list resource groups: %w", err)
+		}
+		for _, resourceGroup := range page.Value {
+			infraID := clusterInfraID(resourceGroup.Tags)
+			if infraID == "" {
+				continue
+			}
+			resourceGroupName := ptr.Deref(resourceGroup.Name, "")
+			resources = append(resources, inventory.Resource{
+				InfraID:  infraID,
+				Location: ptr.Deref(resourceGroup.Location, ""),
+				Type:     ptr.Deref(resourceGroup.Type, "Microsoft.Resources/resourceGroups"),
+				ID:       resourceGroupName,
+			})
+
+			pager := resourcesClient.NewListByResourceGroupPager(resourceGroupName, nil)
+			for pager.More() {
+				page, err := pager.NextPage(ctx)
+				if err != nil {
+					return nil, fmt.Errorf("failed to list resources of resource group %s: %w", resourceGroupName, err)
+				}
+				for _, resource := range page.Value {
+					resources = append(resources, inventory.Resource{
+						InfraID:  infraID,
+						Location: resourceGroupName,
+						Type:     ptr.Deref(resource.Type, ""),
+						ID:       ptr.Deref(resource.Name, ""),
+					})
+				}
+			}
+		}
+	}
+	return resources, nil
+}
+
+// clusterInfraID returns the infra ID of the cluster tag in the tags, if any.
+func clusterInfraID(tags map[string]*string) string {
+	for key, value := range tags {
+		if strings.HasPrefix(key, clusterTagPrefix) && ptr.Deref(value, "") == clusterTagValue {
+			return strings.TrimPrefix(key, clusterTagPrefix)
+		}
+	}
+	return ""
+}
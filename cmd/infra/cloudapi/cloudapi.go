@@ -0,0 +1,342 @@
+// Package cloudapi implements the logging, rate limiting, audit trail and proxying of the cloud API calls made by the
+// infra commands. It is provider agnostic: AWS sessions are instrumented with request handlers, the other SDKs with an
+// http.RoundTripper.
+package cloudapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/go-logr/logr"
+	"github.com/spf13/pflag"
+	"golang.org/x/time/rate"
+)
+
+const (
+	ProviderAWS     = "aws"
+	ProviderAzure   = "azure"
+	ProviderPowerVS = "powervs"
+
+	ResultSuccess   = "Success"
+	ResultError     = "Error"
+	ResultThrottled = "Throttled"
+)
+
+// Options configures the auditing and rate limiting of the cloud API calls of an infra command.
+type Options struct {
+	// QPS is the maximum average number of cloud API calls per second, 0 means unlimited.
+	QPS float64
+	// Burst is the maximum number of cloud API calls above QPS in a burst.
+	Burst int
+	// AuditFile is the path of the file the audit trail is written to, one JSON object per call.
+	AuditFile string
+	// LogCalls logs every cloud API call.
+	LogCalls bool
+	// Proxy is the URL of the HTTP(S) or SOCKS5 proxy the cloud API calls go through. When unset, the calls go through
+	// the proxy of the HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables, if any.
+	Proxy string
+	// ProxyCABundle is the path of a PEM file of the certificate authorities to trust in addition to the system ones,
+	// e.g. the one of a TLS intercepting proxy.
+	ProxyCABundle string
+}
+
+func (o *Options) BindFlags(flags *pflag.FlagSet) {
+	flags.Float64Var(&o.QPS, "cloud-api-qps", o.QPS, "Maximum average number of cloud API calls per second, 0 means unlimited")
+	flags.IntVar(&o.Burst, "cloud-api-burst", o.Burst, "Maximum number of cloud API calls above --cloud-api-qps in a burst, defaults to 1")
+	flags.StringVar(&o.AuditFile, "cloud-api-audit-file", o.AuditFile, "Path of a file to write the audit trail of the cloud API calls to, as one JSON object per line")
+	flags.BoolVar(&o.LogCalls, "log-cloud-api-calls", o.LogCalls, "If true, the service, operation, duration and result of every cloud API call is logged")
+	flags.StringVar(&o.Proxy, "cloud-proxy", envOrDefault(ProxyEnvVar, o.Proxy), fmt.Sprintf("URL of the HTTP(S) or SOCKS5 proxy the cloud API calls go through, e.g. socks5://localhost:1080. Defaults to $%s, then to the proxy of $HTTPS_PROXY, $HTTP_PROXY and $NO_PROXY", ProxyEnvVar))
+	flags.StringVar(&o.ProxyCABundle, "cloud-proxy-ca-bundle", envOrDefault(ProxyCABundleEnvVar, o.ProxyCABundle), fmt.Sprintf("Path of a PEM file of certificate authorities to trust for the cloud API calls in addition to the system ones, e.g. of a TLS intercepting proxy. Defaults to $%s", ProxyCABundleEnvVar))
+}
+
+func (o *Options) Validate() error {
+	if o.QPS < 0 {
+		return fmt.Errorf("--cloud-api-qps must not be negative")
+	}
+	if o.Burst < 0 {
+		return fmt.Errorf("--cloud-api-burst must not be negative")
+	}
+	if o.Burst > 0 && o.QPS == 0 {
+		return fmt.Errorf("--cloud-api-burst requires --cloud-api-qps")
+	}
+	if o.Proxy != "" {
+		if err := validateProxyURL(o.Proxy); err != nil {
+			return fmt.Errorf("invalid --cloud-proxy: %w", err)
+		}
+	}
+	return nil
+}
+
+// Start validates the options and returns a context carrying the Auditor of the cloud API calls, along with a
+// function closing it. The context carries no Auditor when neither logging, rate limiting, the audit trail nor a proxy
+// is enabled.
+func (o *Options) Start(ctx context.Context, log logr.Logger) (context.Context, func(), error) {
+	if err := o.Validate(); err != nil {
+		return nil, nil, err
+	}
+	if o.QPS == 0 && o.AuditFile == "" && !o.LogCalls && o.Proxy == "" && o.ProxyCABundle == "" {
+		return ctx, func() {}, nil
+	}
+	auditor, err := NewAuditor(*o, log)
+	if err != nil {
+		return nil, nil, err
+	}
+	return WithAuditor(ctx, auditor), func() {
+		if err := auditor.Close(); err != nil {
+			log.Error(err, "Failed to close the cloud API audit file")
+		}
+	}, nil
+}
+
+// Call is a cloud API call, as written to the audit trail.
+type Call struct {
+	Time            time.Time `json:"time"`
+	Provider        string    `json:"provider"`
+	Service         string    `json:"service"`
+	Operation       string    `json:"operation"`
+	DurationSeconds float64   `json:"durationSeconds"`
+	Result          string    `json:"result"`
+	StatusCode      int       `json:"statusCode,omitempty"`
+	Error           string    `json:"error,omitempty"`
+}
+
+// Auditor logs, rate limits, records and proxies cloud API calls. A nil *Auditor does nothing, so callers don't need to
+// check whether auditing is enabled.
+type Auditor struct {
+	log      logr.Logger
+	logCalls bool
+	limiter  *rate.Limiter
+	// transport sends the cloud API calls through the proxy, nil when none is configured.
+	transport *http.Transport
+
+	lock  sync.Mutex
+	trail *os.File
+}
+
+func NewAuditor(opts Options, log logr.Logger) (*Auditor, error) {
+	a := &Auditor{
+		log:      log,
+		logCalls: opts.LogCalls,
+	}
+	if opts.QPS > 0 {
+		a.limiter = rate.NewLimiter(rate.Limit(opts.QPS), max(opts.Burst, 1))
+	}
+	transport, err := newProxyTransport(opts.Proxy, opts.ProxyCABundle)
+	if err != nil {
+		return nil, err
+	}
+	a.transport = transport
+	if opts.AuditFile != "" {
+		trail, err := os.OpenFile(opts.AuditFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open cloud API audit file: %w", err)
+		}
+		a.trail = trail
+	}
+	return a, nil
+}
+
+func (a *Auditor) Close() error {
+	if a == nil || a.trail == nil {
+		return nil
+	}
+	return a.trail.Close()
+}
+
+// Wait blocks until the rate limit allows another cloud API call.
+func (a *Auditor) Wait(ctx context.Context) error {
+	if a == nil || a.limiter == nil {
+		return nil
+	}
+	return a.limiter.Wait(ctx)
+}
+
+// Record logs a cloud API call and appends it to the audit trail.
+func (a *Auditor) Record(call Call) {
+	if a == nil {
+		return
+	}
+	if a.logCalls {
+		keysAndValues := []any{"provider", call.Provider, "service", call.Service, "operation", call.Operation, "duration", time.Duration(call.DurationSeconds * float64(time.Second)).String(), "result", call.Result}
+		if call.StatusCode != 0 {
+			keysAndValues = append(keysAndValues, "statusCode", call.StatusCode)
+		}
+		if call.Error != "" {
+			keysAndValues = append(keysAndValues, "error", call.Error)
+		}
+		a.log.Info("Cloud API call", keysAndValues...)
+	}
+	if a.trail == nil {
+		return
+	}
+	line, err := json.Marshal(call)
+	if err != nil {
+		a.log.Error(err, "Failed to serialize cloud API call")
+		return
+	}
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if _, err := a.trail.Write(append(line, '\n')); err != nil {
+		a.log.Error(err, "Failed to write cloud API audit file")
+	}
+}
+
+type contextKey struct{}
+
+func WithAuditor(ctx context.Context, a *Auditor) context.Context {
+	return context.WithValue(ctx, contextKey{}, a)
+}
+
+// FromContext returns the Auditor of the context, nil if there is none.
+func FromContext(ctx context.Context) *Auditor {
+	a, _ := ctx.Value(contextKey{}).(*Auditor)
+	return a
+}
+
+// InstrumentAWSSession makes every attempt of the requests of the clients created from the session wait for the rate
+// limit, get recorded and go through the proxy.
+func (a *Auditor) InstrumentAWSSession(sess *session.Session) {
+	if a == nil {
+		return
+	}
+	if a.transport != nil {
+		sess.Config.HTTPClient = &http.Client{Transport: a.transport}
+	}
+	sess.Handlers.Send.PushFrontNamed(request.NamedHandler{
+		Name: "hypershift.cloudapi.Wait",
+		Fn: func(r *request.Request) {
+			if err := a.Wait(r.Context()); err != nil {
+				r.Error = awserr.New(request.CanceledErrorCode, "rate limiter wait canceled", err)
+			}
+		},
+	})
+	sess.Handlers.CompleteAttempt.PushBackNamed(request.NamedHandler{
+		Name: "hypershift.cloudapi.Record",
+		Fn: func(r *request.Request) {
+			call := Call{
+				Time:            r.AttemptTime,
+				Provider:        ProviderAWS,
+				Service:         r.ClientInfo.ServiceName,
+				Operation:       r.Operation.Name,
+				DurationSeconds: time.Since(r.AttemptTime).Seconds(),
+				Result:          ResultSuccess,
+			}
+			if r.HTTPResponse != nil {
+				call.StatusCode = r.HTTPResponse.StatusCode
+			}
+			if r.Error != nil {
+				call.Result = ResultError
+				if request.IsErrorThrottle(r.Error) {
+					call.Result = ResultThrottled
+				}
+				call.Error = r.Error.Error()
+			}
+			a.Record(call)
+		},
+	})
+}
+
+// RoundTripper wraps the transport of an HTTP based SDK client. The host of a request is recorded as its service, its
+// method and path as its operation. A nil next uses http.DefaultTransport. When a proxy is configured, next is
+// replaced by the transport going through it.
+func (a *Auditor) RoundTripper(provider string, next http.RoundTripper) http.RoundTripper {
+	if a != nil && a.transport != nil {
+		next = a.transport
+	}
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	if a == nil {
+		return next
+	}
+	return &roundTripper{auditor: a, provider: provider, next: next}
+}
+
+// HTTPClient returns an http.Client using RoundTripper, nil if a is nil.
+func (a *Auditor) HTTPClient(provider string) *http.Client {
+	if a == nil {
+		return nil
+	}
+	return &http.Client{Transport: a.RoundTripper(provider, nil)}
+}
+
+// ARMClientOptions returns the options of the Azure Resource Manager clients, nil if a is nil.
+func (a *Auditor) ARMClientOptions() *arm.ClientOptions {
+	if a == nil {
+		return nil
+	}
+	return &arm.ClientOptions{ClientOptions: policy.ClientOptions{Transport: a.HTTPClient(ProviderAzure)}}
+}
+
+// AzureCredentialOptions returns the options of the Azure credentials, so the tokens are requested through the proxy.
+// It's nil if a is nil or no proxy is configured.
+func (a *Auditor) AzureCredentialOptions() *azidentity.DefaultAzureCredentialOptions {
+	if a == nil || a.transport == nil {
+		return nil
+	}
+	return &azidentity.DefaultAzureCredentialOptions{ClientOptions: policy.ClientOptions{Transport: &http.Client{Transport: a.transport}}}
+}
+
+// InstrumentIBMService wraps the transport of an IBM Cloud SDK service. When a proxy is configured, the IAM tokens of
+// the service are requested through it too.
+func (a *Auditor) InstrumentIBMService(service *core.BaseService) {
+	if a == nil || service == nil {
+		return
+	}
+	client := service.GetHTTPClient()
+	client.Transport = a.RoundTripper(ProviderPowerVS, client.Transport)
+	if a.transport == nil || service.Options == nil {
+		return
+	}
+	if authenticator, ok := service.Options.Authenticator.(*core.IamAuthenticator); ok {
+		authenticator.Client = &http.Client{Transport: a.transport, Timeout: iamTokenRequestTimeout}
+	}
+}
+
+type roundTripper struct {
+	auditor  *Auditor
+	provider string
+	next     http.RoundTripper
+}
+
+func (t *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.auditor.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+	call := Call{
+		Time:      time.Now(),
+		Provider:  t.provider,
+		Service:   req.URL.Host,
+		Operation: req.Method + " " + req.URL.Path,
+		Result:    ResultSuccess,
+	}
+	resp, err := t.next.RoundTrip(req)
+	call.DurationSeconds = time.Since(call.Time).Seconds()
+	switch {
+	case err != nil:
+		call.Result = ResultError
+		call.Error = err.Error()
+	case resp.StatusCode == http.StatusTooManyRequests:
+		call.StatusCode = resp.StatusCode
+		call.Result = ResultThrottled
+	case resp.StatusCode >= http.StatusBadRequest:
+		call.StatusCode = resp.StatusCode
+		call.Result = ResultError
+	default:
+		call.StatusCode = resp.StatusCode
+	}
+	t.auditor.Record(call)
+	return resp, err
+}
@@ -0,0 +1,175 @@
+package cloudapi
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/go-logr/logr"
+)
+
+func TestOptionsValidate(t *testing.T) {
+	testCases := []struct {
+		name        string
+		opts        Options
+		expectError bool
+	}{
+		{
+			name: "When nothing is set it should be valid",
+		},
+		{
+			name: "When qps and burst are set it should be valid",
+			opts: Options{QPS: 5, Burst: 10},
+		},
+		{
+			name:        "When qps is negative it should be invalid",
+			opts:        Options{QPS: -1},
+			expectError: true,
+		},
+		{
+			name:        "When burst is set without qps it should be invalid",
+			opts:        Options{Burst: 10},
+			expectError: true,
+		},
+		{
+			name: "When the proxy is a socks5 URL it should be valid",
+			opts: Options{Proxy: "socks5://localhost:1080"},
+		},
+		{
+			name:        "When the proxy has an unsupported scheme it should be invalid",
+			opts:        Options{Proxy: "ftp://proxy.example.com"},
+			expectError: true,
+		},
+		{
+			name:        "When the proxy has no host it should be invalid",
+			opts:        Options{Proxy: "http://"},
+			expectError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := tc.opts.Validate()
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestNilAuditor(t *testing.T) {
+	g := NewWithT(t)
+
+	var a *Auditor
+	g.Expect(a.RoundTripper(ProviderAzure, nil)).To(Equal(http.DefaultTransport))
+	g.Expect(a.ARMClientOptions()).To(BeNil())
+	g.Expect(a.Wait(context.Background())).To(Succeed())
+	g.Expect(a.Close()).To(Succeed())
+	a.Record(Call{})
+	g.Expect(FromContext(context.Background())).To(BeNil())
+}
+
+func TestRoundTripper(t *testing.T) {
+	g := NewWithT(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/throttled":
+			w.WriteHeader(http.StatusTooManyRequests)
+		case "/error":
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	auditFile := filepath.Join(t.TempDir(), "audit.jsonl")
+	a, err := NewAuditor(Options{QPS: 100, AuditFile: auditFile}, logr.Discard())
+	g.Expect(err).ToNot(HaveOccurred())
+	client := &http.Client{Transport: a.RoundTripper(ProviderAzure, nil)}
+	for _, path := range []string{"/ok", "/throttled", "/error"} {
+		resp, err := client.Get(server.URL + path)
+		g.Expect(err).ToNot(HaveOccurred())
+		resp.Body.Close()
+	}
+	g.Expect(a.Close()).To(Succeed())
+
+	calls := readAuditFile(g, auditFile)
+	g.Expect(calls).To(HaveLen(3))
+	for i, expected := range []struct {
+		operation  string
+		result     string
+		statusCode int
+	}{
+		{"GET /ok", ResultSuccess, http.StatusOK},
+		{"GET /throttled", ResultThrottled, http.StatusTooManyRequests},
+		{"GET /error", ResultError, http.StatusInternalServerError},
+	} {
+		g.Expect(calls[i].Provider).To(Equal(ProviderAzure))
+		g.Expect(calls[i].Service).To(Equal(server.Listener.Addr().String()))
+		g.Expect(calls[i].Operation).To(Equal(expected.operation))
+		g.Expect(calls[i].Result).To(Equal(expected.result))
+		g.Expect(calls[i].StatusCode).To(Equal(expected.statusCode))
+	}
+}
+
+func TestInstrumentAWSSession(t *testing.T) {
+	g := NewWithT(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/xml")
+		_, _ = w.Write([]byte(`<DescribeVpcsResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/"><vpcSet/></DescribeVpcsResponse>`))
+	}))
+	defer server.Close()
+
+	auditFile := filepath.Join(t.TempDir(), "audit.jsonl")
+	a, err := NewAuditor(Options{AuditFile: auditFile}, logr.Discard())
+	g.Expect(err).ToNot(HaveOccurred())
+	sess, err := session.NewSession(&aws.Config{
+		Region:      aws.String("us-east-1"),
+		Endpoint:    aws.String(server.URL),
+		Credentials: credentials.NewStaticCredentials("key", "secret", ""),
+		MaxRetries:  aws.Int(0),
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	a.InstrumentAWSSession(sess)
+
+	_, err = ec2.New(sess).DescribeVpcs(&ec2.DescribeVpcsInput{})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(a.Close()).To(Succeed())
+
+	calls := readAuditFile(g, auditFile)
+	g.Expect(calls).To(HaveLen(1))
+	g.Expect(calls[0].Provider).To(Equal(ProviderAWS))
+	g.Expect(calls[0].Service).To(Equal("ec2"))
+	g.Expect(calls[0].Operation).To(Equal("DescribeVpcs"))
+	g.Expect(calls[0].Result).To(Equal(ResultSuccess))
+	g.Expect(calls[0].StatusCode).To(Equal(http.StatusOK))
+}
+
+func readAuditFile(g Gomega, path string) []Call {
+	f, err := os.Open(path)
+	g.Expect(err).ToNot(HaveOccurred())
+	defer f.Close()
+	var calls []Call
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var call Call
+		g.Expect(json.Unmarshal(scanner.Bytes(), &call)).To(Succeed())
+		calls = append(calls, call)
+	}
+	g.Expect(scanner.Err()).ToNot(HaveOccurred())
+	return calls
+}
@@ -0,0 +1,87 @@
+package cloudapi
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+const (
+	// ProxyEnvVar is the environment variable defaulting --cloud-proxy.
+	ProxyEnvVar = "HYPERSHIFT_CLOUD_PROXY"
+	// ProxyCABundleEnvVar is the environment variable defaulting --cloud-proxy-ca-bundle.
+	ProxyCABundleEnvVar = "HYPERSHIFT_CLOUD_PROXY_CA_BUNDLE"
+
+	// iamTokenRequestTimeout matches the timeout of the default client of the IBM Cloud IAM authenticator.
+	iamTokenRequestTimeout = 30 * time.Second
+)
+
+func envOrDefault(name, defaultValue string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+func validateProxyURL(proxy string) error {
+	proxyURL, err := url.Parse(proxy)
+	if err != nil {
+		return err
+	}
+	switch proxyURL.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return fmt.Errorf("unsupported scheme %q, must be http, https or socks5", proxyURL.Scheme)
+	}
+	if proxyURL.Host == "" {
+		return fmt.Errorf("%q has no host", proxy)
+	}
+	return nil
+}
+
+// newProxyTransport returns the transport of the cloud API calls going through the proxy and trusting the certificate
+// authorities of the CA bundle in addition to the system ones, nil if neither is set. Without a proxy, the transport
+// uses the proxy of the environment, as http.DefaultTransport. The hosts of NO_PROXY are never proxied.
+func newProxyTransport(proxy, caBundle string) (*http.Transport, error) {
+	if proxy == "" && caBundle == "" {
+		return nil, nil
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if proxy != "" {
+		if err := validateProxyURL(proxy); err != nil {
+			return nil, fmt.Errorf("invalid cloud proxy: %w", err)
+		}
+		proxyFunc := (&httpproxy.Config{
+			HTTPProxy:  proxy,
+			HTTPSProxy: proxy,
+			NoProxy:    envOrDefault("NO_PROXY", os.Getenv("no_proxy")),
+		}).ProxyFunc()
+		transport.Proxy = func(req *http.Request) (*url.URL, error) {
+			return proxyFunc(req.URL)
+		}
+	}
+	if caBundle != "" {
+		pem, err := os.ReadFile(caBundle)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the cloud proxy CA bundle: %w", err)
+		}
+		roots, err := x509.SystemCertPool()
+		if err != nil {
+			roots = x509.NewCertPool()
+		}
+		if !roots.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificate found in the cloud proxy CA bundle %s", caBundle)
+		}
+		transport.TLSClientConfig = &tls.Config{
+			RootCAs:    roots,
+			MinVersion: tls.VersionTLS12,
+		}
+	}
+	return transport, nil
+}
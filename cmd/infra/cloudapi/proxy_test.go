@@ -0,0 +1,92 @@
+package cloudapi
+
+import (
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/go-logr/logr"
+)
+
+func TestProxy(t *testing.T) {
+	t.Run("When a proxy is configured it should send the cloud API calls through it", func(t *testing.T) {
+		g := NewWithT(t)
+		t.Setenv("NO_PROXY", "")
+		var proxied []string
+		proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			proxied = append(proxied, r.URL.String())
+			_, _ = io.WriteString(w, "proxied")
+		}))
+		defer proxy.Close()
+
+		a, err := NewAuditor(Options{Proxy: proxy.URL}, logr.Discard())
+		g.Expect(err).ToNot(HaveOccurred())
+		resp, err := a.HTTPClient(ProviderAzure).Get("http://management.azure.example.com/subscriptions")
+		g.Expect(err).ToNot(HaveOccurred())
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(string(body)).To(Equal("proxied"))
+		g.Expect(proxied).To(ConsistOf("http://management.azure.example.com/subscriptions"))
+		g.Expect(a.AzureCredentialOptions()).ToNot(BeNil())
+	})
+
+	t.Run("When a host is in NO_PROXY it should not send its calls through the proxy", func(t *testing.T) {
+		g := NewWithT(t)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = io.WriteString(w, "direct")
+		}))
+		defer server.Close()
+		t.Setenv("NO_PROXY", "127.0.0.1")
+
+		a, err := NewAuditor(Options{Proxy: "http://proxy.invalid:3128"}, logr.Discard())
+		g.Expect(err).ToNot(HaveOccurred())
+		resp, err := a.HTTPClient(ProviderAzure).Get(server.URL)
+		g.Expect(err).ToNot(HaveOccurred())
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(string(body)).To(Equal("direct"))
+	})
+
+	t.Run("When a CA bundle is configured it should trust its certificate authorities", func(t *testing.T) {
+		g := NewWithT(t)
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		defer server.Close()
+
+		a, err := NewAuditor(Options{}, logr.Discard())
+		g.Expect(err).ToNot(HaveOccurred())
+		_, err = (&http.Client{Transport: a.RoundTripper(ProviderPowerVS, nil)}).Get(server.URL)
+		g.Expect(err).To(HaveOccurred())
+
+		caBundle := filepath.Join(t.TempDir(), "ca.pem")
+		g.Expect(os.WriteFile(caBundle, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0600)).To(Succeed())
+		a, err = NewAuditor(Options{ProxyCABundle: caBundle}, logr.Discard())
+		g.Expect(err).ToNot(HaveOccurred())
+		resp, err := (&http.Client{Transport: a.RoundTripper(ProviderPowerVS, nil)}).Get(server.URL)
+		g.Expect(err).ToNot(HaveOccurred())
+		resp.Body.Close()
+	})
+
+	t.Run("When the CA bundle has no certificate it should fail", func(t *testing.T) {
+		g := NewWithT(t)
+		caBundle := filepath.Join(t.TempDir(), "ca.pem")
+		g.Expect(os.WriteFile(caBundle, []byte("not a certificate"), 0600)).To(Succeed())
+		_, err := NewAuditor(Options{ProxyCABundle: caBundle}, logr.Discard())
+		g.Expect(err).To(HaveOccurred())
+	})
+
+	t.Run("When no proxy is configured it should not change the transports", func(t *testing.T) {
+		g := NewWithT(t)
+		a, err := NewAuditor(Options{LogCalls: true}, logr.Discard())
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(a.AzureCredentialOptions()).To(BeNil())
+		g.Expect(a.RoundTripper(ProviderAzure, nil).(*roundTripper).next).To(Equal(http.DefaultTransport))
+	})
+}
@@ -0,0 +1,121 @@
+// Package inventory maps the cloud resources created by HyperShift for the infrastructure of clusters to the
+// HostedClusters they belong to.
+package inventory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	OutputTable = "table"
+	OutputJSON  = "json"
+)
+
+// Resource is a cloud resource tagged as owned by the infrastructure of a cluster.
+type Resource struct {
+	// InfraID is the infra ID the resource is tagged with.
+	InfraID string `json:"-"`
+	// Location is where the resource lives, e.g. the AWS region or the Azure resource group.
+	Location string `json:"location"`
+	// Type is the type of the resource, e.g. ec2:vpc or Microsoft.Network/virtualNetworks.
+	Type string `json:"type"`
+	// ID is the identifier of the resource.
+	ID string `json:"id"`
+}
+
+// Cluster groups the resources of an infra ID, along with the HostedCluster using it.
+type Cluster struct {
+	InfraID string `json:"infraID"`
+	// HostedCluster is the namespace/name of the HostedCluster with the infra ID, if any.
+	HostedCluster string `json:"hostedCluster,omitempty"`
+	// Orphaned is true when no HostedCluster of the management cluster has the infra ID.
+	Orphaned  bool       `json:"orphaned"`
+	Resources []Resource `json:"resources"`
+}
+
+// Report is the inventory of the infrastructure of all clusters found in the cloud account.
+type Report struct {
+	Clusters []Cluster `json:"clusters"`
+}
+
+// Build groups the resources by infra ID, and maps each infra ID to the HostedCluster using it. Infra IDs no
+// HostedCluster uses are flagged as orphaned.
+func Build(ctx context.Context, c client.Client, resources []Resource) (*Report, error) {
+	hostedClusters := &hyperv1.HostedClusterList{}
+	if err := c.List(ctx, hostedClusters); err != nil {
+		return nil, fmt.Errorf("failed to list hostedclusters: %w", err)
+	}
+	hostedClusterByInfraID := map[string]string{}
+	for _, hostedCluster := range hostedClusters.Items {
+		if hostedCluster.Spec.InfraID != "" {
+			hostedClusterByInfraID[hostedCluster.Spec.InfraID] = client.ObjectKeyFromObject(&hostedCluster).String()
+		}
+	}
+
+	clusters := map[string]*Cluster{}
+	for _, resource := range resources {
+		cluster, ok := clusters[resource.InfraID]
+		if !ok {
+			hostedCluster, found := hostedClusterByInfraID[resource.InfraID]
+			cluster = &Cluster{InfraID: resource.InfraID, HostedCluster: hostedCluster, Orphaned: !found}
+			clusters[resource.InfraID] = cluster
+		}
+		cluster.Resources = append(cluster.Resources, resource)
+	}
+
+	report := &Report{Clusters: []Cluster{}}
+	for _, cluster := range clusters {
+		sort.SliceStable(cluster.Resources, func(i, j int) bool {
+			a, b := cluster.Resources[i], cluster.Resources[j]
+			if a.Location != b.Location {
+				return a.Location < b.Location
+			}
+			if a.Type != b.Type {
+				return a.Type < b.Type
+			}
+			return a.ID < b.ID
+		})
+		report.Clusters = append(report.Clusters, *cluster)
+	}
+	sort.Slice(report.Clusters, func(i, j int) bool {
+		return report.Clusters[i].InfraID < report.Clusters[j].InfraID
+	})
+	return report, nil
+}
+
+// Print writes the report as a table or as JSON.
+func Print(out io.Writer, report *Report, output string) error {
+	switch output {
+	case OutputJSON:
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	case OutputTable:
+	default:
+		return fmt.Errorf("unsupported output format %q", output)
+	}
+
+	orphaned := 0
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(w, "INFRA ID\tHOSTED CLUSTER\tLOCATION\tTYPE\tID\n")
+	for _, cluster := range report.Clusters {
+		hostedCluster := cluster.HostedCluster
+		if cluster.Orphaned {
+			hostedCluster = "<orphaned>"
+			orphaned++
+		}
+		for _, resource := range cluster.Resources {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", cluster.InfraID, hostedCluster, resource.Location, resource.Type, resource.ID)
+		}
+	}
+	fmt.Fprintf(w, "\nFound infrastructure of %d clusters, %d orphaned\n", len(report.Clusters), orphaned)
+	return w.Flush()
+}
@@ -0,0 +1,83 @@
+package inventory
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/support/api"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestBuild(t *testing.T) {
+	g := NewWithT(t)
+
+	hostedCluster := &hyperv1.HostedCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "example"},
+		Spec:       hyperv1.HostedClusterSpec{InfraID: "example-abcde"},
+	}
+	c := fake.NewClientBuilder().WithScheme(api.Scheme).WithObjects(hostedCluster).Build()
+
+	resources := []Resource{
+		{InfraID: "example-abcde", Location: "us-west-2", Type: "ec2:vpc", ID: "vpc-2"},
+		{InfraID: "leftover-fghij", Location: "us-east-1", Type: "ec2:subnet", ID: "subnet-1"},
+		{InfraID: "example-abcde", Location: "us-east-1", Type: "ec2:vpc", ID: "vpc-1"},
+		{InfraID: "example-abcde", Location: "us-east-1", Type: "ec2:subnet", ID: "subnet-2"},
+	}
+
+	report, err := Build(context.Background(), c, resources)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(report.Clusters).To(Equal([]Cluster{
+		{
+			InfraID:       "example-abcde",
+			HostedCluster: "clusters/example",
+			Resources: []Resource{
+				{InfraID: "example-abcde", Location: "us-east-1", Type: "ec2:subnet", ID: "subnet-2"},
+				{InfraID: "example-abcde", Location: "us-east-1", Type: "ec2:vpc", ID: "vpc-1"},
+				{InfraID: "example-abcde", Location: "us-west-2", Type: "ec2:vpc", ID: "vpc-2"},
+			},
+		},
+		{
+			InfraID:  "leftover-fghij",
+			Orphaned: true,
+			Resources: []Resource{
+				{InfraID: "leftover-fghij", Location: "us-east-1", Type: "ec2:subnet", ID: "subnet-1"},
+			},
+		},
+	}))
+}
+
+func TestPrint(t *testing.T) {
+	report := &Report{Clusters: []Cluster{
+		{InfraID: "example-abcde", HostedCluster: "clusters/example", Resources: []Resource{{Location: "us-east-1", Type: "ec2:vpc", ID: "vpc-1"}}},
+		{InfraID: "leftover-fghij", Orphaned: true, Resources: []Resource{{Location: "us-east-1", Type: "ec2:subnet", ID: "subnet-1"}}},
+	}}
+
+	t.Run("When printing a table it should flag orphaned infrastructure", func(t *testing.T) {
+		g := NewWithT(t)
+		out := &bytes.Buffer{}
+		g.Expect(Print(out, report, OutputTable)).To(Succeed())
+		g.Expect(out.String()).To(ContainSubstring("clusters/example"))
+		g.Expect(out.String()).To(MatchRegexp(`leftover-fghij\s+<orphaned>\s+us-east-1\s+ec2:subnet\s+subnet-1`))
+		g.Expect(out.String()).To(ContainSubstring("Found infrastructure of 2 clusters, 1 orphaned"))
+	})
+
+	t.Run("When printing JSON it should encode the report", func(t *testing.T) {
+		g := NewWithT(t)
+		out := &bytes.Buffer{}
+		g.Expect(Print(out, report, OutputJSON)).To(Succeed())
+		decoded := &Report{}
+		g.Expect(json.Unmarshal(out.Bytes(), decoded)).To(Succeed())
+		g.Expect(decoded.Clusters).To(HaveLen(2))
+		g.Expect(decoded.Clusters[1].Orphaned).To(BeTrue())
+	})
+
+	t.Run("When the output format is unsupported it should fail", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(Print(&bytes.Buffer{}, report, "yaml")).ToNot(Succeed())
+	})
+}
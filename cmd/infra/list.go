@@ -0,0 +1,20 @@
+package infra
+
+import (
+	"github.com/openshift/hypershift/cmd/infra/aws"
+	"github.com/openshift/hypershift/cmd/infra/azure"
+	"github.com/spf13/cobra"
+)
+
+func NewListCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "infra",
+		Short:        "Lists the cloud infrastructure of all HyperShift clusters and maps it to HostedClusters",
+		SilenceUsage: true,
+	}
+
+	cmd.AddCommand(aws.NewListCommand())
+	cmd.AddCommand(azure.NewListCommand())
+
+	return cmd
+}
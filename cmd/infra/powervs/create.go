@@ -30,6 +30,7 @@ import (
 	"github.com/IBM/platform-services-go-sdk/resourcemanagerv2"
 	"github.com/IBM/vpc-go-sdk/vpcv1"
 
+	"github.com/openshift/hypershift/cmd/infra/cloudapi"
 	hypershiftLog "github.com/openshift/hypershift/cmd/log"
 )
 
@@ -103,6 +104,9 @@ type CreateInfraOptions struct {
 	PER                    bool
 	TransitGatewayLocation string
 	TransitGateway         string
+	// CloudAPI configures the logging, rate limiting and audit trail of the IBM Cloud API calls. The PowerVS API calls
+	// are not covered, they are logged with Debug.
+	CloudAPI cloudapi.Options
 }
 
 type TimeDuration struct {
@@ -231,6 +235,7 @@ func NewCreateCommand() *cobra.Command {
 	cmd.Flags().BoolVar(&opts.PER, "power-edge-router", opts.PER, "Enabling this flag will utilize Power Edge Router solution via transit gateway instead of cloud connection to create a connection between PowerVS and VPC")
 	cmd.Flags().StringVar(&opts.TransitGatewayLocation, "transit-gateway-location", opts.TransitGatewayLocation, "IBM Cloud Transit Gateway location")
 	cmd.Flags().StringVar(&opts.TransitGateway, "transit-gateway", opts.TransitGateway, "IBM Cloud Transit Gateway. Use this flag to reuse an existing Transit Gateway resource for cluster's infra")
+	opts.CloudAPI.BindFlags(cmd.Flags())
 
 	// these options are only for development and testing purpose,
 	// can use these to reuse the existing resources, so hiding it.
@@ -244,7 +249,12 @@ func NewCreateCommand() *cobra.Command {
 	cmd.MarkFlagRequired("infra-id")
 
 	cmd.RunE = func(cmd *cobra.Command, args []string) error {
-		if err := opts.Run(cmd.Context()); err != nil {
+		ctx, done, err := opts.CloudAPI.Start(cmd.Context(), log(opts.InfraID))
+		if err != nil {
+			return err
+		}
+		defer done()
+		if err := opts.Run(ctx); err != nil {
 			log(opts.InfraID).Error(err, "Failed to create infrastructure")
 			return err
 		}
@@ -366,11 +376,13 @@ func (infra *Infra) SetupInfra(ctx context.Context, options *CreateInfraOptions)
 	if err != nil {
 		return err
 	}
+	cloudapi.FromContext(ctx).InstrumentIBMService(gtag.Service)
 
 	v1, err := createVpcService(options.VPCRegion, options.InfraID)
 	if err != nil {
 		return fmt.Errorf("error creating vpc service: %w", err)
 	}
+	cloudapi.FromContext(ctx).InstrumentIBMService(v1.Service)
 
 	if err := infra.setupVpc(ctx, options, v1, gtag); err != nil {
 		return fmt.Errorf("error setup vpc: %w", err)
@@ -504,6 +516,7 @@ func getCISDomainDetails(ctx context.Context, baseDomain string) (string, string
 	if err != nil {
 		return "", "", err
 	}
+	cloudapi.FromContext(ctx).InstrumentIBMService(rcv2.Service)
 
 	if rcv2 == nil {
 		return "", "", fmt.Errorf("unable to get resource controller")
@@ -542,6 +555,7 @@ func getCISDomainDetails(ctx context.Context, baseDomain string) (string, string
 			if err != nil {
 				continue
 			}
+			cloudapi.FromContext(ctx).InstrumentIBMService(zv1.Service)
 			if zv1 == nil {
 				continue
 			}
@@ -589,6 +603,7 @@ func checkForExistingDNSRecord(ctx context.Context, options *CreateInfraOptions,
 	if err != nil {
 		return fmt.Errorf("error creating dns record client: %w", err)
 	}
+	cloudapi.FromContext(ctx).InstrumentIBMService(dnsRecordsV1.Service)
 
 	recordName := fmt.Sprintf("*.apps.%s.%s", options.Name, options.BaseDomain)
 	listDnsRecordsOpt := &dnsrecordsv1.ListAllDnsRecordsOptions{Name: &recordName}
@@ -633,6 +648,7 @@ func getServiceInfo(ctx context.Context, service string, servicePlan string) (st
 	if err != nil {
 		return "", "", err
 	}
+	cloudapi.FromContext(ctx).InstrumentIBMService(gcv1.Service)
 
 	if gcv1 == nil {
 		return "", "", fmt.Errorf("unable to get global catalog")
@@ -700,6 +716,7 @@ func getResourceGroupID(ctx context.Context, resourceGroup string, accountID str
 	if err != nil {
 		return "", err
 	}
+	cloudapi.FromContext(ctx).InstrumentIBMService(rmv2.Service)
 
 	if rmv2 == nil {
 		return "", fmt.Errorf("unable to get resource controller")
@@ -732,6 +749,7 @@ func (infra *Infra) createCloudInstance(ctx context.Context, options *CreateInfr
 	if err != nil {
 		return nil, err
 	}
+	cloudapi.FromContext(ctx).InstrumentIBMService(rcv2.Service)
 
 	if rcv2 == nil {
 		return nil, fmt.Errorf("unable to get resource controller")
@@ -815,6 +833,7 @@ func getAccount(ctx context.Context, auth core.Authenticator) (string, error) {
 	if err != nil {
 		return "", err
 	}
+	cloudapi.FromContext(ctx).InstrumentIBMService(iamv1.Service)
 
 	apiKeyDetailsOpt := iamidentityv1.GetAPIKeysDetailsOptions{IamAPIKey: &cloudApiKey}
 	apiKey, _, err := iamv1.GetAPIKeysDetailsWithContext(ctx, &apiKeyDetailsOpt)
@@ -1165,6 +1184,7 @@ func (infra *Infra) setupPowerVSCloudConnection(ctx context.Context, options *Cr
 	if err != nil {
 		return err
 	}
+	cloudapi.FromContext(ctx).InstrumentIBMService(directLinkV1.Service)
 
 	gwIntf, resp, err := directLinkV1.GetGateway(&directlinkv1.GetGatewayOptions{ID: &infra.CloudConnectionID})
 	if err != nil || resp.StatusCode != 200 {
@@ -1436,6 +1456,7 @@ func (infra *Infra) isCloudConnectionReady(ctx context.Context, options *CreateI
 	if err != nil {
 		return err
 	}
+	cloudapi.FromContext(ctx).InstrumentIBMService(directLinkV1.Service)
 
 	f := func() (bool, error) {
 		cloudConn, err = client.Get(infra.CloudConnectionID)
@@ -1505,6 +1526,7 @@ func (infra *Infra) setupTransitGateway(ctx context.Context, options *CreateInfr
 	if err != nil {
 		return err
 	}
+	cloudapi.FromContext(ctx).InstrumentIBMService(tgapisv1.Service)
 	var transitGateway *transitgatewayapisv1.TransitGateway
 	if options.TransitGateway != "" {
 		log(options.InfraID).Info("Validating Transit Gateway", "name", options.TransitGateway)
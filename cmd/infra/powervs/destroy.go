@@ -33,6 +33,8 @@ import (
 	"github.com/IBM/platform-services-go-sdk/resourcecontrollerv2"
 	"github.com/IBM/vpc-go-sdk/vpcv1"
 	regionutils "github.com/ppc64le-cloud/powervs-utils"
+
+	"github.com/openshift/hypershift/cmd/infra/cloudapi"
 )
 
 const (
@@ -76,6 +78,9 @@ type DestroyInfraOptions struct {
 	PER                    bool
 	TransitGatewayLocation string
 	TransitGateway         string
+	// CloudAPI configures the logging, rate limiting and audit trail of the IBM Cloud API calls. The PowerVS API calls
+	// are not covered, they are logged with Debug.
+	CloudAPI cloudapi.Options
 }
 
 func NewDestroyCommand() *cobra.Command {
@@ -106,6 +111,7 @@ func NewDestroyCommand() *cobra.Command {
 	cmd.Flags().BoolVar(&opts.PER, "power-edge-router", opts.PER, "Enabling this flag ensures that the Power Edge router that was used to create the cluster is cleaned up.")
 	cmd.Flags().StringVar(&opts.TransitGatewayLocation, "transit-gateway-location", opts.TransitGatewayLocation, "IBM Cloud Transit Gateway location")
 	cmd.Flags().StringVar(&opts.TransitGateway, "transit-gateway", opts.TransitGateway, "IBM Cloud Transit Gateway. Use this flag to reuse an existing Transit Gateway resource for cluster's infra")
+	opts.CloudAPI.BindFlags(cmd.Flags())
 
 	cmd.MarkFlagRequired("name")
 	cmd.MarkFlagRequired("resource-group")
@@ -123,7 +129,12 @@ func NewDestroyCommand() *cobra.Command {
 	cmd.Flags().MarkHidden("transit-gateway")
 
 	cmd.RunE = func(cmd *cobra.Command, args []string) error {
-		if err := opts.Run(cmd.Context()); err != nil {
+		ctx, done, err := opts.CloudAPI.Start(cmd.Context(), log(opts.InfraID))
+		if err != nil {
+			return err
+		}
+		defer done()
+		if err := opts.Run(ctx); err != nil {
 			log(opts.InfraID).Error(err, "Failed to destroy infrastructure")
 			return err
 		}
@@ -254,6 +265,7 @@ func (options *DestroyInfraOptions) DestroyInfra(ctx context.Context, infra *Inf
 	if err != nil {
 		return err
 	}
+	cloudapi.FromContext(ctx).InstrumentIBMService(v1.Service)
 
 	if err = destroyVpcSubnet(ctx, options, infra, resourceGroupID, v1, options.InfraID); err != nil {
 		errL = append(errL, fmt.Errorf("error destroying vpc subnet: %w", err))
@@ -296,6 +308,7 @@ func deleteDNSRecords(ctx context.Context, options *DestroyInfraOptions) error {
 	if err != nil {
 		return fmt.Errorf("error creating dns record service %w", err)
 	}
+	cloudapi.FromContext(ctx).InstrumentIBMService(dnsRecordsV1.Service)
 
 	recordName := fmt.Sprintf("*.apps.%s.%s", options.Name, options.BaseDomain)
 	listDnsRecordsOpt := &dnsrecordsv1.ListAllDnsRecordsOptions{Name: &recordName}
@@ -374,6 +387,7 @@ func destroyPowerVsCloudInstance(ctx context.Context, options *DestroyInfraOptio
 	if err != nil {
 		return err
 	}
+	cloudapi.FromContext(ctx).InstrumentIBMService(rcv2.Service)
 
 	// Attempt cloud instance deletion only when it is created by hypershift
 	// Nothing to clean up on user provided PowerVS instance
@@ -880,6 +894,7 @@ func deleteCOS(ctx context.Context, options *DestroyInfraOptions, resourceGroupI
 	if err != nil {
 		return err
 	}
+	cloudapi.FromContext(ctx).InstrumentIBMService(rcv2.Service)
 
 	// Checking COS instance available to delete, if not skip COS deletion
 	cosInstance, err := findCOSInstance(rcv2, cosInstanceName, resourceGroupID)
@@ -950,6 +965,7 @@ func destroyTransitGateway(ctx context.Context, options *DestroyInfraOptions) er
 	if err != nil {
 		return err
 	}
+	cloudapi.FromContext(ctx).InstrumentIBMService(tgapisv1.Service)
 
 	if options.TransitGateway != "" {
 		return nil
@@ -7,6 +7,8 @@ import (
 	"github.com/IBM/networking-go-sdk/transitgatewayapisv1"
 	"github.com/IBM/platform-services-go-sdk/resourcecontrollerv2"
 	"github.com/IBM/vpc-go-sdk/vpcv1"
+
+	"github.com/openshift/hypershift/cmd/infra/cloudapi"
 )
 
 var (
@@ -31,6 +33,7 @@ func validateCloudInstanceByID(ctx context.Context, cloudInstanceID string) (*re
 	if err != nil {
 		return nil, err
 	}
+	cloudapi.FromContext(ctx).InstrumentIBMService(rcv2.Service)
 
 	resourceInstance, _, err := rcv2.GetResourceInstanceWithContext(ctx, &resourcecontrollerv2.GetResourceInstanceOptions{ID: &cloudInstanceID})
 	if err != nil {
@@ -55,6 +58,7 @@ func validateCloudInstanceByName(ctx context.Context, cloudInstance string, reso
 	if err != nil {
 		return nil, err
 	}
+	cloudapi.FromContext(ctx).InstrumentIBMService(rcv2.Service)
 	var resourceInstance *resourcecontrollerv2.ResourceInstance
 
 	f := func(start string) (bool, string, error) {
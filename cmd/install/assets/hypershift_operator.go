@@ -3,20 +3,26 @@ package assets
 import (
 	_ "embed"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
 	cmdutil "github.com/openshift/hypershift/cmd/util"
 	"github.com/openshift/hypershift/pkg/version"
+	supportawsutil "github.com/openshift/hypershift/support/awsutil"
 	"github.com/openshift/hypershift/support/config"
 	"github.com/openshift/hypershift/support/images"
 	"github.com/openshift/hypershift/support/metrics"
+	"github.com/openshift/hypershift/support/oidc"
+	"github.com/openshift/hypershift/support/proxy"
 	"github.com/openshift/hypershift/support/rhobsmonitoring"
 	"github.com/openshift/hypershift/support/util"
 
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	schedulingv1 "k8s.io/api/scheduling/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -26,6 +32,7 @@ import (
 	"k8s.io/utils/ptr"
 
 	"github.com/google/uuid"
+	routev1 "github.com/openshift/api/route/v1"
 	prometheusoperatorv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	cdicore "kubevirt.io/containerized-data-importer-api/pkg/apis/core"
 )
@@ -95,6 +102,7 @@ func (o HyperShiftNamespace) Build() *corev1.Namespace {
 const (
 	awsCredsSecretName            = "hypershift-operator-aws-credentials"
 	oidcProviderS3CredsSecretName = "hypershift-operator-oidc-provider-s3-credentials"
+	oidcStorageProviderSecretName = "hypershift-operator-oidc-storage-provider-credentials"
 	externaDNSCredsSecretName     = "external-dns-credentials"
 
 	HypershiftOperatorName = "operator"
@@ -146,6 +154,28 @@ func (o HyperShiftOperatorOIDCProviderS3Secret) Build() *corev1.Secret {
 	return secret
 }
 
+type HyperShiftOperatorOIDCStorageProviderSecret struct {
+	Namespace *corev1.Namespace
+	CredBytes []byte
+}
+
+func (o HyperShiftOperatorOIDCStorageProviderSecret) Build() *corev1.Secret {
+	secret := &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Secret",
+			APIVersion: corev1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      oidcStorageProviderSecretName,
+			Namespace: o.Namespace.Name,
+		},
+		Data: map[string][]byte{
+			"credentials": o.CredBytes,
+		},
+	}
+	return secret
+}
+
 type ExternalDNSCredsSecret struct {
 	Namespace  *corev1.Namespace
 	CredsBytes []byte
@@ -176,6 +206,8 @@ type ExternalDNSDeployment struct {
 	DomainFilter      string
 	CredentialsSecret *corev1.Secret
 	TxtOwnerId        string
+	// AWSRegion is a region of the AWS partition of the Route53 records, e.g. a GovCloud or China region.
+	AWSRegion string
 }
 
 func (o ExternalDNSDeployment) Build() *appsv1.Deployment {
@@ -292,8 +324,8 @@ func (o ExternalDNSDeployment) Build() *appsv1.Deployment {
 			corev1.EnvVar{
 				Name: "AWS_REGION",
 				// external-dns only makes route53 requests which is a global service,
-				// thus we can assume us-east-1 without having to request it on the command line
-				Value: "us-east-1",
+				// thus we only need the global region of the partition
+				Value: supportawsutil.Route53Region(o.AWSRegion),
 			})
 		deployment.Spec.Template.Spec.Containers[0].Args = append(deployment.Spec.Template.Spec.Containers[0].Args,
 			"--aws-zone-type=public",
@@ -344,10 +376,18 @@ type HyperShiftOperatorDeployment struct {
 	AWSPrivateSecret                        *corev1.Secret
 	AWSPrivateSecretKey                     string
 	AWSPrivateRegion                        string
+	AWSEndpointOverrides                    map[string]string
+	ManagementClusterProxy                  proxy.Config
 	OIDCBucketName                          string
 	OIDCBucketRegion                        string
 	OIDCStorageProviderS3Secret             *corev1.Secret
 	OIDCStorageProviderS3SecretKey          string
+	OIDCStorageProvider                     string
+	OIDCStorageProviderAzureAccount         string
+	OIDCStorageProviderAzureContainer       string
+	OIDCStorageProviderGCSBucketName        string
+	OIDCStorageProviderSecret               *corev1.Secret
+	OIDCStorageProviderIssuerURLBase        string
 	MetricsSet                              metrics.MetricsSet
 	IncludeVersion                          bool
 	UWMTelemetry                            bool
@@ -358,8 +398,53 @@ type HyperShiftOperatorDeployment struct {
 	EnableDedicatedRequestServingIsolation  bool
 	ManagedService                          string
 	EnableSizeTagging                       bool
+	EnableReleaseInfoCache                  bool
+	Shards                                  int32
+	ControlPlaneHardeningProfile            string
+	ControlPlaneSeccompProfile              string
+	ControlPlaneHardeningExceptions         string
+	FailedClusterGCTTL                      time.Duration
+	FailedClusterGCCleanupCloudResources    bool
+	AzureResourceTagsDenyList               []string
+	MandatoryResourceTags                   map[string]string
+	NotificationWebhookSecret               string
+	MachinePricesConfigMap                  string
+	KubeAPIServerProbeInterval              time.Duration
+	HighAvailability                        bool
+	ResourceProfile                         string
 }
 
+const (
+	OperatorResourceProfileSmall  = "small"
+	OperatorResourceProfileMedium = "medium"
+	OperatorResourceProfileLarge  = "large"
+)
+
+// OperatorResourceProfiles are the resource requests of the operator container for each sizing profile, roughly for
+// tens, about a hundred and hundreds of HostedClusters.
+var OperatorResourceProfiles = map[string]corev1.ResourceList{
+	OperatorResourceProfileSmall: {
+		corev1.ResourceCPU:    resource.MustParse("100m"),
+		corev1.ResourceMemory: resource.MustParse("512Mi"),
+	},
+	OperatorResourceProfileMedium: {
+		corev1.ResourceCPU:    resource.MustParse("500m"),
+		corev1.ResourceMemory: resource.MustParse("2Gi"),
+	},
+	OperatorResourceProfileLarge: {
+		corev1.ResourceCPU:    resource.MustParse("2"),
+		corev1.ResourceMemory: resource.MustParse("8Gi"),
+	},
+}
+
+// Leader election durations of highly available installs, shorter than the operator defaults so that another
+// replica takes over within half a minute when the leader is lost.
+const (
+	haLeaderElectionLeaseDuration = 30 * time.Second
+	haLeaderElectionRenewDeadline = 20 * time.Second
+	haLeaderElectionRetryPeriod   = 5 * time.Second
+)
+
 func (o HyperShiftOperatorDeployment) Build() *appsv1.Deployment {
 	args := []string{
 		"run",
@@ -372,6 +457,18 @@ func (o HyperShiftOperatorDeployment) Build() *appsv1.Deployment {
 		fmt.Sprintf("--private-platform=%s", o.PrivatePlatform),
 	}
 
+	ports := []corev1.ContainerPort{
+		{
+			Name:          "metrics",
+			ContainerPort: 9000,
+			Protocol:      corev1.ProtocolTCP,
+		},
+		{
+			Name:          "manager",
+			ContainerPort: 9443,
+			Protocol:      corev1.ProtocolTCP,
+		},
+	}
 	var volumeMounts []corev1.VolumeMount
 	var initVolumeMounts []corev1.VolumeMount
 	var volumes []corev1.Volume
@@ -442,10 +539,111 @@ func (o HyperShiftOperatorDeployment) Build() *appsv1.Deployment {
 		})
 	}
 
+	if o.OIDCStorageProviderSecret != nil && len(o.OIDCStorageProviderSecret.Name) > 0 {
+		args = append(args,
+			"--oidc-storage-provider="+o.OIDCStorageProvider,
+			"--oidc-storage-provider-credentials=/etc/oidc-storage-provider-creds/credentials",
+		)
+		if len(o.OIDCStorageProviderAzureAccount) > 0 {
+			args = append(args,
+				"--oidc-storage-provider-azure-account="+o.OIDCStorageProviderAzureAccount,
+				"--oidc-storage-provider-azure-container="+o.OIDCStorageProviderAzureContainer,
+			)
+		}
+		if len(o.OIDCStorageProviderGCSBucketName) > 0 {
+			args = append(args, "--oidc-storage-provider-gcs-bucket-name="+o.OIDCStorageProviderGCSBucketName)
+		}
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      "oidc-storage-provider-creds",
+			MountPath: "/etc/oidc-storage-provider-creds",
+		})
+		volumes = append(volumes, corev1.Volume{
+			Name: "oidc-storage-provider-creds",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: o.OIDCStorageProviderSecret.Name,
+				},
+			},
+		})
+	}
+
+	if o.OIDCStorageProvider == oidc.DocumentStoreManagementCluster {
+		args = append(args, "--oidc-storage-provider="+o.OIDCStorageProvider)
+		ports = append(ports, corev1.ContainerPort{
+			Name:          "oidc-discovery",
+			ContainerPort: 9080,
+			Protocol:      corev1.ProtocolTCP,
+		})
+	}
+	if len(o.OIDCStorageProviderIssuerURLBase) > 0 {
+		args = append(args, "--oidc-storage-provider-issuer-url-base="+o.OIDCStorageProviderIssuerURLBase)
+	}
+
 	if o.UWMTelemetry {
 		args = append(args, "--enable-uwm-telemetry-remote-write")
 	}
 
+	if o.EnableReleaseInfoCache {
+		args = append(args, "--release-info-cache-dir=/var/cache/release-info")
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      "release-info-cache",
+			MountPath: "/var/cache/release-info",
+		})
+		volumes = append(volumes, corev1.Volume{
+			Name: "release-info-cache",
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		})
+	}
+
+	if o.Shards > 1 {
+		args = append(args, fmt.Sprintf("--shard-count=%d", o.Shards))
+	}
+
+	if o.ControlPlaneHardeningProfile != "" {
+		args = append(args, fmt.Sprintf("--control-plane-hardening-profile=%s", o.ControlPlaneHardeningProfile))
+	}
+	if o.ControlPlaneSeccompProfile != "" {
+		args = append(args, fmt.Sprintf("--control-plane-seccomp-profile=%s", o.ControlPlaneSeccompProfile))
+	}
+	if o.ControlPlaneHardeningExceptions != "" {
+		args = append(args, fmt.Sprintf("--control-plane-hardening-exceptions=%s", o.ControlPlaneHardeningExceptions))
+	}
+	if o.FailedClusterGCTTL > 0 {
+		args = append(args, fmt.Sprintf("--failed-cluster-gc-ttl=%s", o.FailedClusterGCTTL))
+		if o.FailedClusterGCCleanupCloudResources {
+			args = append(args, "--failed-cluster-gc-cleanup-cloud-resources")
+		}
+	}
+	if len(o.AzureResourceTagsDenyList) > 0 {
+		args = append(args, fmt.Sprintf("--azure-resource-tags-deny-list=%s", strings.Join(o.AzureResourceTagsDenyList, ",")))
+	}
+	if len(o.MandatoryResourceTags) > 0 {
+		var tags []string
+		for key, value := range o.MandatoryResourceTags {
+			tags = append(tags, key+"="+value)
+		}
+		sort.Strings(tags)
+		args = append(args, fmt.Sprintf("--mandatory-resource-tags=%s", strings.Join(tags, ",")))
+	}
+	if o.NotificationWebhookSecret != "" {
+		args = append(args, fmt.Sprintf("--notification-webhook-secret=%s", o.NotificationWebhookSecret))
+	}
+	if o.MachinePricesConfigMap != "" {
+		args = append(args, fmt.Sprintf("--machine-prices-configmap=%s", o.MachinePricesConfigMap))
+	}
+	if o.KubeAPIServerProbeInterval > 0 {
+		args = append(args, fmt.Sprintf("--kube-apiserver-probe-interval=%s", o.KubeAPIServerProbeInterval))
+	}
+	if o.HighAvailability {
+		args = append(args,
+			fmt.Sprintf("--leader-election-lease-duration=%s", haLeaderElectionLeaseDuration),
+			fmt.Sprintf("--leader-election-renew-deadline=%s", haLeaderElectionRenewDeadline),
+			fmt.Sprintf("--leader-election-retry-period=%s", haLeaderElectionRetryPeriod),
+		)
+	}
+
 	if o.EnableCVOManagementClusterMetricsAccess {
 		envVars = append(envVars, corev1.EnvVar{
 			Name:  config.EnableCVOManagementClusterMetricsAccessEnvVar,
@@ -467,6 +665,17 @@ func (o HyperShiftOperatorDeployment) Build() *appsv1.Deployment {
 		})
 	}
 
+	if len(o.AWSEndpointOverrides) > 0 {
+		envVars = append(envVars, corev1.EnvVar{
+			Name:  supportawsutil.EndpointOverridesEnvVar,
+			Value: supportawsutil.FormatEndpointOverrides(o.AWSEndpointOverrides),
+		})
+	}
+
+	if o.ManagementClusterProxy.IsSet() {
+		proxy.SetEnvVarsTo(&envVars, o.ManagementClusterProxy.HTTPProxy, o.ManagementClusterProxy.HTTPSProxy, o.ManagementClusterProxy.NoProxy)
+	}
+
 	image := o.OperatorImage
 
 	if mapImage, ok := o.Images["hypershift-operator"]; ok {
@@ -664,18 +873,7 @@ func (o HyperShiftOperatorDeployment) Build() *appsv1.Deployment {
 								FailureThreshold:    int32(3),
 								TimeoutSeconds:      int32(5),
 							},
-							Ports: []corev1.ContainerPort{
-								{
-									Name:          "metrics",
-									ContainerPort: 9000,
-									Protocol:      corev1.ProtocolTCP,
-								},
-								{
-									Name:          "manager",
-									ContainerPort: 9443,
-									Protocol:      corev1.ProtocolTCP,
-								},
-							},
+							Ports: ports,
 							Resources: corev1.ResourceRequirements{
 								Requests: corev1.ResourceList{
 									corev1.ResourceMemory: resource.MustParse("150Mi"),
@@ -697,6 +895,33 @@ func (o HyperShiftOperatorDeployment) Build() *appsv1.Deployment {
 		}
 	}
 
+	if requests, ok := OperatorResourceProfiles[o.ResourceProfile]; ok {
+		deployment.Spec.Template.Spec.Containers[0].Resources.Requests = requests.DeepCopy()
+	}
+
+	// Highly available installs require the replicas to run on different nodes and prefer different zones, instead of
+	// only preferring different nodes.
+	if o.HighAvailability {
+		selector := &metav1.LabelSelector{MatchLabels: map[string]string{"name": HypershiftOperatorName}}
+		deployment.Spec.Template.Spec.Affinity.PodAntiAffinity = &corev1.PodAntiAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{
+				{
+					LabelSelector: selector,
+					TopologyKey:   corev1.LabelHostname,
+				},
+			},
+			PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+				{
+					PodAffinityTerm: corev1.PodAffinityTerm{
+						LabelSelector: selector,
+						TopologyKey:   corev1.LabelTopologyZone,
+					},
+					Weight: 100,
+				},
+			},
+		}
+	}
+
 	if o.AdditionalTrustBundle != nil {
 		// Add trusted-ca mount with optional configmap
 		util.DeploymentAddTrustBundleVolume(&corev1.LocalObjectReference{Name: o.AdditionalTrustBundle.Name}, deployment)
@@ -739,6 +964,33 @@ func (o HyperShiftOperatorDeployment) Build() *appsv1.Deployment {
 	return deployment
 }
 
+type HyperShiftOperatorPodDisruptionBudget struct {
+	Namespace *corev1.Namespace
+}
+
+// Build returns a PodDisruptionBudget allowing a single operator replica to be disrupted at a time.
+func (o HyperShiftOperatorPodDisruptionBudget) Build() *policyv1.PodDisruptionBudget {
+	maxUnavailable := intstr.FromInt32(1)
+	return &policyv1.PodDisruptionBudget{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "PodDisruptionBudget",
+			APIVersion: policyv1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: o.Namespace.Name,
+			Name:      HypershiftOperatorName,
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MaxUnavailable: &maxUnavailable,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"name": HypershiftOperatorName,
+				},
+			},
+		},
+	}
+}
+
 type HyperShiftOperatorService struct {
 	Namespace *corev1.Namespace
 }
@@ -782,6 +1034,80 @@ func (o HyperShiftOperatorService) Build() *corev1.Service {
 	}
 }
 
+// HyperShiftOperatorOIDCDiscoveryService exposes the OIDC discovery documents served by the operator with the
+// ManagementCluster OIDC storage provider.
+type HyperShiftOperatorOIDCDiscoveryService struct {
+	Namespace *corev1.Namespace
+}
+
+func (o HyperShiftOperatorOIDCDiscoveryService) Build() *corev1.Service {
+	return &corev1.Service{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Service",
+			APIVersion: corev1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: o.Namespace.Name,
+			Name:      "oidc-discovery",
+			Labels: map[string]string{
+				"name": HypershiftOperatorName,
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			Type: corev1.ServiceTypeClusterIP,
+			Selector: map[string]string{
+				"name": HypershiftOperatorName,
+			},
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "oidc-discovery",
+					Protocol:   corev1.ProtocolTCP,
+					Port:       80,
+					TargetPort: intstr.FromString("oidc-discovery"),
+				},
+			},
+		},
+	}
+}
+
+// HyperShiftOperatorOIDCDiscoveryRoute publishes the OIDC discovery documents served by the operator under the host
+// of the issuer URL base, terminating TLS at the router.
+type HyperShiftOperatorOIDCDiscoveryRoute struct {
+	Namespace *corev1.Namespace
+	Service   *corev1.Service
+	Host      string
+}
+
+func (o HyperShiftOperatorOIDCDiscoveryRoute) Build() *routev1.Route {
+	return &routev1.Route{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Route",
+			APIVersion: routev1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: o.Namespace.Name,
+			Name:      o.Service.Name,
+			Labels: map[string]string{
+				"name": HypershiftOperatorName,
+			},
+		},
+		Spec: routev1.RouteSpec{
+			Host: o.Host,
+			To: routev1.RouteTargetReference{
+				Kind: "Service",
+				Name: o.Service.Name,
+			},
+			Port: &routev1.RoutePort{
+				TargetPort: intstr.FromString("oidc-discovery"),
+			},
+			TLS: &routev1.TLSConfig{
+				Termination:                   routev1.TLSTerminationEdge,
+				InsecureEdgeTerminationPolicy: routev1.InsecureEdgeTerminationPolicyRedirect,
+			},
+		},
+	}
+}
+
 type ExternalDNSServiceAccount struct {
 	Namespace *corev1.Namespace
 }
@@ -1005,6 +1331,8 @@ func (o HyperShiftOperatorClusterRole) Build() *rbacv1.ClusterRole {
 					"serviceaccounts",
 					"services",
 					"endpoints",
+					"resourcequotas",
+					"limitranges",
 				},
 				Verbs: []string{rbacv1.VerbAll},
 			},
@@ -1063,6 +1391,11 @@ func (o HyperShiftOperatorClusterRole) Build() *rbacv1.ClusterRole {
 				Resources: []string{"agents"},
 				Verbs:     []string{rbacv1.VerbAll},
 			},
+			{ // This allows hypershift operator to reboot released agents into the discovery image
+				APIGroups: []string{"metal3.io"},
+				Resources: []string{"baremetalhosts"},
+				Verbs:     []string{"get", "list", "watch", "patch"},
+			},
 			{ // This allows hypershift operator to grant RBAC permissions for kubevirt-csi to create/delete volumesnapshots.
 				APIGroups: []string{"snapshot.storage.k8s.io"},
 				Resources: []string{"volumesnapshots"},
@@ -1741,6 +2074,7 @@ func (o HyperShiftValidatingWebhookConfiguration) Build() *admissionregistration
 						Operations: []admissionregistrationv1.OperationType{
 							admissionregistrationv1.Create,
 							admissionregistrationv1.Update,
+							admissionregistrationv1.Delete,
 						},
 						Rule: admissionregistrationv1.Rule{
 							APIGroups:   []string{"hypershift.openshift.io"},
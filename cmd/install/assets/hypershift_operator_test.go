@@ -98,6 +98,49 @@ func TestHyperShiftOperatorDeployment_Build(t *testing.T) {
 				fmt.Sprintf("--private-platform=%s", string(hyperv1.NonePlatform)),
 			},
 		},
+		"release info cache results in an emptyDir cache volume": {
+			inputBuildParameters: HyperShiftOperatorDeployment{
+				Namespace: &corev1.Namespace{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: testNamespace,
+					},
+				},
+				OperatorImage: testOperatorImage,
+				ServiceAccount: &corev1.ServiceAccount{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "hypershift",
+					},
+				},
+				Replicas:               3,
+				PrivatePlatform:        string(hyperv1.NonePlatform),
+				EnableReleaseInfoCache: true,
+			},
+			expectedArgs: []string{
+				"run",
+				"--namespace=$(MY_NAMESPACE)",
+				"--pod-name=$(MY_NAME)",
+				"--metrics-addr=:9000",
+				fmt.Sprintf("--enable-dedicated-request-serving-isolation=%t", false),
+				fmt.Sprintf("--enable-ocp-cluster-monitoring=%t", false),
+				fmt.Sprintf("--enable-ci-debug-output=%t", false),
+				fmt.Sprintf("--private-platform=%s", string(hyperv1.NonePlatform)),
+				"--release-info-cache-dir=/var/cache/release-info",
+			},
+			expectedVolumeMounts: []corev1.VolumeMount{
+				{
+					Name:      "release-info-cache",
+					MountPath: "/var/cache/release-info",
+				},
+			},
+			expectedVolumes: []corev1.Volume{
+				{
+					Name: "release-info-cache",
+					VolumeSource: corev1.VolumeSource{
+						EmptyDir: &corev1.EmptyDirVolumeSource{},
+					},
+				},
+			},
+		},
 		"specify oidc parameters result in appropriate volumes and volumeMounts": {
 			inputBuildParameters: HyperShiftOperatorDeployment{
 				Namespace: &corev1.Namespace{
@@ -244,6 +287,39 @@ func TestHyperShiftOperatorDeployment_Build(t *testing.T) {
 				},
 			},
 		},
+		"ManagementCluster oidc storage provider results in appropriate arguments": {
+			inputBuildParameters: HyperShiftOperatorDeployment{
+				Namespace: &corev1.Namespace{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: testNamespace,
+					},
+				},
+				OperatorImage: testOperatorImage,
+				ServiceAccount: &corev1.ServiceAccount{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "hypershift",
+					},
+				},
+				Replicas:                         3,
+				PrivatePlatform:                  string(hyperv1.NonePlatform),
+				OIDCStorageProvider:              "ManagementCluster",
+				OIDCStorageProviderIssuerURLBase: "https://oidc.apps.example.com",
+			},
+			expectedVolumeMounts: nil,
+			expectedVolumes:      nil,
+			expectedArgs: []string{
+				"run",
+				"--namespace=$(MY_NAMESPACE)",
+				"--pod-name=$(MY_NAME)",
+				"--metrics-addr=:9000",
+				fmt.Sprintf("--enable-dedicated-request-serving-isolation=%t", false),
+				fmt.Sprintf("--enable-ocp-cluster-monitoring=%t", false),
+				fmt.Sprintf("--enable-ci-debug-output=%t", false),
+				fmt.Sprintf("--private-platform=%s", string(hyperv1.NonePlatform)),
+				"--oidc-storage-provider=ManagementCluster",
+				"--oidc-storage-provider-issuer-url-base=https://oidc.apps.example.com",
+			},
+		},
 		"specify dedicated request serving isolation parameter (true) result in appropriate arguments": {
 			inputBuildParameters: HyperShiftOperatorDeployment{
 				Namespace: &corev1.Namespace{
@@ -315,3 +391,28 @@ func TestHyperShiftOperatorDeployment_Build(t *testing.T) {
 		})
 	}
 }
+
+func TestHyperShiftOperatorDeployment_BuildHighAvailability(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	deployment := HyperShiftOperatorDeployment{
+		Namespace:        &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "hypershift"}},
+		OperatorImage:    "myimage",
+		ServiceAccount:   &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "hypershift"}},
+		Replicas:         3,
+		PrivatePlatform:  string(hyperv1.NonePlatform),
+		HighAvailability: true,
+		ResourceProfile:  OperatorResourceProfileMedium,
+	}.Build()
+
+	podSpec := deployment.Spec.Template.Spec
+	g.Expect(podSpec.Containers[0].Args).To(ContainElements(
+		"--leader-election-lease-duration=30s",
+		"--leader-election-renew-deadline=20s",
+		"--leader-election-retry-period=5s",
+	))
+	g.Expect(podSpec.Containers[0].Resources.Requests).To(Equal(OperatorResourceProfiles[OperatorResourceProfileMedium]))
+	g.Expect(podSpec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution).To(HaveLen(1))
+	g.Expect(podSpec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution[0].TopologyKey).To(Equal(corev1.LabelHostname))
+	g.Expect(podSpec.Affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution[0].PodAffinityTerm.TopologyKey).To(Equal(corev1.LabelTopologyZone))
+}
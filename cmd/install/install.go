@@ -18,6 +18,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"net/url"
 	"os"
 	"strings"
 	"time"
@@ -44,7 +45,11 @@ import (
 	"github.com/openshift/hypershift/cmd/util"
 	"github.com/openshift/hypershift/cmd/version"
 	hyperapi "github.com/openshift/hypershift/support/api"
+	supportawsutil "github.com/openshift/hypershift/support/awsutil"
+	"github.com/openshift/hypershift/support/clusteridentity"
 	"github.com/openshift/hypershift/support/metrics"
+	"github.com/openshift/hypershift/support/oidc"
+	"github.com/openshift/hypershift/support/proxy"
 	"github.com/openshift/hypershift/support/rhobsmonitoring"
 )
 
@@ -75,16 +80,28 @@ type Options struct {
 	AWSPrivateCredentialsSecret               string
 	AWSPrivateCredentialsSecretKey            string
 	AWSPrivateRegion                          string
+	AWSEndpointOverrides                      map[string]string
+	ManagementClusterHTTPProxy                string
+	ManagementClusterHTTPSProxy               string
+	ManagementClusterNoProxy                  string
 	OIDCStorageProviderS3Region               string
 	OIDCStorageProviderS3BucketName           string
 	OIDCStorageProviderS3Credentials          string
 	OIDCStorageProviderS3CredentialsSecret    string
 	OIDCStorageProviderS3CredentialsSecretKey string
+	OIDCStorageProvider                       string
+	OIDCStorageProviderAzureAccount           string
+	OIDCStorageProviderAzureContainer         string
+	OIDCStorageProviderGCSBucketName          string
+	OIDCStorageProviderCredentials            string
+	OIDCStorageProviderCredentialsSecret      string
+	OIDCStorageProviderIssuerURLBase          string
 	ExternalDNSProvider                       string
 	ExternalDNSCredentials                    string
 	ExternalDNSCredentialsSecret              string
 	ExternalDNSDomainFilter                   string
 	ExternalDNSTxtOwnerId                     string
+	ExternalDNSAWSRegion                      string
 	ExternalDNSImage                          string
 	EnableAdminRBACGeneration                 bool
 	EnableUWMTelemetryRemoteWrite             bool
@@ -100,6 +117,35 @@ type Options struct {
 	PullSecretFile                            string
 	ManagedService                            string
 	EnableSizeTagging                         bool
+	EnableReleaseInfoCache                    bool
+	HyperShiftOperatorShards                  int32
+	ControlPlaneHardeningProfile              string
+	ControlPlaneSeccompProfile                string
+	ControlPlaneHardeningExceptions           string
+	FailedClusterGCTTL                        time.Duration
+	FailedClusterGCCleanupCloudResources      bool
+	AzureResourceTagsDenyList                 []string
+	MandatoryResourceTags                     map[string]string
+	NotificationWebhookSecret                 string
+	MachinePricesConfigMap                    string
+	KubeAPIServerProbeInterval                time.Duration
+	HighAvailability                          bool
+	OperatorResourceProfile                   string
+}
+
+// oidcIssuerURLBase returns the public URL the OIDC documents stored by the AzureBlob, GCS or ManagementCluster OIDC
+// storage provider are served from, which the CLI discovers to derive the issuer URL of the clusters.
+func (o *Options) oidcIssuerURLBase() string {
+	switch {
+	case o.OIDCStorageProvider == oidc.DocumentStoreS3:
+		return ""
+	case o.OIDCStorageProviderIssuerURLBase != "":
+		return strings.TrimSuffix(o.OIDCStorageProviderIssuerURLBase, "/")
+	case o.OIDCStorageProvider == oidc.DocumentStoreGCS:
+		return "https://storage.googleapis.com/" + o.OIDCStorageProviderGCSBucketName
+	default:
+		return ""
+	}
 }
 
 func (o *Options) Validate() error {
@@ -115,6 +161,14 @@ func (o *Options) Validate() error {
 		errs = append(errs, fmt.Errorf("--private-platform must be either %s or %s", hyperv1.AWSPlatform, hyperv1.NonePlatform))
 	}
 
+	if err := supportawsutil.ValidateEndpointOverrides(o.AWSEndpointOverrides); err != nil {
+		errs = append(errs, err)
+	}
+
+	if len(o.ManagementClusterNoProxy) > 0 && len(o.ManagementClusterHTTPProxy) == 0 && len(o.ManagementClusterHTTPSProxy) == 0 {
+		errs = append(errs, fmt.Errorf("--management-cluster-no-proxy requires --management-cluster-http-proxy or --management-cluster-https-proxy"))
+	}
+
 	if len(o.OIDCStorageProviderS3CredentialsSecret) > 0 && len(o.OIDCStorageProviderS3Credentials) > 0 {
 		errs = append(errs, fmt.Errorf("only one of --oidc-storage-provider-s3-secret or --oidc-storage-provider-s3-credentials is supported"))
 	}
@@ -125,6 +179,30 @@ func (o *Options) Validate() error {
 	if strings.Contains(o.OIDCStorageProviderS3BucketName, ".") {
 		errs = append(errs, fmt.Errorf("oidc bucket name must not contain dots (.); see the notes on HTTPS at https://docs.aws.amazon.com/AmazonS3/latest/userguide/bucketnamingrules.html"))
 	}
+	switch o.OIDCStorageProvider {
+	case "", oidc.DocumentStoreS3:
+	case oidc.DocumentStoreAzureBlob, oidc.DocumentStoreGCS:
+		if len(o.OIDCStorageProviderCredentialsSecret) > 0 && len(o.OIDCStorageProviderCredentials) > 0 {
+			errs = append(errs, fmt.Errorf("only one of --oidc-storage-provider-secret or --oidc-storage-provider-credentials is supported"))
+		}
+		if len(o.OIDCStorageProviderCredentialsSecret) == 0 && len(o.OIDCStorageProviderCredentials) == 0 {
+			errs = append(errs, fmt.Errorf("--oidc-storage-provider-credentials or --oidc-storage-provider-secret is required with --oidc-storage-provider=%s", o.OIDCStorageProvider))
+		}
+		if o.OIDCStorageProvider == oidc.DocumentStoreAzureBlob && (len(o.OIDCStorageProviderAzureAccount) == 0 || len(o.OIDCStorageProviderIssuerURLBase) == 0) {
+			errs = append(errs, fmt.Errorf("--oidc-storage-provider-azure-account and --oidc-storage-provider-issuer-url-base are required with --oidc-storage-provider=%s", o.OIDCStorageProvider))
+		}
+		if o.OIDCStorageProvider == oidc.DocumentStoreGCS && len(o.OIDCStorageProviderGCSBucketName) == 0 {
+			errs = append(errs, fmt.Errorf("--oidc-storage-provider-gcs-bucket-name is required with --oidc-storage-provider=%s", o.OIDCStorageProvider))
+		}
+	case oidc.DocumentStoreManagementCluster:
+		// The documents are served by the operator through a route of the management cluster, whose host is the
+		// one of the issuer URL base.
+		if issuerURLBase, err := url.Parse(o.OIDCStorageProviderIssuerURLBase); err != nil || issuerURLBase.Scheme != "https" || issuerURLBase.Host == "" || strings.Trim(issuerURLBase.Path, "/") != "" {
+			errs = append(errs, fmt.Errorf("--oidc-storage-provider-issuer-url-base must be an https URL without path with --oidc-storage-provider=%s", o.OIDCStorageProvider))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("--oidc-storage-provider must be one of %s, %s, %s or %s", oidc.DocumentStoreS3, oidc.DocumentStoreAzureBlob, oidc.DocumentStoreGCS, oidc.DocumentStoreManagementCluster))
+	}
 
 	if len(o.ExternalDNSProvider) > 0 {
 		if len(o.ExternalDNSCredentials) == 0 && len(o.ExternalDNSCredentialsSecret) == 0 {
@@ -152,6 +230,27 @@ func (o *Options) Validate() error {
 		errs = append(errs, fmt.Errorf("when invoking this command with the --rhobs-monitoring flag, the --enable-cvo-management-cluster-metrics-access flag is not supported "))
 	}
 
+	if o.HyperShiftOperatorShards < 0 {
+		errs = append(errs, fmt.Errorf("--hypershift-operator-shards must not be negative"))
+	}
+
+	switch o.ControlPlaneHardeningProfile {
+	case "", hyperv1.ControlPlaneHardeningProfileNone, hyperv1.ControlPlaneHardeningProfileRestricted:
+	default:
+		errs = append(errs, fmt.Errorf("--control-plane-hardening-profile must be either %s or %s", hyperv1.ControlPlaneHardeningProfileNone, hyperv1.ControlPlaneHardeningProfileRestricted))
+	}
+
+	if err := clusteridentity.ValidateMandatoryTags(o.MandatoryResourceTags); err != nil {
+		errs = append(errs, fmt.Errorf("--mandatory-resource-tags: %w", err))
+	}
+
+	if o.HighAvailability && o.Development {
+		errs = append(errs, fmt.Errorf("--ha is not supported with --development"))
+	}
+	if _, ok := assets.OperatorResourceProfiles[o.OperatorResourceProfile]; o.OperatorResourceProfile != "" && !ok {
+		errs = append(errs, fmt.Errorf("--operator-resource-profile must be one of %s, %s or %s", assets.OperatorResourceProfileSmall, assets.OperatorResourceProfileMedium, assets.OperatorResourceProfileLarge))
+	}
+
 	if len(o.ManagedService) > 0 && o.ManagedService != hyperv1.AroHCP && o.ManagedService != hyperv1.RosaHCP {
 		errs = append(errs, fmt.Errorf("not a valid managed service type: %s", o.ManagedService))
 	}
@@ -167,6 +266,20 @@ func (o *Options) ApplyDefaults() {
 	default:
 		o.HyperShiftOperatorReplicas = 1
 	}
+	// Every shard needs a replica holding it, plus a spare one to take over a shard quickly.
+	if !o.Development && o.HyperShiftOperatorShards > 1 {
+		o.HyperShiftOperatorReplicas = o.HyperShiftOperatorShards + 1
+	}
+	// Highly available installs keep two replicas running while a node is drained, and size the operator for a
+	// production management cluster unless a profile is chosen.
+	if o.HighAvailability && !o.Development {
+		if o.HyperShiftOperatorReplicas < 3 {
+			o.HyperShiftOperatorReplicas = 3
+		}
+		if o.OperatorResourceProfile == "" {
+			o.OperatorResourceProfile = assets.OperatorResourceProfileMedium
+		}
+	}
 }
 
 func NewCommand() *cobra.Command {
@@ -183,6 +296,7 @@ func NewCommand() *cobra.Command {
 	opts.ExternalDNSImage = ExternalDNSImage
 	opts.CertRotationScale = 24 * time.Hour
 	opts.EnableSizeTagging = false
+	opts.HyperShiftOperatorShards = 1
 
 	cmd.PersistentFlags().StringVar(&opts.Namespace, "namespace", "hypershift", "The namespace in which to install HyperShift")
 	cmd.PersistentFlags().StringVar(&opts.HyperShiftImage, "hypershift-image", version.HyperShiftImage, "The HyperShift image to deploy")
@@ -198,16 +312,28 @@ func NewCommand() *cobra.Command {
 	cmd.PersistentFlags().StringVar(&opts.AWSPrivateCredentialsSecret, "aws-private-secret", "", "Name of an existing secret containing the AWS private link credentials.")
 	cmd.PersistentFlags().StringVar(&opts.AWSPrivateCredentialsSecretKey, "aws-private-secret-key", "credentials", "Name of the secret key containing the AWS private link credentials.")
 	cmd.PersistentFlags().StringVar(&opts.AWSPrivateRegion, "aws-private-region", opts.AWSPrivateRegion, "AWS region where private clusters are supported by this operator")
+	cmd.PersistentFlags().StringVar(&opts.ManagementClusterHTTPProxy, "management-cluster-http-proxy", opts.ManagementClusterHTTPProxy, "Egress proxy of the management cluster for HTTP requests, used by the operator and the control plane components of HostedClusters without the hypershift.openshift.io/management-cluster-*-proxy annotations")
+	cmd.PersistentFlags().StringVar(&opts.ManagementClusterHTTPSProxy, "management-cluster-https-proxy", opts.ManagementClusterHTTPSProxy, "Egress proxy of the management cluster for HTTPS requests, used by the operator and the control plane components of HostedClusters without the hypershift.openshift.io/management-cluster-*-proxy annotations")
+	cmd.PersistentFlags().StringVar(&opts.ManagementClusterNoProxy, "management-cluster-no-proxy", opts.ManagementClusterNoProxy, "Comma separated list of destinations reached without the egress proxy of the management cluster")
+	cmd.PersistentFlags().StringToStringVar(&opts.AWSEndpointOverrides, "aws-endpoint-overrides", opts.AWSEndpointOverrides, "A comma separated list of service=url pairs overriding the endpoints of the AWS services used by this operator, e.g. ec2=https://ec2.example.com,elasticloadbalancing=https://elb.example.com")
 	cmd.PersistentFlags().StringVar(&opts.OIDCStorageProviderS3Region, "oidc-storage-provider-s3-region", "", "Region of the OIDC bucket. Required for AWS guest clusters")
 	cmd.PersistentFlags().StringVar(&opts.OIDCStorageProviderS3BucketName, "oidc-storage-provider-s3-bucket-name", "", "Name of the bucket in which to store the clusters OIDC discovery information. Required for AWS guest clusters")
 	cmd.PersistentFlags().StringVar(&opts.OIDCStorageProviderS3Credentials, "oidc-storage-provider-s3-credentials", opts.OIDCStorageProviderS3Credentials, "Credentials to use for writing the OIDC documents into the S3 bucket. Required for AWS guest clusters")
 	cmd.PersistentFlags().StringVar(&opts.OIDCStorageProviderS3CredentialsSecret, "oidc-storage-provider-s3-secret", "", "Name of an existing secret containing the OIDC S3 credentials.")
 	cmd.PersistentFlags().StringVar(&opts.OIDCStorageProviderS3CredentialsSecretKey, "oidc-storage-provider-s3-secret-key", "credentials", "Name of the secret key containing the OIDC S3 credentials.")
+	cmd.PersistentFlags().StringVar(&opts.OIDCStorageProvider, "oidc-storage-provider", oidc.DocumentStoreS3, "Storage hosting the clusters OIDC discovery information (supports \"S3\", \"AzureBlob\", \"GCS\" or \"ManagementCluster\")")
+	cmd.PersistentFlags().StringVar(&opts.OIDCStorageProviderAzureAccount, "oidc-storage-provider-azure-account", "", "Name of the Azure storage account in which to store the clusters OIDC discovery information. Required with the AzureBlob OIDC storage provider")
+	cmd.PersistentFlags().StringVar(&opts.OIDCStorageProviderAzureContainer, "oidc-storage-provider-azure-container", oidc.AzureStaticWebsiteContainer, "Name of the Azure storage account container in which to store the clusters OIDC discovery information")
+	cmd.PersistentFlags().StringVar(&opts.OIDCStorageProviderGCSBucketName, "oidc-storage-provider-gcs-bucket-name", "", "Name of the GCS bucket in which to store the clusters OIDC discovery information. Required with the GCS OIDC storage provider")
+	cmd.PersistentFlags().StringVar(&opts.OIDCStorageProviderCredentials, "oidc-storage-provider-credentials", "", "Credentials file of the AzureBlob or GCS OIDC storage provider: the base64 encoded storage account key, or the service account key")
+	cmd.PersistentFlags().StringVar(&opts.OIDCStorageProviderCredentialsSecret, "oidc-storage-provider-secret", "", "Name of an existing secret containing the AzureBlob or GCS OIDC storage provider credentials under the credentials key")
+	cmd.PersistentFlags().StringVar(&opts.OIDCStorageProviderIssuerURLBase, "oidc-storage-provider-issuer-url-base", "", "Public URL the OIDC discovery information stored by the AzureBlob, GCS or ManagementCluster OIDC storage provider is served from, e.g. the static website URL of the Azure storage account. Defaults to the public URL of the bucket with the GCS OIDC storage provider. With the ManagementCluster OIDC storage provider, the host of the route exposing the operator")
 	cmd.PersistentFlags().StringVar(&opts.ExternalDNSProvider, "external-dns-provider", opts.OIDCStorageProviderS3Credentials, "Provider to use for managing DNS records using external-dns")
 	cmd.PersistentFlags().StringVar(&opts.ExternalDNSCredentials, "external-dns-credentials", opts.OIDCStorageProviderS3Credentials, "Credentials to use for managing DNS records using external-dns")
 	cmd.PersistentFlags().StringVar(&opts.ExternalDNSCredentialsSecret, "external-dns-secret", "", "Name of an existing secret containing the external-dns credentials.")
 	cmd.PersistentFlags().StringVar(&opts.ExternalDNSDomainFilter, "external-dns-domain-filter", "", "Restrict external-dns to changes within the specified domain.")
 	cmd.PersistentFlags().StringVar(&opts.ExternalDNSTxtOwnerId, "external-dns-txt-owner-id", "", "external-dns TXT registry owner ID.")
+	cmd.PersistentFlags().StringVar(&opts.ExternalDNSAWSRegion, "external-dns-aws-region", "", "A region of the AWS partition of the records managed by external-dns with the aws provider, e.g. us-gov-west-1 or cn-north-1. Defaults to the commercial partition.")
 	cmd.PersistentFlags().StringVar(&opts.ExternalDNSImage, "external-dns-image", opts.ExternalDNSImage, "Image to use for external-dns")
 	cmd.PersistentFlags().BoolVar(&opts.EnableAdminRBACGeneration, "enable-admin-rbac-generation", false, "Generate RBAC manifests for hosted cluster admins")
 	cmd.PersistentFlags().StringVar(&opts.ImageRefsFile, "image-refs", opts.ImageRefsFile, "Image references to user in Hypershift installation")
@@ -225,6 +351,21 @@ func NewCommand() *cobra.Command {
 	cmd.PersistentFlags().StringVar(&opts.PullSecretFile, "pull-secret", opts.PullSecretFile, "File path to a pull secret.")
 	cmd.PersistentFlags().StringVar(&opts.ManagedService, "managed-service", opts.ManagedService, "The type of managed service the HyperShift Operator is installed on; this is used to configure different HostedCluster options depending on the managed service. Examples: ARO-HCP, ROSA-HCP")
 	cmd.PersistentFlags().BoolVar(&opts.EnableSizeTagging, "enable-size-tagging", opts.EnableSizeTagging, "If true, HyperShift will tag the HostedCluster with a size label corresponding to the number of worker nodes")
+	cmd.PersistentFlags().BoolVar(&opts.EnableReleaseInfoCache, "enable-release-info-cache", opts.EnableReleaseInfoCache, "If true, the HyperShift operator caches release image metadata by digest on disk to avoid repeated registry pulls")
+	cmd.PersistentFlags().Int32Var(&opts.HyperShiftOperatorShards, "hypershift-operator-shards", opts.HyperShiftOperatorShards, "Number of shards HostedClusters are split into. Each shard is reconciled by a different HyperShift operator replica, HostedClusters are assigned to a shard by the hash of their name or by the hypershift.openshift.io/operator-shard label")
+
+	cmd.PersistentFlags().StringVar(&opts.ControlPlaneHardeningProfile, "control-plane-hardening-profile", opts.ControlPlaneHardeningProfile, "Hardening profile applied by default to the control plane pods of HostedClusters (supports \"None\" or \"Restricted\"). HostedClusters can override it with the hypershift.openshift.io/control-plane-hardening-profile annotation")
+	cmd.PersistentFlags().StringVar(&opts.ControlPlaneSeccompProfile, "control-plane-seccomp-profile", opts.ControlPlaneSeccompProfile, "Seccomp profile applied by default to the hardened control plane pods: RuntimeDefault or Localhost/<path>")
+	cmd.PersistentFlags().StringVar(&opts.ControlPlaneHardeningExceptions, "control-plane-hardening-exceptions", opts.ControlPlaneHardeningExceptions, "Comma separated names of the control plane Deployments and StatefulSets not hardened by default")
+	cmd.PersistentFlags().DurationVar(&opts.FailedClusterGCTTL, "failed-cluster-gc-ttl", opts.FailedClusterGCTTL, "If set, HostedClusters that never became available and whose provisioning has been failing for longer than this duration are deleted by the HyperShift operator")
+	cmd.PersistentFlags().BoolVar(&opts.FailedClusterGCCleanupCloudResources, "failed-cluster-gc-cleanup-cloud-resources", opts.FailedClusterGCCleanupCloudResources, "If true, the cloud infrastructure of the HostedClusters deleted because of --failed-cluster-gc-ttl is cleaned up too, otherwise it's left intact")
+	cmd.PersistentFlags().StringVar(&opts.NotificationWebhookSecret, "notification-webhook-secret", opts.NotificationWebhookSecret, "If set, the name of a Secret in the HyperShift operator namespace with the url of a webhook, and optionally the hmac-key to sign with, which lifecycle notifications of the HostedClusters and NodePools are POSTed to")
+	cmd.PersistentFlags().StringSliceVar(&opts.AzureResourceTagsDenyList, "azure-resource-tags-deny-list", opts.AzureResourceTagsDenyList, "Keys of the tags of the resourceTags of Azure HostedClusters which the HyperShift operator never applies to their resources, e.g. tags reserved by the service managing the resource groups")
+	cmd.PersistentFlags().StringToStringVar(&opts.MandatoryResourceTags, "mandatory-resource-tags", opts.MandatoryResourceTags, "Tags the HyperShift operator applies to the cloud resources of every AWS and Azure HostedCluster along with their identity tags, e.g. cost-center=1234,org=payments")
+	cmd.PersistentFlags().StringVar(&opts.MachinePricesConfigMap, "machine-prices-configmap", opts.MachinePricesConfigMap, "If set, the name of a ConfigMap in the HyperShift operator namespace holding the hourly prices of the machine types, used to estimate the cost of the NodePools")
+	cmd.PersistentFlags().DurationVar(&opts.KubeAPIServerProbeInterval, "kube-apiserver-probe-interval", opts.KubeAPIServerProbeInterval, "If set, how often the HyperShift operator probes the kube-apiserver of each HostedCluster with synthetic requests through its published endpoint and through konnectivity, to export its availability and latency")
+	cmd.PersistentFlags().BoolVar(&opts.HighAvailability, "ha", opts.HighAvailability, "If true, the HyperShift operator runs at least 3 replicas on different nodes, preferably in different zones, with a PodDisruptionBudget and a faster leader election, and uses the medium resource profile by default")
+	cmd.PersistentFlags().StringVar(&opts.OperatorResourceProfile, "operator-resource-profile", opts.OperatorResourceProfile, "Resource requests of the HyperShift operator: small, medium or large for tens, about a hundred or hundreds of HostedClusters. If unset, minimal requests are used")
 
 	cmd.RunE = func(cmd *cobra.Command, args []string) error {
 		opts.ApplyDefaults()
@@ -549,6 +690,29 @@ func hyperShiftOperatorManifests(opts Options) ([]crclient.Object, []crclient.Ob
 		}
 	}
 
+	var oidcStorageProviderSecret *corev1.Secret
+	if opts.OIDCStorageProvider != oidc.DocumentStoreS3 {
+		if opts.OIDCStorageProviderCredentials != "" {
+			oidcCreds, err := os.ReadFile(opts.OIDCStorageProviderCredentials)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			oidcStorageProviderSecret = assets.HyperShiftOperatorOIDCStorageProviderSecret{
+				Namespace: operatorNamespace,
+				CredBytes: oidcCreds,
+			}.Build()
+			objects = append(objects, oidcStorageProviderSecret)
+		} else if opts.OIDCStorageProviderCredentialsSecret != "" {
+			oidcStorageProviderSecret = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: operatorNamespace.Name,
+					Name:      opts.OIDCStorageProviderCredentialsSecret,
+				},
+			}
+		}
+	}
+
 	var operatorCredentialsSecret *corev1.Secret
 	switch hyperv1.PlatformType(opts.PrivatePlatform) {
 	case hyperv1.AWSPlatform:
@@ -647,6 +811,7 @@ func hyperShiftOperatorManifests(opts Options) ([]crclient.Object, []crclient.Ob
 			DomainFilter:      opts.ExternalDNSDomainFilter,
 			CredentialsSecret: externalDNSSecret,
 			TxtOwnerId:        opts.ExternalDNSTxtOwnerId,
+			AWSRegion:         opts.ExternalDNSAWSRegion,
 		}.Build()
 		objects = append(objects, externalDNSDeployment)
 	}
@@ -671,12 +836,19 @@ func hyperShiftOperatorManifests(opts Options) ([]crclient.Object, []crclient.Ob
 		EnableValidatingWebhook:                 opts.EnableValidatingWebhook,
 		PrivatePlatform:                         opts.PrivatePlatform,
 		AWSPrivateRegion:                        opts.AWSPrivateRegion,
+		AWSEndpointOverrides:                    opts.AWSEndpointOverrides,
 		AWSPrivateSecret:                        operatorCredentialsSecret,
 		AWSPrivateSecretKey:                     opts.AWSPrivateCredentialsSecretKey,
 		OIDCBucketName:                          opts.OIDCStorageProviderS3BucketName,
 		OIDCBucketRegion:                        opts.OIDCStorageProviderS3Region,
 		OIDCStorageProviderS3Secret:             oidcSecret,
 		OIDCStorageProviderS3SecretKey:          opts.OIDCStorageProviderS3CredentialsSecretKey,
+		OIDCStorageProvider:                     opts.OIDCStorageProvider,
+		OIDCStorageProviderAzureAccount:         opts.OIDCStorageProviderAzureAccount,
+		OIDCStorageProviderAzureContainer:       opts.OIDCStorageProviderAzureContainer,
+		OIDCStorageProviderGCSBucketName:        opts.OIDCStorageProviderGCSBucketName,
+		OIDCStorageProviderSecret:               oidcStorageProviderSecret,
+		OIDCStorageProviderIssuerURLBase:        opts.oidcIssuerURLBase(),
 		Images:                                  images,
 		MetricsSet:                              opts.MetricsSet,
 		IncludeVersion:                          !opts.Template,
@@ -688,14 +860,57 @@ func hyperShiftOperatorManifests(opts Options) ([]crclient.Object, []crclient.Ob
 		EnableDedicatedRequestServingIsolation:  opts.EnableDedicatedRequestServingIsolation,
 		ManagedService:                          opts.ManagedService,
 		EnableSizeTagging:                       opts.EnableSizeTagging,
+		EnableReleaseInfoCache:                  opts.EnableReleaseInfoCache,
+		Shards:                                  opts.HyperShiftOperatorShards,
+		ControlPlaneHardeningProfile:            opts.ControlPlaneHardeningProfile,
+		ControlPlaneSeccompProfile:              opts.ControlPlaneSeccompProfile,
+		ControlPlaneHardeningExceptions:         opts.ControlPlaneHardeningExceptions,
+		FailedClusterGCTTL:                      opts.FailedClusterGCTTL,
+		FailedClusterGCCleanupCloudResources:    opts.FailedClusterGCCleanupCloudResources,
+		AzureResourceTagsDenyList:               opts.AzureResourceTagsDenyList,
+		MandatoryResourceTags:                   opts.MandatoryResourceTags,
+		NotificationWebhookSecret:               opts.NotificationWebhookSecret,
+		MachinePricesConfigMap:                  opts.MachinePricesConfigMap,
+		KubeAPIServerProbeInterval:              opts.KubeAPIServerProbeInterval,
+		HighAvailability:                        opts.HighAvailability,
+		ResourceProfile:                         opts.OperatorResourceProfile,
+		ManagementClusterProxy: proxy.Config{
+			HTTPProxy:  opts.ManagementClusterHTTPProxy,
+			HTTPSProxy: opts.ManagementClusterHTTPSProxy,
+			NoProxy:    opts.ManagementClusterNoProxy,
+		},
 	}.Build()
 	objects = append(objects, operatorDeployment)
 
+	if opts.HighAvailability {
+		operatorPDB := assets.HyperShiftOperatorPodDisruptionBudget{
+			Namespace: operatorNamespace,
+		}.Build()
+		objects = append(objects, operatorPDB)
+	}
+
 	operatorService := assets.HyperShiftOperatorService{
 		Namespace: operatorNamespace,
 	}.Build()
 	objects = append(objects, operatorService)
 
+	if opts.OIDCStorageProvider == oidc.DocumentStoreManagementCluster {
+		oidcDiscoveryService := assets.HyperShiftOperatorOIDCDiscoveryService{
+			Namespace: operatorNamespace,
+		}.Build()
+		objects = append(objects, oidcDiscoveryService)
+
+		issuerURLBase, err := url.Parse(opts.OIDCStorageProviderIssuerURLBase)
+		if err != nil {
+			return nil, nil, err
+		}
+		objects = append(objects, assets.HyperShiftOperatorOIDCDiscoveryRoute{
+			Namespace: operatorNamespace,
+			Service:   oidcDiscoveryService,
+			Host:      issuerURLBase.Hostname(),
+		}.Build())
+	}
+
 	prometheusRole := assets.HyperShiftPrometheusRole{
 		Namespace: operatorNamespace,
 	}.Build()
@@ -784,6 +999,19 @@ func hyperShiftOperatorManifests(opts Options) ([]crclient.Object, []crclient.Ob
 		objects = append(objects, readerRoleBinding)
 	}
 
+	if issuerURLBase := opts.oidcIssuerURLBase(); issuerURLBase != "" {
+		objects = append(objects, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "kube-public",
+				Name:      "oidc-storage-provider-config",
+			},
+			Data: map[string]string{
+				"issuerURLBase": issuerURLBase,
+				"provider":      opts.OIDCStorageProvider,
+			},
+		})
+	}
+
 	if opts.OIDCStorageProviderS3BucketName != "" {
 		objects = append(objects, &corev1.ConfigMap{
 			ObjectMeta: metav1.ObjectMeta{
@@ -73,6 +73,62 @@ func TestOptions_Validate(t *testing.T) {
 			},
 			expectError: true,
 		},
+		"when an unknown oidc storage provider is set it errors": {
+			inputOptions: Options{
+				PrivatePlatform:     string(hyperv1.NonePlatform),
+				OIDCStorageProvider: "Swift",
+			},
+			expectError: true,
+		},
+		"when the AzureBlob oidc storage provider is set without issuer url base it errors": {
+			inputOptions: Options{
+				PrivatePlatform:                 string(hyperv1.NonePlatform),
+				OIDCStorageProvider:             "AzureBlob",
+				OIDCStorageProviderAzureAccount: "myaccount",
+				OIDCStorageProviderCredentials:  "/path/to/credentials",
+			},
+			expectError: true,
+		},
+		"when the GCS oidc storage provider is set without credentials it errors": {
+			inputOptions: Options{
+				PrivatePlatform:                  string(hyperv1.NonePlatform),
+				OIDCStorageProvider:              "GCS",
+				OIDCStorageProviderGCSBucketName: "mybucket",
+			},
+			expectError: true,
+		},
+		"when the GCS oidc storage provider is set with bucket and credentials there is no error": {
+			inputOptions: Options{
+				PrivatePlatform:                      string(hyperv1.NonePlatform),
+				OIDCStorageProvider:                  "GCS",
+				OIDCStorageProviderGCSBucketName:     "mybucket",
+				OIDCStorageProviderCredentialsSecret: "mysecret",
+			},
+			expectError: false,
+		},
+		"when the ManagementCluster oidc storage provider is set without issuer url base it errors": {
+			inputOptions: Options{
+				PrivatePlatform:     string(hyperv1.NonePlatform),
+				OIDCStorageProvider: "ManagementCluster",
+			},
+			expectError: true,
+		},
+		"when the ManagementCluster oidc storage provider is set with an issuer url base with a path it errors": {
+			inputOptions: Options{
+				PrivatePlatform:                  string(hyperv1.NonePlatform),
+				OIDCStorageProvider:              "ManagementCluster",
+				OIDCStorageProviderIssuerURLBase: "https://oidc.apps.example.com/clusters",
+			},
+			expectError: true,
+		},
+		"when the ManagementCluster oidc storage provider is set with an https issuer url base there is no error": {
+			inputOptions: Options{
+				PrivatePlatform:                  string(hyperv1.NonePlatform),
+				OIDCStorageProvider:              "ManagementCluster",
+				OIDCStorageProviderIssuerURLBase: "https://oidc.apps.example.com",
+			},
+			expectError: false,
+		},
 		"when all data specified there is no error": {
 			inputOptions: Options{
 				PrivatePlatform:                           string(hyperv1.NonePlatform),
@@ -83,6 +139,29 @@ func TestOptions_Validate(t *testing.T) {
 			},
 			expectError: false,
 		},
+		"when ha is used with development it errors": {
+			inputOptions: Options{
+				PrivatePlatform:  string(hyperv1.NonePlatform),
+				HighAvailability: true,
+				Development:      true,
+			},
+			expectError: true,
+		},
+		"when an unknown operator resource profile is used it errors": {
+			inputOptions: Options{
+				PrivatePlatform:         string(hyperv1.NonePlatform),
+				OperatorResourceProfile: "huge",
+			},
+			expectError: true,
+		},
+		"when ha is used with an operator resource profile there is no error": {
+			inputOptions: Options{
+				PrivatePlatform:         string(hyperv1.NonePlatform),
+				HighAvailability:        true,
+				OperatorResourceProfile: "large",
+			},
+			expectError: false,
+		},
 	}
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -96,3 +175,34 @@ func TestOptions_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestOptions_ApplyDefaultsHighAvailability(t *testing.T) {
+	tests := map[string]struct {
+		inputOptions     Options
+		expectedReplicas int32
+		expectedProfile  string
+	}{
+		"when ha is set it runs 3 replicas with the medium profile": {
+			inputOptions:     Options{HighAvailability: true, HyperShiftOperatorShards: 1},
+			expectedReplicas: 3,
+			expectedProfile:  "medium",
+		},
+		"when ha is set with more shards it runs a replica per shard plus a spare one": {
+			inputOptions:     Options{HighAvailability: true, HyperShiftOperatorShards: 3, OperatorResourceProfile: "small"},
+			expectedReplicas: 4,
+			expectedProfile:  "small",
+		},
+		"when ha is not set it runs a single replica without profile": {
+			inputOptions:     Options{HyperShiftOperatorShards: 1},
+			expectedReplicas: 1,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			test.inputOptions.ApplyDefaults()
+			g.Expect(test.inputOptions.HyperShiftOperatorReplicas).To(Equal(test.expectedReplicas))
+			g.Expect(test.inputOptions.OperatorResourceProfile).To(Equal(test.expectedProfile))
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package instancetypes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	awsutil "github.com/openshift/hypershift/cmd/infra/aws/util"
+	"github.com/spf13/cobra"
+)
+
+type AWSOptions struct {
+	Region             string
+	AWSCredentialsFile string
+}
+
+func NewAWSCommand(opts *Options) *cobra.Command {
+	awsOpts := &AWSOptions{
+		Region: "us-east-1",
+	}
+
+	cmd := &cobra.Command{
+		Use:          "aws",
+		Short:        "Lists the EC2 instance types of a region meeting the OpenShift worker requirements",
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringVar(&awsOpts.Region, "region", awsOpts.Region, "The region to list the instance types of")
+	cmd.Flags().StringVar(&awsOpts.AWSCredentialsFile, "aws-creds", awsOpts.AWSCredentialsFile, "Path to an AWS credentials file (required)")
+	_ = cmd.MarkFlagRequired("aws-creds")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		awsSession := awsutil.NewSession("cli-instance-types", awsOpts.AWSCredentialsFile, "", "", awsOpts.Region)
+		ec2Client := ec2.New(awsSession, awsutil.NewConfig())
+		return opts.Run(cmd.Context(), func(ctx context.Context, names ...string) ([]InstanceType, error) {
+			return ListAWSInstanceTypes(ctx, ec2Client, names...)
+		}, cmd.OutOrStdout())
+	}
+
+	return cmd
+}
+
+// ListAWSInstanceTypes lists the current generation instance types offered in the region of the client, or the given
+// instance types only.
+func ListAWSInstanceTypes(ctx context.Context, ec2Client ec2iface.EC2API, names ...string) ([]InstanceType, error) {
+	input := &ec2.DescribeInstanceTypesInput{}
+	if len(names) > 0 {
+		input.InstanceTypes = aws.StringSlice(names)
+	} else {
+		input.Filters = []*ec2.Filter{{Name: aws.String("current-generation"), Values: aws.StringSlice([]string{"true"})}}
+	}
+	var instanceTypes []InstanceType
+	err := ec2Client.DescribeInstanceTypesPagesWithContext(ctx, input, func(output *ec2.DescribeInstanceTypesOutput, _ bool) bool {
+		for _, info := range output.InstanceTypes {
+			if instanceType, ok := awsInstanceType(info); ok {
+				instanceTypes = append(instanceTypes, instanceType)
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe instance types: %w", err)
+	}
+	return instanceTypes, nil
+}
+
+// awsInstanceType converts the description of an EC2 instance type. It returns false for the instance types of
+// architectures NodePools don't support.
+func awsInstanceType(info *ec2.InstanceTypeInfo) (InstanceType, bool) {
+	instanceType := InstanceType{Name: aws.StringValue(info.InstanceType)}
+	if info.ProcessorInfo != nil {
+		for _, arch := range aws.StringValueSlice(info.ProcessorInfo.SupportedArchitectures) {
+			switch arch {
+			case ec2.ArchitectureTypeX8664:
+				instanceType.Arch = hyperv1.ArchitectureAMD64
+			case ec2.ArchitectureTypeArm64:
+				instanceType.Arch = hyperv1.ArchitectureARM64
+			}
+		}
+	}
+	if instanceType.Arch == "" {
+		return InstanceType{}, false
+	}
+	if info.VCpuInfo != nil {
+		instanceType.VCPUs = aws.Int64Value(info.VCpuInfo.DefaultVCpus)
+	}
+	if info.MemoryInfo != nil {
+		instanceType.MemoryMiB = aws.Int64Value(info.MemoryInfo.SizeInMiB)
+	}
+	if info.GpuInfo != nil {
+		for _, gpu := range info.GpuInfo.Gpus {
+			instanceType.GPUs += aws.Int64Value(gpu.Count)
+		}
+	}
+	return instanceType, true
+}
+
+// ValidateAWSInstanceType validates the instance type is offered in the region of the client and meets the OpenShift
+// worker requirements of the architecture.
+func ValidateAWSInstanceType(ctx context.Context, ec2Client ec2iface.EC2API, name, arch string) error {
+	instanceTypes, err := ListAWSInstanceTypes(ctx, ec2Client, name)
+	if err != nil {
+		return fmt.Errorf("failed to validate instance type %s: %w", name, err)
+	}
+	if len(instanceTypes) == 0 {
+		return fmt.Errorf("instance type %s is not offered", name)
+	}
+	return WorkerRequirements(arch).Check(instanceTypes[0])
+}
@@ -0,0 +1,143 @@
+package instancetypes
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/cmd/infra/cloudapi"
+	"github.com/openshift/hypershift/cmd/log"
+	"github.com/openshift/hypershift/cmd/util"
+	"github.com/spf13/cobra"
+	"k8s.io/utils/ptr"
+)
+
+type AzureOptions struct {
+	Location        string
+	CredentialsFile string
+}
+
+func NewAzureCommand(opts *Options) *cobra.Command {
+	azureOpts := &AzureOptions{
+		Location: "eastus",
+	}
+
+	cmd := &cobra.Command{
+		Use:          "azure",
+		Short:        "Lists the VM sizes of a location meeting the OpenShift worker requirements",
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringVar(&azureOpts.Location, "location", azureOpts.Location, "The location to list the VM sizes of")
+	cmd.Flags().StringVar(&azureOpts.CredentialsFile, "azure-creds", azureOpts.CredentialsFile, "Path to an Azure credentials file (required)")
+	_ = cmd.MarkFlagRequired("azure-creds")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		subscriptionID, azureCreds, err := util.SetupAzureCredentialsWithOptions(log.Log, nil, azureOpts.CredentialsFile, cloudapi.FromContext(cmd.Context()).AzureCredentialOptions())
+		if err != nil {
+			return err
+		}
+		return opts.Run(cmd.Context(), func(ctx context.Context, names ...string) ([]InstanceType, error) {
+			return ListAzureVMSizes(ctx, subscriptionID, azureCreds, azureOpts.Location, names...)
+		}, cmd.OutOrStdout())
+	}
+
+	return cmd
+}
+
+// ListAzureVMSizes lists the VM sizes the subscription can create in the location, or the given VM sizes only.
+func ListAzureVMSizes(ctx context.Context, subscriptionID string, azureCreds azcore.TokenCredential, location string, names ...string) ([]InstanceType, error) {
+	skusClient, err := armcompute.NewResourceSKUsClient(subscriptionID, azureCreds, cloudapi.FromContext(ctx).ARMClientOptions())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create resource SKUs client: %w", err)
+	}
+	var skus []*armcompute.ResourceSKU
+	pager := skusClient.NewListPager(&armcompute.ResourceSKUsClientListOptions{Filter: ptr.To(fmt.Sprintf("location eq '%s'", location))})
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list resource SKUs of location %s: %w", location, err)
+		}
+		skus = append(skus, page.Value...)
+	}
+	return azureVMSizes(skus, location, names...), nil
+}
+
+// azureVMSizes returns the VM sizes of the resource SKUs which aren't restricted in the location, or the given VM
+// sizes only.
+func azureVMSizes(skus []*armcompute.ResourceSKU, location string, names ...string) []InstanceType {
+	var vmSizes []InstanceType
+	for _, sku := range skus {
+		if sku == nil || ptr.Deref(sku.ResourceType, "") != "virtualMachines" || restrictedInLocation(sku, location) {
+			continue
+		}
+		name := ptr.Deref(sku.Name, "")
+		if len(names) > 0 && !containsFold(names, name) {
+			continue
+		}
+		vmSize := InstanceType{Name: name, Arch: hyperv1.ArchitectureAMD64}
+		for _, capability := range sku.Capabilities {
+			if capability == nil {
+				continue
+			}
+			value := ptr.Deref(capability.Value, "")
+			switch ptr.Deref(capability.Name, "") {
+			case "vCPUs":
+				vmSize.VCPUs, _ = strconv.ParseInt(value, 10, 64)
+			case "MemoryGB":
+				if memoryGiB, err := strconv.ParseFloat(value, 64); err == nil {
+					vmSize.MemoryMiB = int64(math.Round(memoryGiB * 1024))
+				}
+			case "GPUs":
+				vmSize.GPUs, _ = strconv.ParseInt(value, 10, 64)
+			case "CpuArchitectureType":
+				if strings.EqualFold(value, "Arm64") {
+					vmSize.Arch = hyperv1.ArchitectureARM64
+				}
+			}
+		}
+		vmSizes = append(vmSizes, vmSize)
+	}
+	return vmSizes
+}
+
+func restrictedInLocation(sku *armcompute.ResourceSKU, location string) bool {
+	for _, restriction := range sku.Restrictions {
+		if restriction == nil || ptr.Deref(restriction.Type, "") != armcompute.ResourceSKURestrictionsTypeLocation {
+			continue
+		}
+		for _, value := range restriction.Values {
+			if strings.EqualFold(ptr.Deref(value, ""), location) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateAzureVMSize validates the subscription can create VMs of the VM size in the location, and the VM size meets
+// the OpenShift worker requirements of the architecture.
+func ValidateAzureVMSize(ctx context.Context, subscriptionID string, azureCreds azcore.TokenCredential, location, name, arch string) error {
+	vmSizes, err := ListAzureVMSizes(ctx, subscriptionID, azureCreds, location, name)
+	if err != nil {
+		return fmt.Errorf("failed to validate VM size %s: %w", name, err)
+	}
+	if len(vmSizes) == 0 {
+		return fmt.Errorf("VM size %s is not offered in location %s", name, location)
+	}
+	return WorkerRequirements(arch).Check(vmSizes[0])
+}
@@ -0,0 +1,167 @@
+package instancetypes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/cmd/cluster/core"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// MinWorkerCPUs and MinWorkerMemoryGiB are the minimum resources of OpenShift worker machines.
+	MinWorkerCPUs      = 2
+	MinWorkerMemoryGiB = 8
+)
+
+// InstanceType is a machine type of a cloud provider.
+type InstanceType struct {
+	Name      string `json:"name"`
+	Arch      string `json:"arch"`
+	VCPUs     int64  `json:"vcpus"`
+	MemoryMiB int64  `json:"memoryMiB"`
+	GPUs      int64  `json:"gpus,omitempty"`
+}
+
+// Requirements are the requirements the instance types of NodePools must meet.
+type Requirements struct {
+	MinCPUs      int64
+	MinMemoryGiB int64
+	// Arch is the processor architecture the instance types must have, any when empty.
+	Arch string
+	// GPU requires the instance types to have GPUs.
+	GPU bool
+}
+
+// WorkerRequirements returns the requirements of OpenShift worker machines of the architecture.
+func WorkerRequirements(arch string) Requirements {
+	return Requirements{MinCPUs: MinWorkerCPUs, MinMemoryGiB: MinWorkerMemoryGiB, Arch: arch}
+}
+
+// Check returns an error describing why the instance type doesn't meet the requirements, if it doesn't.
+func (r Requirements) Check(instanceType InstanceType) error {
+	var problems []string
+	if r.Arch != "" && instanceType.Arch != r.Arch {
+		problems = append(problems, fmt.Sprintf("its architecture is %s, not %s", instanceType.Arch, r.Arch))
+	}
+	if instanceType.VCPUs < r.MinCPUs {
+		problems = append(problems, fmt.Sprintf("it has %d vCPUs, less than %d", instanceType.VCPUs, r.MinCPUs))
+	}
+	if instanceType.MemoryMiB < r.MinMemoryGiB*1024 {
+		problems = append(problems, fmt.Sprintf("it has %s of memory, less than %d GiB", formatMemory(instanceType.MemoryMiB), r.MinMemoryGiB))
+	}
+	if r.GPU && instanceType.GPUs == 0 {
+		problems = append(problems, "it has no GPUs")
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("instance type %s doesn't meet the requirements: %s", instanceType.Name, strings.Join(problems, ", "))
+	}
+	return nil
+}
+
+// Filter returns the instance types meeting the requirements, from the smallest to the largest.
+func Filter(instanceTypes []InstanceType, requirements Requirements) []InstanceType {
+	var result []InstanceType
+	for _, instanceType := range instanceTypes {
+		if requirements.Check(instanceType) == nil {
+			result = append(result, instanceType)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].VCPUs != result[j].VCPUs {
+			return result[i].VCPUs < result[j].VCPUs
+		}
+		if result[i].MemoryMiB != result[j].MemoryMiB {
+			return result[i].MemoryMiB < result[j].MemoryMiB
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// Options are the options of the instance-types commands common to the platforms.
+type Options struct {
+	Requirements
+	// Validate is an instance type to validate against the requirements instead of listing the instance types.
+	Validate string
+	Output   string
+}
+
+// lister lists the instance types of a platform, or the given ones only.
+type lister func(ctx context.Context, names ...string) ([]InstanceType, error)
+
+func NewCommand() *cobra.Command {
+	opts := &Options{
+		Requirements: Requirements{MinCPUs: MinWorkerCPUs, MinMemoryGiB: MinWorkerMemoryGiB},
+		Output:       core.StatusOutputText,
+	}
+
+	cmd := &cobra.Command{
+		Use:          "instance-types",
+		Short:        "Lists the instance types meeting the OpenShift worker requirements, or validates one",
+		SilenceUsage: true,
+	}
+
+	cmd.PersistentFlags().StringVar(&opts.Arch, "arch", opts.Arch, "Only list the instance types of this processor architecture (e.g. arm64, amd64)")
+	cmd.PersistentFlags().Int64Var(&opts.MinCPUs, "min-cpus", opts.MinCPUs, "The minimum number of vCPUs of the instance types")
+	cmd.PersistentFlags().Int64Var(&opts.MinMemoryGiB, "min-memory", opts.MinMemoryGiB, "The minimum memory of the instance types, in GiB")
+	cmd.PersistentFlags().BoolVar(&opts.GPU, "gpu", opts.GPU, "Only list the instance types with GPUs")
+	cmd.PersistentFlags().StringVar(&opts.Validate, "validate", opts.Validate, "Validate this instance type against the requirements instead of listing the instance types")
+	cmd.PersistentFlags().StringVarP(&opts.Output, "output", "o", opts.Output, "Output format. Supported options: text, json")
+
+	cmd.AddCommand(NewAWSCommand(opts))
+	cmd.AddCommand(NewAzureCommand(opts))
+
+	return cmd
+}
+
+// Run lists the instance types meeting the requirements, or validates the instance type to validate.
+func (o *Options) Run(ctx context.Context, list lister, out io.Writer) error {
+	if o.Output != core.StatusOutputText && o.Output != core.StatusOutputJSON {
+		return fmt.Errorf("unsupported output format %q", o.Output)
+	}
+	if o.Arch != "" && o.Arch != hyperv1.ArchitectureAMD64 && o.Arch != hyperv1.ArchitectureARM64 {
+		return fmt.Errorf("unsupported architecture %q", o.Arch)
+	}
+
+	var names []string
+	if o.Validate != "" {
+		names = []string{o.Validate}
+	}
+	instanceTypes, err := list(ctx, names...)
+	if err != nil {
+		return err
+	}
+	if o.Validate != "" {
+		if len(instanceTypes) == 0 {
+			return fmt.Errorf("instance type %s is not offered", o.Validate)
+		}
+		if err := o.Check(instanceTypes[0]); err != nil {
+			return err
+		}
+	} else {
+		instanceTypes = Filter(instanceTypes, o.Requirements)
+	}
+
+	if o.Output == core.StatusOutputJSON {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(instanceTypes)
+	}
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(w, "NAME\tARCH\tVCPUS\tMEMORY\tGPUS\n")
+	for _, instanceType := range instanceTypes {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%d\n", instanceType.Name, instanceType.Arch, instanceType.VCPUs, formatMemory(instanceType.MemoryMiB), instanceType.GPUs)
+	}
+	return w.Flush()
+}
+
+func formatMemory(memoryMiB int64) string {
+	return fmt.Sprintf("%g GiB", float64(memoryMiB)/1024)
+}
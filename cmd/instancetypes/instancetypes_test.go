@@ -0,0 +1,257 @@
+package instancetypes
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	. "github.com/onsi/gomega"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/cmd/cluster/core"
+	"k8s.io/utils/ptr"
+)
+
+var testInstanceTypes = []InstanceType{
+	{Name: "m5.xlarge", Arch: hyperv1.ArchitectureAMD64, VCPUs: 4, MemoryMiB: 16384},
+	{Name: "m5.large", Arch: hyperv1.ArchitectureAMD64, VCPUs: 2, MemoryMiB: 8192},
+	{Name: "t3.medium", Arch: hyperv1.ArchitectureAMD64, VCPUs: 2, MemoryMiB: 4096},
+	{Name: "m6g.xlarge", Arch: hyperv1.ArchitectureARM64, VCPUs: 4, MemoryMiB: 16384},
+	{Name: "g4dn.xlarge", Arch: hyperv1.ArchitectureAMD64, VCPUs: 4, MemoryMiB: 16384, GPUs: 1},
+}
+
+func TestRequirementsCheck(t *testing.T) {
+	testCases := []struct {
+		name          string
+		requirements  Requirements
+		instanceType  InstanceType
+		expectedError string
+	}{
+		{
+			name:         "When the instance type meets the worker requirements it should succeed",
+			requirements: WorkerRequirements(hyperv1.ArchitectureAMD64),
+			instanceType: InstanceType{Name: "m5.large", Arch: hyperv1.ArchitectureAMD64, VCPUs: 2, MemoryMiB: 8192},
+		},
+		{
+			name:          "When the instance type has too little memory it should fail",
+			requirements:  WorkerRequirements(hyperv1.ArchitectureAMD64),
+			instanceType:  InstanceType{Name: "t3.medium", Arch: hyperv1.ArchitectureAMD64, VCPUs: 2, MemoryMiB: 4096},
+			expectedError: "instance type t3.medium doesn't meet the requirements: it has 4 GiB of memory, less than 8 GiB",
+		},
+		{
+			name:          "When the instance type has another architecture and too few vCPUs it should report both",
+			requirements:  WorkerRequirements(hyperv1.ArchitectureARM64),
+			instanceType:  InstanceType{Name: "t3.micro", Arch: hyperv1.ArchitectureAMD64, VCPUs: 1, MemoryMiB: 8192},
+			expectedError: "instance type t3.micro doesn't meet the requirements: its architecture is amd64, not arm64, it has 1 vCPUs, less than 2",
+		},
+		{
+			name:          "When GPUs are required and the instance type has none it should fail",
+			requirements:  Requirements{MinCPUs: MinWorkerCPUs, MinMemoryGiB: MinWorkerMemoryGiB, GPU: true},
+			instanceType:  InstanceType{Name: "m5.large", Arch: hyperv1.ArchitectureAMD64, VCPUs: 2, MemoryMiB: 8192},
+			expectedError: "instance type m5.large doesn't meet the requirements: it has no GPUs",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := tc.requirements.Check(tc.instanceType)
+			if tc.expectedError != "" {
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestFilter(t *testing.T) {
+	testCases := []struct {
+		name         string
+		requirements Requirements
+		expected     []string
+	}{
+		{
+			name:         "When filtering by the worker requirements it should sort the instance types from the smallest",
+			requirements: WorkerRequirements(""),
+			expected:     []string{"m5.large", "g4dn.xlarge", "m5.xlarge", "m6g.xlarge"},
+		},
+		{
+			name:         "When filtering by architecture it should only return the instance types of the architecture",
+			requirements: WorkerRequirements(hyperv1.ArchitectureARM64),
+			expected:     []string{"m6g.xlarge"},
+		},
+		{
+			name:         "When filtering by GPU it should only return the instance types with GPUs",
+			requirements: Requirements{MinCPUs: MinWorkerCPUs, MinMemoryGiB: MinWorkerMemoryGiB, GPU: true},
+			expected:     []string{"g4dn.xlarge"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			var names []string
+			for _, instanceType := range Filter(testInstanceTypes, tc.requirements) {
+				names = append(names, instanceType.Name)
+			}
+			g.Expect(names).To(Equal(tc.expected))
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	list := func(_ context.Context, names ...string) ([]InstanceType, error) {
+		if len(names) == 0 {
+			return testInstanceTypes, nil
+		}
+		var result []InstanceType
+		for _, instanceType := range testInstanceTypes {
+			if instanceType.Name == names[0] {
+				result = append(result, instanceType)
+			}
+		}
+		return result, nil
+	}
+
+	t.Run("When listing as text it should print a table of the instance types meeting the requirements", func(t *testing.T) {
+		g := NewWithT(t)
+		opts := &Options{Requirements: WorkerRequirements(hyperv1.ArchitectureARM64), Output: core.StatusOutputText}
+		out := &bytes.Buffer{}
+		g.Expect(opts.Run(context.Background(), list, out)).To(Succeed())
+		g.Expect(out.String()).To(Equal("NAME        ARCH   VCPUS  MEMORY  GPUS\nm6g.xlarge  arm64  4      16 GiB  0\n"))
+	})
+
+	t.Run("When listing as JSON it should print the instance types meeting the requirements", func(t *testing.T) {
+		g := NewWithT(t)
+		opts := &Options{Requirements: Requirements{MinCPUs: MinWorkerCPUs, MinMemoryGiB: MinWorkerMemoryGiB, GPU: true}, Output: core.StatusOutputJSON}
+		out := &bytes.Buffer{}
+		g.Expect(opts.Run(context.Background(), list, out)).To(Succeed())
+		var instanceTypes []InstanceType
+		g.Expect(json.Unmarshal(out.Bytes(), &instanceTypes)).To(Succeed())
+		g.Expect(instanceTypes).To(Equal([]InstanceType{testInstanceTypes[4]}))
+	})
+
+	t.Run("When validating an instance type below the requirements it should fail", func(t *testing.T) {
+		g := NewWithT(t)
+		opts := &Options{Requirements: WorkerRequirements(""), Validate: "t3.medium", Output: core.StatusOutputText}
+		g.Expect(opts.Run(context.Background(), list, &bytes.Buffer{})).To(MatchError(ContainSubstring("it has 4 GiB of memory")))
+	})
+
+	t.Run("When validating an instance type which isn't offered it should fail", func(t *testing.T) {
+		g := NewWithT(t)
+		opts := &Options{Requirements: WorkerRequirements(""), Validate: "x9.huge", Output: core.StatusOutputText}
+		g.Expect(opts.Run(context.Background(), list, &bytes.Buffer{})).To(MatchError("instance type x9.huge is not offered"))
+	})
+
+	t.Run("When the output format is unsupported it should fail", func(t *testing.T) {
+		g := NewWithT(t)
+		opts := &Options{Output: "yaml"}
+		g.Expect(opts.Run(context.Background(), list, &bytes.Buffer{})).To(MatchError(`unsupported output format "yaml"`))
+	})
+}
+
+type fakeEC2 struct {
+	ec2iface.EC2API
+	instanceTypes []*ec2.InstanceTypeInfo
+	input         *ec2.DescribeInstanceTypesInput
+}
+
+func (f *fakeEC2) DescribeInstanceTypesPagesWithContext(_ aws.Context, input *ec2.DescribeInstanceTypesInput, fn func(*ec2.DescribeInstanceTypesOutput, bool) bool, _ ...request.Option) error {
+	f.input = input
+	var instanceTypes []*ec2.InstanceTypeInfo
+	for _, info := range f.instanceTypes {
+		if len(input.InstanceTypes) == 0 || aws.StringValue(input.InstanceTypes[0]) == aws.StringValue(info.InstanceType) {
+			instanceTypes = append(instanceTypes, info)
+		}
+	}
+	fn(&ec2.DescribeInstanceTypesOutput{InstanceTypes: instanceTypes}, true)
+	return nil
+}
+
+func TestListAWSInstanceTypes(t *testing.T) {
+	g := NewWithT(t)
+	client := &fakeEC2{instanceTypes: []*ec2.InstanceTypeInfo{
+		{
+			InstanceType:  aws.String("g4dn.xlarge"),
+			ProcessorInfo: &ec2.ProcessorInfo{SupportedArchitectures: aws.StringSlice([]string{ec2.ArchitectureTypeX8664})},
+			VCpuInfo:      &ec2.VCpuInfo{DefaultVCpus: aws.Int64(4)},
+			MemoryInfo:    &ec2.MemoryInfo{SizeInMiB: aws.Int64(16384)},
+			GpuInfo:       &ec2.GpuInfo{Gpus: []*ec2.GpuDeviceInfo{{Count: aws.Int64(1)}}},
+		},
+		{
+			InstanceType:  aws.String("m6g.large"),
+			ProcessorInfo: &ec2.ProcessorInfo{SupportedArchitectures: aws.StringSlice([]string{ec2.ArchitectureTypeArm64})},
+			VCpuInfo:      &ec2.VCpuInfo{DefaultVCpus: aws.Int64(2)},
+			MemoryInfo:    &ec2.MemoryInfo{SizeInMiB: aws.Int64(8192)},
+		},
+		{
+			InstanceType:  aws.String("mac1.metal"),
+			ProcessorInfo: &ec2.ProcessorInfo{SupportedArchitectures: aws.StringSlice([]string{ec2.ArchitectureTypeX8664Mac})},
+		},
+	}}
+
+	instanceTypes, err := ListAWSInstanceTypes(context.Background(), client)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(client.input.Filters).To(HaveLen(1))
+	g.Expect(instanceTypes).To(Equal([]InstanceType{
+		{Name: "g4dn.xlarge", Arch: hyperv1.ArchitectureAMD64, VCPUs: 4, MemoryMiB: 16384, GPUs: 1},
+		{Name: "m6g.large", Arch: hyperv1.ArchitectureARM64, VCPUs: 2, MemoryMiB: 8192},
+	}))
+
+	g.Expect(ValidateAWSInstanceType(context.Background(), client, "m6g.large", hyperv1.ArchitectureARM64)).To(Succeed())
+	g.Expect(aws.StringValueSlice(client.input.InstanceTypes)).To(Equal([]string{"m6g.large"}))
+	g.Expect(client.input.Filters).To(BeEmpty())
+}
+
+func TestAzureVMSizes(t *testing.T) {
+	g := NewWithT(t)
+	capabilities := func(values map[string]string) []*armcompute.ResourceSKUCapabilities {
+		var result []*armcompute.ResourceSKUCapabilities
+		for name, value := range values {
+			result = append(result, &armcompute.ResourceSKUCapabilities{Name: ptr.To(name), Value: ptr.To(value)})
+		}
+		return result
+	}
+	skus := []*armcompute.ResourceSKU{
+		{
+			Name:         ptr.To("Standard_D4s_v4"),
+			ResourceType: ptr.To("virtualMachines"),
+			Capabilities: capabilities(map[string]string{"vCPUs": "4", "MemoryGB": "16", "CpuArchitectureType": "x64"}),
+		},
+		{
+			Name:         ptr.To("Standard_D4ps_v5"),
+			ResourceType: ptr.To("virtualMachines"),
+			Capabilities: capabilities(map[string]string{"vCPUs": "4", "MemoryGB": "16", "CpuArchitectureType": "Arm64"}),
+		},
+		{
+			Name:         ptr.To("Standard_NC4as_T4_v3"),
+			ResourceType: ptr.To("virtualMachines"),
+			Capabilities: capabilities(map[string]string{"vCPUs": "4", "MemoryGB": "28", "GPUs": "1"}),
+		},
+		{
+			Name:         ptr.To("Standard_D2s_v3"),
+			ResourceType: ptr.To("virtualMachines"),
+			Restrictions: []*armcompute.ResourceSKURestrictions{{
+				Type:   ptr.To(armcompute.ResourceSKURestrictionsTypeLocation),
+				Values: []*string{ptr.To("eastus")},
+			}},
+		},
+		{
+			Name:         ptr.To("Premium_LRS"),
+			ResourceType: ptr.To("disks"),
+		},
+	}
+
+	g.Expect(azureVMSizes(skus, "eastus")).To(Equal([]InstanceType{
+		{Name: "Standard_D4s_v4", Arch: hyperv1.ArchitectureAMD64, VCPUs: 4, MemoryMiB: 16384},
+		{Name: "Standard_D4ps_v5", Arch: hyperv1.ArchitectureARM64, VCPUs: 4, MemoryMiB: 16384},
+		{Name: "Standard_NC4as_T4_v3", Arch: hyperv1.ArchitectureAMD64, VCPUs: 4, MemoryMiB: 28672, GPUs: 1},
+	}))
+	g.Expect(azureVMSizes(skus, "eastus", "standard_d4ps_v5")).To(Equal([]InstanceType{
+		{Name: "Standard_D4ps_v5", Arch: hyperv1.ArchitectureARM64, VCPUs: 4, MemoryMiB: 16384},
+	}))
+}
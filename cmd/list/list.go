@@ -0,0 +1,18 @@
+package list
+
+import (
+	"github.com/openshift/hypershift/cmd/infra"
+	"github.com/spf13/cobra"
+)
+
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "list",
+		Short:        "Commands for listing HyperShift resources",
+		SilenceUsage: true,
+	}
+
+	cmd.AddCommand(infra.NewListCommand())
+
+	return cmd
+}
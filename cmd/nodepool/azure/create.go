@@ -20,6 +20,10 @@ type AzurePlatformCreateOptions struct {
 	EnableEphemeralOSDisk  bool
 	DiskStorageAccountType string
 	SubnetID               string
+
+	EnableAcceleratedNetworking bool
+	SecurityType                string
+	ConfidentialDiskEncryption  string
 }
 
 func NewCreateCommand(coreOpts *core.CreateNodePoolOptions) *cobra.Command {
@@ -36,12 +40,15 @@ func NewCreateCommand(coreOpts *core.CreateNodePoolOptions) *cobra.Command {
 
 	cmd.Flags().StringVar(&platformOpts.InstanceType, "instance-type", platformOpts.InstanceType, "The instance type to use for the nodepool")
 	cmd.Flags().Int32Var(&platformOpts.DiskSize, "root-disk-size", platformOpts.DiskSize, "The size of the root disk for machines in the NodePool (minimum 16)")
-	cmd.Flags().StringVar(&platformOpts.AvailabilityZone, "availability-zone", platformOpts.AvailabilityZone, "The availabilityZone for the nodepool. Must be left unspecified if in a region that doesn't support AZs")
+	cmd.Flags().StringVar(&platformOpts.AvailabilityZone, "availability-zone", platformOpts.AvailabilityZone, "The availabilityZone for the nodepool. Must be left unspecified if in a region that doesn't support AZs. When unspecified, the VMs of the nodepool are spread across the fault domains of an availability set of the nodepool")
 	cmd.Flags().StringVar(&platformOpts.ResourceGroupName, "resource-group-name", platformOpts.ResourceGroupName, "A resource group name to create the HostedCluster infrastructure resources under.")
 	cmd.Flags().StringVar(&platformOpts.DiskEncryptionSetID, "disk-encryption-set-id", platformOpts.DiskEncryptionSetID, "The Disk Encryption Set ID to use to encrypt the OS disks for the VMs.")
 	cmd.Flags().BoolVar(&platformOpts.EnableEphemeralOSDisk, "enable-ephemeral-disk", platformOpts.EnableEphemeralOSDisk, "If enabled, the Azure VMs in the NodePool will be setup with ephemeral OS disks")
 	cmd.Flags().StringVar(&platformOpts.DiskStorageAccountType, "disk-storage-account-type", platformOpts.DiskStorageAccountType, "The disk storage account type for the OS disks for the VMs.")
 	cmd.Flags().StringVar(&platformOpts.SubnetID, "subnet-id", platformOpts.SubnetID, "The subnet id where the VMs will be placed.")
+	cmd.Flags().StringVar(&platformOpts.SecurityType, "security-type", platformOpts.SecurityType, "The security type of the Azure VMs in the NodePool, TrustedLaunch or ConfidentialVM. The boot image of the NodePool is then updated automatically to a Generation 2 image of its release")
+	cmd.Flags().StringVar(&platformOpts.ConfidentialDiskEncryption, "confidential-disk-encryption", platformOpts.ConfidentialDiskEncryption, "The encryption of the OS disks of confidential VMs, VMGuestStateOnly or DiskWithVMGuestState")
+	cmd.Flags().BoolVar(&platformOpts.EnableAcceleratedNetworking, "enable-accelerated-networking", platformOpts.EnableAcceleratedNetworking, "If enabled, the Azure VMs in the NodePool will be setup with accelerated networking. The instance type must support it")
 
 	cmd.RunE = coreOpts.CreateRunFunc(platformOpts)
 
@@ -53,6 +60,9 @@ func (o *AzurePlatformCreateOptions) UpdateNodePool(_ context.Context, nodePool
 	if o.DiskEncryptionSetID != "" && o.ResourceGroupName == "" {
 		return fmt.Errorf("resource-group-name is required when using disk-encryption-set-id")
 	}
+	if o.ConfidentialDiskEncryption != "" && o.SecurityType != string(hyperv1.AzureVMSecurityTypeConfidentialVM) {
+		return fmt.Errorf("confidential-disk-encryption requires --security-type=%s", hyperv1.AzureVMSecurityTypeConfidentialVM)
+	}
 
 	nodePool.Spec.Platform.Type = hyperv1.AzurePlatform
 	nodePool.Spec.Platform.Azure = &hyperv1.AzureNodePoolPlatform{
@@ -63,6 +73,16 @@ func (o *AzurePlatformCreateOptions) UpdateNodePool(_ context.Context, nodePool
 		EnableEphemeralOSDisk:  o.EnableEphemeralOSDisk,
 		DiskStorageAccountType: o.DiskStorageAccountType,
 		SubnetID:               o.SubnetID,
+
+		EnableAcceleratedNetworking: o.EnableAcceleratedNetworking,
+	}
+	if o.SecurityType != "" {
+		nodePool.Spec.Platform.Azure.SecurityProfile = &hyperv1.AzureVMSecurityProfile{
+			SecurityType:               hyperv1.AzureVMSecurityType(o.SecurityType),
+			ConfidentialDiskEncryption: hyperv1.AzureConfidentialDiskEncryption(o.ConfidentialDiskEncryption),
+		}
+		// The default boot image can't boot VMs with a security type.
+		nodePool.Spec.BootImageUpdatePolicy = hyperv1.BootImageUpdatePolicyAutomatic
 	}
 	return nil
 }
@@ -0,0 +1,277 @@
+package nodepool
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	kubeclient "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/kubectl/pkg/drain"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/cmd/log"
+	"github.com/openshift/hypershift/cmd/util"
+	hyperapi "github.com/openshift/hypershift/support/api"
+)
+
+type DeleteOptions struct {
+	Name         string
+	Namespace    string
+	DrainFirst   bool
+	DrainTimeout time.Duration
+	Timeout      time.Duration
+	PollInterval time.Duration
+
+	Log logr.Logger
+}
+
+func NewDeleteCommand() *cobra.Command {
+	opts := &DeleteOptions{
+		Namespace:    "clusters",
+		DrainTimeout: 10 * time.Minute,
+		Timeout:      10 * time.Minute,
+		PollInterval: 10 * time.Second,
+		Log:          log.Log,
+	}
+
+	cmd := &cobra.Command{
+		Use:          "nodepool",
+		Short:        "Deletes a NodePool, optionally draining its nodes and verifying its workloads were relocated first",
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringVar(&opts.Name, "name", opts.Name, "The name of the NodePool (required)")
+	cmd.Flags().StringVar(&opts.Namespace, "namespace", opts.Namespace, "The namespace of the NodePool")
+	cmd.Flags().BoolVar(&opts.DrainFirst, "drain-first", opts.DrainFirst, "If true, cordons and drains all nodes of the NodePool and waits for the evicted workloads to run on other nodes before deleting the NodePool")
+	cmd.Flags().DurationVar(&opts.DrainTimeout, "drain-timeout", opts.DrainTimeout, "How long to wait for each node to be drained when --drain-first is set")
+	cmd.Flags().DurationVar(&opts.Timeout, "timeout", opts.Timeout, "How long to wait for the evicted workloads to be running on other nodes when --drain-first is set")
+
+	_ = cmd.MarkFlagRequired("name")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		c, err := util.GetClient()
+		if err != nil {
+			return err
+		}
+		if err := opts.Run(cmd.Context(), c, cmd.OutOrStdout()); err != nil {
+			opts.Log.Error(err, "Failed to delete NodePool")
+			return err
+		}
+		return nil
+	}
+
+	return cmd
+}
+
+func (o *DeleteOptions) Run(ctx context.Context, c crclient.Client, out io.Writer) error {
+	nodePool := &hyperv1.NodePool{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: o.Namespace, Name: o.Name}, nodePool); err != nil {
+		return fmt.Errorf("failed to get NodePool: %w", err)
+	}
+
+	if o.DrainFirst {
+		restConfig, err := guestRESTConfig(ctx, c, o.Namespace, nodePool.Spec.ClusterName)
+		if err != nil {
+			return err
+		}
+		guestClient, err := crclient.New(restConfig, crclient.Options{Scheme: hyperapi.Scheme})
+		if err != nil {
+			return fmt.Errorf("failed to create guest cluster client: %w", err)
+		}
+		guestKubeClient, err := kubeclient.NewForConfig(restConfig)
+		if err != nil {
+			return fmt.Errorf("failed to create guest cluster kube client: %w", err)
+		}
+		if err := o.drainNodePool(ctx, guestClient, o.drainer(ctx, guestKubeClient, out), out); err != nil {
+			return fmt.Errorf("NodePool %s/%s was not deleted: %w", o.Namespace, o.Name, err)
+		}
+	}
+
+	if err := c.Delete(ctx, nodePool); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete NodePool: %w", err)
+	}
+	fmt.Fprintf(out, "NodePool %s/%s deleted\n", o.Namespace, o.Name)
+	return nil
+}
+
+// guestRESTConfig returns a REST config for the guest cluster of the named HostedCluster, built from its published
+// admin kubeconfig.
+func guestRESTConfig(ctx context.Context, c crclient.Client, namespace, name string) (*rest.Config, error) {
+	hostedCluster := &hyperv1.HostedCluster{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, hostedCluster); err != nil {
+		return nil, fmt.Errorf("failed to get HostedCluster %s/%s: %w", namespace, name, err)
+	}
+	if hostedCluster.Status.KubeConfig == nil {
+		return nil, fmt.Errorf("HostedCluster %s/%s has no kubeconfig published yet", namespace, name)
+	}
+	kubeconfigSecret := &corev1.Secret{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: hostedCluster.Status.KubeConfig.Name}, kubeconfigSecret); err != nil {
+		return nil, fmt.Errorf("failed to get kubeconfig secret: %w", err)
+	}
+	restConfig, err := clientcmd.RESTConfigFromKubeConfig(kubeconfigSecret.Data["kubeconfig"])
+	if err != nil {
+		return nil, fmt.Errorf("failed to load guest kubeconfig: %w", err)
+	}
+	return restConfig, nil
+}
+
+func (o *DeleteOptions) drainer(ctx context.Context, kubeClient kubeclient.Interface, out io.Writer) *drain.Helper {
+	return &drain.Helper{
+		Ctx:                 ctx,
+		Client:              kubeClient,
+		IgnoreAllDaemonSets: true,
+		DeleteEmptyDirData:  true,
+		GracePeriodSeconds:  -1,
+		Timeout:             o.DrainTimeout,
+		OnPodDeletedOrEvicted: func(pod *corev1.Pod, usingEviction bool) {
+			fmt.Fprintf(out, "Evicted pod %s/%s\n", pod.Namespace, pod.Name)
+		},
+		Out:    out,
+		ErrOut: out,
+	}
+}
+
+// drainNodePool cordons every node of the NodePool, drains them and waits until the workloads that ran on them are
+// running on other nodes. Nodes are all cordoned before any is drained so evicted pods don't land on another node of
+// the same NodePool.
+func (o *DeleteOptions) drainNodePool(ctx context.Context, guestClient crclient.Client, drainer *drain.Helper, out io.Writer) error {
+	nodes := &corev1.NodeList{}
+	if err := guestClient.List(ctx, nodes, crclient.MatchingLabels{hyperv1.NodePoolLabel: o.Name}); err != nil {
+		return fmt.Errorf("failed to list nodes of NodePool: %w", err)
+	}
+	if len(nodes.Items) == 0 {
+		fmt.Fprintf(out, "NodePool %s/%s has no nodes to drain\n", o.Namespace, o.Name)
+		return nil
+	}
+
+	nodeNames := sets.New[string]()
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		nodeNames.Insert(node.Name)
+		if err := drain.RunCordonOrUncordon(drainer, node, true); err != nil {
+			return fmt.Errorf("failed to cordon node %s: %w", node.Name, err)
+		}
+		fmt.Fprintf(out, "Cordoned node %s\n", node.Name)
+	}
+
+	pods := &corev1.PodList{}
+	if err := guestClient.List(ctx, pods); err != nil {
+		return fmt.Errorf("failed to list pods: %w", err)
+	}
+	workloads := workloadsToRelocate(pods.Items, nodeNames)
+
+	for _, name := range sets.List(nodeNames) {
+		if err := drain.RunNodeDrain(drainer, name); err != nil {
+			return fmt.Errorf("failed to drain node %s, the NodePool nodes remain cordoned: %w", name, err)
+		}
+		fmt.Fprintf(out, "Drained node %s\n", name)
+	}
+
+	return o.waitForRelocation(ctx, guestClient, workloads, nodeNames, out)
+}
+
+// waitForRelocation polls the pods of the evicted workloads until all of them are running and ready, printing the
+// blockers whenever they change.
+func (o *DeleteOptions) waitForRelocation(ctx context.Context, guestClient crclient.Client, workloads map[types.UID]string, nodeNames sets.Set[string], out io.Writer) error {
+	if len(workloads) == 0 {
+		return nil
+	}
+	waitCtx, cancel := context.WithTimeout(ctx, o.Timeout)
+	defer cancel()
+
+	var blockers []string
+	err := wait.PollUntilContextCancel(waitCtx, o.PollInterval, true, func(ctx context.Context) (bool, error) {
+		pods := &corev1.PodList{}
+		if err := guestClient.List(ctx, pods); err != nil {
+			return false, err
+		}
+		current := relocationBlockers(pods.Items, workloads, nodeNames)
+		if strings.Join(current, "\n") != strings.Join(blockers, "\n") && len(current) > 0 {
+			fmt.Fprintf(out, "Waiting for workloads to be running on other nodes:\n  %s\n", strings.Join(current, "\n  "))
+		}
+		blockers = current
+		return len(blockers) == 0, nil
+	})
+	if err != nil {
+		if waitCtx.Err() != nil {
+			return fmt.Errorf("timed out after %s waiting for workloads to be running on other nodes, the NodePool nodes remain cordoned: %s", o.Timeout, strings.Join(blockers, "; "))
+		}
+		return err
+	}
+	fmt.Fprintf(out, "All workloads of NodePool %s/%s are running on other nodes\n", o.Namespace, o.Name)
+	return nil
+}
+
+// workloadsToRelocate returns the controllers of the pods running on the given nodes which are expected to be
+// recreated on other nodes, keyed by UID. Pods of DaemonSets, static pods and finished pods are not relocated.
+func workloadsToRelocate(pods []corev1.Pod, nodeNames sets.Set[string]) map[types.UID]string {
+	workloads := map[types.UID]string{}
+	for i := range pods {
+		pod := &pods[i]
+		if !nodeNames.Has(pod.Spec.NodeName) || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		owner := metav1.GetControllerOf(pod)
+		if owner == nil || owner.Kind == "DaemonSet" || owner.Kind == "Node" {
+			continue
+		}
+		workloads[owner.UID] = fmt.Sprintf("%s %s/%s", owner.Kind, pod.Namespace, owner.Name)
+	}
+	return workloads
+}
+
+// relocationBlockers describes every pod of the given workloads which is not yet running and ready on a node outside
+// the NodePool.
+func relocationBlockers(pods []corev1.Pod, workloads map[types.UID]string, nodeNames sets.Set[string]) []string {
+	var blockers []string
+	for i := range pods {
+		pod := &pods[i]
+		owner := metav1.GetControllerOf(pod)
+		if owner == nil || pod.DeletionTimestamp != nil || pod.Status.Phase == corev1.PodSucceeded {
+			continue
+		}
+		workload, ok := workloads[owner.UID]
+		if !ok {
+			continue
+		}
+		if blocker := podRelocationBlocker(pod, nodeNames); blocker != "" {
+			blockers = append(blockers, fmt.Sprintf("%s: pod %s %s", workload, pod.Name, blocker))
+		}
+	}
+	sort.Strings(blockers)
+	return blockers
+}
+
+func podRelocationBlocker(pod *corev1.Pod, nodeNames sets.Set[string]) string {
+	if nodeNames.Has(pod.Spec.NodeName) {
+		return fmt.Sprintf("is still on node %s", pod.Spec.NodeName)
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodScheduled && condition.Status != corev1.ConditionTrue {
+			return fmt.Sprintf("can't be scheduled: %s", condition.Message)
+		}
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			if condition.Status == corev1.ConditionTrue {
+				return ""
+			}
+			break
+		}
+	}
+	return fmt.Sprintf("is %s and not ready", pod.Status.Phase)
+}
@@ -0,0 +1,162 @@
+package nodepool
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/support/api"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestDeleteNodePool(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	nodePool := &hyperv1.NodePool{ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "workers"}}
+	c := fake.NewClientBuilder().WithScheme(api.Scheme).WithObjects(nodePool).Build()
+	opts := &DeleteOptions{Namespace: "clusters", Name: "workers"}
+
+	g.Expect(opts.Run(ctx, c, &bytes.Buffer{})).To(Succeed())
+	err := c.Get(ctx, types.NamespacedName{Namespace: "clusters", Name: "workers"}, &hyperv1.NodePool{})
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+	g.Expect(opts.Run(ctx, c, &bytes.Buffer{})).ToNot(Succeed())
+}
+
+func TestDrainNodePoolWithoutNodes(t *testing.T) {
+	g := NewWithT(t)
+
+	otherNode := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "other", Labels: map[string]string{hyperv1.NodePoolLabel: "other"}}}
+	guestClient := fake.NewClientBuilder().WithScheme(api.Scheme).WithObjects(otherNode).Build()
+	opts := &DeleteOptions{Namespace: "clusters", Name: "workers"}
+
+	out := &bytes.Buffer{}
+	g.Expect(opts.drainNodePool(context.Background(), guestClient, nil, out)).To(Succeed())
+	g.Expect(out.String()).To(ContainSubstring("has no nodes to drain"))
+}
+
+func TestWorkloadsToRelocate(t *testing.T) {
+	g := NewWithT(t)
+
+	pods := []corev1.Pod{
+		relocationPod("web-1", "pool-node", ownedBy("ReplicaSet", "web", "rs-uid")),
+		relocationPod("web-2", "pool-node", ownedBy("ReplicaSet", "web", "rs-uid")),
+		relocationPod("db-0", "pool-node", ownedBy("StatefulSet", "db", "sts-uid")),
+		relocationPod("dns", "pool-node", ownedBy("DaemonSet", "dns", "ds-uid")),
+		relocationPod("static", "pool-node", ownedBy("Node", "pool-node", "node-uid")),
+		relocationPod("job", "pool-node", ownedBy("Job", "job", "job-uid"), withPhase(corev1.PodSucceeded)),
+		relocationPod("api", "other-node", ownedBy("ReplicaSet", "api", "api-uid")),
+		relocationPod("bare", "pool-node"),
+	}
+
+	g.Expect(workloadsToRelocate(pods, sets.New("pool-node"))).To(Equal(map[types.UID]string{
+		"rs-uid":  "ReplicaSet default/web",
+		"sts-uid": "StatefulSet default/db",
+	}))
+}
+
+func TestRelocationBlockers(t *testing.T) {
+	workloads := map[types.UID]string{"rs-uid": "ReplicaSet default/web"}
+	nodeNames := sets.New("pool-node")
+
+	testCases := []struct {
+		name     string
+		pods     []corev1.Pod
+		expected []string
+	}{
+		{
+			name: "When all pods are ready on other nodes it should report no blockers",
+			pods: []corev1.Pod{
+				relocationPod("web-1", "other-node", ownedBy("ReplicaSet", "web", "rs-uid"), withReady()),
+				relocationPod("api-1", "pool-node", ownedBy("ReplicaSet", "api", "api-uid")),
+			},
+		},
+		{
+			name: "When a pod can't be scheduled it should report the scheduling failure",
+			pods: []corev1.Pod{
+				relocationPod("web-1", "other-node", ownedBy("ReplicaSet", "web", "rs-uid"), withReady()),
+				relocationPod("web-2", "", ownedBy("ReplicaSet", "web", "rs-uid"), withUnschedulable("0/3 nodes are available: 3 Insufficient cpu.")),
+			},
+			expected: []string{"ReplicaSet default/web: pod web-2 can't be scheduled: 0/3 nodes are available: 3 Insufficient cpu."},
+		},
+		{
+			name: "When a pod is not ready yet it should report it",
+			pods: []corev1.Pod{
+				relocationPod("web-1", "other-node", ownedBy("ReplicaSet", "web", "rs-uid"), withPhase(corev1.PodPending)),
+			},
+			expected: []string{"ReplicaSet default/web: pod web-1 is Pending and not ready"},
+		},
+		{
+			name: "When a pod is still on a node of the NodePool it should report it",
+			pods: []corev1.Pod{
+				relocationPod("web-1", "pool-node", ownedBy("ReplicaSet", "web", "rs-uid"), withReady()),
+			},
+			expected: []string{"ReplicaSet default/web: pod web-1 is still on node pool-node"},
+		},
+		{
+			name: "When an evicted pod is terminating it should be ignored",
+			pods: []corev1.Pod{
+				relocationPod("web-1", "pool-node", ownedBy("ReplicaSet", "web", "rs-uid"), withDeletionTimestamp()),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(relocationBlockers(tc.pods, workloads, nodeNames)).To(Equal(tc.expected))
+		})
+	}
+}
+
+func relocationPod(name, nodeName string, opts ...func(*corev1.Pod)) corev1.Pod {
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+		Spec:       corev1.PodSpec{NodeName: nodeName},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	for _, opt := range opts {
+		opt(&pod)
+	}
+	return pod
+}
+
+func ownedBy(kind, name string, uid types.UID) func(*corev1.Pod) {
+	return func(pod *corev1.Pod) {
+		pod.OwnerReferences = append(pod.OwnerReferences, metav1.OwnerReference{Kind: kind, Name: name, UID: uid, Controller: ptr.To(true)})
+	}
+}
+
+func withPhase(phase corev1.PodPhase) func(*corev1.Pod) {
+	return func(pod *corev1.Pod) {
+		pod.Status.Phase = phase
+	}
+}
+
+func withReady() func(*corev1.Pod) {
+	return func(pod *corev1.Pod) {
+		pod.Status.Conditions = append(pod.Status.Conditions, corev1.PodCondition{Type: corev1.PodReady, Status: corev1.ConditionTrue})
+	}
+}
+
+func withUnschedulable(message string) func(*corev1.Pod) {
+	return func(pod *corev1.Pod) {
+		pod.Status.Phase = corev1.PodPending
+		pod.Status.Conditions = append(pod.Status.Conditions, corev1.PodCondition{Type: corev1.PodScheduled, Status: corev1.ConditionFalse, Message: message})
+	}
+}
+
+func withDeletionTimestamp() func(*corev1.Pod) {
+	return func(pod *corev1.Pod) {
+		pod.DeletionTimestamp = ptr.To(metav1.Now())
+	}
+}
@@ -0,0 +1,615 @@
+package nodepool
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/go-logr/logr"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	capiv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/cmd/cluster/core"
+	awsutil "github.com/openshift/hypershift/cmd/infra/aws/util"
+	"github.com/openshift/hypershift/cmd/log"
+	"github.com/openshift/hypershift/cmd/util"
+	"github.com/openshift/hypershift/hypershift-operator/controllers/manifests"
+	"github.com/openshift/hypershift/hypershift-operator/controllers/manifests/ignitionserver"
+	"github.com/openshift/hypershift/hypershift-operator/controllers/nodepool"
+)
+
+const (
+	FindingPass = "Pass"
+	FindingWarn = "Warn"
+	FindingFail = "Fail"
+	FindingSkip = "Skip"
+
+	// nodePoolAnnotation is set by the NodePool controller on the Machines and token Secrets of a NodePool, with the
+	// namespaced name of the NodePool.
+	nodePoolAnnotation = "hypershift.openshift.io/nodePool"
+
+	// ignitionProbeTimeout bounds the request sent to the ignition endpoint.
+	ignitionProbeTimeout = 10 * time.Second
+)
+
+// consoleLogPatterns are the console output lines that point at the usual reasons an instance fails to join, with
+// what they mean.
+var consoleLogPatterns = []struct {
+	pattern string
+	meaning string
+}{
+	{pattern: "no such host", meaning: "the ignition endpoint can't be resolved from the instance"},
+	{pattern: "i/o timeout", meaning: "the ignition endpoint can't be reached from the instance"},
+	{pattern: "connection refused", meaning: "the ignition endpoint refuses connections from the instance"},
+	{pattern: "no route to host", meaning: "the instance has no route to the ignition endpoint"},
+	{pattern: "x509:", meaning: "the instance doesn't trust the certificate served for the ignition endpoint, e.g. because of a proxy"},
+	{pattern: "failed to fetch config", meaning: "ignition couldn't fetch its payload"},
+	{pattern: "Ignition failed", meaning: "ignition failed"},
+	{pattern: "emergency mode", meaning: "the instance failed to boot"},
+}
+
+type DiagnoseOptions struct {
+	Name               string
+	Namespace          string
+	AWSCredentialsFile string
+	Output             string
+	NoColor            bool
+
+	Log logr.Logger
+
+	// ec2Client is used for the checks which need to query AWS, it is only set when AWS credentials are given.
+	ec2Client ec2iface.EC2API
+}
+
+// NodePoolDiagnosis is the outcome of the checks of the chain a NodePool's instances go through to join the cluster.
+type NodePoolDiagnosis struct {
+	Namespace string    `json:"namespace"`
+	Name      string    `json:"name"`
+	Findings  []Finding `json:"findings"`
+}
+
+// Finding is the outcome of one check, with the action to take when it didn't pass.
+type Finding struct {
+	Check   string `json:"check"`
+	Status  string `json:"status"`
+	Message string `json:"message"`
+	Action  string `json:"action,omitempty"`
+}
+
+func NewDiagnoseCommand() *cobra.Command {
+	opts := &DiagnoseOptions{
+		Namespace: "clusters",
+		Output:    core.StatusOutputText,
+		Log:       log.Log,
+	}
+
+	cmd := &cobra.Command{
+		Use:          "nodepool",
+		Short:        "Diagnoses why the nodes of a NodePool don't join the cluster",
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringVar(&opts.Name, "name", opts.Name, "The name of the NodePool (required)")
+	cmd.Flags().StringVar(&opts.Namespace, "namespace", opts.Namespace, "The namespace of the NodePool")
+	cmd.Flags().StringVar(&opts.AWSCredentialsFile, "aws-creds", opts.AWSCredentialsFile, "Path to an AWS credentials file. When set on AWS, the security group rules and the console logs of the instances are checked as well")
+	cmd.Flags().StringVarP(&opts.Output, "output", "o", opts.Output, "Output format. Supported options: text, json")
+	cmd.Flags().BoolVar(&opts.NoColor, "no-color", opts.NoColor, "Disable colored output")
+
+	_ = cmd.MarkFlagRequired("name")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		c, err := util.GetClient()
+		if err != nil {
+			return err
+		}
+		if err := opts.Run(cmd.Context(), c, cmd.OutOrStdout()); err != nil {
+			opts.Log.Error(err, "Failed to diagnose NodePool")
+			return err
+		}
+		return nil
+	}
+
+	return cmd
+}
+
+func (o *DiagnoseOptions) Run(ctx context.Context, c crclient.Client, out io.Writer) error {
+	if o.Output != core.StatusOutputText && o.Output != core.StatusOutputJSON {
+		return fmt.Errorf("unsupported output format %q", o.Output)
+	}
+	diagnosis, err := o.Diagnose(ctx, c)
+	if err != nil {
+		return err
+	}
+	if o.Output == core.StatusOutputJSON {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(diagnosis)
+	}
+	return PrintNodePoolDiagnosis(out, diagnosis, !o.NoColor)
+}
+
+// Diagnose checks, in order, each link of the chain the instances of the NodePool go through to join the cluster:
+// the control plane being available, the ignition endpoint being reachable, the ignition token and payload being
+// valid, the boot image matching the NodePool, the network rules allowing the instances to reach the ignition
+// endpoint, and the instances which didn't become nodes.
+func (o *DiagnoseOptions) Diagnose(ctx context.Context, c crclient.Client) (*NodePoolDiagnosis, error) {
+	nodePool := &hyperv1.NodePool{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: o.Namespace, Name: o.Name}, nodePool); err != nil {
+		return nil, fmt.Errorf("failed to get NodePool: %w", err)
+	}
+	hostedCluster := &hyperv1.HostedCluster{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: o.Namespace, Name: nodePool.Spec.ClusterName}, hostedCluster); err != nil {
+		return nil, fmt.Errorf("failed to get HostedCluster %s/%s: %w", o.Namespace, nodePool.Spec.ClusterName, err)
+	}
+	controlPlaneNamespace := manifests.HostedControlPlaneNamespace(hostedCluster.Namespace, hostedCluster.Name)
+
+	if o.ec2Client == nil && o.AWSCredentialsFile != "" && hostedCluster.Spec.Platform.Type == hyperv1.AWSPlatform && hostedCluster.Spec.Platform.AWS != nil {
+		awsSession := awsutil.NewSession("cli-diagnose-nodepool", o.AWSCredentialsFile, "", "", hostedCluster.Spec.Platform.AWS.Region)
+		o.ec2Client = ec2.New(awsSession, awsutil.NewConfig())
+	}
+
+	machines, err := nodePoolMachines(ctx, c, controlPlaneNamespace, nodePool)
+	if err != nil {
+		return nil, err
+	}
+
+	diagnosis := &NodePoolDiagnosis{Namespace: nodePool.Namespace, Name: nodePool.Name}
+	diagnosis.Findings = append(diagnosis.Findings, checkControlPlane(hostedCluster))
+	diagnosis.Findings = append(diagnosis.Findings, checkIgnitionEndpoint(ctx, c, hostedCluster, controlPlaneNamespace))
+	diagnosis.Findings = append(diagnosis.Findings, checkIgnitionToken(ctx, c, nodePool, controlPlaneNamespace)...)
+	diagnosis.Findings = append(diagnosis.Findings, checkBootImage(nodePool)...)
+	if finding := o.checkNetworkRules(ctx, nodePool, hostedCluster); finding != nil {
+		diagnosis.Findings = append(diagnosis.Findings, *finding)
+	}
+	diagnosis.Findings = append(diagnosis.Findings, checkMachines(nodePool, machines, controlPlaneNamespace))
+	if finding := o.checkConsoleLogs(ctx, hostedCluster, machines); finding != nil {
+		diagnosis.Findings = append(diagnosis.Findings, *finding)
+	}
+	return diagnosis, nil
+}
+
+// nodePoolMachines returns the CAPI Machines of the NodePool.
+func nodePoolMachines(ctx context.Context, c crclient.Client, controlPlaneNamespace string, nodePool *hyperv1.NodePool) ([]capiv1.Machine, error) {
+	machineList := &capiv1.MachineList{}
+	if err := c.List(ctx, machineList, crclient.InNamespace(controlPlaneNamespace)); err != nil {
+		return nil, fmt.Errorf("failed to list Machines: %w", err)
+	}
+	var machines []capiv1.Machine
+	for _, machine := range machineList.Items {
+		if machine.Annotations[nodePoolAnnotation] == crclient.ObjectKeyFromObject(nodePool).String() {
+			machines = append(machines, machine)
+		}
+	}
+	return machines, nil
+}
+
+func checkControlPlane(hostedCluster *hyperv1.HostedCluster) Finding {
+	finding := Finding{Check: "ControlPlane"}
+	condition := meta.FindStatusCondition(hostedCluster.Status.Conditions, string(hyperv1.HostedClusterAvailable))
+	if condition == nil || condition.Status != metav1.ConditionTrue {
+		finding.Status = FindingFail
+		finding.Message = fmt.Sprintf("HostedCluster %s/%s is not available", hostedCluster.Namespace, hostedCluster.Name)
+		if condition != nil && condition.Message != "" {
+			finding.Message += ": " + condition.Message
+		}
+		finding.Action = fmt.Sprintf("Nodes can't join until the control plane is available, run 'hypershift status cluster --namespace %s --name %s' to find out why it isn't", hostedCluster.Namespace, hostedCluster.Name)
+		return finding
+	}
+	finding.Status = FindingPass
+	finding.Message = fmt.Sprintf("HostedCluster %s/%s is available", hostedCluster.Namespace, hostedCluster.Name)
+	return finding
+}
+
+// checkIgnitionEndpoint sends a request to the health endpoint of the ignition server through its published endpoint.
+// The request is sent from the network of the CLI, which is not necessarily the network of the instances.
+func checkIgnitionEndpoint(ctx context.Context, c crclient.Client, hostedCluster *hyperv1.HostedCluster, controlPlaneNamespace string) Finding {
+	finding := Finding{Check: "IgnitionEndpoint"}
+	endpoint := hostedCluster.Status.IgnitionEndpoint
+	if endpoint == "" {
+		finding.Status = FindingFail
+		finding.Message = "The HostedCluster has no ignition endpoint published"
+		finding.Action = fmt.Sprintf("Check that the Ignition service publishing strategy of the HostedCluster can be fulfilled and that the ignition-server Deployment in namespace %s is available", controlPlaneNamespace)
+		return finding
+	}
+
+	caSecret := ignitionserver.IgnitionCACertSecret(controlPlaneNamespace)
+	if err := c.Get(ctx, crclient.ObjectKeyFromObject(caSecret), caSecret); err != nil {
+		finding.Status = FindingFail
+		finding.Message = fmt.Sprintf("Failed to get the CA of the ignition server: %v", err)
+		finding.Action = fmt.Sprintf("Check that the Secret %s/%s exists, it is created by the HostedCluster controller", caSecret.Namespace, caSecret.Name)
+		return finding
+	}
+	if err := probeIgnitionEndpoint(ctx, endpoint, caSecret.Data[corev1.TLSCertKey]); err != nil {
+		finding.Status = FindingFail
+		finding.Message = fmt.Sprintf("The ignition endpoint %s is not reachable from this machine: %v", endpoint, err)
+		finding.Action = fmt.Sprintf("Check that %s resolves and accepts connections on the published port, and that the ignition-server pods in namespace %s are running", endpoint, controlPlaneNamespace)
+		return finding
+	}
+	finding.Status = FindingPass
+	finding.Message = fmt.Sprintf("The ignition endpoint %s is reachable from this machine, which doesn't prove it is from the network of the instances", endpoint)
+	return finding
+}
+
+// probeIgnitionEndpoint requests the health endpoint of the ignition server, which unlike the payload endpoint doesn't
+// consume a token.
+func probeIgnitionEndpoint(ctx context.Context, endpoint string, caCert []byte) error {
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caCert) {
+		return fmt.Errorf("the CA of the ignition server has no valid certificate")
+	}
+	client := &http.Client{
+		Timeout:   ignitionProbeTimeout,
+		Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool}},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("https://%s/healthz", endpoint), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+// checkIgnitionToken checks that the NodePool has an active ignition token, that the ignition server generated a
+// payload for it and whether any instance fetched it.
+func checkIgnitionToken(ctx context.Context, c crclient.Client, nodePool *hyperv1.NodePool, controlPlaneNamespace string) []Finding {
+	tokenFinding := Finding{Check: "IgnitionToken"}
+	secrets := &corev1.SecretList{}
+	if err := c.List(ctx, secrets, crclient.InNamespace(controlPlaneNamespace)); err != nil {
+		tokenFinding.Status = FindingFail
+		tokenFinding.Message = fmt.Sprintf("Failed to list the token Secrets of the NodePool: %v", err)
+		return []Finding{tokenFinding}
+	}
+	var active *corev1.Secret
+	for i, secret := range secrets.Items {
+		if secret.Annotations[nodepool.TokenSecretAnnotation] != "true" || secret.Annotations[nodePoolAnnotation] != crclient.ObjectKeyFromObject(nodePool).String() {
+			continue
+		}
+		// Tokens of previous configs are marked to expire, the active token never is.
+		if _, expiring := secret.Annotations[hyperv1.IgnitionServerTokenExpirationTimestampAnnotation]; expiring {
+			continue
+		}
+		active = &secrets.Items[i]
+	}
+	switch {
+	case active == nil:
+		tokenFinding.Status = FindingFail
+		tokenFinding.Message = "The NodePool has no active ignition token"
+		tokenFinding.Action = fmt.Sprintf("The token is created by the NodePool controller, check the conditions of the NodePool and the logs of the hypershift operator for NodePool %s/%s", nodePool.Namespace, nodePool.Name)
+	case len(active.Data[nodepool.TokenSecretTokenKey]) == 0:
+		tokenFinding.Status = FindingFail
+		tokenFinding.Message = fmt.Sprintf("The token Secret %s has no token", active.Name)
+		tokenFinding.Action = fmt.Sprintf("Delete the Secret %s/%s for the NodePool controller to generate a new token", active.Namespace, active.Name)
+	default:
+		tokenFinding.Status = FindingPass
+		tokenFinding.Message = fmt.Sprintf("The NodePool has an active ignition token in Secret %s", active.Name)
+		if generated := active.Annotations[nodepool.TokenSecretTokenGenerationTime]; generated != "" {
+			tokenFinding.Message += fmt.Sprintf(", generated at %s", generated)
+		}
+	}
+	findings := []Finding{tokenFinding}
+
+	payloadFinding := conditionFinding("IgnitionPayload", nodePool, hyperv1.NodePoolValidGeneratedPayloadConditionType,
+		fmt.Sprintf("Check the logs of the ignition-server Deployment in namespace %s, the payload is generated from the release image and the configuration of the NodePool", controlPlaneNamespace))
+	findings = append(findings, payloadFinding)
+
+	reachedFinding := Finding{Check: "IgnitionReached"}
+	condition := findNodePoolCondition(nodePool, hyperv1.NodePoolReachedIgnitionEndpoint)
+	switch {
+	case condition != nil && condition.Status == corev1.ConditionTrue:
+		reachedFinding.Status = FindingPass
+		reachedFinding.Message = "At least one instance fetched its ignition payload"
+	case active != nil && active.Annotations[nodepool.TokenSecretIgnitionReachedAnnotation] == "true":
+		reachedFinding.Status = FindingPass
+		reachedFinding.Message = "At least one instance fetched its ignition payload"
+	default:
+		reachedFinding.Status = FindingWarn
+		reachedFinding.Message = "No instance of the NodePool has fetched its ignition payload yet"
+		reachedFinding.Action = "If instances have been running for more than a few minutes, they can't reach the ignition endpoint: check the network rules, DNS and proxy configuration of the subnet of the instances, and their console logs"
+	}
+	return append(findings, reachedFinding)
+}
+
+// checkBootImage checks that a boot image was found for the NodePool and that the release image provides the
+// architecture of the NodePool.
+func checkBootImage(nodePool *hyperv1.NodePool) []Finding {
+	return []Finding{
+		conditionFinding("BootImage", nodePool, hyperv1.NodePoolValidPlatformImageType,
+			"Check that the release image of the NodePool provides a boot image for its platform, region and architecture, or set the image of the NodePool explicitly"),
+		conditionFinding("Architecture", nodePool, hyperv1.NodePoolValidReleaseImageArchConditionType,
+			fmt.Sprintf("Use a multi-architecture release image, or set the architecture of the NodePool to the one of its release image (currently %s)", nodePool.Spec.Arch)),
+	}
+}
+
+// checkNetworkRules checks that the security groups of the instances allow them to reach the ignition endpoint. It
+// returns nil on platforms where the rules are not managed through the NodePool.
+func (o *DiagnoseOptions) checkNetworkRules(ctx context.Context, nodePool *hyperv1.NodePool, hostedCluster *hyperv1.HostedCluster) *Finding {
+	switch hostedCluster.Spec.Platform.Type {
+	case hyperv1.AWSPlatform:
+		finding := conditionFinding("SecurityGroups", nodePool, hyperv1.NodePoolAWSSecurityGroupAvailableConditionType,
+			"The default security group is created by the HostedCluster controller, check the conditions of the HostedCluster, or set the security groups of the NodePool")
+		if finding.Status != FindingPass {
+			return &finding
+		}
+		if o.ec2Client == nil {
+			finding.Status = FindingSkip
+			finding.Message = "The rules of the security groups were not checked"
+			finding.Action = "Pass --aws-creds to check that the security groups allow the instances to reach the ignition endpoint"
+			return &finding
+		}
+		finding = checkSecurityGroupRules(ctx, o.ec2Client, securityGroupIDs(nodePool, hostedCluster))
+		return &finding
+	case hyperv1.AzurePlatform:
+		if hostedCluster.Spec.Platform.Azure == nil || hostedCluster.Spec.Platform.Azure.SecurityGroupID == "" {
+			return nil
+		}
+		return &Finding{
+			Check:   "NetworkSecurityGroup",
+			Status:  FindingSkip,
+			Message: fmt.Sprintf("The rules of the network security group %s were not checked", hostedCluster.Spec.Platform.Azure.SecurityGroupID),
+			Action:  fmt.Sprintf("Check that the outbound rules allow TCP to port 443 of the ignition endpoint %s, e.g. with 'az network nsg rule list --ids %s'", hostedCluster.Status.IgnitionEndpoint, hostedCluster.Spec.Platform.Azure.SecurityGroupID),
+		}
+	}
+	return nil
+}
+
+// securityGroupIDs returns the IDs of the security groups attached to the instances of the NodePool.
+func securityGroupIDs(nodePool *hyperv1.NodePool, hostedCluster *hyperv1.HostedCluster) []string {
+	var ids []string
+	if hostedCluster.Status.Platform != nil && hostedCluster.Status.Platform.AWS != nil && hostedCluster.Status.Platform.AWS.DefaultWorkerSecurityGroupID != "" {
+		ids = append(ids, hostedCluster.Status.Platform.AWS.DefaultWorkerSecurityGroupID)
+	}
+	if nodePool.Spec.Platform.AWS != nil {
+		for _, sg := range nodePool.Spec.Platform.AWS.SecurityGroups {
+			if sg.ID != nil {
+				ids = append(ids, *sg.ID)
+			}
+		}
+	}
+	return ids
+}
+
+// checkSecurityGroupRules checks that at least one of the security groups allows outbound HTTPS, which the instances
+// need to fetch their payload from the ignition endpoint.
+func checkSecurityGroupRules(ctx context.Context, ec2Client ec2iface.EC2API, ids []string) Finding {
+	finding := Finding{Check: "SecurityGroups"}
+	if len(ids) == 0 {
+		finding.Status = FindingFail
+		finding.Message = "The instances of the NodePool have no security group"
+		finding.Action = "Set the security groups of the NodePool, or check the conditions of the HostedCluster for the default security group"
+		return finding
+	}
+	output, err := ec2Client.DescribeSecurityGroupsWithContext(ctx, &ec2.DescribeSecurityGroupsInput{GroupIds: aws.StringSlice(ids)})
+	if err != nil {
+		finding.Status = FindingFail
+		finding.Message = fmt.Sprintf("Failed to describe security groups %s: %v", strings.Join(ids, ", "), err)
+		finding.Action = "Check that the AWS credentials are for the account of the HostedCluster and allow ec2:DescribeSecurityGroups"
+		return finding
+	}
+	for _, group := range output.SecurityGroups {
+		for _, permission := range group.IpPermissionsEgress {
+			if permitsHTTPS(permission) {
+				finding.Status = FindingPass
+				finding.Message = fmt.Sprintf("Security group %s allows outbound HTTPS", aws.StringValue(group.GroupId))
+				return finding
+			}
+		}
+	}
+	finding.Status = FindingFail
+	finding.Message = fmt.Sprintf("None of the security groups %s allows outbound HTTPS, so the instances can't reach the ignition endpoint", strings.Join(ids, ", "))
+	finding.Action = "Add an egress rule allowing TCP to port 443 to one of the security groups of the NodePool"
+	return finding
+}
+
+func permitsHTTPS(permission *ec2.IpPermission) bool {
+	switch aws.StringValue(permission.IpProtocol) {
+	case "-1":
+		return true
+	case "tcp", "6":
+		return aws.Int64Value(permission.FromPort) <= 443 && aws.Int64Value(permission.ToPort) >= 443
+	}
+	return false
+}
+
+// checkMachines reports the Machines of the NodePool which didn't become nodes.
+func checkMachines(nodePool *hyperv1.NodePool, machines []capiv1.Machine, controlPlaneNamespace string) Finding {
+	finding := Finding{Check: "Machines"}
+	if len(machines) == 0 {
+		if nodePool.Spec.Replicas != nil && *nodePool.Spec.Replicas == 0 {
+			finding.Status = FindingPass
+			finding.Message = "The NodePool is scaled to zero"
+			return finding
+		}
+		finding.Status = FindingWarn
+		finding.Message = "The NodePool has no Machines"
+		finding.Action = fmt.Sprintf("Check the MachineDeployment or MachineSet %s/%s, and the logs of the cluster-api provider in namespace %s", controlPlaneNamespace, nodePool.Name, controlPlaneNamespace)
+		return finding
+	}
+	var withoutNode []string
+	for _, machine := range machines {
+		if machine.Status.NodeRef != nil {
+			continue
+		}
+		description := fmt.Sprintf("%s (%s", machine.Name, machine.Status.Phase)
+		if machine.Status.FailureMessage != nil {
+			description += ": " + *machine.Status.FailureMessage
+		}
+		withoutNode = append(withoutNode, description+")")
+	}
+	if len(withoutNode) == 0 {
+		finding.Status = FindingPass
+		finding.Message = fmt.Sprintf("All %d Machines of the NodePool have a node", len(machines))
+		return finding
+	}
+	finding.Status = FindingFail
+	finding.Message = fmt.Sprintf("%d of %d Machines have no node: %s", len(withoutNode), len(machines), strings.Join(withoutNode, ", "))
+	finding.Action = "Machines which are provisioned but have no node booted without joining the cluster, the findings above and the console logs of their instances point at the link which broke"
+	return finding
+}
+
+// checkConsoleLogs scans the console logs of the instances of the Machines without a node for the errors instances
+// log when they fail to fetch their ignition payload. It returns nil on platforms other than AWS.
+func (o *DiagnoseOptions) checkConsoleLogs(ctx context.Context, hostedCluster *hyperv1.HostedCluster, machines []capiv1.Machine) *Finding {
+	if hostedCluster.Spec.Platform.Type != hyperv1.AWSPlatform {
+		return nil
+	}
+	finding := &Finding{Check: "ConsoleLogs"}
+	if o.ec2Client == nil {
+		finding.Status = FindingSkip
+		finding.Message = "The console logs of the instances were not checked"
+		finding.Action = "Pass --aws-creds to scan the console logs of the instances which didn't join for errors"
+		return finding
+	}
+
+	var problems []string
+	scanned := 0
+	for _, machine := range machines {
+		if machine.Status.NodeRef != nil || machine.Spec.ProviderID == nil {
+			continue
+		}
+		// The provider ID of an AWS instance is aws:///<zone>/<instance ID>.
+		instanceID := path.Base(*machine.Spec.ProviderID)
+		output, err := o.ec2Client.GetConsoleOutputWithContext(ctx, &ec2.GetConsoleOutputInput{InstanceId: aws.String(instanceID)})
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: failed to get console output: %v", instanceID, err))
+			continue
+		}
+		consoleLog, err := base64.StdEncoding.DecodeString(aws.StringValue(output.Output))
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: failed to decode console output: %v", instanceID, err))
+			continue
+		}
+		scanned++
+		for _, meaning := range scanConsoleLog(string(consoleLog)) {
+			problems = append(problems, fmt.Sprintf("%s: %s", instanceID, meaning))
+		}
+	}
+	switch {
+	case len(problems) > 0:
+		finding.Status = FindingFail
+		finding.Message = strings.Join(problems, "; ")
+		finding.Action = "Run 'hypershift console-logs aws' to get the full console logs of the instances"
+	case scanned == 0:
+		finding.Status = FindingPass
+		finding.Message = "No instance without a node to scan the console logs of"
+	default:
+		finding.Status = FindingPass
+		finding.Message = fmt.Sprintf("No known error in the console logs of %d instances without a node", scanned)
+	}
+	return finding
+}
+
+// scanConsoleLog returns the meaning of each known error found in the console log, once per error.
+func scanConsoleLog(consoleLog string) []string {
+	found := map[string]bool{}
+	var meanings []string
+	scanner := bufio.NewScanner(strings.NewReader(consoleLog))
+	for scanner.Scan() {
+		for _, p := range consoleLogPatterns {
+			if !found[p.pattern] && strings.Contains(scanner.Text(), p.pattern) {
+				found[p.pattern] = true
+				meanings = append(meanings, p.meaning)
+			}
+		}
+	}
+	return meanings
+}
+
+// conditionFinding turns a NodePool condition which is expected to be true into a finding.
+func conditionFinding(check string, nodePool *hyperv1.NodePool, conditionType string, action string) Finding {
+	finding := Finding{Check: check}
+	condition := findNodePoolCondition(nodePool, conditionType)
+	switch {
+	case condition == nil:
+		finding.Status = FindingWarn
+		finding.Message = fmt.Sprintf("The NodePool has no %s condition yet", conditionType)
+	case condition.Status == corev1.ConditionTrue:
+		finding.Status = FindingPass
+		finding.Message = condition.Message
+		if finding.Message == "" {
+			finding.Message = fmt.Sprintf("%s is true", conditionType)
+		}
+	default:
+		finding.Status = FindingFail
+		finding.Message = fmt.Sprintf("%s is %s: %s", conditionType, condition.Status, condition.Message)
+		finding.Action = action
+	}
+	return finding
+}
+
+func findNodePoolCondition(nodePool *hyperv1.NodePool, conditionType string) *hyperv1.NodePoolCondition {
+	for i := range nodePool.Status.Conditions {
+		if nodePool.Status.Conditions[i].Type == conditionType {
+			return &nodePool.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+func PrintNodePoolDiagnosis(out io.Writer, diagnosis *NodePoolDiagnosis, color bool) error {
+	colorize := func(c, s string) string {
+		if !color {
+			return s
+		}
+		return c + s + "\033[0m"
+	}
+	statusColor := func(status string) string {
+		switch status {
+		case FindingPass:
+			return colorize("\033[32m", status)
+		case FindingFail:
+			return colorize("\033[31m", status)
+		default:
+			return colorize("\033[33m", status)
+		}
+	}
+
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(w, "NodePool %s/%s:\n", diagnosis.Namespace, diagnosis.Name)
+	fmt.Fprintf(w, "  CHECK\tSTATUS\tMESSAGE\n")
+	for _, finding := range diagnosis.Findings {
+		fmt.Fprintf(w, "  %s\t%s\t%s\n", finding.Check, statusColor(finding.Status), finding.Message)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	var actions []Finding
+	for _, finding := range diagnosis.Findings {
+		if finding.Action != "" && finding.Status != FindingPass {
+			actions = append(actions, finding)
+		}
+	}
+	if len(actions) > 0 {
+		fmt.Fprintf(out, "\nSuggested actions:\n")
+		for _, finding := range actions {
+			fmt.Fprintf(out, "  %s: %s\n", finding.Check, finding.Action)
+		}
+	}
+	return nil
+}
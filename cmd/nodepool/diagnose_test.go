@@ -0,0 +1,216 @@
+package nodepool
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	. "github.com/onsi/gomega"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/hypershift-operator/controllers/nodepool"
+	"github.com/openshift/hypershift/support/api"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	capiv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type fakeEC2 struct {
+	ec2iface.EC2API
+	egress     []*ec2.IpPermission
+	consoleLog string
+}
+
+func (f *fakeEC2) DescribeSecurityGroupsWithContext(_ aws.Context, input *ec2.DescribeSecurityGroupsInput, _ ...request.Option) (*ec2.DescribeSecurityGroupsOutput, error) {
+	var groups []*ec2.SecurityGroup
+	for _, id := range input.GroupIds {
+		groups = append(groups, &ec2.SecurityGroup{GroupId: id, IpPermissionsEgress: f.egress})
+	}
+	return &ec2.DescribeSecurityGroupsOutput{SecurityGroups: groups}, nil
+}
+
+func (f *fakeEC2) GetConsoleOutputWithContext(_ aws.Context, input *ec2.GetConsoleOutputInput, _ ...request.Option) (*ec2.GetConsoleOutputOutput, error) {
+	return &ec2.GetConsoleOutputOutput{InstanceId: input.InstanceId, Output: aws.String(base64.StdEncoding.EncodeToString([]byte(f.consoleLog)))}, nil
+}
+
+func TestDiagnoseNodePool(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/healthz" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	caCert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	ignitionEndpoint := strings.TrimPrefix(server.URL, "https://")
+
+	trueCondition := func(conditionType string) hyperv1.NodePoolCondition {
+		return hyperv1.NodePoolCondition{Type: conditionType, Status: corev1.ConditionTrue}
+	}
+
+	testCases := []struct {
+		name             string
+		ignitionEndpoint string
+		conditions       []hyperv1.NodePoolCondition
+		tokenSecrets     []client.Object
+		ec2Client        *fakeEC2
+		expected         map[string]string
+	}{
+		{
+			name: "When the control plane serves ignition but instances time out it should point at the network",
+			conditions: []hyperv1.NodePoolCondition{
+				trueCondition(hyperv1.NodePoolValidGeneratedPayloadConditionType),
+				trueCondition(hyperv1.NodePoolValidPlatformImageType),
+				trueCondition(hyperv1.NodePoolValidReleaseImageArchConditionType),
+				trueCondition(hyperv1.NodePoolAWSSecurityGroupAvailableConditionType),
+			},
+			ignitionEndpoint: ignitionEndpoint,
+			tokenSecrets: []client.Object{
+				tokenSecret("token-workers-old", "old", true),
+				tokenSecret("token-workers-new", "new", false),
+			},
+			ec2Client: &fakeEC2{
+				egress:     []*ec2.IpPermission{{IpProtocol: aws.String("tcp"), FromPort: aws.Int64(22), ToPort: aws.Int64(22)}},
+				consoleLog: "ignition[812]: GET error: Get \"https://ignition.example.com/ignition\": dial tcp 10.0.0.1:443: i/o timeout\n",
+			},
+			expected: map[string]string{
+				"ControlPlane":     FindingPass,
+				"IgnitionEndpoint": FindingPass,
+				"IgnitionToken":    FindingPass,
+				"IgnitionPayload":  FindingPass,
+				"IgnitionReached":  FindingWarn,
+				"BootImage":        FindingPass,
+				"Architecture":     FindingPass,
+				"SecurityGroups":   FindingFail,
+				"Machines":         FindingFail,
+				"ConsoleLogs":      FindingFail,
+			},
+		},
+		{
+			name: "When the ignition endpoint isn't published and no credentials are given it should skip the cloud checks",
+			conditions: []hyperv1.NodePoolCondition{
+				{Type: hyperv1.NodePoolValidReleaseImageArchConditionType, Status: corev1.ConditionFalse, Message: "release image doesn't provide arm64"},
+				trueCondition(hyperv1.NodePoolAWSSecurityGroupAvailableConditionType),
+			},
+			expected: map[string]string{
+				"ControlPlane":     FindingPass,
+				"IgnitionEndpoint": FindingFail,
+				"IgnitionToken":    FindingFail,
+				"IgnitionPayload":  FindingWarn,
+				"IgnitionReached":  FindingWarn,
+				"BootImage":        FindingWarn,
+				"Architecture":     FindingFail,
+				"SecurityGroups":   FindingSkip,
+				"Machines":         FindingFail,
+				"ConsoleLogs":      FindingSkip,
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			nodePool := &hyperv1.NodePool{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "workers"},
+				Spec:       hyperv1.NodePoolSpec{ClusterName: "example", Replicas: ptr.To[int32](2)},
+				Status:     hyperv1.NodePoolStatus{Conditions: tc.conditions},
+			}
+			hostedCluster := &hyperv1.HostedCluster{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "example"},
+				Spec: hyperv1.HostedClusterSpec{Platform: hyperv1.PlatformSpec{
+					Type: hyperv1.AWSPlatform,
+					AWS:  &hyperv1.AWSPlatformSpec{Region: "us-east-1"},
+				}},
+				Status: hyperv1.HostedClusterStatus{
+					IgnitionEndpoint: tc.ignitionEndpoint,
+					Conditions:       []metav1.Condition{{Type: string(hyperv1.HostedClusterAvailable), Status: metav1.ConditionTrue}},
+					Platform:         &hyperv1.PlatformStatus{AWS: &hyperv1.AWSPlatformStatus{DefaultWorkerSecurityGroupID: "sg-default"}},
+				},
+			}
+			caSecret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "clusters-example", Name: "ignition-server-ca-cert"},
+				Data:       map[string][]byte{corev1.TLSCertKey: caCert},
+			}
+			joined := &capiv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "clusters-example", Name: "workers-a", Annotations: map[string]string{nodePoolAnnotation: "clusters/workers"}},
+				Spec:       capiv1.MachineSpec{ProviderID: ptr.To("aws:///us-east-1a/i-joined")},
+				Status:     capiv1.MachineStatus{NodeRef: &corev1.ObjectReference{Name: "ip-10-0-0-1"}},
+			}
+			notJoined := &capiv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "clusters-example", Name: "workers-b", Annotations: map[string]string{nodePoolAnnotation: "clusters/workers"}},
+				Spec:       capiv1.MachineSpec{ProviderID: ptr.To("aws:///us-east-1a/i-stuck")},
+				Status:     capiv1.MachineStatus{Phase: string(capiv1.MachinePhaseProvisioned)},
+			}
+			otherPool := &capiv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "clusters-example", Name: "other-a", Annotations: map[string]string{nodePoolAnnotation: "clusters/other"}},
+			}
+			c := fake.NewClientBuilder().WithScheme(api.Scheme).
+				WithObjects(nodePool, hostedCluster, caSecret, joined, notJoined, otherPool).
+				WithObjects(tc.tokenSecrets...).
+				Build()
+
+			opts := &DiagnoseOptions{Namespace: "clusters", Name: "workers", Output: "text", NoColor: true}
+			if tc.ec2Client != nil {
+				opts.ec2Client = tc.ec2Client
+			}
+			diagnosis, err := opts.Diagnose(context.Background(), c)
+			g.Expect(err).ToNot(HaveOccurred())
+
+			statuses := map[string]string{}
+			for _, finding := range diagnosis.Findings {
+				statuses[finding.Check] = finding.Status
+				if finding.Status == FindingFail {
+					g.Expect(finding.Action).ToNot(BeEmpty(), "finding %s has no action", finding.Check)
+				}
+			}
+			g.Expect(statuses).To(Equal(tc.expected))
+
+			out := &bytes.Buffer{}
+			g.Expect(opts.Run(context.Background(), c, out)).To(Succeed())
+			g.Expect(out.String()).To(ContainSubstring("workers-b (Provisioned)"))
+			g.Expect(out.String()).To(ContainSubstring("Suggested actions:"))
+		})
+	}
+}
+
+func TestScanConsoleLog(t *testing.T) {
+	g := NewWithT(t)
+	consoleLog := `[    5.1] ignition[812]: GET https://ignition.example.com/ignition: attempt #1
+[    5.2] ignition[812]: GET error: x509: certificate signed by unknown authority
+[    6.2] ignition[812]: GET error: x509: certificate signed by unknown authority
+[   65.3] ignition[812]: failed to fetch config: context deadline exceeded
+`
+	g.Expect(scanConsoleLog(consoleLog)).To(Equal([]string{
+		"the instance doesn't trust the certificate served for the ignition endpoint, e.g. because of a proxy",
+		"ignition couldn't fetch its payload",
+	}))
+	g.Expect(scanConsoleLog("Red Hat Enterprise Linux CoreOS\nlogin:")).To(BeEmpty())
+}
+
+func tokenSecret(name, token string, expiring bool) *corev1.Secret {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "clusters-example",
+			Name:      name,
+			Annotations: map[string]string{
+				nodepool.TokenSecretAnnotation: "true",
+				nodePoolAnnotation:             "clusters/workers",
+			},
+		},
+		Data: map[string][]byte{nodepool.TokenSecretTokenKey: []byte(token)},
+	}
+	if expiring {
+		secret.Annotations[hyperv1.IgnitionServerTokenExpirationTimestampAnnotation] = "2026-10-15T00:00:00Z"
+	}
+	return secret
+}
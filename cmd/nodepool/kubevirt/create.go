@@ -23,6 +23,7 @@ type KubevirtPlatformCreateOptions struct {
 	CacheStrategyType          string
 	NetworkInterfaceMultiQueue string
 	QoSClass                   string
+	InfraNamespace             string
 }
 
 func NewCreateCommand(coreOpts *core.CreateNodePoolOptions) *cobra.Command {
@@ -51,6 +52,7 @@ func NewCreateCommand(coreOpts *core.CreateNodePoolOptions) *cobra.Command {
 	cmd.Flags().StringVar(&platformOpts.CacheStrategyType, "root-volume-cache-strategy", platformOpts.CacheStrategyType, "Set the boot image caching strategy; Supported values:\n- \"None\": no caching (default).\n- \"PVC\": Cache into a PVC; only for QCOW image; ignored for container images")
 	cmd.Flags().StringVar(&platformOpts.NetworkInterfaceMultiQueue, "network-multiqueue", platformOpts.NetworkInterfaceMultiQueue, `If "Enable", virtual network interfaces configured with a virtio bus will also enable the vhost multiqueue feature for network devices. supported values are "Enable" and "Disable"; default = "Disable"`)
 	cmd.Flags().StringVar(&platformOpts.QoSClass, "qos-class", platformOpts.QoSClass, `If "Guaranteed", set the limit cpu and memory of the VirtualMachineInstance, to be the same as the requested cpu and memory; supported values: "Burstable" and "Guaranteed"`)
+	cmd.Flags().StringVar(&platformOpts.InfraNamespace, "infra-namespace", platformOpts.InfraNamespace, "The namespace in the external infra cluster to place the NodePool VMs in. Defaults to the HostedCluster infra namespace; requires the HostedCluster to use an external infra cluster")
 
 	cmd.RunE = coreOpts.CreateRunFunc(platformOpts)
 
@@ -94,6 +96,7 @@ func (o *KubevirtPlatformCreateOptions) UpdateNodePool(_ context.Context, nodePo
 		NetworkInterfaceMultiQueue: multiQueue,
 		QoSClass:                   qosClass,
 	})
+	nodePool.Spec.Platform.Kubevirt.InfraNamespace = o.InfraNamespace
 	return nil
 }
 
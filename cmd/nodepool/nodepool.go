@@ -0,0 +1,17 @@
+package nodepool
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "nodepool",
+		Short:        "Commands for inspecting HyperShift NodePools",
+		SilenceUsage: true,
+	}
+
+	cmd.AddCommand(NewRenderConfigCommand())
+
+	return cmd
+}
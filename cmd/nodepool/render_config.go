@@ -0,0 +1,191 @@
+package nodepool
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/go-logr/logr"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/cmd/log"
+	"github.com/openshift/hypershift/cmd/util"
+	"github.com/openshift/hypershift/hypershift-operator/controllers/nodepool"
+	"github.com/openshift/hypershift/support/releaseinfo"
+	supportutil "github.com/openshift/hypershift/support/util"
+)
+
+type RenderConfigOptions struct {
+	Name                    string
+	Namespace               string
+	NodePoolFile            string
+	ConfigFiles             []string
+	HypershiftOperatorImage string
+	Diff                    bool
+
+	Log logr.Logger
+}
+
+func NewRenderConfigCommand() *cobra.Command {
+	opts := &RenderConfigOptions{
+		Namespace: "clusters",
+		Log:       log.Log,
+	}
+
+	cmd := &cobra.Command{
+		Use:          "render-config",
+		Short:        "Renders the MachineConfigs a NodePool would be configured with, to preview config changes before they are rolled out",
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringVar(&opts.Name, "name", opts.Name, "The name of the NodePool (required)")
+	cmd.Flags().StringVar(&opts.Namespace, "namespace", opts.Namespace, "The namespace of the NodePool")
+	cmd.Flags().StringVar(&opts.NodePoolFile, "nodepool-file", opts.NodePoolFile, "Path to a NodePool manifest whose spec is rendered instead of the spec of the NodePool in the cluster")
+	cmd.Flags().StringArrayVar(&opts.ConfigFiles, "config-file", opts.ConfigFiles, "Path to a ConfigMap manifest holding a MachineConfig, rendered as if it was applied and referenced by the NodePool config. Can be specified multiple times")
+	cmd.Flags().StringVar(&opts.HypershiftOperatorImage, "hypershift-operator-image", opts.HypershiftOperatorImage, "The HyperShift operator image, only needed for HostedClusters whose release image has no hypershift component image")
+	cmd.Flags().BoolVar(&opts.Diff, "diff", opts.Diff, "If true, prints the difference with the config the NodePool is currently rolled out with instead of the rendered config")
+
+	_ = cmd.MarkFlagRequired("name")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if err := opts.Run(cmd.Context(), cmd.OutOrStdout()); err != nil {
+			opts.Log.Error(err, "Failed to render NodePool config")
+			return err
+		}
+		return nil
+	}
+
+	return cmd
+}
+
+func (o *RenderConfigOptions) Run(ctx context.Context, out io.Writer) error {
+	c, err := util.GetClient()
+	if err != nil {
+		return err
+	}
+
+	nodePool := &hyperv1.NodePool{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: o.Namespace, Name: o.Name}, nodePool); err != nil {
+		return fmt.Errorf("failed to get NodePool: %w", err)
+	}
+	if o.NodePoolFile != "" {
+		desired := &hyperv1.NodePool{}
+		if err := readManifest(o.NodePoolFile, desired); err != nil {
+			return err
+		}
+		// Only the spec is previewed, the annotations and status track what is rolled out.
+		nodePool.Spec = desired.Spec
+	}
+
+	configMaps, err := o.readConfigMaps(nodePool)
+	if err != nil {
+		return err
+	}
+	if len(configMaps) > 0 {
+		c = &configMapOverlayClient{Client: c, configMaps: configMaps}
+	}
+
+	hcluster := &hyperv1.HostedCluster{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: nodePool.Namespace, Name: nodePool.Spec.ClusterName}, hcluster); err != nil {
+		return fmt.Errorf("failed to get HostedCluster: %w", err)
+	}
+
+	rendered, err := nodepool.RenderConfig(ctx, c, nodepool.RenderConfigOptions{
+		ReleaseProvider:         &releaseinfo.RegistryClientProvider{},
+		ImageMetadataProvider:   &supportutil.RegistryClientImageMetadataProvider{},
+		HypershiftOperatorImage: o.HypershiftOperatorImage,
+	}, hcluster, nodePool)
+	if err != nil {
+		return err
+	}
+
+	if rendered.NeedsRollout() {
+		o.Log.Info("The NodePool would be rolled out with the rendered config", "current", rendered.CurrentConfigHash, "target", rendered.TargetConfigHash)
+	} else {
+		o.Log.Info("The NodePool is rolled out with the rendered config", "current", rendered.CurrentConfigHash)
+	}
+
+	if !o.Diff {
+		_, err := fmt.Fprintln(out, rendered.Config)
+		return err
+	}
+	diff, err := DiffConfig(rendered.CurrentConfig, rendered.Config)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprint(out, diff)
+	return err
+}
+
+// readConfigMaps reads the ConfigMaps of the config files and references the ones the NodePool doesn't reference yet.
+func (o *RenderConfigOptions) readConfigMaps(nodePool *hyperv1.NodePool) (map[types.NamespacedName]*corev1.ConfigMap, error) {
+	configMaps := map[types.NamespacedName]*corev1.ConfigMap{}
+	for _, file := range o.ConfigFiles {
+		configMap := &corev1.ConfigMap{}
+		if err := readManifest(file, configMap); err != nil {
+			return nil, err
+		}
+		if configMap.Namespace != "" && configMap.Namespace != nodePool.Namespace {
+			return nil, fmt.Errorf("ConfigMap %s in %s must be in the NodePool namespace %s", configMap.Name, file, nodePool.Namespace)
+		}
+		configMap.Namespace = nodePool.Namespace
+		configMaps[crclient.ObjectKeyFromObject(configMap)] = configMap
+
+		referenced := false
+		for _, ref := range nodePool.Spec.Config {
+			if ref.Name == configMap.Name {
+				referenced = true
+				break
+			}
+		}
+		if !referenced {
+			nodePool.Spec.Config = append(nodePool.Spec.Config, corev1.LocalObjectReference{Name: configMap.Name})
+		}
+	}
+	return configMaps, nil
+}
+
+// DiffConfig returns the unified diff between two configs.
+func DiffConfig(current, target string) (string, error) {
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(current),
+		B:        difflib.SplitLines(target),
+		FromFile: "current",
+		ToFile:   "rendered",
+		Context:  3,
+	})
+}
+
+func readManifest(file string, obj interface{}) error {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", file, err)
+	}
+	if err := yaml.Unmarshal(data, obj); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", file, err)
+	}
+	return nil
+}
+
+// configMapOverlayClient serves the given ConfigMaps instead of the ones in the cluster.
+type configMapOverlayClient struct {
+	crclient.Client
+	configMaps map[types.NamespacedName]*corev1.ConfigMap
+}
+
+func (c *configMapOverlayClient) Get(ctx context.Context, key crclient.ObjectKey, obj crclient.Object, opts ...crclient.GetOption) error {
+	if configMap, ok := obj.(*corev1.ConfigMap); ok {
+		if override, ok := c.configMaps[key]; ok {
+			override.DeepCopyInto(configMap)
+			return nil
+		}
+	}
+	return c.Client.Get(ctx, key, obj, opts...)
+}
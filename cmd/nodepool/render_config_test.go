@@ -0,0 +1,89 @@
+package nodepool
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestDiffConfig(t *testing.T) {
+	testCases := []struct {
+		name     string
+		current  string
+		target   string
+		expected string
+	}{
+		{
+			name:     "When the configs are equal it should return no diff",
+			current:  "kind: MachineConfig\nmetadata:\n  name: a",
+			target:   "kind: MachineConfig\nmetadata:\n  name: a",
+			expected: "",
+		},
+		{
+			name:    "When the configs differ it should return a unified diff",
+			current: "kind: MachineConfig\nmetadata:\n  name: a",
+			target:  "kind: MachineConfig\nmetadata:\n  name: b",
+			expected: `--- current
++++ rendered
+@@ -1,3 +1,3 @@
+ kind: MachineConfig
+ metadata:
+-  name: a
++  name: b
+`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			diff, err := DiffConfig(tc.current, tc.target)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(diff).To(Equal(tc.expected))
+		})
+	}
+}
+
+func TestReadConfigMaps(t *testing.T) {
+	g := NewWithT(t)
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "config.yaml")
+	g.Expect(os.WriteFile(file, []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: new-config
+data:
+  config: "kind: MachineConfig"
+`), 0600)).To(Succeed())
+
+	nodePool := &hyperv1.NodePool{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "example"},
+		Spec: hyperv1.NodePoolSpec{
+			Config: []corev1.LocalObjectReference{{Name: "existing-config"}},
+		},
+	}
+	existing := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "existing-config"},
+		Data:       map[string]string{"config": "kind: KubeletConfig"},
+	}
+
+	opts := &RenderConfigOptions{ConfigFiles: []string{file}}
+	configMaps, err := opts.readConfigMaps(nodePool)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(nodePool.Spec.Config).To(Equal([]corev1.LocalObjectReference{{Name: "existing-config"}, {Name: "new-config"}}))
+
+	c := &configMapOverlayClient{Client: fake.NewClientBuilder().WithObjects(existing).Build(), configMaps: configMaps}
+	configMap := &corev1.ConfigMap{}
+	g.Expect(c.Get(context.Background(), types.NamespacedName{Namespace: "clusters", Name: "new-config"}, configMap)).To(Succeed())
+	g.Expect(configMap.Data).To(HaveKeyWithValue("config", "kind: MachineConfig"))
+	g.Expect(c.Get(context.Background(), types.NamespacedName{Namespace: "clusters", Name: "existing-config"}, configMap)).To(Succeed())
+	g.Expect(configMap.Data).To(HaveKeyWithValue("config", "kind: KubeletConfig"))
+}
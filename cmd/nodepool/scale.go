@@ -0,0 +1,142 @@
+package nodepool
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/cmd/log"
+	"github.com/openshift/hypershift/cmd/util"
+)
+
+type ScaleOptions struct {
+	Name         string
+	Namespace    string
+	Replicas     int32
+	Wait         bool
+	Timeout      time.Duration
+	PollInterval time.Duration
+
+	Log logr.Logger
+}
+
+func NewScaleCommand() *cobra.Command {
+	opts := &ScaleOptions{
+		Namespace:    "clusters",
+		Timeout:      30 * time.Minute,
+		PollInterval: 10 * time.Second,
+		Log:          log.Log,
+	}
+
+	cmd := &cobra.Command{
+		Use:          "nodepool",
+		Short:        "Sets the number of replicas of a NodePool, optionally waiting for the nodes to be ready",
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringVar(&opts.Name, "name", opts.Name, "The name of the NodePool (required)")
+	cmd.Flags().StringVar(&opts.Namespace, "namespace", opts.Namespace, "The namespace of the NodePool")
+	cmd.Flags().Int32Var(&opts.Replicas, "replicas", opts.Replicas, "The desired number of nodes of the NodePool (required)")
+	cmd.Flags().BoolVar(&opts.Wait, "wait", opts.Wait, "If true, waits until the NodePool has the desired number of ready nodes")
+	cmd.Flags().DurationVar(&opts.Timeout, "timeout", opts.Timeout, "How long to wait for the NodePool to be scaled when --wait is set")
+
+	_ = cmd.MarkFlagRequired("name")
+	_ = cmd.MarkFlagRequired("replicas")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		c, err := util.GetClient()
+		if err != nil {
+			return err
+		}
+		if err := opts.Run(cmd.Context(), c, cmd.OutOrStdout()); err != nil {
+			opts.Log.Error(err, "Failed to scale NodePool")
+			return err
+		}
+		return nil
+	}
+
+	return cmd
+}
+
+func (o *ScaleOptions) Run(ctx context.Context, c crclient.Client, out io.Writer) error {
+	if o.Replicas < 0 {
+		return fmt.Errorf("replicas must not be negative, got %d", o.Replicas)
+	}
+
+	nodePool := &hyperv1.NodePool{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: o.Namespace, Name: o.Name}, nodePool); err != nil {
+		return fmt.Errorf("failed to get NodePool: %w", err)
+	}
+	if nodePool.Spec.AutoScaling != nil {
+		return fmt.Errorf("NodePool %s/%s has autoscaling enabled, its replicas are managed by the autoscaler", o.Namespace, o.Name)
+	}
+
+	if nodePool.Spec.Replicas == nil || *nodePool.Spec.Replicas != o.Replicas {
+		original := nodePool.DeepCopy()
+		nodePool.Spec.Replicas = &o.Replicas
+		if err := c.Patch(ctx, nodePool, crclient.MergeFrom(original)); err != nil {
+			return fmt.Errorf("failed to scale NodePool: %w", err)
+		}
+		fmt.Fprintf(out, "NodePool %s/%s scaled to %d replicas\n", o.Namespace, o.Name, o.Replicas)
+	} else {
+		fmt.Fprintf(out, "NodePool %s/%s already has %d desired replicas\n", o.Namespace, o.Name, o.Replicas)
+	}
+
+	if !o.Wait {
+		return nil
+	}
+	return o.waitForReplicas(ctx, c, out)
+}
+
+// waitForReplicas polls the NodePool until it reports the desired number of ready nodes, printing the progress
+// whenever it changes.
+func (o *ScaleOptions) waitForReplicas(ctx context.Context, c crclient.Client, out io.Writer) error {
+	waitCtx, cancel := context.WithTimeout(ctx, o.Timeout)
+	defer cancel()
+
+	lastProgress := ""
+	err := wait.PollUntilContextCancel(waitCtx, o.PollInterval, true, func(ctx context.Context) (bool, error) {
+		nodePool := &hyperv1.NodePool{}
+		if err := c.Get(ctx, types.NamespacedName{Namespace: o.Namespace, Name: o.Name}, nodePool); err != nil {
+			return false, err
+		}
+		scaled, progress := nodePoolScaleProgress(nodePool, o.Replicas)
+		if progress != lastProgress {
+			fmt.Fprintf(out, "Waiting for NodePool %s/%s: %s\n", o.Namespace, o.Name, progress)
+			lastProgress = progress
+		}
+		return scaled, nil
+	})
+	if err != nil {
+		if waitCtx.Err() != nil {
+			return fmt.Errorf("timed out after %s waiting for NodePool %s/%s to have %d ready replicas, last status: %s", o.Timeout, o.Namespace, o.Name, o.Replicas, lastProgress)
+		}
+		return err
+	}
+	fmt.Fprintf(out, "NodePool %s/%s has %d ready replicas\n", o.Namespace, o.Name, o.Replicas)
+	return nil
+}
+
+// nodePoolScaleProgress returns whether the NodePool converged to the desired number of ready nodes, and a
+// description of its progress.
+func nodePoolScaleProgress(nodePool *hyperv1.NodePool, replicas int32) (bool, string) {
+	progress := fmt.Sprintf("%d of %d replicas ready", nodePool.Status.Replicas, replicas)
+	if nodePool.Status.Replicas != replicas {
+		return false, progress
+	}
+	for _, condition := range nodePool.Status.Conditions {
+		if condition.Type == hyperv1.NodePoolAllMachinesReadyConditionType && condition.Status != corev1.ConditionTrue {
+			return false, fmt.Sprintf("%s, machines not ready: %s", progress, condition.Message)
+		}
+	}
+	return true, progress
+}
@@ -0,0 +1,141 @@
+package nodepool
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/support/api"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestScaleNodePool(t *testing.T) {
+	nodePool := func(replicas int32, readyReplicas int32, autoScaling bool) *hyperv1.NodePool {
+		np := &hyperv1.NodePool{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "workers"},
+			Spec:       hyperv1.NodePoolSpec{Replicas: ptr.To(replicas)},
+			Status:     hyperv1.NodePoolStatus{Replicas: readyReplicas},
+		}
+		if autoScaling {
+			np.Spec.Replicas = nil
+			np.Spec.AutoScaling = &hyperv1.NodePoolAutoScaling{Min: 1, Max: 3}
+		}
+		return np
+	}
+
+	testCases := []struct {
+		name             string
+		nodePool         *hyperv1.NodePool
+		replicas         int32
+		wait             bool
+		expectError      bool
+		expectedReplicas *int32
+	}{
+		{
+			name:             "When scaling without waiting it should only patch the replicas",
+			nodePool:         nodePool(2, 2, false),
+			replicas:         5,
+			expectedReplicas: ptr.To[int32](5),
+		},
+		{
+			name:             "When waiting and the NodePool has the desired ready replicas it should succeed",
+			nodePool:         nodePool(3, 3, false),
+			replicas:         3,
+			wait:             true,
+			expectedReplicas: ptr.To[int32](3),
+		},
+		{
+			name:             "When waiting and the NodePool doesn't converge before the timeout it should fail",
+			nodePool:         nodePool(2, 2, false),
+			replicas:         4,
+			wait:             true,
+			expectError:      true,
+			expectedReplicas: ptr.To[int32](4),
+		},
+		{
+			name:        "When the NodePool has autoscaling enabled it should fail",
+			nodePool:    nodePool(0, 1, true),
+			replicas:    2,
+			expectError: true,
+		},
+		{
+			name:             "When the replicas are negative it should fail",
+			nodePool:         nodePool(2, 2, false),
+			replicas:         -1,
+			expectError:      true,
+			expectedReplicas: ptr.To[int32](2),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			c := fake.NewClientBuilder().WithScheme(api.Scheme).WithObjects(tc.nodePool).WithStatusSubresource(tc.nodePool).Build()
+			opts := &ScaleOptions{
+				Name:         "workers",
+				Namespace:    "clusters",
+				Replicas:     tc.replicas,
+				Wait:         tc.wait,
+				Timeout:      100 * time.Millisecond,
+				PollInterval: 10 * time.Millisecond,
+			}
+
+			err := opts.Run(context.Background(), c, &bytes.Buffer{})
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+
+			actual := &hyperv1.NodePool{}
+			g.Expect(c.Get(context.Background(), types.NamespacedName{Namespace: "clusters", Name: "workers"}, actual)).To(Succeed())
+			g.Expect(actual.Spec.Replicas).To(Equal(tc.expectedReplicas))
+		})
+	}
+}
+
+func TestNodePoolScaleProgress(t *testing.T) {
+	testCases := []struct {
+		name           string
+		status         hyperv1.NodePoolStatus
+		expectedScaled bool
+	}{
+		{
+			name:   "When the NodePool has fewer ready replicas than desired it should not be scaled",
+			status: hyperv1.NodePoolStatus{Replicas: 1},
+		},
+		{
+			name: "When the NodePool has the desired replicas but machines are not ready it should not be scaled",
+			status: hyperv1.NodePoolStatus{
+				Replicas: 2,
+				Conditions: []hyperv1.NodePoolCondition{
+					{Type: hyperv1.NodePoolAllMachinesReadyConditionType, Status: corev1.ConditionFalse, Message: "1 of 2 machines are not ready"},
+				},
+			},
+		},
+		{
+			name: "When the NodePool has the desired ready replicas it should be scaled",
+			status: hyperv1.NodePoolStatus{
+				Replicas: 2,
+				Conditions: []hyperv1.NodePoolCondition{
+					{Type: hyperv1.NodePoolAllMachinesReadyConditionType, Status: corev1.ConditionTrue},
+				},
+			},
+			expectedScaled: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			scaled, progress := nodePoolScaleProgress(&hyperv1.NodePool{Status: tc.status}, 2)
+			g.Expect(scaled).To(Equal(tc.expectedScaled))
+			g.Expect(progress).ToNot(BeEmpty())
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package release
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/hypershift/cmd/log"
+	"github.com/openshift/hypershift/support/releaseinfo"
+)
+
+type InfoOptions struct {
+	ReleaseImage   string
+	PullSecretFile string
+	CacheDir       string
+	CacheTTL       time.Duration
+	NoCache        bool
+	Output         string
+
+	Log logr.Logger
+}
+
+// ReleaseInfo is the metadata of a release image reported by the release info command.
+type ReleaseInfo struct {
+	Image             string            `json:"image"`
+	Digest            string            `json:"digest"`
+	Version           string            `json:"version"`
+	Architectures     []string          `json:"architectures,omitempty"`
+	ComponentVersions map[string]string `json:"componentVersions,omitempty"`
+	ComponentImages   map[string]string `json:"componentImages"`
+}
+
+func NewInfoCommand() *cobra.Command {
+	opts := &InfoOptions{
+		CacheTTL: releaseinfo.DefaultPersistentCacheTTL,
+		Output:   "text",
+		Log:      log.Log,
+	}
+	if cacheDir, err := os.UserCacheDir(); err == nil {
+		opts.CacheDir = filepath.Join(cacheDir, "hypershift", "release-info")
+	}
+
+	cmd := &cobra.Command{
+		Use:          "info",
+		Short:        "Prints the version, architectures and component images of a release image",
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringVar(&opts.ReleaseImage, "release-image", opts.ReleaseImage, "The OCP release image to inspect (required)")
+	cmd.Flags().StringVar(&opts.PullSecretFile, "pull-secret", opts.PullSecretFile, "Path to a pull secret used to access the release image")
+	cmd.Flags().StringVar(&opts.CacheDir, "cache-dir", opts.CacheDir, "Directory where release metadata is cached by digest")
+	cmd.Flags().DurationVar(&opts.CacheTTL, "cache-ttl", opts.CacheTTL, "How long cached release metadata is trusted before it is refreshed")
+	cmd.Flags().BoolVar(&opts.NoCache, "no-cache", opts.NoCache, "If true, release metadata is always read from the registry and is not cached")
+	cmd.Flags().StringVarP(&opts.Output, "output", "o", opts.Output, "Output format, one of: text, json")
+
+	_ = cmd.MarkFlagRequired("release-image")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		if opts.Output != "text" && opts.Output != "json" {
+			return fmt.Errorf("unsupported output format %q, must be one of: text, json", opts.Output)
+		}
+		if err := opts.Run(cmd.Context(), cmd.OutOrStdout()); err != nil {
+			opts.Log.Error(err, "Failed to get release info")
+			return err
+		}
+		return nil
+	}
+
+	return cmd
+}
+
+func (o *InfoOptions) Run(ctx context.Context, out io.Writer) error {
+	var pullSecret []byte
+	if o.PullSecretFile != "" {
+		var err error
+		pullSecret, err = os.ReadFile(o.PullSecretFile)
+		if err != nil {
+			return fmt.Errorf("failed to read pull secret file: %w", err)
+		}
+	}
+
+	provider := &releaseinfo.PersistentCacheProvider{
+		Inner: &releaseinfo.RegistryClientProvider{},
+		TTL:   o.CacheTTL,
+	}
+	if !o.NoCache {
+		provider.Dir = o.CacheDir
+	}
+
+	info, err := GetReleaseInfo(ctx, provider, o.ReleaseImage, pullSecret)
+	if err != nil {
+		return err
+	}
+
+	if o.Output == "json" {
+		data, err := json.MarshalIndent(info, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal release info: %w", err)
+		}
+		_, err = fmt.Fprintln(out, string(data))
+		return err
+	}
+	return PrintReleaseInfo(out, info)
+}
+
+// GetReleaseInfo looks up the metadata of the release image through the cache.
+func GetReleaseInfo(ctx context.Context, provider *releaseinfo.PersistentCacheProvider, image string, pullSecret []byte) (*ReleaseInfo, error) {
+	imageDigest, err := provider.Digest(ctx, image, pullSecret)
+	if err != nil {
+		return nil, err
+	}
+	releaseImage, err := provider.Lookup(ctx, image, pullSecret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up release image metadata: %w", err)
+	}
+
+	info := &ReleaseInfo{
+		Image:           image,
+		Digest:          imageDigest.String(),
+		Version:         releaseImage.Version(),
+		ComponentImages: releaseImage.ComponentImages(),
+	}
+	if releaseImage.StreamMetadata != nil {
+		for arch := range releaseImage.StreamMetadata.Architectures {
+			info.Architectures = append(info.Architectures, arch)
+		}
+		sort.Strings(info.Architectures)
+	}
+	// Component versions are informational, don't fail on malformed annotations.
+	if versions, err := releaseImage.ComponentVersions(); err == nil {
+		info.ComponentVersions = versions
+	}
+	return info, nil
+}
+
+// PrintReleaseInfo renders the release info as human readable text.
+func PrintReleaseInfo(out io.Writer, info *ReleaseInfo) error {
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(w, "Image:\t%s\n", info.Image)
+	fmt.Fprintf(w, "Digest:\t%s\n", info.Digest)
+	fmt.Fprintf(w, "Version:\t%s\n", info.Version)
+	if len(info.Architectures) > 0 {
+		fmt.Fprintf(w, "Architectures:\t%v\n", info.Architectures)
+	}
+
+	if len(info.ComponentVersions) > 0 {
+		fmt.Fprintf(w, "\nCOMPONENT\tVERSION\n")
+		for _, name := range sortedKeys(info.ComponentVersions) {
+			fmt.Fprintf(w, "%s\t%s\n", name, info.ComponentVersions[name])
+		}
+	}
+
+	fmt.Fprintf(w, "\nNAME\tIMAGE\n")
+	for _, name := range sortedKeys(info.ComponentImages) {
+		fmt.Fprintf(w, "%s\t%s\n", name, info.ComponentImages[name])
+	}
+	return w.Flush()
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
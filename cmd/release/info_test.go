@@ -0,0 +1,56 @@
+package release
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/opencontainers/go-digest"
+	imageapi "github.com/openshift/api/image/v1"
+	"github.com/openshift/hypershift/support/releaseinfo"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type staticProvider struct{}
+
+func (staticProvider) Lookup(context.Context, string, []byte) (*releaseinfo.ReleaseImage, error) {
+	return &releaseinfo.ReleaseImage{
+		ImageStream: &imageapi.ImageStream{
+			ObjectMeta: metav1.ObjectMeta{Name: "4.15.0"},
+			Spec: imageapi.ImageStreamSpec{
+				Tags: []imageapi.TagReference{
+					{Name: "hyperkube", From: &corev1.ObjectReference{Name: "quay.io/ocp/hyperkube@sha256:1234"}},
+					{Name: "etcd", From: &corev1.ObjectReference{Name: "quay.io/ocp/etcd@sha256:5678"}},
+				},
+			},
+		},
+		StreamMetadata: &releaseinfo.CoreOSStreamMetadata{
+			Architectures: map[string]releaseinfo.CoreOSArchitecture{"x86_64": {}, "aarch64": {}},
+		},
+	}, nil
+}
+
+func TestGetReleaseInfo(t *testing.T) {
+	g := NewWithT(t)
+	provider := &releaseinfo.PersistentCacheProvider{
+		Inner: staticProvider{},
+		Dir:   t.TempDir(),
+		ResolveDigest: func(context.Context, string, []byte) (digest.Digest, error) {
+			return "sha256:ac9e9b5a4ee5a8fcf2d2fb6b4c5e6b0e8a15b09a0e2c63a1f35c8df3e7ef2b10", nil
+		},
+	}
+
+	info, err := GetReleaseInfo(context.Background(), provider, "quay.io/openshift-release-dev/ocp-release:4.15.0-multi", nil)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(info.Version).To(Equal("4.15.0"))
+	g.Expect(info.Digest).To(Equal("sha256:ac9e9b5a4ee5a8fcf2d2fb6b4c5e6b0e8a15b09a0e2c63a1f35c8df3e7ef2b10"))
+	g.Expect(info.Architectures).To(Equal([]string{"aarch64", "x86_64"}))
+	g.Expect(info.ComponentImages).To(HaveKeyWithValue("etcd", "quay.io/ocp/etcd@sha256:5678"))
+
+	out := &bytes.Buffer{}
+	g.Expect(PrintReleaseInfo(out, info)).To(Succeed())
+	g.Expect(out.String()).To(ContainSubstring("Version:        4.15.0"))
+	g.Expect(out.String()).To(MatchRegexp(`etcd\s+quay.io/ocp/etcd@sha256:5678\nhyperkube\s+`))
+}
@@ -0,0 +1,17 @@
+package release
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "release",
+		Short:        "Commands for inspecting OCP release images",
+		SilenceUsage: true,
+	}
+
+	cmd.AddCommand(NewInfoCommand())
+
+	return cmd
+}
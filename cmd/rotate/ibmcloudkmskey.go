@@ -0,0 +1,198 @@
+package rotate
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/cmd/log"
+	"github.com/openshift/hypershift/cmd/util"
+	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/kas/kms"
+)
+
+type IBMCloudKMSKeyOptions struct {
+	Name              string
+	Namespace         string
+	CRKID             string
+	InstanceID        string
+	URL               string
+	CorrelationID     string
+	PrunePreviousKeys bool
+	Timeout           time.Duration
+	PollInterval      time.Duration
+
+	Log logr.Logger
+}
+
+func NewIBMCloudKMSKeyCommand() *cobra.Command {
+	opts := &IBMCloudKMSKeyOptions{
+		Namespace:    "clusters",
+		Timeout:      60 * time.Minute,
+		PollInterval: 10 * time.Second,
+		Log:          log.Log,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "ibmcloud-kms-key",
+		Short: "Rotates the IBM Cloud KMS root key encrypting the secrets of a HostedCluster, waiting for them to be re-encrypted",
+		Long: `Rotates the IBM Cloud Key Protect or Hyper Protect Crypto Services root key encrypting the secrets of a
+HostedCluster: the new root key is added to the key list of the HostedCluster with the next key version, which makes it
+the active key. Once the kube-apiserver uses it, the encrypted resources are re-encrypted with it, and the rotation
+succeeds when the KMSDataReencrypted condition of the HostedCluster reports the new key. The previous keys can then
+be removed from the key list with --prune-previous-keys.`,
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringVar(&opts.Name, "name", opts.Name, "The name of the HostedCluster (required)")
+	cmd.Flags().StringVar(&opts.Namespace, "namespace", opts.Namespace, "The namespace of the HostedCluster")
+	cmd.Flags().StringVar(&opts.CRKID, "crk-id", opts.CRKID, "The ID of the new customer root key (required)")
+	cmd.Flags().StringVar(&opts.InstanceID, "instance-id", opts.InstanceID, "The ID of the Key Protect or Hyper Protect Crypto Services instance of the new key. Defaults to the instance of the active key")
+	cmd.Flags().StringVar(&opts.URL, "url", opts.URL, "The URL of the KMS API of the new key. Defaults to the URL of the active key")
+	cmd.Flags().StringVar(&opts.CorrelationID, "correlation-id", opts.CorrelationID, "The correlation ID used to track the KMS API calls with the new key. Defaults to the correlation ID of the active key")
+	cmd.Flags().BoolVar(&opts.PrunePreviousKeys, "prune-previous-keys", opts.PrunePreviousKeys, "If true, the previous keys are removed from the key list once the encrypted resources are re-encrypted with the new key")
+	cmd.Flags().DurationVar(&opts.Timeout, "timeout", opts.Timeout, "How long to wait for the encrypted resources to be re-encrypted with the new key")
+
+	_ = cmd.MarkFlagRequired("name")
+	_ = cmd.MarkFlagRequired("crk-id")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		c, err := util.GetClient()
+		if err != nil {
+			return err
+		}
+		if err := opts.Run(cmd.Context(), c, cmd.OutOrStdout()); err != nil {
+			opts.Log.Error(err, "Failed to rotate IBM Cloud KMS key")
+			return err
+		}
+		return nil
+	}
+
+	return cmd
+}
+
+func (o *IBMCloudKMSKeyOptions) Run(ctx context.Context, c crclient.Client, out io.Writer) error {
+	hcluster := &hyperv1.HostedCluster{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: o.Namespace, Name: o.Name}, hcluster); err != nil {
+		return fmt.Errorf("failed to get HostedCluster: %w", err)
+	}
+	secretEncryption := hcluster.Spec.SecretEncryption
+	if secretEncryption == nil || secretEncryption.KMS == nil || secretEncryption.KMS.Provider != hyperv1.IBMCloud || secretEncryption.KMS.IBMCloud == nil || len(secretEncryption.KMS.IBMCloud.KeyList) == 0 {
+		return fmt.Errorf("the secrets of HostedCluster %s/%s are not encrypted with IBM Cloud KMS", o.Namespace, o.Name)
+	}
+
+	active := kms.IBMCloudActiveKey(secretEncryption.KMS.IBMCloud.KeyList)
+	newKey := hyperv1.IBMCloudKMSKeyEntry{
+		CRKID:         o.CRKID,
+		InstanceID:    active.InstanceID,
+		CorrelationID: active.CorrelationID,
+		URL:           active.URL,
+		KeyVersion:    active.KeyVersion + 1,
+	}
+	if o.InstanceID != "" {
+		newKey.InstanceID = o.InstanceID
+	}
+	if o.URL != "" {
+		newKey.URL = o.URL
+	}
+	if o.CorrelationID != "" {
+		newKey.CorrelationID = o.CorrelationID
+	}
+	if newKey.CRKID == active.CRKID && newKey.InstanceID == active.InstanceID {
+		return fmt.Errorf("root key %s is the active key of HostedCluster %s/%s", o.CRKID, o.Namespace, o.Name)
+	}
+
+	original := hcluster.DeepCopy()
+	ibmCloud := hcluster.Spec.SecretEncryption.KMS.IBMCloud
+	ibmCloud.KeyList = append(ibmCloud.KeyList, newKey)
+	if err := c.Patch(ctx, hcluster, crclient.MergeFromWithOptions(original, crclient.MergeFromWithOptimisticLock{})); err != nil {
+		return fmt.Errorf("failed to add the new key to HostedCluster: %w", err)
+	}
+	fmt.Fprintf(out, "Added root key %s to HostedCluster %s/%s with key version %d\n", newKey.CRKID, o.Namespace, o.Name, newKey.KeyVersion)
+
+	if err := o.waitForReencryption(ctx, c, out, newKey.KeyVersion); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "The encrypted resources of HostedCluster %s/%s are re-encrypted with key version %d\n", o.Namespace, o.Name, newKey.KeyVersion)
+
+	if !o.PrunePreviousKeys {
+		return nil
+	}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: o.Namespace, Name: o.Name}, hcluster); err != nil {
+		return fmt.Errorf("failed to get HostedCluster: %w", err)
+	}
+	original = hcluster.DeepCopy()
+	ibmCloud = hcluster.Spec.SecretEncryption.KMS.IBMCloud
+	var pruned []string
+	var keyList []hyperv1.IBMCloudKMSKeyEntry
+	for _, key := range ibmCloud.KeyList {
+		if key.KeyVersion < newKey.KeyVersion {
+			pruned = append(pruned, fmt.Sprintf("%d", key.KeyVersion))
+			continue
+		}
+		keyList = append(keyList, key)
+	}
+	ibmCloud.KeyList = keyList
+	if err := c.Patch(ctx, hcluster, crclient.MergeFromWithOptions(original, crclient.MergeFromWithOptimisticLock{})); err != nil {
+		return fmt.Errorf("failed to remove the previous keys from HostedCluster: %w", err)
+	}
+	fmt.Fprintf(out, "Removed the previous key versions %s from HostedCluster %s/%s\n", strings.Join(pruned, ", "), o.Namespace, o.Name)
+	return nil
+}
+
+// waitForReencryption polls the HostedCluster until its KMSDataReencrypted condition reports the outcome of the
+// re-encryption with the key version, printing the progress whenever it changes.
+func (o *IBMCloudKMSKeyOptions) waitForReencryption(ctx context.Context, c crclient.Client, out io.Writer, keyVersion int) error {
+	waitCtx, cancel := context.WithTimeout(ctx, o.Timeout)
+	defer cancel()
+
+	lastProgress := ""
+	var reencryptionErr error
+	err := wait.PollUntilContextCancel(waitCtx, o.PollInterval, true, func(ctx context.Context) (bool, error) {
+		hcluster := &hyperv1.HostedCluster{}
+		if err := c.Get(ctx, types.NamespacedName{Namespace: o.Namespace, Name: o.Name}, hcluster); err != nil {
+			return false, err
+		}
+		done, progress, err := reencryptionProgress(hcluster, keyVersion)
+		if progress != lastProgress {
+			fmt.Fprintf(out, "Waiting for HostedCluster %s/%s: %s\n", o.Namespace, o.Name, progress)
+			lastProgress = progress
+		}
+		reencryptionErr = err
+		return done, nil
+	})
+	if err != nil {
+		if waitCtx.Err() != nil {
+			return fmt.Errorf("timed out after %s waiting for the encrypted resources of HostedCluster %s/%s to be re-encrypted, last status: %s", o.Timeout, o.Namespace, o.Name, lastProgress)
+		}
+		return err
+	}
+	return reencryptionErr
+}
+
+// reencryptionProgress returns whether the re-encryption of the encrypted resources of the HostedCluster with the key
+// version is over, a description of its progress, and an error if it failed.
+func reencryptionProgress(hcluster *hyperv1.HostedCluster, keyVersion int) (bool, string, error) {
+	condition := meta.FindStatusCondition(hcluster.Status.Conditions, string(hyperv1.KMSDataReencrypted))
+	// The message of the condition names the key version it reports on.
+	if condition == nil || !strings.Contains(condition.Message, fmt.Sprintf("key version %d ", keyVersion)) {
+		return false, "waiting for the new key to be observed", nil
+	}
+	switch {
+	case condition.Status == metav1.ConditionTrue:
+		return true, condition.Message, nil
+	case condition.Reason == hyperv1.RotationFailedReason:
+		return true, condition.Message, fmt.Errorf("re-encryption failed: %s", condition.Message)
+	}
+	return false, condition.Message, nil
+}
@@ -0,0 +1,176 @@
+package rotate
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/support/api"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRotateIBMCloudKMSKey(t *testing.T) {
+	activeKey := hyperv1.IBMCloudKMSKeyEntry{CRKID: "crk-1", InstanceID: "instance", CorrelationID: "correlation", URL: "https://us-south.kms.cloud.ibm.com", KeyVersion: 1}
+	ibmCloudCluster := func(conditions ...metav1.Condition) *hyperv1.HostedCluster {
+		return &hyperv1.HostedCluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "hc"},
+			Spec: hyperv1.HostedClusterSpec{
+				Platform: hyperv1.PlatformSpec{Type: hyperv1.IBMCloudPlatform},
+				SecretEncryption: &hyperv1.SecretEncryptionSpec{
+					Type: hyperv1.KMS,
+					KMS: &hyperv1.KMSSpec{
+						Provider: hyperv1.IBMCloud,
+						IBMCloud: &hyperv1.IBMCloudKMSSpec{Region: "us-south", KeyList: []hyperv1.IBMCloudKMSKeyEntry{activeKey}},
+					},
+				},
+			},
+			Status: hyperv1.HostedClusterStatus{Conditions: conditions},
+		}
+	}
+	reencryptedCondition := func(status metav1.ConditionStatus, reason, message string) metav1.Condition {
+		return metav1.Condition{Type: string(hyperv1.KMSDataReencrypted), Status: status, Reason: reason, Message: message}
+	}
+
+	testCases := []struct {
+		name            string
+		hcluster        *hyperv1.HostedCluster
+		crkID           string
+		prune           bool
+		expectError     bool
+		expectedKeyList []hyperv1.IBMCloudKMSKeyEntry
+	}{
+		{
+			name:     "When the resources are re-encrypted with the new key it should prune the previous keys",
+			hcluster: ibmCloudCluster(reencryptedCondition(metav1.ConditionTrue, hyperv1.AsExpectedReason, "The encrypted resources are re-encrypted with key version 2 (root key crk-2)")),
+			crkID:    "crk-2",
+			prune:    true,
+			expectedKeyList: []hyperv1.IBMCloudKMSKeyEntry{
+				{CRKID: "crk-2", InstanceID: "instance", CorrelationID: "correlation", URL: "https://us-south.kms.cloud.ibm.com", KeyVersion: 2},
+			},
+		},
+		{
+			name:        "When the re-encryption fails it should keep the previous keys",
+			hcluster:    ibmCloudCluster(reencryptedCondition(metav1.ConditionFalse, hyperv1.RotationFailedReason, "Failed to re-encrypt the encrypted resources with key version 2 (root key crk-2): BackoffLimitExceeded")),
+			crkID:       "crk-2",
+			prune:       true,
+			expectError: true,
+			expectedKeyList: []hyperv1.IBMCloudKMSKeyEntry{
+				activeKey,
+				{CRKID: "crk-2", InstanceID: "instance", CorrelationID: "correlation", URL: "https://us-south.kms.cloud.ibm.com", KeyVersion: 2},
+			},
+		},
+		{
+			name:        "When the condition reports the previous key it should time out",
+			hcluster:    ibmCloudCluster(reencryptedCondition(metav1.ConditionTrue, hyperv1.AsExpectedReason, "The encrypted resources are re-encrypted with key version 1 (root key crk-1)")),
+			crkID:       "crk-2",
+			expectError: true,
+			expectedKeyList: []hyperv1.IBMCloudKMSKeyEntry{
+				activeKey,
+				{CRKID: "crk-2", InstanceID: "instance", CorrelationID: "correlation", URL: "https://us-south.kms.cloud.ibm.com", KeyVersion: 2},
+			},
+		},
+		{
+			name:            "When the new key is the active key it should fail",
+			hcluster:        ibmCloudCluster(),
+			crkID:           "crk-1",
+			expectError:     true,
+			expectedKeyList: []hyperv1.IBMCloudKMSKeyEntry{activeKey},
+		},
+		{
+			name: "When the secrets are not encrypted with IBM Cloud KMS it should fail",
+			hcluster: &hyperv1.HostedCluster{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "hc"},
+				Spec:       hyperv1.HostedClusterSpec{Platform: hyperv1.PlatformSpec{Type: hyperv1.AWSPlatform}},
+			},
+			crkID:       "crk-2",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			c := fake.NewClientBuilder().WithScheme(api.Scheme).WithObjects(tc.hcluster).WithStatusSubresource(tc.hcluster).Build()
+			opts := &IBMCloudKMSKeyOptions{
+				Name:              "hc",
+				Namespace:         "clusters",
+				CRKID:             tc.crkID,
+				PrunePreviousKeys: tc.prune,
+				Timeout:           100 * time.Millisecond,
+				PollInterval:      10 * time.Millisecond,
+			}
+
+			err := opts.Run(context.Background(), c, &bytes.Buffer{})
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+
+			hcluster := &hyperv1.HostedCluster{}
+			g.Expect(c.Get(context.Background(), types.NamespacedName{Namespace: "clusters", Name: "hc"}, hcluster)).To(Succeed())
+			if tc.expectedKeyList == nil {
+				g.Expect(hcluster.Spec.SecretEncryption).To(BeNil())
+				return
+			}
+			g.Expect(hcluster.Spec.SecretEncryption.KMS.IBMCloud.KeyList).To(Equal(tc.expectedKeyList))
+		})
+	}
+}
+
+func TestReencryptionProgress(t *testing.T) {
+	testCases := []struct {
+		name         string
+		condition    *metav1.Condition
+		expectDone   bool
+		expectFailed bool
+	}{
+		{
+			name: "When the re-encryption is not observed yet it should wait",
+		},
+		{
+			name:      "When the condition reports another key version it should wait",
+			condition: &metav1.Condition{Status: metav1.ConditionTrue, Message: "The encrypted resources are re-encrypted with key version 12 (root key crk-12)"},
+		},
+		{
+			name:      "When the resources are being re-encrypted it should wait",
+			condition: &metav1.Condition{Status: metav1.ConditionFalse, Reason: hyperv1.RotationInProgressReason, Message: "Re-encrypting the encrypted resources with key version 2 (root key crk-2)"},
+		},
+		{
+			name:       "When the resources are re-encrypted it should be done",
+			condition:  &metav1.Condition{Status: metav1.ConditionTrue, Reason: hyperv1.AsExpectedReason, Message: "The encrypted resources are re-encrypted with key version 2 (root key crk-2)"},
+			expectDone: true,
+		},
+		{
+			name:         "When the re-encryption fails it should fail",
+			condition:    &metav1.Condition{Status: metav1.ConditionFalse, Reason: hyperv1.RotationFailedReason, Message: "Failed to re-encrypt the encrypted resources with key version 2 (root key crk-2): BackoffLimitExceeded"},
+			expectDone:   true,
+			expectFailed: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			hcluster := &hyperv1.HostedCluster{}
+			if tc.condition != nil {
+				tc.condition.Type = string(hyperv1.KMSDataReencrypted)
+				hcluster.Status.Conditions = []metav1.Condition{*tc.condition}
+			}
+			done, _, err := reencryptionProgress(hcluster, 2)
+			g.Expect(done).To(Equal(tc.expectDone))
+			if tc.expectFailed {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+		})
+	}
+}
@@ -0,0 +1,261 @@
+package rotate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	awsinfra "github.com/openshift/hypershift/cmd/infra/aws"
+	"github.com/openshift/hypershift/cmd/log"
+	"github.com/openshift/hypershift/cmd/util"
+)
+
+type PlatformCredsOptions struct {
+	Name                 string
+	Namespace            string
+	AWSIAMJSON           string
+	AzureCredentialsFile string
+	RollbackOnFailure    bool
+	Timeout              time.Duration
+	PollInterval         time.Duration
+
+	Log logr.Logger
+}
+
+func NewPlatformCredsCommand() *cobra.Command {
+	opts := &PlatformCredsOptions{
+		Namespace:         "clusters",
+		RollbackOnFailure: true,
+		Timeout:           30 * time.Minute,
+		PollInterval:      10 * time.Second,
+		Log:               log.Log,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "platform-creds",
+		Short: "Rotates the cloud credentials of a HostedCluster, waiting for its control plane components to use them",
+		Long: `Rotates the cloud credentials of a HostedCluster: the IAM roles on AWS and the service principal on Azure.
+The control plane components using the credentials are restarted, and the rotation succeeds once they are all
+available again. Otherwise, the previous credentials are restored.`,
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringVar(&opts.Name, "name", opts.Name, "The name of the HostedCluster (required)")
+	cmd.Flags().StringVar(&opts.Namespace, "namespace", opts.Namespace, "The namespace of the HostedCluster")
+	cmd.Flags().StringVar(&opts.AWSIAMJSON, "aws-iam-json", opts.AWSIAMJSON, "Path to a file with the new IAM roles of an AWS HostedCluster, as output by 'hypershift create iam aws'")
+	cmd.Flags().StringVar(&opts.AzureCredentialsFile, "azure-creds", opts.AzureCredentialsFile, "Path to a file with the new service principal credentials of an Azure HostedCluster")
+	cmd.Flags().BoolVar(&opts.RollbackOnFailure, "rollback-on-failure", opts.RollbackOnFailure, "If true, the previous credentials are restored when the control plane components fail to use the new ones")
+	cmd.Flags().DurationVar(&opts.Timeout, "timeout", opts.Timeout, "How long to wait for the control plane components to use the new credentials")
+
+	_ = cmd.MarkFlagRequired("name")
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		c, err := util.GetClient()
+		if err != nil {
+			return err
+		}
+		if err := opts.Run(cmd.Context(), c, cmd.OutOrStdout()); err != nil {
+			opts.Log.Error(err, "Failed to rotate platform credentials")
+			return err
+		}
+		return nil
+	}
+
+	return cmd
+}
+
+func (o *PlatformCredsOptions) Run(ctx context.Context, c crclient.Client, out io.Writer) error {
+	hcluster := &hyperv1.HostedCluster{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: o.Namespace, Name: o.Name}, hcluster); err != nil {
+		return fmt.Errorf("failed to get HostedCluster: %w", err)
+	}
+	previous := hcluster.Spec.Platform.DeepCopy()
+
+	// The Azure credentials are written to a new secret so that the previous one is left intact for the rollback.
+	var newSecret *corev1.Secret
+	platform := hcluster.Spec.Platform.DeepCopy()
+	switch platform.Type {
+	case hyperv1.AWSPlatform:
+		roles, err := o.readAWSRoles()
+		if err != nil {
+			return err
+		}
+		if roles == platform.AWS.RolesRef {
+			return fmt.Errorf("the IAM roles in %s are the current IAM roles of HostedCluster %s/%s", o.AWSIAMJSON, o.Namespace, o.Name)
+		}
+		platform.AWS.RolesRef = roles
+	case hyperv1.AzurePlatform:
+		if o.AzureCredentialsFile == "" {
+			return fmt.Errorf("--azure-creds is required to rotate the credentials of an Azure HostedCluster")
+		}
+		creds, err := util.ReadCredentials(o.AzureCredentialsFile)
+		if err != nil {
+			return err
+		}
+		newSecret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: o.Namespace,
+				Name:      fmt.Sprintf("%s-cloud-credentials-%d", o.Name, time.Now().Unix()),
+			},
+			Data: map[string][]byte{
+				"AZURE_SUBSCRIPTION_ID": []byte(creds.SubscriptionID),
+				"AZURE_TENANT_ID":       []byte(creds.TenantID),
+				"AZURE_CLIENT_ID":       []byte(creds.ClientID),
+				"AZURE_CLIENT_SECRET":   []byte(creds.ClientSecret),
+			},
+		}
+		if err := c.Create(ctx, newSecret); err != nil {
+			return fmt.Errorf("failed to create the secret of the new credentials: %w", err)
+		}
+		fmt.Fprintf(out, "Created secret %s/%s with the new credentials\n", newSecret.Namespace, newSecret.Name)
+		platform.Azure.Credentials.Name = newSecret.Name
+	default:
+		return fmt.Errorf("rotating the platform credentials of %s HostedClusters is not supported", platform.Type)
+	}
+
+	rotation, err := o.applyPlatformCredentials(ctx, c, hcluster, platform, "")
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Rotated the platform credentials of HostedCluster %s/%s, restarting its control plane components\n", o.Namespace, o.Name)
+
+	rotateErr := o.waitForRotation(ctx, c, out)
+	if rotateErr == nil {
+		fmt.Fprintf(out, "The control plane components of HostedCluster %s/%s use the new platform credentials\n", o.Namespace, o.Name)
+		if platform.Type == hyperv1.AzurePlatform {
+			fmt.Fprintf(out, "The previous credentials in secret %s/%s are no longer used by the HostedCluster\n", o.Namespace, previous.Azure.Credentials.Name)
+		}
+		return nil
+	}
+	if !o.RollbackOnFailure {
+		return rotateErr
+	}
+
+	fmt.Fprintf(out, "Rolling back to the previous platform credentials: %v\n", rotateErr)
+	if err := c.Get(ctx, types.NamespacedName{Namespace: o.Namespace, Name: o.Name}, hcluster); err != nil {
+		return fmt.Errorf("failed to get HostedCluster: %w", err)
+	}
+	if _, err := o.applyPlatformCredentials(ctx, c, hcluster, previous, rotation); err != nil {
+		return fmt.Errorf("%w, and failed to roll back: %v", rotateErr, err)
+	}
+	if err := o.waitForRotation(ctx, c, out); err != nil {
+		return fmt.Errorf("%w, and failed to roll back: %v", rotateErr, err)
+	}
+	if newSecret != nil {
+		if err := c.Delete(ctx, newSecret); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("%w, rolled back but failed to delete secret %s/%s: %v", rotateErr, newSecret.Namespace, newSecret.Name, err)
+		}
+	}
+	return fmt.Errorf("%w, rolled back to the previous platform credentials", rotateErr)
+}
+
+func (o *PlatformCredsOptions) readAWSRoles() (hyperv1.AWSRolesRef, error) {
+	if o.AWSIAMJSON == "" {
+		return hyperv1.AWSRolesRef{}, fmt.Errorf("--aws-iam-json is required to rotate the credentials of an AWS HostedCluster")
+	}
+	raw, err := os.ReadFile(o.AWSIAMJSON)
+	if err != nil {
+		return hyperv1.AWSRolesRef{}, fmt.Errorf("failed to read %s: %w", o.AWSIAMJSON, err)
+	}
+	iamInfo := &awsinfra.CreateIAMOutput{}
+	if err := json.Unmarshal(raw, iamInfo); err != nil {
+		return hyperv1.AWSRolesRef{}, fmt.Errorf("failed to parse %s: %w", o.AWSIAMJSON, err)
+	}
+	roles := iamInfo.Roles
+	for name, arn := range map[string]string{
+		"ingressARN":              roles.IngressARN,
+		"imageRegistryARN":        roles.ImageRegistryARN,
+		"storageARN":              roles.StorageARN,
+		"networkARN":              roles.NetworkARN,
+		"kubeCloudControllerARN":  roles.KubeCloudControllerARN,
+		"nodePoolManagementARN":   roles.NodePoolManagementARN,
+		"controlPlaneOperatorARN": roles.ControlPlaneOperatorARN,
+	} {
+		if arn == "" {
+			return hyperv1.AWSRolesRef{}, fmt.Errorf("%s has no %s role", o.AWSIAMJSON, name)
+		}
+	}
+	return roles, nil
+}
+
+// applyPlatformCredentials updates the platform credentials of the HostedCluster and restarts its control plane
+// components. It returns the date of the rotation, which is always after the date of the previous rotation.
+func (o *PlatformCredsOptions) applyPlatformCredentials(ctx context.Context, c crclient.Client, hcluster *hyperv1.HostedCluster, platform *hyperv1.PlatformSpec, previousRotation string) (string, error) {
+	now := time.Now().UTC().Truncate(time.Second)
+	if previous, err := time.Parse(time.RFC3339, previousRotation); err == nil && !now.After(previous) {
+		now = previous.Add(time.Second)
+	}
+	rotation := now.Format(time.RFC3339)
+
+	original := hcluster.DeepCopy()
+	hcluster.Spec.Platform = *platform
+	if hcluster.Annotations == nil {
+		hcluster.Annotations = map[string]string{}
+	}
+	hcluster.Annotations[hyperv1.RestartDateAnnotation] = rotation
+	hcluster.Annotations[hyperv1.PlatformCredentialsRotationAnnotation] = rotation
+	if err := c.Patch(ctx, hcluster, crclient.MergeFrom(original)); err != nil {
+		return "", fmt.Errorf("failed to update the platform credentials of HostedCluster: %w", err)
+	}
+	return rotation, nil
+}
+
+// waitForRotation polls the HostedCluster until its PlatformCredentialsRotated condition reports the outcome of the
+// last rotation, printing the progress whenever it changes.
+func (o *PlatformCredsOptions) waitForRotation(ctx context.Context, c crclient.Client, out io.Writer) error {
+	waitCtx, cancel := context.WithTimeout(ctx, o.Timeout)
+	defer cancel()
+
+	lastProgress := ""
+	var rotationErr error
+	err := wait.PollUntilContextCancel(waitCtx, o.PollInterval, true, func(ctx context.Context) (bool, error) {
+		hcluster := &hyperv1.HostedCluster{}
+		if err := c.Get(ctx, types.NamespacedName{Namespace: o.Namespace, Name: o.Name}, hcluster); err != nil {
+			return false, err
+		}
+		done, progress, err := rotationProgress(hcluster)
+		if progress != lastProgress {
+			fmt.Fprintf(out, "Waiting for HostedCluster %s/%s: %s\n", o.Namespace, o.Name, progress)
+			lastProgress = progress
+		}
+		rotationErr = err
+		return done, nil
+	})
+	if err != nil {
+		if waitCtx.Err() != nil {
+			return fmt.Errorf("timed out after %s waiting for the platform credentials of HostedCluster %s/%s to be rotated, last status: %s", o.Timeout, o.Namespace, o.Name, lastProgress)
+		}
+		return err
+	}
+	return rotationErr
+}
+
+// rotationProgress returns whether the last rotation of the platform credentials of the HostedCluster is over, a
+// description of its progress, and an error if it failed.
+func rotationProgress(hcluster *hyperv1.HostedCluster) (bool, string, error) {
+	condition := meta.FindStatusCondition(hcluster.Status.Conditions, string(hyperv1.PlatformCredentialsRotated))
+	if condition == nil || condition.ObservedGeneration != hcluster.Generation {
+		return false, "waiting for the rotation to be observed", nil
+	}
+	switch {
+	case condition.Status == metav1.ConditionTrue:
+		return true, condition.Message, nil
+	case condition.Reason == hyperv1.RotationFailedReason:
+		return true, condition.Message, fmt.Errorf("platform credentials rotation failed: %s", condition.Message)
+	}
+	return false, condition.Message, nil
+}
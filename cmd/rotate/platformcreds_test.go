@@ -0,0 +1,210 @@
+package rotate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	awsinfra "github.com/openshift/hypershift/cmd/infra/aws"
+	"github.com/openshift/hypershift/support/api"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRotatePlatformCreds(t *testing.T) {
+	roles := hyperv1.AWSRolesRef{
+		IngressARN:              "arn:aws:iam::123456789012:role/ingress",
+		ImageRegistryARN:        "arn:aws:iam::123456789012:role/registry",
+		StorageARN:              "arn:aws:iam::123456789012:role/storage",
+		NetworkARN:              "arn:aws:iam::123456789012:role/network",
+		KubeCloudControllerARN:  "arn:aws:iam::123456789012:role/ccm",
+		NodePoolManagementARN:   "arn:aws:iam::123456789012:role/nodepool",
+		ControlPlaneOperatorARN: "arn:aws:iam::123456789012:role/cpo",
+	}
+	rotatedCondition := func(status metav1.ConditionStatus, reason string) metav1.Condition {
+		return metav1.Condition{
+			Type:    string(hyperv1.PlatformCredentialsRotated),
+			Status:  status,
+			Reason:  reason,
+			Message: "components",
+		}
+	}
+	azureCluster := func(condition metav1.Condition) *hyperv1.HostedCluster {
+		return &hyperv1.HostedCluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "hc"},
+			Spec: hyperv1.HostedClusterSpec{
+				Platform: hyperv1.PlatformSpec{
+					Type:  hyperv1.AzurePlatform,
+					Azure: &hyperv1.AzurePlatformSpec{Credentials: corev1.LocalObjectReference{Name: "hc-cloud-credentials"}},
+				},
+			},
+			Status: hyperv1.HostedClusterStatus{Conditions: []metav1.Condition{condition}},
+		}
+	}
+
+	testCases := []struct {
+		name                        string
+		hcluster                    *hyperv1.HostedCluster
+		iamRoles                    *hyperv1.AWSRolesRef
+		rollback                    bool
+		expectError                 bool
+		expectRotatedSecret         bool
+		expectedAzureCredentialsRef string
+	}{
+		{
+			name:                "When the components use the new Azure credentials it should point the HostedCluster to them",
+			hcluster:            azureCluster(rotatedCondition(metav1.ConditionTrue, hyperv1.AsExpectedReason)),
+			expectRotatedSecret: true,
+		},
+		{
+			name:                "When the components fail with the new Azure credentials without rollback it should keep them",
+			hcluster:            azureCluster(rotatedCondition(metav1.ConditionFalse, hyperv1.RotationFailedReason)),
+			expectError:         true,
+			expectRotatedSecret: true,
+		},
+		{
+			name:                        "When the components fail with the new Azure credentials with rollback it should restore the previous ones",
+			hcluster:                    azureCluster(rotatedCondition(metav1.ConditionFalse, hyperv1.RotationFailedReason)),
+			rollback:                    true,
+			expectError:                 true,
+			expectedAzureCredentialsRef: "hc-cloud-credentials",
+		},
+		{
+			name: "When the AWS IAM roles are the current ones it should fail",
+			hcluster: &hyperv1.HostedCluster{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "hc"},
+				Spec: hyperv1.HostedClusterSpec{
+					Platform: hyperv1.PlatformSpec{
+						Type: hyperv1.AWSPlatform,
+						AWS:  &hyperv1.AWSPlatformSpec{RolesRef: roles},
+					},
+				},
+			},
+			iamRoles:    &roles,
+			expectError: true,
+		},
+		{
+			name: "When the platform has no rotatable credentials it should fail",
+			hcluster: &hyperv1.HostedCluster{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "hc"},
+				Spec:       hyperv1.HostedClusterSpec{Platform: hyperv1.PlatformSpec{Type: hyperv1.NonePlatform}},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			dir := t.TempDir()
+			azureCreds := filepath.Join(dir, "azure-creds.json")
+			g.Expect(os.WriteFile(azureCreds, []byte(`{"subscriptionId":"sub","tenantId":"tenant","clientId":"client","clientSecret":"secret"}`), 0600)).To(Succeed())
+			iamJSON := filepath.Join(dir, "iam.json")
+			if tc.iamRoles != nil {
+				raw, err := json.Marshal(awsinfra.CreateIAMOutput{Roles: *tc.iamRoles})
+				g.Expect(err).ToNot(HaveOccurred())
+				g.Expect(os.WriteFile(iamJSON, raw, 0600)).To(Succeed())
+			}
+
+			c := fake.NewClientBuilder().WithScheme(api.Scheme).WithObjects(tc.hcluster).WithStatusSubresource(tc.hcluster).Build()
+			opts := &PlatformCredsOptions{
+				Name:                 "hc",
+				Namespace:            "clusters",
+				AWSIAMJSON:           iamJSON,
+				AzureCredentialsFile: azureCreds,
+				RollbackOnFailure:    tc.rollback,
+				Timeout:              time.Second,
+				PollInterval:         10 * time.Millisecond,
+			}
+
+			err := opts.Run(context.Background(), c, &bytes.Buffer{})
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+
+			hcluster := &hyperv1.HostedCluster{}
+			g.Expect(c.Get(context.Background(), types.NamespacedName{Namespace: "clusters", Name: "hc"}, hcluster)).To(Succeed())
+			if tc.hcluster.Spec.Platform.Type != hyperv1.AzurePlatform {
+				g.Expect(hcluster.Annotations).ToNot(HaveKey(hyperv1.PlatformCredentialsRotationAnnotation))
+				return
+			}
+			g.Expect(hcluster.Annotations).To(HaveKey(hyperv1.PlatformCredentialsRotationAnnotation))
+			g.Expect(hcluster.Annotations[hyperv1.RestartDateAnnotation]).To(Equal(hcluster.Annotations[hyperv1.PlatformCredentialsRotationAnnotation]))
+
+			secrets := &corev1.SecretList{}
+			g.Expect(c.List(context.Background(), secrets)).To(Succeed())
+			if tc.expectRotatedSecret {
+				g.Expect(secrets.Items).To(HaveLen(1))
+				g.Expect(hcluster.Spec.Platform.Azure.Credentials.Name).To(Equal(secrets.Items[0].Name))
+				g.Expect(secrets.Items[0].Data).To(HaveKeyWithValue("AZURE_CLIENT_SECRET", []byte("secret")))
+			} else {
+				g.Expect(hcluster.Spec.Platform.Azure.Credentials.Name).To(Equal(tc.expectedAzureCredentialsRef))
+			}
+		})
+	}
+}
+
+func TestRotationProgress(t *testing.T) {
+	testCases := []struct {
+		name         string
+		condition    *metav1.Condition
+		generation   int64
+		expectDone   bool
+		expectFailed bool
+	}{
+		{
+			name: "When the rotation is not observed yet it should wait",
+		},
+		{
+			name:       "When the condition is for a previous generation it should wait",
+			generation: 2,
+			condition:  &metav1.Condition{Status: metav1.ConditionTrue, ObservedGeneration: 1},
+		},
+		{
+			name:      "When components are restarting it should wait",
+			condition: &metav1.Condition{Status: metav1.ConditionFalse, Reason: hyperv1.RotationInProgressReason},
+		},
+		{
+			name:       "When components use the new credentials it should be done",
+			condition:  &metav1.Condition{Status: metav1.ConditionTrue, Reason: hyperv1.AsExpectedReason},
+			expectDone: true,
+		},
+		{
+			name:         "When components fail to roll out it should fail",
+			condition:    &metav1.Condition{Status: metav1.ConditionFalse, Reason: hyperv1.RotationFailedReason},
+			expectDone:   true,
+			expectFailed: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			hcluster := &hyperv1.HostedCluster{ObjectMeta: metav1.ObjectMeta{Generation: tc.generation}}
+			if tc.condition != nil {
+				tc.condition.Type = string(hyperv1.PlatformCredentialsRotated)
+				hcluster.Status.Conditions = []metav1.Condition{*tc.condition}
+			}
+
+			done, _, err := rotationProgress(hcluster)
+			g.Expect(done).To(Equal(tc.expectDone))
+			if tc.expectFailed {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package rotate
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "rotate",
+		Short:        "Commands for rotating HyperShift credentials",
+		SilenceUsage: true,
+	}
+
+	cmd.AddCommand(NewPlatformCredsCommand())
+	cmd.AddCommand(NewIBMCloudKMSKeyCommand())
+
+	return cmd
+}
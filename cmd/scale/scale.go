@@ -0,0 +1,18 @@
+package scale
+
+import (
+	"github.com/openshift/hypershift/cmd/nodepool"
+	"github.com/spf13/cobra"
+)
+
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "scale",
+		Short:        "Commands for scaling HyperShift resources",
+		SilenceUsage: true,
+	}
+
+	cmd.AddCommand(nodepool.NewScaleCommand())
+
+	return cmd
+}
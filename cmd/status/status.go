@@ -0,0 +1,18 @@
+package status
+
+import (
+	"github.com/openshift/hypershift/cmd/cluster/core"
+	"github.com/spf13/cobra"
+)
+
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "status",
+		Short:        "Commands for reporting the health of resources",
+		SilenceUsage: true,
+	}
+
+	cmd.AddCommand(core.NewStatusCommand())
+
+	return cmd
+}
@@ -0,0 +1,18 @@
+package test
+
+import (
+	"github.com/openshift/hypershift/cmd/cluster/core"
+	"github.com/spf13/cobra"
+)
+
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "test",
+		Short:        "Commands for validating hosted clusters",
+		SilenceUsage: true,
+	}
+
+	cmd.AddCommand(core.NewTestCommand())
+
+	return cmd
+}
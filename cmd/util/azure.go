@@ -23,6 +23,12 @@ type AzureCreds struct {
 
 // SetupAzureCredentials creates the Azure credentials needed to create Azure resources from credentials passed in from the user or from a credentials file
 func SetupAzureCredentials(l logr.Logger, credentials *AzureCreds, credentialsFile string) (string, *azidentity.DefaultAzureCredential, error) {
+	return SetupAzureCredentialsWithOptions(l, credentials, credentialsFile, nil)
+}
+
+// SetupAzureCredentialsWithOptions creates the Azure credentials as SetupAzureCredentials, with options, e.g. the
+// transport the tokens are requested with.
+func SetupAzureCredentialsWithOptions(l logr.Logger, credentials *AzureCreds, credentialsFile string, options *azidentity.DefaultAzureCredentialOptions) (string, *azidentity.DefaultAzureCredential, error) {
 	creds := credentials
 	if creds == nil {
 		var err error
@@ -36,7 +42,7 @@ func SetupAzureCredentials(l logr.Logger, credentials *AzureCreds, credentialsFi
 	_ = os.Setenv("AZURE_TENANT_ID", creds.TenantID)
 	_ = os.Setenv("AZURE_CLIENT_ID", creds.ClientID)
 	_ = os.Setenv("AZURE_CLIENT_SECRET", creds.ClientSecret)
-	azureCreds, err := azidentity.NewDefaultAzureCredential(nil)
+	azureCreds, err := azidentity.NewDefaultAzureCredential(options)
 	if err != nil {
 		return "", nil, fmt.Errorf("failed to create Azure credentials to create image gallery: %w", err)
 	}
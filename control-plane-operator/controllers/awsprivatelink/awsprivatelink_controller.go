@@ -41,6 +41,8 @@ import (
 	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
 	awsutil "github.com/openshift/hypershift/cmd/infra/aws/util"
 	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/manifests"
+	supportawsutil "github.com/openshift/hypershift/support/awsutil"
+	"github.com/openshift/hypershift/support/conditions"
 	"github.com/openshift/hypershift/support/config"
 	"github.com/openshift/hypershift/support/upsert"
 	"github.com/openshift/hypershift/support/util"
@@ -174,7 +176,6 @@ func (r *PrivateServiceObserver) Reconcile(ctx context.Context, req ctrl.Request
 const (
 	finalizer                              = "hypershift.openshift.io/control-plane-operator-finalizer"
 	endpointServiceDeletionRequeueDuration = 5 * time.Second
-	hypershiftLocalZone                    = "hypershift.local"
 	routerDomain                           = "apps"
 )
 
@@ -206,8 +207,8 @@ func (r *AWSEndpointServiceReconciler) SetupWithManager(mgr ctrl.Manager) error
 	awsConfig := aws.NewConfig()
 	r.ec2Client = ec2.New(awsSession, awsConfig)
 	route53Config := aws.NewConfig()
-	// Hardcode region for route53 config
-	route53Config.Region = aws.String("us-east-1")
+	// Route53 is a global service, signed with the global region of the partition of the cluster
+	route53Config.Region = aws.String(supportawsutil.Route53Region(aws.StringValue(awsSession.Config.Region)))
 	r.route53Client = route53.New(awsSession, route53Config)
 
 	return nil
@@ -330,7 +331,7 @@ func (r *AWSEndpointServiceReconciler) Reconcile(ctx context.Context, req ctrl.R
 		meta.SetStatusCondition(&awsEndpointService.Status.Conditions, metav1.Condition{
 			Type:    string(hyperv1.AWSEndpointAvailable),
 			Status:  metav1.ConditionFalse,
-			Reason:  hyperv1.AWSErrorReason,
+			Reason:  conditions.ReasonForError(err, hyperv1.InfraFailureReason),
 			Message: err.Error(),
 		})
 		if !equality.Semantic.DeepEqual(*oldStatus, awsEndpointService.Status) {
@@ -564,7 +565,7 @@ func (r *AWSEndpointServiceReconciler) reconcileAWSEndpointService(ctx context.C
 		return nil
 	}
 
-	zoneName := zoneName(hcp.Name)
+	zoneName := util.InternalDNSDomain(hcp.Name, hcp.Spec.DNS)
 	zoneID, err := lookupZoneID(ctx, route53Client, zoneName)
 	if err != nil {
 		return err
@@ -584,9 +585,9 @@ func (r *AWSEndpointServiceReconciler) reconcileAWSEndpointService(ctx context.C
 	awsEndpointService.Status.DNSNames = fqdns
 	awsEndpointService.Status.DNSZoneID = zoneID
 
-	if isPublic, externalNames := util.IsPublicHCP(hcp), hcpExternalNames(hcp); !isPublic && len(externalNames) > 0 {
-		// only if not public and external names are configured, create services of type ExternalName so external-dns
-		// can create records for them
+	if isPublic, externalNames := util.IsPublicHCP(hcp), hcpExternalNames(hcp); !isPublic && !util.IsInternalDNSHCP(hcp) && len(externalNames) > 0 {
+		// only if not public, publishing its DNS records and external names are configured, create services of type
+		// ExternalName so external-dns can create records for them
 		var errs []error
 		for svcType, externalName := range externalNames {
 			var svc *corev1.Service
@@ -660,12 +661,10 @@ func hcpExternalNames(hcp *hyperv1.HostedControlPlane) map[string]string {
 	return result
 }
 
-func zoneName(hcpName string) string {
-	return fmt.Sprintf("%s.%s", hcpName, hypershiftLocalZone)
-}
-
-func RouterZoneName(hcpName string) string {
-	return routerDomain + "." + zoneName(hcpName)
+// RouterZoneName returns the domain of the routes of the internal DNS domain
+// of a cluster.
+func RouterZoneName(internalDNSDomain string) string {
+	return routerDomain + "." + internalDNSDomain
 }
 
 func recordsForService(awsEndpointService *hyperv1.AWSEndpointService, hcp *hyperv1.HostedControlPlane) []string {
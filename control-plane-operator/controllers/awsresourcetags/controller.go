@@ -0,0 +1,240 @@
+package awsresourcetags
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	awsutil "github.com/openshift/hypershift/cmd/infra/aws/util"
+	"github.com/openshift/hypershift/support/conditions"
+	"github.com/openshift/hypershift/support/util"
+)
+
+const (
+	ControllerName = "aws-resource-tags"
+
+	// resyncPeriod is how often the tags are reconciled while the HostedControlPlane doesn't change, to also tag the
+	// resources which were created without them, or whose tags were changed outside of the cluster.
+	resyncPeriod = 30 * time.Minute
+
+	// tagResourcesBatchSize is the maximum number of resources of a TagResources call.
+	tagResourcesBatchSize = 20
+
+	// maxReportedFailures is the maximum number of resources which couldn't be tagged listed in the condition message.
+	maxReportedFailures = 5
+)
+
+// taggedResourceTypes are the types of the resources owned by the cluster the resource tags are applied to.
+var taggedResourceTypes = []string{
+	"ec2:instance",
+	"ec2:volume",
+	"ec2:security-group",
+	"elasticloadbalancing:loadbalancer",
+}
+
+// taggingAPI is the subset of the AWS Resource Groups Tagging API used to tag the resources of the cluster.
+type taggingAPI interface {
+	GetResourcesPagesWithContext(aws.Context, *resourcegroupstaggingapi.GetResourcesInput, func(*resourcegroupstaggingapi.GetResourcesOutput, bool) bool, ...request.Option) error
+	TagResourcesWithContext(aws.Context, *resourcegroupstaggingapi.TagResourcesInput, ...request.Option) (*resourcegroupstaggingapi.TagResourcesOutput, error)
+}
+
+// AWSResourceTagsReconciler applies the resource tags of the AWS platform of a HostedControlPlane to the EC2 instances,
+// volumes, security groups and load balancers owned by the cluster, so that tags added or changed after the cluster
+// was created are applied to its existing resources too. Tags removed from the spec aren't removed from the resources,
+// since they can't be told apart from tags added outside of the cluster.
+type AWSResourceTagsReconciler struct {
+	client.Client
+	tagging taggingAPI
+}
+
+func (r *AWSResourceTagsReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.Client = mgr.GetClient()
+
+	// AWS_SHARED_CREDENTIALS_FILE and AWS_REGION envvar should be set in operator deployment
+	awsSession := awsutil.NewSession("control-plane-operator", "", "", "", "")
+	r.tagging = resourcegroupstaggingapi.New(awsSession, aws.NewConfig())
+
+	_, err := ctrl.NewControllerManagedBy(mgr).
+		Named(ControllerName).
+		For(&hyperv1.HostedControlPlane{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Build(r)
+	if err != nil {
+		return fmt.Errorf("failed setting up with a controller manager: %w", err)
+	}
+	return nil
+}
+
+func (r *AWSResourceTagsReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	hcp := &hyperv1.HostedControlPlane{}
+	if err := r.Get(ctx, req.NamespacedName, hcp); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("failed to get hostedcontrolplane: %w", err)
+	}
+	if !hcp.DeletionTimestamp.IsZero() || hcp.Spec.Platform.AWS == nil {
+		return ctrl.Result{}, nil
+	}
+	if isPaused, duration := util.IsReconciliationPaused(log, hcp.Spec.PausedUntil); isPaused {
+		log.Info("Reconciliation paused", "pausedUntil", *hcp.Spec.PausedUntil)
+		return ctrl.Result{RequeueAfter: duration}, nil
+	}
+
+	originalHCP := hcp.DeepCopy()
+	condition := resourceTagsCondition(applyResourceTags(ctx, r.tagging, hcp.Spec.InfraID, hcp.Spec.Platform.AWS.ResourceTags))
+	condition.ObservedGeneration = hcp.Generation
+	meta.SetStatusCondition(&hcp.Status.Conditions, condition)
+	if !equality.Semantic.DeepEqual(originalHCP.Status, hcp.Status) {
+		if err := r.Status().Patch(ctx, hcp, client.MergeFrom(originalHCP)); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to update status: %w", err)
+		}
+	}
+	return ctrl.Result{RequeueAfter: resyncPeriod}, nil
+}
+
+// tagResult is the outcome of applying the resource tags to the resources of a cluster.
+type tagResult struct {
+	// resources is the number of resources owned by the cluster.
+	resources int
+	// failed are the resources which couldn't be tagged, by ARN.
+	failed map[string]*resourcegroupstaggingapi.FailureInfo
+}
+
+// applyResourceTags tags the resources owned by the cluster which are missing any of the tags or have another value
+// for it. The resources missing the same tags are tagged together.
+func applyResourceTags(ctx context.Context, tagging taggingAPI, infraID string, tags []hyperv1.AWSResourceTag) (tagResult, error) {
+	result := tagResult{failed: map[string]*resourcegroupstaggingapi.FailureInfo{}}
+	if len(tags) == 0 {
+		return result, nil
+	}
+
+	resourcesByTags := map[string][]*string{}
+	tagsByKey := map[string]map[string]*string{}
+	err := tagging.GetResourcesPagesWithContext(ctx, &resourcegroupstaggingapi.GetResourcesInput{
+		ResourceTypeFilters: aws.StringSlice(taggedResourceTypes),
+		TagFilters: []*resourcegroupstaggingapi.TagFilter{{
+			Key:    aws.String("kubernetes.io/cluster/" + infraID),
+			Values: aws.StringSlice([]string{"owned"}),
+		}},
+	}, func(page *resourcegroupstaggingapi.GetResourcesOutput, _ bool) bool {
+		for _, mapping := range page.ResourceTagMappingList {
+			result.resources++
+			missing := missingTags(mapping.Tags, tags)
+			if len(missing) == 0 {
+				continue
+			}
+			key := tagsKey(missing)
+			tagsByKey[key] = missing
+			resourcesByTags[key] = append(resourcesByTags[key], mapping.ResourceARN)
+		}
+		return true
+	})
+	if err != nil {
+		return result, fmt.Errorf("failed to get the resources of the cluster: %w", err)
+	}
+
+	keys := make([]string, 0, len(resourcesByTags))
+	for key := range resourcesByTags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		arns := resourcesByTags[key]
+		for start := 0; start < len(arns); start += tagResourcesBatchSize {
+			end := start + tagResourcesBatchSize
+			if end > len(arns) {
+				end = len(arns)
+			}
+			output, err := tagging.TagResourcesWithContext(ctx, &resourcegroupstaggingapi.TagResourcesInput{
+				ResourceARNList: arns[start:end],
+				Tags:            tagsByKey[key],
+			})
+			if err != nil {
+				return result, fmt.Errorf("failed to tag the resources of the cluster: %w", err)
+			}
+			for arn, failure := range output.FailedResourcesMap {
+				result.failed[arn] = failure
+			}
+		}
+	}
+	return result, nil
+}
+
+// missingTags returns the tags the resource doesn't have, or has another value for.
+func missingTags(current []*resourcegroupstaggingapi.Tag, desired []hyperv1.AWSResourceTag) map[string]*string {
+	values := map[string]string{}
+	for _, tag := range current {
+		values[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+	}
+	missing := map[string]*string{}
+	for _, tag := range desired {
+		if value, exists := values[tag.Key]; !exists || value != tag.Value {
+			missing[tag.Key] = aws.String(tag.Value)
+		}
+	}
+	return missing
+}
+
+// tagsKey returns a key identifying the set of tags.
+func tagsKey(tags map[string]*string) string {
+	pairs := make([]string, 0, len(tags))
+	for key, value := range tags {
+		pairs = append(pairs, key+"="+aws.StringValue(value))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, "\x00")
+}
+
+// resourceTagsCondition returns the AWSResourceTagsApplied condition reporting the result of applying the tags. The
+// resources which couldn't be tagged are listed in its message, along with the error returned for them.
+func resourceTagsCondition(result tagResult, err error) metav1.Condition {
+	condition := metav1.Condition{
+		Type: string(hyperv1.AWSResourceTagsApplied),
+	}
+	switch {
+	case err != nil:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = conditions.ReasonForError(err, hyperv1.InfraFailureReason)
+		condition.Message = err.Error()
+	case len(result.failed) > 0:
+		arns := make([]string, 0, len(result.failed))
+		for arn := range result.failed {
+			arns = append(arns, arn)
+		}
+		sort.Strings(arns)
+		var failures []string
+		for _, arn := range arns {
+			if len(failures) == maxReportedFailures {
+				failures = append(failures, fmt.Sprintf("and %d more", len(arns)-maxReportedFailures))
+				break
+			}
+			failure := result.failed[arn]
+			failures = append(failures, fmt.Sprintf("%s: %s: %s", arn, aws.StringValue(failure.ErrorCode), aws.StringValue(failure.ErrorMessage)))
+		}
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = conditions.ReasonForProviderErrorCode(aws.StringValue(result.failed[arns[0]].ErrorCode))
+		condition.Message = fmt.Sprintf("Failed to tag %d of the %d resources of the cluster: %s", len(arns), result.resources, strings.Join(failures, "; "))
+	default:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = hyperv1.AsExpectedReason
+		condition.Message = fmt.Sprintf("The resource tags are applied to the %d resources of the cluster", result.resources)
+	}
+	return condition
+}
@@ -0,0 +1,163 @@
+package awsresourcetags
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+)
+
+type fakeTagging struct {
+	resources []*resourcegroupstaggingapi.ResourceTagMapping
+	getErr    error
+	failed    map[string]*resourcegroupstaggingapi.FailureInfo
+	tagged    []*resourcegroupstaggingapi.TagResourcesInput
+}
+
+func (f *fakeTagging) GetResourcesPagesWithContext(_ aws.Context, _ *resourcegroupstaggingapi.GetResourcesInput, fn func(*resourcegroupstaggingapi.GetResourcesOutput, bool) bool, _ ...request.Option) error {
+	if f.getErr != nil {
+		return f.getErr
+	}
+	fn(&resourcegroupstaggingapi.GetResourcesOutput{ResourceTagMappingList: f.resources}, true)
+	return nil
+}
+
+func (f *fakeTagging) TagResourcesWithContext(_ aws.Context, input *resourcegroupstaggingapi.TagResourcesInput, _ ...request.Option) (*resourcegroupstaggingapi.TagResourcesOutput, error) {
+	f.tagged = append(f.tagged, input)
+	output := &resourcegroupstaggingapi.TagResourcesOutput{FailedResourcesMap: map[string]*resourcegroupstaggingapi.FailureInfo{}}
+	for _, arn := range input.ResourceARNList {
+		if failure, failed := f.failed[aws.StringValue(arn)]; failed {
+			output.FailedResourcesMap[aws.StringValue(arn)] = failure
+		}
+	}
+	return output, nil
+}
+
+func resource(arn string, tags map[string]string) *resourcegroupstaggingapi.ResourceTagMapping {
+	mapping := &resourcegroupstaggingapi.ResourceTagMapping{ResourceARN: aws.String(arn)}
+	for key, value := range tags {
+		mapping.Tags = append(mapping.Tags, &resourcegroupstaggingapi.Tag{Key: aws.String(key), Value: aws.String(value)})
+	}
+	return mapping
+}
+
+func TestApplyResourceTags(t *testing.T) {
+	desired := []hyperv1.AWSResourceTag{{Key: "team", Value: "payments"}, {Key: "env", Value: "prod"}}
+
+	t.Run("When resources are missing tags it should only apply the missing ones, grouped by resources missing the same tags", func(t *testing.T) {
+		g := NewWithT(t)
+		tagging := &fakeTagging{resources: []*resourcegroupstaggingapi.ResourceTagMapping{
+			resource("arn:instance-1", map[string]string{"team": "payments", "env": "prod"}),
+			resource("arn:instance-2", map[string]string{"team": "payments"}),
+			resource("arn:volume-1", map[string]string{"env": "prod", "team": "billing"}),
+			resource("arn:volume-2", nil),
+			resource("arn:sg-1", nil),
+		}}
+
+		result, err := applyResourceTags(context.Background(), tagging, "infra", desired)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result.resources).To(Equal(5))
+		g.Expect(result.failed).To(BeEmpty())
+		g.Expect(tagging.tagged).To(HaveLen(3))
+
+		applied := map[string]map[string]string{}
+		for _, input := range tagging.tagged {
+			for _, arn := range input.ResourceARNList {
+				applied[aws.StringValue(arn)] = aws.StringValueMap(input.Tags)
+			}
+		}
+		g.Expect(applied).To(Equal(map[string]map[string]string{
+			"arn:instance-2": {"env": "prod"},
+			"arn:volume-1":   {"team": "payments"},
+			"arn:volume-2":   {"team": "payments", "env": "prod"},
+			"arn:sg-1":       {"team": "payments", "env": "prod"},
+		}))
+	})
+
+	t.Run("When more resources are missing the same tags than a call can tag it should tag them in batches", func(t *testing.T) {
+		g := NewWithT(t)
+		tagging := &fakeTagging{}
+		for i := 0; i < 45; i++ {
+			tagging.resources = append(tagging.resources, resource(fmt.Sprintf("arn:instance-%d", i), nil))
+		}
+
+		_, err := applyResourceTags(context.Background(), tagging, "infra", desired)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(tagging.tagged).To(HaveLen(3))
+		g.Expect(tagging.tagged[0].ResourceARNList).To(HaveLen(tagResourcesBatchSize))
+		g.Expect(tagging.tagged[2].ResourceARNList).To(HaveLen(5))
+	})
+
+	t.Run("When there are no resource tags it should not look up the resources", func(t *testing.T) {
+		g := NewWithT(t)
+		tagging := &fakeTagging{getErr: fmt.Errorf("unexpected call")}
+		_, err := applyResourceTags(context.Background(), tagging, "infra", nil)
+		g.Expect(err).ToNot(HaveOccurred())
+	})
+}
+
+func TestResourceTagsCondition(t *testing.T) {
+	testCases := []struct {
+		name            string
+		result          tagResult
+		err             error
+		expectedStatus  metav1.ConditionStatus
+		expectedReason  string
+		expectedMessage string
+	}{
+		{
+			name:            "When all the resources are tagged it should be true",
+			result:          tagResult{resources: 4},
+			expectedStatus:  metav1.ConditionTrue,
+			expectedReason:  hyperv1.AsExpectedReason,
+			expectedMessage: "The resource tags are applied to the 4 resources of the cluster",
+		},
+		{
+			name: "When some resources couldn't be tagged it should be false and list them",
+			result: tagResult{resources: 4, failed: map[string]*resourcegroupstaggingapi.FailureInfo{
+				"arn:volume-1": {ErrorCode: aws.String("InternalServiceException"), ErrorMessage: aws.String("try again")},
+				"arn:lb-1":     {ErrorCode: aws.String("InternalServiceException"), ErrorMessage: aws.String("try again")},
+			}},
+			expectedStatus:  metav1.ConditionFalse,
+			expectedReason:  hyperv1.InfraFailureReason,
+			expectedMessage: "Failed to tag 2 of the 4 resources of the cluster: arn:lb-1: InternalServiceException: try again; arn:volume-1: InternalServiceException: try again",
+		},
+		{
+			name:            "When the resources can't be looked up it should be false with the reason of the error",
+			err:             fmt.Errorf("failed to get the resources of the cluster: %w", awserr.New("AccessDeniedException", "not authorized", nil)),
+			expectedStatus:  metav1.ConditionFalse,
+			expectedReason:  hyperv1.InvalidCredentialsReason,
+			expectedMessage: "failed to get the resources of the cluster: AccessDeniedException: not authorized",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			condition := resourceTagsCondition(tc.result, tc.err)
+			g.Expect(condition.Type).To(Equal(string(hyperv1.AWSResourceTagsApplied)))
+			g.Expect(condition.Status).To(Equal(tc.expectedStatus))
+			g.Expect(condition.Reason).To(Equal(tc.expectedReason))
+			g.Expect(condition.Message).To(Equal(tc.expectedMessage))
+		})
+	}
+
+	t.Run("When many resources couldn't be tagged it should only list the first ones", func(t *testing.T) {
+		g := NewWithT(t)
+		result := tagResult{resources: 10, failed: map[string]*resourcegroupstaggingapi.FailureInfo{}}
+		for i := 0; i < 8; i++ {
+			result.failed[fmt.Sprintf("arn:instance-%d", i)] = &resourcegroupstaggingapi.FailureInfo{ErrorCode: aws.String("InternalServiceException")}
+		}
+		condition := resourceTagsCondition(result, nil)
+		g.Expect(condition.Message).To(HavePrefix("Failed to tag 8 of the 10 resources of the cluster"))
+		g.Expect(condition.Message).To(HaveSuffix("and 3 more"))
+	})
+}
@@ -11,12 +11,14 @@ import (
 )
 
 type AWSParams struct {
-	Zone             string                  `json:"zone"`
-	VPC              string                  `json:"vpc"`
-	ClusterID        string                  `json:"clusterID"`
-	SubnetID         string                  `json:"subnetID"`
-	OwnerRef         *metav1.OwnerReference  `json:"ownerRef"`
-	DeploymentConfig config.DeploymentConfig `json:"deploymentConfig"`
+	Zone             string                       `json:"zone"`
+	VPC              string                       `json:"vpc"`
+	ClusterID        string                       `json:"clusterID"`
+	SubnetID         string                       `json:"subnetID"`
+	Region           string                       `json:"region"`
+	ServiceEndpoints []hyperv1.AWSServiceEndpoint `json:"serviceEndpoints"`
+	OwnerRef         *metav1.OwnerReference       `json:"ownerRef"`
+	DeploymentConfig config.DeploymentConfig      `json:"deploymentConfig"`
 }
 
 func NewAWSParams(hcp *hyperv1.HostedControlPlane) *AWSParams {
@@ -24,8 +26,10 @@ func NewAWSParams(hcp *hyperv1.HostedControlPlane) *AWSParams {
 		return nil
 	}
 	p := &AWSParams{
-		ClusterID: hcp.Spec.InfraID,
-		VPC:       hcp.Spec.Platform.AWS.CloudProviderConfig.VPC,
+		ClusterID:        hcp.Spec.InfraID,
+		VPC:              hcp.Spec.Platform.AWS.CloudProviderConfig.VPC,
+		Region:           hcp.Spec.Platform.AWS.Region,
+		ServiceEndpoints: hcp.Spec.Platform.AWS.ServiceEndpoints,
 	}
 	if hcp.Spec.Platform.AWS.CloudProviderConfig != nil {
 		p.Zone = hcp.Spec.Platform.AWS.CloudProviderConfig.Zone
@@ -21,12 +21,26 @@ VPC = %s
 KubernetesClusterID = %s
 SubnetID = %s`
 
+// serviceOverrideTemplate overrides the endpoint of an AWS service, e.g. to reach the services of the GovCloud and
+// China partitions through custom or FIPS endpoints.
+const serviceOverrideTemplate = `
+
+[ServiceOverride "%d"]
+Service = %s
+Region = %s
+URL = %s
+SigningRegion = %s`
+
 func (p *AWSParams) ReconcileCloudConfig(cm *corev1.ConfigMap) error {
 	util.EnsureOwnerRef(cm, p.OwnerRef)
 	if cm.Data == nil {
 		cm.Data = map[string]string{}
 	}
-	cm.Data[ProviderConfigKey] = fmt.Sprintf(configTemplate, p.Zone, p.VPC, p.ClusterID, p.SubnetID)
+	config := fmt.Sprintf(configTemplate, p.Zone, p.VPC, p.ClusterID, p.SubnetID)
+	for i, endpoint := range p.ServiceEndpoints {
+		config += fmt.Sprintf(serviceOverrideTemplate, i, endpoint.Name, p.Region, endpoint.URL, p.Region)
+	}
+	cm.Data[ProviderConfigKey] = config
 	return nil
 }
 
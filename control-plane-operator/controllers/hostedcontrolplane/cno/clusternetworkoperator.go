@@ -62,6 +62,7 @@ type Params struct {
 	ReleaseVersion          string
 	AvailabilityProberImage string
 	HostedClusterName       string
+	InternalDNSDomain       string
 	CAConfigMap             string
 	CAConfigMapKey          string
 	APIServerAddress        string
@@ -109,6 +110,7 @@ func NewParams(hcp *hyperv1.HostedControlPlane, version string, releaseImageProv
 		IsPrivate:               util.IsPrivateHCP(hcp),
 		ExposedThroughHCPRouter: isOVNSBDBExposedThroughHCPRouter(hcp),
 		HostedClusterName:       hcp.Name,
+		InternalDNSDomain:       util.InternalDNSDomain(hcp.Name, hcp.Spec.DNS),
 		TokenAudience:           hcp.Spec.IssuerURL,
 		SbDbPubStrategy:         util.ServicePublishingStrategyByTypeForHCP(hcp, hyperv1.OVNSbDb),
 		DefaultIngressDomain:    defaultIngressDomain,
@@ -128,7 +130,7 @@ func NewParams(hcp *hyperv1.HostedControlPlane, version string, releaseImageProv
 	p.DeploymentConfig.SetDefaults(hcp, nil, utilpointer.Int(1))
 	p.DeploymentConfig.SetDefaultSecurityContext = setDefaultSecurityContext
 	if util.IsPrivateHCP(hcp) {
-		p.APIServerAddress = fmt.Sprintf("api.%s.hypershift.local", hcp.Name)
+		p.APIServerAddress = util.InternalAPIHostname(hcp.Name, hcp.Spec.DNS)
 		p.APIServerPort = 443
 	} else {
 		p.APIServerAddress = hcp.Status.ControlPlaneEndpoint.Host
@@ -354,7 +356,7 @@ func ReconcileDeployment(dep *appsv1.Deployment, params Params, platformType hyp
 
 	sbDbRouteHost := util.ShortenRouteHostnameIfNeeded("ovnkube-sbdb", dep.Namespace, params.DefaultIngressDomain)
 	if params.IsPrivate {
-		sbDbRouteHost = "ovnkube-sbdb." + awsprivatelink.RouterZoneName(params.HostedClusterName)
+		sbDbRouteHost = "ovnkube-sbdb." + awsprivatelink.RouterZoneName(params.InternalDNSDomain)
 	} else if params.SbDbPubStrategy != nil && params.SbDbPubStrategy.Route != nil && params.SbDbPubStrategy.Route.Hostname != "" {
 		sbDbRouteHost = params.SbDbPubStrategy.Route.Hostname
 	}
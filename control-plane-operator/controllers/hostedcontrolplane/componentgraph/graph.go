@@ -0,0 +1,231 @@
+package componentgraph
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"time"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+// Component is a part of the hosted control plane which is reconciled as a unit.
+type Component struct {
+	// Name identifies the component in the graph, in its dependencies and in metrics.
+	Name string
+	// DependsOn are the names of the components which must be reconciled and ready before this component is reconciled.
+	DependsOn []string
+	// Reconcile reconciles the component and returns whether it is ready. The components depending on a component
+	// which is not ready are not reconciled.
+	Reconcile func(ctx context.Context) (ready bool, err error)
+}
+
+// Status is the outcome of reconciling a component.
+type Status string
+
+const (
+	// StatusReady is the status of a component which was reconciled and is ready.
+	StatusReady Status = "Ready"
+	// StatusProgressing is the status of a component which was reconciled but is not ready yet.
+	StatusProgressing Status = "Progressing"
+	// StatusFailed is the status of a component which failed to reconcile.
+	StatusFailed Status = "Failed"
+	// StatusBlocked is the status of a component which was not reconciled because some of its dependencies are not ready.
+	StatusBlocked Status = "Blocked"
+)
+
+// Result is the result of reconciling a component.
+type Result struct {
+	Name   string
+	Status Status
+	Err    error
+	// Duration is the time taken to reconcile the component. It is zero for blocked components.
+	Duration time.Duration
+	// BlockedBy are the dependencies which were not ready, for blocked components.
+	BlockedBy []string
+}
+
+// ReadyOnSuccess adapts a reconcile function for a component which is ready as soon as it is reconciled without error.
+func ReadyOnSuccess(reconcile func(ctx context.Context) error) func(ctx context.Context) (bool, error) {
+	return func(ctx context.Context) (bool, error) {
+		if err := reconcile(ctx); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+}
+
+// Graph is a set of components and their dependencies. Components whose dependencies are all ready are reconciled
+// in parallel.
+type Graph struct {
+	components []Component
+	index      map[string]int
+	dependents [][]int
+}
+
+// New returns the graph of the given components. It fails if a component is defined more than once, if a
+// component depends on an unknown component or if the dependencies have a cycle.
+func New(components ...Component) (*Graph, error) {
+	g := &Graph{
+		components: components,
+		index:      make(map[string]int, len(components)),
+		dependents: make([][]int, len(components)),
+	}
+	for i, c := range components {
+		if _, exists := g.index[c.Name]; exists {
+			return nil, fmt.Errorf("component %s is defined more than once", c.Name)
+		}
+		g.index[c.Name] = i
+	}
+	for i, c := range components {
+		for _, dependency := range c.DependsOn {
+			j, exists := g.index[dependency]
+			if !exists {
+				return nil, fmt.Errorf("component %s depends on unknown component %s", c.Name, dependency)
+			}
+			g.dependents[j] = append(g.dependents[j], i)
+		}
+	}
+	if cycle := g.findCycle(); len(cycle) > 0 {
+		return nil, fmt.Errorf("component dependencies have a cycle: %s", strings.Join(cycle, " -> "))
+	}
+	return g, nil
+}
+
+// findCycle returns the names of the components forming a dependency cycle, if any.
+func (g *Graph) findCycle() []string {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make([]int, len(g.components))
+	var path []string
+	var visit func(i int) []string
+	visit = func(i int) []string {
+		state[i] = visiting
+		path = append(path, g.components[i].Name)
+		for _, dependency := range g.components[i].DependsOn {
+			j := g.index[dependency]
+			switch state[j] {
+			case visiting:
+				for k, name := range path {
+					if name == dependency {
+						return append(path[k:], dependency)
+					}
+				}
+			case unvisited:
+				if cycle := visit(j); len(cycle) > 0 {
+					return cycle
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[i] = visited
+		return nil
+	}
+	for i := range g.components {
+		if state[i] == unvisited {
+			if cycle := visit(i); len(cycle) > 0 {
+				return cycle
+			}
+		}
+	}
+	return nil
+}
+
+// Run reconciles the components of the graph, at most maxParallel at a time, and returns the result of every
+// component in the order they were defined. The returned error aggregates the errors of the failed components.
+func (g *Graph) Run(ctx context.Context, maxParallel int) ([]Result, error) {
+	if maxParallel < 1 {
+		maxParallel = 1
+	}
+	results := make([]Result, len(g.components))
+	waiting := make([]int, len(g.components))
+	for i, c := range g.components {
+		results[i].Name = c.Name
+		waiting[i] = len(c.DependsOn)
+	}
+
+	done := make(chan int)
+	semaphore := make(chan struct{}, maxParallel)
+	running := 0
+	start := func(i int) {
+		running++
+		go func() {
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+			results[i] = g.reconcile(ctx, i)
+			done <- i
+		}()
+	}
+
+	// complete records that a component is finished and starts or blocks the dependents which aren't
+	// waiting for any other dependency.
+	var complete func(i int)
+	complete = func(i int) {
+		observe(results[i])
+		for _, dependent := range g.dependents[i] {
+			if results[i].Status != StatusReady {
+				results[dependent].BlockedBy = append(results[dependent].BlockedBy, results[i].Name)
+			}
+			waiting[dependent]--
+			if waiting[dependent] > 0 {
+				continue
+			}
+			if len(results[dependent].BlockedBy) > 0 {
+				sort.Strings(results[dependent].BlockedBy)
+				results[dependent].Status = StatusBlocked
+				complete(dependent)
+				continue
+			}
+			start(dependent)
+		}
+	}
+
+	for i := range g.components {
+		if waiting[i] == 0 {
+			start(i)
+		}
+	}
+	for running > 0 {
+		i := <-done
+		running--
+		complete(i)
+	}
+
+	var errs []error
+	for _, result := range results {
+		if result.Err != nil {
+			errs = append(errs, result.Err)
+		}
+	}
+	return results, utilerrors.NewAggregate(errs)
+}
+
+func (g *Graph) reconcile(ctx context.Context, i int) (result Result) {
+	component := g.components[i]
+	result.Name = component.Name
+	startTime := time.Now()
+	defer func() {
+		result.Duration = time.Since(startTime)
+		if r := recover(); r != nil {
+			result.Status = StatusFailed
+			result.Err = fmt.Errorf("panic reconciling component %s: %v\n%s", component.Name, r, debug.Stack())
+		}
+	}()
+
+	ready, err := component.Reconcile(ctx)
+	switch {
+	case err != nil:
+		result.Status = StatusFailed
+		result.Err = err
+	case ready:
+		result.Status = StatusReady
+	default:
+		result.Status = StatusProgressing
+	}
+	return result
+}
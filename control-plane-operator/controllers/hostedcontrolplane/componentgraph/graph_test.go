@@ -0,0 +1,152 @@
+package componentgraph
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestNew(t *testing.T) {
+	noop := ReadyOnSuccess(func(context.Context) error { return nil })
+	testCases := []struct {
+		name          string
+		components    []Component
+		expectedError string
+	}{
+		{
+			name: "When the dependencies are valid it should succeed",
+			components: []Component{
+				{Name: "etcd", Reconcile: noop},
+				{Name: "kube-apiserver", DependsOn: []string{"etcd"}, Reconcile: noop},
+			},
+		},
+		{
+			name: "When a component is defined twice it should fail",
+			components: []Component{
+				{Name: "etcd", Reconcile: noop},
+				{Name: "etcd", Reconcile: noop},
+			},
+			expectedError: "component etcd is defined more than once",
+		},
+		{
+			name: "When a component depends on an unknown component it should fail",
+			components: []Component{
+				{Name: "kube-apiserver", DependsOn: []string{"etcd"}, Reconcile: noop},
+			},
+			expectedError: "component kube-apiserver depends on unknown component etcd",
+		},
+		{
+			name: "When the dependencies have a cycle it should fail",
+			components: []Component{
+				{Name: "a", DependsOn: []string{"c"}, Reconcile: noop},
+				{Name: "b", DependsOn: []string{"a"}, Reconcile: noop},
+				{Name: "c", DependsOn: []string{"b"}, Reconcile: noop},
+			},
+			expectedError: "component dependencies have a cycle: a -> c -> b -> a",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			_, err := New(tc.components...)
+			if tc.expectedError != "" {
+				g.Expect(err).To(MatchError(tc.expectedError))
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	g := NewWithT(t)
+
+	var lock sync.Mutex
+	var order []string
+	reconciled := func(name string, ready bool, err error) func(context.Context) (bool, error) {
+		return func(context.Context) (bool, error) {
+			lock.Lock()
+			defer lock.Unlock()
+			order = append(order, name)
+			return ready, err
+		}
+	}
+
+	graph, err := New(
+		Component{Name: "etcd", Reconcile: reconciled("etcd", true, nil)},
+		Component{Name: "kube-apiserver", DependsOn: []string{"etcd"}, Reconcile: reconciled("kube-apiserver", true, nil)},
+		Component{Name: "kube-scheduler", DependsOn: []string{"kube-apiserver"}, Reconcile: reconciled("kube-scheduler", false, nil)},
+		Component{Name: "kube-controller-manager", DependsOn: []string{"kube-apiserver"}, Reconcile: reconciled("kube-controller-manager", false, fmt.Errorf("failed"))},
+		Component{Name: "operators", DependsOn: []string{"kube-scheduler", "kube-controller-manager"}, Reconcile: reconciled("operators", true, nil)},
+		Component{Name: "operator-config", DependsOn: []string{"operators"}, Reconcile: reconciled("operator-config", true, nil)},
+	)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	results, err := graph.Run(context.Background(), 2)
+	g.Expect(err).To(MatchError("failed"))
+	g.Expect(order).To(HaveLen(4))
+	g.Expect(order[:2]).To(Equal([]string{"etcd", "kube-apiserver"}))
+	g.Expect(order[2:]).To(ConsistOf("kube-scheduler", "kube-controller-manager"))
+
+	statuses := map[string]Status{}
+	for _, result := range results {
+		statuses[result.Name] = result.Status
+	}
+	g.Expect(statuses).To(Equal(map[string]Status{
+		"etcd":                    StatusReady,
+		"kube-apiserver":          StatusReady,
+		"kube-scheduler":          StatusProgressing,
+		"kube-controller-manager": StatusFailed,
+		"operators":               StatusBlocked,
+		"operator-config":         StatusBlocked,
+	}))
+	g.Expect(results[4].BlockedBy).To(Equal([]string{"kube-controller-manager", "kube-scheduler"}))
+	g.Expect(results[5].BlockedBy).To(Equal([]string{"operators"}))
+}
+
+func TestRunParallelism(t *testing.T) {
+	g := NewWithT(t)
+
+	var running, maxRunning int32
+	reconcile := func(context.Context) (bool, error) {
+		current := atomic.AddInt32(&running, 1)
+		for {
+			observed := atomic.LoadInt32(&maxRunning)
+			if current <= observed || atomic.CompareAndSwapInt32(&maxRunning, observed, current) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		return true, nil
+	}
+	var components []Component
+	for i := 0; i < 8; i++ {
+		components = append(components, Component{Name: fmt.Sprintf("component-%d", i), Reconcile: reconcile})
+	}
+	graph, err := New(components...)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	_, err = graph.Run(context.Background(), 3)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(atomic.LoadInt32(&maxRunning)).To(BeNumerically(">", 1))
+	g.Expect(atomic.LoadInt32(&maxRunning)).To(BeNumerically("<=", 3))
+}
+
+func TestRunRecoversPanics(t *testing.T) {
+	g := NewWithT(t)
+
+	graph, err := New(Component{Name: "broken", Reconcile: func(context.Context) (bool, error) {
+		panic("broken")
+	}})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	results, err := graph.Run(context.Background(), 1)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(results[0].Status).To(Equal(StatusFailed))
+}
@@ -0,0 +1,43 @@
+package componentgraph
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	ComponentReconcileDurationMetricName = "hypershift_control_plane_component_reconcile_duration_seconds"
+	ComponentStatusMetricName            = "hypershift_control_plane_component_status"
+)
+
+var (
+	componentReconcileDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    ComponentReconcileDurationMetricName,
+		Help:    "Time taken to reconcile a hosted control plane component, by component and resulting status.",
+		Buckets: []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+	}, []string{"component", "status"})
+
+	componentStatus = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: ComponentStatusMetricName,
+		Help: "Status of a hosted control plane component after its last reconciliation. The value is 1 for the current status of the component and 0 for the others.",
+	}, []string{"component", "status"})
+
+	statuses = []Status{StatusReady, StatusProgressing, StatusFailed, StatusBlocked}
+)
+
+func init() {
+	metrics.Registry.MustRegister(componentReconcileDuration, componentStatus)
+}
+
+func observe(result Result) {
+	if result.Status != StatusBlocked {
+		componentReconcileDuration.WithLabelValues(result.Name, string(result.Status)).Observe(result.Duration.Seconds())
+	}
+	for _, status := range statuses {
+		value := 0.0
+		if status == result.Status {
+			value = 1
+		}
+		componentStatus.WithLabelValues(result.Name, string(status)).Set(value)
+	}
+}
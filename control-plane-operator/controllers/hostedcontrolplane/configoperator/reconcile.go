@@ -133,7 +133,6 @@ func ReconcileRole(role *rbacv1.Role, ownerRef config.OwnerRef, platform hyperv1
 		{
 			APIGroups: []string{corev1.SchemeGroupVersion.Group},
 			Resources: []string{
-				"secrets",
 				"services",
 			},
 			Verbs: []string{
@@ -142,6 +141,18 @@ func ReconcileRole(role *rbacv1.Role, ownerRef config.OwnerRef, platform hyperv1
 				"watch",
 			},
 		},
+		{
+			APIGroups: []string{corev1.SchemeGroupVersion.Group},
+			Resources: []string{
+				"secrets",
+			},
+			Verbs: []string{
+				"get",
+				"list",
+				"watch",
+				"patch", // Needed to record the propagation of NodePool SSH keys
+			},
+		},
 		{
 			APIGroups: []string{capiv1.GroupVersion.Group},
 			Resources: []string{
@@ -328,6 +339,7 @@ func ReconcileDeployment(deployment *appsv1.Deployment, image, hcpName, openShif
 		// injects the kubevirt credentials secret volume, volume mount path, and appends cli arg.
 		util.DeploymentAddKubevirtInfraCredentials(deployment)
 	}
+	config.DeploymentAddPprofBindAddress(hcp.Annotations, deployment)
 
 	deploymentConfig.ApplyTo(deployment)
 	util.AvailabilityProber(kas.InClusterKASReadyURL(hcp.Spec.Platform.Type), availabilityProberImage, &deployment.Spec.Template.Spec, func(o *util.AvailabilityProberOpts) {
@@ -0,0 +1,128 @@
+package hostedcontrolplane
+
+import (
+	"context"
+	"fmt"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/manifests"
+	"github.com/prometheus/client_golang/prometheus"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const CrossZoneTrafficRatioMetricName = "hypershift_control_plane_cross_zone_traffic_ratio"
+
+var crossZoneTrafficRatio = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: CrossZoneTrafficRatioMetricName,
+	Help: "Estimated fraction of the traffic from a control plane component to an internal control plane service which crosses availability zones, by client component and service. " +
+		"It is estimated from the zones of the client and service endpoints and the topology hints of the service endpoints.",
+}, []string{"client", "service"})
+
+func init() {
+	metrics.Registry.MustRegister(crossZoneTrafficRatio)
+}
+
+// crossZoneRoute is internal control plane traffic from the pods of the client service to the endpoints of service.
+type crossZoneRoute struct {
+	client  string
+	service string
+}
+
+// crossZoneRoutes are the internal control plane routes with topology aware routing in highly available control planes.
+var crossZoneRoutes = []crossZoneRoute{
+	{client: manifests.KubeAPIServerServiceName, service: manifests.EtcdClientZonalService("").Name},
+	{client: manifests.OauthServerService("").Name, service: manifests.KubeAPIServerServiceName},
+	{client: manifests.OpenshiftAPIServerService("").Name, service: manifests.KonnectivityServerLocalService("").Name},
+	{client: manifests.OauthAPIServerService("").Name, service: manifests.KonnectivityServerLocalService("").Name},
+	{client: manifests.OauthServerService("").Name, service: manifests.KonnectivityServerLocalService("").Name},
+}
+
+// reportCrossZoneTraffic reports the estimated fraction of the traffic of each internal control plane route which
+// crosses availability zones. Routes whose clients or endpoints aren't known, and every route of single replica
+// control planes, aren't reported.
+func (r *HostedControlPlaneReconciler) reportCrossZoneTraffic(ctx context.Context, hcp *hyperv1.HostedControlPlane) error {
+	if hcp.Spec.ControllerAvailabilityPolicy != hyperv1.HighlyAvailable {
+		for _, route := range crossZoneRoutes {
+			crossZoneTrafficRatio.DeleteLabelValues(route.client, route.service)
+		}
+		return nil
+	}
+
+	endpointSlices := &discoveryv1.EndpointSliceList{}
+	if err := r.List(ctx, endpointSlices, client.InNamespace(hcp.Namespace)); err != nil {
+		return fmt.Errorf("failed to list endpoint slices: %w", err)
+	}
+	endpoints := map[string][]discoveryv1.Endpoint{}
+	for _, endpointSlice := range endpointSlices.Items {
+		service := endpointSlice.Labels[discoveryv1.LabelServiceName]
+		endpoints[service] = append(endpoints[service], endpointSlice.Endpoints...)
+	}
+
+	for _, route := range crossZoneRoutes {
+		var clientZones []string
+		for _, endpoint := range endpoints[route.client] {
+			if endpoint.Zone != nil {
+				clientZones = append(clientZones, *endpoint.Zone)
+			}
+		}
+		if ratio, ok := estimateCrossZoneTrafficRatio(clientZones, endpoints[route.service]); ok {
+			crossZoneTrafficRatio.WithLabelValues(route.client, route.service).Set(ratio)
+		} else {
+			crossZoneTrafficRatio.DeleteLabelValues(route.client, route.service)
+		}
+	}
+	return nil
+}
+
+// estimateCrossZoneTrafficRatio estimates the fraction of the traffic from clients in clientZones to the endpoints of a
+// service which crosses zones, assuming every client sends as much traffic, spread evenly across the ready endpoints
+// kube-proxy routes it to. Like kube-proxy, topology hints are only used when every ready endpoint has some, and a
+// client falls back to every ready endpoint when none is hinted for its zone. It returns false when there are no
+// clients or ready endpoints in a known zone.
+func estimateCrossZoneTrafficRatio(clientZones []string, endpoints []discoveryv1.Endpoint) (float64, bool) {
+	var ready []discoveryv1.Endpoint
+	useHints := true
+	for _, endpoint := range endpoints {
+		if endpoint.Conditions.Ready != nil && !*endpoint.Conditions.Ready {
+			continue
+		}
+		if endpoint.Zone == nil {
+			continue
+		}
+		ready = append(ready, endpoint)
+		if endpoint.Hints == nil || len(endpoint.Hints.ForZones) == 0 {
+			useHints = false
+		}
+	}
+	if len(clientZones) == 0 || len(ready) == 0 {
+		return 0, false
+	}
+
+	var total float64
+	for _, zone := range clientZones {
+		var routed []discoveryv1.Endpoint
+		if useHints {
+			for _, endpoint := range ready {
+				for _, hint := range endpoint.Hints.ForZones {
+					if hint.Name == zone {
+						routed = append(routed, endpoint)
+						break
+					}
+				}
+			}
+		}
+		if len(routed) == 0 {
+			routed = ready
+		}
+		crossZone := 0
+		for _, endpoint := range routed {
+			if *endpoint.Zone != zone {
+				crossZone++
+			}
+		}
+		total += float64(crossZone) / float64(len(routed))
+	}
+	return total / float64(len(clientZones)), true
+}
@@ -0,0 +1,139 @@
+package hostedcontrolplane
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/support/api"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func zonalEndpoint(zone string, hintedZones ...string) discoveryv1.Endpoint {
+	endpoint := discoveryv1.Endpoint{Zone: ptr.To(zone)}
+	if len(hintedZones) > 0 {
+		endpoint.Hints = &discoveryv1.EndpointHints{}
+		for _, hintedZone := range hintedZones {
+			endpoint.Hints.ForZones = append(endpoint.Hints.ForZones, discoveryv1.ForZone{Name: hintedZone})
+		}
+	}
+	return endpoint
+}
+
+func TestEstimateCrossZoneTrafficRatio(t *testing.T) {
+	zones := []string{"us-east-1a", "us-east-1b", "us-east-1c"}
+	testCases := []struct {
+		name          string
+		clientZones   []string
+		endpoints     []discoveryv1.Endpoint
+		expectedRatio float64
+		expectedOK    bool
+	}{
+		{
+			name:        "When the endpoints have no hints it should spread the traffic across every zone",
+			clientZones: zones,
+			endpoints: []discoveryv1.Endpoint{
+				zonalEndpoint("us-east-1a"), zonalEndpoint("us-east-1b"), zonalEndpoint("us-east-1c"),
+			},
+			expectedRatio: 2.0 / 3,
+			expectedOK:    true,
+		},
+		{
+			name:        "When every endpoint is hinted for its zone it should keep the traffic in the zone",
+			clientZones: zones,
+			endpoints: []discoveryv1.Endpoint{
+				zonalEndpoint("us-east-1a", "us-east-1a"), zonalEndpoint("us-east-1b", "us-east-1b"), zonalEndpoint("us-east-1c", "us-east-1c"),
+			},
+			expectedRatio: 0,
+			expectedOK:    true,
+		},
+		{
+			name:        "When an endpoint has no hints it should ignore the hints of the others",
+			clientZones: zones,
+			endpoints: []discoveryv1.Endpoint{
+				zonalEndpoint("us-east-1a", "us-east-1a"), zonalEndpoint("us-east-1b", "us-east-1b"), zonalEndpoint("us-east-1c"),
+			},
+			expectedRatio: 2.0 / 3,
+			expectedOK:    true,
+		},
+		{
+			name:        "When no endpoint is hinted for the zone of a client it should route the client to every endpoint",
+			clientZones: []string{"us-east-1a", "us-east-1c"},
+			endpoints: []discoveryv1.Endpoint{
+				zonalEndpoint("us-east-1a", "us-east-1a"), zonalEndpoint("us-east-1b", "us-east-1b"),
+			},
+			expectedRatio: 0.5,
+			expectedOK:    true,
+		},
+		{
+			name:        "When an endpoint isn't ready it should not route traffic to it",
+			clientZones: []string{"us-east-1a"},
+			endpoints: []discoveryv1.Endpoint{
+				zonalEndpoint("us-east-1a"),
+				func() discoveryv1.Endpoint {
+					endpoint := zonalEndpoint("us-east-1b")
+					endpoint.Conditions.Ready = ptr.To(false)
+					return endpoint
+				}(),
+			},
+			expectedRatio: 0,
+			expectedOK:    true,
+		},
+		{
+			name:      "When there are no clients it should not estimate the ratio",
+			endpoints: []discoveryv1.Endpoint{zonalEndpoint("us-east-1a")},
+		},
+		{
+			name:        "When there are no endpoints it should not estimate the ratio",
+			clientZones: zones,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ratio, ok := estimateCrossZoneTrafficRatio(tc.clientZones, tc.endpoints)
+			g.Expect(ok).To(Equal(tc.expectedOK))
+			g.Expect(ratio).To(BeNumerically("~", tc.expectedRatio, 1e-9))
+		})
+	}
+}
+
+func TestReportCrossZoneTraffic(t *testing.T) {
+	endpointSlice := func(service string, endpoints ...discoveryv1.Endpoint) *discoveryv1.EndpointSlice {
+		return &discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "hcp-ns",
+				Name:      service + "-abcde",
+				Labels:    map[string]string{discoveryv1.LabelServiceName: service},
+			},
+			AddressType: discoveryv1.AddressTypeIPv4,
+			Endpoints:   endpoints,
+		}
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(api.Scheme).WithObjects(
+		endpointSlice("kube-apiserver", zonalEndpoint("us-east-1a"), zonalEndpoint("us-east-1b")),
+		endpointSlice("etcd-client-zonal", zonalEndpoint("us-east-1a", "us-east-1a"), zonalEndpoint("us-east-1b", "us-east-1b")),
+	).Build()
+	r := &HostedControlPlaneReconciler{Client: fakeClient}
+	hcp := &hyperv1.HostedControlPlane{ObjectMeta: metav1.ObjectMeta{Namespace: "hcp-ns"}}
+
+	t.Run("When the control plane is highly available it should report the routes whose clients and endpoints are known", func(t *testing.T) {
+		g := NewWithT(t)
+		hcp.Spec.ControllerAvailabilityPolicy = hyperv1.HighlyAvailable
+		g.Expect(r.reportCrossZoneTraffic(context.Background(), hcp)).To(Succeed())
+		g.Expect(testutil.CollectAndCount(crossZoneTrafficRatio)).To(Equal(1))
+		g.Expect(testutil.ToFloat64(crossZoneTrafficRatio.WithLabelValues("kube-apiserver", "etcd-client-zonal"))).To(BeZero())
+	})
+
+	t.Run("When the control plane is single replica it should not report any route", func(t *testing.T) {
+		g := NewWithT(t)
+		hcp.Spec.ControllerAvailabilityPolicy = hyperv1.SingleReplica
+		g.Expect(r.reportCrossZoneTraffic(context.Background(), hcp)).To(Succeed())
+		g.Expect(testutil.CollectAndCount(crossZoneTrafficRatio)).To(BeZero())
+	})
+}
@@ -6,6 +6,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/pointer"
 
 	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
 	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/imageprovider"
@@ -16,6 +17,9 @@ import (
 
 const (
 	EtcdSTSQuotaBackendSize = 8 * 1024 * 1024 * 1024
+
+	// DefaultTerminationGracePeriodSeconds is the termination grace period of the etcd members, the pod default.
+	DefaultTerminationGracePeriodSeconds = 30
 )
 
 type EtcdParams struct {
@@ -32,6 +36,8 @@ type EtcdParams struct {
 	Availability hyperv1.AvailabilityPolicy
 
 	SnapshotRestored bool
+
+	Disruption hyperv1.ManagedEtcdDisruptionSpec
 }
 
 func etcdPodSelector() map[string]string {
@@ -92,6 +98,16 @@ func NewEtcdParams(hcp *hyperv1.HostedControlPlane, releaseImageProvider *imagep
 		}
 	}
 
+	if disruption := hcp.Spec.Etcd.Managed.Disruption; disruption != nil {
+		p.Disruption = *disruption.DeepCopy()
+	}
+	if p.Disruption.PodDisruptionBudget == "" {
+		p.Disruption.PodDisruptionBudget = hyperv1.QuorumGuardEtcdPodDisruptionBudget
+	}
+	if p.Disruption.TerminationGracePeriodSeconds == nil {
+		p.Disruption.TerminationGracePeriodSeconds = pointer.Int64(DefaultTerminationGracePeriodSeconds)
+	}
+
 	if len(hcp.Spec.Etcd.Managed.Storage.RestoreSnapshotURL) > 0 {
 		p.StorageSpec.RestoreSnapshotURL = hcp.Spec.Etcd.Managed.Storage.RestoreSnapshotURL
 		p.SnapshotRestored = meta.IsStatusConditionTrue(hcp.Status.Conditions, string(hyperv1.EtcdSnapshotRestored))
@@ -5,6 +5,7 @@ import (
 
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 
 	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
 	"github.com/openshift/hypershift/api/util/ipnet"
@@ -18,6 +19,7 @@ func TestNewEtcdParams(t *testing.T) {
 		images                   map[string]string
 		expectedStorageSpec      hyperv1.ManagedEtcdStorageSpec
 		expectedSnapshotRestored bool
+		expectedDisruption       *hyperv1.ManagedEtcdDisruptionSpec
 	}{
 		{
 			name: "default managed storage options if unset",
@@ -104,6 +106,44 @@ func TestNewEtcdParams(t *testing.T) {
 			},
 			expectedSnapshotRestored: true,
 		},
+		{
+			name: "Managed with disruption options",
+			hcp: &hyperv1.HostedControlPlane{
+				Spec: hyperv1.HostedControlPlaneSpec{
+					Etcd: hyperv1.EtcdSpec{
+						ManagementType: hyperv1.Managed,
+						Managed: &hyperv1.ManagedEtcdSpec{
+							Storage: hyperv1.ManagedEtcdStorageSpec{
+								Type: hyperv1.PersistentVolumeEtcdStorage,
+							},
+							Disruption: &hyperv1.ManagedEtcdDisruptionSpec{
+								PodDisruptionBudget:           hyperv1.AllowEvictionEtcdPodDisruptionBudget,
+								TerminationGracePeriodSeconds: ptr.To[int64](120),
+								PreStopChecks:                 []hyperv1.EtcdPreStopCheck{hyperv1.DataSyncEtcdPreStopCheck},
+							},
+						},
+					},
+					Networking: hyperv1.ClusterNetworking{
+						ClusterNetwork: []hyperv1.ClusterNetworkEntry{
+							{
+								CIDR: *ipnet.MustParseCIDR("10.132.0.0/14"),
+							},
+						},
+					},
+				},
+			},
+			images: map[string]string{"etcd": "someimage"},
+			expectedStorageSpec: hyperv1.ManagedEtcdStorageSpec{
+				PersistentVolume: &hyperv1.PersistentVolumeEtcdStorageSpec{
+					Size: &hyperv1.DefaultPersistentVolumeEtcdStorageSize,
+				},
+			},
+			expectedDisruption: &hyperv1.ManagedEtcdDisruptionSpec{
+				PodDisruptionBudget:           hyperv1.AllowEvictionEtcdPodDisruptionBudget,
+				TerminationGracePeriodSeconds: ptr.To[int64](120),
+				PreStopChecks:                 []hyperv1.EtcdPreStopCheck{hyperv1.DataSyncEtcdPreStopCheck},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -115,6 +155,14 @@ func TestNewEtcdParams(t *testing.T) {
 			g.Expect(p.EtcdImage).To(Equal(tt.images["etcd"]))
 			g.Expect(p.StorageSpec).To(Equal(tt.expectedStorageSpec))
 			g.Expect(p.SnapshotRestored).To(Equal(tt.expectedSnapshotRestored))
+			expectedDisruption := tt.expectedDisruption
+			if expectedDisruption == nil {
+				expectedDisruption = &hyperv1.ManagedEtcdDisruptionSpec{
+					PodDisruptionBudget:           hyperv1.QuorumGuardEtcdPodDisruptionBudget,
+					TerminationGracePeriodSeconds: ptr.To[int64](DefaultTerminationGracePeriodSeconds),
+				}
+			}
+			g.Expect(p.Disruption).To(Equal(*expectedDisruption))
 		})
 	}
 }
@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"strings"
 
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
 	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/manifests"
 	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/pki"
 	"github.com/openshift/hypershift/support/certs"
@@ -22,6 +23,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/pointer"
+	"k8s.io/utils/ptr"
 )
 
 func etcdContainer() *corev1.Container {
@@ -95,6 +97,7 @@ func ReconcileStatefulSet(ss *appsv1.StatefulSet, p *EtcdParams) error {
 		},
 	}
 	ss.Spec.Template.Labels = etcdPodSelector()
+	ss.Spec.Template.Spec.TerminationGracePeriodSeconds = p.Disruption.TerminationGracePeriodSeconds
 
 	ss.Spec.Template.Spec.Containers = []corev1.Container{
 		util.BuildContainer(etcdContainer(), buildEtcdContainer(p, ss.Namespace)),
@@ -250,12 +253,13 @@ if [[ ! -f /var/lib/data/member/snap/db ]]; then
 	if [[ -n "${MEMBER_ID}" ]]; then
 	  echo "A member with this name (${HOSTNAME}) already exists, removing"
 	  etcdctl member remove "${MEMBER_ID}"
-	  echo "Adding new member"
-	  etcdctl member add ${HOSTNAME} --peer-urls https://${HOSTNAME}.etcd-discovery.${NAMESPACE}.svc:2380
-	  echo "existing" > /etc/etcd/clusterstate/existing
 	else
-	  echo "A member does not exist with name (${HOSTNAME}), nothing to do"
+	  # The member was removed from the cluster when it was replaced by the etcd member replacement controller.
+	  echo "A member does not exist with name (${HOSTNAME})"
 	fi
+	echo "Adding new member"
+	etcdctl member add ${HOSTNAME} --peer-urls https://${HOSTNAME}.etcd-discovery.${NAMESPACE}.svc:2380
+	echo "existing" > /etc/etcd/clusterstate/existing
   else
     echo "Cannot list members in cluster, so likely not up yet"
   fi
@@ -302,6 +306,50 @@ func buildResetMemberContainer(p *EtcdParams, ns string) func(c *corev1.Containe
 	}
 }
 
+const preStopScript = `
+#!/bin/bash
+
+set -u
+
+# This script runs the pre-stop checks passed as arguments before the etcd
+# member stops. Each check waits until it passes, the kubelet kills the member
+# when the termination grace period expires.
+
+export ETCDCTL_API=3
+export ETCDCTL_CACERT=/etc/etcd/tls/etcd-ca/ca.crt
+export ETCDCTL_CERT=/etc/etcd/tls/client/etcd-client.crt
+export ETCDCTL_KEY=/etc/etcd/tls/client/etcd-client.key
+export ETCDCTL_ENDPOINTS=https://localhost:2379
+
+status_field() {
+  etcdctl endpoint status -w fields | awk -F' : ' -v field="\"$1\"" '$1 == field { print $2; exit }'
+}
+
+for check in "$@"; do
+  case "${check}" in
+  MemberHealth)
+    echo "Waiting for all the members to be healthy"
+    until etcdctl endpoint health --cluster; do
+      sleep 2
+    done
+    ;;
+  DataSync)
+    echo "Waiting for the member to apply its committed entries"
+    until [[ -n "$(status_field RaftIndex)" && "$(status_field RaftIndex)" == "$(status_field RaftAppliedIndex)" ]]; do
+      sleep 1
+    done
+    if [[ "$(status_field MemberID)" == "$(status_field Leader)" ]]; then
+      TRANSFEREE_ID=$(etcdctl member list -w simple | awk -F', ' -v name="${HOSTNAME}" '$2 == "started" && $3 != name { print $1; exit }')
+      if [[ -n "${TRANSFEREE_ID}" ]]; then
+        echo "Transferring the leadership to member ${TRANSFEREE_ID}"
+        etcdctl move-leader "${TRANSFEREE_ID}" || echo "Failed to transfer the leadership"
+      fi
+    fi
+    ;;
+  esac
+done
+`
+
 func buildEtcdContainer(p *EtcdParams, namespace string) func(c *corev1.Container) {
 	return func(c *corev1.Container) {
 		var podIP, allInterfaces string
@@ -459,6 +507,18 @@ fi
 			SuccessThreshold: 1,
 			FailureThreshold: 18,
 		}
+		c.Lifecycle = nil
+		if len(p.Disruption.PreStopChecks) > 0 {
+			command := []string{"/bin/bash", "-c", preStopScript, "pre-stop"}
+			for _, check := range p.Disruption.PreStopChecks {
+				command = append(command, string(check))
+			}
+			c.Lifecycle = &corev1.Lifecycle{
+				PreStop: &corev1.LifecycleHandler{
+					Exec: &corev1.ExecAction{Command: command},
+				},
+			}
+		}
 	}
 }
 
@@ -651,6 +711,25 @@ func ReconcileClientService(service *corev1.Service, ownerRef config.OwnerRef) e
 	return nil
 }
 
+// ReconcileZonalClientService reconciles the ClusterIP client service of highly available etcd clusters, which routes
+// the clients to an etcd member in their own zone when possible.
+func ReconcileZonalClientService(service *corev1.Service, ownerRef config.OwnerRef) error {
+	ownerRef.ApplyTo(service)
+	service.Labels = etcdPodSelector()
+	service.Spec.Type = corev1.ServiceTypeClusterIP
+	service.Spec.Selector = etcdPodSelector()
+	service.Spec.Ports = []corev1.ServicePort{
+		{
+			Name:       "etcd-client",
+			Protocol:   corev1.ProtocolTCP,
+			Port:       2379,
+			TargetPort: intstr.Parse("client"),
+		},
+	}
+	util.ReconcileTopologyAwareRouting(service, hyperv1.HighlyAvailable)
+	return nil
+}
+
 // ReconcileServiceMonitor
 func ReconcileServiceMonitor(sm *prometheusoperatorv1.ServiceMonitor, ownerRef config.OwnerRef, clusterID string, metricsSet metrics.MetricsSet) error {
 	ownerRef.ApplyTo(sm)
@@ -708,6 +787,12 @@ func ReconcilePodDisruptionBudget(pdb *policyv1.PodDisruptionBudget, p *EtcdPara
 
 	p.OwnerRef.ApplyTo(pdb)
 	util.ReconcilePodDisruptionBudget(pdb, p.Availability)
+	// The member of a single replica control plane can't be evicted without losing the quorum, allow it when asked to
+	// rather than blocking the drain of its node.
+	if p.Disruption.PodDisruptionBudget == hyperv1.AllowEvictionEtcdPodDisruptionBudget && p.Availability == hyperv1.SingleReplica {
+		pdb.Spec.MinAvailable = nil
+		pdb.Spec.MaxUnavailable = ptr.To(intstr.FromInt(1))
+	}
 	return nil
 }
 
@@ -731,6 +816,46 @@ func ReconcileDefragControllerRole(role *rbacv1.Role, p *EtcdParams) error {
 				"update",
 			},
 		},
+		// Needed to replace failed etcd members.
+		{
+			APIGroups: []string{""},
+			Resources: []string{
+				"pods",
+				"persistentvolumeclaims",
+			},
+			Verbs: []string{
+				"get",
+				"list",
+				"watch",
+				"delete",
+			},
+		},
+		{
+			APIGroups: []string{"apps"},
+			Resources: []string{"statefulsets"},
+			Verbs: []string{
+				"get",
+				"list",
+				"watch",
+			},
+		},
+		{
+			APIGroups: []string{hyperv1.GroupVersion.Group},
+			Resources: []string{"hostedcontrolplanes"},
+			Verbs: []string{
+				"get",
+				"list",
+				"watch",
+			},
+		},
+		{
+			APIGroups: []string{hyperv1.GroupVersion.Group},
+			Resources: []string{"hostedcontrolplanes/status"},
+			Verbs: []string{
+				"patch",
+				"update",
+			},
+		},
 	}
 	return nil
 }
@@ -6,6 +6,9 @@ import (
 
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
 
 	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
 	"github.com/openshift/hypershift/support/config"
@@ -146,6 +149,79 @@ func TestBuildEtcdContainer(t *testing.T) {
 	}
 }
 
+func TestBuildEtcdContainerPreStop(t *testing.T) {
+	tests := []struct {
+		name            string
+		disruption      hyperv1.ManagedEtcdDisruptionSpec
+		expectedPreStop []string
+	}{
+		{
+			name: "given no pre-stop checks, it should not set a pre-stop hook",
+		},
+		{
+			name: "given pre-stop checks, it should run them in the pre-stop hook",
+			disruption: hyperv1.ManagedEtcdDisruptionSpec{
+				PreStopChecks: []hyperv1.EtcdPreStopCheck{hyperv1.MemberHealthEtcdPreStopCheck, hyperv1.DataSyncEtcdPreStopCheck},
+			},
+			expectedPreStop: []string{"/bin/bash", "-c", preStopScript, "pre-stop", "MemberHealth", "DataSync"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			c := corev1.Container{}
+			buildEtcdContainer(&EtcdParams{Disruption: tt.disruption}, "test-ns")(&c)
+			if tt.expectedPreStop == nil {
+				g.Expect(c.Lifecycle).To(BeNil())
+				return
+			}
+			g.Expect(c.Lifecycle.PreStop.Exec.Command).To(Equal(tt.expectedPreStop))
+		})
+	}
+}
+
+func TestReconcilePodDisruptionBudget(t *testing.T) {
+	tests := []struct {
+		name                   string
+		availability           hyperv1.AvailabilityPolicy
+		policy                 hyperv1.EtcdPodDisruptionBudgetPolicy
+		expectedMinAvailable   *intstr.IntOrString
+		expectedMaxUnavailable *intstr.IntOrString
+	}{
+		{
+			name:                 "given a single replica control plane with the quorum guard policy, it should not allow evictions",
+			availability:         hyperv1.SingleReplica,
+			policy:               hyperv1.QuorumGuardEtcdPodDisruptionBudget,
+			expectedMinAvailable: ptr.To(intstr.FromInt(1)),
+		},
+		{
+			name:                   "given a single replica control plane with the allow eviction policy, it should allow evicting the member",
+			availability:           hyperv1.SingleReplica,
+			policy:                 hyperv1.AllowEvictionEtcdPodDisruptionBudget,
+			expectedMaxUnavailable: ptr.To(intstr.FromInt(1)),
+		},
+		{
+			name:                   "given a highly available control plane with the allow eviction policy, it should allow evicting one member at a time",
+			availability:           hyperv1.HighlyAvailable,
+			policy:                 hyperv1.AllowEvictionEtcdPodDisruptionBudget,
+			expectedMaxUnavailable: ptr.To(intstr.FromInt(1)),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			pdb := &policyv1.PodDisruptionBudget{}
+			p := &EtcdParams{
+				Availability: tt.availability,
+				Disruption:   hyperv1.ManagedEtcdDisruptionSpec{PodDisruptionBudget: tt.policy},
+			}
+			g.Expect(ReconcilePodDisruptionBudget(pdb, p)).To(Succeed())
+			g.Expect(pdb.Spec.MinAvailable).To(Equal(tt.expectedMinAvailable))
+			g.Expect(pdb.Spec.MaxUnavailable).To(Equal(tt.expectedMaxUnavailable))
+		})
+	}
+}
+
 func TestBuildEtcdMetricsContainer(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -0,0 +1,138 @@
+package etcd
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"time"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Keys of the TLS client Secret of an unmanaged etcd.
+const (
+	UnmanagedClientCertKey = "etcd-client.crt"
+	UnmanagedClientKeyKey  = "etcd-client.key"
+	UnmanagedClientCAKey   = "etcd-client-ca.crt"
+
+	unmanagedEndpointDialTimeout = 5 * time.Second
+)
+
+// UnmanagedEtcdError is an error validating an unmanaged etcd, along with the reason reported in conditions.
+type UnmanagedEtcdError struct {
+	Reason string
+	Err    error
+}
+
+func (e *UnmanagedEtcdError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *UnmanagedEtcdError) Unwrap() error {
+	return e.Err
+}
+
+// UnmanagedEtcdErrorReason returns the condition reason of an error returned by ValidateUnmanagedClientSecret
+// or CheckUnmanagedEndpoint.
+func UnmanagedEtcdErrorReason(err error) string {
+	var unmanagedErr *UnmanagedEtcdError
+	if errors.As(err, &unmanagedErr) {
+		return unmanagedErr.Reason
+	}
+	return hyperv1.UnmanagedEtcdMisconfiguredReason
+}
+
+func misconfigured(format string, args ...interface{}) error {
+	return &UnmanagedEtcdError{Reason: hyperv1.UnmanagedEtcdMisconfiguredReason, Err: fmt.Errorf(format, args...)}
+}
+
+// ValidateUnmanagedClientSecret validates the TLS client Secret of an unmanaged etcd and returns the TLS config
+// to connect to it: the client certificate must match its key and be currently valid, and the CA must be parsable.
+func ValidateUnmanagedClientSecret(secret *corev1.Secret, now time.Time) (*tls.Config, error) {
+	for key, description := range map[string]string{
+		UnmanagedClientCertKey: "client cert",
+		UnmanagedClientKeyKey:  "client key",
+		UnmanagedClientCAKey:   "client ca",
+	} {
+		if _, ok := secret.Data[key]; !ok {
+			return nil, misconfigured("etcd secret %s does not have %s", secret.Name, description)
+		}
+	}
+
+	clientCert, err := tls.X509KeyPair(secret.Data[UnmanagedClientCertKey], secret.Data[UnmanagedClientKeyKey])
+	if err != nil {
+		return nil, misconfigured("etcd secret %s has an invalid client cert and key pair: %v", secret.Name, err)
+	}
+	leaf, err := x509.ParseCertificate(clientCert.Certificate[0])
+	if err != nil {
+		return nil, misconfigured("etcd secret %s has an invalid client cert: %v", secret.Name, err)
+	}
+	if now.Before(leaf.NotBefore) {
+		return nil, misconfigured("etcd secret %s has a client cert which is not valid before %s", secret.Name, leaf.NotBefore.Format(time.RFC3339))
+	}
+	if now.After(leaf.NotAfter) {
+		return nil, misconfigured("etcd secret %s has a client cert which expired at %s", secret.Name, leaf.NotAfter.Format(time.RFC3339))
+	}
+
+	rootCAs := x509.NewCertPool()
+	if !rootCAs.AppendCertsFromPEM(secret.Data[UnmanagedClientCAKey]) {
+		return nil, misconfigured("etcd secret %s has an invalid client ca", secret.Name)
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{clientCert},
+		RootCAs:      rootCAs,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+// CheckUnmanagedEndpoint connects to the endpoint of an unmanaged etcd with the given TLS config, as the
+// kube-apiserver does. It fails if the endpoint is unreachable, or if its serving certificate isn't signed by the
+// client ca or doesn't include the endpoint host in its SANs.
+func CheckUnmanagedEndpoint(ctx context.Context, endpoint string, tlsConfig *tls.Config) error {
+	endpointURL, err := url.Parse(endpoint)
+	if err != nil || endpointURL.Host == "" {
+		return misconfigured("invalid etcd endpoint %q", endpoint)
+	}
+	address := endpointURL.Host
+	if endpointURL.Port() == "" {
+		address = net.JoinHostPort(endpointURL.Hostname(), "2379")
+	}
+
+	config := tlsConfig.Clone()
+	config.ServerName = endpointURL.Hostname()
+	dialer := &tls.Dialer{
+		NetDialer: &net.Dialer{Timeout: unmanagedEndpointDialTimeout},
+		Config:    config,
+	}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		var hostnameErr x509.HostnameError
+		var unknownAuthorityErr x509.UnknownAuthorityError
+		var certificateInvalidErr x509.CertificateInvalidError
+		var tlsVerificationErr *tls.CertificateVerificationError
+		switch {
+		case errors.As(err, &hostnameErr):
+			return &UnmanagedEtcdError{
+				Reason: hyperv1.EtcdServerCertificateInvalidReason,
+				Err:    fmt.Errorf("etcd serving certificate SANs don't include %s: %w", endpointURL.Hostname(), err),
+			}
+		case errors.As(err, &unknownAuthorityErr), errors.As(err, &certificateInvalidErr), errors.As(err, &tlsVerificationErr):
+			return &UnmanagedEtcdError{
+				Reason: hyperv1.EtcdServerCertificateInvalidReason,
+				Err:    fmt.Errorf("etcd serving certificate can't be verified with the client ca: %w", err),
+			}
+		default:
+			return &UnmanagedEtcdError{
+				Reason: hyperv1.EtcdEndpointUnreachableReason,
+				Err:    fmt.Errorf("etcd endpoint %s is unreachable: %w", endpoint, err),
+			}
+		}
+	}
+	return conn.Close()
+}
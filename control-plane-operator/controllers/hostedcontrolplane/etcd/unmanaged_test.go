@@ -0,0 +1,221 @@
+package etcd
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/support/certs"
+)
+
+type testCA struct {
+	key  *rsa.PrivateKey
+	cert *x509.Certificate
+}
+
+func newTestCA(t *testing.T) *testCA {
+	key, cert, err := certs.GenerateSelfSignedCertificate(&certs.CertCfg{
+		Subject:   pkix.Name{CommonName: "etcd-ca", OrganizationalUnit: []string{"openshift"}},
+		KeyUsages: x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		Validity:  certs.ValidityOneDay,
+		IsCA:      true,
+	})
+	if err != nil {
+		t.Fatalf("failed to generate CA: %v", err)
+	}
+	return &testCA{key: key, cert: cert}
+}
+
+func (ca *testCA) sign(t *testing.T, cfg *certs.CertCfg) ([]byte, []byte) {
+	cfg.KeyUsages = x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature
+	cfg.Validity = certs.ValidityOneDay
+	key, cert, err := certs.GenerateSignedCertificate(ca.key, ca.cert, cfg)
+	if err != nil {
+		t.Fatalf("failed to generate certificate: %v", err)
+	}
+	return certs.CertToPem(cert), certs.PrivateKeyToPem(key)
+}
+
+func (ca *testCA) clientSecret(t *testing.T) *corev1.Secret {
+	cert, key := ca.sign(t, &certs.CertCfg{
+		Subject:      pkix.Name{CommonName: "etcd-client"},
+		ExtKeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "etcd-client-tls"},
+		Data: map[string][]byte{
+			UnmanagedClientCertKey: cert,
+			UnmanagedClientKeyKey:  key,
+			UnmanagedClientCAKey:   certs.CertToPem(ca.cert),
+		},
+	}
+}
+
+// serve serves TLS on a local port with a serving certificate for the given DNS names, and returns its port.
+func (ca *testCA) serve(t *testing.T, dnsNames ...string) int {
+	certPEM, keyPEM := ca.sign(t, &certs.CertCfg{
+		Subject:      pkix.Name{CommonName: "etcd-server"},
+		ExtKeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		DNSNames:     dnsNames,
+	})
+	serverCert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		t.Fatalf("failed to load serving certificate: %v", err)
+	}
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(ca.cert)
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientCAs:    clientCAs,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	})
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { _ = listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			_ = conn.(*tls.Conn).Handshake()
+			_ = conn.Close()
+		}
+	}()
+	return listener.Addr().(*net.TCPAddr).Port
+}
+
+func TestValidateUnmanagedClientSecret(t *testing.T) {
+	ca := newTestCA(t)
+	otherCA := newTestCA(t)
+
+	testCases := []struct {
+		name          string
+		secret        func() *corev1.Secret
+		now           time.Time
+		expectedError string
+	}{
+		{
+			name:   "When the client secret is valid it should succeed",
+			secret: func() *corev1.Secret { return ca.clientSecret(t) },
+		},
+		{
+			name: "When the client key is missing it should fail",
+			secret: func() *corev1.Secret {
+				secret := ca.clientSecret(t)
+				delete(secret.Data, UnmanagedClientKeyKey)
+				return secret
+			},
+			expectedError: "does not have client key",
+		},
+		{
+			name: "When the client key doesn't match the client cert it should fail",
+			secret: func() *corev1.Secret {
+				secret := ca.clientSecret(t)
+				secret.Data[UnmanagedClientKeyKey] = otherCA.clientSecret(t).Data[UnmanagedClientKeyKey]
+				return secret
+			},
+			expectedError: "invalid client cert and key pair",
+		},
+		{
+			name:          "When the client cert has expired it should fail",
+			secret:        func() *corev1.Secret { return ca.clientSecret(t) },
+			now:           time.Now().Add(2 * certs.ValidityOneDay),
+			expectedError: "expired",
+		},
+		{
+			name: "When the client ca is invalid it should fail",
+			secret: func() *corev1.Secret {
+				secret := ca.clientSecret(t)
+				secret.Data[UnmanagedClientCAKey] = []byte("invalid")
+				return secret
+			},
+			expectedError: "invalid client ca",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			now := tc.now
+			if now.IsZero() {
+				now = time.Now()
+			}
+			tlsConfig, err := ValidateUnmanagedClientSecret(tc.secret(), now)
+			if tc.expectedError == "" {
+				g.Expect(err).ToNot(HaveOccurred())
+				g.Expect(tlsConfig.Certificates).To(HaveLen(1))
+				return
+			}
+			g.Expect(err).To(MatchError(ContainSubstring(tc.expectedError)))
+			g.Expect(UnmanagedEtcdErrorReason(err)).To(Equal(hyperv1.UnmanagedEtcdMisconfiguredReason))
+		})
+	}
+}
+
+func TestCheckUnmanagedEndpoint(t *testing.T) {
+	ca := newTestCA(t)
+	otherCA := newTestCA(t)
+
+	unusedPort := func() int {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatalf("failed to listen: %v", err)
+		}
+		port := listener.Addr().(*net.TCPAddr).Port
+		_ = listener.Close()
+		return port
+	}
+
+	testCases := []struct {
+		name           string
+		port           func() int
+		expectedReason string
+	}{
+		{
+			name: "When the endpoint serves a certificate valid for its host it should succeed",
+			port: func() int { return ca.serve(t, "localhost") },
+		},
+		{
+			name:           "When the serving certificate SANs don't include the endpoint host it should fail",
+			port:           func() int { return ca.serve(t, "etcd.example.com") },
+			expectedReason: hyperv1.EtcdServerCertificateInvalidReason,
+		},
+		{
+			name:           "When the serving certificate isn't signed by the client ca it should fail",
+			port:           func() int { return otherCA.serve(t, "localhost") },
+			expectedReason: hyperv1.EtcdServerCertificateInvalidReason,
+		},
+		{
+			name:           "When the endpoint is unreachable it should fail",
+			port:           unusedPort,
+			expectedReason: hyperv1.EtcdEndpointUnreachableReason,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			tlsConfig, err := ValidateUnmanagedClientSecret(ca.clientSecret(t), time.Now())
+			g.Expect(err).ToNot(HaveOccurred())
+
+			err = CheckUnmanagedEndpoint(context.Background(), fmt.Sprintf("https://localhost:%d", tc.port()), tlsConfig)
+			if tc.expectedReason == "" {
+				g.Expect(err).ToNot(HaveOccurred())
+				return
+			}
+			g.Expect(err).To(HaveOccurred())
+			g.Expect(UnmanagedEtcdErrorReason(err)).To(Equal(tc.expectedReason))
+		})
+	}
+}
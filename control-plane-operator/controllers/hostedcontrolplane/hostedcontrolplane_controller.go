@@ -22,12 +22,14 @@ import (
 	pkimanifests "github.com/openshift/hypershift/control-plane-pki-operator/manifests"
 	"k8s.io/apimachinery/pkg/runtime"
 
+	"github.com/IBM/go-sdk-core/v5/core"
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/aws/aws-sdk-go/service/kms"
 	"github.com/go-logr/logr"
 	routev1 "github.com/openshift/api/route/v1"
+	certificatesv1alpha1 "github.com/openshift/hypershift/api/certificates/v1alpha1"
 	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
 	awsutil "github.com/openshift/hypershift/cmd/infra/aws/util"
 	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/autoscaler"
@@ -38,6 +40,7 @@ import (
 	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/clusterpolicy"
 	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/cno"
 	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/common"
+	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/componentgraph"
 	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/configoperator"
 	kubevirtcsi "github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/csi/kubevirt"
 	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/cvo"
@@ -49,6 +52,7 @@ import (
 	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/ingress"
 	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/ingressoperator"
 	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/kas"
+	kaskms "github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/kas/kms"
 	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/kcm"
 	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/konnectivity"
 	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/machineapprover"
@@ -114,6 +118,9 @@ const (
 
 	hcpReadyRequeueInterval    = 1 * time.Minute
 	hcpNotReadyRequeueInterval = 15 * time.Second
+
+	// maxParallelComponentReconciles is the maximum number of control plane components reconciled at the same time.
+	maxParallelComponentReconciles = 10
 )
 
 type InfrastructureStatus struct {
@@ -166,6 +173,7 @@ type HostedControlPlaneReconciler struct {
 
 	Log                                     logr.Logger
 	ReleaseProvider                         releaseinfo.ProviderWithOpenShiftImageRegistryOverrides
+	ImageMirrorResolver                     *util.ImageMirrorResolver
 	createOrUpdate                          func(hcp *hyperv1.HostedControlPlane) upsert.CreateOrUpdateFN
 	EnableCIDebugOutput                     bool
 	OperateOnReleaseImage                   string
@@ -176,6 +184,7 @@ type HostedControlPlaneReconciler struct {
 	awsSession                              *session.Session
 	reconcileInfrastructureStatus           func(ctx context.Context, hcp *hyperv1.HostedControlPlane) (InfrastructureStatus, error)
 	EnableCVOManagementClusterMetricsAccess bool
+	startup                                 *startupTracker
 }
 
 func (r *HostedControlPlaneReconciler) SetupWithManager(mgr ctrl.Manager, createOrUpdate upsert.CreateOrUpdateFN) error {
@@ -248,11 +257,71 @@ func createOrUpdateWithDelayForScrapeConfigs(hcp *hyperv1.HostedControlPlane, up
 	}
 }
 
+// createOrUpdateWithExtensions injects the control plane extensions into the pods of the control plane Deployments
+// and StatefulSets they select, and removes the previously injected ones from the others. It is applied before the
+// hardening profile, so injected sidecars are hardened as well.
+func createOrUpdateWithExtensions(extensions *config.ControlPlaneExtensions, upstreamCreateOrUpdate upsert.CreateOrUpdateFN) upsert.CreateOrUpdateFN {
+	return func(ctx context.Context, c client.Client, obj client.Object, f controllerutil.MutateFn) (controllerutil.OperationResult, error) {
+		return upstreamCreateOrUpdate(ctx, c, obj, func() error {
+			if err := f(); err != nil {
+				return err
+			}
+			extensions.ApplyTo(obj)
+			return nil
+		})
+	}
+}
+
+// invalidControlPlaneExtensionsError is returned when the control plane extensions ConfigMap can't be applied.
+type invalidControlPlaneExtensionsError struct {
+	err error
+}
+
+func (e *invalidControlPlaneExtensionsError) Error() string {
+	return fmt.Sprintf("invalid control plane extensions: %v", e.err)
+}
+
+// controlPlaneExtensions returns the control plane extensions synced in the control plane namespace, or nil when
+// none are configured.
+func (r *HostedControlPlaneReconciler) controlPlaneExtensions(ctx context.Context, hcp *hyperv1.HostedControlPlane) (*config.ControlPlaneExtensions, error) {
+	configMap := manifests.ControlPlaneExtensionsConfigMap(hcp.Namespace)
+	if err := r.Get(ctx, client.ObjectKeyFromObject(configMap), configMap); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get control plane extensions configmap: %w", err)
+	}
+	extensions, err := config.ControlPlaneExtensionsFrom(configMap)
+	if err != nil {
+		return nil, &invalidControlPlaneExtensionsError{err: err}
+	}
+	return extensions, nil
+}
+
+// createOrUpdateWithHardening applies the hardening profile selected for the HostedControlPlane to the pods of all
+// the control plane workloads.
+func createOrUpdateWithHardening(hcp *hyperv1.HostedControlPlane, upstreamCreateOrUpdate upsert.CreateOrUpdateFN) upsert.CreateOrUpdateFN {
+	hardening := config.ControlPlaneHardeningFrom(hcp.Annotations)
+	if hardening == nil {
+		return upstreamCreateOrUpdate
+	}
+	return func(ctx context.Context, c client.Client, obj client.Object, f controllerutil.MutateFn) (controllerutil.OperationResult, error) {
+		return upstreamCreateOrUpdate(ctx, c, obj, func() error {
+			if err := f(); err != nil {
+				return err
+			}
+			hardening.ApplyTo(obj)
+			return nil
+		})
+	}
+}
+
 func (r *HostedControlPlaneReconciler) setup(upstreamCreateOrUpdate upsert.CreateOrUpdateFN) {
 	createOrUpdateFactory := createOrUpdateWithOwnerRefFactory(upstreamCreateOrUpdate)
+	r.startup = newStartupTracker()
 
 	r.createOrUpdate = func(hcp *hyperv1.HostedControlPlane) upsert.CreateOrUpdateFN {
-		return createOrUpdateWithDelayForScrapeConfigs(hcp, createOrUpdateFactory(hcp))
+		return createOrUpdateWithDelayForScrapeConfigs(hcp, createOrUpdateWithHardening(hcp, createOrUpdateFactory(hcp)))
 	}
 }
 
@@ -277,6 +346,8 @@ func (r *HostedControlPlaneReconciler) eventHandlers(scheme *runtime.Scheme, res
 		{obj: &rbacv1.Role{}, handler: handler.EnqueueRequestForOwner(scheme, restMapper, &hyperv1.HostedControlPlane{})},
 		{obj: &rbacv1.RoleBinding{}, handler: handler.EnqueueRequestForOwner(scheme, restMapper, &hyperv1.HostedControlPlane{})},
 		{obj: &batchv1.CronJob{}, handler: handler.EnqueueRequestForOwner(scheme, restMapper, &hyperv1.HostedControlPlane{})},
+		{obj: &batchv1.Job{}, handler: handler.EnqueueRequestForOwner(scheme, restMapper, &hyperv1.HostedControlPlane{})},
+		{obj: &certificatesv1alpha1.CertificateRevocationRequest{}, handler: handler.EnqueueRequestsFromMapFunc(r.hostedControlPlaneInNamespace)},
 	}
 	if r.ManagementClusterCapabilities.Has(capabilities.CapabilityRoute) {
 		handlers = append(handlers, eventHandler{obj: &routev1.Route{}, handler: handler.EnqueueRequestForOwner(scheme, restMapper, &hyperv1.HostedControlPlane{})})
@@ -314,13 +385,14 @@ func (r *HostedControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 		condition := &metav1.Condition{
 			Type: string(hyperv1.AWSDefaultSecurityGroupDeleted),
 		}
-		if shouldCleanupCloudResources(r.Log, hostedControlPlane) {
+		cleanupCloudResources := shouldCleanupCloudResources(r.Log, hostedControlPlane)
+		if cleanupCloudResources {
 			if code, destroyErr := r.destroyAWSDefaultSecurityGroup(ctx, hostedControlPlane); destroyErr != nil {
 				condition.Message = "failed to delete AWS default security group"
 				if code == "DependencyViolation" {
 					condition.Message = destroyErr.Error()
 				}
-				condition.Reason = hyperv1.AWSErrorReason
+				condition.Reason = conditions.ReasonForProviderErrorCode(code)
 				condition.Status = metav1.ConditionFalse
 				meta.SetStatusCondition(&hostedControlPlane.Status.Conditions, *condition)
 
@@ -356,6 +428,10 @@ func (r *HostedControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 			}
 		}
 
+		if err := r.destroyNodePoolSecurityGroups(ctx, hostedControlPlane, cleanupCloudResources); err != nil {
+			return ctrl.Result{}, err
+		}
+
 		if controllerutil.ContainsFinalizer(hostedControlPlane, finalizer) {
 			originalHCP := hostedControlPlane.DeepCopy()
 			controllerutil.RemoveFinalizer(hostedControlPlane, finalizer)
@@ -427,13 +503,7 @@ func (r *HostedControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 				newCondition = *conditionPtr
 			}
 		case hyperv1.Unmanaged:
-			r.Log.Info("Assuming Etcd cluster is running in unmanaged etcd strategy")
-			newCondition = metav1.Condition{
-				Type:    string(hyperv1.EtcdAvailable),
-				Status:  metav1.ConditionTrue,
-				Reason:  "EtcdRunning",
-				Message: "Etcd cluster is assumed to be running in unmanaged state",
-			}
+			newCondition = r.unmanagedEtcdCondition(ctx, hostedControlPlane)
 		}
 		newCondition.ObservedGeneration = hostedControlPlane.Generation
 		meta.SetStatusCondition(&hostedControlPlane.Status.Conditions, newCondition)
@@ -464,6 +534,20 @@ func (r *HostedControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 		r.validateAWSKMSConfig(ctx, hostedControlPlane)
 	case hyperv1.AzurePlatform:
 		r.validateAzureKMSConfig(ctx, hostedControlPlane)
+	case hyperv1.IBMCloudPlatform, hyperv1.PowerVSPlatform:
+		r.validateIBMCloudKMSConfig(ctx, hostedControlPlane)
+	}
+
+	// Reconcile KMS re-encryption status
+	if ibmCloudKMSSpec(hostedControlPlane) != nil {
+		condition, err := r.kmsDataReencryptedCondition(ctx, hostedControlPlane)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		condition.ObservedGeneration = hostedControlPlane.Generation
+		meta.SetStatusCondition(&hostedControlPlane.Status.Conditions, condition)
+	} else {
+		meta.RemoveStatusCondition(&hostedControlPlane.Status.Conditions, string(hyperv1.KMSDataReencrypted))
 	}
 
 	// Reconcile Kube APIServer status
@@ -679,6 +763,13 @@ func (r *HostedControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 		meta.SetStatusCondition(&hostedControlPlane.Status.Conditions, condition)
 	}
 
+	// Record how long the control plane took to start once all its components were ready.
+	if hostedControlPlane.Status.ControlPlaneStartupDuration == nil {
+		if duration := r.startup.duration(hostedControlPlane); duration != nil {
+			hostedControlPlane.Status.ControlPlaneStartupDuration = &metav1.Duration{Duration: *duration}
+		}
+	}
+
 	kubeconfig := manifests.KASExternalKubeconfigSecret(hostedControlPlane.Namespace, hostedControlPlane.Spec.KubeConfig)
 	if err := r.Get(ctx, client.ObjectKeyFromObject(kubeconfig), kubeconfig); err != nil {
 		if !apierrors.IsNotFound(err) {
@@ -711,13 +802,29 @@ func (r *HostedControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 	}
 
 	// Reconcile valid release info status
-	releaseImage, err := r.LookupReleaseImage(ctx, hostedControlPlane)
+	releaseImage, unreachableImages, err := r.LookupReleaseImage(ctx, hostedControlPlane)
 	if err != nil {
 		return reconcile.Result{}, fmt.Errorf("failed to look up release image metadata: %w", err)
 	}
 	releaseImageProvider := imageprovider.New(releaseImage)
 	{
-		if len(releaseImageProvider.GetMissingImages()) == 0 {
+		if missingImages := releaseImageProvider.GetMissingImages(); len(missingImages) > 0 {
+			meta.SetStatusCondition(&hostedControlPlane.Status.Conditions, metav1.Condition{
+				Type:               string(hyperv1.ValidReleaseInfo),
+				Status:             metav1.ConditionFalse,
+				Reason:             hyperv1.MissingReleaseImagesReason,
+				Message:            strings.Join(missingImages, ", "),
+				ObservedGeneration: hostedControlPlane.Generation,
+			})
+		} else if len(unreachableImages) > 0 {
+			meta.SetStatusCondition(&hostedControlPlane.Status.Conditions, metav1.Condition{
+				Type:               string(hyperv1.ValidReleaseInfo),
+				Status:             metav1.ConditionFalse,
+				Reason:             hyperv1.UnreachableReleaseImagesReason,
+				Message:            fmt.Sprintf("Images not reachable from any registry mirror: %s", strings.Join(unreachableImages, ", ")),
+				ObservedGeneration: hostedControlPlane.Generation,
+			})
+		} else {
 			meta.SetStatusCondition(&hostedControlPlane.Status.Conditions, metav1.Condition{
 				Type:               string(hyperv1.ValidReleaseInfo),
 				Status:             metav1.ConditionTrue,
@@ -725,14 +832,34 @@ func (r *HostedControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 				Message:            hyperv1.AllIsWellMessage,
 				ObservedGeneration: hostedControlPlane.Generation,
 			})
-		} else {
+		}
+	}
+
+	// Reconcile the validity of the control plane extensions
+	{
+		extensions, err := r.controlPlaneExtensions(ctx, hostedControlPlane)
+		var invalid *invalidControlPlaneExtensionsError
+		switch {
+		case errors.As(err, &invalid):
 			meta.SetStatusCondition(&hostedControlPlane.Status.Conditions, metav1.Condition{
-				Type:               string(hyperv1.ValidReleaseInfo),
+				Type:               string(hyperv1.ValidControlPlaneExtensions),
 				Status:             metav1.ConditionFalse,
-				Reason:             hyperv1.MissingReleaseImagesReason,
-				Message:            strings.Join(releaseImageProvider.GetMissingImages(), ", "),
+				Reason:             hyperv1.InvalidConfigurationReason,
+				Message:            invalid.Error(),
+				ObservedGeneration: hostedControlPlane.Generation,
+			})
+		case err != nil:
+			return reconcile.Result{}, err
+		case extensions != nil:
+			meta.SetStatusCondition(&hostedControlPlane.Status.Conditions, metav1.Condition{
+				Type:               string(hyperv1.ValidControlPlaneExtensions),
+				Status:             metav1.ConditionTrue,
+				Reason:             hyperv1.AsExpectedReason,
+				Message:            hyperv1.AllIsWellMessage,
 				ObservedGeneration: hostedControlPlane.Generation,
 			})
+		default:
+			meta.RemoveStatusCondition(&hostedControlPlane.Status.Conditions, string(hyperv1.ValidControlPlaneExtensions))
 		}
 	}
 
@@ -861,21 +988,35 @@ func (r *HostedControlPlaneReconciler) validateConfigAndClusterCapabilities(hc *
 	return nil
 }
 
-func (r *HostedControlPlaneReconciler) LookupReleaseImage(ctx context.Context, hcp *hyperv1.HostedControlPlane) (*releaseinfo.ReleaseImage, error) {
+// LookupReleaseImage returns the control plane release image with its component
+// images pointing to their first reachable management cluster registry mirror,
+// along with the names of the components whose images can't be reached.
+func (r *HostedControlPlaneReconciler) LookupReleaseImage(ctx context.Context, hcp *hyperv1.HostedControlPlane) (*releaseinfo.ReleaseImage, []string, error) {
 	pullSecret := common.PullSecret(hcp.Namespace)
 	if err := r.Client.Get(ctx, client.ObjectKeyFromObject(pullSecret), pullSecret); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	lookupCtx, lookupCancel := context.WithTimeout(ctx, 2*time.Minute)
 	defer lookupCancel()
-	return r.ReleaseProvider.Lookup(lookupCtx, util.HCPControlPlaneReleaseImage(hcp), pullSecret.Data[corev1.DockerConfigJsonKey])
-
+	releaseImage, err := r.ReleaseProvider.Lookup(lookupCtx, util.HCPControlPlaneReleaseImage(hcp), pullSecret.Data[corev1.DockerConfigJsonKey])
+	if err != nil {
+		return nil, nil, err
+	}
+	releaseImage, unreachableImages := r.ImageMirrorResolver.ResolveReleaseImage(lookupCtx, releaseImage, pullSecret.Data[corev1.DockerConfigJsonKey])
+	return releaseImage, unreachableImages, nil
 }
 
 func (r *HostedControlPlaneReconciler) update(ctx context.Context, hostedControlPlane *hyperv1.HostedControlPlane, releaseImage *releaseinfo.ReleaseImage) (reconcile.Result, error) {
 
 	createOrUpdate := r.createOrUpdate(hostedControlPlane)
 
+	// Invalid extensions are reported by the ValidControlPlaneExtensions condition and are not applied.
+	extensions, err := r.controlPlaneExtensions(ctx, hostedControlPlane)
+	if err != nil && !errors.As(err, new(*invalidControlPlaneExtensionsError)) {
+		return reconcile.Result{}, err
+	}
+	createOrUpdate = createOrUpdateWithExtensions(extensions, createOrUpdate)
+
 	r.Log.Info("Reconciling infrastructure services")
 	if err := r.reconcileInfrastructure(ctx, hostedControlPlane, createOrUpdate); err != nil {
 		return reconcile.Result{}, fmt.Errorf("failed to ensure infrastructure: %w", err)
@@ -924,350 +1065,487 @@ func (r *HostedControlPlaneReconciler) reconcile(ctx context.Context, hostedCont
 	if err != nil {
 		return fmt.Errorf("failed to get lookup release image: %w", err)
 	}
+	userReleaseImage, _ = r.ImageMirrorResolver.ResolveReleaseImage(ctx, userReleaseImage, pullSecret.Data[corev1.DockerConfigJsonKey])
 	userReleaseImageProvider := imageprovider.New(userReleaseImage)
 
-	// Reconcile default service account
-	r.Log.Info("Reconciling default service account")
-	if err := r.reconcileDefaultServiceAccount(ctx, hostedControlPlane, createOrUpdate); err != nil {
-		return fmt.Errorf("failed to reconcile default service account: %w", err)
-	}
-
 	openShiftTrustedCABundleConfigMapForCPOExists, err := doesOpenShiftTrustedCABundleConfigMapForCPOExist(ctx, r.Client, hostedControlPlane.Namespace)
 	if err != nil {
 		return err
 	}
 
-	// Reconcile PKI
-	if _, exists := hostedControlPlane.Annotations[hyperv1.DisablePKIReconciliationAnnotation]; !exists {
-		r.Log.Info("Reconciling PKI")
-		if err := r.reconcilePKI(ctx, hostedControlPlane, infraStatus, createOrUpdate); err != nil {
-			return fmt.Errorf("failed to reconcile PKI: %w", err)
-		}
-
-		r.Log.Info("Reconciling Control Plane PKI Operator")
-		if err := r.reconcileControlPlanePKIOperator(ctx, hostedControlPlane, releaseImageProvider, createOrUpdate, openShiftTrustedCABundleConfigMapForCPOExists, r.CertRotationScale); err != nil {
-			return fmt.Errorf("failed to reconcile control plane pki operator: %w", err)
-		}
-	}
-
-	// Reconcile etcd
-	r.Log.Info("Reconciling Etcd")
-	switch hostedControlPlane.Spec.Etcd.ManagementType {
-	case hyperv1.Managed:
-		statefulSet := manifests.EtcdStatefulSet(hostedControlPlane.Namespace)
-		if err := r.reconcileManagedEtcd(ctx, hostedControlPlane, releaseImageProvider, createOrUpdate, statefulSet); err != nil {
-			return fmt.Errorf("failed to reconcile etcd: %w", err)
-		}
-		// Block until etcd is fully rolled out at the desired generation
-		if ready := util.IsStatefulSetReady(ctx, statefulSet); !ready {
-			r.Log.Info("Waiting for etcd statefulset to become ready")
-			return nil
-		}
-	case hyperv1.Unmanaged:
-		if err := r.reconcileUnmanagedEtcd(ctx, hostedControlPlane, createOrUpdate); err != nil {
-			return fmt.Errorf("failed to reconcile etcd: %w", err)
-		}
-	default:
-		return fmt.Errorf("unrecognized etcd management type: %s", hostedControlPlane.Spec.Etcd.ManagementType)
-	}
-
-	// Reconcile Cloud Provider Config
-	r.Log.Info("Reconciling cloud provider config")
-	if err := r.reconcileCloudProviderConfig(ctx, hostedControlPlane, createOrUpdate); err != nil {
-		return fmt.Errorf("failed to reconcile cloud provider config: %w", err)
-	}
-
-	// Reconcile kube apiserver
-	r.Log.Info("Reconciling Kube API Server")
-	kubeAPIServerDeployment := manifests.KASDeployment(hostedControlPlane.Namespace)
-	if err := r.reconcileKubeAPIServer(ctx, hostedControlPlane, releaseImageProvider, userReleaseImageProvider, infraStatus.APIHost, infraStatus.APIPort, infraStatus.OAuthHost, infraStatus.OAuthPort, createOrUpdate, kubeAPIServerDeployment); err != nil {
-		return fmt.Errorf("failed to reconcile kube apiserver: %w", err)
-	}
-
-	// Block until kube apiserver is fully ready to enforce upgrade order of version skew policy
-	// https://kubernetes.io/releases/version-skew-policy/#supported-component-upgrade-order
-	if ready := util.IsDeploymentReady(ctx, kubeAPIServerDeployment); !ready {
-		r.Log.Info("Waiting for kube apiserver deployment to become ready")
-		return nil
-	}
-
-	// Reconcile kube controller manager
-	r.Log.Info("Reconciling Kube Controller Manager")
-	kcmDeployment := manifests.KCMDeployment(hostedControlPlane.Namespace)
-	if err := r.reconcileKubeControllerManager(ctx, hostedControlPlane, releaseImageProvider, createOrUpdate, kcmDeployment); err != nil {
-		return fmt.Errorf("failed to reconcile kube controller manager: %w", err)
-	}
-
-	// Reconcile kube scheduler
-	r.Log.Info("Reconciling Kube Scheduler")
-	schedulerDeployment := manifests.SchedulerDeployment(hostedControlPlane.Namespace)
-	if err := r.reconcileKubeScheduler(ctx, hostedControlPlane, releaseImageProvider, createOrUpdate, schedulerDeployment); err != nil {
-		return fmt.Errorf("failed to reconcile kube scheduler: %w", err)
-	}
-
 	r.Log.Info("Looking up observed configuration")
 	observedConfig := &globalconfig.ObservedConfig{}
 	if err := globalconfig.ReadObservedConfig(ctx, r.Client, observedConfig, hostedControlPlane.Namespace); err != nil {
 		return fmt.Errorf("failed to read observed global config: %w", err)
 	}
 
-	// Reconcile openshift apiserver
-	r.Log.Info("Reconciling OpenShift API Server")
-	openshiftAPIServerDeployment := manifests.OpenShiftAPIServerDeployment(hostedControlPlane.Namespace)
-	if err := r.reconcileOpenShiftAPIServer(ctx, hostedControlPlane, observedConfig, releaseImageProvider, createOrUpdate, openshiftAPIServerDeployment); err != nil {
-		return fmt.Errorf("failed to reconcile openshift apiserver: %w", err)
-	}
-
-	// Block on kube controller manager being rolled out at the desired version
-	if ready := util.IsDeploymentReady(ctx, kcmDeployment); !ready {
-		r.Log.Info("Waiting for kube controller manager deployment to become ready")
-		return nil
-	}
-
-	// Block on kube scheduler being rolled out at the desired version
-	if ready := util.IsDeploymentReady(ctx, schedulerDeployment); !ready {
-		r.Log.Info("Waiting for kube scheduler deployment to become ready")
-		return nil
-	}
-
-	// Block until openshift apiserver is fully ready to enforce upgrade order of version skew policy
-	// https://github.com/openshift/enhancements/blob/master/enhancements/update/eus-upgrades-mvp.md
-	if ready := util.IsDeploymentReady(ctx, openshiftAPIServerDeployment); !ready {
-		r.Log.Info("Waiting for openshift apiserver deployment to become ready")
-		return nil
-	}
-
-	if err := r.reconcileSREMetricsConfig(ctx, createOrUpdate, hostedControlPlane.Namespace); err != nil {
-		return fmt.Errorf("failed to reconcile metrics config: %w", err)
-	}
-
-	if useHCPRouter(hostedControlPlane) {
-		r.Log.Info("Reconciling router")
-		if err := r.reconcileRouter(ctx, hostedControlPlane, releaseImageProvider, createOrUpdate, util.IsRouteKAS(hostedControlPlane), infraStatus.InternalHCPRouterHost, infraStatus.ExternalHCPRouterHost); err != nil {
-			return fmt.Errorf("failed to reconcile router: %w", err)
-		}
-	}
-
-	r.Log.Info("Reconciling ignition server")
-	if err := ignitionserver.ReconcileIgnitionServer(ctx,
-		r.Client,
-		createOrUpdate,
-		releaseImageProvider.Version(),
-		releaseImageProvider.GetImage(util.CPOImageName),
-		releaseImageProvider.ComponentImages(),
-		hostedControlPlane,
-		r.DefaultIngressDomain,
-		// The healthz handler was added before the CPO started to manage the ignition server, and it's the same binary,
-		// so we know it always exists here.
-		true,
-		r.ReleaseProvider.GetRegistryOverrides(),
-		util.ConvertOpenShiftImageRegistryOverridesToCommandLineFlag(r.ReleaseProvider.GetOpenShiftImageRegistryOverrides()),
-		r.ManagementClusterCapabilities.Has(capabilities.CapabilitySecurityContextConstraint),
-		config.OwnerRefFrom(hostedControlPlane),
-		openShiftTrustedCABundleConfigMapForCPOExists,
-		r.ReleaseProvider.GetMirroredReleaseImage(),
-	); err != nil {
-		return fmt.Errorf("failed to reconcile ignition server: %w", err)
-	}
-
-	// Reconcile Konnectivity
-	r.Log.Info("Reconciling Konnectivity")
-	if err := r.reconcileKonnectivity(ctx, hostedControlPlane, releaseImageProvider, infraStatus, createOrUpdate); err != nil {
-		return fmt.Errorf("failed to reconcile konnectivity: %w", err)
+	graph, err := componentgraph.New(r.controlPlaneComponents(hostedControlPlane, createOrUpdate, releaseImageProvider, userReleaseImageProvider, infraStatus, observedConfig, openShiftTrustedCABundleConfigMapForCPOExists)...)
+	if err != nil {
+		return fmt.Errorf("failed to build control plane component graph: %w", err)
 	}
-
-	if util.HCPOAuthEnabled(hostedControlPlane) {
-		// Reconcile openshift oauth apiserver
-		r.Log.Info("Reconciling OpenShift OAuth API Server")
-		if err := r.reconcileOpenShiftOAuthAPIServer(ctx, hostedControlPlane, observedConfig, releaseImageProvider, createOrUpdate); err != nil {
-			return fmt.Errorf("failed to reconcile openshift oauth apiserver: %w", err)
-		}
-
-		// Reconcile oauth server
-		r.Log.Info("Reconciling OAuth Server")
-		if err := r.reconcileOAuthServer(ctx, hostedControlPlane, releaseImageProvider, infraStatus.OAuthHost, infraStatus.OAuthPort, createOrUpdate); err != nil {
-			return fmt.Errorf("failed to reconcile openshift oauth apiserver: %w", err)
+	results, err := graph.Run(ctx, maxParallelComponentReconciles)
+	r.startup.observe(hostedControlPlane, results)
+	for _, result := range results {
+		switch result.Status {
+		case componentgraph.StatusFailed:
+			r.Log.Info("Failed to reconcile component", "component", result.Name, "duration", result.Duration.String())
+		case componentgraph.StatusBlocked:
+			r.Log.Info("Waiting for dependencies before reconciling component", "component", result.Name, "dependencies", result.BlockedBy)
 		}
 	}
+	return err
+}
 
-	// Reconcile openshift controller manager
-	r.Log.Info("Reconciling OpenShift Controller Manager")
-	if err := r.reconcileOpenShiftControllerManager(ctx, hostedControlPlane, observedConfig, releaseImageProvider, createOrUpdate); err != nil {
-		return fmt.Errorf("failed to reconcile openshift controller manager: %w", err)
-	}
-
-	// Reconcile openshift route controller manager
-	r.Log.Info("Reconciling OpenShift Route Controller Manager")
-	if err := r.reconcileOpenShiftRouteControllerManager(ctx, hostedControlPlane, releaseImageProvider, createOrUpdate); err != nil {
-		return fmt.Errorf("failed to reconcile openshift route controller manager: %w", err)
-	}
-
-	// Reconcile cluster policy controller
-	r.Log.Info("Reconciling Cluster Policy Controller")
-	if err := r.reconcileClusterPolicyController(ctx, hostedControlPlane, releaseImageProvider, createOrUpdate); err != nil {
-		return fmt.Errorf("failed to reconcile cluster policy controller: %w", err)
-	}
-
-	// Reconcile cluster version operator
-	r.Log.Info("Reconciling Cluster Version Operator")
-	if err := r.reconcileClusterVersionOperator(ctx, hostedControlPlane, releaseImageProvider, createOrUpdate); err != nil {
-		return fmt.Errorf("failed to reconcile cluster version operator: %w", err)
+// controlPlaneComponents returns the components of the hosted control plane and their dependencies. Components are
+// reconciled in parallel once all their dependencies are ready.
+func (r *HostedControlPlaneReconciler) controlPlaneComponents(hostedControlPlane *hyperv1.HostedControlPlane, createOrUpdate upsert.CreateOrUpdateFN, releaseImageProvider, userReleaseImageProvider *imageprovider.ReleaseImageProvider, infraStatus InfrastructureStatus, observedConfig *globalconfig.ObservedConfig, openShiftTrustedCABundleConfigMapForCPOExists bool) []componentgraph.Component {
+	components := []componentgraph.Component{
+		{
+			Name: "default-service-account",
+			Reconcile: componentgraph.ReadyOnSuccess(func(ctx context.Context) error {
+				r.Log.Info("Reconciling default service account")
+				if err := r.reconcileDefaultServiceAccount(ctx, hostedControlPlane, createOrUpdate); err != nil {
+					return fmt.Errorf("failed to reconcile default service account: %w", err)
+				}
+				return nil
+			}),
+		},
+		{
+			Name: "cloud-provider-config",
+			Reconcile: componentgraph.ReadyOnSuccess(func(ctx context.Context) error {
+				r.Log.Info("Reconciling cloud provider config")
+				if err := r.reconcileCloudProviderConfig(ctx, hostedControlPlane, createOrUpdate); err != nil {
+					return fmt.Errorf("failed to reconcile cloud provider config: %w", err)
+				}
+				return nil
+			}),
+		},
 	}
 
-	r.Log.Info("Reconciling ClusterNetworkOperator")
-	if err := r.reconcileClusterNetworkOperator(ctx, hostedControlPlane, releaseImageProvider, userReleaseImageProvider, createOrUpdate); err != nil {
-		return fmt.Errorf("failed to reconcile cluster network operator: %w", err)
+	var pkiDependencies []string
+	if _, exists := hostedControlPlane.Annotations[hyperv1.DisablePKIReconciliationAnnotation]; !exists {
+		pkiDependencies = []string{"pki"}
+		components = append(components,
+			componentgraph.Component{
+				Name: "pki",
+				Reconcile: componentgraph.ReadyOnSuccess(func(ctx context.Context) error {
+					r.Log.Info("Reconciling PKI")
+					if err := r.reconcilePKI(ctx, hostedControlPlane, infraStatus, createOrUpdate); err != nil {
+						return fmt.Errorf("failed to reconcile PKI: %w", err)
+					}
+					return nil
+				}),
+			},
+			componentgraph.Component{
+				Name:      "control-plane-pki-operator",
+				DependsOn: pkiDependencies,
+				Reconcile: componentgraph.ReadyOnSuccess(func(ctx context.Context) error {
+					r.Log.Info("Reconciling Control Plane PKI Operator")
+					if err := r.reconcileControlPlanePKIOperator(ctx, hostedControlPlane, releaseImageProvider, createOrUpdate, openShiftTrustedCABundleConfigMapForCPOExists, r.CertRotationScale); err != nil {
+						return fmt.Errorf("failed to reconcile control plane pki operator: %w", err)
+					}
+					return nil
+				}),
+			},
+		)
 	}
 
-	r.Log.Info("Reconciling Cluster Node Tuning Operator")
-	if err := r.reconcileClusterNodeTuningOperator(ctx, hostedControlPlane, releaseImageProvider, userReleaseImageProvider, createOrUpdate); err != nil {
-		return fmt.Errorf("failed to reconcile cluster node tuning operator: %w", err)
-	}
+	kubeAPIServerDeployment := manifests.KASDeployment(hostedControlPlane.Namespace)
+	kcmDeployment := manifests.KCMDeployment(hostedControlPlane.Namespace)
+	schedulerDeployment := manifests.SchedulerDeployment(hostedControlPlane.Namespace)
+	openshiftAPIServerDeployment := manifests.OpenShiftAPIServerDeployment(hostedControlPlane.Namespace)
+	components = append(components,
+		componentgraph.Component{
+			Name:      "etcd",
+			DependsOn: pkiDependencies,
+			Reconcile: func(ctx context.Context) (bool, error) {
+				r.Log.Info("Reconciling Etcd")
+				switch hostedControlPlane.Spec.Etcd.ManagementType {
+				case hyperv1.Managed:
+					statefulSet := manifests.EtcdStatefulSet(hostedControlPlane.Namespace)
+					if err := r.reconcileManagedEtcd(ctx, hostedControlPlane, releaseImageProvider, createOrUpdate, statefulSet); err != nil {
+						return false, fmt.Errorf("failed to reconcile etcd: %w", err)
+					}
+					// Block until etcd is fully rolled out at the desired generation
+					if ready := util.IsStatefulSetReady(ctx, statefulSet); !ready {
+						r.Log.Info("Waiting for etcd statefulset to become ready")
+						return false, nil
+					}
+				case hyperv1.Unmanaged:
+					if err := r.reconcileUnmanagedEtcd(ctx, hostedControlPlane, createOrUpdate); err != nil {
+						return false, fmt.Errorf("failed to reconcile etcd: %w", err)
+					}
+				default:
+					return false, fmt.Errorf("unrecognized etcd management type: %s", hostedControlPlane.Spec.Etcd.ManagementType)
+				}
+				return true, nil
+			},
+		},
+		componentgraph.Component{
+			Name:      "kube-apiserver",
+			DependsOn: append([]string{"default-service-account", "etcd", "cloud-provider-config"}, pkiDependencies...),
+			Reconcile: func(ctx context.Context) (bool, error) {
+				r.Log.Info("Reconciling Kube API Server")
+				if err := r.reconcileKubeAPIServer(ctx, hostedControlPlane, releaseImageProvider, userReleaseImageProvider, infraStatus.APIHost, infraStatus.APIPort, infraStatus.OAuthHost, infraStatus.OAuthPort, createOrUpdate, kubeAPIServerDeployment); err != nil {
+					return false, fmt.Errorf("failed to reconcile kube apiserver: %w", err)
+				}
+				// Block until kube apiserver is fully ready to enforce upgrade order of version skew policy
+				// https://kubernetes.io/releases/version-skew-policy/#supported-component-upgrade-order
+				if ready := util.IsDeploymentReady(ctx, kubeAPIServerDeployment); !ready {
+					r.Log.Info("Waiting for kube apiserver deployment to become ready")
+					return false, nil
+				}
+				return true, nil
+			},
+		},
+		componentgraph.Component{
+			Name:      "kube-controller-manager",
+			DependsOn: []string{"kube-apiserver"},
+			Reconcile: func(ctx context.Context) (bool, error) {
+				r.Log.Info("Reconciling Kube Controller Manager")
+				if err := r.reconcileKubeControllerManager(ctx, hostedControlPlane, releaseImageProvider, createOrUpdate, kcmDeployment); err != nil {
+					return false, fmt.Errorf("failed to reconcile kube controller manager: %w", err)
+				}
+				// Block on kube controller manager being rolled out at the desired version
+				if ready := util.IsDeploymentReady(ctx, kcmDeployment); !ready {
+					r.Log.Info("Waiting for kube controller manager deployment to become ready")
+					return false, nil
+				}
+				return true, nil
+			},
+		},
+		componentgraph.Component{
+			Name:      "kube-scheduler",
+			DependsOn: []string{"kube-apiserver"},
+			Reconcile: func(ctx context.Context) (bool, error) {
+				r.Log.Info("Reconciling Kube Scheduler")
+				if err := r.reconcileKubeScheduler(ctx, hostedControlPlane, releaseImageProvider, createOrUpdate, schedulerDeployment); err != nil {
+					return false, fmt.Errorf("failed to reconcile kube scheduler: %w", err)
+				}
+				// Block on kube scheduler being rolled out at the desired version
+				if ready := util.IsDeploymentReady(ctx, schedulerDeployment); !ready {
+					r.Log.Info("Waiting for kube scheduler deployment to become ready")
+					return false, nil
+				}
+				return true, nil
+			},
+		},
+		componentgraph.Component{
+			Name:      "openshift-apiserver",
+			DependsOn: []string{"kube-apiserver"},
+			Reconcile: func(ctx context.Context) (bool, error) {
+				r.Log.Info("Reconciling OpenShift API Server")
+				if err := r.reconcileOpenShiftAPIServer(ctx, hostedControlPlane, observedConfig, releaseImageProvider, createOrUpdate, openshiftAPIServerDeployment); err != nil {
+					return false, fmt.Errorf("failed to reconcile openshift apiserver: %w", err)
+				}
+				// Block until openshift apiserver is fully ready to enforce upgrade order of version skew policy
+				// https://github.com/openshift/enhancements/blob/master/enhancements/update/eus-upgrades-mvp.md
+				if ready := util.IsDeploymentReady(ctx, openshiftAPIServerDeployment); !ready {
+					r.Log.Info("Waiting for openshift apiserver deployment to become ready")
+					return false, nil
+				}
+				return true, nil
+			},
+		},
+	)
 
-	r.Log.Info("Reconciling DNSOperator")
-	if err := r.reconcileDNSOperator(ctx, hostedControlPlane, releaseImageProvider, userReleaseImageProvider, createOrUpdate); err != nil {
-		return fmt.Errorf("failed to reconcile DNS operator: %w", err)
+	// The remaining components are reconciled once the core control plane is rolled out at the desired version.
+	coreComponents := []string{"kube-controller-manager", "kube-scheduler", "openshift-apiserver"}
+	component := func(name, description string, reconcile func(ctx context.Context) error) componentgraph.Component {
+		return componentgraph.Component{
+			Name:      name,
+			DependsOn: coreComponents,
+			Reconcile: componentgraph.ReadyOnSuccess(func(ctx context.Context) error {
+				if description != "" {
+					r.Log.Info("Reconciling " + description)
+				}
+				return reconcile(ctx)
+			}),
+		}
 	}
 
-	r.Log.Info("Reconciling IngressOperator")
-	if err := r.reconcileIngressOperator(ctx, hostedControlPlane, releaseImageProvider, userReleaseImageProvider, createOrUpdate); err != nil {
-		return fmt.Errorf("failed to reconcile ingress operator: %w", err)
-	}
+	components = append(components,
+		component("sre-metrics-config", "", func(ctx context.Context) error {
+			if err := r.reconcileSREMetricsConfig(ctx, createOrUpdate, hostedControlPlane.Namespace); err != nil {
+				return fmt.Errorf("failed to reconcile metrics config: %w", err)
+			}
+			return nil
+		}),
+		component("ignition-server", "ignition server", func(ctx context.Context) error {
+			if err := ignitionserver.ReconcileIgnitionServer(ctx,
+				r.Client,
+				createOrUpdate,
+				releaseImageProvider.Version(),
+				releaseImageProvider.GetImage(util.CPOImageName),
+				releaseImageProvider.ComponentImages(),
+				hostedControlPlane,
+				r.DefaultIngressDomain,
+				// The healthz handler was added before the CPO started to manage the ignition server, and it's the same binary,
+				// so we know it always exists here.
+				true,
+				r.ReleaseProvider.GetRegistryOverrides(),
+				util.ConvertOpenShiftImageRegistryOverridesToCommandLineFlag(r.ReleaseProvider.GetOpenShiftImageRegistryOverrides()),
+				r.ManagementClusterCapabilities.Has(capabilities.CapabilitySecurityContextConstraint),
+				config.OwnerRefFrom(hostedControlPlane),
+				openShiftTrustedCABundleConfigMapForCPOExists,
+				r.ReleaseProvider.GetMirroredReleaseImage(),
+			); err != nil {
+				return fmt.Errorf("failed to reconcile ignition server: %w", err)
+			}
+			return nil
+		}),
+		component("konnectivity", "Konnectivity", func(ctx context.Context) error {
+			if err := r.reconcileKonnectivity(ctx, hostedControlPlane, releaseImageProvider, infraStatus, createOrUpdate); err != nil {
+				return fmt.Errorf("failed to reconcile konnectivity: %w", err)
+			}
+			if err := r.reconcileKonnectivityRevocationStatus(ctx, hostedControlPlane, kubeAPIServerDeployment); err != nil {
+				return fmt.Errorf("failed to reconcile konnectivity certificate revocation status: %w", err)
+			}
+			return nil
+		}),
+		component("openshift-controller-manager", "OpenShift Controller Manager", func(ctx context.Context) error {
+			if err := r.reconcileOpenShiftControllerManager(ctx, hostedControlPlane, observedConfig, releaseImageProvider, createOrUpdate); err != nil {
+				return fmt.Errorf("failed to reconcile openshift controller manager: %w", err)
+			}
+			return nil
+		}),
+		component("openshift-route-controller-manager", "OpenShift Route Controller Manager", func(ctx context.Context) error {
+			if err := r.reconcileOpenShiftRouteControllerManager(ctx, hostedControlPlane, releaseImageProvider, createOrUpdate); err != nil {
+				return fmt.Errorf("failed to reconcile openshift route controller manager: %w", err)
+			}
+			return nil
+		}),
+		component("cluster-policy-controller", "Cluster Policy Controller", func(ctx context.Context) error {
+			if err := r.reconcileClusterPolicyController(ctx, hostedControlPlane, releaseImageProvider, createOrUpdate); err != nil {
+				return fmt.Errorf("failed to reconcile cluster policy controller: %w", err)
+			}
+			return nil
+		}),
+		component("cluster-version-operator", "Cluster Version Operator", func(ctx context.Context) error {
+			if err := r.reconcileClusterVersionOperator(ctx, hostedControlPlane, releaseImageProvider, createOrUpdate); err != nil {
+				return fmt.Errorf("failed to reconcile cluster version operator: %w", err)
+			}
+			return nil
+		}),
+		component("cluster-network-operator", "ClusterNetworkOperator", func(ctx context.Context) error {
+			if err := r.reconcileClusterNetworkOperator(ctx, hostedControlPlane, releaseImageProvider, userReleaseImageProvider, createOrUpdate); err != nil {
+				return fmt.Errorf("failed to reconcile cluster network operator: %w", err)
+			}
+			return nil
+		}),
+		component("cluster-node-tuning-operator", "Cluster Node Tuning Operator", func(ctx context.Context) error {
+			if err := r.reconcileClusterNodeTuningOperator(ctx, hostedControlPlane, releaseImageProvider, userReleaseImageProvider, createOrUpdate); err != nil {
+				return fmt.Errorf("failed to reconcile cluster node tuning operator: %w", err)
+			}
+			return nil
+		}),
+		component("dns-operator", "DNSOperator", func(ctx context.Context) error {
+			if err := r.reconcileDNSOperator(ctx, hostedControlPlane, releaseImageProvider, userReleaseImageProvider, createOrUpdate); err != nil {
+				return fmt.Errorf("failed to reconcile DNS operator: %w", err)
+			}
+			return nil
+		}),
+		component("ingress-operator", "IngressOperator", func(ctx context.Context) error {
+			if err := r.reconcileIngressOperator(ctx, hostedControlPlane, releaseImageProvider, userReleaseImageProvider, createOrUpdate); err != nil {
+				return fmt.Errorf("failed to reconcile ingress operator: %w", err)
+			}
+			return nil
+		}),
+		component("hosted-cluster-config-operator", "Hosted Cluster Config Operator", func(ctx context.Context) error {
+			if err := r.reconcileHostedClusterConfigOperator(ctx, hostedControlPlane, userReleaseImageProvider, infraStatus, createOrUpdate, openShiftTrustedCABundleConfigMapForCPOExists); err != nil {
+				return fmt.Errorf("failed to reconcile hosted cluster config operator: %w", err)
+			}
+			return nil
+		}),
+		component("cloud-controller-manager", "Cloud Controller Manager", func(ctx context.Context) error {
+			if err := r.reconcileCloudControllerManager(ctx, hostedControlPlane, releaseImageProvider, createOrUpdate); err != nil {
+				return fmt.Errorf("failed to reconcile cloud controller manager: %w", err)
+			}
+			return nil
+		}),
+		component("operator-lifecycle-manager", "OLM", func(ctx context.Context) error {
+			if err := r.reconcileOperatorLifecycleManager(ctx, hostedControlPlane, releaseImageProvider, userReleaseImageProvider, createOrUpdate); err != nil {
+				return fmt.Errorf("failed to reconcile olm: %w", err)
+			}
+			return nil
+		}),
+		component("image-registry-operator", "Image Registry Operator", func(ctx context.Context) error {
+			if err := r.reconcileImageRegistryOperator(ctx, hostedControlPlane, releaseImageProvider, userReleaseImageProvider, createOrUpdate); err != nil {
+				return fmt.Errorf("failed to reconcile image registry operator: %w", err)
+			}
+			return nil
+		}),
+		component("core-ignition-config", "core machine configs", func(ctx context.Context) error {
+			if err := r.reconcileCoreIgnitionConfig(ctx, hostedControlPlane, createOrUpdate); err != nil {
+				return fmt.Errorf("failed to reconcile ignition: %w", err)
+			}
+			return nil
+		}),
+		component("machine-config-server-config", "machine config server config", func(ctx context.Context) error {
+			if err := r.reconcileMachineConfigServerConfig(ctx, hostedControlPlane, createOrUpdate); err != nil {
+				return fmt.Errorf("failed to reconcile mcs config: %w", err)
+			}
+			return nil
+		}),
+		component("default-security-group", "default security group", func(ctx context.Context) error {
+			// The security group status is patched onto a copy, the HostedControlPlane is read concurrently by the other components.
+			if err := r.reconcileDefaultSecurityGroup(ctx, hostedControlPlane.DeepCopy()); err != nil {
+				return fmt.Errorf("failed to reconcile default security group")
+			}
+			return nil
+		}),
+		component("nodepool-security-groups", "NodePool security groups", func(ctx context.Context) error {
+			if err := r.reconcileNodePoolSecurityGroups(ctx, hostedControlPlane); err != nil {
+				return fmt.Errorf("failed to reconcile NodePool security groups: %w", err)
+			}
+			return nil
+		}),
+		component("cross-zone-traffic", "", func(ctx context.Context) error {
+			if err := r.reportCrossZoneTraffic(ctx, hostedControlPlane); err != nil {
+				return fmt.Errorf("failed to report cross-zone traffic: %w", err)
+			}
+			return nil
+		}),
+	)
 
-	// Reconcile hosted cluster config operator
-	r.Log.Info("Reconciling Hosted Cluster Config Operator")
-	if err := r.reconcileHostedClusterConfigOperator(ctx, hostedControlPlane, userReleaseImageProvider, infraStatus, createOrUpdate, openShiftTrustedCABundleConfigMapForCPOExists); err != nil {
-		return fmt.Errorf("failed to reconcile hosted cluster config operator: %w", err)
+	if useHCPRouter(hostedControlPlane) {
+		components = append(components, component("router", "router", func(ctx context.Context) error {
+			if err := r.reconcileRouter(ctx, hostedControlPlane, releaseImageProvider, createOrUpdate, util.IsRouteKAS(hostedControlPlane), infraStatus.InternalHCPRouterHost, infraStatus.ExternalHCPRouterHost); err != nil {
+				return fmt.Errorf("failed to reconcile router: %w", err)
+			}
+			return nil
+		}))
 	}
 
-	// Reconcile cloud controller manager
-	r.Log.Info("Reconciling Cloud Controller Manager")
-	if err := r.reconcileCloudControllerManager(ctx, hostedControlPlane, releaseImageProvider, createOrUpdate); err != nil {
-		return fmt.Errorf("failed to reconcile cloud controller manager: %w", err)
+	if util.HCPOAuthEnabled(hostedControlPlane) {
+		components = append(components,
+			component("openshift-oauth-apiserver", "OpenShift OAuth API Server", func(ctx context.Context) error {
+				if err := r.reconcileOpenShiftOAuthAPIServer(ctx, hostedControlPlane, observedConfig, releaseImageProvider, createOrUpdate); err != nil {
+					return fmt.Errorf("failed to reconcile openshift oauth apiserver: %w", err)
+				}
+				return nil
+			}),
+			component("oauth-server", "OAuth Server", func(ctx context.Context) error {
+				if err := r.reconcileOAuthServer(ctx, hostedControlPlane, releaseImageProvider, infraStatus.OAuthHost, infraStatus.OAuthPort, createOrUpdate); err != nil {
+					return fmt.Errorf("failed to reconcile openshift oauth apiserver: %w", err)
+				}
+				return nil
+			}),
+			component("kubeadmin-password", "kubeadmin password secret", func(ctx context.Context) error {
+				explicitOauthConfig := hostedControlPlane.Spec.Configuration != nil && hostedControlPlane.Spec.Configuration.OAuth != nil
+				if err := r.reconcileKubeadminPassword(ctx, hostedControlPlane, explicitOauthConfig, createOrUpdate); err != nil {
+					return fmt.Errorf("failed to ensure control plane: %w", err)
+				}
+				return nil
+			}),
+		)
 	}
 
 	if hostedControlPlane.Spec.Platform.Type == hyperv1.AWSPlatform {
-		// Reconcile cloud credential operator
-		r.Log.Info("Reconciling Cloud Credential Operator")
-		if err := r.reconcileCloudCredentialOperator(ctx, hostedControlPlane, releaseImageProvider, createOrUpdate); err != nil {
-			return fmt.Errorf("failed to reconcile cloud controller manager: %w", err)
-		}
-	}
-
-	// Reconcile OLM
-	r.Log.Info("Reconciling OLM")
-	if err := r.reconcileOperatorLifecycleManager(ctx, hostedControlPlane, releaseImageProvider, userReleaseImageProvider, createOrUpdate); err != nil {
-		return fmt.Errorf("failed to reconcile olm: %w", err)
+		components = append(components,
+			component("cloud-credential-operator", "Cloud Credential Operator", func(ctx context.Context) error {
+				if err := r.reconcileCloudCredentialOperator(ctx, hostedControlPlane, releaseImageProvider, createOrUpdate); err != nil {
+					return fmt.Errorf("failed to reconcile cloud controller manager: %w", err)
+				}
+				return nil
+			}),
+			// TODO: consider using a side container in the etcd pod instead.
+			component("etcd-backup", "etcd-backup cronJob", func(ctx context.Context) error {
+				return r.reconcileEtcdBackup(ctx, hostedControlPlane, releaseImageProvider, createOrUpdate)
+			}),
+		)
 	}
 
-	// Reconcile image registry operator
-	r.Log.Info("Reconciling Image Registry Operator")
-	if err := r.reconcileImageRegistryOperator(ctx, hostedControlPlane, releaseImageProvider, userReleaseImageProvider, createOrUpdate); err != nil {
-		return fmt.Errorf("failed to reconcile image registry operator: %w", err)
+	if ibmCloudKMSSpec(hostedControlPlane) != nil {
+		components = append(components,
+			component("kms-reencrypt", "KMS re-encryption job", func(ctx context.Context) error {
+				return r.reconcileKMSReencryption(ctx, hostedControlPlane, releaseImageProvider, createOrUpdate, kubeAPIServerDeployment)
+			}),
+		)
 	}
 
 	if IsStorageAndCSIManaged(hostedControlPlane) {
-		// Reconcile cluster storage operator
-		r.Log.Info("Reconciling cluster storage operator")
-		if err := r.reconcileClusterStorageOperator(ctx, hostedControlPlane, releaseImageProvider, userReleaseImageProvider, createOrUpdate); err != nil {
-			return fmt.Errorf("failed to reconcile cluster storage operator: %w", err)
-		}
-	}
-
-	// Reconcile Ignition
-	r.Log.Info("Reconciling core machine configs")
-	if err := r.reconcileCoreIgnitionConfig(ctx, hostedControlPlane, createOrUpdate); err != nil {
-		return fmt.Errorf("failed to reconcile ignition: %w", err)
-	}
-
-	// Reconcile machine config server config
-	r.Log.Info("Reconciling machine config server config")
-	if err := r.reconcileMachineConfigServerConfig(ctx, hostedControlPlane, createOrUpdate); err != nil {
-		return fmt.Errorf("failed to reconcile mcs config: %w", err)
+		components = append(components,
+			component("cluster-storage-operator", "cluster storage operator", func(ctx context.Context) error {
+				if err := r.reconcileClusterStorageOperator(ctx, hostedControlPlane, releaseImageProvider, userReleaseImageProvider, createOrUpdate); err != nil {
+					return fmt.Errorf("failed to reconcile cluster storage operator: %w", err)
+				}
+				return nil
+			}),
+			component("csi-driver", "CSI Driver", func(ctx context.Context) error {
+				if err := r.reconcileCSIDriver(ctx, hostedControlPlane, releaseImageProvider, createOrUpdate); err != nil {
+					return fmt.Errorf("failed to reconcile csi driver: %w", err)
+				}
+				return nil
+			}),
+			component("csi-snapshot-controller-operator", "CSI snapshot controller operator", func(ctx context.Context) error {
+				if err := r.reconcileCSISnapshotControllerOperator(ctx, hostedControlPlane, releaseImageProvider, userReleaseImageProvider, createOrUpdate); err != nil {
+					return fmt.Errorf("failed to reconcile CSI snapshot controller operator: %w", err)
+				}
+				return nil
+			}),
+		)
 	}
 
-	// Reconcile kubeadmin password
-	if util.HCPOAuthEnabled(hostedControlPlane) {
-		r.Log.Info("Reconciling kubeadmin password secret")
-		explicitOauthConfig := hostedControlPlane.Spec.Configuration != nil && hostedControlPlane.Spec.Configuration.OAuth != nil
-		if err := r.reconcileKubeadminPassword(ctx, hostedControlPlane, explicitOauthConfig, createOrUpdate); err != nil {
-			return fmt.Errorf("failed to ensure control plane: %w", err)
-		}
+	// Disable machine management components if enabled
+	if _, exists := hostedControlPlane.Annotations[hyperv1.DisableMachineManagement]; !exists {
+		components = append(components,
+			component("autoscaler", "autoscaler", func(ctx context.Context) error {
+				if err := r.reconcileAutoscaler(ctx, hostedControlPlane, releaseImageProvider, createOrUpdate); err != nil {
+					return fmt.Errorf("failed to reconcile autoscaler: %w", err)
+				}
+				return nil
+			}),
+			component("machine-approver", "machine approver", func(ctx context.Context) error {
+				if err := r.reconcileMachineApprover(ctx, hostedControlPlane, releaseImageProvider, createOrUpdate); err != nil {
+					return fmt.Errorf("failed to reconcile machine approver: %w", err)
+				}
+				return nil
+			}),
+		)
 	}
 
-	if IsStorageAndCSIManaged(hostedControlPlane) {
-		// Reconcile cloud csi driver
-		r.Log.Info("Reconciling CSI Driver")
-		if err := r.reconcileCSIDriver(ctx, hostedControlPlane, releaseImageProvider, createOrUpdate); err != nil {
-			return fmt.Errorf("failed to reconcile csi driver: %w", err)
-		}
+	return components
+}
 
-		// Reconcile CSI snapshot controller operator
-		r.Log.Info("Reconciling CSI snapshot controller operator")
-		if err := r.reconcileCSISnapshotControllerOperator(ctx, hostedControlPlane, releaseImageProvider, userReleaseImageProvider, createOrUpdate); err != nil {
-			return fmt.Errorf("failed to reconcile CSI snapshot controller operator: %w", err)
+func (r *HostedControlPlaneReconciler) reconcileEtcdBackup(ctx context.Context, hostedControlPlane *hyperv1.HostedControlPlane, releaseImageProvider *imageprovider.ReleaseImageProvider, createOrUpdate upsert.CreateOrUpdateFN) error {
+	configMapName := "etcd-backup-config" // TODO: get configMap name from annotation?
+	configMap := &corev1.ConfigMap{}
+	if err := r.Client.Get(ctx, client.ObjectKey{Namespace: hostedControlPlane.Namespace, Name: configMapName}, configMap); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get etcd backup configMap: %w", err)
 		}
-	}
 
-	// Disable machine management components if enabled
-	if _, exists := hostedControlPlane.Annotations[hyperv1.DisableMachineManagement]; !exists {
-		r.Log.Info("Reconciling autoscaler")
-		if err := r.reconcileAutoscaler(ctx, hostedControlPlane, releaseImageProvider, createOrUpdate); err != nil {
-			return fmt.Errorf("failed to reconcile autoscaler: %w", err)
+		serviceAccount := manifests.EtcdBackupServiceAccount(hostedControlPlane.Namespace)
+		if _, err := util.DeleteIfNeeded(ctx, r.Client, serviceAccount); err != nil {
+			return fmt.Errorf("failed to delete etcd backup service account: %w", err)
 		}
-
-		r.Log.Info("Reconciling machine approver")
-		if err := r.reconcileMachineApprover(ctx, hostedControlPlane, releaseImageProvider, createOrUpdate); err != nil {
-			return fmt.Errorf("failed to reconcile machine approver: %w", err)
+		cronJob := manifests.EtcdBackupCronJob(hostedControlPlane.Namespace)
+		if _, err := util.DeleteIfNeeded(ctx, r.Client, cronJob); err != nil {
+			return fmt.Errorf("failed to delete etcd backup cronJob: %w", err)
 		}
+		return nil
 	}
 
-	r.Log.Info("Reconciling default security group")
-	if err := r.reconcileDefaultSecurityGroup(ctx, hostedControlPlane); err != nil {
-		return fmt.Errorf("failed to reconcile default security group")
+	serviceAccount := manifests.EtcdBackupServiceAccount(hostedControlPlane.Namespace)
+	if _, err := createOrUpdate(ctx, r.Client, serviceAccount, func() error {
+		util.EnsurePullSecret(serviceAccount, common.PullSecret("").Name)
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to reconcile etcd-backup cronJob service account: %w", err)
 	}
 
-	// TODO: consider using a side container in the etcd pod instead.
-	r.Log.Info("Reconciling etcd-backup cronJob")
-	if hostedControlPlane.Spec.Platform.Type == hyperv1.AWSPlatform {
-		configMapName := "etcd-backup-config" // TODO: get configMap name from annotation?
-		configMap := &corev1.ConfigMap{}
-		if err := r.Client.Get(ctx, client.ObjectKey{Namespace: hostedControlPlane.Namespace, Name: configMapName}, configMap); err != nil {
-			if !apierrors.IsNotFound(err) {
-				return fmt.Errorf("failed to get etcd backup configMap: %w", err)
-			}
-
-			serviceAccount := manifests.EtcdBackupServiceAccount(hostedControlPlane.Namespace)
-			if _, err := util.DeleteIfNeeded(ctx, r.Client, serviceAccount); err != nil {
-				return fmt.Errorf("failed to delete etcd backup service account: %w", err)
-			}
-			cronJob := manifests.EtcdBackupCronJob(hostedControlPlane.Namespace)
-			if _, err := util.DeleteIfNeeded(ctx, r.Client, cronJob); err != nil {
-				return fmt.Errorf("failed to delete etcd backup cronJob: %w", err)
-			}
-		} else {
-			serviceAccount := manifests.EtcdBackupServiceAccount(hostedControlPlane.Namespace)
-			if _, err = createOrUpdate(ctx, r.Client, serviceAccount, func() error {
-				util.EnsurePullSecret(serviceAccount, common.PullSecret("").Name)
-				return nil
-			}); err != nil {
-				return fmt.Errorf("failed to reconcile etcd-backup cronJob service account: %w", err)
-			}
-
-			cronJob := manifests.EtcdBackupCronJob(hostedControlPlane.Namespace)
-			if _, err = createOrUpdate(ctx, r.Client, cronJob, func() error {
-				return r.reconcileEtcdBackupCronJob(cronJob,
-					configMap,
-					serviceAccount,
-					hostedControlPlane,
-					releaseImageProvider.GetImage(util.CPOImageName),
-					releaseImageProvider.GetImage("etcd"))
-			}); err != nil {
-				return fmt.Errorf("failed to reconcile etcd-backup cronJob: %w", err)
-			}
-		}
+	cronJob := manifests.EtcdBackupCronJob(hostedControlPlane.Namespace)
+	if _, err := createOrUpdate(ctx, r.Client, cronJob, func() error {
+		return r.reconcileEtcdBackupCronJob(cronJob,
+			configMap,
+			serviceAccount,
+			hostedControlPlane,
+			releaseImageProvider.GetImage(util.CPOImageName),
+			releaseImageProvider.GetImage("etcd"))
+	}); err != nil {
+		return fmt.Errorf("failed to reconcile etcd-backup cronJob: %w", err)
 	}
-
 	return nil
 }
 
@@ -1564,7 +1842,7 @@ func (r *HostedControlPlaneReconciler) reconcileAPIServerService(ctx context.Con
 		// destination for the HCP router
 		internalRoute := manifests.KubeAPIServerInternalRoute(hcp.Namespace)
 		if _, err := createOrUpdate(ctx, r.Client, internalRoute, func() error {
-			return kas.ReconcileInternalRoute(internalRoute, p.OwnerReference)
+			return kas.ReconcileInternalRoute(internalRoute, p.OwnerReference, util.InternalDNSDomain(hcp.Name, hcp.Spec.DNS))
 		}); err != nil {
 			return fmt.Errorf("failed to reconcile apiserver internal route %s: %w", internalRoute.Name, err)
 		}
@@ -1599,7 +1877,7 @@ func (r *HostedControlPlaneReconciler) reconcileKonnectivityServerService(ctx co
 	konnectivityRoute := manifests.KonnectivityServerRoute(hcp.Namespace)
 	if util.IsPrivateHCP(hcp) {
 		if _, err := createOrUpdate(ctx, r.Client, konnectivityRoute, func() error {
-			return kas.ReconcileKonnectivityInternalRoute(konnectivityRoute, p.OwnerRef)
+			return kas.ReconcileKonnectivityInternalRoute(konnectivityRoute, p.OwnerRef, util.InternalDNSDomain(hcp.Name, hcp.Spec.DNS))
 		}); err != nil {
 			return fmt.Errorf("failed to reconcile Konnectivity server internal route: %w", err)
 		}
@@ -1682,7 +1960,7 @@ func (r *HostedControlPlaneReconciler) reconcileOAuthServerService(ctx context.C
 	if util.IsPrivateHCP(hcp) {
 		oauthInternalRoute := manifests.OauthServerInternalRoute(hcp.Namespace)
 		if _, err := createOrUpdate(ctx, r.Client, oauthInternalRoute, func() error {
-			return oauth.ReconcileInternalRoute(oauthInternalRoute, p.OwnerRef)
+			return oauth.ReconcileInternalRoute(oauthInternalRoute, p.OwnerRef, util.InternalDNSDomain(hcp.Name, hcp.Spec.DNS))
 		}); err != nil {
 			return fmt.Errorf("failed to reconcile OAuth internal route: %w", err)
 		}
@@ -2147,7 +2425,7 @@ func (r *HostedControlPlaneReconciler) reconcilePKI(ctx context.Context, hcp *hy
 	// KAS server secret
 	kasServerSecret := manifests.KASServerCertSecret(hcp.Namespace)
 	if _, err := createOrUpdate(ctx, r, kasServerSecret, func() error {
-		return pki.ReconcileKASServerCertSecret(kasServerSecret, rootCASecret, p.OwnerRef, p.ExternalAPIAddress, p.InternalAPIAddress, p.ServiceCIDR, p.NodeInternalAPIServerIP)
+		return pki.ReconcileKASServerCertSecret(kasServerSecret, rootCASecret, p.OwnerRef, p.ExternalAPIAddress, p.InternalAPIAddress, p.ExternalAPIAdditionalAddresses, p.ServiceCIDR, p.NodeInternalAPIServerIP)
 	}); err != nil {
 		return fmt.Errorf("failed to reconcile kas server secret: %w", err)
 	}
@@ -2211,9 +2489,13 @@ func (r *HostedControlPlaneReconciler) reconcilePKI(ctx context.Context, hcp *hy
 		return fmt.Errorf("failed to reconcile cluster policy controller cert: %w", err)
 	}
 
+	konnectivityRevokedBefore, revocationErr := r.konnectivityRevocationTimestamp(ctx, hcp)
+	if revocationErr != nil {
+		return revocationErr
+	}
 	konnectivitySigner := manifests.KonnectivitySignerSecret(hcp.Namespace)
 	if _, err := createOrUpdate(ctx, r, konnectivitySigner, func() error {
-		return pki.ReconcileKonnectivitySignerSecret(konnectivitySigner, p.OwnerRef)
+		return pki.ReconcileKonnectivitySignerSecret(konnectivitySigner, p.OwnerRef, konnectivityRevokedBefore)
 	}); err != nil {
 		return fmt.Errorf("failed to reconcile konnectivity signer secret: %v", err)
 	}
@@ -2583,6 +2865,19 @@ func (r *HostedControlPlaneReconciler) reconcileManagedEtcd(ctx context.Context,
 		r.Log.Info("reconciled etcd client service", "result", result)
 	}
 
+	zonalClientService := manifests.EtcdClientZonalService(hcp.Namespace)
+	if hcp.Spec.ControllerAvailabilityPolicy == hyperv1.HighlyAvailable {
+		if result, err := createOrUpdate(ctx, r, zonalClientService, func() error {
+			return etcd.ReconcileZonalClientService(zonalClientService, p.OwnerRef)
+		}); err != nil {
+			return fmt.Errorf("failed to reconcile etcd zonal client service: %w", err)
+		} else {
+			r.Log.Info("reconciled etcd zonal client service", "result", result)
+		}
+	} else if _, err := util.DeleteIfNeeded(ctx, r, zonalClientService); err != nil {
+		return fmt.Errorf("failed to delete etcd zonal client service: %w", err)
+	}
+
 	serviceMonitor := manifests.EtcdServiceMonitor(hcp.Namespace)
 	if result, err := createOrUpdate(ctx, r, serviceMonitor, func() error {
 		return etcd.ReconcileServiceMonitor(serviceMonitor, p.OwnerRef, hcp.Spec.ClusterID, r.MetricsSet)
@@ -2648,16 +2943,18 @@ func (r *HostedControlPlaneReconciler) reconcileUnmanagedEtcd(ctx context.Contex
 	if err := r.Client.Get(ctx, client.ObjectKey{Namespace: hcp.GetNamespace(), Name: hcp.Spec.Etcd.Unmanaged.TLS.ClientSecret.Name}, &src); err != nil {
 		return fmt.Errorf("failed to get etcd client cert %s: %w", hcp.Spec.Etcd.Unmanaged.TLS.ClientSecret.Name, err)
 	}
-	if _, ok := src.Data["etcd-client.crt"]; !ok {
-		return fmt.Errorf("etcd secret %s does not have client cert", hcp.Spec.Etcd.Unmanaged.TLS.ClientSecret.Name)
-	}
-	if _, ok := src.Data["etcd-client.key"]; !ok {
-		return fmt.Errorf("etcd secret %s does not have client key", hcp.Spec.Etcd.Unmanaged.TLS.ClientSecret.Name)
-	}
-	if _, ok := src.Data["etcd-client-ca.crt"]; !ok {
-		return fmt.Errorf("etcd secret %s does not have client ca", hcp.Spec.Etcd.Unmanaged.TLS.ClientSecret.Name)
-	}
 	kubeComponentEtcdClientSecret := manifests.EtcdClientSecret(hcp.GetNamespace())
+	if validationErr := r.validateUnmanagedEtcd(ctx, hcp, &src); validationErr != nil {
+		// Don't roll out an invalid client certificate to the kube-apiserver, which would crashloop, but keep using
+		// the previous one. The EtcdAvailable condition reports the invalid certificate.
+		if err := r.Client.Get(ctx, client.ObjectKeyFromObject(kubeComponentEtcdClientSecret), kubeComponentEtcdClientSecret); err == nil {
+			r.Log.Info("Not rolling out invalid etcd client tls secret, keeping the previous one", "name", src.Name, "error", validationErr.Error())
+			return nil
+		} else if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get etcd client tls secret: %w", err)
+		}
+		return validationErr
+	}
 	r.Log.Info("Reconciling openshift control plane etcd client tls secret", "name", kubeComponentEtcdClientSecret.Name)
 	_, err := createOrUpdate(ctx, r.Client, kubeComponentEtcdClientSecret, func() error {
 		if kubeComponentEtcdClientSecret.Data == nil {
@@ -2670,6 +2967,60 @@ func (r *HostedControlPlaneReconciler) reconcileUnmanagedEtcd(ctx context.Contex
 	return err
 }
 
+// validateUnmanagedEtcd validates the TLS client secret of an unmanaged etcd, and checks the etcd endpoint is
+// reachable and its serving certificate is valid for the endpoint with it.
+func (r *HostedControlPlaneReconciler) validateUnmanagedEtcd(ctx context.Context, hcp *hyperv1.HostedControlPlane, secret *corev1.Secret) error {
+	tlsConfig, err := etcd.ValidateUnmanagedClientSecret(secret, time.Now())
+	if err != nil {
+		return err
+	}
+	return etcd.CheckUnmanagedEndpoint(ctx, hcp.Spec.Etcd.Unmanaged.Endpoint, tlsConfig)
+}
+
+// unmanagedEtcdCondition returns the EtcdAvailable condition of an unmanaged etcd. When a rotation of the TLS client
+// secret is rejected but the previous client certificate still works, etcd is available and the condition reports the
+// reason the rotation was rejected.
+func (r *HostedControlPlaneReconciler) unmanagedEtcdCondition(ctx context.Context, hcp *hyperv1.HostedControlPlane) metav1.Condition {
+	condition := metav1.Condition{
+		Type:   string(hyperv1.EtcdAvailable),
+		Status: metav1.ConditionFalse,
+		Reason: hyperv1.UnmanagedEtcdMisconfiguredReason,
+	}
+	if hcp.Spec.Etcd.Unmanaged == nil || len(hcp.Spec.Etcd.Unmanaged.TLS.ClientSecret.Name) == 0 || len(hcp.Spec.Etcd.Unmanaged.Endpoint) == 0 {
+		condition.Message = "etcd metadata not specified for unmanaged deployment"
+		return condition
+	}
+
+	src := &corev1.Secret{}
+	if err := r.Client.Get(ctx, client.ObjectKey{Namespace: hcp.Namespace, Name: hcp.Spec.Etcd.Unmanaged.TLS.ClientSecret.Name}, src); err != nil {
+		condition.Message = fmt.Sprintf("failed to get etcd client cert %s: %v", hcp.Spec.Etcd.Unmanaged.TLS.ClientSecret.Name, err)
+		return condition
+	}
+	srcErr := r.validateUnmanagedEtcd(ctx, hcp, src)
+	if srcErr == nil {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "EtcdRunning"
+		condition.Message = "Etcd endpoint is reachable with the TLS client certificate"
+		return condition
+	}
+	condition.Reason = etcd.UnmanagedEtcdErrorReason(srcErr)
+	condition.Message = srcErr.Error()
+
+	inUse := manifests.EtcdClientSecret(hcp.Namespace)
+	if err := r.Client.Get(ctx, client.ObjectKeyFromObject(inUse), inUse); err != nil {
+		return condition
+	}
+	if equality.Semantic.DeepEqual(inUse.Data, src.Data) {
+		return condition
+	}
+	if err := r.validateUnmanagedEtcd(ctx, hcp, inUse); err != nil {
+		return condition
+	}
+	condition.Status = metav1.ConditionTrue
+	condition.Message = fmt.Sprintf("Rotation of etcd TLS client secret %s is not rolled out, the previous client certificate is still in use: %v", src.Name, srcErr)
+	return condition
+}
+
 func (r *HostedControlPlaneReconciler) reconcileKonnectivity(ctx context.Context, hcp *hyperv1.HostedControlPlane, releaseImageProvider *imageprovider.ReleaseImageProvider, infraStatus InfrastructureStatus, createOrUpdate upsert.CreateOrUpdateFN) error {
 	r.Log.Info("Reconciling Konnectivity")
 	p := konnectivity.NewKonnectivityParams(hcp, releaseImageProvider, infraStatus.KonnectivityHost, infraStatus.KonnectivityPort, r.SetDefaultSecurityContext)
@@ -2680,7 +3031,7 @@ func (r *HostedControlPlaneReconciler) reconcileKonnectivity(ctx context.Context
 	}
 	serverLocalService := manifests.KonnectivityServerLocalService(hcp.Namespace)
 	if _, err := createOrUpdate(ctx, r, serverLocalService, func() error {
-		return kas.ReconcileKonnectivityServerLocalService(serverLocalService, p.OwnerRef)
+		return kas.ReconcileKonnectivityServerLocalService(serverLocalService, p.OwnerRef, hcp.Spec.ControllerAvailabilityPolicy)
 	}); err != nil {
 		return fmt.Errorf("failed to reconcile konnectivity server local service: %w", err)
 	}
@@ -2692,8 +3043,16 @@ func (r *HostedControlPlaneReconciler) reconcileKonnectivity(ctx context.Context
 	if util.HCPOAuthEnabled(hcp) {
 		ips = append(ips, infraStatus.OauthAPIServerHost)
 	}
+	konnectivityCA := manifests.KonnectivityCAConfigMap(hcp.Namespace)
+	if err := r.Get(ctx, client.ObjectKeyFromObject(konnectivityCA), konnectivityCA); err != nil {
+		return fmt.Errorf("failed to get konnectivity CA config map: %w", err)
+	}
 	if _, err := createOrUpdate(ctx, r, agentDeployment, func() error {
-		return konnectivity.ReconcileAgentDeployment(agentDeployment, p.OwnerRef, p.AgentDeploymentConfig, p.KonnectivityAgentImage, ips)
+		if err := konnectivity.ReconcileAgentDeployment(agentDeployment, p.OwnerRef, p.AgentDeploymentConfig, p.KonnectivityAgentImage, ips, p.SyncForever); err != nil {
+			return err
+		}
+		pki.ApplyKonnectivityCAHashAnnotation(&agentDeployment.Spec.Template, konnectivityCA)
+		return nil
 	}); err != nil {
 		return fmt.Errorf("failed to reconcile konnectivity agent deployment: %w", err)
 	}
@@ -2939,8 +3298,21 @@ func (r *HostedControlPlaneReconciler) reconcileKubeAPIServer(ctx context.Contex
 		}
 	}
 
+	konnectivityCA := manifests.KonnectivityCAConfigMap(hcp.Namespace)
+	if err := r.Get(ctx, client.ObjectKeyFromObject(konnectivityCA), konnectivityCA); err != nil {
+		return fmt.Errorf("failed to get konnectivity CA config map: %w", err)
+	}
+
+	var unmanagedEtcdClientSecret *corev1.Secret
+	if hcp.Spec.Etcd.ManagementType == hyperv1.Unmanaged {
+		unmanagedEtcdClientSecret = manifests.EtcdClientSecret(hcp.Namespace)
+		if err := r.Get(ctx, client.ObjectKeyFromObject(unmanagedEtcdClientSecret), unmanagedEtcdClientSecret); err != nil {
+			return fmt.Errorf("failed to get etcd client tls secret: %w", err)
+		}
+	}
+
 	if _, err := createOrUpdate(ctx, r, kubeAPIServerDeployment, func() error {
-		return kas.ReconcileKubeAPIServerDeployment(kubeAPIServerDeployment,
+		if err := kas.ReconcileKubeAPIServerDeployment(kubeAPIServerDeployment,
 			hcp,
 			p.OwnerRef,
 			p.DeploymentConfig,
@@ -2960,7 +3332,15 @@ func (r *HostedControlPlaneReconciler) reconcileKubeAPIServer(ctx context.Contex
 			p.FeatureGate,
 			oidcCA,
 			p.CipherSuites(),
-		)
+		); err != nil {
+			return err
+		}
+		if unmanagedEtcdClientSecret != nil {
+			kas.ApplyEtcdClientCertHashAnnotation(&kubeAPIServerDeployment.Spec.Template, unmanagedEtcdClientSecret)
+		}
+		// The konnectivity server runs in the kube-apiserver pods.
+		pki.ApplyKonnectivityCAHashAnnotation(&kubeAPIServerDeployment.Spec.Template, konnectivityCA)
+		return nil
 	}); err != nil {
 		return fmt.Errorf("failed to reconcile api server deployment: %w", err)
 	}
@@ -3408,6 +3788,13 @@ func (r *HostedControlPlaneReconciler) reconcileClusterNetworkOperator(ctx conte
 		return fmt.Errorf("failed to restart network node identity: %w", err)
 	}
 
+	// CNO manages overall cloud-network-config-controller deployment. CPO manages restarts, which also pick up
+	// rotated platform credentials.
+	cloudNetworkConfigControllerDeployment := manifests.CloudNetworkConfigControllerDeployment(hcp.Namespace)
+	if err := cno.SetRestartAnnotationAndPatch(ctx, r.Client, cloudNetworkConfigControllerDeployment, p.DeploymentConfig); err != nil {
+		return fmt.Errorf("failed to restart cloud network config controller: %w", err)
+	}
+
 	return nil
 }
 
@@ -3561,69 +3948,86 @@ func (r *HostedControlPlaneReconciler) reconcileCloudCredentialOperator(ctx cont
 
 func (r *HostedControlPlaneReconciler) reconcileOperatorLifecycleManager(ctx context.Context, hcp *hyperv1.HostedControlPlane, releaseImageProvider *imageprovider.ReleaseImageProvider, userReleaseImageProvider *imageprovider.ReleaseImageProvider, createOrUpdate upsert.CreateOrUpdateFN) error {
 	p := olm.NewOperatorLifecycleManagerParams(hcp, releaseImageProvider, userReleaseImageProvider.Version(), r.SetDefaultSecurityContext)
-	if (hcp.Spec.Configuration != nil && hcp.Spec.Configuration.OperatorHub != nil &&
-		hcp.Spec.Configuration.OperatorHub.DisableAllDefaultSources) ||
-		hcp.Spec.OLMCatalogPlacement != hyperv1.ManagementOLMCatalogPlacement {
-		// Disable all default sources
-		olmServices := olm.OLMServices(hcp.Namespace)
-		for _, svc := range olmServices {
-			if err := r.Client.Delete(ctx, svc.Manifest); err != nil {
-				if !apierrors.IsNotFound(err) {
-					return fmt.Errorf("failed to delete %s service on OLM reconcile: %w", svc.Name, err)
-				}
+	defaultSourcesDisabled := (hcp.Spec.Configuration != nil && hcp.Spec.Configuration.OperatorHub != nil &&
+		hcp.Spec.Configuration.OperatorHub.DisableAllDefaultSources) || olm.DefaultCatalogsDisabled(hcp)
+	// Default catalogs replaced by a custom catalog source are kept, with the image of the custom catalog source.
+	catalogEnabled := func(catalog string) bool {
+		return hcp.Spec.OLMCatalogPlacement == hyperv1.ManagementOLMCatalogPlacement &&
+			(!defaultSourcesDisabled || olm.CatalogSourceOverride(hcp, catalog) != nil)
+	}
+
+	// Disable default sources
+	olmServices := olm.OLMServices(hcp.Namespace)
+	for _, svc := range olmServices {
+		if catalogEnabled(svc.Catalog) {
+			continue
+		}
+		if err := r.Client.Delete(ctx, svc.Manifest); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return fmt.Errorf("failed to delete %s service on OLM reconcile: %w", svc.Name, err)
 			}
 		}
-		olmDeployments := olm.OLMDeployments(p, hcp.Namespace)
-		for _, dep := range olmDeployments {
-			if _, err := util.DeleteIfNeeded(ctx, r.Client, dep.Manifest); err != nil {
-				if !apierrors.IsNotFound(err) {
-					return fmt.Errorf("failed to delete %s deployment on OLM reconcile: %w", dep.Name, err)
-				}
+	}
+	olmDeployments := olm.OLMDeployments(p, hcp.Namespace)
+	for _, dep := range olmDeployments {
+		if catalogEnabled(dep.Catalog) {
+			continue
+		}
+		if _, err := util.DeleteIfNeeded(ctx, r.Client, dep.Manifest); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return fmt.Errorf("failed to delete %s deployment on OLM reconcile: %w", dep.Name, err)
 			}
 		}
+	}
 
-	} else {
-		// Enable all default sources
-		if hcp.Spec.OLMCatalogPlacement == hyperv1.ManagementOLMCatalogPlacement {
-			overrideImages, err := checkCatalogImageOverides(p.CertifiedOperatorsCatalogImageOverride, p.CommunityOperatorsCatalogImageOverride, p.RedHatMarketplaceCatalogImageOverride, p.RedHatOperatorsCatalogImageOverride)
-			if err != nil {
-				return fmt.Errorf("failed to reconcile catalogs: %w", err)
-			}
+	// Enable default sources
+	if hcp.Spec.OLMCatalogPlacement == hyperv1.ManagementOLMCatalogPlacement && (!defaultSourcesDisabled || len(p.CatalogSourceImages) > 0) {
+		overrideImages, err := checkCatalogImageOverides(p.CertifiedOperatorsCatalogImageOverride, p.CommunityOperatorsCatalogImageOverride, p.RedHatMarketplaceCatalogImageOverride, p.RedHatOperatorsCatalogImageOverride)
+		if err != nil {
+			return fmt.Errorf("failed to reconcile catalogs: %w", err)
+		}
 
-			catalogsImageStream := manifests.CatalogsImageStream(hcp.Namespace)
-			if !overrideImages {
-				isImageRegistryOverrides := util.ConvertImageRegistryOverrideStringToMap(p.OLMCatalogsISRegistryOverridesAnnotation)
-				if _, err := createOrUpdate(ctx, r, catalogsImageStream, func() error {
-					return olm.ReconcileCatalogsImageStream(catalogsImageStream, p.OwnerRef, isImageRegistryOverrides)
-				}); err != nil {
-					return fmt.Errorf("failed to reconcile catalogs image stream: %w", err)
-				}
-			} else if r.ManagementClusterCapabilities.Has(capabilities.CapabilityImageStream) {
-				if _, err := util.DeleteIfNeeded(ctx, r, catalogsImageStream); err != nil {
-					return fmt.Errorf("failed to remove OLM Catalog ImageStream: %w", err)
-				}
+		catalogsImageStream := manifests.CatalogsImageStream(hcp.Namespace)
+		if !overrideImages {
+			isImageRegistryOverrides := util.ConvertImageRegistryOverrideStringToMap(p.OLMCatalogsISRegistryOverridesAnnotation)
+			if _, err := createOrUpdate(ctx, r, catalogsImageStream, func() error {
+				return olm.ReconcileCatalogsImageStream(catalogsImageStream, p.OwnerRef, isImageRegistryOverrides)
+			}); err != nil {
+				return fmt.Errorf("failed to reconcile catalogs image stream: %w", err)
 			}
+		} else if r.ManagementClusterCapabilities.Has(capabilities.CapabilityImageStream) {
+			if _, err := util.DeleteIfNeeded(ctx, r, catalogsImageStream); err != nil {
+				return fmt.Errorf("failed to remove OLM Catalog ImageStream: %w", err)
+			}
+		}
 
-			olmServices := olm.OLMServices(hcp.Namespace)
-			for _, svc := range olmServices {
-				if _, err := createOrUpdate(ctx, r, svc.Manifest, func() error {
-					return svc.Reconciler(svc.Manifest, p.OwnerRef)
-				}); err != nil {
-					return fmt.Errorf("failed to reconcile %s service: %w", svc.Name, err)
-				}
+		for _, svc := range olmServices {
+			if !catalogEnabled(svc.Catalog) {
+				continue
 			}
+			if _, err := createOrUpdate(ctx, r, svc.Manifest, func() error {
+				return svc.Reconciler(svc.Manifest, p.OwnerRef)
+			}); err != nil {
+				return fmt.Errorf("failed to reconcile %s service: %w", svc.Name, err)
+			}
+		}
 
-			olmDeployments := olm.OLMDeployments(p, hcp.Namespace)
-			for _, dep := range olmDeployments {
-				if _, err := createOrUpdate(ctx, r, dep.Manifest, func() error {
-					return dep.Reconciler(dep.Manifest, p.OwnerRef, p.DeploymentConfig, dep.Image)
-				}); err != nil {
-					return fmt.Errorf("failed to reconcile %s deployment with image %s: %w", dep.Name, dep.Image, err)
-				}
+		for _, dep := range olmDeployments {
+			if !catalogEnabled(dep.Catalog) {
+				continue
+			}
+			if _, err := createOrUpdate(ctx, r, dep.Manifest, func() error {
+				return dep.Reconciler(dep.Manifest, p.OwnerRef, p.DeploymentConfig, dep.Image)
+			}); err != nil {
+				return fmt.Errorf("failed to reconcile %s deployment with image %s: %w", dep.Name, dep.Image, err)
 			}
 		}
 	}
 
+	if err := r.reconcileCustomCatalogSources(ctx, hcp, p, createOrUpdate); err != nil {
+		return err
+	}
+
 	if _, exists := hcp.Annotations[hyperv1.DisableMonitoringServices]; !exists {
 		catalogOperatorMetricsService := manifests.CatalogOperatorMetricsService(hcp.Namespace)
 		if _, err := createOrUpdate(ctx, r, catalogOperatorMetricsService, func() error {
@@ -3756,6 +4160,57 @@ func (r *HostedControlPlaneReconciler) reconcileOperatorLifecycleManager(ctx con
 	return nil
 }
 
+// reconcileCustomCatalogSources serves the custom catalog sources in the control plane namespace in the management
+// OLM catalog placement, and removes the ones which are no longer configured.
+func (r *HostedControlPlaneReconciler) reconcileCustomCatalogSources(ctx context.Context, hcp *hyperv1.HostedControlPlane, p *olm.OperatorLifecycleManagerParams, createOrUpdate upsert.CreateOrUpdateFN) error {
+	expected := sets.New[string]()
+	if hcp.Spec.OLMCatalogPlacement == hyperv1.ManagementOLMCatalogPlacement {
+		for _, source := range olm.CustomCatalogSources(hcp) {
+			name := olm.CustomCatalogServiceName(source.Name)
+			expected.Insert(name)
+
+			svc := manifests.CustomCatalogService(hcp.Namespace, name)
+			if _, err := createOrUpdate(ctx, r, svc, func() error {
+				return olm.ReconcileCustomCatalogService(svc, p.OwnerRef, source.Name)
+			}); err != nil {
+				return fmt.Errorf("failed to reconcile %s catalog service: %w", source.Name, err)
+			}
+			deployment := manifests.CustomCatalogDeployment(hcp.Namespace, name)
+			if _, err := createOrUpdate(ctx, r, deployment, func() error {
+				return olm.ReconcileCustomCatalogDeployment(deployment, p.OwnerRef, p.DeploymentConfig, source)
+			}); err != nil {
+				return fmt.Errorf("failed to reconcile %s catalog deployment with image %s: %w", source.Name, source.Image, err)
+			}
+		}
+	}
+
+	services := &corev1.ServiceList{}
+	if err := r.List(ctx, services, client.InNamespace(hcp.Namespace), olm.CustomCatalogSourceSelector()); err != nil {
+		return fmt.Errorf("failed to list custom catalog services: %w", err)
+	}
+	for i := range services.Items {
+		if expected.Has(services.Items[i].Name) {
+			continue
+		}
+		if _, err := util.DeleteIfNeeded(ctx, r, &services.Items[i]); err != nil {
+			return fmt.Errorf("failed to delete custom catalog service %s: %w", services.Items[i].Name, err)
+		}
+	}
+	deployments := &appsv1.DeploymentList{}
+	if err := r.List(ctx, deployments, client.InNamespace(hcp.Namespace), olm.CustomCatalogSourceSelector()); err != nil {
+		return fmt.Errorf("failed to list custom catalog deployments: %w", err)
+	}
+	for i := range deployments.Items {
+		if expected.Has(deployments.Items[i].Name) {
+			continue
+		}
+		if _, err := util.DeleteIfNeeded(ctx, r, &deployments.Items[i]); err != nil {
+			return fmt.Errorf("failed to delete custom catalog deployment %s: %w", deployments.Items[i].Name, err)
+		}
+	}
+	return nil
+}
+
 func checkCatalogImageOverides(images ...string) (bool, error) {
 	override := false
 	for _, image := range images {
@@ -3817,7 +4272,15 @@ func (r *HostedControlPlaneReconciler) reconcileMachineConfigServerConfig(ctx co
 		return fmt.Errorf("failed to get root kubelet client CA: %w", err)
 	}
 
-	p, err := mcs.NewMCSParams(hcp, rootCA, pullSecret, trustedCABundle, kubeletClientCA)
+	var registryCA *corev1.ConfigMap
+	if hcp.Spec.Configuration != nil && hcp.Spec.Configuration.Image != nil && hcp.Spec.Configuration.Image.AdditionalTrustedCA.Name != "" {
+		registryCA = &corev1.ConfigMap{}
+		if err := r.Get(ctx, client.ObjectKey{Namespace: hcp.Namespace, Name: hcp.Spec.Configuration.Image.AdditionalTrustedCA.Name}, registryCA); err != nil {
+			return fmt.Errorf("failed to get image additionalTrustedCA configmap: %w", err)
+		}
+	}
+
+	p, err := mcs.NewMCSParams(hcp, rootCA, pullSecret, trustedCABundle, registryCA, kubeletClientCA)
 	if err != nil {
 		return fmt.Errorf("failed to initialise machine config server parameters config: %w", err)
 	}
@@ -4607,7 +5070,7 @@ func healthCheckIdentityProvider(ctx context.Context, hcp *hyperv1.HostedControl
 				ObservedGeneration: hcp.Generation,
 				Status:             metav1.ConditionUnknown,
 				Message:            awsErr.Code(),
-				Reason:             hyperv1.AWSErrorReason,
+				Reason:             conditions.ReasonForProviderErrorCode(awsErr.Code()),
 			}
 			meta.SetStatusCondition(&hcp.Status.Conditions, condition)
 			log.Info("Error health checking AWS identity provider", awsErr.Code(), awsErr.Message())
@@ -4660,7 +5123,7 @@ func (r *HostedControlPlaneReconciler) reconcileDefaultSecurityGroup(ctx context
 			Type:    string(hyperv1.AWSDefaultSecurityGroupCreated),
 			Status:  metav1.ConditionFalse,
 			Message: creationErr.Error(),
-			Reason:  hyperv1.AWSErrorReason,
+			Reason:  conditions.ReasonForError(creationErr, hyperv1.InfraFailureReason),
 		}
 	} else {
 		condition = &metav1.Condition{
@@ -4955,7 +5418,7 @@ func (r *HostedControlPlaneReconciler) validateAWSKMSConfig(ctx context.Context,
 			ObservedGeneration: hcp.Generation,
 			Status:             metav1.ConditionFalse,
 			Message:            fmt.Sprintf("failed to encrypt data using KMS (key: %s), code: %s", kmsKeyArn, awsErrorCode(err)),
-			Reason:             hyperv1.AWSErrorReason,
+			Reason:             conditions.ReasonForError(err, hyperv1.InfraFailureReason),
 		}
 	}
 
@@ -5010,7 +5473,7 @@ func (r *HostedControlPlaneReconciler) validateAzureKMSConfig(ctx context.Contex
 	vaultURL := fmt.Sprintf("https://%s.%s", azureKmsSpec.ActiveKey.KeyVaultName, azureEnv.KeyVaultDNSSuffix)
 	keysClient, err := azkeys.NewClient(vaultURL, cred, nil)
 	if err != nil {
-		conditions.SetFalseCondition(hcp, hyperv1.ValidAzureKMSConfig, hyperv1.AzureErrorReason,
+		conditions.SetFalseCondition(hcp, hyperv1.ValidAzureKMSConfig, conditions.ReasonForError(err, hyperv1.InfraFailureReason),
 			fmt.Sprintf("failed to create azure keys client: %v", err))
 		return
 	}
@@ -5033,7 +5496,88 @@ func (r *HostedControlPlaneReconciler) validateAzureKMSConfig(ctx context.Contex
 			ObservedGeneration: hcp.Generation,
 			Status:             metav1.ConditionFalse,
 			Message:            fmt.Sprintf("failed to encrypt data using KMS (key: %s/%s): %v", azureKmsSpec.ActiveKey.KeyName, azureKmsSpec.ActiveKey.KeyVersion, err),
-			Reason:             hyperv1.AzureErrorReason,
+			Reason:             conditions.ReasonForError(err, hyperv1.InfraFailureReason),
+		}
+	}
+
+	meta.SetStatusCondition(&hcp.Status.Conditions, condition)
+}
+
+func (r *HostedControlPlaneReconciler) validateIBMCloudKMSConfig(ctx context.Context, hcp *hyperv1.HostedControlPlane) {
+	ibmCloudKMS := ibmCloudKMSSpec(hcp)
+	if ibmCloudKMS == nil {
+		condition := metav1.Condition{
+			Type:               string(hyperv1.ValidIBMCloudKMSConfig),
+			ObservedGeneration: hcp.Generation,
+			Status:             metav1.ConditionUnknown,
+			Message:            "IBM Cloud KMS is not configured",
+			Reason:             hyperv1.StatusUnknownReason,
+		}
+		meta.SetStatusCondition(&hcp.Status.Conditions, condition)
+		return
+	}
+
+	if err := kaskms.ValidateIBMCloudKeyList(ibmCloudKMS.KeyList); err != nil {
+		conditions.SetFalseCondition(hcp, hyperv1.ValidIBMCloudKMSConfig, hyperv1.InvalidConfigurationReason,
+			fmt.Sprintf("invalid IBM Cloud KMS key list: %v", err))
+		return
+	}
+
+	// With the managed authentication the credentials are provided by the platform, so only the unmanaged
+	// credentials of the customer can be checked.
+	if ibmCloudKMS.Auth.Type != hyperv1.IBMCloudKMSUnmanagedAuth {
+		condition := metav1.Condition{
+			Type:               string(hyperv1.ValidIBMCloudKMSConfig),
+			ObservedGeneration: hcp.Generation,
+			Status:             metav1.ConditionTrue,
+			Message:            hyperv1.AllIsWellMessage,
+			Reason:             hyperv1.AsExpectedReason,
+		}
+		meta.SetStatusCondition(&hcp.Status.Conditions, condition)
+		return
+	}
+	if ibmCloudKMS.Auth.Unmanaged == nil || ibmCloudKMS.Auth.Unmanaged.Credentials.Name == "" {
+		conditions.SetFalseCondition(hcp, hyperv1.ValidIBMCloudKMSConfig, hyperv1.InvalidConfigurationReason,
+			"the credentials of the unmanaged IBM Cloud KMS authentication are not specified")
+		return
+	}
+
+	credentialsSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: hcp.Namespace, Name: ibmCloudKMS.Auth.Unmanaged.Credentials.Name}}
+	if err := r.Client.Get(ctx, client.ObjectKeyFromObject(credentialsSecret), credentialsSecret); err != nil {
+		condition := metav1.Condition{
+			Type:               string(hyperv1.ValidIBMCloudKMSConfig),
+			ObservedGeneration: hcp.Generation,
+			Status:             metav1.ConditionUnknown,
+			Message:            fmt.Sprintf("failed to get ibmcloud kms credentials secret: %v", err),
+			Reason:             hyperv1.StatusUnknownReason,
+		}
+		meta.SetStatusCondition(&hcp.Status.Conditions, condition)
+		return
+	}
+
+	authenticator, err := core.NewIamAuthenticatorBuilder().SetApiKey(string(credentialsSecret.Data[hyperv1.IBMCloudIAMAPIKeySecretKey])).Build()
+	if err != nil {
+		conditions.SetFalseCondition(hcp, hyperv1.ValidIBMCloudKMSConfig, hyperv1.InvalidIBMCloudCredentialsReason,
+			fmt.Sprintf("invalid ibmcloud kms credentials: %v", err))
+		return
+	}
+
+	condition := metav1.Condition{
+		Type:               string(hyperv1.ValidIBMCloudKMSConfig),
+		ObservedGeneration: hcp.Generation,
+		Status:             metav1.ConditionTrue,
+		Message:            hyperv1.AllIsWellMessage,
+		Reason:             hyperv1.AsExpectedReason,
+	}
+
+	activeKey := kaskms.IBMCloudActiveKey(ibmCloudKMS.KeyList)
+	if err := kaskms.WrapWithIBMCloudKMSKey(ctx, &http.Client{Timeout: 30 * time.Second}, authenticator, activeKey); err != nil {
+		condition = metav1.Condition{
+			Type:               string(hyperv1.ValidIBMCloudKMSConfig),
+			ObservedGeneration: hcp.Generation,
+			Status:             metav1.ConditionFalse,
+			Message:            fmt.Sprintf("failed to encrypt data using KMS (key version: %d): %v", activeKey.KeyVersion, err),
+			Reason:             conditions.ReasonForError(err, hyperv1.InfraFailureReason),
 		}
 	}
 
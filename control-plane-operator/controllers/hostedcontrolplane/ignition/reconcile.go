@@ -18,11 +18,17 @@ import (
 	"github.com/openshift/hypershift/support/api"
 	"github.com/openshift/hypershift/support/config"
 	mcfgv1 "github.com/openshift/hypershift/thirdparty/machineconfigoperator/pkg/apis/machineconfiguration.openshift.io/v1"
+	"github.com/vincent-petithory/dataurl"
+	"k8s.io/utils/pointer"
 )
 
 const (
 	ignitionConfigKey = "config"
 	ignitionVersion   = "3.2.0"
+
+	fipsKernelArgument     = "fips=1"
+	fipsCryptoPolicy       = "FIPS"
+	cryptoPolicyConfigPath = "/etc/crypto-policies/config"
 )
 
 var (
@@ -39,6 +45,16 @@ func ReconcileFIPSIgnitionConfig(cm *corev1.ConfigMap, ownerRef config.OwnerRef,
 	machineConfig := manifests.MachineConfigFIPS()
 	SetMachineConfigLabels(machineConfig)
 	machineConfig.Spec.FIPS = fipsEnabled
+	if fipsEnabled {
+		// RHCOS enables FIPS mode on first boot from spec.fips. The kernel argument and the system wide crypto
+		// policy of FIPS mode are rendered as well, so in place updates keep enforcing them.
+		machineConfig.Spec.KernelArguments = []string{fipsKernelArgument}
+		serializedConfig, err := fipsConfig()
+		if err != nil {
+			return fmt.Errorf("failed to serialize ignition config: %w", err)
+		}
+		machineConfig.Spec.Config.Raw = serializedConfig
+	}
 	return reconcileMachineConfigIgnitionConfigMap(cm, machineConfig, ownerRef)
 }
 
@@ -75,6 +91,27 @@ func workerSSHConfig(sshKey string) ([]byte, error) {
 	return serializeIgnitionConfig(config)
 }
 
+func fipsConfig() ([]byte, error) {
+	config := &igntypes.Config{}
+	config.Ignition.Version = ignitionVersion
+	mode := 0644
+	config.Storage.Files = []igntypes.File{
+		{
+			Node: igntypes.Node{
+				Path:      cryptoPolicyConfigPath,
+				Overwrite: pointer.Bool(true),
+			},
+			FileEmbedded1: igntypes.FileEmbedded1{
+				Mode: &mode,
+				Contents: igntypes.Resource{
+					Source: pointer.String(dataurl.EncodeBytes([]byte(fipsCryptoPolicy + "\n"))),
+				},
+			},
+		},
+	}
+	return serializeIgnitionConfig(config)
+}
+
 func serializeIgnitionConfig(cfg *igntypes.Config) ([]byte, error) {
 	jsonBytes, err := json.Marshal(cfg)
 	if err != nil {
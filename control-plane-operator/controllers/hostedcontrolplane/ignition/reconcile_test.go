@@ -3,7 +3,9 @@ package ignition
 import (
 	"testing"
 
+	"github.com/openshift/hypershift/support/config"
 	"github.com/openshift/hypershift/support/testutil"
+	corev1 "k8s.io/api/core/v1"
 	k8syaml "sigs.k8s.io/yaml"
 )
 
@@ -19,3 +21,11 @@ func TestWorkerSSHConfig(t *testing.T) {
 	}
 	testutil.CompareWithFixture(t, yamlConfig)
 }
+
+func TestFIPSIgnitionConfig(t *testing.T) {
+	cm := &corev1.ConfigMap{}
+	if err := ReconcileFIPSIgnitionConfig(cm, config.OwnerRef{}, true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	testutil.CompareWithFixture(t, []byte(cm.Data[ignitionConfigKey]))
+}
@@ -87,7 +87,7 @@ func ReconcileIgnitionServer(ctx context.Context,
 		ignitionServerRoute := ignitionserver.Route(controlPlaneNamespace)
 		if util.IsPrivateHCP(hcp) {
 			if _, err := createOrUpdate(ctx, c, ignitionServerRoute, func() error {
-				err := reconcileInternalRoute(ignitionServerRoute, ownerRef, routeServiceName)
+				err := reconcileInternalRoute(ignitionServerRoute, ownerRef, routeServiceName, util.InternalDNSDomain(hcp.Name, hcp.Spec.DNS))
 				if err != nil {
 					return fmt.Errorf("failed to reconcile internal route in ignition server: %w", err)
 				}
@@ -355,10 +355,9 @@ func reconcileExternalRoute(route *routev1.Route, ownerRef config.OwnerRef, svcN
 	return util.ReconcileExternalRoute(route, hostname, defaultIngressDomain, svcName)
 }
 
-func reconcileInternalRoute(route *routev1.Route, ownerRef config.OwnerRef, svcName string) error {
+func reconcileInternalRoute(route *routev1.Route, ownerRef config.OwnerRef, svcName string, internalDNSDomain string) error {
 	ownerRef.ApplyTo(route)
-	// Assumes ownerRef is the HCP
-	return util.ReconcileInternalRoute(route, ownerRef.Reference.Name, svcName)
+	return util.ReconcileInternalRoute(route, internalDNSDomain, svcName)
 }
 
 func reconcileCACertSecret(caCertSecret *corev1.Secret) error {
@@ -652,6 +651,7 @@ func reconcileDeployment(deployment *appsv1.Deployment,
 							"--registry-overrides", util.ConvertRegistryOverridesToCommandLineFlag(registryOverrides),
 							"--platform", string(hcp.Spec.Platform.Type),
 							"--feature-gate-manifest=/shared/99_feature-gate.yaml",
+							"--payload-cache-dir=/payloads/payload-cache",
 						},
 						LivenessProbe: &corev1.Probe{
 							ProbeHandler:        probeHandler,
@@ -708,9 +708,43 @@ func reconcileDeployment(deployment *appsv1.Deployment,
 	}
 	proxy.SetEnvVars(&deployment.Spec.Template.Spec.Containers[0].Env)
 
+	if _, ok := hcp.Annotations[hyperv1.IgnitionServerPayloadStorageSecretAnnotation]; ok {
+		deployment.Spec.Template.Spec.Volumes = append(deployment.Spec.Template.Spec.Volumes, corev1.Volume{
+			Name: "payload-storage",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName:  ignitionserver.PayloadStorageSecret("").Name,
+					DefaultMode: utilpointer.Int32(0640),
+				},
+			},
+		})
+		deployment.Spec.Template.Spec.Containers[0].VolumeMounts = append(deployment.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      "payload-storage",
+			MountPath: "/etc/ignition-server/payload-storage",
+		})
+		deployment.Spec.Template.Spec.Containers[0].Command = append(deployment.Spec.Template.Spec.Containers[0].Command, "--payload-storage-config=/etc/ignition-server/payload-storage")
+	}
+
+	if _, ok := hcp.Annotations[hyperv1.IgnitionServerAttestationConfigAnnotation]; ok {
+		deployment.Spec.Template.Spec.Volumes = append(deployment.Spec.Template.Spec.Volumes, corev1.Volume{
+			Name: "attestation",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: ignitionserver.AttestationConfigMap("").Name},
+				},
+			},
+		})
+		deployment.Spec.Template.Spec.Containers[0].VolumeMounts = append(deployment.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      "attestation",
+			MountPath: "/etc/ignition-server/attestation",
+		})
+		deployment.Spec.Template.Spec.Containers[0].Command = append(deployment.Spec.Template.Spec.Containers[0].Command, "--attestation-config=/etc/ignition-server/attestation")
+	}
+
 	if len(mirroredReleaseImage) > 0 {
 		deployment.Spec.Template.Spec.Containers[0].Env = append(deployment.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{Name: "MIRRORED_RELEASE_IMAGE", Value: mirroredReleaseImage})
 	}
+	config.DeploymentAddPprofBindAddress(hcp.Annotations, deployment)
 
 	if hcp.Spec.AdditionalTrustBundle != nil {
 		// Add trusted-ca mount with optional configmap
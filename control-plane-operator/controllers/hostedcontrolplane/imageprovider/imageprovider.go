@@ -1,8 +1,14 @@
 package imageprovider
 
-import "github.com/openshift/hypershift/support/releaseinfo"
+import (
+	"sync"
 
+	"github.com/openshift/hypershift/support/releaseinfo"
+)
+
+// ReleaseImageProvider is safe for concurrent use, control plane components are reconciled in parallel.
 type ReleaseImageProvider struct {
+	lock             sync.Mutex
 	missingImages    []string
 	componentsImages map[string]string
 
@@ -27,6 +33,8 @@ func NewFromImages(componentsImages map[string]string) *ReleaseImageProvider {
 func (p *ReleaseImageProvider) GetImage(key string) string {
 	image, exist := p.componentsImages[key]
 	if !exist || image == "" {
+		p.lock.Lock()
+		defer p.lock.Unlock()
 		p.missingImages = append(p.missingImages, key)
 	}
 
@@ -34,7 +42,9 @@ func (p *ReleaseImageProvider) GetImage(key string) string {
 }
 
 func (p *ReleaseImageProvider) GetMissingImages() []string {
-	return p.missingImages
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return append([]string(nil), p.missingImages...)
 }
 
 func (p *ReleaseImageProvider) ImageExist(key string) (string, bool) {
@@ -133,11 +133,18 @@ func generateConfig(p KubeAPIServerConfigParams) *kcpv1.KubeAPIServerConfig {
 		config.AuthConfig.OAuthMetadataFile = cpath(kasVolumeOauthMetadata().Name, OauthMetadataConfigKey)
 	}
 
+	// The first issuer is the one tokens are issued under, the others are
+	// only accepted.
+	serviceAccountIssuers := []string{p.ServiceAccountIssuerURL}
+	if p.SecondaryIssuerURL != "" {
+		serviceAccountIssuers = append(serviceAccountIssuers, p.SecondaryIssuerURL)
+	}
+
 	args := kubeAPIServerArgs{}
 	args.Set("advertise-address", p.AdvertiseAddress)
 	args.Set("allow-privileged", "true")
 	args.Set("anonymous-auth", "true")
-	args.Set("api-audiences", p.ServiceAccountIssuerURL)
+	args.Set("api-audiences", serviceAccountIssuers...)
 	args.Set("audit-log-format", "json")
 	args.Set("audit-log-maxbackup", "1")
 	args.Set("audit-log-maxsize", "10")
@@ -170,6 +177,9 @@ func generateConfig(p KubeAPIServerConfigParams) *kcpv1.KubeAPIServerConfig {
 	}
 	args.Set("enable-aggregator-routing", "true")
 	args.Set("enable-logs-handler", "false")
+	if p.EnablePriorityAndFairness != "" {
+		args.Set("enable-priority-and-fairness", p.EnablePriorityAndFairness)
+	}
 	args.Set("endpoint-reconciler-type", "lease")
 	args.Set("etcd-cafile", cpath(kasVolumeEtcdCA().Name, certs.CASignerCertMapKey))
 	args.Set("etcd-certfile", cpath(kasVolumeEtcdClientCert().Name, pki.EtcdClientCrtKey))
@@ -181,7 +191,7 @@ func generateConfig(p KubeAPIServerConfigParams) *kcpv1.KubeAPIServerConfig {
 	featureGates := append([]string{}, p.FeatureGates...)
 	featureGates = append(featureGates, "StructuredAuthenticationConfiguration=true")
 	args.Set("feature-gates", featureGates...)
-	args.Set("goaway-chance", "0")
+	args.Set("goaway-chance", p.GoAwayChance)
 	args.Set("http2-max-streams-per-connection", "2000")
 	args.Set("kubelet-certificate-authority", cpath(kasVolumeKubeletClientCA().Name, certs.CASignerCertMapKey))
 	args.Set("kubelet-client-certificate", cpath(kasVolumeKubeletClientCert().Name, corev1.TLSCertKey))
@@ -189,8 +199,8 @@ func generateConfig(p KubeAPIServerConfigParams) *kcpv1.KubeAPIServerConfig {
 	args.Set("kubelet-preferred-address-types", "InternalIP")
 	args.Set("kubelet-read-only-port", "0")
 	args.Set("kubernetes-service-node-port", "0")
-	args.Set("max-mutating-requests-inflight", "1000")
-	args.Set("max-requests-inflight", "3000")
+	args.Set("max-mutating-requests-inflight", p.MaxMutatingRequestsInflight)
+	args.Set("max-requests-inflight", p.MaxRequestsInflight)
 	args.Set("min-request-timeout", "3600")
 	args.Set("proxy-client-cert-file", cpath(kasVolumeAggregatorCert().Name, corev1.TLSCertKey))
 	args.Set("proxy-client-key-file", cpath(kasVolumeAggregatorCert().Name, corev1.TLSPrivateKeyKey))
@@ -209,7 +219,7 @@ func generateConfig(p KubeAPIServerConfigParams) *kcpv1.KubeAPIServerConfig {
 		}
 	}
 	args.Set("runtime-config", runtimeConfig...)
-	args.Set("service-account-issuer", p.ServiceAccountIssuerURL)
+	args.Set("service-account-issuer", serviceAccountIssuers...)
 	args.Set("service-account-jwks-uri", jwksURL(p.ServiceAccountIssuerURL))
 	args.Set("service-account-lookup", "true")
 	args.Set("service-account-signing-key-file", cpath(kasVolumeServiceAccountKey().Name, pki.ServiceSignerPrivateKey))
@@ -19,6 +19,7 @@ import (
 	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/cloud/aws"
 	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/cloud/azure"
 	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/common"
+	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/etcd"
 	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/manifests"
 	"github.com/openshift/hypershift/support/api"
 	"github.com/openshift/hypershift/support/certs"
@@ -32,6 +33,7 @@ const (
 	authConfigHashAnnotation                   = "kube-apiserver.hypershift.openshift.io/auth-config-hash"
 	auditConfigHashAnnotation                  = "kube-apiserver.hypershift.openshift.io/audit-config-hash"
 	configHashAnnotation                       = "kube-apiserver.hypershift.openshift.io/config-hash"
+	etcdClientCertHashAnnotation               = "kube-apiserver.hypershift.openshift.io/etcd-client-cert-hash"
 	awsPodIdentityWebhookServingCertVolumeName = "aws-pod-identity-webhook-serving-certs"
 	awsPodIdentityWebhookKubeconfigVolumeName  = "aws-pod-identity-webhook-kubeconfig"
 )
@@ -122,9 +124,15 @@ func ReconcileKubeAPIServerDeployment(deployment *appsv1.Deployment,
 
 	secretEncryptionData := hcp.Spec.SecretEncryption
 	etcdMgmtType := hcp.Spec.Etcd.ManagementType
-	var additionalNoProxyCIDRS []string
-	additionalNoProxyCIDRS = append(additionalNoProxyCIDRS, util.ClusterCIDRs(hcp.Spec.Networking.ClusterNetwork)...)
-	additionalNoProxyCIDRS = append(additionalNoProxyCIDRS, util.ServiceCIDRs(hcp.Spec.Networking.ServiceNetwork)...)
+	var additionalNoProxy []string
+	additionalNoProxy = append(additionalNoProxy, util.ClusterCIDRs(hcp.Spec.Networking.ClusterNetwork)...)
+	additionalNoProxy = append(additionalNoProxy, util.ServiceCIDRs(hcp.Spec.Networking.ServiceNetwork)...)
+	// Webhooks and aggregated APIs of the guest cluster are reached through Konnectivity by their service names, etcd
+	// and the other control plane services by their service names in the control plane namespace.
+	additionalNoProxy = append(additionalNoProxy, proxy.InClusterNoProxy...)
+	if etcdMgmtType == hyperv1.Unmanaged && hcp.Spec.Etcd.Unmanaged != nil {
+		additionalNoProxy = append(additionalNoProxy, proxy.NoProxyHosts(hcp.Spec.Etcd.Unmanaged.Endpoint)...)
+	}
 
 	configBytes, ok := config.Data[KubeAPIServerConfigKey]
 	if !ok {
@@ -203,7 +211,7 @@ func ReconcileKubeAPIServerDeployment(deployment *appsv1.Deployment,
 			},
 			Containers: []corev1.Container{
 				util.BuildContainer(kasContainerApplyBootstrap(), buildKASContainerApplyBootstrap(images.CLI)),
-				util.BuildContainer(kasContainerMain(), buildKASContainerMain(images.HyperKube, port, additionalNoProxyCIDRS, hcp)),
+				util.BuildContainer(kasContainerMain(), buildKASContainerMain(images.HyperKube, port, additionalNoProxy, hcp)),
 				util.BuildContainer(konnectivityServerContainer(), buildKonnectivityServerContainer(images.KonnectivityServer, deploymentConfig.Replicas, cipherSuites)),
 			},
 			Volumes: []corev1.Volume{
@@ -420,7 +428,7 @@ func kasContainerMain() *corev1.Container {
 	}
 }
 
-func buildKASContainerMain(image string, port int32, noProxyCIDRs []string, hcp *hyperv1.HostedControlPlane) func(c *corev1.Container) {
+func buildKASContainerMain(image string, port int32, noProxy []string, hcp *hyperv1.HostedControlPlane) func(c *corev1.Container) {
 	return func(c *corev1.Container) {
 		c.Image = image
 		c.TerminationMessagePolicy = corev1.TerminationMessageReadFile
@@ -449,7 +457,7 @@ func buildKASContainerMain(image string, port int32, noProxyCIDRs []string, hcp
 		// the the egress transport and that breaks the egress selection/konnektivity usage.
 		// Using a CIDR is not supported by Go's default ProxyFunc, but Kube uses a custom one by default that does support it:
 		// https://github.com/kubernetes/kubernetes/blob/ab13c85316015cf9f115e29923ba9740bd1564fd/staging/src/k8s.io/apimachinery/pkg/util/net/http.go#L112-L114
-		proxy.SetEnvVars(&c.Env, noProxyCIDRs...)
+		proxy.SetEnvVars(&c.Env, noProxy...)
 
 		if hcp.Annotations[hyperv1.KubeAPIServerGOGCAnnotation] != "" {
 			c.Env = append(c.Env, corev1.EnvVar{
@@ -967,3 +975,14 @@ func buildKonnectivityVolumeClusterCerts(v *corev1.Volume) {
 		DefaultMode: pointer.Int32(0640),
 	}
 }
+
+// ApplyEtcdClientCertHashAnnotation rolls out the kube-apiserver pods when the TLS client secret of an unmanaged etcd
+// is rotated, so they connect to etcd with the new client certificate.
+func ApplyEtcdClientCertHashAnnotation(podTemplate *corev1.PodTemplateSpec, etcdClientSecret *corev1.Secret) {
+	if podTemplate.Annotations == nil {
+		podTemplate.Annotations = map[string]string{}
+	}
+	podTemplate.Annotations[etcdClientCertHashAnnotation] = util.HashSimple(string(etcdClientSecret.Data[etcd.UnmanagedClientCertKey]) +
+		string(etcdClientSecret.Data[etcd.UnmanagedClientKeyKey]) +
+		string(etcdClientSecret.Data[etcd.UnmanagedClientCAKey]))
+}
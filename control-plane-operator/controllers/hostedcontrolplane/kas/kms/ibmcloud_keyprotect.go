@@ -0,0 +1,109 @@
+package kms
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// IBMCloudActiveKey returns the entry of the key list with the highest key version, which is used to encrypt new
+// data.
+func IBMCloudActiveKey(keyList []hyperv1.IBMCloudKMSKeyEntry) hyperv1.IBMCloudKMSKeyEntry {
+	var active hyperv1.IBMCloudKMSKeyEntry
+	for i, key := range keyList {
+		if i == 0 || key.KeyVersion > active.KeyVersion {
+			active = key
+		}
+	}
+	return active
+}
+
+// IBMCloudKeyName returns the name identifying the key entry, e.g. in the KMSDataReencrypted condition.
+func IBMCloudKeyName(key hyperv1.IBMCloudKMSKeyEntry) string {
+	return fmt.Sprintf("%s%d", ibmKeyNamePrefix, key.KeyVersion)
+}
+
+// ValidateIBMCloudKeyList returns an error if the key list is empty, or if several of its entries share a key version.
+func ValidateIBMCloudKeyList(keyList []hyperv1.IBMCloudKMSKeyEntry) error {
+	if len(keyList) == 0 {
+		return fmt.Errorf("the key list is empty")
+	}
+	versions := map[int]string{}
+	for _, key := range keyList {
+		if crkID, exists := versions[key.KeyVersion]; exists {
+			return fmt.Errorf("keys %s and %s have the same key version %d", crkID, key.CRKID, key.KeyVersion)
+		}
+		versions[key.KeyVersion] = key.CRKID
+	}
+	return nil
+}
+
+// IBMCloudKMSPodSpecHasKey returns whether the IBM Cloud KMS container of the kube-apiserver pod spec is configured
+// with the key entry.
+func IBMCloudKMSPodSpecHasKey(podSpec *corev1.PodSpec, key hyperv1.IBMCloudKMSKeyEntry) bool {
+	for _, container := range podSpec.Containers {
+		if container.Name != kasContainerIBMCloudKMS().Name {
+			continue
+		}
+		for _, env := range container.Env {
+			if env.Name != "KP_DATA_JSON" {
+				continue
+			}
+			entries := map[string]ibmCloudKMSInfoEnvVarEntry{}
+			if err := json.Unmarshal([]byte(env.Value), &entries); err != nil {
+				return false
+			}
+			entry, exists := entries[fmt.Sprint(key.KeyVersion)]
+			return exists && entry.CRKID == key.CRKID
+		}
+	}
+	return false
+}
+
+// WrapWithIBMCloudKMSKey wraps sample data with the Key Protect or Hyper Protect Crypto Services root key of the key
+// entry, to check that the key is enabled and that the authenticator is allowed to use it.
+func WrapWithIBMCloudKMSKey(ctx context.Context, httpClient *http.Client, authenticator core.Authenticator, key hyperv1.IBMCloudKMSKeyEntry) error {
+	body, err := json.Marshal(map[string]string{"plaintext": base64.StdEncoding.EncodeToString([]byte("text"))})
+	if err != nil {
+		return fmt.Errorf("failed to marshal wrap request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/api/v2/keys/%s/actions/wrap", strings.TrimSuffix(key.URL, "/"), key.CRKID), bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create wrap request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/vnd.ibm.kms.key_action+json")
+	req.Header.Set("bluemix-instance", key.InstanceID)
+	if key.CorrelationID != "" {
+		req.Header.Set("correlation-id", key.CorrelationID)
+	}
+	if err := authenticator.Authenticate(req); err != nil {
+		return fmt.Errorf("failed to authenticate: %w", err)
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to wrap data with key %s: %w", key.CRKID, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		var kmsErr struct {
+			Resources []struct {
+				ErrorMsg string `json:"errorMsg"`
+			} `json:"resources"`
+		}
+		respBody, _ := io.ReadAll(resp.Body)
+		if json.Unmarshal(respBody, &kmsErr) == nil && len(kmsErr.Resources) > 0 && kmsErr.Resources[0].ErrorMsg != "" {
+			return fmt.Errorf("failed to wrap data with key %s: %s: %s", key.CRKID, resp.Status, kmsErr.Resources[0].ErrorMsg)
+		}
+		return fmt.Errorf("failed to wrap data with key %s: %s", key.CRKID, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package kms
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	. "github.com/onsi/gomega"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestIBMCloudActiveKey(t *testing.T) {
+	g := NewWithT(t)
+	keyList := []hyperv1.IBMCloudKMSKeyEntry{{CRKID: "crk-2", KeyVersion: 2}, {CRKID: "crk-3", KeyVersion: 3}, {CRKID: "crk-1", KeyVersion: 1}}
+	g.Expect(IBMCloudActiveKey(keyList).CRKID).To(Equal("crk-3"))
+	g.Expect(IBMCloudKeyName(IBMCloudActiveKey(keyList))).To(Equal("ibm3"))
+	g.Expect(ValidateIBMCloudKeyList(keyList)).To(Succeed())
+	g.Expect(ValidateIBMCloudKeyList(append(keyList, hyperv1.IBMCloudKMSKeyEntry{CRKID: "crk-4", KeyVersion: 3}))).To(MatchError("keys crk-3 and crk-4 have the same key version 3"))
+	g.Expect(ValidateIBMCloudKeyList(nil)).To(MatchError("the key list is empty"))
+}
+
+func TestIBMCloudKMSPodSpecHasKey(t *testing.T) {
+	g := NewWithT(t)
+	keyList := []hyperv1.IBMCloudKMSKeyEntry{{CRKID: "crk-1", InstanceID: "instance", URL: "https://kms.example.com", KeyVersion: 1}}
+	provider, err := NewIBMCloudKMSProvider(&hyperv1.IBMCloudKMSSpec{Region: "us-south", Auth: hyperv1.IBMCloudKMSAuthSpec{Type: hyperv1.IBMCloudKMSManagedAuth}, KeyList: keyList}, "kms-image")
+	g.Expect(err).ToNot(HaveOccurred())
+	podSpec := &corev1.PodSpec{Containers: []corev1.Container{{Name: KasMainContainerName}}}
+	g.Expect(provider.ApplyKMSConfig(podSpec)).To(Succeed())
+
+	g.Expect(IBMCloudKMSPodSpecHasKey(podSpec, keyList[0])).To(BeTrue())
+	g.Expect(IBMCloudKMSPodSpecHasKey(podSpec, hyperv1.IBMCloudKMSKeyEntry{CRKID: "crk-2", KeyVersion: 2})).To(BeFalse())
+	g.Expect(IBMCloudKMSPodSpecHasKey(podSpec, hyperv1.IBMCloudKMSKeyEntry{CRKID: "crk-2", KeyVersion: 1})).To(BeFalse())
+	g.Expect(IBMCloudKMSPodSpecHasKey(&corev1.PodSpec{}, keyList[0])).To(BeFalse())
+}
+
+func TestWrapWithIBMCloudKMSKey(t *testing.T) {
+	g := NewWithT(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"resources":[{"errorMsg":"Unauthorized: The user does not have access to the specified resource"}]}`))
+			return
+		}
+		g.Expect(r.URL.Path).To(Equal("/api/v2/keys/crk-1/actions/wrap"))
+		g.Expect(r.Header.Get("bluemix-instance")).To(Equal("instance"))
+		g.Expect(r.Header.Get("correlation-id")).To(Equal("correlation"))
+		var body map[string]string
+		g.Expect(json.NewDecoder(r.Body).Decode(&body)).To(Succeed())
+		g.Expect(body["plaintext"]).To(Equal(base64.StdEncoding.EncodeToString([]byte("text"))))
+		_, _ = w.Write([]byte(`{"ciphertext":"wrapped"}`))
+	}))
+	defer server.Close()
+	key := hyperv1.IBMCloudKMSKeyEntry{CRKID: "crk-1", InstanceID: "instance", CorrelationID: "correlation", URL: server.URL + "/", KeyVersion: 1}
+
+	authenticator, err := core.NewBearerTokenAuthenticator("token")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(WrapWithIBMCloudKMSKey(context.Background(), server.Client(), authenticator, key)).To(Succeed())
+
+	authenticator, err = core.NewBearerTokenAuthenticator("wrong")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(WrapWithIBMCloudKMSKey(context.Background(), server.Client(), authenticator, key)).To(MatchError("failed to wrap data with key crk-1: 401 Unauthorized: Unauthorized: The user does not have access to the specified resource"))
+}
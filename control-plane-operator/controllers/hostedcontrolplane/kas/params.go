@@ -44,6 +44,7 @@ type KubeAPIServerParams struct {
 	CloudProviderCreds  *corev1.LocalObjectReference `json:"cloudProviderCreds"`
 
 	ServiceAccountIssuer string   `json:"serviceAccountIssuer"`
+	SecondaryIssuer      string   `json:"secondaryIssuer"`
 	ServiceCIDRs         []string `json:"serviceCIDRs"`
 	ClusterCIDRs         []string `json:"clusterCIDRs"`
 	AdvertiseAddress     string   `json:"advertiseAddress"`
@@ -69,6 +70,11 @@ type KubeAPIServerParams struct {
 
 	Availability           hyperv1.AvailabilityPolicy
 	APIServerSTSDirectives string
+
+	MaxRequestsInflight         string
+	MaxMutatingRequestsInflight string
+	EnablePriorityAndFairness   string
+	GoAwayChance                string
 }
 
 type KubeAPIServerServiceParams struct {
@@ -88,10 +94,11 @@ func NewKubeAPIServerParams(ctx context.Context, hcp *hyperv1.HostedControlPlane
 	params := &KubeAPIServerParams{
 		ExternalAddress:      externalAPIAddress,
 		ExternalPort:         externalAPIPort,
-		InternalAddress:      fmt.Sprintf("api.%s.hypershift.local", hcp.Name),
+		InternalAddress:      util.InternalAPIHostname(hcp.Name, hcp.Spec.DNS),
 		ExternalOAuthAddress: externalOAuthAddress,
 		ExternalOAuthPort:    externalOAuthPort,
 		ServiceAccountIssuer: hcp.Spec.IssuerURL,
+		SecondaryIssuer:      hcp.Spec.SecondaryIssuerURL,
 		ServiceCIDRs:         util.ServiceCIDRs(hcp.Spec.Networking.ServiceNetwork),
 		ClusterCIDRs:         util.ClusterCIDRs(hcp.Spec.Networking.ClusterNetwork),
 		Availability:         hcp.Spec.ControllerAvailabilityPolicy,
@@ -129,6 +136,10 @@ func NewKubeAPIServerParams(ctx context.Context, hcp *hyperv1.HostedControlPlane
 		params.EtcdURL = hcp.Spec.Etcd.Unmanaged.Endpoint
 	case hyperv1.Managed:
 		params.EtcdURL = fmt.Sprintf("https://etcd-client.%s.svc:2379", hcp.Namespace)
+		if hcp.Spec.ControllerAvailabilityPolicy == hyperv1.HighlyAvailable {
+			// The zonal client service keeps the etcd traffic of each kube-apiserver in its zone when possible.
+			params.EtcdURL = fmt.Sprintf("https://%s.%s.svc:2379", manifests.EtcdClientZonalService("").Name, hcp.Namespace)
+		}
 	default:
 		params.EtcdURL = config.DefaultEtcdURL
 	}
@@ -138,6 +149,20 @@ func NewKubeAPIServerParams(ctx context.Context, hcp *hyperv1.HostedControlPlane
 	if hcp.Annotations[hyperv1.APICriticalPriorityClass] != "" {
 		params.Scheduling.PriorityClass = hcp.Annotations[hyperv1.APICriticalPriorityClass]
 	}
+
+	params.MaxRequestsInflight = "3000"
+	if hcp.Annotations[hyperv1.KubeAPIServerMaxRequestsInflightAnnotation] != "" {
+		params.MaxRequestsInflight = hcp.Annotations[hyperv1.KubeAPIServerMaxRequestsInflightAnnotation]
+	}
+	params.MaxMutatingRequestsInflight = "1000"
+	if hcp.Annotations[hyperv1.KubeAPIServerMaxMutatingRequestsInflightAnnotation] != "" {
+		params.MaxMutatingRequestsInflight = hcp.Annotations[hyperv1.KubeAPIServerMaxMutatingRequestsInflightAnnotation]
+	}
+	params.EnablePriorityAndFairness = hcp.Annotations[hyperv1.KubeAPIServerEnablePriorityAndFairnessAnnotation]
+	params.GoAwayChance = "0"
+	if hcp.Annotations[hyperv1.KubeAPIServerGoAwayChanceAnnotation] != "" {
+		params.GoAwayChance = hcp.Annotations[hyperv1.KubeAPIServerGoAwayChanceAnnotation]
+	}
 	baseLivenessProbeConfig := corev1.Probe{
 		ProbeHandler: corev1.ProbeHandler{
 			HTTPGet: &corev1.HTTPGetAction{
@@ -366,6 +391,7 @@ func (p *KubeAPIServerParams) ConfigParams() KubeAPIServerConfigParams {
 		DefaultNodeSelector:          p.DefaultNodeSelector(),
 		AdvertiseAddress:             p.AdvertiseAddress,
 		ServiceAccountIssuerURL:      p.ServiceAccountIssuerURL(),
+		SecondaryIssuerURL:           p.SecondaryIssuer,
 		CloudProvider:                p.CloudProvider,
 		CloudProviderConfigRef:       p.CloudProviderConfig,
 		EtcdURL:                      p.EtcdURL,
@@ -376,6 +402,10 @@ func (p *KubeAPIServerParams) ConfigParams() KubeAPIServerConfigParams {
 		DisableProfiling:             p.DisableProfiling,
 		APIServerSTSDirectives:       p.APIServerSTSDirectives,
 		Authentication:               p.Authentication,
+		MaxRequestsInflight:          p.MaxRequestsInflight,
+		MaxMutatingRequestsInflight:  p.MaxMutatingRequestsInflight,
+		EnablePriorityAndFairness:    p.EnablePriorityAndFairness,
+		GoAwayChance:                 p.GoAwayChance,
 	}
 }
 
@@ -392,6 +422,7 @@ type KubeAPIServerConfigParams struct {
 	DefaultNodeSelector          string
 	AdvertiseAddress             string
 	ServiceAccountIssuerURL      string
+	SecondaryIssuerURL           string
 	CloudProvider                string
 	CloudProviderConfigRef       *corev1.LocalObjectReference
 	EtcdURL                      string
@@ -402,6 +433,10 @@ type KubeAPIServerConfigParams struct {
 	DisableProfiling             bool
 	APIServerSTSDirectives       string
 	Authentication               *configv1.AuthenticationSpec
+	MaxRequestsInflight          string
+	MaxMutatingRequestsInflight  string
+	EnablePriorityAndFairness    string
+	GoAwayChance                 string
 }
 
 func (p *KubeAPIServerParams) TLSSecurityProfile() *configv1.TLSSecurityProfile {
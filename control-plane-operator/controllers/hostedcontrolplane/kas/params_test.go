@@ -76,3 +76,146 @@ func TestNewAPIServerParamsAPIAdvertiseAddressAndPort(t *testing.T) {
 		})
 	}
 }
+
+func TestKubeAPIServerRequestTuning(t *testing.T) {
+	tests := []struct {
+		name                     string
+		annotations              map[string]string
+		expectedMaxInflight      string
+		expectedMaxMutating      string
+		expectedPriorityFairness []string
+		expectedGoAway           string
+	}{
+		{
+			name:                "When no annotations are set it should use the default limits",
+			expectedMaxInflight: "3000",
+			expectedMaxMutating: "1000",
+			expectedGoAway:      "0",
+		},
+		{
+			name: "When the limits are set by annotations it should use them",
+			annotations: map[string]string{
+				hyperv1.KubeAPIServerMaxRequestsInflightAnnotation:         "800",
+				hyperv1.KubeAPIServerMaxMutatingRequestsInflightAnnotation: "400",
+			},
+			expectedMaxInflight: "800",
+			expectedMaxMutating: "400",
+			expectedGoAway:      "0",
+		},
+		{
+			name: "When API Priority and Fairness is disabled by annotation it should set the flag",
+			annotations: map[string]string{
+				hyperv1.KubeAPIServerEnablePriorityAndFairnessAnnotation: "false",
+			},
+			expectedMaxInflight:      "3000",
+			expectedMaxMutating:      "1000",
+			expectedPriorityFairness: []string{"false"},
+			expectedGoAway:           "0",
+		},
+		{
+			name: "When the goaway chance is set by annotation it should use it",
+			annotations: map[string]string{
+				hyperv1.KubeAPIServerGoAwayChanceAnnotation: "0.001",
+			},
+			expectedMaxInflight: "3000",
+			expectedMaxMutating: "1000",
+			expectedGoAway:      "0.001",
+		},
+	}
+
+	imageProvider := imageprovider.NewFromImages(map[string]string{})
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			hcp := &hyperv1.HostedControlPlane{}
+			hcp.Annotations = test.annotations
+			hcp.Spec.Networking.ServiceNetwork = []hyperv1.ServiceNetworkEntry{{CIDR: *ipnet.MustParseCIDR("10.0.0.0/24")}}
+			p := NewKubeAPIServerParams(context.Background(), hcp, imageProvider, "", 0, "", 0, false)
+
+			config := generateConfig(p.ConfigParams())
+			g.Expect(config.APIServerArguments["max-requests-inflight"]).To(ConsistOf(test.expectedMaxInflight))
+			g.Expect(config.APIServerArguments["max-mutating-requests-inflight"]).To(ConsistOf(test.expectedMaxMutating))
+			g.Expect(config.APIServerArguments["goaway-chance"]).To(ConsistOf(test.expectedGoAway))
+			if test.expectedPriorityFairness == nil {
+				g.Expect(config.APIServerArguments).ToNot(HaveKey("enable-priority-and-fairness"))
+			} else {
+				g.Expect(config.APIServerArguments["enable-priority-and-fairness"]).To(ConsistOf(test.expectedPriorityFairness))
+			}
+		})
+	}
+}
+
+func TestKubeAPIServerServiceAccountIssuers(t *testing.T) {
+	tests := []struct {
+		name               string
+		issuerURL          string
+		secondaryIssuerURL string
+		expectedIssuers    []string
+	}{
+		{
+			name:            "When no issuer is set it should use the default issuer",
+			expectedIssuers: []string{config.DefaultServiceAccountIssuer},
+		},
+		{
+			name:            "When an issuer is set it should use it",
+			issuerURL:       "https://oidc.example.com",
+			expectedIssuers: []string{"https://oidc.example.com"},
+		},
+		{
+			name:               "When a secondary issuer is set it should accept it after the issuer",
+			issuerURL:          "https://oidc.example.com",
+			secondaryIssuerURL: "https://bucket.s3.us-east-1.amazonaws.com/infra-id",
+			expectedIssuers:    []string{"https://oidc.example.com", "https://bucket.s3.us-east-1.amazonaws.com/infra-id"},
+		},
+	}
+
+	imageProvider := imageprovider.NewFromImages(map[string]string{})
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			hcp := &hyperv1.HostedControlPlane{}
+			hcp.Spec.IssuerURL = test.issuerURL
+			hcp.Spec.SecondaryIssuerURL = test.secondaryIssuerURL
+			hcp.Spec.Networking.ServiceNetwork = []hyperv1.ServiceNetworkEntry{{CIDR: *ipnet.MustParseCIDR("10.0.0.0/24")}}
+			p := NewKubeAPIServerParams(context.Background(), hcp, imageProvider, "", 0, "", 0, false)
+
+			config := generateConfig(p.ConfigParams())
+			g.Expect(config.APIServerArguments["service-account-issuer"]).To(BeEquivalentTo(test.expectedIssuers))
+			g.Expect(config.APIServerArguments["api-audiences"]).To(BeEquivalentTo(test.expectedIssuers))
+			g.Expect(config.APIServerArguments["service-account-jwks-uri"]).To(ConsistOf(jwksURL(test.expectedIssuers[0])))
+		})
+	}
+}
+
+func TestKubeAPIServerEtcdURL(t *testing.T) {
+	tests := []struct {
+		name            string
+		availability    hyperv1.AvailabilityPolicy
+		expectedEtcdURL string
+	}{
+		{
+			name:            "When the control plane is single replica it should reach etcd through the headless client service",
+			availability:    hyperv1.SingleReplica,
+			expectedEtcdURL: "https://etcd-client.hcp-ns.svc:2379",
+		},
+		{
+			name:            "When the control plane is highly available it should reach etcd through the zonal client service",
+			availability:    hyperv1.HighlyAvailable,
+			expectedEtcdURL: "https://etcd-client-zonal.hcp-ns.svc:2379",
+		},
+	}
+
+	imageProvider := imageprovider.NewFromImages(map[string]string{})
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			hcp := &hyperv1.HostedControlPlane{}
+			hcp.Namespace = "hcp-ns"
+			hcp.Spec.ControllerAvailabilityPolicy = test.availability
+			hcp.Spec.Etcd.ManagementType = hyperv1.Managed
+			hcp.Spec.Networking.ServiceNetwork = []hyperv1.ServiceNetworkEntry{{CIDR: *ipnet.MustParseCIDR("10.0.0.0/24")}}
+			p := NewKubeAPIServerParams(context.Background(), hcp, imageProvider, "", 0, "", 0, false)
+			g.Expect(p.EtcdURL).To(Equal(test.expectedEtcdURL))
+		})
+	}
+}
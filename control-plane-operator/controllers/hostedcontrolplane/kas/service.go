@@ -18,6 +18,10 @@ import (
 	"github.com/openshift/hypershift/support/util"
 )
 
+// azureInternalLoadBalancerAnnotation makes the Azure cloud provider create an
+// internal load balancer for a service.
+const azureInternalLoadBalancerAnnotation = "service.beta.kubernetes.io/azure-load-balancer-internal"
+
 func ReconcileService(svc *corev1.Service, strategy *hyperv1.ServicePublishingStrategy, owner *metav1.OwnerReference, apiServerServicePort int, apiAllowedCIDRBlocks []string, hcp *hyperv1.HostedControlPlane) error {
 	isPublic := util.IsPublicHCP(hcp)
 	isPrivate := util.IsPrivateHCP(hcp)
@@ -52,6 +56,8 @@ func ReconcileService(svc *corev1.Service, strategy *hyperv1.ServicePublishingSt
 	if svc.Annotations == nil {
 		svc.Annotations = map[string]string{}
 	}
+	// In-cluster clients such as the OAuth server reach the kube-apiserver through the cluster IP of the service.
+	util.ReconcileTopologyAwareRouting(svc, hcp.Spec.ControllerAvailabilityPolicy)
 	if hcp.Spec.Platform.Type == hyperv1.AWSPlatform {
 		svc.Annotations["service.beta.kubernetes.io/aws-load-balancer-type"] = "nlb"
 		util.ApplyAWSLoadBalancerSubnetsAnnotation(svc, hcp)
@@ -63,6 +69,15 @@ func ReconcileService(svc *corev1.Service, strategy *hyperv1.ServicePublishingSt
 			if strategy.LoadBalancer != nil && strategy.LoadBalancer.Hostname != "" {
 				svc.Annotations[hyperv1.ExternalDNSHostnameAnnotation] = strategy.LoadBalancer.Hostname
 			}
+			reconcileCustomDomainAnnotations(svc, strategy)
+			if util.IsInternalDNSHCP(hcp) {
+				// No public DNS records are created, the load balancer is only reachable privately
+				delete(svc.Annotations, hyperv1.ExternalDNSHostnameAnnotation)
+				delete(svc.Annotations, hyperv1.ExternalDNSTargetAnnotation)
+				if hcp.Spec.Platform.Type == hyperv1.AzurePlatform {
+					svc.Annotations[azureInternalLoadBalancerAnnotation] = "true"
+				}
+			}
 			if isPrivate {
 				// AWS Private link requires endpoint and service endpoints to exist in the same underlying zone.
 				// To ensure that requirement is satisfied in Regions with more than 3 zones, managed services create subnets in all of them.
@@ -88,6 +103,19 @@ func ReconcileService(svc *corev1.Service, strategy *hyperv1.ServicePublishingSt
 	return nil
 }
 
+// reconcileCustomDomainAnnotations renders the external-dns record pointing the
+// custom domain to the customer frontend. When the strategy has no target the
+// DNS record is managed by the customer and any previously rendered target is
+// removed.
+func reconcileCustomDomainAnnotations(svc *corev1.Service, strategy *hyperv1.ServicePublishingStrategy) {
+	if strategy.CustomDomain == nil || strategy.CustomDomain.Target == "" {
+		delete(svc.Annotations, hyperv1.ExternalDNSTargetAnnotation)
+		return
+	}
+	svc.Annotations[hyperv1.ExternalDNSHostnameAnnotation] = strategy.CustomDomain.Hostname
+	svc.Annotations[hyperv1.ExternalDNSTargetAnnotation] = strategy.CustomDomain.Target
+}
+
 func ReconcileServiceClusterIP(svc *corev1.Service, owner *metav1.OwnerReference) error {
 	util.EnsureOwnerRef(svc, owner)
 	if svc.Spec.Selector == nil {
@@ -128,6 +156,11 @@ func ReconcileServiceStatus(svc *corev1.Service, strategy *hyperv1.ServicePublis
 		}
 		port = int32(apiServerPort)
 		switch {
+		case strategy.CustomDomain != nil:
+			host = strategy.CustomDomain.Hostname
+			if strategy.CustomDomain.Port != 0 {
+				port = strategy.CustomDomain.Port
+			}
 		case strategy.LoadBalancer != nil && strategy.LoadBalancer.Hostname != "":
 			host = strategy.LoadBalancer.Hostname
 		case svc.Status.LoadBalancer.Ingress[0].Hostname != "":
@@ -227,14 +260,13 @@ func reconcileExternalRoute(route *routev1.Route, owner *metav1.OwnerReference,
 	return nil
 }
 
-func ReconcileInternalRoute(route *routev1.Route, owner *metav1.OwnerReference) error {
+func ReconcileInternalRoute(route *routev1.Route, owner *metav1.OwnerReference, internalDNSDomain string) error {
 	util.EnsureOwnerRef(route, owner)
-	route.Spec.Host = fmt.Sprintf("api.%s.hypershift.local", owner.Name)
-	// Assumes owner is the HCP
-	return util.ReconcileInternalRoute(route, "", manifests.KubeAPIServerService("").Name)
+	route.Spec.Host = "api." + internalDNSDomain
+	return util.ReconcileInternalRoute(route, internalDNSDomain, manifests.KubeAPIServerService("").Name)
 }
 
-func ReconcileKonnectivityServerLocalService(svc *corev1.Service, ownerRef config.OwnerRef) error {
+func ReconcileKonnectivityServerLocalService(svc *corev1.Service, ownerRef config.OwnerRef, availability hyperv1.AvailabilityPolicy) error {
 	ownerRef.ApplyTo(svc)
 	svc.Spec.Selector = kasLabels()
 	var portSpec corev1.ServicePort
@@ -248,6 +280,7 @@ func ReconcileKonnectivityServerLocalService(svc *corev1.Service, ownerRef confi
 	portSpec.TargetPort = intstr.FromInt(KonnectivityServerLocalPort)
 	svc.Spec.Type = corev1.ServiceTypeClusterIP
 	svc.Spec.Ports[0] = portSpec
+	util.ReconcileTopologyAwareRouting(svc, availability)
 	return nil
 }
 
@@ -266,7 +299,7 @@ func ReconcileKonnectivityServerService(svc *corev1.Service, ownerRef config.Own
 	switch strategy.Type {
 	case hyperv1.LoadBalancer:
 		svc.Spec.Type = corev1.ServiceTypeLoadBalancer
-		if strategy.LoadBalancer != nil && strategy.LoadBalancer.Hostname != "" {
+		if strategy.LoadBalancer != nil && strategy.LoadBalancer.Hostname != "" && !util.IsInternalDNSHCP(hcp) {
 			if svc.Annotations == nil {
 				svc.Annotations = map[string]string{}
 			}
@@ -299,10 +332,9 @@ func ReconcileKonnectivityExternalRoute(route *routev1.Route, ownerRef config.Ow
 	return nil
 }
 
-func ReconcileKonnectivityInternalRoute(route *routev1.Route, ownerRef config.OwnerRef) error {
+func ReconcileKonnectivityInternalRoute(route *routev1.Route, ownerRef config.OwnerRef, internalDNSDomain string) error {
 	ownerRef.ApplyTo(route)
-	// Assumes ownerRef is the HCP
-	if err := util.ReconcileInternalRoute(route, ownerRef.Reference.Name, manifests.KonnectivityServerService(route.Namespace).Name); err != nil {
+	if err := util.ReconcileInternalRoute(route, internalDNSDomain, manifests.KonnectivityServerService(route.Namespace).Name); err != nil {
 		return err
 	}
 	if route.Annotations == nil {
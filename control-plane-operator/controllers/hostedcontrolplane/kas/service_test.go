@@ -0,0 +1,105 @@
+package kas
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/manifests"
+	"github.com/openshift/hypershift/support/config"
+	"github.com/openshift/hypershift/support/events"
+)
+
+func TestReconcileServiceCustomDomain(t *testing.T) {
+	testCases := []struct {
+		name                string
+		customDomain        *hyperv1.CustomDomainPublishingStrategy
+		expectedAnnotations map[string]string
+		expectedHost        string
+		expectedPort        int32
+	}{
+		{
+			name:                "When no custom domain is set it should publish the load balancer hostname",
+			expectedAnnotations: map[string]string{hyperv1.ExternalDNSHostnameAnnotation: "api.lb.example.com"},
+			expectedHost:        "api.lb.example.com",
+			expectedPort:        7443,
+		},
+		{
+			name:                "When a custom domain without target is set it should publish the custom domain and leave DNS to the customer",
+			customDomain:        &hyperv1.CustomDomainPublishingStrategy{Hostname: "api.example.com", Port: 443},
+			expectedAnnotations: map[string]string{hyperv1.ExternalDNSHostnameAnnotation: "api.lb.example.com"},
+			expectedHost:        "api.example.com",
+			expectedPort:        443,
+		},
+		{
+			name:         "When a custom domain with target is set it should render the external-dns record for the custom domain",
+			customDomain: &hyperv1.CustomDomainPublishingStrategy{Hostname: "api.example.com", Target: "example.azurefd.net"},
+			expectedAnnotations: map[string]string{
+				hyperv1.ExternalDNSHostnameAnnotation: "api.example.com",
+				hyperv1.ExternalDNSTargetAnnotation:   "example.azurefd.net",
+			},
+			expectedHost: "api.example.com",
+			expectedPort: 7443,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			hcp := &hyperv1.HostedControlPlane{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "test"},
+				Spec: hyperv1.HostedControlPlaneSpec{
+					Platform: hyperv1.PlatformSpec{Type: hyperv1.AzurePlatform},
+				},
+			}
+			strategy := &hyperv1.ServicePublishingStrategy{
+				Type:         hyperv1.LoadBalancer,
+				LoadBalancer: &hyperv1.LoadBalancerPublishingStrategy{Hostname: "api.lb.example.com"},
+				CustomDomain: tc.customDomain,
+			}
+
+			svc := manifests.KubeAPIServerService(hcp.Namespace)
+			g.Expect(ReconcileService(svc, strategy, &metav1.OwnerReference{}, 7443, nil, hcp)).To(Succeed())
+			g.Expect(svc.Annotations).To(Equal(tc.expectedAnnotations))
+
+			svc.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{IP: "10.0.0.1"}}
+			host, port, _, err := ReconcileServiceStatus(svc, strategy, 7443, events.NewMessageCollector(nil, nil))
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(host).To(Equal(tc.expectedHost))
+			g.Expect(port).To(Equal(tc.expectedPort))
+		})
+	}
+}
+
+func TestReconcileServiceInternalDNSPublishing(t *testing.T) {
+	g := NewWithT(t)
+	hcp := &hyperv1.HostedControlPlane{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test"},
+		Spec: hyperv1.HostedControlPlaneSpec{
+			Platform: hyperv1.PlatformSpec{Type: hyperv1.AzurePlatform},
+			DNS:      hyperv1.DNSSpec{Publishing: hyperv1.InternalDNSPublishing},
+		},
+	}
+	strategy := &hyperv1.ServicePublishingStrategy{
+		Type:         hyperv1.LoadBalancer,
+		LoadBalancer: &hyperv1.LoadBalancerPublishingStrategy{Hostname: "api.lb.example.com"},
+		CustomDomain: &hyperv1.CustomDomainPublishingStrategy{Hostname: "api.example.com", Target: "example.azurefd.net"},
+	}
+
+	svc := manifests.KubeAPIServerService(hcp.Namespace)
+	g.Expect(ReconcileService(svc, strategy, &metav1.OwnerReference{}, 7443, nil, hcp)).To(Succeed())
+	g.Expect(svc.Spec.Type).To(Equal(corev1.ServiceTypeLoadBalancer))
+	g.Expect(svc.Annotations).To(Equal(map[string]string{azureInternalLoadBalancerAnnotation: "true"}))
+}
+
+func TestReconcileKonnectivityServerLocalServiceTopologyAwareRouting(t *testing.T) {
+	g := NewWithT(t)
+	svc := manifests.KonnectivityServerLocalService("test")
+	g.Expect(ReconcileKonnectivityServerLocalService(svc, config.OwnerRef{}, hyperv1.HighlyAvailable)).To(Succeed())
+	g.Expect(svc.Annotations).To(HaveKeyWithValue(corev1.AnnotationTopologyMode, "Auto"))
+
+	g.Expect(ReconcileKonnectivityServerLocalService(svc, config.OwnerRef{}, hyperv1.SingleReplica)).To(Succeed())
+	g.Expect(svc.Annotations).ToNot(HaveKey(corev1.AnnotationTopologyMode))
+}
@@ -19,5 +19,6 @@ func TestReconcileRecordingRules(t *testing.T) {
 		ruleNames.Insert(rule.Record)
 	}
 	g.Expect(ruleNames.Has("instance:etcd_object_counts:sum")).To(BeTrue())
+	g.Expect(ruleNames.Has("hypershift:control_plane_cross_zone_bytes:rate5m")).To(BeTrue())
 	g.Expect(rules.Spec.Groups[0].Rules[0].Labels).To(HaveKeyWithValue("_id", "fake-id"))
 }
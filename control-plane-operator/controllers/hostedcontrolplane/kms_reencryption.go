@@ -0,0 +1,182 @@
+package hostedcontrolplane
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strconv"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/common"
+	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/imageprovider"
+	kaskms "github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/kas/kms"
+	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/manifests"
+	"github.com/openshift/hypershift/support/upsert"
+	"github.com/openshift/hypershift/support/util"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// kmsReencryptKeyVersionAnnotation is the version of the KMS key the kms-reencrypt job re-encrypts the encrypted
+	// resources with.
+	kmsReencryptKeyVersionAnnotation = "hypershift.openshift.io/kms-key-version"
+
+	kmsReencryptKubeconfigDir = "/etc/kubernetes/kubeconfig"
+)
+
+// ibmCloudKMSSpec returns the IBM Cloud KMS spec of the secret encryption of the HostedControlPlane, or nil if its
+// secrets aren't encrypted with IBM Cloud KMS.
+func ibmCloudKMSSpec(hcp *hyperv1.HostedControlPlane) *hyperv1.IBMCloudKMSSpec {
+	secretEncryption := hcp.Spec.SecretEncryption
+	if secretEncryption == nil || secretEncryption.Type != hyperv1.KMS || secretEncryption.KMS == nil || secretEncryption.KMS.Provider != hyperv1.IBMCloud {
+		return nil
+	}
+	return secretEncryption.KMS.IBMCloud
+}
+
+// reconcileKMSReencryption runs the kms-reencrypt job, which re-encrypts the encrypted resources with the active KMS
+// key, once all the kube-apiserver pods use it. The job is replaced whenever a new key becomes active, so the
+// resources encrypted with the previous keys are re-encrypted before these keys are removed from the key list.
+func (r *HostedControlPlaneReconciler) reconcileKMSReencryption(ctx context.Context, hcp *hyperv1.HostedControlPlane, releaseImageProvider *imageprovider.ReleaseImageProvider, createOrUpdate upsert.CreateOrUpdateFN, kubeAPIServerDeployment *appsv1.Deployment) error {
+	activeKey := kaskms.IBMCloudActiveKey(ibmCloudKMSSpec(hcp).KeyList)
+
+	job := manifests.KMSReencryptJob(hcp.Namespace)
+	if err := r.Get(ctx, client.ObjectKeyFromObject(job), job); err == nil {
+		if job.Annotations[kmsReencryptKeyVersionAnnotation] == strconv.Itoa(activeKey.KeyVersion) || job.DeletionTimestamp != nil {
+			return nil
+		}
+		// The job re-encrypted the resources with a previous key, it is created again once it's deleted.
+		if err := r.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete kms re-encryption job: %w", err)
+		}
+		return nil
+	} else if !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to get kms re-encryption job: %w", err)
+	}
+
+	if !kaskms.IBMCloudKMSPodSpecHasKey(&kubeAPIServerDeployment.Spec.Template.Spec, activeKey) || !util.IsDeploymentReady(ctx, kubeAPIServerDeployment) {
+		r.Log.Info("Waiting for the kube apiserver to roll out the active kms key before re-encrypting resources", "keyVersion", activeKey.KeyVersion)
+		return nil
+	}
+
+	serviceAccount := manifests.KMSReencryptServiceAccount(hcp.Namespace)
+	if _, err := createOrUpdate(ctx, r.Client, serviceAccount, func() error {
+		util.EnsurePullSecret(serviceAccount, common.PullSecret("").Name)
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to reconcile kms re-encryption job service account: %w", err)
+	}
+
+	// The job is only created here: its pod template is immutable, and it's replaced rather than updated.
+	if _, err := createOrUpdate(ctx, r.Client, job, func() error {
+		reconcileKMSReencryptJob(job, serviceAccount, activeKey.KeyVersion, releaseImageProvider.GetImage(util.CPOImageName))
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to create kms re-encryption job: %w", err)
+	}
+	return nil
+}
+
+func reconcileKMSReencryptJob(job *batchv1.Job, serviceAccount *corev1.ServiceAccount, keyVersion int, cpoImage string) {
+	if job.Annotations == nil {
+		job.Annotations = map[string]string{}
+	}
+	job.Annotations[kmsReencryptKeyVersionAnnotation] = strconv.Itoa(keyVersion)
+	job.Spec = batchv1.JobSpec{
+		BackoffLimit: ptr.To[int32](3),
+		Template: corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{"app": job.Name},
+			},
+			Spec: corev1.PodSpec{
+				ServiceAccountName:           serviceAccount.Name,
+				AutomountServiceAccountToken: ptr.To(false),
+				RestartPolicy:                corev1.RestartPolicyNever,
+				Containers: []corev1.Container{
+					{
+						Name:            "kms-reencrypt",
+						Image:           cpoImage,
+						ImagePullPolicy: corev1.PullIfNotPresent,
+						Command:         []string{"/usr/bin/control-plane-operator"},
+						Args: []string{
+							"kms-reencrypt",
+							"--kubeconfig", path.Join(kmsReencryptKubeconfigDir, DefaultAdminKubeconfigKey),
+						},
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse("10m"),
+								corev1.ResourceMemory: resource.MustParse("50Mi"),
+							},
+						},
+						VolumeMounts: []corev1.VolumeMount{
+							{
+								Name:      "kubeconfig",
+								MountPath: kmsReencryptKubeconfigDir,
+							},
+						},
+					},
+				},
+				Volumes: []corev1.Volume{
+					{
+						Name: "kubeconfig",
+						VolumeSource: corev1.VolumeSource{
+							Secret: &corev1.SecretVolumeSource{
+								SecretName:  manifests.KASServiceKubeconfigSecret("").Name,
+								DefaultMode: ptr.To[int32](0640),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// kmsDataReencryptedCondition returns the KMSDataReencrypted condition reporting whether the kms-reencrypt job
+// re-encrypted the encrypted resources with the active KMS key.
+func (r *HostedControlPlaneReconciler) kmsDataReencryptedCondition(ctx context.Context, hcp *hyperv1.HostedControlPlane) (metav1.Condition, error) {
+	activeKey := kaskms.IBMCloudActiveKey(ibmCloudKMSSpec(hcp).KeyList)
+	condition := metav1.Condition{
+		Type:    string(hyperv1.KMSDataReencrypted),
+		Status:  metav1.ConditionFalse,
+		Reason:  hyperv1.RotationInProgressReason,
+		Message: fmt.Sprintf("Waiting for the kube-apiserver to roll out key version %d (root key %s)", activeKey.KeyVersion, activeKey.CRKID),
+	}
+
+	job := manifests.KMSReencryptJob(hcp.Namespace)
+	if err := r.Get(ctx, client.ObjectKeyFromObject(job), job); err != nil {
+		if apierrors.IsNotFound(err) {
+			return condition, nil
+		}
+		return condition, fmt.Errorf("failed to get kms re-encryption job: %w", err)
+	}
+	if job.Annotations[kmsReencryptKeyVersionAnnotation] != strconv.Itoa(activeKey.KeyVersion) || job.DeletionTimestamp != nil {
+		return condition, nil
+	}
+
+	for _, jobCondition := range job.Status.Conditions {
+		if jobCondition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch jobCondition.Type {
+		case batchv1.JobComplete:
+			condition.Status = metav1.ConditionTrue
+			condition.Reason = hyperv1.AsExpectedReason
+			condition.Message = fmt.Sprintf("The encrypted resources are re-encrypted with key version %d (root key %s)", activeKey.KeyVersion, activeKey.CRKID)
+			return condition, nil
+		case batchv1.JobFailed:
+			condition.Reason = hyperv1.RotationFailedReason
+			condition.Message = fmt.Sprintf("Failed to re-encrypt the encrypted resources with key version %d (root key %s): %s. Delete job %s to retry", activeKey.KeyVersion, activeKey.CRKID, jobCondition.Message, job.Name)
+			return condition, nil
+		}
+	}
+	condition.Message = fmt.Sprintf("Re-encrypting the encrypted resources with key version %d (root key %s)", activeKey.KeyVersion, activeKey.CRKID)
+	return condition, nil
+}
@@ -0,0 +1,102 @@
+package hostedcontrolplane
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/imageprovider"
+	kaskms "github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/kas/kms"
+	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/manifests"
+	"github.com/openshift/hypershift/support/api"
+	"github.com/openshift/hypershift/support/util"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+func TestReconcileKMSReencryption(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	firstKey := hyperv1.IBMCloudKMSKeyEntry{CRKID: "crk-1", InstanceID: "instance", URL: "https://us-south.kms.cloud.ibm.com", KeyVersion: 1}
+	secondKey := hyperv1.IBMCloudKMSKeyEntry{CRKID: "crk-2", InstanceID: "instance", URL: "https://us-south.kms.cloud.ibm.com", KeyVersion: 2}
+	ibmCloudKMS := &hyperv1.IBMCloudKMSSpec{
+		Region:  "us-south",
+		Auth:    hyperv1.IBMCloudKMSAuthSpec{Type: hyperv1.IBMCloudKMSManagedAuth},
+		KeyList: []hyperv1.IBMCloudKMSKeyEntry{firstKey},
+	}
+	hcp := &hyperv1.HostedControlPlane{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "hcp-ns", Name: "hcp"},
+		Spec: hyperv1.HostedControlPlaneSpec{
+			SecretEncryption: &hyperv1.SecretEncryptionSpec{
+				Type: hyperv1.KMS,
+				KMS:  &hyperv1.KMSSpec{Provider: hyperv1.IBMCloud, IBMCloud: ibmCloudKMS},
+			},
+		},
+	}
+	kasDeployment := func() *appsv1.Deployment {
+		provider, err := kaskms.NewIBMCloudKMSProvider(ibmCloudKMS, "kms-image")
+		g.Expect(err).ToNot(HaveOccurred())
+		deployment := manifests.KASDeployment(hcp.Namespace)
+		deployment.Spec.Replicas = ptr.To[int32](1)
+		deployment.Spec.Template.Spec.Containers = []corev1.Container{{Name: kaskms.KasMainContainerName}}
+		g.Expect(provider.ApplyKMSConfig(&deployment.Spec.Template.Spec)).To(Succeed())
+		deployment.Status = appsv1.DeploymentStatus{Replicas: 1, ReadyReplicas: 1, AvailableReplicas: 1, UpdatedReplicas: 1}
+		return deployment
+	}
+
+	c := fake.NewClientBuilder().WithScheme(api.Scheme).WithObjects(hcp).WithStatusSubresource(&batchv1.Job{}).Build()
+	r := &HostedControlPlaneReconciler{Client: c, Log: logr.Discard()}
+	releaseImageProvider := imageprovider.NewFromImages(map[string]string{util.CPOImageName: "cpo-image"})
+	expectCondition := func(status metav1.ConditionStatus, reason, message string) {
+		t.Helper()
+		condition, err := r.kmsDataReencryptedCondition(ctx, hcp)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(condition.Status).To(Equal(status))
+		g.Expect(condition.Reason).To(Equal(reason))
+		g.Expect(condition.Message).To(HavePrefix(message))
+	}
+	setJobCondition := func(conditionType batchv1.JobConditionType) {
+		job := manifests.KMSReencryptJob(hcp.Namespace)
+		g.Expect(c.Get(ctx, client.ObjectKeyFromObject(job), job)).To(Succeed())
+		job.Status.Conditions = []batchv1.JobCondition{{Type: conditionType, Status: corev1.ConditionTrue, Message: "Job has reached the specified backoff limit"}}
+		g.Expect(c.Status().Update(ctx, job)).To(Succeed())
+	}
+
+	// When the kube-apiserver uses the active key it should re-encrypt the resources with it.
+	expectCondition(metav1.ConditionFalse, hyperv1.RotationInProgressReason, "Waiting for the kube-apiserver to roll out key version 1 ")
+	g.Expect(r.reconcileKMSReencryption(ctx, hcp, releaseImageProvider, controllerutil.CreateOrUpdate, kasDeployment())).To(Succeed())
+	job := manifests.KMSReencryptJob(hcp.Namespace)
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(job), job)).To(Succeed())
+	g.Expect(job.Annotations).To(HaveKeyWithValue(kmsReencryptKeyVersionAnnotation, "1"))
+	g.Expect(job.Spec.Template.Spec.Containers[0].Image).To(Equal("cpo-image"))
+	g.Expect(job.Spec.Template.Spec.Containers[0].Args).To(Equal([]string{"kms-reencrypt", "--kubeconfig", "/etc/kubernetes/kubeconfig/kubeconfig"}))
+	expectCondition(metav1.ConditionFalse, hyperv1.RotationInProgressReason, "Re-encrypting the encrypted resources with key version 1 ")
+	setJobCondition(batchv1.JobComplete)
+	expectCondition(metav1.ConditionTrue, hyperv1.AsExpectedReason, "The encrypted resources are re-encrypted with key version 1 ")
+
+	// When a new key becomes active it should replace the job once the kube-apiserver uses the new key.
+	previousDeployment := kasDeployment()
+	ibmCloudKMS.KeyList = append(ibmCloudKMS.KeyList, secondKey)
+	expectCondition(metav1.ConditionFalse, hyperv1.RotationInProgressReason, "Waiting for the kube-apiserver to roll out key version 2 ")
+	g.Expect(r.reconcileKMSReencryption(ctx, hcp, releaseImageProvider, controllerutil.CreateOrUpdate, previousDeployment)).To(Succeed())
+	g.Expect(apierrors.IsNotFound(c.Get(ctx, client.ObjectKeyFromObject(job), job))).To(BeTrue())
+	g.Expect(r.reconcileKMSReencryption(ctx, hcp, releaseImageProvider, controllerutil.CreateOrUpdate, previousDeployment)).To(Succeed())
+	g.Expect(apierrors.IsNotFound(c.Get(ctx, client.ObjectKeyFromObject(job), job))).To(BeTrue())
+	g.Expect(r.reconcileKMSReencryption(ctx, hcp, releaseImageProvider, controllerutil.CreateOrUpdate, kasDeployment())).To(Succeed())
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(job), job)).To(Succeed())
+	g.Expect(job.Annotations).To(HaveKeyWithValue(kmsReencryptKeyVersionAnnotation, "2"))
+
+	// When the job fails it should report the failure.
+	setJobCondition(batchv1.JobFailed)
+	expectCondition(metav1.ConditionFalse, hyperv1.RotationFailedReason, "Failed to re-encrypt the encrypted resources with key version 2 (root key crk-2): Job has reached the specified backoff limit")
+}
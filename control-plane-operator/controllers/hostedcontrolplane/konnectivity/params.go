@@ -20,12 +20,14 @@ type KonnectivityParams struct {
 	OwnerRef               config.OwnerRef
 	AgentDeploymentConfig  config.DeploymentConfig
 	AgentDeamonSetConfig   config.DeploymentConfig
+	SyncForever            bool
 }
 
 func NewKonnectivityParams(hcp *hyperv1.HostedControlPlane, releaseImageProvider *imageprovider.ReleaseImageProvider, externalAddress string, externalPort int32, setDefaultSecurityContext bool) *KonnectivityParams {
 	p := &KonnectivityParams{
 		KonnectivityAgentImage: releaseImageProvider.GetImage("konnectivity-agent"),
 		OwnerRef:               config.OwnerRefFrom(hcp),
+		SyncForever:            hcp.Annotations[hyperv1.KonnectivityAgentConnectionRebalancingAnnotation] == "true",
 	}
 
 	p.AgentDeploymentConfig.Resources = config.ResourcesSpec{
@@ -73,7 +73,7 @@ func buildKonnectivityVolumeAgentCerts(v *corev1.Volume) {
 	}
 }
 
-func ReconcileAgentDeployment(deployment *appsv1.Deployment, ownerRef config.OwnerRef, deploymentConfig config.DeploymentConfig, image string, ips []string) error {
+func ReconcileAgentDeployment(deployment *appsv1.Deployment, ownerRef config.OwnerRef, deploymentConfig config.DeploymentConfig, image string, ips []string, syncForever bool) error {
 	ownerRef.ApplyTo(deployment)
 
 	// preserve existing resource requirements for main scheduler container
@@ -91,7 +91,7 @@ func ReconcileAgentDeployment(deployment *appsv1.Deployment, ownerRef config.Own
 		Spec: corev1.PodSpec{
 			AutomountServiceAccountToken: pointer.Bool(false),
 			Containers: []corev1.Container{
-				util.BuildContainer(konnectivityAgentContainer(), buildKonnectivityAgentContainer(image, ips)),
+				util.BuildContainer(konnectivityAgentContainer(), buildKonnectivityAgentContainer(image, ips, syncForever)),
 			},
 			Volumes: []corev1.Volume{
 				util.BuildVolume(konnectivityVolumeAgentCerts(), buildKonnectivityVolumeAgentCerts),
@@ -103,7 +103,7 @@ func ReconcileAgentDeployment(deployment *appsv1.Deployment, ownerRef config.Own
 	return nil
 }
 
-func buildKonnectivityAgentContainer(image string, ips []string) func(c *corev1.Container) {
+func buildKonnectivityAgentContainer(image string, ips []string, syncForever bool) func(c *corev1.Container) {
 	cpath := func(volume, file string) string {
 		return path.Join(volumeMounts.Path(konnectivityAgentContainer().Name, volume), file)
 	}
@@ -148,6 +148,9 @@ func buildKonnectivityAgentContainer(image string, ips []string) func(c *corev1.
 			"--v",
 			"3",
 		}
+		if syncForever {
+			c.Args = append(c.Args, "--sync-forever")
+		}
 		c.VolumeMounts = volumeMounts.ContainerMounts(c.Name)
 	}
 }
@@ -43,8 +43,38 @@ func TestReconcileKonnectivityAgentDeploymentNoChanges(t *testing.T) {
 		g := NewGomegaWithT(t)
 		konnectivityAgentDeployment.Spec.MinReadySeconds = 60
 		expectedMinReadySeconds := konnectivityAgentDeployment.Spec.MinReadySeconds
-		err := ReconcileAgentDeployment(konnectivityAgentDeployment, ownerRef, tc.deploymentConfig, imageName, tc.ips)
+		err := ReconcileAgentDeployment(konnectivityAgentDeployment, ownerRef, tc.deploymentConfig, imageName, tc.ips, false)
 		g.Expect(err).To(BeNil())
 		g.Expect(expectedMinReadySeconds).To(Equal(konnectivityAgentDeployment.Spec.MinReadySeconds))
 	}
 }
+
+func TestReconcileKonnectivityAgentDeploymentSyncForever(t *testing.T) {
+	testCases := []struct {
+		name        string
+		syncForever bool
+	}{
+		{
+			name: "When connection rebalancing is disabled it should stop syncing once connected to all servers",
+		},
+		{
+			name:        "When connection rebalancing is enabled it should keep syncing with the servers",
+			syncForever: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			hcp := &hyperv1.HostedControlPlane{ObjectMeta: metav1.ObjectMeta{Name: "hcp", Namespace: "test"}}
+			deployment := manifests.KonnectivityAgentDeployment(hcp.Namespace)
+
+			err := ReconcileAgentDeployment(deployment, config.OwnerRefFrom(hcp), config.DeploymentConfig{}, "konnectivity-agent-image", []string{"1.2.3.4"}, tc.syncForever)
+			g.Expect(err).ToNot(HaveOccurred())
+			if tc.syncForever {
+				g.Expect(deployment.Spec.Template.Spec.Containers[0].Args).To(ContainElement("--sync-forever"))
+			} else {
+				g.Expect(deployment.Spec.Template.Spec.Containers[0].Args).ToNot(ContainElement("--sync-forever"))
+			}
+		})
+	}
+}
@@ -0,0 +1,214 @@
+package hostedcontrolplane
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	certificatesv1alpha1 "github.com/openshift/hypershift/api/certificates/v1alpha1"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/manifests"
+	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/pki"
+	"github.com/openshift/hypershift/control-plane-pki-operator/certificates"
+	"github.com/openshift/hypershift/support/certs"
+	"github.com/openshift/hypershift/support/util"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// konnectivityRevocationRequests returns the CertificateRevocationRequests of the konnectivity signer class. The
+// konnectivity signer is managed by the control-plane-operator, so it revokes the konnectivity certificates instead of
+// the control-plane-pki-operator.
+func (r *HostedControlPlaneReconciler) konnectivityRevocationRequests(ctx context.Context, hcp *hyperv1.HostedControlPlane) ([]certificatesv1alpha1.CertificateRevocationRequest, error) {
+	crrList := &certificatesv1alpha1.CertificateRevocationRequestList{}
+	if err := r.List(ctx, crrList, client.InNamespace(hcp.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list certificate revocation requests: %w", err)
+	}
+	var crrs []certificatesv1alpha1.CertificateRevocationRequest
+	for _, crr := range crrList.Items {
+		if crr.Spec.SignerClass == string(certificates.KonnectivitySigner) {
+			crrs = append(crrs, crr)
+		}
+	}
+	return crrs, nil
+}
+
+// konnectivityRevocationTimestamp returns the latest revocation timestamp of the CertificateRevocationRequests of the
+// konnectivity signer class, after committing one for the requests observed for the first time. The konnectivity
+// signer is regenerated when it was issued before that time.
+func (r *HostedControlPlaneReconciler) konnectivityRevocationTimestamp(ctx context.Context, hcp *hyperv1.HostedControlPlane) (*metav1.Time, error) {
+	crrs, err := r.konnectivityRevocationRequests(ctx, hcp)
+	if err != nil {
+		return nil, err
+	}
+
+	var revokedBefore *metav1.Time
+	for i := range crrs {
+		crr := &crrs[i]
+		if crr.Status.RevocationTimestamp == nil {
+			original := crr.DeepCopy()
+			// Timestamps are serialized with a precision of a second, like the validity of certificates.
+			revocationTimestamp := metav1.NewTime(time.Now().Truncate(time.Second))
+			crr.Status.RevocationTimestamp = &revocationTimestamp
+			meta.SetStatusCondition(&crr.Status.Conditions, metav1.Condition{
+				Type:    certificatesv1alpha1.SignerClassValidType,
+				Status:  metav1.ConditionTrue,
+				Reason:  hyperv1.AsExpectedReason,
+				Message: fmt.Sprintf("Signer class %q known.", crr.Spec.SignerClass),
+			})
+			// The optimistic lock keeps a stale cache from committing a later timestamp, which would regenerate the
+			// signer again.
+			if err := r.Status().Patch(ctx, crr, client.MergeFromWithOptions(original, client.MergeFromWithOptimisticLock{})); err != nil {
+				return nil, fmt.Errorf("failed to commit the revocation timestamp of certificate revocation request %s: %w", crr.Name, err)
+			}
+			r.Log.Info("Revoking konnectivity certificates", "certificateRevocationRequest", crr.Name, "revokedBefore", revocationTimestamp)
+		}
+		if revokedBefore == nil || revokedBefore.Before(crr.Status.RevocationTimestamp) {
+			revokedBefore = crr.Status.RevocationTimestamp
+		}
+	}
+	return revokedBefore, nil
+}
+
+// reconcileKonnectivityRevocationStatus reports the progress of the revocation of the konnectivity certificates on the
+// CertificateRevocationRequests of the konnectivity signer class. The certificates are revoked once the konnectivity
+// server, which runs in the kube-apiserver pods, only trusts the new signer. The konnectivity agents of the data plane
+// are rolled out with the new CA and certificate by the hosted cluster config operator.
+func (r *HostedControlPlaneReconciler) reconcileKonnectivityRevocationStatus(ctx context.Context, hcp *hyperv1.HostedControlPlane, kubeAPIServerDeployment *appsv1.Deployment) error {
+	crrs, err := r.konnectivityRevocationRequests(ctx, hcp)
+	if err != nil || len(crrs) == 0 {
+		return err
+	}
+
+	signer := manifests.KonnectivitySignerSecret(hcp.Namespace)
+	if err := r.Get(ctx, client.ObjectKeyFromObject(signer), signer); err != nil {
+		return fmt.Errorf("failed to get konnectivity signer secret: %w", err)
+	}
+	ca := manifests.KonnectivityCAConfigMap(hcp.Namespace)
+	if err := r.Get(ctx, client.ObjectKeyFromObject(ca), ca); err != nil {
+		return fmt.Errorf("failed to get konnectivity CA config map: %w", err)
+	}
+	leaves := []*corev1.Secret{
+		manifests.KonnectivityServerSecret(hcp.Namespace),
+		manifests.KonnectivityClusterSecret(hcp.Namespace),
+		manifests.KonnectivityClientSecret(hcp.Namespace),
+		manifests.KonnectivityAgentSecret(hcp.Namespace),
+	}
+	for _, leaf := range leaves {
+		if err := r.Get(ctx, client.ObjectKeyFromObject(leaf), leaf); err != nil {
+			return fmt.Errorf("failed to get konnectivity certificate secret %s: %w", leaf.Name, err)
+		}
+	}
+	agentDeployment := manifests.KonnectivityAgentDeployment(hcp.Namespace)
+	if err := r.Get(ctx, client.ObjectKeyFromObject(agentDeployment), agentDeployment); err != nil {
+		return fmt.Errorf("failed to get konnectivity agent deployment: %w", err)
+	}
+	pendingRollouts := konnectivityCAPendingRollouts(ctx, ca, kubeAPIServerDeployment, agentDeployment)
+
+	for i := range crrs {
+		crr := &crrs[i]
+		if crr.Status.RevocationTimestamp == nil {
+			continue
+		}
+		original := crr.DeepCopy()
+		for _, condition := range konnectivityRevocationConditions(crr.Status.RevocationTimestamp.Time, signer, leaves, pendingRollouts) {
+			meta.SetStatusCondition(&crr.Status.Conditions, condition)
+		}
+		if equality.Semantic.DeepEqual(original.Status, crr.Status) {
+			continue
+		}
+		if err := r.Status().Patch(ctx, crr, client.MergeFrom(original)); err != nil {
+			return fmt.Errorf("failed to update the status of certificate revocation request %s: %w", crr.Name, err)
+		}
+	}
+	return nil
+}
+
+// konnectivityCAPendingRollouts returns the names of the deployments which aren't rolled out with the konnectivity CA.
+func konnectivityCAPendingRollouts(ctx context.Context, ca *corev1.ConfigMap, deployments ...*appsv1.Deployment) []string {
+	var pending []string
+	for _, deployment := range deployments {
+		if deployment.Spec.Template.Annotations[pki.KonnectivityCAHashAnnotation] != pki.KonnectivityCAHash(ca) || !util.IsDeploymentReady(ctx, deployment) {
+			pending = append(pending, deployment.Name)
+		}
+	}
+	return pending
+}
+
+func konnectivityRevocationConditions(revokedBefore time.Time, signer *corev1.Secret, leaves []*corev1.Secret, pendingRollouts []string) []metav1.Condition {
+	if pki.SignerIssuedBefore(signer, revokedBefore) {
+		return []metav1.Condition{
+			{
+				Type:    certificatesv1alpha1.RootCertificatesRegeneratedType,
+				Status:  metav1.ConditionFalse,
+				Reason:  certificatesv1alpha1.RootCertificatesStaleReason,
+				Message: fmt.Sprintf("Signer certificate %s/%s needs to be regenerated.", signer.Namespace, signer.Name),
+			},
+		}
+	}
+	conditions := []metav1.Condition{
+		{
+			Type:    certificatesv1alpha1.RootCertificatesRegeneratedType,
+			Status:  metav1.ConditionTrue,
+			Reason:  hyperv1.AsExpectedReason,
+			Message: fmt.Sprintf("Signer certificate %s/%s regenerated.", signer.Namespace, signer.Name),
+		},
+	}
+
+	var staleLeaves []string
+	for _, leaf := range leaves {
+		if !certs.HasCAHash(leaf, signer, &certs.CAOpts{}) {
+			staleLeaves = append(staleLeaves, leaf.Namespace+"/"+leaf.Name)
+		}
+	}
+	if len(staleLeaves) > 0 {
+		return append(conditions, metav1.Condition{
+			Type:    certificatesv1alpha1.LeafCertificatesRegeneratedType,
+			Status:  metav1.ConditionFalse,
+			Reason:  certificatesv1alpha1.LeafCertificatesStaleReason,
+			Message: fmt.Sprintf("Leaf certificates %s need to be regenerated.", strings.Join(staleLeaves, ", ")),
+		})
+	}
+	conditions = append(conditions, metav1.Condition{
+		Type:    certificatesv1alpha1.LeafCertificatesRegeneratedType,
+		Status:  metav1.ConditionTrue,
+		Reason:  hyperv1.AsExpectedReason,
+		Message: "All leaf certificates are re-generated.",
+	})
+
+	if len(pendingRollouts) > 0 {
+		return append(conditions,
+			metav1.Condition{
+				Type:    certificatesv1alpha1.NewCertificatesTrustedType,
+				Status:  metav1.ConditionFalse,
+				Reason:  hyperv1.WaitingForAvailableReason,
+				Message: fmt.Sprintf("New signer certificate %s/%s not yet trusted, waiting for %s to roll out.", signer.Namespace, signer.Name, strings.Join(pendingRollouts, ", ")),
+			},
+			metav1.Condition{
+				Type:    certificatesv1alpha1.PreviousCertificatesRevokedType,
+				Status:  metav1.ConditionFalse,
+				Reason:  hyperv1.WaitingForAvailableReason,
+				Message: "Previous signer certificate not yet revoked.",
+			},
+		)
+	}
+	return append(conditions,
+		metav1.Condition{
+			Type:    certificatesv1alpha1.NewCertificatesTrustedType,
+			Status:  metav1.ConditionTrue,
+			Reason:  hyperv1.AsExpectedReason,
+			Message: fmt.Sprintf("New signer certificate %s/%s trusted.", signer.Namespace, signer.Name),
+		},
+		metav1.Condition{
+			Type:    certificatesv1alpha1.PreviousCertificatesRevokedType,
+			Status:  metav1.ConditionTrue,
+			Reason:  hyperv1.AsExpectedReason,
+			Message: "Previous signer certificate revoked.",
+		},
+	)
+}
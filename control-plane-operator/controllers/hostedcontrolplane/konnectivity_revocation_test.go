@@ -0,0 +1,180 @@
+package hostedcontrolplane
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	certificatesv1alpha1 "github.com/openshift/hypershift/api/certificates/v1alpha1"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/manifests"
+	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/pki"
+	"github.com/openshift/hypershift/control-plane-pki-operator/certificates"
+	"github.com/openshift/hypershift/support/api"
+	"github.com/openshift/hypershift/support/config"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// konnectivityPKI reconciles the konnectivity signer, and returns it with its CA bundle and the leaf certificates it
+// signed.
+func konnectivityPKI(t *testing.T, signer *corev1.Secret, revokedBefore *metav1.Time) (*corev1.Secret, *corev1.ConfigMap, []*corev1.Secret) {
+	t.Helper()
+	g := NewWithT(t)
+	namespace := signer.Namespace
+	g.Expect(pki.ReconcileKonnectivitySignerSecret(signer, config.OwnerRef{}, revokedBefore)).To(Succeed())
+	ca := manifests.KonnectivityCAConfigMap(namespace)
+	g.Expect(pki.ReconcileKonnectivityConfigMap(ca, config.OwnerRef{}, signer)).To(Succeed())
+	server := manifests.KonnectivityServerSecret(namespace)
+	g.Expect(pki.ReconcileKonnectivityServerSecret(server, signer, config.OwnerRef{})).To(Succeed())
+	cluster := manifests.KonnectivityClusterSecret(namespace)
+	g.Expect(pki.ReconcileKonnectivityClusterSecret(cluster, signer, config.OwnerRef{}, "konnectivity.example.com")).To(Succeed())
+	clientCert := manifests.KonnectivityClientSecret(namespace)
+	g.Expect(pki.ReconcileKonnectivityClientSecret(clientCert, signer, config.OwnerRef{})).To(Succeed())
+	agent := manifests.KonnectivityAgentSecret(namespace)
+	g.Expect(pki.ReconcileKonnectivityAgentSecret(agent, signer, config.OwnerRef{})).To(Succeed())
+	return signer, ca, []*corev1.Secret{server, cluster, clientCert, agent}
+}
+
+func TestKonnectivityRevocationConditions(t *testing.T) {
+	signer, _, leaves := konnectivityPKI(t, manifests.KonnectivitySignerSecret("hcp-ns"), nil)
+	otherSigner, _, otherLeaves := konnectivityPKI(t, manifests.KonnectivitySignerSecret("hcp-ns"), nil)
+
+	conditionStatuses := func(conditions []metav1.Condition) map[string]metav1.ConditionStatus {
+		statuses := map[string]metav1.ConditionStatus{}
+		for _, condition := range conditions {
+			statuses[condition.Type] = condition.Status
+		}
+		return statuses
+	}
+
+	testCases := []struct {
+		name            string
+		revokedBefore   time.Time
+		signer          *corev1.Secret
+		leaves          []*corev1.Secret
+		pendingRollouts []string
+		expected        map[string]metav1.ConditionStatus
+	}{
+		{
+			name:          "When the signer was issued before the revocation it should need to be regenerated",
+			revokedBefore: time.Now().Add(time.Hour),
+			signer:        signer,
+			leaves:        leaves,
+			expected: map[string]metav1.ConditionStatus{
+				certificatesv1alpha1.RootCertificatesRegeneratedType: metav1.ConditionFalse,
+			},
+		},
+		{
+			name:          "When leaf certificates were signed by the previous signer they should need to be regenerated",
+			revokedBefore: time.Now().Add(-time.Hour),
+			signer:        signer,
+			leaves:        append([]*corev1.Secret{otherLeaves[0]}, leaves[1:]...),
+			expected: map[string]metav1.ConditionStatus{
+				certificatesv1alpha1.RootCertificatesRegeneratedType: metav1.ConditionTrue,
+				certificatesv1alpha1.LeafCertificatesRegeneratedType: metav1.ConditionFalse,
+			},
+		},
+		{
+			name:            "When the konnectivity server isn't rolled out with the new CA the previous certificates should not be revoked",
+			revokedBefore:   time.Now().Add(-time.Hour),
+			signer:          otherSigner,
+			leaves:          otherLeaves,
+			pendingRollouts: []string{"kube-apiserver"},
+			expected: map[string]metav1.ConditionStatus{
+				certificatesv1alpha1.RootCertificatesRegeneratedType: metav1.ConditionTrue,
+				certificatesv1alpha1.LeafCertificatesRegeneratedType: metav1.ConditionTrue,
+				certificatesv1alpha1.NewCertificatesTrustedType:      metav1.ConditionFalse,
+				certificatesv1alpha1.PreviousCertificatesRevokedType: metav1.ConditionFalse,
+			},
+		},
+		{
+			name:          "When the konnectivity server and agent are rolled out with the new CA the previous certificates should be revoked",
+			revokedBefore: time.Now().Add(-time.Hour),
+			signer:        signer,
+			leaves:        leaves,
+			expected: map[string]metav1.ConditionStatus{
+				certificatesv1alpha1.RootCertificatesRegeneratedType: metav1.ConditionTrue,
+				certificatesv1alpha1.LeafCertificatesRegeneratedType: metav1.ConditionTrue,
+				certificatesv1alpha1.NewCertificatesTrustedType:      metav1.ConditionTrue,
+				certificatesv1alpha1.PreviousCertificatesRevokedType: metav1.ConditionTrue,
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			conditions := konnectivityRevocationConditions(tc.revokedBefore, tc.signer, tc.leaves, tc.pendingRollouts)
+			g.Expect(conditionStatuses(conditions)).To(Equal(tc.expected))
+		})
+	}
+}
+
+func TestReconcileKonnectivityRevocation(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	hcp := &hyperv1.HostedControlPlane{ObjectMeta: metav1.ObjectMeta{Namespace: "hcp-ns", Name: "hcp"}}
+	konnectivityCRR := &certificatesv1alpha1.CertificateRevocationRequest{
+		ObjectMeta: metav1.ObjectMeta{Namespace: hcp.Namespace, Name: "revoke-konnectivity"},
+		Spec:       certificatesv1alpha1.CertificateRevocationRequestSpec{SignerClass: string(certificates.KonnectivitySigner)},
+	}
+	breakGlassCRR := &certificatesv1alpha1.CertificateRevocationRequest{
+		ObjectMeta: metav1.ObjectMeta{Namespace: hcp.Namespace, Name: "revoke-break-glass"},
+		Spec:       certificatesv1alpha1.CertificateRevocationRequestSpec{SignerClass: string(certificates.CustomerBreakGlassSigner)},
+	}
+	signer, ca, leaves := konnectivityPKI(t, manifests.KonnectivitySignerSecret(hcp.Namespace), nil)
+	rolledOutDeployment := func(deployment *appsv1.Deployment, ca *corev1.ConfigMap) *appsv1.Deployment {
+		deployment.Spec.Replicas = ptr.To[int32](1)
+		pki.ApplyKonnectivityCAHashAnnotation(&deployment.Spec.Template, ca)
+		deployment.Status = appsv1.DeploymentStatus{Replicas: 1, ReadyReplicas: 1, AvailableReplicas: 1, UpdatedReplicas: 1}
+		return deployment
+	}
+	kasDeployment := rolledOutDeployment(manifests.KASDeployment(hcp.Namespace), ca)
+	agentDeployment := rolledOutDeployment(manifests.KonnectivityAgentDeployment(hcp.Namespace), ca)
+
+	objects := []client.Object{hcp, konnectivityCRR, breakGlassCRR, signer, ca, agentDeployment}
+	for _, leaf := range leaves {
+		objects = append(objects, leaf)
+	}
+	c := fake.NewClientBuilder().WithScheme(api.Scheme).WithObjects(objects...).WithStatusSubresource(&certificatesv1alpha1.CertificateRevocationRequest{}).Build()
+	r := &HostedControlPlaneReconciler{Client: c, Log: logr.Discard()}
+
+	// A revocation timestamp should only be committed for the requests of the konnectivity signer class.
+	revokedBefore, err := r.konnectivityRevocationTimestamp(ctx, hcp)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(revokedBefore).ToNot(BeNil())
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(konnectivityCRR), konnectivityCRR)).To(Succeed())
+	g.Expect(konnectivityCRR.Status.RevocationTimestamp).To(Equal(revokedBefore))
+	g.Expect(meta.IsStatusConditionTrue(konnectivityCRR.Status.Conditions, certificatesv1alpha1.SignerClassValidType)).To(BeTrue())
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(breakGlassCRR), breakGlassCRR)).To(Succeed())
+	g.Expect(breakGlassCRR.Status.RevocationTimestamp).To(BeNil())
+
+	// The committed timestamp should not change anymore.
+	again, err := r.konnectivityRevocationTimestamp(ctx, hcp)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(again).To(Equal(revokedBefore))
+
+	// The signer should be regenerated if it was issued before the revocation, along with the certificates it signed.
+	signer, ca, leaves = konnectivityPKI(t, signer, revokedBefore)
+	g.Expect(pki.SignerIssuedBefore(signer, revokedBefore.Time)).To(BeFalse())
+	for _, object := range append([]client.Object{signer, ca}, leaves[0], leaves[1], leaves[2], leaves[3]) {
+		g.Expect(c.Update(ctx, object)).To(Succeed())
+	}
+
+	// Once the konnectivity server and agent are rolled out with the new CA, the revocation should be complete.
+	agentDeployment = rolledOutDeployment(agentDeployment, ca)
+	g.Expect(c.Update(ctx, agentDeployment)).To(Succeed())
+	g.Expect(r.reconcileKonnectivityRevocationStatus(ctx, hcp, rolledOutDeployment(kasDeployment, ca))).To(Succeed())
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(konnectivityCRR), konnectivityCRR)).To(Succeed())
+	g.Expect(meta.IsStatusConditionTrue(konnectivityCRR.Status.Conditions, certificatesv1alpha1.PreviousCertificatesRevokedType)).To(BeTrue())
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(breakGlassCRR), breakGlassCRR)).To(Succeed())
+	g.Expect(breakGlassCRR.Status.Conditions).To(BeEmpty())
+}
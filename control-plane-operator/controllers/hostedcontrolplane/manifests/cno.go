@@ -11,6 +11,7 @@ import (
 const clusterNetworkOperator = "cluster-network-operator"
 const multusAdmissionController = "multus-admission-controller"
 const networkNodeIdentity = "network-node-identity"
+const cloudNetworkConfigController = "cloud-network-config-controller"
 
 func ClusterNetworkOperatorDeployment(ns string) *appsv1.Deployment {
 	return &appsv1.Deployment{
@@ -66,6 +67,15 @@ func NetworkNodeIdentityDeployment(namespace string) *appsv1.Deployment {
 	}
 }
 
+func CloudNetworkConfigControllerDeployment(namespace string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      cloudNetworkConfigController,
+		},
+	}
+}
+
 func OVNKubeSBDBRoute(namespace string) *routev1.Route {
 	return &routev1.Route{
 		ObjectMeta: metav1.ObjectMeta{
@@ -13,3 +13,14 @@ func OpenShiftTrustedCABundleFromCPO(namespace string) *corev1.ConfigMap {
 		},
 	}
 }
+
+// ControlPlaneExtensionsConfigMap is the copy of the control plane extensions ConfigMap referenced by the
+// HostedCluster, synced in the control plane namespace.
+func ControlPlaneExtensionsConfigMap(namespace string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      "control-plane-extensions",
+		},
+	}
+}
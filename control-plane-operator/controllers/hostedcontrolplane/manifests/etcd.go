@@ -41,6 +41,17 @@ func EtcdClientService(ns string) *corev1.Service {
 	}
 }
 
+// EtcdClientZonalService is the ClusterIP service the kube-apiserver of highly available control planes reaches etcd
+// through. Unlike the headless etcd-client service it supports topology aware routing.
+func EtcdClientZonalService(ns string) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "etcd-client-zonal",
+			Namespace: ns,
+		},
+	}
+}
+
 func EtcdServiceMonitor(ns string) *prometheusoperatorv1.ServiceMonitor {
 	return &prometheusoperatorv1.ServiceMonitor{
 		ObjectMeta: metav1.ObjectMeta{
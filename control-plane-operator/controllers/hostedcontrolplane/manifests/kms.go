@@ -0,0 +1,25 @@
+package manifests
+
+import (
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func KMSReencryptServiceAccount(hcpNamespace string) *corev1.ServiceAccount {
+	return &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "kms-reencrypt",
+			Namespace: hcpNamespace,
+		},
+	}
+}
+
+func KMSReencryptJob(hcpNamespace string) *batchv1.Job {
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "kms-reencrypt",
+			Namespace: hcpNamespace,
+		},
+	}
+}
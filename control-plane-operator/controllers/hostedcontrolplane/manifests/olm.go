@@ -99,6 +99,26 @@ func RedHatOperatorsService(ns string) *corev1.Service {
 	}
 }
 
+// Custom Catalogs
+
+func CustomCatalogDeployment(ns, name string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: ns,
+		},
+	}
+}
+
+func CustomCatalogService(ns, name string) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: ns,
+		},
+	}
+}
+
 // Catalog Operator
 
 func CatalogOperatorMetricsService(ns string) *corev1.Service {
@@ -52,6 +52,23 @@ func ReconcileMachineConfigServerConfig(cm *corev1.ConfigMap, p *MCSParams) erro
 		cm.Data["user-ca-bundle-config.yaml"] = serializedUserCA
 	}
 
+	// The image config additionalTrustedCA references a ConfigMap in openshift-config, which the MCC needs in order
+	// to render the registry CAs trusted by the container runtime of the nodes.
+	if p.RegistryCA != nil && len(p.RegistryCA.Data) > 0 {
+		registryCA := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      p.RegistryCA.Name,
+				Namespace: "openshift-config",
+			},
+			Data: p.RegistryCA.Data,
+		}
+		serializedRegistryCA, err := serialize(registryCA)
+		if err != nil {
+			return err
+		}
+		cm.Data["image-registry-ca-config.yaml"] = serializedRegistryCA
+	}
+
 	cm.Data["root-ca.crt"] = string(p.RootCA.Data[certs.CASignerCertMapKey])
 	cm.Data["signer-ca.crt"] = p.KubeletClientCA.Data[certs.CASignerCertMapKey]
 	cm.Data["cluster-dns-02-config.yaml"] = serializedDNS
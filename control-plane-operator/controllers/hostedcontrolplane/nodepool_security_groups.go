@@ -0,0 +1,292 @@
+package hostedcontrolplane
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	supportawsutil "github.com/openshift/hypershift/support/awsutil"
+	"github.com/openshift/hypershift/support/util"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	// nodePoolSecurityGroupFinalizer keeps the ConfigMap of a dedicated NodePool security group until the security
+	// group is deleted.
+	nodePoolSecurityGroupFinalizer = "hypershift.openshift.io/security-group"
+
+	// nodePoolSecurityGroupRulesHashAnnotation records on the ConfigMap the hash of the ingress rules last applied to
+	// the security group, so the security group is only described again once they change.
+	nodePoolSecurityGroupRulesHashAnnotation = "hypershift.openshift.io/security-group-rules-hash"
+)
+
+// reconcileNodePoolSecurityGroups creates the security groups dedicated to NodePools, which the NodePool controller
+// mirrors into ConfigMaps of the control plane namespace, keeps their ingress rules in sync and deletes them once
+// their ConfigMap is deleted. The id of each security group is recorded on its ConfigMap.
+func (r *HostedControlPlaneReconciler) reconcileNodePoolSecurityGroups(ctx context.Context, hcp *hyperv1.HostedControlPlane) error {
+	if hcp.Spec.Platform.Type != hyperv1.AWSPlatform || r.ec2Client == nil {
+		return nil
+	}
+	validProvider := meta.FindStatusCondition(hcp.Status.Conditions, string(hyperv1.ValidAWSIdentityProvider))
+	if validProvider == nil || validProvider.Status != metav1.ConditionTrue {
+		return nil
+	}
+
+	configMaps := &corev1.ConfigMapList{}
+	if err := r.List(ctx, configMaps, client.InNamespace(hcp.Namespace), client.HasLabels{supportawsutil.NodePoolSecurityGroupConfigMapLabel}); err != nil {
+		return fmt.Errorf("failed to list NodePool security group ConfigMaps: %w", err)
+	}
+	var errs []error
+	for i := range configMaps.Items {
+		if err := r.reconcileNodePoolSecurityGroup(ctx, hcp, &configMaps.Items[i]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+func (r *HostedControlPlaneReconciler) reconcileNodePoolSecurityGroup(ctx context.Context, hcp *hyperv1.HostedControlPlane, configMap *corev1.ConfigMap) error {
+	log := ctrl.LoggerFrom(ctx)
+	nodePoolName := configMap.Labels[hyperv1.NodePoolLabel]
+
+	if !configMap.DeletionTimestamp.IsZero() {
+		if !controllerutil.ContainsFinalizer(configMap, nodePoolSecurityGroupFinalizer) {
+			return nil
+		}
+		if sgID := configMap.Annotations[supportawsutil.NodePoolSecurityGroupIDAnnotation]; sgID != "" {
+			// Fails with a DependencyViolation until the instances of the NodePool using the security group are gone.
+			if err := deleteNodePoolSecurityGroup(ctx, r.ec2Client, sgID); err != nil {
+				return fmt.Errorf("failed to delete the security group of NodePool %s: %w", nodePoolName, err)
+			}
+			log.Info("Deleted NodePool security group", "nodePool", nodePoolName, "id", sgID)
+		}
+		original := configMap.DeepCopy()
+		controllerutil.RemoveFinalizer(configMap, nodePoolSecurityGroupFinalizer)
+		if err := r.Patch(ctx, configMap, client.MergeFrom(original)); err != nil {
+			return fmt.Errorf("failed to remove finalizer from %s ConfigMap: %w", configMap.Name, err)
+		}
+		return nil
+	}
+
+	rulesJSON := configMap.Data[supportawsutil.NodePoolSecurityGroupRulesKey]
+	rulesHash := util.HashSimple(rulesJSON)
+	sgID := configMap.Annotations[supportawsutil.NodePoolSecurityGroupIDAnnotation]
+	if sgID != "" && configMap.Annotations[nodePoolSecurityGroupRulesHashAnnotation] == rulesHash {
+		return nil
+	}
+	var rules []hyperv1.AWSSecurityGroupIngressRule
+	if err := json.Unmarshal([]byte(rulesJSON), &rules); err != nil {
+		return fmt.Errorf("failed to decode the ingress rules of NodePool %s: %w", nodePoolName, err)
+	}
+
+	// The finalizer is persisted before the security group is created so it's never leaked.
+	if !controllerutil.ContainsFinalizer(configMap, nodePoolSecurityGroupFinalizer) {
+		original := configMap.DeepCopy()
+		controllerutil.AddFinalizer(configMap, nodePoolSecurityGroupFinalizer)
+		if err := r.Patch(ctx, configMap, client.MergeFrom(original)); err != nil {
+			return fmt.Errorf("failed to add finalizer to %s ConfigMap: %w", configMap.Name, err)
+		}
+	}
+
+	sg, err := ensureNodePoolSecurityGroup(ctx, r.ec2Client, hcp, nodePoolName, sgID)
+	if err != nil {
+		return fmt.Errorf("failed to ensure the security group of NodePool %s: %w", nodePoolName, err)
+	}
+	if err := syncSecurityGroupIngress(ctx, r.ec2Client, sg, supportawsutil.NodePoolSGIngressRules(rules)); err != nil {
+		return fmt.Errorf("failed to sync the ingress rules of the security group of NodePool %s: %w", nodePoolName, err)
+	}
+
+	original := configMap.DeepCopy()
+	if configMap.Annotations == nil {
+		configMap.Annotations = map[string]string{}
+	}
+	configMap.Annotations[supportawsutil.NodePoolSecurityGroupIDAnnotation] = awssdk.StringValue(sg.GroupId)
+	configMap.Annotations[nodePoolSecurityGroupRulesHashAnnotation] = rulesHash
+	if err := r.Patch(ctx, configMap, client.MergeFrom(original)); err != nil {
+		return fmt.Errorf("failed to record the security group of NodePool %s: %w", nodePoolName, err)
+	}
+	return nil
+}
+
+// destroyNodePoolSecurityGroups releases the ConfigMaps of the NodePool security groups when the HostedControlPlane is
+// deleted, deleting the security groups first when the cloud resources are cleaned up. As for the default security
+// group, security groups still in use are skipped.
+func (r *HostedControlPlaneReconciler) destroyNodePoolSecurityGroups(ctx context.Context, hcp *hyperv1.HostedControlPlane, cleanupCloudResources bool) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	configMaps := &corev1.ConfigMapList{}
+	if err := r.List(ctx, configMaps, client.InNamespace(hcp.Namespace), client.HasLabels{supportawsutil.NodePoolSecurityGroupConfigMapLabel}); err != nil {
+		return fmt.Errorf("failed to list NodePool security group ConfigMaps: %w", err)
+	}
+	for i := range configMaps.Items {
+		configMap := &configMaps.Items[i]
+		if !controllerutil.ContainsFinalizer(configMap, nodePoolSecurityGroupFinalizer) {
+			continue
+		}
+		if sgID := configMap.Annotations[supportawsutil.NodePoolSecurityGroupIDAnnotation]; sgID != "" && cleanupCloudResources && r.ec2Client != nil {
+			if err := deleteNodePoolSecurityGroup(ctx, r.ec2Client, sgID); err != nil {
+				if awsErrorCode(err) != "DependencyViolation" {
+					return fmt.Errorf("failed to delete NodePool security group %s: %w", sgID, err)
+				}
+				log.Error(err, "Skipping NodePool security group deletion because of dependency violation.", "id", sgID)
+			}
+		}
+		original := configMap.DeepCopy()
+		controllerutil.RemoveFinalizer(configMap, nodePoolSecurityGroupFinalizer)
+		if err := r.Patch(ctx, configMap, client.MergeFrom(original)); err != nil {
+			return fmt.Errorf("failed to remove finalizer from %s ConfigMap: %w", configMap.Name, err)
+		}
+	}
+	return nil
+}
+
+func nodePoolSecurityGroupFilters(infraID, nodePoolName string) []*ec2.Filter {
+	return []*ec2.Filter{
+		{
+			Name:   awssdk.String(fmt.Sprintf("tag:kubernetes.io/cluster/%s", infraID)),
+			Values: []*string{awssdk.String("owned")},
+		},
+		{
+			Name:   awssdk.String(fmt.Sprintf("tag:%s", supportawsutil.NodePoolSecurityGroupTagKey)),
+			Values: []*string{awssdk.String(nodePoolName)},
+		},
+	}
+}
+
+// ensureNodePoolSecurityGroup returns the security group dedicated to the NodePool, creating it if it doesn't exist.
+// The security group is looked up by id when known, and by its tags otherwise.
+func ensureNodePoolSecurityGroup(ctx context.Context, ec2Client ec2iface.EC2API, hcp *hyperv1.HostedControlPlane, nodePoolName, sgID string) (*ec2.SecurityGroup, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	describeInput := &ec2.DescribeSecurityGroupsInput{Filters: nodePoolSecurityGroupFilters(hcp.Spec.InfraID, nodePoolName)}
+	if sgID != "" {
+		describeInput = &ec2.DescribeSecurityGroupsInput{GroupIds: []*string{awssdk.String(sgID)}}
+	}
+	describeResult, err := ec2Client.DescribeSecurityGroupsWithContext(ctx, describeInput)
+	if err != nil && awsErrorCode(err) != "InvalidGroup.NotFound" {
+		return nil, fmt.Errorf("cannot list security groups, code: %s", awsErrorCode(err))
+	}
+	if err == nil && len(describeResult.SecurityGroups) > 0 {
+		return describeResult.SecurityGroups[0], nil
+	}
+
+	name := supportawsutil.NodePoolSecurityGroupName(hcp.Spec.InfraID, nodePoolName)
+	tags := map[string]string{}
+	for _, tag := range hcp.Spec.Platform.AWS.ResourceTags {
+		tags[tag.Key] = tag.Value
+	}
+	tags[fmt.Sprintf("kubernetes.io/cluster/%s", hcp.Spec.InfraID)] = "owned"
+	tags[supportawsutil.NodePoolSecurityGroupTagKey] = nodePoolName
+	if _, ok := tags["Name"]; !ok {
+		tags["Name"] = name
+	}
+	var ec2Tags []*ec2.Tag
+	for _, key := range sets.StringKeySet(tags).List() {
+		ec2Tags = append(ec2Tags, &ec2.Tag{Key: awssdk.String(key), Value: awssdk.String(tags[key])})
+	}
+	createResult, err := ec2Client.CreateSecurityGroupWithContext(ctx, &ec2.CreateSecurityGroupInput{
+		GroupName:   awssdk.String(name),
+		Description: awssdk.String(fmt.Sprintf("%s NodePool security group", nodePoolName)),
+		VpcId:       awssdk.String(hcp.Spec.Platform.AWS.CloudProviderConfig.VPC),
+		TagSpecifications: []*ec2.TagSpecification{
+			{
+				ResourceType: awssdk.String("security-group"),
+				Tags:         ec2Tags,
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create security group, code: %s", awsErrorCode(err))
+	}
+	sgID = awssdk.StringValue(createResult.GroupId)
+
+	describeInput = &ec2.DescribeSecurityGroupsInput{GroupIds: []*string{awssdk.String(sgID)}}
+	if err := ec2Client.WaitUntilSecurityGroupExistsWithContext(ctx, describeInput); err != nil {
+		return nil, fmt.Errorf("failed to find created security group (id: %s), code: %s", sgID, awsErrorCode(err))
+	}
+	describeResult, err = ec2Client.DescribeSecurityGroupsWithContext(ctx, describeInput)
+	if err != nil || len(describeResult.SecurityGroups) == 0 {
+		return nil, fmt.Errorf("failed to fetch security group (id: %s), code: %s", sgID, awsErrorCode(err))
+	}
+	log.Info("Created NodePool security group", "nodePool", nodePoolName, "id", sgID)
+	return describeResult.SecurityGroups[0], nil
+}
+
+// ipPermissionKey identifies a single CIDR block ingress permission, regardless of its description.
+func ipPermissionKey(permission *ec2.IpPermission, cidr string) string {
+	return fmt.Sprintf("%s/%d/%d/%s", awssdk.StringValue(permission.IpProtocol), awssdk.Int64Value(permission.FromPort), awssdk.Int64Value(permission.ToPort), cidr)
+}
+
+// splitIpPermissions returns the CIDR block permissions of permissions, one per CIDR block, by key. Permissions
+// referencing security groups or prefix lists aren't managed and are left out.
+func splitIpPermissions(permissions []*ec2.IpPermission) map[string]*ec2.IpPermission {
+	result := map[string]*ec2.IpPermission{}
+	for _, permission := range permissions {
+		for _, ipRange := range permission.IpRanges {
+			result[ipPermissionKey(permission, awssdk.StringValue(ipRange.CidrIp))] = &ec2.IpPermission{
+				IpProtocol: permission.IpProtocol,
+				FromPort:   permission.FromPort,
+				ToPort:     permission.ToPort,
+				IpRanges:   []*ec2.IpRange{ipRange},
+			}
+		}
+	}
+	return result
+}
+
+// syncSecurityGroupIngress authorizes the desired ingress permissions missing from the security group and revokes
+// the CIDR block permissions that are no longer desired.
+func syncSecurityGroupIngress(ctx context.Context, ec2Client ec2iface.EC2API, sg *ec2.SecurityGroup, desired []*ec2.IpPermission) error {
+	current := splitIpPermissions(sg.IpPermissions)
+	wanted := splitIpPermissions(desired)
+
+	var authorize, revoke []*ec2.IpPermission
+	for _, key := range sets.StringKeySet(wanted).List() {
+		if _, exists := current[key]; !exists {
+			authorize = append(authorize, wanted[key])
+		}
+	}
+	for _, key := range sets.StringKeySet(current).List() {
+		if _, exists := wanted[key]; !exists {
+			revoke = append(revoke, current[key])
+		}
+	}
+
+	if len(revoke) > 0 {
+		if _, err := ec2Client.RevokeSecurityGroupIngressWithContext(ctx, &ec2.RevokeSecurityGroupIngressInput{
+			GroupId:       sg.GroupId,
+			IpPermissions: revoke,
+		}); err != nil {
+			return fmt.Errorf("failed to revoke security group ingress rules, code: %s", awsErrorCode(err))
+		}
+	}
+	if len(authorize) > 0 {
+		if _, err := ec2Client.AuthorizeSecurityGroupIngressWithContext(ctx, &ec2.AuthorizeSecurityGroupIngressInput{
+			GroupId:       sg.GroupId,
+			IpPermissions: authorize,
+		}); err != nil && awsErrorCode(err) != "InvalidPermission.Duplicate" {
+			return fmt.Errorf("failed to set security group ingress rules, code: %s", awsErrorCode(err))
+		}
+	}
+	return nil
+}
+
+func deleteNodePoolSecurityGroup(ctx context.Context, ec2Client ec2iface.EC2API, sgID string) error {
+	if _, err := ec2Client.DeleteSecurityGroupWithContext(ctx, &ec2.DeleteSecurityGroupInput{
+		GroupId: awssdk.String(sgID),
+	}); err != nil && awsErrorCode(err) != "InvalidGroup.NotFound" {
+		return err
+	}
+	return nil
+}
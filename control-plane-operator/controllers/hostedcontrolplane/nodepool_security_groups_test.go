@@ -0,0 +1,234 @@
+package hostedcontrolplane
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	. "github.com/onsi/gomega"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/support/api"
+	supportawsutil "github.com/openshift/hypershift/support/awsutil"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type fakeSecurityGroupsEC2Client struct {
+	ec2iface.EC2API
+	groups    map[string]*ec2.SecurityGroup
+	created   []*ec2.CreateSecurityGroupInput
+	revoked   []*ec2.IpPermission
+	deleteErr error
+}
+
+func (c *fakeSecurityGroupsEC2Client) DescribeSecurityGroupsWithContext(_ aws.Context, input *ec2.DescribeSecurityGroupsInput, _ ...request.Option) (*ec2.DescribeSecurityGroupsOutput, error) {
+	output := &ec2.DescribeSecurityGroupsOutput{}
+	for _, id := range input.GroupIds {
+		sg, ok := c.groups[aws.StringValue(id)]
+		if !ok {
+			return nil, awserr.New("InvalidGroup.NotFound", "not found", nil)
+		}
+		output.SecurityGroups = append(output.SecurityGroups, sg)
+	}
+	if len(input.Filters) > 0 {
+		for _, sg := range c.groups {
+			matches := true
+			for _, filter := range input.Filters {
+				found := false
+				for _, tag := range sg.Tags {
+					if "tag:"+aws.StringValue(tag.Key) == aws.StringValue(filter.Name) && aws.StringValue(tag.Value) == aws.StringValue(filter.Values[0]) {
+						found = true
+					}
+				}
+				matches = matches && found
+			}
+			if matches {
+				output.SecurityGroups = append(output.SecurityGroups, sg)
+			}
+		}
+	}
+	return output, nil
+}
+
+func (c *fakeSecurityGroupsEC2Client) CreateSecurityGroupWithContext(_ aws.Context, input *ec2.CreateSecurityGroupInput, _ ...request.Option) (*ec2.CreateSecurityGroupOutput, error) {
+	c.created = append(c.created, input)
+	id := "sg-created"
+	c.groups[id] = &ec2.SecurityGroup{GroupId: aws.String(id), Tags: input.TagSpecifications[0].Tags}
+	return &ec2.CreateSecurityGroupOutput{GroupId: aws.String(id)}, nil
+}
+
+func (c *fakeSecurityGroupsEC2Client) WaitUntilSecurityGroupExistsWithContext(aws.Context, *ec2.DescribeSecurityGroupsInput, ...request.WaiterOption) error {
+	return nil
+}
+
+func (c *fakeSecurityGroupsEC2Client) AuthorizeSecurityGroupIngressWithContext(_ aws.Context, input *ec2.AuthorizeSecurityGroupIngressInput, _ ...request.Option) (*ec2.AuthorizeSecurityGroupIngressOutput, error) {
+	sg := c.groups[aws.StringValue(input.GroupId)]
+	sg.IpPermissions = append(sg.IpPermissions, input.IpPermissions...)
+	return &ec2.AuthorizeSecurityGroupIngressOutput{}, nil
+}
+
+func (c *fakeSecurityGroupsEC2Client) RevokeSecurityGroupIngressWithContext(_ aws.Context, input *ec2.RevokeSecurityGroupIngressInput, _ ...request.Option) (*ec2.RevokeSecurityGroupIngressOutput, error) {
+	c.revoked = append(c.revoked, input.IpPermissions...)
+	sg := c.groups[aws.StringValue(input.GroupId)]
+	var kept []*ec2.IpPermission
+	revoked := splitIpPermissions(input.IpPermissions)
+	for key, permission := range splitIpPermissions(sg.IpPermissions) {
+		if _, ok := revoked[key]; !ok {
+			kept = append(kept, permission)
+		}
+	}
+	sg.IpPermissions = kept
+	return &ec2.RevokeSecurityGroupIngressOutput{}, nil
+}
+
+func (c *fakeSecurityGroupsEC2Client) DeleteSecurityGroupWithContext(_ aws.Context, input *ec2.DeleteSecurityGroupInput, _ ...request.Option) (*ec2.DeleteSecurityGroupOutput, error) {
+	if c.deleteErr != nil {
+		return nil, c.deleteErr
+	}
+	delete(c.groups, aws.StringValue(input.GroupId))
+	return &ec2.DeleteSecurityGroupOutput{}, nil
+}
+
+func TestReconcileNodePoolSecurityGroups(t *testing.T) {
+	hcp := &hyperv1.HostedControlPlane{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "hcp-ns", Name: "hcp"},
+		Spec: hyperv1.HostedControlPlaneSpec{
+			InfraID: "infra",
+			Platform: hyperv1.PlatformSpec{
+				Type: hyperv1.AWSPlatform,
+				AWS: &hyperv1.AWSPlatformSpec{
+					CloudProviderConfig: &hyperv1.AWSCloudProviderConfig{VPC: "vpc-1"},
+					ResourceTags:        []hyperv1.AWSResourceTag{{Key: "team", Value: "db"}},
+				},
+			},
+		},
+		Status: hyperv1.HostedControlPlaneStatus{
+			Conditions: []metav1.Condition{{Type: string(hyperv1.ValidAWSIdentityProvider), Status: metav1.ConditionTrue}},
+		},
+	}
+	configMap := func(rules string, annotations map[string]string, finalizers ...string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: hcp.Namespace,
+				Name:      "security-group-db",
+				Labels: map[string]string{
+					supportawsutil.NodePoolSecurityGroupConfigMapLabel: "true",
+					hyperv1.NodePoolLabel:                              "db",
+				},
+				Annotations: annotations,
+				Finalizers:  finalizers,
+			},
+			Data: map[string]string{supportawsutil.NodePoolSecurityGroupRulesKey: rules},
+		}
+	}
+	postgres := `[{"protocol":"tcp","fromPort":5432,"toPort":5432,"cidrBlocks":["10.0.0.0/16"]}]`
+
+	t.Run("When a NodePool has a dedicated security group it should create it and record its id", func(t *testing.T) {
+		g := NewWithT(t)
+		cm := configMap(postgres, nil)
+		c := fake.NewClientBuilder().WithScheme(api.Scheme).WithObjects(cm).Build()
+		ec2Client := &fakeSecurityGroupsEC2Client{groups: map[string]*ec2.SecurityGroup{}}
+		r := &HostedControlPlaneReconciler{Client: c, ec2Client: ec2Client}
+
+		g.Expect(r.reconcileNodePoolSecurityGroups(context.Background(), hcp)).To(Succeed())
+
+		g.Expect(ec2Client.created).To(HaveLen(1))
+		g.Expect(aws.StringValue(ec2Client.created[0].GroupName)).To(Equal("infra-db-sg"))
+		g.Expect(aws.StringValue(ec2Client.created[0].VpcId)).To(Equal("vpc-1"))
+		tags := map[string]string{}
+		for _, tag := range ec2Client.created[0].TagSpecifications[0].Tags {
+			tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+		}
+		g.Expect(tags).To(Equal(map[string]string{
+			"team":                             "db",
+			"kubernetes.io/cluster/infra":      "owned",
+			"hypershift.openshift.io/nodepool": "db",
+			"Name":                             "infra-db-sg",
+		}))
+		g.Expect(splitIpPermissions(ec2Client.groups["sg-created"].IpPermissions)).To(HaveKey("tcp/5432/5432/10.0.0.0/16"))
+
+		g.Expect(c.Get(context.Background(), client.ObjectKeyFromObject(cm), cm)).To(Succeed())
+		g.Expect(cm.Annotations).To(HaveKeyWithValue(supportawsutil.NodePoolSecurityGroupIDAnnotation, "sg-created"))
+		g.Expect(cm.Finalizers).To(ContainElement(nodePoolSecurityGroupFinalizer))
+
+		// Reconciling again with the same rules doesn't call AWS.
+		ec2Client.groups = nil
+		g.Expect(r.reconcileNodePoolSecurityGroups(context.Background(), hcp)).To(Succeed())
+	})
+
+	t.Run("When the ingress rules change it should sync them with the security group", func(t *testing.T) {
+		g := NewWithT(t)
+		cm := configMap(`[{"protocol":"tcp","fromPort":443,"toPort":443,"cidrBlocks":["0.0.0.0/0"]}]`,
+			map[string]string{supportawsutil.NodePoolSecurityGroupIDAnnotation: "sg-1", nodePoolSecurityGroupRulesHashAnnotation: "outdated"},
+			nodePoolSecurityGroupFinalizer)
+		c := fake.NewClientBuilder().WithScheme(api.Scheme).WithObjects(cm).Build()
+		ec2Client := &fakeSecurityGroupsEC2Client{groups: map[string]*ec2.SecurityGroup{
+			"sg-1": {
+				GroupId:       aws.String("sg-1"),
+				IpPermissions: supportawsutil.NodePoolSGIngressRules([]hyperv1.AWSSecurityGroupIngressRule{{Protocol: hyperv1.AWSSecurityGroupProtocolTCP, FromPort: aws.Int32(5432), ToPort: aws.Int32(5432), CIDRBlocks: []string{"10.0.0.0/16"}}}),
+			},
+		}}
+		r := &HostedControlPlaneReconciler{Client: c, ec2Client: ec2Client}
+
+		g.Expect(r.reconcileNodePoolSecurityGroups(context.Background(), hcp)).To(Succeed())
+
+		g.Expect(ec2Client.created).To(BeEmpty())
+		permissions := splitIpPermissions(ec2Client.groups["sg-1"].IpPermissions)
+		g.Expect(permissions).To(HaveLen(1))
+		g.Expect(permissions).To(HaveKey("tcp/443/443/0.0.0.0/0"))
+		g.Expect(ec2Client.revoked).To(HaveLen(1))
+	})
+
+	t.Run("When the ConfigMap is deleted it should delete the security group and release the ConfigMap", func(t *testing.T) {
+		g := NewWithT(t)
+		cm := configMap(postgres, map[string]string{supportawsutil.NodePoolSecurityGroupIDAnnotation: "sg-1"}, nodePoolSecurityGroupFinalizer)
+		c := fake.NewClientBuilder().WithScheme(api.Scheme).WithObjects(cm).Build()
+		g.Expect(c.Delete(context.Background(), cm)).To(Succeed())
+		ec2Client := &fakeSecurityGroupsEC2Client{groups: map[string]*ec2.SecurityGroup{"sg-1": {GroupId: aws.String("sg-1")}}}
+		r := &HostedControlPlaneReconciler{Client: c, ec2Client: ec2Client}
+
+		g.Expect(r.reconcileNodePoolSecurityGroups(context.Background(), hcp)).To(Succeed())
+
+		g.Expect(ec2Client.groups).To(BeEmpty())
+		err := c.Get(context.Background(), client.ObjectKeyFromObject(cm), cm)
+		g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	t.Run("When the security group is still in use it should keep the ConfigMap", func(t *testing.T) {
+		g := NewWithT(t)
+		cm := configMap(postgres, map[string]string{supportawsutil.NodePoolSecurityGroupIDAnnotation: "sg-1"}, nodePoolSecurityGroupFinalizer)
+		c := fake.NewClientBuilder().WithScheme(api.Scheme).WithObjects(cm).Build()
+		g.Expect(c.Delete(context.Background(), cm)).To(Succeed())
+		ec2Client := &fakeSecurityGroupsEC2Client{
+			groups:    map[string]*ec2.SecurityGroup{"sg-1": {GroupId: aws.String("sg-1")}},
+			deleteErr: awserr.New("DependencyViolation", "in use", nil),
+		}
+		r := &HostedControlPlaneReconciler{Client: c, ec2Client: ec2Client}
+
+		g.Expect(r.reconcileNodePoolSecurityGroups(context.Background(), hcp)).ToNot(Succeed())
+		g.Expect(c.Get(context.Background(), client.ObjectKeyFromObject(cm), cm)).To(Succeed())
+		g.Expect(cm.Finalizers).To(ContainElement(nodePoolSecurityGroupFinalizer))
+	})
+
+	t.Run("When the HostedControlPlane is deleted it should release the ConfigMaps", func(t *testing.T) {
+		g := NewWithT(t)
+		cm := configMap(postgres, map[string]string{supportawsutil.NodePoolSecurityGroupIDAnnotation: "sg-1"}, nodePoolSecurityGroupFinalizer)
+		c := fake.NewClientBuilder().WithScheme(api.Scheme).WithObjects(cm).Build()
+		ec2Client := &fakeSecurityGroupsEC2Client{
+			groups:    map[string]*ec2.SecurityGroup{"sg-1": {GroupId: aws.String("sg-1")}},
+			deleteErr: awserr.New("DependencyViolation", "in use", nil),
+		}
+		r := &HostedControlPlaneReconciler{Client: c, ec2Client: ec2Client}
+
+		g.Expect(r.destroyNodePoolSecurityGroups(context.Background(), hcp, true)).To(Succeed())
+		g.Expect(c.Get(context.Background(), client.ObjectKeyFromObject(cm), cm)).To(Succeed())
+		g.Expect(cm.Finalizers).To(BeEmpty())
+	})
+}
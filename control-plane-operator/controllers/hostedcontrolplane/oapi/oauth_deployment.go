@@ -3,6 +3,7 @@ package oapi
 import (
 	"fmt"
 	"path"
+	"strings"
 
 	configv1 "github.com/openshift/api/config/v1"
 	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
@@ -152,6 +153,10 @@ func buildOAuthContainerMain(p *OAuthDeploymentParams) func(c *corev1.Container)
 		cpath := func(volume, file string) string {
 			return path.Join(oauthVolumeMounts.Path(c.Name, volume), file)
 		}
+		apiAudiences := []string{p.ServiceAccountIssuerURL}
+		if p.SecondaryIssuerURL != "" {
+			apiAudiences = append(apiAudiences, p.SecondaryIssuerURL)
+		}
 		c.Image = p.Image
 		c.Command = []string{"/usr/bin/oauth-apiserver"}
 		c.Args = []string{
@@ -160,7 +165,7 @@ func buildOAuthContainerMain(p *OAuthDeploymentParams) func(c *corev1.Container)
 			fmt.Sprintf("--authentication-kubeconfig=%s", cpath(oauthVolumeKubeconfig().Name, kas.KubeconfigKey)),
 			fmt.Sprintf("--kubeconfig=%s", cpath(oauthVolumeKubeconfig().Name, kas.KubeconfigKey)),
 			fmt.Sprintf("--secure-port=%d", OpenShiftOAuthAPIServerPort),
-			fmt.Sprintf("--api-audiences=%s", p.ServiceAccountIssuerURL),
+			fmt.Sprintf("--api-audiences=%s", strings.Join(apiAudiences, ",")),
 			fmt.Sprintf("--audit-log-path=%s", cpath(oauthVolumeWorkLogs().Name, "audit.log")),
 			"--audit-log-format=json",
 			"--audit-log-maxsize=10",
@@ -19,6 +19,7 @@ type OpenShiftAPIServerParams struct {
 	IngressSubDomain        string
 	EtcdURL                 string `json:"etcdURL"`
 	ServiceAccountIssuerURL string `json:"serviceAccountIssuerURL"`
+	SecondaryIssuerURL      string `json:"secondaryIssuerURL"`
 
 	OpenShiftAPIServerDeploymentConfig      config.DeploymentConfig `json:"openshiftAPIServerDeploymentConfig,inline"`
 	OpenShiftOAuthAPIServerDeploymentConfig config.DeploymentConfig `json:"openshiftOAuthAPIServerDeploymentConfig,inline"`
@@ -41,6 +42,7 @@ type OAuthDeploymentParams struct {
 	MinTLSVersion                string
 	CipherSuites                 []string
 	ServiceAccountIssuerURL      string
+	SecondaryIssuerURL           string
 	DeploymentConfig             config.DeploymentConfig
 	AvailabilityProberImage      string
 	Availability                 hyperv1.AvailabilityPolicy
@@ -55,6 +57,7 @@ func NewOpenShiftAPIServerParams(hcp *hyperv1.HostedControlPlane, observedConfig
 		OAuthAPIServerImage:     releaseImageProvider.GetImage("oauth-apiserver"),
 		ProxyImage:              releaseImageProvider.GetImage("socks5-proxy"),
 		ServiceAccountIssuerURL: hcp.Spec.IssuerURL,
+		SecondaryIssuerURL:      hcp.Spec.SecondaryIssuerURL,
 		IngressSubDomain:        globalconfig.IngressDomain(hcp),
 		AvailabilityProberImage: releaseImageProvider.GetImage(util.AvailabilityProberImageName),
 		Availability:            hcp.Spec.ControllerAvailabilityPolicy,
@@ -220,6 +223,7 @@ func (p *OpenShiftAPIServerParams) OAuthAPIServerDeploymentParams(hcp *hyperv1.H
 		Image:                   p.OAuthAPIServerImage,
 		EtcdURL:                 p.EtcdURL,
 		ServiceAccountIssuerURL: p.ServiceAccountIssuerURL,
+		SecondaryIssuerURL:      p.SecondaryIssuerURL,
 		DeploymentConfig:        p.OpenShiftOAuthAPIServerDeploymentConfig,
 		MinTLSVersion:           p.MinTLSVersion(),
 		CipherSuites:            p.CipherSuites(),
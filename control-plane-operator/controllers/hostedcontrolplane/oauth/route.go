@@ -28,8 +28,7 @@ func ReconcileExternalPrivateRoute(route *routev1.Route, ownerRef config.OwnerRe
 	return nil
 }
 
-func ReconcileInternalRoute(route *routev1.Route, ownerRef config.OwnerRef) error {
+func ReconcileInternalRoute(route *routev1.Route, ownerRef config.OwnerRef, internalDNSDomain string) error {
 	ownerRef.ApplyTo(route)
-	// Assumes ownerRef is the HCP
-	return util.ReconcileInternalRoute(route, ownerRef.Reference.Name, manifests.OauthServerService(route.Namespace).Name)
+	return util.ReconcileInternalRoute(route, internalDNSDomain, manifests.OauthServerService(route.Namespace).Name)
 }
@@ -10,7 +10,9 @@ import (
 	"github.com/openshift/hypershift/support/assets"
 	prometheusoperatorv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	imagev1 "github.com/openshift/api/image/v1"
 
@@ -109,6 +111,90 @@ func reconcileCatalogDeployment(deployment *appsv1.Deployment, ownerRef config.O
 	return nil
 }
 
+// CustomCatalogSourceLabel labels the resources of a custom catalog source with its name.
+const CustomCatalogSourceLabel = "hypershift.openshift.io/custom-catalog-source"
+
+// IsDefaultCatalog returns whether the catalog is one of the default catalogs.
+func IsDefaultCatalog(name string) bool {
+	_, ok := CatalogToImage[name]
+	return ok
+}
+
+// DefaultCatalogsDisabled returns whether the default catalogs are disabled by the OLMCatalogs configuration.
+func DefaultCatalogsDisabled(hcp *hyperv1.HostedControlPlane) bool {
+	return hcp.Spec.OLMCatalogs != nil && hcp.Spec.OLMCatalogs.DisableDefaultCatalogs
+}
+
+// CatalogSourceOverride returns the custom catalog source replacing the given default catalog, if any.
+func CatalogSourceOverride(hcp *hyperv1.HostedControlPlane, catalog string) *hyperv1.OLMCatalogSource {
+	if hcp.Spec.OLMCatalogs == nil {
+		return nil
+	}
+	for i := range hcp.Spec.OLMCatalogs.CatalogSources {
+		if hcp.Spec.OLMCatalogs.CatalogSources[i].Name == catalog {
+			return &hcp.Spec.OLMCatalogs.CatalogSources[i]
+		}
+	}
+	return nil
+}
+
+// CustomCatalogSources returns the custom catalog sources which don't replace a default catalog.
+func CustomCatalogSources(hcp *hyperv1.HostedControlPlane) []hyperv1.OLMCatalogSource {
+	if hcp.Spec.OLMCatalogs == nil {
+		return nil
+	}
+	var sources []hyperv1.OLMCatalogSource
+	for _, source := range hcp.Spec.OLMCatalogs.CatalogSources {
+		if !IsDefaultCatalog(source.Name) {
+			sources = append(sources, source)
+		}
+	}
+	return sources
+}
+
+// CustomCatalogServiceName returns the name of the service serving a custom catalog source in the control plane
+// namespace. It is prefixed so it can't conflict with control plane services.
+func CustomCatalogServiceName(name string) string {
+	return "catalog-" + name
+}
+
+// CustomCatalogSourceSelector selects the deployments and services serving custom catalog sources.
+func CustomCatalogSourceSelector() client.MatchingLabelsSelector {
+	selector, _ := labels.Parse(CustomCatalogSourceLabel)
+	return client.MatchingLabelsSelector{Selector: selector}
+}
+
+func customCatalogLabels(name string) map[string]string {
+	return map[string]string{
+		CustomCatalogSourceLabel:      name,
+		"app":                         CustomCatalogServiceName(name),
+		hyperv1.ControlPlaneComponent: CustomCatalogServiceName(name),
+	}
+}
+
+func ReconcileCustomCatalogService(svc *corev1.Service, ownerRef config.OwnerRef, name string) error {
+	if err := reconcileCatalogService(svc, ownerRef, redHatOperatorsCatalogService); err != nil {
+		return err
+	}
+	if svc.Labels == nil {
+		svc.Labels = map[string]string{}
+	}
+	svc.Labels[CustomCatalogSourceLabel] = name
+	svc.Spec.Selector = map[string]string{CustomCatalogSourceLabel: name}
+	return nil
+}
+
+func ReconcileCustomCatalogDeployment(deployment *appsv1.Deployment, ownerRef config.OwnerRef, dc config.DeploymentConfig, source hyperv1.OLMCatalogSource) error {
+	sourceDeployment := redHatOperatorsCatalogDeployment.DeepCopy()
+	sourceDeployment.Spec.Selector.MatchLabels = map[string]string{CustomCatalogSourceLabel: source.Name}
+	sourceDeployment.Spec.Template.Labels = customCatalogLabels(source.Name)
+	if err := reconcileCatalogDeployment(deployment, ownerRef, dc, sourceDeployment, source.Image); err != nil {
+		return err
+	}
+	deployment.Labels[CustomCatalogSourceLabel] = source.Name
+	return nil
+}
+
 func findTagReference(tags []imagev1.TagReference, name string) *imagev1.TagReference {
 	for _, tag := range tags {
 		if tag.Name == name {
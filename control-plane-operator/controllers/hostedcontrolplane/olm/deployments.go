@@ -8,6 +8,7 @@ import (
 
 type OLMDeployment struct {
 	Name       string
+	Catalog    string
 	Manifest   *appsv1.Deployment
 	Reconciler func(*appsv1.Deployment, config.OwnerRef, config.DeploymentConfig, string) error
 	Image      string
@@ -17,27 +18,31 @@ func OLMDeployments(p *OperatorLifecycleManagerParams, hcpNamespace string) []OL
 	return []OLMDeployment{
 		{
 			Name:       "certifiedOperatorsDeployment",
+			Catalog:    "certified-operators",
 			Manifest:   manifests.CertifiedOperatorsDeployment(hcpNamespace),
 			Reconciler: ReconcileCertifiedOperatorsDeployment,
-			Image:      p.CertifiedOperatorsCatalogImageOverride,
+			Image:      p.catalogImage("certified-operators", p.CertifiedOperatorsCatalogImageOverride),
 		},
 		{
 			Name:       "communityOperatorsDeployment",
+			Catalog:    "community-operators",
 			Manifest:   manifests.CommunityOperatorsDeployment(hcpNamespace),
 			Reconciler: ReconcileCommunityOperatorsDeployment,
-			Image:      p.CommunityOperatorsCatalogImageOverride,
+			Image:      p.catalogImage("community-operators", p.CommunityOperatorsCatalogImageOverride),
 		},
 		{
 			Name:       "marketplaceOperatorsDeployment",
+			Catalog:    "redhat-marketplace",
 			Manifest:   manifests.RedHatMarketplaceOperatorsDeployment(hcpNamespace),
 			Reconciler: ReconcileRedHatMarketplaceOperatorsDeployment,
-			Image:      p.RedHatMarketplaceCatalogImageOverride,
+			Image:      p.catalogImage("redhat-marketplace", p.RedHatMarketplaceCatalogImageOverride),
 		},
 		{
 			Name:       "redHatOperatorsDeployment",
+			Catalog:    "redhat-operators",
 			Manifest:   manifests.RedHatOperatorsDeployment(hcpNamespace),
 			Reconciler: ReconcileRedHatOperatorsDeployment,
-			Image:      p.RedHatOperatorsCatalogImageOverride,
+			Image:      p.catalogImage("redhat-operators", p.RedHatOperatorsCatalogImageOverride),
 		},
 	}
 }
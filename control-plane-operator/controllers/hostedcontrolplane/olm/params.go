@@ -23,6 +23,7 @@ type OperatorLifecycleManagerParams struct {
 	RedHatMarketplaceCatalogImageOverride    string
 	RedHatOperatorsCatalogImageOverride      string
 	OLMCatalogsISRegistryOverridesAnnotation string
+	CatalogSourceImages                      map[string]string
 	ReleaseVersion                           string
 	DeploymentConfig                         config.DeploymentConfig
 	PackageServerConfig                      config.DeploymentConfig
@@ -68,6 +69,9 @@ func NewOperatorLifecycleManagerParams(hcp *hyperv1.HostedControlPlane, releaseI
 
 	if hcp.Spec.OLMCatalogPlacement == "management" {
 		params.NoProxy = append(params.NoProxy, "certified-operators", "community-operators", "redhat-operators", "redhat-marketplace")
+		for _, source := range CustomCatalogSources(hcp) {
+			params.NoProxy = append(params.NoProxy, CustomCatalogServiceName(source.Name))
+		}
 	}
 
 	params.CertifiedOperatorsCatalogImageOverride = hcp.Annotations[hyperv1.CertifiedOperatorsCatalogImageAnnotation]
@@ -77,5 +81,21 @@ func NewOperatorLifecycleManagerParams(hcp *hyperv1.HostedControlPlane, releaseI
 
 	params.OLMCatalogsISRegistryOverridesAnnotation = hcp.Annotations[hyperv1.OLMCatalogsISRegistryOverridesAnnotation]
 
+	params.CatalogSourceImages = map[string]string{}
+	for catalog := range CatalogToImage {
+		if source := CatalogSourceOverride(hcp, catalog); source != nil {
+			params.CatalogSourceImages[catalog] = source.Image
+		}
+	}
+
 	return params
 }
+
+// catalogImage returns the image of a default catalog: the image of the custom catalog source replacing it if any,
+// otherwise the image override.
+func (p *OperatorLifecycleManagerParams) catalogImage(catalog, imageOverride string) string {
+	if image, ok := p.CatalogSourceImages[catalog]; ok {
+		return image
+	}
+	return imageOverride
+}
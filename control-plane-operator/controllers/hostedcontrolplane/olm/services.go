@@ -8,6 +8,7 @@ import (
 
 type OLMService struct {
 	Name       string
+	Catalog    string
 	Manifest   *corev1.Service
 	Reconciler func(*corev1.Service, config.OwnerRef) error
 }
@@ -16,21 +17,25 @@ func OLMServices(hcpNamespace string) []OLMService {
 	return []OLMService{
 		{
 			Name:       "certifiedOperatorsService",
+			Catalog:    "certified-operators",
 			Manifest:   manifests.CertifiedOperatorsService(hcpNamespace),
 			Reconciler: ReconcileCertifiedOperatorsService,
 		},
 		{
 			Name:       "communityOperatorsService",
+			Catalog:    "community-operators",
 			Manifest:   manifests.CommunityOperatorsService(hcpNamespace),
 			Reconciler: ReconcileCommunityOperatorsService,
 		},
 		{
 			Name:       "marketplaceOperatorsService",
+			Catalog:    "redhat-marketplace",
 			Manifest:   manifests.RedHatMarketplaceOperatorsService(hcpNamespace),
 			Reconciler: ReconcileRedHatMarketplaceOperatorsService,
 		},
 		{
 			Name:       "redHatOperatorsService",
+			Catalog:    "redhat-operators",
 			Manifest:   manifests.RedHatOperatorsService(hcpNamespace),
 			Reconciler: ReconcileRedHatOperatorsService,
 		},
@@ -24,7 +24,7 @@ const (
 	ServiceSignerPublicKey  = "service-account.pub"
 )
 
-func ReconcileKASServerCertSecret(secret, ca *corev1.Secret, ownerRef config.OwnerRef, externalAPIAddress, internalAPIAddress string, serviceCIDRs []string, nodeInternalAPIServerIP string) error {
+func ReconcileKASServerCertSecret(secret, ca *corev1.Secret, ownerRef config.OwnerRef, externalAPIAddress, internalAPIAddress string, additionalExternalAPIAddresses, serviceCIDRs []string, nodeInternalAPIServerIP string) error {
 	svc := manifests.KubeAPIServerService(secret.Namespace)
 	svcAddresses := make([]string, 0)
 
@@ -53,10 +53,12 @@ func ReconcileKASServerCertSecret(secret, ca *corev1.Secret, ownerRef config.Own
 	apiServerIPs = append(apiServerIPs, svcAddresses...)
 	apiServerIPs = append(apiServerIPs, nodeInternalAPIServerIP)
 
-	if isNumericIP(externalAPIAddress) {
-		apiServerIPs = append(apiServerIPs, externalAPIAddress)
-	} else {
-		dnsNames = append(dnsNames, externalAPIAddress)
+	for _, address := range append([]string{externalAPIAddress}, additionalExternalAPIAddresses...) {
+		if isNumericIP(address) {
+			apiServerIPs = append(apiServerIPs, address)
+		} else {
+			dnsNames = append(dnsNames, address)
+		}
 	}
 	if isNumericIP(internalAPIAddress) {
 		apiServerIPs = append(apiServerIPs, internalAPIAddress)
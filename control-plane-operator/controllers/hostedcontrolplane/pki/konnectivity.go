@@ -2,15 +2,52 @@ package pki
 
 import (
 	"fmt"
+	"time"
 
+	"github.com/openshift/hypershift/support/certs"
 	"github.com/openshift/hypershift/support/config"
+	"github.com/openshift/hypershift/support/util"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-func ReconcileKonnectivitySignerSecret(secret *corev1.Secret, ownerRef config.OwnerRef) error {
+// KonnectivityCAHashAnnotation is the hash of the konnectivity CA the pods of the konnectivity server and agents run
+// with. They only load their certificates on startup, so they must be rolled out when the konnectivity signer is
+// regenerated.
+const KonnectivityCAHashAnnotation = "hypershift.openshift.io/konnectivity-ca-hash"
+
+// ReconcileKonnectivitySignerSecret reconciles the konnectivity signer. When revokedBefore is set, the signer is
+// regenerated if it was issued before that time, so that the certificates it signed stop being trusted.
+func ReconcileKonnectivitySignerSecret(secret *corev1.Secret, ownerRef config.OwnerRef, revokedBefore *metav1.Time) error {
+	if revokedBefore != nil && SignerIssuedBefore(secret, revokedBefore.Time) {
+		delete(secret.Data, certs.CASignerCertMapKey)
+		delete(secret.Data, certs.CASignerKeyMapKey)
+	}
 	return reconcileSelfSignedCA(secret, ownerRef, "konnectivity-signer", "kubernetes")
 }
 
+// SignerIssuedBefore returns whether the certificate of the signer secret was issued before t.
+func SignerIssuedBefore(secret *corev1.Secret, t time.Time) bool {
+	cert, err := certs.PemToCertificate(secret.Data[certs.CASignerCertMapKey])
+	if err != nil {
+		return false
+	}
+	return cert.NotBefore.Before(t)
+}
+
+// KonnectivityCAHash returns the hash of the konnectivity CA bundle.
+func KonnectivityCAHash(ca *corev1.ConfigMap) string {
+	return util.HashSimple(ca.Data[certs.CASignerCertMapKey])
+}
+
+// ApplyKonnectivityCAHashAnnotation rolls out the pods of the pod template when the konnectivity CA changes.
+func ApplyKonnectivityCAHashAnnotation(podTemplate *corev1.PodTemplateSpec, ca *corev1.ConfigMap) {
+	if podTemplate.Annotations == nil {
+		podTemplate.Annotations = map[string]string{}
+	}
+	podTemplate.Annotations[KonnectivityCAHashAnnotation] = KonnectivityCAHash(ca)
+}
+
 func ReconcileKonnectivityServerSecret(secret, ca *corev1.Secret, ownerRef config.OwnerRef) error {
 	dnsNames := []string{
 		"localhost",
@@ -0,0 +1,56 @@
+package pki
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/openshift/hypershift/support/certs"
+	"github.com/openshift/hypershift/support/config"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestReconcileKonnectivitySignerSecret(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name             string
+		revokedBefore    *metav1.Time
+		expectRegenerate bool
+	}{
+		{
+			name: "No revocation, signer is kept",
+		},
+		{
+			name:          "Signer issued after the revocation, signer is kept",
+			revokedBefore: &metav1.Time{Time: time.Now().Add(-time.Hour)},
+		},
+		{
+			name:             "Signer issued before the revocation, signer is regenerated",
+			revokedBefore:    &metav1.Time{Time: time.Now().Add(time.Hour)},
+			expectRegenerate: true,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			secret := &corev1.Secret{}
+			if err := ReconcileKonnectivitySignerSecret(secret, config.OwnerRef{}, nil); err != nil {
+				t.Fatalf("failed to reconcile signer: %v", err)
+			}
+			original := secret.DeepCopy()
+
+			if err := ReconcileKonnectivitySignerSecret(secret, config.OwnerRef{}, tc.revokedBefore); err != nil {
+				t.Fatalf("failed to reconcile signer: %v", err)
+			}
+			regenerated := !bytes.Equal(secret.Data[certs.CASignerCertMapKey], original.Data[certs.CASignerCertMapKey])
+			if regenerated != tc.expectRegenerate {
+				t.Errorf("expected signer regeneration to be %t, was %t", tc.expectRegenerate, regenerated)
+			}
+			if bytes.Equal(secret.Data[certs.CASignerKeyMapKey], original.Data[certs.CASignerKeyMapKey]) == regenerated {
+				t.Errorf("signer key and certificate weren't regenerated together")
+			}
+		})
+	}
+}
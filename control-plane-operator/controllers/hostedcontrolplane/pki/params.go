@@ -1,8 +1,6 @@
 package pki
 
 import (
-	"fmt"
-
 	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
 
 	"github.com/openshift/hypershift/support/config"
@@ -23,6 +21,11 @@ type PKIParams struct {
 	// An externally accessible DNS name or IP for the API server. Currently obtained from the load balancer DNS name.
 	ExternalAPIAddress string `json:"externalAPIAddress"`
 
+	// ExternalAPIAdditionalAddresses
+	// Additional externally accessible DNS names or IPs for the API server. Currently obtained from the load balancer
+	// hostname when the API server is published under a custom domain.
+	ExternalAPIAdditionalAddresses []string `json:"externalAPIAdditionalAddresses,omitempty"`
+
 	// InternalAPIAddress
 	// An internally accessible DNS name or IP for the API server.
 	InternalAPIAddress string `json:"internalAPIAddress"`
@@ -72,13 +75,21 @@ func NewPKIParams(hcp *hyperv1.HostedControlPlane,
 		ClusterCIDR:                  clusterCIDRs,
 		Namespace:                    hcp.Namespace,
 		ExternalAPIAddress:           apiExternalAddress,
-		InternalAPIAddress:           fmt.Sprintf("api.%s.hypershift.local", hcp.Name),
+		InternalAPIAddress:           util.InternalAPIHostname(hcp.Name, hcp.Spec.DNS),
 		ExternalKconnectivityAddress: konnectivityExternalAddress,
 		ExternalOauthAddress:         oauthExternalAddress,
 		IngressSubdomain:             globalconfig.IngressDomain(hcp),
 		OwnerRef:                     config.OwnerRefFrom(hcp),
 	}
 
+	// When the API server is published under a custom domain the load balancer
+	// hostname needs to remain valid for the customer frontend to reach it.
+	if strategy := util.ServicePublishingStrategyByTypeForHCP(hcp, hyperv1.APIServer); strategy != nil && strategy.CustomDomain != nil {
+		if strategy.LoadBalancer != nil && strategy.LoadBalancer.Hostname != "" && strategy.LoadBalancer.Hostname != apiExternalAddress {
+			p.ExternalAPIAdditionalAddresses = append(p.ExternalAPIAdditionalAddresses, strategy.LoadBalancer.Hostname)
+		}
+	}
+
 	// If the first serviceCIDR is an IPv4 we need to set the config.DefaultAdvertiseIPv4Address
 	// as fake IP in the node to access the haproxy exposed as kube-api-server-proxy
 	// Even with that, we cannot set more than one AdvertiseAddress so both
@@ -0,0 +1,112 @@
+package hostedcontrolplane
+
+import (
+	"sync"
+	"time"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/componentgraph"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	ControlPlaneStartupDurationMetricName          = "hypershift_control_plane_startup_duration_seconds"
+	ControlPlaneComponentStartupDurationMetricName = "hypershift_control_plane_component_startup_duration_seconds"
+)
+
+var (
+	startupDurationBuckets = []float64{30, 60, 120, 180, 240, 300, 450, 600, 900, 1200, 1800, 3600}
+
+	controlPlaneStartupDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    ControlPlaneStartupDurationMetricName,
+		Help:    "Time from the creation of a hosted control plane until all its components were ready for the first time.",
+		Buckets: startupDurationBuckets,
+	})
+
+	componentStartupDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    ControlPlaneComponentStartupDurationMetricName,
+		Help:    "Time from the creation of a hosted control plane until one of its components was ready for the first time, by component.",
+		Buckets: startupDurationBuckets,
+	}, []string{"component"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(controlPlaneStartupDuration, componentStartupDuration)
+}
+
+// startupTracker measures the cold start of hosted control planes: how long their components take to be ready for
+// the first time after the HostedControlPlane was created. A nil tracker doesn't track anything.
+type startupTracker struct {
+	mu      sync.Mutex
+	startup map[types.UID]*controlPlaneStartup
+	now     func() time.Time
+}
+
+type controlPlaneStartup struct {
+	// untracked is set for a control plane which was already available when it was first observed, e.g. after the
+	// control plane operator was upgraded, whose startup can't be measured.
+	untracked bool
+	ready     sets.Set[string]
+	// duration is set once all the components were ready.
+	duration *time.Duration
+}
+
+func newStartupTracker() *startupTracker {
+	return &startupTracker{
+		startup: map[types.UID]*controlPlaneStartup{},
+		now:     time.Now,
+	}
+}
+
+// observe records the components which are ready for the first time in the results of a reconciliation of the
+// control plane components.
+func (t *startupTracker) observe(hcp *hyperv1.HostedControlPlane, results []componentgraph.Result) {
+	if t == nil || hcp.Status.ControlPlaneStartupDuration != nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	startup, exists := t.startup[hcp.UID]
+	if !exists {
+		startup = &controlPlaneStartup{
+			untracked: meta.IsStatusConditionTrue(hcp.Status.Conditions, string(hyperv1.HostedControlPlaneAvailable)),
+			ready:     sets.New[string](),
+		}
+		t.startup[hcp.UID] = startup
+	}
+	if startup.untracked || startup.duration != nil {
+		return
+	}
+
+	elapsed := t.now().Sub(hcp.CreationTimestamp.Time)
+	for _, result := range results {
+		if result.Status == componentgraph.StatusReady && !startup.ready.Has(result.Name) {
+			startup.ready.Insert(result.Name)
+			componentStartupDuration.WithLabelValues(result.Name).Observe(elapsed.Seconds())
+		}
+	}
+	if len(results) > 0 && startup.ready.Len() == len(results) {
+		duration := elapsed.Round(time.Second)
+		startup.duration = &duration
+		controlPlaneStartupDuration.Observe(elapsed.Seconds())
+	}
+}
+
+// duration returns how long the control plane took to start, or nil if it hasn't started yet or its startup isn't
+// tracked.
+func (t *startupTracker) duration(hcp *hyperv1.HostedControlPlane) *time.Duration {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if startup, exists := t.startup[hcp.UID]; exists {
+		return startup.duration
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package hostedcontrolplane
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/componentgraph"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestStartupTracker(t *testing.T) {
+	created := time.Now()
+	newHCP := func(available bool) *hyperv1.HostedControlPlane {
+		hcp := &hyperv1.HostedControlPlane{ObjectMeta: metav1.ObjectMeta{UID: "uid", CreationTimestamp: metav1.NewTime(created)}}
+		if available {
+			hcp.Status.Conditions = []metav1.Condition{{Type: string(hyperv1.HostedControlPlaneAvailable), Status: metav1.ConditionTrue}}
+		}
+		return hcp
+	}
+	results := func(statuses ...componentgraph.Status) []componentgraph.Result {
+		var results []componentgraph.Result
+		for i, status := range statuses {
+			results = append(results, componentgraph.Result{Name: string(rune('a' + i)), Status: status})
+		}
+		return results
+	}
+
+	t.Run("When all the components are ready for the first time it should return the startup duration", func(t *testing.T) {
+		g := NewWithT(t)
+		now := created
+		tracker := newStartupTracker()
+		tracker.now = func() time.Time { return now }
+		hcp := newHCP(false)
+
+		now = created.Add(time.Minute)
+		tracker.observe(hcp, results(componentgraph.StatusReady, componentgraph.StatusProgressing))
+		g.Expect(tracker.duration(hcp)).To(BeNil())
+
+		// Components which become not ready again don't restart the startup.
+		now = created.Add(3 * time.Minute)
+		tracker.observe(hcp, results(componentgraph.StatusFailed, componentgraph.StatusReady))
+		g.Expect(tracker.duration(hcp)).To(HaveValue(Equal(3 * time.Minute)))
+
+		now = created.Add(5 * time.Minute)
+		tracker.observe(hcp, results(componentgraph.StatusReady, componentgraph.StatusReady))
+		g.Expect(tracker.duration(hcp)).To(HaveValue(Equal(3 * time.Minute)))
+	})
+
+	t.Run("When the control plane was already available when first observed it should not track its startup", func(t *testing.T) {
+		g := NewWithT(t)
+		tracker := newStartupTracker()
+		hcp := newHCP(true)
+		tracker.observe(hcp, results(componentgraph.StatusReady))
+		g.Expect(tracker.duration(hcp)).To(BeNil())
+	})
+
+	t.Run("When the tracker is nil it should not track anything", func(t *testing.T) {
+		g := NewWithT(t)
+		var tracker *startupTracker
+		hcp := newHCP(false)
+		tracker.observe(hcp, results(componentgraph.StatusReady))
+		g.Expect(tracker.duration(hcp)).To(BeNil())
+	})
+}
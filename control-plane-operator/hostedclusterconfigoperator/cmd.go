@@ -297,6 +297,7 @@ func (o *HostedClusterConfigOperator) Run(ctx context.Context) error {
 		CPCluster:             cpCluster,
 		Logger:                ctrl.Log.WithName("hypershift-operator"),
 		ReleaseProvider:       releaseProvider,
+		ImageMirrorResolver:   util.NewImageMirrorResolver(imageRegistryOverrides),
 		KonnectivityAddress:   o.KonnectivityAddress,
 		KonnectivityPort:      o.KonnectivityPort,
 		OAuthAddress:          o.OAuthAddress,
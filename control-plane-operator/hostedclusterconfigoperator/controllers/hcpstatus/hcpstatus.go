@@ -42,6 +42,9 @@ func Setup(ctx context.Context, opts *operator.HostedClusterConfigOperatorConfig
 	if err := c.Watch(source.Kind(opts.Manager.GetCache(), &configv1.ClusterVersion{}), handler.EnqueueRequestsFromMapFunc(clusterVersionMapper)); err != nil {
 		return fmt.Errorf("failed to watch clusterversion: %w", err)
 	}
+	if err := c.Watch(source.Kind(opts.Manager.GetCache(), &configv1.ClusterOperator{}), handler.EnqueueRequestsFromMapFunc(clusterVersionMapper)); err != nil {
+		return fmt.Errorf("failed to watch clusteroperators: %w", err)
+	}
 
 	return nil
 }
@@ -160,5 +163,37 @@ func (h *hcpStatusReconciler) reconcile(ctx context.Context, hcp *hyperv1.Hosted
 	}
 	log.Info("Finished reconciling hosted cluster version conditions")
 
+	meta.SetStatusCondition(&hcp.Status.Conditions, h.ingressAvailableCondition(ctx, hcp))
+
 	return nil
 }
+
+// ingressAvailableCondition bubbles up the Available condition of the ingress ClusterOperator.
+func (h *hcpStatusReconciler) ingressAvailableCondition(ctx context.Context, hcp *hyperv1.HostedControlPlane) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               string(hyperv1.IngressAvailable),
+		Status:             metav1.ConditionUnknown,
+		Reason:             hyperv1.StatusUnknownReason,
+		ObservedGeneration: hcp.Generation,
+	}
+
+	var clusterOperator configv1.ClusterOperator
+	if err := h.hostedClusterClient.Get(ctx, crclient.ObjectKey{Name: "ingress"}, &clusterOperator); err != nil {
+		condition.Message = fmt.Sprintf("failed to get ingress clusteroperator: %v", err)
+		return condition
+	}
+
+	available := findClusterOperatorStatusCondition(clusterOperator.Status.Conditions, configv1.OperatorAvailable)
+	if available == nil {
+		condition.Message = "Condition not found in the ingress clusteroperator."
+		return condition
+	}
+
+	condition.Status = metav1.ConditionStatus(available.Status)
+	condition.Reason = available.Reason
+	if condition.Reason == "" {
+		condition.Reason = hyperv1.FromClusterOperatorReason
+	}
+	condition.Message = available.Message
+	return condition
+}
@@ -24,6 +24,10 @@ import (
 
 const (
 	managedByValue = "control-plane-operator.hypershift.openshift.io"
+
+	// machineNameLabel is set on the passthrough endpoint slices to the name of the Machine they point to, so they
+	// can be removed with the Machine when they can't be owned by its VirtualMachine.
+	machineNameLabel = "hypershift.openshift.io/machine"
 )
 
 func (r *reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
@@ -169,11 +173,19 @@ func (r *reconciler) reconcileKubevirtPassthroughServiceEndpointsByIPFamily(ctx
 				Name:      machine.Spec.InfrastructureRef.Name,
 			}
 			vm := &kubevirtv1.VirtualMachine{}
-			if err := r.kubevirtInfraClient.Get(ctx, vmKey, vm); err != nil {
+			if err := r.kubevirtInfraClient.Get(ctx, vmKey, vm); err == nil {
+				ownerRef := config.OwnerRefFrom(vm)
+				ownerRef.ApplyTo(endpointSlice)
+			} else if !apierrors.IsNotFound(err) || len(machine.Status.Addresses) == 0 {
 				return err
+			} else {
+				// A Machine with addresses whose VirtualMachine is not found has it in the infra namespace of
+				// its NodePool. The endpoint slice is then removed along with the Machine.
+				if endpointSlice.Labels == nil {
+					endpointSlice.Labels = map[string]string{}
+				}
+				endpointSlice.Labels[machineNameLabel] = machine.Name
 			}
-			ownerRef := config.OwnerRefFrom(vm)
-			ownerRef.ApplyTo(endpointSlice)
 		}
 
 		if endpointSlice.Labels == nil {
@@ -225,6 +237,18 @@ func (r *reconciler) removeOrphanKubevirtPassthroughEndpointSlices(ctx context.C
 	}
 
 	for _, endpointSlice := range endpointSliceList.Items {
+		if machineName := endpointSlice.Labels[machineNameLabel]; machineName != "" && len(endpointSlice.OwnerReferences) == 0 {
+			err := r.client.Get(ctx, client.ObjectKey{Namespace: hcp.Namespace, Name: machineName}, &capiv1.Machine{})
+			if err != nil && !apierrors.IsNotFound(err) {
+				return fmt.Errorf("failed looking for machine referenced by endpoint slice managed by control plane operator: %w", err)
+			}
+			if apierrors.IsNotFound(err) {
+				if err := r.kubevirtInfraClient.Delete(ctx, &endpointSlice); err != nil && !apierrors.IsNotFound(err) {
+					return fmt.Errorf("failed deleting kubevirt passthrough endpoint slice of deleted machine: %w", err)
+				}
+				continue
+			}
+		}
 		serviceName := endpointSlice.Labels[discoveryv1.LabelServiceName]
 		if serviceName == "" {
 			continue
@@ -365,6 +365,15 @@ func TestReconcileDefaultIngressEndpoints(t *testing.T) {
 		}
 	}
 
+	// VirtualMachines in the infra namespace of their NodePool can't own the endpoint slices
+	withVirtualMachineInOtherNamespace := func(machine capiv1.Machine) func(eps discoveryv1.EndpointSlice) discoveryv1.EndpointSlice {
+		return func(eps discoveryv1.EndpointSlice) discoveryv1.EndpointSlice {
+			eps.OwnerReferences = nil
+			eps.Labels[machineNameLabel] = machine.Name
+			return eps
+		}
+	}
+
 	withSelector := func(service corev1.Service) corev1.Service {
 		service.Spec.Selector = map[string]string{"key1": "value1"}
 		return service
@@ -450,6 +459,34 @@ func TestReconcileDefaultIngressEndpoints(t *testing.T) {
 			},
 			hcp: kubevirtHCP,
 		},
+		{
+			name:             "With Running machines whose virtual machines are in another infra namespace should create endpoint slices labeled with their machine",
+			machines:         pairOfDualStackRunningMachines,
+			services:         []corev1.Service{defaultIngressService},
+			expectedServices: []corev1.Service{defaultIngressService},
+			expectedIngressEndpointSlices: []discoveryv1.EndpointSlice{
+				defaultIngressEndpointSliceIPv4(pairOfDualStackRunningMachines[0], pairOfVirtualMachines[0], withVirtualMachineInOtherNamespace(pairOfDualStackRunningMachines[0])),
+				defaultIngressEndpointSliceIPv4(pairOfDualStackRunningMachines[1], pairOfVirtualMachines[1], withVirtualMachineInOtherNamespace(pairOfDualStackRunningMachines[1])),
+				defaultIngressEndpointSliceIPv6(pairOfDualStackRunningMachines[0], pairOfVirtualMachines[0], withVirtualMachineInOtherNamespace(pairOfDualStackRunningMachines[0])),
+				defaultIngressEndpointSliceIPv6(pairOfDualStackRunningMachines[1], pairOfVirtualMachines[1], withVirtualMachineInOtherNamespace(pairOfDualStackRunningMachines[1])),
+			},
+			hcp: kubevirtHCP,
+		},
+		{
+			name:             "Should remove endpoint slices of deleted machines whose virtual machines are in another infra namespace",
+			machines:         pairOfDualStackRunningMachines[:1],
+			services:         []corev1.Service{defaultIngressService},
+			expectedServices: []corev1.Service{defaultIngressService},
+			endpointSlices: []discoveryv1.EndpointSlice{
+				defaultIngressEndpointSliceIPv4(pairOfDualStackRunningMachines[1], pairOfVirtualMachines[1], withVirtualMachineInOtherNamespace(pairOfDualStackRunningMachines[1])),
+				defaultIngressEndpointSliceIPv6(pairOfDualStackRunningMachines[1], pairOfVirtualMachines[1], withVirtualMachineInOtherNamespace(pairOfDualStackRunningMachines[1])),
+			},
+			expectedIngressEndpointSlices: []discoveryv1.EndpointSlice{
+				defaultIngressEndpointSliceIPv4(pairOfDualStackRunningMachines[0], pairOfVirtualMachines[0], withVirtualMachineInOtherNamespace(pairOfDualStackRunningMachines[0])),
+				defaultIngressEndpointSliceIPv6(pairOfDualStackRunningMachines[0], pairOfVirtualMachines[0], withVirtualMachineInOtherNamespace(pairOfDualStackRunningMachines[0])),
+			},
+			hcp: kubevirtHCP,
+		},
 	}
 
 	ctrl.SetLogger(zap.New(zap.UseDevMode(true)))
@@ -5,6 +5,7 @@ import (
 	v1 "github.com/openshift/api/operator/v1"
 	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
 	"github.com/openshift/hypershift/support/globalconfig"
+	"github.com/openshift/hypershift/support/util"
 )
 
 type IngressParams struct {
@@ -15,6 +16,10 @@ type IngressParams struct {
 	IBMCloudUPI       bool
 	AWSNLB            bool
 	LoadBalancerScope v1.LoadBalancerScope
+	Placement         *hyperv1.DataPlaneInfrastructurePlacement
+	// DefaultIngressController is the configuration of the HostedCluster for the default IngressController, whose
+	// fields are reconciled on the existing IngressController when set.
+	DefaultIngressController *hyperv1.DefaultIngressControllerSpec
 }
 
 func NewIngressParams(hcp *hyperv1.HostedControlPlane) *IngressParams {
@@ -29,7 +34,7 @@ func NewIngressParams(hcp *hyperv1.HostedControlPlane) *IngressParams {
 	if hcp.Annotations[hyperv1.PrivateIngressControllerAnnotation] == "true" {
 		isPrivate = true
 	}
-	if hcp.Annotations[hyperv1.IngressControllerLoadBalancerScope] == string(v1.InternalLoadBalancer) {
+	if hcp.Annotations[hyperv1.IngressControllerLoadBalancerScope] == string(v1.InternalLoadBalancer) || util.IsInternalDNSHCP(hcp) {
 		loadBalancerScope = v1.InternalLoadBalancer
 	}
 	if hcp.Spec.InfrastructureAvailabilityPolicy == hyperv1.HighlyAvailable {
@@ -42,6 +47,29 @@ func NewIngressParams(hcp *hyperv1.HostedControlPlane) *IngressParams {
 		}
 	}
 
+	placement := hcp.Spec.DataPlaneInfrastructurePlacement
+	if spec := hcp.Spec.DefaultIngressController; spec != nil {
+		if spec.Replicas != nil {
+			replicas = *spec.Replicas
+		}
+		switch spec.LoadBalancerScope {
+		case hyperv1.ExternalIngressLoadBalancer:
+			loadBalancerScope = v1.ExternalLoadBalancer
+		case hyperv1.InternalIngressLoadBalancer:
+			loadBalancerScope = v1.InternalLoadBalancer
+		}
+		if spec.NodePool != "" {
+			placement = placement.DeepCopy()
+			if placement == nil {
+				placement = &hyperv1.DataPlaneInfrastructurePlacement{}
+			}
+			if placement.NodeSelector == nil {
+				placement.NodeSelector = map[string]string{}
+			}
+			placement.NodeSelector[hyperv1.NodePoolLabel] = spec.NodePool
+		}
+	}
+
 	return &IngressParams{
 		IngressSubdomain:  globalconfig.IngressDomain(hcp),
 		Replicas:          replicas,
@@ -50,5 +78,8 @@ func NewIngressParams(hcp *hyperv1.HostedControlPlane) *IngressParams {
 		IBMCloudUPI:       ibmCloudUPI,
 		AWSNLB:            nlb,
 		LoadBalancerScope: loadBalancerScope,
+		Placement:         placement,
+
+		DefaultIngressController: hcp.Spec.DefaultIngressController,
 	}
 }
@@ -6,7 +6,9 @@ import (
 	configv1 "github.com/openshift/api/config/v1"
 	v1 "github.com/openshift/api/operator/v1"
 	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 
 	. "github.com/onsi/gomega"
 )
@@ -53,6 +55,26 @@ func TestNewIngressParams(t *testing.T) {
 				LoadBalancerScope: v1.ExternalLoadBalancer,
 			},
 		},
+		{
+			name: "InternalDNSPublishing",
+			args: args{
+				hcp: &hyperv1.HostedControlPlane{
+					Spec: hyperv1.HostedControlPlaneSpec{
+						DNS: hyperv1.DNSSpec{
+							Publishing: hyperv1.InternalDNSPublishing,
+						},
+					},
+				},
+			},
+			want: &IngressParams{
+				IngressSubdomain:  "apps.",
+				Replicas:          1,
+				IsPrivate:         false,
+				IBMCloudUPI:       false,
+				AWSNLB:            false,
+				LoadBalancerScope: v1.InternalLoadBalancer,
+			},
+		},
 		{
 			name: "HighlyAvailable",
 			args: args{
@@ -157,6 +179,44 @@ func TestNewIngressParams(t *testing.T) {
 				LoadBalancerScope: v1.InternalLoadBalancer,
 			},
 		},
+		{
+			name: "DefaultIngressController",
+			args: args{
+				hcp: &hyperv1.HostedControlPlane{
+					Spec: hyperv1.HostedControlPlaneSpec{
+						InfrastructureAvailabilityPolicy: hyperv1.HighlyAvailable,
+						DNS: hyperv1.DNSSpec{
+							Publishing: hyperv1.InternalDNSPublishing,
+						},
+						DataPlaneInfrastructurePlacement: &hyperv1.DataPlaneInfrastructurePlacement{
+							Tolerations: []corev1.Toleration{{Key: "infra", Operator: corev1.TolerationOpExists}},
+						},
+						DefaultIngressController: &hyperv1.DefaultIngressControllerSpec{
+							Replicas:          ptr.To[int32](3),
+							NodePool:          "infra",
+							LoadBalancerScope: hyperv1.ExternalIngressLoadBalancer,
+						},
+					},
+				},
+			},
+			want: &IngressParams{
+				IngressSubdomain:  "apps.",
+				Replicas:          3,
+				IsPrivate:         false,
+				IBMCloudUPI:       false,
+				AWSNLB:            false,
+				LoadBalancerScope: v1.ExternalLoadBalancer,
+				Placement: &hyperv1.DataPlaneInfrastructurePlacement{
+					NodeSelector: map[string]string{hyperv1.NodePoolLabel: "infra"},
+					Tolerations:  []corev1.Toleration{{Key: "infra", Operator: corev1.TolerationOpExists}},
+				},
+				DefaultIngressController: &hyperv1.DefaultIngressControllerSpec{
+					Replicas:          ptr.To[int32](3),
+					NodePool:          "infra",
+					LoadBalancerScope: hyperv1.ExternalIngressLoadBalancer,
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 
 	operatorv1 "github.com/openshift/api/operator/v1"
@@ -12,9 +13,22 @@ import (
 	"github.com/openshift/hypershift/control-plane-operator/hostedclusterconfigoperator/controllers/resources/manifests"
 )
 
-func ReconcileDefaultIngressController(ingressController *operatorv1.IngressController, ingressSubdomain string, platformType hyperv1.PlatformType, replicas int32, isIBMCloudUPI bool, isPrivate bool, useNLB bool, loadBalancerScope operatorv1.LoadBalancerScope) error {
-	// If ingress controller already exists, skip reconciliation to allow day-2 configuration
+func ReconcileDefaultIngressController(ingressController *operatorv1.IngressController, ingressSubdomain string, platformType hyperv1.PlatformType, replicas int32, isIBMCloudUPI bool, isPrivate bool, useNLB bool, loadBalancerScope operatorv1.LoadBalancerScope, placement *hyperv1.DataPlaneInfrastructurePlacement, spec *hyperv1.DefaultIngressControllerSpec) error {
+	// If ingress controller already exists, skip reconciliation to allow day-2 configuration,
+	// except for the node placement when the data plane infrastructure placement is set, and
+	// the fields set in the default ingress controller configuration of the HostedCluster.
 	if ingressController.ResourceVersion != "" {
+		if placement != nil {
+			reconcileDefaultIngressControllerNodePlacement(ingressController, platformType, placement)
+		}
+		if spec != nil {
+			if spec.Replicas != nil {
+				ingressController.Spec.Replicas = &(replicas)
+			}
+			if spec.LoadBalancerScope != "" {
+				reconcileDefaultIngressControllerLoadBalancerScope(ingressController, loadBalancerScope)
+			}
+		}
 		return nil
 	}
 
@@ -72,14 +86,6 @@ func ReconcileDefaultIngressController(ingressController *operatorv1.IngressCont
 				},
 			}
 		}
-		ingressController.Spec.NodePlacement = &operatorv1.NodePlacement{
-			Tolerations: []corev1.Toleration{
-				{
-					Key:   "dedicated",
-					Value: "edge",
-				},
-			},
-		}
 	default:
 		ingressController.Spec.EndpointPublishingStrategy = &operatorv1.EndpointPublishingStrategy{
 			Type: operatorv1.LoadBalancerServiceStrategyType,
@@ -94,9 +100,48 @@ func ReconcileDefaultIngressController(ingressController *operatorv1.IngressCont
 			Private: &operatorv1.PrivateStrategy{},
 		}
 	}
+	if spec != nil && spec.LoadBalancerScope != "" {
+		reconcileDefaultIngressControllerLoadBalancerScope(ingressController, loadBalancerScope)
+	}
+	reconcileDefaultIngressControllerNodePlacement(ingressController, platformType, placement)
 	return nil
 }
 
+// reconcileDefaultIngressControllerLoadBalancerScope sets the scope of the load balancer of an ingress controller
+// published with a load balancer service.
+func reconcileDefaultIngressControllerLoadBalancerScope(ingressController *operatorv1.IngressController, loadBalancerScope operatorv1.LoadBalancerScope) {
+	strategy := ingressController.Spec.EndpointPublishingStrategy
+	if strategy == nil || strategy.Type != operatorv1.LoadBalancerServiceStrategyType {
+		return
+	}
+	if strategy.LoadBalancer == nil {
+		strategy.LoadBalancer = &operatorv1.LoadBalancerStrategy{}
+	}
+	strategy.LoadBalancer.Scope = loadBalancerScope
+}
+
+func reconcileDefaultIngressControllerNodePlacement(ingressController *operatorv1.IngressController, platformType hyperv1.PlatformType, placement *hyperv1.DataPlaneInfrastructurePlacement) {
+	nodePlacement := &operatorv1.NodePlacement{}
+	if platformType == hyperv1.IBMCloudPlatform {
+		nodePlacement.Tolerations = []corev1.Toleration{
+			{
+				Key:   "dedicated",
+				Value: "edge",
+			},
+		}
+	}
+	if placement != nil {
+		if len(placement.NodeSelector) > 0 {
+			nodePlacement.NodeSelector = &metav1.LabelSelector{MatchLabels: placement.NodeSelector}
+		}
+		nodePlacement.Tolerations = append(nodePlacement.Tolerations, placement.Tolerations...)
+	}
+	if nodePlacement.NodeSelector == nil && len(nodePlacement.Tolerations) == 0 {
+		nodePlacement = nil
+	}
+	ingressController.Spec.NodePlacement = nodePlacement
+}
+
 func ReconcileDefaultIngressControllerCertSecret(certSecret *corev1.Secret, sourceSecret *corev1.Secret) error {
 	if _, hasCertKey := sourceSecret.Data[corev1.TLSCertKey]; !hasCertKey {
 		return fmt.Errorf("source secret %s/%s does not have a cert key", sourceSecret.Namespace, sourceSecret.Name)
@@ -24,6 +24,8 @@ func TestReconcileDefaultIngressController(t *testing.T) {
 		inputIsPrivate            bool
 		inputIsNLB                bool
 		inputLoadBalancerScope    operatorv1.LoadBalancerScope
+		inputPlacement            *hyperv1.DataPlaneInfrastructurePlacement
+		inputSpec                 *hyperv1.DefaultIngressControllerSpec
 		expectedIngressController *operatorv1.IngressController
 	}{
 		{
@@ -284,11 +286,136 @@ func TestReconcileDefaultIngressController(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:                   "Data plane infrastructure placement is applied to the node placement",
+			inputPlatformType:      hyperv1.AWSPlatform,
+			inputIngressController: manifests.IngressDefaultIngressController(),
+			inputIngressDomain:     fakeIngressDomain,
+			inputReplicas:          fakeInputReplicas,
+			inputPlacement: &hyperv1.DataPlaneInfrastructurePlacement{
+				NodeSelector: map[string]string{"node-role.kubernetes.io/infra": ""},
+				Tolerations:  []corev1.Toleration{{Key: "infra", Operator: corev1.TolerationOpExists}},
+			},
+			expectedIngressController: &operatorv1.IngressController{
+				ObjectMeta: manifests.IngressDefaultIngressController().ObjectMeta,
+				Spec: operatorv1.IngressControllerSpec{
+					Domain:   fakeIngressDomain,
+					Replicas: &fakeInputReplicas,
+					EndpointPublishingStrategy: &operatorv1.EndpointPublishingStrategy{
+						Type: operatorv1.LoadBalancerServiceStrategyType,
+					},
+					DefaultCertificate: &corev1.LocalObjectReference{
+						Name: manifests.IngressDefaultIngressControllerCert().Name,
+					},
+					NodePlacement: &operatorv1.NodePlacement{
+						NodeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"node-role.kubernetes.io/infra": ""}},
+						Tolerations:  []corev1.Toleration{{Key: "infra", Operator: corev1.TolerationOpExists}},
+					},
+				},
+			},
+		},
+		{
+			name:              "Existing ingress controller only gets the data plane infrastructure placement reconciled",
+			inputPlatformType: hyperv1.IBMCloudPlatform,
+			inputIngressController: &operatorv1.IngressController{
+				ObjectMeta: func() metav1.ObjectMeta {
+					m := manifests.IngressDefaultIngressController().ObjectMeta
+					m.ResourceVersion = "1"
+					return m
+				}(),
+			},
+			inputIngressDomain: fakeIngressDomain,
+			inputReplicas:      fakeInputReplicas,
+			inputPlacement: &hyperv1.DataPlaneInfrastructurePlacement{
+				Tolerations: []corev1.Toleration{{Key: "infra", Operator: corev1.TolerationOpExists}},
+			},
+			expectedIngressController: &operatorv1.IngressController{
+				ObjectMeta: func() metav1.ObjectMeta {
+					m := manifests.IngressDefaultIngressController().ObjectMeta
+					m.ResourceVersion = "1"
+					return m
+				}(),
+				Spec: operatorv1.IngressControllerSpec{
+					NodePlacement: &operatorv1.NodePlacement{
+						Tolerations: []corev1.Toleration{
+							{Key: "dedicated", Value: "edge"},
+							{Key: "infra", Operator: corev1.TolerationOpExists},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:                   "Load balancer scope of the HostedCluster is applied to the load balancer",
+			inputPlatformType:      hyperv1.AzurePlatform,
+			inputIngressController: manifests.IngressDefaultIngressController(),
+			inputIngressDomain:     fakeIngressDomain,
+			inputReplicas:          fakeInputReplicas,
+			inputLoadBalancerScope: operatorv1.InternalLoadBalancer,
+			inputSpec:              &hyperv1.DefaultIngressControllerSpec{LoadBalancerScope: hyperv1.InternalIngressLoadBalancer},
+			expectedIngressController: &operatorv1.IngressController{
+				ObjectMeta: manifests.IngressDefaultIngressController().ObjectMeta,
+				Spec: operatorv1.IngressControllerSpec{
+					Domain:   fakeIngressDomain,
+					Replicas: &fakeInputReplicas,
+					EndpointPublishingStrategy: &operatorv1.EndpointPublishingStrategy{
+						Type: operatorv1.LoadBalancerServiceStrategyType,
+						LoadBalancer: &operatorv1.LoadBalancerStrategy{
+							Scope: operatorv1.InternalLoadBalancer,
+						},
+					},
+					DefaultCertificate: &corev1.LocalObjectReference{
+						Name: manifests.IngressDefaultIngressControllerCert().Name,
+					},
+				},
+			},
+		},
+		{
+			name:              "Existing ingress controller gets the replicas and load balancer scope of the HostedCluster reconciled",
+			inputPlatformType: hyperv1.AWSPlatform,
+			inputIngressController: &operatorv1.IngressController{
+				ObjectMeta: func() metav1.ObjectMeta {
+					m := manifests.IngressDefaultIngressController().ObjectMeta
+					m.ResourceVersion = "1"
+					return m
+				}(),
+				Spec: operatorv1.IngressControllerSpec{
+					Domain: "custom.example.com",
+					EndpointPublishingStrategy: &operatorv1.EndpointPublishingStrategy{
+						Type: operatorv1.LoadBalancerServiceStrategyType,
+					},
+				},
+			},
+			inputIngressDomain:     fakeIngressDomain,
+			inputReplicas:          fakeInputReplicas,
+			inputLoadBalancerScope: operatorv1.InternalLoadBalancer,
+			inputSpec: &hyperv1.DefaultIngressControllerSpec{
+				Replicas:          &fakeInputReplicas,
+				LoadBalancerScope: hyperv1.InternalIngressLoadBalancer,
+			},
+			expectedIngressController: &operatorv1.IngressController{
+				ObjectMeta: func() metav1.ObjectMeta {
+					m := manifests.IngressDefaultIngressController().ObjectMeta
+					m.ResourceVersion = "1"
+					return m
+				}(),
+				Spec: operatorv1.IngressControllerSpec{
+					Domain:   "custom.example.com",
+					Replicas: &fakeInputReplicas,
+					EndpointPublishingStrategy: &operatorv1.EndpointPublishingStrategy{
+						Type: operatorv1.LoadBalancerServiceStrategyType,
+						LoadBalancer: &operatorv1.LoadBalancerStrategy{
+							Scope: operatorv1.InternalLoadBalancer,
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, tc := range testsCases {
 		t.Run(tc.name, func(t *testing.T) {
 			g := NewGomegaWithT(t)
-			err := ReconcileDefaultIngressController(tc.inputIngressController, tc.inputIngressDomain, tc.inputPlatformType, tc.inputReplicas, tc.inputIsIBMCloudUPI, tc.inputIsPrivate, tc.inputIsNLB, tc.inputLoadBalancerScope)
+			err := ReconcileDefaultIngressController(tc.inputIngressController, tc.inputIngressDomain, tc.inputPlatformType, tc.inputReplicas, tc.inputIsIBMCloudUPI, tc.inputIsPrivate, tc.inputIsNLB, tc.inputLoadBalancerScope, tc.inputPlacement, tc.inputSpec)
 			g.Expect(err).To(BeNil())
 			g.Expect(tc.inputIngressController).To(BeEquivalentTo(tc.expectedIngressController))
 		})
@@ -17,6 +17,7 @@ type KonnectivityParams struct {
 	Image           string
 	ExternalAddress string
 	ExternalPort    int32
+	SyncForever     bool
 	config.DeploymentConfig
 }
 
@@ -25,6 +26,7 @@ func NewKonnectivityParams(hcp *hyperv1.HostedControlPlane, images map[string]st
 		Image:           images["konnectivity-agent"],
 		ExternalAddress: externalAddress,
 		ExternalPort:    externalPort,
+		SyncForever:     hcp.Annotations[hyperv1.KonnectivityAgentConnectionRebalancingAnnotation] == "true",
 	}
 
 	p.DeploymentConfig.Resources = config.ResourcesSpec{
@@ -40,6 +42,9 @@ func NewKonnectivityParams(hcp *hyperv1.HostedControlPlane, images map[string]st
 		// Always run, even if nodes are not ready e.G. because there are networking issues as this helps a lot in debugging
 		Tolerations: []corev1.Toleration{{Operator: corev1.TolerationOpExists}},
 	}
+	if hcp.Spec.DataPlaneInfrastructurePlacement != nil {
+		p.DeploymentConfig.Scheduling.NodeSelector = hcp.Spec.DataPlaneInfrastructurePlacement.NodeSelector
+	}
 	p.DeploymentConfig.LivenessProbes = config.LivenessProbes{
 		konnectivityAgentContainer().Name: {
 			ProbeHandler: corev1.ProbeHandler{
@@ -14,6 +14,7 @@ import (
 
 	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
 	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/manifests"
+	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/pki"
 	"github.com/openshift/hypershift/support/config"
 	"github.com/openshift/hypershift/support/util"
 )
@@ -33,7 +34,10 @@ var (
 	maxSurge       = intstr.FromInt(0)
 )
 
-func ReconcileAgentDaemonSet(daemonset *appsv1.DaemonSet, deploymentConfig config.DeploymentConfig, image string, host string, port int32, platform hyperv1.PlatformSpec, proxy configv1.ProxyStatus) {
+// ReconcileAgentDaemonSet reconciles the konnectivity agents of the data plane. The agents only load their CA and
+// certificate on startup, so caHash, the hash of the konnectivity CA, rolls them out when the konnectivity signer is
+// regenerated to revoke the certificates it issued.
+func ReconcileAgentDaemonSet(daemonset *appsv1.DaemonSet, deploymentConfig config.DeploymentConfig, image string, host string, port int32, syncForever bool, platform hyperv1.PlatformSpec, proxy configv1.ProxyStatus, caHash string) {
 	var labels map[string]string
 	if daemonset.Spec.Selector != nil && daemonset.Spec.Selector.MatchLabels != nil {
 		labels = daemonset.Spec.Selector.MatchLabels
@@ -43,13 +47,21 @@ func ReconcileAgentDaemonSet(daemonset *appsv1.DaemonSet, deploymentConfig confi
 		}
 	}
 
+	var annotations map[string]string
+	if caHash != "" {
+		annotations = map[string]string{
+			pki.KonnectivityCAHashAnnotation: caHash,
+		}
+	}
+
 	daemonset.Spec = appsv1.DaemonSetSpec{
 		Selector: &metav1.LabelSelector{
 			MatchLabels: labels,
 		},
 		Template: corev1.PodTemplateSpec{
 			ObjectMeta: metav1.ObjectMeta{
-				Labels: labels,
+				Labels:      labels,
+				Annotations: annotations,
 			},
 			Spec: corev1.PodSpec{
 				// Default is not the default, it means that the kubelets will re-use the hosts DNS resolver
@@ -60,7 +72,7 @@ func ReconcileAgentDaemonSet(daemonset *appsv1.DaemonSet, deploymentConfig confi
 					RunAsUser: pointer.Int64(1000),
 				},
 				Containers: []corev1.Container{
-					util.BuildContainer(konnectivityAgentContainer(), buildKonnectivityWorkerAgentContainer(image, host, port, proxy)),
+					util.BuildContainer(konnectivityAgentContainer(), buildKonnectivityWorkerAgentContainer(image, host, port, syncForever, proxy)),
 				},
 				Volumes: []corev1.Volume{
 					util.BuildVolume(konnectivityVolumeAgentCerts(), buildKonnectivityVolumeWorkerAgentCerts),
@@ -104,7 +116,7 @@ func konnectivityVolumeCACert() *corev1.Volume {
 	}
 }
 
-func buildKonnectivityWorkerAgentContainer(image, host string, port int32, proxy configv1.ProxyStatus) func(c *corev1.Container) {
+func buildKonnectivityWorkerAgentContainer(image, host string, port int32, syncForever bool, proxy configv1.ProxyStatus) func(c *corev1.Container) {
 	cpath := func(volume, file string) string {
 		return path.Join(volumeMounts.Path(konnectivityAgentContainer().Name, volume), file)
 	}
@@ -140,6 +152,9 @@ func buildKonnectivityWorkerAgentContainer(image, host string, port int32, proxy
 			"--v",
 			"3",
 		}
+		if syncForever {
+			c.Args = append(c.Args, "--sync-forever")
+		}
 		c.Env = []corev1.EnvVar{
 			{
 				Name:  "HTTP_PROXY",
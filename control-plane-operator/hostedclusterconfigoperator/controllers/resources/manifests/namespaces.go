@@ -96,3 +96,11 @@ func NamespaceKubeSystem() *corev1.Namespace {
 		ObjectMeta: metav1.ObjectMeta{Name: "kube-system"},
 	}
 }
+
+func NamespaceClusterCSIDrivers() *corev1.Namespace {
+	return &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "openshift-cluster-csi-drivers",
+		},
+	}
+}
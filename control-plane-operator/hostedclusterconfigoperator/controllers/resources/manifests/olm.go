@@ -44,6 +44,15 @@ func RedHatOperatorsCatalogSource() *operatorsv1alpha1.CatalogSource {
 	}
 }
 
+func CustomCatalogSource(name string) *operatorsv1alpha1.CatalogSource {
+	return &operatorsv1alpha1.CatalogSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "openshift-marketplace",
+		},
+	}
+}
+
 func OLMPackageServerAPIService() *apiregistrationv1.APIService {
 	return &apiregistrationv1.APIService{
 		ObjectMeta: metav1.ObjectMeta{
@@ -14,6 +14,17 @@ func ProxyTrustedCAConfigMap(name string) *corev1.ConfigMap {
 	}
 }
 
+// ImageAdditionalTrustedCAConfigMap is the ConfigMap referenced by the image config additionalTrustedCA, holding
+// the CAs trusted when accessing image registries.
+func ImageAdditionalTrustedCAConfigMap(name string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "openshift-config",
+		},
+	}
+}
+
 func UserCABundle() *corev1.ConfigMap {
 	return &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
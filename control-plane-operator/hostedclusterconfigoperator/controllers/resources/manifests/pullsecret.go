@@ -1,6 +1,9 @@
 package manifests
 
 import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -20,3 +23,21 @@ func PullSecretTargetNamespaces() []string {
 		"openshift",
 	}
 }
+
+func NodePoolPullSecret(nodePoolName string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("pull-secret-%s", nodePoolName),
+			Namespace: "kube-system",
+		},
+	}
+}
+
+func PullSecretSyncerDaemonSet(nodePoolName string) *appsv1.DaemonSet {
+	return &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("pull-secret-syncer-%s", nodePoolName),
+			Namespace: "kube-system",
+		},
+	}
+}
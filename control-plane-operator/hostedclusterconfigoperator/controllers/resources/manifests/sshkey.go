@@ -0,0 +1,27 @@
+package manifests
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func NodePoolSSHKeySecret(nodePoolName string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("ssh-key-%s", nodePoolName),
+			Namespace: "kube-system",
+		},
+	}
+}
+
+func SSHKeySyncerDaemonSet(nodePoolName string) *appsv1.DaemonSet {
+	return &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("ssh-key-syncer-%s", nodePoolName),
+			Namespace: "kube-system",
+		},
+	}
+}
@@ -1,6 +1,9 @@
 package namespaces
 
 import (
+	"encoding/json"
+	"fmt"
+
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -28,6 +31,28 @@ func ReconcileOpenShiftIngressNamespace(ns *corev1.Namespace) error {
 	return nil
 }
 
+// defaultTolerationsAnnotation holds the tolerations the PodTolerationRestriction admission plugin adds to the pods of
+// a namespace.
+const defaultTolerationsAnnotation = "scheduler.alpha.kubernetes.io/defaultTolerations"
+
+// ReconcileClusterCSIDriversNamespace adds the given tolerations to the CSI driver pods, so the CSI driver node pods
+// are scheduled on tainted nodes too.
+func ReconcileClusterCSIDriversNamespace(ns *corev1.Namespace, tolerations []corev1.Toleration) error {
+	if len(tolerations) == 0 {
+		delete(ns.Annotations, defaultTolerationsAnnotation)
+		return nil
+	}
+	serializedTolerations, err := json.Marshal(tolerations)
+	if err != nil {
+		return fmt.Errorf("failed to serialize tolerations: %w", err)
+	}
+	if ns.Annotations == nil {
+		ns.Annotations = map[string]string{}
+	}
+	ns.Annotations[defaultTolerationsAnnotation] = string(serializedTolerations)
+	return nil
+}
+
 func ensureLabels(obj metav1.Object, labels map[string]string) {
 	objLabels := obj.GetLabels()
 	if objLabels == nil {
@@ -3,12 +3,15 @@ package network
 import (
 	"context"
 	"fmt"
+	"strings"
 
+	configv1 "github.com/openshift/api/config/v1"
 	operatorv1 "github.com/openshift/api/operator/v1"
 	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -123,3 +126,52 @@ func DetectSuboptimalMTU(ctx context.Context, mgmtClient client.Client,
 	}
 	return nil
 }
+
+// ReportClusterNetworkApplied sets the ClusterNetworkApplied condition of the HCP from the cluster networks the
+// network operator of the guest cluster reports in the status of the network config.
+func ReportClusterNetworkApplied(ctx context.Context, mgmtClient client.Client, networkConfig *configv1.Network, hcp *hyperv1.HostedControlPlane) error {
+	condition := clusterNetworkAppliedCondition(networkConfig, hcp)
+	if condition == nil {
+		return nil
+	}
+	originalHCP := hcp.DeepCopy()
+	meta.SetStatusCondition(&hcp.Status.Conditions, *condition)
+	if !equality.Semantic.DeepEqual(hcp.Status, originalHCP.Status) {
+		if err := mgmtClient.Status().Patch(ctx, hcp, client.MergeFromWithOptions(originalHCP, client.MergeFromWithOptimisticLock{})); err != nil {
+			return fmt.Errorf("failed to set cluster network applied condition on HCP: %w", err)
+		}
+	}
+	return nil
+}
+
+// clusterNetworkAppliedCondition returns the ClusterNetworkApplied condition of the HCP, or nil if the network
+// operator of the guest cluster hasn't reported the cluster networks in use yet.
+func clusterNetworkAppliedCondition(networkConfig *configv1.Network, hcp *hyperv1.HostedControlPlane) *metav1.Condition {
+	if len(networkConfig.Status.ClusterNetwork) == 0 {
+		return nil
+	}
+	applied := sets.New[string]()
+	for _, entry := range networkConfig.Status.ClusterNetwork {
+		applied.Insert(entry.CIDR)
+	}
+	var pending []string
+	for _, entry := range hcp.Spec.Networking.ClusterNetwork {
+		if !applied.Has(entry.CIDR.String()) {
+			pending = append(pending, entry.CIDR.String())
+		}
+	}
+
+	condition := &metav1.Condition{
+		Type:               string(hyperv1.ClusterNetworkApplied),
+		Status:             metav1.ConditionTrue,
+		Reason:             hyperv1.AsExpectedReason,
+		Message:            hyperv1.AllIsWellMessage,
+		ObservedGeneration: hcp.Generation,
+	}
+	if len(pending) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = hyperv1.ClusterNetworkExpansionInProgressReason
+		condition.Message = fmt.Sprintf("Waiting for the guest cluster network to use the cluster network CIDRs %s", strings.Join(pending, ", "))
+	}
+	return condition
+}
@@ -4,8 +4,11 @@ import (
 	"testing"
 
 	. "github.com/onsi/gomega"
+	configv1 "github.com/openshift/api/config/v1"
 	operatorv1 "github.com/openshift/api/operator/v1"
 	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/api/util/ipnet"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestReconcileDefaultIngressController(t *testing.T) {
@@ -251,3 +254,56 @@ func TestReconcileDefaultIngressController(t *testing.T) {
 		})
 	}
 }
+
+func TestClusterNetworkAppliedCondition(t *testing.T) {
+	hcp := &hyperv1.HostedControlPlane{}
+	hcp.Spec.Networking.ClusterNetwork = []hyperv1.ClusterNetworkEntry{
+		{CIDR: *ipnet.MustParseCIDR("10.132.0.0/14")},
+		{CIDR: *ipnet.MustParseCIDR("10.200.0.0/14")},
+	}
+
+	testCases := []struct {
+		name            string
+		applied         []string
+		expectedStatus  metav1.ConditionStatus
+		expectedReason  string
+		expectCondition bool
+	}{
+		{
+			name:            "When the guest network operator hasn't reported the cluster network it should not report a condition",
+			expectCondition: false,
+		},
+		{
+			name:            "When the guest cluster uses all the cluster network CIDRs it should be true",
+			applied:         []string{"10.132.0.0/14", "10.200.0.0/14"},
+			expectCondition: true,
+			expectedStatus:  metav1.ConditionTrue,
+			expectedReason:  hyperv1.AsExpectedReason,
+		},
+		{
+			name:            "When the guest cluster doesn't use an appended cluster network CIDR yet it should be false",
+			applied:         []string{"10.132.0.0/14"},
+			expectCondition: true,
+			expectedStatus:  metav1.ConditionFalse,
+			expectedReason:  hyperv1.ClusterNetworkExpansionInProgressReason,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			networkConfig := &configv1.Network{}
+			for _, cidr := range tc.applied {
+				networkConfig.Status.ClusterNetwork = append(networkConfig.Status.ClusterNetwork, configv1.ClusterNetworkEntry{CIDR: cidr, HostPrefix: 23})
+			}
+
+			condition := clusterNetworkAppliedCondition(networkConfig, hcp)
+			if !tc.expectCondition {
+				g.Expect(condition).To(BeNil())
+				return
+			}
+			g.Expect(condition).ToNot(BeNil())
+			g.Expect(condition.Status).To(Equal(tc.expectedStatus))
+			g.Expect(condition.Reason).To(Equal(tc.expectedReason))
+		})
+	}
+}
@@ -1,29 +1,46 @@
 package olm
 
 import (
+	"fmt"
+	"time"
+
 	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/olm"
 	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"time"
 )
 
 func ReconcileCertifiedOperatorsCatalogSource(cs *operatorsv1alpha1.CatalogSource, p *OperatorLifecycleManagerParams) {
-	reconcileCatalogSource(cs, "certified-operators:50051", p.CertifiedOperatorsImage, "Certified Operators", -200, p.OLMCatalogPlacement)
+	reconcileCatalogSource(cs, "certified-operators:50051", p.CertifiedOperatorsImage, "Certified Operators", -200, p)
 }
 
 func ReconcileCommunityOperatorsCatalogSource(cs *operatorsv1alpha1.CatalogSource, p *OperatorLifecycleManagerParams) {
-	reconcileCatalogSource(cs, "community-operators:50051", p.CommunityOperatorsImage, "Community Operators", -400, p.OLMCatalogPlacement)
+	reconcileCatalogSource(cs, "community-operators:50051", p.CommunityOperatorsImage, "Community Operators", -400, p)
 }
 
 func ReconcileRedHatMarketplaceCatalogSource(cs *operatorsv1alpha1.CatalogSource, p *OperatorLifecycleManagerParams) {
-	reconcileCatalogSource(cs, "redhat-marketplace:50051", p.RedHatMarketplaceImage, "Red Hat Marketplace", -300, p.OLMCatalogPlacement)
+	reconcileCatalogSource(cs, "redhat-marketplace:50051", p.RedHatMarketplaceImage, "Red Hat Marketplace", -300, p)
 }
 
 func ReconcileRedHatOperatorsCatalogSource(cs *operatorsv1alpha1.CatalogSource, p *OperatorLifecycleManagerParams) {
-	reconcileCatalogSource(cs, "redhat-operators:50051", p.RedHatOperatorsImage, "Red Hat Operators", -100, p.OLMCatalogPlacement)
+	reconcileCatalogSource(cs, "redhat-operators:50051", p.RedHatOperatorsImage, "Red Hat Operators", -100, p)
 }
 
-func reconcileCatalogSource(cs *operatorsv1alpha1.CatalogSource, address string, image string, displayName string, priority int, placement hyperv1.OLMCatalogPlacement) {
+func reconcileCatalogSource(cs *operatorsv1alpha1.CatalogSource, address string, image string, displayName string, priority int, p *OperatorLifecycleManagerParams) {
+	publisher := "Red Hat"
+	// A custom catalog source named after the default catalog replaces it.
+	if source, ok := p.CatalogSourceOverrides[cs.Name]; ok {
+		image = source.Image
+		if source.DisplayName != "" {
+			displayName = source.DisplayName
+		}
+		if source.Publisher != "" {
+			publisher = source.Publisher
+		}
+		if source.Priority != 0 {
+			priority = source.Priority
+		}
+	}
 	if cs.Annotations == nil {
 		cs.Annotations = map[string]string{}
 	}
@@ -31,7 +48,7 @@ func reconcileCatalogSource(cs *operatorsv1alpha1.CatalogSource, address string,
 	cs.Spec = operatorsv1alpha1.CatalogSourceSpec{
 		SourceType:  operatorsv1alpha1.SourceTypeGrpc,
 		DisplayName: displayName,
-		Publisher:   "Red Hat",
+		Publisher:   publisher,
 		Priority:    priority,
 		UpdateStrategy: &operatorsv1alpha1.UpdateStrategy{
 			RegistryPoll: &operatorsv1alpha1.RegistryPoll{
@@ -40,10 +57,41 @@ func reconcileCatalogSource(cs *operatorsv1alpha1.CatalogSource, address string,
 			},
 		},
 	}
-	if placement == hyperv1.ManagementOLMCatalogPlacement {
+	if p.OLMCatalogPlacement == hyperv1.ManagementOLMCatalogPlacement {
 		cs.Spec.Address = address
 	}
-	if placement == hyperv1.GuestOLMCatalogPlacement {
+	if p.OLMCatalogPlacement == hyperv1.GuestOLMCatalogPlacement {
 		cs.Spec.Image = image
 	}
 }
+
+// ReconcileCustomCatalogSource reconciles a custom catalog source. In the management OLM catalog placement, it is
+// served from the control plane namespace.
+func ReconcileCustomCatalogSource(cs *operatorsv1alpha1.CatalogSource, source hyperv1.OLMCatalogSource, p *OperatorLifecycleManagerParams) {
+	if cs.Labels == nil {
+		cs.Labels = map[string]string{}
+	}
+	cs.Labels[olm.CustomCatalogSourceLabel] = source.Name
+	displayName := source.DisplayName
+	if displayName == "" {
+		displayName = source.Name
+	}
+	cs.Spec = operatorsv1alpha1.CatalogSourceSpec{
+		SourceType:  operatorsv1alpha1.SourceTypeGrpc,
+		DisplayName: displayName,
+		Publisher:   source.Publisher,
+		Priority:    source.Priority,
+		UpdateStrategy: &operatorsv1alpha1.UpdateStrategy{
+			RegistryPoll: &operatorsv1alpha1.RegistryPoll{
+				RawInterval: "10m",
+				Interval:    &metav1.Duration{Duration: 10 * time.Minute},
+			},
+		},
+	}
+	if p.OLMCatalogPlacement == hyperv1.ManagementOLMCatalogPlacement {
+		cs.Spec.Address = fmt.Sprintf("%s:50051", olm.CustomCatalogServiceName(source.Name))
+	}
+	if p.OLMCatalogPlacement == hyperv1.GuestOLMCatalogPlacement {
+		cs.Spec.Image = source.Image
+	}
+}
@@ -11,6 +11,9 @@ type OperatorLifecycleManagerParams struct {
 	RedHatMarketplaceImage  string
 	RedHatOperatorsImage    string
 	OLMCatalogPlacement     hyperv1.OLMCatalogPlacement
+	CatalogSourceOverrides  map[string]hyperv1.OLMCatalogSource
+	CustomCatalogSources    []hyperv1.OLMCatalogSource
+	DisableDefaultCatalogs  bool
 }
 
 func NewOperatorLifecycleManagerParams(hcp *hyperv1.HostedControlPlane) *OperatorLifecycleManagerParams {
@@ -20,6 +23,14 @@ func NewOperatorLifecycleManagerParams(hcp *hyperv1.HostedControlPlane) *Operato
 		RedHatMarketplaceImage:  olm.CatalogToImage["redhat-marketplace"],
 		RedHatOperatorsImage:    olm.CatalogToImage["redhat-operators"],
 		OLMCatalogPlacement:     hcp.Spec.OLMCatalogPlacement,
+		CatalogSourceOverrides:  map[string]hyperv1.OLMCatalogSource{},
+		CustomCatalogSources:    olm.CustomCatalogSources(hcp),
+		DisableDefaultCatalogs:  olm.DefaultCatalogsDisabled(hcp),
+	}
+	for catalog := range olm.CatalogToImage {
+		if source := olm.CatalogSourceOverride(hcp, catalog); source != nil {
+			params.CatalogSourceOverrides[catalog] = *source
+		}
 	}
 
 	return params
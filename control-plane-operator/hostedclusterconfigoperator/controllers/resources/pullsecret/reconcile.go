@@ -0,0 +1,161 @@
+package pullsecret
+
+import (
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/support/util"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// kubeletPullSecretPath is the global pull secret of the node, which CRI-O
+	// reads on every image pull.
+	kubeletPullSecretPath = "/var/lib/kubelet/config.json"
+
+	syncScript = `#!/bin/bash
+set -euo pipefail
+source=/etc/pull-secret/.dockerconfigjson
+target=/host` + kubeletPullSecretPath + `
+while true; do
+  if ! cmp -s "${source}" "${target}"; then
+    cp "${source}" "${target}.tmp"
+    chmod 0600 "${target}.tmp"
+    mv "${target}.tmp" "${target}"
+    echo "$(date -u +%Y-%m-%dT%H:%M:%SZ) Updated node pull secret"
+  fi
+  sleep 30
+done
+`
+)
+
+var (
+	volumeMounts = util.PodVolumeMounts{
+		syncerContainer().Name: util.ContainerVolumeMounts{
+			pullSecretVolume().Name: "/etc/pull-secret",
+			kubeletVolume().Name:    "/host/var/lib/kubelet",
+		},
+	}
+	maxUnavailable = intstr.FromString("10%")
+)
+
+// ReconcileNodePoolPullSecret copies the NodePool pull secret from the control
+// plane into the guest cluster.
+func ReconcileNodePoolPullSecret(secret, source *corev1.Secret) {
+	if secret.Labels == nil {
+		secret.Labels = map[string]string{}
+	}
+	secret.Labels[hyperv1.NodePoolPullSecretLabel] = "true"
+	secret.Labels[hyperv1.NodePoolLabel] = source.Labels[hyperv1.NodePoolLabel]
+	secret.Type = corev1.SecretTypeDockerConfigJson
+	secret.Data = map[string][]byte{
+		corev1.DockerConfigJsonKey: source.Data[corev1.DockerConfigJsonKey],
+	}
+}
+
+// ReconcileSyncerDaemonSet reconciles the DaemonSet which keeps the global pull
+// secret of the Nodes of a NodePool in sync with the NodePool pull secret, so
+// that pull secret changes are applied without replacing or rebooting Nodes.
+func ReconcileSyncerDaemonSet(daemonset *appsv1.DaemonSet, nodePoolName, secretName, image string) {
+	labels := map[string]string{
+		"app":                 "pull-secret-syncer",
+		hyperv1.NodePoolLabel: nodePoolName,
+	}
+	if daemonset.Labels == nil {
+		daemonset.Labels = map[string]string{}
+	}
+	daemonset.Labels[hyperv1.NodePoolPullSecretLabel] = "true"
+	daemonset.Labels[hyperv1.NodePoolLabel] = nodePoolName
+
+	daemonset.Spec = appsv1.DaemonSetSpec{
+		Selector: &metav1.LabelSelector{
+			MatchLabels: labels,
+		},
+		Template: corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: labels,
+			},
+			Spec: corev1.PodSpec{
+				NodeSelector: map[string]string{
+					hyperv1.NodePoolLabel: nodePoolName,
+				},
+				Tolerations: []corev1.Toleration{
+					{
+						Operator: corev1.TolerationOpExists,
+					},
+				},
+				PriorityClassName:            "system-node-critical",
+				AutomountServiceAccountToken: ptr.To(false),
+				Containers: []corev1.Container{
+					util.BuildContainer(syncerContainer(), buildSyncerContainer(image)),
+				},
+				Volumes: []corev1.Volume{
+					util.BuildVolume(pullSecretVolume(), buildPullSecretVolume(secretName)),
+					util.BuildVolume(kubeletVolume(), buildKubeletVolume),
+				},
+			},
+		},
+		UpdateStrategy: appsv1.DaemonSetUpdateStrategy{
+			Type: appsv1.RollingUpdateDaemonSetStrategyType,
+			RollingUpdate: &appsv1.RollingUpdateDaemonSet{
+				MaxUnavailable: &maxUnavailable,
+			},
+		},
+	}
+}
+
+func syncerContainer() *corev1.Container {
+	return &corev1.Container{
+		Name: "pull-secret-syncer",
+	}
+}
+
+func pullSecretVolume() *corev1.Volume {
+	return &corev1.Volume{
+		Name: "pull-secret",
+	}
+}
+
+func kubeletVolume() *corev1.Volume {
+	return &corev1.Volume{
+		Name: "kubelet",
+	}
+}
+
+func buildSyncerContainer(image string) func(c *corev1.Container) {
+	return func(c *corev1.Container) {
+		c.Image = image
+		c.ImagePullPolicy = corev1.PullIfNotPresent
+		c.Command = []string{"/bin/bash", "-c", syncScript}
+		c.SecurityContext = &corev1.SecurityContext{
+			// Required to write into the kubelet directory of the host.
+			Privileged: ptr.To(true),
+		}
+		c.Resources = corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("5m"),
+				corev1.ResourceMemory: resource.MustParse("10Mi"),
+			},
+		}
+		c.VolumeMounts = volumeMounts.ContainerMounts(c.Name)
+	}
+}
+
+func buildPullSecretVolume(secretName string) func(v *corev1.Volume) {
+	return func(v *corev1.Volume) {
+		v.Secret = &corev1.SecretVolumeSource{
+			SecretName:  secretName,
+			DefaultMode: ptr.To[int32](0400),
+		}
+	}
+}
+
+func buildKubeletVolume(v *corev1.Volume) {
+	v.HostPath = &corev1.HostPathVolumeSource{
+		Path: "/var/lib/kubelet",
+		Type: ptr.To(corev1.HostPathDirectory),
+	}
+}
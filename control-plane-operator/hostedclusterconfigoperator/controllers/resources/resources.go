@@ -11,10 +11,12 @@ import (
 
 	"github.com/openshift/hypershift/control-plane-operator/hostedclusterconfigoperator/api"
 	"github.com/openshift/hypershift/control-plane-operator/hostedclusterconfigoperator/controllers/resources/cco"
+	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v6/apis/volumesnapshot/v1"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -29,6 +31,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
@@ -48,6 +51,8 @@ import (
 	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/cvo"
 	cpomanifests "github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/manifests"
 	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/ocm"
+	cpoolm "github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/olm"
+	cpopki "github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/pki"
 	alerts "github.com/openshift/hypershift/control-plane-operator/hostedclusterconfigoperator/controllers/resources/alerts"
 	ccm "github.com/openshift/hypershift/control-plane-operator/hostedclusterconfigoperator/controllers/resources/cloudcontrollermanager/azure"
 	"github.com/openshift/hypershift/control-plane-operator/hostedclusterconfigoperator/controllers/resources/crd"
@@ -61,8 +66,10 @@ import (
 	"github.com/openshift/hypershift/control-plane-operator/hostedclusterconfigoperator/controllers/resources/oapi"
 	"github.com/openshift/hypershift/control-plane-operator/hostedclusterconfigoperator/controllers/resources/oauth"
 	"github.com/openshift/hypershift/control-plane-operator/hostedclusterconfigoperator/controllers/resources/olm"
+	"github.com/openshift/hypershift/control-plane-operator/hostedclusterconfigoperator/controllers/resources/pullsecret"
 	"github.com/openshift/hypershift/control-plane-operator/hostedclusterconfigoperator/controllers/resources/rbac"
 	"github.com/openshift/hypershift/control-plane-operator/hostedclusterconfigoperator/controllers/resources/registry"
+	"github.com/openshift/hypershift/control-plane-operator/hostedclusterconfigoperator/controllers/resources/sshkey"
 	"github.com/openshift/hypershift/control-plane-operator/hostedclusterconfigoperator/controllers/resources/storage"
 	"github.com/openshift/hypershift/control-plane-operator/hostedclusterconfigoperator/operator"
 	"github.com/openshift/hypershift/support/config"
@@ -109,6 +116,7 @@ type reconciler struct {
 	hcpName                   string
 	hcpNamespace              string
 	releaseProvider           releaseinfo.Provider
+	imageMirrorResolver       *util.ImageMirrorResolver
 	konnectivityServerAddress string
 	konnectivityServerPort    int32
 	oauthAddress              string
@@ -166,6 +174,7 @@ func Setup(ctx context.Context, opts *operator.HostedClusterConfigOperatorConfig
 		hcpName:                   opts.HCPName,
 		hcpNamespace:              opts.Namespace,
 		releaseProvider:           opts.ReleaseProvider,
+		imageMirrorResolver:       opts.ImageMirrorResolver,
 		konnectivityServerAddress: opts.KonnectivityAddress,
 		konnectivityServerPort:    opts.KonnectivityPort,
 		oauthAddress:              opts.OAuthAddress,
@@ -215,6 +224,14 @@ func Setup(ctx context.Context, opts *operator.HostedClusterConfigOperatorConfig
 	if err := c.Watch(source.Kind(opts.CPCluster.GetCache(), &hyperv1.HostedControlPlane{}), eventHandler()); err != nil {
 		return fmt.Errorf("failed to watch HostedControlPlane: %w", err)
 	}
+	// NodePool pull secrets and SSH keys are generated in the control plane namespace by the NodePool controller.
+	if err := c.Watch(source.Kind(opts.CPCluster.GetCache(), &corev1.Secret{}), eventHandler(), predicate.NewPredicateFuncs(func(o client.Object) bool {
+		_, isPullSecret := o.GetLabels()[hyperv1.NodePoolPullSecretLabel]
+		_, isSSHKey := o.GetLabels()[hyperv1.NodePoolSSHKeyLabel]
+		return isPullSecret || isSSHKey
+	})); err != nil {
+		return fmt.Errorf("failed to watch Secrets: %w", err)
+	}
 
 	return nil
 }
@@ -254,6 +271,12 @@ func (r *reconciler) Reconcile(ctx context.Context, _ ctrl.Request) (ctrl.Result
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to get lookup release image %s: %w", hcp.Spec.ReleaseImage, err)
 	}
+	// Data plane components are pulled by guest nodes, which don't know about the
+	// management cluster mirrors, so point them to a reachable mirror upfront.
+	releaseImage, unreachableImages := r.imageMirrorResolver.ResolveReleaseImage(ctx, releaseImage, pullSecret.Data[corev1.DockerConfigJsonKey])
+	if len(unreachableImages) > 0 {
+		log.Info("Some release images are not reachable from any registry mirror", "images", unreachableImages)
+	}
 	var errs []error
 	log.Info("reconciling guest cluster crds")
 	if err := r.reconcileCRDs(ctx); err != nil {
@@ -478,6 +501,16 @@ func (r *reconciler) Reconcile(ctx context.Context, _ ctrl.Request) (ctrl.Result
 		}
 	}
 
+	log.Info("reconciling nodepool pull secrets")
+	if err := r.reconcileNodePoolPullSecrets(ctx, hcp, releaseImage); err != nil {
+		errs = append(errs, fmt.Errorf("failed to reconcile nodepool pull secrets: %w", err))
+	}
+
+	log.Info("reconciling nodepool ssh keys")
+	if err := r.reconcileNodePoolSSHKeys(ctx, hcp, releaseImage); err != nil {
+		errs = append(errs, fmt.Errorf("failed to reconcile nodepool ssh keys: %w", err))
+	}
+
 	log.Info("reconciling user cert CA bundle")
 	if err := r.reconcileUserCertCABundle(ctx, hcp); err != nil {
 		errs = append(errs, fmt.Errorf("failed to reconcile user cert CA bundle: %w", err))
@@ -676,6 +709,11 @@ func (r *reconciler) reconcileConfig(ctx context.Context, hcp *hyperv1.HostedCon
 		errs = append(errs, fmt.Errorf("failed to reconcile dns config: %w", err))
 	}
 
+	// Copy image additionalTrustedCA to guest cluster.
+	if err := r.reconcileImageAdditionalTrustedCAConfigMap(ctx, hcp); err != nil {
+		errs = append(errs, fmt.Errorf("failed to reconcile image additionalTrustedCA configmap: %w", err))
+	}
+
 	image := globalconfig.ImageConfig()
 	if _, err := r.CreateOrUpdate(ctx, r.client, image, func() error {
 		globalconfig.ReconcileImageConfig(image, hcp)
@@ -700,6 +738,8 @@ func (r *reconciler) reconcileConfig(ctx context.Context, hcp *hyperv1.HostedCon
 		return nil
 	}); err != nil {
 		errs = append(errs, fmt.Errorf("failed to create network config: %w", err))
+	} else if err := networkoperator.ReportClusterNetworkApplied(ctx, r.cpClient, networkConfig, hcp); err != nil {
+		errs = append(errs, err)
 	}
 
 	// Copy proxy trustedCA to guest cluster.
@@ -799,6 +839,56 @@ func (r *reconciler) reconcileProxyTrustedCAConfigMap(ctx context.Context, hcp *
 	return nil
 }
 
+func (r *reconciler) reconcileImageAdditionalTrustedCAConfigMap(ctx context.Context, hcp *hyperv1.HostedControlPlane) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	configMapRef := ""
+	if hcp.Spec.Configuration != nil && hcp.Spec.Configuration.Image != nil {
+		configMapRef = hcp.Spec.Configuration.Image.AdditionalTrustedCA.Name
+	}
+
+	image := globalconfig.ImageConfig()
+	if err := r.client.Get(ctx, client.ObjectKeyFromObject(image), image); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	currentConfigMapRef := image.Spec.AdditionalTrustedCA.Name
+	if currentConfigMapRef != "" && currentConfigMapRef != configMapRef {
+		// cleanup the old configMap in the hosted cluster, unless it is still the proxy trustedCA.
+		// The configMap in the control plane namespace is managed by the hypershift operator.
+		proxyTrustedCA := ""
+		if hcp.Spec.Configuration != nil && hcp.Spec.Configuration.Proxy != nil {
+			proxyTrustedCA = hcp.Spec.Configuration.Proxy.TrustedCA.Name
+		}
+		if currentConfigMapRef != proxyTrustedCA {
+			// log and ignore deletion errors, should not disrupt normal workflow
+			cm := manifests.ImageAdditionalTrustedCAConfigMap(currentConfigMapRef)
+			if err := r.client.Delete(ctx, cm); err != nil && !apierrors.IsNotFound(err) {
+				log.Error(err, "failed to delete configmap in hosted cluster", "name", cm.Name, "namespace", cm.Namespace)
+			}
+		}
+	}
+
+	if configMapRef == "" {
+		return nil
+	}
+
+	sourceCM := &corev1.ConfigMap{}
+	if err := r.cpClient.Get(ctx, client.ObjectKey{Namespace: hcp.Namespace, Name: configMapRef}, sourceCM); err != nil {
+		return fmt.Errorf("failed to get referenced additionalTrustedCA configmap %s/%s: %w", hcp.Namespace, configMapRef, err)
+	}
+
+	destCM := manifests.ImageAdditionalTrustedCAConfigMap(sourceCM.Name)
+	if _, err := r.CreateOrUpdate(ctx, r.client, destCM, func() error {
+		destCM.Data = sourceCM.Data
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to reconcile referenced additionalTrustedCA config map %s/%s: %w", destCM.Namespace, destCM.Name, err)
+	}
+
+	return nil
+}
+
 func (r *reconciler) reconcileNamespaces(ctx context.Context) error {
 	namespaceManifests := []struct {
 		manifest  func() *corev1.Namespace
@@ -907,7 +997,7 @@ func (r *reconciler) reconcileIngressController(ctx context.Context, hcp *hyperv
 	p := ingress.NewIngressParams(hcp)
 	ingressController := manifests.IngressDefaultIngressController()
 	if _, err := r.CreateOrUpdate(ctx, r.client, ingressController, func() error {
-		return ingress.ReconcileDefaultIngressController(ingressController, p.IngressSubdomain, p.PlatformType, p.Replicas, p.IBMCloudUPI, p.IsPrivate, p.AWSNLB, p.LoadBalancerScope)
+		return ingress.ReconcileDefaultIngressController(ingressController, p.IngressSubdomain, p.PlatformType, p.Replicas, p.IBMCloudUPI, p.IsPrivate, p.AWSNLB, p.LoadBalancerScope, p.Placement, p.DefaultIngressController)
 	}); err != nil {
 		errs = append(errs, fmt.Errorf("failed to reconcile default ingress controller: %w", err))
 	}
@@ -1048,6 +1138,162 @@ func (r *reconciler) reconcileAuthOIDC(ctx context.Context, hcp *hyperv1.HostedC
 	return errors.NewAggregate(errs)
 }
 
+// reconcileNodePoolPullSecrets syncs the pull secret of every NodePool into the guest cluster, along with a
+// DaemonSet that writes it onto the Nodes of the NodePool. This propagates pull secret rotations and NodePool
+// additional pull secrets to existing Nodes without replacing them.
+func (r *reconciler) reconcileNodePoolPullSecrets(ctx context.Context, hcp *hyperv1.HostedControlPlane, releaseImage *releaseinfo.ReleaseImage) error {
+	cpSecrets := &corev1.SecretList{}
+	if err := r.cpClient.List(ctx, cpSecrets, client.InNamespace(hcp.Namespace), client.HasLabels{hyperv1.NodePoolPullSecretLabel}); err != nil {
+		return fmt.Errorf("failed to list nodepool pull secrets: %w", err)
+	}
+
+	image := releaseImage.ComponentImages()["cli"]
+	var errs []error
+	nodePools := sets.New[string]()
+	for i := range cpSecrets.Items {
+		cpSecret := &cpSecrets.Items[i]
+		nodePoolName := cpSecret.Labels[hyperv1.NodePoolLabel]
+		if nodePoolName == "" || !cpSecret.DeletionTimestamp.IsZero() {
+			continue
+		}
+		nodePools.Insert(nodePoolName)
+
+		secret := manifests.NodePoolPullSecret(nodePoolName)
+		if _, err := r.CreateOrUpdate(ctx, r.client, secret, func() error {
+			pullsecret.ReconcileNodePoolPullSecret(secret, cpSecret)
+			return nil
+		}); err != nil {
+			errs = append(errs, fmt.Errorf("failed to reconcile pull secret for nodepool %s: %w", nodePoolName, err))
+			continue
+		}
+
+		if image == "" {
+			errs = append(errs, fmt.Errorf("cli image not found in release image"))
+			continue
+		}
+		daemonSet := manifests.PullSecretSyncerDaemonSet(nodePoolName)
+		if _, err := r.CreateOrUpdate(ctx, r.client, daemonSet, func() error {
+			pullsecret.ReconcileSyncerDaemonSet(daemonSet, nodePoolName, secret.Name, image)
+			return nil
+		}); err != nil {
+			errs = append(errs, fmt.Errorf("failed to reconcile pull secret syncer for nodepool %s: %w", nodePoolName, err))
+		}
+	}
+
+	// Remove the resources of deleted NodePools.
+	daemonSets := &appsv1.DaemonSetList{}
+	if err := r.client.List(ctx, daemonSets, client.InNamespace(manifests.NamespaceKubeSystem().Name), client.HasLabels{hyperv1.NodePoolPullSecretLabel}); err != nil {
+		errs = append(errs, fmt.Errorf("failed to list pull secret syncers: %w", err))
+	} else {
+		for i := range daemonSets.Items {
+			if !nodePools.Has(daemonSets.Items[i].Labels[hyperv1.NodePoolLabel]) {
+				if _, err := util.DeleteIfNeeded(ctx, r.client, &daemonSets.Items[i]); err != nil {
+					errs = append(errs, err)
+				}
+			}
+		}
+	}
+	secrets := &corev1.SecretList{}
+	if err := r.client.List(ctx, secrets, client.InNamespace(manifests.NamespaceKubeSystem().Name), client.HasLabels{hyperv1.NodePoolPullSecretLabel}); err != nil {
+		errs = append(errs, fmt.Errorf("failed to list nodepool pull secrets: %w", err))
+	} else {
+		for i := range secrets.Items {
+			if !nodePools.Has(secrets.Items[i].Labels[hyperv1.NodePoolLabel]) {
+				if _, err := util.DeleteIfNeeded(ctx, r.client, &secrets.Items[i]); err != nil {
+					errs = append(errs, err)
+				}
+			}
+		}
+	}
+
+	return errors.NewAggregate(errs)
+}
+
+// reconcileNodePoolSSHKeys syncs the SSH key of the NodePools which rotate it without replacing Nodes into the guest
+// cluster, along with a DaemonSet that writes it onto the Nodes of the NodePool. Once all the Nodes have the key, its hash
+// is recorded on the control plane Secret for the NodePool controller to report the propagation.
+func (r *reconciler) reconcileNodePoolSSHKeys(ctx context.Context, hcp *hyperv1.HostedControlPlane, releaseImage *releaseinfo.ReleaseImage) error {
+	cpSecrets := &corev1.SecretList{}
+	if err := r.cpClient.List(ctx, cpSecrets, client.InNamespace(hcp.Namespace), client.HasLabels{hyperv1.NodePoolSSHKeyLabel}); err != nil {
+		return fmt.Errorf("failed to list nodepool ssh key secrets: %w", err)
+	}
+
+	image := releaseImage.ComponentImages()["cli"]
+	var errs []error
+	nodePools := sets.New[string]()
+	for i := range cpSecrets.Items {
+		cpSecret := &cpSecrets.Items[i]
+		nodePoolName := cpSecret.Labels[hyperv1.NodePoolLabel]
+		if nodePoolName == "" || !cpSecret.DeletionTimestamp.IsZero() {
+			continue
+		}
+		nodePools.Insert(nodePoolName)
+
+		secret := manifests.NodePoolSSHKeySecret(nodePoolName)
+		if _, err := r.CreateOrUpdate(ctx, r.client, secret, func() error {
+			sshkey.ReconcileNodePoolSSHKeySecret(secret, cpSecret)
+			return nil
+		}); err != nil {
+			errs = append(errs, fmt.Errorf("failed to reconcile ssh key for nodepool %s: %w", nodePoolName, err))
+			continue
+		}
+
+		if image == "" {
+			errs = append(errs, fmt.Errorf("cli image not found in release image"))
+			continue
+		}
+		sshKeyHash := util.HashSimple(cpSecret.Data[sshkey.SSHKeySecretKey])
+		daemonSet := manifests.SSHKeySyncerDaemonSet(nodePoolName)
+		if _, err := r.CreateOrUpdate(ctx, r.client, daemonSet, func() error {
+			sshkey.ReconcileSyncerDaemonSet(daemonSet, nodePoolName, secret.Name, sshKeyHash, image)
+			return nil
+		}); err != nil {
+			errs = append(errs, fmt.Errorf("failed to reconcile ssh key syncer for nodepool %s: %w", nodePoolName, err))
+			continue
+		}
+
+		if propagatedHash := sshkey.PropagatedSSHKeyHash(daemonSet); propagatedHash == sshKeyHash &&
+			cpSecret.Annotations[hyperv1.NodePoolSSHKeyPropagatedHashAnnotation] != propagatedHash {
+			original := cpSecret.DeepCopy()
+			if cpSecret.Annotations == nil {
+				cpSecret.Annotations = map[string]string{}
+			}
+			cpSecret.Annotations[hyperv1.NodePoolSSHKeyPropagatedHashAnnotation] = propagatedHash
+			if err := r.cpClient.Patch(ctx, cpSecret, client.MergeFrom(original)); err != nil {
+				errs = append(errs, fmt.Errorf("failed to record ssh key propagation for nodepool %s: %w", nodePoolName, err))
+			}
+		}
+	}
+
+	// Remove the resources of NodePools which were deleted or don't rotate the SSH key anymore.
+	daemonSets := &appsv1.DaemonSetList{}
+	if err := r.client.List(ctx, daemonSets, client.InNamespace(manifests.NamespaceKubeSystem().Name), client.HasLabels{hyperv1.NodePoolSSHKeyLabel}); err != nil {
+		errs = append(errs, fmt.Errorf("failed to list ssh key syncers: %w", err))
+	} else {
+		for i := range daemonSets.Items {
+			if !nodePools.Has(daemonSets.Items[i].Labels[hyperv1.NodePoolLabel]) {
+				if _, err := util.DeleteIfNeeded(ctx, r.client, &daemonSets.Items[i]); err != nil {
+					errs = append(errs, err)
+				}
+			}
+		}
+	}
+	secrets := &corev1.SecretList{}
+	if err := r.client.List(ctx, secrets, client.InNamespace(manifests.NamespaceKubeSystem().Name), client.HasLabels{hyperv1.NodePoolSSHKeyLabel}); err != nil {
+		errs = append(errs, fmt.Errorf("failed to list nodepool ssh key secrets: %w", err))
+	} else {
+		for i := range secrets.Items {
+			if !nodePools.Has(secrets.Items[i].Labels[hyperv1.NodePoolLabel]) {
+				if _, err := util.DeleteIfNeeded(ctx, r.client, &secrets.Items[i]); err != nil {
+					errs = append(errs, err)
+				}
+			}
+		}
+	}
+
+	return errors.NewAggregate(errs)
+}
+
 func (r *reconciler) reconcileKonnectivityAgent(ctx context.Context, hcp *hyperv1.HostedControlPlane, releaseImage *releaseinfo.ReleaseImage) error {
 	var errs []error
 
@@ -1068,10 +1314,12 @@ func (r *reconciler) reconcileKonnectivityAgent(ctx context.Context, hcp *hyperv
 
 	p := konnectivity.NewKonnectivityParams(hcp, releaseImage.ComponentImages(), r.konnectivityServerAddress, r.konnectivityServerPort)
 
+	var konnectivityCA *corev1.ConfigMap
 	controlPlaneKonnectivityCA := manifests.KonnectivityControlPlaneCAConfigMap(hcp.Namespace)
 	if err := r.cpClient.Get(ctx, client.ObjectKeyFromObject(controlPlaneKonnectivityCA), controlPlaneKonnectivityCA); err != nil {
 		errs = append(errs, fmt.Errorf("failed to get control plane konnectivity agent CA config map: %w", err))
 	} else {
+		konnectivityCA = controlPlaneKonnectivityCA
 		hostedKonnectivityCA := manifests.KonnectivityHostedCAConfigMap()
 		if _, err := r.CreateOrUpdate(ctx, r.client, hostedKonnectivityCA, func() error {
 			util.CopyConfigMap(hostedKonnectivityCA, controlPlaneKonnectivityCA)
@@ -1102,7 +1350,12 @@ func (r *reconciler) reconcileKonnectivityAgent(ctx context.Context, hcp *hyperv
 
 	agentDaemonset := manifests.KonnectivityAgentDaemonSet()
 	if _, err := r.CreateOrUpdate(ctx, r.client, agentDaemonset, func() error {
-		konnectivity.ReconcileAgentDaemonSet(agentDaemonset, p.DeploymentConfig, p.Image, p.ExternalAddress, p.ExternalPort, hcp.Spec.Platform, proxy.Status)
+		// Keep the CA the agents were rolled out with when the current one can't be read.
+		caHash := agentDaemonset.Spec.Template.Annotations[cpopki.KonnectivityCAHashAnnotation]
+		if konnectivityCA != nil {
+			caHash = cpopki.KonnectivityCAHash(konnectivityCA)
+		}
+		konnectivity.ReconcileAgentDaemonSet(agentDaemonset, p.DeploymentConfig, p.Image, p.ExternalAddress, p.ExternalPort, p.SyncForever, hcp.Spec.Platform, proxy.Status, caHash)
 		return nil
 	}); err != nil {
 		errs = append(errs, fmt.Errorf("failed to reconcile konnectivity agent daemonset: %w", err))
@@ -1466,6 +1719,37 @@ func (r *reconciler) reconcileOperatorHub(ctx context.Context, operatorHub *conf
 	return nil
 }
 
+// reconcileCustomCatalogSources reconciles the custom catalog sources, and removes the ones which are no longer
+// configured.
+func (r *reconciler) reconcileCustomCatalogSources(ctx context.Context, p *olm.OperatorLifecycleManagerParams) []error {
+	var errs []error
+	expected := sets.New[string]()
+	for _, source := range p.CustomCatalogSources {
+		expected.Insert(source.Name)
+		cs := manifests.CustomCatalogSource(source.Name)
+		if _, err := r.CreateOrUpdate(ctx, r.client, cs, func() error {
+			olm.ReconcileCustomCatalogSource(cs, source, p)
+			return nil
+		}); err != nil {
+			errs = append(errs, fmt.Errorf("failed to reconcile catalog source %s/%s: %w", cs.Namespace, cs.Name, err))
+		}
+	}
+
+	catalogSources := &operatorsv1alpha1.CatalogSourceList{}
+	if err := r.client.List(ctx, catalogSources, client.InNamespace(manifests.CustomCatalogSource("").Namespace), cpoolm.CustomCatalogSourceSelector()); err != nil {
+		return append(errs, fmt.Errorf("failed to list custom catalog sources: %w", err))
+	}
+	for i := range catalogSources.Items {
+		if expected.Has(catalogSources.Items[i].Name) {
+			continue
+		}
+		if _, err := util.DeleteIfNeeded(ctx, r.client, &catalogSources.Items[i]); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete catalog source %s: %w", catalogSources.Items[i].Name, err))
+		}
+	}
+	return errs
+}
+
 func (r *reconciler) reconcileOLM(ctx context.Context, hcp *hyperv1.HostedControlPlane) []error {
 	var errs []error
 
@@ -1516,7 +1800,8 @@ func (r *reconciler) reconcileOLM(ctx context.Context, hcp *hyperv1.HostedContro
 
 	for _, catalog := range catalogs {
 		cs := catalog.manifest()
-		if operatorHub.Spec.DisableAllDefaultSources {
+		_, overridden := p.CatalogSourceOverrides[cs.Name]
+		if (operatorHub.Spec.DisableAllDefaultSources || p.DisableDefaultCatalogs) && !overridden {
 			if _, err := util.DeleteIfNeeded(ctx, r.client, cs); err != nil {
 				if !apierrors.IsNotFound(err) {
 					errs = append(errs, fmt.Errorf("failed to delete catalogSource %s/%s: %w", cs.Namespace, cs.Name, err))
@@ -1532,6 +1817,8 @@ func (r *reconciler) reconcileOLM(ctx context.Context, hcp *hyperv1.HostedContro
 		}
 	}
 
+	errs = append(errs, r.reconcileCustomCatalogSources(ctx, p)...)
+
 	rootCA := cpomanifests.RootCASecret(hcp.Namespace)
 	if err := r.cpClient.Get(ctx, client.ObjectKeyFromObject(rootCA), rootCA); err != nil {
 		errs = append(errs, fmt.Errorf("failed to get root ca cert from control plane namespace: %w", err))
@@ -2241,15 +2528,117 @@ func (r *reconciler) reconcileStorage(ctx context.Context, hcp *hyperv1.HostedCo
 		errs = append(errs, fmt.Errorf("failed to reconcile Storage : %w", err))
 	}
 
-	if hcp.Spec.Platform.Type == hyperv1.AWSPlatform {
-		driver := manifests.ClusterCSIDriver(operatorv1.AWSEBSCSIDriver)
+	driverName, hasPlatformDriver := storage.PlatformCSIDriver(hcp.Spec.Platform.Type)
+	if hcp.Spec.Platform.Type == hyperv1.AWSPlatform || (hasPlatformDriver && hcp.Spec.Storage != nil && hcp.Spec.Storage.StorageClassState != "") {
+		driver := manifests.ClusterCSIDriver(driverName)
 		if _, err := r.CreateOrUpdate(ctx, r.client, driver, func() error {
-			storage.ReconcileClusterCSIDriver(driver)
+			storage.ReconcileClusterCSIDriver(driver, hcp.Spec.Storage)
 			return nil
 		}); err != nil {
 			errs = append(errs, fmt.Errorf("failed to reconcile ClusterCSIDriver %s: %w", driver.Name, err))
 		}
 	}
+	if hasPlatformDriver {
+		errs = append(errs, r.reconcileStorageClasses(ctx, hcp, driverName)...)
+	}
+
+	var csiTolerations []corev1.Toleration
+	if hcp.Spec.DataPlaneInfrastructurePlacement != nil {
+		csiTolerations = hcp.Spec.DataPlaneInfrastructurePlacement.Tolerations
+	}
+	csiNamespace := manifests.NamespaceClusterCSIDrivers()
+	if _, err := r.CreateOrUpdate(ctx, r.client, csiNamespace, func() error {
+		return namespaces.ReconcileClusterCSIDriversNamespace(csiNamespace, csiTolerations)
+	}); err != nil {
+		errs = append(errs, fmt.Errorf("failed to reconcile namespace %s: %w", csiNamespace.Name, err))
+	}
+	return errs
+}
+
+// reconcileStorageClasses reconciles the StorageClasses and VolumeSnapshotClasses of the CSI driver of the platform
+// listed in the storage spec of the HostedControlPlane, deletes the ones it created which are no longer listed, and
+// unmarks the other default classes when one of them is the default.
+func (r *reconciler) reconcileStorageClasses(ctx context.Context, hcp *hyperv1.HostedControlPlane, driver operatorv1.CSIDriverName) []error {
+	var errs []error
+	spec := hcp.Spec.Storage
+	if spec == nil {
+		spec = &hyperv1.ClusterStorageSpec{}
+	}
+
+	wantedStorageClasses := sets.New[string]()
+	hasDefaultStorageClass := false
+	for _, class := range spec.StorageClasses {
+		wantedStorageClasses.Insert(class.Name)
+		hasDefaultStorageClass = hasDefaultStorageClass || class.Default
+		sc := &storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: class.Name}}
+		if _, err := r.CreateOrUpdate(ctx, r.client, sc, func() error {
+			storage.ReconcileStorageClass(sc, driver, class)
+			return nil
+		}); err != nil {
+			errs = append(errs, fmt.Errorf("failed to reconcile StorageClass %s: %w", class.Name, err))
+		}
+	}
+	storageClasses := &storagev1.StorageClassList{}
+	if err := r.client.List(ctx, storageClasses); err != nil {
+		errs = append(errs, fmt.Errorf("failed to list StorageClasses: %w", err))
+	} else {
+		for i := range storageClasses.Items {
+			sc := &storageClasses.Items[i]
+			switch {
+			case wantedStorageClasses.Has(sc.Name):
+			case sc.Labels[storage.ManagedStorageClassLabel] == "true":
+				if _, err := util.DeleteIfNeeded(ctx, r.client, sc); err != nil {
+					errs = append(errs, fmt.Errorf("failed to delete StorageClass %s: %w", sc.Name, err))
+				}
+			case hasDefaultStorageClass && sc.Annotations[storage.DefaultStorageClassAnnotation] == "true":
+				// The CSI driver operators keep the default annotation of their StorageClasses as it is set, so
+				// setting it to false rather than removing it is stable.
+				original := sc.DeepCopy()
+				sc.Annotations[storage.DefaultStorageClassAnnotation] = "false"
+				if err := r.client.Patch(ctx, sc, client.MergeFrom(original)); err != nil {
+					errs = append(errs, fmt.Errorf("failed to unmark the default StorageClass %s: %w", sc.Name, err))
+				}
+			}
+		}
+	}
+
+	wantedVolumeSnapshotClasses := sets.New[string]()
+	hasDefaultVolumeSnapshotClass := false
+	for _, class := range spec.VolumeSnapshotClasses {
+		wantedVolumeSnapshotClasses.Insert(class.Name)
+		hasDefaultVolumeSnapshotClass = hasDefaultVolumeSnapshotClass || class.Default
+		vsc := &snapshotv1.VolumeSnapshotClass{ObjectMeta: metav1.ObjectMeta{Name: class.Name}}
+		if _, err := r.CreateOrUpdate(ctx, r.client, vsc, func() error {
+			storage.ReconcileVolumeSnapshotClass(vsc, driver, class)
+			return nil
+		}); err != nil {
+			errs = append(errs, fmt.Errorf("failed to reconcile VolumeSnapshotClass %s: %w", class.Name, err))
+		}
+	}
+	volumeSnapshotClasses := &snapshotv1.VolumeSnapshotClassList{}
+	if err := r.client.List(ctx, volumeSnapshotClasses); err != nil {
+		// The VolumeSnapshotClass API is installed by the CSI snapshot controller operator.
+		if !meta.IsNoMatchError(err) || len(spec.VolumeSnapshotClasses) > 0 {
+			errs = append(errs, fmt.Errorf("failed to list VolumeSnapshotClasses: %w", err))
+		}
+	} else {
+		for i := range volumeSnapshotClasses.Items {
+			vsc := &volumeSnapshotClasses.Items[i]
+			switch {
+			case wantedVolumeSnapshotClasses.Has(vsc.Name):
+			case vsc.Labels[storage.ManagedStorageClassLabel] == "true":
+				if _, err := util.DeleteIfNeeded(ctx, r.client, vsc); err != nil {
+					errs = append(errs, fmt.Errorf("failed to delete VolumeSnapshotClass %s: %w", vsc.Name, err))
+				}
+			case hasDefaultVolumeSnapshotClass && vsc.Driver == string(driver) && vsc.Annotations[storage.DefaultVolumeSnapshotClassAnnotation] == "true":
+				original := vsc.DeepCopy()
+				vsc.Annotations[storage.DefaultVolumeSnapshotClassAnnotation] = "false"
+				if err := r.client.Patch(ctx, vsc, client.MergeFrom(original)); err != nil {
+					errs = append(errs, fmt.Errorf("failed to unmark the default VolumeSnapshotClass %s: %w", vsc.Name, err))
+				}
+			}
+		}
+	}
 	return errs
 }
 
@@ -7,9 +7,11 @@ import (
 	"testing"
 	"time"
 
+	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v6/apis/volumesnapshot/v1"
 	. "github.com/onsi/gomega"
 	appsv1 "k8s.io/api/apps/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -20,9 +22,12 @@ import (
 	cpomanifests "github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/manifests"
 	"github.com/openshift/hypershift/control-plane-operator/hostedclusterconfigoperator/api"
 	"github.com/openshift/hypershift/control-plane-operator/hostedclusterconfigoperator/controllers/resources/manifests"
+	"github.com/openshift/hypershift/control-plane-operator/hostedclusterconfigoperator/controllers/resources/storage"
 	"github.com/openshift/hypershift/support/globalconfig"
 	fakereleaseprovider "github.com/openshift/hypershift/support/releaseinfo/fake"
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
 	controllerruntime "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -271,6 +276,76 @@ func TestReconcileOLM(t *testing.T) {
 	}
 }
 
+func TestReconcileOLMCustomCatalogSources(t *testing.T) {
+	ctx := context.Background()
+	hcp := fakeHCP()
+	hcp.Namespace = "openshift-operator-lifecycle-manager"
+	fakeCPService := manifests.OLMPackageServerControlPlaneService(hcp.Namespace)
+	fakeCPService.Spec.ClusterIP = "172.30.108.248"
+	rootCA := cpomanifests.RootCASecret(hcp.Namespace)
+
+	testCases := []struct {
+		name                string
+		olmCatalogPlacement hyperv1.OLMCatalogPlacement
+		expectedAddress     map[string]string
+		expectedImage       map[string]string
+	}{
+		{
+			name:                "When catalogs are placed in the management cluster it should point custom catalog sources to the control plane services",
+			olmCatalogPlacement: hyperv1.ManagementOLMCatalogPlacement,
+			expectedAddress: map[string]string{
+				"redhat-operators": "redhat-operators:50051",
+				"mirrored":         "catalog-mirrored:50051",
+			},
+		},
+		{
+			name:                "When catalogs are placed in the guest cluster it should serve custom catalog sources from their images",
+			olmCatalogPlacement: hyperv1.GuestOLMCatalogPlacement,
+			expectedImage: map[string]string{
+				"redhat-operators": "mirror.example.com/redhat/redhat-operator-index:v4.16",
+				"mirrored":         "mirror.example.com/mirrored-index:latest",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			hcp.Spec.OLMCatalogPlacement = tc.olmCatalogPlacement
+			hcp.Spec.OLMCatalogs = &hyperv1.OLMCatalogs{
+				DisableDefaultCatalogs: true,
+				CatalogSources: []hyperv1.OLMCatalogSource{
+					{Name: "redhat-operators", Image: "mirror.example.com/redhat/redhat-operator-index:v4.16"},
+					{Name: "mirrored", Image: "mirror.example.com/mirrored-index:latest", DisplayName: "Mirrored"},
+				},
+			}
+			r := &reconciler{
+				client:                 fake.NewClientBuilder().WithScheme(api.Scheme).Build(),
+				cpClient:               fake.NewClientBuilder().WithScheme(api.Scheme).WithObjects(rootCA, fakeCPService, hcp).Build(),
+				CreateOrUpdateProvider: &simpleCreateOrUpdater{},
+				rootCA:                 "fake",
+			}
+			g.Expect(r.reconcileOLM(ctx, hcp)).To(BeEmpty())
+
+			for _, disabled := range []string{"certified-operators", "community-operators", "redhat-marketplace"} {
+				err := r.client.Get(ctx, client.ObjectKey{Namespace: "openshift-marketplace", Name: disabled}, &operatorsv1alpha1.CatalogSource{})
+				g.Expect(errors.IsNotFound(err)).To(BeTrue(), "default catalog %s should be disabled", disabled)
+			}
+			for _, name := range []string{"redhat-operators", "mirrored"} {
+				cs := &operatorsv1alpha1.CatalogSource{}
+				g.Expect(r.client.Get(ctx, client.ObjectKey{Namespace: "openshift-marketplace", Name: name}, cs)).To(Succeed())
+				g.Expect(cs.Spec.Address).To(Equal(tc.expectedAddress[name]))
+				g.Expect(cs.Spec.Image).To(Equal(tc.expectedImage[name]))
+			}
+
+			// Removing a custom catalog source removes its CatalogSource.
+			hcp.Spec.OLMCatalogs.CatalogSources = hcp.Spec.OLMCatalogs.CatalogSources[:1]
+			g.Expect(r.reconcileOLM(ctx, hcp)).To(BeEmpty())
+			err := r.client.Get(ctx, client.ObjectKey{Namespace: "openshift-marketplace", Name: "mirrored"}, &operatorsv1alpha1.CatalogSource{})
+			g.Expect(errors.IsNotFound(err)).To(BeTrue())
+		})
+	}
+}
+
 type simpleCreateOrUpdater struct{}
 
 func (*simpleCreateOrUpdater) CreateOrUpdate(ctx context.Context, c client.Client, obj client.Object, f controllerutil.MutateFn) (controllerutil.OperationResult, error) {
@@ -536,6 +611,52 @@ func TestReconcileUserCertCABundle(t *testing.T) {
 	}
 }
 
+func TestReconcileImageAdditionalTrustedCAConfigMap(t *testing.T) {
+	ctx := context.Background()
+	testNamespace := "master-cluster1"
+	registryCA := func(name string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace},
+			Data:       map[string]string{"registry.example.com..5000": "acertxyz"},
+		}
+	}
+	hcpWithImageConfig := func(additionalTrustedCA string) *hyperv1.HostedControlPlane {
+		hcp := fakeHCP()
+		hcp.Namespace = testNamespace
+		hcp.Spec.Configuration = &hyperv1.ClusterConfiguration{
+			Image: &configv1.ImageSpec{
+				AdditionalTrustedCA: configv1.ConfigMapNameReference{Name: additionalTrustedCA},
+			},
+		}
+		return hcp
+	}
+
+	g := NewWithT(t)
+	hcp := hcpWithImageConfig("registry-ca")
+	r := &reconciler{
+		client:                 fake.NewClientBuilder().WithScheme(api.Scheme).Build(),
+		cpClient:               fake.NewClientBuilder().WithScheme(api.Scheme).WithObjects(registryCA("registry-ca"), registryCA("other-registry-ca"), hcp).Build(),
+		CreateOrUpdateProvider: &simpleCreateOrUpdater{},
+	}
+
+	// When additionalTrustedCA is set it should be copied to the guest openshift-config namespace.
+	g.Expect(r.reconcileImageAdditionalTrustedCAConfigMap(ctx, hcp)).To(Succeed())
+	guestCA := manifests.ImageAdditionalTrustedCAConfigMap("registry-ca")
+	g.Expect(r.client.Get(ctx, client.ObjectKeyFromObject(guestCA), guestCA)).To(Succeed())
+	g.Expect(guestCA.Data).To(Equal(registryCA("registry-ca").Data))
+
+	image := globalconfig.ImageConfig()
+	globalconfig.ReconcileImageConfig(image, hcp)
+	g.Expect(r.client.Create(ctx, image)).To(Succeed())
+
+	// When additionalTrustedCA changes it should copy the new ConfigMap and delete the old one.
+	hcp = hcpWithImageConfig("other-registry-ca")
+	g.Expect(r.reconcileImageAdditionalTrustedCAConfigMap(ctx, hcp)).To(Succeed())
+	g.Expect(r.client.Get(ctx, client.ObjectKeyFromObject(manifests.ImageAdditionalTrustedCAConfigMap("other-registry-ca")), &corev1.ConfigMap{})).To(Succeed())
+	err := r.client.Get(ctx, client.ObjectKeyFromObject(guestCA), &corev1.ConfigMap{})
+	g.Expect(errors.IsNotFound(err)).To(BeTrue())
+}
+
 var _ manifestReconciler = manifestAndReconcile[*rbacv1.ClusterRole]{}
 
 func TestDestroyCloudResources(t *testing.T) {
@@ -919,3 +1040,83 @@ func TestReconcileClusterVersion(t *testing.T) {
 	g.Expect(clusterVersion.Spec.Overrides).To(Equal(testOverrides))
 	g.Expect(clusterVersion.Spec.Channel).To(BeEmpty())
 }
+
+func TestReconcileStorageClasses(t *testing.T) {
+	ctx := context.Background()
+	g := NewWithT(t)
+
+	operatorStorageClass := &storagev1.StorageClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "gp3-csi",
+			Annotations: map[string]string{storage.DefaultStorageClassAnnotation: "true"},
+		},
+		Provisioner: string(operatorv1.AWSEBSCSIDriver),
+	}
+	operatorVolumeSnapshotClass := &snapshotv1.VolumeSnapshotClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "csi-aws-vsc",
+			Annotations: map[string]string{storage.DefaultVolumeSnapshotClassAnnotation: "true"},
+		},
+		Driver:         string(operatorv1.AWSEBSCSIDriver),
+		DeletionPolicy: snapshotv1.VolumeSnapshotContentDelete,
+	}
+	hcp := fakeHCP()
+	hcp.Spec.Platform.Type = hyperv1.AWSPlatform
+	hcp.Spec.Storage = &hyperv1.ClusterStorageSpec{
+		StorageClasses: []hyperv1.CSIStorageClass{
+			{
+				Name:          "encrypted",
+				Default:       true,
+				Parameters:    map[string]string{"type": "gp3", "encrypted": "true"},
+				ReclaimPolicy: ptr.To(corev1.PersistentVolumeReclaimRetain),
+			},
+			{
+				Name:       "io2",
+				Parameters: map[string]string{"type": "io2"},
+			},
+		},
+		VolumeSnapshotClasses: []hyperv1.CSIVolumeSnapshotClass{
+			{
+				Name:           "retained",
+				Default:        true,
+				DeletionPolicy: "Retain",
+			},
+		},
+	}
+	r := &reconciler{
+		client:                 fake.NewClientBuilder().WithScheme(api.Scheme).WithObjects(operatorStorageClass, operatorVolumeSnapshotClass).Build(),
+		CreateOrUpdateProvider: &simpleCreateOrUpdater{},
+	}
+
+	// When storage classes are configured it should create them and unmark the other defaults.
+	g.Expect(r.reconcileStorageClasses(ctx, hcp, operatorv1.AWSEBSCSIDriver)).To(BeEmpty())
+	encrypted := &storagev1.StorageClass{}
+	g.Expect(r.client.Get(ctx, client.ObjectKey{Name: "encrypted"}, encrypted)).To(Succeed())
+	g.Expect(encrypted.Provisioner).To(Equal(string(operatorv1.AWSEBSCSIDriver)))
+	g.Expect(encrypted.Parameters).To(Equal(map[string]string{"type": "gp3", "encrypted": "true"}))
+	g.Expect(*encrypted.ReclaimPolicy).To(Equal(corev1.PersistentVolumeReclaimRetain))
+	g.Expect(*encrypted.VolumeBindingMode).To(Equal(storagev1.VolumeBindingWaitForFirstConsumer))
+	g.Expect(encrypted.Annotations).To(HaveKeyWithValue(storage.DefaultStorageClassAnnotation, "true"))
+	io2 := &storagev1.StorageClass{}
+	g.Expect(r.client.Get(ctx, client.ObjectKey{Name: "io2"}, io2)).To(Succeed())
+	g.Expect(io2.Annotations).To(HaveKeyWithValue(storage.DefaultStorageClassAnnotation, "false"))
+	g.Expect(r.client.Get(ctx, client.ObjectKeyFromObject(operatorStorageClass), operatorStorageClass)).To(Succeed())
+	g.Expect(operatorStorageClass.Annotations).To(HaveKeyWithValue(storage.DefaultStorageClassAnnotation, "false"))
+
+	retained := &snapshotv1.VolumeSnapshotClass{}
+	g.Expect(r.client.Get(ctx, client.ObjectKey{Name: "retained"}, retained)).To(Succeed())
+	g.Expect(retained.Driver).To(Equal(string(operatorv1.AWSEBSCSIDriver)))
+	g.Expect(retained.DeletionPolicy).To(Equal(snapshotv1.VolumeSnapshotContentRetain))
+	g.Expect(r.client.Get(ctx, client.ObjectKeyFromObject(operatorVolumeSnapshotClass), operatorVolumeSnapshotClass)).To(Succeed())
+	g.Expect(operatorVolumeSnapshotClass.Annotations).To(HaveKeyWithValue(storage.DefaultVolumeSnapshotClassAnnotation, "false"))
+
+	// When storage classes are removed from the spec it should delete them and leave the others alone.
+	hcp.Spec.Storage = nil
+	g.Expect(r.reconcileStorageClasses(ctx, hcp, operatorv1.AWSEBSCSIDriver)).To(BeEmpty())
+	err := r.client.Get(ctx, client.ObjectKeyFromObject(encrypted), encrypted)
+	g.Expect(errors.IsNotFound(err)).To(BeTrue())
+	err = r.client.Get(ctx, client.ObjectKeyFromObject(retained), retained)
+	g.Expect(errors.IsNotFound(err)).To(BeTrue())
+	g.Expect(r.client.Get(ctx, client.ObjectKeyFromObject(operatorStorageClass), operatorStorageClass)).To(Succeed())
+	g.Expect(r.client.Get(ctx, client.ObjectKeyFromObject(operatorVolumeSnapshotClass), operatorVolumeSnapshotClass)).To(Succeed())
+}
@@ -0,0 +1,194 @@
+package sshkey
+
+import (
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/support/util"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	SSHKeySecretKey = "id_rsa.pub"
+
+	// sshKeyHashAnnotation is set on the syncer Pods so that they are rolled out
+	// when the SSH key changes, which tells when all the Nodes have the new key.
+	sshKeyHashAnnotation = "hypershift.openshift.io/ssh-key-hash"
+
+	// syncScript writes the SSH key where Ignition and the MCD write it for the
+	// core user, authorized_keys.d/ignition on RHCOS 9 and authorized_keys before.
+	syncScript = `#!/bin/bash
+set -euo pipefail
+source=/etc/ssh-key/` + SSHKeySecretKey + `
+dir=/host/home/core/.ssh
+target="${dir}/authorized_keys"
+if [[ -d "${dir}/authorized_keys.d" ]]; then
+  target="${dir}/authorized_keys.d/ignition"
+fi
+while true; do
+  if ! cmp -s "${source}" "${target}"; then
+    cp "${source}" "${target}.tmp"
+    chmod 0600 "${target}.tmp"
+    chown --reference="${dir}" "${target}.tmp"
+    mv "${target}.tmp" "${target}"
+    echo "$(date -u +%Y-%m-%dT%H:%M:%SZ) Updated node SSH key"
+  fi
+  touch /tmp/synced
+  sleep 30
+done
+`
+)
+
+var (
+	volumeMounts = util.PodVolumeMounts{
+		syncerContainer().Name: util.ContainerVolumeMounts{
+			sshKeyVolume().Name:  "/etc/ssh-key",
+			coreSSHVolume().Name: "/host/home/core/.ssh",
+		},
+	}
+	maxUnavailable = intstr.FromString("10%")
+)
+
+// ReconcileNodePoolSSHKeySecret copies the NodePool SSH key from the control
+// plane into the guest cluster.
+func ReconcileNodePoolSSHKeySecret(secret, source *corev1.Secret) {
+	if secret.Labels == nil {
+		secret.Labels = map[string]string{}
+	}
+	secret.Labels[hyperv1.NodePoolSSHKeyLabel] = "true"
+	secret.Labels[hyperv1.NodePoolLabel] = source.Labels[hyperv1.NodePoolLabel]
+	secret.Data = map[string][]byte{
+		SSHKeySecretKey: source.Data[SSHKeySecretKey],
+	}
+}
+
+// ReconcileSyncerDaemonSet reconciles the DaemonSet which writes the SSH key
+// onto the Nodes of a NodePool, so that SSH key changes are applied without
+// replacing the Nodes.
+func ReconcileSyncerDaemonSet(daemonset *appsv1.DaemonSet, nodePoolName, secretName, sshKeyHash, image string) {
+	labels := map[string]string{
+		"app":                 "ssh-key-syncer",
+		hyperv1.NodePoolLabel: nodePoolName,
+	}
+	if daemonset.Labels == nil {
+		daemonset.Labels = map[string]string{}
+	}
+	daemonset.Labels[hyperv1.NodePoolSSHKeyLabel] = "true"
+	daemonset.Labels[hyperv1.NodePoolLabel] = nodePoolName
+
+	daemonset.Spec = appsv1.DaemonSetSpec{
+		Selector: &metav1.LabelSelector{
+			MatchLabels: labels,
+		},
+		Template: corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: labels,
+				Annotations: map[string]string{
+					sshKeyHashAnnotation: sshKeyHash,
+				},
+			},
+			Spec: corev1.PodSpec{
+				NodeSelector: map[string]string{
+					hyperv1.NodePoolLabel: nodePoolName,
+				},
+				Tolerations: []corev1.Toleration{
+					{
+						Operator: corev1.TolerationOpExists,
+					},
+				},
+				PriorityClassName:            "system-node-critical",
+				AutomountServiceAccountToken: ptr.To(false),
+				Containers: []corev1.Container{
+					util.BuildContainer(syncerContainer(), buildSyncerContainer(image)),
+				},
+				Volumes: []corev1.Volume{
+					util.BuildVolume(sshKeyVolume(), buildSSHKeyVolume(secretName)),
+					util.BuildVolume(coreSSHVolume(), buildCoreSSHVolume),
+				},
+			},
+		},
+		UpdateStrategy: appsv1.DaemonSetUpdateStrategy{
+			Type: appsv1.RollingUpdateDaemonSetStrategyType,
+			RollingUpdate: &appsv1.RollingUpdateDaemonSet{
+				MaxUnavailable: &maxUnavailable,
+			},
+		},
+	}
+}
+
+// PropagatedSSHKeyHash returns the hash of the SSH key written onto all the
+// Nodes by the syncer DaemonSet, or an empty string if it is still rolling out.
+func PropagatedSSHKeyHash(daemonset *appsv1.DaemonSet) string {
+	status := daemonset.Status
+	if status.ObservedGeneration < daemonset.Generation ||
+		status.UpdatedNumberScheduled != status.DesiredNumberScheduled ||
+		status.NumberReady != status.DesiredNumberScheduled {
+		return ""
+	}
+	return daemonset.Spec.Template.Annotations[sshKeyHashAnnotation]
+}
+
+func syncerContainer() *corev1.Container {
+	return &corev1.Container{
+		Name: "ssh-key-syncer",
+	}
+}
+
+func sshKeyVolume() *corev1.Volume {
+	return &corev1.Volume{
+		Name: "ssh-key",
+	}
+}
+
+func coreSSHVolume() *corev1.Volume {
+	return &corev1.Volume{
+		Name: "core-ssh",
+	}
+}
+
+func buildSyncerContainer(image string) func(c *corev1.Container) {
+	return func(c *corev1.Container) {
+		c.Image = image
+		c.ImagePullPolicy = corev1.PullIfNotPresent
+		c.Command = []string{"/bin/bash", "-c", syncScript}
+		c.SecurityContext = &corev1.SecurityContext{
+			// Required to write into the home directory of the core user of the host.
+			Privileged: ptr.To(true),
+		}
+		// Ready once the SSH key has been written onto the Node.
+		c.ReadinessProbe = &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{
+				Exec: &corev1.ExecAction{
+					Command: []string{"cat", "/tmp/synced"},
+				},
+			},
+			PeriodSeconds: 5,
+		}
+		c.Resources = corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("5m"),
+				corev1.ResourceMemory: resource.MustParse("10Mi"),
+			},
+		}
+		c.VolumeMounts = volumeMounts.ContainerMounts(c.Name)
+	}
+}
+
+func buildSSHKeyVolume(secretName string) func(v *corev1.Volume) {
+	return func(v *corev1.Volume) {
+		v.Secret = &corev1.SecretVolumeSource{
+			SecretName:  secretName,
+			DefaultMode: ptr.To[int32](0400),
+		}
+	}
+}
+
+func buildCoreSSHVolume(v *corev1.Volume) {
+	v.HostPath = &corev1.HostPathVolumeSource{
+		Path: "/home/core/.ssh",
+		Type: ptr.To(corev1.HostPathDirectoryOrCreate),
+	}
+}
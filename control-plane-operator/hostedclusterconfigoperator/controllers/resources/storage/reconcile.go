@@ -1,9 +1,40 @@
 package storage
 
 import (
+	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v6/apis/volumesnapshot/v1"
 	operatorv1 "github.com/openshift/api/operator/v1"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/utils/ptr"
 )
 
+const (
+	// ManagedStorageClassLabel marks the StorageClasses and VolumeSnapshotClasses created from the storage spec of
+	// the HostedControlPlane, so that they're deleted once they're removed from it.
+	ManagedStorageClassLabel = "hypershift.openshift.io/managed-storage-class"
+
+	DefaultStorageClassAnnotation        = "storageclass.kubernetes.io/is-default-class"
+	DefaultVolumeSnapshotClassAnnotation = "snapshot.storage.kubernetes.io/is-default-class"
+)
+
+// PlatformCSIDriver returns the CSI driver of the platform deployed by the Cluster Storage Operator whose
+// StorageClasses can be configured with the storage spec of the HostedControlPlane.
+func PlatformCSIDriver(platform hyperv1.PlatformType) (operatorv1.CSIDriverName, bool) {
+	switch platform {
+	case hyperv1.AWSPlatform:
+		return operatorv1.AWSEBSCSIDriver, true
+	case hyperv1.AzurePlatform:
+		return operatorv1.AzureDiskCSIDriver, true
+	case hyperv1.IBMCloudPlatform:
+		return operatorv1.IBMVPCBlockCSIDriver, true
+	case hyperv1.PowerVSPlatform:
+		return operatorv1.IBMPowerVSBlockCSIDriver, true
+	default:
+		return "", false
+	}
+}
+
 func ReconcileOperatorSpec(spec *operatorv1.OperatorSpec) {
 	spec.LogLevel = operatorv1.Normal
 	spec.OperatorLogLevel = operatorv1.Normal
@@ -18,6 +49,59 @@ func ReconcileStorage(storage *operatorv1.Storage) {
 	ReconcileOperatorSpec(&storage.Spec.OperatorSpec)
 }
 
-func ReconcileClusterCSIDriver(driver *operatorv1.ClusterCSIDriver) {
+func ReconcileClusterCSIDriver(driver *operatorv1.ClusterCSIDriver, storage *hyperv1.ClusterStorageSpec) {
 	ReconcileOperatorSpec(&driver.Spec.OperatorSpec)
+	if storage != nil && storage.StorageClassState != "" {
+		driver.Spec.StorageClassState = operatorv1.StorageClassStateName(storage.StorageClassState)
+	}
+}
+
+// ReconcileStorageClass reconciles a StorageClass of the CSI driver from its spec. The parameters, reclaim policy
+// and volume binding mode of an existing StorageClass are immutable, so they're only set when it's created.
+func ReconcileStorageClass(sc *storagev1.StorageClass, driver operatorv1.CSIDriverName, spec hyperv1.CSIStorageClass) {
+	if sc.Labels == nil {
+		sc.Labels = map[string]string{}
+	}
+	sc.Labels[ManagedStorageClassLabel] = "true"
+	if sc.Annotations == nil {
+		sc.Annotations = map[string]string{}
+	}
+	sc.Annotations[DefaultStorageClassAnnotation] = boolString(spec.Default)
+	sc.AllowVolumeExpansion = ptr.To(ptr.Deref(spec.AllowVolumeExpansion, true))
+	if !sc.CreationTimestamp.IsZero() {
+		return
+	}
+	sc.Provisioner = string(driver)
+	sc.Parameters = spec.Parameters
+	sc.ReclaimPolicy = ptr.To(ptr.Deref(spec.ReclaimPolicy, corev1.PersistentVolumeReclaimDelete))
+	sc.VolumeBindingMode = ptr.To(ptr.Deref(spec.VolumeBindingMode, storagev1.VolumeBindingWaitForFirstConsumer))
+}
+
+// ReconcileVolumeSnapshotClass reconciles a VolumeSnapshotClass of the CSI driver from its spec. The parameters of
+// an existing VolumeSnapshotClass are immutable, so they're only set when it's created.
+func ReconcileVolumeSnapshotClass(vsc *snapshotv1.VolumeSnapshotClass, driver operatorv1.CSIDriverName, spec hyperv1.CSIVolumeSnapshotClass) {
+	if vsc.Labels == nil {
+		vsc.Labels = map[string]string{}
+	}
+	vsc.Labels[ManagedStorageClassLabel] = "true"
+	if vsc.Annotations == nil {
+		vsc.Annotations = map[string]string{}
+	}
+	vsc.Annotations[DefaultVolumeSnapshotClassAnnotation] = boolString(spec.Default)
+	vsc.DeletionPolicy = snapshotv1.VolumeSnapshotContentDelete
+	if spec.DeletionPolicy != "" {
+		vsc.DeletionPolicy = snapshotv1.DeletionPolicy(spec.DeletionPolicy)
+	}
+	if !vsc.CreationTimestamp.IsZero() {
+		return
+	}
+	vsc.Driver = string(driver)
+	vsc.Parameters = spec.Parameters
+}
+
+func boolString(b bool) string {
+	if b {
+		return "true"
+	}
+	return "false"
 }
@@ -25,9 +25,11 @@ import (
 	operatorv1 "github.com/openshift/api/operator/v1"
 	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
 	"github.com/openshift/hypershift/control-plane-operator/hostedclusterconfigoperator/api"
+	"github.com/openshift/hypershift/support/config"
 	"github.com/openshift/hypershift/support/labelenforcingclient"
 	"github.com/openshift/hypershift/support/releaseinfo"
 	"github.com/openshift/hypershift/support/upsert"
+	"github.com/openshift/hypershift/support/util"
 
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 )
@@ -64,6 +66,7 @@ type HostedClusterConfigOperatorConfig struct {
 	PlatformType                 hyperv1.PlatformType
 	ControllerFuncs              map[string]ControllerSetupFunc
 	ReleaseProvider              releaseinfo.Provider
+	ImageMirrorResolver          *util.ImageMirrorResolver
 	KonnectivityAddress          string
 	KonnectivityPort             int32
 	OAuthAddress                 string
@@ -93,6 +96,7 @@ func Mgr(cfg, cpConfig *rest.Config, namespace string) ctrl.Manager {
 		RenewDeadline:                 &renewDeadline,
 		RetryPeriod:                   &retryPeriod,
 		HealthProbeBindAddress:        ":6060",
+		PprofBindAddress:              os.Getenv(config.PprofBindAddressEnvVar),
 		Metrics: metricsserver.Options{
 			BindAddress: "0.0.0.0:8080",
 		},
@@ -9,6 +9,7 @@ import (
 
 	availabilityprober "github.com/openshift/hypershift/availability-prober"
 	"github.com/openshift/hypershift/control-plane-operator/controllers/awsprivatelink"
+	"github.com/openshift/hypershift/control-plane-operator/controllers/awsresourcetags"
 	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/manifests"
 	"github.com/openshift/hypershift/control-plane-operator/hostedclusterconfigoperator"
 	pkiconfig "github.com/openshift/hypershift/control-plane-pki-operator/config"
@@ -16,6 +17,7 @@ import (
 	etcdbackup "github.com/openshift/hypershift/etcd-backup"
 	etcddefrag "github.com/openshift/hypershift/etcd-defrag"
 	ignitionserver "github.com/openshift/hypershift/ignition-server/cmd"
+	kmsreencrypt "github.com/openshift/hypershift/kms-reencrypt"
 	konnectivitysocks5proxy "github.com/openshift/hypershift/konnectivity-socks5-proxy"
 	kubernetesdefaultproxy "github.com/openshift/hypershift/kubernetes-default-proxy"
 	"github.com/openshift/hypershift/pkg/version"
@@ -136,6 +138,7 @@ func defaultCommand() *cobra.Command {
 	cmd.AddCommand(kubernetesdefaultproxy.NewStartCommand())
 	cmd.AddCommand(dnsresolver.NewCommand())
 	cmd.AddCommand(etcdbackup.NewStartCommand())
+	cmd.AddCommand(kmsreencrypt.NewStartCommand())
 
 	return cmd
 
@@ -213,6 +216,7 @@ func NewStartCommand() *cobra.Command {
 			RenewDeadline:                 &renewDeadline,
 			RetryPeriod:                   &retryPeriod,
 			HealthProbeBindAddress:        healthProbeAddr,
+			PprofBindAddress:              os.Getenv(config.PprofBindAddressEnvVar),
 			Cache: cache.Options{
 				DefaultFieldSelector: fields.OneTermEqualSelector("metadata.namespace", namespace),
 			},
@@ -414,6 +418,7 @@ func NewStartCommand() *cobra.Command {
 			Client:                                  mgr.GetClient(),
 			ManagementClusterCapabilities:           mgmtClusterCaps,
 			ReleaseProvider:                         releaseProvider,
+			ImageMirrorResolver:                     util.NewImageMirrorResolver(imageRegistryOverrides),
 			EnableCIDebugOutput:                     enableCIDebugOutput,
 			OperateOnReleaseImage:                   os.Getenv("OPERATE_ON_RELEASE_IMAGE"),
 			DefaultIngressDomain:                    defaultIngressDomain,
@@ -425,6 +430,14 @@ func NewStartCommand() *cobra.Command {
 			os.Exit(1)
 		}
 
+		// AWS_SHARED_CREDENTIALS_FILE is only set in the operator deployment of AWS hosted control planes
+		if os.Getenv("AWS_SHARED_CREDENTIALS_FILE") != "" {
+			if err := (&awsresourcetags.AWSResourceTagsReconciler{}).SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to create controller", "controller", awsresourcetags.ControllerName)
+				os.Exit(1)
+			}
+		}
+
 		if mgmtClusterCaps.Has(capabilities.CapabilityRoute) {
 			controllerName := "PrivateKubeAPIServerServiceObserver"
 			if err := (&awsprivatelink.PrivateServiceObserver{
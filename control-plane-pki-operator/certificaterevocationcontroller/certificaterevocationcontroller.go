@@ -358,6 +358,9 @@ func (c *CertificateRevocationController) processCertificateRevocationRequest(ct
 	if err != nil {
 		return nil, false, err
 	}
+	if crr.Spec.SignerClass == string(certificates.KonnectivitySigner) {
+		return nil, false, nil // the konnectivity signer is revoked by the control-plane-operator, which manages it
+	}
 
 	for _, step := range []revocationStep{
 		// we haven't seen this CRR before, so choose a revocation timestamp
@@ -248,6 +248,16 @@ func TestCertificateRevocationController_processCertificateRevocationRequest(t *
 				},
 			},
 		},
+		{
+			name:         "konnectivity signer class is left to the control-plane-operator",
+			now:          revocationClock.Now,
+			crrNamespace: "crr-ns",
+			crrName:      "crr-name",
+			crr: &certificatesv1alpha1.CertificateRevocationRequest{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "crr-ns", Name: "crr-name"},
+				Spec:       certificatesv1alpha1.CertificateRevocationRequestSpec{SignerClass: string(certificates.KonnectivitySigner)},
+			},
+		},
 		{
 			name:         "current signer is copied if none exists",
 			now:          postRevocationClock.Now,
@@ -20,11 +20,14 @@ const (
 	CustomerBreakGlassSigner SignerClass = "customer-break-glass"
 	// SREBreakGlassSigner is the signer class used to mint break-glass credentials for SRE.
 	SREBreakGlassSigner SignerClass = "sre-break-glass"
+	// KonnectivitySigner is the signer class of the konnectivity server and agent certificates. Its signer is managed
+	// by the control-plane-operator, which also revokes it.
+	KonnectivitySigner SignerClass = "konnectivity"
 )
 
 func ValidSignerClass(input string) bool {
 	switch SignerClass(input) {
-	case CustomerBreakGlassSigner, SREBreakGlassSigner:
+	case CustomerBreakGlassSigner, SREBreakGlassSigner, KonnectivitySigner:
 		return true
 	default:
 		return false
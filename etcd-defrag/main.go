@@ -21,6 +21,9 @@ import (
 
 type Options struct {
 	Namespace string
+	// MemberFailureThreshold is how long an etcd member must have been failing before it is replaced.
+	// Zero disables member replacement.
+	MemberFailureThreshold time.Duration
 }
 
 func NewStartCommand() *cobra.Command {
@@ -30,10 +33,12 @@ func NewStartCommand() *cobra.Command {
 	}
 
 	opts := Options{
-		Namespace: "",
+		Namespace:              "",
+		MemberFailureThreshold: defaultMemberFailureThreshold,
 	}
 
 	cmd.Flags().StringVar(&opts.Namespace, "namespace", os.Getenv("MY_NAMESPACE"), "The namespace this operator lives in (required)")
+	cmd.Flags().DurationVar(&opts.MemberFailureThreshold, "member-failure-threshold", opts.MemberFailureThreshold, "How long an etcd member must have been failing before it is replaced. Zero disables member replacement.")
 
 	cmd.Run = func(cmd *cobra.Command, args []string) {
 		ctx, cancel := context.WithCancel(context.Background())
@@ -93,6 +98,19 @@ func run(ctx context.Context, opts Options) error {
 		return fmt.Errorf("unable to create controller: %s: %w", controllerName, err)
 	}
 
+	if opts.MemberFailureThreshold > 0 {
+		controllerName := "EtcdMemberReplacementController"
+		if err := (&MemberReplacementController{
+			Client:           mgr.GetClient(),
+			log:              logger.WithName(controllerName),
+			ControllerName:   controllerName,
+			Namespace:        opts.Namespace,
+			FailureThreshold: opts.MemberFailureThreshold,
+		}).SetupWithManager(ctx, mgr); err != nil {
+			return fmt.Errorf("unable to create controller: %s: %w", controllerName, err)
+		}
+	}
+
 	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
 		return fmt.Errorf("problem running manager: %w", err)
 	}
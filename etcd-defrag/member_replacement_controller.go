@@ -0,0 +1,285 @@
+package etcddefrag
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"go.etcd.io/etcd/api/v3/etcdserverpb"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/pkg/etcdcli"
+	"github.com/openshift/library-go/pkg/operator/events"
+)
+
+const (
+	etcdStatefulSetName = "etcd"
+	etcdDataVolumeName  = "data"
+
+	memberReplacementRequeueDuration = time.Minute
+	defaultMemberFailureThreshold    = 10 * time.Minute
+)
+
+// MemberReplacementController replaces managed etcd members which have permanently failed, e.g. because their PVC is
+// corrupted or lost or because their zone is lost. A failed member is removed from the etcd cluster, and its PVC and
+// pod are deleted, so the StatefulSet provisions a replacement PVC and the reset-member init container of the new pod
+// adds it back as a new member. Members are only replaced while the other members keep quorum, one at a time.
+// The EtcdQuorumAtRisk condition of the HostedControlPlane reports failed members and replacements.
+type MemberReplacementController struct {
+	client.Client
+	log logr.Logger
+
+	ControllerName string
+	Namespace      string
+	// FailureThreshold is how long a member must have been failing before it is replaced.
+	FailureThreshold time.Duration
+
+	etcdClient etcdcli.EtcdClient
+}
+
+// memberState is the observed state of an etcd member, of its pod and of its PVC.
+type memberState struct {
+	name    string
+	member  *etcdserverpb.Member
+	healthy bool
+	pod     *corev1.Pod
+	pvc     *corev1.PersistentVolumeClaim
+}
+
+type memberReplacementTicker struct {
+	controller *MemberReplacementController
+}
+
+func (m *memberReplacementTicker) Start(ctx context.Context) error {
+	ticker := time.NewTicker(memberReplacementRequeueDuration)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := m.controller.runMemberReplacement(ctx); err != nil {
+				m.controller.log.Error(err, "failed to run member replacement")
+			}
+		}
+	}
+}
+
+func (r *MemberReplacementController) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
+	// Members are checked through the etcd client Service, since the local member might be the failed one.
+	endpointsFunc := func() ([]string, error) {
+		return []string{"https://etcd-client:2379"}, nil
+	}
+	r.etcdClient = etcdcli.NewEtcdClient(endpointsFunc, events.NewLoggingEventRecorder(r.ControllerName))
+	if r.FailureThreshold == 0 {
+		r.FailureThreshold = defaultMemberFailureThreshold
+	}
+
+	if err := mgr.Add(&memberReplacementTicker{controller: r}); err != nil {
+		return fmt.Errorf("failed to add member replacement ticker runnable to manager: %w", err)
+	}
+	return nil
+}
+
+func (r *MemberReplacementController) runMemberReplacement(ctx context.Context) error {
+	states, err := r.memberStates(ctx)
+	if err != nil {
+		return err
+	}
+
+	failed, condition := planMemberReplacement(states, time.Now(), r.FailureThreshold)
+	if err := r.setCondition(ctx, condition); err != nil {
+		return err
+	}
+	if failed == nil {
+		return nil
+	}
+
+	r.log.Info("Replacing failed etcd member", "member", failed.name)
+	if failed.member != nil {
+		if err := r.etcdClient.MemberRemove(ctx, failed.member.ID); err != nil {
+			return fmt.Errorf("failed to remove etcd member %s: %w", failed.name, err)
+		}
+	}
+	if failed.pvc != nil {
+		if err := r.Delete(ctx, failed.pvc); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete PVC %s: %w", failed.pvc.Name, err)
+		}
+	}
+	if failed.pod != nil {
+		if err := r.Delete(ctx, failed.pod); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete pod %s: %w", failed.pod.Name, err)
+		}
+	}
+	return nil
+}
+
+// memberStates returns the state of every member of the etcd StatefulSet.
+func (r *MemberReplacementController) memberStates(ctx context.Context) ([]memberState, error) {
+	statefulSet := &appsv1.StatefulSet{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: r.Namespace, Name: etcdStatefulSetName}, statefulSet); err != nil {
+		return nil, fmt.Errorf("failed to get etcd StatefulSet: %w", err)
+	}
+
+	memberHealth, err := r.etcdClient.MemberHealth(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check etcd member health: %w", err)
+	}
+
+	var states []memberState
+	for i := 0; i < int(ptr.Deref(statefulSet.Spec.Replicas, 0)); i++ {
+		state := memberState{name: fmt.Sprintf("%s-%d", etcdStatefulSetName, i)}
+		for _, check := range memberHealth {
+			if memberMatches(check.Member, state.name) {
+				state.member = check.Member
+				state.healthy = check.Healthy
+			}
+		}
+
+		pod := &corev1.Pod{}
+		if err := r.Get(ctx, client.ObjectKey{Namespace: r.Namespace, Name: state.name}, pod); err == nil {
+			state.pod = pod
+		} else if !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get pod %s: %w", state.name, err)
+		}
+
+		pvc := &corev1.PersistentVolumeClaim{}
+		if err := r.Get(ctx, client.ObjectKey{Namespace: r.Namespace, Name: fmt.Sprintf("%s-%s", etcdDataVolumeName, state.name)}, pvc); err == nil {
+			state.pvc = pvc
+		} else if !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get PVC of %s: %w", state.name, err)
+		}
+		states = append(states, state)
+	}
+	return states, nil
+}
+
+// memberMatches returns whether the member runs in the pod with the given name. Members which have not started yet
+// have no name, so they are matched by their peer URL.
+func memberMatches(member *etcdserverpb.Member, podName string) bool {
+	if member.Name != "" {
+		return member.Name == podName
+	}
+	for _, peerURL := range member.PeerURLs {
+		if strings.HasPrefix(peerURL, fmt.Sprintf("https://%s.", podName)) {
+			return true
+		}
+	}
+	return false
+}
+
+// failingSince returns since when the member has been failing, or nil if it is not failing.
+func failingSince(state memberState) *time.Time {
+	if state.pvc != nil && state.pvc.Status.Phase == corev1.ClaimLost {
+		return ptr.To(state.pvc.CreationTimestamp.Time)
+	}
+	if state.pod == nil || state.pod.DeletionTimestamp != nil {
+		// The StatefulSet is recreating the pod.
+		return nil
+	}
+	for _, condition := range state.pod.Status.Conditions {
+		if condition.Type == corev1.PodScheduled && condition.Status == corev1.ConditionFalse && condition.Reason == corev1.PodReasonUnschedulable {
+			// e.g. the volume of the member is bound to a lost zone.
+			return ptr.To(condition.LastTransitionTime.Time)
+		}
+	}
+	if state.healthy {
+		return nil
+	}
+	for _, condition := range state.pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			if condition.Status == corev1.ConditionTrue {
+				return nil
+			}
+			return ptr.To(condition.LastTransitionTime.Time)
+		}
+	}
+	return ptr.To(state.pod.CreationTimestamp.Time)
+}
+
+// planMemberReplacement returns the member to replace, if any, and the EtcdQuorumAtRisk condition.
+// A member is replaced once it has been failing for longer than the threshold, as long as the healthy members
+// keep quorum. Only the first permanently failed member is replaced, the next ones are replaced once it has rejoined.
+func planMemberReplacement(states []memberState, now time.Time, threshold time.Duration) (*memberState, metav1.Condition) {
+	condition := metav1.Condition{
+		Type:    string(hyperv1.EtcdQuorumAtRisk),
+		Status:  metav1.ConditionFalse,
+		Reason:  hyperv1.AsExpectedReason,
+		Message: "All etcd members are healthy",
+	}
+
+	var failing, permanentlyFailed []string
+	failingIndex := map[string]int{}
+	healthyVotingMembers := 0
+	for i, state := range states {
+		if state.member != nil && state.healthy && !state.member.IsLearner {
+			healthyVotingMembers++
+		}
+		since := failingSince(state)
+		if since == nil {
+			continue
+		}
+		failing = append(failing, state.name)
+		failingIndex[state.name] = i
+		if now.Sub(*since) >= threshold {
+			permanentlyFailed = append(permanentlyFailed, state.name)
+		}
+	}
+	if len(failing) == 0 {
+		return nil, condition
+	}
+	sort.Strings(failing)
+	sort.Strings(permanentlyFailed)
+
+	condition.Status = metav1.ConditionTrue
+	quorum := len(states)/2 + 1
+	if healthyVotingMembers < quorum {
+		condition.Reason = hyperv1.EtcdQuorumLostReason
+		condition.Message = fmt.Sprintf("Only %d of %d etcd members are healthy, failed members %s can't be replaced without quorum. Etcd must be restored from a backup",
+			healthyVotingMembers, len(states), strings.Join(failing, ", "))
+		return nil, condition
+	}
+	if len(permanentlyFailed) == 0 {
+		condition.Reason = hyperv1.EtcdMemberUnhealthyReason
+		condition.Message = fmt.Sprintf("Etcd members %s are unhealthy, they will be replaced if they don't recover within %s",
+			strings.Join(failing, ", "), threshold)
+		return nil, condition
+	}
+
+	failed := states[failingIndex[permanentlyFailed[0]]]
+	condition.Reason = hyperv1.EtcdMemberReplacementInProgressReason
+	condition.Message = fmt.Sprintf("Replacing failed etcd member %s, the failure of another member until it rejoins would lose quorum", failed.name)
+	return &failed, condition
+}
+
+func (r *MemberReplacementController) setCondition(ctx context.Context, condition metav1.Condition) error {
+	hcpList := &hyperv1.HostedControlPlaneList{}
+	if err := r.List(ctx, hcpList, client.InNamespace(r.Namespace)); err != nil {
+		return fmt.Errorf("failed to list HostedControlPlanes: %w", err)
+	}
+	if len(hcpList.Items) == 0 {
+		return fmt.Errorf("no HostedControlPlane found in namespace %s", r.Namespace)
+	}
+	hcp := &hcpList.Items[0]
+
+	original := hcp.DeepCopy()
+	condition.ObservedGeneration = hcp.Generation
+	if !meta.SetStatusCondition(&hcp.Status.Conditions, condition) {
+		return nil
+	}
+	if err := r.Status().Patch(ctx, hcp, client.MergeFromWithOptions(original, client.MergeFromWithOptimisticLock{})); err != nil {
+		return fmt.Errorf("failed to set %s condition: %w", condition.Type, err)
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package etcddefrag
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"go.etcd.io/etcd/api/v3/etcdserverpb"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+)
+
+func TestMemberMatches(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(memberMatches(&etcdserverpb.Member{Name: "etcd-1"}, "etcd-1")).To(BeTrue())
+	g.Expect(memberMatches(&etcdserverpb.Member{Name: "etcd-1"}, "etcd-0")).To(BeFalse())
+	g.Expect(memberMatches(&etcdserverpb.Member{PeerURLs: []string{"https://etcd-1.etcd-discovery.ns.svc:2380"}}, "etcd-1")).To(BeTrue())
+	g.Expect(memberMatches(&etcdserverpb.Member{PeerURLs: []string{"https://etcd-1.etcd-discovery.ns.svc:2380"}}, "etcd-0")).To(BeFalse())
+}
+
+func TestPlanMemberReplacement(t *testing.T) {
+	now := time.Now()
+	threshold := 10 * time.Minute
+	longAgo := metav1.NewTime(now.Add(-time.Hour))
+	recently := metav1.NewTime(now.Add(-time.Minute))
+
+	healthy := func(name string) memberState {
+		return memberState{
+			name:    name,
+			member:  &etcdserverpb.Member{Name: name},
+			healthy: true,
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Status: corev1.PodStatus{Conditions: []corev1.PodCondition{
+					{Type: corev1.PodReady, Status: corev1.ConditionTrue, LastTransitionTime: longAgo},
+				}},
+			},
+			pvc: &corev1.PersistentVolumeClaim{Status: corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimBound}},
+		}
+	}
+	notReady := func(name string, since metav1.Time) memberState {
+		state := healthy(name)
+		state.healthy = false
+		state.pod.Status.Conditions = []corev1.PodCondition{
+			{Type: corev1.PodReady, Status: corev1.ConditionFalse, LastTransitionTime: since},
+		}
+		return state
+	}
+	unschedulable := func(name string, since metav1.Time) memberState {
+		state := notReady(name, since)
+		state.pod.Status.Conditions = []corev1.PodCondition{
+			{Type: corev1.PodScheduled, Status: corev1.ConditionFalse, Reason: corev1.PodReasonUnschedulable, LastTransitionTime: since},
+		}
+		return state
+	}
+	lostVolume := func(name string) memberState {
+		state := notReady(name, recently)
+		state.pvc.CreationTimestamp = longAgo
+		state.pvc.Status.Phase = corev1.ClaimLost
+		return state
+	}
+
+	testCases := []struct {
+		name           string
+		states         []memberState
+		expectedMember string
+		expectedStatus metav1.ConditionStatus
+		expectedReason string
+	}{
+		{
+			name:           "When all members are healthy it should not replace any member",
+			states:         []memberState{healthy("etcd-0"), healthy("etcd-1"), healthy("etcd-2")},
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: hyperv1.AsExpectedReason,
+		},
+		{
+			name:           "When a member has recently failed it should report quorum at risk without replacing it",
+			states:         []memberState{healthy("etcd-0"), notReady("etcd-1", recently), healthy("etcd-2")},
+			expectedStatus: metav1.ConditionTrue,
+			expectedReason: hyperv1.EtcdMemberUnhealthyReason,
+		},
+		{
+			name:           "When a member has failed for longer than the threshold it should replace it",
+			states:         []memberState{healthy("etcd-0"), notReady("etcd-1", longAgo), healthy("etcd-2")},
+			expectedMember: "etcd-1",
+			expectedStatus: metav1.ConditionTrue,
+			expectedReason: hyperv1.EtcdMemberReplacementInProgressReason,
+		},
+		{
+			name:           "When the pod of a member is unschedulable for longer than the threshold it should replace it",
+			states:         []memberState{unschedulable("etcd-0", longAgo), healthy("etcd-1"), healthy("etcd-2")},
+			expectedMember: "etcd-0",
+			expectedStatus: metav1.ConditionTrue,
+			expectedReason: hyperv1.EtcdMemberReplacementInProgressReason,
+		},
+		{
+			name:           "When the volume of a member is lost it should replace it",
+			states:         []memberState{healthy("etcd-0"), healthy("etcd-1"), lostVolume("etcd-2")},
+			expectedMember: "etcd-2",
+			expectedStatus: metav1.ConditionTrue,
+			expectedReason: hyperv1.EtcdMemberReplacementInProgressReason,
+		},
+		{
+			name:           "When quorum is lost it should not replace any member",
+			states:         []memberState{healthy("etcd-0"), notReady("etcd-1", longAgo), notReady("etcd-2", longAgo)},
+			expectedStatus: metav1.ConditionTrue,
+			expectedReason: hyperv1.EtcdQuorumLostReason,
+		},
+		{
+			name: "When the pod of a member is being recreated it should not replace it",
+			states: func() []memberState {
+				states := []memberState{healthy("etcd-0"), healthy("etcd-1"), notReady("etcd-2", longAgo)}
+				states[2].pod = nil
+				return states
+			}(),
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: hyperv1.AsExpectedReason,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			failed, condition := planMemberReplacement(tc.states, now, threshold)
+			if tc.expectedMember == "" {
+				g.Expect(failed).To(BeNil())
+			} else {
+				g.Expect(failed).ToNot(BeNil())
+				g.Expect(failed.name).To(Equal(tc.expectedMember))
+			}
+			g.Expect(condition.Type).To(Equal(string(hyperv1.EtcdQuorumAtRisk)))
+			g.Expect(condition.Status).To(Equal(tc.expectedStatus))
+			g.Expect(condition.Reason).To(Equal(tc.expectedReason))
+		})
+	}
+}
@@ -77,8 +77,11 @@ type ExampleOptions struct {
 	AutoRepair                       bool
 	EtcdStorageClass                 string
 	ExternalDNSDomain                string
+	ClusterDNSDomain                 string
+	DNSPublishing                    hyperv1.DNSPublishingMode
 	Arch                             string
 	PausedUntil                      string
+	Lifetime                         time.Duration
 	OLMCatalogPlacement              hyperv1.OLMCatalogPlacement
 	OperatorHub                      *configv1.OperatorHubSpec
 	AWS                              *ExampleAWSOptions
@@ -163,9 +166,10 @@ func (o ExampleOptions) Resources() *ExampleResources {
 					},
 					Zone: o.AWS.Zones[0].Name,
 				},
-				ResourceTags:   o.AWS.ResourceTags,
-				MultiArch:      o.AWS.MultiArch,
-				EndpointAccess: endpointAccess,
+				ResourceTags:     o.AWS.ResourceTags,
+				MultiArch:        o.AWS.MultiArch,
+				EndpointAccess:   endpointAccess,
+				ServiceEndpoints: o.AWS.ServiceEndpoints,
 			},
 		}
 
@@ -247,7 +251,7 @@ func (o ExampleOptions) Resources() *ExampleResources {
 			},
 		}
 		agentResources := &ExampleAgentResources{
-			&rbacv1.Role{
+			CAPIProviderAgentRole: &rbacv1.Role{
 				TypeMeta: metav1.TypeMeta{
 					Kind:       "Role",
 					APIVersion: rbacv1.SchemeGroupVersion.String(),
@@ -265,6 +269,18 @@ func (o ExampleOptions) Resources() *ExampleResources {
 				},
 			},
 		}
+		if o.Agent.NeedsInfraEnv() {
+			agentResources.PullSecret = o.Agent.pullSecret(o.Name, o.PullSecret)
+			agentResources.NMStateConfigs = o.Agent.nmStateConfigs(o.Name)
+			agentResources.InfraEnv = o.Agent.infraEnv(o.Name, agentResources.PullSecret.Name, strings.TrimSpace(string(o.SSHPublicKey)), o.Arch)
+		}
+		if o.Agent.HTTPProxy != "" || o.Agent.HTTPSProxy != "" {
+			proxyConfig = &configv1.ProxySpec{
+				HTTPProxy:  o.Agent.HTTPProxy,
+				HTTPSProxy: o.Agent.HTTPSProxy,
+				NoProxy:    o.Agent.NoProxy,
+			}
+		}
 		resources = agentResources.AsObjects()
 		services = getServicePublishingStrategyMappingByAPIServerAddress(o.Agent.APIServerAddress, o.NetworkType)
 	case o.Kubevirt != nil:
@@ -440,6 +456,13 @@ func (o ExampleOptions) Resources() *ExampleResources {
 				}
 			}
 		}
+		if o.Azure.APIServerCustomDomain != nil {
+			for i, svc := range services {
+				if svc.Service == hyperv1.APIServer && svc.Type == hyperv1.LoadBalancer {
+					services[i].CustomDomain = o.Azure.APIServerCustomDomain
+				}
+			}
+		}
 
 	case o.PowerVS != nil:
 		resources = o.PowerVS.Resources.AsObjects()
@@ -529,9 +552,11 @@ func (o ExampleOptions) Resources() *ExampleResources {
 			SSHKey:     sshKeyReference,
 			FIPS:       o.FIPS,
 			DNS: hyperv1.DNSSpec{
-				BaseDomain:    o.BaseDomain,
-				PublicZoneID:  o.PublicZoneID,
-				PrivateZoneID: o.PrivateZoneID,
+				BaseDomain:       o.BaseDomain,
+				PublicZoneID:     o.PublicZoneID,
+				PrivateZoneID:    o.PrivateZoneID,
+				ClusterDNSDomain: o.ClusterDNSDomain,
+				Publishing:       o.DNSPublishing,
 			},
 			ControllerAvailabilityPolicy:     o.ControlPlaneAvailabilityPolicy,
 			InfrastructureAvailabilityPolicy: o.InfrastructureAvailabilityPolicy,
@@ -543,6 +568,10 @@ func (o ExampleOptions) Resources() *ExampleResources {
 		cluster.Spec.PausedUntil = &o.PausedUntil
 	}
 
+	if o.Lifetime > 0 {
+		cluster.Spec.Lifetime = &hyperv1.HostedClusterLifetime{TTL: metav1.Duration{Duration: o.Lifetime}}
+	}
+
 	if cluster.Spec.Configuration == nil {
 		cluster.Spec.Configuration = &hyperv1.ClusterConfiguration{}
 	}
@@ -555,6 +584,11 @@ func (o ExampleOptions) Resources() *ExampleResources {
 		cluster.Spec.OLMCatalogPlacement = hyperv1.OLMCatalogPlacement(o.OLMCatalogPlacement)
 	}
 
+	if o.DNSPublishing == hyperv1.InternalDNSPublishing {
+		// no records are created in the public zone
+		cluster.Spec.DNS.PublicZoneID = ""
+	}
+
 	if o.BaseDomainPrefix == "none" {
 		// set empty prefix explicitly
 		cluster.Spec.DNS.BaseDomainPrefix = pointer.String("")
@@ -652,13 +686,13 @@ func (o ExampleOptions) Resources() *ExampleResources {
 	var nodePools []*hyperv1.NodePool
 	switch cluster.Spec.Platform.Type {
 	case hyperv1.AWSPlatform:
-		for _, zone := range o.AWS.Zones {
-			nodePool := defaultNodePool(fmt.Sprintf("%s-%s", cluster.Name, zone.Name))
+		awsNodePool := func(name string, zone ExampleAWSOptionsZones, instanceType string) *hyperv1.NodePool {
+			nodePool := defaultNodePool(name)
 			if nodePool.Spec.Management.UpgradeType == "" {
 				nodePool.Spec.Management.UpgradeType = hyperv1.UpgradeTypeReplace
 			}
 			nodePool.Spec.Platform.AWS = &hyperv1.AWSNodePoolPlatform{
-				InstanceType:    o.AWS.InstanceType,
+				InstanceType:    instanceType,
 				InstanceProfile: o.AWS.InstanceProfile,
 				Subnet: hyperv1.AWSResourceReference{
 					ID: zone.SubnetID,
@@ -670,7 +704,16 @@ func (o ExampleOptions) Resources() *ExampleResources {
 					EncryptionKey: o.AWS.RootVolumeEncryptionKey,
 				},
 			}
-			nodePools = append(nodePools, nodePool)
+			return nodePool
+		}
+		for _, zone := range o.AWS.Zones {
+			nodePools = append(nodePools, awsNodePool(fmt.Sprintf("%s-%s", cluster.Name, zone.Name), zone, o.AWS.InstanceType))
+			// The AMIs of the NodePools are resolved for their architecture by the NodePool controller.
+			for _, arch := range o.AWS.AdditionalArchitectures {
+				nodePool := awsNodePool(fmt.Sprintf("%s-%s-%s", cluster.Name, zone.Name, arch.Arch), zone, arch.InstanceType)
+				nodePool.Spec.Arch = arch.Arch
+				nodePools = append(nodePools, nodePool)
+			}
 		}
 	case hyperv1.KubevirtPlatform:
 		nodePool := defaultNodePool(cluster.Name)
@@ -722,6 +765,8 @@ func (o ExampleOptions) Resources() *ExampleResources {
 					EnableEphemeralOSDisk:  o.Azure.EnableEphemeralOSDisk,
 					DiskStorageAccountType: o.Azure.DiskStorageAccountType,
 					SubnetID:               o.Azure.SubnetID,
+
+					EnableAcceleratedNetworking: o.Azure.EnableAcceleratedNetworking,
 				}
 				nodePools = append(nodePools, nodePool)
 			}
@@ -739,6 +784,8 @@ func (o ExampleOptions) Resources() *ExampleResources {
 				EnableEphemeralOSDisk:  o.Azure.EnableEphemeralOSDisk,
 				DiskStorageAccountType: o.Azure.DiskStorageAccountType,
 				SubnetID:               o.Azure.SubnetID,
+
+				EnableAcceleratedNetworking: o.Azure.EnableAcceleratedNetworking,
 			}
 			nodePools = append(nodePools, nodePool)
 		}
@@ -1,20 +1,266 @@
 package fixtures
 
 import (
+	"fmt"
+	"net"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+)
+
+const (
+	agentInstallAPIVersion = "agent-install.openshift.io/v1beta1"
+
+	// infraEnvLabel is the label NMStateConfigs are selected by the InfraEnv with.
+	infraEnvLabel = "infraenvs.agent-install.openshift.io"
+
+	// DefaultAgentBondMode is the bonding mode used for hosts with several NICs.
+	DefaultAgentBondMode = "active-backup"
 )
 
 type ExampleAgentOptions struct {
 	APIServerAddress   string
 	AgentNamespace     string
 	AgentLabelSelector string
+
+	// HTTPProxy, HTTPSProxy and NoProxy configure the proxy used by the
+	// discovery image of the InfraEnv and by the hosted cluster.
+	HTTPProxy  string
+	HTTPSProxy string
+	NoProxy    string
+
+	// StaticNetworkHosts configure static networking of the hosts booting the
+	// discovery image of the InfraEnv, for environments without DHCP.
+	StaticNetworkHosts   []AgentStaticNetworkHost
+	StaticNetworkGateway string
+	StaticNetworkDNS     []string
+	BondMode             string
+}
+
+// AgentStaticNetworkHost is the static network configuration of a host. Hosts
+// with more than one NIC get them bonded.
+type AgentStaticNetworkHost struct {
+	MACAddresses []string
+	IP           string
 }
 
 type ExampleAgentResources struct {
 	CAPIProviderAgentRole *rbacv1.Role
+	PullSecret            *corev1.Secret
+	InfraEnv              *unstructured.Unstructured
+	NMStateConfigs        []*unstructured.Unstructured
 }
 
 func (o *ExampleAgentResources) AsObjects() []crclient.Object {
-	return []crclient.Object{o.CAPIProviderAgentRole}
+	objects := []crclient.Object{o.CAPIProviderAgentRole}
+	if o.PullSecret != nil {
+		objects = append(objects, o.PullSecret)
+	}
+	for _, nmStateConfig := range o.NMStateConfigs {
+		objects = append(objects, nmStateConfig)
+	}
+	if o.InfraEnv != nil {
+		objects = append(objects, o.InfraEnv)
+	}
+	return objects
+}
+
+// NeedsInfraEnv returns true if the options require an InfraEnv to be generated
+// for the hosted cluster.
+func (o *ExampleAgentOptions) NeedsInfraEnv() bool {
+	return o.HTTPProxy != "" || o.HTTPSProxy != "" || len(o.StaticNetworkHosts) > 0
+}
+
+// ParseAgentStaticNetworkHost parses the static network configuration of a host
+// in the form of "mac=<mac>[,mac=<mac>...],ip=<address>/<prefix>".
+func ParseAgentStaticNetworkHost(s string) (AgentStaticNetworkHost, error) {
+	host := AgentStaticNetworkHost{}
+	for _, option := range strings.Split(s, ",") {
+		key, value, found := strings.Cut(strings.TrimSpace(option), "=")
+		if !found {
+			return host, fmt.Errorf("invalid static network host option %q, expected key=value", option)
+		}
+		switch key {
+		case "mac":
+			if _, err := net.ParseMAC(value); err != nil {
+				return host, fmt.Errorf("invalid static network host MAC address %q: %w", value, err)
+			}
+			host.MACAddresses = append(host.MACAddresses, value)
+		case "ip":
+			if _, _, err := net.ParseCIDR(value); err != nil {
+				return host, fmt.Errorf("invalid static network host IP address %q, expected <address>/<prefix>: %w", value, err)
+			}
+			host.IP = value
+		default:
+			return host, fmt.Errorf("unknown static network host option %q", key)
+		}
+	}
+	if len(host.MACAddresses) == 0 || host.IP == "" {
+		return host, fmt.Errorf("static network host %q must set at least one mac and an ip", s)
+	}
+	return host, nil
+}
+
+func (o *ExampleAgentOptions) pullSecret(name string, pullSecret []byte) *corev1.Secret {
+	return &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Secret",
+			APIVersion: corev1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: o.AgentNamespace,
+			Name:      name + "-pull-secret",
+		},
+		Type: corev1.SecretTypeDockerConfigJson,
+		Data: map[string][]byte{
+			corev1.DockerConfigJsonKey: pullSecret,
+		},
+	}
+}
+
+func (o *ExampleAgentOptions) infraEnv(name, pullSecretName, sshPublicKey, arch string) *unstructured.Unstructured {
+	spec := map[string]interface{}{
+		"pullSecretRef": map[string]interface{}{
+			"name": pullSecretName,
+		},
+	}
+	if sshPublicKey != "" {
+		spec["sshAuthorizedKey"] = sshPublicKey
+	}
+	if cpuArchitecture, ok := hyperv1.ArchAliases[arch]; ok {
+		spec["cpuArchitecture"] = cpuArchitecture
+	}
+	if o.HTTPProxy != "" || o.HTTPSProxy != "" {
+		proxy := map[string]interface{}{}
+		if o.HTTPProxy != "" {
+			proxy["httpProxy"] = o.HTTPProxy
+		}
+		if o.HTTPSProxy != "" {
+			proxy["httpsProxy"] = o.HTTPSProxy
+		}
+		if o.NoProxy != "" {
+			proxy["noProxy"] = o.NoProxy
+		}
+		spec["proxy"] = proxy
+	}
+	if len(o.StaticNetworkHosts) > 0 {
+		spec["nmStateConfigLabelSelector"] = map[string]interface{}{
+			"matchLabels": map[string]interface{}{
+				infraEnvLabel: name,
+			},
+		}
+	}
+
+	infraEnv := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	infraEnv.SetAPIVersion(agentInstallAPIVersion)
+	infraEnv.SetKind("InfraEnv")
+	infraEnv.SetNamespace(o.AgentNamespace)
+	infraEnv.SetName(name)
+	return infraEnv
+}
+
+func (o *ExampleAgentOptions) nmStateConfigs(name string) []*unstructured.Unstructured {
+	var nmStateConfigs []*unstructured.Unstructured
+	for i, host := range o.StaticNetworkHosts {
+		var interfaces []interface{}
+		var nmStateInterfaces []interface{}
+		for j, mac := range host.MACAddresses {
+			interfaceName := fmt.Sprintf("eth%d", j)
+			interfaces = append(interfaces, map[string]interface{}{
+				"name":       interfaceName,
+				"macAddress": mac,
+			})
+			nmStateInterfaces = append(nmStateInterfaces, map[string]interface{}{
+				"name":        interfaceName,
+				"type":        "ethernet",
+				"state":       "up",
+				"mac-address": mac,
+			})
+		}
+
+		ipInterface := nmStateInterfaces[0].(map[string]interface{})
+		if len(host.MACAddresses) > 1 {
+			bondMode := o.BondMode
+			if bondMode == "" {
+				bondMode = DefaultAgentBondMode
+			}
+			var ports []interface{}
+			for _, iface := range interfaces {
+				ports = append(ports, iface.(map[string]interface{})["name"])
+			}
+			ipInterface = map[string]interface{}{
+				"name":  "bond0",
+				"type":  "bond",
+				"state": "up",
+				"link-aggregation": map[string]interface{}{
+					"mode": bondMode,
+					"port": ports,
+				},
+			}
+			nmStateInterfaces = append(nmStateInterfaces, ipInterface)
+		}
+
+		ip, ipNet, _ := net.ParseCIDR(host.IP)
+		prefixLength, _ := ipNet.Mask.Size()
+		ipFamily, defaultDestination := "ipv4", "0.0.0.0/0"
+		if ip.To4() == nil {
+			ipFamily, defaultDestination = "ipv6", "::/0"
+		}
+		ipInterface[ipFamily] = map[string]interface{}{
+			"enabled": true,
+			"dhcp":    false,
+			"address": []interface{}{
+				map[string]interface{}{
+					"ip":            ip.String(),
+					"prefix-length": int64(prefixLength),
+				},
+			},
+		}
+
+		config := map[string]interface{}{
+			"interfaces": nmStateInterfaces,
+		}
+		if o.StaticNetworkGateway != "" {
+			config["routes"] = map[string]interface{}{
+				"config": []interface{}{
+					map[string]interface{}{
+						"destination":        defaultDestination,
+						"next-hop-address":   o.StaticNetworkGateway,
+						"next-hop-interface": ipInterface["name"],
+					},
+				},
+			}
+		}
+		if len(o.StaticNetworkDNS) > 0 {
+			var servers []interface{}
+			for _, server := range o.StaticNetworkDNS {
+				servers = append(servers, server)
+			}
+			config["dns-resolver"] = map[string]interface{}{
+				"config": map[string]interface{}{
+					"server": servers,
+				},
+			}
+		}
+
+		nmStateConfig := &unstructured.Unstructured{Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"config":     config,
+				"interfaces": interfaces,
+			},
+		}}
+		nmStateConfig.SetAPIVersion(agentInstallAPIVersion)
+		nmStateConfig.SetKind("NMStateConfig")
+		nmStateConfig.SetNamespace(o.AgentNamespace)
+		nmStateConfig.SetName(fmt.Sprintf("%s-%d", name, i))
+		nmStateConfig.SetLabels(map[string]string{infraEnvLabel: name})
+		nmStateConfigs = append(nmStateConfigs, nmStateConfig)
+	}
+	return nmStateConfigs
 }
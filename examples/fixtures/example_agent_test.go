@@ -0,0 +1,143 @@
+package fixtures
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestParseAgentStaticNetworkHost(t *testing.T) {
+	testCases := []struct {
+		name         string
+		host         string
+		expectedHost AgentStaticNetworkHost
+		expectError  bool
+	}{
+		{
+			name:         "When a host has a single mac it should be parsed",
+			host:         "mac=52:54:00:aa:bb:01,ip=192.168.122.10/24",
+			expectedHost: AgentStaticNetworkHost{MACAddresses: []string{"52:54:00:aa:bb:01"}, IP: "192.168.122.10/24"},
+		},
+		{
+			name:         "When a host has several macs it should keep all of them",
+			host:         "mac=52:54:00:aa:bb:01, mac=52:54:00:aa:bb:02, ip=fd00::10/64",
+			expectedHost: AgentStaticNetworkHost{MACAddresses: []string{"52:54:00:aa:bb:01", "52:54:00:aa:bb:02"}, IP: "fd00::10/64"},
+		},
+		{
+			name:        "When the ip has no prefix it should fail",
+			host:        "mac=52:54:00:aa:bb:01,ip=192.168.122.10",
+			expectError: true,
+		},
+		{
+			name:        "When the mac is invalid it should fail",
+			host:        "mac=52:54:00,ip=192.168.122.10/24",
+			expectError: true,
+		},
+		{
+			name:        "When the host has no mac it should fail",
+			host:        "ip=192.168.122.10/24",
+			expectError: true,
+		},
+		{
+			name:        "When an option is unknown it should fail",
+			host:        "mac=52:54:00:aa:bb:01,ip=192.168.122.10/24,vlan=10",
+			expectError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			host, err := ParseAgentStaticNetworkHost(tc.host)
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(host).To(Equal(tc.expectedHost))
+		})
+	}
+}
+
+func TestAgentInfraEnvResources(t *testing.T) {
+	g := NewWithT(t)
+	o := ExampleOptions{
+		Namespace:    "clusters",
+		Name:         "example",
+		PullSecret:   []byte(`{"auths":{}}`),
+		SSHPublicKey: []byte("ssh-rsa AAAA\n"),
+		Arch:         "amd64",
+		Agent: &ExampleAgentOptions{
+			AgentNamespace:       "agents",
+			HTTPProxy:            "http://proxy.example.com:3128",
+			HTTPSProxy:           "http://proxy.example.com:3128",
+			NoProxy:              ".example.com",
+			StaticNetworkGateway: "192.168.122.1",
+			StaticNetworkDNS:     []string{"192.168.122.1"},
+			StaticNetworkHosts: []AgentStaticNetworkHost{
+				{MACAddresses: []string{"52:54:00:aa:bb:01"}, IP: "192.168.122.10/24"},
+				{MACAddresses: []string{"52:54:00:aa:bb:02", "52:54:00:aa:bb:03"}, IP: "192.168.122.11/24"},
+			},
+		},
+	}
+
+	resources := o.Resources()
+	g.Expect(resources.Cluster.Spec.Configuration.Proxy.HTTPProxy).To(Equal("http://proxy.example.com:3128"))
+	g.Expect(resources.Cluster.Spec.Configuration.Proxy.NoProxy).To(Equal(".example.com"))
+
+	objects := map[string]*unstructured.Unstructured{}
+	for _, object := range resources.Resources {
+		if u, ok := object.(*unstructured.Unstructured); ok {
+			g.Expect(u.GetNamespace()).To(Equal("agents"))
+			objects[u.GetKind()+"/"+u.GetName()] = u
+		}
+	}
+	g.Expect(objects).To(HaveLen(3))
+
+	infraEnv := objects["InfraEnv/example"]
+	g.Expect(infraEnv).ToNot(BeNil())
+	g.Expect(nestedString(infraEnv.Object, "spec", "pullSecretRef", "name")).To(Equal("example-pull-secret"))
+	g.Expect(nestedString(infraEnv.Object, "spec", "sshAuthorizedKey")).To(Equal("ssh-rsa AAAA"))
+	g.Expect(nestedString(infraEnv.Object, "spec", "cpuArchitecture")).To(Equal("x86_64"))
+	g.Expect(nestedString(infraEnv.Object, "spec", "proxy", "httpsProxy")).To(Equal("http://proxy.example.com:3128"))
+	g.Expect(nestedString(infraEnv.Object, "spec", "nmStateConfigLabelSelector", "matchLabels", infraEnvLabel)).To(Equal("example"))
+
+	single := objects["NMStateConfig/example-0"]
+	g.Expect(single).ToNot(BeNil())
+	g.Expect(single.GetLabels()).To(HaveKeyWithValue(infraEnvLabel, "example"))
+	interfaces, _, _ := unstructured.NestedSlice(single.Object, "spec", "config", "interfaces")
+	g.Expect(interfaces).To(HaveLen(1))
+	addresses, _, _ := unstructured.NestedSlice(interfaces[0].(map[string]interface{}), "ipv4", "address")
+	g.Expect(addresses).To(ConsistOf(map[string]interface{}{"ip": "192.168.122.10", "prefix-length": int64(24)}))
+
+	bonded := objects["NMStateConfig/example-1"]
+	g.Expect(bonded).ToNot(BeNil())
+	interfaces, _, _ = unstructured.NestedSlice(bonded.Object, "spec", "config", "interfaces")
+	g.Expect(interfaces).To(HaveLen(3))
+	bond := interfaces[2].(map[string]interface{})
+	g.Expect(bond["name"]).To(Equal("bond0"))
+	g.Expect(nestedString(bond, "link-aggregation", "mode")).To(Equal(DefaultAgentBondMode))
+	routes, _, _ := unstructured.NestedSlice(bonded.Object, "spec", "config", "routes", "config")
+	g.Expect(routes).To(ConsistOf(HaveKeyWithValue("next-hop-interface", "bond0")))
+}
+
+func TestAgentResourcesWithoutInfraEnv(t *testing.T) {
+	g := NewWithT(t)
+	o := ExampleOptions{
+		Namespace:  "clusters",
+		Name:       "example",
+		PullSecret: []byte(`{"auths":{}}`),
+		Agent:      &ExampleAgentOptions{AgentNamespace: "agents"},
+	}
+
+	resources := o.Resources()
+	for _, object := range resources.Resources {
+		g.Expect(object).ToNot(BeAssignableToTypeOf(&unstructured.Unstructured{}))
+	}
+	g.Expect(resources.Cluster.Spec.Configuration.Proxy).To(BeNil())
+}
+
+func nestedString(obj map[string]interface{}, fields ...string) string {
+	value, _, _ := unstructured.NestedString(obj, fields...)
+	return value
+}
@@ -21,6 +21,17 @@ type ExampleAWSOptions struct {
 	EndpointAccess          string
 	ProxyAddress            string
 	MultiArch               bool
+	ServiceEndpoints        []hyperv1.AWSServiceEndpoint
+
+	// AdditionalArchitectures are the architectures of the NodePools created in every zone in addition to the NodePool
+	// of the default architecture. Their NodePools are named after the zone and the architecture.
+	AdditionalArchitectures []ExampleAWSArchitecture
+}
+
+// ExampleAWSArchitecture is an architecture of the NodePools of a multi-arch cluster.
+type ExampleAWSArchitecture struct {
+	Arch         string
+	InstanceType string
 }
 
 type ExampleAWSOptionsZones struct {
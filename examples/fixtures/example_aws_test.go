@@ -0,0 +1,52 @@
+package fixtures
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"k8s.io/utils/ptr"
+)
+
+func TestAWSMultiArchNodePools(t *testing.T) {
+	g := NewWithT(t)
+	o := ExampleOptions{
+		Namespace:        "clusters",
+		Name:             "example",
+		PullSecret:       []byte(`{"auths":{}}`),
+		Arch:             hyperv1.ArchitectureARM64,
+		NodePoolReplicas: 2,
+		AWS: &ExampleAWSOptions{
+			Region:       "us-east-1",
+			InstanceType: "m6g.xlarge",
+			Zones: []ExampleAWSOptionsZones{
+				{Name: "us-east-1a", SubnetID: ptr.To("subnet-a")},
+				{Name: "us-east-1b", SubnetID: ptr.To("subnet-b")},
+			},
+			MultiArch:               true,
+			AdditionalArchitectures: []ExampleAWSArchitecture{{Arch: hyperv1.ArchitectureAMD64, InstanceType: "m5.large"}},
+		},
+	}
+
+	type nodePool struct {
+		name, arch, instanceType, subnet string
+		ami                              string
+	}
+	var nodePools []nodePool
+	for _, np := range o.Resources().NodePools {
+		nodePools = append(nodePools, nodePool{
+			name:         np.Name,
+			arch:         np.Spec.Arch,
+			instanceType: np.Spec.Platform.AWS.InstanceType,
+			subnet:       ptr.Deref(np.Spec.Platform.AWS.Subnet.ID, ""),
+			ami:          np.Spec.Platform.AWS.AMI,
+		})
+		g.Expect(*np.Spec.Replicas).To(Equal(int32(2)))
+	}
+	g.Expect(nodePools).To(Equal([]nodePool{
+		{name: "example-us-east-1a", arch: hyperv1.ArchitectureARM64, instanceType: "m6g.xlarge", subnet: "subnet-a"},
+		{name: "example-us-east-1a-amd64", arch: hyperv1.ArchitectureAMD64, instanceType: "m5.large", subnet: "subnet-a"},
+		{name: "example-us-east-1b", arch: hyperv1.ArchitectureARM64, instanceType: "m6g.xlarge", subnet: "subnet-b"},
+		{name: "example-us-east-1b-amd64", arch: hyperv1.ArchitectureAMD64, instanceType: "m5.large", subnet: "subnet-b"},
+	}))
+}
@@ -1,6 +1,9 @@
 package fixtures
 
-import "github.com/openshift/hypershift/cmd/util"
+import (
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/cmd/util"
+)
 
 type ExampleAzureOptions struct {
 	Creds                  util.AzureCreds
@@ -18,6 +21,9 @@ type ExampleAzureOptions struct {
 	EnableEphemeralOSDisk  bool
 	DiskStorageAccountType string
 	EncryptionKey          *AzureEncryptionKey
+
+	EnableAcceleratedNetworking bool
+	APIServerCustomDomain       *hyperv1.CustomDomainPublishingStrategy
 }
 
 type AzureEncryptionKey struct {
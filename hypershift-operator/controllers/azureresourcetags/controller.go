@@ -0,0 +1,314 @@
+package azureresourcetags
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/support/clusteridentity"
+	"github.com/openshift/hypershift/support/conditions"
+	hyperutil "github.com/openshift/hypershift/support/util"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+const (
+	controllerName = "azure-resource-tags"
+
+	// resyncPeriod is how often the tags are reconciled while the HostedCluster doesn't change, to also tag the
+	// resources which were created without them, or whose tags were changed outside of the cluster.
+	resyncPeriod = 30 * time.Minute
+
+	// maxReportedFailures is the maximum number of resources which couldn't be tagged listed in the condition message.
+	maxReportedFailures = 5
+)
+
+// taggedResourceTypes are the types of the resources in the resource group of the cluster the resource tags are
+// applied to. Azure resource types are case-insensitive.
+var taggedResourceTypes = sets.New(
+	"microsoft.compute/virtualmachines",
+	"microsoft.compute/disks",
+	"microsoft.network/networkinterfaces",
+	"microsoft.network/loadbalancers",
+)
+
+// azureResource is a resource in the resource group of a cluster.
+type azureResource struct {
+	ID   string
+	Type string
+	Tags map[string]*string
+}
+
+// resourcesAPI is the subset of the Azure Resource Manager API used to tag the resources of the cluster.
+type resourcesAPI interface {
+	// ListResources returns the resources in the resource group.
+	ListResources(ctx context.Context, resourceGroup string) ([]azureResource, error)
+	// MergeTags adds the tags to the resource, replacing the values of the tags it already has.
+	MergeTags(ctx context.Context, resourceID string, tags map[string]*string) error
+}
+
+// Reconciler applies the resource tags of the Azure platform of a HostedCluster, its identity tags and the mandatory
+// tags to the virtual machines, disks, network interfaces and load balancers in its resource group, so that tags added or changed after the cluster was
+// created are applied to its existing resources too. Tags removed from the spec aren't removed from the resources,
+// since they can't be told apart from tags added outside of the cluster. The outcome is reported with the
+// AzureResourceTagsApplied condition of the HostedCluster.
+type Reconciler struct {
+	client.Client
+
+	// TagKeyDenyList are the keys of the tags which are never applied, e.g. because they are reserved by the service
+	// managing the resource groups. Keys are compared case-insensitively, like Azure does.
+	TagKeyDenyList []string
+
+	// MandatoryTags are applied to the resources of every HostedCluster, along with its identity tags.
+	MandatoryTags map[string]string
+
+	// resourcesForCluster returns the client of the Azure resources of the HostedCluster.
+	resourcesForCluster func(ctx context.Context, c client.Client, hc *hyperv1.HostedCluster) (resourcesAPI, error)
+}
+
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.resourcesForCluster == nil {
+		r.resourcesForCluster = newARMResources
+	}
+	_, err := ctrl.NewControllerManagedBy(mgr).
+		Named(controllerName).
+		For(&hyperv1.HostedCluster{}, builder.WithPredicates(predicate.GenerationChangedPredicate{}, hyperutil.PredicatesForHostedClusterAnnotationScoping(mgr.GetClient()))).
+		Build(r)
+	if err != nil {
+		return fmt.Errorf("failed setting up with a controller manager: %w", err)
+	}
+	return nil
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	hc := &hyperv1.HostedCluster{}
+	if err := r.Get(ctx, req.NamespacedName, hc); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("failed to get hostedcluster: %w", err)
+	}
+	if !hc.DeletionTimestamp.IsZero() || hc.Spec.Platform.Azure == nil {
+		return ctrl.Result{}, nil
+	}
+	if isPaused, duration := hyperutil.IsReconciliationPaused(log, hc.Spec.PausedUntil); isPaused {
+		log.Info("Reconciliation paused", "pausedUntil", *hc.Spec.PausedUntil)
+		return ctrl.Result{RequeueAfter: duration}, nil
+	}
+
+	originalHC := hc.DeepCopy()
+	tags := allowedTags(resourceTags(hc, r.MandatoryTags), r.TagKeyDenyList)
+	if len(tags) == 0 {
+		// There is nothing to apply, and clusters without resource tags don't need their credentials to be used.
+		meta.RemoveStatusCondition(&hc.Status.Conditions, string(hyperv1.AzureResourceTagsApplied))
+	} else {
+		var condition metav1.Condition
+		resources, err := r.resourcesForCluster(ctx, r.Client, hc)
+		if err != nil {
+			condition = resourceTagsCondition(tagResult{}, err)
+		} else {
+			condition = resourceTagsCondition(applyResourceTags(ctx, resources, hc.Spec.Platform.Azure.ResourceGroupName, tags))
+		}
+		condition.ObservedGeneration = hc.Generation
+		meta.SetStatusCondition(&hc.Status.Conditions, condition)
+	}
+	if !equality.Semantic.DeepEqual(originalHC.Status, hc.Status) {
+		if err := r.Status().Patch(ctx, hc, client.MergeFromWithOptions(originalHC, client.MergeFromWithOptimisticLock{})); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to update status: %w", err)
+		}
+	}
+	if len(tags) == 0 {
+		return ctrl.Result{}, nil
+	}
+	return ctrl.Result{RequeueAfter: resyncPeriod}, nil
+}
+
+// resourceTags returns the resource tags of the HostedCluster followed by its identity tags and the mandatory tags,
+// which override the resource tags with the same key.
+func resourceTags(hc *hyperv1.HostedCluster, mandatoryTags map[string]string) []hyperv1.AzureResourceTag {
+	tags := append([]hyperv1.AzureResourceTag{}, hc.Spec.Platform.Azure.ResourceTags...)
+	for _, tag := range clusteridentity.ForHostedCluster(hc).Tags(mandatoryTags) {
+		tags = append(tags, hyperv1.AzureResourceTag{Key: tag.Key, Value: tag.Value})
+	}
+	return tags
+}
+
+// allowedTags returns the tags whose key isn't in the deny-list, by key.
+func allowedTags(tags []hyperv1.AzureResourceTag, denyList []string) map[string]string {
+	denied := sets.New[string]()
+	for _, key := range denyList {
+		denied.Insert(strings.ToLower(key))
+	}
+	allowed := map[string]string{}
+	for _, tag := range tags {
+		if denied.Has(strings.ToLower(tag.Key)) {
+			continue
+		}
+		allowed[tag.Key] = tag.Value
+	}
+	return allowed
+}
+
+// tagResult is the outcome of applying the resource tags to the resources of a cluster.
+type tagResult struct {
+	// resources is the number of resources of the cluster the tags apply to.
+	resources int
+	// failed are the resources which couldn't be tagged, by ID.
+	failed map[string]error
+}
+
+// applyResourceTags tags the resources of the cluster which are missing any of the tags or have another value for it.
+func applyResourceTags(ctx context.Context, resources resourcesAPI, resourceGroup string, tags map[string]string) (tagResult, error) {
+	result := tagResult{failed: map[string]error{}}
+	if len(tags) == 0 {
+		return result, nil
+	}
+
+	list, err := resources.ListResources(ctx, resourceGroup)
+	if err != nil {
+		return result, fmt.Errorf("failed to list the resources of resource group %s: %w", resourceGroup, err)
+	}
+	for _, resource := range list {
+		if !taggedResourceTypes.Has(strings.ToLower(resource.Type)) {
+			continue
+		}
+		result.resources++
+		missing := missingTags(resource.Tags, tags)
+		if len(missing) == 0 {
+			continue
+		}
+		if err := resources.MergeTags(ctx, resource.ID, missing); err != nil {
+			result.failed[resource.ID] = err
+		}
+	}
+	return result, nil
+}
+
+// missingTags returns the tags the resource doesn't have, or has another value for. Tag keys are case-insensitive,
+// tag values aren't.
+func missingTags(current map[string]*string, desired map[string]string) map[string]*string {
+	values := map[string]string{}
+	for key, value := range current {
+		values[strings.ToLower(key)] = ptr.Deref(value, "")
+	}
+	missing := map[string]*string{}
+	for key, value := range desired {
+		if currentValue, exists := values[strings.ToLower(key)]; !exists || currentValue != value {
+			missing[key] = ptr.To(value)
+		}
+	}
+	return missing
+}
+
+// resourceTagsCondition returns the AzureResourceTagsApplied condition reporting the result of applying the tags. The
+// resources which couldn't be tagged are listed in its message, along with the error code returned for them.
+func resourceTagsCondition(result tagResult, err error) metav1.Condition {
+	condition := metav1.Condition{
+		Type: string(hyperv1.AzureResourceTagsApplied),
+	}
+	switch {
+	case err != nil:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = conditions.ReasonForError(err, hyperv1.InfraFailureReason)
+		condition.Message = err.Error()
+	case len(result.failed) > 0:
+		ids := make([]string, 0, len(result.failed))
+		for id := range result.failed {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		var failures []string
+		for _, id := range ids {
+			if len(failures) == maxReportedFailures {
+				failures = append(failures, fmt.Sprintf("and %d more", len(ids)-maxReportedFailures))
+				break
+			}
+			// The errors of the Azure API span several lines, their code is enough to tell what failed.
+			failure := conditions.ProviderErrorCode(result.failed[id])
+			if failure == "" {
+				failure = result.failed[id].Error()
+			}
+			failures = append(failures, fmt.Sprintf("%s: %s", id, failure))
+		}
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = conditions.ReasonForError(result.failed[ids[0]], hyperv1.InfraFailureReason)
+		condition.Message = fmt.Sprintf("Failed to tag %d of the %d resources of the cluster: %s", len(ids), result.resources, strings.Join(failures, "; "))
+	default:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = hyperv1.AsExpectedReason
+		condition.Message = fmt.Sprintf("The resource tags are applied to the %d resources of the cluster", result.resources)
+	}
+	return condition
+}
+
+// armResources tags the resources of a HostedCluster with its Azure credentials.
+type armResources struct {
+	resources *armresources.Client
+	tags      *armresources.TagsClient
+}
+
+func newARMResources(ctx context.Context, c client.Client, hc *hyperv1.HostedCluster) (resourcesAPI, error) {
+	credentialsSecret := &corev1.Secret{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: hc.Namespace, Name: hc.Spec.Platform.Azure.Credentials.Name}, credentialsSecret); err != nil {
+		return nil, fmt.Errorf("failed to get azure credentials secret: %w", err)
+	}
+	credentials, err := azidentity.NewClientSecretCredential(
+		string(credentialsSecret.Data["AZURE_TENANT_ID"]),
+		string(credentialsSecret.Data["AZURE_CLIENT_ID"]),
+		string(credentialsSecret.Data["AZURE_CLIENT_SECRET"]), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to obtain azure client credential: %w", err)
+	}
+	resourcesClient, err := armresources.NewClient(hc.Spec.Platform.Azure.SubscriptionID, credentials, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create resources client: %w", err)
+	}
+	tagsClient, err := armresources.NewTagsClient(hc.Spec.Platform.Azure.SubscriptionID, credentials, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tags client: %w", err)
+	}
+	return &armResources{resources: resourcesClient, tags: tagsClient}, nil
+}
+
+func (a *armResources) ListResources(ctx context.Context, resourceGroup string) ([]azureResource, error) {
+	var resources []azureResource
+	pager := a.resources.NewListByResourceGroupPager(resourceGroup, nil)
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, resource := range page.Value {
+			resources = append(resources, azureResource{
+				ID:   ptr.Deref(resource.ID, ""),
+				Type: ptr.Deref(resource.Type, ""),
+				Tags: resource.Tags,
+			})
+		}
+	}
+	return resources, nil
+}
+
+func (a *armResources) MergeTags(ctx context.Context, resourceID string, tags map[string]*string) error {
+	_, err := a.tags.UpdateAtScope(ctx, resourceID, armresources.TagsPatchResource{
+		Operation:  ptr.To(armresources.TagsPatchOperationMerge),
+		Properties: &armresources.Tags{Tags: tags},
+	}, nil)
+	return err
+}
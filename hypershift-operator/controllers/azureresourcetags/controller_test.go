@@ -0,0 +1,236 @@
+package azureresourcetags
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/support/api"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type fakeResources struct {
+	resources []azureResource
+	listErr   error
+	failed    map[string]error
+	merged    map[string]map[string]string
+}
+
+func (f *fakeResources) ListResources(_ context.Context, _ string) ([]azureResource, error) {
+	return f.resources, f.listErr
+}
+
+func (f *fakeResources) MergeTags(_ context.Context, resourceID string, tags map[string]*string) error {
+	if err, failed := f.failed[resourceID]; failed {
+		return err
+	}
+	if f.merged == nil {
+		f.merged = map[string]map[string]string{}
+	}
+	f.merged[resourceID] = map[string]string{}
+	for key, value := range tags {
+		f.merged[resourceID][key] = ptr.Deref(value, "")
+	}
+	return nil
+}
+
+func resource(id, resourceType string, tags map[string]string) azureResource {
+	r := azureResource{ID: id, Type: resourceType, Tags: map[string]*string{}}
+	for key, value := range tags {
+		r.Tags[key] = ptr.To(value)
+	}
+	return r
+}
+
+func TestApplyResourceTags(t *testing.T) {
+	desired := map[string]string{"team": "payments", "env": "prod"}
+
+	t.Run("When resources are missing tags it should only apply the missing ones to the tagged resource types", func(t *testing.T) {
+		g := NewWithT(t)
+		resources := &fakeResources{resources: []azureResource{
+			resource("vm-1", "Microsoft.Compute/virtualMachines", map[string]string{"team": "payments", "env": "prod"}),
+			resource("vm-2", "Microsoft.Compute/virtualMachines", map[string]string{"Team": "payments"}),
+			resource("disk-1", "Microsoft.Compute/disks", map[string]string{"env": "prod", "team": "billing"}),
+			resource("nic-1", "microsoft.network/networkinterfaces", nil),
+			resource("lb-1", "Microsoft.Network/loadBalancers", nil),
+			resource("identity-1", "Microsoft.ManagedIdentity/userAssignedIdentities", nil),
+		}}
+
+		result, err := applyResourceTags(context.Background(), resources, "rg", desired)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result.resources).To(Equal(5))
+		g.Expect(result.failed).To(BeEmpty())
+		g.Expect(resources.merged).To(Equal(map[string]map[string]string{
+			"vm-2":   {"env": "prod"},
+			"disk-1": {"team": "payments"},
+			"nic-1":  {"team": "payments", "env": "prod"},
+			"lb-1":   {"team": "payments", "env": "prod"},
+		}))
+	})
+
+	t.Run("When some resources can't be tagged it should tag the others and report the failures", func(t *testing.T) {
+		g := NewWithT(t)
+		resources := &fakeResources{
+			resources: []azureResource{
+				resource("vm-1", "Microsoft.Compute/virtualMachines", nil),
+				resource("vm-2", "Microsoft.Compute/virtualMachines", nil),
+			},
+			failed: map[string]error{"vm-1": fmt.Errorf("conflict")},
+		}
+
+		result, err := applyResourceTags(context.Background(), resources, "rg", desired)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result.failed).To(HaveKey("vm-1"))
+		g.Expect(resources.merged).To(HaveKey("vm-2"))
+	})
+
+	t.Run("When there are no resource tags it should not list the resources", func(t *testing.T) {
+		g := NewWithT(t)
+		resources := &fakeResources{listErr: fmt.Errorf("unexpected call")}
+		_, err := applyResourceTags(context.Background(), resources, "rg", nil)
+		g.Expect(err).ToNot(HaveOccurred())
+	})
+}
+
+func TestAllowedTags(t *testing.T) {
+	g := NewWithT(t)
+	tags := []hyperv1.AzureResourceTag{{Key: "team", Value: "payments"}, {Key: "Reserved", Value: "x"}, {Key: "env", Value: "prod"}}
+	g.Expect(allowedTags(tags, []string{"reserved", "owner"})).To(Equal(map[string]string{"team": "payments", "env": "prod"}))
+	g.Expect(allowedTags(tags, nil)).To(HaveLen(3))
+}
+
+func TestResourceTagsCondition(t *testing.T) {
+	testCases := []struct {
+		name            string
+		result          tagResult
+		err             error
+		expectedStatus  metav1.ConditionStatus
+		expectedReason  string
+		expectedMessage string
+	}{
+		{
+			name:            "When all the resources are tagged it should be true",
+			result:          tagResult{resources: 4},
+			expectedStatus:  metav1.ConditionTrue,
+			expectedReason:  hyperv1.AsExpectedReason,
+			expectedMessage: "The resource tags are applied to the 4 resources of the cluster",
+		},
+		{
+			name: "When some resources couldn't be tagged it should be false and list them with their error code",
+			result: tagResult{resources: 4, failed: map[string]error{
+				"vm-1":   &azcore.ResponseError{ErrorCode: "AuthorizationFailed", StatusCode: http.StatusForbidden},
+				"disk-1": &azcore.ResponseError{ErrorCode: "AuthorizationFailed", StatusCode: http.StatusForbidden},
+			}},
+			expectedStatus:  metav1.ConditionFalse,
+			expectedReason:  hyperv1.InvalidCredentialsReason,
+			expectedMessage: "Failed to tag 2 of the 4 resources of the cluster: disk-1: AuthorizationFailed; vm-1: AuthorizationFailed",
+		},
+		{
+			name:            "When the resources can't be listed it should be false with the reason of the error",
+			err:             fmt.Errorf("failed to list the resources of resource group rg: %w", &azcore.ResponseError{ErrorCode: "ResourceGroupNotFound", StatusCode: http.StatusNotFound}),
+			expectedStatus:  metav1.ConditionFalse,
+			expectedReason:  hyperv1.InfraFailureReason,
+			expectedMessage: "failed to list the resources of resource group rg: ",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			condition := resourceTagsCondition(tc.result, tc.err)
+			g.Expect(condition.Type).To(Equal(string(hyperv1.AzureResourceTagsApplied)))
+			g.Expect(condition.Status).To(Equal(tc.expectedStatus))
+			g.Expect(condition.Reason).To(Equal(tc.expectedReason))
+			g.Expect(condition.Message).To(HavePrefix(tc.expectedMessage))
+		})
+	}
+
+	t.Run("When many resources couldn't be tagged it should only list the first ones", func(t *testing.T) {
+		g := NewWithT(t)
+		result := tagResult{resources: 10, failed: map[string]error{}}
+		for i := 0; i < 8; i++ {
+			result.failed[fmt.Sprintf("vm-%d", i)] = fmt.Errorf("conflict")
+		}
+		condition := resourceTagsCondition(result, nil)
+		g.Expect(condition.Message).To(HavePrefix("Failed to tag 8 of the 10 resources of the cluster"))
+		g.Expect(condition.Message).To(HaveSuffix("and 3 more"))
+	})
+}
+
+func TestReconcile(t *testing.T) {
+	hostedCluster := func(tags ...hyperv1.AzureResourceTag) *hyperv1.HostedCluster {
+		return &hyperv1.HostedCluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "example", Generation: 2},
+			Spec: hyperv1.HostedClusterSpec{
+				Platform: hyperv1.PlatformSpec{
+					Type:  hyperv1.AzurePlatform,
+					Azure: &hyperv1.AzurePlatformSpec{ResourceGroupName: "rg", ResourceTags: tags},
+				},
+			},
+		}
+	}
+
+	t.Run("When the cluster has resource tags it should apply them and set the condition", func(t *testing.T) {
+		g := NewWithT(t)
+		hc := hostedCluster(hyperv1.AzureResourceTag{Key: "team", Value: "payments"}, hyperv1.AzureResourceTag{Key: "owner", Value: "me"})
+		c := fake.NewClientBuilder().WithScheme(api.Scheme).WithObjects(hc).WithStatusSubresource(hc).Build()
+		resources := &fakeResources{resources: []azureResource{resource("vm-1", "Microsoft.Compute/virtualMachines", nil)}}
+		r := &Reconciler{
+			Client:         c,
+			TagKeyDenyList: []string{"Owner"},
+			MandatoryTags:  map[string]string{"cost-center": "1234"},
+			resourcesForCluster: func(context.Context, client.Client, *hyperv1.HostedCluster) (resourcesAPI, error) {
+				return resources, nil
+			},
+		}
+
+		result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(hc)})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result.RequeueAfter).To(Equal(resyncPeriod))
+		g.Expect(resources.merged).To(Equal(map[string]map[string]string{"vm-1": {
+			"team":                                 "payments",
+			"cost-center":                          "1234",
+			"hypershift.openshift.io_cluster-name": "example",
+		}}))
+
+		g.Expect(c.Get(context.Background(), client.ObjectKeyFromObject(hc), hc)).To(Succeed())
+		condition := meta.FindStatusCondition(hc.Status.Conditions, string(hyperv1.AzureResourceTagsApplied))
+		g.Expect(condition).ToNot(BeNil())
+		g.Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		g.Expect(condition.ObservedGeneration).To(Equal(int64(2)))
+	})
+
+	t.Run("When the cluster has no resource tags it should still apply its identity tags", func(t *testing.T) {
+		g := NewWithT(t)
+		hc := hostedCluster()
+		hc.Spec.InfraID = "example-abcde"
+		hc.Spec.ClusterID = "2a1c0f4e-3f7b-4a5e-9d9e-6f1b2c3d4e5f"
+		hc.Annotations = map[string]string{hyperv1.ClusterOwnerAnnotation: "Payments Team"}
+		c := fake.NewClientBuilder().WithScheme(api.Scheme).WithObjects(hc).WithStatusSubresource(hc).Build()
+		resources := &fakeResources{resources: []azureResource{resource("vm-1", "Microsoft.Compute/virtualMachines", nil)}}
+		r := &Reconciler{
+			Client: c,
+			resourcesForCluster: func(context.Context, client.Client, *hyperv1.HostedCluster) (resourcesAPI, error) {
+				return resources, nil
+			},
+		}
+
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(hc)})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(resources.merged).To(Equal(map[string]map[string]string{"vm-1": {
+			"hypershift.openshift.io_infra-id":     "example-abcde",
+			"hypershift.openshift.io_cluster-id":   "2a1c0f4e-3f7b-4a5e-9d9e-6f1b2c3d4e5f",
+			"hypershift.openshift.io_cluster-name": "example",
+			"hypershift.openshift.io_owner":        "Payments_Team",
+		}}))
+	})
+}
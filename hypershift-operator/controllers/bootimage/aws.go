@@ -0,0 +1,134 @@
+package bootimage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/support/releaseinfo"
+)
+
+const (
+	// preferredSourceRegion is the region AMIs missing from a release are copied from, when the release has an AMI
+	// for it.
+	preferredSourceRegion = "us-east-1"
+
+	// sourceImageTag is the tag of the copied AMIs holding the ID of the AMI they were copied from.
+	sourceImageTag = "hypershift.openshift.io/source-image"
+)
+
+// awsBootImage returns the AMI of the NodePool release in the region of the HostedCluster, copying it from another
+// region when the release has none for it. It returns false while the copied AMI is not available yet.
+func (r *Reconciler) awsBootImage(ctx context.Context, hc *hyperv1.HostedCluster, nodePool *hyperv1.NodePool, releaseImage *releaseinfo.ReleaseImage) (string, bool, error) {
+	if hc.Spec.Platform.AWS == nil {
+		return "", false, fmt.Errorf("the HostedCluster for this NodePool has no .Spec.Platform.AWS")
+	}
+	archName := hyperv1.ArchAliases[nodePool.Spec.Arch]
+	arch, ok := releaseImage.StreamMetadata.Architectures[archName]
+	if !ok {
+		return "", false, fmt.Errorf("couldn't find OS metadata for architecture %q", nodePool.Spec.Arch)
+	}
+
+	region := hc.Spec.Platform.AWS.Region
+	if image, ok := arch.Images.AWS.Regions[region]; ok && image.Image != "" {
+		return image.Image, true, nil
+	}
+
+	sourceRegion, source, ok := sourceAMI(region, arch.Images.AWS.Regions)
+	if !ok {
+		return "", false, fmt.Errorf("release image metadata has no AMI to copy into region %q", region)
+	}
+	if r.EC2ClientForRegion == nil {
+		return "", false, fmt.Errorf("release image metadata has no AMI for region %q and the operator has no AWS credentials to copy AMI %s from region %s", region, source.Image, sourceRegion)
+	}
+	accountID := ""
+	if roleARN, err := arn.Parse(hc.Spec.Platform.AWS.RolesRef.NodePoolManagementARN); err == nil {
+		accountID = roleARN.AccountID
+	}
+	return copyAMI(ctx, r.EC2ClientForRegion(region), sourceRegion, source, fmt.Sprintf("rhcos-%s-%s", source.Release, archName), accountID)
+}
+
+// sourceAMI returns the region and AMI a release AMI is copied into the region from, picking the preferred source
+// region when the release has an AMI for it and the first region of the same partition otherwise.
+func sourceAMI(region string, images map[string]releaseinfo.CoreOSAWSImage) (string, releaseinfo.CoreOSAWSImage, bool) {
+	partition, _ := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), region)
+	var candidates []string
+	for candidate, image := range images {
+		if image.Image == "" {
+			continue
+		}
+		if candidatePartition, ok := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), candidate); ok && candidatePartition.ID() != partition.ID() {
+			continue
+		}
+		if candidate == preferredSourceRegion {
+			return candidate, image, true
+		}
+		candidates = append(candidates, candidate)
+	}
+	if len(candidates) == 0 {
+		return "", releaseinfo.CoreOSAWSImage{}, false
+	}
+	sort.Strings(candidates)
+	return candidates[0], images[candidates[0]], true
+}
+
+// copyAMI copies the source AMI under the given name unless a copy already exists, and shares the copy with the
+// account when the copy is owned by another one. It returns the ID of the copy, and true once it's available.
+func copyAMI(ctx context.Context, ec2Client ec2iface.EC2API, sourceRegion string, source releaseinfo.CoreOSAWSImage, name, accountID string) (string, bool, error) {
+	output, err := ec2Client.DescribeImagesWithContext(ctx, &ec2.DescribeImagesInput{
+		Owners:  []*string{aws.String("self")},
+		Filters: []*ec2.Filter{{Name: aws.String("name"), Values: []*string{aws.String(name)}}},
+	})
+	if err != nil {
+		return "", false, fmt.Errorf("failed to describe AMI %s: %w", name, err)
+	}
+
+	if len(output.Images) == 0 {
+		copyOutput, err := ec2Client.CopyImageWithContext(ctx, &ec2.CopyImageInput{
+			Name:          aws.String(name),
+			Description:   aws.String(fmt.Sprintf("Copy of RHCOS %s AMI %s from %s", source.Release, source.Image, sourceRegion)),
+			SourceImageId: aws.String(source.Image),
+			SourceRegion:  aws.String(sourceRegion),
+			TagSpecifications: []*ec2.TagSpecification{{
+				ResourceType: aws.String(ec2.ResourceTypeImage),
+				Tags:         []*ec2.Tag{{Key: aws.String(sourceImageTag), Value: aws.String(source.Image)}},
+			}},
+		})
+		if err != nil {
+			return "", false, fmt.Errorf("failed to copy AMI %s from region %s: %w", source.Image, sourceRegion, err)
+		}
+		return aws.StringValue(copyOutput.ImageId), false, nil
+	}
+
+	image := output.Images[0]
+	imageID := aws.StringValue(image.ImageId)
+	switch aws.StringValue(image.State) {
+	case ec2.ImageStateAvailable:
+	case ec2.ImageStatePending:
+		return imageID, false, nil
+	default:
+		reason := ""
+		if image.StateReason != nil {
+			reason = aws.StringValue(image.StateReason.Message)
+		}
+		return "", false, fmt.Errorf("copy %s of AMI %s is %s: %s", imageID, source.Image, aws.StringValue(image.State), reason)
+	}
+
+	if accountID != "" && accountID != aws.StringValue(image.OwnerId) {
+		if _, err := ec2Client.ModifyImageAttributeWithContext(ctx, &ec2.ModifyImageAttributeInput{
+			ImageId: image.ImageId,
+			LaunchPermission: &ec2.LaunchPermissionModifications{
+				Add: []*ec2.LaunchPermission{{UserId: aws.String(accountID)}},
+			},
+		}); err != nil {
+			return "", false, fmt.Errorf("failed to share AMI %s with account %s: %w", imageID, accountID, err)
+		}
+	}
+	return imageID, true, nil
+}
@@ -0,0 +1,351 @@
+package bootimage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/storage/armstorage"
+	"github.com/Azure/go-autorest/autorest"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/support/releaseinfo"
+	"github.com/tombuildsstuff/giovanni/storage/2019-12-12/blob/blobs"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// vhdContainerName is the blob container the RHCOS VHDs are copied into before being published.
+	vhdContainerName = "vhd"
+
+	// galleryImageVersionName is the version of the gallery image definitions. Every RHCOS release is published as its
+	// own image definition, since the RHCOS release numbers don't fit in the version numbers of a gallery.
+	galleryImageVersionName = "1.0.0"
+
+	// gen2DefinitionSuffix is the suffix of the gallery image definitions of Generation 2 images. The RHCOS VHDs boot
+	// both generations, but a gallery image definition has a single generation.
+	gen2DefinitionSuffix = "-gen2"
+
+	// trustedLaunchAndConfidentialVMSupported is the value of the SecurityType feature of the Generation 2 image
+	// definitions, which lets them boot both trusted launch and confidential VMs.
+	trustedLaunchAndConfidentialVMSupported = "TrustedLaunchAndConfidentialVmSupported"
+)
+
+// galleryImage is an RHCOS VHD to publish as a gallery image version.
+type galleryImage struct {
+	// Definition is the name of the gallery image definition.
+	Definition string
+	// Architecture is the CPU architecture of the image.
+	Architecture armcompute.Architecture
+	// SourceURL is the URL of the VHD in the RHCOS blob storage.
+	SourceURL string
+	// HyperVGeneration is the VM generation the image boots.
+	HyperVGeneration armcompute.HyperVGeneration
+	// SecurityType is the value of the SecurityType feature of the image definition, if any.
+	SecurityType string
+}
+
+// azureImagePublisher publishes RHCOS VHDs as gallery image versions.
+type azureImagePublisher interface {
+	// PublishImageVersion starts or continues the publishing of the image. It returns the ID of the gallery image
+	// version, and true once it's available.
+	PublishImageVersion(ctx context.Context, image galleryImage) (string, bool, error)
+}
+
+// azureBootImage returns the gallery image version of the RHCOS VHD of the NodePool release. It returns false while
+// the image version is not available yet.
+func (r *Reconciler) azureBootImage(ctx context.Context, hc *hyperv1.HostedCluster, nodePool *hyperv1.NodePool, releaseImage *releaseinfo.ReleaseImage) (string, bool, error) {
+	if hc.Spec.Platform.Azure == nil {
+		return "", false, fmt.Errorf("the HostedCluster for this NodePool has no .Spec.Platform.Azure")
+	}
+	archName := hyperv1.ArchAliases[nodePool.Spec.Arch]
+	arch, ok := releaseImage.StreamMetadata.Architectures[archName]
+	if !ok {
+		return "", false, fmt.Errorf("couldn't find OS metadata for architecture %q", nodePool.Spec.Arch)
+	}
+	vhd := arch.RHCOS.AzureDisk
+	if vhd.URL == "" {
+		return "", false, fmt.Errorf("release image metadata has no Azure VHD for architecture %q", nodePool.Spec.Arch)
+	}
+
+	image := galleryImage{
+		Definition:       fmt.Sprintf("rhcos-%s-%s", vhd.Release, archName),
+		Architecture:     azureArchitecture(archName),
+		SourceURL:        vhd.URL,
+		HyperVGeneration: armcompute.HyperVGenerationV1,
+	}
+	// Trusted launch and confidential VMs only boot Generation 2 images.
+	if requiresGen2Image(nodePool) {
+		image.Definition += gen2DefinitionSuffix
+		image.HyperVGeneration = armcompute.HyperVGenerationV2
+		image.SecurityType = trustedLaunchAndConfidentialVMSupported
+	}
+
+	publisher, err := r.azurePublisher(ctx, r.Client, hc)
+	if err != nil {
+		return "", false, err
+	}
+	return publisher.PublishImageVersion(ctx, image)
+}
+
+// requiresGen2Image returns true if the VMs of the NodePool boot Generation 2 images only.
+func requiresGen2Image(nodePool *hyperv1.NodePool) bool {
+	return nodePool.Spec.Platform.Azure != nil && nodePool.Spec.Platform.Azure.SecurityProfile != nil
+}
+
+// compatibleAzureBootImage returns false if the published boot image of the NodePool can't boot its VMs, e.g.
+// because a security profile was added to the NodePool after a Generation 1 image was published for its release.
+func compatibleAzureBootImage(nodePool *hyperv1.NodePool, image string) bool {
+	return !requiresGen2Image(nodePool) || strings.Contains(image, gen2DefinitionSuffix+"/versions/")
+}
+
+func azureArchitecture(archName string) armcompute.Architecture {
+	if archName == hyperv1.ArchAliases[hyperv1.ArchitectureARM64] {
+		return armcompute.ArchitectureArm64
+	}
+	return armcompute.ArchitectureX64
+}
+
+// galleryName returns the name of the shared image gallery of the HostedCluster. Gallery names don't allow hyphens.
+func galleryName(hc *hyperv1.HostedCluster) string {
+	return "hypershift_" + strings.ReplaceAll(hc.Spec.InfraID, "-", "_")
+}
+
+// storageAccountName returns the name of the storage account the VHDs of the HostedCluster are copied into. Storage
+// account names are globally unique, lower case alphanumeric and up to 24 characters long.
+func storageAccountName(hc *hyperv1.HostedCluster) string {
+	hash := sha256.Sum256([]byte(hc.Spec.Platform.Azure.SubscriptionID + "/" + hc.Spec.Platform.Azure.ResourceGroupName + "/" + hc.Spec.InfraID))
+	return "rhcos" + hex.EncodeToString(hash[:])[:19]
+}
+
+// armImagePublisher publishes the gallery image versions in the resource group of the HostedCluster with the Azure
+// credentials of the HostedCluster.
+type armImagePublisher struct {
+	hc          *hyperv1.HostedCluster
+	credentials azcore.TokenCredential
+}
+
+func newAzureImagePublisher(ctx context.Context, c client.Client, hc *hyperv1.HostedCluster) (azureImagePublisher, error) {
+	credentialsSecret := &corev1.Secret{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: hc.Namespace, Name: hc.Spec.Platform.Azure.Credentials.Name}, credentialsSecret); err != nil {
+		return nil, fmt.Errorf("failed to get azure credentials secret: %w", err)
+	}
+	credentials, err := azidentity.NewClientSecretCredential(
+		string(credentialsSecret.Data["AZURE_TENANT_ID"]),
+		string(credentialsSecret.Data["AZURE_CLIENT_ID"]),
+		string(credentialsSecret.Data["AZURE_CLIENT_SECRET"]), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to obtain azure client credential: %w", err)
+	}
+	return &armImagePublisher{hc: hc, credentials: credentials}, nil
+}
+
+func (p *armImagePublisher) PublishImageVersion(ctx context.Context, image galleryImage) (string, bool, error) {
+	azure := p.hc.Spec.Platform.Azure
+	resourceGroup := azure.ResourceGroupName
+
+	blobURL, ready, err := p.copyVHD(ctx, image)
+	if err != nil || !ready {
+		return "", false, err
+	}
+
+	galleriesClient, err := armcompute.NewGalleriesClient(azure.SubscriptionID, p.credentials, nil)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to create galleries client: %w", err)
+	}
+	gallery, err := galleriesClient.Get(ctx, resourceGroup, galleryName(p.hc), nil)
+	if err != nil {
+		if !isNotFound(err) {
+			return "", false, fmt.Errorf("failed to get gallery: %w", err)
+		}
+		if _, err := galleriesClient.BeginCreateOrUpdate(ctx, resourceGroup, galleryName(p.hc), armcompute.Gallery{
+			Location: ptr.To(azure.Location),
+		}, nil); err != nil {
+			return "", false, fmt.Errorf("failed to create gallery: %w", err)
+		}
+		return "", false, nil
+	}
+	if ready, err := provisioned("gallery", ptr.Deref(gallery.Properties, armcompute.GalleryProperties{}).ProvisioningState); err != nil || !ready {
+		return "", false, err
+	}
+
+	imagesClient, err := armcompute.NewGalleryImagesClient(azure.SubscriptionID, p.credentials, nil)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to create gallery images client: %w", err)
+	}
+	definition, err := imagesClient.Get(ctx, resourceGroup, galleryName(p.hc), image.Definition, nil)
+	if err != nil {
+		if !isNotFound(err) {
+			return "", false, fmt.Errorf("failed to get gallery image definition %s: %w", image.Definition, err)
+		}
+		if _, err := imagesClient.BeginCreateOrUpdate(ctx, resourceGroup, galleryName(p.hc), image.Definition, armcompute.GalleryImage{
+			Location: ptr.To(azure.Location),
+			Properties: &armcompute.GalleryImageProperties{
+				Identifier: &armcompute.GalleryImageIdentifier{
+					Publisher: ptr.To("hypershift"),
+					Offer:     ptr.To("rhcos"),
+					SKU:       ptr.To(image.Definition),
+				},
+				OSType:           ptr.To(armcompute.OperatingSystemTypesLinux),
+				OSState:          ptr.To(armcompute.OperatingSystemStateTypesGeneralized),
+				HyperVGeneration: ptr.To(image.HyperVGeneration),
+				Architecture:     ptr.To(image.Architecture),
+				Features:         galleryImageFeatures(image),
+			},
+		}, nil); err != nil {
+			return "", false, fmt.Errorf("failed to create gallery image definition %s: %w", image.Definition, err)
+		}
+		return "", false, nil
+	}
+	if ready, err := provisioned("gallery image definition", ptr.Deref(definition.Properties, armcompute.GalleryImageProperties{}).ProvisioningState); err != nil || !ready {
+		return "", false, err
+	}
+
+	versionsClient, err := armcompute.NewGalleryImageVersionsClient(azure.SubscriptionID, p.credentials, nil)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to create gallery image versions client: %w", err)
+	}
+	version, err := versionsClient.Get(ctx, resourceGroup, galleryName(p.hc), image.Definition, galleryImageVersionName, nil)
+	if err != nil {
+		if !isNotFound(err) {
+			return "", false, fmt.Errorf("failed to get gallery image version of %s: %w", image.Definition, err)
+		}
+		storageAccountID := fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Storage/storageAccounts/%s", azure.SubscriptionID, resourceGroup, storageAccountName(p.hc))
+		if _, err := versionsClient.BeginCreateOrUpdate(ctx, resourceGroup, galleryName(p.hc), image.Definition, galleryImageVersionName, armcompute.GalleryImageVersion{
+			Location: ptr.To(azure.Location),
+			Properties: &armcompute.GalleryImageVersionProperties{
+				StorageProfile: &armcompute.GalleryImageVersionStorageProfile{
+					OSDiskImage: &armcompute.GalleryOSDiskImage{
+						Source: &armcompute.GalleryDiskImageSource{
+							URI:              ptr.To(blobURL),
+							StorageAccountID: ptr.To(storageAccountID),
+						},
+					},
+				},
+			},
+		}, nil); err != nil {
+			return "", false, fmt.Errorf("failed to create gallery image version of %s: %w", image.Definition, err)
+		}
+		return "", false, nil
+	}
+	if ready, err := provisioned("gallery image version", ptr.Deref(version.Properties, armcompute.GalleryImageVersionProperties{}).ProvisioningState); err != nil || !ready {
+		return ptr.Deref(version.ID, ""), false, err
+	}
+	return ptr.Deref(version.ID, ""), true, nil
+}
+
+// copyVHD copies the RHCOS VHD into the storage account of the HostedCluster, creating the storage account when it
+// doesn't exist. It returns the URL of the copy, and true once the copy succeeded.
+func (p *armImagePublisher) copyVHD(ctx context.Context, image galleryImage) (string, bool, error) {
+	azure := p.hc.Spec.Platform.Azure
+	resourceGroup := azure.ResourceGroupName
+	accountName := storageAccountName(p.hc)
+
+	accountsClient, err := armstorage.NewAccountsClient(azure.SubscriptionID, p.credentials, nil)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to create storage accounts client: %w", err)
+	}
+	account, err := accountsClient.GetProperties(ctx, resourceGroup, accountName, nil)
+	if err != nil {
+		if !isNotFound(err) {
+			return "", false, fmt.Errorf("failed to get storage account %s: %w", accountName, err)
+		}
+		if _, err := accountsClient.BeginCreate(ctx, resourceGroup, accountName, armstorage.AccountCreateParameters{
+			SKU: &armstorage.SKU{
+				Name: ptr.To(armstorage.SKUNamePremiumLRS),
+				Tier: ptr.To(armstorage.SKUTierStandard),
+			},
+			Location: ptr.To(azure.Location),
+		}, nil); err != nil {
+			return "", false, fmt.Errorf("failed to create storage account %s: %w", accountName, err)
+		}
+		return "", false, nil
+	}
+	if account.Properties == nil || ptr.Deref(account.Properties.ProvisioningState, "") != armstorage.ProvisioningStateSucceeded {
+		return "", false, nil
+	}
+
+	containersClient, err := armstorage.NewBlobContainersClient(azure.SubscriptionID, p.credentials, nil)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to create blob containers client: %w", err)
+	}
+	if _, err := containersClient.Get(ctx, resourceGroup, accountName, vhdContainerName, nil); err != nil {
+		if !isNotFound(err) {
+			return "", false, fmt.Errorf("failed to get blob container: %w", err)
+		}
+		if _, err := containersClient.Create(ctx, resourceGroup, accountName, vhdContainerName, armstorage.BlobContainer{}, nil); err != nil {
+			return "", false, fmt.Errorf("failed to create blob container: %w", err)
+		}
+	}
+
+	// Storage objects have their own authentication, with the keys of the storage account.
+	keys, err := accountsClient.ListKeys(ctx, resourceGroup, accountName, nil)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to list storage account keys: %w", err)
+	}
+	if len(keys.Keys) == 0 || keys.Keys[0].Value == nil {
+		return "", false, errors.New("no storage account keys exist")
+	}
+	blobAuth, err := autorest.NewSharedKeyAuthorizer(accountName, *keys.Keys[0].Value, autorest.SharedKey)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to construct storage object authorizer: %w", err)
+	}
+	blobClient := blobs.New()
+	blobClient.Authorizer = blobAuth
+
+	blobName := image.Definition + ".vhd"
+	blobURL := "https://" + accountName + ".blob.core.windows.net/" + vhdContainerName + "/" + blobName
+	properties, err := blobClient.GetProperties(ctx, accountName, vhdContainerName, blobName, blobs.GetPropertiesInput{})
+	if err != nil {
+		if properties.StatusCode != http.StatusNotFound {
+			return "", false, fmt.Errorf("failed to get the properties of blob %s: %w", blobName, err)
+		}
+		if _, err := blobClient.Copy(ctx, accountName, vhdContainerName, blobName, blobs.CopyInput{
+			CopySource: image.SourceURL,
+			MetaData: map[string]string{
+				"source_uri": image.SourceURL,
+			},
+		}); err != nil {
+			return "", false, fmt.Errorf("failed to copy %s: %w", image.SourceURL, err)
+		}
+		return blobURL, false, nil
+	}
+	switch properties.CopyStatus {
+	case blobs.Success:
+		return blobURL, true, nil
+	case blobs.Pending:
+		return blobURL, false, nil
+	default:
+		return "", false, fmt.Errorf("copy of %s is %s: %s", image.SourceURL, properties.CopyStatus, properties.CopyStatusDescription)
+	}
+}
+
+func galleryImageFeatures(image galleryImage) []*armcompute.GalleryImageFeature {
+	if image.SecurityType == "" {
+		return nil
+	}
+	return []*armcompute.GalleryImageFeature{{Name: ptr.To("SecurityType"), Value: ptr.To(image.SecurityType)}}
+}
+
+func provisioned(resource string, state *armcompute.GalleryProvisioningState) (bool, error) {
+	switch ptr.Deref(state, "") {
+	case armcompute.GalleryProvisioningStateSucceeded:
+		return true, nil
+	case armcompute.GalleryProvisioningStateFailed:
+		return false, fmt.Errorf("provisioning of the %s failed", resource)
+	}
+	return false, nil
+}
+
+func isNotFound(err error) bool {
+	var responseErr *azcore.ResponseError
+	return errors.As(err, &responseErr) && responseErr.StatusCode == http.StatusNotFound
+}
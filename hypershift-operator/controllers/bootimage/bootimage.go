@@ -0,0 +1,207 @@
+package bootimage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/hypershift-operator/controllers/nodepool"
+	supportconditions "github.com/openshift/hypershift/support/conditions"
+	"github.com/openshift/hypershift/support/releaseinfo"
+	hyperutil "github.com/openshift/hypershift/support/util"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	controllerName = "nodepool-bootimage"
+
+	// pendingRequeueInterval is how often the copy of a boot image that is not available yet is checked.
+	pendingRequeueInterval = 30 * time.Second
+)
+
+// Reconciler updates the boot image in the platform spec of the NodePools with an Automatic boot image update policy
+// to the RHCOS boot image of their release: the AMI on AWS and the image ID on Azure. On AWS, the AMI of the release
+// is copied into the region of the HostedCluster when the release has none for it. On Azure, the VHD of the release
+// is published as a version in a shared image gallery of the HostedCluster resource group. Once the boot image is
+// available, the NodePool is annotated with the release it was updated for, which unblocks the rollout of the
+// release by the NodePool controller.
+type Reconciler struct {
+	client.Client
+	record.EventRecorder
+
+	ReleaseProvider releaseinfo.Provider
+
+	// EC2ClientForRegion returns an EC2 client for the region with the credentials of the operator, which copies the
+	// AMIs missing from a release. It's nil when the operator has no AWS credentials.
+	EC2ClientForRegion func(region string) ec2iface.EC2API
+
+	// azurePublisher returns the publisher of the boot images of an Azure HostedCluster.
+	azurePublisher func(ctx context.Context, c client.Client, hc *hyperv1.HostedCluster) (azureImagePublisher, error)
+}
+
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.EventRecorder = mgr.GetEventRecorderFor(controllerName)
+	if r.azurePublisher == nil {
+		r.azurePublisher = newAzureImagePublisher
+	}
+	_, err := ctrl.NewControllerManagedBy(mgr).
+		Named(controllerName).
+		For(&hyperv1.NodePool{}, builder.WithPredicates(hyperutil.PredicatesForHostedClusterAnnotationScoping(mgr.GetClient()))).
+		Build(r)
+	if err != nil {
+		return fmt.Errorf("failed setting up with a controller manager: %w", err)
+	}
+	return nil
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	nodePool := &hyperv1.NodePool{}
+	if err := r.Get(ctx, req.NamespacedName, nodePool); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("failed to get nodepool: %w", err)
+	}
+	if !nodePool.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+	if !nodepool.UpdatesBootImage(nodePool) {
+		return ctrl.Result{}, r.removeCondition(ctx, nodePool)
+	}
+
+	// The boot image was already updated for the release.
+	if current := currentBootImage(nodePool); current != "" && nodePool.Annotations[hyperv1.NodePoolBootImageReleaseAnnotation] == nodePool.Spec.Release.Image &&
+		(nodePool.Spec.Platform.Type != hyperv1.AzurePlatform || compatibleAzureBootImage(nodePool, current)) {
+		return ctrl.Result{}, r.setCondition(ctx, nodePool, corev1.ConditionTrue, hyperv1.AsExpectedReason,
+			fmt.Sprintf("Boot image is %q", current))
+	}
+
+	hc, err := nodepool.GetHostedClusterByName(ctx, r.Client, nodePool.Namespace, nodePool.Spec.ClusterName)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to get hostedcluster: %w", err)
+	}
+
+	releaseImage, err := r.lookupReleaseImage(ctx, hc, nodePool)
+	if err != nil {
+		return ctrl.Result{}, r.updateFailed(ctx, nodePool, err)
+	}
+
+	var image string
+	var available bool
+	switch nodePool.Spec.Platform.Type {
+	case hyperv1.AWSPlatform:
+		image, available, err = r.awsBootImage(ctx, hc, nodePool, releaseImage)
+	case hyperv1.AzurePlatform:
+		image, available, err = r.azureBootImage(ctx, hc, nodePool, releaseImage)
+	}
+	if err != nil {
+		return ctrl.Result{}, r.updateFailed(ctx, nodePool, err)
+	}
+	if !available {
+		log.Info("Waiting for the boot image to become available", "image", image)
+		return ctrl.Result{RequeueAfter: pendingRequeueInterval}, r.setCondition(ctx, nodePool, corev1.ConditionFalse, hyperv1.BootImageUpdatingReason,
+			fmt.Sprintf("Waiting for boot image %q of release %q to become available", image, nodePool.Spec.Release.Image))
+	}
+
+	original := nodePool.DeepCopy()
+	setBootImage(nodePool, image)
+	if nodePool.Annotations == nil {
+		nodePool.Annotations = map[string]string{}
+	}
+	nodePool.Annotations[hyperv1.NodePoolBootImageReleaseAnnotation] = nodePool.Spec.Release.Image
+	if err := r.Patch(ctx, nodePool, client.MergeFrom(original)); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to update the boot image of nodepool: %w", err)
+	}
+	log.Info("Updated the boot image", "image", image, "release", nodePool.Spec.Release.Image)
+
+	return ctrl.Result{}, r.setCondition(ctx, nodePool, corev1.ConditionTrue, hyperv1.AsExpectedReason,
+		fmt.Sprintf("Boot image is %q", image))
+}
+
+func (r *Reconciler) lookupReleaseImage(ctx context.Context, hc *hyperv1.HostedCluster, nodePool *hyperv1.NodePool) (*releaseinfo.ReleaseImage, error) {
+	pullSecret := &corev1.Secret{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: hc.Namespace, Name: hc.Spec.PullSecret.Name}, pullSecret); err != nil {
+		return nil, fmt.Errorf("failed to get pull secret: %w", err)
+	}
+	lookupCtx, cancel := context.WithTimeout(ctx, 1*time.Minute)
+	defer cancel()
+	releaseImage, err := r.ReleaseProvider.Lookup(lookupCtx, nodePool.Spec.Release.Image, pullSecret.Data[corev1.DockerConfigJsonKey])
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up release image metadata: %w", err)
+	}
+	if releaseImage.StreamMetadata == nil {
+		return nil, fmt.Errorf("release image %q has no RHCOS stream metadata", nodePool.Spec.Release.Image)
+	}
+	return releaseImage, nil
+}
+
+// updateFailed reports the failure to update the boot image of the NodePool and returns the error to retry.
+func (r *Reconciler) updateFailed(ctx context.Context, nodePool *hyperv1.NodePool, updateErr error) error {
+	r.Eventf(nodePool, corev1.EventTypeWarning, hyperv1.BootImageUpdateFailedReason, "Failed to update the boot image: %v", updateErr)
+	reason := supportconditions.ReasonForError(updateErr, hyperv1.BootImageUpdateFailedReason)
+	if err := r.setCondition(ctx, nodePool, corev1.ConditionFalse, reason, updateErr.Error()); err != nil {
+		return err
+	}
+	return updateErr
+}
+
+func (r *Reconciler) setCondition(ctx context.Context, nodePool *hyperv1.NodePool, status corev1.ConditionStatus, reason, message string) error {
+	original := nodePool.DeepCopy()
+	nodepool.SetStatusCondition(&nodePool.Status.Conditions, hyperv1.NodePoolCondition{
+		Type:               hyperv1.NodePoolBootImageUpdatedConditionType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: nodePool.Generation,
+	})
+	if err := r.Status().Patch(ctx, nodePool, client.MergeFrom(original)); err != nil {
+		return fmt.Errorf("failed to update nodepool status: %w", err)
+	}
+	return nil
+}
+
+func (r *Reconciler) removeCondition(ctx context.Context, nodePool *hyperv1.NodePool) error {
+	if nodepool.FindStatusCondition(nodePool.Status.Conditions, hyperv1.NodePoolBootImageUpdatedConditionType) == nil {
+		return nil
+	}
+	original := nodePool.DeepCopy()
+	var conditions []hyperv1.NodePoolCondition
+	for _, condition := range nodePool.Status.Conditions {
+		if condition.Type != hyperv1.NodePoolBootImageUpdatedConditionType {
+			conditions = append(conditions, condition)
+		}
+	}
+	nodePool.Status.Conditions = conditions
+	if err := r.Status().Patch(ctx, nodePool, client.MergeFrom(original)); err != nil {
+		return fmt.Errorf("failed to update nodepool status: %w", err)
+	}
+	return nil
+}
+
+func currentBootImage(nodePool *hyperv1.NodePool) string {
+	switch nodePool.Spec.Platform.Type {
+	case hyperv1.AWSPlatform:
+		return nodePool.Spec.Platform.AWS.AMI
+	case hyperv1.AzurePlatform:
+		return nodePool.Spec.Platform.Azure.ImageID
+	}
+	return ""
+}
+
+func setBootImage(nodePool *hyperv1.NodePool, image string) {
+	switch nodePool.Spec.Platform.Type {
+	case hyperv1.AWSPlatform:
+		nodePool.Spec.Platform.AWS.AMI = image
+	case hyperv1.AzurePlatform:
+		nodePool.Spec.Platform.Azure.ImageID = image
+	}
+}
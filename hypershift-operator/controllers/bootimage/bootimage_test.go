@@ -0,0 +1,374 @@
+package bootimage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	. "github.com/onsi/gomega"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/hypershift-operator/controllers/nodepool"
+	"github.com/openshift/hypershift/support/api"
+	"github.com/openshift/hypershift/support/releaseinfo"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const releaseImage = "quay.io/openshift-release-dev/ocp-release:4.16.0-x86_64"
+
+type fakeReleaseProvider struct{}
+
+func (fakeReleaseProvider) Lookup(context.Context, string, []byte) (*releaseinfo.ReleaseImage, error) {
+	architecture := releaseinfo.CoreOSArchitecture{}
+	architecture.Images.AWS.Regions = map[string]releaseinfo.CoreOSAWSImage{
+		"us-east-1": {Release: "416.94.202405291527-0", Image: "ami-us-east-1"},
+		"us-west-2": {Release: "416.94.202405291527-0", Image: "ami-us-west-2"},
+	}
+	architecture.RHCOS.AzureDisk = releaseinfo.CoreAzureDisk{
+		Release: "416.94.202405291527-0",
+		URL:     "https://rhcos.blob.core.windows.net/imagebucket/rhcos-416.94.202405291527-0-azure.x86_64.vhd",
+	}
+	return &releaseinfo.ReleaseImage{
+		StreamMetadata: &releaseinfo.CoreOSStreamMetadata{
+			Architectures: map[string]releaseinfo.CoreOSArchitecture{"x86_64": architecture},
+		},
+	}, nil
+}
+
+type fakeEC2Client struct {
+	ec2iface.EC2API
+	images      []*ec2.Image
+	copied      *ec2.CopyImageInput
+	sharedWith  string
+	copiedImage string
+}
+
+func (f *fakeEC2Client) DescribeImagesWithContext(aws.Context, *ec2.DescribeImagesInput, ...request.Option) (*ec2.DescribeImagesOutput, error) {
+	return &ec2.DescribeImagesOutput{Images: f.images}, nil
+}
+
+func (f *fakeEC2Client) CopyImageWithContext(_ aws.Context, input *ec2.CopyImageInput, _ ...request.Option) (*ec2.CopyImageOutput, error) {
+	f.copied = input
+	return &ec2.CopyImageOutput{ImageId: aws.String(f.copiedImage)}, nil
+}
+
+func (f *fakeEC2Client) ModifyImageAttributeWithContext(_ aws.Context, input *ec2.ModifyImageAttributeInput, _ ...request.Option) (*ec2.ModifyImageAttributeOutput, error) {
+	f.sharedWith = aws.StringValue(input.LaunchPermission.Add[0].UserId)
+	return &ec2.ModifyImageAttributeOutput{}, nil
+}
+
+type fakeAzurePublisher struct {
+	published galleryImage
+	available bool
+}
+
+func (f *fakeAzurePublisher) PublishImageVersion(_ context.Context, image galleryImage) (string, bool, error) {
+	f.published = image
+	return "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/galleries/hypershift_infra/images/" + image.Definition + "/versions/1.0.0", f.available, nil
+}
+
+func TestReconcile(t *testing.T) {
+	testCases := []struct {
+		name               string
+		platform           hyperv1.PlatformType
+		region             string
+		policy             hyperv1.BootImageUpdatePolicy
+		annotations        map[string]string
+		ami                string
+		conditions         []hyperv1.NodePoolCondition
+		existingImages     []*ec2.Image
+		noAWSCredentials   bool
+		azureAvailable     bool
+		imageID            string
+		securityProfile    *hyperv1.AzureVMSecurityProfile
+		expectAMI          string
+		expectImageID      string
+		expectAnnotated    bool
+		expectCopyFrom     string
+		expectSharedWith   string
+		expectCondition    *corev1.ConditionStatus
+		expectReason       string
+		expectRequeueAfter time.Duration
+		expectError        bool
+	}{
+		{
+			name:       "When the policy is Manual it should not update the boot image",
+			platform:   hyperv1.AWSPlatform,
+			region:     "us-east-1",
+			policy:     hyperv1.BootImageUpdatePolicyManual,
+			ami:        "ami-custom",
+			conditions: []hyperv1.NodePoolCondition{{Type: hyperv1.NodePoolBootImageUpdatedConditionType, Status: corev1.ConditionTrue}},
+			expectAMI:  "ami-custom",
+		},
+		{
+			name:            "When the release has an AMI for the region it should update the AMI",
+			platform:        hyperv1.AWSPlatform,
+			region:          "us-west-2",
+			policy:          hyperv1.BootImageUpdatePolicyAutomatic,
+			ami:             "ami-previous",
+			expectAMI:       "ami-us-west-2",
+			expectAnnotated: true,
+			expectCondition: ptr.To(corev1.ConditionTrue),
+			expectReason:    hyperv1.AsExpectedReason,
+		},
+		{
+			name:            "When the boot image was already updated for the release it should leave it untouched",
+			platform:        hyperv1.AWSPlatform,
+			region:          "us-west-2",
+			policy:          hyperv1.BootImageUpdatePolicyAutomatic,
+			annotations:     map[string]string{hyperv1.NodePoolBootImageReleaseAnnotation: releaseImage},
+			ami:             "ami-custom",
+			expectAMI:       "ami-custom",
+			expectAnnotated: true,
+			expectCondition: ptr.To(corev1.ConditionTrue),
+			expectReason:    hyperv1.AsExpectedReason,
+		},
+		{
+			name:               "When the release has no AMI for the region it should copy the AMI and wait for it",
+			platform:           hyperv1.AWSPlatform,
+			region:             "us-east-2",
+			policy:             hyperv1.BootImageUpdatePolicyAutomatic,
+			ami:                "ami-previous",
+			expectAMI:          "ami-previous",
+			expectCopyFrom:     "us-east-1",
+			expectCondition:    ptr.To(corev1.ConditionFalse),
+			expectReason:       hyperv1.BootImageUpdatingReason,
+			expectRequeueAfter: pendingRequeueInterval,
+		},
+		{
+			name:     "When the copied AMI is available it should share it and update the AMI",
+			platform: hyperv1.AWSPlatform,
+			region:   "us-east-2",
+			policy:   hyperv1.BootImageUpdatePolicyAutomatic,
+			existingImages: []*ec2.Image{{
+				ImageId: aws.String("ami-copy"),
+				OwnerId: aws.String("111111111111"),
+				State:   aws.String(ec2.ImageStateAvailable),
+			}},
+			expectAMI:        "ami-copy",
+			expectAnnotated:  true,
+			expectSharedWith: "222222222222",
+			expectCondition:  ptr.To(corev1.ConditionTrue),
+			expectReason:     hyperv1.AsExpectedReason,
+		},
+		{
+			name:             "When the release has no AMI for the region and the operator has no AWS credentials it should fail",
+			platform:         hyperv1.AWSPlatform,
+			region:           "us-east-2",
+			policy:           hyperv1.BootImageUpdatePolicyAutomatic,
+			noAWSCredentials: true,
+			expectCondition:  ptr.To(corev1.ConditionFalse),
+			expectReason:     hyperv1.BootImageUpdateFailedReason,
+			expectError:      true,
+		},
+		{
+			name:               "When the Azure gallery image version is not available it should wait for it",
+			platform:           hyperv1.AzurePlatform,
+			policy:             hyperv1.BootImageUpdatePolicyAutomatic,
+			expectCondition:    ptr.To(corev1.ConditionFalse),
+			expectReason:       hyperv1.BootImageUpdatingReason,
+			expectRequeueAfter: pendingRequeueInterval,
+		},
+		{
+			name:            "When the Azure gallery image version is available it should update the image ID",
+			platform:        hyperv1.AzurePlatform,
+			policy:          hyperv1.BootImageUpdatePolicyAutomatic,
+			azureAvailable:  true,
+			expectImageID:   "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/galleries/hypershift_infra/images/rhcos-416.94.202405291527-0-x86_64/versions/1.0.0",
+			expectAnnotated: true,
+			expectCondition: ptr.To(corev1.ConditionTrue),
+			expectReason:    hyperv1.AsExpectedReason,
+		},
+		{
+			name:            "When the NodePool requires trusted launch and has a Generation 1 image of the release it should publish a Generation 2 image",
+			platform:        hyperv1.AzurePlatform,
+			policy:          hyperv1.BootImageUpdatePolicyAutomatic,
+			annotations:     map[string]string{hyperv1.NodePoolBootImageReleaseAnnotation: releaseImage},
+			imageID:         "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/galleries/hypershift_infra/images/rhcos-416.94.202405291527-0-x86_64/versions/1.0.0",
+			securityProfile: &hyperv1.AzureVMSecurityProfile{SecurityType: hyperv1.AzureVMSecurityTypeTrustedLaunch},
+			azureAvailable:  true,
+			expectImageID:   "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/galleries/hypershift_infra/images/rhcos-416.94.202405291527-0-x86_64-gen2/versions/1.0.0",
+			expectAnnotated: true,
+			expectCondition: ptr.To(corev1.ConditionTrue),
+			expectReason:    hyperv1.AsExpectedReason,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			hc := &hyperv1.HostedCluster{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "hc"},
+				Spec: hyperv1.HostedClusterSpec{
+					InfraID:    "infra",
+					PullSecret: corev1.LocalObjectReference{Name: "pull-secret"},
+					Platform:   hyperv1.PlatformSpec{Type: tc.platform},
+				},
+			}
+			nodePool := &hyperv1.NodePool{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "np", Annotations: tc.annotations},
+				Spec: hyperv1.NodePoolSpec{
+					ClusterName:           "hc",
+					Arch:                  hyperv1.ArchitectureAMD64,
+					Release:               hyperv1.Release{Image: releaseImage},
+					BootImageUpdatePolicy: tc.policy,
+					Platform:              hyperv1.NodePoolPlatform{Type: tc.platform},
+				},
+				Status: hyperv1.NodePoolStatus{Conditions: tc.conditions},
+			}
+			switch tc.platform {
+			case hyperv1.AWSPlatform:
+				hc.Spec.Platform.AWS = &hyperv1.AWSPlatformSpec{
+					Region:   tc.region,
+					RolesRef: hyperv1.AWSRolesRef{NodePoolManagementARN: "arn:aws:iam::222222222222:role/nodepool-management"},
+				}
+				nodePool.Spec.Platform.AWS = &hyperv1.AWSNodePoolPlatform{AMI: tc.ami}
+			case hyperv1.AzurePlatform:
+				hc.Spec.Platform.Azure = &hyperv1.AzurePlatformSpec{}
+				nodePool.Spec.Platform.Azure = &hyperv1.AzureNodePoolPlatform{ImageID: tc.imageID, SecurityProfile: tc.securityProfile}
+			}
+			pullSecret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "pull-secret"},
+				Data:       map[string][]byte{corev1.DockerConfigJsonKey: []byte("{}")},
+			}
+
+			c := fake.NewClientBuilder().WithScheme(api.Scheme).
+				WithObjects(hc, nodePool, pullSecret).
+				WithStatusSubresource(&hyperv1.NodePool{}).
+				Build()
+			ec2Client := &fakeEC2Client{images: tc.existingImages, copiedImage: "ami-copy"}
+			azurePublisher := &fakeAzurePublisher{available: tc.azureAvailable}
+			r := &Reconciler{
+				Client:          c,
+				EventRecorder:   record.NewFakeRecorder(10),
+				ReleaseProvider: fakeReleaseProvider{},
+				azurePublisher: func(context.Context, client.Client, *hyperv1.HostedCluster) (azureImagePublisher, error) {
+					return azurePublisher, nil
+				},
+			}
+			if !tc.noAWSCredentials {
+				r.EC2ClientForRegion = func(string) ec2iface.EC2API { return ec2Client }
+			}
+
+			result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(nodePool)})
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+			g.Expect(result.RequeueAfter).To(Equal(tc.expectRequeueAfter))
+
+			g.Expect(c.Get(context.Background(), client.ObjectKeyFromObject(nodePool), nodePool)).To(Succeed())
+			switch tc.platform {
+			case hyperv1.AWSPlatform:
+				g.Expect(nodePool.Spec.Platform.AWS.AMI).To(Equal(tc.expectAMI))
+			case hyperv1.AzurePlatform:
+				g.Expect(nodePool.Spec.Platform.Azure.ImageID).To(Equal(tc.expectImageID))
+				g.Expect(azurePublisher.published.SourceURL).To(HaveSuffix("azure.x86_64.vhd"))
+				if tc.securityProfile != nil {
+					g.Expect(azurePublisher.published.HyperVGeneration).To(Equal(armcompute.HyperVGenerationV2))
+					g.Expect(azurePublisher.published.SecurityType).To(Equal(trustedLaunchAndConfidentialVMSupported))
+				} else {
+					g.Expect(azurePublisher.published.HyperVGeneration).To(Equal(armcompute.HyperVGenerationV1))
+				}
+			}
+			if tc.expectAnnotated {
+				g.Expect(nodePool.Annotations).To(HaveKeyWithValue(hyperv1.NodePoolBootImageReleaseAnnotation, releaseImage))
+			} else {
+				g.Expect(nodePool.Annotations).ToNot(HaveKey(hyperv1.NodePoolBootImageReleaseAnnotation))
+			}
+
+			if tc.expectCopyFrom != "" {
+				g.Expect(ec2Client.copied).ToNot(BeNil())
+				g.Expect(aws.StringValue(ec2Client.copied.SourceRegion)).To(Equal(tc.expectCopyFrom))
+				g.Expect(aws.StringValue(ec2Client.copied.SourceImageId)).To(Equal("ami-" + tc.expectCopyFrom))
+			} else {
+				g.Expect(ec2Client.copied).To(BeNil())
+			}
+			g.Expect(ec2Client.sharedWith).To(Equal(tc.expectSharedWith))
+
+			condition := nodepool.FindStatusCondition(nodePool.Status.Conditions, hyperv1.NodePoolBootImageUpdatedConditionType)
+			if tc.expectCondition == nil {
+				g.Expect(condition).To(BeNil())
+				return
+			}
+			g.Expect(condition).ToNot(BeNil())
+			g.Expect(condition.Status).To(Equal(*tc.expectCondition))
+			g.Expect(condition.Reason).To(Equal(tc.expectReason))
+		})
+	}
+}
+
+func TestSourceAMI(t *testing.T) {
+	testCases := []struct {
+		name         string
+		region       string
+		images       map[string]releaseinfo.CoreOSAWSImage
+		expectRegion string
+		expectFound  bool
+	}{
+		{
+			name:   "When the release has an AMI for the preferred region it should copy it",
+			region: "eu-south-2",
+			images: map[string]releaseinfo.CoreOSAWSImage{
+				"ap-south-1": {Image: "ami-ap-south-1"},
+				"us-east-1":  {Image: "ami-us-east-1"},
+			},
+			expectRegion: "us-east-1",
+			expectFound:  true,
+		},
+		{
+			name:   "When the release has no AMI for the preferred region it should copy the AMI of the first region",
+			region: "eu-south-2",
+			images: map[string]releaseinfo.CoreOSAWSImage{
+				"us-west-2":  {Image: "ami-us-west-2"},
+				"ap-south-1": {Image: "ami-ap-south-1"},
+			},
+			expectRegion: "ap-south-1",
+			expectFound:  true,
+		},
+		{
+			name:   "When the release only has AMIs in another partition it should not find an AMI to copy",
+			region: "us-gov-west-1",
+			images: map[string]releaseinfo.CoreOSAWSImage{
+				"us-east-1": {Image: "ami-us-east-1"},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			region, image, found := sourceAMI(tc.region, tc.images)
+			g.Expect(found).To(Equal(tc.expectFound))
+			if tc.expectFound {
+				g.Expect(region).To(Equal(tc.expectRegion))
+				g.Expect(image.Image).To(Equal("ami-" + tc.expectRegion))
+			}
+		})
+	}
+}
+
+func TestStorageAccountName(t *testing.T) {
+	g := NewWithT(t)
+	hc := &hyperv1.HostedCluster{Spec: hyperv1.HostedClusterSpec{
+		InfraID: "my-cluster-abcde",
+		Platform: hyperv1.PlatformSpec{Azure: &hyperv1.AzurePlatformSpec{
+			SubscriptionID:    "00000000-0000-0000-0000-000000000000",
+			ResourceGroupName: "my-cluster-rg",
+		}},
+	}}
+	name := storageAccountName(hc)
+	g.Expect(name).To(MatchRegexp("^[a-z0-9]{3,24}$"))
+	g.Expect(storageAccountName(hc)).To(Equal(name))
+	g.Expect(galleryName(hc)).To(Equal("hypershift_my_cluster_abcde"))
+}
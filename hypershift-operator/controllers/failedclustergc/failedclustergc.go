@@ -0,0 +1,197 @@
+package failedclustergc
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	hcmetrics "github.com/openshift/hypershift/hypershift-operator/controllers/hostedcluster/metrics"
+	"github.com/openshift/hypershift/hypershift-operator/controllers/manifests"
+	hyperutil "github.com/openshift/hypershift/support/util"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	crmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	controllerName = "failed-cluster-gc"
+
+	// GarbageCollectedReason is the reason of the event recorded on the HostedClusters deleted by this controller.
+	GarbageCollectedReason = "GarbageCollected"
+
+	garbageCollectedMetricName = "hypershift_failed_cluster_garbage_collected_total"
+)
+
+// provisioningFailureConditions are the HostedCluster conditions which, when false, mean that the provisioning of
+// the cluster failed and can't progress without a change to the cluster or to its credentials.
+var provisioningFailureConditions = []hyperv1.ConditionType{
+	hyperv1.ValidHostedClusterConfiguration,
+	hyperv1.SupportedHostedCluster,
+	hyperv1.ValidReleaseImage,
+	hyperv1.ReleaseImageVerified,
+	hyperv1.ValidFIPSConfiguration,
+	hyperv1.ValidReleaseInfo,
+	hyperv1.ValidHostedControlPlaneConfiguration,
+	hyperv1.ValidOIDCConfiguration,
+	hyperv1.ValidAWSIdentityProvider,
+	hyperv1.ValidAWSKMSConfig,
+	hyperv1.ValidAzureKMSConfig,
+	hyperv1.ValidIBMCloudKMSConfig,
+	hyperv1.PlatformCredentialsFound,
+	hyperv1.ReconciliationSucceeded,
+}
+
+var garbageCollectedClusters = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: garbageCollectedMetricName,
+	Help: "Number of HostedClusters deleted because their provisioning failed for longer than the garbage collection TTL, by failed condition",
+}, []string{"condition"})
+
+func init() {
+	crmetrics.Registry.MustRegister(garbageCollectedClusters)
+}
+
+// Reconciler deletes the HostedClusters that never became available and whose provisioning has been failing for
+// longer than the TTL, e.g. because of invalid credentials, so that their partially created control plane resources
+// are reclaimed. What was reclaimed is reported with an event on the HostedCluster.
+type Reconciler struct {
+	client.Client
+	record.EventRecorder
+
+	// TTL is how long the provisioning of a HostedCluster may be failing before it's deleted.
+	TTL time.Duration
+
+	// CleanupCloudResources requests the cleanup of the cloud infrastructure of the deleted HostedClusters. When
+	// false, their cloud infrastructure is left intact with the OrphanInfra deletion policy.
+	CleanupCloudResources bool
+
+	now func() time.Time
+}
+
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.EventRecorder = mgr.GetEventRecorderFor(controllerName)
+	r.now = time.Now
+	_, err := ctrl.NewControllerManagedBy(mgr).
+		Named(controllerName).
+		For(&hyperv1.HostedCluster{}, builder.WithPredicates(hyperutil.PredicatesForHostedClusterAnnotationScoping(mgr.GetClient()))).
+		Build(r)
+	if err != nil {
+		return fmt.Errorf("failed setting up with a controller manager: %w", err)
+	}
+	return nil
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	hc := &hyperv1.HostedCluster{}
+	if err := r.Get(ctx, req.NamespacedName, hc); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("failed to get hostedcluster: %w", err)
+	}
+	if !hc.DeletionTimestamp.IsZero() || hc.Spec.PausedUntil != nil {
+		return ctrl.Result{}, nil
+	}
+	if _, hasBeenAvailable := hc.Annotations[hcmetrics.HasBeenAvailableAnnotation]; hasBeenAvailable {
+		return ctrl.Result{}, nil
+	}
+
+	failure := provisioningFailure(hc)
+	if failure == nil {
+		return ctrl.Result{}, nil
+	}
+	if failingFor := r.now().Sub(failure.LastTransitionTime.Time); failingFor < r.TTL {
+		return ctrl.Result{RequeueAfter: r.TTL - failingFor}, nil
+	}
+	if !hyperutil.HCDeletionAllowed(hc) {
+		log.Info("Not deleting failed hostedcluster because its deletion is not allowed", "condition", failure.Type)
+		return ctrl.Result{}, nil
+	}
+
+	nodePools, err := r.nodePoolNames(ctx, hc)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	original := hc.DeepCopy()
+	if r.CleanupCloudResources {
+		if hc.Annotations == nil {
+			hc.Annotations = map[string]string{}
+		}
+		hc.Annotations[hyperv1.CleanupCloudResourcesAnnotation] = "true"
+	} else if hyperutil.HCDeletionPolicyType(hc) == hyperv1.DeletionPolicyDelete {
+		hc.Spec.DeletionPolicy = &hyperv1.DeletionPolicy{Type: hyperv1.DeletionPolicyOrphanInfra}
+	}
+	if err := r.Patch(ctx, hc, client.MergeFrom(original)); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to prepare the deletion of hostedcluster: %w", err)
+	}
+	if err := r.Delete(ctx, hc); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("failed to delete hostedcluster: %w", err)
+	}
+
+	message := reclaimedMessage(hc, failure, nodePools, r.TTL)
+	log.Info("Deleted failed hostedcluster", "condition", failure.Type, "reason", failure.Reason, "reclaimed", message)
+	r.Event(hc, corev1.EventTypeWarning, GarbageCollectedReason, message)
+	garbageCollectedClusters.WithLabelValues(failure.Type).Inc()
+	return ctrl.Result{}, nil
+}
+
+func (r *Reconciler) nodePoolNames(ctx context.Context, hc *hyperv1.HostedCluster) ([]string, error) {
+	nodePools := &hyperv1.NodePoolList{}
+	if err := r.List(ctx, nodePools, client.InNamespace(hc.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list nodepools: %w", err)
+	}
+	var names []string
+	for _, nodePool := range nodePools.Items {
+		if nodePool.Spec.ClusterName == hc.Name {
+			names = append(names, nodePool.Name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// provisioningFailure returns the provisioning failure condition of the HostedCluster that has been false for the
+// longest time, or nil if its provisioning isn't failing.
+func provisioningFailure(hc *hyperv1.HostedCluster) *metav1.Condition {
+	var failure *metav1.Condition
+	for _, conditionType := range provisioningFailureConditions {
+		condition := meta.FindStatusCondition(hc.Status.Conditions, string(conditionType))
+		if condition == nil || condition.Status != metav1.ConditionFalse {
+			continue
+		}
+		if failure == nil || condition.LastTransitionTime.Before(&failure.LastTransitionTime) {
+			failure = condition
+		}
+	}
+	return failure
+}
+
+// reclaimedMessage describes why the HostedCluster was deleted and what its deletion reclaims.
+func reclaimedMessage(hc *hyperv1.HostedCluster, failure *metav1.Condition, nodePools []string, ttl time.Duration) string {
+	reclaimed := []string{fmt.Sprintf("control plane namespace %s", manifests.HostedControlPlaneNamespace(hc.Namespace, hc.Name))}
+	if len(nodePools) > 0 {
+		reclaimed = append(reclaimed, fmt.Sprintf("NodePools %s", strings.Join(nodePools, ", ")))
+	}
+	if hyperutil.HCDeletionPolicyType(hc) == hyperv1.DeletionPolicyOrphanInfra {
+		reclaimed = append(reclaimed, "cloud infrastructure left intact")
+	} else {
+		reclaimed = append(reclaimed, "cloud infrastructure")
+	}
+	return fmt.Sprintf("Deleted the HostedCluster because its provisioning has been failing for more than %s (%s: %s). Reclaimed: %s",
+		ttl, failure.Type, failure.Message, strings.Join(reclaimed, "; "))
+}
@@ -0,0 +1,124 @@
+package failedclustergc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	hcmetrics "github.com/openshift/hypershift/hypershift-operator/controllers/hostedcluster/metrics"
+	"github.com/openshift/hypershift/support/api"
+	hyperutil "github.com/openshift/hypershift/support/util"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReconcile(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	failedCondition := func(failingFor time.Duration) metav1.Condition {
+		return metav1.Condition{
+			Type:               string(hyperv1.ValidAWSIdentityProvider),
+			Status:             metav1.ConditionFalse,
+			Reason:             hyperv1.InvalidIdentityProvider,
+			Message:            "invalid credentials",
+			LastTransitionTime: metav1.NewTime(now.Add(-failingFor)),
+		}
+	}
+
+	testCases := []struct {
+		name                  string
+		annotations           map[string]string
+		deletionPolicy        *hyperv1.DeletionPolicy
+		conditions            []metav1.Condition
+		cleanupCloudResources bool
+		expectDeleted         bool
+		expectRequeueAfter    time.Duration
+		expectDeletionPolicy  hyperv1.DeletionPolicyType
+	}{
+		{
+			name: "When the provisioning is not failing it should not delete the cluster",
+			conditions: []metav1.Condition{
+				{Type: string(hyperv1.ValidAWSIdentityProvider), Status: metav1.ConditionTrue, LastTransitionTime: metav1.NewTime(now.Add(-2 * time.Hour))},
+			},
+		},
+		{
+			name:               "When the provisioning has been failing for less than the TTL it should requeue",
+			conditions:         []metav1.Condition{failedCondition(20 * time.Minute)},
+			expectRequeueAfter: 40 * time.Minute,
+		},
+		{
+			name:                 "When the provisioning has been failing for longer than the TTL it should delete the cluster and orphan its infrastructure",
+			conditions:           []metav1.Condition{failedCondition(2 * time.Hour)},
+			expectDeleted:        true,
+			expectDeletionPolicy: hyperv1.DeletionPolicyOrphanInfra,
+		},
+		{
+			name:                  "When the cloud resources cleanup is enabled it should delete the cluster and its infrastructure",
+			conditions:            []metav1.Condition{failedCondition(2 * time.Hour)},
+			cleanupCloudResources: true,
+			expectDeleted:         true,
+			expectDeletionPolicy:  hyperv1.DeletionPolicyDelete,
+		},
+		{
+			name:        "When the cluster has been available it should not delete the cluster",
+			annotations: map[string]string{hcmetrics.HasBeenAvailableAnnotation: "true"},
+			conditions:  []metav1.Condition{failedCondition(2 * time.Hour)},
+		},
+		{
+			name:           "When the cluster deletion is protected it should not delete the cluster",
+			deletionPolicy: &hyperv1.DeletionPolicy{Type: hyperv1.DeletionPolicyProtect},
+			conditions:     []metav1.Condition{failedCondition(2 * time.Hour)},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			hc := &hyperv1.HostedCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "clusters",
+					Name:        "hc",
+					Annotations: tc.annotations,
+					Finalizers:  []string{"hypershift.openshift.io/finalizer"},
+				},
+				Spec:   hyperv1.HostedClusterSpec{DeletionPolicy: tc.deletionPolicy},
+				Status: hyperv1.HostedClusterStatus{Conditions: tc.conditions},
+			}
+			nodePool := &hyperv1.NodePool{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "np"},
+				Spec:       hyperv1.NodePoolSpec{ClusterName: "hc"},
+			}
+			c := fake.NewClientBuilder().WithScheme(api.Scheme).WithObjects(hc, nodePool).Build()
+			recorder := record.NewFakeRecorder(1)
+			r := &Reconciler{
+				Client:                c,
+				EventRecorder:         recorder,
+				TTL:                   time.Hour,
+				CleanupCloudResources: tc.cleanupCloudResources,
+				now:                   func() time.Time { return now },
+			}
+
+			result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(hc)})
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(result.RequeueAfter).To(Equal(tc.expectRequeueAfter))
+
+			err = c.Get(context.Background(), client.ObjectKeyFromObject(hc), hc)
+			g.Expect(apierrors.IsNotFound(err)).To(BeFalse())
+			if !tc.expectDeleted {
+				g.Expect(hc.DeletionTimestamp).To(BeNil())
+				g.Expect(recorder.Events).To(BeEmpty())
+				return
+			}
+			g.Expect(hc.DeletionTimestamp).ToNot(BeNil())
+			g.Expect(recorder.Events).To(Receive(And(ContainSubstring(GarbageCollectedReason), ContainSubstring("NodePools np"))))
+			if tc.cleanupCloudResources {
+				g.Expect(hc.Annotations).To(HaveKeyWithValue(hyperv1.CleanupCloudResourcesAnnotation, "true"))
+			}
+			g.Expect(hyperutil.HCDeletionPolicyType(hc)).To(Equal(tc.expectDeletionPolicy))
+		})
+	}
+}
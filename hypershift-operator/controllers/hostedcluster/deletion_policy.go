@@ -0,0 +1,97 @@
+package hostedcluster
+
+import (
+	"context"
+	"fmt"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/hypershift-operator/controllers/manifests"
+	"github.com/openshift/hypershift/hypershift-operator/controllers/manifests/controlplaneoperator"
+	hyperutil "github.com/openshift/hypershift/support/util"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	capiv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// deletionProtectedCondition returns the DeletionProtected condition of a deleted HostedCluster whose deletion is
+// blocked by the Protect deletion policy.
+func deletionProtectedCondition(hc *hyperv1.HostedCluster) metav1.Condition {
+	return metav1.Condition{
+		Type:               string(hyperv1.DeletionProtected),
+		Status:             metav1.ConditionTrue,
+		Reason:             hyperv1.DeletionNotAllowedReason,
+		Message:            fmt.Sprintf("The deletion policy is %s, set the %s annotation to \"true\" to allow the deletion", hyperv1.DeletionPolicyProtect, hyperv1.AllowDeletionAnnotation),
+		ObservedGeneration: hc.Generation,
+	}
+}
+
+// setDeletionProtectedCondition sets the DeletionProtected condition on a deleted HostedCluster whose deletion
+// is blocked by the Protect deletion policy, and returns whether the condition changed.
+func setDeletionProtectedCondition(hc *hyperv1.HostedCluster) bool {
+	condition := deletionProtectedCondition(hc)
+	if existing := meta.FindStatusCondition(hc.Status.Conditions, condition.Type); existing != nil &&
+		existing.Status == condition.Status && existing.Message == condition.Message {
+		return false
+	}
+	meta.SetStatusCondition(&hc.Status.Conditions, condition)
+	return true
+}
+
+// removeDeletionFinalizers removes the finalizers blocking the cleanup of a deleted HostedCluster, orphaning
+// whatever they were cleaning up, and deletes its control plane namespace. It's used by the Force deletion policy
+// once the cleanup of the HostedCluster takes longer than its force timeout.
+func (r *HostedClusterReconciler) removeDeletionFinalizers(ctx context.Context, hc *hyperv1.HostedCluster) error {
+	log := ctrl.LoggerFrom(ctx)
+	controlPlaneNamespace := manifests.HostedControlPlaneNamespace(hc.Namespace, hc.Name)
+
+	var objects []client.Object
+	nodePools, err := listNodePools(ctx, r.Client, hc.Namespace, hc.Name)
+	if err != nil {
+		return err
+	}
+	for i := range nodePools {
+		objects = append(objects, &nodePools[i])
+	}
+	var awsEndpointServices hyperv1.AWSEndpointServiceList
+	if err := r.List(ctx, &awsEndpointServices, client.InNamespace(controlPlaneNamespace)); err != nil {
+		return fmt.Errorf("failed to list awsendpointservices in namespace %s: %w", controlPlaneNamespace, err)
+	}
+	for i := range awsEndpointServices.Items {
+		objects = append(objects, &awsEndpointServices.Items[i])
+	}
+	hcp := controlplaneoperator.HostedControlPlane(controlPlaneNamespace, hc.Name)
+	if err := r.Get(ctx, client.ObjectKeyFromObject(hcp), hcp); err == nil {
+		objects = append(objects, hcp)
+	} else if !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to get hosted control plane: %w", err)
+	}
+	if len(hc.Spec.InfraID) > 0 {
+		cluster := &capiv1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: controlPlaneNamespace, Name: hc.Spec.InfraID}}
+		if err := r.Get(ctx, client.ObjectKeyFromObject(cluster), cluster); err == nil {
+			objects = append(objects, cluster)
+		} else if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get cluster: %w", err)
+		}
+	}
+
+	for _, obj := range objects {
+		if len(obj.GetFinalizers()) == 0 {
+			continue
+		}
+		original := obj.DeepCopyObject().(client.Object)
+		obj.SetFinalizers(nil)
+		if err := r.Patch(ctx, obj, client.MergeFrom(original)); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to remove finalizers from %T %s: %w", obj, client.ObjectKeyFromObject(obj), err)
+		}
+		log.Info("Removed finalizers because of the Force deletion policy", "type", fmt.Sprintf("%T", obj), "object", client.ObjectKeyFromObject(obj))
+	}
+
+	if _, err := hyperutil.DeleteIfNeeded(ctx, r.Client, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: controlPlaneNamespace}}); err != nil {
+		return err
+	}
+	return nil
+}
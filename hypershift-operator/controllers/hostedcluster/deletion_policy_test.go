@@ -0,0 +1,70 @@
+package hostedcluster
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/hypershift-operator/controllers/manifests"
+	"github.com/openshift/hypershift/hypershift-operator/controllers/manifests/controlplaneoperator"
+	"github.com/openshift/hypershift/support/api"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestSetDeletionProtectedCondition(t *testing.T) {
+	g := NewWithT(t)
+	hc := &hyperv1.HostedCluster{ObjectMeta: metav1.ObjectMeta{Generation: 2}}
+
+	g.Expect(setDeletionProtectedCondition(hc)).To(BeTrue())
+	condition := meta.FindStatusCondition(hc.Status.Conditions, string(hyperv1.DeletionProtected))
+	g.Expect(condition).ToNot(BeNil())
+	g.Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+	g.Expect(condition.Reason).To(Equal(hyperv1.DeletionNotAllowedReason))
+	g.Expect(condition.ObservedGeneration).To(Equal(int64(2)))
+
+	g.Expect(setDeletionProtectedCondition(hc)).To(BeFalse())
+}
+
+func TestRemoveDeletionFinalizers(t *testing.T) {
+	g := NewWithT(t)
+	hc := &hyperv1.HostedCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "hc"},
+		Spec: hyperv1.HostedClusterSpec{
+			DeletionPolicy: &hyperv1.DeletionPolicy{Type: hyperv1.DeletionPolicyForce},
+		},
+	}
+	controlPlaneNamespace := manifests.HostedControlPlaneNamespace(hc.Namespace, hc.Name)
+	nodePool := &hyperv1.NodePool{
+		ObjectMeta: metav1.ObjectMeta{Namespace: hc.Namespace, Name: "np", Finalizers: []string{"hypershift.openshift.io/finalizer"}},
+		Spec:       hyperv1.NodePoolSpec{ClusterName: hc.Name},
+	}
+	otherNodePool := &hyperv1.NodePool{
+		ObjectMeta: metav1.ObjectMeta{Namespace: hc.Namespace, Name: "other", Finalizers: []string{"hypershift.openshift.io/finalizer"}},
+		Spec:       hyperv1.NodePoolSpec{ClusterName: "other"},
+	}
+	hcp := controlplaneoperator.HostedControlPlane(controlPlaneNamespace, hc.Name)
+	hcp.Finalizers = []string{"hypershift.openshift.io/finalizer"}
+	awsEndpointService := &hyperv1.AWSEndpointService{
+		ObjectMeta: metav1.ObjectMeta{Namespace: controlPlaneNamespace, Name: "private-router", Finalizers: []string{"hypershift.openshift.io/control-plane-operator-finalizer"}},
+	}
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: controlPlaneNamespace}}
+
+	c := fake.NewClientBuilder().WithScheme(api.Scheme).WithObjects(hc, nodePool, otherNodePool, hcp, awsEndpointService, namespace).Build()
+	r := &HostedClusterReconciler{Client: c}
+	g.Expect(r.removeDeletionFinalizers(context.Background(), hc)).To(Succeed())
+
+	for _, obj := range []client.Object{nodePool, hcp, awsEndpointService} {
+		g.Expect(c.Get(context.Background(), client.ObjectKeyFromObject(obj), obj)).To(Succeed())
+		g.Expect(obj.GetFinalizers()).To(BeEmpty())
+	}
+	g.Expect(c.Get(context.Background(), client.ObjectKeyFromObject(otherNodePool), otherNodePool)).To(Succeed())
+	g.Expect(otherNodePool.Finalizers).ToNot(BeEmpty())
+	err := c.Get(context.Background(), client.ObjectKeyFromObject(namespace), namespace)
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+}
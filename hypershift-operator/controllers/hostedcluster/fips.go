@@ -0,0 +1,17 @@
+package hostedcluster
+
+import (
+	"fmt"
+
+	"github.com/openshift/hypershift/support/capabilities"
+	"github.com/openshift/hypershift/support/releaseinfo"
+)
+
+// validateFIPSConfiguration returns an error if a HostedCluster with FIPS mode enabled can't run in FIPS mode on
+// this management cluster with the given release image.
+func (r *HostedClusterReconciler) validateFIPSConfiguration(releaseImage *releaseinfo.ReleaseImage) error {
+	if !r.ManagementClusterCapabilities.Has(capabilities.CapabilityFIPS) {
+		return fmt.Errorf("the management cluster doesn't run in FIPS mode with FIPS capable operator binaries, so the control plane can't run in FIPS mode")
+	}
+	return releaseImage.ValidateFIPS("")
+}
@@ -0,0 +1,205 @@
+package hostedcluster
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	cpomanifests "github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/manifests"
+	"github.com/openshift/hypershift/hypershift-operator/controllers/manifests"
+	hyperutil "github.com/openshift/hypershift/support/util"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// reconcileComponentStatuses computes hcluster.Status.ComponentStatuses and the Healthy condition.
+// It expects the conditions bubbled up from the HostedControlPlane to be already set on the HostedCluster.
+func (r *HostedClusterReconciler) reconcileComponentStatuses(ctx context.Context, hcluster *hyperv1.HostedCluster, hcp *hyperv1.HostedControlPlane) error {
+	controlPlaneNamespace := manifests.HostedControlPlaneNamespace(hcluster.Namespace, hcluster.Name)
+
+	scheduler, err := r.getDeploymentIfExists(ctx, cpomanifests.SchedulerDeployment(controlPlaneNamespace))
+	if err != nil {
+		return err
+	}
+	nodePools, err := listNodePools(ctx, r.Client, hcluster.Namespace, hcluster.Name)
+	if err != nil {
+		return err
+	}
+
+	etcdCondition := hyperv1.EtcdAvailable
+	if hcluster.Spec.Etcd.ManagementType == hyperv1.Unmanaged {
+		etcdCondition = hyperv1.UnmanagedEtcdAvailable
+	}
+
+	componentStatuses := []hyperv1.HostedClusterComponentStatus{
+		componentStatusFromCondition(hyperv1.EtcdComponent, hcluster.Status.Conditions, etcdCondition),
+		componentStatusFromCondition(hyperv1.KubeAPIServerComponent, hcluster.Status.Conditions, hyperv1.KubeAPIServerAvailable),
+		componentStatusFromDeployment(hyperv1.KubeSchedulerComponent, scheduler),
+	}
+	// The OAuth server is not deployed when an external OIDC provider is configured.
+	if hyperutil.HCOAuthEnabled(hcluster) {
+		oauthServer, err := r.getDeploymentIfExists(ctx, cpomanifests.OAuthServerDeployment(controlPlaneNamespace))
+		if err != nil {
+			return err
+		}
+		componentStatuses = append(componentStatuses, componentStatusFromDeployment(hyperv1.OAuthServerComponent, oauthServer))
+	}
+	componentStatuses = append(componentStatuses,
+		componentStatusFromCondition(hyperv1.IngressComponent, hcluster.Status.Conditions, hyperv1.IngressAvailable),
+		nodesComponentStatus(hcp, nodePools),
+	)
+	hcluster.Status.ComponentStatuses = componentStatuses
+	meta.SetStatusCondition(&hcluster.Status.Conditions, computeHostedClusterHealthy(hcluster))
+	return nil
+}
+
+// getDeploymentIfExists returns nil when the deployment does not exist.
+func (r *HostedClusterReconciler) getDeploymentIfExists(ctx context.Context, deployment *appsv1.Deployment) (*appsv1.Deployment, error) {
+	if err := r.Client.Get(ctx, client.ObjectKeyFromObject(deployment), deployment); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get deployment %s: %w", deployment.Name, err)
+	}
+	return deployment, nil
+}
+
+func componentStatusFromCondition(name hyperv1.HostedClusterComponentName, conditions []metav1.Condition, conditionType hyperv1.ConditionType) hyperv1.HostedClusterComponentStatus {
+	condition := meta.FindStatusCondition(conditions, string(conditionType))
+	if condition == nil {
+		return hyperv1.HostedClusterComponentStatus{
+			Name:      name,
+			Available: metav1.ConditionUnknown,
+			Reason:    hyperv1.StatusUnknownReason,
+			Message:   fmt.Sprintf("Condition %s not found", conditionType),
+		}
+	}
+	return hyperv1.HostedClusterComponentStatus{
+		Name:      name,
+		Available: condition.Status,
+		Reason:    condition.Reason,
+		Message:   condition.Message,
+	}
+}
+
+func componentStatusFromDeployment(name hyperv1.HostedClusterComponentName, deployment *appsv1.Deployment) hyperv1.HostedClusterComponentStatus {
+	if deployment == nil {
+		return hyperv1.HostedClusterComponentStatus{
+			Name:      name,
+			Available: metav1.ConditionFalse,
+			Reason:    hyperv1.DeploymentNotFoundReason,
+			Message:   "Deployment not found",
+		}
+	}
+	for _, cond := range deployment.Status.Conditions {
+		if cond.Type != appsv1.DeploymentAvailable {
+			continue
+		}
+		status := hyperv1.HostedClusterComponentStatus{
+			Name:      name,
+			Available: metav1.ConditionStatus(cond.Status),
+			Reason:    cond.Reason,
+			Message:   cond.Message,
+		}
+		if cond.Status == corev1.ConditionTrue {
+			status.Reason = hyperv1.AsExpectedReason
+			status.Message = fmt.Sprintf("Deployment %s is available", deployment.Name)
+		}
+		return status
+	}
+	return hyperv1.HostedClusterComponentStatus{
+		Name:      name,
+		Available: metav1.ConditionFalse,
+		Reason:    hyperv1.WaitingForAvailableReason,
+		Message:   fmt.Sprintf("Deployment %s is not yet available", deployment.Name),
+	}
+}
+
+// nodesComponentStatus compares the nodes reported by the HostedControlPlane with the replicas requested by the NodePools.
+func nodesComponentStatus(hcp *hyperv1.HostedControlPlane, nodePools []hyperv1.NodePool) hyperv1.HostedClusterComponentStatus {
+	var desired int32
+	for _, nodePool := range nodePools {
+		if nodePool.Spec.Replicas != nil {
+			desired += *nodePool.Spec.Replicas
+		} else {
+			desired += nodePool.Status.Replicas
+		}
+	}
+	status := hyperv1.HostedClusterComponentStatus{
+		Name:            hyperv1.NodesComponent,
+		DesiredReplicas: ptr.To(desired),
+	}
+
+	if hcp == nil || hcp.Status.NodeCount == nil {
+		status.Available = metav1.ConditionUnknown
+		status.Reason = hyperv1.StatusUnknownReason
+		status.Message = "Node count is not reported by the hosted control plane"
+		return status
+	}
+
+	ready := int32(*hcp.Status.NodeCount)
+	status.ReadyReplicas = ptr.To(ready)
+	if ready < desired {
+		status.Available = metav1.ConditionFalse
+		status.Reason = hyperv1.WaitingForNodesReason
+		status.Message = fmt.Sprintf("%d of %d nodes joined the cluster", ready, desired)
+		return status
+	}
+	status.Available = metav1.ConditionTrue
+	status.Reason = hyperv1.AsExpectedReason
+	status.Message = fmt.Sprintf("%d of %d nodes joined the cluster", ready, desired)
+	return status
+}
+
+// computeHostedClusterHealthy rolls up the component statuses and the Degraded condition into the Healthy condition.
+func computeHostedClusterHealthy(hcluster *hyperv1.HostedCluster) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               string(hyperv1.HostedClusterHealthy),
+		ObservedGeneration: hcluster.Generation,
+	}
+
+	var notAvailable, unknown []string
+	var reason string
+	for _, component := range hcluster.Status.ComponentStatuses {
+		switch component.Available {
+		case metav1.ConditionTrue:
+			continue
+		case metav1.ConditionFalse:
+			notAvailable = append(notAvailable, fmt.Sprintf("%s: %s", component.Name, component.Message))
+			reason = string(component.Name) + hyperv1.ComponentNotAvailableReasonSuffix
+		default:
+			unknown = append(unknown, fmt.Sprintf("%s: %s", component.Name, component.Message))
+		}
+	}
+
+	switch {
+	case len(notAvailable) > 1:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = hyperv1.MultipleComponentsNotAvailableReason
+		condition.Message = strings.Join(notAvailable, "; ")
+	case len(notAvailable) == 1:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = reason
+		condition.Message = notAvailable[0]
+	case meta.IsStatusConditionTrue(hcluster.Status.Conditions, string(hyperv1.HostedClusterDegraded)):
+		degraded := meta.FindStatusCondition(hcluster.Status.Conditions, string(hyperv1.HostedClusterDegraded))
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = degraded.Reason
+		condition.Message = degraded.Message
+	case len(unknown) > 0:
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = hyperv1.StatusUnknownReason
+		condition.Message = strings.Join(unknown, "; ")
+	default:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = hyperv1.AsExpectedReason
+		condition.Message = hyperv1.AllIsWellMessage
+	}
+	return condition
+}
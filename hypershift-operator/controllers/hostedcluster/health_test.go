@@ -0,0 +1,158 @@
+package hostedcluster
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	configv1 "github.com/openshift/api/config/v1"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/support/api"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestComputeHostedClusterHealthy(t *testing.T) {
+	available := func(name hyperv1.HostedClusterComponentName) hyperv1.HostedClusterComponentStatus {
+		return hyperv1.HostedClusterComponentStatus{Name: name, Available: metav1.ConditionTrue, Reason: hyperv1.AsExpectedReason}
+	}
+	tests := map[string]struct {
+		componentStatuses []hyperv1.HostedClusterComponentStatus
+		conditions        []metav1.Condition
+		expectedStatus    metav1.ConditionStatus
+		expectedReason    string
+	}{
+		"all components available should be healthy": {
+			componentStatuses: []hyperv1.HostedClusterComponentStatus{
+				available(hyperv1.EtcdComponent),
+				available(hyperv1.KubeAPIServerComponent),
+			},
+			expectedStatus: metav1.ConditionTrue,
+			expectedReason: hyperv1.AsExpectedReason,
+		},
+		"a single unavailable component should be reported in the reason": {
+			componentStatuses: []hyperv1.HostedClusterComponentStatus{
+				available(hyperv1.EtcdComponent),
+				{Name: hyperv1.KubeAPIServerComponent, Available: metav1.ConditionFalse, Message: "down"},
+			},
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: "KubeAPIServerNotAvailable",
+		},
+		"multiple unavailable components should be aggregated": {
+			componentStatuses: []hyperv1.HostedClusterComponentStatus{
+				{Name: hyperv1.EtcdComponent, Available: metav1.ConditionFalse},
+				{Name: hyperv1.KubeAPIServerComponent, Available: metav1.ConditionFalse},
+			},
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: hyperv1.MultipleComponentsNotAvailableReason,
+		},
+		"degraded cluster should not be healthy": {
+			componentStatuses: []hyperv1.HostedClusterComponentStatus{
+				available(hyperv1.EtcdComponent),
+			},
+			conditions: []metav1.Condition{
+				{Type: string(hyperv1.HostedClusterDegraded), Status: metav1.ConditionTrue, Reason: "UnavailableReplicas"},
+			},
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: "UnavailableReplicas",
+		},
+		"unknown component should make health unknown": {
+			componentStatuses: []hyperv1.HostedClusterComponentStatus{
+				available(hyperv1.EtcdComponent),
+				{Name: hyperv1.NodesComponent, Available: metav1.ConditionUnknown},
+			},
+			expectedStatus: metav1.ConditionUnknown,
+			expectedReason: hyperv1.StatusUnknownReason,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			g := NewWithT(t)
+			hcluster := &hyperv1.HostedCluster{
+				Status: hyperv1.HostedClusterStatus{
+					ComponentStatuses: test.componentStatuses,
+					Conditions:        test.conditions,
+				},
+			}
+			condition := computeHostedClusterHealthy(hcluster)
+			g.Expect(condition.Type).To(Equal(string(hyperv1.HostedClusterHealthy)))
+			g.Expect(condition.Status).To(Equal(test.expectedStatus))
+			g.Expect(condition.Reason).To(Equal(test.expectedReason))
+		})
+	}
+}
+
+func TestNodesComponentStatus(t *testing.T) {
+	nodePools := []hyperv1.NodePool{
+		{Spec: hyperv1.NodePoolSpec{Replicas: ptr.To[int32](2)}},
+		{Status: hyperv1.NodePoolStatus{Replicas: 1}},
+	}
+	tests := map[string]struct {
+		hcp            *hyperv1.HostedControlPlane
+		expectedStatus metav1.ConditionStatus
+		expectedReady  *int32
+	}{
+		"missing node count should be unknown": {
+			hcp:            &hyperv1.HostedControlPlane{},
+			expectedStatus: metav1.ConditionUnknown,
+		},
+		"fewer nodes than desired should not be available": {
+			hcp:            &hyperv1.HostedControlPlane{Status: hyperv1.HostedControlPlaneStatus{NodeCount: ptr.To(2)}},
+			expectedStatus: metav1.ConditionFalse,
+			expectedReady:  ptr.To[int32](2),
+		},
+		"all nodes joined should be available": {
+			hcp:            &hyperv1.HostedControlPlane{Status: hyperv1.HostedControlPlaneStatus{NodeCount: ptr.To(3)}},
+			expectedStatus: metav1.ConditionTrue,
+			expectedReady:  ptr.To[int32](3),
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			g := NewWithT(t)
+			status := nodesComponentStatus(test.hcp, nodePools)
+			g.Expect(status.Available).To(Equal(test.expectedStatus))
+			g.Expect(status.DesiredReplicas).To(Equal(ptr.To[int32](3)))
+			g.Expect(status.ReadyReplicas).To(Equal(test.expectedReady))
+		})
+	}
+}
+
+func TestReconcileComponentStatusesOAuth(t *testing.T) {
+	tests := map[string]struct {
+		configuration       *hyperv1.ClusterConfiguration
+		expectOAuthReported bool
+	}{
+		"built-in OAuth should be reported": {
+			expectOAuthReported: true,
+		},
+		"OAuth should not be reported when an external OIDC provider is configured": {
+			configuration: &hyperv1.ClusterConfiguration{
+				Authentication: &configv1.AuthenticationSpec{Type: configv1.AuthenticationTypeOIDC},
+			},
+			expectOAuthReported: false,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			g := NewWithT(t)
+			hcluster := &hyperv1.HostedCluster{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "test"},
+				Spec:       hyperv1.HostedClusterSpec{Configuration: test.configuration},
+			}
+			r := &HostedClusterReconciler{
+				Client: fake.NewClientBuilder().WithScheme(api.Scheme).Build(),
+			}
+			g.Expect(r.reconcileComponentStatuses(context.Background(), hcluster, nil)).To(Succeed())
+
+			var oauthReported bool
+			for _, component := range hcluster.Status.ComponentStatuses {
+				if component.Name == hyperv1.OAuthServerComponent {
+					oauthReported = true
+				}
+			}
+			g.Expect(oauthReported).To(Equal(test.expectOAuthReported))
+		})
+	}
+}
@@ -25,15 +25,12 @@ import (
 	"os"
 	"reflect"
 	"runtime"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
 	"time"
 
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/awserr"
-	"github.com/aws/aws-sdk-go/service/s3"
-	"github.com/aws/aws-sdk-go/service/s3/s3iface"
 	"github.com/blang/semver"
 	"github.com/go-logr/logr"
 	"github.com/google/uuid"
@@ -57,7 +54,9 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 	utilrand "k8s.io/apimachinery/pkg/util/rand"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/utils/clock"
 	k8sutilspointer "k8s.io/utils/pointer"
@@ -79,6 +78,7 @@ import (
 	"github.com/openshift/hypershift/api/util/configrefs"
 	"github.com/openshift/hypershift/cmd/util"
 	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/autoscaler"
+	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/etcd"
 	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/machineapprover"
 	cpomanifests "github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/manifests"
 	"github.com/openshift/hypershift/control-plane-pki-operator/certificates"
@@ -91,19 +91,22 @@ import (
 	"github.com/openshift/hypershift/hypershift-operator/controllers/manifests/controlplaneoperator"
 	controlplanepkioperatormanifests "github.com/openshift/hypershift/hypershift-operator/controllers/manifests/controlplanepkioperator"
 	"github.com/openshift/hypershift/hypershift-operator/controllers/manifests/ignitionserver"
+	"github.com/openshift/hypershift/hypershift-operator/controllers/sharding"
 	kvinfra "github.com/openshift/hypershift/kubevirtexternalinfra"
 	"github.com/openshift/hypershift/support/api"
+	supportawsutil "github.com/openshift/hypershift/support/awsutil"
 	"github.com/openshift/hypershift/support/capabilities"
 	"github.com/openshift/hypershift/support/certs"
+	supportconditions "github.com/openshift/hypershift/support/conditions"
 	"github.com/openshift/hypershift/support/config"
 	"github.com/openshift/hypershift/support/globalconfig"
 	"github.com/openshift/hypershift/support/images"
+	"github.com/openshift/hypershift/support/imageverification"
 	"github.com/openshift/hypershift/support/infraid"
 	"github.com/openshift/hypershift/support/metrics"
 	"github.com/openshift/hypershift/support/oidc"
 	"github.com/openshift/hypershift/support/proxy"
 	"github.com/openshift/hypershift/support/releaseinfo"
-	"github.com/openshift/hypershift/support/releaseinfo/registryclient"
 	"github.com/openshift/hypershift/support/rhobsmonitoring"
 	"github.com/openshift/hypershift/support/supportedversion"
 	"github.com/openshift/hypershift/support/upsert"
@@ -132,6 +135,11 @@ const (
 
 	etcdEncKeyPostfix    = "-etcd-encryption-key"
 	managedServiceEnvVar = "MANAGED_SERVICE"
+
+	// propagatedLabelsAnnotation and propagatedAnnotationsAnnotation record on the control plane namespace the
+	// keys that were propagated from the HostedCluster spec, so they can be removed once dropped from the spec.
+	propagatedLabelsAnnotation      = "hypershift.openshift.io/propagated-labels"
+	propagatedAnnotationsAnnotation = "hypershift.openshift.io/propagated-annotations"
 )
 
 var (
@@ -167,8 +175,12 @@ type HostedClusterReconciler struct {
 
 	PrivatePlatform hyperv1.PlatformType
 
-	OIDCStorageProviderS3BucketName string
-	S3Client                        s3iface.S3API
+	// OIDCDocumentStore stores the OIDC discovery documents of the AWS hosted clusters, and of the hosted clusters
+	// of other platforms whose issuer URL is under OIDCIssuerURLBase.
+	OIDCDocumentStore oidc.DocumentStore
+
+	// OIDCIssuerURLBase is the public URL the documents stored in OIDCDocumentStore are served from.
+	OIDCIssuerURLBase string
 
 	MetricsSet    metrics.MetricsSet
 	SREConfigHash string
@@ -186,6 +198,18 @@ type HostedClusterReconciler struct {
 	CertRotationScale time.Duration
 
 	EnableCVOManagementClusterMetricsAccess bool
+
+	// Shard is the subset of HostedClusters reconciled by this operator replica.
+	Shard sharding.Shard
+
+	// ControlPlaneHardening is the default value of the control plane hardening annotations, applied to the
+	// HostedControlPlanes of the HostedClusters which don't set them.
+	ControlPlaneHardening map[string]string
+
+	// MandatoryResourceTags are applied to the cloud resources of every HostedCluster, along with its identity tags.
+	MandatoryResourceTags map[string]string
+
+	recorder record.EventRecorder
 }
 
 // +kubebuilder:rbac:groups=hypershift.openshift.io,resources=hostedclusters,verbs=get;list;watch;create;update;patch;delete
@@ -199,19 +223,22 @@ func (r *HostedClusterReconciler) SetupWithManager(mgr ctrl.Manager, createOrUpd
 		r.now = metav1.Now
 	}
 	r.createOrUpdate = createOrUpdateWithAnnotationFactory(createOrUpdate)
+	r.recorder = mgr.GetEventRecorderFor("hostedcluster-controller")
 	// Set up watches for resource types the controller manages. The list basically
 	// tracks types of the resources in the clusterapi, controlplaneoperator, and
 	// ignitionserver manifests packages. Since we're receiving watch events across
 	// namespaces, the events are filtered to enqueue only those resources which
 	// are annotated as being associated with a hostedcluster (using an annotation).
 	bldr := ctrl.NewControllerManagedBy(mgr).
-		For(&hyperv1.HostedCluster{}, builder.WithPredicates(hyperutil.PredicatesForHostedClusterAnnotationScoping(mgr.GetClient()))).
+		For(&hyperv1.HostedCluster{}, builder.WithPredicates(hyperutil.PredicatesForHostedClusterAnnotationScoping(mgr.GetClient()), r.Shard.Predicate(mgr.GetClient()))).
 		WithOptions(controller.Options{
 			RateLimiter:             workqueue.NewItemExponentialFailureRateLimiter(1*time.Second, 10*time.Second),
 			MaxConcurrentReconciles: 10,
+			// When sharded, every replica holding a shard reconciles, not only the leader.
+			NeedLeaderElection: k8sutilspointer.Bool(!r.Shard.Enabled()),
 		})
 	for _, managedResource := range r.managedResources() {
-		bldr.Watches(managedResource, handler.EnqueueRequestsFromMapFunc(enqueueHostedClustersFunc(metricsSet, operatorNamespace, mgr.GetClient())), builder.WithPredicates(hyperutil.PredicatesForHostedClusterAnnotationScoping(mgr.GetClient())))
+		bldr.Watches(managedResource, handler.EnqueueRequestsFromMapFunc(enqueueHostedClustersFunc(metricsSet, operatorNamespace, mgr.GetClient())), builder.WithPredicates(hyperutil.PredicatesForHostedClusterAnnotationScoping(mgr.GetClient()), r.Shard.Predicate(mgr.GetClient())))
 	}
 
 	// Set based on SCC capability
@@ -306,12 +333,22 @@ func (r *HostedClusterReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		return ctrl.Result{}, fmt.Errorf("failed to get cluster %q: %w", req.NamespacedName, err)
 	}
 
+	if !r.Shard.OwnsHostedCluster(hcluster) {
+		log.Info("hostedcluster belongs to another shard, skipping reconcile")
+		return ctrl.Result{}, nil
+	}
+
+	wasPaused := meta.IsStatusConditionFalse(hcluster.Status.Conditions, string(hyperv1.ReconciliationActive))
 	var res reconcile.Result
 	if r.overwriteReconcile != nil {
 		res, err = r.overwriteReconcile(ctx, req, log, hcluster)
 	} else {
 		res, err = r.reconcile(ctx, req, log, hcluster)
 	}
+	// Reconcile the HostedCluster again when it starts expiring or its lifetime expires.
+	if requeueAfter := lifetimeRequeueAfter(hcluster, r.now().Time); err == nil && requeueAfter > 0 && (res.RequeueAfter == 0 || requeueAfter < res.RequeueAfter) {
+		res.RequeueAfter = requeueAfter
+	}
 	condition := metav1.Condition{
 		Type:               string(hyperv1.ReconciliationSucceeded),
 		ObservedGeneration: hcluster.Generation,
@@ -325,6 +362,9 @@ func (r *HostedClusterReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		condition.Reason = "ReconciliationError"
 		condition.Message = err.Error()
 	}
+	if wasPaused && meta.IsStatusConditionTrue(hcluster.Status.Conditions, string(hyperv1.ReconciliationActive)) {
+		r.recorder.Event(hcluster, corev1.EventTypeNormal, "ReconciliationResumed", "The pause of the HostedCluster ended and its reconciliation resumed")
+	}
 	old := meta.FindStatusCondition(hcluster.Status.Conditions, string(hyperv1.ReconciliationSucceeded))
 	if old != nil {
 		old.LastTransitionTime = condition.LastTransitionTime
@@ -462,14 +502,38 @@ func (r *HostedClusterReconciler) reconcile(ctx context.Context, req ctrl.Reques
 		// in AWS, and SRE will report them to the final user.
 		hostedClusterDestroyedCondition := meta.FindStatusCondition(hcluster.Status.Conditions, string(hyperv1.HostedClusterDestroyed))
 		if hostedClusterDestroyedCondition == nil || hostedClusterDestroyedCondition.Status != metav1.ConditionTrue {
+			// The deletion of a protected hostedcluster is blocked until it's allowed with an annotation.
+			if !hyperutil.HCDeletionAllowed(hcluster) {
+				if setDeletionProtectedCondition(hcluster) {
+					if err := r.Client.Status().Update(ctx, hcluster); err != nil {
+						return ctrl.Result{}, fmt.Errorf("failed to update status: %w", err)
+					}
+				}
+				log.Info("hostedcluster deletion is blocked by its deletion policy", "name", req.NamespacedName, "annotation", hyperv1.AllowDeletionAnnotation)
+				return ctrl.Result{}, nil
+			}
+			if meta.FindStatusCondition(hcluster.Status.Conditions, string(hyperv1.DeletionProtected)) != nil {
+				meta.RemoveStatusCondition(&hcluster.Status.Conditions, string(hyperv1.DeletionProtected))
+				if err := r.Client.Status().Update(ctx, hcluster); err != nil {
+					return ctrl.Result{}, fmt.Errorf("failed to update status: %w", err)
+				}
+			}
+
 			// Keep trying to delete until we know it's safe to finalize.
 			completed, err := r.delete(ctx, hcluster)
 			if err != nil {
 				return ctrl.Result{}, fmt.Errorf("failed to delete hostedcluster: %w", err)
 			}
 			if !completed {
-				log.Info("hostedcluster is still deleting", "name", req.NamespacedName)
-				return ctrl.Result{RequeueAfter: clusterDeletionRequeueDuration}, nil
+				forceTimeout := hyperutil.HCDeletionForceTimeout(hcluster)
+				if forceTimeout == 0 || time.Since(hcluster.DeletionTimestamp.Time) < forceTimeout {
+					log.Info("hostedcluster is still deleting", "name", req.NamespacedName)
+					return ctrl.Result{RequeueAfter: clusterDeletionRequeueDuration}, nil
+				}
+				log.Info("Forcing hostedcluster deletion because its cleanup exceeded the force timeout", "name", req.NamespacedName, "forceTimeout", forceTimeout)
+				if err := r.removeDeletionFinalizers(ctx, hcluster); err != nil {
+					return ctrl.Result{}, fmt.Errorf("failed to force hostedcluster deletion: %w", err)
+				}
 			}
 		}
 
@@ -701,6 +765,32 @@ func (r *HostedClusterReconciler) reconcile(ctx context.Context, req ctrl.Reques
 		}
 	}
 
+	// Copy the EtcdQuorumAtRisk condition from the HostedControlPlane. It is only reported for managed etcd with
+	// multiple members.
+	if hcp != nil {
+		quorumAtRiskCondition := meta.FindStatusCondition(hcp.Status.Conditions, string(hyperv1.EtcdQuorumAtRisk))
+		if quorumAtRiskCondition != nil {
+			quorumAtRiskCondition.ObservedGeneration = hcluster.Generation
+			meta.SetStatusCondition(&hcluster.Status.Conditions, *quorumAtRiskCondition)
+		}
+	}
+
+	// Copy the conditions from the HostedControlPlane which are only reported in some cases: ClusterNetworkApplied
+	// once the guest cluster network operator reports the cluster networks in use, AWSResourceTagsApplied for
+	// AWS clusters, and ValidControlPlaneExtensions when control plane extensions are configured.
+	if _, hasExtensions := hcluster.Annotations[hyperv1.ControlPlaneExtensionsAnnotation]; !hasExtensions {
+		meta.RemoveStatusCondition(&hcluster.Status.Conditions, string(hyperv1.ValidControlPlaneExtensions))
+	}
+	if hcp != nil {
+		for _, conditionType := range []hyperv1.ConditionType{hyperv1.ClusterNetworkApplied, hyperv1.AWSResourceTagsApplied, hyperv1.ValidControlPlaneExtensions} {
+			condition := meta.FindStatusCondition(hcp.Status.Conditions, string(conditionType))
+			if condition != nil {
+				condition.ObservedGeneration = hcluster.Generation
+				meta.SetStatusCondition(&hcluster.Status.Conditions, *condition)
+			}
+		}
+	}
+
 	// Copy conditions from hostedcontrolplane
 	{
 		hcpConditions := []hyperv1.ConditionType{
@@ -710,6 +800,7 @@ func (r *HostedClusterReconciler) reconcile(ctx context.Context, req ctrl.Reques
 			hyperv1.ExternalDNSReachable,
 			hyperv1.ValidHostedControlPlaneConfiguration,
 			hyperv1.ValidReleaseInfo,
+			hyperv1.IngressAvailable,
 		}
 
 		for _, conditionType := range hcpConditions {
@@ -735,7 +826,21 @@ func (r *HostedClusterReconciler) reconcile(ctx context.Context, req ctrl.Reques
 
 	// Copy the platform status from the hostedcontrolplane
 	if hcp != nil {
-		hcluster.Status.Platform = hcp.Status.Platform
+		hcluster.Status.Platform = hcp.Status.Platform.DeepCopy()
+	}
+
+	// Aggregate the infra namespaces of the KubeVirt virtual machines of the NodePools
+	if hcluster.Spec.Platform.Type == hyperv1.KubevirtPlatform {
+		nodePools, err := listNodePools(ctx, r.Client, hcluster.Namespace, hcluster.Name)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to get nodePools by cluster name for cluster %q: %w", hcluster.Name, err)
+		}
+		if infraNamespaces := kubevirtInfraNamespaces(nodePools); len(infraNamespaces) > 0 {
+			if hcluster.Status.Platform == nil {
+				hcluster.Status.Platform = &hyperv1.PlatformStatus{}
+			}
+			hcluster.Status.Platform.Kubevirt = &hyperv1.KubevirtPlatformStatus{InfraNamespaces: infraNamespaces}
+		}
 	}
 
 	// Copy the AWSDefaultSecurityGroupCreated condition from the hostedcontrolplane
@@ -765,6 +870,26 @@ func (r *HostedClusterReconciler) reconcile(ctx context.Context, req ctrl.Reques
 		}
 	}
 
+	if hcluster.Spec.Platform.Type == hyperv1.IBMCloudPlatform || hcluster.Spec.Platform.Type == hyperv1.PowerVSPlatform {
+		if hcp != nil {
+			validKMSConfig := meta.FindStatusCondition(hcp.Status.Conditions, string(hyperv1.ValidIBMCloudKMSConfig))
+			if validKMSConfig != nil {
+				validKMSConfig.ObservedGeneration = hcluster.Generation
+				meta.SetStatusCondition(&hcluster.Status.Conditions, *validKMSConfig)
+			}
+		}
+	}
+
+	// Copy the KMSDataReencrypted condition from the hostedcontrolplane
+	if hcp != nil {
+		if reencrypted := meta.FindStatusCondition(hcp.Status.Conditions, string(hyperv1.KMSDataReencrypted)); reencrypted != nil {
+			reencrypted.ObservedGeneration = hcluster.Generation
+			meta.SetStatusCondition(&hcluster.Status.Conditions, *reencrypted)
+		} else {
+			meta.RemoveStatusCondition(&hcluster.Status.Conditions, string(hyperv1.KMSDataReencrypted))
+		}
+	}
+
 	// Reconcile unmanaged etcd client tls secret validation error status. Note only update status on validation error case to
 	// provide clear status to the user on the resource without having to look at operator logs.
 	{
@@ -837,10 +962,14 @@ func (r *HostedClusterReconciler) reconcile(ctx context.Context, req ctrl.Reques
 			Type:               string(hyperv1.ValidHostedClusterConfiguration),
 			ObservedGeneration: hcluster.Generation,
 		}
-		if err := r.validateConfigAndClusterCapabilities(ctx, hcluster); err != nil {
+		err := utilerrors.NewAggregate([]error{
+			r.validateConfigAndClusterCapabilities(ctx, hcluster),
+			validateClusterNetworkExpansion(hcluster, hcp),
+		})
+		if err != nil {
 			condition.Status = metav1.ConditionFalse
 			condition.Message = err.Error()
-			condition.Reason = hyperv1.InvalidConfigurationReason
+			condition.Reason = supportconditions.ReasonForError(err, hyperv1.InvalidConfigurationReason)
 		} else {
 			condition.Status = metav1.ConditionTrue
 			condition.Message = "Configuration passes validation"
@@ -981,7 +1110,15 @@ func (r *HostedClusterReconciler) reconcile(ctx context.Context, req ctrl.Reques
 		newCondition.ObservedGeneration = hcluster.Generation
 		meta.SetStatusCondition(&hcluster.Status.Conditions, newCondition)
 	}
-	meta.SetStatusCondition(&hcluster.Status.Conditions, hyperutil.GenerateReconciliationActiveCondition(hcluster.Spec.PausedUntil, hcluster.Generation))
+	{
+		condition := hyperutil.GenerateHostedClusterReconciliationActiveCondition(hcluster)
+		if condition.Status == metav1.ConditionFalse && hcluster.Spec.PauseScope == hyperv1.PauseScopeControlPlaneUpgrades &&
+			hcp != nil && hyperutil.HCPControlPlaneReleaseImage(hcp) != hyperutil.HCControlPlaneReleaseImage(hcluster) {
+			condition.Message = fmt.Sprintf("%s, the control plane runs release %s and the upgrade to release %s is held",
+				condition.Message, hyperutil.HCPControlPlaneReleaseImage(hcp), hyperutil.HCControlPlaneReleaseImage(hcluster))
+		}
+		meta.SetStatusCondition(&hcluster.Status.Conditions, condition)
+	}
 
 	// Set ValidReleaseImage condition
 	{
@@ -995,7 +1132,7 @@ func (r *HostedClusterReconciler) reconcile(ctx context.Context, req ctrl.Reques
 				Type:               string(hyperv1.ValidReleaseImage),
 				ObservedGeneration: hcluster.Generation,
 			}
-			err := r.validateReleaseImage(ctx, hcluster, releaseProvider)
+			err := r.validateReleaseImage(ctx, hcluster, releaseProvider, registryClientImageMetadataProvider)
 			if err != nil {
 				condition.Status = metav1.ConditionFalse
 				condition.Message = err.Error()
@@ -1014,11 +1151,83 @@ func (r *HostedClusterReconciler) reconcile(ctx context.Context, req ctrl.Reques
 		}
 	}
 
+	// Set ReleaseImageVerified condition
+	if hcluster.Spec.ImageVerification == nil {
+		meta.RemoveStatusCondition(&hcluster.Status.Conditions, string(hyperv1.ReleaseImageVerified))
+	} else {
+		condition := meta.FindStatusCondition(hcluster.Status.Conditions, string(hyperv1.ReleaseImageVerified))
+
+		// Verifying the signature requires looking up the registry and the signature stores, so skip if we have
+		// already verified the release image of this generation.
+		if condition == nil || condition.ObservedGeneration != hcluster.Generation || condition.Status != metav1.ConditionTrue {
+			condition := metav1.Condition{
+				Type:               string(hyperv1.ReleaseImageVerified),
+				ObservedGeneration: hcluster.Generation,
+			}
+			if err := r.verifyReleaseImage(ctx, hcluster); err != nil {
+				condition.Status = metav1.ConditionFalse
+				condition.Message = err.Error()
+
+				if imageverification.IsNotVerified(err) {
+					condition.Reason = hyperv1.ImageVerificationFailedReason
+				} else {
+					condition.Reason = hyperv1.ImageVerificationErrorReason
+				}
+			} else {
+				condition.Status = metav1.ConditionTrue
+				condition.Reason = hyperv1.AsExpectedReason
+				condition.Message = "Release image signature is verified"
+			}
+			meta.SetStatusCondition(&hcluster.Status.Conditions, condition)
+		}
+	}
+
 	releaseImage, err := r.lookupReleaseImage(ctx, hcluster, releaseProvider)
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to lookup release image: %w", err)
 	}
 
+	// Set ValidFIPSConfiguration condition
+	if !hcluster.Spec.FIPS {
+		meta.RemoveStatusCondition(&hcluster.Status.Conditions, string(hyperv1.ValidFIPSConfiguration))
+	} else {
+		condition := metav1.Condition{
+			Type:               string(hyperv1.ValidFIPSConfiguration),
+			ObservedGeneration: hcluster.Generation,
+			Status:             metav1.ConditionTrue,
+			Reason:             hyperv1.AsExpectedReason,
+			Message:            "FIPS mode is supported",
+		}
+		if err := r.validateFIPSConfiguration(releaseImage); err != nil {
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = hyperv1.FIPSUnsupportedReason
+			condition.Message = err.Error()
+		}
+		meta.SetStatusCondition(&hcluster.Status.Conditions, condition)
+	}
+
+	// Set PlatformCredentialsRotated condition
+	if _, rotated := hcluster.Annotations[hyperv1.PlatformCredentialsRotationAnnotation]; !rotated {
+		meta.RemoveStatusCondition(&hcluster.Status.Conditions, string(hyperv1.PlatformCredentialsRotated))
+	} else {
+		condition, err := r.platformCredentialsRotatedCondition(ctx, hcluster)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		meta.SetStatusCondition(&hcluster.Status.Conditions, condition)
+	}
+
+	// Set ZoneSpreadAchieved condition
+	if hcluster.Spec.ControllerAvailabilityPolicy != hyperv1.HighlyAvailable {
+		meta.RemoveStatusCondition(&hcluster.Status.Conditions, string(hyperv1.ZoneSpreadAchieved))
+	} else {
+		condition, err := r.zoneSpreadAchievedCondition(ctx, hcluster)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		meta.SetStatusCondition(&hcluster.Status.Conditions, condition)
+	}
+
 	// Set Progressing condition
 	{
 		condition := metav1.Condition{
@@ -1042,6 +1251,16 @@ func (r *HostedClusterReconciler) reconcile(ctx context.Context, req ctrl.Reques
 		meta.SetStatusCondition(&hcluster.Status.Conditions, condition)
 	}
 
+	// Set the component statuses and the Healthy condition
+	if err := r.reconcileComponentStatuses(ctx, hcluster, hcp); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to reconcile component statuses: %w", err)
+	}
+
+	// Set the expiration status
+	if reconcileLifetimeStatus(hcluster, r.now().Time) {
+		r.recorder.Eventf(hcluster, corev1.EventTypeWarning, hyperv1.LifetimeExpiringReason, "The lifetime of the HostedCluster expires, it will be deleted at %s", hcluster.Status.ExpirationTime.UTC().Format(time.RFC3339))
+	}
+
 	// Persist status updates
 	if err := r.Client.Status().Update(ctx, hcluster); err != nil {
 		if apierrors.IsConflict(err) {
@@ -1064,12 +1283,27 @@ func (r *HostedClusterReconciler) reconcile(ctx context.Context, req ctrl.Reques
 	}
 
 	// if paused: ensure associated HostedControlPlane (if it exists) is also paused and stop reconciliation
-	if isPaused, duration := hyperutil.IsReconciliationPaused(log, hcluster.Spec.PausedUntil); isPaused {
-		if err := pauseHostedControlPlane(ctx, r.Client, hcp, hcluster.Spec.PausedUntil); err != nil {
-			return ctrl.Result{}, err
+	if pausedUntil := hyperutil.HostedClusterPausedUntil(hcluster, hyperv1.PauseScopeAll); pausedUntil != nil {
+		if isPaused, duration := hyperutil.IsReconciliationPaused(log, pausedUntil); isPaused {
+			if err := pauseHostedControlPlane(ctx, r.Client, hcp, pausedUntil); err != nil {
+				return ctrl.Result{}, err
+			}
+			log.Info("Reconciliation paused", "name", req.NamespacedName, "pausedUntil", *pausedUntil)
+			return ctrl.Result{RequeueAfter: duration}, nil
 		}
-		log.Info("Reconciliation paused", "name", req.NamespacedName, "pausedUntil", *hcluster.Spec.PausedUntil)
-		return ctrl.Result{RequeueAfter: duration}, nil
+	}
+
+	// Delete the HostedCluster once its lifetime expired, its deletion is then reconciled as any other. A protected
+	// HostedCluster is deleted once its deletion is allowed.
+	if lifetimeExpired(hcluster, r.now().Time) && !hyperutil.HCDeletionAllowed(hcluster) {
+		log.Info("Lifetime of hostedcluster expired, but its deletion is not allowed", "expirationTime", hcluster.Status.ExpirationTime)
+	} else if lifetimeExpired(hcluster, r.now().Time) {
+		log.Info("Deleting hostedcluster, its lifetime expired", "expirationTime", hcluster.Status.ExpirationTime)
+		r.recorder.Eventf(hcluster, corev1.EventTypeWarning, hyperv1.LifetimeExpiredReason, "The lifetime of the HostedCluster expired at %s, deleting it", hcluster.Status.ExpirationTime.UTC().Format(time.RFC3339))
+		if err := r.Delete(ctx, hcluster); err != nil && !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, fmt.Errorf("failed to delete expired hostedcluster: %w", err)
+		}
+		return ctrl.Result{}, nil
 	}
 
 	if err := r.defaultClusterIDsIfNeeded(ctx, hcluster); err != nil {
@@ -1085,6 +1319,11 @@ func (r *HostedClusterReconciler) reconcile(ctx context.Context, req ctrl.Reques
 		return ctrl.Result{}, err
 	}
 
+	// releaseHCluster is the HostedCluster whose release is reconciled for the control plane. It only differs from the
+	// HostedCluster while an upgrade of the control plane is paused.
+	releaseHCluster := hcluster
+	var upgradePausedFor time.Duration
+
 	// Block here if the cluster configuration does not pass validation
 	{
 		validConfig := meta.FindStatusCondition(hcluster.Status.Conditions, string(hyperv1.ValidHostedClusterConfiguration))
@@ -1105,6 +1344,19 @@ func (r *HostedClusterReconciler) reconcile(ctx context.Context, req ctrl.Reques
 			log.Error(fmt.Errorf("release image is invalid"), "reconciliation is blocked", "message", validReleaseImage.Message)
 			return ctrl.Result{}, nil
 		}
+		releaseImageVerified := meta.FindStatusCondition(hcluster.Status.Conditions, string(hyperv1.ReleaseImageVerified))
+		if releaseImageVerified != nil && releaseImageVerified.Status == metav1.ConditionFalse {
+			if releaseImageVerified.Reason == hyperv1.ImageVerificationErrorReason {
+				return ctrl.Result{}, errors.New(releaseImageVerified.Message)
+			}
+			log.Error(fmt.Errorf("release image signature is not verified"), "reconciliation is blocked", "message", releaseImageVerified.Message)
+			return ctrl.Result{}, nil
+		}
+		validFIPSConfiguration := meta.FindStatusCondition(hcluster.Status.Conditions, string(hyperv1.ValidFIPSConfiguration))
+		if validFIPSConfiguration != nil && validFIPSConfiguration.Status == metav1.ConditionFalse {
+			log.Error(fmt.Errorf("FIPS mode is not supported"), "reconciliation is blocked", "message", validFIPSConfiguration.Message)
+			return ctrl.Result{}, nil
+		}
 		upgrading, msg, err := isUpgrading(hcluster, releaseImage)
 		if upgrading {
 			if err != nil {
@@ -1114,6 +1366,20 @@ func (r *HostedClusterReconciler) reconcile(ctx context.Context, req ctrl.Reques
 			if msg != "" {
 				log.Info(msg)
 			}
+			if hcluster.Annotations[hyperv1.BlockUpgradeOnZoneSpreadViolationAnnotation] == "true" {
+				if zoneSpread := meta.FindStatusCondition(hcluster.Status.Conditions, string(hyperv1.ZoneSpreadAchieved)); zoneSpread != nil && zoneSpread.Status == metav1.ConditionFalse {
+					log.Error(fmt.Errorf("control plane zone spread is violated"), "upgrade is blocked", "message", zoneSpread.Message)
+					return ctrl.Result{}, nil
+				}
+			}
+			// The control plane keeps running its current release until the pause of upgrades ends, while the rest of
+			// the HostedCluster keeps being reconciled.
+			if isPaused, duration := hyperutil.IsReconciliationPaused(log, hyperutil.HostedClusterPausedUntil(hcluster, hyperv1.PauseScopeControlPlaneUpgrades)); isPaused {
+				releaseHCluster = currentReleaseHostedCluster(hcluster, hcp)
+				upgradePausedFor = duration
+				log.Info("Control plane upgrade paused", "pausedUntil", *hcluster.Spec.PausedUntil,
+					"release", hyperutil.HCControlPlaneReleaseImage(releaseHCluster), "heldRelease", hyperutil.HCControlPlaneReleaseImage(hcluster))
+			}
 		}
 	}
 
@@ -1125,11 +1391,11 @@ func (r *HostedClusterReconciler) reconcile(ctx context.Context, req ctrl.Reques
 	if !ok {
 		return ctrl.Result{}, fmt.Errorf("expected %s key in pull secret", corev1.DockerConfigJsonKey)
 	}
-	controlPlaneOperatorImage, err := GetControlPlaneOperatorImage(ctx, hcluster, releaseProvider, r.HypershiftOperatorImage, pullSecretBytes)
+	controlPlaneOperatorImage, err := GetControlPlaneOperatorImage(ctx, releaseHCluster, releaseProvider, r.HypershiftOperatorImage, pullSecretBytes)
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to get controlPlaneOperatorImage: %w", err)
 	}
-	controlPlaneOperatorImageLabels, err := GetControlPlaneOperatorImageLabels(ctx, hcluster, controlPlaneOperatorImage, pullSecretBytes, registryClientImageMetadataProvider)
+	controlPlaneOperatorImageLabels, err := GetControlPlaneOperatorImageLabels(ctx, releaseHCluster, controlPlaneOperatorImage, pullSecretBytes, registryClientImageMetadataProvider)
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to get controlPlaneOperatorImageLabels: %w", err)
 	}
@@ -1152,6 +1418,9 @@ func (r *HostedClusterReconciler) reconcile(ctx context.Context, req ctrl.Reques
 
 	// Reconcile the hosted cluster namespace
 	_, err = createOrUpdate(ctx, r.Client, controlPlaneNamespace, func() error {
+		// Propagate the HostedCluster labels and annotations first, so the ones set below take precedence
+		propagateControlPlaneNamespaceMetadata(controlPlaneNamespace, hcluster)
+
 		if controlPlaneNamespace.Labels == nil {
 			controlPlaneNamespace.Labels = make(map[string]string)
 		}
@@ -1188,7 +1457,7 @@ func (r *HostedClusterReconciler) reconcile(ctx context.Context, req ctrl.Reques
 		return ctrl.Result{}, fmt.Errorf("failed to reconcile namespace: %w", err)
 	}
 
-	p, err := platform.GetPlatform(ctx, hcluster, releaseProvider, utilitiesImage, pullSecretBytes)
+	p, err := platform.GetPlatform(ctx, releaseHCluster, releaseProvider, utilitiesImage, pullSecretBytes)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
@@ -1247,6 +1516,70 @@ func (r *HostedClusterReconciler) reconcile(ctx context.Context, req ctrl.Reques
 		}
 	}
 
+	// Reconcile the ignition server payload storage secret by syncing the secret referenced by the HostedCluster
+	// in the control plane namespace.
+	{
+		dst := ignitionserver.PayloadStorageSecret(controlPlaneNamespace.Name)
+		if srcName, ok := hcluster.Annotations[hyperv1.IgnitionServerPayloadStorageSecretAnnotation]; ok {
+			var src corev1.Secret
+			if err := r.Client.Get(ctx, client.ObjectKey{Namespace: hcluster.GetNamespace(), Name: srcName}, &src); err != nil {
+				return ctrl.Result{}, fmt.Errorf("failed to get ignition server payload storage secret %s: %w", srcName, err)
+			}
+			_, err = createOrUpdate(ctx, r.Client, dst, func() error {
+				dst.Type = corev1.SecretTypeOpaque
+				dst.Data = src.Data
+				return nil
+			})
+			if err != nil {
+				return ctrl.Result{}, fmt.Errorf("failed to reconcile ignition server payload storage secret: %w", err)
+			}
+		} else if _, err := hyperutil.DeleteIfNeeded(ctx, r.Client, dst); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to delete ignition server payload storage secret: %w", err)
+		}
+	}
+
+	// Reconcile the ignition server attestation configmap by syncing the configmap referenced by the HostedCluster
+	// in the control plane namespace.
+	{
+		dst := ignitionserver.AttestationConfigMap(controlPlaneNamespace.Name)
+		if srcName, ok := hcluster.Annotations[hyperv1.IgnitionServerAttestationConfigAnnotation]; ok {
+			var src corev1.ConfigMap
+			if err := r.Client.Get(ctx, client.ObjectKey{Namespace: hcluster.GetNamespace(), Name: srcName}, &src); err != nil {
+				return ctrl.Result{}, fmt.Errorf("failed to get ignition server attestation configmap %s: %w", srcName, err)
+			}
+			_, err = createOrUpdate(ctx, r.Client, dst, func() error {
+				dst.Data = src.Data
+				return nil
+			})
+			if err != nil {
+				return ctrl.Result{}, fmt.Errorf("failed to reconcile ignition server attestation configmap: %w", err)
+			}
+		} else if _, err := hyperutil.DeleteIfNeeded(ctx, r.Client, dst); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to delete ignition server attestation configmap: %w", err)
+		}
+	}
+
+	// Reconcile the control plane extensions configmap by syncing the configmap referenced by the HostedCluster in
+	// the control plane namespace. The control plane operator validates and applies it.
+	{
+		dst := cpomanifests.ControlPlaneExtensionsConfigMap(controlPlaneNamespace.Name)
+		if srcName, ok := hcluster.Annotations[hyperv1.ControlPlaneExtensionsAnnotation]; ok {
+			var src corev1.ConfigMap
+			if err := r.Client.Get(ctx, client.ObjectKey{Namespace: hcluster.GetNamespace(), Name: srcName}, &src); err != nil {
+				return ctrl.Result{}, fmt.Errorf("failed to get control plane extensions configmap %s: %w", srcName, err)
+			}
+			_, err = createOrUpdate(ctx, r.Client, dst, func() error {
+				dst.Data = src.Data
+				return nil
+			})
+			if err != nil {
+				return ctrl.Result{}, fmt.Errorf("failed to reconcile control plane extensions configmap: %w", err)
+			}
+		} else if _, err := hyperutil.DeleteIfNeeded(ctx, r.Client, dst); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to delete control plane extensions configmap: %w", err)
+		}
+	}
+
 	// Reconcile the HostedControlPlane Secret Encryption Info
 	if hcluster.Spec.SecretEncryption != nil {
 		log.Info("Reconciling secret encryption configuration")
@@ -1504,12 +1837,39 @@ func (r *HostedClusterReconciler) reconcile(ctx context.Context, req ctrl.Reques
 	}
 	hcp = controlplaneoperator.HostedControlPlane(controlPlaneNamespace.Name, hcluster.Name)
 	_, err = createOrUpdate(ctx, r.Client, hcp, func() error {
-		return reconcileHostedControlPlane(hcp, hcluster, isAutoscalingNeeded)
+		if err := reconcileHostedControlPlane(hcp, releaseHCluster, isAutoscalingNeeded); err != nil {
+			return err
+		}
+		for key, value := range r.ControlPlaneHardening {
+			if _, isSet := hcluster.Annotations[key]; !isSet && value != "" {
+				hcp.Annotations[key] = value
+			}
+		}
+		if dropped := reconcileResourceIdentityTags(hcp, hcluster, r.MandatoryResourceTags); len(dropped) > 0 {
+			log.Info("Not applying resource tags, the maximum number of resource tags is reached", "keys", dropped)
+		}
+		return nil
 	})
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to reconcile hostedcontrolplane: %w", err)
 	}
 
+	// Harden the control plane workloads deployed by this operator like the ones deployed by the control plane operator.
+	if hardening := config.ControlPlaneHardeningFrom(hcp.Annotations); hardening != nil {
+		upstreamCreateOrUpdate := createOrUpdate
+		createOrUpdate = func(ctx context.Context, c client.Client, obj client.Object, f controllerutil.MutateFn) (controllerutil.OperationResult, error) {
+			return upstreamCreateOrUpdate(ctx, c, obj, func() error {
+				if err := f(); err != nil {
+					return err
+				}
+				if obj.GetNamespace() == controlPlaneNamespace.Name {
+					hardening.ApplyTo(obj)
+				}
+				return nil
+			})
+		}
+	}
+
 	// Reconcile CAPI Infra CR.
 	infraCR, err := p.ReconcileCAPIInfraCR(ctx, r.Client, createOrUpdate,
 		hcluster,
@@ -1529,7 +1889,7 @@ func (r *HostedClusterReconciler) reconcile(ctx context.Context, req ctrl.Reques
 		return ctrl.Result{}, err
 	}
 	if capiProviderDeploymentSpec != nil {
-		proxy.SetEnvVars(&capiProviderDeploymentSpec.Template.Spec.Containers[0].Env)
+		proxy.SetEnvVarsFor(&capiProviderDeploymentSpec.Template.Spec.Containers[0].Env, proxy.ForHostedCluster(hcluster))
 	}
 
 	// Reconcile cluster prometheus RBAC resources if enabled
@@ -1642,7 +2002,7 @@ func (r *HostedClusterReconciler) reconcile(ctx context.Context, req ctrl.Reques
 	// Disable machine management components if enabled
 	if _, exists := hcluster.Annotations[hyperv1.DisableMachineManagement]; !exists {
 		// Reconcile the CAPI manager components
-		err = r.reconcileCAPIManager(ctx, createOrUpdate, hcluster, hcp, pullSecretBytes, &releaseProvider)
+		err = r.reconcileCAPIManager(ctx, createOrUpdate, releaseHCluster, hcp, pullSecretBytes, &releaseProvider)
 		if err != nil {
 			return ctrl.Result{}, fmt.Errorf("failed to reconcile capi manager: %w", err)
 		}
@@ -1655,7 +2015,7 @@ func (r *HostedClusterReconciler) reconcile(ctx context.Context, req ctrl.Reques
 
 	// Get release image version
 	var releaseImageVersion semver.Version
-	releaseInfo, err := r.lookupReleaseImage(ctx, hcluster, releaseProvider)
+	releaseInfo, err := r.lookupReleaseImage(ctx, releaseHCluster, releaseProvider)
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to lookup release image: %w", err)
 	}
@@ -1669,14 +2029,14 @@ func (r *HostedClusterReconciler) reconcile(ctx context.Context, req ctrl.Reques
 	// TODO (alberto): drop this after dropping < 4.11 support.
 	if !controlPlaneOperatorManagesMachineAutoscaler {
 		// Reconcile the autoscaler.
-		err = r.reconcileAutoscaler(ctx, createOrUpdate, hcluster, hcp, utilitiesImage, pullSecretBytes, releaseImageVersion, releaseProvider)
+		err = r.reconcileAutoscaler(ctx, createOrUpdate, releaseHCluster, hcp, utilitiesImage, pullSecretBytes, releaseImageVersion, releaseProvider)
 		if err != nil {
 			return ctrl.Result{}, fmt.Errorf("failed to reconcile autoscaler: %w", err)
 		}
 	}
 	if !controlPlaneOperatorManagesMachineApprover {
 		// Reconcile the machine approver.
-		if err = r.reconcileMachineApprover(ctx, createOrUpdate, hcluster, hcp, utilitiesImage, pullSecretBytes, releaseImageVersion, releaseProvider); err != nil {
+		if err = r.reconcileMachineApprover(ctx, createOrUpdate, releaseHCluster, hcp, utilitiesImage, pullSecretBytes, releaseImageVersion, releaseProvider); err != nil {
 			return ctrl.Result{}, fmt.Errorf("failed to reconcile machine approver: %w", err)
 		}
 	}
@@ -1697,7 +2057,7 @@ func (r *HostedClusterReconciler) reconcile(ctx context.Context, req ctrl.Reques
 	}
 
 	// Reconcile the control plane operator
-	err = r.reconcileControlPlaneOperator(ctx, createOrUpdate, hcluster, hcp, controlPlaneOperatorImage, utilitiesImage, defaultIngressDomain, cpoHasUtilities, openShiftTrustedCABundleConfigMapExists, r.CertRotationScale, releaseImageVersion, releaseProvider)
+	err = r.reconcileControlPlaneOperator(ctx, createOrUpdate, releaseHCluster, hcp, controlPlaneOperatorImage, utilitiesImage, defaultIngressDomain, cpoHasUtilities, openShiftTrustedCABundleConfigMapExists, r.CertRotationScale, releaseImageVersion, releaseProvider)
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to reconcile control plane operator: %w", err)
 	}
@@ -1712,7 +2072,7 @@ func (r *HostedClusterReconciler) reconcile(ctx context.Context, req ctrl.Reques
 
 	// Reconcile the Ignition server
 	if !controlplaneOperatorManagesIgnitionServer {
-		releaseInfo, err := r.lookupReleaseImage(ctx, hcluster, releaseProvider)
+		releaseInfo, err := r.lookupReleaseImage(ctx, releaseHCluster, releaseProvider)
 		if err != nil {
 			return ctrl.Result{}, fmt.Errorf("failed to lookup release image: %w", err)
 		}
@@ -1738,10 +2098,10 @@ func (r *HostedClusterReconciler) reconcile(ctx context.Context, req ctrl.Reques
 		return ctrl.Result{}, fmt.Errorf("failed to reconcile network policies: %w", err)
 	}
 
-	// Reconcile the AWS OIDC discovery
-	switch hcluster.Spec.Platform.Type {
-	case hyperv1.AWSPlatform:
-		if err := r.reconcileAWSOIDCDocuments(ctx, log, hcluster, hcp); err != nil {
+	// Reconcile the OIDC discovery of AWS clusters, and of the other clusters whose issuer is served from the OIDC
+	// storage provider
+	if hcluster.Spec.Platform.Type == hyperv1.AWSPlatform || r.servesOIDCDocuments(hcluster) {
+		if err := r.reconcileOIDCDocuments(ctx, log, hcluster, hcp); err != nil {
 			meta.SetStatusCondition(&hcluster.Status.Conditions, metav1.Condition{
 				Type:               string(hyperv1.ValidOIDCConfiguration),
 				Status:             metav1.ConditionFalse,
@@ -1750,9 +2110,9 @@ func (r *HostedClusterReconciler) reconcile(ctx context.Context, req ctrl.Reques
 				Message:            err.Error(),
 			})
 			if statusErr := r.Client.Status().Update(ctx, hcluster); statusErr != nil {
-				return ctrl.Result{}, fmt.Errorf("failed to reconcile AWS OIDC documents: %s, failed to update status: %w", err, statusErr)
+				return ctrl.Result{}, fmt.Errorf("failed to reconcile OIDC documents: %s, failed to update status: %w", err, statusErr)
 			}
-			return ctrl.Result{}, fmt.Errorf("failed to reconcile the AWS OIDC documents: %w", err)
+			return ctrl.Result{}, fmt.Errorf("failed to reconcile the OIDC documents: %w", err)
 		}
 		meta.SetStatusCondition(&hcluster.Status.Conditions, metav1.Condition{
 			Type:               string(hyperv1.ValidOIDCConfiguration),
@@ -1767,7 +2127,32 @@ func (r *HostedClusterReconciler) reconcile(ctx context.Context, req ctrl.Reques
 	}
 
 	log.Info("successfully reconciled")
-	return ctrl.Result{}, nil
+	// Roll out the held control plane upgrade once the pause of upgrades ends.
+	requeueAfter := upgradePausedFor
+	// Disable the pprof endpoints of the control plane operator once the time they were enabled for is over.
+	if until, enabled := config.PprofEnabledUntil(hcluster.Annotations, time.Now()); enabled && (requeueAfter == 0 || time.Until(until) < requeueAfter) {
+		requeueAfter = time.Until(until)
+	}
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// currentReleaseHostedCluster returns a copy of the HostedCluster whose release is the one its control plane currently
+// runs: the release of the HostedControlPlane, or the latest release of the version history when the
+// HostedControlPlane does not exist.
+func currentReleaseHostedCluster(hcluster *hyperv1.HostedCluster, hcp *hyperv1.HostedControlPlane) *hyperv1.HostedCluster {
+	current := hcluster.DeepCopy()
+	switch {
+	case hcp != nil && hcp.Spec.ReleaseImage != "":
+		current.Spec.Release.Image = hcp.Spec.ReleaseImage
+		current.Spec.ControlPlaneRelease = nil
+		if hcp.Spec.ControlPlaneReleaseImage != nil {
+			current.Spec.ControlPlaneRelease = &hyperv1.Release{Image: *hcp.Spec.ControlPlaneReleaseImage}
+		}
+	case hcluster.Status.Version != nil && len(hcluster.Status.Version.History) > 0:
+		current.Spec.Release.Image = hcluster.Status.Version.History[0].Image
+		current.Spec.ControlPlaneRelease = nil
+	}
+	return current
 }
 
 // reconcileHostedControlPlane reconciles the given HostedControlPlane, which
@@ -1789,6 +2174,7 @@ func reconcileHostedControlPlane(hcp *hyperv1.HostedControlPlane, hcluster *hype
 		hyperv1.PortierisImageAnnotation,
 		hyperutil.DebugDeploymentsAnnotation,
 		hyperv1.DisableProfilingAnnotation,
+		hyperv1.EnablePprofUntilAnnotation,
 		hyperv1.PrivateIngressControllerAnnotation,
 		hyperv1.IngressControllerLoadBalancerScope,
 		hyperv1.CleanupCloudResourcesAnnotation,
@@ -1805,9 +2191,22 @@ func reconcileHostedControlPlane(hcp *hyperv1.HostedControlPlane, hcluster *hype
 		hyperv1.OLMCatalogsISRegistryOverridesAnnotation,
 		hyperv1.KubeAPIServerGOGCAnnotation,
 		hyperv1.KubeAPIServerGOMemoryLimitAnnotation,
+		hyperv1.KubeAPIServerMaxRequestsInflightAnnotation,
+		hyperv1.KubeAPIServerMaxMutatingRequestsInflightAnnotation,
+		hyperv1.KubeAPIServerEnablePriorityAndFairnessAnnotation,
+		hyperv1.KubeAPIServerGoAwayChanceAnnotation,
+		hyperv1.KonnectivityAgentConnectionRebalancingAnnotation,
 		hyperv1.RequestServingNodeAdditionalSelectorAnnotation,
 		hyperv1.AWSLoadBalancerSubnetsAnnotation,
 		hyperv1.ManagementPlatformAnnotation,
+		hyperv1.IgnitionServerPayloadStorageSecretAnnotation,
+		hyperv1.IgnitionServerAttestationConfigAnnotation,
+		hyperv1.ControlPlaneHardeningProfileAnnotation,
+		hyperv1.ControlPlaneSeccompProfileAnnotation,
+		hyperv1.ControlPlaneHardeningExceptionsAnnotation,
+		hyperv1.ManagementClusterHTTPProxyAnnotation,
+		hyperv1.ManagementClusterHTTPSProxyAnnotation,
+		hyperv1.ManagementClusterNoProxyAnnotation,
 	}
 	for _, key := range mirroredAnnotations {
 		val, hasVal := hcluster.Annotations[key]
@@ -1859,6 +2258,7 @@ func reconcileHostedControlPlane(hcp *hyperv1.HostedControlPlane, hcluster *hype
 
 	hcp.Spec.FIPS = hcluster.Spec.FIPS
 	hcp.Spec.IssuerURL = hcluster.Spec.IssuerURL
+	hcp.Spec.SecondaryIssuerURL = hcluster.Spec.SecondaryIssuerURL
 	hcp.Spec.ServiceAccountSigningKey = hcluster.Spec.ServiceAccountSigningKey
 
 	hcp.Spec.Networking = hcluster.Spec.Networking
@@ -1886,10 +2286,16 @@ func reconcileHostedControlPlane(hcp *hyperv1.HostedControlPlane, hcluster *hype
 		hcp.Spec.SecretEncryption = hcluster.Spec.SecretEncryption.DeepCopy()
 	}
 
-	hcp.Spec.PausedUntil = hcluster.Spec.PausedUntil
+	hcp.Spec.PausedUntil = hyperutil.HostedClusterPausedUntil(hcluster, hyperv1.PauseScopeAll)
 	hcp.Spec.OLMCatalogPlacement = hcluster.Spec.OLMCatalogPlacement
+	hcp.Spec.OLMCatalogs = hcluster.Spec.OLMCatalogs
 	hcp.Spec.Autoscaling = hcluster.Spec.Autoscaling
 	hcp.Spec.NodeSelector = hcluster.Spec.NodeSelector
+	hcp.Spec.Labels = hcluster.Spec.Labels
+	hcp.Spec.Annotations = hcluster.Spec.Annotations
+	hcp.Spec.DataPlaneInfrastructurePlacement = hcluster.Spec.DataPlaneInfrastructurePlacement
+	hcp.Spec.DefaultIngressController = hcluster.Spec.DefaultIngressController
+	hcp.Spec.Storage = hcluster.Spec.Storage
 
 	// Pass through Platform spec.
 	hcp.Spec.Platform = *hcluster.Spec.Platform.DeepCopy()
@@ -2637,6 +3043,8 @@ func reconcileControlPlaneOperatorDeployment(
 		)
 	}
 
+	config.DeploymentAddPprofBindAddress(hc.Annotations, deployment)
+
 	managedServiceType, ok := os.LookupEnv(managedServiceEnvVar)
 	if ok {
 		deployment.Spec.Template.Spec.Containers[0].Env = append(deployment.Spec.Template.Spec.Containers[0].Env,
@@ -2648,7 +3056,8 @@ func reconcileControlPlaneOperatorDeployment(
 	}
 
 	mainContainer = hyperutil.FindContainer("control-plane-operator", deployment.Spec.Template.Spec.Containers)
-	proxy.SetEnvVars(&mainContainer.Env)
+	// The control plane operator passes its proxy on to the control plane components
+	proxy.SetEnvVarsFor(&mainContainer.Env, proxy.ForHostedCluster(hc))
 
 	// Add platform specific settings
 	switch hc.Spec.Platform.Type {
@@ -2683,6 +3092,12 @@ func reconcileControlPlaneOperatorDeployment(
 				Name:  "AWS_SDK_LOAD_CONFIG",
 				Value: "true",
 			})
+		if len(hc.Spec.Platform.AWS.ServiceEndpoints) > 0 {
+			deployment.Spec.Template.Spec.Containers[0].Env = append(deployment.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{
+				Name:  supportawsutil.EndpointOverridesEnvVar,
+				Value: supportawsutil.FormatEndpointOverrides(supportawsutil.EndpointOverrides(hc.Spec.Platform.AWS.ServiceEndpoints)),
+			})
+		}
 		deployment.Spec.Template.Spec.Containers[0].VolumeMounts = append(deployment.Spec.Template.Spec.Containers[0].VolumeMounts,
 			corev1.VolumeMount{
 				Name:      "cloud-token",
@@ -3542,28 +3957,12 @@ func computeUnmanagedEtcdAvailability(hcluster *hyperv1.HostedCluster, unmanaged
 			Message: "etcd metadata not specified for unmanaged deployment",
 		}
 	}
-	if _, ok := unmanagedEtcdTLSClientSecret.Data["etcd-client.crt"]; !ok {
+	if _, err := etcd.ValidateUnmanagedClientSecret(unmanagedEtcdTLSClientSecret, time.Now()); err != nil {
 		return metav1.Condition{
 			Type:    string(hyperv1.UnmanagedEtcdAvailable),
 			Status:  metav1.ConditionFalse,
 			Reason:  hyperv1.UnmanagedEtcdMisconfiguredReason,
-			Message: fmt.Sprintf("etcd secret %s does not have client cert", hcluster.Spec.Etcd.Unmanaged.TLS.ClientSecret.Name),
-		}
-	}
-	if _, ok := unmanagedEtcdTLSClientSecret.Data["etcd-client.key"]; !ok {
-		return metav1.Condition{
-			Type:    string(hyperv1.UnmanagedEtcdAvailable),
-			Status:  metav1.ConditionFalse,
-			Reason:  hyperv1.UnmanagedEtcdMisconfiguredReason,
-			Message: fmt.Sprintf("etcd secret %s does not have client key", hcluster.Spec.Etcd.Unmanaged.TLS.ClientSecret.Name),
-		}
-	}
-	if _, ok := unmanagedEtcdTLSClientSecret.Data["etcd-client-ca.crt"]; !ok {
-		return metav1.Condition{
-			Type:    string(hyperv1.UnmanagedEtcdAvailable),
-			Status:  metav1.ConditionFalse,
-			Reason:  hyperv1.UnmanagedEtcdMisconfiguredReason,
-			Message: fmt.Sprintf("etcd secret %s does not have client ca", hcluster.Spec.Etcd.Unmanaged.TLS.ClientSecret.Name),
+			Message: err.Error(),
 		}
 	}
 	return metav1.Condition{
@@ -3576,6 +3975,8 @@ func computeUnmanagedEtcdAvailability(hcluster *hyperv1.HostedCluster, unmanaged
 func computeAWSEndpointServiceCondition(awsEndpointServiceList hyperv1.AWSEndpointServiceList, conditionType hyperv1.ConditionType) metav1.Condition {
 	var messages []string
 	var conditions []metav1.Condition
+	// The reason of the first failed condition is kept, so the failure can still be told apart.
+	var reason string
 
 	for _, awsEndpoint := range awsEndpointServiceList.Items {
 		condition := meta.FindStatusCondition(awsEndpoint.Status.Conditions, string(conditionType))
@@ -3584,6 +3985,9 @@ func computeAWSEndpointServiceCondition(awsEndpointServiceList hyperv1.AWSEndpoi
 
 			if condition.Status == metav1.ConditionFalse {
 				messages = append(messages, condition.Message)
+				if reason == "" {
+					reason = condition.Reason
+				}
 			}
 		}
 	}
@@ -3601,7 +4005,7 @@ func computeAWSEndpointServiceCondition(awsEndpointServiceList hyperv1.AWSEndpoi
 		return metav1.Condition{
 			Type:    string(conditionType),
 			Status:  metav1.ConditionFalse,
-			Reason:  hyperv1.AWSErrorReason,
+			Reason:  reason,
 			Message: strings.Join(messages, "; "),
 		}
 	}
@@ -3614,6 +4018,17 @@ func computeAWSEndpointServiceCondition(awsEndpointServiceList hyperv1.AWSEndpoi
 	}
 }
 
+// kubevirtInfraNamespaces returns the sorted infra namespaces in which the NodePools create KubeVirt virtual machines.
+func kubevirtInfraNamespaces(nodePools []hyperv1.NodePool) []string {
+	infraNamespaces := sets.New[string]()
+	for _, nodePool := range nodePools {
+		if nodePool.Status.Platform != nil && nodePool.Status.Platform.KubeVirt != nil && nodePool.Status.Platform.KubeVirt.InfraNamespace != "" {
+			infraNamespaces.Insert(nodePool.Status.Platform.KubeVirt.InfraNamespace)
+		}
+	}
+	return sets.List(infraNamespaces)
+}
+
 func listNodePools(ctx context.Context, c client.Client, clusterNamespace, clusterName string) ([]hyperv1.NodePool, error) {
 	nodePoolList := &hyperv1.NodePoolList{}
 	if err := c.List(ctx, nodePoolList); err != nil {
@@ -3647,6 +4062,7 @@ func (r *HostedClusterReconciler) deleteNodePools(ctx context.Context, c client.
 
 // deleteAWSEndpointServices loops over AWSEndpointServiceList items and sends a delete request for each.
 // If the HC has no valid aws credentials it removes the CPO finalizer for each AWSEndpointService.
+// If the deletion policy of the HC is OrphanInfra it removes the CPO finalizer before deleting them.
 // It returns true if len(awsEndpointServiceList.Items) != 0.
 func deleteAWSEndpointServices(ctx context.Context, c client.Client, hc *hyperv1.HostedCluster, namespace string) (bool, error) {
 	log := ctrl.LoggerFrom(ctx)
@@ -3654,9 +4070,23 @@ func deleteAWSEndpointServices(ctx context.Context, c client.Client, hc *hyperv1
 	if err := c.List(ctx, &awsEndpointServiceList, &client.ListOptions{Namespace: namespace}); err != nil && !apierrors.IsNotFound(err) {
 		return false, fmt.Errorf("error listing awsendpointservices in namespace %s: %w", namespace, err)
 	}
+	orphanInfra := hyperutil.HCDeletionPolicyType(hc) == hyperv1.DeletionPolicyOrphanInfra
 	for _, ep := range awsEndpointServiceList.Items {
+		// With the OrphanInfra deletion policy the CPO finalizer is removed before the deletion, so that the
+		// endpoint service is left intact in AWS.
+		if orphanInfra {
+			cpoFinalizer := "hypershift.openshift.io/control-plane-operator-finalizer"
+			if controllerutil.ContainsFinalizer(&ep, cpoFinalizer) {
+				controllerutil.RemoveFinalizer(&ep, cpoFinalizer)
+				if err := c.Update(ctx, &ep); err != nil {
+					return false, fmt.Errorf("failed to remove finalizer from awsendpointservice: %w", err)
+				}
+				log.Info("Removed CPO finalizer for awsendpointservice because of the OrphanInfra deletion policy", "name", ep.Name, "endpoint-id", ep.Status.EndpointID)
+			}
+		}
+
 		if ep.DeletionTimestamp != nil {
-			if platformaws.ValidCredentials(hc) {
+			if platformaws.ValidCredentials(hc) || orphanInfra {
 				continue
 			}
 
@@ -3700,34 +4130,50 @@ func (r *HostedClusterReconciler) delete(ctx context.Context, hc *hyperv1.Hosted
 	controlPlaneNamespace := manifests.HostedControlPlaneNamespace(hc.Namespace, hc.Name)
 	log := ctrl.LoggerFrom(ctx)
 
-	// ensure that the cleanup annotation has been propagated to the hcp if it is set
-	if hc.Annotations[hyperv1.CleanupCloudResourcesAnnotation] == "true" {
+	// ensure that the cleanup annotation has been propagated to the hcp if it is set, or that it's removed from the
+	// hcp when the deletion policy leaves the cloud resources of the cluster behind.
+	orphanInfra := hyperutil.HCDeletionPolicyType(hc) == hyperv1.DeletionPolicyOrphanInfra
+	cleanupCloudResources := hc.Annotations[hyperv1.CleanupCloudResourcesAnnotation] == "true" && !orphanInfra
+	if cleanupCloudResources || orphanInfra {
 		hcp := controlplaneoperator.HostedControlPlane(controlPlaneNamespace, hc.Name)
 		err := r.Get(ctx, client.ObjectKeyFromObject(hcp), hcp)
 		if err != nil && !apierrors.IsNotFound(err) {
 			return false, fmt.Errorf("cannot get hosted control plane: %w", err)
 		}
-		if err == nil && hcp.Annotations[hyperv1.CleanupCloudResourcesAnnotation] != "true" {
+		if err == nil && (hcp.Annotations[hyperv1.CleanupCloudResourcesAnnotation] == "true") != cleanupCloudResources {
 			original := hcp.DeepCopy()
 			if hcp.Annotations == nil {
 				hcp.Annotations = map[string]string{}
 			}
-			hcp.Annotations[hyperv1.CleanupCloudResourcesAnnotation] = "true"
+			if cleanupCloudResources {
+				hcp.Annotations[hyperv1.CleanupCloudResourcesAnnotation] = "true"
+			} else {
+				delete(hcp.Annotations, hyperv1.CleanupCloudResourcesAnnotation)
+			}
 			if err := r.Patch(ctx, hcp, client.MergeFromWithOptions(original)); err != nil {
 				return false, fmt.Errorf("cannot patch hosted control plane with cleanup annotation: %w", err)
 			}
 		}
 	}
 
-	err := r.deleteNodePools(ctx, r.Client, hc.Namespace, hc.Name)
+	p, err := platform.GetPlatform(ctx, hc, nil, "", nil)
 	if err != nil {
 		return false, err
 	}
 
-	p, err := platform.GetPlatform(ctx, hc, nil, "", nil)
+	// Orphaned machines are handled before the NodePools are deleted, so that the machines of a cluster with the
+	// OrphanInfra deletion policy are paused before their deletion starts.
+	if od, ok := p.(platform.OrphanDeleter); ok && len(hc.Spec.InfraID) > 0 {
+		if err = od.DeleteOrphanedMachines(ctx, r.Client, hc, controlPlaneNamespace); err != nil {
+			return false, err
+		}
+	}
+
+	err = r.deleteNodePools(ctx, r.Client, hc.Namespace, hc.Name)
 	if err != nil {
 		return false, err
 	}
+
 	if hc != nil && len(hc.Spec.InfraID) > 0 {
 		exists, err := hyperutil.DeleteIfNeeded(ctx, r.Client, &capiv1.Cluster{
 			ObjectMeta: metav1.ObjectMeta{
@@ -3739,12 +4185,6 @@ func (r *HostedClusterReconciler) delete(ctx context.Context, hc *hyperv1.Hosted
 			return false, err
 		}
 
-		if od, ok := p.(platform.OrphanDeleter); ok {
-			if err = od.DeleteOrphanedMachines(ctx, r.Client, hc, controlPlaneNamespace); err != nil {
-				return false, err
-			}
-		}
-
 		if exists {
 			log.Info("Waiting for cluster deletion", "clusterName", hc.Spec.InfraID, "controlPlaneNamespace", controlPlaneNamespace)
 			return false, nil
@@ -3813,8 +4253,10 @@ func (r *HostedClusterReconciler) delete(ctx context.Context, hc *hyperv1.Hosted
 		return false, nil
 	}
 
-	if err := r.cleanupOIDCBucketData(ctx, log, hc); err != nil {
-		return false, fmt.Errorf("failed to clean up OIDC bucket data: %w", err)
+	if !orphanInfra {
+		if err := r.cleanupOIDCBucketData(ctx, log, hc); err != nil {
+			return false, fmt.Errorf("failed to clean up OIDC bucket data: %w", err)
+		}
 	}
 
 	r.KubevirtInfraClients.Delete(hc.Spec.InfraID)
@@ -3860,6 +4302,10 @@ func enqueueHostedClustersFunc(metricsSet metrics.MetricsSet, operatorNamespace
 			hostedClusterName = obj.GetAnnotations()[HostedClusterAnnotation]
 		}
 		if hostedClusterName == "" {
+			if secret, isSecret := obj.(*corev1.Secret); isSecret {
+				// The TLS client secret of an unmanaged etcd is provided by the user, so it isn't annotated.
+				return enqueueHostedClustersForUnmanagedEtcdSecret(ctx, c, secret)
+			}
 			return []reconcile.Request{}
 		}
 		return []reconcile.Request{
@@ -3868,6 +4314,23 @@ func enqueueHostedClustersFunc(metricsSet metrics.MetricsSet, operatorNamespace
 	}
 }
 
+// enqueueHostedClustersForUnmanagedEtcdSecret returns the HostedClusters using the secret as unmanaged etcd TLS client
+// secret, so rotations of the etcd client certificate are rolled out to the control plane.
+func enqueueHostedClustersForUnmanagedEtcdSecret(ctx context.Context, c client.Client, secret *corev1.Secret) []reconcile.Request {
+	hcList := &hyperv1.HostedClusterList{}
+	if err := c.List(ctx, hcList, client.InNamespace(secret.Namespace)); err != nil {
+		ctrllog.Log.Error(err, "failed to list hosted clusters while processing secret event", "secret", client.ObjectKeyFromObject(secret))
+		return []reconcile.Request{}
+	}
+	var requests []reconcile.Request
+	for _, hc := range hcList.Items {
+		if hc.Spec.Etcd.ManagementType == hyperv1.Unmanaged && hc.Spec.Etcd.Unmanaged != nil && hc.Spec.Etcd.Unmanaged.TLS.ClientSecret.Name == secret.Name {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&hc)})
+		}
+	}
+	return requests
+}
+
 func (r *HostedClusterReconciler) reconcileClusterPrometheusRBAC(ctx context.Context, createOrUpdate upsert.CreateOrUpdateFN, namespace string) error {
 	role := &rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "openshift-prometheus"}}
 	if _, err := createOrUpdate(ctx, r.Client, role, func() error {
@@ -3924,7 +4387,7 @@ func (r *HostedClusterReconciler) validateConfigAndClusterCapabilities(ctx conte
 	var errs []error
 	for _, svc := range hc.Spec.Services {
 		if svc.Type == hyperv1.Route && !r.ManagementClusterCapabilities.Has(capabilities.CapabilityRoute) {
-			errs = append(errs, fmt.Errorf("cluster does not support Routes, but service %q is exposed via a Route", svc.Service))
+			errs = append(errs, supportconditions.UnsupportedCombination("cluster does not support Routes, but service %q is exposed via a Route", svc.Service))
 		}
 	}
 
@@ -3967,9 +4430,56 @@ func (r *HostedClusterReconciler) validateConfigAndClusterCapabilities(ctx conte
 		errs = append(errs, err...)
 	}
 
+	if err := validateKubeAPIServerRequestLimits(hc); err != nil {
+		errs = append(errs, err...)
+	}
+
+	if err := validateConnectionRebalancing(hc); err != nil {
+		errs = append(errs, err...)
+	}
+
 	return utilerrors.NewAggregate(errs)
 }
 
+// validateKubeAPIServerRequestLimits validates the annotations tuning the inflight request limits and API Priority
+// and Fairness of the kube-apiserver.
+func validateKubeAPIServerRequestLimits(hc *hyperv1.HostedCluster) []error {
+	var errs []error
+	for _, annotation := range []string{hyperv1.KubeAPIServerMaxRequestsInflightAnnotation, hyperv1.KubeAPIServerMaxMutatingRequestsInflightAnnotation} {
+		value, ok := hc.Annotations[annotation]
+		if !ok {
+			continue
+		}
+		if limit, err := strconv.ParseInt(value, 10, 32); err != nil || limit < 1 {
+			errs = append(errs, fmt.Errorf("annotation %s must be a positive integer, got %q", annotation, value))
+		}
+	}
+	if value, ok := hc.Annotations[hyperv1.KubeAPIServerEnablePriorityAndFairnessAnnotation]; ok {
+		if _, err := strconv.ParseBool(value); err != nil {
+			errs = append(errs, fmt.Errorf("annotation %s must be a boolean, got %q", hyperv1.KubeAPIServerEnablePriorityAndFairnessAnnotation, value))
+		}
+	}
+	return errs
+}
+
+// validateConnectionRebalancing validates the annotations tuning how client and konnectivity agent connections
+// are redistributed across kube-apiserver replicas.
+func validateConnectionRebalancing(hc *hyperv1.HostedCluster) []error {
+	var errs []error
+	if value, ok := hc.Annotations[hyperv1.KubeAPIServerGoAwayChanceAnnotation]; ok {
+		// The kube-apiserver rejects values above 0.02, as a higher chance causes too many reconnections.
+		if chance, err := strconv.ParseFloat(value, 64); err != nil || chance < 0 || chance > 0.02 {
+			errs = append(errs, fmt.Errorf("annotation %s must be a number between 0 and 0.02, got %q", hyperv1.KubeAPIServerGoAwayChanceAnnotation, value))
+		}
+	}
+	if value, ok := hc.Annotations[hyperv1.KonnectivityAgentConnectionRebalancingAnnotation]; ok {
+		if _, err := strconv.ParseBool(value); err != nil {
+			errs = append(errs, fmt.Errorf("annotation %s must be a boolean, got %q", hyperv1.KonnectivityAgentConnectionRebalancingAnnotation, value))
+		}
+	}
+	return errs
+}
+
 func (r *HostedClusterReconciler) validateUserCAConfigMaps(ctx context.Context, hc *hyperv1.HostedCluster) []error {
 	var userCABundles []client.ObjectKey
 	if hc.Spec.AdditionalTrustBundle != nil {
@@ -3995,7 +4505,7 @@ func (r *HostedClusterReconciler) validateUserCAConfigMaps(ctx context.Context,
 	return errs
 }
 
-func (r *HostedClusterReconciler) validateReleaseImage(ctx context.Context, hc *hyperv1.HostedCluster, releaseProvider releaseinfo.ProviderWithOpenShiftImageRegistryOverrides) error {
+func (r *HostedClusterReconciler) validateReleaseImage(ctx context.Context, hc *hyperv1.HostedCluster, releaseProvider releaseinfo.ProviderWithOpenShiftImageRegistryOverrides, imageMetadataProvider hyperutil.ImageMetadataProvider) error {
 	if _, exists := hc.Annotations[hyperv1.SkipReleaseImageValidation]; exists {
 		return nil
 	}
@@ -4030,33 +4540,20 @@ func (r *HostedClusterReconciler) validateReleaseImage(ctx context.Context, hc *
 		currentVersion = &version
 	}
 
-	// Validate release image is multi-arch
-	if hc.Spec.Platform.Type == hyperv1.AWSPlatform && hc.Spec.Platform.AWS.MultiArch {
-		isMultiArchReleaseImage, err := registryclient.IsMultiArchManifestList(ctx, hc.Spec.Release.Image, pullSecretBytes)
-		if err != nil {
-			return fmt.Errorf("failed to determine if release image multi-arch: %w", err)
-		}
-
-		if !isMultiArchReleaseImage {
-			return fmt.Errorf("release image is not a multi-arch image")
-		}
+	// Validate the release image provides images for the CPU arch of the management cluster, which runs the control
+	// plane components. The NodePools validate their release image provides images for their own CPU arch, which
+	// doesn't need to match the management cluster's.
+	architectures, err := imageMetadataProvider.ImageArchitectures(ctx, hyperutil.HCControlPlaneReleaseImage(hc), pullSecretBytes)
+	if err != nil {
+		return fmt.Errorf("failed to look up the architectures of the release image: %w", err)
+	}
+	if mgmtClusterCPUArch := runtime.GOARCH; len(architectures) > 0 && !slices.Contains(architectures, mgmtClusterCPUArch) {
+		return fmt.Errorf("release image does not provide images for the management cluster cpu arch %s, it provides: %s", mgmtClusterCPUArch, strings.Join(architectures, ", "))
 	}
 
-	// Validate each NodePool CPU arch matches the management cluster's CPU arch when the Hosted Cluster is not multi-arch
-	if hc.Spec.Platform.Type == hyperv1.AWSPlatform && !hc.Spec.Platform.AWS.MultiArch {
-		nodePoolList := &hyperv1.NodePoolList{}
-		if err := r.List(ctx, nodePoolList, client.InNamespace(hc.Namespace)); err != nil {
-			return fmt.Errorf("failed to get list of nodepools for cpu arch validation: %w", err)
-		}
-
-		mgmtClusterCPUArch := runtime.GOARCH
-
-		for _, nodePool := range nodePoolList.Items {
-			if err := hyperutil.DoesMgmtClusterAndNodePoolCPUArchMatch(mgmtClusterCPUArch, nodePool.Spec.Arch); err != nil {
-				return err
-			}
-		}
-
+	// Validate release image is multi-arch
+	if hc.Spec.Platform.Type == hyperv1.AWSPlatform && hc.Spec.Platform.AWS.MultiArch && len(architectures) < 2 {
+		return fmt.Errorf("release image is not a multi-arch image")
 	}
 
 	minSupportedVersion := supportedversion.GetMinSupportedVersion(hc)
@@ -4067,7 +4564,7 @@ func (r *HostedClusterReconciler) validateReleaseImage(ctx context.Context, hc *
 func isProgressing(hc *hyperv1.HostedCluster, releaseImage *releaseinfo.ReleaseImage) (bool, error) {
 	for _, condition := range hc.Status.Conditions {
 		switch string(condition.Type) {
-		case string(hyperv1.SupportedHostedCluster), string(hyperv1.ValidHostedClusterConfiguration), string(hyperv1.ValidReleaseImage), string(hyperv1.ReconciliationActive):
+		case string(hyperv1.SupportedHostedCluster), string(hyperv1.ValidHostedClusterConfiguration), string(hyperv1.ValidReleaseImage), string(hyperv1.ReleaseImageVerified), string(hyperv1.ValidFIPSConfiguration), string(hyperv1.ReconciliationActive):
 			if condition.Status == metav1.ConditionFalse {
 				return false, fmt.Errorf("%s condition is false: %s", string(condition.Type), condition.Message)
 			}
@@ -4137,7 +4634,7 @@ func (r *HostedClusterReconciler) validateAWSConfig(hc *hyperv1.HostedCluster) e
 		}
 
 		if servicePublishingStrategy != nil && servicePublishingStrategy.Type != hyperv1.Route {
-			errs = append(errs, fmt.Errorf("service type %v with publishing strategy %v is not supported, use Route", serviceType, servicePublishingStrategy.Type))
+			errs = append(errs, supportconditions.UnsupportedCombination("service type %v with publishing strategy %v is not supported, use Route", serviceType, servicePublishingStrategy.Type))
 		}
 	}
 
@@ -4148,15 +4645,20 @@ func (r *HostedClusterReconciler) validateAWSConfig(hc *hyperv1.HostedCluster) e
 
 	if hc.Spec.Platform.AWS.EndpointAccess == hyperv1.Private {
 		if servicePublishingStrategy != nil && servicePublishingStrategy.Type != hyperv1.Route && servicePublishingStrategy.Type != hyperv1.LoadBalancer {
-			errs = append(errs, fmt.Errorf("service type %v with publishing strategy %v is not supported, use Route", hyperv1.APIServer, servicePublishingStrategy.Type))
+			errs = append(errs, supportconditions.UnsupportedCombination("service type %v with publishing strategy %v is not supported, use Route", hyperv1.APIServer, servicePublishingStrategy.Type))
 		}
 
 	} else {
 		if !hyperutil.UseDedicatedDNSForKASByHC(hc) && servicePublishingStrategy.Type != hyperv1.LoadBalancer {
-			errs = append(errs, fmt.Errorf("service type %v with publishing strategy %v is not supported, use Route or Loadbalancer", hyperv1.APIServer, servicePublishingStrategy.Type))
+			errs = append(errs, supportconditions.UnsupportedCombination("service type %v with publishing strategy %v is not supported, use Route or Loadbalancer", hyperv1.APIServer, servicePublishingStrategy.Type))
 		}
 	}
 
+	// The control plane endpoints of a public cluster are published in the public zone
+	if hyperutil.IsInternalDNSHC(hc) && hc.Spec.Platform.AWS.EndpointAccess != hyperv1.Private {
+		errs = append(errs, supportconditions.UnsupportedCombination("DNS publishing %s requires endpoint access %s, got %s", hyperv1.InternalDNSPublishing, hyperv1.Private, hc.Spec.Platform.AWS.EndpointAccess))
+	}
+
 	return utilerrors.NewAggregate(errs)
 }
 
@@ -4207,21 +4709,51 @@ func (r *HostedClusterReconciler) validatePublishingStrategyMapping(hc *hyperv1.
 			hostname = svc.Route.Hostname
 		}
 
-		if hostname == "" {
-			continue
+		hostnames := []string{hostname}
+		if svc.CustomDomain != nil {
+			if err := validateCustomDomainPublishingStrategy(hc, svc); err != nil {
+				return err
+			}
+			hostnames = append(hostnames, svc.CustomDomain.Hostname)
 		}
 
-		serviceType, exists := hostnameServiceMap[hostname]
-		if exists {
-			return fmt.Errorf("service type %s can't be published with the same hostname %s as service type %s", svc.Service, hostname, serviceType)
-		}
+		for _, hostname := range hostnames {
+			if hostname == "" {
+				continue
+			}
 
-		hostnameServiceMap[hostname] = string(svc.Service)
+			serviceType, exists := hostnameServiceMap[hostname]
+			if exists {
+				return fmt.Errorf("service type %s can't be published with the same hostname %s as service type %s", svc.Service, hostname, serviceType)
+			}
+
+			hostnameServiceMap[hostname] = string(svc.Service)
+		}
 	}
 
 	return nil
 }
 
+// validateCustomDomainPublishingStrategy validates that a custom domain is only
+// used for publishing the Azure API server through a LoadBalancer.
+func validateCustomDomainPublishingStrategy(hc *hyperv1.HostedCluster, svc hyperv1.ServicePublishingStrategyMapping) error {
+	if hc.Spec.Platform.Type != hyperv1.AzurePlatform {
+		return supportconditions.UnsupportedCombination("custom domain publishing is only supported on the %s platform", hyperv1.AzurePlatform)
+	}
+	if svc.Service != hyperv1.APIServer || svc.Type != hyperv1.LoadBalancer {
+		return supportconditions.UnsupportedCombination("custom domain publishing is only supported for service type %s with the %s strategy", hyperv1.APIServer, hyperv1.LoadBalancer)
+	}
+	if errs := validation.IsDNS1123Subdomain(svc.CustomDomain.Hostname); len(errs) > 0 {
+		return fmt.Errorf("custom domain hostname %q is invalid: %s", svc.CustomDomain.Hostname, strings.Join(errs, ", "))
+	}
+	if target := svc.CustomDomain.Target; target != "" {
+		if errs := validation.IsDNS1123Subdomain(target); len(errs) > 0 {
+			return fmt.Errorf("custom domain target %q is invalid: %s", target, strings.Join(errs, ", "))
+		}
+	}
+	return nil
+}
+
 func (r *HostedClusterReconciler) validateAzureConfig(ctx context.Context, hc *hyperv1.HostedCluster) error {
 	if hc.Spec.Platform.Type != hyperv1.AzurePlatform {
 		return nil
@@ -4306,6 +4838,55 @@ func (r *HostedClusterReconciler) validateNetworks(hc *hyperv1.HostedCluster) er
 	return errs.ToAggregate()
 }
 
+// validateClusterNetworkExpansion validates the changes of the cluster network of the HostedCluster against the
+// cluster network of its HostedControlPlane: entries can only be appended, with the OVNKubernetes network type, and
+// only once the guest cluster uses the entries appended previously.
+func validateClusterNetworkExpansion(hc *hyperv1.HostedCluster, hcp *hyperv1.HostedControlPlane) error {
+	if hcp == nil {
+		return nil
+	}
+	current := hcp.Spec.Networking.ClusterNetwork
+	if err := validateClusterNetworkAppend(current, hc.Spec.Networking.ClusterNetwork, hc.Spec.Networking.NetworkType); err != nil {
+		return err
+	}
+	if len(hc.Spec.Networking.ClusterNetwork) > len(current) {
+		applied := meta.FindStatusCondition(hcp.Status.Conditions, string(hyperv1.ClusterNetworkApplied))
+		if applied != nil && applied.Status == metav1.ConditionFalse {
+			return field.Forbidden(field.NewPath("spec", "networking", "clusterNetwork"),
+				fmt.Sprintf("a previous expansion of the cluster network is still in progress: %s", applied.Message))
+		}
+	}
+	return nil
+}
+
+// validateClusterNetworkAppend validates that the desired cluster network only appends entries to the current one,
+// of the IP families the current one already has, and that the network type supports expanding the cluster network.
+func validateClusterNetworkAppend(current, desired []hyperv1.ClusterNetworkEntry, networkType hyperv1.NetworkType) error {
+	path := field.NewPath("spec", "networking", "clusterNetwork")
+	if len(desired) < len(current) {
+		return field.Forbidden(path, "cluster network entries can't be removed")
+	}
+	families := sets.New[bool]()
+	for i := range current {
+		if current[i].CIDR.String() != desired[i].CIDR.String() || current[i].HostPrefix != desired[i].HostPrefix {
+			return field.Forbidden(path.Index(i), "existing cluster network entries are immutable, new entries can only be appended")
+		}
+		families.Insert(current[i].CIDR.IP.To4() != nil)
+	}
+	if len(desired) == len(current) {
+		return nil
+	}
+	if networkType != hyperv1.OVNKubernetes {
+		return supportconditions.UnsupportedCombination("cluster network entries can only be appended with the %s network type, not %s", hyperv1.OVNKubernetes, networkType)
+	}
+	for i := len(current); i < len(desired); i++ {
+		if !families.Has(desired[i].CIDR.IP.To4() != nil) {
+			return field.Invalid(path.Index(i), desired[i].CIDR.String(), "appended cluster network entries must be of an IP family the cluster network already has")
+		}
+	}
+	return nil
+}
+
 // findAdvertiseAddress function returns a string and an error indicating the AdvertiseAddress for the hostedcluster.
 // if the advertise address is properly set, it will return that value and nil, otherwise will return an error.
 // if the advertise address is not set, it will return the default one based on the network primary stack.
@@ -4520,6 +5101,10 @@ const (
 	oidcDocumentsFinalizer         = "hypershift.io/aws-oidc-discovery"
 	serviceAccountSigningKeySecret = "sa-signing-key"
 	serviceSignerPublicKey         = "service-account.pub"
+
+	// oidcDocumentsIssuerAnnotation records the issuer the OIDC documents were
+	// last uploaded for, so that they are uploaded again when it changes.
+	oidcDocumentsIssuerAnnotation = "hypershift.openshift.io/oidc-documents-issuer-url"
 )
 
 func oidcDocumentGenerators() map[string]oidc.OIDCDocumentGeneratorFunc {
@@ -4529,7 +5114,14 @@ func oidcDocumentGenerators() map[string]oidc.OIDCDocumentGeneratorFunc {
 	}
 }
 
-func (r *HostedClusterReconciler) reconcileAWSOIDCDocuments(ctx context.Context, log logr.Logger, hcluster *hyperv1.HostedCluster, hcp *hyperv1.HostedControlPlane) error {
+// servesOIDCDocuments returns whether the OIDC documents of the hosted cluster are served from the OIDC storage
+// provider, because its issuer URL is under the public URL of the provider.
+func (r *HostedClusterReconciler) servesOIDCDocuments(hcluster *hyperv1.HostedCluster) bool {
+	return r.OIDCDocumentStore != nil && r.OIDCIssuerURLBase != "" &&
+		hcluster.Spec.IssuerURL == r.OIDCIssuerURLBase+"/"+hcluster.Spec.InfraID
+}
+
+func (r *HostedClusterReconciler) reconcileOIDCDocuments(ctx context.Context, log logr.Logger, hcluster *hyperv1.HostedCluster, hcp *hyperv1.HostedControlPlane) error {
 	if hcp.Status.KubeConfig == nil {
 		return nil
 	}
@@ -4542,13 +5134,13 @@ func (r *HostedClusterReconciler) reconcileAWSOIDCDocuments(ctx context.Context,
 	}
 
 	// We use the presence of the finalizer to short-circuit the document upload to avoid
-	// constantly re-uploading it.
-	if controllerutil.ContainsFinalizer(hcluster, oidcDocumentsFinalizer) {
+	// constantly re-uploading it, unless the issuer changed since the upload.
+	if controllerutil.ContainsFinalizer(hcluster, oidcDocumentsFinalizer) && hcluster.Annotations[oidcDocumentsIssuerAnnotation] == hcp.Spec.IssuerURL {
 		return nil
 	}
 
-	if r.OIDCStorageProviderS3BucketName == "" || r.S3Client == nil {
-		return errors.New("hypershift wasn't configured with a S3 bucket or credentials, this makes it unable to set up OIDC for AWS clusters. Please install hypershift with the --oidc-storage-provider-s3-bucket-name, --oidc-storage-provider-s3-region and --oidc-storage-provider-s3-credentials flags set. The bucket must pre-exist and the credentials must be authorized to write into it")
+	if r.OIDCDocumentStore == nil {
+		return errors.New("hypershift wasn't configured with a storage for the OIDC documents, this makes it unable to set up OIDC for AWS clusters. Please install hypershift with the --oidc-storage-provider-s3-bucket-name, --oidc-storage-provider-s3-region and --oidc-storage-provider-s3-credentials flags set, or with the --oidc-storage-provider flag set to AzureBlob or GCS and its matching flags. The bucket or container must pre-exist and the credentials must be authorized to write into it")
 	}
 
 	secret := &corev1.Secret{
@@ -4575,36 +5167,21 @@ func (r *HostedClusterReconciler) reconcileAWSOIDCDocuments(ctx context.Context,
 		if err != nil {
 			return fmt.Errorf("failed to generate OIDC document %s: %w", path, err)
 		}
-		_, err = r.S3Client.PutObject(&s3.PutObjectInput{
-			Body:   bodyReader,
-			Bucket: aws.String(r.OIDCStorageProviderS3BucketName),
-			Key:    aws.String(hcluster.Spec.InfraID + path),
-		})
-		if err != nil {
-			wrapped := fmt.Errorf("failed to upload %s to the %s s3 bucket", path, r.OIDCStorageProviderS3BucketName)
-			if awsErr := awserr.Error(nil); errors.As(err, &awsErr) {
-				switch awsErr.Code() {
-				case s3.ErrCodeNoSuchBucket:
-					wrapped = fmt.Errorf("%w: %s: this could be a misconfiguration of the hypershift operator; check the --oidc-storage-provider-s3-bucket-name flag", wrapped, awsErr.Code())
-				default:
-					// Generally, the underlying message from AWS has unique per-request
-					// info not suitable for publishing as condition messages, so just
-					// return the code. If other specific error types can be handled, add
-					// new switch cases and try to provide more actionable info to the
-					// user.
-					wrapped = fmt.Errorf("%w: aws returned an error: %s", wrapped, awsErr.Code())
-				}
-			}
-			return wrapped
+		if err := r.OIDCDocumentStore.Put(ctx, hcluster.Spec.InfraID+path, bodyReader); err != nil {
+			return err
 		}
 	}
 
-	hcluster.Finalizers = append(hcluster.Finalizers, oidcDocumentsFinalizer)
+	controllerutil.AddFinalizer(hcluster, oidcDocumentsFinalizer)
+	if hcluster.Annotations == nil {
+		hcluster.Annotations = map[string]string{}
+	}
+	hcluster.Annotations[oidcDocumentsIssuerAnnotation] = hcp.Spec.IssuerURL
 	if err := r.Client.Update(ctx, hcluster); err != nil {
 		return fmt.Errorf("failed to update the hosted cluster after adding the %s finalizer: %w", oidcDocumentsFinalizer, err)
 	}
 
-	log.Info("Successfully uploaded the OIDC documents to the S3 bucket")
+	log.Info("Successfully uploaded the OIDC documents")
 
 	return nil
 }
@@ -4614,24 +5191,16 @@ func (r *HostedClusterReconciler) cleanupOIDCBucketData(ctx context.Context, log
 		return nil
 	}
 
-	if r.OIDCStorageProviderS3BucketName == "" || r.S3Client == nil {
-		return fmt.Errorf("hypershift wasn't configured with AWS credentials and a bucket, can not clean up OIDC documents from bucket. Please either set those up or clean up manually and then remove the %s finalizer from the hosted cluster", oidcDocumentsFinalizer)
+	if r.OIDCDocumentStore == nil {
+		return fmt.Errorf("hypershift wasn't configured with a storage for the OIDC documents, can not clean up OIDC documents from it. Please either set it up or clean up manually and then remove the %s finalizer from the hosted cluster", oidcDocumentsFinalizer)
 	}
 
-	var objectsToDelete []*s3.ObjectIdentifier
+	var keys []string
 	for path := range oidcDocumentGenerators() {
-		objectsToDelete = append(objectsToDelete, &s3.ObjectIdentifier{
-			Key: aws.String(hcluster.Spec.InfraID + path),
-		})
+		keys = append(keys, hcluster.Spec.InfraID+path)
 	}
-
-	if _, err := r.S3Client.DeleteObjects(&s3.DeleteObjectsInput{
-		Bucket: aws.String(r.OIDCStorageProviderS3BucketName),
-		Delete: &s3.Delete{Objects: objectsToDelete},
-	}); err != nil {
-		if awsErr := awserr.Error(nil); !errors.As(err, &awsErr) || awsErr.Code() != s3.ErrCodeNoSuchBucket {
-			return fmt.Errorf("failed to delete OIDC objects from %s S3 bucket: %w", r.OIDCStorageProviderS3BucketName, err)
-		}
+	if err := r.OIDCDocumentStore.Delete(ctx, keys); err != nil {
+		return err
 	}
 
 	controllerutil.RemoveFinalizer(hcluster, oidcDocumentsFinalizer)
@@ -4639,7 +5208,7 @@ func (r *HostedClusterReconciler) cleanupOIDCBucketData(ctx context.Context, log
 		return fmt.Errorf("failed to update hostedcluster after removing %s finalizer: %w", oidcDocumentsFinalizer, err)
 	}
 
-	log.Info("Successfully deleted the OIDC documents from the S3 bucket")
+	log.Info("Successfully deleted the OIDC documents")
 	return nil
 }
 
@@ -5176,3 +5745,38 @@ func isAPIServerRoute(hcluster *hyperv1.HostedCluster) bool {
 	}
 	return false
 }
+
+// propagateControlPlaneNamespaceMetadata sets the labels and annotations from the HostedCluster spec on the control
+// plane namespace, and removes the ones that were propagated before but are no longer in the spec.
+func propagateControlPlaneNamespaceMetadata(namespace *corev1.Namespace, hcluster *hyperv1.HostedCluster) {
+	if namespace.Annotations == nil {
+		namespace.Annotations = map[string]string{}
+	}
+	namespace.Labels = propagateMetadata(namespace.Labels, hcluster.Spec.Labels, namespace.Annotations, propagatedLabelsAnnotation)
+	namespace.Annotations = propagateMetadata(namespace.Annotations, hcluster.Spec.Annotations, namespace.Annotations, propagatedAnnotationsAnnotation)
+	if len(namespace.Annotations) == 0 {
+		namespace.Annotations = nil
+	}
+}
+
+// propagateMetadata sets desired on existing and removes the keys listed in tracking[trackingKey] that are no longer
+// desired. The keys of desired are then recorded in tracking[trackingKey].
+func propagateMetadata(existing, desired, tracking map[string]string, trackingKey string) map[string]string {
+	if existing == nil {
+		existing = map[string]string{}
+	}
+	for _, key := range strings.Split(tracking[trackingKey], ",") {
+		if _, stillDesired := desired[key]; key != "" && !stillDesired {
+			delete(existing, key)
+		}
+	}
+	for key, value := range desired {
+		existing[key] = value
+	}
+	if len(desired) == 0 {
+		delete(tracking, trackingKey)
+	} else {
+		tracking[trackingKey] = strings.Join(sets.List(sets.KeySet(desired)), ",")
+	}
+	return existing
+}
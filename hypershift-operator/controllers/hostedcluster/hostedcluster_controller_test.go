@@ -2,9 +2,14 @@ package hostedcluster
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
 	"errors"
 	"fmt"
 	"reflect"
+	"runtime"
 	"testing"
 	"time"
 
@@ -14,6 +19,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	. "github.com/onsi/gomega"
 	configv1 "github.com/openshift/api/config/v1"
+	imageapi "github.com/openshift/api/image/v1"
 	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
 	"github.com/openshift/hypershift/api/util/ipnet"
 	"github.com/openshift/hypershift/cmd/version"
@@ -27,7 +33,9 @@ import (
 	"github.com/openshift/hypershift/support/api"
 	"github.com/openshift/hypershift/support/capabilities"
 	fakecapabilities "github.com/openshift/hypershift/support/capabilities/fake"
+	supportconditions "github.com/openshift/hypershift/support/conditions"
 	"github.com/openshift/hypershift/support/config"
+	"github.com/openshift/hypershift/support/oidc"
 	"github.com/openshift/hypershift/support/releaseinfo"
 	fakereleaseprovider "github.com/openshift/hypershift/support/releaseinfo/fake"
 	"github.com/openshift/hypershift/support/thirdparty/library-go/pkg/image/dockerv1client"
@@ -40,10 +48,12 @@ import (
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	errors2 "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/clock"
 	clocktesting "k8s.io/utils/clock/testing"
 	"k8s.io/utils/pointer"
@@ -1585,10 +1595,15 @@ func TestValidateConfigAndClusterCapabilities(t *testing.T) {
 }
 
 func TestValidateReleaseImage(t *testing.T) {
+	otherCPUArch := hyperv1.ArchitectureARM64
+	if runtime.GOARCH == hyperv1.ArchitectureARM64 {
+		otherCPUArch = hyperv1.ArchitectureAMD64
+	}
 	testCases := []struct {
 		name                  string
 		other                 []crclient.Object
 		hostedCluster         *hyperv1.HostedCluster
+		architectures         []string
 		expectedResult        error
 		expectedNotFoundError bool
 	}{
@@ -1983,6 +1998,87 @@ func TestValidateReleaseImage(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "When the release image provides images for the management cluster and other cpu archs it should succeed",
+			other: []crclient.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "pull-secret"},
+					Data: map[string][]byte{
+						corev1.DockerConfigJsonKey: nil,
+					},
+				},
+			},
+			hostedCluster: &hyperv1.HostedCluster{
+				Spec: hyperv1.HostedClusterSpec{
+					Networking: hyperv1.ClusterNetworking{
+						NetworkType: hyperv1.OVNKubernetes,
+					},
+					PullSecret: corev1.LocalObjectReference{
+						Name: "pull-secret",
+					},
+					Release: hyperv1.Release{
+						Image: "image-4.15.0",
+					},
+				},
+			},
+			architectures: []string{runtime.GOARCH, otherCPUArch},
+		},
+		{
+			name: "When the release image does not provide images for the management cluster cpu arch it should fail",
+			other: []crclient.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "pull-secret"},
+					Data: map[string][]byte{
+						corev1.DockerConfigJsonKey: nil,
+					},
+				},
+			},
+			hostedCluster: &hyperv1.HostedCluster{
+				Spec: hyperv1.HostedClusterSpec{
+					Networking: hyperv1.ClusterNetworking{
+						NetworkType: hyperv1.OVNKubernetes,
+					},
+					PullSecret: corev1.LocalObjectReference{
+						Name: "pull-secret",
+					},
+					Release: hyperv1.Release{
+						Image: "image-4.15.0",
+					},
+				},
+			},
+			architectures:  []string{otherCPUArch},
+			expectedResult: fmt.Errorf("release image does not provide images for the management cluster cpu arch %s, it provides: %s", runtime.GOARCH, otherCPUArch),
+		},
+		{
+			name: "When a multi-arch AWS HostedCluster uses a single arch release image it should fail",
+			other: []crclient.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "pull-secret"},
+					Data: map[string][]byte{
+						corev1.DockerConfigJsonKey: nil,
+					},
+				},
+			},
+			hostedCluster: &hyperv1.HostedCluster{
+				Spec: hyperv1.HostedClusterSpec{
+					Networking: hyperv1.ClusterNetworking{
+						NetworkType: hyperv1.OVNKubernetes,
+					},
+					PullSecret: corev1.LocalObjectReference{
+						Name: "pull-secret",
+					},
+					Release: hyperv1.Release{
+						Image: "image-4.15.0",
+					},
+					Platform: hyperv1.PlatformSpec{
+						Type: hyperv1.AWSPlatform,
+						AWS:  &hyperv1.AWSPlatformSpec{MultiArch: true},
+					},
+				},
+			},
+			architectures:  []string{runtime.GOARCH},
+			expectedResult: errors.New("release image is not a multi-arch image"),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -2007,16 +2103,17 @@ func TestValidateReleaseImage(t *testing.T) {
 							},
 						},
 						&fakeimagemetadataprovider.FakeImageMetadataProvider{
-							Result: &dockerv1client.DockerImageConfig{},
+							Result:        &dockerv1client.DockerImageConfig{},
+							Architectures: tc.architectures,
 						},
 						nil
 				},
 			}
 
 			ctx := context.Background()
-			releaseProvider, _, err := r.ReconcileMetadataProviders(ctx, nil)
+			releaseProvider, imageMetadataProvider, err := r.ReconcileMetadataProviders(ctx, nil)
 			g.Expect(err).ToNot(HaveOccurred())
-			actual := r.validateReleaseImage(ctx, tc.hostedCluster, releaseProvider)
+			actual := r.validateReleaseImage(ctx, tc.hostedCluster, releaseProvider, imageMetadataProvider)
 			if diff := cmp.Diff(actual, tc.expectedResult, equateErrorMessage); diff != "" {
 				t.Errorf("actual validation result differs from expected: %s", diff)
 			}
@@ -2096,6 +2193,115 @@ func TestPauseHostedControlPlane(t *testing.T) {
 	}
 }
 
+func TestReconciliationResumedEvent(t *testing.T) {
+	reconciliationActive := func(status metav1.ConditionStatus) metav1.Condition {
+		return metav1.Condition{Type: string(hyperv1.ReconciliationActive), Status: status}
+	}
+	testCases := []struct {
+		name        string
+		before      metav1.ConditionStatus
+		after       metav1.ConditionStatus
+		expectEvent bool
+	}{
+		{
+			name:        "When the pause ends it should emit an event",
+			before:      metav1.ConditionFalse,
+			after:       metav1.ConditionTrue,
+			expectEvent: true,
+		},
+		{
+			name:   "When the pause continues it should not emit an event",
+			before: metav1.ConditionFalse,
+			after:  metav1.ConditionFalse,
+		},
+		{
+			name:   "When the reconciliation was not paused it should not emit an event",
+			before: metav1.ConditionTrue,
+			after:  metav1.ConditionTrue,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			hcluster := &hyperv1.HostedCluster{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "hc"},
+				Status:     hyperv1.HostedClusterStatus{Conditions: []metav1.Condition{reconciliationActive(tc.before)}},
+			}
+			recorder := record.NewFakeRecorder(1)
+			r := &HostedClusterReconciler{
+				Client: fake.NewClientBuilder().WithScheme(api.Scheme).WithObjects(hcluster).WithStatusSubresource(hcluster).Build(),
+				overwriteReconcile: func(ctx context.Context, req ctrl.Request, log logr.Logger, hcluster *hyperv1.HostedCluster) (ctrl.Result, error) {
+					meta.SetStatusCondition(&hcluster.Status.Conditions, reconciliationActive(tc.after))
+					return ctrl.Result{}, nil
+				},
+				now:      metav1.Now,
+				recorder: recorder,
+			}
+
+			_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: crclient.ObjectKeyFromObject(hcluster)})
+			g.Expect(err).ToNot(HaveOccurred())
+			if tc.expectEvent {
+				g.Expect(recorder.Events).To(Receive(ContainSubstring("ReconciliationResumed")))
+			} else {
+				g.Expect(recorder.Events).ToNot(Receive())
+			}
+		})
+	}
+}
+
+func TestCurrentReleaseHostedCluster(t *testing.T) {
+	const (
+		currentRelease = "quay.io/openshift-release-dev/ocp-release:4.15.0-x86_64"
+		targetRelease  = "quay.io/openshift-release-dev/ocp-release:4.16.0-x86_64"
+	)
+	testCases := []struct {
+		name                               string
+		hcp                                *hyperv1.HostedControlPlane
+		history                            []configv1.UpdateHistory
+		expectedRelease                    string
+		expectedControlPlaneReleaseIsUnset bool
+	}{
+		{
+			name: "When the HostedControlPlane exists it should use its release",
+			hcp: &hyperv1.HostedControlPlane{
+				Spec: hyperv1.HostedControlPlaneSpec{ReleaseImage: currentRelease},
+			},
+			history:                            []configv1.UpdateHistory{{Image: "other"}},
+			expectedRelease:                    currentRelease,
+			expectedControlPlaneReleaseIsUnset: true,
+		},
+		{
+			name:                               "When the HostedControlPlane does not exist it should use the latest release of the version history",
+			history:                            []configv1.UpdateHistory{{Image: currentRelease}, {Image: "older"}},
+			expectedRelease:                    currentRelease,
+			expectedControlPlaneReleaseIsUnset: true,
+		},
+		{
+			name:            "When there is no current release it should keep the release of the HostedCluster",
+			expectedRelease: targetRelease,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			hcluster := &hyperv1.HostedCluster{
+				Spec: hyperv1.HostedClusterSpec{
+					Release:             hyperv1.Release{Image: targetRelease},
+					ControlPlaneRelease: &hyperv1.Release{Image: targetRelease},
+				},
+			}
+			if tc.history != nil {
+				hcluster.Status.Version = &hyperv1.ClusterVersionStatus{History: tc.history}
+			}
+
+			current := currentReleaseHostedCluster(hcluster, tc.hcp)
+			g.Expect(current.Spec.Release.Image).To(Equal(tc.expectedRelease))
+			g.Expect(current.Spec.ControlPlaneRelease == nil).To(Equal(tc.expectedControlPlaneReleaseIsUnset))
+			g.Expect(hcluster.Spec.Release.Image).To(Equal(targetRelease), "the HostedCluster must not be mutated")
+		})
+	}
+}
+
 func TestDefaultClusterIDsIfNeeded(t *testing.T) {
 	testHC := func(infraID, clusterID string) *hyperv1.HostedCluster {
 		return &hyperv1.HostedCluster{
@@ -2622,6 +2828,65 @@ func TestIsProgressing(t *testing.T) {
 			want:    false,
 			wantErr: true,
 		},
+		{
+			name: "cluster update is blocked by an unverified release image",
+			hc: &hyperv1.HostedCluster{
+				Spec: hyperv1.HostedClusterSpec{
+					Release: hyperv1.Release{
+						Image: "release-1.3",
+					},
+					PullSecret: corev1.LocalObjectReference{
+						Name: "pull-secret",
+					},
+				},
+				Status: hyperv1.HostedClusterStatus{
+					Version: &hyperv1.ClusterVersionStatus{
+						Desired: configv1.Release{
+							Image:   "release-1.2",
+							Version: "1.2.0",
+						},
+					},
+					Conditions: []metav1.Condition{
+						{
+							Type:   string(hyperv1.ReleaseImageVerified),
+							Status: metav1.ConditionFalse,
+						},
+					},
+				},
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "cluster update is blocked by an invalid FIPS configuration",
+			hc: &hyperv1.HostedCluster{
+				Spec: hyperv1.HostedClusterSpec{
+					Release: hyperv1.Release{
+						Image: "release-1.3",
+					},
+					PullSecret: corev1.LocalObjectReference{
+						Name: "pull-secret",
+					},
+					FIPS: true,
+				},
+				Status: hyperv1.HostedClusterStatus{
+					Version: &hyperv1.ClusterVersionStatus{
+						Desired: configv1.Release{
+							Image:   "release-1.2",
+							Version: "1.2.0",
+						},
+					},
+					Conditions: []metav1.Condition{
+						{
+							Type:   string(hyperv1.ValidFIPSConfiguration),
+							Status: metav1.ConditionFalse,
+						},
+					},
+				},
+			},
+			want:    false,
+			wantErr: true,
+		},
 		{
 			name: "cluster upgrade is blocked by ClusterVersionUpgradeable",
 			hc: &hyperv1.HostedCluster{
@@ -2840,6 +3105,31 @@ func TestComputeAWSEndpointServiceCondition(t *testing.T) {
 				Message: "error message A; error message B",
 			},
 		},
+		{
+			name: "When both endpoints conditions are false with different reasons it should report the reason of the first one",
+			endpointAConditions: []metav1.Condition{
+				{
+					Type:    string(hyperv1.AWSEndpointAvailable),
+					Status:  metav1.ConditionFalse,
+					Reason:  hyperv1.InvalidCredentialsReason,
+					Message: "error message A",
+				},
+			},
+			endpointBConditions: []metav1.Condition{
+				{
+					Type:    string(hyperv1.AWSEndpointAvailable),
+					Status:  metav1.ConditionFalse,
+					Reason:  hyperv1.QuotaExceededReason,
+					Message: "error message B",
+				},
+			},
+			expected: metav1.Condition{
+				Type:    string(hyperv1.AWSEndpointAvailable),
+				Status:  metav1.ConditionFalse,
+				Reason:  hyperv1.InvalidCredentialsReason,
+				Message: "error message A; error message B",
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -3628,3 +3918,454 @@ func TestKubevirtETCDEncKey(t *testing.T) {
 		)
 	}
 }
+
+func TestValidateFIPSConfiguration(t *testing.T) {
+	releaseImage := func(archs ...string) *releaseinfo.ReleaseImage {
+		metadata := &releaseinfo.CoreOSStreamMetadata{Architectures: map[string]releaseinfo.CoreOSArchitecture{}}
+		for _, arch := range archs {
+			metadata.Architectures[arch] = releaseinfo.CoreOSArchitecture{}
+		}
+		return &releaseinfo.ReleaseImage{
+			ImageStream:    &imageapi.ImageStream{ObjectMeta: metav1.ObjectMeta{Name: "4.16.0"}},
+			StreamMetadata: metadata,
+		}
+	}
+
+	testCases := []struct {
+		name                          string
+		managementClusterCapabilities capabilities.CapabiltyChecker
+		releaseImage                  *releaseinfo.ReleaseImage
+		expectError                   bool
+	}{
+		{
+			name:                          "When the management cluster and the release image support FIPS mode it should be valid",
+			managementClusterCapabilities: &fakecapabilities.FakeSupportAllCapabilities{},
+			releaseImage:                  releaseImage("x86_64", "aarch64"),
+		},
+		{
+			name:                          "When the management cluster doesn't run in FIPS mode it should fail",
+			managementClusterCapabilities: fakecapabilities.NewSupportAllExcept(capabilities.CapabilityFIPS),
+			releaseImage:                  releaseImage("x86_64"),
+			expectError:                   true,
+		},
+		{
+			name:                          "When the release image has no FIPS capable bootimages it should fail",
+			managementClusterCapabilities: &fakecapabilities.FakeSupportAllCapabilities{},
+			releaseImage:                  releaseImage("aarch64"),
+			expectError:                   true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			r := &HostedClusterReconciler{ManagementClusterCapabilities: tc.managementClusterCapabilities}
+			err := r.validateFIPSConfiguration(tc.releaseImage)
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestValidateKubeAPIServerRequestLimits(t *testing.T) {
+	testCases := []struct {
+		name        string
+		annotations map[string]string
+		expectError bool
+	}{
+		{
+			name: "When no request limit annotations are set it should be valid",
+		},
+		{
+			name: "When valid request limit annotations are set it should be valid",
+			annotations: map[string]string{
+				hyperv1.KubeAPIServerMaxRequestsInflightAnnotation:         "800",
+				hyperv1.KubeAPIServerMaxMutatingRequestsInflightAnnotation: "400",
+				hyperv1.KubeAPIServerEnablePriorityAndFairnessAnnotation:   "true",
+			},
+		},
+		{
+			name: "When an inflight limit is not a positive integer it should fail",
+			annotations: map[string]string{
+				hyperv1.KubeAPIServerMaxMutatingRequestsInflightAnnotation: "0",
+			},
+			expectError: true,
+		},
+		{
+			name: "When the priority and fairness annotation is not a boolean it should fail",
+			annotations: map[string]string{
+				hyperv1.KubeAPIServerEnablePriorityAndFairnessAnnotation: "disabled",
+			},
+			expectError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			hc := &hyperv1.HostedCluster{ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations}}
+			errs := validateKubeAPIServerRequestLimits(hc)
+			if tc.expectError {
+				g.Expect(errs).ToNot(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateConnectionRebalancing(t *testing.T) {
+	testCases := []struct {
+		name        string
+		annotations map[string]string
+		expectError bool
+	}{
+		{
+			name: "When no connection rebalancing annotations are set it should be valid",
+		},
+		{
+			name: "When valid connection rebalancing annotations are set it should be valid",
+			annotations: map[string]string{
+				hyperv1.KubeAPIServerGoAwayChanceAnnotation:              "0.001",
+				hyperv1.KonnectivityAgentConnectionRebalancingAnnotation: "true",
+			},
+		},
+		{
+			name: "When the goaway chance is above the kube-apiserver maximum it should fail",
+			annotations: map[string]string{
+				hyperv1.KubeAPIServerGoAwayChanceAnnotation: "0.1",
+			},
+			expectError: true,
+		},
+		{
+			name: "When the konnectivity connection rebalancing annotation is not a boolean it should fail",
+			annotations: map[string]string{
+				hyperv1.KonnectivityAgentConnectionRebalancingAnnotation: "always",
+			},
+			expectError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			hc := &hyperv1.HostedCluster{ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations}}
+			errs := validateConnectionRebalancing(hc)
+			if tc.expectError {
+				g.Expect(errs).ToNot(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateClusterNetworkExpansion(t *testing.T) {
+	v4 := hyperv1.ClusterNetworkEntry{CIDR: *ipnet.MustParseCIDR("10.132.0.0/14"), HostPrefix: 23}
+	v4Expansion := hyperv1.ClusterNetworkEntry{CIDR: *ipnet.MustParseCIDR("10.200.0.0/14"), HostPrefix: 23}
+	v6 := hyperv1.ClusterNetworkEntry{CIDR: *ipnet.MustParseCIDR("fd01::/48"), HostPrefix: 64}
+	expansionInProgress := metav1.Condition{
+		Type:    string(hyperv1.ClusterNetworkApplied),
+		Status:  metav1.ConditionFalse,
+		Reason:  hyperv1.ClusterNetworkExpansionInProgressReason,
+		Message: "Waiting for the guest cluster network to use the cluster network CIDRs 10.200.0.0/14",
+	}
+
+	testCases := []struct {
+		name           string
+		clusterNetwork []hyperv1.ClusterNetworkEntry
+		networkType    hyperv1.NetworkType
+		hcp            *hyperv1.HostedControlPlane
+		expectedReason string
+	}{
+		{
+			name:           "When there is no hosted control plane yet it should be valid",
+			clusterNetwork: []hyperv1.ClusterNetworkEntry{v4, v4Expansion},
+			networkType:    hyperv1.OpenShiftSDN,
+		},
+		{
+			name:           "When the cluster network is unchanged it should be valid",
+			clusterNetwork: []hyperv1.ClusterNetworkEntry{v4},
+			networkType:    hyperv1.OpenShiftSDN,
+			hcp:            hcpWithClusterNetwork([]hyperv1.ClusterNetworkEntry{v4}),
+		},
+		{
+			name:           "When an entry is appended with OVNKubernetes it should be valid",
+			clusterNetwork: []hyperv1.ClusterNetworkEntry{v4, v4Expansion},
+			networkType:    hyperv1.OVNKubernetes,
+			hcp:            hcpWithClusterNetwork([]hyperv1.ClusterNetworkEntry{v4}),
+		},
+		{
+			name:           "When an entry is appended with another network type it should fail as an unsupported combination",
+			clusterNetwork: []hyperv1.ClusterNetworkEntry{v4, v4Expansion},
+			networkType:    hyperv1.OpenShiftSDN,
+			hcp:            hcpWithClusterNetwork([]hyperv1.ClusterNetworkEntry{v4}),
+			expectedReason: hyperv1.UnsupportedCombinationReason,
+		},
+		{
+			name:           "When an existing entry is changed it should fail",
+			clusterNetwork: []hyperv1.ClusterNetworkEntry{v4Expansion},
+			networkType:    hyperv1.OVNKubernetes,
+			hcp:            hcpWithClusterNetwork([]hyperv1.ClusterNetworkEntry{v4}),
+			expectedReason: hyperv1.InvalidConfigurationReason,
+		},
+		{
+			name:           "When an entry is removed it should fail",
+			clusterNetwork: []hyperv1.ClusterNetworkEntry{v4},
+			networkType:    hyperv1.OVNKubernetes,
+			hcp:            hcpWithClusterNetwork([]hyperv1.ClusterNetworkEntry{v4, v4Expansion}),
+			expectedReason: hyperv1.InvalidConfigurationReason,
+		},
+		{
+			name:           "When an entry of another IP family is appended it should fail",
+			clusterNetwork: []hyperv1.ClusterNetworkEntry{v4, v6},
+			networkType:    hyperv1.OVNKubernetes,
+			hcp:            hcpWithClusterNetwork([]hyperv1.ClusterNetworkEntry{v4}),
+			expectedReason: hyperv1.InvalidConfigurationReason,
+		},
+		{
+			name:           "When an entry is appended while a previous expansion is in progress it should fail",
+			clusterNetwork: []hyperv1.ClusterNetworkEntry{v4, v4Expansion, {CIDR: *ipnet.MustParseCIDR("10.220.0.0/14")}},
+			networkType:    hyperv1.OVNKubernetes,
+			hcp:            hcpWithClusterNetwork([]hyperv1.ClusterNetworkEntry{v4, v4Expansion}, expansionInProgress),
+			expectedReason: hyperv1.InvalidConfigurationReason,
+		},
+		{
+			name:           "When the cluster network is unchanged while an expansion is in progress it should be valid",
+			clusterNetwork: []hyperv1.ClusterNetworkEntry{v4, v4Expansion},
+			networkType:    hyperv1.OVNKubernetes,
+			hcp:            hcpWithClusterNetwork([]hyperv1.ClusterNetworkEntry{v4, v4Expansion}, expansionInProgress),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			hc := &hyperv1.HostedCluster{}
+			hc.Spec.Networking.ClusterNetwork = tc.clusterNetwork
+			hc.Spec.Networking.NetworkType = tc.networkType
+
+			err := validateClusterNetworkExpansion(hc, tc.hcp)
+			if tc.expectedReason == "" {
+				g.Expect(err).ToNot(HaveOccurred())
+				return
+			}
+			g.Expect(err).To(HaveOccurred())
+			g.Expect(supportconditions.ReasonForError(err, hyperv1.InvalidConfigurationReason)).To(Equal(tc.expectedReason))
+		})
+	}
+}
+
+func hcpWithClusterNetwork(clusterNetwork []hyperv1.ClusterNetworkEntry, conditions ...metav1.Condition) *hyperv1.HostedControlPlane {
+	hcp := &hyperv1.HostedControlPlane{}
+	hcp.Spec.Networking.ClusterNetwork = clusterNetwork
+	hcp.Status.Conditions = conditions
+	return hcp
+}
+
+func TestValidatePublishingStrategyMapping(t *testing.T) {
+	apiServerCustomDomain := func(strategyType hyperv1.PublishingStrategyType, customDomain *hyperv1.CustomDomainPublishingStrategy) hyperv1.ServicePublishingStrategyMapping {
+		return hyperv1.ServicePublishingStrategyMapping{
+			Service: hyperv1.APIServer,
+			ServicePublishingStrategy: hyperv1.ServicePublishingStrategy{
+				Type:         strategyType,
+				CustomDomain: customDomain,
+			},
+		}
+	}
+	testCases := []struct {
+		name        string
+		platform    hyperv1.PlatformType
+		services    []hyperv1.ServicePublishingStrategyMapping
+		expectError bool
+	}{
+		{
+			name:     "When the Azure API server is published under a custom domain it should be valid",
+			platform: hyperv1.AzurePlatform,
+			services: []hyperv1.ServicePublishingStrategyMapping{
+				apiServerCustomDomain(hyperv1.LoadBalancer, &hyperv1.CustomDomainPublishingStrategy{Hostname: "api.example.com", Target: "example.azurefd.net"}),
+			},
+		},
+		{
+			name:     "When a custom domain is used on a platform other than Azure it should fail",
+			platform: hyperv1.AWSPlatform,
+			services: []hyperv1.ServicePublishingStrategyMapping{
+				apiServerCustomDomain(hyperv1.LoadBalancer, &hyperv1.CustomDomainPublishingStrategy{Hostname: "api.example.com"}),
+			},
+			expectError: true,
+		},
+		{
+			name:     "When a custom domain is used with the Route strategy it should fail",
+			platform: hyperv1.AzurePlatform,
+			services: []hyperv1.ServicePublishingStrategyMapping{
+				apiServerCustomDomain(hyperv1.Route, &hyperv1.CustomDomainPublishingStrategy{Hostname: "api.example.com"}),
+			},
+			expectError: true,
+		},
+		{
+			name:     "When the custom domain target is not a valid DNS name it should fail",
+			platform: hyperv1.AzurePlatform,
+			services: []hyperv1.ServicePublishingStrategyMapping{
+				apiServerCustomDomain(hyperv1.LoadBalancer, &hyperv1.CustomDomainPublishingStrategy{Hostname: "api.example.com", Target: "https://example.azurefd.net"}),
+			},
+			expectError: true,
+		},
+		{
+			name:     "When the custom domain is the hostname of another service it should fail",
+			platform: hyperv1.AzurePlatform,
+			services: []hyperv1.ServicePublishingStrategyMapping{
+				apiServerCustomDomain(hyperv1.LoadBalancer, &hyperv1.CustomDomainPublishingStrategy{Hostname: "oauth.example.com"}),
+				{
+					Service: hyperv1.OAuthServer,
+					ServicePublishingStrategy: hyperv1.ServicePublishingStrategy{
+						Type:  hyperv1.Route,
+						Route: &hyperv1.RoutePublishingStrategy{Hostname: "oauth.example.com"},
+					},
+				},
+			},
+			expectError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			hc := &hyperv1.HostedCluster{
+				Spec: hyperv1.HostedClusterSpec{
+					Platform: hyperv1.PlatformSpec{Type: tc.platform},
+					Services: tc.services,
+				},
+			}
+			err := (&HostedClusterReconciler{}).validatePublishingStrategyMapping(hc)
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+		})
+	}
+}
+
+func TestReconcileOIDCDocumentsManagementCluster(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	publicKey, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	if err != nil {
+		t.Fatalf("failed to marshal public key: %v", err)
+	}
+
+	testCases := []struct {
+		name             string
+		issuerURL        string
+		expectedServed   bool
+		expectedDocument bool
+	}{
+		{
+			name:             "When the issuer URL is served from the OIDC storage provider it should store the documents",
+			issuerURL:        "https://oidc.apps.example.com/infra-id",
+			expectedServed:   true,
+			expectedDocument: true,
+		},
+		{
+			name:      "When the issuer URL is served elsewhere it should not store the documents",
+			issuerURL: "https://kubernetes.default.svc",
+		},
+		{
+			name:      "When the issuer URL is under the base URL with another infra ID it should not store the documents",
+			issuerURL: "https://oidc.apps.example.com/other",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			hcluster := &hyperv1.HostedCluster{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "hc"},
+				Spec: hyperv1.HostedClusterSpec{
+					InfraID:   "infra-id",
+					IssuerURL: tc.issuerURL,
+					Platform:  hyperv1.PlatformSpec{Type: hyperv1.NonePlatform},
+				},
+			}
+			hcp := &hyperv1.HostedControlPlane{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "clusters-hc", Name: "hc"},
+				Spec:       hyperv1.HostedControlPlaneSpec{IssuerURL: tc.issuerURL},
+				Status:     hyperv1.HostedControlPlaneStatus{KubeConfig: &hyperv1.KubeconfigSecretRef{Name: "kubeconfig"}},
+			}
+			signingKey := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: hcp.Namespace, Name: serviceAccountSigningKeySecret},
+				Data: map[string][]byte{
+					serviceSignerPublicKey: pem.EncodeToMemory(&pem.Block{Type: "RSA PUBLIC KEY", Bytes: publicKey}),
+				},
+			}
+			c := fake.NewClientBuilder().WithScheme(api.Scheme).WithObjects(hcluster, signingKey).Build()
+			r := &HostedClusterReconciler{
+				Client:            c,
+				OIDCDocumentStore: &oidc.ConfigMapDocumentStore{Client: c, Namespace: "hypershift"},
+				OIDCIssuerURLBase: "https://oidc.apps.example.com",
+			}
+
+			g.Expect(r.servesOIDCDocuments(hcluster)).To(Equal(tc.expectedServed))
+			if !tc.expectedServed {
+				return
+			}
+			g.Expect(r.reconcileOIDCDocuments(context.Background(), logr.Discard(), hcluster, hcp)).To(Succeed())
+			g.Expect(hcluster.Finalizers).To(ContainElement(oidcDocumentsFinalizer))
+
+			cm := &corev1.ConfigMap{}
+			g.Expect(c.Get(context.Background(), crclient.ObjectKey{Namespace: "hypershift", Name: "oidc-discovery-infra-id"}, cm)).To(Succeed())
+			g.Expect(cm.Data).To(HaveKeyWithValue(".well-known_openid-configuration", ContainSubstring(`"issuer": "https://oidc.apps.example.com/infra-id"`)))
+			g.Expect(cm.Data).To(HaveKey("openid_v1_jwks"))
+
+			g.Expect(r.cleanupOIDCBucketData(context.Background(), logr.Discard(), hcluster)).To(Succeed())
+			err := c.Get(context.Background(), crclient.ObjectKeyFromObject(cm), cm)
+			g.Expect(errors2.IsNotFound(err)).To(BeTrue())
+		})
+	}
+}
+
+func TestPropagateControlPlaneNamespaceMetadata(t *testing.T) {
+	testCases := []struct {
+		name                string
+		namespace           *corev1.Namespace
+		labels              map[string]string
+		annotations         map[string]string
+		expectedLabels      map[string]string
+		expectedAnnotations map[string]string
+	}{
+		{
+			name:                "When labels and annotations are set it should add them to the namespace and record their keys",
+			namespace:           &corev1.Namespace{},
+			labels:              map[string]string{"cost-center": "1234", "team": "a"},
+			annotations:         map[string]string{"policy.example.com/tier": "gold"},
+			expectedLabels:      map[string]string{"cost-center": "1234", "team": "a"},
+			expectedAnnotations: map[string]string{"policy.example.com/tier": "gold", propagatedLabelsAnnotation: "cost-center,team", propagatedAnnotationsAnnotation: "policy.example.com/tier"},
+		},
+		{
+			name: "When a propagated label is removed from the spec it should be removed from the namespace",
+			namespace: &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Labels:      map[string]string{"cost-center": "1234", "team": "a", "other": "kept"},
+				Annotations: map[string]string{propagatedLabelsAnnotation: "cost-center,team"},
+			}},
+			labels:              map[string]string{"team": "b"},
+			expectedLabels:      map[string]string{"team": "b", "other": "kept"},
+			expectedAnnotations: map[string]string{propagatedLabelsAnnotation: "team"},
+		},
+		{
+			name: "When all propagated metadata is removed from the spec it should remove it and the tracking annotations",
+			namespace: &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Labels:      map[string]string{"cost-center": "1234"},
+				Annotations: map[string]string{"policy.example.com/tier": "gold", propagatedLabelsAnnotation: "cost-center", propagatedAnnotationsAnnotation: "policy.example.com/tier"},
+			}},
+			expectedLabels: map[string]string{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			hc := &hyperv1.HostedCluster{Spec: hyperv1.HostedClusterSpec{Labels: tc.labels, Annotations: tc.annotations}}
+			propagateControlPlaneNamespaceMetadata(tc.namespace, hc)
+			g.Expect(tc.namespace.Labels).To(Equal(tc.expectedLabels))
+			g.Expect(tc.namespace.Annotations).To(Equal(tc.expectedAnnotations))
+		})
+	}
+}
@@ -178,6 +178,10 @@ func (v hostedClusterValidator) ValidateUpdate(ctx context.Context, oldHC, newHC
 		return nil, fmt.Errorf("wrong type %T for validation, instead of HostedCluster", oldHC)
 	}
 
+	if err := validateClusterNetworkAppend(hcOld.Spec.Networking.ClusterNetwork, hc.Spec.Networking.ClusterNetwork, hc.Spec.Networking.NetworkType); err != nil {
+		return nil, err
+	}
+
 	switch hc.Spec.Platform.Type {
 	case hyperv1.KubevirtPlatform:
 		err := v.validateUpdateKubevirtHostedCluster(ctx, hcOld, hc)
@@ -188,7 +192,16 @@ func (v hostedClusterValidator) ValidateUpdate(ctx context.Context, oldHC, newHC
 	return nil, nil
 }
 
-func (v hostedClusterValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+func (v hostedClusterValidator) ValidateDelete(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	hc, ok := obj.(*hyperv1.HostedCluster)
+	if !ok {
+		return nil, fmt.Errorf("wrong type %T for validation, instead of HostedCluster", obj)
+	}
+
+	if !hyperutil.HCDeletionAllowed(hc) {
+		return nil, fmt.Errorf("HostedCluster %s/%s has the %s deletion policy, set the %s annotation to \"true\" to allow its deletion",
+			hc.Namespace, hc.Name, hyperv1.DeletionPolicyProtect, hyperv1.AllowDeletionAnnotation)
+	}
 	return nil, nil
 }
 
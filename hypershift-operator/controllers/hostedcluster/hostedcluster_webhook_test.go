@@ -185,6 +185,54 @@ func TestValidateKVHostedClusterUpdate(t *testing.T) {
 	}
 }
 
+func TestValidateHostedClusterDelete(t *testing.T) {
+	for _, testCase := range []struct {
+		name        string
+		annotations map[string]string
+		policy      *v1beta1.DeletionPolicy
+		expectError bool
+	}{
+		{
+			name: "When the deletion policy is not set it should allow the deletion",
+		},
+		{
+			name:   "When the deletion policy is OrphanInfra it should allow the deletion",
+			policy: &v1beta1.DeletionPolicy{Type: v1beta1.DeletionPolicyOrphanInfra},
+		},
+		{
+			name:        "When the deletion policy is Protect it should deny the deletion",
+			policy:      &v1beta1.DeletionPolicy{Type: v1beta1.DeletionPolicyProtect},
+			expectError: true,
+		},
+		{
+			name:        "When the deletion policy is Protect and the deletion is allowed it should allow the deletion",
+			annotations: map[string]string{v1beta1.AllowDeletionAnnotation: "true"},
+			policy:      &v1beta1.DeletionPolicy{Type: v1beta1.DeletionPolicyProtect},
+		},
+	} {
+		t.Run(testCase.name, func(tt *testing.T) {
+			hc := &v1beta1.HostedCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "cluster-under-test",
+					Namespace:   "myns",
+					Annotations: testCase.annotations,
+				},
+				Spec: v1beta1.HostedClusterSpec{
+					DeletionPolicy: testCase.policy,
+				},
+			}
+			hcVal := &hostedClusterValidator{}
+			_, err := hcVal.ValidateDelete(context.Background(), hc)
+
+			if testCase.expectError && err == nil {
+				tt.Error("should return error but didn't")
+			} else if !testCase.expectError && err != nil {
+				tt.Errorf("should not return error but returned %q", err.Error())
+			}
+		})
+	}
+}
+
 func TestValidateJsonAnnotation(t *testing.T) {
 	for _, tc := range []struct {
 		name        string
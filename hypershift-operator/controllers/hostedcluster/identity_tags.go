@@ -0,0 +1,45 @@
+package hostedcluster
+
+import (
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/support/clusteridentity"
+)
+
+// maxAWSResourceTags is the maximum number of resource tags of the AWS platform.
+const maxAWSResourceTags = 25
+
+// reconcileResourceIdentityTags adds the identity tags of the cluster and the mandatory tags of the HyperShift
+// operator to the resource tags of the AWS platform of the HostedControlPlane, so that the control plane components
+// tag the cloud resources they create and the resources owned by the cluster with them. They aren't added to the
+// HostedCluster spec the machine templates of the NodePools are generated from, so that changing them, e.g. when the
+// lifetime of the cluster changes, doesn't replace the nodes: their instances are tagged in place. The tags override
+// the resource tags of the HostedCluster with the same key. It returns the keys of the tags which were dropped
+// because the maximum number of resource tags is reached.
+func reconcileResourceIdentityTags(hcp *hyperv1.HostedControlPlane, hcluster *hyperv1.HostedCluster, mandatoryTags map[string]string) []string {
+	if hcp.Spec.Platform.AWS == nil || hcluster.Spec.InfraID == "" {
+		return nil
+	}
+
+	var dropped []string
+	resourceTags := hcp.Spec.Platform.AWS.ResourceTags
+	for _, tag := range clusteridentity.ForHostedCluster(hcluster).Tags(mandatoryTags) {
+		found := false
+		for i := range resourceTags {
+			if resourceTags[i].Key == tag.Key {
+				resourceTags[i].Value = tag.Value
+				found = true
+				break
+			}
+		}
+		if found {
+			continue
+		}
+		if len(resourceTags) >= maxAWSResourceTags {
+			dropped = append(dropped, tag.Key)
+			continue
+		}
+		resourceTags = append(resourceTags, hyperv1.AWSResourceTag{Key: tag.Key, Value: tag.Value})
+	}
+	hcp.Spec.Platform.AWS.ResourceTags = resourceTags
+	return dropped
+}
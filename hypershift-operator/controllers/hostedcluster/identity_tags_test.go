@@ -0,0 +1,102 @@
+package hostedcluster
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestReconcileResourceIdentityTags(t *testing.T) {
+	hostedCluster := func(tags ...hyperv1.AWSResourceTag) *hyperv1.HostedCluster {
+		return &hyperv1.HostedCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "clusters",
+				Name:        "example",
+				Annotations: map[string]string{hyperv1.ClusterOwnerAnnotation: "payments"},
+			},
+			Spec: hyperv1.HostedClusterSpec{
+				InfraID:   "example-abcde",
+				ClusterID: "2a1c0f4e-3f7b-4a5e-9d9e-6f1b2c3d4e5f",
+				Platform: hyperv1.PlatformSpec{
+					Type: hyperv1.AWSPlatform,
+					AWS:  &hyperv1.AWSPlatformSpec{ResourceTags: tags},
+				},
+			},
+		}
+	}
+	manyTags := func(n int) []hyperv1.AWSResourceTag {
+		var tags []hyperv1.AWSResourceTag
+		for i := 0; i < n; i++ {
+			tags = append(tags, hyperv1.AWSResourceTag{Key: fmt.Sprintf("tag-%d", i), Value: "value"})
+		}
+		return tags
+	}
+
+	testCases := []struct {
+		name            string
+		hcluster        *hyperv1.HostedCluster
+		mandatoryTags   map[string]string
+		expectedTags    []hyperv1.AWSResourceTag
+		expectedDropped []string
+	}{
+		{
+			name:          "When the cluster has resource tags it should append the identity and mandatory tags",
+			hcluster:      hostedCluster(hyperv1.AWSResourceTag{Key: "team", Value: "payments"}),
+			mandatoryTags: map[string]string{"cost-center": "1234"},
+			expectedTags: []hyperv1.AWSResourceTag{
+				{Key: "team", Value: "payments"},
+				{Key: "hypershift.openshift.io_infra-id", Value: "example-abcde"},
+				{Key: "hypershift.openshift.io_cluster-id", Value: "2a1c0f4e-3f7b-4a5e-9d9e-6f1b2c3d4e5f"},
+				{Key: "hypershift.openshift.io_cluster-name", Value: "example"},
+				{Key: "hypershift.openshift.io_owner", Value: "payments"},
+				{Key: "cost-center", Value: "1234"},
+			},
+		},
+		{
+			name:          "When the cluster sets a mandatory tag it should override its value",
+			hcluster:      hostedCluster(hyperv1.AWSResourceTag{Key: "cost-center", Value: "9999"}),
+			mandatoryTags: map[string]string{"cost-center": "1234"},
+			expectedTags: []hyperv1.AWSResourceTag{
+				{Key: "cost-center", Value: "1234"},
+				{Key: "hypershift.openshift.io_infra-id", Value: "example-abcde"},
+				{Key: "hypershift.openshift.io_cluster-id", Value: "2a1c0f4e-3f7b-4a5e-9d9e-6f1b2c3d4e5f"},
+				{Key: "hypershift.openshift.io_cluster-name", Value: "example"},
+				{Key: "hypershift.openshift.io_owner", Value: "payments"},
+			},
+		},
+		{
+			name:     "When the maximum number of resource tags is reached it should drop the tags which don't fit",
+			hcluster: hostedCluster(manyTags(22)...),
+			expectedTags: append(manyTags(22),
+				hyperv1.AWSResourceTag{Key: "hypershift.openshift.io_infra-id", Value: "example-abcde"},
+				hyperv1.AWSResourceTag{Key: "hypershift.openshift.io_cluster-id", Value: "2a1c0f4e-3f7b-4a5e-9d9e-6f1b2c3d4e5f"},
+				hyperv1.AWSResourceTag{Key: "hypershift.openshift.io_cluster-name", Value: "example"},
+			),
+			expectedDropped: []string{"hypershift.openshift.io_owner"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			hcp := &hyperv1.HostedControlPlane{}
+			hcp.Spec.Platform = *tc.hcluster.Spec.Platform.DeepCopy()
+			originalHCluster := tc.hcluster.DeepCopy()
+
+			dropped := reconcileResourceIdentityTags(hcp, tc.hcluster, tc.mandatoryTags)
+			g.Expect(dropped).To(Equal(tc.expectedDropped))
+			g.Expect(hcp.Spec.Platform.AWS.ResourceTags).To(Equal(tc.expectedTags))
+			// The HostedCluster spec the NodePool machine templates are generated from is left untouched.
+			g.Expect(tc.hcluster).To(Equal(originalHCluster))
+		})
+	}
+
+	t.Run("When the cluster isn't on AWS it should not add tags", func(t *testing.T) {
+		g := NewWithT(t)
+		hcp := &hyperv1.HostedControlPlane{}
+		g.Expect(reconcileResourceIdentityTags(hcp, &hyperv1.HostedCluster{}, nil)).To(BeEmpty())
+		g.Expect(hcp.Spec.Platform.AWS).To(BeNil())
+	})
+}
@@ -83,7 +83,7 @@ func ReconcileIgnitionServer(ctx context.Context,
 		ignitionServerRoute := ignitionserver.Route(controlPlaneNamespace)
 		if util.IsPrivateHCP(hcp) {
 			if _, err := createOrUpdate(ctx, c, ignitionServerRoute, func() error {
-				err := reconcileInternalRoute(ignitionServerRoute, ownerRef, routeServiceName)
+				err := reconcileInternalRoute(ignitionServerRoute, ownerRef, routeServiceName, util.InternalDNSDomain(hcp.Name, hcp.Spec.DNS))
 				if err != nil {
 					return fmt.Errorf("failed to reconcile internal route in ignition server: %w", err)
 				}
@@ -330,10 +330,9 @@ func reconcileExternalRoute(route *routev1.Route, ownerRef config.OwnerRef, svcN
 	return util.ReconcileExternalRoute(route, hostname, defaultIngressDomain, svcName)
 }
 
-func reconcileInternalRoute(route *routev1.Route, ownerRef config.OwnerRef, svcName string) error {
+func reconcileInternalRoute(route *routev1.Route, ownerRef config.OwnerRef, svcName string, internalDNSDomain string) error {
 	ownerRef.ApplyTo(route)
-	// Assumes ownerRef is the HCP
-	return util.ReconcileInternalRoute(route, ownerRef.Reference.Name, svcName)
+	return util.ReconcileInternalRoute(route, internalDNSDomain, svcName)
 }
 
 func reconcileCACertSecret(caCertSecret *corev1.Secret) error {
@@ -605,7 +604,7 @@ func reconcileDeployment(deployment *appsv1.Deployment,
 			},
 		},
 	}
-	proxy.SetEnvVars(&deployment.Spec.Template.Spec.Containers[0].Env)
+	proxy.SetEnvVarsFor(&deployment.Spec.Template.Spec.Containers[0].Env, proxy.ForHostedControlPlane(hcp))
 
 	// set security context
 	if !managementClusterHasCapabilitySecurityContextConstraint {
@@ -718,7 +717,7 @@ haproxy -f /tmp/haproxy.conf
 			},
 		},
 	}
-	proxy.SetEnvVars(&deployment.Spec.Template.Spec.Containers[0].Env)
+	proxy.SetEnvVarsFor(&deployment.Spec.Template.Spec.Containers[0].Env, proxy.ForHostedControlPlane(hcp))
 
 	if hcp.Spec.AdditionalTrustBundle != nil {
 		// Add trusted-ca mount with optional configmap
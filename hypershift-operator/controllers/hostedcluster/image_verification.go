@@ -0,0 +1,30 @@
+package hostedcluster
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/support/imageverification"
+)
+
+// verifyReleaseImage verifies the signature of the release image of the HostedCluster with its image verification
+// policy.
+func (r *HostedClusterReconciler) verifyReleaseImage(ctx context.Context, hc *hyperv1.HostedCluster) error {
+	verifier, err := imageverification.NewVerifier(ctx, r.Client, hc.Namespace, hc.Spec.ImageVerification)
+	if err != nil {
+		return err
+	}
+	var pullSecret corev1.Secret
+	if err := r.Client.Get(ctx, types.NamespacedName{Namespace: hc.Namespace, Name: hc.Spec.PullSecret.Name}, &pullSecret); err != nil {
+		return fmt.Errorf("failed to get pull secret: %w", err)
+	}
+	pullSecretBytes, ok := pullSecret.Data[corev1.DockerConfigJsonKey]
+	if !ok {
+		return fmt.Errorf("expected %s key in pull secret", corev1.DockerConfigJsonKey)
+	}
+	return verifier.Verify(ctx, hc.Spec.Release.Image, pullSecretBytes)
+}
@@ -302,8 +302,13 @@ func ValidCredentials(hc *hyperv1.HostedCluster) bool {
 	return true
 }
 
+// DeleteOrphanedMachines removes the finalizers of the deleted AWSMachines of the HostedCluster when their
+// instances can't or mustn't be terminated: either because the HostedCluster has no valid AWS credentials, or
+// because its deletion policy is OrphanInfra. With OrphanInfra the AWSMachines are also paused, so that the
+// instances aren't terminated by CAPA before their finalizers are removed.
 func (AWS) DeleteOrphanedMachines(ctx context.Context, c client.Client, hc *hyperv1.HostedCluster, controlPlaneNamespace string) error {
-	if ValidCredentials(hc) {
+	orphanInfra := util.HCDeletionPolicyType(hc) == hyperv1.DeletionPolicyOrphanInfra
+	if ValidCredentials(hc) && !orphanInfra {
 		return nil
 	}
 	awsMachineList := capiaws.AWSMachineList{}
@@ -314,13 +319,29 @@ func (AWS) DeleteOrphanedMachines(ctx context.Context, c client.Client, hc *hype
 	var errs []error
 	for i := range awsMachineList.Items {
 		awsMachine := &awsMachineList.Items[i]
+		if orphanInfra {
+			if _, paused := awsMachine.Annotations[capiv1.PausedAnnotation]; !paused {
+				if awsMachine.Annotations == nil {
+					awsMachine.Annotations = map[string]string{}
+				}
+				awsMachine.Annotations[capiv1.PausedAnnotation] = "true"
+				if err := c.Update(ctx, awsMachine); err != nil {
+					errs = append(errs, fmt.Errorf("failed to pause machine %s/%s: %w", awsMachine.Namespace, awsMachine.Name, err))
+					continue
+				}
+			}
+		}
 		if !awsMachine.DeletionTimestamp.IsZero() {
 			awsMachine.Finalizers = []string{}
 			if err := c.Update(ctx, awsMachine); err != nil {
 				errs = append(errs, fmt.Errorf("failed to delete machine %s/%s: %w", awsMachine.Namespace, awsMachine.Name, err))
 				continue
 			}
-			logger.Info("skipping cleanup of awsmachine because of invalid AWS identity provider", "machine", client.ObjectKeyFromObject(awsMachine))
+			if orphanInfra {
+				logger.Info("skipping cleanup of awsmachine because of the OrphanInfra deletion policy", "machine", client.ObjectKeyFromObject(awsMachine))
+			} else {
+				logger.Info("skipping cleanup of awsmachine because of invalid AWS identity provider", "machine", client.ObjectKeyFromObject(awsMachine))
+			}
 		}
 	}
 	return utilerrors.NewAggregate(errs)
@@ -72,7 +72,8 @@ type Platform interface {
 // been orphaned by a failure to communicate with the provider.
 type OrphanDeleter interface {
 	// DeleteOrphanedMachines removes the finalizer from provider machines if they have been deleted and it is no
-	// longer possible to delete them normally via the provider (ie. the OIDC provider is no longer valid)
+	// longer possible to delete them normally via the provider (ie. the OIDC provider is no longer valid), or if the
+	// deletion policy of the HostedCluster orphans its infrastructure.
 	DeleteOrphanedMachines(ctx context.Context, c client.Client, hc *hyperv1.HostedCluster, controlPlaneNamespace string) error
 }
 
@@ -0,0 +1,86 @@
+package hostedcluster
+
+import (
+	"fmt"
+	"time"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// defaultExpiryWarning is how long before its deletion a HostedCluster is reported as expiring when its lifetime
+// doesn't set an expiry warning.
+const defaultExpiryWarning = time.Hour
+
+// expiryWarning returns how long before its deletion the HostedCluster is reported as expiring.
+func expiryWarning(lifetime *hyperv1.HostedClusterLifetime) time.Duration {
+	if lifetime.ExpiryWarning != nil {
+		return lifetime.ExpiryWarning.Duration
+	}
+	return defaultExpiryWarning
+}
+
+// reconcileLifetimeStatus sets the expiration time and the Expiring condition of a HostedCluster from its lifetime,
+// or removes them when it has none. The HostedCluster expires once its TTL elapsed since its creation, but never
+// before the expiry warning elapsed since it was first reported as expiring, so that setting or shortening a lifetime
+// that already elapsed doesn't delete the HostedCluster without notice. It returns whether the HostedCluster started
+// expiring.
+func reconcileLifetimeStatus(hc *hyperv1.HostedCluster, now time.Time) bool {
+	lifetime := hc.Spec.Lifetime
+	if lifetime == nil {
+		hc.Status.ExpirationTime = nil
+		meta.RemoveStatusCondition(&hc.Status.Conditions, string(hyperv1.Expiring))
+		return false
+	}
+
+	expirationTime := hc.CreationTimestamp.Add(lifetime.TTL.Duration)
+	expiringSince := now
+	wasExpiring := false
+	if expiring := meta.FindStatusCondition(hc.Status.Conditions, string(hyperv1.Expiring)); expiring != nil && expiring.Status == metav1.ConditionTrue {
+		expiringSince = expiring.LastTransitionTime.Time
+		wasExpiring = true
+	}
+	isExpiring := !now.Before(expirationTime.Add(-expiryWarning(lifetime)))
+	if isExpiring && expirationTime.Before(expiringSince.Add(expiryWarning(lifetime))) {
+		expirationTime = expiringSince.Add(expiryWarning(lifetime))
+	}
+	hc.Status.ExpirationTime = &metav1.Time{Time: expirationTime}
+
+	condition := metav1.Condition{
+		Type:               string(hyperv1.Expiring),
+		Status:             metav1.ConditionFalse,
+		Reason:             hyperv1.AsExpectedReason,
+		Message:            fmt.Sprintf("The HostedCluster will be deleted at %s", expirationTime.UTC().Format(time.RFC3339)),
+		ObservedGeneration: hc.Generation,
+		LastTransitionTime: metav1.NewTime(now),
+	}
+	if isExpiring {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = hyperv1.LifetimeExpiringReason
+		condition.Message = fmt.Sprintf("The lifetime of the HostedCluster expires, it will be deleted at %s", expirationTime.UTC().Format(time.RFC3339))
+	}
+	meta.SetStatusCondition(&hc.Status.Conditions, condition)
+	return !wasExpiring && isExpiring
+}
+
+// lifetimeExpired returns whether the lifetime of the HostedCluster expired.
+func lifetimeExpired(hc *hyperv1.HostedCluster, now time.Time) bool {
+	return hc.Status.ExpirationTime != nil && !now.Before(hc.Status.ExpirationTime.Time)
+}
+
+// lifetimeRequeueAfter returns how long until the HostedCluster must be reconciled to report it's expiring or delete
+// it, or 0 when it doesn't need to be.
+func lifetimeRequeueAfter(hc *hyperv1.HostedCluster, now time.Time) time.Duration {
+	if hc.Spec.Lifetime == nil || hc.Status.ExpirationTime == nil || !hc.DeletionTimestamp.IsZero() {
+		return 0
+	}
+	expirationTime := hc.Status.ExpirationTime.Time
+	if warningTime := expirationTime.Add(-expiryWarning(hc.Spec.Lifetime)); now.Before(warningTime) {
+		return warningTime.Sub(now)
+	}
+	if now.Before(expirationTime) {
+		return expirationTime.Sub(now)
+	}
+	return 0
+}
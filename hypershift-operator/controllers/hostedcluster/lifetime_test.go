@@ -0,0 +1,134 @@
+package hostedcluster
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestReconcileLifetimeStatus(t *testing.T) {
+	created := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	hostedCluster := func(lifetime *hyperv1.HostedClusterLifetime, conditions ...metav1.Condition) *hyperv1.HostedCluster {
+		return &hyperv1.HostedCluster{
+			ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(created)},
+			Spec:       hyperv1.HostedClusterSpec{Lifetime: lifetime},
+			Status:     hyperv1.HostedClusterStatus{Conditions: conditions},
+		}
+	}
+	lifetime := &hyperv1.HostedClusterLifetime{TTL: metav1.Duration{Duration: 8 * time.Hour}}
+
+	testCases := []struct {
+		name                    string
+		hc                      *hyperv1.HostedCluster
+		now                     time.Time
+		expectedExpiring        metav1.ConditionStatus
+		expectedStartedExpiring bool
+		expectedExpirationTime  time.Time
+		expectedRequeueAfter    time.Duration
+	}{
+		{
+			name:                 "When the lifetime doesn't expire soon it should not report the cluster as expiring",
+			hc:                   hostedCluster(lifetime),
+			now:                  created.Add(time.Hour),
+			expectedExpiring:     metav1.ConditionFalse,
+			expectedRequeueAfter: 6 * time.Hour,
+		},
+		{
+			name:                    "When the lifetime expires within the default warning it should report the cluster as expiring",
+			hc:                      hostedCluster(lifetime, metav1.Condition{Type: string(hyperv1.Expiring), Status: metav1.ConditionFalse}),
+			now:                     created.Add(7 * time.Hour),
+			expectedExpiring:        metav1.ConditionTrue,
+			expectedStartedExpiring: true,
+			expectedRequeueAfter:    time.Hour,
+		},
+		{
+			name:                 "When the cluster was already expiring it should not report it started expiring",
+			hc:                   hostedCluster(lifetime, metav1.Condition{Type: string(hyperv1.Expiring), Status: metav1.ConditionTrue}),
+			now:                  created.Add(7*time.Hour + 45*time.Minute),
+			expectedExpiring:     metav1.ConditionTrue,
+			expectedRequeueAfter: 15 * time.Minute,
+		},
+		{
+			name: "When the expiry warning is set it should report the cluster as expiring within it",
+			hc: hostedCluster(&hyperv1.HostedClusterLifetime{
+				TTL:           metav1.Duration{Duration: 8 * time.Hour},
+				ExpiryWarning: &metav1.Duration{Duration: 2 * time.Hour},
+			}),
+			now:                     created.Add(6 * time.Hour),
+			expectedExpiring:        metav1.ConditionTrue,
+			expectedStartedExpiring: true,
+			expectedRequeueAfter:    2 * time.Hour,
+		},
+		{
+			name: "When the lifetime expired after the expiry warning it should not requeue",
+			hc: hostedCluster(lifetime, metav1.Condition{
+				Type:               string(hyperv1.Expiring),
+				Status:             metav1.ConditionTrue,
+				LastTransitionTime: metav1.NewTime(created.Add(7 * time.Hour)),
+			}),
+			now:              created.Add(9 * time.Hour),
+			expectedExpiring: metav1.ConditionTrue,
+		},
+		{
+			name:                    "When a lifetime that already elapsed is set it should postpone the deletion by the expiry warning",
+			hc:                      hostedCluster(lifetime),
+			now:                     created.Add(9 * time.Hour),
+			expectedExpiring:        metav1.ConditionTrue,
+			expectedStartedExpiring: true,
+			expectedExpirationTime:  created.Add(10 * time.Hour),
+			expectedRequeueAfter:    time.Hour,
+		},
+		{
+			name: "When the lifetime is shortened within the expiry warning it should postpone the deletion by the expiry warning",
+			hc: hostedCluster(&hyperv1.HostedClusterLifetime{TTL: metav1.Duration{Duration: 8 * time.Hour}},
+				metav1.Condition{Type: string(hyperv1.Expiring), Status: metav1.ConditionFalse}),
+			now:                     created.Add(7*time.Hour + 45*time.Minute),
+			expectedExpiring:        metav1.ConditionTrue,
+			expectedStartedExpiring: true,
+			expectedExpirationTime:  created.Add(8*time.Hour + 45*time.Minute),
+			expectedRequeueAfter:    time.Hour,
+		},
+		{
+			name: "When the cluster was reported as expiring for less than the expiry warning it should not expire",
+			hc: hostedCluster(lifetime, metav1.Condition{
+				Type:               string(hyperv1.Expiring),
+				Status:             metav1.ConditionTrue,
+				LastTransitionTime: metav1.NewTime(created.Add(8*time.Hour + 30*time.Minute)),
+			}),
+			now:                    created.Add(9 * time.Hour),
+			expectedExpiring:       metav1.ConditionTrue,
+			expectedExpirationTime: created.Add(9*time.Hour + 30*time.Minute),
+			expectedRequeueAfter:   30 * time.Minute,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			expectedExpirationTime := tc.expectedExpirationTime
+			if expectedExpirationTime.IsZero() {
+				expectedExpirationTime = created.Add(8 * time.Hour)
+			}
+			g.Expect(reconcileLifetimeStatus(tc.hc, tc.now)).To(Equal(tc.expectedStartedExpiring))
+			g.Expect(tc.hc.Status.ExpirationTime).To(Equal(&metav1.Time{Time: expectedExpirationTime}))
+			condition := meta.FindStatusCondition(tc.hc.Status.Conditions, string(hyperv1.Expiring))
+			g.Expect(condition).ToNot(BeNil())
+			g.Expect(condition.Status).To(Equal(tc.expectedExpiring))
+			g.Expect(lifetimeExpired(tc.hc, tc.now)).To(Equal(!tc.now.Before(expectedExpirationTime)))
+			g.Expect(lifetimeRequeueAfter(tc.hc, tc.now)).To(Equal(tc.expectedRequeueAfter))
+		})
+	}
+
+	t.Run("When the lifetime is removed it should remove the expiration status", func(t *testing.T) {
+		g := NewWithT(t)
+		hc := hostedCluster(nil, metav1.Condition{Type: string(hyperv1.Expiring), Status: metav1.ConditionTrue})
+		hc.Status.ExpirationTime = &metav1.Time{Time: created}
+		g.Expect(reconcileLifetimeStatus(hc, created)).To(BeFalse())
+		g.Expect(hc.Status.ExpirationTime).To(BeNil())
+		g.Expect(hc.Status.Conditions).To(BeEmpty())
+		g.Expect(lifetimeExpired(hc, created)).To(BeFalse())
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net"
 	"net/netip"
+	"strings"
 
 	"github.com/blang/semver"
 	configv1 "github.com/openshift/api/config/v1"
@@ -14,6 +15,7 @@ import (
 	"github.com/openshift/hypershift/support/capabilities"
 	"github.com/openshift/hypershift/support/config"
 	"github.com/openshift/hypershift/support/upsert"
+	"github.com/openshift/hypershift/support/util"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -87,6 +89,16 @@ func (r *HostedClusterReconciler) reconcileNetworkPolicies(ctx context.Context,
 		}
 	}
 
+	// Reconcile the network policies of the isolation level
+	isolation, err := networkPolicyIsolationFrom(hcluster.Annotations)
+	if err != nil {
+		return err
+	}
+	appliesManagementKASNetworkPolicy := controlPlaneOperatorAppliesManagementKASNetworkPolicyLabel && hcluster.Spec.Platform.Type == hyperv1.AWSPlatform
+	if err := r.reconcileIsolationNetworkPolicies(ctx, createOrUpdate, controlPlaneNamespaceName, isolation, managementClusterNetwork, kubernetesEndpoint, appliesManagementKASNetworkPolicy); err != nil {
+		return err
+	}
+
 	// Reconcile openshift-monitoring Network Policy
 	policy = networkpolicy.OpenshiftMonitoringNetworkPolicy(controlPlaneNamespaceName)
 	if _, err := createOrUpdate(ctx, r.Client, policy, func() error {
@@ -270,52 +282,7 @@ func reconcilePrivateRouterNetworkPolicy(policy *networkingv1.NetworkPolicy, hcl
 		},
 	})
 
-	if isOpenShiftDNS {
-		// Allow traffic to openshift-dns namespace
-		dnsUDPPort := intstr.FromInt(5353)
-		dnsUDPProtocol := corev1.ProtocolUDP
-		dnsTCPPort := intstr.FromInt(5353)
-		dnsTCPProtocol := corev1.ProtocolTCP
-		policy.Spec.Egress = append(policy.Spec.Egress, networkingv1.NetworkPolicyEgressRule{
-			To: []networkingv1.NetworkPolicyPeer{
-				{
-					NamespaceSelector: &metav1.LabelSelector{
-						MatchLabels: map[string]string{
-							"kubernetes.io/metadata.name": "openshift-dns",
-						},
-					},
-				},
-			},
-			Ports: []networkingv1.NetworkPolicyPort{
-				{
-					Port:     &dnsUDPPort,
-					Protocol: &dnsUDPProtocol,
-				},
-				{
-					Port:     &dnsTCPPort,
-					Protocol: &dnsTCPProtocol,
-				},
-			},
-		})
-	} else {
-		// All traffic to any destination on port 53 for both TCP and UDP
-		dnsUDPPort := intstr.FromInt(53)
-		dnsUDPProtocol := corev1.ProtocolUDP
-		dnsTCPPort := intstr.FromInt(53)
-		dnsTCPProtocol := corev1.ProtocolTCP
-		policy.Spec.Egress = append(policy.Spec.Egress, networkingv1.NetworkPolicyEgressRule{
-			Ports: []networkingv1.NetworkPolicyPort{
-				{
-					Port:     &dnsUDPPort,
-					Protocol: &dnsUDPProtocol,
-				},
-				{
-					Port:     &dnsTCPPort,
-					Protocol: &dnsTCPProtocol,
-				},
-			},
-		})
-	}
+	policy.Spec.Egress = append(policy.Spec.Egress, dnsEgressRule(isOpenShiftDNS))
 
 	policy.Spec.PolicyTypes = []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress}
 
@@ -636,55 +603,17 @@ func reconcileManagementKASNetworkPolicy(policy *networkingv1.NetworkPolicy, man
 			},
 		})
 
-	if isOpenShiftDNS {
-		// Allow traffic to openshift-dns namespace
-		dnsUDPPort := intstr.FromInt(5353)
-		dnsUDPProtocol := corev1.ProtocolUDP
-		dnsTCPPort := intstr.FromInt(5353)
-		dnsTCPProtocol := corev1.ProtocolTCP
-		policy.Spec.Egress = append(policy.Spec.Egress, networkingv1.NetworkPolicyEgressRule{
-			To: []networkingv1.NetworkPolicyPeer{
-				{
-					NamespaceSelector: &metav1.LabelSelector{
-						MatchLabels: map[string]string{
-							"kubernetes.io/metadata.name": "openshift-dns",
-						},
-					},
-				},
-			},
-			Ports: []networkingv1.NetworkPolicyPort{
-				{
-					Port:     &dnsUDPPort,
-					Protocol: &dnsUDPProtocol,
-				},
-				{
-					Port:     &dnsTCPPort,
-					Protocol: &dnsTCPProtocol,
-				},
-			},
-		})
-	} else {
-		// All traffic to any destination on port 53 for both TCP and UDP
-		dnsUDPPort := intstr.FromInt(53)
-		dnsUDPProtocol := corev1.ProtocolUDP
-		dnsTCPPort := intstr.FromInt(53)
-		dnsTCPProtocol := corev1.ProtocolTCP
-		policy.Spec.Egress = append(policy.Spec.Egress, networkingv1.NetworkPolicyEgressRule{
-			Ports: []networkingv1.NetworkPolicyPort{
-				{
-					Port:     &dnsUDPPort,
-					Protocol: &dnsUDPProtocol,
-				},
-				{
-					Port:     &dnsTCPPort,
-					Protocol: &dnsTCPProtocol,
-				},
-			},
-		})
-	}
+	policy.Spec.Egress = append(policy.Spec.Egress, dnsEgressRule(isOpenShiftDNS))
 
 	policy.Spec.PolicyTypes = []networkingv1.PolicyType{networkingv1.PolicyTypeEgress}
-	policy.Spec.PodSelector = metav1.LabelSelector{
+	policy.Spec.PodSelector = managementKASPodSelector()
+
+	return nil
+}
+
+// managementKASPodSelector selects the pods denied access to the management cluster KAS.
+func managementKASPodSelector() metav1.LabelSelector {
+	return metav1.LabelSelector{
 		MatchExpressions: []metav1.LabelSelectorRequirement{
 			{
 				Key:      NeedManagementKASAccessLabel,
@@ -698,8 +627,6 @@ func reconcileManagementKASNetworkPolicy(policy *networkingv1.NetworkPolicy, man
 			},
 		},
 	}
-
-	return nil
 }
 
 func kasEndpointsToCIDRs(kubernetesEndpoint *corev1.Endpoints) []string {
@@ -765,3 +692,235 @@ func reconcileMetricsServerNetworkPolicy(policy *networkingv1.NetworkPolicy) err
 
 	return nil
 }
+
+// dnsEgressRule allows egress traffic to the management cluster DNS.
+func dnsEgressRule(isOpenShiftDNS bool) networkingv1.NetworkPolicyEgressRule {
+	if isOpenShiftDNS {
+		// Allow traffic to openshift-dns namespace
+		dnsUDPPort := intstr.FromInt(5353)
+		dnsUDPProtocol := corev1.ProtocolUDP
+		dnsTCPPort := intstr.FromInt(5353)
+		dnsTCPProtocol := corev1.ProtocolTCP
+		return networkingv1.NetworkPolicyEgressRule{
+			To: []networkingv1.NetworkPolicyPeer{
+				{
+					NamespaceSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							"kubernetes.io/metadata.name": "openshift-dns",
+						},
+					},
+				},
+			},
+			Ports: []networkingv1.NetworkPolicyPort{
+				{
+					Port:     &dnsUDPPort,
+					Protocol: &dnsUDPProtocol,
+				},
+				{
+					Port:     &dnsTCPPort,
+					Protocol: &dnsTCPProtocol,
+				},
+			},
+		}
+	}
+
+	// All traffic to any destination on port 53 for both TCP and UDP
+	dnsUDPPort := intstr.FromInt(53)
+	dnsUDPProtocol := corev1.ProtocolUDP
+	dnsTCPPort := intstr.FromInt(53)
+	dnsTCPProtocol := corev1.ProtocolTCP
+	return networkingv1.NetworkPolicyEgressRule{
+		Ports: []networkingv1.NetworkPolicyPort{
+			{
+				Port:     &dnsUDPPort,
+				Protocol: &dnsUDPProtocol,
+			},
+			{
+				Port:     &dnsTCPPort,
+				Protocol: &dnsTCPProtocol,
+			},
+		},
+	}
+}
+
+// networkPolicyIsolation is the isolation level of the network policies of a control plane namespace, configured by
+// the NetworkPolicyIsolationAnnotation of the HostedCluster.
+type networkPolicyIsolation struct {
+	Level string
+	// AllowedCIDRs are the CIDRs allowed to reach the control plane pods with the Custom isolation level.
+	AllowedCIDRs []string
+}
+
+func networkPolicyIsolationFrom(annotations map[string]string) (networkPolicyIsolation, error) {
+	isolation := networkPolicyIsolation{Level: annotations[hyperv1.NetworkPolicyIsolationAnnotation]}
+	switch isolation.Level {
+	case "":
+		isolation.Level = hyperv1.NetworkPolicyIsolationDefault
+	case hyperv1.NetworkPolicyIsolationDefault, hyperv1.NetworkPolicyIsolationStrict:
+	case hyperv1.NetworkPolicyIsolationCustom:
+		for _, key := range []string{hyperv1.NetworkPolicyMonitoringCIDRsAnnotation, hyperv1.NetworkPolicyBastionCIDRsAnnotation} {
+			for _, cidr := range strings.Split(annotations[key], ",") {
+				if cidr = strings.TrimSpace(cidr); cidr == "" {
+					continue
+				}
+				prefix, err := netip.ParsePrefix(cidr)
+				if err != nil {
+					return isolation, fmt.Errorf("invalid CIDR %q in annotation %s: %w", cidr, key, err)
+				}
+				isolation.AllowedCIDRs = append(isolation.AllowedCIDRs, prefix.Masked().String())
+			}
+		}
+	default:
+		return isolation, fmt.Errorf("unsupported network policy isolation level %q, must be one of %s, %s or %s", isolation.Level,
+			hyperv1.NetworkPolicyIsolationDefault, hyperv1.NetworkPolicyIsolationStrict, hyperv1.NetworkPolicyIsolationCustom)
+	}
+	return isolation, nil
+}
+
+// reconcileIsolationNetworkPolicies reconciles the network policies of the Strict and Custom isolation levels, and
+// removes the ones of the levels not selected anymore.
+func (r *HostedClusterReconciler) reconcileIsolationNetworkPolicies(ctx context.Context, createOrUpdate upsert.CreateOrUpdateFN, controlPlaneNamespaceName string, isolation networkPolicyIsolation, managementClusterNetwork *configv1.Network, kubernetesEndpoint *corev1.Endpoints, appliesManagementKASNetworkPolicy bool) error {
+	isOpenShiftDNS := r.ManagementClusterCapabilities.Has(capabilities.CapabilityDNS)
+	isStrict := isolation.Level == hyperv1.NetworkPolicyIsolationStrict || isolation.Level == hyperv1.NetworkPolicyIsolationCustom
+
+	policy := networkpolicy.StrictIsolationNetworkPolicy(controlPlaneNamespaceName)
+	if isStrict {
+		// When the management KAS network policy is applied, only the pods it selects are denied access to the
+		// management cluster KAS.
+		podSelector := metav1.LabelSelector{}
+		if appliesManagementKASNetworkPolicy {
+			podSelector = managementKASPodSelector()
+		}
+		if _, err := createOrUpdate(ctx, r.Client, policy, func() error {
+			return reconcileStrictIsolationNetworkPolicy(policy, podSelector, managementClusterNetwork, kubernetesEndpoint, isOpenShiftDNS, !appliesManagementKASNetworkPolicy)
+		}); err != nil {
+			return fmt.Errorf("failed to reconcile strict isolation network policy: %w", err)
+		}
+	} else if _, err := util.DeleteIfNeeded(ctx, r.Client, policy); err != nil {
+		return fmt.Errorf("failed to delete strict isolation network policy: %w", err)
+	}
+
+	policy = networkpolicy.StrictIsolationManagementKASNetworkPolicy(controlPlaneNamespaceName)
+	if isStrict && appliesManagementKASNetworkPolicy {
+		podSelector := metav1.LabelSelector{
+			MatchExpressions: []metav1.LabelSelectorRequirement{
+				{
+					Key:      NeedManagementKASAccessLabel,
+					Operator: metav1.LabelSelectorOpExists,
+				},
+			},
+		}
+		if _, err := createOrUpdate(ctx, r.Client, policy, func() error {
+			return reconcileStrictIsolationNetworkPolicy(policy, podSelector, managementClusterNetwork, kubernetesEndpoint, isOpenShiftDNS, true)
+		}); err != nil {
+			return fmt.Errorf("failed to reconcile strict isolation management KAS network policy: %w", err)
+		}
+	} else if _, err := util.DeleteIfNeeded(ctx, r.Client, policy); err != nil {
+		return fmt.Errorf("failed to delete strict isolation management KAS network policy: %w", err)
+	}
+
+	policy = networkpolicy.CustomAllowlistNetworkPolicy(controlPlaneNamespaceName)
+	if isolation.Level == hyperv1.NetworkPolicyIsolationCustom && len(isolation.AllowedCIDRs) > 0 {
+		if _, err := createOrUpdate(ctx, r.Client, policy, func() error {
+			return reconcileCustomAllowlistNetworkPolicy(policy, isolation.AllowedCIDRs)
+		}); err != nil {
+			return fmt.Errorf("failed to reconcile custom allowlist network policy: %w", err)
+		}
+	} else if _, err := util.DeleteIfNeeded(ctx, r.Client, policy); err != nil {
+		return fmt.Errorf("failed to delete custom allowlist network policy: %w", err)
+	}
+
+	return nil
+}
+
+// reconcileStrictIsolationNetworkPolicy denies egress traffic from the selected pods to the management cluster
+// clusterNetwork and, unless allowManagementKAS is set, to the management cluster KAS. Traffic to the same namespace
+// and to the management cluster DNS is allowed. The private router and virt-launcher pods are never selected, as
+// their own network policies already restrict their egress traffic.
+func reconcileStrictIsolationNetworkPolicy(policy *networkingv1.NetworkPolicy, podSelector metav1.LabelSelector, managementClusterNetwork *configv1.Network, kubernetesEndpoint *corev1.Endpoints, isOpenShiftDNS bool, allowManagementKAS bool) error {
+	// Allow traffic to same namespace
+	policy.Spec.Egress = []networkingv1.NetworkPolicyEgressRule{
+		{
+			To: []networkingv1.NetworkPolicyPeer{
+				{
+					PodSelector: &metav1.LabelSelector{},
+				},
+			},
+		},
+	}
+
+	blockedIPv4Networks := []string{}
+	blockedIPv6Networks := []string{}
+	// In vanilla kube management cluster this would be nil.
+	if managementClusterNetwork != nil {
+		for _, network := range managementClusterNetwork.Spec.ClusterNetwork {
+			blockedIPv4Networks, blockedIPv6Networks = addToBlockedNetworks(network.CIDR, blockedIPv4Networks, blockedIPv6Networks)
+		}
+	}
+	kasCIDRs := kasEndpointsToCIDRs(kubernetesEndpoint)
+	if !allowManagementKAS {
+		for _, network := range kasCIDRs {
+			blockedIPv4Networks, blockedIPv6Networks = addToBlockedNetworks(network, blockedIPv4Networks, blockedIPv6Networks)
+		}
+	}
+
+	// Allow to any destination not on the management cluster network
+	// i.e. block all inter-namespace egress not allowed by other rules.
+	policy.Spec.Egress = append(policy.Spec.Egress,
+		networkingv1.NetworkPolicyEgressRule{
+			To: []networkingv1.NetworkPolicyPeer{
+				{
+					IPBlock: &networkingv1.IPBlock{
+						CIDR:   "0.0.0.0/0",
+						Except: blockedIPv4Networks,
+					},
+				},
+				{
+					IPBlock: &networkingv1.IPBlock{
+						CIDR:   "::/0",
+						Except: blockedIPv6Networks,
+					},
+				},
+			},
+		})
+
+	// The management cluster KAS may run on the management cluster network.
+	if allowManagementKAS && len(kasCIDRs) > 0 {
+		rule := networkingv1.NetworkPolicyEgressRule{}
+		for _, cidr := range kasCIDRs {
+			rule.To = append(rule.To, networkingv1.NetworkPolicyPeer{IPBlock: &networkingv1.IPBlock{CIDR: cidr}})
+		}
+		policy.Spec.Egress = append(policy.Spec.Egress, rule)
+	}
+
+	policy.Spec.Egress = append(policy.Spec.Egress, dnsEgressRule(isOpenShiftDNS))
+
+	policy.Spec.PolicyTypes = []networkingv1.PolicyType{networkingv1.PolicyTypeEgress}
+	policy.Spec.PodSelector = *podSelector.DeepCopy()
+	policy.Spec.PodSelector.MatchExpressions = append(policy.Spec.PodSelector.MatchExpressions,
+		metav1.LabelSelectorRequirement{
+			Key:      "app",
+			Operator: metav1.LabelSelectorOpNotIn,
+			Values:   []string{"private-router"},
+		},
+		metav1.LabelSelectorRequirement{
+			Key:      "kubevirt.io",
+			Operator: metav1.LabelSelectorOpNotIn,
+			Values:   []string{"virt-launcher"},
+		},
+	)
+
+	return nil
+}
+
+// reconcileCustomAllowlistNetworkPolicy allows ingress traffic from the given CIDRs to all the pods of the namespace.
+func reconcileCustomAllowlistNetworkPolicy(policy *networkingv1.NetworkPolicy, allowedCIDRs []string) error {
+	rule := networkingv1.NetworkPolicyIngressRule{}
+	for _, cidr := range allowedCIDRs {
+		rule.From = append(rule.From, networkingv1.NetworkPolicyPeer{IPBlock: &networkingv1.IPBlock{CIDR: cidr}})
+	}
+	policy.Spec.Ingress = []networkingv1.NetworkPolicyIngressRule{rule}
+	policy.Spec.PodSelector = metav1.LabelSelector{}
+	policy.Spec.PolicyTypes = []networkingv1.PolicyType{networkingv1.PolicyTypeIngress}
+	return nil
+}
@@ -0,0 +1,128 @@
+package hostedcluster
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	configv1 "github.com/openshift/api/config/v1"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNetworkPolicyIsolationFrom(t *testing.T) {
+	testCases := []struct {
+		name        string
+		annotations map[string]string
+		expected    networkPolicyIsolation
+		expectedErr bool
+	}{
+		{
+			name:     "When no isolation level is set it should default to Default",
+			expected: networkPolicyIsolation{Level: hyperv1.NetworkPolicyIsolationDefault},
+		},
+		{
+			name: "When the Strict isolation level is set it should ignore the CIDR allowlists",
+			annotations: map[string]string{
+				hyperv1.NetworkPolicyIsolationAnnotation:       hyperv1.NetworkPolicyIsolationStrict,
+				hyperv1.NetworkPolicyMonitoringCIDRsAnnotation: "10.0.0.0/16",
+			},
+			expected: networkPolicyIsolation{Level: hyperv1.NetworkPolicyIsolationStrict},
+		},
+		{
+			name: "When the Custom isolation level is set it should allow the monitoring and bastion CIDRs",
+			annotations: map[string]string{
+				hyperv1.NetworkPolicyIsolationAnnotation:       hyperv1.NetworkPolicyIsolationCustom,
+				hyperv1.NetworkPolicyMonitoringCIDRsAnnotation: "10.0.0.0/16, 10.1.0.0/16",
+				hyperv1.NetworkPolicyBastionCIDRsAnnotation:    "192.168.1.10/24,fd00::/64",
+			},
+			expected: networkPolicyIsolation{
+				Level:        hyperv1.NetworkPolicyIsolationCustom,
+				AllowedCIDRs: []string{"10.0.0.0/16", "10.1.0.0/16", "192.168.1.0/24", "fd00::/64"},
+			},
+		},
+		{
+			name: "When a CIDR is invalid it should fail",
+			annotations: map[string]string{
+				hyperv1.NetworkPolicyIsolationAnnotation:    hyperv1.NetworkPolicyIsolationCustom,
+				hyperv1.NetworkPolicyBastionCIDRsAnnotation: "192.168.1.10",
+			},
+			expectedErr: true,
+		},
+		{
+			name: "When the isolation level is unknown it should fail",
+			annotations: map[string]string{
+				hyperv1.NetworkPolicyIsolationAnnotation: "Paranoid",
+			},
+			expectedErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			isolation, err := networkPolicyIsolationFrom(tc.annotations)
+			if tc.expectedErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(isolation).To(Equal(tc.expected))
+		})
+	}
+}
+
+func TestReconcileStrictIsolationNetworkPolicy(t *testing.T) {
+	managementClusterNetwork := &configv1.Network{
+		Spec: configv1.NetworkSpec{
+			ClusterNetwork: []configv1.ClusterNetworkEntry{{CIDR: "10.128.0.0/14"}, {CIDR: "fd01::/48"}},
+		},
+	}
+	kubernetesEndpoint := &corev1.Endpoints{
+		Subsets: []corev1.EndpointSubset{{Addresses: []corev1.EndpointAddress{{IP: "10.0.0.1"}}}},
+	}
+	testCases := []struct {
+		name                  string
+		allowManagementKAS    bool
+		expectedBlockedIPv4   []string
+		expectedKASEgressRule bool
+	}{
+		{
+			name:                "When the management KAS is not allowed it should block it",
+			expectedBlockedIPv4: []string{"10.128.0.0/14", "10.0.0.1/32"},
+		},
+		{
+			name:                  "When the management KAS is allowed it should allow it explicitly",
+			allowManagementKAS:    true,
+			expectedBlockedIPv4:   []string{"10.128.0.0/14"},
+			expectedKASEgressRule: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			policy := &networkingv1.NetworkPolicy{}
+			err := reconcileStrictIsolationNetworkPolicy(policy, managementKASPodSelector(), managementClusterNetwork, kubernetesEndpoint, true, tc.allowManagementKAS)
+			g.Expect(err).ToNot(HaveOccurred())
+
+			g.Expect(policy.Spec.PolicyTypes).To(ConsistOf(networkingv1.PolicyTypeEgress))
+			g.Expect(policy.Spec.Egress[1].To).To(ConsistOf(
+				networkingv1.NetworkPolicyPeer{IPBlock: &networkingv1.IPBlock{CIDR: "0.0.0.0/0", Except: tc.expectedBlockedIPv4}},
+				networkingv1.NetworkPolicyPeer{IPBlock: &networkingv1.IPBlock{CIDR: "::/0", Except: []string{"fd01::/48"}}},
+			))
+			if tc.expectedKASEgressRule {
+				g.Expect(policy.Spec.Egress).To(HaveLen(4))
+				g.Expect(policy.Spec.Egress[2].To).To(ConsistOf(networkingv1.NetworkPolicyPeer{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.1/32"}}))
+			} else {
+				g.Expect(policy.Spec.Egress).To(HaveLen(3))
+			}
+
+			// The pods which have their own egress network policies should never be selected.
+			g.Expect(policy.Spec.PodSelector.MatchExpressions).To(ContainElements(
+				metav1.LabelSelectorRequirement{Key: "app", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"private-router"}},
+				metav1.LabelSelectorRequirement{Key: "kubevirt.io", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"virt-launcher"}},
+			))
+			g.Expect(policy.Spec.PodSelector.MatchExpressions).To(ContainElements(managementKASPodSelector().MatchExpressions))
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package hostedcluster
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/hypershift-operator/controllers/manifests"
+	hyperutil "github.com/openshift/hypershift/support/util"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// platformCredentialsConsumers returns the names of the control plane Deployments which use the platform
+// credentials, directly or through the secrets synced into the guest cluster, and only pick up rotated credentials
+// when restarted.
+func platformCredentialsConsumers(platform hyperv1.PlatformType) []string {
+	consumers := []string{
+		"capi-provider",
+		"cloud-network-config-controller",
+		"cluster-image-registry-operator",
+		"cluster-storage-operator",
+		"ingress-operator",
+	}
+	switch platform {
+	case hyperv1.AWSPlatform:
+		return append(consumers, "aws-cloud-controller-manager", "control-plane-operator")
+	case hyperv1.AzurePlatform:
+		return append(consumers, "azure-cloud-controller-manager")
+	}
+	return nil
+}
+
+// platformCredentialsRotatedCondition returns the PlatformCredentialsRotated condition of a HostedCluster whose
+// platform credentials were rotated: it's true once all the control plane components using the credentials were
+// restarted after the rotation and are available.
+func (r *HostedClusterReconciler) platformCredentialsRotatedCondition(ctx context.Context, hcluster *hyperv1.HostedCluster) (metav1.Condition, error) {
+	rotation := hcluster.Annotations[hyperv1.PlatformCredentialsRotationAnnotation]
+	restartDate := hcluster.Annotations[hyperv1.RestartDateAnnotation]
+	condition := metav1.Condition{
+		Type:               string(hyperv1.PlatformCredentialsRotated),
+		ObservedGeneration: hcluster.Generation,
+		Status:             metav1.ConditionTrue,
+		Reason:             hyperv1.AsExpectedReason,
+		Message:            fmt.Sprintf("Platform credentials rotated at %s are in use", rotation),
+	}
+	// The components are restarted with the restart date annotation, which must not predate the rotation.
+	if restartDate == "" || restartDate < rotation {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = hyperv1.RotationInProgressReason
+		condition.Message = fmt.Sprintf("The %s annotation must be set to restart the control plane components after the rotation at %s", hyperv1.RestartDateAnnotation, rotation)
+		return condition, nil
+	}
+
+	controlPlaneNamespace := manifests.HostedControlPlaneNamespace(hcluster.Namespace, hcluster.Name)
+	var pending, failed []string
+	for _, name := range platformCredentialsConsumers(hcluster.Spec.Platform.Type) {
+		deployment := &appsv1.Deployment{}
+		if err := r.Get(ctx, client.ObjectKey{Namespace: controlPlaneNamespace, Name: name}, deployment); err != nil {
+			// Not every component runs in every control plane.
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return metav1.Condition{}, fmt.Errorf("failed to get deployment %s: %w", name, err)
+		}
+		switch {
+		case deploymentProgressDeadlineExceeded(deployment):
+			failed = append(failed, name)
+		case deployment.Spec.Template.Annotations[hyperv1.RestartDateAnnotation] != restartDate || !hyperutil.IsDeploymentReady(ctx, deployment):
+			pending = append(pending, name)
+		}
+	}
+
+	switch {
+	case len(failed) > 0:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = hyperv1.RotationFailedReason
+		condition.Message = fmt.Sprintf("Components failed to roll out with the platform credentials rotated at %s: %s", rotation, strings.Join(failed, ", "))
+	case len(pending) > 0:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = hyperv1.RotationInProgressReason
+		condition.Message = fmt.Sprintf("Waiting for components to restart with the platform credentials rotated at %s: %s", rotation, strings.Join(pending, ", "))
+	}
+	return condition, nil
+}
+
+func deploymentProgressDeadlineExceeded(deployment *appsv1.Deployment) bool {
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type == appsv1.DeploymentProgressing {
+			return condition.Status == corev1.ConditionFalse && condition.Reason == "ProgressDeadlineExceeded"
+		}
+	}
+	return false
+}
@@ -0,0 +1,129 @@
+package hostedcluster
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/support/api"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestPlatformCredentialsRotatedCondition(t *testing.T) {
+	const rotation = "2024-05-01T10:00:00Z"
+
+	deployment := func(name, restartDate string, ready bool, progressDeadlineExceeded bool) *appsv1.Deployment {
+		d := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "clusters-hc", Name: name},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: ptr.To[int32](1),
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{hyperv1.RestartDateAnnotation: restartDate}},
+				},
+			},
+		}
+		if ready {
+			d.Status = appsv1.DeploymentStatus{Replicas: 1, UpdatedReplicas: 1, ReadyReplicas: 1, AvailableReplicas: 1}
+		}
+		if progressDeadlineExceeded {
+			d.Status.Conditions = []appsv1.DeploymentCondition{{
+				Type:   appsv1.DeploymentProgressing,
+				Status: corev1.ConditionFalse,
+				Reason: "ProgressDeadlineExceeded",
+			}}
+		}
+		return d
+	}
+
+	testCases := []struct {
+		name           string
+		restartDate    string
+		deployments    []client.Object
+		expectedStatus metav1.ConditionStatus
+		expectedReason string
+	}{
+		{
+			name:        "When all the components are restarted and available it should be true",
+			restartDate: rotation,
+			deployments: []client.Object{
+				deployment("capi-provider", rotation, true, false),
+				deployment("aws-cloud-controller-manager", rotation, true, false),
+				deployment("control-plane-operator", rotation, true, false),
+			},
+			expectedStatus: metav1.ConditionTrue,
+			expectedReason: hyperv1.AsExpectedReason,
+		},
+		{
+			name:        "When a component is not restarted yet it should be in progress",
+			restartDate: rotation,
+			deployments: []client.Object{
+				deployment("capi-provider", rotation, true, false),
+				deployment("aws-cloud-controller-manager", "2024-01-01T00:00:00Z", true, false),
+			},
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: hyperv1.RotationInProgressReason,
+		},
+		{
+			name:        "When a component is restarted but not available yet it should be in progress",
+			restartDate: rotation,
+			deployments: []client.Object{
+				deployment("capi-provider", rotation, false, false),
+			},
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: hyperv1.RotationInProgressReason,
+		},
+		{
+			name:        "When a component fails to roll out it should fail",
+			restartDate: rotation,
+			deployments: []client.Object{
+				deployment("capi-provider", rotation, true, false),
+				deployment("aws-cloud-controller-manager", rotation, false, true),
+			},
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: hyperv1.RotationFailedReason,
+		},
+		{
+			name:        "When the components were not restarted after the rotation it should be in progress",
+			restartDate: "2024-01-01T00:00:00Z",
+			deployments: []client.Object{
+				deployment("capi-provider", "2024-01-01T00:00:00Z", true, false),
+			},
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: hyperv1.RotationInProgressReason,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			hcluster := &hyperv1.HostedCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "clusters",
+					Name:      "hc",
+					Annotations: map[string]string{
+						hyperv1.PlatformCredentialsRotationAnnotation: rotation,
+						hyperv1.RestartDateAnnotation:                 tc.restartDate,
+					},
+				},
+				Spec: hyperv1.HostedClusterSpec{
+					Platform: hyperv1.PlatformSpec{Type: hyperv1.AWSPlatform},
+				},
+			}
+			r := &HostedClusterReconciler{
+				Client: fake.NewClientBuilder().WithScheme(api.Scheme).WithObjects(tc.deployments...).Build(),
+			}
+
+			condition, err := r.platformCredentialsRotatedCondition(context.Background(), hcluster)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(condition.Status).To(Equal(tc.expectedStatus))
+			g.Expect(condition.Reason).To(Equal(tc.expectedReason))
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package hostedcluster
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/hypershift-operator/controllers/manifests"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// zoneSpreadAchievedCondition returns the ZoneSpreadAchieved condition of a HighlyAvailable HostedCluster: it's true
+// when the scheduled pods of every control plane Deployment and StatefulSet with more than one replica run in at
+// least two zones. The anti-affinity of the components only requests the spread, this checks where the pods landed.
+func (r *HostedClusterReconciler) zoneSpreadAchievedCondition(ctx context.Context, hcluster *hyperv1.HostedCluster) (metav1.Condition, error) {
+	controlPlaneNamespace := manifests.HostedControlPlaneNamespace(hcluster.Namespace, hcluster.Name)
+
+	type component struct {
+		name     string
+		selector *metav1.LabelSelector
+	}
+	var components []component
+	deployments := &appsv1.DeploymentList{}
+	if err := r.List(ctx, deployments, client.InNamespace(controlPlaneNamespace)); err != nil {
+		return metav1.Condition{}, fmt.Errorf("failed to list deployments: %w", err)
+	}
+	for _, deployment := range deployments.Items {
+		if deployment.Spec.Replicas != nil && *deployment.Spec.Replicas > 1 {
+			components = append(components, component{name: deployment.Name, selector: deployment.Spec.Selector})
+		}
+	}
+	statefulSets := &appsv1.StatefulSetList{}
+	if err := r.List(ctx, statefulSets, client.InNamespace(controlPlaneNamespace)); err != nil {
+		return metav1.Condition{}, fmt.Errorf("failed to list statefulsets: %w", err)
+	}
+	for _, statefulSet := range statefulSets.Items {
+		if statefulSet.Spec.Replicas != nil && *statefulSet.Spec.Replicas > 1 {
+			components = append(components, component{name: statefulSet.Name, selector: statefulSet.Spec.Selector})
+		}
+	}
+
+	nodeZones := map[string]string{}
+	nodeZone := func(name string) (string, error) {
+		if zone, ok := nodeZones[name]; ok {
+			return zone, nil
+		}
+		node := &corev1.Node{}
+		if err := r.Get(ctx, client.ObjectKey{Name: name}, node); err != nil {
+			if apierrors.IsNotFound(err) {
+				return "", nil
+			}
+			return "", fmt.Errorf("failed to get node %s: %w", name, err)
+		}
+		nodeZones[name] = node.Labels[corev1.LabelTopologyZone]
+		return nodeZones[name], nil
+	}
+
+	var violations []string
+	for _, c := range components {
+		selector, err := metav1.LabelSelectorAsSelector(c.selector)
+		if err != nil {
+			return metav1.Condition{}, fmt.Errorf("invalid selector of %s: %w", c.name, err)
+		}
+		pods := &corev1.PodList{}
+		if err := r.List(ctx, pods, client.InNamespace(controlPlaneNamespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+			return metav1.Condition{}, fmt.Errorf("failed to list pods of %s: %w", c.name, err)
+		}
+		zones := sets.New[string]()
+		for _, pod := range pods.Items {
+			if pod.Spec.NodeName == "" || pod.DeletionTimestamp != nil {
+				continue
+			}
+			zone, err := nodeZone(pod.Spec.NodeName)
+			if err != nil {
+				return metav1.Condition{}, err
+			}
+			if zone != "" {
+				zones.Insert(zone)
+			}
+		}
+		if zones.Len() < 2 {
+			violations = append(violations, fmt.Sprintf("%s (zones: %s)", c.name, strings.Join(sets.List(zones), ", ")))
+		}
+	}
+	sort.Strings(violations)
+
+	condition := metav1.Condition{
+		Type:               string(hyperv1.ZoneSpreadAchieved),
+		ObservedGeneration: hcluster.Generation,
+		Status:             metav1.ConditionTrue,
+		Reason:             hyperv1.AsExpectedReason,
+		Message:            "The pods of the replicated control plane components run in at least two zones",
+	}
+	if len(violations) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = hyperv1.ZoneSpreadViolatedReason
+		condition.Message = fmt.Sprintf("The pods of these control plane components run in less than two zones: %s", strings.Join(violations, "; "))
+	}
+	return condition, nil
+}
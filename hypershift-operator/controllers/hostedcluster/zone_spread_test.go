@@ -0,0 +1,100 @@
+package hostedcluster
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/support/api"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestZoneSpreadAchievedCondition(t *testing.T) {
+	node := func(name, zone string) *corev1.Node {
+		return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{corev1.LabelTopologyZone: zone}}}
+	}
+	pod := func(name, app, nodeName string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "clusters-hc", Name: name, Labels: map[string]string{"app": app}},
+			Spec:       corev1.PodSpec{NodeName: nodeName},
+		}
+	}
+	deployment := func(name string, replicas int32) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "clusters-hc", Name: name},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: ptr.To(replicas),
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": name}},
+			},
+		}
+	}
+	statefulSet := func(name string, replicas int32) *appsv1.StatefulSet {
+		return &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "clusters-hc", Name: name},
+			Spec: appsv1.StatefulSetSpec{
+				Replicas: ptr.To(replicas),
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": name}},
+			},
+		}
+	}
+	nodes := []client.Object{node("node-a", "zone-a"), node("node-b", "zone-b"), node("node-a2", "zone-a")}
+
+	testCases := []struct {
+		name            string
+		objects         []client.Object
+		expectedStatus  metav1.ConditionStatus
+		expectedMessage string
+	}{
+		{
+			name: "When the pods of every component run in two zones it should be true",
+			objects: []client.Object{
+				deployment("kube-apiserver", 2), pod("kas-1", "kube-apiserver", "node-a"), pod("kas-2", "kube-apiserver", "node-b"),
+				statefulSet("etcd", 3), pod("etcd-0", "etcd", "node-a"), pod("etcd-1", "etcd", "node-a2"), pod("etcd-2", "etcd", "node-b"),
+			},
+			expectedStatus: metav1.ConditionTrue,
+		},
+		{
+			name: "When the pods of a component run in a single zone it should be false",
+			objects: []client.Object{
+				deployment("kube-apiserver", 2), pod("kas-1", "kube-apiserver", "node-a"), pod("kas-2", "kube-apiserver", "node-b"),
+				statefulSet("etcd", 3), pod("etcd-0", "etcd", "node-a"), pod("etcd-1", "etcd", "node-a2"), pod("etcd-2", "etcd", ""),
+			},
+			expectedStatus:  metav1.ConditionFalse,
+			expectedMessage: "The pods of these control plane components run in less than two zones: etcd (zones: zone-a)",
+		},
+		{
+			name: "When a component has a single replica it should be ignored",
+			objects: []client.Object{
+				deployment("cluster-version-operator", 1), pod("cvo", "cluster-version-operator", "node-a"),
+			},
+			expectedStatus: metav1.ConditionTrue,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			hcluster := &hyperv1.HostedCluster{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "hc"},
+				Spec:       hyperv1.HostedClusterSpec{ControllerAvailabilityPolicy: hyperv1.HighlyAvailable},
+			}
+			r := &HostedClusterReconciler{
+				Client: fake.NewClientBuilder().WithScheme(api.Scheme).WithObjects(append(tc.objects, nodes...)...).Build(),
+			}
+
+			condition, err := r.zoneSpreadAchievedCondition(context.Background(), hcluster)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(condition.Status).To(Equal(tc.expectedStatus))
+			if tc.expectedMessage != "" {
+				g.Expect(condition.Message).To(Equal(tc.expectedMessage))
+			}
+		})
+	}
+}
@@ -179,7 +179,7 @@ func (r *reconciler) reconcile(
 	}
 
 	logger := ctrl.LoggerFrom(ctx)
-	isPaused, duration, err := hyperutil.ProcessPausedUntilField(hostedCluster.Spec.PausedUntil, r.now())
+	isPaused, duration, err := hyperutil.ProcessPausedUntilField(hyperutil.HostedClusterPausedUntil(hostedCluster, hypershiftv1beta1.PauseScopeAll), r.now())
 	if err != nil {
 		logger.Error(err, "error processing hosted cluster paused field")
 		return nil, nil // user needs to reformat the field, returning error is useless
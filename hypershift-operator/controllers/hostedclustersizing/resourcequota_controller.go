@@ -0,0 +1,123 @@
+package hostedclustersizing
+
+import (
+	"context"
+	"fmt"
+
+	hypershiftv1beta1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	schedulingv1alpha1 "github.com/openshift/hypershift/api/scheduling/v1alpha1"
+	"github.com/openshift/hypershift/hypershift-operator/controllers/manifests"
+	"github.com/openshift/hypershift/support/upsert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	ResourceQuotaControllerName = "hostedclusterresourcequota"
+
+	// controlPlaneResourceLimitsName is the name of the ResourceQuota and LimitRange managed
+	// in each control plane namespace.
+	controlPlaneResourceLimitsName = "hosted-control-plane"
+)
+
+// resourceQuotaReconciler manages a ResourceQuota and LimitRange in the control plane namespace
+// of each HostedCluster, as configured for the HostedCluster's size class.
+type resourceQuotaReconciler struct {
+	client.Client
+	upsert.CreateOrUpdateProvider
+}
+
+func (r *resourceQuotaReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	logger := ctrl.LoggerFrom(ctx)
+
+	hostedCluster := &hypershiftv1beta1.HostedCluster{}
+	if err := r.Get(ctx, request.NamespacedName, hostedCluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, fmt.Errorf("could not get hosted cluster %s: %w", request.NamespacedName.String(), err)
+	}
+	if !hostedCluster.DeletionTimestamp.IsZero() {
+		// the control plane namespace and everything in it is removed along with the HostedCluster
+		return reconcile.Result{}, nil
+	}
+
+	config := &schedulingv1alpha1.ClusterSizingConfiguration{}
+	if err := r.Get(ctx, types.NamespacedName{Name: "cluster"}, config); err != nil {
+		return reconcile.Result{}, fmt.Errorf("could not get cluster sizing configuration: %w", err)
+	}
+	if condition := meta.FindStatusCondition(config.Status.Conditions, schedulingv1alpha1.ClusterSizingConfigurationValidType); condition == nil || condition.Status != metav1.ConditionTrue {
+		logger.Info("Cluster sizing configuration is not valid, skipping for now")
+		return reconcile.Result{}, nil
+	}
+
+	controlPlaneNamespace := manifests.HostedControlPlaneNamespace(hostedCluster.Namespace, hostedCluster.Name)
+	namespace := &corev1.Namespace{}
+	if err := r.Get(ctx, types.NamespacedName{Name: controlPlaneNamespace}, namespace); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("Control plane namespace does not exist yet, skipping for now")
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, fmt.Errorf("could not get control plane namespace %s: %w", controlPlaneNamespace, err)
+	}
+	if !namespace.DeletionTimestamp.IsZero() {
+		return reconcile.Result{}, nil
+	}
+
+	management := managementForSize(config, hostedCluster.Labels[hypershiftv1beta1.HostedClusterSizeLabel])
+
+	resourceQuota := &corev1.ResourceQuota{ObjectMeta: metav1.ObjectMeta{Namespace: controlPlaneNamespace, Name: controlPlaneResourceLimitsName}}
+	if management != nil && management.ControlPlaneResourceQuota != nil {
+		if _, err := r.CreateOrUpdate(ctx, r.Client, resourceQuota, func() error {
+			resourceQuota.Spec.Hard = management.ControlPlaneResourceQuota.Hard.DeepCopy()
+			return nil
+		}); err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to reconcile control plane resource quota: %w", err)
+		}
+	} else if err := deleteIfExists(ctx, r.Client, resourceQuota); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to delete control plane resource quota: %w", err)
+	}
+
+	limitRange := &corev1.LimitRange{ObjectMeta: metav1.ObjectMeta{Namespace: controlPlaneNamespace, Name: controlPlaneResourceLimitsName}}
+	if management != nil && management.ControlPlaneLimitRange != nil {
+		if _, err := r.CreateOrUpdate(ctx, r.Client, limitRange, func() error {
+			limitRange.Spec.Limits = nil
+			for _, limit := range management.ControlPlaneLimitRange.Limits {
+				limitRange.Spec.Limits = append(limitRange.Spec.Limits, *limit.DeepCopy())
+			}
+			return nil
+		}); err != nil {
+			return reconcile.Result{}, fmt.Errorf("failed to reconcile control plane limit range: %w", err)
+		}
+	} else if err := deleteIfExists(ctx, r.Client, limitRange); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to delete control plane limit range: %w", err)
+	}
+
+	return reconcile.Result{}, nil
+}
+
+// managementForSize returns the management configuration for the named size class, if any.
+func managementForSize(config *schedulingv1alpha1.ClusterSizingConfiguration, size string) *schedulingv1alpha1.Management {
+	if size == "" {
+		return nil
+	}
+	for i := range config.Spec.Sizes {
+		if config.Spec.Sizes[i].Name == size {
+			return config.Spec.Sizes[i].Management
+		}
+	}
+	return nil
+}
+
+func deleteIfExists(ctx context.Context, c client.Client, obj client.Object) error {
+	if err := c.Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package hostedclustersizing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	hypershiftv1beta1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	schedulingv1alpha1 "github.com/openshift/hypershift/api/scheduling/v1alpha1"
+	"github.com/openshift/hypershift/support/api"
+	"github.com/openshift/hypershift/support/upsert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestResourceQuotaReconciler_Reconcile(t *testing.T) {
+	const controlPlaneNamespace = "clusters-hc"
+
+	hard := corev1.ResourceList{
+		corev1.ResourceRequestsCPU:    resource.MustParse("8"),
+		corev1.ResourceRequestsMemory: resource.MustParse("32Gi"),
+	}
+	limits := []corev1.LimitRangeItem{{
+		Type:           corev1.LimitTypeContainer,
+		DefaultRequest: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("10m")},
+		Max:            corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("16Gi")},
+	}}
+
+	sizingConfig := func(valid bool) *schedulingv1alpha1.ClusterSizingConfiguration {
+		status := metav1.ConditionTrue
+		if !valid {
+			status = metav1.ConditionFalse
+		}
+		return &schedulingv1alpha1.ClusterSizingConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+			Spec: schedulingv1alpha1.ClusterSizingConfigurationSpec{
+				Sizes: []schedulingv1alpha1.SizeConfiguration{
+					{Name: "small"},
+					{Name: "large", Management: &schedulingv1alpha1.Management{
+						ControlPlaneResourceQuota: &schedulingv1alpha1.ControlPlaneResourceQuota{Hard: hard},
+						ControlPlaneLimitRange:    &schedulingv1alpha1.ControlPlaneLimitRange{Limits: limits},
+					}},
+				},
+			},
+			Status: schedulingv1alpha1.ClusterSizingConfigurationStatus{
+				Conditions: []metav1.Condition{{Type: schedulingv1alpha1.ClusterSizingConfigurationValidType, Status: status}},
+			},
+		}
+	}
+	hostedCluster := func(size string) *hypershiftv1beta1.HostedCluster {
+		hc := &hypershiftv1beta1.HostedCluster{ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "hc"}}
+		if size != "" {
+			hc.Labels = map[string]string{hypershiftv1beta1.HostedClusterSizeLabel: size}
+		}
+		return hc
+	}
+	existingResourceQuota := &corev1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Namespace: controlPlaneNamespace, Name: controlPlaneResourceLimitsName},
+		Spec:       corev1.ResourceQuotaSpec{Hard: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("10")}},
+	}
+	existingLimitRange := &corev1.LimitRange{
+		ObjectMeta: metav1.ObjectMeta{Namespace: controlPlaneNamespace, Name: controlPlaneResourceLimitsName},
+		Spec:       corev1.LimitRangeSpec{Limits: []corev1.LimitRangeItem{{Type: corev1.LimitTypePod}}},
+	}
+
+	testCases := []struct {
+		name                  string
+		objects               []client.Object
+		expectedResourceQuota *corev1.ResourceQuotaSpec
+		expectedLimitRange    *corev1.LimitRangeSpec
+	}{
+		{
+			name:                  "When the size class configures quotas and limits, it should create them in the control plane namespace",
+			objects:               []client.Object{sizingConfig(true), hostedCluster("large")},
+			expectedResourceQuota: &corev1.ResourceQuotaSpec{Hard: hard},
+			expectedLimitRange:    &corev1.LimitRangeSpec{Limits: limits},
+		},
+		{
+			name:                  "When existing quotas and limits differ from the size class, it should update them",
+			objects:               []client.Object{sizingConfig(true), hostedCluster("large"), existingResourceQuota.DeepCopy(), existingLimitRange.DeepCopy()},
+			expectedResourceQuota: &corev1.ResourceQuotaSpec{Hard: hard},
+			expectedLimitRange:    &corev1.LimitRangeSpec{Limits: limits},
+		},
+		{
+			name:    "When the size class does not configure quotas and limits, it should remove existing ones",
+			objects: []client.Object{sizingConfig(true), hostedCluster("small"), existingResourceQuota.DeepCopy(), existingLimitRange.DeepCopy()},
+		},
+		{
+			name:    "When the hosted cluster has no size, it should not manage quotas and limits",
+			objects: []client.Object{sizingConfig(true), hostedCluster("")},
+		},
+		{
+			name:                  "When the sizing configuration is invalid, it should leave existing quotas and limits untouched",
+			objects:               []client.Object{sizingConfig(false), hostedCluster("small"), existingResourceQuota.DeepCopy(), existingLimitRange.DeepCopy()},
+			expectedResourceQuota: &existingResourceQuota.Spec,
+			expectedLimitRange:    &existingLimitRange.Spec,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: controlPlaneNamespace}}
+			c := fake.NewClientBuilder().WithScheme(api.Scheme).WithObjects(append(testCase.objects, namespace)...).Build()
+			r := &resourceQuotaReconciler{
+				Client:                 c,
+				CreateOrUpdateProvider: upsert.New(false),
+			}
+
+			ctx := context.Background()
+			if _, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "clusters", Name: "hc"}}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			key := types.NamespacedName{Namespace: controlPlaneNamespace, Name: controlPlaneResourceLimitsName}
+			resourceQuota := &corev1.ResourceQuota{}
+			err := c.Get(ctx, key, resourceQuota)
+			switch {
+			case testCase.expectedResourceQuota == nil && !apierrors.IsNotFound(err):
+				t.Errorf("expected no resource quota, got %v", err)
+			case testCase.expectedResourceQuota != nil && err != nil:
+				t.Errorf("failed to get resource quota: %v", err)
+			case testCase.expectedResourceQuota != nil:
+				if diff := cmp.Diff(testCase.expectedResourceQuota, &resourceQuota.Spec); diff != "" {
+					t.Errorf("got incorrect resource quota: %v", diff)
+				}
+			}
+
+			limitRange := &corev1.LimitRange{}
+			err = c.Get(ctx, key, limitRange)
+			switch {
+			case testCase.expectedLimitRange == nil && !apierrors.IsNotFound(err):
+				t.Errorf("expected no limit range, got %v", err)
+			case testCase.expectedLimitRange != nil && err != nil:
+				t.Errorf("failed to get limit range: %v", err)
+			case testCase.expectedLimitRange != nil:
+				if diff := cmp.Diff(testCase.expectedLimitRange, &limitRange.Spec); diff != "" {
+					t.Errorf("got incorrect limit range: %v", diff)
+				}
+			}
+		})
+	}
+}
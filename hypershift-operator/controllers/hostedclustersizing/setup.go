@@ -10,6 +10,7 @@ import (
 	hypershiftclient "github.com/openshift/hypershift/client/clientset/clientset"
 	"github.com/openshift/hypershift/hypershift-operator/controllers/manifests"
 	"github.com/openshift/hypershift/support/releaseinfo"
+	"github.com/openshift/hypershift/support/upsert"
 	hyperutil "github.com/openshift/hypershift/support/util"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -36,7 +37,7 @@ const (
 	hostedClusterForNodePoolIndex = ".spec.clusterName"
 )
 
-func SetupWithManager(ctx context.Context, mgr ctrl.Manager, hypershiftOperatorImage string, releaseProvider *releaseinfo.ProviderWithOpenShiftImageRegistryOverridesDecorator, imageMetadataProvider *hyperutil.RegistryClientImageMetadataProvider) error {
+func SetupWithManager(ctx context.Context, mgr ctrl.Manager, hypershiftOperatorImage string, releaseProvider *releaseinfo.ProviderWithOpenShiftImageRegistryOverridesDecorator, imageMetadataProvider *hyperutil.RegistryClientImageMetadataProvider, createOrUpdate upsert.CreateOrUpdateProvider) error {
 	hypershiftClient, err := hypershiftclient.NewForConfig(mgr.GetConfig())
 	if err != nil {
 		return err
@@ -74,22 +75,24 @@ func SetupWithManager(ctx context.Context, mgr ctrl.Manager, hypershiftOperatorI
 		return fmt.Errorf("could not set up node pool -> hosted cluster indexer: %w", err)
 	}
 
+	// when the sizing configuration changes, we need to re-process every HostedCluster
+	enqueueAllHostedClusters := handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, _ client.Object) []reconcile.Request {
+		hostedClusters := hypershiftv1beta1.HostedClusterList{}
+		if err := mgr.GetClient().List(ctx, &hostedClusters); err != nil {
+			mgr.GetLogger().Error(err, "failed to list hosted clusters when enqueuing for sizing configuration change")
+			return nil
+		}
+		var out []reconcile.Request
+		for _, hc := range hostedClusters.Items {
+			out = append(out, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: hc.Namespace, Name: hc.Name}})
+		}
+		return out
+	})
+
 	if err := ctrl.NewControllerManagedBy(mgr).
 		Named(ControllerName).
 		For(&hypershiftv1beta1.HostedCluster{}).
-		Watches(&schedulingv1alpha1.ClusterSizingConfiguration{}, handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, _ client.Object) []reconcile.Request {
-			// when the sizing configuration changes, we need to re-process every HostedCluster
-			hostedClusters := hypershiftv1beta1.HostedClusterList{}
-			if err := mgr.GetClient().List(ctx, &hostedClusters); err != nil {
-				mgr.GetLogger().Error(err, "failed to list hosted clusters when enqueuing for sizing configuration change")
-				return nil
-			}
-			var out []reconcile.Request
-			for _, hc := range hostedClusters.Items {
-				out = append(out, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: hc.Namespace, Name: hc.Name}})
-			}
-			return out
-		})).
+		Watches(&schedulingv1alpha1.ClusterSizingConfiguration{}, enqueueAllHostedClusters).
 		Watches(&hypershiftv1beta1.NodePool{}, handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, object client.Object) []reconcile.Request {
 			// when a NodePool changes, queue the HostedCluster for it
 			nodePool, ok := object.(*hypershiftv1beta1.NodePool)
@@ -142,6 +145,19 @@ func SetupWithManager(ctx context.Context, mgr ctrl.Manager, hypershiftOperatorI
 		return fmt.Errorf("failed to set up %s controller: %w", ValidatingControllerName, err)
 	}
 
+	if err := ctrl.NewControllerManagedBy(mgr).
+		Named(ResourceQuotaControllerName).
+		For(&hypershiftv1beta1.HostedCluster{}).
+		Watches(&schedulingv1alpha1.ClusterSizingConfiguration{}, enqueueAllHostedClusters).
+		WithOptions(controller.Options{
+			RateLimiter: workqueue.NewItemExponentialFailureRateLimiter(1*time.Second, 10*time.Second),
+		}).Complete(&resourceQuotaReconciler{
+		Client:                 mgr.GetClient(),
+		CreateOrUpdateProvider: createOrUpdate,
+	}); err != nil {
+		return fmt.Errorf("failed to set up %s controller: %w", ResourceQuotaControllerName, err)
+	}
+
 	return nil
 }
 
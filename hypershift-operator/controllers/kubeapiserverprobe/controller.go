@@ -0,0 +1,153 @@
+package kubeapiserverprobe
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	hyperutil "github.com/openshift/hypershift/support/util"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+)
+
+const (
+	controllerName = "kube-apiserver-probe"
+
+	// kubeconfigKey is the key of the kubeconfig in the admin kubeconfig Secret of a HostedCluster.
+	kubeconfigKey = "kubeconfig"
+)
+
+// Reconciler actively probes the kube-apiserver of every HostedCluster at each interval, with synthetic requests
+// through its published endpoint and through konnectivity, instead of inferring its health from the readiness of its
+// Deployment. The outcome of the probes is exported as per cluster availability and latency metrics, and reported
+// with the KubeAPIServerReachable condition of the HostedCluster.
+type Reconciler struct {
+	client.Client
+
+	// Interval is how often the kube-apiserver of each HostedCluster is probed.
+	Interval time.Duration
+
+	probe   probeFunc
+	now     func() time.Time
+	windows *availabilityWindows
+}
+
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.probe == nil {
+		r.probe = probeKubeAPIServer
+	}
+	if r.now == nil {
+		r.now = time.Now
+	}
+	if r.windows == nil {
+		r.windows = newAvailabilityWindows()
+	}
+	_, err := ctrl.NewControllerManagedBy(mgr).
+		Named(controllerName).
+		For(&hyperv1.HostedCluster{}, builder.WithPredicates(hyperutil.PredicatesForHostedClusterAnnotationScoping(mgr.GetClient()))).
+		WithOptions(controller.Options{
+			// Probes wait on the network, so a slow kube-apiserver must not delay the probes of the others.
+			MaxConcurrentReconciles: 10,
+		}).
+		Build(r)
+	if err != nil {
+		return fmt.Errorf("failed setting up with a controller manager: %w", err)
+	}
+	return nil
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	hc := &hyperv1.HostedCluster{}
+	if err := r.Get(ctx, req.NamespacedName, hc); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.deleteMetrics(req.Namespace, req.Name)
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("failed to get hostedcluster: %w", err)
+	}
+	if !hc.DeletionTimestamp.IsZero() {
+		r.deleteMetrics(hc.Namespace, hc.Name)
+		return ctrl.Result{}, nil
+	}
+
+	originalHC := hc.DeepCopy()
+	condition, err := r.probeHostedCluster(ctx, hc)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	condition.ObservedGeneration = hc.Generation
+	meta.SetStatusCondition(&hc.Status.Conditions, condition)
+	if !equality.Semantic.DeepEqual(originalHC.Status, hc.Status) {
+		if err := r.Status().Patch(ctx, hc, client.MergeFromWithOptions(originalHC, client.MergeFromWithOptimisticLock{})); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to update status: %w", err)
+		}
+	}
+	return ctrl.Result{RequeueAfter: r.Interval}, nil
+}
+
+// probeHostedCluster probes the kube-apiserver of the HostedCluster through its admin kubeconfig, records the outcome
+// in the metrics and returns the KubeAPIServerReachable condition.
+func (r *Reconciler) probeHostedCluster(ctx context.Context, hc *hyperv1.HostedCluster) (metav1.Condition, error) {
+	condition := metav1.Condition{Type: string(hyperv1.KubeAPIServerReachable)}
+	if hc.Status.KubeConfig == nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = hyperv1.KubeconfigWaitingForCreateReason
+		condition.Message = "The admin kubeconfig of the cluster isn't available yet"
+		return condition, nil
+	}
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: hc.Namespace, Name: hc.Status.KubeConfig.Name}, secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = hyperv1.KubeconfigWaitingForCreateReason
+			condition.Message = "The admin kubeconfig of the cluster isn't available yet"
+			return condition, nil
+		}
+		return condition, fmt.Errorf("failed to get admin kubeconfig secret: %w", err)
+	}
+
+	results := r.probe(ctx, secret.Data[kubeconfigKey])
+	var failures []string
+	konnectivityProbed := false
+	for _, result := range results {
+		r.recordProbe(hc.Namespace, hc.Name, result)
+		if result.path == pathKonnectivity {
+			konnectivityProbed = true
+		}
+		if result.err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", pathDescription(result.path), result.err))
+		}
+	}
+
+	switch {
+	case len(failures) > 0:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = hyperv1.KubeAPIServerProbeFailedReason
+		condition.Message = fmt.Sprintf("Probes of the kube-apiserver failed: %s", strings.Join(failures, "; "))
+	case !konnectivityProbed:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = hyperv1.AsExpectedReason
+		condition.Message = "The kube-apiserver is reachable through its published endpoint, konnectivity is probed once the cluster has nodes"
+	default:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = hyperv1.AsExpectedReason
+		condition.Message = "The kube-apiserver is reachable through its published endpoint and through konnectivity"
+	}
+	return condition, nil
+}
+
+func pathDescription(path string) string {
+	if path == pathKonnectivity {
+		return "through konnectivity"
+	}
+	return "through the published endpoint"
+}
@@ -0,0 +1,211 @@
+package kubeapiserverprobe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/support/api"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReconcile(t *testing.T) {
+	now := time.Now()
+	adminKubeconfig := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "example-admin-kubeconfig"},
+		Data:       map[string][]byte{kubeconfigKey: []byte("kubeconfig")},
+	}
+
+	testCases := []struct {
+		name              string
+		kubeconfig        *corev1.LocalObjectReference
+		results           []probeResult
+		expectedStatus    metav1.ConditionStatus
+		expectedReason    string
+		expectedMessage   string
+		expectedAvailable map[string]float64
+	}{
+		{
+			name:            "When the admin kubeconfig isn't available yet it should not probe",
+			expectedStatus:  metav1.ConditionFalse,
+			expectedReason:  hyperv1.KubeconfigWaitingForCreateReason,
+			expectedMessage: "The admin kubeconfig of the cluster isn't available yet",
+		},
+		{
+			name:       "When both paths are reachable it should report the kube-apiserver reachable",
+			kubeconfig: &corev1.LocalObjectReference{Name: adminKubeconfig.Name},
+			results: []probeResult{
+				{path: pathEndpoint, latency: 20 * time.Millisecond},
+				{path: pathKonnectivity, latency: 80 * time.Millisecond},
+			},
+			expectedStatus:    metav1.ConditionTrue,
+			expectedReason:    hyperv1.AsExpectedReason,
+			expectedMessage:   "The kube-apiserver is reachable through its published endpoint and through konnectivity",
+			expectedAvailable: map[string]float64{pathEndpoint: 1, pathKonnectivity: 1},
+		},
+		{
+			name:              "When the cluster has no nodes it should only probe the published endpoint",
+			kubeconfig:        &corev1.LocalObjectReference{Name: adminKubeconfig.Name},
+			results:           []probeResult{{path: pathEndpoint, latency: 20 * time.Millisecond}},
+			expectedStatus:    metav1.ConditionTrue,
+			expectedReason:    hyperv1.AsExpectedReason,
+			expectedMessage:   "The kube-apiserver is reachable through its published endpoint, konnectivity is probed once the cluster has nodes",
+			expectedAvailable: map[string]float64{pathEndpoint: 1},
+		},
+		{
+			name:       "When konnectivity is unreachable it should report the failure",
+			kubeconfig: &corev1.LocalObjectReference{Name: adminKubeconfig.Name},
+			results: []probeResult{
+				{path: pathEndpoint, latency: 20 * time.Millisecond},
+				{path: pathKonnectivity, err: fmt.Errorf("no agent available")},
+			},
+			expectedStatus:    metav1.ConditionFalse,
+			expectedReason:    hyperv1.KubeAPIServerProbeFailedReason,
+			expectedMessage:   "Probes of the kube-apiserver failed: through konnectivity: no agent available",
+			expectedAvailable: map[string]float64{pathEndpoint: 1, pathKonnectivity: 0},
+		},
+	}
+	for i, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			// Each case probes its own HostedCluster, so the metrics of the cases don't add up.
+			hc := &hyperv1.HostedCluster{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: fmt.Sprintf("example-%d", i), Generation: 2},
+				Status:     hyperv1.HostedClusterStatus{KubeConfig: tc.kubeconfig},
+			}
+			c := fake.NewClientBuilder().WithScheme(api.Scheme).WithObjects(hc, adminKubeconfig).WithStatusSubresource(hc).Build()
+			r := &Reconciler{
+				Client:   c,
+				Interval: time.Minute,
+				probe: func(_ context.Context, kubeconfig []byte) []probeResult {
+					g.Expect(string(kubeconfig)).To(Equal("kubeconfig"))
+					return tc.results
+				},
+				now:     func() time.Time { return now },
+				windows: newAvailabilityWindows(),
+			}
+
+			result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(hc)})
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(result.RequeueAfter).To(Equal(time.Minute))
+			g.Expect(c.Get(context.Background(), client.ObjectKeyFromObject(hc), hc)).To(Succeed())
+			condition := meta.FindStatusCondition(hc.Status.Conditions, string(hyperv1.KubeAPIServerReachable))
+			g.Expect(condition).ToNot(BeNil())
+			g.Expect(condition.Status).To(Equal(tc.expectedStatus))
+			g.Expect(condition.Reason).To(Equal(tc.expectedReason))
+			g.Expect(condition.Message).To(Equal(tc.expectedMessage))
+			g.Expect(condition.ObservedGeneration).To(Equal(int64(2)))
+
+			for path, expected := range tc.expectedAvailable {
+				g.Expect(testutil.ToFloat64(availability.WithLabelValues(hc.Namespace, hc.Name, path))).To(Equal(expected))
+			}
+
+			// Deleting the HostedCluster removes its series.
+			g.Expect(c.Delete(context.Background(), hc)).To(Succeed())
+			_, err = r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(hc)})
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(availability.DeletePartialMatch(map[string]string{"namespace": hc.Namespace, "name": hc.Name})).To(BeZero())
+		})
+	}
+}
+
+func TestAvailabilityWindows(t *testing.T) {
+	g := NewWithT(t)
+	windows := newAvailabilityWindows()
+	key := windowKey{namespace: "clusters", name: "example", path: pathEndpoint}
+	start := time.Now()
+
+	g.Expect(windows.record(key, start, true)).To(Equal(1.0))
+	g.Expect(windows.record(key, start.Add(time.Minute), false)).To(Equal(0.5))
+	g.Expect(windows.record(key, start.Add(2*time.Minute), true)).To(BeNumerically("~", 2.0/3.0))
+	// The first probe falls out of the window.
+	g.Expect(windows.record(key, start.Add(availabilityWindow+time.Second), true)).To(BeNumerically("~", 2.0/3.0))
+
+	windows.forget("clusters", "example")
+	g.Expect(windows.samples).To(BeEmpty())
+}
+
+func TestProbeKubeAPIServer(t *testing.T) {
+	testCases := []struct {
+		name          string
+		nodes         string
+		healthzStatus int
+		expectedPaths []string
+		expectedErrs  []bool
+	}{
+		{
+			name:          "When the cluster has a node it should probe both paths",
+			nodes:         `{"kind":"NodeList","apiVersion":"v1","items":[{"metadata":{"name":"node-1"}}]}`,
+			healthzStatus: http.StatusOK,
+			expectedPaths: []string{pathEndpoint, pathKonnectivity},
+			expectedErrs:  []bool{false, false},
+		},
+		{
+			name:          "When the cluster has no nodes it should only probe the endpoint",
+			nodes:         `{"kind":"NodeList","apiVersion":"v1","items":[]}`,
+			expectedPaths: []string{pathEndpoint},
+			expectedErrs:  []bool{false},
+		},
+		{
+			name:          "When the kubelet can't be reached it should fail the konnectivity probe",
+			nodes:         `{"kind":"NodeList","apiVersion":"v1","items":[{"metadata":{"name":"node-1"}}]}`,
+			healthzStatus: http.StatusServiceUnavailable,
+			expectedPaths: []string{pathEndpoint, pathKonnectivity},
+			expectedErrs:  []bool{false, true},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			mux := http.NewServeMux()
+			mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, "ok")
+			})
+			mux.HandleFunc("/api/v1/nodes", func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprint(w, tc.nodes)
+			})
+			mux.HandleFunc("/api/v1/nodes/node-1/proxy/healthz", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.healthzStatus)
+			})
+			server := httptest.NewServer(mux)
+			defer server.Close()
+			kubeconfig := fmt.Sprintf(`apiVersion: v1
+kind: Config
+clusters:
+- name: cluster
+  cluster:
+    server: %s
+contexts:
+- name: admin
+  context:
+    cluster: cluster
+    user: admin
+current-context: admin
+users:
+- name: admin
+  user:
+    token: token
+`, server.URL)
+
+			results := probeKubeAPIServer(context.Background(), []byte(kubeconfig))
+			g.Expect(results).To(HaveLen(len(tc.expectedPaths)))
+			for i, result := range results {
+				g.Expect(result.path).To(Equal(tc.expectedPaths[i]))
+				g.Expect(result.err != nil).To(Equal(tc.expectedErrs[i]), "%v", result.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package kubeapiserverprobe
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	crmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	probesMetricName        = "hypershift_hostedcluster_kube_apiserver_probes_total"
+	probeDurationMetricName = "hypershift_hostedcluster_kube_apiserver_probe_duration_seconds"
+	availabilityMetricName  = "hypershift_hostedcluster_kube_apiserver_availability_ratio"
+
+	// availabilityWindow is the rolling window the availability ratio is computed over.
+	availabilityWindow = time.Hour
+)
+
+var (
+	probes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: probesMetricName,
+		Help: "Number of synthetic requests sent to the kube-apiservers of the HostedClusters, by path and result",
+	}, []string{"namespace", "name", "path", "result"})
+
+	probeDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    probeDurationMetricName,
+		Help:    "Latency of the successful synthetic requests sent to the kube-apiservers of the HostedClusters, by path",
+		Buckets: []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10},
+	}, []string{"namespace", "name", "path"})
+
+	availability = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: availabilityMetricName,
+		Help: "Ratio of the synthetic requests sent to the kube-apiservers of the HostedClusters in the last hour which succeeded, by path",
+	}, []string{"namespace", "name", "path"})
+)
+
+func init() {
+	crmetrics.Registry.MustRegister(probes, probeDuration, availability)
+}
+
+// windowKey identifies the probes of a path of a HostedCluster.
+type windowKey struct {
+	namespace string
+	name      string
+	path      string
+}
+
+type sample struct {
+	time    time.Time
+	success bool
+}
+
+// availabilityWindows keeps the outcome of the probes of the availability window, to compute the availability ratio
+// of each path of each HostedCluster.
+type availabilityWindows struct {
+	mu      sync.Mutex
+	samples map[windowKey][]sample
+}
+
+func newAvailabilityWindows() *availabilityWindows {
+	return &availabilityWindows{samples: map[windowKey][]sample{}}
+}
+
+// record adds the outcome of a probe and returns the availability ratio of its window.
+func (w *availabilityWindows) record(key windowKey, now time.Time, success bool) float64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	samples := append(w.samples[key], sample{time: now, success: success})
+	first := 0
+	for first < len(samples) && !samples[first].time.After(now.Add(-availabilityWindow)) {
+		first++
+	}
+	samples = samples[first:]
+	w.samples[key] = samples
+
+	succeeded := 0
+	for _, s := range samples {
+		if s.success {
+			succeeded++
+		}
+	}
+	return float64(succeeded) / float64(len(samples))
+}
+
+// forget drops the probes of the HostedCluster.
+func (w *availabilityWindows) forget(namespace, name string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for key := range w.samples {
+		if key.namespace == namespace && key.name == name {
+			delete(w.samples, key)
+		}
+	}
+}
+
+// recordProbe exports the outcome of the probe.
+func (r *Reconciler) recordProbe(namespace, name string, result probeResult) {
+	outcome := "success"
+	if result.err != nil {
+		outcome = "failure"
+	} else {
+		probeDuration.WithLabelValues(namespace, name, result.path).Observe(result.latency.Seconds())
+	}
+	probes.WithLabelValues(namespace, name, result.path, outcome).Inc()
+	ratio := r.windows.record(windowKey{namespace: namespace, name: name, path: result.path}, r.now(), result.err == nil)
+	availability.WithLabelValues(namespace, name, result.path).Set(ratio)
+}
+
+// deleteMetrics removes the series of the HostedCluster.
+func (r *Reconciler) deleteMetrics(namespace, name string) {
+	labels := prometheus.Labels{"namespace": namespace, "name": name}
+	probes.DeletePartialMatch(labels)
+	probeDuration.DeletePartialMatch(labels)
+	availability.DeletePartialMatch(labels)
+	r.windows.forget(namespace, name)
+}
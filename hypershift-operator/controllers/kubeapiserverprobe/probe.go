@@ -0,0 +1,67 @@
+package kubeapiserverprobe
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const (
+	// pathEndpoint is the path of the probes sent to the published endpoint of the kube-apiserver.
+	pathEndpoint = "endpoint"
+	// pathKonnectivity is the path of the probes the kube-apiserver proxies through konnectivity to the kubelet of a
+	// node.
+	pathKonnectivity = "konnectivity"
+
+	// probeTimeout bounds each probe, so an unresponsive kube-apiserver counts as unavailable.
+	probeTimeout = 10 * time.Second
+)
+
+// probeResult is the outcome of a probe of one path.
+type probeResult struct {
+	path    string
+	latency time.Duration
+	err     error
+}
+
+// probeFunc probes the kube-apiserver the kubeconfig points to. It returns no result for the paths it couldn't probe,
+// e.g. konnectivity when the cluster has no nodes.
+type probeFunc func(ctx context.Context, kubeconfig []byte) []probeResult
+
+// probeKubeAPIServer sends the synthetic requests of the probes: the readiness endpoint of the kube-apiserver, which
+// only depends on its published endpoint being reachable, and the health endpoint of the kubelet of a node through
+// the node proxy of the kube-apiserver, which is tunneled through konnectivity.
+func probeKubeAPIServer(ctx context.Context, kubeconfig []byte) []probeResult {
+	restConfig, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return []probeResult{{path: pathEndpoint, err: fmt.Errorf("failed to load kubeconfig: %w", err)}}
+	}
+	restConfig.Timeout = probeTimeout
+	client, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return []probeResult{{path: pathEndpoint, err: fmt.Errorf("failed to create client: %w", err)}}
+	}
+
+	start := time.Now()
+	_, err = client.Discovery().RESTClient().Get().AbsPath("/readyz").DoRaw(ctx)
+	results := []probeResult{{path: pathEndpoint, latency: time.Since(start), err: err}}
+	if err != nil {
+		// The konnectivity path can't be probed without the endpoint.
+		return results
+	}
+
+	nodes, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{Limit: 1})
+	if err != nil {
+		return append(results, probeResult{path: pathKonnectivity, err: fmt.Errorf("failed to list nodes: %w", err)})
+	}
+	if len(nodes.Items) == 0 {
+		return results
+	}
+	start = time.Now()
+	_, err = client.CoreV1().RESTClient().Get().AbsPath("/api/v1/nodes", nodes.Items[0].Name, "proxy", "healthz").DoRaw(ctx)
+	return append(results, probeResult{path: pathKonnectivity, latency: time.Since(start), err: err})
+}
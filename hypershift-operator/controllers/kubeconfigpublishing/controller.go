@@ -0,0 +1,232 @@
+package kubeconfigpublishing
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/support/conditions"
+	hyperutil "github.com/openshift/hypershift/support/util"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	controllerName = "kubeconfig-publishing"
+
+	// kubeconfigKey is the key of the kubeconfig in the Secrets of the published kubeconfigs.
+	kubeconfigKey = "kubeconfig"
+
+	// retryPeriod is how long the controller waits before publishing again the kubeconfigs which couldn't be published.
+	retryPeriod = time.Minute
+)
+
+// Reconciler publishes the kubeconfigs of the kubeconfigPublishing spec of HostedClusters to their external secret
+// store. The hash of each published kubeconfig is recorded in the status of the HostedCluster, so a kubeconfig is only
+// published again once its Secret changes, e.g. when it's rotated. The outcome is reported with the
+// KubeconfigPublished condition of the HostedCluster.
+type Reconciler struct {
+	client.Client
+
+	// storeForCluster returns the client of the secret store of the HostedCluster.
+	storeForCluster func(spec *hyperv1.KubeconfigPublishingSpec, credentialsSecret *corev1.Secret, hc *hyperv1.HostedCluster) (secretStore, error)
+	now             func() time.Time
+}
+
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.storeForCluster == nil {
+		r.storeForCluster = newSecretStore
+	}
+	if r.now == nil {
+		r.now = time.Now
+	}
+	_, err := ctrl.NewControllerManagedBy(mgr).
+		Named(controllerName).
+		For(&hyperv1.HostedCluster{}, builder.WithPredicates(hyperutil.PredicatesForHostedClusterAnnotationScoping(mgr.GetClient()))).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.hostedClustersForSecret)).
+		Build(r)
+	if err != nil {
+		return fmt.Errorf("failed setting up with a controller manager: %w", err)
+	}
+	return nil
+}
+
+// hostedClustersForSecret returns the HostedClusters in the namespace of the Secret which publish it, or use it as the
+// credentials of their store.
+func (r *Reconciler) hostedClustersForSecret(ctx context.Context, obj client.Object) []reconcile.Request {
+	hostedClusters := &hyperv1.HostedClusterList{}
+	if err := r.List(ctx, hostedClusters, client.InNamespace(obj.GetNamespace())); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "Failed to list hostedclusters")
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range hostedClusters.Items {
+		hc := &hostedClusters.Items[i]
+		if hc.Spec.KubeconfigPublishing == nil {
+			continue
+		}
+		referenced := hc.Spec.KubeconfigPublishing.Credentials.Name == obj.GetName()
+		for _, kubeconfig := range kubeconfigsToPublish(hc) {
+			referenced = referenced || kubeconfig.Secret.Name == obj.GetName()
+		}
+		if referenced {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(hc)})
+		}
+	}
+	return requests
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	hc := &hyperv1.HostedCluster{}
+	if err := r.Get(ctx, req.NamespacedName, hc); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("failed to get hostedcluster: %w", err)
+	}
+	if !hc.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+	if isPaused, duration := hyperutil.IsReconciliationPaused(log, hc.Spec.PausedUntil); isPaused {
+		log.Info("Reconciliation paused", "pausedUntil", *hc.Spec.PausedUntil)
+		return ctrl.Result{RequeueAfter: duration}, nil
+	}
+
+	originalHC := hc.DeepCopy()
+	var result ctrl.Result
+	if hc.Spec.KubeconfigPublishing == nil {
+		meta.RemoveStatusCondition(&hc.Status.Conditions, string(hyperv1.KubeconfigPublished))
+		hc.Status.PublishedKubeconfigs = nil
+	} else {
+		published, failed := r.publish(ctx, hc)
+		hc.Status.PublishedKubeconfigs = published
+		condition := publishedCondition(hc, failed)
+		condition.ObservedGeneration = hc.Generation
+		meta.SetStatusCondition(&hc.Status.Conditions, condition)
+		if len(failed) > 0 {
+			result.RequeueAfter = retryPeriod
+		}
+	}
+	if !equality.Semantic.DeepEqual(originalHC.Status, hc.Status) {
+		if err := r.Status().Patch(ctx, hc, client.MergeFromWithOptions(originalHC, client.MergeFromWithOptimisticLock{})); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to update status: %w", err)
+		}
+	}
+	return result, nil
+}
+
+// kubeconfigsToPublish returns the kubeconfigs published for the HostedCluster: its admin kubeconfig, once it's
+// reported in its status, followed by the additional kubeconfigs.
+func kubeconfigsToPublish(hc *hyperv1.HostedCluster) []hyperv1.PublishedKubeconfig {
+	spec := hc.Spec.KubeconfigPublishing
+	var kubeconfigs []hyperv1.PublishedKubeconfig
+	if hc.Status.KubeConfig != nil {
+		kubeconfigs = append(kubeconfigs, hyperv1.PublishedKubeconfig{Name: spec.AdminKubeconfigName, Secret: *hc.Status.KubeConfig})
+	}
+	return append(kubeconfigs, spec.AdditionalKubeconfigs...)
+}
+
+// publish publishes the kubeconfigs which changed since they were last published. It returns the status of the
+// published kubeconfigs, and the errors of the ones which couldn't be published by name.
+func (r *Reconciler) publish(ctx context.Context, hc *hyperv1.HostedCluster) ([]hyperv1.PublishedKubeconfigStatus, map[string]error) {
+	previous := map[string]hyperv1.PublishedKubeconfigStatus{}
+	for _, status := range hc.Status.PublishedKubeconfigs {
+		previous[status.Name] = status
+	}
+
+	var published []hyperv1.PublishedKubeconfigStatus
+	failed := map[string]error{}
+	var store secretStore
+	for _, kubeconfig := range kubeconfigsToPublish(hc) {
+		status, wasPublished := previous[kubeconfig.Name]
+		secret := &corev1.Secret{}
+		if err := r.Get(ctx, client.ObjectKey{Namespace: hc.Namespace, Name: kubeconfig.Secret.Name}, secret); err != nil {
+			failed[kubeconfig.Name] = fmt.Errorf("failed to get secret %s: %w", kubeconfig.Secret.Name, err)
+		} else if value := secret.Data[kubeconfigKey]; len(value) == 0 {
+			failed[kubeconfig.Name] = fmt.Errorf("secret %s has no %s key", kubeconfig.Secret.Name, kubeconfigKey)
+		} else if hash := kubeconfigHash(value); !wasPublished || status.Hash != hash {
+			// The store is only created once a kubeconfig needs to be published, so its credentials aren't read
+			// while the kubeconfigs don't change.
+			if store == nil {
+				var err error
+				if store, err = r.newStore(ctx, hc); err != nil {
+					failed[kubeconfig.Name] = err
+				}
+			}
+			if store != nil {
+				if err := store.PutSecret(ctx, kubeconfig.Name, value); err != nil {
+					failed[kubeconfig.Name] = err
+				} else {
+					status = hyperv1.PublishedKubeconfigStatus{Name: kubeconfig.Name, Hash: hash, LastPublishedTime: metav1.NewTime(r.now())}
+					wasPublished = true
+				}
+			}
+		}
+		// A kubeconfig which couldn't be published again keeps the status of its last publication.
+		if wasPublished {
+			published = append(published, status)
+		}
+	}
+	return published, failed
+}
+
+func (r *Reconciler) newStore(ctx context.Context, hc *hyperv1.HostedCluster) (secretStore, error) {
+	spec := hc.Spec.KubeconfigPublishing
+	credentialsSecret := &corev1.Secret{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: hc.Namespace, Name: spec.Credentials.Name}, credentialsSecret); err != nil {
+		return nil, fmt.Errorf("failed to get store credentials secret %s: %w", spec.Credentials.Name, err)
+	}
+	return r.storeForCluster(spec, credentialsSecret, hc)
+}
+
+func kubeconfigHash(kubeconfig []byte) string {
+	sum := sha256.Sum256(kubeconfig)
+	return hex.EncodeToString(sum[:])
+}
+
+// publishedCondition returns the KubeconfigPublished condition reporting the kubeconfigs which couldn't be published.
+func publishedCondition(hc *hyperv1.HostedCluster, failed map[string]error) metav1.Condition {
+	spec := hc.Spec.KubeconfigPublishing
+	condition := metav1.Condition{
+		Type: string(hyperv1.KubeconfigPublished),
+	}
+	switch {
+	case len(failed) > 0:
+		var failures []string
+		var reason string
+		for _, kubeconfig := range kubeconfigsToPublish(hc) {
+			if err, isFailed := failed[kubeconfig.Name]; isFailed {
+				failures = append(failures, fmt.Sprintf("%s: %v", kubeconfig.Name, err))
+				if reason == "" {
+					reason = conditions.ReasonForError(err, hyperv1.KubeconfigPublishFailedReason)
+				}
+			}
+		}
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = reason
+		condition.Message = fmt.Sprintf("Failed to publish kubeconfigs to the %s store: %s", spec.Store.Type, strings.Join(failures, "; "))
+	case hc.Status.KubeConfig == nil:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = hyperv1.KubeconfigWaitingForCreateReason
+		condition.Message = "The admin kubeconfig of the cluster isn't available yet"
+	default:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = hyperv1.AsExpectedReason
+		condition.Message = fmt.Sprintf("The kubeconfigs are published to the %s store", spec.Store.Type)
+	}
+	return condition
+}
@@ -0,0 +1,177 @@
+package kubeconfigpublishing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/support/api"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type fakeStore struct {
+	secrets map[string]string
+	failed  map[string]error
+	puts    int
+}
+
+func (f *fakeStore) PutSecret(_ context.Context, name string, value []byte) error {
+	f.puts++
+	if err, failed := f.failed[name]; failed {
+		return err
+	}
+	if f.secrets == nil {
+		f.secrets = map[string]string{}
+	}
+	f.secrets[name] = string(value)
+	return nil
+}
+
+func kubeconfigSecret(name, kubeconfig string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: name},
+		Data:       map[string][]byte{kubeconfigKey: []byte(kubeconfig)},
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	now := time.Now()
+	hostedCluster := func() *hyperv1.HostedCluster {
+		return &hyperv1.HostedCluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "example", Generation: 2},
+			Spec: hyperv1.HostedClusterSpec{
+				KubeconfigPublishing: &hyperv1.KubeconfigPublishingSpec{
+					Store:               hyperv1.KubeconfigStore{Type: hyperv1.VaultKubeconfigStore, Vault: &hyperv1.VaultKubeconfigStoreSpec{Address: "https://vault.example.com"}},
+					Credentials:         corev1.LocalObjectReference{Name: "vault-credentials"},
+					AdminKubeconfigName: "clusters/example/admin",
+					AdditionalKubeconfigs: []hyperv1.PublishedKubeconfig{
+						{Name: "clusters/example/pipeline", Secret: corev1.LocalObjectReference{Name: "pipeline-kubeconfig"}},
+					},
+				},
+			},
+			Status: hyperv1.HostedClusterStatus{KubeConfig: &corev1.LocalObjectReference{Name: "example-admin-kubeconfig"}},
+		}
+	}
+	reconciler := func(c client.Client, store *fakeStore) *Reconciler {
+		return &Reconciler{
+			Client: c,
+			storeForCluster: func(*hyperv1.KubeconfigPublishingSpec, *corev1.Secret, *hyperv1.HostedCluster) (secretStore, error) {
+				return store, nil
+			},
+			now: func() time.Time { return now },
+		}
+	}
+	credentials := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "vault-credentials"}}
+
+	t.Run("When the kubeconfigs are published it should only publish them again once they're rotated", func(t *testing.T) {
+		g := NewWithT(t)
+		hc := hostedCluster()
+		admin := kubeconfigSecret("example-admin-kubeconfig", "admin")
+		c := fake.NewClientBuilder().WithScheme(api.Scheme).WithObjects(hc, credentials, admin, kubeconfigSecret("pipeline-kubeconfig", "pipeline")).WithStatusSubresource(hc).Build()
+		store := &fakeStore{}
+		r := reconciler(c, store)
+
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(hc)})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(store.secrets).To(Equal(map[string]string{"clusters/example/admin": "admin", "clusters/example/pipeline": "pipeline"}))
+		g.Expect(c.Get(context.Background(), client.ObjectKeyFromObject(hc), hc)).To(Succeed())
+		g.Expect(hc.Status.PublishedKubeconfigs).To(HaveLen(2))
+		g.Expect(hc.Status.PublishedKubeconfigs[0].Hash).To(Equal(kubeconfigHash([]byte("admin"))))
+		condition := meta.FindStatusCondition(hc.Status.Conditions, string(hyperv1.KubeconfigPublished))
+		g.Expect(condition).ToNot(BeNil())
+		g.Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		g.Expect(condition.ObservedGeneration).To(Equal(int64(2)))
+
+		_, err = r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(hc)})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(store.puts).To(Equal(2))
+
+		admin.Data[kubeconfigKey] = []byte("rotated")
+		g.Expect(c.Update(context.Background(), admin)).To(Succeed())
+		_, err = r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(hc)})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(store.puts).To(Equal(3))
+		g.Expect(store.secrets["clusters/example/admin"]).To(Equal("rotated"))
+	})
+
+	t.Run("When a kubeconfig can't be published it should report it and keep its previous publication", func(t *testing.T) {
+		g := NewWithT(t)
+		hc := hostedCluster()
+		hc.Status.PublishedKubeconfigs = []hyperv1.PublishedKubeconfigStatus{{Name: "clusters/example/admin", Hash: "old", LastPublishedTime: metav1.NewTime(now.Add(-time.Hour).Truncate(time.Second))}}
+		c := fake.NewClientBuilder().WithScheme(api.Scheme).WithObjects(hc, credentials, kubeconfigSecret("example-admin-kubeconfig", "admin")).WithStatusSubresource(hc).Build()
+		store := &fakeStore{failed: map[string]error{"clusters/example/admin": fmt.Errorf("permission denied")}}
+
+		result, err := reconciler(c, store).Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(hc)})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result.RequeueAfter).To(Equal(retryPeriod))
+		g.Expect(c.Get(context.Background(), client.ObjectKeyFromObject(hc), hc)).To(Succeed())
+		g.Expect(hc.Status.PublishedKubeconfigs).To(HaveLen(1))
+		g.Expect(hc.Status.PublishedKubeconfigs[0].Hash).To(Equal("old"))
+		condition := meta.FindStatusCondition(hc.Status.Conditions, string(hyperv1.KubeconfigPublished))
+		g.Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		g.Expect(condition.Reason).To(Equal(hyperv1.KubeconfigPublishFailedReason))
+		g.Expect(condition.Message).To(Equal("Failed to publish kubeconfigs to the Vault store: clusters/example/admin: permission denied; clusters/example/pipeline: failed to get secret pipeline-kubeconfig: secrets \"pipeline-kubeconfig\" not found"))
+	})
+
+	t.Run("When publishing is disabled it should clear the status", func(t *testing.T) {
+		g := NewWithT(t)
+		hc := hostedCluster()
+		hc.Spec.KubeconfigPublishing = nil
+		hc.Status.PublishedKubeconfigs = []hyperv1.PublishedKubeconfigStatus{{Name: "clusters/example/admin", Hash: "old"}}
+		hc.Status.Conditions = []metav1.Condition{{Type: string(hyperv1.KubeconfigPublished), Status: metav1.ConditionTrue, Reason: hyperv1.AsExpectedReason}}
+		c := fake.NewClientBuilder().WithScheme(api.Scheme).WithObjects(hc).WithStatusSubresource(hc).Build()
+
+		_, err := reconciler(c, &fakeStore{}).Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(hc)})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(c.Get(context.Background(), client.ObjectKeyFromObject(hc), hc)).To(Succeed())
+		g.Expect(hc.Status.PublishedKubeconfigs).To(BeEmpty())
+		g.Expect(meta.FindStatusCondition(hc.Status.Conditions, string(hyperv1.KubeconfigPublished))).To(BeNil())
+	})
+
+	t.Run("When the admin kubeconfig isn't available yet it should wait for it", func(t *testing.T) {
+		g := NewWithT(t)
+		hc := hostedCluster()
+		hc.Status.KubeConfig = nil
+		hc.Spec.KubeconfigPublishing.AdditionalKubeconfigs = nil
+		condition := publishedCondition(hc, nil)
+		g.Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		g.Expect(condition.Reason).To(Equal(hyperv1.KubeconfigWaitingForCreateReason))
+	})
+}
+
+func TestVaultStore(t *testing.T) {
+	g := NewWithT(t)
+	var path string
+	var body map[string]map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "token" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+		path = r.URL.Path
+		g.Expect(r.Header.Get("X-Vault-Namespace")).To(Equal("team"))
+		g.Expect(json.NewDecoder(r.Body).Decode(&body)).To(Succeed())
+	}))
+	defer server.Close()
+
+	store := &vaultStore{address: server.URL, mountPath: "kv", namespace: "team", token: "token", httpClient: server.Client()}
+	g.Expect(store.PutSecret(context.Background(), "clusters/example/admin", []byte("admin"))).To(Succeed())
+	g.Expect(path).To(Equal("/v1/kv/data/clusters/example/admin"))
+	g.Expect(body).To(Equal(map[string]map[string]string{"data": {"kubeconfig": "admin"}}))
+
+	store.token = "wrong"
+	g.Expect(store.PutSecret(context.Background(), "clusters/example/admin", []byte("admin"))).To(MatchError("vault returned 403 Forbidden: permission denied"))
+}
@@ -0,0 +1,192 @@
+package kubeconfigpublishing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	awsAccessKeyIDKey     = "aws_access_key_id"
+	awsSecretAccessKeyKey = "aws_secret_access_key"
+	azureTenantIDKey      = "AZURE_TENANT_ID"
+	azureClientIDKey      = "AZURE_CLIENT_ID"
+	azureClientSecretKey  = "AZURE_CLIENT_SECRET"
+	vaultTokenKey         = "token"
+
+	keyVaultScope      = "https://vault.azure.net/.default"
+	keyVaultAPIVersion = "7.4"
+)
+
+// secretStore is an external secret store kubeconfigs are published to.
+type secretStore interface {
+	// PutSecret creates the secret with the value, or adds the value as its new version if it exists.
+	PutSecret(ctx context.Context, name string, value []byte) error
+}
+
+// newSecretStore returns the client of the store of the spec, authenticated with the credentials Secret.
+func newSecretStore(spec *hyperv1.KubeconfigPublishingSpec, credentialsSecret *corev1.Secret, hc *hyperv1.HostedCluster) (secretStore, error) {
+	data := credentialsSecret.Data
+	switch spec.Store.Type {
+	case hyperv1.AWSSecretsManagerKubeconfigStore:
+		if spec.Store.AWSSecretsManager == nil {
+			return nil, fmt.Errorf("awsSecretsManager is required with the %s store", spec.Store.Type)
+		}
+		awsSession, err := session.NewSession(aws.NewConfig().
+			WithRegion(spec.Store.AWSSecretsManager.Region).
+			WithCredentials(credentials.NewStaticCredentials(string(data[awsAccessKeyIDKey]), string(data[awsSecretAccessKeyKey]), "")))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create aws session: %w", err)
+		}
+		return &awsSecretsManagerStore{
+			client:      secretsmanager.New(awsSession),
+			description: fmt.Sprintf("Kubeconfig of hosted cluster %s/%s", hc.Namespace, hc.Name),
+		}, nil
+	case hyperv1.AzureKeyVaultKubeconfigStore:
+		if spec.Store.AzureKeyVault == nil {
+			return nil, fmt.Errorf("azureKeyVault is required with the %s store", spec.Store.Type)
+		}
+		azureCredentials, err := azidentity.NewClientSecretCredential(string(data[azureTenantIDKey]), string(data[azureClientIDKey]), string(data[azureClientSecretKey]), nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to obtain azure client credential: %w", err)
+		}
+		return &azureKeyVaultStore{
+			vaultURL: strings.TrimSuffix(spec.Store.AzureKeyVault.VaultURL, "/"),
+			pipeline: runtime.NewPipeline("hypershift", "", runtime.PipelineOptions{
+				PerRetry: []policy.Policy{runtime.NewBearerTokenPolicy(azureCredentials, []string{keyVaultScope}, nil)},
+			}, nil),
+		}, nil
+	case hyperv1.VaultKubeconfigStore:
+		if spec.Store.Vault == nil {
+			return nil, fmt.Errorf("vault is required with the %s store", spec.Store.Type)
+		}
+		token := string(data[vaultTokenKey])
+		if token == "" {
+			return nil, fmt.Errorf("credentials secret %s has no %s", credentialsSecret.Name, vaultTokenKey)
+		}
+		mountPath := spec.Store.Vault.MountPath
+		if mountPath == "" {
+			mountPath = "secret"
+		}
+		return &vaultStore{
+			address:    strings.TrimSuffix(spec.Store.Vault.Address, "/"),
+			mountPath:  strings.Trim(mountPath, "/"),
+			namespace:  spec.Store.Vault.Namespace,
+			token:      token,
+			httpClient: &http.Client{Timeout: 30 * time.Second},
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported kubeconfig store type %q", spec.Store.Type)
+	}
+}
+
+// awsSecretsManagerStore publishes kubeconfigs as AWS Secrets Manager secrets.
+type awsSecretsManagerStore struct {
+	client      *secretsmanager.SecretsManager
+	description string
+}
+
+func (s *awsSecretsManagerStore) PutSecret(ctx context.Context, name string, value []byte) error {
+	_, err := s.client.PutSecretValueWithContext(ctx, &secretsmanager.PutSecretValueInput{
+		SecretId:     aws.String(name),
+		SecretString: aws.String(string(value)),
+	})
+	var awsErr awserr.Error
+	if !errors.As(err, &awsErr) || awsErr.Code() != secretsmanager.ErrCodeResourceNotFoundException {
+		return err
+	}
+	_, err = s.client.CreateSecretWithContext(ctx, &secretsmanager.CreateSecretInput{
+		Name:         aws.String(name),
+		Description:  aws.String(s.description),
+		SecretString: aws.String(string(value)),
+	})
+	return err
+}
+
+// azureKeyVaultStore publishes kubeconfigs as Azure Key Vault secrets.
+type azureKeyVaultStore struct {
+	vaultURL string
+	pipeline runtime.Pipeline
+}
+
+func (s *azureKeyVaultStore) PutSecret(ctx context.Context, name string, value []byte) error {
+	req, err := runtime.NewRequest(ctx, http.MethodPut, fmt.Sprintf("%s/secrets/%s", s.vaultURL, url.PathEscape(name)))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	query := req.Raw().URL.Query()
+	query.Set("api-version", keyVaultAPIVersion)
+	req.Raw().URL.RawQuery = query.Encode()
+	if err := runtime.MarshalAsJSON(req, map[string]string{"value": string(value), "contentType": "application/yaml"}); err != nil {
+		return fmt.Errorf("failed to marshal secret: %w", err)
+	}
+	resp, err := s.pipeline.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if !runtime.HasStatusCode(resp, http.StatusOK) {
+		return runtime.NewResponseError(resp)
+	}
+	return nil
+}
+
+// vaultStore publishes kubeconfigs as secrets of a HashiCorp Vault KV version 2 secrets engine, in their kubeconfig
+// key.
+type vaultStore struct {
+	address    string
+	mountPath  string
+	namespace  string
+	token      string
+	httpClient *http.Client
+}
+
+func (s *vaultStore) PutSecret(ctx context.Context, name string, value []byte) error {
+	body, err := json.Marshal(map[string]interface{}{"data": map[string]string{"kubeconfig": string(value)}})
+	if err != nil {
+		return fmt.Errorf("failed to marshal secret: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/v1/%s/data/%s", s.address, s.mountPath, strings.Trim(name, "/")), bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Vault-Token", s.token)
+	if s.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", s.namespace)
+	}
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to write vault secret: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var vaultErr struct {
+			Errors []string `json:"errors"`
+		}
+		respBody, _ := io.ReadAll(resp.Body)
+		if json.Unmarshal(respBody, &vaultErr) == nil && len(vaultErr.Errors) > 0 {
+			return fmt.Errorf("vault returned %s: %s", resp.Status, strings.Join(vaultErr.Errors, "; "))
+		}
+		return fmt.Errorf("vault returned %s", resp.Status)
+	}
+	return nil
+}
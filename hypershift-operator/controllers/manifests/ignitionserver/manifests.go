@@ -70,6 +70,28 @@ func ProxyDeployment(namespace string) *appsv1.Deployment {
 	}
 }
 
+// PayloadStorageSecret is the copy, in the control plane namespace, of the Secret configuring the object storage
+// the ignition server publishes payloads to.
+func PayloadStorageSecret(namespace string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      ResourceName + "-payload-storage",
+		},
+	}
+}
+
+// AttestationConfigMap is the copy, in the control plane namespace, of the ConfigMap configuring the platform
+// attestation the ignition server requires from instances.
+func AttestationConfigMap(namespace string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      ResourceName + "-attestation",
+		},
+	}
+}
+
 func IgnitionCACertSecret(namespace string) *corev1.Secret {
 	return &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
@@ -51,6 +51,46 @@ func MachineConfigAPIServerHAProxy() *mcfgv1.MachineConfig {
 	}
 }
 
+func MachineConfigChrony() *mcfgv1.MachineConfig {
+	return &mcfgv1.MachineConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "50-chrony-configuration",
+		},
+	}
+}
+
+func MachineConfigLayeredOSImage() *mcfgv1.MachineConfig {
+	return &mcfgv1.MachineConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "50-layered-os-image",
+		},
+	}
+}
+
+func MachineConfigAdditionalTrustBundle() *mcfgv1.MachineConfig {
+	return &mcfgv1.MachineConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "50-additional-trust-bundle",
+		},
+	}
+}
+
+func MachineConfigCgroupMode() *mcfgv1.MachineConfig {
+	return &mcfgv1.MachineConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "50-cgroup-mode",
+		},
+	}
+}
+
+func ContainerRuntimeConfigNodePool() *mcfgv1.ContainerRuntimeConfig {
+	return &mcfgv1.ContainerRuntimeConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "nodepool-container-runtime",
+		},
+	}
+}
+
 func OperatorDeployment(ns string) *appsv1.Deployment {
 	return &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
@@ -103,3 +103,30 @@ func VirtLauncherNetworkPolicy(namespace string) *networkingv1.NetworkPolicy {
 		},
 	}
 }
+
+func StrictIsolationNetworkPolicy(namespace string) *networkingv1.NetworkPolicy {
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      "strict-isolation",
+		},
+	}
+}
+
+func StrictIsolationManagementKASNetworkPolicy(namespace string) *networkingv1.NetworkPolicy {
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      "strict-isolation-management-kas",
+		},
+	}
+}
+
+func CustomAllowlistNetworkPolicy(namespace string) *networkingv1.NetworkPolicy {
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      "custom-allowlist",
+		},
+	}
+}
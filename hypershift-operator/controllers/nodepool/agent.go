@@ -1,8 +1,36 @@
 package nodepool
 
 import (
+	"context"
+	"fmt"
+	"time"
+
 	agentv1 "github.com/openshift/cluster-api-provider-agent/api/v1beta1"
 	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	capiv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// agentBoundConditionType and the reasons below are set on Agents by the assisted-service.
+	agentBoundConditionType                = "Bound"
+	agentUnbindingReason                   = "Unbinding"
+	agentUnbindingPendingUserActionReason  = "UnbindingPendingUserAction"
+	agentBareMetalHostLabel                = "agent-install.openshift.io/bmh"
+	bareMetalHostRebootAnnotation          = "reboot.metal3.io"
+	agentReprovisioningRequestedAnnotation = "hypershift.openshift.io/reprovisioning-requested"
+
+	agentReprovisioningRequeueInterval = 30 * time.Second
+)
+
+var (
+	agentGVK         = schema.GroupVersionKind{Group: "agent-install.openshift.io", Version: "v1beta1", Kind: "Agent"}
+	bareMetalHostGVK = schema.GroupVersionKind{Group: "metal3.io", Version: "v1alpha1", Kind: "BareMetalHost"}
 )
 
 func agentMachineTemplateSpec(nodePool *hyperv1.NodePool) *agentv1.AgentMachineTemplateSpec {
@@ -16,3 +44,129 @@ func agentMachineTemplateSpec(nodePool *hyperv1.NodePool) *agentv1.AgentMachineT
 		},
 	}
 }
+
+func agentAutomaticHostReprovisioning(nodePool *hyperv1.NodePool) bool {
+	return nodePool.Spec.Platform.Type == hyperv1.AgentPlatform &&
+		nodePool.Spec.Platform.Agent != nil &&
+		nodePool.Spec.Platform.Agent.HostReprovisioning == hyperv1.AgentHostReprovisioningAutomatic
+}
+
+// agentReprovisioningRollingUpdate replaces Machines one at a time without surging, so the host released by a
+// Machine can be reinstalled for its replacement when there are no spare hosts.
+func agentReprovisioningRollingUpdate(rollingUpdate *capiv1.MachineRollingUpdateDeployment) *capiv1.MachineRollingUpdateDeployment {
+	maxSurge := intstr.FromInt(0)
+	maxUnavailable := intstr.FromInt(1)
+	if rollingUpdate != nil && rollingUpdate.MaxUnavailable != nil {
+		if value, err := intstr.GetScaledValueFromIntOrPercent(rollingUpdate.MaxUnavailable, 1, true); err == nil && value > 0 {
+			maxUnavailable = *rollingUpdate.MaxUnavailable
+		}
+	}
+	return &capiv1.MachineRollingUpdateDeployment{
+		MaxSurge:       &maxSurge,
+		MaxUnavailable: &maxUnavailable,
+	}
+}
+
+// reconcileAgentHostReprovisioning reboots the released hosts that the NodePool could select back into the discovery
+// image, so they are reinstalled for new Machines. Only hosts managed by a BareMetalHost can be rebooted. It returns
+// a requeue interval while hosts are being released.
+func (r *NodePoolReconciler) reconcileAgentHostReprovisioning(ctx context.Context, hcluster *hyperv1.HostedCluster, nodePool *hyperv1.NodePool) (time.Duration, error) {
+	log := ctrl.LoggerFrom(ctx)
+	if !agentAutomaticHostReprovisioning(nodePool) || hcluster.Spec.Platform.Agent == nil {
+		return 0, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(nodePool.Spec.Platform.Agent.AgentLabelSelector)
+	if err != nil {
+		return 0, fmt.Errorf("invalid agent label selector: %w", err)
+	}
+	agents := &unstructured.UnstructuredList{}
+	agents.SetGroupVersionKind(agentGVK.GroupVersion().WithKind(agentGVK.Kind + "List"))
+	if err := r.List(ctx, agents, client.InNamespace(hcluster.Spec.Platform.Agent.AgentNamespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return 0, fmt.Errorf("failed to list agents: %w", err)
+	}
+
+	var requeueAfter time.Duration
+	for i := range agents.Items {
+		agent := &agents.Items[i]
+		switch agentBoundReason(agent) {
+		case agentUnbindingReason:
+			requeueAfter = agentReprovisioningRequeueInterval
+		case agentUnbindingPendingUserActionReason:
+			if _, requested := agent.GetAnnotations()[agentReprovisioningRequestedAnnotation]; requested {
+				continue
+			}
+			bareMetalHostName := agent.GetLabels()[agentBareMetalHostLabel]
+			if bareMetalHostName == "" {
+				log.Info("Released agent is not managed by a BareMetalHost, it must be booted into the discovery image by hand", "agent", agent.GetName())
+				continue
+			}
+			if err := r.rebootBareMetalHost(ctx, agent.GetNamespace(), bareMetalHostName); err != nil {
+				return 0, err
+			}
+			if err := r.setAgentReprovisioningRequested(ctx, agent, true); err != nil {
+				return 0, err
+			}
+			log.Info("Rebooting released agent into the discovery image", "agent", agent.GetName(), "bareMetalHost", bareMetalHostName)
+		default:
+			// The agent was reprovisioned, allow rebooting it again the next time it is released
+			if _, requested := agent.GetAnnotations()[agentReprovisioningRequestedAnnotation]; requested {
+				if err := r.setAgentReprovisioningRequested(ctx, agent, false); err != nil {
+					return 0, err
+				}
+			}
+		}
+	}
+	return requeueAfter, nil
+}
+
+func (r *NodePoolReconciler) rebootBareMetalHost(ctx context.Context, namespace, name string) error {
+	bareMetalHost := &unstructured.Unstructured{}
+	bareMetalHost.SetGroupVersionKind(bareMetalHostGVK)
+	if err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, bareMetalHost); err != nil {
+		return fmt.Errorf("failed to get BareMetalHost %s/%s: %w", namespace, name, err)
+	}
+	original := bareMetalHost.DeepCopy()
+	annotations := bareMetalHost.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[bareMetalHostRebootAnnotation] = ""
+	bareMetalHost.SetAnnotations(annotations)
+	if err := r.Patch(ctx, bareMetalHost, client.MergeFrom(original)); err != nil {
+		return fmt.Errorf("failed to reboot BareMetalHost %s/%s: %w", namespace, name, err)
+	}
+	return nil
+}
+
+func (r *NodePoolReconciler) setAgentReprovisioningRequested(ctx context.Context, agent *unstructured.Unstructured, requested bool) error {
+	original := agent.DeepCopy()
+	annotations := agent.GetAnnotations()
+	if requested {
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[agentReprovisioningRequestedAnnotation] = time.Now().UTC().Format(time.RFC3339)
+	} else {
+		delete(annotations, agentReprovisioningRequestedAnnotation)
+	}
+	agent.SetAnnotations(annotations)
+	if err := r.Patch(ctx, agent, client.MergeFrom(original)); err != nil {
+		return fmt.Errorf("failed to annotate agent %s/%s: %w", agent.GetNamespace(), agent.GetName(), err)
+	}
+	return nil
+}
+
+// agentBoundReason returns the reason of the Bound condition of an Agent.
+func agentBoundReason(agent *unstructured.Unstructured) string {
+	conditions, _, _ := unstructured.NestedSlice(agent.Object, "status", "conditions")
+	for _, condition := range conditions {
+		condition, ok := condition.(map[string]interface{})
+		if !ok || condition["type"] != agentBoundConditionType {
+			continue
+		}
+		reason, _ := condition["reason"].(string)
+		return reason
+	}
+	return ""
+}
@@ -0,0 +1,174 @@
+package nodepool
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/support/api"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	capiv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestAgentReprovisioningRollingUpdate(t *testing.T) {
+	intOrStr := func(value intstr.IntOrString) *intstr.IntOrString { return &value }
+	testCases := []struct {
+		name                   string
+		rollingUpdate          *capiv1.MachineRollingUpdateDeployment
+		expectedMaxUnavailable intstr.IntOrString
+	}{
+		{
+			name:                   "When no rolling update is set it should replace one machine at a time",
+			expectedMaxUnavailable: intstr.FromInt(1),
+		},
+		{
+			name: "When surging is allowed it should disable surging",
+			rollingUpdate: &capiv1.MachineRollingUpdateDeployment{
+				MaxSurge:       intOrStr(intstr.FromInt(2)),
+				MaxUnavailable: intOrStr(intstr.FromInt(0)),
+			},
+			expectedMaxUnavailable: intstr.FromInt(1),
+		},
+		{
+			name: "When more machines may be unavailable it should keep max unavailable",
+			rollingUpdate: &capiv1.MachineRollingUpdateDeployment{
+				MaxSurge:       intOrStr(intstr.FromInt(1)),
+				MaxUnavailable: intOrStr(intstr.FromString("50%")),
+			},
+			expectedMaxUnavailable: intstr.FromString("50%"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			rollingUpdate := agentReprovisioningRollingUpdate(tc.rollingUpdate)
+			g.Expect(*rollingUpdate.MaxSurge).To(Equal(intstr.FromInt(0)))
+			g.Expect(*rollingUpdate.MaxUnavailable).To(Equal(tc.expectedMaxUnavailable))
+		})
+	}
+}
+
+func TestReconcileAgentHostReprovisioning(t *testing.T) {
+	const agentNamespace = "agents"
+
+	agent := func(name, boundReason string, labels, annotations map[string]string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(agentGVK)
+		u.SetNamespace(agentNamespace)
+		u.SetName(name)
+		u.SetLabels(labels)
+		u.SetAnnotations(annotations)
+		if boundReason != "" {
+			_ = unstructured.SetNestedSlice(u.Object, []interface{}{
+				map[string]interface{}{"type": agentBoundConditionType, "status": "False", "reason": boundReason},
+			}, "status", "conditions")
+		}
+		return u
+	}
+	bareMetalHost := func(name string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(bareMetalHostGVK)
+		u.SetNamespace(agentNamespace)
+		u.SetName(name)
+		return u
+	}
+	hostedCluster := &hyperv1.HostedCluster{
+		Spec: hyperv1.HostedClusterSpec{
+			Platform: hyperv1.PlatformSpec{
+				Type:  hyperv1.AgentPlatform,
+				Agent: &hyperv1.AgentPlatformSpec{AgentNamespace: agentNamespace},
+			},
+		},
+	}
+	nodePool := func(reprovisioning hyperv1.AgentHostReprovisioning) *hyperv1.NodePool {
+		return &hyperv1.NodePool{
+			Spec: hyperv1.NodePoolSpec{
+				Platform: hyperv1.NodePoolPlatform{
+					Type: hyperv1.AgentPlatform,
+					Agent: &hyperv1.AgentNodePoolPlatform{
+						AgentLabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "workers"}},
+						HostReprovisioning: reprovisioning,
+					},
+				},
+			},
+		}
+	}
+	selected := map[string]string{"pool": "workers", agentBareMetalHostLabel: "host"}
+
+	testCases := []struct {
+		name                 string
+		reprovisioning       hyperv1.AgentHostReprovisioning
+		objects              []client.Object
+		expectedRequeueAfter time.Duration
+		expectReboot         bool
+		expectRequested      bool
+	}{
+		{
+			name:           "When host reprovisioning is manual it should not reboot released hosts",
+			reprovisioning: hyperv1.AgentHostReprovisioningManual,
+			objects:        []client.Object{agent("agent", agentUnbindingPendingUserActionReason, selected, nil), bareMetalHost("host")},
+		},
+		{
+			name:            "When a selected agent is released it should reboot its host into discovery",
+			reprovisioning:  hyperv1.AgentHostReprovisioningAutomatic,
+			objects:         []client.Object{agent("agent", agentUnbindingPendingUserActionReason, selected, nil), bareMetalHost("host")},
+			expectReboot:    true,
+			expectRequested: true,
+		},
+		{
+			name:           "When a released agent was already rebooted it should not reboot its host again",
+			reprovisioning: hyperv1.AgentHostReprovisioningAutomatic,
+			objects: []client.Object{
+				agent("agent", agentUnbindingPendingUserActionReason, selected, map[string]string{agentReprovisioningRequestedAnnotation: "2024-01-01T00:00:00Z"}),
+				bareMetalHost("host"),
+			},
+			expectRequested: true,
+		},
+		{
+			name:           "When a released agent is not selected by the NodePool it should not reboot its host",
+			reprovisioning: hyperv1.AgentHostReprovisioningAutomatic,
+			objects:        []client.Object{agent("agent", agentUnbindingPendingUserActionReason, map[string]string{agentBareMetalHostLabel: "host"}, nil), bareMetalHost("host")},
+		},
+		{
+			name:                 "When a selected agent is being released it should requeue",
+			reprovisioning:       hyperv1.AgentHostReprovisioningAutomatic,
+			objects:              []client.Object{agent("agent", agentUnbindingReason, selected, nil), bareMetalHost("host")},
+			expectedRequeueAfter: agentReprovisioningRequeueInterval,
+		},
+		{
+			name:           "When a rebooted agent is bound again it should clear the reprovisioning request",
+			reprovisioning: hyperv1.AgentHostReprovisioningAutomatic,
+			objects: []client.Object{
+				agent("agent", "Bound", selected, map[string]string{agentReprovisioningRequestedAnnotation: "2024-01-01T00:00:00Z"}),
+				bareMetalHost("host"),
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			c := fake.NewClientBuilder().WithScheme(api.Scheme).WithObjects(tc.objects...).Build()
+			r := &NodePoolReconciler{Client: c}
+
+			requeueAfter, err := r.reconcileAgentHostReprovisioning(context.Background(), hostedCluster, nodePool(tc.reprovisioning))
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(requeueAfter).To(Equal(tc.expectedRequeueAfter))
+
+			host := bareMetalHost("host")
+			g.Expect(c.Get(context.Background(), client.ObjectKeyFromObject(host), host)).To(Succeed())
+			_, rebooted := host.GetAnnotations()[bareMetalHostRebootAnnotation]
+			g.Expect(rebooted).To(Equal(tc.expectReboot))
+
+			updatedAgent := agent("agent", "", nil, nil)
+			g.Expect(c.Get(context.Background(), client.ObjectKeyFromObject(updatedAgent), updatedAgent)).To(Succeed())
+			_, requested := updatedAgent.GetAnnotations()[agentReprovisioningRequestedAnnotation]
+			g.Expect(requested).To(Equal(tc.expectRequested))
+		})
+	}
+}
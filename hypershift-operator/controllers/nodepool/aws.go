@@ -1,11 +1,20 @@
 package nodepool
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/support/awsutil"
+	supportutil "github.com/openshift/hypershift/support/util"
+	corev1 "k8s.io/api/core/v1"
 	k8sutilspointer "k8s.io/utils/pointer"
 	capiaws "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
@@ -75,6 +84,15 @@ func awsMachineTemplateSpec(infraName, ami string, hostedCluster *hyperv1.Hosted
 			ID: &sgID,
 		})
 	}
+	if nodePool.Spec.Platform.AWS.DedicatedSecurityGroup != nil {
+		if nodePool.Status.Platform == nil || nodePool.Status.Platform.AWS == nil || nodePool.Status.Platform.AWS.DedicatedSecurityGroupID == "" {
+			return nil, &NotReadyError{fmt.Errorf("the dedicated security group for the NodePool has not been created")}
+		}
+		sgID := nodePool.Status.Platform.AWS.DedicatedSecurityGroupID
+		securityGroups = append(securityGroups, capiaws.AWSResourceReference{
+			ID: &sgID,
+		})
+	}
 
 	instanceProfile := fmt.Sprintf("%s-worker-profile", infraName)
 	if nodePool.Spec.Platform.AWS.InstanceProfile != "" {
@@ -129,3 +147,130 @@ func awsMachineTemplateSpec(infraName, ami string, hostedCluster *hyperv1.Hosted
 
 	return awsMachineTemplateSpec, nil
 }
+
+// resolveAMILookup returns the AMI the AMILookup of the NodePool resolves to in the region. The result is recorded in
+// the NodePool status and only resolved again once the lookup, release, architecture or region change, so images
+// published in between don't roll out the NodePool on their own.
+func (r *NodePoolReconciler) resolveAMILookup(ctx context.Context, nodePool *hyperv1.NodePool, region string) (string, error) {
+	lookup := nodePool.Spec.Platform.AWS.AMILookup
+	lookupHash := supportutil.HashSimple(struct {
+		Lookup  *hyperv1.AWSAMILookup
+		Release string
+		Arch    string
+		Region  string
+	}{lookup, nodePool.Spec.Release.Image, nodePool.Spec.Arch, region})
+
+	if status := nodePool.Status.Platform; status != nil && status.AWS != nil && status.AWS.AMI != "" && status.AWS.AMILookupHash == lookupHash {
+		return status.AWS.AMI, nil
+	}
+	if r.EC2ClientForRegion == nil {
+		return "", fmt.Errorf("the operator has no AWS credentials to look up AMIs with")
+	}
+	// NodePools sharing the lookup, release and architecture share its result.
+	key := cloudLookupKey{kind: cloudLookupKindAMI, region: region, credentials: operatorCredentials, query: lookupHash}
+	result, err := r.cloudLookups.get(key, amiLookupCacheTTL, func() (interface{}, error) {
+		return lookupAMI(ctx, r.EC2ClientForRegion(region), lookup, nodePool.Spec.Arch)
+	})
+	if err != nil {
+		return "", err
+	}
+	ami := result.(string)
+
+	awsStatus := nodePoolAWSStatus(nodePool)
+	awsStatus.AMI = ami
+	awsStatus.AMILookupHash = lookupHash
+	return ami, nil
+}
+
+// nodePoolAWSStatus returns the AWS platform status of the NodePool, initializing it if needed.
+func nodePoolAWSStatus(nodePool *hyperv1.NodePool) *hyperv1.AWSNodePoolStatus {
+	if nodePool.Status.Platform == nil {
+		nodePool.Status.Platform = &hyperv1.NodePoolPlatformStatus{}
+	}
+	if nodePool.Status.Platform.AWS == nil {
+		nodePool.Status.Platform.AWS = &hyperv1.AWSNodePoolStatus{}
+	}
+	return nodePool.Status.Platform.AWS
+}
+
+// reconcileDedicatedSecurityGroup mirrors the dedicated security group of the NodePool into a ConfigMap of the control
+// plane namespace, from which the control plane operator creates it with the credentials of the HostedCluster, and
+// records its id in the NodePool status once created. The ConfigMap is deleted once the dedicated security group is
+// unset, which makes the control plane operator delete the security group.
+func (r *NodePoolReconciler) reconcileDedicatedSecurityGroup(ctx context.Context, nodePool *hyperv1.NodePool, controlPlaneNamespace string) error {
+	configMap := SecurityGroupConfigMap(controlPlaneNamespace, nodePool.Name)
+	if nodePool.Spec.Platform.AWS.DedicatedSecurityGroup == nil {
+		if _, err := supportutil.DeleteIfNeeded(ctx, r.Client, configMap); err != nil {
+			return fmt.Errorf("failed to delete security group ConfigMap: %w", err)
+		}
+		if nodePool.Status.Platform != nil && nodePool.Status.Platform.AWS != nil {
+			nodePool.Status.Platform.AWS.DedicatedSecurityGroupID = ""
+		}
+		return nil
+	}
+
+	if _, err := r.CreateOrUpdate(ctx, r.Client, configMap, func() error {
+		return reconcileSecurityGroupConfigMap(configMap, nodePool)
+	}); err != nil {
+		return fmt.Errorf("failed to reconcile security group ConfigMap: %w", err)
+	}
+	nodePoolAWSStatus(nodePool).DedicatedSecurityGroupID = configMap.Annotations[awsutil.NodePoolSecurityGroupIDAnnotation]
+	return nil
+}
+
+func reconcileSecurityGroupConfigMap(configMap *corev1.ConfigMap, nodePool *hyperv1.NodePool) error {
+	rules, err := json.Marshal(nodePool.Spec.Platform.AWS.DedicatedSecurityGroup.IngressRules)
+	if err != nil {
+		return fmt.Errorf("failed to encode the ingress rules: %w", err)
+	}
+	if configMap.Annotations == nil {
+		configMap.Annotations = make(map[string]string)
+	}
+	if configMap.Labels == nil {
+		configMap.Labels = make(map[string]string)
+	}
+	configMap.Annotations[nodePoolAnnotation] = client.ObjectKeyFromObject(nodePool).String()
+	configMap.Labels[nodePoolAnnotation] = nodePool.GetName()
+	configMap.Labels[awsutil.NodePoolSecurityGroupConfigMapLabel] = "true"
+	configMap.Data = map[string]string{
+		awsutil.NodePoolSecurityGroupRulesKey: string(rules),
+	}
+	return nil
+}
+
+// lookupAMI returns the newest available AMI matching the lookup and architecture.
+func lookupAMI(ctx context.Context, ec2Client ec2iface.EC2API, lookup *hyperv1.AWSAMILookup, arch string) (string, error) {
+	ec2Arch := ec2.ArchitectureValuesX8664
+	if arch == hyperv1.ArchitectureARM64 {
+		ec2Arch = ec2.ArchitectureValuesArm64
+	}
+	filters := []*ec2.Filter{
+		{Name: aws.String("architecture"), Values: []*string{aws.String(ec2Arch)}},
+		{Name: aws.String("state"), Values: []*string{aws.String(ec2.ImageStateAvailable)}},
+	}
+	if lookup.Name != "" {
+		filters = append(filters, &ec2.Filter{Name: aws.String("name"), Values: []*string{aws.String(lookup.Name)}})
+	}
+	for _, tag := range lookup.Tags {
+		filters = append(filters, &ec2.Filter{Name: aws.String("tag:" + tag.Key), Values: []*string{aws.String(tag.Value)}})
+	}
+
+	output, err := ec2Client.DescribeImagesWithContext(ctx, &ec2.DescribeImagesInput{
+		Owners:  aws.StringSlice(lookup.Owners),
+		Filters: filters,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to look up AMIs: %w", err)
+	}
+	var newest *ec2.Image
+	for _, image := range output.Images {
+		// Creation dates are in ISO 8601 format, so they sort lexically.
+		if newest == nil || aws.StringValue(image.CreationDate) > aws.StringValue(newest.CreationDate) {
+			newest = image
+		}
+	}
+	if newest == nil {
+		return "", fmt.Errorf("no available %s AMI owned by %v matches the lookup", ec2Arch, lookup.Owners)
+	}
+	return aws.StringValue(newest.ImageId), nil
+}
@@ -1,14 +1,26 @@
 package nodepool
 
 import (
+	"context"
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/google/go-cmp/cmp"
+	. "github.com/onsi/gomega"
 	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/support/api"
+	"github.com/openshift/hypershift/support/awsutil"
+	"github.com/openshift/hypershift/support/upsert"
 	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sutilspointer "k8s.io/utils/pointer"
 	capiaws "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 const amiName = "ami"
@@ -33,6 +45,7 @@ func TestAWSMachineTemplate(t *testing.T) {
 		clusterStatus       *hyperv1.HostedClusterStatus
 		nodePool            hyperv1.NodePoolSpec
 		nodePoolAnnotations map[string]string
+		nodePoolStatus      hyperv1.NodePoolStatus
 		expected            *capiaws.AWSMachineTemplate
 		checkError          func(*testing.T, error)
 	}{
@@ -127,6 +140,28 @@ func TestAWSMachineTemplate(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "NodePool dedicated sg is used in addition to cluster default",
+			nodePool: hyperv1.NodePoolSpec{Platform: hyperv1.NodePoolPlatform{AWS: &hyperv1.AWSNodePoolPlatform{
+				DedicatedSecurityGroup: &hyperv1.AWSNodePoolSecurityGroup{},
+			}}},
+			nodePoolStatus: hyperv1.NodePoolStatus{Platform: &hyperv1.NodePoolPlatformStatus{AWS: &hyperv1.AWSNodePoolStatus{DedicatedSecurityGroupID: "nodepool-dedicated"}}},
+			expected: defaultAWSMachineTemplate(func(tmpl *capiaws.AWSMachineTemplate) {
+				tmpl.Spec.Template.Spec.AdditionalSecurityGroups = []capiaws.AWSResourceReference{{ID: defaultSG[0].ID}, {ID: k8sutilspointer.String("nodepool-dedicated")}}
+			}),
+		},
+		{
+			name: "NotReady error is returned if the NodePool dedicated sg is not created yet",
+			nodePool: hyperv1.NodePoolSpec{Platform: hyperv1.NodePoolPlatform{AWS: &hyperv1.AWSNodePoolPlatform{
+				DedicatedSecurityGroup: &hyperv1.AWSNodePoolSecurityGroup{},
+			}}},
+			checkError: func(t *testing.T, err error) {
+				_, isNotReady := err.(*NotReadyError)
+				if err == nil || !isNotReady {
+					t.Errorf("did not get expected NotReady error")
+				}
+			},
+		},
 		{
 			name:     "NodePool has ec2-http-tokens annotation with 'required' as a value",
 			nodePool: hyperv1.NodePoolSpec{Platform: hyperv1.NodePoolPlatform{AWS: &hyperv1.AWSNodePoolPlatform{}}},
@@ -156,7 +191,8 @@ func TestAWSMachineTemplate(t *testing.T) {
 					ObjectMeta: metav1.ObjectMeta{
 						Annotations: tc.nodePoolAnnotations,
 					},
-					Spec: tc.nodePool,
+					Spec:   tc.nodePool,
+					Status: tc.nodePoolStatus,
 				},
 				true)
 			if tc.checkError != nil {
@@ -223,3 +259,166 @@ func defaultAWSMachineTemplate(modify ...func(*capiaws.AWSMachineTemplate)) *cap
 
 	return template
 }
+
+type fakeImagesEC2Client struct {
+	ec2iface.EC2API
+	images []*ec2.Image
+	input  *ec2.DescribeImagesInput
+	calls  int
+}
+
+func (c *fakeImagesEC2Client) DescribeImagesWithContext(_ aws.Context, input *ec2.DescribeImagesInput, _ ...request.Option) (*ec2.DescribeImagesOutput, error) {
+	c.calls++
+	c.input = input
+	return &ec2.DescribeImagesOutput{Images: c.images}, nil
+}
+
+func TestResolveAMILookup(t *testing.T) {
+	lookup := &hyperv1.AWSAMILookup{
+		Owners: []string{"123456789012"},
+		Name:   "rhcos-*",
+		Tags:   []hyperv1.AWSResourceTag{{Key: "team", Value: "nodes"}},
+	}
+	images := []*ec2.Image{
+		{ImageId: aws.String("ami-old"), CreationDate: aws.String("2024-01-01T00:00:00.000Z")},
+		{ImageId: aws.String("ami-new"), CreationDate: aws.String("2024-06-01T00:00:00.000Z")},
+	}
+	nodePool := func(release string) *hyperv1.NodePool {
+		return &hyperv1.NodePool{
+			Spec: hyperv1.NodePoolSpec{
+				Arch:    hyperv1.ArchitectureARM64,
+				Release: hyperv1.Release{Image: release},
+				Platform: hyperv1.NodePoolPlatform{
+					Type: hyperv1.AWSPlatform,
+					AWS:  &hyperv1.AWSNodePoolPlatform{AMILookup: lookup},
+				},
+			},
+		}
+	}
+
+	t.Run("When the lookup was not resolved it should resolve the newest matching AMI", func(t *testing.T) {
+		g := NewWithT(t)
+		ec2Client := &fakeImagesEC2Client{images: images}
+		r := &NodePoolReconciler{EC2ClientForRegion: func(region string) ec2iface.EC2API {
+			g.Expect(region).To(Equal("us-east-1"))
+			return ec2Client
+		}}
+		np := nodePool("release:4.16")
+
+		ami, err := r.resolveAMILookup(context.Background(), np, "us-east-1")
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(ami).To(Equal("ami-new"))
+		g.Expect(np.Status.Platform.AWS.AMI).To(Equal("ami-new"))
+		g.Expect(aws.StringValueSlice(ec2Client.input.Owners)).To(Equal(lookup.Owners))
+		filters := map[string][]string{}
+		for _, filter := range ec2Client.input.Filters {
+			filters[aws.StringValue(filter.Name)] = aws.StringValueSlice(filter.Values)
+		}
+		g.Expect(filters).To(Equal(map[string][]string{
+			"architecture": {"arm64"},
+			"state":        {"available"},
+			"name":         {"rhcos-*"},
+			"tag:team":     {"nodes"},
+		}))
+	})
+
+	t.Run("When the lookup was resolved for the release it should not resolve it again", func(t *testing.T) {
+		g := NewWithT(t)
+		ec2Client := &fakeImagesEC2Client{images: images}
+		r := &NodePoolReconciler{EC2ClientForRegion: func(string) ec2iface.EC2API { return ec2Client }}
+		np := nodePool("release:4.16")
+		_, err := r.resolveAMILookup(context.Background(), np, "us-east-1")
+		g.Expect(err).ToNot(HaveOccurred())
+
+		ec2Client.images = []*ec2.Image{{ImageId: aws.String("ami-newer"), CreationDate: aws.String("2024-07-01T00:00:00.000Z")}}
+		ami, err := r.resolveAMILookup(context.Background(), np, "us-east-1")
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(ami).To(Equal("ami-new"))
+		g.Expect(ec2Client.calls).To(Equal(1))
+
+		np.Spec.Release.Image = "release:4.17"
+		ami, err = r.resolveAMILookup(context.Background(), np, "us-east-1")
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(ami).To(Equal("ami-newer"))
+		g.Expect(ec2Client.calls).To(Equal(2))
+	})
+
+	t.Run("When no AMI matches the lookup it should fail", func(t *testing.T) {
+		g := NewWithT(t)
+		r := &NodePoolReconciler{EC2ClientForRegion: func(string) ec2iface.EC2API { return &fakeImagesEC2Client{} }}
+		_, err := r.resolveAMILookup(context.Background(), nodePool("release:4.16"), "us-east-1")
+		g.Expect(err).To(HaveOccurred())
+	})
+
+	t.Run("When the operator has no AWS credentials it should fail", func(t *testing.T) {
+		g := NewWithT(t)
+		r := &NodePoolReconciler{}
+		_, err := r.resolveAMILookup(context.Background(), nodePool("release:4.16"), "us-east-1")
+		g.Expect(err).To(HaveOccurred())
+	})
+}
+
+func TestReconcileDedicatedSecurityGroup(t *testing.T) {
+	const controlPlaneNamespace = "clusters-test"
+	nodePool := func(sg *hyperv1.AWSNodePoolSecurityGroup) *hyperv1.NodePool {
+		return &hyperv1.NodePool{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "db"},
+			Spec: hyperv1.NodePoolSpec{
+				Platform: hyperv1.NodePoolPlatform{
+					Type: hyperv1.AWSPlatform,
+					AWS:  &hyperv1.AWSNodePoolPlatform{DedicatedSecurityGroup: sg},
+				},
+			},
+		}
+	}
+	sg := &hyperv1.AWSNodePoolSecurityGroup{
+		IngressRules: []hyperv1.AWSSecurityGroupIngressRule{{
+			Protocol:   hyperv1.AWSSecurityGroupProtocolTCP,
+			FromPort:   aws.Int32(5432),
+			ToPort:     aws.Int32(5432),
+			CIDRBlocks: []string{"10.0.0.0/16"},
+		}},
+	}
+
+	t.Run("When the NodePool has a dedicated security group it should mirror its rules into a ConfigMap", func(t *testing.T) {
+		g := NewWithT(t)
+		c := fake.NewClientBuilder().WithScheme(api.Scheme).Build()
+		r := &NodePoolReconciler{Client: c, CreateOrUpdateProvider: upsert.New(false)}
+		np := nodePool(sg)
+
+		g.Expect(r.reconcileDedicatedSecurityGroup(context.Background(), np, controlPlaneNamespace)).To(Succeed())
+
+		configMap := SecurityGroupConfigMap(controlPlaneNamespace, np.Name)
+		g.Expect(c.Get(context.Background(), client.ObjectKeyFromObject(configMap), configMap)).To(Succeed())
+		g.Expect(configMap.Labels).To(HaveKeyWithValue(awsutil.NodePoolSecurityGroupConfigMapLabel, "true"))
+		g.Expect(configMap.Labels).To(HaveKeyWithValue(hyperv1.NodePoolLabel, "db"))
+		g.Expect(configMap.Data[awsutil.NodePoolSecurityGroupRulesKey]).To(MatchJSON(`[{"protocol":"tcp","fromPort":5432,"toPort":5432,"cidrBlocks":["10.0.0.0/16"]}]`))
+		g.Expect(np.Status.Platform.AWS.DedicatedSecurityGroupID).To(BeEmpty())
+	})
+
+	t.Run("When the security group was created it should record its id in the NodePool status", func(t *testing.T) {
+		g := NewWithT(t)
+		configMap := SecurityGroupConfigMap(controlPlaneNamespace, "db")
+		configMap.Annotations = map[string]string{awsutil.NodePoolSecurityGroupIDAnnotation: "sg-123"}
+		c := fake.NewClientBuilder().WithScheme(api.Scheme).WithObjects(configMap).Build()
+		r := &NodePoolReconciler{Client: c, CreateOrUpdateProvider: upsert.New(false)}
+		np := nodePool(sg)
+
+		g.Expect(r.reconcileDedicatedSecurityGroup(context.Background(), np, controlPlaneNamespace)).To(Succeed())
+		g.Expect(np.Status.Platform.AWS.DedicatedSecurityGroupID).To(Equal("sg-123"))
+	})
+
+	t.Run("When the dedicated security group is unset it should delete the ConfigMap", func(t *testing.T) {
+		g := NewWithT(t)
+		configMap := SecurityGroupConfigMap(controlPlaneNamespace, "db")
+		c := fake.NewClientBuilder().WithScheme(api.Scheme).WithObjects(configMap).Build()
+		r := &NodePoolReconciler{Client: c, CreateOrUpdateProvider: upsert.New(false)}
+		np := nodePool(nil)
+		np.Status.Platform = &hyperv1.NodePoolPlatformStatus{AWS: &hyperv1.AWSNodePoolStatus{DedicatedSecurityGroupID: "sg-123"}}
+
+		g.Expect(r.reconcileDedicatedSecurityGroup(context.Background(), np, controlPlaneNamespace)).To(Succeed())
+		err := c.Get(context.Background(), client.ObjectKeyFromObject(configMap), configMap)
+		g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		g.Expect(np.Status.Platform.AWS.DedicatedSecurityGroupID).To(BeEmpty())
+	})
+}
@@ -1,13 +1,21 @@
 package nodepool
 
 import (
+	"context"
 	"crypto/rand"
 	"crypto/rsa"
 	"encoding/base64"
 	"fmt"
+	"strings"
+
 	"github.com/openshift/hypershift/support/azureutil"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute/v5"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
 
@@ -43,7 +51,8 @@ func azureMachineTemplateSpec(hcluster *hyperv1.HostedCluster, nodePool *hyperv1
 			},
 		},
 		NetworkInterfaces: []capiazure.NetworkInterface{{
-			SubnetName: subnetName,
+			SubnetName:            subnetName,
+			AcceleratedNetworking: acceleratedNetworking(nodePool),
 		}},
 		Identity:               capiazure.VMIdentityUserAssigned,
 		UserAssignedIdentities: []capiazure.UserAssignedIdentity{{ProviderID: hcluster.Spec.Platform.Azure.MachineIdentityID}},
@@ -60,6 +69,26 @@ func azureMachineTemplateSpec(hcluster *hyperv1.HostedCluster, nodePool *hyperv1
 		}
 	}
 
+	if securityProfile := nodePool.Spec.Platform.Azure.SecurityProfile; securityProfile != nil {
+		if azureMachineTemplate.Template.Spec.SecurityProfile == nil {
+			azureMachineTemplate.Template.Spec.SecurityProfile = &capiazure.SecurityProfile{}
+		}
+		azureMachineTemplate.Template.Spec.SecurityProfile.SecurityType = capiazure.SecurityTypes(securityProfile.SecurityType)
+		azureMachineTemplate.Template.Spec.SecurityProfile.UefiSettings = &capiazure.UefiSettings{
+			SecureBootEnabled: utilpointer.Bool(utilpointer.BoolDeref(securityProfile.SecureBoot, true)),
+			VTpmEnabled:       utilpointer.Bool(utilpointer.BoolDeref(securityProfile.VTPM, true)),
+		}
+		if securityProfile.SecurityType == hyperv1.AzureVMSecurityTypeConfidentialVM {
+			encryption := securityProfile.ConfidentialDiskEncryption
+			if encryption == "" {
+				encryption = hyperv1.AzureConfidentialDiskEncryptionVMGuestStateOnly
+			}
+			azureMachineTemplate.Template.Spec.OSDisk.ManagedDisk.SecurityProfile = &capiazure.VMDiskSecurityProfile{
+				SecurityEncryptionType: capiazure.SecurityEncryptionType(encryption),
+			}
+		}
+	}
+
 	if nodePool.Spec.Platform.Azure.EnableEphemeralOSDisk {
 		// This is set to "None" if not explicitly set - https://github.com/kubernetes-sigs/cluster-api-provider-azure/blob/f44d953844de58e4b6fe8f51d88b0bf75a04e9ec/api/v1beta1/azuremachine_default.go#L54
 		// "VMs and VM Scale Set Instances using an ephemeral OS disk support only Readonly caching."
@@ -91,9 +120,148 @@ func bootImage(hcluster *hyperv1.HostedCluster, nodepool *hyperv1.NodePool) stri
 	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Compute/images/rhcos.x86_64.vhd", hcluster.Spec.Platform.Azure.SubscriptionID, hcluster.Spec.Platform.Azure.ResourceGroupName)
 }
 
+// acceleratedNetworking returns whether accelerated networking is enabled on the network interface of the VMs. It is
+// nil unless enabled by the NodePool, which lets the cluster-api provider enable it whenever the VM size supports it.
+func acceleratedNetworking(nodepool *hyperv1.NodePool) *bool {
+	if !nodepool.Spec.Platform.Azure.EnableAcceleratedNetworking {
+		return nil
+	}
+	return utilpointer.Bool(true)
+}
+
 func failureDomain(nodepool *hyperv1.NodePool) *string {
 	if nodepool.Spec.Platform.Azure.AvailabilityZone == "" {
 		return nil
 	}
 	return utilpointer.String(nodepool.Spec.Platform.Azure.AvailabilityZone)
 }
+
+// reconcileAzureNodePoolStatus records the availability set the VMs of the NodePool are placed in, which they only are
+// when the NodePool has no availability zone.
+func reconcileAzureNodePoolStatus(nodePool *hyperv1.NodePool, hcluster *hyperv1.HostedCluster) {
+	availabilitySetName := ""
+	if nodePool.Spec.Platform.Azure != nil && nodePool.Spec.Platform.Azure.AvailabilityZone == "" {
+		availabilitySetName = azureutil.AvailabilitySetName(hcluster.Spec.InfraID, nodePool.Name)
+	}
+	if availabilitySetName == "" && (nodePool.Status.Platform == nil || nodePool.Status.Platform.Azure == nil) {
+		return
+	}
+	if nodePool.Status.Platform == nil {
+		nodePool.Status.Platform = &hyperv1.NodePoolPlatformStatus{}
+	}
+	if nodePool.Status.Platform.Azure == nil {
+		nodePool.Status.Platform.Azure = &hyperv1.AzureNodePoolStatus{}
+	}
+	nodePool.Status.Platform.Azure.AvailabilitySetName = availabilitySetName
+}
+
+const (
+	// azureAcceleratedNetworkingCapability is the capability of the VM sizes which support accelerated networking.
+	azureAcceleratedNetworkingCapability = "AcceleratedNetworkingEnabled"
+	// azureHyperVGenerationsCapability lists the VM generations a VM size supports, e.g. "V1,V2".
+	azureHyperVGenerationsCapability = "HyperVGenerations"
+	// azureTrustedLaunchDisabledCapability is the capability of the VM sizes which don't support trusted launch.
+	azureTrustedLaunchDisabledCapability = "TrustedLaunchDisabled"
+	// azureConfidentialComputingTypeCapability is the confidential computing technology of confidential VM sizes.
+	azureConfidentialComputingTypeCapability = "ConfidentialComputingType"
+	// cloudLookupKindAzureVMSize is the kind of the lookups of the capabilities of an Azure VM size.
+	cloudLookupKindAzureVMSize = "azure-vm-size"
+)
+
+// validateAzureVMSize validates that the VM size of the NodePool supports the options the NodePool enables, so that
+// the VMs don't fail to be created.
+func (r *NodePoolReconciler) validateAzureVMSize(ctx context.Context, nodePool *hyperv1.NodePool, hcluster *hyperv1.HostedCluster) error {
+	platform := nodePool.Spec.Platform.Azure
+	if platform == nil || hcluster.Spec.Platform.Azure == nil {
+		return nil
+	}
+	// The default boot image is a Generation 1 managed image, which can't boot trusted launch or confidential VMs.
+	if platform.SecurityProfile != nil && platform.ImageID == "" && nodePool.Spec.BootImageUpdatePolicy != hyperv1.BootImageUpdatePolicyAutomatic {
+		return fmt.Errorf("security type %s requires a Generation 2 image: set the imageID of the NodePool or its bootImageUpdatePolicy to %s",
+			platform.SecurityProfile.SecurityType, hyperv1.BootImageUpdatePolicyAutomatic)
+	}
+	if !platform.EnableAcceleratedNetworking && platform.SecurityProfile == nil {
+		return nil
+	}
+
+	lookup := r.lookupAzureVMSize
+	if lookup == nil {
+		lookup = r.armVMSizeCapabilities
+	}
+	location := hcluster.Spec.Platform.Azure.Location
+	key := cloudLookupKey{
+		kind:        cloudLookupKindAzureVMSize,
+		region:      location,
+		credentials: hcluster.Spec.Platform.Azure.SubscriptionID,
+		query:       platform.VMSize,
+	}
+	value, err := r.cloudLookups.get(key, instanceTypeCacheTTL, func() (interface{}, error) {
+		return lookup(ctx, hcluster, platform.VMSize)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to look up the capabilities of VM size %s: %w", platform.VMSize, err)
+	}
+	capabilities := value.(map[string]string)
+
+	if platform.EnableAcceleratedNetworking && !strings.EqualFold(capabilities[azureAcceleratedNetworkingCapability], "True") {
+		return fmt.Errorf("VM size %s doesn't support accelerated networking in location %s", platform.VMSize, location)
+	}
+	if platform.SecurityProfile == nil {
+		return nil
+	}
+	if !sets.New(strings.Split(capabilities[azureHyperVGenerationsCapability], ",")...).Has("V2") {
+		return fmt.Errorf("VM size %s doesn't support Generation 2 VMs in location %s, which security type %s requires", platform.VMSize, location, platform.SecurityProfile.SecurityType)
+	}
+	switch platform.SecurityProfile.SecurityType {
+	case hyperv1.AzureVMSecurityTypeTrustedLaunch:
+		if strings.EqualFold(capabilities[azureTrustedLaunchDisabledCapability], "True") {
+			return fmt.Errorf("VM size %s doesn't support trusted launch in location %s", platform.VMSize, location)
+		}
+	case hyperv1.AzureVMSecurityTypeConfidentialVM:
+		if capabilities[azureConfidentialComputingTypeCapability] == "" {
+			return fmt.Errorf("VM size %s isn't a confidential VM size in location %s", platform.VMSize, location)
+		}
+	}
+	return nil
+}
+
+// armVMSizeCapabilities looks up the capabilities of the VM size in the location of the HostedCluster with the Azure
+// credentials of the HostedCluster.
+func (r *NodePoolReconciler) armVMSizeCapabilities(ctx context.Context, hcluster *hyperv1.HostedCluster, vmSize string) (map[string]string, error) {
+	azure := hcluster.Spec.Platform.Azure
+	credentialsSecret := &corev1.Secret{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: hcluster.Namespace, Name: azure.Credentials.Name}, credentialsSecret); err != nil {
+		return nil, fmt.Errorf("failed to get azure credentials secret: %w", err)
+	}
+	credentials, err := azidentity.NewClientSecretCredential(
+		string(credentialsSecret.Data["AZURE_TENANT_ID"]),
+		string(credentialsSecret.Data["AZURE_CLIENT_ID"]),
+		string(credentialsSecret.Data["AZURE_CLIENT_SECRET"]), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to obtain azure client credential: %w", err)
+	}
+	skusClient, err := armcompute.NewResourceSKUsClient(azure.SubscriptionID, credentials, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create resource SKUs client: %w", err)
+	}
+	pager := skusClient.NewListPager(&armcompute.ResourceSKUsClientListOptions{Filter: to.Ptr(fmt.Sprintf("location eq '%s'", azure.Location))})
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list resource SKUs: %w", err)
+		}
+		for _, sku := range page.Value {
+			if sku == nil || utilpointer.StringDeref(sku.ResourceType, "") != "virtualMachines" || !strings.EqualFold(utilpointer.StringDeref(sku.Name, ""), vmSize) {
+				continue
+			}
+			capabilities := map[string]string{}
+			for _, capability := range sku.Capabilities {
+				if capability != nil && capability.Name != nil {
+					capabilities[*capability.Name] = utilpointer.StringDeref(capability.Value, "")
+				}
+			}
+			return capabilities, nil
+		}
+	}
+	return nil, fmt.Errorf("VM size %s is not available in location %s", vmSize, azure.Location)
+}
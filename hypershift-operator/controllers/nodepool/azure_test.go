@@ -1,9 +1,15 @@
 package nodepool
 
 import (
+	"context"
+	"fmt"
 	"testing"
 
+	. "github.com/onsi/gomega"
 	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilpointer "k8s.io/utils/pointer"
+	capiazure "sigs.k8s.io/cluster-api-provider-azure/api/v1beta1"
 )
 
 func TestBootImage(t *testing.T) {
@@ -40,3 +46,256 @@ func TestBootImage(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcileAzureNodePoolStatus(t *testing.T) {
+	hcluster := &hyperv1.HostedCluster{Spec: hyperv1.HostedClusterSpec{InfraID: "example-abcde"}}
+
+	testCases := []struct {
+		name     string
+		zone     string
+		status   *hyperv1.NodePoolPlatformStatus
+		expected *hyperv1.NodePoolPlatformStatus
+	}{
+		{
+			name: "When the NodePool has no availability zone it should report its availability set",
+			expected: &hyperv1.NodePoolPlatformStatus{Azure: &hyperv1.AzureNodePoolStatus{
+				AvailabilitySetName: "example-abcde_workers-as",
+			}},
+		},
+		{
+			name: "When the NodePool has an availability zone it should not report an availability set",
+			zone: "1",
+		},
+		{
+			name: "When the NodePool is moved to an availability zone it should clear its availability set",
+			zone: "1",
+			status: &hyperv1.NodePoolPlatformStatus{Azure: &hyperv1.AzureNodePoolStatus{
+				AvailabilitySetName: "example-abcde_workers-as",
+			}},
+			expected: &hyperv1.NodePoolPlatformStatus{Azure: &hyperv1.AzureNodePoolStatus{}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			nodePool := &hyperv1.NodePool{
+				ObjectMeta: metav1.ObjectMeta{Name: "workers"},
+				Spec: hyperv1.NodePoolSpec{Platform: hyperv1.NodePoolPlatform{
+					Type:  hyperv1.AzurePlatform,
+					Azure: &hyperv1.AzureNodePoolPlatform{AvailabilityZone: tc.zone},
+				}},
+				Status: hyperv1.NodePoolStatus{Platform: tc.status},
+			}
+			reconcileAzureNodePoolStatus(nodePool, hcluster)
+			g.Expect(nodePool.Status.Platform).To(Equal(tc.expected))
+		})
+	}
+}
+
+func TestValidateAzureVMSize(t *testing.T) {
+	hcluster := &hyperv1.HostedCluster{Spec: hyperv1.HostedClusterSpec{Platform: hyperv1.PlatformSpec{Azure: &hyperv1.AzurePlatformSpec{
+		Location:       "eastus",
+		SubscriptionID: "123-123",
+	}}}}
+	capabilities := map[string]map[string]string{
+		"Standard_D4s_v3":   {azureAcceleratedNetworkingCapability: "True", azureHyperVGenerationsCapability: "V1,V2"},
+		"Standard_A2_v2":    {azureAcceleratedNetworkingCapability: "False", azureHyperVGenerationsCapability: "V1"},
+		"Standard_DC4as_v5": {azureHyperVGenerationsCapability: "V2", azureTrustedLaunchDisabledCapability: "True", azureConfidentialComputingTypeCapability: "SNP"},
+	}
+	imageID := "/subscriptions/123-123/resourceGroups/rg/providers/Microsoft.Compute/galleries/g/images/rhcos-gen2/versions/1.0.0"
+
+	testCases := []struct {
+		name                  string
+		vmSize                string
+		acceleratedNetworking bool
+		securityType          hyperv1.AzureVMSecurityType
+		imageID               string
+		expectedErr           string
+		expectedLookups       int
+	}{
+		{
+			name:   "When accelerated networking isn't enabled it should not look up the VM size",
+			vmSize: "Standard_A2_v2",
+		},
+		{
+			name:                  "When the VM size supports accelerated networking it should succeed",
+			vmSize:                "Standard_D4s_v3",
+			acceleratedNetworking: true,
+			expectedLookups:       1,
+		},
+		{
+			name:                  "When the VM size doesn't support accelerated networking it should fail",
+			vmSize:                "Standard_A2_v2",
+			acceleratedNetworking: true,
+			expectedErr:           "VM size Standard_A2_v2 doesn't support accelerated networking in location eastus",
+			expectedLookups:       1,
+		},
+		{
+			name:                  "When the VM size isn't available it should fail",
+			vmSize:                "Standard_Unknown",
+			acceleratedNetworking: true,
+			expectedErr:           "failed to look up the capabilities of VM size Standard_Unknown",
+			expectedLookups:       2,
+		},
+		{
+			name:            "When the VM size supports trusted launch it should succeed",
+			vmSize:          "Standard_D4s_v3",
+			securityType:    hyperv1.AzureVMSecurityTypeTrustedLaunch,
+			imageID:         imageID,
+			expectedLookups: 1,
+		},
+		{
+			name:            "When the VM size doesn't support Generation 2 VMs it should fail",
+			vmSize:          "Standard_A2_v2",
+			securityType:    hyperv1.AzureVMSecurityTypeTrustedLaunch,
+			imageID:         imageID,
+			expectedErr:     "VM size Standard_A2_v2 doesn't support Generation 2 VMs in location eastus",
+			expectedLookups: 1,
+		},
+		{
+			name:            "When the VM size doesn't support trusted launch it should fail",
+			vmSize:          "Standard_DC4as_v5",
+			securityType:    hyperv1.AzureVMSecurityTypeTrustedLaunch,
+			imageID:         imageID,
+			expectedErr:     "VM size Standard_DC4as_v5 doesn't support trusted launch in location eastus",
+			expectedLookups: 1,
+		},
+		{
+			name:            "When the VM size is a confidential VM size it should succeed",
+			vmSize:          "Standard_DC4as_v5",
+			securityType:    hyperv1.AzureVMSecurityTypeConfidentialVM,
+			imageID:         imageID,
+			expectedLookups: 1,
+		},
+		{
+			name:            "When the VM size isn't a confidential VM size it should fail",
+			vmSize:          "Standard_D4s_v3",
+			securityType:    hyperv1.AzureVMSecurityTypeConfidentialVM,
+			imageID:         imageID,
+			expectedErr:     "VM size Standard_D4s_v3 isn't a confidential VM size in location eastus",
+			expectedLookups: 1,
+		},
+		{
+			name:         "When a security type is set without a Generation 2 image it should fail",
+			vmSize:       "Standard_D4s_v3",
+			securityType: hyperv1.AzureVMSecurityTypeTrustedLaunch,
+			expectedErr:  "security type TrustedLaunch requires a Generation 2 image",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			lookups := 0
+			r := &NodePoolReconciler{
+				cloudLookups: newCloudLookupCache(),
+				lookupAzureVMSize: func(_ context.Context, _ *hyperv1.HostedCluster, vmSize string) (map[string]string, error) {
+					lookups++
+					if c, ok := capabilities[vmSize]; ok {
+						return c, nil
+					}
+					return nil, fmt.Errorf("VM size %s is not available", vmSize)
+				},
+			}
+			nodePool := &hyperv1.NodePool{Spec: hyperv1.NodePoolSpec{Platform: hyperv1.NodePoolPlatform{
+				Type: hyperv1.AzurePlatform,
+				Azure: &hyperv1.AzureNodePoolPlatform{
+					VMSize:                      tc.vmSize,
+					ImageID:                     tc.imageID,
+					EnableAcceleratedNetworking: tc.acceleratedNetworking,
+				},
+			}}}
+			if tc.securityType != "" {
+				nodePool.Spec.Platform.Azure.SecurityProfile = &hyperv1.AzureVMSecurityProfile{SecurityType: tc.securityType}
+			}
+
+			// Validate twice to check that successful lookups are cached and failed ones aren't.
+			for i := 0; i < 2; i++ {
+				err := r.validateAzureVMSize(context.Background(), nodePool, hcluster)
+				if tc.expectedErr != "" {
+					g.Expect(err).To(MatchError(ContainSubstring(tc.expectedErr)))
+				} else {
+					g.Expect(err).ToNot(HaveOccurred())
+				}
+			}
+			g.Expect(lookups).To(Equal(tc.expectedLookups))
+
+			if tc.acceleratedNetworking {
+				g.Expect(acceleratedNetworking(nodePool)).To(Equal(utilpointer.Bool(true)))
+			} else {
+				g.Expect(acceleratedNetworking(nodePool)).To(BeNil())
+			}
+		})
+	}
+}
+
+func TestAzureMachineTemplateSpecSecurityProfile(t *testing.T) {
+	hcluster := &hyperv1.HostedCluster{Spec: hyperv1.HostedClusterSpec{Platform: hyperv1.PlatformSpec{Azure: &hyperv1.AzurePlatformSpec{
+		SubscriptionID:    "123-123",
+		ResourceGroupName: "rg-name",
+	}}}}
+
+	testCases := []struct {
+		name                   string
+		securityProfile        *hyperv1.AzureVMSecurityProfile
+		expected               *capiazure.SecurityProfile
+		expectedDiskEncryption capiazure.SecurityEncryptionType
+	}{
+		{
+			name: "When no security profile is set it should not set one",
+		},
+		{
+			name:            "When trusted launch is set it should enable secure boot and the vTPM by default",
+			securityProfile: &hyperv1.AzureVMSecurityProfile{SecurityType: hyperv1.AzureVMSecurityTypeTrustedLaunch},
+			expected: &capiazure.SecurityProfile{
+				SecurityType: capiazure.SecurityTypesTrustedLaunch,
+				UefiSettings: &capiazure.UefiSettings{SecureBootEnabled: utilpointer.Bool(true), VTpmEnabled: utilpointer.Bool(true)},
+			},
+		},
+		{
+			name: "When a confidential VM is set it should encrypt the VM guest state by default",
+			securityProfile: &hyperv1.AzureVMSecurityProfile{
+				SecurityType: hyperv1.AzureVMSecurityTypeConfidentialVM,
+				SecureBoot:   utilpointer.Bool(false),
+			},
+			expected: &capiazure.SecurityProfile{
+				SecurityType: capiazure.SecurityTypesConfidentialVM,
+				UefiSettings: &capiazure.UefiSettings{SecureBootEnabled: utilpointer.Bool(false), VTpmEnabled: utilpointer.Bool(true)},
+			},
+			expectedDiskEncryption: capiazure.SecurityEncryptionTypeVMGuestStateOnly,
+		},
+		{
+			name: "When a confidential VM encrypts its OS disk it should set the disk encryption",
+			securityProfile: &hyperv1.AzureVMSecurityProfile{
+				SecurityType:               hyperv1.AzureVMSecurityTypeConfidentialVM,
+				ConfidentialDiskEncryption: hyperv1.AzureConfidentialDiskEncryptionDiskWithVMGuestState,
+			},
+			expected: &capiazure.SecurityProfile{
+				SecurityType: capiazure.SecurityTypesConfidentialVM,
+				UefiSettings: &capiazure.UefiSettings{SecureBootEnabled: utilpointer.Bool(true), VTpmEnabled: utilpointer.Bool(true)},
+			},
+			expectedDiskEncryption: capiazure.SecurityEncryptionTypeDiskWithVMGuestState,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			nodePool := &hyperv1.NodePool{Spec: hyperv1.NodePoolSpec{Platform: hyperv1.NodePoolPlatform{
+				Type: hyperv1.AzurePlatform,
+				Azure: &hyperv1.AzureNodePoolPlatform{
+					VMSize:          "Standard_DC4as_v5",
+					SubnetID:        "/subscriptions/123-123/resourceGroups/rg-name/providers/Microsoft.Network/virtualNetworks/vnet/subnets/default",
+					SecurityProfile: tc.securityProfile,
+				},
+			}}}
+
+			spec, err := azureMachineTemplateSpec(hcluster, nodePool, capiazure.AzureMachineTemplateSpec{})
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(spec.Template.Spec.SecurityProfile).To(Equal(tc.expected))
+			if tc.expectedDiskEncryption == "" {
+				g.Expect(spec.Template.Spec.OSDisk.ManagedDisk.SecurityProfile).To(BeNil())
+			} else {
+				g.Expect(spec.Template.Spec.OSDisk.ManagedDisk.SecurityProfile).To(Equal(&capiazure.VMDiskSecurityProfile{SecurityEncryptionType: tc.expectedDiskEncryption}))
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package nodepool
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	configv1 "github.com/openshift/api/config/v1"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/support/certs"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// getBootstrapTrustBundle returns the contents of the NodePool bootstrap
+// additionalTrustBundle, or an empty string when it doesn't set one.
+func (r *NodePoolReconciler) getBootstrapTrustBundle(ctx context.Context, nodePool *hyperv1.NodePool) (string, error) {
+	if nodePool.Spec.Bootstrap == nil || nodePool.Spec.Bootstrap.AdditionalTrustBundle == nil {
+		return "", nil
+	}
+	name := nodePool.Spec.Bootstrap.AdditionalTrustBundle.Name
+	configMap := &corev1.ConfigMap{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: nodePool.Namespace, Name: name}, configMap); err != nil {
+		return "", fmt.Errorf("failed to get bootstrap additional trust bundle configmap %s/%s: %w", nodePool.Namespace, name, err)
+	}
+	trustBundle, hasKey := configMap.Data[certs.UserCABundleMapKey]
+	if !hasKey {
+		return "", fmt.Errorf("bootstrap additional trust bundle configmap %s/%s must have a %s key", nodePool.Namespace, name, certs.UserCABundleMapKey)
+	}
+	return trustBundle, nil
+}
+
+// bootstrapProxy returns the proxy the machines of the NodePool fetch their
+// ignition payload through: the NodePool bootstrap proxy when it sets one,
+// the HostedCluster proxy otherwise.
+func bootstrapProxy(proxy *configv1.Proxy, nodePool *hyperv1.NodePool) configv1.ProxyStatus {
+	if nodePool.Spec.Bootstrap == nil || nodePool.Spec.Bootstrap.Proxy == nil {
+		return proxy.Status
+	}
+	return configv1.ProxyStatus{
+		HTTPProxy:  nodePool.Spec.Bootstrap.Proxy.HTTPProxy,
+		HTTPSProxy: nodePool.Spec.Bootstrap.Proxy.HTTPSProxy,
+		NoProxy:    strings.Join(nodePool.Spec.Bootstrap.Proxy.NoProxy, ","),
+	}
+}
+
+// referencesBootstrapTrustBundle returns true if the NodePool bootstrap
+// additionalTrustBundle is the given ConfigMap.
+func referencesBootstrapTrustBundle(nodePool *hyperv1.NodePool, name string) bool {
+	return nodePool.Spec.Bootstrap != nil && nodePool.Spec.Bootstrap.AdditionalTrustBundle != nil &&
+		nodePool.Spec.Bootstrap.AdditionalTrustBundle.Name == name
+}
@@ -0,0 +1,142 @@
+package nodepool
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	ignitionapi "github.com/coreos/ignition/v2/config/v3_2/types"
+	. "github.com/onsi/gomega"
+	configv1 "github.com/openshift/api/config/v1"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestGetBootstrapTrustBundle(t *testing.T) {
+	testCases := []struct {
+		name                string
+		bootstrap           *hyperv1.NodePoolBootstrap
+		configMap           *corev1.ConfigMap
+		expectedTrustBundle string
+		expectError         bool
+	}{
+		{
+			name: "When the NodePool has no bootstrap config it should return an empty bundle",
+		},
+		{
+			name:      "When the NodePool bootstrap config has no additional trust bundle it should return an empty bundle",
+			bootstrap: &hyperv1.NodePoolBootstrap{Proxy: &hyperv1.NodePoolBootstrapProxy{HTTPSProxy: "http://proxy.example.com:3128"}},
+		},
+		{
+			name:      "When the NodePool has a bootstrap additional trust bundle it should return its contents",
+			bootstrap: &hyperv1.NodePoolBootstrap{AdditionalTrustBundle: &corev1.LocalObjectReference{Name: "egress-ca"}},
+			configMap: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "egress-ca"},
+				Data:       map[string]string{"ca-bundle.crt": testTrustBundle},
+			},
+			expectedTrustBundle: testTrustBundle,
+		},
+		{
+			name:      "When the bootstrap additional trust bundle configmap has no ca-bundle.crt key it should fail",
+			bootstrap: &hyperv1.NodePoolBootstrap{AdditionalTrustBundle: &corev1.LocalObjectReference{Name: "egress-ca"}},
+			configMap: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "egress-ca"},
+				Data:       map[string]string{"ca.crt": testTrustBundle},
+			},
+			expectError: true,
+		},
+		{
+			name:        "When the bootstrap additional trust bundle configmap doesn't exist it should fail",
+			bootstrap:   &hyperv1.NodePoolBootstrap{AdditionalTrustBundle: &corev1.LocalObjectReference{Name: "egress-ca"}},
+			expectError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			builder := fake.NewClientBuilder()
+			if tc.configMap != nil {
+				builder = builder.WithObjects(tc.configMap)
+			}
+			r := &NodePoolReconciler{Client: builder.Build()}
+			nodePool := &hyperv1.NodePool{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "np"},
+				Spec:       hyperv1.NodePoolSpec{Bootstrap: tc.bootstrap},
+			}
+
+			trustBundle, err := r.getBootstrapTrustBundle(context.Background(), nodePool)
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(trustBundle).To(Equal(tc.expectedTrustBundle))
+		})
+	}
+}
+
+func TestIgnConfigBootstrap(t *testing.T) {
+	clusterProxy := &configv1.Proxy{
+		Status: configv1.ProxyStatus{
+			HTTPProxy:  "http://cluster-proxy.example.com:3128",
+			HTTPSProxy: "http://cluster-proxy.example.com:3128",
+			NoProxy:    ".cluster.local,10.0.0.0/16",
+		},
+	}
+	encodedCA := base64.StdEncoding.EncodeToString([]byte("ignition-server-ca"))
+
+	testCases := []struct {
+		name                 string
+		bootstrap            *hyperv1.NodePoolBootstrap
+		bootstrapTrustBundle string
+		expectedProxy        ignitionapi.Proxy
+		expectedCAs          []ignitionapi.Resource
+	}{
+		{
+			name: "When the NodePool has no bootstrap config it should fetch ignition through the HostedCluster proxy trusting the ignition server CA",
+			expectedProxy: ignitionapi.Proxy{
+				HTTPProxy:  ptr.To("http://cluster-proxy.example.com:3128"),
+				HTTPSProxy: ptr.To("http://cluster-proxy.example.com:3128"),
+				NoProxy:    []ignitionapi.NoProxyItem{".cluster.local", "10.0.0.0/16"},
+			},
+			expectedCAs: []ignitionapi.Resource{
+				{Source: ptr.To("data:text/plain;base64," + encodedCA)},
+			},
+		},
+		{
+			name: "When the NodePool has a bootstrap proxy and trust bundle it should fetch ignition through its proxy trusting its CAs too",
+			bootstrap: &hyperv1.NodePoolBootstrap{
+				Proxy: &hyperv1.NodePoolBootstrapProxy{
+					HTTPSProxy: "http://edge-proxy.example.com:8080",
+					NoProxy:    []string{"169.254.169.254", ".edge.local"},
+				},
+				AdditionalTrustBundle: &corev1.LocalObjectReference{Name: "egress-ca"},
+			},
+			bootstrapTrustBundle: testTrustBundle,
+			expectedProxy: ignitionapi.Proxy{
+				HTTPSProxy: ptr.To("http://edge-proxy.example.com:8080"),
+				NoProxy:    []ignitionapi.NoProxyItem{"169.254.169.254", ".edge.local"},
+			},
+			expectedCAs: []ignitionapi.Resource{
+				{Source: ptr.To("data:text/plain;base64," + encodedCA)},
+				{Source: ptr.To("data:text/plain;base64," + base64.StdEncoding.EncodeToString([]byte(testTrustBundle)))},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			nodePool := &hyperv1.NodePool{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "np"},
+				Spec:       hyperv1.NodePoolSpec{Bootstrap: tc.bootstrap},
+			}
+
+			cfg := ignConfig(encodedCA, "token", "ignition.example.com", "hash", clusterProxy, nodePool, tc.bootstrapTrustBundle)
+			g.Expect(cfg.Ignition.Proxy).To(Equal(tc.expectedProxy))
+			g.Expect(cfg.Ignition.Security.TLS.CertificateAuthorities).To(Equal(tc.expectedCAs))
+		})
+	}
+}
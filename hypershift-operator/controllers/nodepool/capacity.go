@@ -0,0 +1,163 @@
+package nodepool
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"gopkg.in/inf.v0"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// MachinePricesCurrencyKey is the key of the machine prices ConfigMap holding the ISO 4217 code of the currency of
+	// the prices.
+	MachinePricesCurrencyKey = "currency"
+
+	defaultMachinePricesCurrency = "USD"
+)
+
+// MachinePriceSource estimates the hourly cost of the machines of the NodePools.
+type MachinePriceSource interface {
+	// HourlyPrice returns the hourly price of a machine of the machine type in the region of the platform, and its
+	// currency. It returns false when it has no price for the machine type.
+	HourlyPrice(ctx context.Context, platform hyperv1.PlatformType, region, machineType string) (resource.Quantity, string, bool, error)
+}
+
+// ConfigMapMachinePriceSource reads the machine prices from a ConfigMap. Its keys are <platform>.<region>.<machine
+// type>, e.g. aws.us-east-1.m5.xlarge, and hold the hourly price of a machine as a decimal number. The currency key
+// holds the currency of the prices, USD by default.
+type ConfigMapMachinePriceSource struct {
+	Client    client.Client
+	Namespace string
+	Name      string
+}
+
+func (s *ConfigMapMachinePriceSource) HourlyPrice(ctx context.Context, platform hyperv1.PlatformType, region, machineType string) (resource.Quantity, string, bool, error) {
+	prices := &corev1.ConfigMap{}
+	if err := s.Client.Get(ctx, client.ObjectKey{Namespace: s.Namespace, Name: s.Name}, prices); err != nil {
+		if apierrors.IsNotFound(err) {
+			return resource.Quantity{}, "", false, nil
+		}
+		return resource.Quantity{}, "", false, fmt.Errorf("failed to get machine prices configmap: %w", err)
+	}
+	value, ok := prices.Data[strings.ToLower(string(platform))+"."+region+"."+machineType]
+	if !ok {
+		return resource.Quantity{}, "", false, nil
+	}
+	price, err := resource.ParseQuantity(strings.TrimSpace(value))
+	if err != nil {
+		return resource.Quantity{}, "", false, fmt.Errorf("invalid price %q for machine type %s in region %s: %w", value, machineType, region, err)
+	}
+	currency := prices.Data[MachinePricesCurrencyKey]
+	if currency == "" {
+		currency = defaultMachinePricesCurrency
+	}
+	return price, currency, true, nil
+}
+
+// machineTypeCapacity is the number of vCPUs and memory of a machine type.
+type machineTypeCapacity struct {
+	cpu    resource.Quantity
+	memory resource.Quantity
+}
+
+// ec2InstanceTypeCapacity resolves the capacity of the EC2 instance type, through the cloud lookup cache of the
+// reconciler.
+func (r *NodePoolReconciler) ec2InstanceTypeCapacity(ctx context.Context, instanceType string) (*machineTypeCapacity, error) {
+	// The instance types are described in the default region of the operator.
+	key := cloudLookupKey{kind: cloudLookupKindInstanceType, credentials: operatorCredentials, query: instanceType}
+	capacity, err := r.cloudLookups.get(key, instanceTypeCacheTTL, func() (interface{}, error) {
+		return describeInstanceTypeCapacity(ctx, r.EC2Client, instanceType)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return capacity.(*machineTypeCapacity), nil
+}
+
+func describeInstanceTypeCapacity(ctx context.Context, ec2Client ec2iface.EC2API, instanceType string) (*machineTypeCapacity, error) {
+	output, err := ec2Client.DescribeInstanceTypesWithContext(ctx, &ec2.DescribeInstanceTypesInput{InstanceTypes: []*string{aws.String(instanceType)}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe instance type %s: %w", instanceType, err)
+	}
+	if len(output.InstanceTypes) != 1 || output.InstanceTypes[0].VCpuInfo == nil || output.InstanceTypes[0].MemoryInfo == nil {
+		return nil, fmt.Errorf("unexpected description of instance type %s", instanceType)
+	}
+	info := output.InstanceTypes[0]
+	return &machineTypeCapacity{
+		cpu:    *resource.NewQuantity(aws.Int64Value(info.VCpuInfo.DefaultVCpus), resource.DecimalSI),
+		memory: *resource.NewQuantity(aws.Int64Value(info.MemoryInfo.SizeInMiB)*1024*1024, resource.BinarySI),
+	}, nil
+}
+
+// setCapacityStatus sets the aggregate capacity and estimated cost of the machines of the NodePool. Parts that can't
+// be resolved are left unset rather than failing the reconciliation.
+func (r *NodePoolReconciler) setCapacityStatus(ctx context.Context, nodePool *hyperv1.NodePool, hcluster *hyperv1.HostedCluster) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	machines, err := r.getMachinesForNodePool(ctx, nodePool)
+	if err != nil {
+		return err
+	}
+	status := &hyperv1.NodePoolCapacityStatus{Machines: int32(len(machines))}
+
+	var region string
+	var capacity *machineTypeCapacity
+	switch nodePool.Spec.Platform.Type {
+	case hyperv1.AWSPlatform:
+		if nodePool.Spec.Platform.AWS == nil || hcluster.Spec.Platform.AWS == nil {
+			break
+		}
+		status.MachineType = nodePool.Spec.Platform.AWS.InstanceType
+		region = hcluster.Spec.Platform.AWS.Region
+		if r.EC2Client != nil {
+			if capacity, err = r.ec2InstanceTypeCapacity(ctx, status.MachineType); err != nil {
+				log.Error(err, "failed to resolve the capacity of the NodePool machines")
+			}
+		}
+	case hyperv1.AzurePlatform:
+		if nodePool.Spec.Platform.Azure == nil || hcluster.Spec.Platform.Azure == nil {
+			break
+		}
+		status.MachineType = nodePool.Spec.Platform.Azure.VMSize
+		region = hcluster.Spec.Platform.Azure.Location
+	case hyperv1.KubevirtPlatform:
+		if kubevirt := nodePool.Spec.Platform.Kubevirt; kubevirt != nil && kubevirt.Compute != nil && kubevirt.Compute.Cores != nil && kubevirt.Compute.Memory != nil {
+			capacity = &machineTypeCapacity{
+				cpu:    *resource.NewQuantity(int64(*kubevirt.Compute.Cores), resource.DecimalSI),
+				memory: kubevirt.Compute.Memory.DeepCopy(),
+			}
+		}
+	}
+
+	if capacity != nil {
+		status.CPU = multiplyQuantity(capacity.cpu, status.Machines)
+		status.Memory = multiplyQuantity(capacity.memory, status.Machines)
+	}
+
+	if r.PriceSource != nil && status.MachineType != "" {
+		price, currency, found, err := r.PriceSource.HourlyPrice(ctx, nodePool.Spec.Platform.Type, region, status.MachineType)
+		if err != nil {
+			log.Error(err, "failed to estimate the cost of the NodePool machines")
+		} else if found {
+			status.EstimatedHourlyCost = new(inf.Dec).Mul(price.AsDec(), inf.NewDec(int64(status.Machines), 0)).String()
+			status.Currency = currency
+		}
+	}
+
+	nodePool.Status.Capacity = status
+	return nil
+}
+
+func multiplyQuantity(q resource.Quantity, n int32) *resource.Quantity {
+	return resource.NewQuantity(q.Value()*int64(n), q.Format)
+}
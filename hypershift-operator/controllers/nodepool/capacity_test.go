@@ -0,0 +1,227 @@
+package nodepool
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/google/go-cmp/cmp"
+	. "github.com/onsi/gomega"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	api "github.com/openshift/hypershift/support/api"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	capiv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type fakeInstanceTypesEC2Client struct {
+	ec2iface.EC2API
+	calls int
+}
+
+func (c *fakeInstanceTypesEC2Client) DescribeInstanceTypesWithContext(_ aws.Context, input *ec2.DescribeInstanceTypesInput, _ ...request.Option) (*ec2.DescribeInstanceTypesOutput, error) {
+	c.calls++
+	if aws.StringValue(input.InstanceTypes[0]) != "m5.xlarge" {
+		return nil, fmt.Errorf("unknown instance type %s", aws.StringValue(input.InstanceTypes[0]))
+	}
+	return &ec2.DescribeInstanceTypesOutput{
+		InstanceTypes: []*ec2.InstanceTypeInfo{{
+			InstanceType: input.InstanceTypes[0],
+			VCpuInfo:     &ec2.VCpuInfo{DefaultVCpus: aws.Int64(4)},
+			MemoryInfo:   &ec2.MemoryInfo{SizeInMiB: aws.Int64(16384)},
+		}},
+	}, nil
+}
+
+func TestSetCapacityStatus(t *testing.T) {
+	prices := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "hypershift", Name: "machine-prices"},
+		Data: map[string]string{
+			"aws.us-east-1.m5.xlarge":      "0.192",
+			"azure.eastus.Standard_D4s_v3": "0.192",
+			MachinePricesCurrencyKey:       "EUR",
+		},
+	}
+
+	testCases := []struct {
+		name             string
+		platform         hyperv1.NodePoolPlatform
+		hcPlatform       hyperv1.PlatformSpec
+		prices           *corev1.ConfigMap
+		expectedCapacity *hyperv1.NodePoolCapacityStatus
+	}{
+		{
+			name: "When the NodePool is on AWS it should report the capacity and cost of its instances",
+			platform: hyperv1.NodePoolPlatform{
+				Type: hyperv1.AWSPlatform,
+				AWS:  &hyperv1.AWSNodePoolPlatform{InstanceType: "m5.xlarge"},
+			},
+			hcPlatform: hyperv1.PlatformSpec{
+				Type: hyperv1.AWSPlatform,
+				AWS:  &hyperv1.AWSPlatformSpec{Region: "us-east-1"},
+			},
+			prices: prices,
+			expectedCapacity: &hyperv1.NodePoolCapacityStatus{
+				Machines:            3,
+				MachineType:         "m5.xlarge",
+				CPU:                 ptr.To(resource.MustParse("12")),
+				Memory:              ptr.To(resource.MustParse("48Gi")),
+				EstimatedHourlyCost: "0.576",
+				Currency:            "EUR",
+			},
+		},
+		{
+			name: "When the instance type can't be described it should report the cost of its instances only",
+			platform: hyperv1.NodePoolPlatform{
+				Type: hyperv1.AWSPlatform,
+				AWS:  &hyperv1.AWSNodePoolPlatform{InstanceType: "m5.unknown"},
+			},
+			hcPlatform: hyperv1.PlatformSpec{
+				Type: hyperv1.AWSPlatform,
+				AWS:  &hyperv1.AWSPlatformSpec{Region: "us-east-1"},
+			},
+			prices: prices,
+			expectedCapacity: &hyperv1.NodePoolCapacityStatus{
+				Machines:    3,
+				MachineType: "m5.unknown",
+			},
+		},
+		{
+			name: "When the region has no price it should report the capacity of its instances only",
+			platform: hyperv1.NodePoolPlatform{
+				Type: hyperv1.AWSPlatform,
+				AWS:  &hyperv1.AWSNodePoolPlatform{InstanceType: "m5.xlarge"},
+			},
+			hcPlatform: hyperv1.PlatformSpec{
+				Type: hyperv1.AWSPlatform,
+				AWS:  &hyperv1.AWSPlatformSpec{Region: "eu-west-1"},
+			},
+			prices: prices,
+			expectedCapacity: &hyperv1.NodePoolCapacityStatus{
+				Machines:    3,
+				MachineType: "m5.xlarge",
+				CPU:         ptr.To(resource.MustParse("12")),
+				Memory:      ptr.To(resource.MustParse("48Gi")),
+			},
+		},
+		{
+			name: "When the prices configmap doesn't exist it should report the capacity of its instances only",
+			platform: hyperv1.NodePoolPlatform{
+				Type: hyperv1.AWSPlatform,
+				AWS:  &hyperv1.AWSNodePoolPlatform{InstanceType: "m5.xlarge"},
+			},
+			hcPlatform: hyperv1.PlatformSpec{
+				Type: hyperv1.AWSPlatform,
+				AWS:  &hyperv1.AWSPlatformSpec{Region: "us-east-1"},
+			},
+			expectedCapacity: &hyperv1.NodePoolCapacityStatus{
+				Machines:    3,
+				MachineType: "m5.xlarge",
+				CPU:         ptr.To(resource.MustParse("12")),
+				Memory:      ptr.To(resource.MustParse("48Gi")),
+			},
+		},
+		{
+			name: "When the NodePool is on Azure it should report the cost of its VMs",
+			platform: hyperv1.NodePoolPlatform{
+				Type:  hyperv1.AzurePlatform,
+				Azure: &hyperv1.AzureNodePoolPlatform{VMSize: "Standard_D4s_v3"},
+			},
+			hcPlatform: hyperv1.PlatformSpec{
+				Type:  hyperv1.AzurePlatform,
+				Azure: &hyperv1.AzurePlatformSpec{Location: "eastus"},
+			},
+			prices: prices,
+			expectedCapacity: &hyperv1.NodePoolCapacityStatus{
+				Machines:            3,
+				MachineType:         "Standard_D4s_v3",
+				EstimatedHourlyCost: "0.576",
+				Currency:            "EUR",
+			},
+		},
+		{
+			name: "When the NodePool is on KubeVirt it should report the capacity of its VMs",
+			platform: hyperv1.NodePoolPlatform{
+				Type: hyperv1.KubevirtPlatform,
+				Kubevirt: &hyperv1.KubevirtNodePoolPlatform{
+					Compute: &hyperv1.KubevirtCompute{
+						Cores:  ptr.To[uint32](2),
+						Memory: ptr.To(resource.MustParse("8Gi")),
+					},
+				},
+			},
+			hcPlatform: hyperv1.PlatformSpec{Type: hyperv1.KubevirtPlatform},
+			prices:     prices,
+			expectedCapacity: &hyperv1.NodePoolCapacityStatus{
+				Machines: 3,
+				CPU:      ptr.To(resource.MustParse("6")),
+				Memory:   ptr.To(resource.MustParse("24Gi")),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			nodePool := &hyperv1.NodePool{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "np"},
+				Spec: hyperv1.NodePoolSpec{
+					ClusterName: "hc",
+					Platform:    tc.platform,
+				},
+			}
+			hcluster := &hyperv1.HostedCluster{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "hc"},
+				Spec:       hyperv1.HostedClusterSpec{Platform: tc.hcPlatform},
+			}
+
+			objects := []client.Object{
+				// A machine of another NodePool which must not be counted.
+				&capiv1.Machine{ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "clusters-hc",
+					Name:        "other",
+					Annotations: map[string]string{nodePoolAnnotation: "clusters/other"},
+				}},
+			}
+			for i := 0; i < 3; i++ {
+				objects = append(objects, &capiv1.Machine{ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "clusters-hc",
+					Name:        fmt.Sprintf("np-%d", i),
+					Annotations: map[string]string{nodePoolAnnotation: "clusters/np"},
+				}})
+			}
+			if tc.prices != nil {
+				objects = append(objects, tc.prices)
+			}
+			c := fake.NewClientBuilder().WithScheme(api.Scheme).WithObjects(objects...).Build()
+
+			ec2Client := &fakeInstanceTypesEC2Client{}
+			r := &NodePoolReconciler{
+				Client:       c,
+				EC2Client:    ec2Client,
+				PriceSource:  &ConfigMapMachinePriceSource{Client: c, Namespace: "hypershift", Name: "machine-prices"},
+				cloudLookups: newCloudLookupCache(),
+			}
+
+			g.Expect(r.setCapacityStatus(context.Background(), nodePool, hcluster)).To(Succeed())
+			g.Expect(nodePool.Status.Capacity).To(BeComparableTo(tc.expectedCapacity, cmp.Comparer(func(a, b resource.Quantity) bool {
+				return a.Cmp(b) == 0
+			})))
+
+			// The capacity of the instance types is only described once.
+			if tc.platform.Type == hyperv1.AWSPlatform && tc.expectedCapacity.CPU != nil {
+				g.Expect(r.setCapacityStatus(context.Background(), nodePool, hcluster)).To(Succeed())
+				g.Expect(ec2Client.calls).To(Equal(1))
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package nodepool
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	crmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	cloudLookupCacheRequestsMetricName = "hypershift_nodepools_cloud_lookup_cache_requests_total"
+
+	// cloudLookupKindAMI is the kind of the lookups of the AMI matching the AMI lookup of a NodePool.
+	cloudLookupKindAMI = "ami"
+	// cloudLookupKindInstanceType is the kind of the lookups of the capacity of an instance type.
+	cloudLookupKindInstanceType = "instance-type"
+	// cloudLookupKindOSImage is the kind of the lookups of the digest the tag of a layered OS image points to. Their
+	// TTL is osImageResolutionInterval.
+	cloudLookupKindOSImage = "os-image"
+
+	// amiLookupCacheTTL is how long a resolved AMI lookup is reused by the NodePools sharing it. New AMIs are
+	// published rarely, and NodePools only pick them up when their lookup or release changes anyway.
+	amiLookupCacheTTL = time.Hour
+	// instanceTypeCacheTTL is how long the capacity of an instance type is cached. It never changes in practice.
+	instanceTypeCacheTTL = 24 * time.Hour
+	// cloudLookupCacheJitter is the fraction of the TTL entries randomly live longer, so that the entries cached at
+	// the same time, e.g. on operator startup, aren't all refreshed in the same reconciliations.
+	cloudLookupCacheJitter = 0.2
+
+	// operatorCredentials identifies the credentials of the operator in the cache keys.
+	operatorCredentials = "operator"
+)
+
+var cloudLookupCacheRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: cloudLookupCacheRequestsMetricName,
+	Help: "Number of cloud provider lookups of the NodePool controller, by kind and whether they were served from the cache",
+}, []string{"kind", "result"})
+
+func init() {
+	crmetrics.Registry.MustRegister(cloudLookupCacheRequests)
+}
+
+// cloudLookupKey identifies a cloud provider lookup. The region and credentials are part of the key since the same
+// query can have a different result in another region or account.
+type cloudLookupKey struct {
+	kind        string
+	region      string
+	credentials string
+	query       string
+}
+
+type cloudLookupEntry struct {
+	value   interface{}
+	expires time.Time
+}
+
+// cloudLookupCache caches the results of cloud provider lookups whose data rarely changes, so they aren't repeated on
+// every reconciliation of every NodePool and don't get the operator throttled on large fleets. Failed lookups aren't
+// cached. A nil cache doesn't cache anything.
+type cloudLookupCache struct {
+	mu      sync.Mutex
+	entries map[cloudLookupKey]cloudLookupEntry
+	now     func() time.Time
+	jitter  func() float64
+}
+
+func newCloudLookupCache() *cloudLookupCache {
+	return &cloudLookupCache{
+		entries: map[cloudLookupKey]cloudLookupEntry{},
+		now:     time.Now,
+		jitter:  rand.Float64,
+	}
+}
+
+// get returns the cached result of the lookup, or the result of load if it isn't cached or has expired, which is
+// then cached for the TTL plus up to cloudLookupCacheJitter of it.
+func (c *cloudLookupCache) get(key cloudLookupKey, ttl time.Duration, load func() (interface{}, error)) (interface{}, error) {
+	if c == nil {
+		return load()
+	}
+
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok && c.now().Before(entry.expires) {
+		cloudLookupCacheRequests.WithLabelValues(key.kind, "hit").Inc()
+		return entry.value, nil
+	}
+	cloudLookupCacheRequests.WithLabelValues(key.kind, "miss").Inc()
+
+	value, err := load()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = cloudLookupEntry{
+		value:   value,
+		expires: c.now().Add(ttl + time.Duration(float64(ttl)*cloudLookupCacheJitter*c.jitter())),
+	}
+	// Expired entries are only replaced when looked up again, so drop the others to keep the cache from growing
+	// with the lookups of deleted NodePools.
+	for k, e := range c.entries {
+		if !c.now().Before(e.expires) {
+			delete(c.entries, k)
+		}
+	}
+	return value, nil
+}
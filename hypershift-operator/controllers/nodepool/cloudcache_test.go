@@ -0,0 +1,105 @@
+package nodepool
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestCloudLookupCache(t *testing.T) {
+	newCache := func(now *time.Time) *cloudLookupCache {
+		c := newCloudLookupCache()
+		c.now = func() time.Time { return *now }
+		c.jitter = func() float64 { return 0.5 }
+		return c
+	}
+	key := cloudLookupKey{kind: "test", region: "us-east-1", credentials: operatorCredentials, query: "query"}
+
+	t.Run("When a lookup is cached it should not be loaded again until it expires", func(t *testing.T) {
+		g := NewWithT(t)
+		now := time.Now()
+		c := newCache(&now)
+		loads := 0
+		load := func() (interface{}, error) {
+			loads++
+			return loads, nil
+		}
+		hits := testutil.ToFloat64(cloudLookupCacheRequests.WithLabelValues("test", "hit"))
+
+		value, err := c.get(key, time.Hour, load)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(value).To(Equal(1))
+
+		// The entry lives for the TTL plus the jittered fraction of it, 10% here.
+		now = now.Add(65 * time.Minute)
+		value, err = c.get(key, time.Hour, load)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(value).To(Equal(1))
+		g.Expect(testutil.ToFloat64(cloudLookupCacheRequests.WithLabelValues("test", "hit"))).To(Equal(hits + 1))
+
+		now = now.Add(5 * time.Minute)
+		value, err = c.get(key, time.Hour, load)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(value).To(Equal(2))
+	})
+
+	t.Run("When lookups are in another region it should not share their result", func(t *testing.T) {
+		g := NewWithT(t)
+		now := time.Now()
+		c := newCache(&now)
+		_, err := c.get(key, time.Hour, func() (interface{}, error) { return "us-east-1", nil })
+		g.Expect(err).ToNot(HaveOccurred())
+
+		otherRegion := key
+		otherRegion.region = "us-west-2"
+		value, err := c.get(otherRegion, time.Hour, func() (interface{}, error) { return "us-west-2", nil })
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(value).To(Equal("us-west-2"))
+	})
+
+	t.Run("When a lookup fails it should not be cached", func(t *testing.T) {
+		g := NewWithT(t)
+		now := time.Now()
+		c := newCache(&now)
+		_, err := c.get(key, time.Hour, func() (interface{}, error) { return nil, errors.New("throttled") })
+		g.Expect(err).To(HaveOccurred())
+
+		value, err := c.get(key, time.Hour, func() (interface{}, error) { return "value", nil })
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(value).To(Equal("value"))
+	})
+
+	t.Run("When entries expire it should drop them", func(t *testing.T) {
+		g := NewWithT(t)
+		now := time.Now()
+		c := newCache(&now)
+		_, err := c.get(key, time.Minute, func() (interface{}, error) { return "value", nil })
+		g.Expect(err).ToNot(HaveOccurred())
+
+		now = now.Add(time.Hour)
+		otherKey := key
+		otherKey.query = "other"
+		_, err = c.get(otherKey, time.Minute, func() (interface{}, error) { return "value", nil })
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(c.entries).To(HaveLen(1))
+		g.Expect(c.entries).To(HaveKey(otherKey))
+	})
+
+	t.Run("When the cache is nil it should always load", func(t *testing.T) {
+		g := NewWithT(t)
+		var c *cloudLookupCache
+		loads := 0
+		for i := 0; i < 2; i++ {
+			_, err := c.get(key, time.Hour, func() (interface{}, error) {
+				loads++
+				return "value", nil
+			})
+			g.Expect(err).ToNot(HaveOccurred())
+		}
+		g.Expect(loads).To(Equal(2))
+	})
+}
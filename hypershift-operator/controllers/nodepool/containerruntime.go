@@ -0,0 +1,98 @@
+package nodepool
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/clarketm/json"
+	ignitionapi "github.com/coreos/ignition/v2/config/v3_2/types"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/ignition"
+	"github.com/openshift/hypershift/hypershift-operator/controllers/manifests"
+	api "github.com/openshift/hypershift/support/api"
+	mcfgv1 "github.com/openshift/hypershift/thirdparty/machineconfigoperator/pkg/apis/machineconfiguration.openshift.io/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// cgroupModeKernelArguments are the kernel arguments selecting the cgroup
+// version of the nodes, as set by the MCO for the cgroupMode of the cluster
+// Node config.
+var cgroupModeKernelArguments = map[hyperv1.CgroupMode][]string{
+	hyperv1.CgroupModeV1: {"systemd.unified_cgroup_hierarchy=0", "systemd.legacy_systemd_cgroup_controller=1"},
+	hyperv1.CgroupModeV2: {"systemd.unified_cgroup_hierarchy=1", `cgroup_no_v1="all"`, "psi=1"},
+}
+
+// minLogSizeMax is the size of the read buffer of conmon, the smallest
+// positive log size limit CRI-O accepts.
+var minLogSizeMax = resource.MustParse("8Ki")
+
+// containerRuntimeConfig returns the serialized ContainerRuntimeConfig
+// rendering the NodePool container runtime options, or an empty string when
+// the NodePool doesn't set any.
+func containerRuntimeConfig(nodePool *hyperv1.NodePool) (string, error) {
+	containerRuntime := nodePool.Spec.ContainerRuntime
+	if containerRuntime == nil || (containerRuntime.DefaultRuntime == "" && containerRuntime.PidsLimit == nil && containerRuntime.LogSizeMax == nil) {
+		return "", nil
+	}
+
+	config := &mcfgv1.ContainerRuntimeConfiguration{
+		DefaultRuntime: mcfgv1.ContainerRuntimeDefaultRuntime(containerRuntime.DefaultRuntime),
+		PidsLimit:      containerRuntime.PidsLimit,
+	}
+	if containerRuntime.LogSizeMax != nil {
+		if containerRuntime.LogSizeMax.Sign() > 0 && containerRuntime.LogSizeMax.Cmp(minLogSizeMax) < 0 {
+			return "", fmt.Errorf("container runtime logSizeMax %s must be negative or at least %s", containerRuntime.LogSizeMax.String(), minLogSizeMax.String())
+		}
+		config.LogSizeMax = *containerRuntime.LogSizeMax
+	}
+
+	containerRuntimeConfig := manifests.ContainerRuntimeConfigNodePool()
+	containerRuntimeConfig.Spec.MachineConfigPoolSelector = &metav1.LabelSelector{
+		MatchLabels: map[string]string{
+			"machineconfiguration.openshift.io/mco-built-in": "",
+		},
+	}
+	containerRuntimeConfig.Spec.ContainerRuntimeConfig = config
+
+	buf := &bytes.Buffer{}
+	containerRuntimeConfig.APIVersion = mcfgv1.SchemeGroupVersion.String()
+	containerRuntimeConfig.Kind = "ContainerRuntimeConfig"
+	if err := api.YamlSerializer.Encode(containerRuntimeConfig, buf); err != nil {
+		return "", fmt.Errorf("failed to serialize container runtime config: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// cgroupModeMachineConfig returns the serialized MachineConfig setting the
+// kernel arguments of the NodePool cgroup mode, or an empty string when the
+// NodePool doesn't set it.
+func cgroupModeMachineConfig(nodePool *hyperv1.NodePool) (string, error) {
+	if nodePool.Spec.ContainerRuntime == nil || nodePool.Spec.ContainerRuntime.CgroupMode == "" {
+		return "", nil
+	}
+	kernelArguments, ok := cgroupModeKernelArguments[nodePool.Spec.ContainerRuntime.CgroupMode]
+	if !ok {
+		return "", fmt.Errorf("unsupported cgroup mode %q", nodePool.Spec.ContainerRuntime.CgroupMode)
+	}
+
+	config := &ignitionapi.Config{}
+	config.Ignition.Version = ignitionapi.MaxVersion.String()
+	serializedConfig, err := json.Marshal(config)
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize cgroup mode ignition config: %w", err)
+	}
+
+	machineConfig := manifests.MachineConfigCgroupMode()
+	ignition.SetMachineConfigLabels(machineConfig)
+	machineConfig.Spec.Config.Raw = serializedConfig
+	machineConfig.Spec.KernelArguments = kernelArguments
+
+	buf := &bytes.Buffer{}
+	machineConfig.APIVersion = mcfgv1.SchemeGroupVersion.String()
+	machineConfig.Kind = "MachineConfig"
+	if err := api.YamlSerializer.Encode(machineConfig, buf); err != nil {
+		return "", fmt.Errorf("failed to serialize cgroup mode machine config: %w", err)
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,120 @@
+package nodepool
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	api "github.com/openshift/hypershift/support/api"
+	mcfgv1 "github.com/openshift/hypershift/thirdparty/machineconfigoperator/pkg/apis/machineconfiguration.openshift.io/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+)
+
+func TestContainerRuntimeConfig(t *testing.T) {
+	testCases := []struct {
+		name             string
+		containerRuntime *hyperv1.NodePoolContainerRuntime
+		expectedConfig   *mcfgv1.ContainerRuntimeConfiguration
+		expectError      bool
+	}{
+		{
+			name: "When no container runtime options are set it should not render a ContainerRuntimeConfig",
+		},
+		{
+			name:             "When only the cgroup mode is set it should not render a ContainerRuntimeConfig",
+			containerRuntime: &hyperv1.NodePoolContainerRuntime{CgroupMode: hyperv1.CgroupModeV2},
+		},
+		{
+			name: "When container runtime options are set it should render them into a ContainerRuntimeConfig",
+			containerRuntime: &hyperv1.NodePoolContainerRuntime{
+				DefaultRuntime: hyperv1.ContainerRuntimeCrun,
+				PidsLimit:      ptr.To[int64](4096),
+				LogSizeMax:     ptr.To(resource.MustParse("50Mi")),
+			},
+			expectedConfig: &mcfgv1.ContainerRuntimeConfiguration{
+				DefaultRuntime: mcfgv1.ContainerRuntimeDefaultRuntimeCrun,
+				PidsLimit:      ptr.To[int64](4096),
+				LogSizeMax:     resource.MustParse("50Mi"),
+			},
+		},
+		{
+			name:             "When the log size max is negative it should render it",
+			containerRuntime: &hyperv1.NodePoolContainerRuntime{LogSizeMax: ptr.To(resource.MustParse("-1"))},
+			expectedConfig:   &mcfgv1.ContainerRuntimeConfiguration{LogSizeMax: resource.MustParse("-1")},
+		},
+		{
+			name:             "When the log size max is smaller than the conmon read buffer it should fail",
+			containerRuntime: &hyperv1.NodePoolContainerRuntime{LogSizeMax: ptr.To(resource.MustParse("1Ki"))},
+			expectError:      true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			nodePool := &hyperv1.NodePool{Spec: hyperv1.NodePoolSpec{ContainerRuntime: tc.containerRuntime}}
+
+			config, err := containerRuntimeConfig(nodePool)
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			if tc.expectedConfig == nil {
+				g.Expect(config).To(BeEmpty())
+				return
+			}
+
+			manifest, err := defaultAndValidateConfigManifest([]byte(config))
+			g.Expect(err).ToNot(HaveOccurred())
+			runtimeConfig := &mcfgv1.ContainerRuntimeConfig{}
+			_, _, err = api.YamlSerializer.Decode(manifest, nil, runtimeConfig)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(runtimeConfig.Spec.MachineConfigPoolSelector.MatchLabels).To(HaveKey("machineconfiguration.openshift.io/mco-built-in"))
+			g.Expect(runtimeConfig.Spec.ContainerRuntimeConfig.DefaultRuntime).To(Equal(tc.expectedConfig.DefaultRuntime))
+			g.Expect(runtimeConfig.Spec.ContainerRuntimeConfig.PidsLimit).To(Equal(tc.expectedConfig.PidsLimit))
+			g.Expect(runtimeConfig.Spec.ContainerRuntimeConfig.LogSizeMax.Cmp(tc.expectedConfig.LogSizeMax)).To(BeZero())
+		})
+	}
+}
+
+func TestCgroupModeMachineConfig(t *testing.T) {
+	testCases := []struct {
+		name                    string
+		containerRuntime        *hyperv1.NodePoolContainerRuntime
+		expectedKernelArguments []string
+	}{
+		{
+			name: "When no cgroup mode is set it should not render a MachineConfig",
+		},
+		{
+			name:                    "When the cgroup mode is v1 it should disable the unified cgroup hierarchy",
+			containerRuntime:        &hyperv1.NodePoolContainerRuntime{CgroupMode: hyperv1.CgroupModeV1},
+			expectedKernelArguments: []string{"systemd.unified_cgroup_hierarchy=0", "systemd.legacy_systemd_cgroup_controller=1"},
+		},
+		{
+			name:                    "When the cgroup mode is v2 it should enable the unified cgroup hierarchy",
+			containerRuntime:        &hyperv1.NodePoolContainerRuntime{CgroupMode: hyperv1.CgroupModeV2},
+			expectedKernelArguments: []string{"systemd.unified_cgroup_hierarchy=1", `cgroup_no_v1="all"`, "psi=1"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			nodePool := &hyperv1.NodePool{Spec: hyperv1.NodePoolSpec{ContainerRuntime: tc.containerRuntime}}
+
+			config, err := cgroupModeMachineConfig(nodePool)
+			g.Expect(err).ToNot(HaveOccurred())
+			if tc.expectedKernelArguments == nil {
+				g.Expect(config).To(BeEmpty())
+				return
+			}
+
+			machineConfig := &mcfgv1.MachineConfig{}
+			_, _, err = api.YamlSerializer.Decode([]byte(config), nil, machineConfig)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(machineConfig.Labels).To(HaveKeyWithValue("machineconfiguration.openshift.io/role", "worker"))
+			g.Expect(machineConfig.Spec.KernelArguments).To(Equal(tc.expectedKernelArguments))
+		})
+	}
+}
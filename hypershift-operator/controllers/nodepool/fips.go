@@ -0,0 +1,16 @@
+package nodepool
+
+import (
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/support/releaseinfo"
+)
+
+// validateFIPSConfiguration returns an error if the nodes of the NodePool can't run in FIPS mode with the given
+// release image.
+func validateFIPSConfiguration(nodePool *hyperv1.NodePool, releaseImage *releaseinfo.ReleaseImage) error {
+	arch, ok := hyperv1.ArchAliases[nodePool.Spec.Arch]
+	if !ok {
+		arch = nodePool.Spec.Arch
+	}
+	return releaseImage.ValidateFIPS(arch)
+}
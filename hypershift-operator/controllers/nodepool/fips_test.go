@@ -0,0 +1,56 @@
+package nodepool
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	imageapi "github.com/openshift/api/image/v1"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/support/releaseinfo"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidateFIPSConfiguration(t *testing.T) {
+	releaseImage := &releaseinfo.ReleaseImage{
+		ImageStream: &imageapi.ImageStream{ObjectMeta: metav1.ObjectMeta{Name: "4.16.0"}},
+		StreamMetadata: &releaseinfo.CoreOSStreamMetadata{
+			Architectures: map[string]releaseinfo.CoreOSArchitecture{
+				"x86_64":  {},
+				"aarch64": {},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name        string
+		arch        string
+		expectError bool
+	}{
+		{
+			name: "When the NodePool architecture supports FIPS mode it should be valid",
+			arch: hyperv1.ArchitectureAMD64,
+		},
+		{
+			name:        "When the NodePool architecture doesn't support FIPS mode it should fail",
+			arch:        hyperv1.ArchitectureARM64,
+			expectError: true,
+		},
+		{
+			name:        "When the release image has no bootimages for the NodePool architecture it should fail",
+			arch:        hyperv1.ArchitectureS390X,
+			expectError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			nodePool := &hyperv1.NodePool{Spec: hyperv1.NodePoolSpec{Arch: tc.arch}}
+			err := validateFIPSConfiguration(nodePool, releaseImage)
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+		})
+	}
+}
@@ -66,7 +66,7 @@ func (r *NodePoolReconciler) reconcileHAProxyIgnitionConfig(ctx context.Context,
 	var apiServerInternalAddress string
 
 	if util.IsPrivateHC(hcluster) {
-		apiServerExternalAddress = fmt.Sprintf("api.%s.hypershift.local", hcluster.Name)
+		apiServerExternalAddress = util.InternalAPIHostname(hcluster.Name, hcluster.Spec.DNS)
 		apiServerExternalPort = 443
 	} else {
 		if hcluster.Status.KubeConfig == nil {
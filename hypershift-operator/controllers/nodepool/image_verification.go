@@ -0,0 +1,42 @@
+package nodepool
+
+import (
+	"context"
+	"strings"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/hypershift-operator/controllers/nodepool/kubevirt"
+	"github.com/openshift/hypershift/support/imageverification"
+)
+
+// imagesToVerify returns the images of the NodePool whose signatures are verified: its release image and, on KubeVirt,
+// its bootimage when it's a container disk image. Other bootimages, e.g. AMIs, are not container images and are
+// verified through the release image which pins them.
+func imagesToVerify(nodePool *hyperv1.NodePool, kubevirtBootImage kubevirt.BootImage) []string {
+	images := []string{nodePool.Spec.Release.Image}
+	if kubevirtBootImage != nil {
+		bootImage := kubevirtBootImage.String()
+		if !strings.HasPrefix(bootImage, "http://") && !strings.HasPrefix(bootImage, "https://") {
+			images = append(images, bootImage)
+		}
+	}
+	return images
+}
+
+// verifyImages verifies the signatures of the images with the image verification policy of the HostedCluster.
+func (r *NodePoolReconciler) verifyImages(ctx context.Context, hcluster *hyperv1.HostedCluster, images []string) error {
+	verifier, err := imageverification.NewVerifier(ctx, r.Client, hcluster.Namespace, hcluster.Spec.ImageVerification)
+	if err != nil {
+		return err
+	}
+	pullSecretBytes, err := r.getPullSecretBytes(ctx, hcluster)
+	if err != nil {
+		return err
+	}
+	for _, image := range images {
+		if err := verifier.Verify(ctx, image, pullSecretBytes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
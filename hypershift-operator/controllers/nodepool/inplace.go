@@ -106,6 +106,9 @@ func (r *NodePoolReconciler) reconcileMachineSet(ctx context.Context,
 	}
 	machineSet.Spec.Template.Annotations[nodePoolAnnotationTaints] = taintsInJSON
 
+	// Propagate deletion hooks.
+	setMachineDeletionHooks(machineSet.Spec.Template.Annotations, nodePool.Spec.MachineDeletionHooks)
+
 	setMachineSetReplicas(nodePool, machineSet)
 
 	isUpdating := false
@@ -284,7 +287,7 @@ func setMachineSetReplicas(nodePool *hyperv1.NodePool, machineSet *capiv1.Machin
 	if !isAutoscalingEnabled(nodePool) {
 		machineSet.Annotations[autoscalerMaxAnnotation] = "0"
 		machineSet.Annotations[autoscalerMinAnnotation] = "0"
-		machineSet.Spec.Replicas = k8sutilspointer.Int32(k8sutilspointer.Int32Deref(nodePool.Spec.Replicas, 0))
+		machineSet.Spec.Replicas = k8sutilspointer.Int32(wantedReplicas(nodePool, machineSet.Spec.Replicas))
 	}
 }
 
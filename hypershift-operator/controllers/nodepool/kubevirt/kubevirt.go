@@ -137,6 +137,26 @@ func PlatformValidation(nodePool *hyperv1.NodePool) error {
 	return nil
 }
 
+// InfraNamespace returns the namespace of the infra cluster in which the virtual machines of the NodePool are
+// created: the NodePool infra namespace, the infra namespace of the external infra cluster credentials, or the
+// control plane namespace.
+func InfraNamespace(nodePool *hyperv1.NodePool, hcluster *hyperv1.HostedCluster, controlPlaneNamespace string) (string, error) {
+	var creds *hyperv1.KubevirtPlatformCredentials
+	if hcluster.Spec.Platform.Kubevirt != nil {
+		creds = hcluster.Spec.Platform.Kubevirt.Credentials
+	}
+	if nodePool.Spec.Platform.Kubevirt != nil && nodePool.Spec.Platform.Kubevirt.InfraNamespace != "" {
+		if creds == nil {
+			return "", fmt.Errorf("the kubevirt platform infraNamespace field requires the HostedCluster to use external infra cluster credentials")
+		}
+		return nodePool.Spec.Platform.Kubevirt.InfraNamespace, nil
+	}
+	if creds != nil && len(creds.InfraNamespace) > 0 {
+		return creds.InfraNamespace, nil
+	}
+	return controlPlaneNamespace, nil
+}
+
 func virtualMachineTemplateBase(nodePool *hyperv1.NodePool, bootImage BootImage) *capikubevirt.VirtualMachineTemplateSpec {
 	const rootVolumeName = "rhcos"
 
@@ -374,6 +394,9 @@ func MachineTemplateSpec(nodePool *hyperv1.NodePool, bootImage BootImage, hclust
 
 	if hcluster.Spec.Platform.Kubevirt != nil && hcluster.Spec.Platform.Kubevirt.Credentials != nil {
 		vmTemplate.ObjectMeta.Namespace = hcluster.Spec.Platform.Kubevirt.Credentials.InfraNamespace
+		if nodePool.Spec.Platform.Kubevirt.InfraNamespace != "" {
+			vmTemplate.ObjectMeta.Namespace = nodePool.Spec.Platform.Kubevirt.InfraNamespace
+		}
 	}
 
 	if err := applyJsonPatches(nodePool, hcluster, vmTemplate); err != nil {
@@ -1308,3 +1308,73 @@ func generateNodeTemplate(options ...nodeTemplateOption) *capikubevirt.VirtualMa
 
 	return template
 }
+
+func TestInfraNamespace(t *testing.T) {
+	const controlPlaneNamespace = "clusters-my-hostedcluster"
+	externalInfraCredentials := &hyperv1.KubevirtPlatformCredentials{
+		InfraNamespace: "infra-ns",
+	}
+
+	testCases := []struct {
+		name              string
+		nodePoolNamespace string
+		credentials       *hyperv1.KubevirtPlatformCredentials
+		expected          string
+		expectErr         bool
+	}{
+		{
+			name:     "When no external infra cluster is used it should return the control plane namespace",
+			expected: controlPlaneNamespace,
+		},
+		{
+			name:        "When an external infra cluster is used it should return the hosted cluster infra namespace",
+			credentials: externalInfraCredentials,
+			expected:    "infra-ns",
+		},
+		{
+			name:              "When the nodepool sets an infra namespace it should return the nodepool infra namespace",
+			nodePoolNamespace: "other-infra-ns",
+			credentials:       externalInfraCredentials,
+			expected:          "other-infra-ns",
+		},
+		{
+			name:              "When the nodepool sets an infra namespace without an external infra cluster it should fail",
+			nodePoolNamespace: "other-infra-ns",
+			expectErr:         true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			nodePool := &hyperv1.NodePool{
+				Spec: hyperv1.NodePoolSpec{
+					Platform: hyperv1.NodePoolPlatform{
+						Type: hyperv1.KubevirtPlatform,
+						Kubevirt: &hyperv1.KubevirtNodePoolPlatform{
+							InfraNamespace: tc.nodePoolNamespace,
+						},
+					},
+				},
+			}
+			hcluster := &hyperv1.HostedCluster{
+				Spec: hyperv1.HostedClusterSpec{
+					Platform: hyperv1.PlatformSpec{
+						Type: hyperv1.KubevirtPlatform,
+						Kubevirt: &hyperv1.KubevirtPlatformSpec{
+							Credentials: tc.credentials,
+						},
+					},
+				},
+			}
+
+			infraNamespace, err := InfraNamespace(nodePool, hcluster, controlPlaneNamespace)
+			if tc.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(infraNamespace).To(Equal(tc.expected))
+		})
+	}
+}
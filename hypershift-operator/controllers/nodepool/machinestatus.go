@@ -0,0 +1,82 @@
+package nodepool
+
+import (
+	"fmt"
+	"sort"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	k8sutilspointer "k8s.io/utils/pointer"
+	capiv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+const (
+	// maxMachineStatuses is the maximum number of machines listed in the status of a NodePool.
+	maxMachineStatuses = 100
+
+	// maxMachineErrorLength is the maximum length of the last error of a machine in the status of a NodePool.
+	maxMachineErrorLength = 1024
+)
+
+// setMachinesStatus summarizes the machines of the NodePool in its status. When there are more than
+// maxMachineStatuses machines, the ones which aren't running or have an error are listed first.
+func setMachinesStatus(nodePool *hyperv1.NodePool, machines []*capiv1.Machine) {
+	statuses := make([]hyperv1.NodePoolMachineStatus, 0, len(machines))
+	for _, machine := range machines {
+		status := hyperv1.NodePoolMachineStatus{
+			Name:       machine.Name,
+			Phase:      machine.Status.Phase,
+			ProviderID: k8sutilspointer.StringDeref(machine.Spec.ProviderID, ""),
+			Version:    k8sutilspointer.StringDeref(machine.Spec.Version, ""),
+			LastError:  machineLastError(machine),
+		}
+		if machine.Status.NodeRef != nil {
+			status.NodeName = machine.Status.NodeRef.Name
+		}
+		statuses = append(statuses, status)
+	}
+	sort.SliceStable(statuses, func(i, j int) bool {
+		return !isMachineStatusHealthy(statuses[i]) && isMachineStatusHealthy(statuses[j])
+	})
+	if len(statuses) > maxMachineStatuses {
+		statuses = statuses[:maxMachineStatuses]
+	}
+	if len(statuses) == 0 {
+		statuses = nil
+	}
+	nodePool.Status.Machines = statuses
+}
+
+func isMachineStatusHealthy(status hyperv1.NodePoolMachineStatus) bool {
+	return status.Phase == string(capiv1.MachinePhaseRunning) && status.LastError == ""
+}
+
+// machineLastError returns the failure of the machine, or else the reason and message of the first of its
+// infrastructure, bootstrap and node conditions which is false with a warning or error severity.
+func machineLastError(machine *capiv1.Machine) string {
+	var lastError string
+	switch {
+	case machine.Status.FailureMessage != nil:
+		lastError = *machine.Status.FailureMessage
+		if machine.Status.FailureReason != nil {
+			lastError = fmt.Sprintf("%s: %s", *machine.Status.FailureReason, lastError)
+		}
+	default:
+		for _, conditionType := range []capiv1.ConditionType{capiv1.InfrastructureReadyCondition, capiv1.BootstrapReadyCondition, capiv1.MachineNodeHealthyCondition} {
+			condition := findCAPIStatusCondition(machine.Status.Conditions, conditionType)
+			if condition == nil || condition.Status != corev1.ConditionFalse ||
+				(condition.Severity != capiv1.ConditionSeverityError && condition.Severity != capiv1.ConditionSeverityWarning) {
+				continue
+			}
+			lastError = condition.Reason
+			if condition.Message != "" && !isSetupCounterCondMessage.MatchString(condition.Message) {
+				lastError = fmt.Sprintf("%s: %s", condition.Reason, condition.Message)
+			}
+			break
+		}
+	}
+	if len(lastError) > maxMachineErrorLength {
+		lastError = lastError[:maxMachineErrorLength]
+	}
+	return lastError
+}
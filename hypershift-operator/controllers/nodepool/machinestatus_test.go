@@ -0,0 +1,146 @@
+package nodepool
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	capiv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	capierrors "sigs.k8s.io/cluster-api/errors"
+)
+
+func TestSetMachinesStatus(t *testing.T) {
+	runningMachine := func(name string) *capiv1.Machine {
+		return &capiv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: capiv1.MachineSpec{
+				ProviderID: ptr.To("aws:///us-east-1a/i-" + name),
+				Version:    ptr.To("4.16.0"),
+			},
+			Status: capiv1.MachineStatus{
+				Phase:   string(capiv1.MachinePhaseRunning),
+				NodeRef: &corev1.ObjectReference{Name: "ip-10-0-1-1." + name},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name     string
+		machines []*capiv1.Machine
+		expected []hyperv1.NodePoolMachineStatus
+	}{
+		{
+			name: "When machines are running it should list them",
+			machines: []*capiv1.Machine{
+				runningMachine("np-aaaaa"),
+			},
+			expected: []hyperv1.NodePoolMachineStatus{{
+				Name:       "np-aaaaa",
+				Phase:      "Running",
+				ProviderID: "aws:///us-east-1a/i-np-aaaaa",
+				NodeName:   "ip-10-0-1-1.np-aaaaa",
+				Version:    "4.16.0",
+			}},
+		},
+		{
+			name: "When machines fail it should report their last error and list them first",
+			machines: []*capiv1.Machine{
+				runningMachine("np-aaaaa"),
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "np-bbbbb"},
+					Spec:       capiv1.MachineSpec{Version: ptr.To("4.16.1")},
+					Status: capiv1.MachineStatus{
+						Phase:          string(capiv1.MachinePhaseFailed),
+						FailureReason:  ptr.To(capierrors.CreateMachineError),
+						FailureMessage: ptr.To("InsufficientInstanceCapacity: no m5.xlarge capacity in us-east-1a"),
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "np-ccccc"},
+					Spec:       capiv1.MachineSpec{Version: ptr.To("4.16.1")},
+					Status: capiv1.MachineStatus{
+						Phase: string(capiv1.MachinePhaseProvisioning),
+						Conditions: capiv1.Conditions{
+							{Type: capiv1.BootstrapReadyCondition, Status: corev1.ConditionFalse, Severity: capiv1.ConditionSeverityInfo, Reason: "WaitingForDataSecret"},
+							{Type: capiv1.InfrastructureReadyCondition, Status: corev1.ConditionFalse, Severity: capiv1.ConditionSeverityError, Reason: "InstanceProvisionFailed", Message: "1 of 2 completed"},
+						},
+					},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "np-ddddd"},
+					Status: capiv1.MachineStatus{
+						Phase: string(capiv1.MachinePhaseProvisioning),
+						Conditions: capiv1.Conditions{
+							{Type: capiv1.BootstrapReadyCondition, Status: corev1.ConditionFalse, Severity: capiv1.ConditionSeverityInfo, Reason: "WaitingForDataSecret"},
+						},
+					},
+				},
+			},
+			expected: []hyperv1.NodePoolMachineStatus{
+				{Name: "np-bbbbb", Phase: "Failed", Version: "4.16.1", LastError: "CreateError: InsufficientInstanceCapacity: no m5.xlarge capacity in us-east-1a"},
+				{Name: "np-ccccc", Phase: "Provisioning", Version: "4.16.1", LastError: "InstanceProvisionFailed"},
+				{Name: "np-ddddd", Phase: "Provisioning"},
+				{Name: "np-aaaaa", Phase: "Running", ProviderID: "aws:///us-east-1a/i-np-aaaaa", NodeName: "ip-10-0-1-1.np-aaaaa", Version: "4.16.0"},
+			},
+		},
+		{
+			name: "When a running machine's node is unhealthy it should report it",
+			machines: []*capiv1.Machine{
+				func() *capiv1.Machine {
+					machine := runningMachine("np-aaaaa")
+					machine.Status.Conditions = capiv1.Conditions{{
+						Type:     capiv1.MachineNodeHealthyCondition,
+						Status:   corev1.ConditionFalse,
+						Severity: capiv1.ConditionSeverityWarning,
+						Reason:   "NodeConditionsFailed",
+						Message:  "Node condition MemoryPressure is True",
+					}}
+					return machine
+				}(),
+			},
+			expected: []hyperv1.NodePoolMachineStatus{{
+				Name:       "np-aaaaa",
+				Phase:      "Running",
+				ProviderID: "aws:///us-east-1a/i-np-aaaaa",
+				NodeName:   "ip-10-0-1-1.np-aaaaa",
+				Version:    "4.16.0",
+				LastError:  "NodeConditionsFailed: Node condition MemoryPressure is True",
+			}},
+		},
+		{
+			name: "When there are no machines it should clear the list",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			nodePool := &hyperv1.NodePool{}
+			nodePool.Status.Machines = []hyperv1.NodePoolMachineStatus{{Name: "np-old"}}
+			setMachinesStatus(nodePool, tc.machines)
+			g.Expect(nodePool.Status.Machines).To(Equal(tc.expected))
+		})
+	}
+
+	t.Run("When there are too many machines it should only list the first ones, unhealthy first", func(t *testing.T) {
+		g := NewWithT(t)
+		var machines []*capiv1.Machine
+		for i := 0; i < maxMachineStatuses+10; i++ {
+			machines = append(machines, runningMachine(fmt.Sprintf("np-%03d", i)))
+		}
+		failed := machines[len(machines)-1]
+		failed.Status.Phase = string(capiv1.MachinePhaseFailed)
+		failed.Status.FailureMessage = ptr.To(strings.Repeat("x", 2*maxMachineErrorLength))
+
+		nodePool := &hyperv1.NodePool{}
+		setMachinesStatus(nodePool, machines)
+		g.Expect(nodePool.Status.Machines).To(HaveLen(maxMachineStatuses))
+		g.Expect(nodePool.Status.Machines[0].Name).To(Equal(failed.Name))
+		g.Expect(nodePool.Status.Machines[0].LastError).To(HaveLen(maxMachineErrorLength))
+		g.Expect(nodePool.Status.Machines[1].Name).To(Equal("np-000"))
+	})
+}
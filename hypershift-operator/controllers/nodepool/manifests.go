@@ -60,6 +60,24 @@ func TokenSecret(namespace, name, payloadInputHash string) *corev1.Secret {
 	}
 }
 
+func NodePoolPullSecret(namespace, name string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      fmt.Sprintf("pull-secret-%s", name),
+		},
+	}
+}
+
+func NodePoolSSHKeySecret(namespace, name string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      fmt.Sprintf("ssh-key-%s", name),
+		},
+	}
+}
+
 func TunedConfigMap(namespace, name string) *corev1.ConfigMap {
 	return &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
@@ -69,6 +87,15 @@ func TunedConfigMap(namespace, name string) *corev1.ConfigMap {
 	}
 }
 
+func SecurityGroupConfigMap(namespace, name string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      fmt.Sprintf("security-group-%s", name),
+		},
+	}
+}
+
 func PerformanceProfileConfigMap(namespace, name string) *corev1.ConfigMap {
 	return &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
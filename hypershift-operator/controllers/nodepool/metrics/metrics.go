@@ -3,6 +3,7 @@ package metrics
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/aws/aws-sdk-go/service/ec2"
@@ -58,6 +59,18 @@ const (
 	DeletingDurationMetricName = "hypershift_nodepools_deleting_duration_seconds" // What about renaming it to hypershift_nodepool_deleting_duration_seconds ?
 	deletingDurationMetricHelp = "Time in seconds it is taking to delete the NodePool since the beginning of the delete. " +
 		"Undefined if the node pool is not deleting or no longer exists."
+
+	CapacityVCpusMetricName = "hypershift_nodepool_capacity_vcpus"
+	capacityVCpusMetricHelp = "Aggregate number of virtual CPUs of the machines of a given NodePool. " +
+		"Undefined if the number of virtual CPUs of its machine type cannot be resolved."
+
+	CapacityMemoryMetricName = "hypershift_nodepool_capacity_memory_bytes"
+	capacityMemoryMetricHelp = "Aggregate memory in bytes of the machines of a given NodePool. " +
+		"Undefined if the memory of its machine type cannot be resolved."
+
+	EstimatedHourlyCostMetricName = "hypershift_nodepool_estimated_hourly_cost"
+	estimatedHourlyCostMetricHelp = "Estimated cost per hour of the machines of a given NodePool, in the currency given by the currency label. " +
+		"Undefined if the machine price source has no price for its machine type."
 )
 
 type void struct{}
@@ -124,6 +137,21 @@ var (
 		DeletingDurationMetricName,
 		deletingDurationMetricHelp,
 		nodePoolLabels, nil)
+
+	capacityVCpusMetricDesc = prometheus.NewDesc(
+		CapacityVCpusMetricName,
+		capacityVCpusMetricHelp,
+		nodePoolLabels, nil)
+
+	capacityMemoryMetricDesc = prometheus.NewDesc(
+		CapacityMemoryMetricName,
+		capacityMemoryMetricHelp,
+		nodePoolLabels, nil)
+
+	estimatedHourlyCostMetricDesc = prometheus.NewDesc(
+		EstimatedHourlyCostMetricName,
+		estimatedHourlyCostMetricHelp,
+		append(nodePoolLabels, "currency"), nil)
 )
 
 type nodePoolsMetricsCollector struct {
@@ -480,6 +508,36 @@ func (c *nodePoolsMetricsCollector) Collect(ch chan<- prometheus.Metric) {
 					nodePoolLabelValues...,
 				)
 			}
+
+			// capacityVCpusMetric, capacityMemoryMetric & estimatedHourlyCostMetric
+			if capacity := nodePool.Status.Capacity; capacity != nil {
+				if capacity.CPU != nil {
+					ch <- prometheus.MustNewConstMetric(
+						capacityVCpusMetricDesc,
+						prometheus.GaugeValue,
+						capacity.CPU.AsApproximateFloat64(),
+						nodePoolLabelValues...,
+					)
+				}
+
+				if capacity.Memory != nil {
+					ch <- prometheus.MustNewConstMetric(
+						capacityMemoryMetricDesc,
+						prometheus.GaugeValue,
+						capacity.Memory.AsApproximateFloat64(),
+						nodePoolLabelValues...,
+					)
+				}
+
+				if estimatedHourlyCost, err := strconv.ParseFloat(capacity.EstimatedHourlyCost, 64); err == nil {
+					ch <- prometheus.MustNewConstMetric(
+						estimatedHourlyCostMetricDesc,
+						prometheus.GaugeValue,
+						estimatedHourlyCost,
+						append(nodePoolLabelValues, capacity.Currency)...,
+					)
+				}
+			}
 		}
 	}
 
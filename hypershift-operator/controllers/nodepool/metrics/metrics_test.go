@@ -12,6 +12,7 @@ import (
 	"github.com/openshift/hypershift/support/api"
 	"github.com/prometheus/client_golang/prometheus"
 	dto "github.com/prometheus/client_model/go"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/clock"
 	"k8s.io/utils/pointer"
@@ -214,3 +215,100 @@ func TestReportVCpusCountByHCluster(t *testing.T) {
 		})
 	}
 }
+
+func TestReportCapacityAndEstimatedHourlyCost(t *testing.T) {
+	cpu := resource.MustParse("12")
+	memory := resource.MustParse("48Gi")
+	testCases := []struct {
+		name           string
+		capacity       *hyperv1.NodePoolCapacityStatus
+		expectedValues map[string]float64
+	}{
+		{
+			name: "When the NodePool has no capacity status it should not report capacity metrics",
+		},
+		{
+			name: "When the NodePool capacity and cost are resolved it should report them",
+			capacity: &hyperv1.NodePoolCapacityStatus{
+				Machines:            3,
+				MachineType:         "m5.xlarge",
+				CPU:                 &cpu,
+				Memory:              &memory,
+				EstimatedHourlyCost: "0.576",
+				Currency:            "USD",
+			},
+			expectedValues: map[string]float64{
+				CapacityVCpusMetricName:       12,
+				CapacityMemoryMetricName:      48 * 1024 * 1024 * 1024,
+				EstimatedHourlyCostMetricName: 0.576,
+			},
+		},
+		{
+			name: "When the NodePool has no price it should only report its capacity",
+			capacity: &hyperv1.NodePoolCapacityStatus{
+				Machines: 3,
+				CPU:      &cpu,
+				Memory:   &memory,
+			},
+			expectedValues: map[string]float64{
+				CapacityVCpusMetricName:  12,
+				CapacityMemoryMetricName: 48 * 1024 * 1024 * 1024,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			nodePool := &hyperv1.NodePool{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "np",
+					Namespace: "any",
+				},
+				Spec: hyperv1.NodePoolSpec{
+					ClusterName: "hc",
+					Platform: hyperv1.NodePoolPlatform{
+						Type: hyperv1.AWSPlatform,
+						AWS:  &hyperv1.AWSNodePoolPlatform{InstanceType: "m5.xlarge"},
+					},
+				},
+				Status: hyperv1.NodePoolStatus{
+					Capacity: tc.capacity,
+				},
+			}
+
+			reg := prometheus.NewPedanticRegistry()
+			reg.MustRegister(createNodePoolsMetricsCollector(fake.NewClientBuilder().WithScheme(api.Scheme).WithObjects(nodePool).Build(), nil, clock.RealClock{}))
+
+			allMetricsValues, err := reg.Gather()
+			if err != nil {
+				t.Fatalf("gathering metrics failed: %v", err)
+			}
+
+			values := map[string]float64{}
+			for _, metricValue := range allMetricsValues {
+				switch metricValue.GetName() {
+				case CapacityVCpusMetricName, CapacityMemoryMetricName, EstimatedHourlyCostMetricName:
+					if len(metricValue.Metric) != 1 {
+						t.Fatalf("expected a single %s time series, got %d", metricValue.GetName(), len(metricValue.Metric))
+					}
+					values[metricValue.GetName()] = metricValue.Metric[0].GetGauge().GetValue()
+					if metricValue.GetName() == EstimatedHourlyCostMetricName {
+						currency := ""
+						for _, label := range metricValue.Metric[0].GetLabel() {
+							if label.GetName() == "currency" {
+								currency = label.GetValue()
+							}
+						}
+						if currency != tc.capacity.Currency {
+							t.Errorf("expected currency label %q, got %q", tc.capacity.Currency, currency)
+						}
+					}
+				}
+			}
+
+			if diff := cmp.Diff(tc.expectedValues, values, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("result differs from expected: %s", diff)
+			}
+		})
+	}
+}
@@ -9,15 +9,18 @@ import (
 	"fmt"
 	"net/netip"
 	"regexp"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/blang/semver"
 	ignitionapi "github.com/coreos/ignition/v2/config/v3_2/types"
 	"github.com/go-logr/logr"
 	"github.com/google/uuid"
+	"github.com/opencontainers/go-digest"
 	configv1 "github.com/openshift/api/config/v1"
 	configv1alpha1 "github.com/openshift/api/config/v1alpha1"
 	"github.com/openshift/api/operator/v1alpha1"
@@ -30,10 +33,13 @@ import (
 	"github.com/openshift/hypershift/hypershift-operator/controllers/manifests"
 	"github.com/openshift/hypershift/hypershift-operator/controllers/manifests/ignitionserver"
 	"github.com/openshift/hypershift/hypershift-operator/controllers/nodepool/kubevirt"
+	"github.com/openshift/hypershift/hypershift-operator/controllers/sharding"
 	ignserver "github.com/openshift/hypershift/ignition-server/controllers"
 	kvinfra "github.com/openshift/hypershift/kubevirtexternalinfra"
 	"github.com/openshift/hypershift/support/api"
+	supportconditions "github.com/openshift/hypershift/support/conditions"
 	"github.com/openshift/hypershift/support/globalconfig"
+	"github.com/openshift/hypershift/support/imageverification"
 	"github.com/openshift/hypershift/support/releaseinfo"
 	"github.com/openshift/hypershift/support/supportedversion"
 	"github.com/openshift/hypershift/support/upsert"
@@ -51,6 +57,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
@@ -90,6 +97,7 @@ const (
 
 	nodePoolAnnotationPlatformMachineTemplate = "hypershift.openshift.io/nodePoolPlatformMachineTemplate"
 	nodePoolAnnotationTaints                  = "hypershift.openshift.io/nodePoolTaints"
+	nodePoolAnnotationMachineDeletionHooks    = "hypershift.openshift.io/nodePoolMachineDeletionHooks"
 	nodePoolCoreIgnitionConfigLabel           = "hypershift.openshift.io/core-ignition-config"
 	TokenSecretTokenGenerationTime            = "hypershift.openshift.io/last-token-generation-time"
 	TokenSecretReleaseKey                     = "release"
@@ -122,6 +130,28 @@ type NodePoolReconciler struct {
 	HypershiftOperatorImage string
 	ImageMetadataProvider   supportutil.ImageMetadataProvider
 	KubevirtInfraClients    kvinfra.KubevirtInfraClientMap
+
+	// Shard is the subset of HostedClusters whose NodePools are reconciled by this operator replica.
+	Shard sharding.Shard
+
+	// EC2Client resolves the capacity of the AWS instance types of the NodePools. It's nil when the operator has no
+	// AWS credentials.
+	EC2Client ec2iface.EC2API
+	// EC2ClientForRegion returns an EC2 client for the region, which resolves the AMI lookups of the NodePools. It's
+	// nil when the operator has no AWS credentials.
+	EC2ClientForRegion func(region string) ec2iface.EC2API
+	// PriceSource estimates the hourly cost of the machines of the NodePools. It's nil when no price source is
+	// configured.
+	PriceSource MachinePriceSource
+
+	// cloudLookups caches the results of the cloud provider lookups of the NodePools.
+	cloudLookups *cloudLookupCache
+	// resolveImageDigest resolves the digest of the layered OS images of the NodePools. It defaults to
+	// registryclient.GetDigest.
+	resolveImageDigest func(ctx context.Context, imageRef string, pullSecret []byte) (digest.Digest, error)
+	// lookupAzureVMSize returns the capabilities of an Azure VM size in the location of the HostedCluster. It
+	// defaults to armVMSizeCapabilities.
+	lookupAzureVMSize func(ctx context.Context, hcluster *hyperv1.HostedCluster, vmSize string) (map[string]string, error)
 }
 
 type NotReadyError struct {
@@ -141,22 +171,26 @@ var (
 )
 
 func (r *NodePoolReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.cloudLookups = newCloudLookupCache()
 	controller, err := ctrl.NewControllerManagedBy(mgr).
-		For(&hyperv1.NodePool{}, builder.WithPredicates(supportutil.PredicatesForHostedClusterAnnotationScoping(mgr.GetClient()))).
+		For(&hyperv1.NodePool{}, builder.WithPredicates(supportutil.PredicatesForHostedClusterAnnotationScoping(mgr.GetClient()), r.Shard.Predicate(mgr.GetClient()))).
 		// We want to reconcile when the HostedCluster IgnitionEndpoint is available.
-		Watches(&hyperv1.HostedCluster{}, handler.EnqueueRequestsFromMapFunc(r.enqueueNodePoolsForHostedCluster), builder.WithPredicates(supportutil.PredicatesForHostedClusterAnnotationScoping(mgr.GetClient()))).
-		Watches(&capiv1.MachineDeployment{}, handler.EnqueueRequestsFromMapFunc(enqueueParentNodePool), builder.WithPredicates(supportutil.PredicatesForHostedClusterAnnotationScoping(mgr.GetClient()))).
-		Watches(&capiv1.MachineSet{}, handler.EnqueueRequestsFromMapFunc(enqueueParentNodePool), builder.WithPredicates(supportutil.PredicatesForHostedClusterAnnotationScoping(mgr.GetClient()))).
-		Watches(&capiaws.AWSMachineTemplate{}, handler.EnqueueRequestsFromMapFunc(enqueueParentNodePool), builder.WithPredicates(supportutil.PredicatesForHostedClusterAnnotationScoping(mgr.GetClient()))).
-		Watches(&agentv1.AgentMachineTemplate{}, handler.EnqueueRequestsFromMapFunc(enqueueParentNodePool), builder.WithPredicates(supportutil.PredicatesForHostedClusterAnnotationScoping(mgr.GetClient()))).
-		Watches(&capiazure.AzureMachineTemplate{}, handler.EnqueueRequestsFromMapFunc(enqueueParentNodePool), builder.WithPredicates(supportutil.PredicatesForHostedClusterAnnotationScoping(mgr.GetClient()))).
-		// We want to reconcile when the user data Secret or the token Secret is unexpectedly changed out of band.
-		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(enqueueParentNodePool), builder.WithPredicates(supportutil.PredicatesForHostedClusterAnnotationScoping(mgr.GetClient()))).
+		Watches(&hyperv1.HostedCluster{}, handler.EnqueueRequestsFromMapFunc(r.enqueueNodePoolsForHostedCluster), builder.WithPredicates(supportutil.PredicatesForHostedClusterAnnotationScoping(mgr.GetClient()), r.Shard.Predicate(mgr.GetClient()))).
+		Watches(&capiv1.MachineDeployment{}, handler.EnqueueRequestsFromMapFunc(enqueueParentNodePool), builder.WithPredicates(supportutil.PredicatesForHostedClusterAnnotationScoping(mgr.GetClient()), r.Shard.Predicate(mgr.GetClient()))).
+		Watches(&capiv1.MachineSet{}, handler.EnqueueRequestsFromMapFunc(enqueueParentNodePool), builder.WithPredicates(supportutil.PredicatesForHostedClusterAnnotationScoping(mgr.GetClient()), r.Shard.Predicate(mgr.GetClient()))).
+		Watches(&capiaws.AWSMachineTemplate{}, handler.EnqueueRequestsFromMapFunc(enqueueParentNodePool), builder.WithPredicates(supportutil.PredicatesForHostedClusterAnnotationScoping(mgr.GetClient()), r.Shard.Predicate(mgr.GetClient()))).
+		Watches(&agentv1.AgentMachineTemplate{}, handler.EnqueueRequestsFromMapFunc(enqueueParentNodePool), builder.WithPredicates(supportutil.PredicatesForHostedClusterAnnotationScoping(mgr.GetClient()), r.Shard.Predicate(mgr.GetClient()))).
+		Watches(&capiazure.AzureMachineTemplate{}, handler.EnqueueRequestsFromMapFunc(enqueueParentNodePool), builder.WithPredicates(supportutil.PredicatesForHostedClusterAnnotationScoping(mgr.GetClient()), r.Shard.Predicate(mgr.GetClient()))).
+		// We want to reconcile when the user data Secret or the token Secret is unexpectedly changed out of band,
+		// and when the HostedCluster pull secret or the additional pull secrets are rotated.
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.enqueueNodePoolsForSecret), builder.WithPredicates(supportutil.PredicatesForHostedClusterAnnotationScoping(mgr.GetClient()), r.Shard.Predicate(mgr.GetClient()))).
 		// We want to reconcile when the ConfigMaps referenced by the spec.config and also the core ones change.
-		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(r.enqueueNodePoolsForConfig), builder.WithPredicates(supportutil.PredicatesForHostedClusterAnnotationScoping(mgr.GetClient()))).
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(r.enqueueNodePoolsForConfig), builder.WithPredicates(supportutil.PredicatesForHostedClusterAnnotationScoping(mgr.GetClient()), r.Shard.Predicate(mgr.GetClient()))).
 		WithOptions(controller.Options{
 			RateLimiter:             workqueue.NewItemExponentialFailureRateLimiter(1*time.Second, 10*time.Second),
 			MaxConcurrentReconciles: 10,
+			// When sharded, every replica holding a shard reconciles, not only the leader.
+			NeedLeaderElection: k8sutilspointer.Bool(!r.Shard.Enabled()),
 		}).
 		Build(r)
 	if err != nil {
@@ -185,6 +219,11 @@ func (r *NodePoolReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		return ctrl.Result{}, err
 	}
 
+	if !r.Shard.OwnsObject(ctx, r.Client, nodePool) {
+		log.Info("HostedCluster belongs to another shard, skipping reconcile")
+		return ctrl.Result{}, nil
+	}
+
 	controlPlaneNamespace := manifests.HostedControlPlaneNamespace(nodePool.Namespace, nodePool.Spec.ClusterName)
 
 	// If deleted, clean up and return early.
@@ -286,6 +325,17 @@ func (r *NodePoolReconciler) reconcile(ctx context.Context, hcluster *hyperv1.Ho
 	if err := enc.Encode(image); err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to encode image global config: %w", err)
 	}
+	// The registry CAs are rendered into the node container runtime config, so changes to their content must also
+	// trigger a nodepool update.
+	if name := image.Spec.AdditionalTrustedCA.Name; name != "" {
+		registryCA := &corev1.ConfigMap{}
+		if err := r.Get(ctx, client.ObjectKey{Namespace: hcluster.Namespace, Name: name}, registryCA); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to get image additionalTrustedCA configmap %s: %w", name, err)
+		}
+		if err := enc.Encode(registryCA.Data); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to encode image additionalTrustedCA: %w", err)
+		}
+	}
 	globalConfig := globalConfigBytes.String()
 
 	if isAutoscalingEnabled(nodePool) {
@@ -357,21 +407,29 @@ func (r *NodePoolReconciler) reconcile(ctx context.Context, hcluster *hyperv1.Ho
 		}
 		removeStatusCondition(&nodePool.Status.Conditions, hyperv1.NodePoolValidMachineConfigConditionType)
 
-		infraNS := controlPlaneNamespace
+		infraNS, err := kubevirt.InfraNamespace(nodePool, hcluster, controlPlaneNamespace)
+		if err != nil {
+			SetStatusCondition(&nodePool.Status.Conditions, hyperv1.NodePoolCondition{
+				Type:               hyperv1.NodePoolValidMachineConfigConditionType,
+				Status:             corev1.ConditionFalse,
+				Reason:             hyperv1.NodePoolValidationFailedReason,
+				Message:            fmt.Sprintf("validation of NodePool KubeVirt platform failed: %s", err.Error()),
+				ObservedGeneration: nodePool.Generation,
+			})
+			return ctrl.Result{}, fmt.Errorf("validation of NodePool KubeVirt platform failed: %w", err)
+		}
+
+		if nodePool.Status.Platform == nil {
+			nodePool.Status.Platform = &hyperv1.NodePoolPlatformStatus{}
+		}
+		if nodePool.Status.Platform.KubeVirt == nil {
+			nodePool.Status.Platform.KubeVirt = &hyperv1.KubeVirtNodePoolStatus{}
+		}
+		nodePool.Status.Platform.KubeVirt.InfraNamespace = infraNS
 		if hcluster.Spec.Platform.Kubevirt != nil &&
 			hcluster.Spec.Platform.Kubevirt.Credentials != nil &&
 			len(hcluster.Spec.Platform.Kubevirt.Credentials.InfraNamespace) > 0 {
 
-			infraNS = hcluster.Spec.Platform.Kubevirt.Credentials.InfraNamespace
-
-			if nodePool.Status.Platform == nil {
-				nodePool.Status.Platform = &hyperv1.NodePoolPlatformStatus{}
-			}
-
-			if nodePool.Status.Platform.KubeVirt == nil {
-				nodePool.Status.Platform.KubeVirt = &hyperv1.KubeVirtNodePoolStatus{}
-			}
-
 			nodePool.Status.Platform.KubeVirt.Credentials = hcluster.Spec.Platform.Kubevirt.Credentials.DeepCopy()
 		}
 		kubevirtBootImage, err = kubevirt.GetImage(nodePool, releaseImage, infraNS)
@@ -470,6 +528,73 @@ func (r *NodePoolReconciler) reconcile(ctx context.Context, hcluster *hyperv1.Ho
 		ObservedGeneration: nodePool.Generation,
 	})
 
+	// Verify the signatures of the release image and the bootimage before rolling them out.
+	if hcluster.Spec.ImageVerification == nil {
+		removeStatusCondition(&nodePool.Status.Conditions, hyperv1.NodePoolImageVerifiedConditionType)
+	} else {
+		images := imagesToVerify(nodePool, kubevirtBootImage)
+		message := fmt.Sprintf("Verified the signatures of images: %s", strings.Join(images, ", "))
+		condition := FindStatusCondition(nodePool.Status.Conditions, hyperv1.NodePoolImageVerifiedConditionType)
+		if condition == nil || condition.ObservedGeneration != nodePool.Generation || condition.Status != corev1.ConditionTrue || condition.Message != message {
+			if err := r.verifyImages(ctx, hcluster, images); err != nil {
+				if !imageverification.IsNotVerified(err) {
+					SetStatusCondition(&nodePool.Status.Conditions, hyperv1.NodePoolCondition{
+						Type:               hyperv1.NodePoolImageVerifiedConditionType,
+						Status:             corev1.ConditionFalse,
+						Reason:             hyperv1.ImageVerificationErrorReason,
+						Message:            err.Error(),
+						ObservedGeneration: nodePool.Generation,
+					})
+					return ctrl.Result{}, fmt.Errorf("failed to verify images: %w", err)
+				}
+				SetStatusCondition(&nodePool.Status.Conditions, hyperv1.NodePoolCondition{
+					Type:               hyperv1.NodePoolImageVerifiedConditionType,
+					Status:             corev1.ConditionFalse,
+					Reason:             hyperv1.ImageVerificationFailedReason,
+					Message:            err.Error(),
+					ObservedGeneration: nodePool.Generation,
+				})
+				// We don't return the error here as reconciling won't verify the images.
+				// An update event will trigger reconciliation.
+				log.Error(err, "image verification failed, rollout is blocked")
+				return ctrl.Result{}, nil
+			}
+			SetStatusCondition(&nodePool.Status.Conditions, hyperv1.NodePoolCondition{
+				Type:               hyperv1.NodePoolImageVerifiedConditionType,
+				Status:             corev1.ConditionTrue,
+				Reason:             hyperv1.AsExpectedReason,
+				Message:            message,
+				ObservedGeneration: nodePool.Generation,
+			})
+		}
+	}
+
+	// Validate the nodes can run in FIPS mode when the HostedCluster requires it.
+	if !hcluster.Spec.FIPS {
+		removeStatusCondition(&nodePool.Status.Conditions, hyperv1.NodePoolValidFIPSConfigurationConditionType)
+	} else {
+		if err := validateFIPSConfiguration(nodePool, releaseImage); err != nil {
+			SetStatusCondition(&nodePool.Status.Conditions, hyperv1.NodePoolCondition{
+				Type:               hyperv1.NodePoolValidFIPSConfigurationConditionType,
+				Status:             corev1.ConditionFalse,
+				Reason:             hyperv1.FIPSUnsupportedReason,
+				Message:            err.Error(),
+				ObservedGeneration: nodePool.Generation,
+			})
+			// We don't return the error here as reconciling won't solve the input problem.
+			// An update event will trigger reconciliation.
+			log.Error(err, "validating FIPS configuration failed")
+			return ctrl.Result{}, nil
+		}
+		SetStatusCondition(&nodePool.Status.Conditions, hyperv1.NodePoolCondition{
+			Type:               hyperv1.NodePoolValidFIPSConfigurationConditionType,
+			Status:             corev1.ConditionTrue,
+			Reason:             hyperv1.AsExpectedReason,
+			Message:            fmt.Sprintf("Nodes with architecture %s can run in FIPS mode", nodePool.Spec.Arch),
+			ObservedGeneration: nodePool.Generation,
+		})
+	}
+
 	// Validate modifying CPU arch support for platform
 	if !isArchAndPlatformSupported(nodePool) {
 		SetStatusCondition(&nodePool.Status.Conditions, hyperv1.NodePoolCondition{
@@ -481,6 +606,42 @@ func (r *NodePoolReconciler) reconcile(ctx context.Context, hcluster *hyperv1.Ho
 		})
 	}
 
+	// Validate the release image provides images for the NodePool CPU arch. The nodes don't need to match the CPU arch
+	// of the management cluster, which only runs the control plane.
+	if _, skip := hcluster.Annotations[hyperv1.SkipReleaseImageValidation]; skip {
+		removeStatusCondition(&nodePool.Status.Conditions, hyperv1.NodePoolValidReleaseImageArchConditionType)
+	} else {
+		// Looking up the architectures of the release image requires a registry lookup, so skip if we have already
+		// observed for this generation.
+		condition := FindStatusCondition(nodePool.Status.Conditions, hyperv1.NodePoolValidReleaseImageArchConditionType)
+		if condition == nil || condition.ObservedGeneration != nodePool.Generation || condition.Status != corev1.ConditionTrue {
+			architectures, err := r.releaseImageArchitectures(ctx, hcluster, nodePool)
+			if err != nil {
+				return ctrl.Result{}, fmt.Errorf("failed to look up the architectures of release image %s: %w", nodePool.Spec.Release.Image, err)
+			}
+			if len(architectures) > 0 && !slices.Contains(architectures, nodePool.Spec.Arch) {
+				SetStatusCondition(&nodePool.Status.Conditions, hyperv1.NodePoolCondition{
+					Type:               hyperv1.NodePoolValidReleaseImageArchConditionType,
+					Status:             corev1.ConditionFalse,
+					Reason:             hyperv1.ReleaseImageArchMismatchReason,
+					Message:            fmt.Sprintf("Release image %s does not provide images for CPU arch %s, it provides: %s. Use a multi-arch release image", nodePool.Spec.Release.Image, nodePool.Spec.Arch, strings.Join(architectures, ", ")),
+					ObservedGeneration: nodePool.Generation,
+				})
+				// We don't return the error here as reconciling won't solve the input problem.
+				// An update event will trigger reconciliation.
+				log.Error(fmt.Errorf("release image does not provide images for CPU arch %s", nodePool.Spec.Arch), "validating release image CPU arch failed")
+				return ctrl.Result{}, nil
+			}
+			SetStatusCondition(&nodePool.Status.Conditions, hyperv1.NodePoolCondition{
+				Type:               hyperv1.NodePoolValidReleaseImageArchConditionType,
+				Status:             corev1.ConditionTrue,
+				Reason:             hyperv1.AsExpectedReason,
+				Message:            fmt.Sprintf("Release image provides images for CPU arch %s", nodePool.Spec.Arch),
+				ObservedGeneration: nodePool.Generation,
+			})
+		}
+	}
+
 	// Validate AWS platform specific input
 	var ami string
 	if nodePool.Spec.Platform.Type == hyperv1.AWSPlatform {
@@ -491,6 +652,25 @@ func (r *NodePoolReconciler) reconcile(ctx context.Context, hcluster *hyperv1.Ho
 			ami = nodePool.Spec.Platform.AWS.AMI
 			// User-defined AMIs cannot be validated
 			removeStatusCondition(&nodePool.Status.Conditions, hyperv1.NodePoolValidPlatformImageType)
+		} else if nodePool.Spec.Platform.AWS.AMILookup != nil {
+			ami, err = r.resolveAMILookup(ctx, nodePool, hcluster.Spec.Platform.AWS.Region)
+			if err != nil {
+				SetStatusCondition(&nodePool.Status.Conditions, hyperv1.NodePoolCondition{
+					Type:               hyperv1.NodePoolValidPlatformImageType,
+					Status:             corev1.ConditionFalse,
+					Reason:             supportconditions.ReasonForError(err, hyperv1.NodePoolValidationFailedReason),
+					Message:            fmt.Sprintf("Couldn't resolve the AMI lookup: %s", err.Error()),
+					ObservedGeneration: nodePool.Generation,
+				})
+				return ctrl.Result{}, fmt.Errorf("couldn't resolve the AMI lookup: %w", err)
+			}
+			SetStatusCondition(&nodePool.Status.Conditions, hyperv1.NodePoolCondition{
+				Type:               hyperv1.NodePoolValidPlatformImageType,
+				Status:             corev1.ConditionTrue,
+				Reason:             hyperv1.AsExpectedReason,
+				Message:            fmt.Sprintf("Bootstrap AMI is %q, resolved from the AMI lookup", ami),
+				ObservedGeneration: nodePool.Generation,
+			})
 		} else {
 			// TODO: Should the region be included in the NodePool platform information?
 			ami, err = defaultNodePoolAMI(hcluster.Spec.Platform.AWS.Region, nodePool.Spec.Arch, releaseImage)
@@ -530,6 +710,34 @@ func (r *NodePoolReconciler) reconcile(ctx context.Context, hcluster *hyperv1.Ho
 				ObservedGeneration: nodePool.Generation,
 			})
 		}
+
+		if err := r.reconcileDedicatedSecurityGroup(ctx, nodePool, controlPlaneNamespace); err != nil {
+			return ctrl.Result{}, err
+		}
+		if nodePool.Spec.Platform.AWS.DedicatedSecurityGroup != nil && nodePool.Status.Platform.AWS.DedicatedSecurityGroupID == "" {
+			SetStatusCondition(&nodePool.Status.Conditions, hyperv1.NodePoolCondition{
+				Type:               hyperv1.NodePoolAWSSecurityGroupAvailableConditionType,
+				Status:             corev1.ConditionFalse,
+				Reason:             hyperv1.DedicatedAWSSecurityGroupNotReadyReason,
+				Message:            "Waiting for the dedicated AWS security group of the NodePool to be created",
+				ObservedGeneration: nodePool.Generation,
+			})
+		}
+	}
+
+	if nodePool.Spec.Platform.Type == hyperv1.AzurePlatform {
+		reconcileAzureNodePoolStatus(nodePool, hcluster)
+		if err := r.validateAzureVMSize(ctx, nodePool, hcluster); err != nil {
+			SetStatusCondition(&nodePool.Status.Conditions, hyperv1.NodePoolCondition{
+				Type:               hyperv1.NodePoolValidMachineTemplateConditionType,
+				Status:             corev1.ConditionFalse,
+				Reason:             supportconditions.ReasonForError(err, hyperv1.InvalidAzureMachineTemplate),
+				Message:            err.Error(),
+				ObservedGeneration: nodePool.Generation,
+			})
+			return ctrl.Result{}, err
+		}
+		removeStatusCondition(&nodePool.Status.Conditions, hyperv1.NodePoolValidMachineTemplateConditionType)
 	}
 
 	// Validate PowerVS platform specific input
@@ -558,16 +766,36 @@ func (r *NodePoolReconciler) reconcile(ctx context.Context, hcluster *hyperv1.Ho
 		})
 	}
 
+	// Resolve the layered OS image.
+	if nodePool.Spec.OSImage == nil {
+		removeStatusCondition(&nodePool.Status.Conditions, hyperv1.NodePoolValidOSImageConditionType)
+		nodePool.Status.OSImage = ""
+	} else {
+		osImage, err := r.resolveOSImage(ctx, nodePool, hcluster)
+		if err != nil {
+			SetStatusCondition(&nodePool.Status.Conditions, hyperv1.NodePoolCondition{
+				Type:               hyperv1.NodePoolValidOSImageConditionType,
+				Status:             corev1.ConditionFalse,
+				Reason:             hyperv1.NodePoolValidationFailedReason,
+				Message:            err.Error(),
+				ObservedGeneration: nodePool.Generation,
+			})
+			return ctrl.Result{}, err
+		}
+		nodePool.Status.OSImage = osImage
+		SetStatusCondition(&nodePool.Status.Conditions, hyperv1.NodePoolCondition{
+			Type:               hyperv1.NodePoolValidOSImageConditionType,
+			Status:             corev1.ConditionTrue,
+			Reason:             hyperv1.AsExpectedReason,
+			Message:            fmt.Sprintf("Nodes boot layered OS image %s", osImage),
+			ObservedGeneration: nodePool.Generation,
+		})
+	}
+
 	// Validate config input.
-	// 3 generic core config resources: fips, ssh and haproxy.
 	// TODO (alberto): consider moving the expectedCoreConfigResources check
 	// into the token Secret controller so we don't block Machine infra creation on this.
-	expectedCoreConfigResources := 3
-	if len(hcluster.Spec.ImageContentSources) > 0 {
-		// additional core config resource created when image content source specified.
-		expectedCoreConfigResources += 1
-	}
-	config, missingConfigs, err := r.getConfig(ctx, nodePool, expectedCoreConfigResources, controlPlaneNamespace, releaseImage, hcluster)
+	config, missingConfigs, err := r.getConfig(ctx, nodePool, expectedCoreConfigResources(hcluster), controlPlaneNamespace, releaseImage, hcluster)
 	if err != nil {
 		SetStatusCondition(&nodePool.Status.Conditions, hyperv1.NodePoolCondition{
 			Type:               hyperv1.NodePoolValidMachineConfigConditionType,
@@ -609,6 +837,14 @@ func (r *NodePoolReconciler) reconcile(ctx context.Context, hcluster *hyperv1.Ho
 
 	// Check if config needs to be updated.
 	targetConfigHash := supportutil.HashSimple(config + pullSecretName)
+	// When the SSH key is excluded from the config hash, SSH key changes are propagated to the existing Nodes
+	// and the token Secret config is updated so new Nodes get the new key, instead of rolling out the NodePool.
+	hashedConfig := config
+	sshKeyExcluded := excludeSSHKeyFromConfigHash(nodePool, targetConfigHash, releaseImage.Version())
+	if sshKeyExcluded {
+		hashedConfig = configWithoutSSHKey(config)
+		targetConfigHash = supportutil.HashSimple(hashedConfig + pullSecretName)
+	}
 	isUpdatingConfig := isUpdatingConfig(nodePool, targetConfigHash)
 	if isUpdatingConfig {
 		SetStatusCondition(&nodePool.Status.Conditions, hyperv1.NodePoolCondition{
@@ -642,8 +878,24 @@ func (r *NodePoolReconciler) reconcile(ctx context.Context, hcluster *hyperv1.Ho
 		removeStatusCondition(&nodePool.Status.Conditions, hyperv1.NodePoolUpdatingVersionConditionType)
 	}
 
+	// Hold the version rollout until the boot image of the NodePool has been updated for the release, so the
+	// release and the boot image are rolled out together.
+	isBootImageUpdateHeld := isUpdatingVersion && isBootImageUpdatePending(nodePool)
+	if isBootImageUpdateHeld {
+		SetStatusCondition(&nodePool.Status.Conditions, hyperv1.NodePoolCondition{
+			Type:               hyperv1.NodePoolUpdatingBootImageConditionType,
+			Status:             corev1.ConditionTrue,
+			Reason:             hyperv1.BootImageUpdatePendingReason,
+			Message:            fmt.Sprintf("Rollout of version %s is held until the boot image is updated for release %s", targetVersion, nodePool.Spec.Release.Image),
+			ObservedGeneration: nodePool.Generation,
+		})
+		log.Info("Waiting for the NodePool boot image to be updated for the release", "release", nodePool.Spec.Release.Image)
+	} else {
+		removeStatusCondition(&nodePool.Status.Conditions, hyperv1.NodePoolUpdatingBootImageConditionType)
+	}
+
 	// Signal ignition payload generation
-	targetPayloadConfigHash := supportutil.HashSimple(config + targetVersion + pullSecretName + globalConfig)
+	targetPayloadConfigHash := supportutil.HashSimple(hashedConfig + targetVersion + pullSecretName + globalConfig)
 	tokenSecret := TokenSecret(controlPlaneNamespace, nodePool.Name, targetPayloadConfigHash)
 	condition, err := r.createValidGeneratedPayloadCondition(ctx, tokenSecret, nodePool.Generation)
 	if err != nil {
@@ -742,6 +994,44 @@ func (r *NodePoolReconciler) reconcile(ctx context.Context, hcluster *hyperv1.Ho
 		return ctrl.Result{}, err
 	}
 
+	if err := r.setCapacityStatus(ctx, nodePool, hcluster); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	scaleUpBlocked, err := r.reconcileScaleUpPreflight(ctx, nodePool, hcluster, controlPlaneNamespace)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to run scale-up preflight checks: %w", err)
+	}
+	var scaleUpRequeueAfter time.Duration
+	if scaleUpBlocked {
+		scaleUpRequeueAfter = scaleUpPreflightInterval
+	}
+
+	// If the rollout is paused, awaiting approval or waiting for the boot image update we keep scaling and repairing
+	// but hold back the target config version.
+	rolloutPausedCondition, isRolloutGated, rolloutPausedFor := rolloutGate(log, nodePool, hcluster, targetPayloadConfigHash)
+	SetStatusCondition(&nodePool.Status.Conditions, rolloutPausedCondition)
+	if (isRolloutGated || isBootImageUpdateHeld) && isAutomatedMachineManagement(nodePool) {
+		paused := isRolloutGated && rolloutPausedCondition.Reason == hyperv1.RolloutPausedReason
+		if err := r.reconcileGatedRollout(ctx, nodePool, controlPlaneNamespace, paused); err != nil {
+			return ctrl.Result{}, err
+		}
+		if err := r.reconcileAutoRepair(ctx, log, nodePool, controlPlaneNamespace, infraID); err != nil {
+			return ctrl.Result{}, err
+		}
+		log.Info("Rollout gated", "reason", rolloutPausedCondition.Reason, "bootImageUpdateHeld", isBootImageUpdateHeld, "target", targetPayloadConfigHash)
+		requeueAfter := scaleUpRequeueAfter
+		// Resume the rollout once the pause of the HostedCluster ends.
+		if rolloutPausedFor > 0 && (requeueAfter == 0 || requeueAfter > rolloutPausedFor) {
+			requeueAfter = rolloutPausedFor
+		}
+		// Check the boot image update again periodically, in case the update of the NodePool is missed.
+		if isBootImageUpdateHeld && (requeueAfter == 0 || requeueAfter > bootImageUpdateCheckInterval) {
+			requeueAfter = bootImageUpdateCheckInterval
+		}
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+
 	// 2. - Reconcile towards expected state of the world.
 	compressedConfig, err := supportutil.CompressAndEncode([]byte(config))
 	if err != nil {
@@ -803,7 +1093,7 @@ func (r *NodePoolReconciler) reconcile(ctx context.Context, hcluster *hyperv1.Ho
 		return ctrl.Result{}, fmt.Errorf("failed to hash HostedCluster configuration: %w", err)
 	}
 	if result, err := r.CreateOrUpdate(ctx, r.Client, tokenSecret, func() error {
-		return reconcileTokenSecret(tokenSecret, nodePool, compressedConfig.Bytes(), pullSecretBytes, hcConfigurationHash)
+		return reconcileTokenSecret(tokenSecret, nodePool, compressedConfig.Bytes(), pullSecretBytes, hcConfigurationHash, sshKeyExcluded)
 	}); err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to reconcile token Secret: %w", err)
 	} else {
@@ -816,15 +1106,51 @@ func (r *NodePoolReconciler) reconcile(ctx context.Context, hcluster *hyperv1.Ho
 		return ctrl.Result{}, fmt.Errorf("token secret is missing token key")
 	}
 
+	bootstrapTrustBundle, err := r.getBootstrapTrustBundle(ctx, nodePool)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
 	userDataSecret := IgnitionUserDataSecret(controlPlaneNamespace, nodePool.GetName(), targetPayloadConfigHash)
 	if result, err := r.CreateOrUpdate(ctx, r.Client, userDataSecret, func() error {
-		return reconcileUserDataSecret(userDataSecret, nodePool, caCertBytes, tokenBytes, ignEndpoint, targetPayloadConfigHash, proxy)
+		return reconcileUserDataSecret(userDataSecret, nodePool, caCertBytes, tokenBytes, ignEndpoint, targetPayloadConfigHash, proxy, bootstrapTrustBundle)
 	}); err != nil {
 		return ctrl.Result{}, err
 	} else {
 		log.Info("Reconciled userData Secret", "result", result)
 	}
 
+	nodePoolPullSecret := NodePoolPullSecret(controlPlaneNamespace, nodePool.GetName())
+	if result, err := r.CreateOrUpdate(ctx, r.Client, nodePoolPullSecret, func() error {
+		return r.reconcileNodePoolPullSecret(ctx, nodePoolPullSecret, nodePool, pullSecretBytes)
+	}); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to reconcile NodePool pull secret: %w", err)
+	} else {
+		log.Info("Reconciled NodePool pull secret", "result", result)
+	}
+
+	nodePoolSSHKeySecret := NodePoolSSHKeySecret(controlPlaneNamespace, nodePool.GetName())
+	if sshKeyExcluded {
+		if result, err := r.CreateOrUpdate(ctx, r.Client, nodePoolSSHKeySecret, func() error {
+			return r.reconcileNodePoolSSHKeySecret(ctx, nodePoolSSHKeySecret, nodePool, hcluster)
+		}); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to reconcile NodePool SSH key secret: %w", err)
+		} else {
+			log.Info("Reconciled NodePool SSH key secret", "result", result)
+		}
+		SetStatusCondition(&nodePool.Status.Conditions, sshKeyPropagatedCondition(nodePool, nodePoolSSHKeySecret, isUpdatingConfig))
+	} else {
+		if _, err := supportutil.DeleteIfNeeded(ctx, r.Client, nodePoolSSHKeySecret); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to delete NodePool SSH key secret: %w", err)
+		}
+		SetStatusCondition(&nodePool.Status.Conditions, sshKeyPropagatedCondition(nodePool, nil, isUpdatingConfig))
+	}
+
+	if condition := additionalTrustBundlePropagatedCondition(nodePool, hcluster, isUpdatingConfig); condition != nil {
+		SetStatusCondition(&nodePool.Status.Conditions, *condition)
+	} else {
+		removeStatusCondition(&nodePool.Status.Conditions, hyperv1.NodePoolAdditionalTrustBundlePropagatedConditionType)
+	}
+
 	// Store new template hash.
 
 	// non automated infrastructure should not have any machine level cluster-api components
@@ -930,17 +1256,39 @@ func (r *NodePoolReconciler) reconcile(ctx context.Context, hcluster *hyperv1.Ho
 		}
 	}
 
+	agentReprovisioningRequeueAfter, err := r.reconcileAgentHostReprovisioning(ctx, hcluster, nodePool)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to reprovision released agents: %w", err)
+	}
+	requeueAfter := agentReprovisioningRequeueAfter
+	// Resolve the tag of the layered OS image again periodically to roll out its updates.
+	if nodePool.Spec.OSImage != nil && (requeueAfter == 0 || requeueAfter > osImageResolutionInterval) {
+		requeueAfter = osImageResolutionInterval
+	}
+	// Run the preflight checks of a blocked scale-up again periodically, the capacity they check can free up.
+	if scaleUpBlocked && (requeueAfter == 0 || requeueAfter > scaleUpRequeueAfter) {
+		requeueAfter = scaleUpRequeueAfter
+	}
+
+	if err := r.reconcileAutoRepair(ctx, log, nodePool, controlPlaneNamespace, infraID); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// reconcileAutoRepair creates the MachineHealthCheck of the NodePool when auto repair is enabled, and deletes it otherwise.
+func (r *NodePoolReconciler) reconcileAutoRepair(ctx context.Context, log logr.Logger, nodePool *hyperv1.NodePool, controlPlaneNamespace, infraID string) error {
 	mhc := machineHealthCheck(nodePool, controlPlaneNamespace)
 	if nodePool.Spec.Management.AutoRepair {
 		if c := FindStatusCondition(nodePool.Status.Conditions, hyperv1.NodePoolReachedIgnitionEndpoint); c == nil || c.Status != corev1.ConditionTrue {
 			log.Info("ReachedIgnitionEndpoint is false, MachineHealthCheck won't be created until this is true")
-			return ctrl.Result{}, nil
+			return nil
 		}
 
 		if result, err := ctrl.CreateOrUpdate(ctx, r.Client, mhc, func() error {
 			return r.reconcileMachineHealthCheck(mhc, nodePool, infraID)
 		}); err != nil {
-			return ctrl.Result{}, fmt.Errorf("failed to reconcile MachineHealthCheck %q: %w",
+			return fmt.Errorf("failed to reconcile MachineHealthCheck %q: %w",
 				client.ObjectKeyFromObject(mhc).String(), err)
 		} else {
 			log.Info("Reconciled MachineHealthCheck", "result", result)
@@ -954,11 +1302,11 @@ func (r *NodePoolReconciler) reconcile(ctx context.Context, hcluster *hyperv1.Ho
 	} else {
 		err := r.Get(ctx, client.ObjectKeyFromObject(mhc), mhc)
 		if err != nil && !apierrors.IsNotFound(err) {
-			return ctrl.Result{}, err
+			return err
 		}
 		if err == nil {
 			if err := r.Delete(ctx, mhc); err != nil && !apierrors.IsNotFound(err) {
-				return ctrl.Result{}, err
+				return err
 			}
 		}
 		SetStatusCondition(&nodePool.Status.Conditions, hyperv1.NodePoolCondition{
@@ -968,7 +1316,7 @@ func (r *NodePoolReconciler) reconcile(ctx context.Context, hcluster *hyperv1.Ho
 			ObservedGeneration: nodePool.Generation,
 		})
 	}
-	return ctrl.Result{}, nil
+	return nil
 }
 
 func isArchAndPlatformSupported(nodePool *hyperv1.NodePool) bool {
@@ -996,7 +1344,8 @@ func isArchAndPlatformSupported(nodePool *hyperv1.NodePool) bool {
 	return supported
 }
 
-// setMachineAndNodeConditions sets the nodePool's AllMachinesReady and AllNodesHealthy conditions.
+// setMachineAndNodeConditions sets the nodePool's AllMachinesReady and AllNodesHealthy conditions, and the summary of
+// its machines.
 func (r *NodePoolReconciler) setMachineAndNodeConditions(ctx context.Context, nodePool *hyperv1.NodePool, hc *hyperv1.HostedCluster) error {
 	// Get all Machines for NodePool.
 	machines, err := r.getMachinesForNodePool(ctx, nodePool)
@@ -1017,6 +1366,8 @@ func (r *NodePoolReconciler) setMachineAndNodeConditions(ctx context.Context, no
 
 	r.setCIDRConflictCondition(nodePool, machines, hc)
 
+	setMachinesStatus(nodePool, machines)
+
 	return nil
 }
 
@@ -1521,7 +1872,9 @@ func (r *NodePoolReconciler) deleteKubeVirtCache(ctx context.Context, nodePool *
 				}
 
 				ns := controlPlaneNamespace
-				if nodePool.Status.Platform.KubeVirt.Credentials != nil && len(nodePool.Status.Platform.KubeVirt.Credentials.InfraNamespace) > 0 {
+				if len(nodePool.Status.Platform.KubeVirt.InfraNamespace) > 0 {
+					ns = nodePool.Status.Platform.KubeVirt.InfraNamespace
+				} else if nodePool.Status.Platform.KubeVirt.Credentials != nil && len(nodePool.Status.Platform.KubeVirt.Credentials.InfraNamespace) > 0 {
 					ns = nodePool.Status.Platform.KubeVirt.Credentials.InfraNamespace
 				}
 
@@ -1549,7 +1902,7 @@ func (r *NodePoolReconciler) deleteNodePoolSecrets(ctx context.Context, nodePool
 	return nil
 }
 
-func reconcileUserDataSecret(userDataSecret *corev1.Secret, nodePool *hyperv1.NodePool, CA, token []byte, ignEndpoint, targetConfigVersionHash string, proxy *configv1.Proxy) error {
+func reconcileUserDataSecret(userDataSecret *corev1.Secret, nodePool *hyperv1.NodePool, CA, token []byte, ignEndpoint, targetConfigVersionHash string, proxy *configv1.Proxy, bootstrapTrustBundle string) error {
 	// The token secret controller deletes expired token Secrets.
 	// When that happens the NodePool controller reconciles and create a new one.
 	// Then it reconciles the userData Secret with the new generated token.
@@ -1563,7 +1916,7 @@ func reconcileUserDataSecret(userDataSecret *corev1.Secret, nodePool *hyperv1.No
 
 	encodedCACert := base64.StdEncoding.EncodeToString(CA)
 	encodedToken := base64.StdEncoding.EncodeToString(token)
-	ignConfig := ignConfig(encodedCACert, encodedToken, ignEndpoint, targetConfigVersionHash, proxy, nodePool)
+	ignConfig := ignConfig(encodedCACert, encodedToken, ignEndpoint, targetConfigVersionHash, proxy, nodePool, bootstrapTrustBundle)
 	userDataValue, err := json.Marshal(ignConfig)
 	if err != nil {
 		return fmt.Errorf("failed to marshal ignition config: %w", err)
@@ -1617,7 +1970,7 @@ func reconcilePerformanceProfileConfigMap(performanceProfileConfigMap *corev1.Co
 	return nil
 }
 
-func reconcileTokenSecret(tokenSecret *corev1.Secret, nodePool *hyperv1.NodePool, compressedConfig []byte, pullSecret []byte, hcConfigurationHash string) error {
+func reconcileTokenSecret(tokenSecret *corev1.Secret, nodePool *hyperv1.NodePool, compressedConfig []byte, pullSecret []byte, hcConfigurationHash string, sshKeyExcluded bool) error {
 	// The token secret controller updates expired token IDs for token Secrets.
 	// When that happens the NodePool controller reconciles the userData Secret with the new token ID.
 	// Therefore, this secret is mutable.
@@ -1638,9 +1991,53 @@ func reconcileTokenSecret(tokenSecret *corev1.Secret, nodePool *hyperv1.NodePool
 		tokenSecret.Data[TokenSecretTokenKey] = []byte(uuid.New().String())
 		tokenSecret.Data[TokenSecretReleaseKey] = []byte(nodePool.Spec.Release.Image)
 		tokenSecret.Data[TokenSecretConfigKey] = compressedConfig
-		tokenSecret.Data[TokenSecretPullSecretHashKey] = []byte(supportutil.HashSimple(pullSecret))
 		tokenSecret.Data[TokenSecretHCConfigurationHashKey] = []byte(hcConfigurationHash)
 	}
+	// The pull secret can be rotated without rolling out the NodePool,
+	// so the ignition server needs to regenerate the payload with the new one.
+	tokenSecret.Data[TokenSecretPullSecretHashKey] = []byte(supportutil.HashSimple(pullSecret))
+	// The SSH key is not part of the token Secret name when excluded from the config hash,
+	// so the config is re-rendered for the ignition server to generate the payload with the new key.
+	if sshKeyExcluded {
+		tokenSecret.Data[TokenSecretConfigKey] = compressedConfig
+	}
+	return nil
+}
+
+// reconcileNodePoolPullSecret reconciles the pull secret used by the Nodes of the NodePool, which is
+// the HostedCluster pull secret merged with the NodePool additional pull secrets.
+// It is synced into the guest cluster and onto the Nodes by the hosted cluster config operator.
+func (r *NodePoolReconciler) reconcileNodePoolPullSecret(ctx context.Context, secret *corev1.Secret, nodePool *hyperv1.NodePool, hcPullSecret []byte) error {
+	pullSecrets := [][]byte{hcPullSecret}
+	for _, ref := range nodePool.Spec.AdditionalPullSecrets {
+		additionalPullSecret := &corev1.Secret{}
+		if err := r.Get(ctx, client.ObjectKey{Namespace: nodePool.Namespace, Name: ref.Name}, additionalPullSecret); err != nil {
+			return fmt.Errorf("cannot get additional pull secret %s/%s: %w", nodePool.Namespace, ref.Name, err)
+		}
+		data, hasKey := additionalPullSecret.Data[corev1.DockerConfigJsonKey]
+		if !hasKey {
+			return fmt.Errorf("additional pull secret %s/%s missing %q key", nodePool.Namespace, ref.Name, corev1.DockerConfigJsonKey)
+		}
+		pullSecrets = append(pullSecrets, data)
+	}
+	merged, err := supportutil.MergePullSecrets(pullSecrets...)
+	if err != nil {
+		return fmt.Errorf("failed to merge pull secrets: %w", err)
+	}
+
+	if secret.Labels == nil {
+		secret.Labels = make(map[string]string)
+	}
+	secret.Labels[hyperv1.NodePoolPullSecretLabel] = "true"
+	secret.Labels[hyperv1.NodePoolLabel] = nodePool.GetName()
+	if secret.Annotations == nil {
+		secret.Annotations = make(map[string]string)
+	}
+	secret.Annotations[nodePoolAnnotation] = client.ObjectKeyFromObject(nodePool).String()
+	secret.Type = corev1.SecretTypeDockerConfigJson
+	secret.Data = map[string][]byte{
+		corev1.DockerConfigJsonKey: merged,
+	}
 	return nil
 }
 
@@ -1755,6 +2152,12 @@ func (r *NodePoolReconciler) reconcileMachineDeployment(log logr.Logger,
 			}
 
 			machine.Annotations[nodePoolAnnotationTaints] = taintsInJSON
+
+			// Propagate deletion hooks. They are left alone on Machines being deleted, so they are not
+			// added back once their owners remove them.
+			if machine.DeletionTimestamp.IsZero() {
+				setMachineDeletionHooks(machine.Annotations, nodePool.Spec.MachineDeletionHooks)
+			}
 			return nil
 		}); err != nil {
 			return fmt.Errorf("failed to reconcile Machine %q: %w",
@@ -1773,8 +2176,16 @@ func (r *NodePoolReconciler) reconcileMachineDeployment(log logr.Logger,
 			MaxSurge:       nodePool.Spec.Management.Replace.RollingUpdate.MaxSurge,
 		}
 	}
+	if agentAutomaticHostReprovisioning(nodePool) && machineDeployment.Spec.Strategy.Type == capiv1.RollingUpdateMachineDeploymentStrategyType {
+		machineDeployment.Spec.Strategy.RollingUpdate = agentReprovisioningRollingUpdate(machineDeployment.Spec.Strategy.RollingUpdate)
+	}
+
+	// Replace all the Machines once the oldest one reaches the maximum node lifetime.
+	machineDeployment.Spec.RolloutAfter = maxNodeLifetimeRolloutAfter(nodePool, machineList.Items)
 
 	setMachineDeploymentReplicas(nodePool, machineDeployment)
+	// Resume a MachineDeployment paused while the rollout was gated.
+	machineDeployment.Spec.Paused = false
 
 	isUpdating := false
 	// Propagate version and userData Secret to the machineDeployment.
@@ -1876,6 +2287,29 @@ func taintsToJSON(taints []hyperv1.Taint) (string, error) {
 	return string(taintsInJSON), nil
 }
 
+// setMachineDeletionHooks sets the CAPI deletion hook annotations of the given hooks, and removes the ones
+// previously set which are not in hooks anymore. The set hooks are tracked in nodePoolAnnotationMachineDeletionHooks.
+func setMachineDeletionHooks(annotations map[string]string, hooks []hyperv1.MachineDeletionHook) {
+	for _, key := range strings.Split(annotations[nodePoolAnnotationMachineDeletionHooks], ",") {
+		delete(annotations, key)
+	}
+	delete(annotations, nodePoolAnnotationMachineDeletionHooks)
+
+	var keys []string
+	for _, hook := range hooks {
+		prefix := capiv1.PreDrainDeleteHookAnnotationPrefix
+		if hook.Phase == hyperv1.MachineDeletionHookPhasePreTerminate {
+			prefix = capiv1.PreTerminateDeleteHookAnnotationPrefix
+		}
+		key := fmt.Sprintf("%s/%s", prefix, hook.Name)
+		annotations[key] = hook.Owner
+		keys = append(keys, key)
+	}
+	if len(keys) > 0 {
+		annotations[nodePoolAnnotationMachineDeletionHooks] = strings.Join(keys, ",")
+	}
+}
+
 func (r *NodePoolReconciler) reconcileMachineHealthCheck(mhc *capiv1.MachineHealthCheck,
 	nodePool *hyperv1.NodePool,
 	CAPIClusterName string) error {
@@ -1943,11 +2377,11 @@ func setMachineDeploymentReplicas(nodePool *hyperv1.NodePool, machineDeployment
 	if !isAutoscalingEnabled(nodePool) {
 		machineDeployment.Annotations[autoscalerMaxAnnotation] = "0"
 		machineDeployment.Annotations[autoscalerMinAnnotation] = "0"
-		machineDeployment.Spec.Replicas = k8sutilspointer.Int32(k8sutilspointer.Int32Deref(nodePool.Spec.Replicas, 0))
+		machineDeployment.Spec.Replicas = k8sutilspointer.Int32(wantedReplicas(nodePool, machineDeployment.Spec.Replicas))
 	}
 }
 
-func ignConfig(encodedCACert, encodedToken, endpoint, targetConfigVersionHash string, proxy *configv1.Proxy, nodePool *hyperv1.NodePool) ignitionapi.Config {
+func ignConfig(encodedCACert, encodedToken, endpoint, targetConfigVersionHash string, proxy *configv1.Proxy, nodePool *hyperv1.NodePool, bootstrapTrustBundle string) ignitionapi.Config {
 	cfg := ignitionapi.Config{
 		Ignition: ignitionapi.Ignition{
 			Version: "3.2.0",
@@ -1983,20 +2417,37 @@ func ignConfig(encodedCACert, encodedToken, endpoint, targetConfigVersionHash st
 			},
 		},
 	}
-	if proxy.Status.HTTPProxy != "" {
-		cfg.Ignition.Proxy.HTTPProxy = k8sutilspointer.String(proxy.Status.HTTPProxy)
+	if bootstrapTrustBundle != "" {
+		cfg.Ignition.Security.TLS.CertificateAuthorities = append(cfg.Ignition.Security.TLS.CertificateAuthorities, ignitionapi.Resource{
+			Source: k8sutilspointer.String(fmt.Sprintf("data:text/plain;base64,%s", base64.StdEncoding.EncodeToString([]byte(bootstrapTrustBundle)))),
+		})
+	}
+	proxyStatus := bootstrapProxy(proxy, nodePool)
+	if proxyStatus.HTTPProxy != "" {
+		cfg.Ignition.Proxy.HTTPProxy = k8sutilspointer.String(proxyStatus.HTTPProxy)
 	}
-	if proxy.Status.HTTPSProxy != "" {
-		cfg.Ignition.Proxy.HTTPSProxy = k8sutilspointer.String(proxy.Status.HTTPSProxy)
+	if proxyStatus.HTTPSProxy != "" {
+		cfg.Ignition.Proxy.HTTPSProxy = k8sutilspointer.String(proxyStatus.HTTPSProxy)
 	}
-	if proxy.Status.NoProxy != "" {
-		for _, item := range strings.Split(proxy.Status.NoProxy, ",") {
+	if proxyStatus.NoProxy != "" {
+		for _, item := range strings.Split(proxyStatus.NoProxy, ",") {
 			cfg.Ignition.Proxy.NoProxy = append(cfg.Ignition.Proxy.NoProxy, ignitionapi.NoProxyItem(item))
 		}
 	}
 	return cfg
 }
 
+// expectedCoreConfigResources returns the number of core config resources created for the NodePools of the HostedCluster:
+// 3 generic core config resources: fips, ssh and haproxy.
+func expectedCoreConfigResources(hcluster *hyperv1.HostedCluster) int {
+	expected := 3
+	if len(hcluster.Spec.ImageContentSources) > 0 {
+		// additional core config resource created when image content source specified.
+		expected += 1
+	}
+	return expected
+}
+
 func (r *NodePoolReconciler) getConfig(ctx context.Context,
 	nodePool *hyperv1.NodePool,
 	expectedCoreConfigResources int,
@@ -2038,6 +2489,45 @@ func (r *NodePoolReconciler) getConfig(ctx context.Context,
 		}
 	}
 
+	ntpConfig, err := ntpMachineConfig(nodePool)
+	if err != nil {
+		errors = append(errors, err)
+	} else if ntpConfig != "" {
+		allConfigPlainText = append(allConfigPlainText, ntpConfig)
+	}
+
+	runtimeConfig, err := containerRuntimeConfig(nodePool)
+	if err != nil {
+		errors = append(errors, err)
+	} else if runtimeConfig != "" {
+		allConfigPlainText = append(allConfigPlainText, runtimeConfig)
+	}
+
+	osImageConfig, err := osImageMachineConfig(nodePool.Status.OSImage)
+	if err != nil {
+		errors = append(errors, err)
+	} else if osImageConfig != "" {
+		allConfigPlainText = append(allConfigPlainText, osImageConfig)
+	}
+
+	cgroupConfig, err := cgroupModeMachineConfig(nodePool)
+	if err != nil {
+		errors = append(errors, err)
+	} else if cgroupConfig != "" {
+		allConfigPlainText = append(allConfigPlainText, cgroupConfig)
+	}
+
+	if distributesAdditionalTrustBundle(nodePool) {
+		trustBundle, err := r.getAdditionalTrustBundle(ctx, hcluster)
+		if err != nil {
+			errors = append(errors, err)
+		} else if trustBundleConfig, err := additionalTrustBundleMachineConfig(trustBundle); err != nil {
+			errors = append(errors, err)
+		} else if trustBundleConfig != "" {
+			allConfigPlainText = append(allConfigPlainText, trustBundleConfig)
+		}
+	}
+
 	coreConfigMapList := &corev1.ConfigMapList{}
 	if err := r.List(ctx, coreConfigMapList, client.MatchingLabels{
 		nodePoolCoreIgnitionConfigLabel: "true",
@@ -2289,6 +2779,15 @@ func encode(obj runtime.Object, ser *serializer.Serializer) ([]byte, error) {
 	return buff.Bytes(), nil
 }
 
+// releaseImageArchitectures returns the CPU architectures provided by the release image of the NodePool.
+func (r *NodePoolReconciler) releaseImageArchitectures(ctx context.Context, hostedCluster *hyperv1.HostedCluster, nodePool *hyperv1.NodePool) ([]string, error) {
+	pullSecretBytes, err := r.getPullSecretBytes(ctx, hostedCluster)
+	if err != nil {
+		return nil, err
+	}
+	return r.ImageMetadataProvider.ImageArchitectures(ctx, nodePool.Spec.Release.Image, pullSecretBytes)
+}
+
 func (r *NodePoolReconciler) getReleaseImage(ctx context.Context, hostedCluster *hyperv1.HostedCluster, currentVersion string, releaseImage string) (*releaseinfo.ReleaseImage, error) {
 	pullSecretBytes, err := r.getPullSecretBytes(ctx, hostedCluster)
 	if err != nil {
@@ -2346,6 +2845,31 @@ func isUpdatingMachineTemplate(nodePool *hyperv1.NodePool, targetMachineTemplate
 	return targetMachineTemplate != nodePool.GetAnnotations()[nodePoolAnnotationPlatformMachineTemplate]
 }
 
+// UpdatesBootImage returns true if the boot image of the NodePool is updated with its release.
+func UpdatesBootImage(nodePool *hyperv1.NodePool) bool {
+	if nodePool.Spec.BootImageUpdatePolicy != hyperv1.BootImageUpdatePolicyAutomatic {
+		return false
+	}
+	switch nodePool.Spec.Platform.Type {
+	case hyperv1.AWSPlatform:
+		// The AMI lookup of the NodePool is resolved with its release instead.
+		return nodePool.Spec.Platform.AWS != nil && nodePool.Spec.Platform.AWS.AMILookup == nil
+	case hyperv1.AzurePlatform:
+		return nodePool.Spec.Platform.Azure != nil
+	}
+	return false
+}
+
+// bootImageUpdateCheckInterval is how often a NodePool whose version rollout is held for its boot image update checks
+// whether the boot image has been updated.
+const bootImageUpdateCheckInterval = time.Minute
+
+// isBootImageUpdatePending returns true if the NodePool boot image is updated automatically and has not been
+// updated yet for the NodePool release.
+func isBootImageUpdatePending(nodePool *hyperv1.NodePool) bool {
+	return UpdatesBootImage(nodePool) && nodePool.Annotations[hyperv1.NodePoolBootImageReleaseAnnotation] != nodePool.Spec.Release.Image
+}
+
 func isAutoscalingEnabled(nodePool *hyperv1.NodePool) bool {
 	return nodePool.Spec.AutoScaling != nil
 }
@@ -2417,6 +2941,49 @@ func (r *NodePoolReconciler) enqueueNodePoolsForHostedCluster(ctx context.Contex
 	return result
 }
 
+func (r *NodePoolReconciler) enqueueNodePoolsForSecret(ctx context.Context, obj client.Object) []reconcile.Request {
+	// Secrets generated by the NodePool controller are reconciled by their parent NodePool.
+	if result := enqueueParentNodePool(ctx, obj); len(result) > 0 {
+		return result
+	}
+
+	var result []reconcile.Request
+	hcList := &hyperv1.HostedClusterList{}
+	if err := r.List(ctx, hcList, client.InNamespace(obj.GetNamespace())); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "Failed to list HostedClusters")
+		return result
+	}
+	pullSecretClusters := sets.New[string]()
+	for _, hc := range hcList.Items {
+		if hc.Spec.PullSecret.Name == obj.GetName() {
+			pullSecretClusters.Insert(hc.Name)
+		}
+	}
+
+	nodePoolList := &hyperv1.NodePoolList{}
+	if err := r.List(ctx, nodePoolList, client.InNamespace(obj.GetNamespace())); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "Failed to list nodePools")
+		return result
+	}
+
+	// Requeue NodePools whose HostedCluster pull secret or additional pull secrets match the Secret.
+	for key := range nodePoolList.Items {
+		nodePool := &nodePoolList.Items[key]
+		reconcileNodePool := pullSecretClusters.Has(nodePool.Spec.ClusterName)
+		for _, ref := range nodePool.Spec.AdditionalPullSecrets {
+			if ref.Name == obj.GetName() {
+				reconcileNodePool = true
+				break
+			}
+		}
+		if reconcileNodePool {
+			result = append(result, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(nodePool)})
+		}
+	}
+
+	return result
+}
+
 func (r *NodePoolReconciler) enqueueNodePoolsForConfig(ctx context.Context, obj client.Object) []reconcile.Request {
 	var result []reconcile.Request
 
@@ -2461,9 +3028,29 @@ func (r *NodePoolReconciler) enqueueNodePoolsForConfig(ctx context.Context, obj
 		return enqueueParentNodePool(ctx, obj)
 	}
 
+	// If the ConfigMap is the image additionalTrustedCA or the additionalTrustBundle of a HostedCluster, reconcile the
+	// NodePools of that HostedCluster.
+	hostedClusterList := &hyperv1.HostedClusterList{}
+	if err := r.List(ctx, hostedClusterList, client.InNamespace(cm.Namespace)); err == nil {
+		for _, hc := range hostedClusterList.Items {
+			isImageAdditionalTrustedCA := hc.Spec.Configuration != nil && hc.Spec.Configuration.Image != nil && hc.Spec.Configuration.Image.AdditionalTrustedCA.Name == cm.Name
+			isAdditionalTrustBundle := hc.Spec.AdditionalTrustBundle != nil && hc.Spec.AdditionalTrustBundle.Name == cm.Name
+			if !isImageAdditionalTrustedCA && !isAdditionalTrustBundle {
+				continue
+			}
+			for key := range nodePoolList.Items {
+				if nodePoolList.Items[key].Spec.ClusterName == hc.Name {
+					result = append(result,
+						reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&nodePoolList.Items[key])},
+					)
+				}
+			}
+		}
+	}
+
 	// Otherwise reconcile NodePools which are referencing the given ConfigMap.
 	for key := range nodePoolList.Items {
-		reconcileNodePool := false
+		reconcileNodePool := referencesBootstrapTrustBundle(&nodePoolList.Items[key], cm.Name)
 		for _, v := range nodePoolList.Items[key].Spec.Config {
 			if v.Name == cm.Name {
 				reconcileNodePool = true
@@ -2102,6 +2102,113 @@ func TestSetExpirationTimestampOnToken(t *testing.T) {
 	}
 }
 
+func TestReconcileNodePoolPullSecret(t *testing.T) {
+	hcPullSecret := []byte(`{"auths":{"quay.io":{"auth":"aGM6cHVsbA=="}}}`)
+	additionalPullSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "extra", Namespace: "clusters"},
+		Data: map[string][]byte{
+			corev1.DockerConfigJsonKey: []byte(`{"auths":{"registry.example.com":{"auth":"ZXh0cmE6cHVsbA=="}}}`),
+		},
+	}
+	invalidPullSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "invalid", Namespace: "clusters"},
+		Data: map[string][]byte{
+			"config.json": []byte(`{}`),
+		},
+	}
+
+	testCases := []struct {
+		name           string
+		additional     []corev1.LocalObjectReference
+		expectedConfig string
+		expectedErr    bool
+	}{
+		{
+			name:           "When there are no additional pull secrets it should contain the HostedCluster pull secret",
+			expectedConfig: `{"auths":{"quay.io":{"auth":"aGM6cHVsbA=="}}}`,
+		},
+		{
+			name:           "When there are additional pull secrets they should be merged with the HostedCluster pull secret",
+			additional:     []corev1.LocalObjectReference{{Name: "extra"}},
+			expectedConfig: `{"auths":{"quay.io":{"auth":"aGM6cHVsbA=="},"registry.example.com":{"auth":"ZXh0cmE6cHVsbA=="}}}`,
+		},
+		{
+			name:        "When an additional pull secret does not exist it should fail",
+			additional:  []corev1.LocalObjectReference{{Name: "missing"}},
+			expectedErr: true,
+		},
+		{
+			name:        "When an additional pull secret has no dockerconfigjson key it should fail",
+			additional:  []corev1.LocalObjectReference{{Name: "invalid"}},
+			expectedErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			nodePool := &hyperv1.NodePool{
+				ObjectMeta: metav1.ObjectMeta{Name: "np", Namespace: "clusters"},
+				Spec:       hyperv1.NodePoolSpec{AdditionalPullSecrets: tc.additional},
+			}
+			r := &NodePoolReconciler{
+				Client: fake.NewClientBuilder().WithObjects(additionalPullSecret, invalidPullSecret).Build(),
+			}
+			secret := NodePoolPullSecret("clusters-hc", nodePool.Name)
+			err := r.reconcileNodePoolPullSecret(context.Background(), secret, nodePool, hcPullSecret)
+			if tc.expectedErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(secret.Type).To(Equal(corev1.SecretTypeDockerConfigJson))
+			g.Expect(string(secret.Data[corev1.DockerConfigJsonKey])).To(MatchJSON(tc.expectedConfig))
+			g.Expect(secret.Labels).To(HaveKeyWithValue(hyperv1.NodePoolLabel, "np"))
+			g.Expect(secret.Labels).To(HaveKey(hyperv1.NodePoolPullSecretLabel))
+			g.Expect(secret.Annotations).To(HaveKeyWithValue(nodePoolAnnotation, "clusters/np"))
+		})
+	}
+}
+
+func TestEnqueueNodePoolsForSecret(t *testing.T) {
+	g := NewWithT(t)
+	hc := &hyperv1.HostedCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "hc", Namespace: "clusters"},
+		Spec:       hyperv1.HostedClusterSpec{PullSecret: corev1.LocalObjectReference{Name: "pull-secret"}},
+	}
+	nodePools := []client.Object{
+		&hyperv1.NodePool{
+			ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "clusters"},
+			Spec:       hyperv1.NodePoolSpec{ClusterName: "hc"},
+		},
+		&hyperv1.NodePool{
+			ObjectMeta: metav1.ObjectMeta{Name: "extra", Namespace: "clusters"},
+			Spec: hyperv1.NodePoolSpec{
+				ClusterName:           "hc",
+				AdditionalPullSecrets: []corev1.LocalObjectReference{{Name: "extra-pull-secret"}},
+			},
+		},
+	}
+	r := &NodePoolReconciler{
+		Client: fake.NewClientBuilder().WithScheme(api.Scheme).WithObjects(append(nodePools, hc)...).Build(),
+	}
+	requestsFor := func(secret *corev1.Secret) []string {
+		var names []string
+		for _, request := range r.enqueueNodePoolsForSecret(context.Background(), secret) {
+			names = append(names, request.Name)
+		}
+		return names
+	}
+
+	g.Expect(requestsFor(&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "pull-secret", Namespace: "clusters"}})).To(ConsistOf("default", "extra"))
+	g.Expect(requestsFor(&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "extra-pull-secret", Namespace: "clusters"}})).To(ConsistOf("extra"))
+	g.Expect(requestsFor(&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "unrelated", Namespace: "clusters"}})).To(BeEmpty())
+	g.Expect(requestsFor(&corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+		Name:        "token-default-abc",
+		Namespace:   "clusters-hc",
+		Annotations: map[string]string{nodePoolAnnotation: "clusters/default"},
+	}})).To(ConsistOf("default"))
+}
+
 func TestNodepoolDeletionDoesntRequireHCluster(t *testing.T) {
 	nodePool := &hyperv1.NodePool{
 		ObjectMeta: metav1.ObjectMeta{
@@ -2364,6 +2471,70 @@ func TestTaintsToJSON(t *testing.T) {
 	}
 }
 
+func TestSetMachineDeletionHooks(t *testing.T) {
+	preDrainHook := capiv1.PreDrainDeleteHookAnnotationPrefix + "/storage"
+	preTerminateHook := capiv1.PreTerminateDeleteHookAnnotationPrefix + "/cnf"
+	testCases := []struct {
+		name        string
+		annotations map[string]string
+		hooks       []hyperv1.MachineDeletionHook
+		expected    map[string]string
+	}{
+		{
+			name:        "When there are no hooks it should not set any annotation",
+			annotations: map[string]string{"foo": "bar"},
+			expected:    map[string]string{"foo": "bar"},
+		},
+		{
+			name:        "When hooks are added it should set their annotations",
+			annotations: map[string]string{"foo": "bar"},
+			hooks: []hyperv1.MachineDeletionHook{
+				{Name: "storage", Phase: hyperv1.MachineDeletionHookPhasePreDrain, Owner: "storage-operator"},
+				{Name: "cnf", Phase: hyperv1.MachineDeletionHookPhasePreTerminate, Owner: "cnf-operator"},
+			},
+			expected: map[string]string{
+				"foo":                                  "bar",
+				preDrainHook:                           "storage-operator",
+				preTerminateHook:                       "cnf-operator",
+				nodePoolAnnotationMachineDeletionHooks: preDrainHook + "," + preTerminateHook,
+			},
+		},
+		{
+			name: "When a hook is removed it should remove its annotation and keep the ones not set by the NodePool",
+			annotations: map[string]string{
+				capiv1.PreDrainDeleteHookAnnotationPrefix + "/external": "external-operator",
+				preDrainHook:                           "storage-operator",
+				preTerminateHook:                       "cnf-operator",
+				nodePoolAnnotationMachineDeletionHooks: preDrainHook + "," + preTerminateHook,
+			},
+			hooks: []hyperv1.MachineDeletionHook{
+				{Name: "cnf", Phase: hyperv1.MachineDeletionHookPhasePreTerminate, Owner: "cnf-operator"},
+			},
+			expected: map[string]string{
+				capiv1.PreDrainDeleteHookAnnotationPrefix + "/external": "external-operator",
+				preTerminateHook:                       "cnf-operator",
+				nodePoolAnnotationMachineDeletionHooks: preTerminateHook,
+			},
+		},
+		{
+			name: "When all hooks are removed it should remove the tracking annotation",
+			annotations: map[string]string{
+				preDrainHook:                           "storage-operator",
+				nodePoolAnnotationMachineDeletionHooks: preDrainHook,
+			},
+			expected: map[string]string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			setMachineDeletionHooks(tc.annotations, tc.hooks)
+			g.Expect(tc.annotations).To(Equal(tc.expected))
+		})
+	}
+}
+
 func TestDefaultNodePoolAMI(t *testing.T) {
 	testCases := []struct {
 		name          string
@@ -3142,3 +3313,91 @@ func TestIsArchAndPlatformSupported(t *testing.T) {
 		})
 	}
 }
+
+func TestIsBootImageUpdatePending(t *testing.T) {
+	const (
+		release         = "quay.io/openshift-release-dev/ocp-release:4.16.0-x86_64"
+		previousRelease = "quay.io/openshift-release-dev/ocp-release:4.15.0-x86_64"
+	)
+	testCases := []struct {
+		name        string
+		platform    hyperv1.NodePoolPlatform
+		policy      hyperv1.BootImageUpdatePolicy
+		annotations map[string]string
+		expected    bool
+	}{
+		{
+			name: "When the policy is Manual it should not be pending",
+			platform: hyperv1.NodePoolPlatform{
+				Type: hyperv1.AWSPlatform,
+				AWS:  &hyperv1.AWSNodePoolPlatform{},
+			},
+			policy: hyperv1.BootImageUpdatePolicyManual,
+		},
+		{
+			name: "When the policy is Automatic and the boot image was not updated for the release it should be pending",
+			platform: hyperv1.NodePoolPlatform{
+				Type: hyperv1.AWSPlatform,
+				AWS:  &hyperv1.AWSNodePoolPlatform{},
+			},
+			policy: hyperv1.BootImageUpdatePolicyAutomatic,
+			annotations: map[string]string{
+				hyperv1.NodePoolBootImageReleaseAnnotation: previousRelease,
+			},
+			expected: true,
+		},
+		{
+			name: "When the policy is Automatic and the boot image was updated for the release it should not be pending",
+			platform: hyperv1.NodePoolPlatform{
+				Type:  hyperv1.AzurePlatform,
+				Azure: &hyperv1.AzureNodePoolPlatform{},
+			},
+			policy: hyperv1.BootImageUpdatePolicyAutomatic,
+			annotations: map[string]string{
+				hyperv1.NodePoolBootImageReleaseAnnotation: release,
+			},
+		},
+		{
+			name: "When the policy is Automatic and the AMI is looked up with the release it should not be pending",
+			platform: hyperv1.NodePoolPlatform{
+				Type: hyperv1.AWSPlatform,
+				AWS: &hyperv1.AWSNodePoolPlatform{
+					AMILookup: &hyperv1.AWSAMILookup{},
+				},
+			},
+			policy: hyperv1.BootImageUpdatePolicyAutomatic,
+			annotations: map[string]string{
+				hyperv1.NodePoolBootImageReleaseAnnotation: previousRelease,
+			},
+		},
+		{
+			name:     "When the AWS platform is not set it should not be pending",
+			platform: hyperv1.NodePoolPlatform{Type: hyperv1.AWSPlatform},
+			policy:   hyperv1.BootImageUpdatePolicyAutomatic,
+		},
+		{
+			name:     "When the Azure platform is not set it should not be pending",
+			platform: hyperv1.NodePoolPlatform{Type: hyperv1.AzurePlatform},
+			policy:   hyperv1.BootImageUpdatePolicyAutomatic,
+		},
+		{
+			name:     "When the platform has no boot image to update it should not be pending",
+			platform: hyperv1.NodePoolPlatform{Type: hyperv1.KubevirtPlatform},
+			policy:   hyperv1.BootImageUpdatePolicyAutomatic,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			nodePool := &hyperv1.NodePool{
+				ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations},
+				Spec: hyperv1.NodePoolSpec{
+					Release:               hyperv1.Release{Image: release},
+					BootImageUpdatePolicy: tc.policy,
+					Platform:              tc.platform,
+				},
+			}
+			g.Expect(isBootImageUpdatePending(nodePool)).To(Equal(tc.expected))
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package nodepool
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/clarketm/json"
+	ignitionapi "github.com/coreos/ignition/v2/config/v3_2/types"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/ignition"
+	"github.com/openshift/hypershift/hypershift-operator/controllers/manifests"
+	api "github.com/openshift/hypershift/support/api"
+	mcfgv1 "github.com/openshift/hypershift/thirdparty/machineconfigoperator/pkg/apis/machineconfiguration.openshift.io/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const chronyConfigPath = "/etc/chrony.conf"
+
+// chronyConfigTemplate is the default chrony configuration of RHCOS, with the
+// pool and DHCP provided time sources replaced by the NodePool NTP servers.
+const chronyConfigTemplate = `%s
+driftfile /var/lib/chrony/drift
+makestep 1.0 3
+rtcsync
+keyfile /etc/chrony.keys
+ntsdumpdir /var/lib/chrony
+leapsectz right/UTC
+logdir /var/log/chrony
+`
+
+// ntpMachineConfig returns the serialized MachineConfig rendering the NodePool
+// NTP servers into the chrony configuration of the nodes, or an empty string
+// when the NodePool doesn't set any.
+func ntpMachineConfig(nodePool *hyperv1.NodePool) (string, error) {
+	if len(nodePool.Spec.NTPServers) == 0 {
+		return "", nil
+	}
+
+	var servers []string
+	for _, server := range nodePool.Spec.NTPServers {
+		if net.ParseIP(server) == nil && len(validation.IsDNS1123Subdomain(strings.ToLower(server))) > 0 {
+			return "", fmt.Errorf("ntp server %q is not a valid hostname or IP address", server)
+		}
+		servers = append(servers, fmt.Sprintf("server %s iburst", server))
+	}
+
+	config := &ignitionapi.Config{}
+	config.Ignition.Version = ignitionapi.MaxVersion.String()
+	config.Storage.Files = []ignitionapi.File{
+		fileFromBytes(chronyConfigPath, 0644, []byte(fmt.Sprintf(chronyConfigTemplate, strings.Join(servers, "\n")))),
+	}
+	serializedConfig, err := json.Marshal(config)
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize chrony ignition config: %w", err)
+	}
+
+	machineConfig := manifests.MachineConfigChrony()
+	ignition.SetMachineConfigLabels(machineConfig)
+	machineConfig.Spec.Config.Raw = serializedConfig
+
+	buf := &bytes.Buffer{}
+	machineConfig.APIVersion = mcfgv1.SchemeGroupVersion.String()
+	machineConfig.Kind = "MachineConfig"
+	if err := api.YamlSerializer.Encode(machineConfig, buf); err != nil {
+		return "", fmt.Errorf("failed to serialize chrony machine config: %w", err)
+	}
+	return buf.String(), nil
+}
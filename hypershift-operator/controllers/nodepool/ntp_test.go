@@ -0,0 +1,66 @@
+package nodepool
+
+import (
+	"encoding/json"
+	"testing"
+
+	ignitionapi "github.com/coreos/ignition/v2/config/v3_2/types"
+	. "github.com/onsi/gomega"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	api "github.com/openshift/hypershift/support/api"
+	mcfgv1 "github.com/openshift/hypershift/thirdparty/machineconfigoperator/pkg/apis/machineconfiguration.openshift.io/v1"
+	"github.com/vincent-petithory/dataurl"
+)
+
+func TestNTPMachineConfig(t *testing.T) {
+	testCases := []struct {
+		name            string
+		ntpServers      []string
+		expectedServers string
+		expectError     bool
+	}{
+		{
+			name: "When no NTP servers are set it should not render a MachineConfig",
+		},
+		{
+			name:            "When NTP servers are set it should render them into the chrony configuration",
+			ntpServers:      []string{"ntp.example.com", "10.0.0.1", "fd00::1"},
+			expectedServers: "server ntp.example.com iburst\nserver 10.0.0.1 iburst\nserver fd00::1 iburst\n",
+		},
+		{
+			name:        "When an NTP server is not a hostname or IP address it should fail",
+			ntpServers:  []string{"ntp.example.com\nallow all"},
+			expectError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			nodePool := &hyperv1.NodePool{Spec: hyperv1.NodePoolSpec{NTPServers: tc.ntpServers}}
+
+			config, err := ntpMachineConfig(nodePool)
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			if tc.expectedServers == "" {
+				g.Expect(config).To(BeEmpty())
+				return
+			}
+
+			machineConfig := &mcfgv1.MachineConfig{}
+			_, _, err = api.YamlSerializer.Decode([]byte(config), nil, machineConfig)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(machineConfig.Labels).To(HaveKeyWithValue("machineconfiguration.openshift.io/role", "worker"))
+
+			ignitionConfig := &ignitionapi.Config{}
+			g.Expect(json.Unmarshal(machineConfig.Spec.Config.Raw, ignitionConfig)).To(Succeed())
+			g.Expect(ignitionConfig.Storage.Files).To(HaveLen(1))
+			g.Expect(ignitionConfig.Storage.Files[0].Path).To(Equal(chronyConfigPath))
+			contents, err := dataurl.DecodeString(*ignitionConfig.Storage.Files[0].Contents.Source)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(string(contents.Data)).To(HavePrefix(tc.expectedServers))
+		})
+	}
+}
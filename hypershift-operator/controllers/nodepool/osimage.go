@@ -0,0 +1,86 @@
+package nodepool
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/clarketm/json"
+	ignitionapi "github.com/coreos/ignition/v2/config/v3_2/types"
+	"github.com/opencontainers/go-digest"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/ignition"
+	"github.com/openshift/hypershift/hypershift-operator/controllers/manifests"
+	api "github.com/openshift/hypershift/support/api"
+	"github.com/openshift/hypershift/support/releaseinfo/registryclient"
+	"github.com/openshift/hypershift/support/thirdparty/library-go/pkg/image/reference"
+	supportutil "github.com/openshift/hypershift/support/util"
+	mcfgv1 "github.com/openshift/hypershift/thirdparty/machineconfigoperator/pkg/apis/machineconfiguration.openshift.io/v1"
+)
+
+// osImageResolutionInterval is how often the tag of the layered OS image of a NodePool is resolved again, so the
+// NodePool rolls out the image the tag points to.
+const osImageResolutionInterval = 10 * time.Minute
+
+// resolveOSImage returns the pull spec of the layered OS image of the NodePool pinned to the digest its tag points
+// to. NodePools sharing the image and the pull secret share the resolution until it expires.
+func (r *NodePoolReconciler) resolveOSImage(ctx context.Context, nodePool *hyperv1.NodePool, hcluster *hyperv1.HostedCluster) (string, error) {
+	image := nodePool.Spec.OSImage.Image
+	ref, err := reference.Parse(image)
+	if err != nil {
+		return "", fmt.Errorf("invalid layered OS image %q: %w", image, err)
+	}
+	if len(ref.ID) > 0 {
+		ref.Tag = ""
+		return ref.Exact(), nil
+	}
+
+	pullSecret, err := r.getPullSecretBytes(ctx, hcluster)
+	if err != nil {
+		return "", err
+	}
+	resolveDigest := r.resolveImageDigest
+	if resolveDigest == nil {
+		resolveDigest = registryclient.GetDigest
+	}
+	key := cloudLookupKey{kind: cloudLookupKindOSImage, credentials: supportutil.HashSimple(pullSecret), query: image}
+	result, err := r.cloudLookups.get(key, osImageResolutionInterval, func() (interface{}, error) {
+		return resolveDigest(ctx, image, pullSecret)
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve the digest of layered OS image %s: %w", image, err)
+	}
+	ref.Tag = ""
+	ref.ID = result.(digest.Digest).String()
+	return ref.Exact(), nil
+}
+
+// osImageMachineConfig returns the serialized MachineConfig pointing the nodes to the layered OS image, or an empty
+// string when the NodePool runs the RHEL CoreOS image of its release. The nodes rebase onto the image on their first
+// boot, and a change of the image is rolled out like any other config change.
+func osImageMachineConfig(osImage string) (string, error) {
+	if osImage == "" {
+		return "", nil
+	}
+
+	config := &ignitionapi.Config{}
+	config.Ignition.Version = ignitionapi.MaxVersion.String()
+	serializedConfig, err := json.Marshal(config)
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize layered OS image ignition config: %w", err)
+	}
+
+	machineConfig := manifests.MachineConfigLayeredOSImage()
+	ignition.SetMachineConfigLabels(machineConfig)
+	machineConfig.Spec.Config.Raw = serializedConfig
+	machineConfig.Spec.OSImageURL = osImage
+
+	buf := &bytes.Buffer{}
+	machineConfig.APIVersion = mcfgv1.SchemeGroupVersion.String()
+	machineConfig.Kind = "MachineConfig"
+	if err := api.YamlSerializer.Encode(machineConfig, buf); err != nil {
+		return "", fmt.Errorf("failed to serialize layered OS image machine config: %w", err)
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,109 @@
+package nodepool
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/opencontainers/go-digest"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	api "github.com/openshift/hypershift/support/api"
+	mcfgv1 "github.com/openshift/hypershift/thirdparty/machineconfigoperator/pkg/apis/machineconfiguration.openshift.io/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestResolveOSImage(t *testing.T) {
+	const imageDigest = "sha256:0d4a9a7d2ac1f0c7e8c7d2e2b8a5e59d5e1f3f2b3a9c0b6c2d1e4f5a6b7c8d9e"
+
+	hcluster := &hyperv1.HostedCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "example"},
+		Spec:       hyperv1.HostedClusterSpec{PullSecret: corev1.LocalObjectReference{Name: "pull-secret"}},
+	}
+	pullSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "pull-secret"},
+		Data:       map[string][]byte{corev1.DockerConfigJsonKey: []byte("{}")},
+	}
+
+	testCases := []struct {
+		name          string
+		image         string
+		resolveErr    error
+		expected      string
+		expectedCalls int
+		expectError   bool
+	}{
+		{
+			name:          "When the image is referenced by tag it should pin it to the digest of the tag",
+			image:         "quay.io/example/custom-os:latest",
+			expected:      "quay.io/example/custom-os@" + imageDigest,
+			expectedCalls: 1,
+		},
+		{
+			name:     "When the image is referenced by digest it should not look it up",
+			image:    "quay.io/example/custom-os:latest@" + imageDigest,
+			expected: "quay.io/example/custom-os@" + imageDigest,
+		},
+		{
+			name:          "When the tag can't be resolved it should fail",
+			image:         "quay.io/example/custom-os:latest",
+			resolveErr:    fmt.Errorf("unauthorized"),
+			expectedCalls: 1,
+			expectError:   true,
+		},
+		{
+			name:        "When the image is not a valid pull spec it should fail",
+			image:       "quay.io/example/Custom OS",
+			expectError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			calls := 0
+			r := &NodePoolReconciler{
+				Client:       fake.NewClientBuilder().WithScheme(api.Scheme).WithObjects(hcluster, pullSecret).Build(),
+				cloudLookups: newCloudLookupCache(),
+				resolveImageDigest: func(ctx context.Context, imageRef string, pullSecret []byte) (digest.Digest, error) {
+					calls++
+					if tc.resolveErr != nil {
+						return "", tc.resolveErr
+					}
+					return imageDigest, nil
+				},
+			}
+			nodePool := &hyperv1.NodePool{Spec: hyperv1.NodePoolSpec{OSImage: &hyperv1.NodePoolOSImage{Image: tc.image}}}
+
+			// Resolve twice, the second resolution is served from the cache.
+			for i := 0; i < 2; i++ {
+				osImage, err := r.resolveOSImage(context.Background(), nodePool, hcluster)
+				if tc.expectError {
+					g.Expect(err).To(HaveOccurred())
+					return
+				}
+				g.Expect(err).ToNot(HaveOccurred())
+				g.Expect(osImage).To(Equal(tc.expected))
+			}
+			g.Expect(calls).To(Equal(tc.expectedCalls))
+		})
+	}
+}
+
+func TestOSImageMachineConfig(t *testing.T) {
+	g := NewWithT(t)
+
+	config, err := osImageMachineConfig("")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(config).To(BeEmpty())
+
+	osImage := "quay.io/example/custom-os@sha256:0d4a9a7d2ac1f0c7e8c7d2e2b8a5e59d5e1f3f2b3a9c0b6c2d1e4f5a6b7c8d9e"
+	config, err = osImageMachineConfig(osImage)
+	g.Expect(err).ToNot(HaveOccurred())
+	machineConfig := &mcfgv1.MachineConfig{}
+	_, _, err = api.YamlSerializer.Decode([]byte(config), nil, machineConfig)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(machineConfig.Labels).To(HaveKeyWithValue("machineconfiguration.openshift.io/role", "worker"))
+	g.Expect(machineConfig.Spec.OSImageURL).To(Equal(osImage))
+}
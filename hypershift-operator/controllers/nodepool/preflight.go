@@ -0,0 +1,186 @@
+package nodepool
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	k8sutilspointer "k8s.io/utils/pointer"
+	capiv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// scaleUpPreflightInterval is how often the preflight checks of a blocked scale-up are run again.
+const scaleUpPreflightInterval = 5 * time.Minute
+
+// provisioningFailureSignatures are the errors the cloud providers report on the Machines they fail to provision
+// which more Machines would fail with as well, with the reason of the ScaleUpBlocked condition they map to.
+var provisioningFailureSignatures = []struct {
+	errorCode string
+	reason    string
+}{
+	// AWS
+	{errorCode: "InsufficientFreeAddressesInSubnet", reason: hyperv1.InsufficientSubnetIPsReason},
+	{errorCode: "VcpuLimitExceeded", reason: hyperv1.InstanceQuotaExceededReason},
+	{errorCode: "InstanceLimitExceeded", reason: hyperv1.InstanceQuotaExceededReason},
+	{errorCode: "InsufficientInstanceCapacity", reason: hyperv1.InsufficientZoneCapacityReason},
+	// Azure
+	{errorCode: "SubnetIsFull", reason: hyperv1.InsufficientSubnetIPsReason},
+	{errorCode: "QuotaExceeded", reason: hyperv1.InstanceQuotaExceededReason},
+	{errorCode: "ZonalAllocationFailed", reason: hyperv1.InsufficientZoneCapacityReason},
+	{errorCode: "AllocationFailed", reason: hyperv1.InsufficientZoneCapacityReason},
+	{errorCode: "SkuNotAvailable", reason: hyperv1.InsufficientZoneCapacityReason},
+}
+
+// reconcileScaleUpPreflight runs the preflight checks of an increase of the replicas of the NodePool and sets its
+// ScaleUpBlocked condition. While the condition is true, the MachineDeployment or MachineSet of the NodePool keeps
+// its current replicas, see wantedReplicas. It returns whether the scale-up is blocked. The checks are best effort:
+// the ones which can't be run don't block the scale-up.
+func (r *NodePoolReconciler) reconcileScaleUpPreflight(ctx context.Context, nodePool *hyperv1.NodePool, hcluster *hyperv1.HostedCluster, controlPlaneNamespace string) (bool, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	if isAutoscalingEnabled(nodePool) || !isAutomatedMachineManagement(nodePool) {
+		removeStatusCondition(&nodePool.Status.Conditions, hyperv1.NodePoolScaleUpBlockedConditionType)
+		return false, nil
+	}
+
+	condition := hyperv1.NodePoolCondition{
+		Type:               hyperv1.NodePoolScaleUpBlockedConditionType,
+		Status:             corev1.ConditionFalse,
+		Reason:             hyperv1.AsExpectedReason,
+		ObservedGeneration: nodePool.Generation,
+	}
+	current, err := r.currentReplicas(ctx, nodePool, controlPlaneNamespace)
+	if err != nil {
+		return false, err
+	}
+	wanted := k8sutilspointer.Int32Deref(nodePool.Spec.Replicas, 0)
+	if wanted <= current || nodePool.Annotations[hyperv1.NodePoolSkipScaleUpPreflightAnnotation] == "true" {
+		SetStatusCondition(&nodePool.Status.Conditions, condition)
+		return false, nil
+	}
+	increase := wanted - current
+
+	machines, err := r.getMachinesForNodePool(ctx, nodePool)
+	if err != nil {
+		return false, err
+	}
+	reason, message := provisioningFailure(machines)
+	if reason == "" && nodePool.Spec.Platform.Type == hyperv1.AWSPlatform && nodePool.Spec.Platform.AWS != nil &&
+		hcluster.Spec.Platform.AWS != nil && r.EC2ClientForRegion != nil {
+		reason, message, err = checkSubnetFreeIPs(ctx, r.EC2ClientForRegion(hcluster.Spec.Platform.AWS.Region), nodePool.Spec.Platform.AWS.Subnet, increase)
+		if err != nil {
+			log.Error(err, "failed to check the free IP addresses of the NodePool subnet, skipping the check")
+		}
+	}
+
+	if reason == "" {
+		condition.Message = fmt.Sprintf("Preflight checks passed for scaling up from %d to %d replicas", current, wanted)
+		SetStatusCondition(&nodePool.Status.Conditions, condition)
+		return false, nil
+	}
+	condition.Status = corev1.ConditionTrue
+	condition.Reason = reason
+	condition.Message = fmt.Sprintf("Scaling up from %d to %d replicas is held back: %s. Annotate the NodePool with %s=true to scale up anyway",
+		current, wanted, message, hyperv1.NodePoolSkipScaleUpPreflightAnnotation)
+	SetStatusCondition(&nodePool.Status.Conditions, condition)
+	log.Info("Scale-up blocked by preflight checks", "reason", reason, "message", message)
+	return true, nil
+}
+
+// currentReplicas returns the replicas of the MachineDeployment or MachineSet of the NodePool, 0 when it doesn't
+// exist yet.
+func (r *NodePoolReconciler) currentReplicas(ctx context.Context, nodePool *hyperv1.NodePool, controlPlaneNamespace string) (int32, error) {
+	var obj client.Object
+	var replicas func() *int32
+	if nodePool.Spec.Management.UpgradeType == hyperv1.UpgradeTypeInPlace {
+		ms := machineSet(nodePool, controlPlaneNamespace)
+		obj, replicas = ms, func() *int32 { return ms.Spec.Replicas }
+	} else {
+		md := machineDeployment(nodePool, controlPlaneNamespace)
+		obj, replicas = md, func() *int32 { return md.Spec.Replicas }
+	}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to get %s: %w", client.ObjectKeyFromObject(obj), err)
+	}
+	return k8sutilspointer.Int32Deref(replicas(), 0), nil
+}
+
+// provisioningFailure returns the reason and a description of the first Machine of the NodePool the cloud provider
+// failed to provision with an error more Machines would fail with as well.
+func provisioningFailure(machines []*capiv1.Machine) (string, string) {
+	for _, machine := range machines {
+		if machine.Status.NodeRef != nil || !machine.DeletionTimestamp.IsZero() {
+			continue
+		}
+		var failures []string
+		if machine.Status.FailureMessage != nil {
+			failures = append(failures, *machine.Status.FailureMessage)
+		}
+		for _, condition := range machine.Status.Conditions {
+			if condition.Type == capiv1.InfrastructureReadyCondition && condition.Status == corev1.ConditionFalse {
+				failures = append(failures, condition.Message)
+			}
+		}
+		for _, failure := range failures {
+			for _, signature := range provisioningFailureSignatures {
+				if strings.Contains(failure, signature.errorCode) {
+					return signature.reason, fmt.Sprintf("Machine %s failed to be provisioned with %s: %s", machine.Name, signature.errorCode, failure)
+				}
+			}
+		}
+	}
+	return "", ""
+}
+
+// checkSubnetFreeIPs checks that the subnet of the NodePool has a free IP address for each new Machine.
+func checkSubnetFreeIPs(ctx context.Context, ec2Client ec2iface.EC2API, subnet hyperv1.AWSResourceReference, increase int32) (string, string, error) {
+	input := &ec2.DescribeSubnetsInput{}
+	switch {
+	case subnet.ID != nil:
+		input.SubnetIds = []*string{subnet.ID}
+	case len(subnet.Filters) > 0:
+		for _, filter := range subnet.Filters {
+			input.Filters = append(input.Filters, &ec2.Filter{Name: aws.String(filter.Name), Values: aws.StringSlice(filter.Values)})
+		}
+	default:
+		return "", "", nil
+	}
+	output, err := ec2Client.DescribeSubnetsWithContext(ctx, input)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to describe subnet: %w", err)
+	}
+	// With filters, the Machines are created in the first subnet matching them.
+	if len(output.Subnets) == 0 {
+		return "", "", nil
+	}
+	free := aws.Int64Value(output.Subnets[0].AvailableIpAddressCount)
+	if free >= int64(increase) {
+		return "", "", nil
+	}
+	return hyperv1.InsufficientSubnetIPsReason,
+		fmt.Sprintf("subnet %s has %d free IP addresses for %d new machines", aws.StringValue(output.Subnets[0].SubnetId), free, increase), nil
+}
+
+// wantedReplicas returns the replicas of the NodePool its MachineDeployment or MachineSet should have, given their
+// current replicas. An increase is held back while the ScaleUpBlocked condition is true.
+func wantedReplicas(nodePool *hyperv1.NodePool, current *int32) int32 {
+	replicas := k8sutilspointer.Int32Deref(nodePool.Spec.Replicas, 0)
+	if condition := FindStatusCondition(nodePool.Status.Conditions, hyperv1.NodePoolScaleUpBlockedConditionType); condition != nil && condition.Status == corev1.ConditionTrue {
+		if replicas > k8sutilspointer.Int32Deref(current, 0) {
+			replicas = k8sutilspointer.Int32Deref(current, 0)
+		}
+	}
+	return replicas
+}
@@ -0,0 +1,236 @@
+package nodepool
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	. "github.com/onsi/gomega"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	api "github.com/openshift/hypershift/support/api"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	capiv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type fakeSubnetsEC2Client struct {
+	ec2iface.EC2API
+	freeIPs int64
+	calls   int
+}
+
+func (c *fakeSubnetsEC2Client) DescribeSubnetsWithContext(_ aws.Context, input *ec2.DescribeSubnetsInput, _ ...request.Option) (*ec2.DescribeSubnetsOutput, error) {
+	c.calls++
+	subnetID := "subnet-filtered"
+	if len(input.SubnetIds) > 0 {
+		subnetID = aws.StringValue(input.SubnetIds[0])
+	}
+	return &ec2.DescribeSubnetsOutput{Subnets: []*ec2.Subnet{{SubnetId: aws.String(subnetID), AvailableIpAddressCount: aws.Int64(c.freeIPs)}}}, nil
+}
+
+func TestReconcileScaleUpPreflight(t *testing.T) {
+	hcluster := &hyperv1.HostedCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "hc"},
+		Spec: hyperv1.HostedClusterSpec{
+			Platform: hyperv1.PlatformSpec{Type: hyperv1.AWSPlatform, AWS: &hyperv1.AWSPlatformSpec{Region: "us-east-1"}},
+		},
+	}
+	newNodePool := func(replicas int32) *hyperv1.NodePool {
+		return &hyperv1.NodePool{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "np", Generation: 3},
+			Spec: hyperv1.NodePoolSpec{
+				ClusterName: "hc",
+				Replicas:    ptr.To(replicas),
+				Management:  hyperv1.NodePoolManagement{UpgradeType: hyperv1.UpgradeTypeReplace},
+				Platform: hyperv1.NodePoolPlatform{
+					Type: hyperv1.AWSPlatform,
+					AWS: &hyperv1.AWSNodePoolPlatform{
+						InstanceType: "m5.xlarge",
+						Subnet:       hyperv1.AWSResourceReference{ID: ptr.To("subnet-1")},
+					},
+				},
+			},
+		}
+	}
+	machineDeployment := func(replicas int32) *capiv1.MachineDeployment {
+		return &capiv1.MachineDeployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "clusters-hc", Name: "np"},
+			Spec:       capiv1.MachineDeploymentSpec{Replicas: ptr.To(replicas)},
+		}
+	}
+	failedMachine := func(message string) *capiv1.Machine {
+		return &capiv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "clusters-hc",
+				Name:        "np-abcde",
+				Annotations: map[string]string{nodePoolAnnotation: "clusters/np"},
+			},
+			Status: capiv1.MachineStatus{
+				Phase: string(capiv1.MachinePhaseProvisioning),
+				Conditions: capiv1.Conditions{{
+					Type:     capiv1.InfrastructureReadyCondition,
+					Status:   corev1.ConditionFalse,
+					Severity: capiv1.ConditionSeverityError,
+					Reason:   "InstanceProvisionFailed",
+					Message:  message,
+				}},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name            string
+		nodePool        *hyperv1.NodePool
+		objects         []client.Object
+		freeIPs         int64
+		expectBlocked   bool
+		expectCondition *hyperv1.NodePoolCondition
+		expectEC2Calls  int
+	}{
+		{
+			name:     "When the NodePool isn't scaling up it should not run the checks",
+			nodePool: newNodePool(3),
+			objects:  []client.Object{machineDeployment(3)},
+			expectCondition: &hyperv1.NodePoolCondition{
+				Type:   hyperv1.NodePoolScaleUpBlockedConditionType,
+				Status: corev1.ConditionFalse,
+				Reason: hyperv1.AsExpectedReason,
+			},
+		},
+		{
+			name:     "When the subnet has enough free IP addresses it should allow the scale-up",
+			nodePool: newNodePool(5),
+			objects:  []client.Object{machineDeployment(3)},
+			freeIPs:  2,
+			expectCondition: &hyperv1.NodePoolCondition{
+				Type:    hyperv1.NodePoolScaleUpBlockedConditionType,
+				Status:  corev1.ConditionFalse,
+				Reason:  hyperv1.AsExpectedReason,
+				Message: "Preflight checks passed for scaling up from 3 to 5 replicas",
+			},
+			expectEC2Calls: 1,
+		},
+		{
+			name:          "When the subnet doesn't have enough free IP addresses it should block the scale-up",
+			nodePool:      newNodePool(5),
+			freeIPs:       4,
+			expectBlocked: true,
+			expectCondition: &hyperv1.NodePoolCondition{
+				Type:    hyperv1.NodePoolScaleUpBlockedConditionType,
+				Status:  corev1.ConditionTrue,
+				Reason:  hyperv1.InsufficientSubnetIPsReason,
+				Message: "Scaling up from 0 to 5 replicas is held back: subnet subnet-1 has 4 free IP addresses for 5 new machines. Annotate the NodePool with hypershift.openshift.io/skip-scale-up-preflight=true to scale up anyway",
+			},
+			expectEC2Calls: 1,
+		},
+		{
+			name:          "When a machine failed to be provisioned because of the vCPU quota it should block the scale-up",
+			nodePool:      newNodePool(5),
+			objects:       []client.Object{machineDeployment(3), failedMachine("failed to create AWSMachine instance: VcpuLimitExceeded: You have requested more vCPU capacity than your current vCPU limit")},
+			freeIPs:       100,
+			expectBlocked: true,
+			expectCondition: &hyperv1.NodePoolCondition{
+				Type:    hyperv1.NodePoolScaleUpBlockedConditionType,
+				Status:  corev1.ConditionTrue,
+				Reason:  hyperv1.InstanceQuotaExceededReason,
+				Message: "Scaling up from 3 to 5 replicas is held back: Machine np-abcde failed to be provisioned with VcpuLimitExceeded: failed to create AWSMachine instance: VcpuLimitExceeded: You have requested more vCPU capacity than your current vCPU limit. Annotate the NodePool with hypershift.openshift.io/skip-scale-up-preflight=true to scale up anyway",
+			},
+		},
+		{
+			name:          "When a machine failed to be provisioned because its zone has no capacity it should block the scale-up",
+			nodePool:      newNodePool(4),
+			objects:       []client.Object{machineDeployment(3), failedMachine("InsufficientInstanceCapacity: We currently do not have sufficient m5.xlarge capacity in the Availability Zone you requested")},
+			expectBlocked: true,
+			expectCondition: &hyperv1.NodePoolCondition{
+				Type:   hyperv1.NodePoolScaleUpBlockedConditionType,
+				Status: corev1.ConditionTrue,
+				Reason: hyperv1.InsufficientZoneCapacityReason,
+			},
+		},
+		{
+			name: "When the checks are skipped it should allow the scale-up",
+			nodePool: func() *hyperv1.NodePool {
+				nodePool := newNodePool(5)
+				nodePool.Annotations = map[string]string{hyperv1.NodePoolSkipScaleUpPreflightAnnotation: "true"}
+				return nodePool
+			}(),
+			freeIPs: 0,
+			expectCondition: &hyperv1.NodePoolCondition{
+				Type:   hyperv1.NodePoolScaleUpBlockedConditionType,
+				Status: corev1.ConditionFalse,
+				Reason: hyperv1.AsExpectedReason,
+			},
+		},
+		{
+			name: "When the NodePool is autoscaled it should remove the condition",
+			nodePool: func() *hyperv1.NodePool {
+				nodePool := newNodePool(0)
+				nodePool.Spec.Replicas = nil
+				nodePool.Spec.AutoScaling = &hyperv1.NodePoolAutoScaling{Min: 1, Max: 5}
+				nodePool.Status.Conditions = []hyperv1.NodePoolCondition{{Type: hyperv1.NodePoolScaleUpBlockedConditionType, Status: corev1.ConditionTrue}}
+				return nodePool
+			}(),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ec2Client := &fakeSubnetsEC2Client{freeIPs: tc.freeIPs}
+			r := &NodePoolReconciler{
+				Client:             fake.NewClientBuilder().WithScheme(api.Scheme).WithObjects(tc.objects...).Build(),
+				EC2ClientForRegion: func(string) ec2iface.EC2API { return ec2Client },
+			}
+
+			blocked, err := r.reconcileScaleUpPreflight(context.Background(), tc.nodePool, hcluster, "clusters-hc")
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(blocked).To(Equal(tc.expectBlocked))
+			g.Expect(ec2Client.calls).To(Equal(tc.expectEC2Calls))
+
+			condition := FindStatusCondition(tc.nodePool.Status.Conditions, hyperv1.NodePoolScaleUpBlockedConditionType)
+			if tc.expectCondition == nil {
+				g.Expect(condition).To(BeNil())
+				return
+			}
+			g.Expect(condition).ToNot(BeNil())
+			g.Expect(condition.Status).To(Equal(tc.expectCondition.Status))
+			g.Expect(condition.Reason).To(Equal(tc.expectCondition.Reason))
+			g.Expect(condition.ObservedGeneration).To(Equal(int64(3)))
+			if tc.expectCondition.Message != "" {
+				g.Expect(condition.Message).To(Equal(tc.expectCondition.Message))
+			}
+		})
+	}
+}
+
+func TestWantedReplicas(t *testing.T) {
+	blocked := []hyperv1.NodePoolCondition{{Type: hyperv1.NodePoolScaleUpBlockedConditionType, Status: corev1.ConditionTrue}}
+	testCases := []struct {
+		name       string
+		replicas   int32
+		current    *int32
+		conditions []hyperv1.NodePoolCondition
+		expected   int32
+	}{
+		{name: "When the scale-up isn't blocked it should scale to the NodePool replicas", replicas: 5, current: ptr.To[int32](3), expected: 5},
+		{name: "When the scale-up is blocked it should keep the current replicas", replicas: 5, current: ptr.To[int32](3), conditions: blocked, expected: 3},
+		{name: "When the scale-up of a new MachineDeployment is blocked it should not create machines", replicas: 5, conditions: blocked, expected: 0},
+		{name: "When the scale-up is blocked it should still scale down", replicas: 2, current: ptr.To[int32](3), conditions: blocked, expected: 2},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			nodePool := &hyperv1.NodePool{
+				Spec:   hyperv1.NodePoolSpec{Replicas: ptr.To(tc.replicas)},
+				Status: hyperv1.NodePoolStatus{Conditions: tc.conditions},
+			}
+			g.Expect(wantedReplicas(nodePool, tc.current)).To(Equal(tc.expected))
+		})
+	}
+}
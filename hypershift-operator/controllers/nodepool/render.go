@@ -0,0 +1,94 @@
+package nodepool
+
+import (
+	"context"
+	"fmt"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/hypershift-operator/controllers/manifests"
+	"github.com/openshift/hypershift/support/releaseinfo"
+	supportutil "github.com/openshift/hypershift/support/util"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// RenderedConfig is the config of a NodePool, i.e. the MachineConfig manifests its ignition payload is generated from.
+type RenderedConfig struct {
+	// Config is the config the NodePool would be rolled out with.
+	Config string
+	// TargetConfigHash is the hash of Config the NodePool controller compares to decide whether to roll out the NodePool.
+	TargetConfigHash string
+	// CurrentConfig is the config the NodePool is currently rolled out with. It is empty if the NodePool
+	// has not been rolled out yet.
+	CurrentConfig string
+	// CurrentConfigHash is the hash of CurrentConfig.
+	CurrentConfigHash string
+}
+
+// NeedsRollout returns whether the NodePool would be rolled out with the rendered config.
+func (c *RenderedConfig) NeedsRollout() bool {
+	return c.TargetConfigHash != c.CurrentConfigHash
+}
+
+// RenderConfigOptions are the dependencies needed to render the config of a NodePool.
+type RenderConfigOptions struct {
+	ReleaseProvider         releaseinfo.Provider
+	ImageMetadataProvider   supportutil.ImageMetadataProvider
+	HypershiftOperatorImage string
+}
+
+// RenderConfig renders the config of the NodePool the same way the NodePool controller does, along with the config the
+// NodePool is currently rolled out with, so config changes can be previewed before they are rolled out.
+// It doesn't change anything in the management cluster.
+func RenderConfig(ctx context.Context, c client.Client, opts RenderConfigOptions, hcluster *hyperv1.HostedCluster, nodePool *hyperv1.NodePool) (*RenderedConfig, error) {
+	r := &NodePoolReconciler{
+		Client:                  client.NewDryRunClient(c),
+		ReleaseProvider:         opts.ReleaseProvider,
+		ImageMetadataProvider:   opts.ImageMetadataProvider,
+		HypershiftOperatorImage: opts.HypershiftOperatorImage,
+	}
+	controlPlaneNamespace := manifests.HostedControlPlaneNamespace(hcluster.Namespace, hcluster.Name)
+
+	releaseImage, err := r.getReleaseImage(ctx, hcluster, nodePool.Status.Version, nodePool.Spec.Release.Image)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up release image metadata: %w", err)
+	}
+	config, missingConfigs, err := r.getConfig(ctx, nodePool, expectedCoreConfigResources(hcluster), controlPlaneNamespace, releaseImage, hcluster)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config: %w", err)
+	}
+	if missingConfigs {
+		return nil, fmt.Errorf("core ignition config has not been created yet")
+	}
+	pullSecretName, err := r.getPullSecretName(ctx, hcluster)
+	if err != nil {
+		return nil, err
+	}
+
+	rendered := &RenderedConfig{
+		Config:            config,
+		TargetConfigHash:  supportutil.HashSimple(config + pullSecretName),
+		CurrentConfigHash: nodePool.GetAnnotations()[nodePoolAnnotationCurrentConfig],
+	}
+	if excludeSSHKeyFromConfigHash(nodePool, rendered.TargetConfigHash, releaseImage.Version()) {
+		rendered.TargetConfigHash = supportutil.HashSimple(configWithoutSSHKey(config) + pullSecretName)
+	}
+
+	currentConfigVersion := nodePool.GetAnnotations()[nodePoolAnnotationCurrentConfigVersion]
+	if currentConfigVersion == "" {
+		return rendered, nil
+	}
+	tokenSecret := TokenSecret(controlPlaneNamespace, nodePool.Name, currentConfigVersion)
+	if err := c.Get(ctx, client.ObjectKeyFromObject(tokenSecret), tokenSecret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return rendered, nil
+		}
+		return nil, fmt.Errorf("failed to get token Secret: %w", err)
+	}
+	currentConfig, err := supportutil.DecodeAndDecompress(tokenSecret.Data[TokenSecretConfigKey])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the config of token Secret %s: %w", tokenSecret.Name, err)
+	}
+	rendered.CurrentConfig = currentConfig.String()
+	return rendered, nil
+}
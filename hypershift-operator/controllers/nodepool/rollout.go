@@ -0,0 +1,130 @@
+package nodepool
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	supportutil "github.com/openshift/hypershift/support/util"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	capiv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// rolloutGate returns the RolloutPaused condition of the NodePool and whether the rollout of the target config
+// version must be held back, either because rollouts are paused or because the rollout is waiting for approval.
+// Rollouts are paused by the NodePool itself or by the HostedCluster pausing NodePool rollouts; for the latter it
+// also returns the time left until the pause ends, if it ends at a given time.
+// NodePools which have not been rolled out yet are never gated, so new NodePools always get their Nodes.
+func rolloutGate(log logr.Logger, nodePool *hyperv1.NodePool, hcluster *hyperv1.HostedCluster, targetConfigVersion string) (hyperv1.NodePoolCondition, bool, time.Duration) {
+	condition := hyperv1.NodePoolCondition{
+		Type:               hyperv1.NodePoolRolloutPausedConditionType,
+		Status:             corev1.ConditionFalse,
+		Reason:             hyperv1.AsExpectedReason,
+		ObservedGeneration: nodePool.Generation,
+	}
+
+	currentConfigVersion := nodePool.GetAnnotations()[nodePoolAnnotationCurrentConfigVersion]
+	pendingRollout := currentConfigVersion != "" && currentConfigVersion != targetConfigVersion
+
+	pausedUntil := supportutil.HostedClusterPausedUntil(hcluster, hyperv1.PauseScopeNodePoolRollouts)
+	if isPaused, duration := supportutil.IsReconciliationPaused(log, pausedUntil); isPaused {
+		condition.Status = corev1.ConditionTrue
+		condition.Reason = hyperv1.RolloutPausedReason
+		condition.Message = "Rollouts are paused by the HostedCluster until its pausedUntil field is removed"
+		if duration > 0 {
+			condition.Message = fmt.Sprintf("Rollouts are paused by the HostedCluster until %s", *pausedUntil)
+		}
+		if pendingRollout {
+			condition.Message += fmt.Sprintf(", rollout of config version %s is held back", targetConfigVersion)
+		}
+		return condition, pendingRollout, duration
+	}
+
+	rollout := nodePool.Spec.Management.Rollout
+	if rollout == nil {
+		return condition, false, 0
+	}
+
+	switch {
+	case rollout.Paused:
+		condition.Status = corev1.ConditionTrue
+		condition.Reason = hyperv1.RolloutPausedReason
+		condition.Message = "Rollouts are paused"
+		if pendingRollout {
+			condition.Message = fmt.Sprintf("Rollouts are paused, rollout of config version %s is held back", targetConfigVersion)
+		}
+		return condition, pendingRollout, 0
+	case rollout.Approval == hyperv1.RolloutApprovalManual && pendingRollout &&
+		nodePool.GetAnnotations()[hyperv1.NodePoolApprovedRolloutAnnotation] != targetConfigVersion:
+		condition.Status = corev1.ConditionTrue
+		condition.Reason = hyperv1.RolloutAwaitingApprovalReason
+		condition.Message = fmt.Sprintf("Rollout of config version %s is awaiting approval, annotate the NodePool with %s=%s to approve it",
+			targetConfigVersion, hyperv1.NodePoolApprovedRolloutAnnotation, targetConfigVersion)
+		return condition, true, 0
+	}
+	return condition, false, 0
+}
+
+// reconcileGatedRollout keeps scaling the MachineDeployment or MachineSet of a NodePool whose rollout is gated,
+// without propagating the target config version. When rollouts are paused, the MachineDeployment is paused as well
+// so a rollout already in progress is halted, like a paused Deployment.
+func (r *NodePoolReconciler) reconcileGatedRollout(ctx context.Context, nodePool *hyperv1.NodePool, controlPlaneNamespace string, paused bool) error {
+	if nodePool.Spec.Management.UpgradeType == hyperv1.UpgradeTypeInPlace {
+		ms := machineSet(nodePool, controlPlaneNamespace)
+		if err := r.Get(ctx, client.ObjectKeyFromObject(ms), ms); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return fmt.Errorf("failed to get MachineSet: %w", err)
+		}
+		original := ms.DeepCopy()
+		setMachineSetReplicas(nodePool, ms)
+		if err := r.Patch(ctx, ms, client.MergeFrom(original)); err != nil {
+			return fmt.Errorf("failed to scale MachineSet: %w", err)
+		}
+		return nil
+	}
+
+	md := machineDeployment(nodePool, controlPlaneNamespace)
+	if err := r.Get(ctx, client.ObjectKeyFromObject(md), md); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get MachineDeployment: %w", err)
+	}
+	original := md.DeepCopy()
+	setMachineDeploymentReplicas(nodePool, md)
+	md.Spec.Paused = paused
+	if err := r.Patch(ctx, md, client.MergeFrom(original)); err != nil {
+		return fmt.Errorf("failed to scale MachineDeployment: %w", err)
+	}
+	return nil
+}
+
+// maxNodeLifetimeRolloutAfter returns when the MachineDeployment of the NodePool must roll out new Machines for none of
+// its Machines to outlive the maximum node lifetime: when the oldest one reaches it. The Machines created by that
+// rollout set the next one. It returns nil when the NodePool has no maximum node lifetime or no Machines.
+func maxNodeLifetimeRolloutAfter(nodePool *hyperv1.NodePool, machines []capiv1.Machine) *metav1.Time {
+	if nodePool.Spec.Management.MaxNodeLifetime == nil {
+		return nil
+	}
+	var oldest *metav1.Time
+	for i := range machines {
+		machine := &machines[i]
+		if machine.GetAnnotations()[nodePoolAnnotation] != client.ObjectKeyFromObject(nodePool).String() || !machine.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if oldest == nil || machine.CreationTimestamp.Before(oldest) {
+			oldest = &machine.CreationTimestamp
+		}
+	}
+	if oldest == nil {
+		return nil
+	}
+	return &metav1.Time{Time: oldest.Add(nodePool.Spec.Management.MaxNodeLifetime.Duration)}
+}
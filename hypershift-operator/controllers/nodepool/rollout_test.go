@@ -0,0 +1,280 @@
+package nodepool
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	api "github.com/openshift/hypershift/support/api"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	capiv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRolloutGate(t *testing.T) {
+	const target = "target"
+	testCases := []struct {
+		name            string
+		rollout         *hyperv1.NodePoolRollout
+		pauseScope      hyperv1.PauseScope
+		pausedUntil     *string
+		annotations     map[string]string
+		expectedStatus  corev1.ConditionStatus
+		expectedReason  string
+		expectedGated   bool
+		expectedRequeue bool
+	}{
+		{
+			name:           "When no rollout policy is set it should not gate the rollout",
+			annotations:    map[string]string{nodePoolAnnotationCurrentConfigVersion: "current"},
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: hyperv1.AsExpectedReason,
+		},
+		{
+			name:           "When rollouts are paused and a rollout is pending it should gate the rollout",
+			rollout:        &hyperv1.NodePoolRollout{Paused: true},
+			annotations:    map[string]string{nodePoolAnnotationCurrentConfigVersion: "current"},
+			expectedStatus: corev1.ConditionTrue,
+			expectedReason: hyperv1.RolloutPausedReason,
+			expectedGated:  true,
+		},
+		{
+			name:           "When rollouts are paused and the NodePool is up to date it should report paused without gating",
+			rollout:        &hyperv1.NodePoolRollout{Paused: true},
+			annotations:    map[string]string{nodePoolAnnotationCurrentConfigVersion: target},
+			expectedStatus: corev1.ConditionTrue,
+			expectedReason: hyperv1.RolloutPausedReason,
+		},
+		{
+			name:           "When rollouts are paused and the NodePool is new it should not gate the rollout",
+			rollout:        &hyperv1.NodePoolRollout{Paused: true},
+			expectedStatus: corev1.ConditionTrue,
+			expectedReason: hyperv1.RolloutPausedReason,
+		},
+		{
+			name:           "When approval is manual and the rollout is not approved it should gate the rollout",
+			rollout:        &hyperv1.NodePoolRollout{Approval: hyperv1.RolloutApprovalManual},
+			annotations:    map[string]string{nodePoolAnnotationCurrentConfigVersion: "current"},
+			expectedStatus: corev1.ConditionTrue,
+			expectedReason: hyperv1.RolloutAwaitingApprovalReason,
+			expectedGated:  true,
+		},
+		{
+			name:    "When approval is manual and a previous rollout was approved it should gate the rollout",
+			rollout: &hyperv1.NodePoolRollout{Approval: hyperv1.RolloutApprovalManual},
+			annotations: map[string]string{
+				nodePoolAnnotationCurrentConfigVersion:    "current",
+				hyperv1.NodePoolApprovedRolloutAnnotation: "current",
+			},
+			expectedStatus: corev1.ConditionTrue,
+			expectedReason: hyperv1.RolloutAwaitingApprovalReason,
+			expectedGated:  true,
+		},
+		{
+			name:    "When approval is manual and the rollout is approved it should not gate the rollout",
+			rollout: &hyperv1.NodePoolRollout{Approval: hyperv1.RolloutApprovalManual},
+			annotations: map[string]string{
+				nodePoolAnnotationCurrentConfigVersion:    "current",
+				hyperv1.NodePoolApprovedRolloutAnnotation: target,
+			},
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: hyperv1.AsExpectedReason,
+		},
+		{
+			name:           "When approval is manual and the NodePool is new it should not gate the rollout",
+			rollout:        &hyperv1.NodePoolRollout{Approval: hyperv1.RolloutApprovalManual},
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: hyperv1.AsExpectedReason,
+		},
+		{
+			name:            "When the HostedCluster pauses NodePool rollouts until a date and a rollout is pending it should gate the rollout until then",
+			pauseScope:      hyperv1.PauseScopeNodePoolRollouts,
+			pausedUntil:     ptr.To(time.Now().Add(time.Hour).Format(time.RFC3339)),
+			annotations:     map[string]string{nodePoolAnnotationCurrentConfigVersion: "current"},
+			expectedStatus:  corev1.ConditionTrue,
+			expectedReason:  hyperv1.RolloutPausedReason,
+			expectedGated:   true,
+			expectedRequeue: true,
+		},
+		{
+			name:           "When the HostedCluster pauses NodePool rollouts and a rollout is pending it should gate the rollout",
+			rollout:        &hyperv1.NodePoolRollout{Approval: hyperv1.RolloutApprovalManual},
+			pauseScope:     hyperv1.PauseScopeNodePoolRollouts,
+			pausedUntil:    ptr.To("true"),
+			annotations:    map[string]string{nodePoolAnnotationCurrentConfigVersion: "current"},
+			expectedStatus: corev1.ConditionTrue,
+			expectedReason: hyperv1.RolloutPausedReason,
+			expectedGated:  true,
+		},
+		{
+			name:           "When the HostedCluster pauses NodePool rollouts and the NodePool is up to date it should report paused without gating",
+			pauseScope:     hyperv1.PauseScopeNodePoolRollouts,
+			pausedUntil:    ptr.To("true"),
+			annotations:    map[string]string{nodePoolAnnotationCurrentConfigVersion: target},
+			expectedStatus: corev1.ConditionTrue,
+			expectedReason: hyperv1.RolloutPausedReason,
+		},
+		{
+			name:           "When the HostedCluster pause of NodePool rollouts has ended it should not gate the rollout",
+			pauseScope:     hyperv1.PauseScopeNodePoolRollouts,
+			pausedUntil:    ptr.To(time.Now().Add(-time.Hour).Format(time.RFC3339)),
+			annotations:    map[string]string{nodePoolAnnotationCurrentConfigVersion: "current"},
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: hyperv1.AsExpectedReason,
+		},
+		{
+			name:           "When the HostedCluster pauses control plane upgrades it should not gate the rollout",
+			pauseScope:     hyperv1.PauseScopeControlPlaneUpgrades,
+			pausedUntil:    ptr.To("true"),
+			annotations:    map[string]string{nodePoolAnnotationCurrentConfigVersion: "current"},
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: hyperv1.AsExpectedReason,
+		},
+		{
+			name:           "When approval is automatic it should not gate the rollout",
+			rollout:        &hyperv1.NodePoolRollout{Approval: hyperv1.RolloutApprovalAutomatic},
+			annotations:    map[string]string{nodePoolAnnotationCurrentConfigVersion: "current"},
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: hyperv1.AsExpectedReason,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			nodePool := &hyperv1.NodePool{
+				ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations},
+				Spec: hyperv1.NodePoolSpec{
+					Management: hyperv1.NodePoolManagement{Rollout: tc.rollout},
+				},
+			}
+			hcluster := &hyperv1.HostedCluster{
+				Spec: hyperv1.HostedClusterSpec{
+					PauseScope:  tc.pauseScope,
+					PausedUntil: tc.pausedUntil,
+				},
+			}
+			condition, gated, requeueAfter := rolloutGate(logr.Discard(), nodePool, hcluster, target)
+			g.Expect(gated).To(Equal(tc.expectedGated))
+			g.Expect(requeueAfter > 0).To(Equal(tc.expectedRequeue))
+			g.Expect(condition.Type).To(Equal(hyperv1.NodePoolRolloutPausedConditionType))
+			g.Expect(condition.Status).To(Equal(tc.expectedStatus))
+			g.Expect(condition.Reason).To(Equal(tc.expectedReason))
+		})
+	}
+}
+
+func TestReconcileGatedRollout(t *testing.T) {
+	testCases := []struct {
+		name           string
+		rollout        *hyperv1.NodePoolRollout
+		paused         bool
+		expectedPaused bool
+	}{
+		{
+			name:           "When rollouts are paused it should scale and pause the MachineDeployment",
+			rollout:        &hyperv1.NodePoolRollout{Paused: true},
+			paused:         true,
+			expectedPaused: true,
+		},
+		{
+			name: "When the version rollout is held for the boot image update it should scale the MachineDeployment without pausing it",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			nodePool := &hyperv1.NodePool{
+				ObjectMeta: metav1.ObjectMeta{Name: "nodepool", Namespace: "clusters"},
+				Spec: hyperv1.NodePoolSpec{
+					Replicas: ptr.To[int32](3),
+					Management: hyperv1.NodePoolManagement{
+						UpgradeType: hyperv1.UpgradeTypeReplace,
+						Rollout:     tc.rollout,
+					},
+				},
+			}
+			md := machineDeployment(nodePool, "clusters-hc")
+			md.Spec.Replicas = ptr.To[int32](1)
+			md.Spec.Template.Spec.Bootstrap.DataSecretName = ptr.To("user-data-current")
+
+			c := fake.NewClientBuilder().WithScheme(api.Scheme).WithObjects(md).Build()
+			r := &NodePoolReconciler{Client: c}
+			g.Expect(r.reconcileGatedRollout(context.Background(), nodePool, "clusters-hc", tc.paused)).To(Succeed())
+
+			got := &capiv1.MachineDeployment{}
+			g.Expect(c.Get(context.Background(), client.ObjectKeyFromObject(md), got)).To(Succeed())
+			g.Expect(got.Spec.Replicas).To(Equal(ptr.To[int32](3)))
+			g.Expect(got.Spec.Paused).To(Equal(tc.expectedPaused))
+			g.Expect(got.Spec.Template.Spec.Bootstrap.DataSecretName).To(Equal(ptr.To("user-data-current")))
+		})
+	}
+}
+
+func TestMaxNodeLifetimeRolloutAfter(t *testing.T) {
+	created := metav1.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	nodePool := &hyperv1.NodePool{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "nodepool"},
+		Spec: hyperv1.NodePoolSpec{
+			Management: hyperv1.NodePoolManagement{
+				UpgradeType:     hyperv1.UpgradeTypeReplace,
+				MaxNodeLifetime: &metav1.Duration{Duration: 720 * time.Hour},
+			},
+		},
+	}
+	machine := func(name string, creationTimestamp metav1.Time, owner string) capiv1.Machine {
+		return capiv1.Machine{ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			CreationTimestamp: creationTimestamp,
+			Annotations:       map[string]string{nodePoolAnnotation: owner},
+		}}
+	}
+	deleting := machine("deleting", metav1.NewTime(created.Add(-time.Hour)), "clusters/nodepool")
+	deleting.DeletionTimestamp = &metav1.Time{Time: created.Time}
+
+	testCases := []struct {
+		name     string
+		nodePool *hyperv1.NodePool
+		machines []capiv1.Machine
+		expected *metav1.Time
+	}{
+		{
+			name:     "When the NodePool has no maximum node lifetime it should not set a rollout",
+			nodePool: &hyperv1.NodePool{ObjectMeta: nodePool.ObjectMeta},
+			machines: []capiv1.Machine{machine("m1", created, "clusters/nodepool")},
+		},
+		{
+			name:     "When the NodePool has no Machines it should not set a rollout",
+			nodePool: nodePool,
+		},
+		{
+			name:     "When the NodePool has Machines it should roll out once the oldest one reaches the maximum node lifetime",
+			nodePool: nodePool,
+			machines: []capiv1.Machine{
+				machine("m1", metav1.NewTime(created.Add(time.Hour)), "clusters/nodepool"),
+				machine("m2", created, "clusters/nodepool"),
+				machine("other", metav1.NewTime(created.Add(-48*time.Hour)), "clusters/other"),
+				deleting,
+			},
+			expected: &metav1.Time{Time: created.Add(720 * time.Hour)},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			rolloutAfter := maxNodeLifetimeRolloutAfter(tc.nodePool, tc.machines)
+			if tc.expected == nil {
+				g.Expect(rolloutAfter).To(BeNil())
+				return
+			}
+			g.Expect(rolloutAfter).ToNot(BeNil())
+			g.Expect(rolloutAfter.Time).To(BeTemporally("==", tc.expected.Time))
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package nodepool
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/hypershift-operator/controllers/manifests"
+	"github.com/openshift/hypershift/hypershift-operator/controllers/sharding"
+	supportutil "github.com/openshift/hypershift/support/util"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	k8sutilspointer "k8s.io/utils/pointer"
+	capiv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	crmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	secretGCControllerName = "nodepool-secret-gc"
+
+	reclaimedSecretsMetricName = "hypershift_nodepools_reclaimed_secrets_total"
+
+	secretKindToken    = "token"
+	secretKindUserData = "user-data"
+)
+
+var reclaimedSecrets = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: reclaimedSecretsMetricName,
+	Help: "Number of token and user data Secrets of old NodePool config generations deleted by the NodePool Secret garbage collection, by kind",
+}, []string{"kind"})
+
+func init() {
+	crmetrics.Registry.MustRegister(reclaimedSecrets)
+}
+
+// SecretGCReconciler deletes the token and user data Secrets of the old config generations of NodePools, which
+// otherwise accumulate in the control plane namespace with every upgrade and config change. It keeps the Secrets of
+// the current generation, of the Retention most recent generations and of the generations still used by Machines,
+// e.g. the old Machines of a rollout in progress.
+type SecretGCReconciler struct {
+	client.Client
+
+	// Retention is the number of most recent config generations whose Secrets are kept.
+	Retention int
+
+	// Shard is the subset of HostedClusters whose NodePools are reconciled by this operator replica.
+	Shard sharding.Shard
+}
+
+func (r *SecretGCReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	_, err := ctrl.NewControllerManagedBy(mgr).
+		Named(secretGCControllerName).
+		For(&hyperv1.NodePool{}, builder.WithPredicates(supportutil.PredicatesForHostedClusterAnnotationScoping(mgr.GetClient()), r.Shard.Predicate(mgr.GetClient()))).
+		WithOptions(controller.Options{
+			// When sharded, every replica holding a shard reconciles, not only the leader.
+			NeedLeaderElection: k8sutilspointer.Bool(!r.Shard.Enabled()),
+		}).
+		Build(r)
+	if err != nil {
+		return fmt.Errorf("failed setting up with a controller manager: %w", err)
+	}
+	return nil
+}
+
+func (r *SecretGCReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	nodePool := &hyperv1.NodePool{}
+	if err := r.Get(ctx, req.NamespacedName, nodePool); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("failed to get nodepool: %w", err)
+	}
+	// The Secrets of deleted NodePools are deleted by the NodePool controller.
+	if !nodePool.DeletionTimestamp.IsZero() || !r.Shard.OwnsObject(ctx, r.Client, nodePool) {
+		return ctrl.Result{}, nil
+	}
+	if isPaused, duration := supportutil.IsReconciliationPaused(log, nodePool.Spec.PausedUntil); isPaused {
+		return ctrl.Result{RequeueAfter: duration}, nil
+	}
+
+	controlPlaneNamespace := manifests.HostedControlPlaneNamespace(nodePool.Namespace, nodePool.Spec.ClusterName)
+	secretList := &corev1.SecretList{}
+	if err := r.List(ctx, secretList, client.InNamespace(controlPlaneNamespace)); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to list secrets: %w", err)
+	}
+	machineList := &capiv1.MachineList{}
+	if err := r.List(ctx, machineList, client.InNamespace(controlPlaneNamespace)); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to list machines: %w", err)
+	}
+	inUse := sets.New[string]()
+	for _, machine := range machineList.Items {
+		if name := k8sutilspointer.StringDeref(machine.Spec.Bootstrap.DataSecretName, ""); name != "" {
+			inUse.Insert(name)
+		}
+	}
+
+	for _, secret := range expiredConfigGenerationSecrets(nodePool, secretList.Items, inUse, r.Retention) {
+		if err := r.Delete(ctx, secret); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return ctrl.Result{}, fmt.Errorf("failed to delete secret %s: %w", secret.Name, err)
+		}
+		kind, _ := configGenerationOf(nodePool, secret)
+		reclaimedSecrets.WithLabelValues(kind).Inc()
+		log.Info("Deleted secret of old config generation", "secret", secret.Name)
+	}
+	return ctrl.Result{}, nil
+}
+
+// configGeneration is the Secrets of a config generation of a NodePool, named after the hash of the config.
+type configGeneration struct {
+	hash    string
+	created time.Time
+	secrets []*corev1.Secret
+}
+
+// expiredConfigGenerationSecrets returns the token and user data Secrets of the NodePool which belong to the config
+// generations that aren't current, aren't one of the retention most recent ones and whose user data isn't in use by
+// a Machine.
+func expiredConfigGenerationSecrets(nodePool *hyperv1.NodePool, secrets []corev1.Secret, inUse sets.Set[string], retention int) []*corev1.Secret {
+	generations := map[string]*configGeneration{}
+	for i := range secrets {
+		secret := &secrets[i]
+		if secret.Annotations[nodePoolAnnotation] != client.ObjectKeyFromObject(nodePool).String() {
+			continue
+		}
+		_, hash := configGenerationOf(nodePool, secret)
+		if hash == "" {
+			continue
+		}
+		generation, exists := generations[hash]
+		if !exists {
+			generation = &configGeneration{hash: hash}
+			generations[hash] = generation
+		}
+		generation.secrets = append(generation.secrets, secret)
+		if created := secret.CreationTimestamp.Time; created.After(generation.created) {
+			generation.created = created
+		}
+	}
+
+	sorted := make([]*configGeneration, 0, len(generations))
+	for _, generation := range generations {
+		sorted = append(sorted, generation)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].created.After(sorted[j].created)
+	})
+
+	currentHash := nodePool.GetAnnotations()[nodePoolAnnotationCurrentConfigVersion]
+	var expired []*corev1.Secret
+	for i, generation := range sorted {
+		if i < retention || generation.hash == currentHash {
+			continue
+		}
+		used := false
+		for _, secret := range generation.secrets {
+			if inUse.Has(secret.Name) {
+				used = true
+			}
+		}
+		if !used {
+			expired = append(expired, generation.secrets...)
+		}
+	}
+	return expired
+}
+
+// configGenerationOf returns the kind of the Secret of a config generation of the NodePool and the hash of the
+// config, or an empty hash if the Secret isn't a token or user data Secret of the NodePool.
+func configGenerationOf(nodePool *hyperv1.NodePool, secret *corev1.Secret) (string, string) {
+	for _, kind := range []string{secretKindToken, secretKindUserData} {
+		if hash, found := strings.CutPrefix(secret.Name, fmt.Sprintf("%s-%s-", kind, nodePool.Name)); found && hash != "" {
+			return kind, hash
+		}
+	}
+	return "", ""
+}
@@ -0,0 +1,148 @@
+package nodepool
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/support/api"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
+	capiv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestExpiredConfigGenerationSecrets(t *testing.T) {
+	nodePool := &hyperv1.NodePool{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "clusters",
+			Name:        "example",
+			Annotations: map[string]string{nodePoolAnnotationCurrentConfigVersion: "c"},
+		},
+	}
+	now := time.Now()
+	// Generations from the newest to the oldest: e (target of a rollout in progress), d, c (current), b, a.
+	var secrets []corev1.Secret
+	for i, hash := range []string{"a", "b", "c", "d", "e"} {
+		created := now.Add(time.Duration(i) * time.Hour)
+		secrets = append(secrets,
+			configGenerationSecret(nodePool, TokenSecret("clusters-example", nodePool.Name, hash).Name, created),
+			configGenerationSecret(nodePool, IgnitionUserDataSecret("clusters-example", nodePool.Name, hash).Name, created))
+	}
+	otherNodePool := &hyperv1.NodePool{ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "example-other"}}
+	secrets = append(secrets,
+		configGenerationSecret(otherNodePool, TokenSecret("clusters-example", otherNodePool.Name, "a").Name, now),
+		configGenerationSecret(nodePool, NodePoolPullSecret("clusters-example", nodePool.Name).Name, now))
+
+	testCases := []struct {
+		name      string
+		retention int
+		inUse     sets.Set[string]
+		expected  []string
+	}{
+		{
+			name:      "When generations are older than the retention it should return their secrets",
+			retention: 2,
+			inUse:     sets.New[string](),
+			expected:  []string{"token-example-b", "user-data-example-b", "token-example-a", "user-data-example-a"},
+		},
+		{
+			name:      "When the current generation is older than the retention it should keep its secrets",
+			retention: 1,
+			inUse:     sets.New[string](),
+			expected:  []string{"token-example-d", "user-data-example-d", "token-example-b", "user-data-example-b", "token-example-a", "user-data-example-a"},
+		},
+		{
+			name:      "When the user data of a generation is used by a Machine it should keep its secrets",
+			retention: 2,
+			inUse:     sets.New[string]("user-data-example-a"),
+			expected:  []string{"token-example-b", "user-data-example-b"},
+		},
+		{
+			name:      "When all generations are within the retention it should return nothing",
+			retention: 5,
+			inUse:     sets.New[string](),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			var names []string
+			for _, secret := range expiredConfigGenerationSecrets(nodePool, secrets, tc.inUse, tc.retention) {
+				names = append(names, secret.Name)
+			}
+			g.Expect(names).To(ConsistOf(tc.expected))
+		})
+	}
+}
+
+func TestSecretGCReconcile(t *testing.T) {
+	g := NewWithT(t)
+	nodePool := &hyperv1.NodePool{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "clusters",
+			Name:        "example",
+			Annotations: map[string]string{nodePoolAnnotationCurrentConfigVersion: "b"},
+		},
+		Spec: hyperv1.NodePoolSpec{ClusterName: "example"},
+	}
+	now := time.Now()
+	objects := []client.Object{nodePool}
+	for i, hash := range []string{"a", "b"} {
+		created := now.Add(time.Duration(i) * time.Hour)
+		token := configGenerationSecret(nodePool, TokenSecret("clusters-example", nodePool.Name, hash).Name, created)
+		userData := configGenerationSecret(nodePool, IgnitionUserDataSecret("clusters-example", nodePool.Name, hash).Name, created)
+		objects = append(objects, &token, &userData)
+	}
+	c := fake.NewClientBuilder().WithScheme(api.Scheme).WithObjects(objects...).Build()
+	reclaimed := testutil.ToFloat64(reclaimedSecrets.WithLabelValues(secretKindUserData))
+
+	r := &SecretGCReconciler{Client: c, Retention: 1}
+	_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(nodePool)})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	secretList := &corev1.SecretList{}
+	g.Expect(c.List(context.Background(), secretList, client.InNamespace("clusters-example"))).To(Succeed())
+	var names []string
+	for _, secret := range secretList.Items {
+		names = append(names, secret.Name)
+	}
+	g.Expect(names).To(ConsistOf("token-example-b", "user-data-example-b"))
+	g.Expect(testutil.ToFloat64(reclaimedSecrets.WithLabelValues(secretKindUserData))).To(Equal(reclaimed + 1))
+
+	// A Machine still using the user data of a generation keeps its secrets.
+	machine := &capiv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "clusters-example", Name: "example-machine"},
+		Spec: capiv1.MachineSpec{
+			ClusterName: "example",
+			Bootstrap:   capiv1.Bootstrap{DataSecretName: ptr.To("user-data-example-c")},
+		},
+	}
+	olderToken := configGenerationSecret(nodePool, "token-example-c", now.Add(-time.Hour))
+	olderUserData := configGenerationSecret(nodePool, "user-data-example-c", now.Add(-time.Hour))
+	g.Expect(c.Create(context.Background(), machine)).To(Succeed())
+	g.Expect(c.Create(context.Background(), &olderToken)).To(Succeed())
+	g.Expect(c.Create(context.Background(), &olderUserData)).To(Succeed())
+	_, err = r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(nodePool)})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(c.Get(context.Background(), types.NamespacedName{Namespace: "clusters-example", Name: "token-example-c"}, &corev1.Secret{})).To(Succeed())
+}
+
+func configGenerationSecret(nodePool *hyperv1.NodePool, name string, created time.Time) corev1.Secret {
+	return corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         "clusters-example",
+			Name:              name,
+			CreationTimestamp: metav1.NewTime(created),
+			Annotations:       map[string]string{nodePoolAnnotation: client.ObjectKeyFromObject(nodePool).String()},
+		},
+	}
+}
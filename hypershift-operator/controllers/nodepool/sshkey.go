@@ -0,0 +1,122 @@
+package nodepool
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	supportutil "github.com/openshift/hypershift/support/util"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// nodePoolAnnotationSSHKeyExcludedFromConfigHash is set on Replace NodePools whose config hash doesn't include
+	// the SSH key MachineConfig, so that SSH key changes are propagated to the existing Nodes instead of replacing them.
+	nodePoolAnnotationSSHKeyExcludedFromConfigHash = "hypershift.openshift.io/nodePoolSSHKeyExcludedFromConfigHash"
+
+	// workerSSHMachineConfigName is the name of the MachineConfig generated by the control plane operator
+	// from hostedCluster.spec.sshKey.
+	workerSSHMachineConfigName = "99-worker-ssh"
+
+	sshKeySecretKey = "id_rsa.pub"
+)
+
+// excludeSSHKeyFromConfigHash returns true if the SSH key MachineConfig must be left out of the config hash of the NodePool.
+// Only Replace NodePools with automated machine management exclude it, InPlace NodePools apply the new MachineConfig
+// through their config update.
+// NodePools which existed before the SSH key was excluded keep including it until they need to roll out for another reason,
+// so that upgrading the HyperShift operator doesn't replace their Machines. The legacyTargetConfigHash is the config hash
+// computed with the SSH key.
+func excludeSSHKeyFromConfigHash(nodePool *hyperv1.NodePool, legacyTargetConfigHash, targetVersion string) bool {
+	if nodePool.Spec.Management.UpgradeType != hyperv1.UpgradeTypeReplace || !isAutomatedMachineManagement(nodePool) {
+		return false
+	}
+	if nodePool.Annotations[nodePoolAnnotationSSHKeyExcludedFromConfigHash] == "true" {
+		return true
+	}
+	currentConfigHash, hasCurrentConfig := nodePool.Annotations[nodePoolAnnotationCurrentConfig]
+	if hasCurrentConfig && currentConfigHash == legacyTargetConfigHash && !isUpdatingVersion(nodePool, targetVersion) {
+		return false
+	}
+	nodePool.Annotations[nodePoolAnnotationSSHKeyExcludedFromConfigHash] = "true"
+	return true
+}
+
+// configWithoutSSHKey returns the config as returned by getConfig without the SSH key MachineConfig.
+func configWithoutSSHKey(config string) string {
+	var manifests []string
+	for _, manifest := range strings.Split(config, "\n---\n") {
+		meta := struct {
+			Kind     string `json:"kind"`
+			Metadata struct {
+				Name string `json:"name"`
+			} `json:"metadata"`
+		}{}
+		if err := yaml.Unmarshal([]byte(manifest), &meta); err == nil &&
+			meta.Kind == "MachineConfig" && meta.Metadata.Name == workerSSHMachineConfigName {
+			continue
+		}
+		manifests = append(manifests, manifest)
+	}
+	return strings.Join(manifests, "\n---\n")
+}
+
+// reconcileNodePoolSSHKeySecret reconciles the Secret holding the SSH key to be written onto the existing Nodes of the NodePool.
+// It is synced into the guest cluster and onto the Nodes by the hosted cluster config operator, which records the hash of
+// the key once all the Nodes have it.
+func (r *NodePoolReconciler) reconcileNodePoolSSHKeySecret(ctx context.Context, secret *corev1.Secret, nodePool *hyperv1.NodePool, hcluster *hyperv1.HostedCluster) error {
+	var sshKey []byte
+	if len(hcluster.Spec.SSHKey.Name) > 0 {
+		sshKeySecret := &corev1.Secret{}
+		if err := r.Get(ctx, client.ObjectKey{Namespace: hcluster.Namespace, Name: hcluster.Spec.SSHKey.Name}, sshKeySecret); err != nil {
+			return fmt.Errorf("cannot get SSH key secret %s/%s: %w", hcluster.Namespace, hcluster.Spec.SSHKey.Name, err)
+		}
+		data, hasKey := sshKeySecret.Data[sshKeySecretKey]
+		if !hasKey {
+			return fmt.Errorf("SSH key secret %s/%s missing %q key", hcluster.Namespace, hcluster.Spec.SSHKey.Name, sshKeySecretKey)
+		}
+		sshKey = data
+	}
+
+	if secret.Labels == nil {
+		secret.Labels = make(map[string]string)
+	}
+	secret.Labels[hyperv1.NodePoolSSHKeyLabel] = "true"
+	secret.Labels[hyperv1.NodePoolLabel] = nodePool.GetName()
+	if secret.Annotations == nil {
+		secret.Annotations = make(map[string]string)
+	}
+	secret.Annotations[nodePoolAnnotation] = client.ObjectKeyFromObject(nodePool).String()
+	secret.Data = map[string][]byte{
+		sshKeySecretKey: sshKey,
+	}
+	return nil
+}
+
+// sshKeyPropagatedCondition returns the SSHKeyPropagated condition of the NodePool. When the SSH key is propagated to the
+// existing Nodes, sshKeySecret is the NodePool SSH key Secret. Otherwise, the key is applied through config updates.
+func sshKeyPropagatedCondition(nodePool *hyperv1.NodePool, sshKeySecret *corev1.Secret, isUpdatingConfig bool) hyperv1.NodePoolCondition {
+	condition := hyperv1.NodePoolCondition{
+		Type:               hyperv1.NodePoolSSHKeyPropagatedConditionType,
+		Status:             corev1.ConditionTrue,
+		Reason:             hyperv1.AsExpectedReason,
+		ObservedGeneration: nodePool.Generation,
+	}
+	if sshKeySecret != nil {
+		if sshKeySecret.Annotations[hyperv1.NodePoolSSHKeyPropagatedHashAnnotation] != supportutil.HashSimple(sshKeySecret.Data[sshKeySecretKey]) {
+			condition.Status = corev1.ConditionFalse
+			condition.Reason = hyperv1.SSHKeyPropagatingReason
+			condition.Message = "Waiting for the SSH key to be written onto the existing Nodes"
+		}
+		return condition
+	}
+	if isUpdatingConfig {
+		condition.Status = corev1.ConditionFalse
+		condition.Reason = hyperv1.SSHKeyPropagatingReason
+		condition.Message = "The SSH key is applied to the Nodes by the config update in progress"
+	}
+	return condition
+}
@@ -0,0 +1,194 @@
+package nodepool
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	supportutil "github.com/openshift/hypershift/support/util"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestExcludeSSHKeyFromConfigHash(t *testing.T) {
+	testCases := []struct {
+		name        string
+		upgradeType hyperv1.UpgradeType
+		platform    hyperv1.PlatformType
+		annotations map[string]string
+		version     string
+		expected    bool
+	}{
+		{
+			name:        "When the NodePool is InPlace it should include the SSH key",
+			upgradeType: hyperv1.UpgradeTypeInPlace,
+			platform:    hyperv1.AWSPlatform,
+			annotations: map[string]string{},
+			expected:    false,
+		},
+		{
+			name:        "When the NodePool has no automated machine management it should include the SSH key",
+			upgradeType: hyperv1.UpgradeTypeReplace,
+			platform:    hyperv1.NonePlatform,
+			annotations: map[string]string{},
+			expected:    false,
+		},
+		{
+			name:        "When the NodePool is new it should exclude the SSH key",
+			upgradeType: hyperv1.UpgradeTypeReplace,
+			platform:    hyperv1.AWSPlatform,
+			annotations: map[string]string{},
+			expected:    true,
+		},
+		{
+			name:        "When the NodePool already excludes the SSH key it should keep excluding it",
+			upgradeType: hyperv1.UpgradeTypeReplace,
+			platform:    hyperv1.AWSPlatform,
+			annotations: map[string]string{
+				nodePoolAnnotationCurrentConfig:                "legacy",
+				nodePoolAnnotationSSHKeyExcludedFromConfigHash: "true",
+			},
+			version:  "4.15.0",
+			expected: true,
+		},
+		{
+			name:        "When an existing NodePool is up to date it should keep including the SSH key",
+			upgradeType: hyperv1.UpgradeTypeReplace,
+			platform:    hyperv1.AWSPlatform,
+			annotations: map[string]string{
+				nodePoolAnnotationCurrentConfig: "legacy",
+			},
+			version:  "4.15.0",
+			expected: false,
+		},
+		{
+			name:        "When an existing NodePool config is updating it should exclude the SSH key",
+			upgradeType: hyperv1.UpgradeTypeReplace,
+			platform:    hyperv1.AWSPlatform,
+			annotations: map[string]string{
+				nodePoolAnnotationCurrentConfig: "previous",
+			},
+			version:  "4.15.0",
+			expected: true,
+		},
+		{
+			name:        "When an existing NodePool version is updating it should exclude the SSH key",
+			upgradeType: hyperv1.UpgradeTypeReplace,
+			platform:    hyperv1.AWSPlatform,
+			annotations: map[string]string{
+				nodePoolAnnotationCurrentConfig: "legacy",
+			},
+			version:  "4.14.0",
+			expected: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			nodePool := &hyperv1.NodePool{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: tc.annotations,
+				},
+				Spec: hyperv1.NodePoolSpec{
+					Management: hyperv1.NodePoolManagement{
+						UpgradeType: tc.upgradeType,
+					},
+					Platform: hyperv1.NodePoolPlatform{
+						Type: tc.platform,
+					},
+				},
+				Status: hyperv1.NodePoolStatus{
+					Version: tc.version,
+				},
+			}
+			g.Expect(excludeSSHKeyFromConfigHash(nodePool, "legacy", "4.15.0")).To(Equal(tc.expected))
+			if tc.expected {
+				g.Expect(nodePool.Annotations).To(HaveKeyWithValue(nodePoolAnnotationSSHKeyExcludedFromConfigHash, "true"))
+			} else {
+				g.Expect(nodePool.Annotations).ToNot(HaveKey(nodePoolAnnotationSSHKeyExcludedFromConfigHash))
+			}
+		})
+	}
+}
+
+func TestConfigWithoutSSHKey(t *testing.T) {
+	g := NewWithT(t)
+
+	sshConfig := `apiVersion: machineconfiguration.openshift.io/v1
+kind: MachineConfig
+metadata:
+  name: 99-worker-ssh
+spec:
+  config:
+    passwd:
+      users:
+      - name: core
+        sshAuthorizedKeys:
+        - ssh-rsa AAAA`
+	fipsConfig := `apiVersion: machineconfiguration.openshift.io/v1
+kind: MachineConfig
+metadata:
+  name: 99-worker-fips
+spec:
+  fips: true`
+	icsp := `apiVersion: operator.openshift.io/v1alpha1
+kind: ImageContentSourcePolicy
+metadata:
+  name: 99-worker-ssh`
+
+	g.Expect(configWithoutSSHKey(fipsConfig + "\n---\n" + sshConfig + "\n---\n" + icsp)).To(Equal(fipsConfig + "\n---\n" + icsp))
+	g.Expect(configWithoutSSHKey(fipsConfig)).To(Equal(fipsConfig))
+}
+
+func TestSSHKeyPropagatedCondition(t *testing.T) {
+	sshKey := []byte("ssh-rsa AAAA")
+	testCases := []struct {
+		name             string
+		sshKeySecret     *corev1.Secret
+		isUpdatingConfig bool
+		expectedStatus   corev1.ConditionStatus
+	}{
+		{
+			name: "When the SSH key was written onto the Nodes it should be true",
+			sshKeySecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						hyperv1.NodePoolSSHKeyPropagatedHashAnnotation: supportutil.HashSimple(sshKey),
+					},
+				},
+				Data: map[string][]byte{sshKeySecretKey: sshKey},
+			},
+			isUpdatingConfig: true,
+			expectedStatus:   corev1.ConditionTrue,
+		},
+		{
+			name: "When a previous SSH key was written onto the Nodes it should be false",
+			sshKeySecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						hyperv1.NodePoolSSHKeyPropagatedHashAnnotation: supportutil.HashSimple([]byte("ssh-rsa BBBB")),
+					},
+				},
+				Data: map[string][]byte{sshKeySecretKey: sshKey},
+			},
+			expectedStatus: corev1.ConditionFalse,
+		},
+		{
+			name:             "When the SSH key is applied by a config update in progress it should be false",
+			isUpdatingConfig: true,
+			expectedStatus:   corev1.ConditionFalse,
+		},
+		{
+			name:           "When the SSH key is applied by the current config it should be true",
+			expectedStatus: corev1.ConditionTrue,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			condition := sshKeyPropagatedCondition(&hyperv1.NodePool{}, tc.sshKeySecret, tc.isUpdatingConfig)
+			g.Expect(condition.Type).To(Equal(hyperv1.NodePoolSSHKeyPropagatedConditionType))
+			g.Expect(condition.Status).To(Equal(tc.expectedStatus))
+		})
+	}
+}
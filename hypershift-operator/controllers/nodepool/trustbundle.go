@@ -0,0 +1,125 @@
+package nodepool
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/clarketm/json"
+	ignitionapi "github.com/coreos/ignition/v2/config/v3_2/types"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/ignition"
+	"github.com/openshift/hypershift/hypershift-operator/controllers/manifests"
+	api "github.com/openshift/hypershift/support/api"
+	"github.com/openshift/hypershift/support/certs"
+	mcfgv1 "github.com/openshift/hypershift/thirdparty/machineconfigoperator/pkg/apis/machineconfiguration.openshift.io/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const additionalTrustBundlePath = "/etc/pki/ca-trust/source/anchors/hypershift-additional-trust-bundle.crt"
+
+// updateCATrustUnit regenerates the consolidated CA trust of the node on boot,
+// so the additional trust bundle is trusted before the kubelet and the
+// container runtime start.
+const updateCATrustUnit = `[Unit]
+Description=Update the CA trust with the HostedCluster additional trust bundle
+Before=crio.service kubelet.service
+ConditionPathExists=` + additionalTrustBundlePath + `
+
+[Service]
+Type=oneshot
+ExecStart=/usr/bin/update-ca-trust extract
+RemainAfterExit=yes
+
+[Install]
+WantedBy=multi-user.target
+`
+
+// distributesAdditionalTrustBundle returns true if the NodePool hasn't opted
+// out of the distribution of the HostedCluster additionalTrustBundle.
+func distributesAdditionalTrustBundle(nodePool *hyperv1.NodePool) bool {
+	return nodePool.Spec.AdditionalTrustBundleDistribution != hyperv1.AdditionalTrustBundleDistributionDisabled
+}
+
+// getAdditionalTrustBundle returns the contents of the HostedCluster
+// additionalTrustBundle, or an empty string when it doesn't set one.
+func (r *NodePoolReconciler) getAdditionalTrustBundle(ctx context.Context, hcluster *hyperv1.HostedCluster) (string, error) {
+	if hcluster.Spec.AdditionalTrustBundle == nil {
+		return "", nil
+	}
+	configMap := &corev1.ConfigMap{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: hcluster.Namespace, Name: hcluster.Spec.AdditionalTrustBundle.Name}, configMap); err != nil {
+		return "", fmt.Errorf("failed to get additional trust bundle configmap %s/%s: %w", hcluster.Namespace, hcluster.Spec.AdditionalTrustBundle.Name, err)
+	}
+	trustBundle, hasKey := configMap.Data[certs.UserCABundleMapKey]
+	if !hasKey {
+		return "", fmt.Errorf("additional trust bundle configmap %s/%s must have a %s key", hcluster.Namespace, hcluster.Spec.AdditionalTrustBundle.Name, certs.UserCABundleMapKey)
+	}
+	return trustBundle, nil
+}
+
+// additionalTrustBundleMachineConfig returns the serialized MachineConfig
+// adding the trust bundle to the CA trust of the nodes, or an empty string
+// when there is no trust bundle to distribute.
+func additionalTrustBundleMachineConfig(trustBundle string) (string, error) {
+	if trustBundle == "" {
+		return "", nil
+	}
+
+	config := &ignitionapi.Config{}
+	config.Ignition.Version = ignitionapi.MaxVersion.String()
+	config.Storage.Files = []ignitionapi.File{
+		fileFromBytes(additionalTrustBundlePath, 0644, []byte(trustBundle)),
+	}
+	config.Systemd.Units = []ignitionapi.Unit{
+		{
+			Name:     "hypershift-update-ca-trust.service",
+			Contents: pointer.String(updateCATrustUnit),
+			Enabled:  pointer.Bool(true),
+		},
+	}
+	serializedConfig, err := json.Marshal(config)
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize additional trust bundle ignition config: %w", err)
+	}
+
+	machineConfig := manifests.MachineConfigAdditionalTrustBundle()
+	ignition.SetMachineConfigLabels(machineConfig)
+	machineConfig.Spec.Config.Raw = serializedConfig
+
+	buf := &bytes.Buffer{}
+	machineConfig.APIVersion = mcfgv1.SchemeGroupVersion.String()
+	machineConfig.Kind = "MachineConfig"
+	if err := api.YamlSerializer.Encode(machineConfig, buf); err != nil {
+		return "", fmt.Errorf("failed to serialize additional trust bundle machine config: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// additionalTrustBundlePropagatedCondition returns the AdditionalTrustBundlePropagated condition of the NodePool, or nil
+// when the HostedCluster has no additionalTrustBundle. The trust bundle is part of the NodePool config, so it is in the
+// CA trust of all the Nodes once no config update is in progress.
+func additionalTrustBundlePropagatedCondition(nodePool *hyperv1.NodePool, hcluster *hyperv1.HostedCluster, isUpdatingConfig bool) *hyperv1.NodePoolCondition {
+	if hcluster.Spec.AdditionalTrustBundle == nil {
+		return nil
+	}
+	condition := &hyperv1.NodePoolCondition{
+		Type:               hyperv1.NodePoolAdditionalTrustBundlePropagatedConditionType,
+		Status:             corev1.ConditionTrue,
+		Reason:             hyperv1.AsExpectedReason,
+		ObservedGeneration: nodePool.Generation,
+	}
+	switch {
+	case !distributesAdditionalTrustBundle(nodePool):
+		condition.Status = corev1.ConditionFalse
+		condition.Reason = hyperv1.TrustBundleDistributionDisabledReason
+		condition.Message = "The NodePool opted out of the additional trust bundle distribution"
+	case isUpdatingConfig:
+		condition.Status = corev1.ConditionFalse
+		condition.Reason = hyperv1.TrustBundlePropagatingReason
+		condition.Message = "The additional trust bundle is applied to the Nodes by the config update in progress"
+	}
+	return condition
+}
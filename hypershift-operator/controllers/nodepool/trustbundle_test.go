@@ -0,0 +1,155 @@
+package nodepool
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	ignitionapi "github.com/coreos/ignition/v2/config/v3_2/types"
+	. "github.com/onsi/gomega"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	api "github.com/openshift/hypershift/support/api"
+	mcfgv1 "github.com/openshift/hypershift/thirdparty/machineconfigoperator/pkg/apis/machineconfiguration.openshift.io/v1"
+	"github.com/vincent-petithory/dataurl"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const testTrustBundle = "-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----\n"
+
+func TestGetAdditionalTrustBundle(t *testing.T) {
+	testCases := []struct {
+		name                string
+		additionalTrustCA   *corev1.LocalObjectReference
+		configMap           *corev1.ConfigMap
+		expectedTrustBundle string
+		expectError         bool
+	}{
+		{
+			name: "When the HostedCluster has no additional trust bundle it should return an empty bundle",
+		},
+		{
+			name:              "When the HostedCluster has an additional trust bundle it should return its contents",
+			additionalTrustCA: &corev1.LocalObjectReference{Name: "user-ca"},
+			configMap: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "user-ca"},
+				Data:       map[string]string{"ca-bundle.crt": testTrustBundle},
+			},
+			expectedTrustBundle: testTrustBundle,
+		},
+		{
+			name:              "When the additional trust bundle configmap has no ca-bundle.crt key it should fail",
+			additionalTrustCA: &corev1.LocalObjectReference{Name: "user-ca"},
+			configMap: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "user-ca"},
+				Data:       map[string]string{"ca.crt": testTrustBundle},
+			},
+			expectError: true,
+		},
+		{
+			name:              "When the additional trust bundle configmap doesn't exist it should fail",
+			additionalTrustCA: &corev1.LocalObjectReference{Name: "user-ca"},
+			expectError:       true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			builder := fake.NewClientBuilder()
+			if tc.configMap != nil {
+				builder = builder.WithObjects(tc.configMap)
+			}
+			r := &NodePoolReconciler{Client: builder.Build()}
+			hcluster := &hyperv1.HostedCluster{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "hc"},
+				Spec:       hyperv1.HostedClusterSpec{AdditionalTrustBundle: tc.additionalTrustCA},
+			}
+
+			trustBundle, err := r.getAdditionalTrustBundle(context.Background(), hcluster)
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(trustBundle).To(Equal(tc.expectedTrustBundle))
+		})
+	}
+}
+
+func TestAdditionalTrustBundleMachineConfig(t *testing.T) {
+	g := NewWithT(t)
+
+	config, err := additionalTrustBundleMachineConfig("")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(config).To(BeEmpty())
+
+	config, err = additionalTrustBundleMachineConfig(testTrustBundle)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	machineConfig := &mcfgv1.MachineConfig{}
+	_, _, err = api.YamlSerializer.Decode([]byte(config), nil, machineConfig)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(machineConfig.Labels).To(HaveKeyWithValue("machineconfiguration.openshift.io/role", "worker"))
+
+	ignitionConfig := &ignitionapi.Config{}
+	g.Expect(json.Unmarshal(machineConfig.Spec.Config.Raw, ignitionConfig)).To(Succeed())
+	g.Expect(ignitionConfig.Storage.Files).To(HaveLen(1))
+	g.Expect(ignitionConfig.Storage.Files[0].Path).To(Equal(additionalTrustBundlePath))
+	contents, err := dataurl.DecodeString(*ignitionConfig.Storage.Files[0].Contents.Source)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(string(contents.Data)).To(Equal(testTrustBundle))
+	g.Expect(ignitionConfig.Systemd.Units).To(HaveLen(1))
+	g.Expect(*ignitionConfig.Systemd.Units[0].Contents).To(ContainSubstring("update-ca-trust"))
+}
+
+func TestAdditionalTrustBundlePropagatedCondition(t *testing.T) {
+	testCases := []struct {
+		name             string
+		trustBundle      *corev1.LocalObjectReference
+		distribution     hyperv1.AdditionalTrustBundleDistribution
+		isUpdatingConfig bool
+		expectedStatus   corev1.ConditionStatus
+		expectedReason   string
+	}{
+		{
+			name: "When the HostedCluster has no additional trust bundle it should not set the condition",
+		},
+		{
+			name:           "When the trust bundle is part of the current config it should be propagated",
+			trustBundle:    &corev1.LocalObjectReference{Name: "user-ca"},
+			expectedStatus: corev1.ConditionTrue,
+			expectedReason: hyperv1.AsExpectedReason,
+		},
+		{
+			name:             "When a config update is in progress it should be propagating",
+			trustBundle:      &corev1.LocalObjectReference{Name: "user-ca"},
+			isUpdatingConfig: true,
+			expectedStatus:   corev1.ConditionFalse,
+			expectedReason:   hyperv1.TrustBundlePropagatingReason,
+		},
+		{
+			name:           "When the NodePool opted out it should report the distribution as disabled",
+			trustBundle:    &corev1.LocalObjectReference{Name: "user-ca"},
+			distribution:   hyperv1.AdditionalTrustBundleDistributionDisabled,
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: hyperv1.TrustBundleDistributionDisabledReason,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			nodePool := &hyperv1.NodePool{Spec: hyperv1.NodePoolSpec{AdditionalTrustBundleDistribution: tc.distribution}}
+			hcluster := &hyperv1.HostedCluster{Spec: hyperv1.HostedClusterSpec{AdditionalTrustBundle: tc.trustBundle}}
+
+			condition := additionalTrustBundlePropagatedCondition(nodePool, hcluster, tc.isUpdatingConfig)
+			if tc.trustBundle == nil {
+				g.Expect(condition).To(BeNil())
+				return
+			}
+			g.Expect(condition).ToNot(BeNil())
+			g.Expect(condition.Status).To(Equal(tc.expectedStatus))
+			g.Expect(condition.Reason).To(Equal(tc.expectedReason))
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package notification
+
+import (
+	"fmt"
+	"time"
+
+	configv1 "github.com/openshift/api/config/v1"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Type is the type of a notification.
+type Type string
+
+const (
+	// ClusterCreated is sent when a HostedCluster is created.
+	ClusterCreated Type = "ClusterCreated"
+	// ClusterDeleted is sent once a HostedCluster is deleted.
+	ClusterDeleted Type = "ClusterDeleted"
+	// ClusterUpgradeStarted is sent when the control plane of a HostedCluster starts upgrading to a new release.
+	ClusterUpgradeStarted Type = "ClusterUpgradeStarted"
+	// ClusterUpgradeFinished is sent when the control plane of a HostedCluster finished upgrading to a new release.
+	ClusterUpgradeFinished Type = "ClusterUpgradeFinished"
+	// ClusterExpiring is sent when the lifetime of a HostedCluster is about to expire, after which it is deleted.
+	ClusterExpiring Type = "ClusterExpiring"
+	// NodePoolScaled is sent when the replicas of a NodePool are changed.
+	NodePoolScaled Type = "NodePoolScaled"
+	// NodePoolUpgradeStarted is sent when the Nodes of a NodePool start upgrading to a new release.
+	NodePoolUpgradeStarted Type = "NodePoolUpgradeStarted"
+	// NodePoolUpgradeFinished is sent when the Nodes of a NodePool finished upgrading to a new release.
+	NodePoolUpgradeFinished Type = "NodePoolUpgradeFinished"
+	// NodePoolRolloutAwaitingApproval is sent when the rollout of a new config version of a NodePool with a Manual
+	// rollout approval is waiting to be approved.
+	NodePoolRolloutAwaitingApproval Type = "NodePoolRolloutAwaitingApproval"
+)
+
+// ObjectReference identifies the object a notification is about.
+type ObjectReference struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+// Notification is the JSON body POSTed to the webhook.
+type Notification struct {
+	// Type is the type of the notification.
+	Type Type `json:"type"`
+	// Time is when the notification was generated.
+	Time time.Time `json:"time"`
+	// HostedCluster is the HostedCluster the notification is about, or the HostedCluster of its NodePool.
+	HostedCluster ObjectReference `json:"hostedCluster"`
+	// NodePool is the NodePool the notification is about, if any.
+	NodePool *ObjectReference `json:"nodePool,omitempty"`
+	// Message is a human readable description of what happened.
+	Message string `json:"message"`
+	// Details are the values specific to the type of the notification, e.g. the versions of an upgrade.
+	Details map[string]string `json:"details,omitempty"`
+}
+
+func clusterNotification(notificationType Type, hc *hyperv1.HostedCluster, now time.Time, message string, details map[string]string) Notification {
+	return Notification{
+		Type:          notificationType,
+		Time:          now,
+		HostedCluster: ObjectReference{Namespace: hc.Namespace, Name: hc.Name},
+		Message:       message,
+		Details:       details,
+	}
+}
+
+func nodePoolNotification(notificationType Type, nodePool *hyperv1.NodePool, now time.Time, message string, details map[string]string) Notification {
+	return Notification{
+		Type:          notificationType,
+		Time:          now,
+		HostedCluster: ObjectReference{Namespace: nodePool.Namespace, Name: nodePool.Spec.ClusterName},
+		NodePool:      &ObjectReference{Namespace: nodePool.Namespace, Name: nodePool.Name},
+		Message:       message,
+		Details:       details,
+	}
+}
+
+// currentUpdate returns the most recent entry of the version history of the HostedCluster, and the version it is
+// updating from if the entry is an upgrade rather than the installation.
+func currentUpdate(hc *hyperv1.HostedCluster) (*configv1.UpdateHistory, string) {
+	if hc.Status.Version == nil || len(hc.Status.Version.History) == 0 {
+		return nil, ""
+	}
+	history := hc.Status.Version.History
+	if len(history) == 1 {
+		return &history[0], ""
+	}
+	return &history[0], history[1].Version
+}
+
+// hostedClusterUpdateNotifications returns the notifications of the changes of a HostedCluster.
+func hostedClusterUpdateNotifications(oldHC, newHC *hyperv1.HostedCluster, now time.Time) []Notification {
+	var notifications []Notification
+	if expiring := meta.FindStatusCondition(newHC.Status.Conditions, string(hyperv1.Expiring)); expiring != nil && expiring.Status == metav1.ConditionTrue &&
+		!meta.IsStatusConditionTrue(oldHC.Status.Conditions, string(hyperv1.Expiring)) && newHC.Status.ExpirationTime != nil {
+		notifications = append(notifications, clusterNotification(ClusterExpiring, newHC, now, expiring.Message,
+			map[string]string{"expirationTime": newHC.Status.ExpirationTime.UTC().Format(time.RFC3339)}))
+	}
+
+	newUpdate, fromVersion := currentUpdate(newHC)
+	// The installation isn't an upgrade.
+	if newUpdate == nil || fromVersion == "" {
+		return notifications
+	}
+	oldUpdate, _ := currentUpdate(oldHC)
+	details := map[string]string{"fromVersion": fromVersion, "toVersion": newUpdate.Version}
+	sameUpdate := oldUpdate != nil && oldUpdate.Image == newUpdate.Image && oldUpdate.StartedTime.Equal(&newUpdate.StartedTime)
+	if !sameUpdate {
+		notifications = append(notifications, clusterNotification(ClusterUpgradeStarted, newHC, now,
+			fmt.Sprintf("Upgrade of hosted cluster %s/%s from %s to %s started", newHC.Namespace, newHC.Name, fromVersion, newUpdate.Version), details))
+	}
+	if newUpdate.State == configv1.CompletedUpdate && (!sameUpdate || oldUpdate.State != configv1.CompletedUpdate) {
+		notifications = append(notifications, clusterNotification(ClusterUpgradeFinished, newHC, now,
+			fmt.Sprintf("Upgrade of hosted cluster %s/%s from %s to %s finished", newHC.Namespace, newHC.Name, fromVersion, newUpdate.Version), details))
+	}
+	return notifications
+}
+
+// nodePoolUpdateNotifications returns the notifications of the changes of a NodePool.
+func nodePoolUpdateNotifications(oldNodePool, newNodePool *hyperv1.NodePool, now time.Time) []Notification {
+	var notifications []Notification
+	name := fmt.Sprintf("%s/%s", newNodePool.Namespace, newNodePool.Name)
+
+	if oldNodePool.Spec.Replicas != nil && newNodePool.Spec.Replicas != nil && *oldNodePool.Spec.Replicas != *newNodePool.Spec.Replicas {
+		notifications = append(notifications, nodePoolNotification(NodePoolScaled, newNodePool, now,
+			fmt.Sprintf("NodePool %s scaled from %d to %d replicas", name, *oldNodePool.Spec.Replicas, *newNodePool.Spec.Replicas),
+			map[string]string{"fromReplicas": fmt.Sprint(*oldNodePool.Spec.Replicas), "toReplicas": fmt.Sprint(*newNodePool.Spec.Replicas)}))
+	}
+
+	oldUpdating := conditionStatus(oldNodePool, hyperv1.NodePoolUpdatingVersionConditionType) == corev1.ConditionTrue
+	if !oldUpdating && conditionStatus(newNodePool, hyperv1.NodePoolUpdatingVersionConditionType) == corev1.ConditionTrue {
+		notifications = append(notifications, nodePoolNotification(NodePoolUpgradeStarted, newNodePool, now,
+			fmt.Sprintf("Upgrade of NodePool %s from %s started", name, newNodePool.Status.Version),
+			map[string]string{"fromVersion": newNodePool.Status.Version, "toReleaseImage": newNodePool.Spec.Release.Image}))
+	}
+	if oldNodePool.Status.Version != "" && newNodePool.Status.Version != oldNodePool.Status.Version {
+		notifications = append(notifications, nodePoolNotification(NodePoolUpgradeFinished, newNodePool, now,
+			fmt.Sprintf("Upgrade of NodePool %s from %s to %s finished", name, oldNodePool.Status.Version, newNodePool.Status.Version),
+			map[string]string{"fromVersion": oldNodePool.Status.Version, "toVersion": newNodePool.Status.Version}))
+	}
+
+	if approval := awaitingApproval(newNodePool); approval != nil {
+		if previous := awaitingApproval(oldNodePool); previous == nil || previous.Message != approval.Message {
+			notifications = append(notifications, nodePoolNotification(NodePoolRolloutAwaitingApproval, newNodePool, now, approval.Message,
+				map[string]string{"approvalAnnotation": hyperv1.NodePoolApprovedRolloutAnnotation}))
+		}
+	}
+	return notifications
+}
+
+func conditionStatus(nodePool *hyperv1.NodePool, conditionType string) corev1.ConditionStatus {
+	for _, condition := range nodePool.Status.Conditions {
+		if condition.Type == conditionType {
+			return condition.Status
+		}
+	}
+	return corev1.ConditionUnknown
+}
+
+// awaitingApproval returns the RolloutPaused condition of the NodePool if its rollout is waiting for approval.
+func awaitingApproval(nodePool *hyperv1.NodePool) *hyperv1.NodePoolCondition {
+	for i := range nodePool.Status.Conditions {
+		condition := &nodePool.Status.Conditions[i]
+		if condition.Type == hyperv1.NodePoolRolloutPausedConditionType && condition.Status == corev1.ConditionTrue && condition.Reason == hyperv1.RolloutAwaitingApprovalReason {
+			return condition
+		}
+	}
+	return nil
+}
@@ -0,0 +1,240 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	toolscache "k8s.io/client-go/tools/cache"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	crmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+const (
+	// SecretURLKey is the key of the URL of the webhook in the notification webhook Secret.
+	SecretURLKey = "url"
+	// SecretHMACKeyKey is the key of the HMAC key the notifications are signed with in the notification webhook Secret.
+	SecretHMACKeyKey = "hmac-key"
+
+	// SignatureHeader is the header of the HMAC-SHA256 signature of the body of the notifications, formatted as
+	// sha256=<hex>.
+	SignatureHeader = "X-HyperShift-Signature"
+	// TypeHeader is the header of the type of the notification.
+	TypeHeader = "X-HyperShift-Notification-Type"
+
+	// queueSize is the number of notifications waiting to be sent above which new notifications are dropped, so that
+	// an unavailable webhook doesn't hold back the informers.
+	queueSize = 1000
+
+	notificationsMetricName = "hypershift_notifications_total"
+)
+
+var notificationsSent = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: notificationsMetricName,
+	Help: "Number of lifecycle notifications POSTed to the notification webhook, by type and result",
+}, []string{"type", "result"})
+
+func init() {
+	crmetrics.Registry.MustRegister(notificationsSent)
+}
+
+// sendBackoff is how a failed notification is retried before it's dropped.
+var sendBackoff = wait.Backoff{
+	Steps:    4,
+	Duration: time.Second,
+	Factor:   2,
+}
+
+// Notifier POSTs JSON notifications about the lifecycle of HostedClusters and NodePools to a webhook: their creation,
+// deletion, upgrades and scaling, and the NodePool rollouts awaiting approval. The URL of the webhook and the HMAC key
+// the notifications are signed with are read from a Secret in the operator namespace every time notifications are
+// sent, so they can be changed without restarting the operator. Notifications are best effort: the changes made while
+// the operator isn't running aren't notified, and notifications which can't be delivered after a few retries are
+// dropped.
+type Notifier struct {
+	// Client reads the notification webhook Secret.
+	Client client.Reader
+	// Cache provides the informers of the HostedClusters and NodePools.
+	Cache cache.Cache
+	// Namespace and SecretName are the namespace and name of the notification webhook Secret.
+	Namespace  string
+	SecretName string
+	// Predicate filters the HostedClusters and NodePools which are notified about.
+	Predicate predicate.Predicate
+
+	HTTPClient *http.Client
+
+	queue   chan Notification
+	started time.Time
+	now     func() time.Time
+}
+
+// NeedLeaderElection makes only the leading operator replica send notifications.
+func (n *Notifier) NeedLeaderElection() bool {
+	return true
+}
+
+func (n *Notifier) Start(ctx context.Context) error {
+	log := ctrl.LoggerFrom(ctx).WithName("notifier")
+	if n.now == nil {
+		n.now = time.Now
+	}
+	if n.HTTPClient == nil {
+		n.HTTPClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	n.queue = make(chan Notification, queueSize)
+	n.started = n.now()
+
+	hcInformer, err := n.Cache.GetInformer(ctx, &hyperv1.HostedCluster{})
+	if err != nil {
+		return fmt.Errorf("failed to get hostedcluster informer: %w", err)
+	}
+	if _, err := hcInformer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if hc, ok := obj.(*hyperv1.HostedCluster); ok && n.created(hc) {
+				n.enqueue(log, clusterNotification(ClusterCreated, hc, n.now(), fmt.Sprintf("Hosted cluster %s/%s created", hc.Namespace, hc.Name),
+					map[string]string{"releaseImage": hc.Spec.Release.Image}))
+			}
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldHC, ok := oldObj.(*hyperv1.HostedCluster)
+			newHC, newOK := newObj.(*hyperv1.HostedCluster)
+			if ok && newOK && n.accepts(newHC) {
+				n.enqueue(log, hostedClusterUpdateNotifications(oldHC, newHC, n.now())...)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if hc, ok := obj.(*hyperv1.HostedCluster); ok && n.accepts(hc) {
+				n.enqueue(log, clusterNotification(ClusterDeleted, hc, n.now(), fmt.Sprintf("Hosted cluster %s/%s deleted", hc.Namespace, hc.Name), nil))
+			}
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to watch hostedclusters: %w", err)
+	}
+
+	nodePoolInformer, err := n.Cache.GetInformer(ctx, &hyperv1.NodePool{})
+	if err != nil {
+		return fmt.Errorf("failed to get nodepool informer: %w", err)
+	}
+	if _, err := nodePoolInformer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldNodePool, ok := oldObj.(*hyperv1.NodePool)
+			newNodePool, newOK := newObj.(*hyperv1.NodePool)
+			if ok && newOK && n.accepts(newNodePool) {
+				n.enqueue(log, nodePoolUpdateNotifications(oldNodePool, newNodePool, n.now())...)
+			}
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to watch nodepools: %w", err)
+	}
+
+	log.Info("Sending lifecycle notifications", "secret", n.Namespace+"/"+n.SecretName)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case notification := <-n.queue:
+			if err := n.send(ctx, notification); err != nil {
+				notificationsSent.WithLabelValues(string(notification.Type), "failed").Inc()
+				log.Error(err, "Failed to send notification", "type", notification.Type, "hostedcluster", notification.HostedCluster)
+				continue
+			}
+			notificationsSent.WithLabelValues(string(notification.Type), "sent").Inc()
+		}
+	}
+}
+
+// created returns whether the HostedCluster was created while notifications are sent, rather than being one of the
+// existing HostedClusters listed when the informer starts.
+func (n *Notifier) created(hc *hyperv1.HostedCluster) bool {
+	return !hc.CreationTimestamp.Time.Before(n.started.Truncate(time.Second)) && n.accepts(hc)
+}
+
+func (n *Notifier) accepts(obj client.Object) bool {
+	return n.Predicate == nil || n.Predicate.Generic(event.GenericEvent{Object: obj})
+}
+
+func (n *Notifier) enqueue(log logr.Logger, notifications ...Notification) {
+	for _, notification := range notifications {
+		select {
+		case n.queue <- notification:
+		default:
+			notificationsSent.WithLabelValues(string(notification.Type), "dropped").Inc()
+			log.Info("Dropped notification, too many notifications are waiting to be sent", "type", notification.Type, "hostedcluster", notification.HostedCluster)
+		}
+	}
+}
+
+// send POSTs the notification to the webhook, retrying on failures.
+func (n *Notifier) send(ctx context.Context, notification Notification) error {
+	secret := &corev1.Secret{}
+	if err := n.Client.Get(ctx, client.ObjectKey{Namespace: n.Namespace, Name: n.SecretName}, secret); err != nil {
+		return fmt.Errorf("failed to get notification webhook secret: %w", err)
+	}
+	url := string(secret.Data[SecretURLKey])
+	if url == "" {
+		return fmt.Errorf("notification webhook secret %s/%s has no %s", n.Namespace, n.SecretName, SecretURLKey)
+	}
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification: %w", err)
+	}
+
+	var lastErr error
+	err = wait.ExponentialBackoffWithContext(ctx, sendBackoff, func(ctx context.Context) (bool, error) {
+		lastErr = post(ctx, n.HTTPClient, url, secret.Data[SecretHMACKeyKey], notification.Type, body)
+		return lastErr == nil, nil
+	})
+	if lastErr != nil {
+		return lastErr
+	}
+	return err
+}
+
+// post POSTs the body to the URL, signed with the HMAC key when there is one.
+func post(ctx context.Context, httpClient *http.Client, url string, hmacKey []byte, notificationType Type, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(TypeHeader, string(notificationType))
+	if len(hmacKey) > 0 {
+		req.Header.Set(SignatureHeader, Signature(hmacKey, body))
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post notification: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("notification webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// Signature returns the value of the signature header of a notification body signed with the HMAC key, which
+// receivers compare to the header to authenticate the notification.
+func Signature(hmacKey, body []byte) string {
+	mac := hmac.New(sha256.New, hmacKey)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,234 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	configv1 "github.com/openshift/api/config/v1"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/support/api"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestHostedClusterUpdateNotifications(t *testing.T) {
+	now := time.Now()
+	started := metav1.NewTime(now.Add(-time.Hour))
+	install := configv1.UpdateHistory{State: configv1.CompletedUpdate, Version: "4.15.1", Image: "release:4.15.1", StartedTime: metav1.NewTime(now.Add(-48 * time.Hour))}
+	hostedCluster := func(history ...configv1.UpdateHistory) *hyperv1.HostedCluster {
+		hc := &hyperv1.HostedCluster{ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "example"}}
+		if len(history) > 0 {
+			hc.Status.Version = &hyperv1.ClusterVersionStatus{History: history}
+		}
+		return hc
+	}
+	partial := configv1.UpdateHistory{State: configv1.PartialUpdate, Version: "4.15.2", Image: "release:4.15.2", StartedTime: started}
+	completed := partial
+	completed.State = configv1.CompletedUpdate
+
+	testCases := []struct {
+		name     string
+		old      *hyperv1.HostedCluster
+		new      *hyperv1.HostedCluster
+		expected []Type
+	}{
+		{
+			name: "When the cluster is installed it should not notify an upgrade",
+			old:  hostedCluster(),
+			new:  hostedCluster(install),
+		},
+		{
+			name:     "When an upgrade starts it should notify it",
+			old:      hostedCluster(install),
+			new:      hostedCluster(partial, install),
+			expected: []Type{ClusterUpgradeStarted},
+		},
+		{
+			name:     "When an upgrade finishes it should notify it",
+			old:      hostedCluster(partial, install),
+			new:      hostedCluster(completed, install),
+			expected: []Type{ClusterUpgradeFinished},
+		},
+		{
+			name: "When an upgrade in progress doesn't change it should not notify anything",
+			old:  hostedCluster(partial, install),
+			new:  hostedCluster(partial, install),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			var types []Type
+			for _, notification := range hostedClusterUpdateNotifications(tc.old, tc.new, now) {
+				types = append(types, notification.Type)
+				g.Expect(notification.HostedCluster).To(Equal(ObjectReference{Namespace: "clusters", Name: "example"}))
+				g.Expect(notification.Details).To(Equal(map[string]string{"fromVersion": "4.15.1", "toVersion": "4.15.2"}))
+			}
+			g.Expect(types).To(Equal(tc.expected))
+		})
+	}
+}
+
+func TestHostedClusterExpiringNotification(t *testing.T) {
+	now := time.Now()
+	expirationTime := metav1.NewTime(now.Add(30 * time.Minute))
+	hostedCluster := func(status metav1.ConditionStatus) *hyperv1.HostedCluster {
+		hc := &hyperv1.HostedCluster{ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "example"}}
+		hc.Status.ExpirationTime = &expirationTime
+		hc.Status.Conditions = []metav1.Condition{{Type: string(hyperv1.Expiring), Status: status, Message: "expiring"}}
+		return hc
+	}
+
+	t.Run("When the cluster starts expiring it should notify it", func(t *testing.T) {
+		g := NewWithT(t)
+		notifications := hostedClusterUpdateNotifications(hostedCluster(metav1.ConditionFalse), hostedCluster(metav1.ConditionTrue), now)
+		g.Expect(notifications).To(HaveLen(1))
+		g.Expect(notifications[0].Type).To(Equal(ClusterExpiring))
+		g.Expect(notifications[0].Message).To(Equal("expiring"))
+		g.Expect(notifications[0].Details).To(Equal(map[string]string{"expirationTime": expirationTime.UTC().Format(time.RFC3339)}))
+	})
+
+	t.Run("When the cluster was already expiring it should not notify it again", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(hostedClusterUpdateNotifications(hostedCluster(metav1.ConditionTrue), hostedCluster(metav1.ConditionTrue), now)).To(BeEmpty())
+	})
+}
+
+func TestNodePoolUpdateNotifications(t *testing.T) {
+	now := time.Now()
+	nodePool := func(replicas int32, version string, conditions ...hyperv1.NodePoolCondition) *hyperv1.NodePool {
+		return &hyperv1.NodePool{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "workers"},
+			Spec:       hyperv1.NodePoolSpec{ClusterName: "example", Replicas: ptr.To(replicas)},
+			Status:     hyperv1.NodePoolStatus{Version: version, Conditions: conditions},
+		}
+	}
+	updating := hyperv1.NodePoolCondition{Type: hyperv1.NodePoolUpdatingVersionConditionType, Status: corev1.ConditionTrue}
+	awaitingApproval := func(message string) hyperv1.NodePoolCondition {
+		return hyperv1.NodePoolCondition{Type: hyperv1.NodePoolRolloutPausedConditionType, Status: corev1.ConditionTrue, Reason: hyperv1.RolloutAwaitingApprovalReason, Message: message}
+	}
+
+	testCases := []struct {
+		name     string
+		old      *hyperv1.NodePool
+		new      *hyperv1.NodePool
+		expected []Type
+	}{
+		{
+			name:     "When the replicas change it should notify the scaling",
+			old:      nodePool(2, "4.15.1"),
+			new:      nodePool(3, "4.15.1"),
+			expected: []Type{NodePoolScaled},
+		},
+		{
+			name:     "When the version starts updating it should notify the upgrade start",
+			old:      nodePool(2, "4.15.1"),
+			new:      nodePool(2, "4.15.1", updating),
+			expected: []Type{NodePoolUpgradeStarted},
+		},
+		{
+			name:     "When the version changes it should notify the upgrade end",
+			old:      nodePool(2, "4.15.1", updating),
+			new:      nodePool(2, "4.15.2"),
+			expected: []Type{NodePoolUpgradeFinished},
+		},
+		{
+			name: "When the first version is reported it should not notify an upgrade",
+			old:  nodePool(2, ""),
+			new:  nodePool(2, "4.15.1"),
+		},
+		{
+			name:     "When a rollout starts awaiting approval it should notify it",
+			old:      nodePool(2, "4.15.1"),
+			new:      nodePool(2, "4.15.1", awaitingApproval("Rollout of config version abc is awaiting approval")),
+			expected: []Type{NodePoolRolloutAwaitingApproval},
+		},
+		{
+			name: "When a rollout is still awaiting the same approval it should not notify it again",
+			old:  nodePool(2, "4.15.1", awaitingApproval("Rollout of config version abc is awaiting approval")),
+			new:  nodePool(2, "4.15.1", awaitingApproval("Rollout of config version abc is awaiting approval")),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			var types []Type
+			for _, notification := range nodePoolUpdateNotifications(tc.old, tc.new, now) {
+				types = append(types, notification.Type)
+				g.Expect(notification.HostedCluster).To(Equal(ObjectReference{Namespace: "clusters", Name: "example"}))
+				g.Expect(notification.NodePool).To(Equal(&ObjectReference{Namespace: "clusters", Name: "workers"}))
+			}
+			g.Expect(types).To(Equal(tc.expected))
+		})
+	}
+}
+
+func TestSend(t *testing.T) {
+	sendBackoff = wait.Backoff{Steps: 3, Duration: time.Millisecond}
+	notification := Notification{
+		Type:          ClusterDeleted,
+		HostedCluster: ObjectReference{Namespace: "clusters", Name: "example"},
+		Message:       "Hosted cluster clusters/example deleted",
+	}
+
+	t.Run("When the webhook fails transiently it should retry and sign the notification", func(t *testing.T) {
+		g := NewWithT(t)
+		var requests int
+		var received Notification
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			if requests == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			body, _ := io.ReadAll(r.Body)
+			g.Expect(r.Header.Get(SignatureHeader)).To(Equal(Signature([]byte("key"), body)))
+			g.Expect(r.Header.Get(TypeHeader)).To(Equal(string(ClusterDeleted)))
+			g.Expect(json.Unmarshal(body, &received)).To(Succeed())
+		}))
+		defer server.Close()
+
+		n := &Notifier{
+			Client: fake.NewClientBuilder().WithScheme(api.Scheme).WithObjects(&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "hypershift", Name: "notifications"},
+				Data:       map[string][]byte{SecretURLKey: []byte(server.URL), SecretHMACKeyKey: []byte("key")},
+			}).Build(),
+			Namespace:  "hypershift",
+			SecretName: "notifications",
+			HTTPClient: server.Client(),
+		}
+		g.Expect(n.send(context.Background(), notification)).To(Succeed())
+		g.Expect(requests).To(Equal(2))
+		g.Expect(received.Message).To(Equal(notification.Message))
+	})
+
+	t.Run("When the webhook keeps failing it should give up with its error", func(t *testing.T) {
+		g := NewWithT(t)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		n := &Notifier{
+			Client: fake.NewClientBuilder().WithScheme(api.Scheme).WithObjects(&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "hypershift", Name: "notifications"},
+				Data:       map[string][]byte{SecretURLKey: []byte(server.URL)},
+			}).Build(),
+			Namespace:  "hypershift",
+			SecretName: "notifications",
+			HTTPClient: server.Client(),
+		}
+		err := n.send(context.Background(), notification)
+		g.Expect(err).To(MatchError(ContainSubstring("500 Internal Server Error")))
+	})
+}
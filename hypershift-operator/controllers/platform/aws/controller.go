@@ -22,6 +22,7 @@ import (
 	awsutil "github.com/openshift/hypershift/cmd/infra/aws/util"
 	"github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/manifests"
 	"github.com/openshift/hypershift/hypershift-operator/controllers/hostedcluster"
+	"github.com/openshift/hypershift/support/conditions"
 	"github.com/openshift/hypershift/support/upsert"
 	supportutil "github.com/openshift/hypershift/support/util"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -198,7 +199,7 @@ func (r *AWSEndpointServiceReconciler) Reconcile(ctx context.Context, req ctrl.R
 		return ctrl.Result{}, fmt.Errorf("failed to get hosted cluster: %w", err)
 	}
 
-	if isPaused, duration := supportutil.IsReconciliationPaused(log, hc.Spec.PausedUntil); isPaused {
+	if isPaused, duration := supportutil.IsReconciliationPaused(log, supportutil.HostedClusterPausedUntil(hc, hyperv1.PauseScopeAll)); isPaused {
 		log.Info("Reconciliation paused", "pausedUntil", *hc.Spec.PausedUntil)
 		return ctrl.Result{RequeueAfter: duration}, nil
 	}
@@ -216,7 +217,7 @@ func (r *AWSEndpointServiceReconciler) Reconcile(ctx context.Context, req ctrl.R
 		meta.SetStatusCondition(&awsEndpointService.Status.Conditions, metav1.Condition{
 			Type:    string(hyperv1.AWSEndpointServiceAvailable),
 			Status:  metav1.ConditionFalse,
-			Reason:  hyperv1.AWSErrorReason,
+			Reason:  conditions.ReasonForError(err, hyperv1.InfraFailureReason),
 			Message: err.Error(),
 		})
 
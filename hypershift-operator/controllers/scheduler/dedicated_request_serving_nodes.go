@@ -3,6 +3,7 @@ package scheduler
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -385,7 +386,7 @@ func (r *DedicatedServingComponentSchedulerAndSizer) Reconcile(ctx context.Conte
 		log.Info("hostedcluster does not use isolated request serving components, nothing to do")
 		return ctrl.Result{}, nil
 	}
-	isPaused, duration, err := util.ProcessPausedUntilField(hc.Spec.PausedUntil, time.Now())
+	isPaused, duration, err := util.ProcessPausedUntilField(util.HostedClusterPausedUntil(hc, hyperv1.PauseScopeAll), time.Now())
 	if err != nil {
 		log.Error(err, "error processing hosted cluster paused field")
 		return ctrl.Result{}, nil // user needs to reformat the field, returning error is useless
@@ -631,6 +632,13 @@ func (r *DedicatedServingComponentSchedulerAndSizer) updateHostedCluster(ctx con
 		hc.Annotations[hyperv1.KubeAPIServerGOMemoryLimitAnnotation] = goMemLimit
 	}
 
+	if sizeConfig.Effects != nil && sizeConfig.Effects.KASMaxRequestsInflight != nil {
+		hc.Annotations[hyperv1.KubeAPIServerMaxRequestsInflightAnnotation] = strconv.Itoa(int(*sizeConfig.Effects.KASMaxRequestsInflight))
+	}
+	if sizeConfig.Effects != nil && sizeConfig.Effects.KASMaxMutatingRequestsInflight != nil {
+		hc.Annotations[hyperv1.KubeAPIServerMaxMutatingRequestsInflightAnnotation] = strconv.Itoa(int(*sizeConfig.Effects.KASMaxMutatingRequestsInflight))
+	}
+
 	if sizeConfig.Effects != nil && sizeConfig.Effects.ControlPlanePriorityClassName != nil {
 		hc.Annotations[hyperv1.ControlPlanePriorityClass] = *sizeConfig.Effects.ControlPlanePriorityClassName
 	}
@@ -311,7 +311,9 @@ func TestHostedClusterSchedulerAndSizer(t *testing.T) {
 						Placeholders: 2,
 					},
 					Effects: &schedulingv1alpha1.Effects{
-						KASGoMemLimit: mustQty("1Gi"),
+						KASGoMemLimit:                  mustQty("1Gi"),
+						KASMaxRequestsInflight:         ptr.To(int32(200)),
+						KASMaxMutatingRequestsInflight: ptr.To(int32(100)),
 					},
 				},
 				{
@@ -321,7 +323,9 @@ func TestHostedClusterSchedulerAndSizer(t *testing.T) {
 						To:   ptr.To(uint32(2)),
 					},
 					Effects: &schedulingv1alpha1.Effects{
-						KASGoMemLimit: mustQty("2Gi"),
+						KASGoMemLimit:                  mustQty("2Gi"),
+						KASMaxRequestsInflight:         ptr.To(int32(400)),
+						KASMaxMutatingRequestsInflight: ptr.To(int32(200)),
 					},
 				},
 				{
@@ -331,7 +335,9 @@ func TestHostedClusterSchedulerAndSizer(t *testing.T) {
 						To:   nil,
 					},
 					Effects: &schedulingv1alpha1.Effects{
-						KASGoMemLimit: mustQty("3Gi"),
+						KASGoMemLimit:                  mustQty("3Gi"),
+						KASMaxRequestsInflight:         ptr.To(int32(800)),
+						KASMaxMutatingRequestsInflight: ptr.To(int32(400)),
 					},
 				},
 			},
@@ -584,6 +590,8 @@ func TestHostedClusterSchedulerAndSizer(t *testing.T) {
 				for _, sizeCfg := range sizingConfig.Spec.Sizes {
 					if sizeCfg.Name == sizeLabel {
 						g.Expect(actual.Annotations[hyperv1.KubeAPIServerGOMemoryLimitAnnotation]).To(Equal(sizeCfg.Effects.KASGoMemLimit.String()))
+						g.Expect(actual.Annotations[hyperv1.KubeAPIServerMaxRequestsInflightAnnotation]).To(Equal(fmt.Sprint(*sizeCfg.Effects.KASMaxRequestsInflight)))
+						g.Expect(actual.Annotations[hyperv1.KubeAPIServerMaxMutatingRequestsInflightAnnotation]).To(Equal(fmt.Sprint(*sizeCfg.Effects.KASMaxMutatingRequestsInflight)))
 						break
 					}
 				}
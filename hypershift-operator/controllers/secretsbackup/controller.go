@@ -0,0 +1,302 @@
+package secretsbackup
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	cpomanifests "github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/manifests"
+	"github.com/openshift/hypershift/hypershift-operator/controllers/manifests"
+	"github.com/openshift/hypershift/support/conditions"
+	hyperutil "github.com/openshift/hypershift/support/util"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	controllerName = "secrets-backup"
+
+	// defaultInterval is how often a bundle is exported when spec.secretsBackup.interval isn't set.
+	defaultInterval = 24 * time.Hour
+
+	// changeCheckPeriod is how often the backed up secrets are compared to the last bundle, so a bundle is exported
+	// soon after they're rotated.
+	changeCheckPeriod = 10 * time.Minute
+
+	// retryPeriod is how long the controller waits before exporting again a bundle which couldn't be exported.
+	retryPeriod = time.Minute
+)
+
+// Reconciler exports a sealed bundle of the control plane secrets of the HostedClusters with a secretsBackup spec to
+// their external object store, every interval and whenever the secrets change. The hash of the secrets of the last
+// bundle is recorded in the status of the HostedCluster, and the outcome is reported with its SecretsBackedUp
+// condition.
+type Reconciler struct {
+	client.Client
+
+	// storeForCluster returns the client of the object store of the HostedCluster.
+	storeForCluster func(spec *hyperv1.SecretsBackupSpec, credentialsSecret *corev1.Secret, hc *hyperv1.HostedCluster) (bundleStore, error)
+	now             func() time.Time
+}
+
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.storeForCluster == nil {
+		r.storeForCluster = newBundleStore
+	}
+	if r.now == nil {
+		r.now = time.Now
+	}
+	_, err := ctrl.NewControllerManagedBy(mgr).
+		Named(controllerName).
+		For(&hyperv1.HostedCluster{}, builder.WithPredicates(hyperutil.PredicatesForHostedClusterAnnotationScoping(mgr.GetClient()))).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.hostedClustersForSecret)).
+		Build(r)
+	if err != nil {
+		return fmt.Errorf("failed setting up with a controller manager: %w", err)
+	}
+	return nil
+}
+
+// hostedClustersForSecret returns the HostedClusters in the namespace of the Secret which back it up, or use it as
+// the credentials of their store.
+func (r *Reconciler) hostedClustersForSecret(ctx context.Context, obj client.Object) []reconcile.Request {
+	hostedClusters := &hyperv1.HostedClusterList{}
+	if err := r.List(ctx, hostedClusters, client.InNamespace(obj.GetNamespace())); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "Failed to list hostedclusters")
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range hostedClusters.Items {
+		hc := &hostedClusters.Items[i]
+		if hc.Spec.SecretsBackup == nil {
+			continue
+		}
+		referenced := hc.Spec.SecretsBackup.Credentials.Name == obj.GetName()
+		for _, secret := range hostedClusterSecrets(hc) {
+			referenced = referenced || secret.Name == obj.GetName()
+		}
+		if referenced {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(hc)})
+		}
+	}
+	return requests
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	hc := &hyperv1.HostedCluster{}
+	if err := r.Get(ctx, req.NamespacedName, hc); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("failed to get hostedcluster: %w", err)
+	}
+	if !hc.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+	if isPaused, duration := hyperutil.IsReconciliationPaused(log, hc.Spec.PausedUntil); isPaused {
+		log.Info("Reconciliation paused", "pausedUntil", *hc.Spec.PausedUntil)
+		return ctrl.Result{RequeueAfter: duration}, nil
+	}
+
+	originalHC := hc.DeepCopy()
+	var result ctrl.Result
+	if hc.Spec.SecretsBackup == nil {
+		meta.RemoveStatusCondition(&hc.Status.Conditions, string(hyperv1.SecretsBackedUp))
+		hc.Status.SecretsBackup = nil
+	} else {
+		var condition metav1.Condition
+		condition, result.RequeueAfter = r.backup(ctx, hc)
+		condition.ObservedGeneration = hc.Generation
+		meta.SetStatusCondition(&hc.Status.Conditions, condition)
+	}
+	if !equality.Semantic.DeepEqual(originalHC.Status, hc.Status) {
+		if err := r.Status().Patch(ctx, hc, client.MergeFromWithOptions(originalHC, client.MergeFromWithOptimisticLock{})); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to update status: %w", err)
+		}
+	}
+	return result, nil
+}
+
+// backup exports a bundle of the control plane secrets when the interval elapsed since the last one, or when the
+// secrets changed. It returns the SecretsBackedUp condition and how long until the secrets must be checked again.
+func (r *Reconciler) backup(ctx context.Context, hc *hyperv1.HostedCluster) (metav1.Condition, time.Duration) {
+	spec := hc.Spec.SecretsBackup
+	condition := metav1.Condition{
+		Type: string(hyperv1.SecretsBackedUp),
+	}
+
+	bundle, missing, err := r.bundle(ctx, hc)
+	if err != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = hyperv1.SecretsBackupFailedReason
+		condition.Message = err.Error()
+		return condition, retryPeriod
+	}
+	if len(missing) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = hyperv1.SecretsBackupWaitingForSecretsReason
+		condition.Message = fmt.Sprintf("The control plane secrets aren't available yet: %s", strings.Join(missing, ", "))
+		return condition, retryPeriod
+	}
+
+	now := r.now()
+	interval := defaultInterval
+	if spec.Interval != nil && spec.Interval.Duration > 0 {
+		interval = spec.Interval.Duration
+	}
+	hash := bundleHash(bundle)
+	if last := hc.Status.SecretsBackup; last == nil || last.Hash != hash || !now.Before(last.LastBackupTime.Add(interval)) {
+		key := objectKey(spec, hc, now)
+		if err := r.put(ctx, hc, key, bundle); err != nil {
+			// A bundle which couldn't be exported keeps the status of the last one.
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = conditions.ReasonForError(err, hyperv1.SecretsBackupFailedReason)
+			condition.Message = fmt.Sprintf("Failed to back up the control plane secrets to the %s store: %v", spec.Store.Type, err)
+			return condition, retryPeriod
+		}
+		hc.Status.SecretsBackup = &hyperv1.SecretsBackupStatus{Key: key, Hash: hash, LastBackupTime: metav1.NewTime(now)}
+	}
+
+	condition.Status = metav1.ConditionTrue
+	condition.Reason = hyperv1.AsExpectedReason
+	condition.Message = fmt.Sprintf("The control plane secrets are backed up as %s", hc.Status.SecretsBackup.Key)
+	return condition, min(hc.Status.SecretsBackup.LastBackupTime.Add(interval).Sub(now), changeCheckPeriod)
+}
+
+func (r *Reconciler) put(ctx context.Context, hc *hyperv1.HostedCluster, key string, bundle []byte) error {
+	spec := hc.Spec.SecretsBackup
+	credentialsSecret := &corev1.Secret{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: hc.Namespace, Name: spec.Credentials.Name}, credentialsSecret); err != nil {
+		return fmt.Errorf("failed to get store credentials secret %s: %w", spec.Credentials.Name, err)
+	}
+	store, err := r.storeForCluster(spec, credentialsSecret, hc)
+	if err != nil {
+		return err
+	}
+	return store.PutBundle(ctx, key, bundle)
+}
+
+// requiredControlPlaneSecrets are the control plane secrets a bundle can't be exported without: they're created
+// with the PKI of the control plane, so their absence means it isn't available yet.
+var requiredControlPlaneSecrets = []func(string) *corev1.Secret{
+	cpomanifests.RootCASecret,
+	cpomanifests.ServiceAccountSigningKeySecret,
+}
+
+// optionalControlPlaneSecrets are the control plane secrets added to the bundle when they exist, as they depend on
+// the configuration of the cluster, e.g. the etcd signers don't exist with an unmanaged etcd.
+var optionalControlPlaneSecrets = []func(string) *corev1.Secret{
+	cpomanifests.CSRSignerCASecret,
+	cpomanifests.EtcdSignerSecret,
+	cpomanifests.EtcdMetricsSignerSecret,
+	cpomanifests.AggregatorClientSigner,
+	cpomanifests.KubeControlPlaneSigner,
+	cpomanifests.KubeAPIServerToKubeletSigner,
+	cpomanifests.SystemAdminSigner,
+	cpomanifests.KonnectivitySignerSecret,
+	cpomanifests.KASSecretEncryptionConfigFile,
+}
+
+// hostedClusterSecrets returns the secrets in the namespace of the HostedCluster added to the bundle: the active and
+// backup AESCBC etcd encryption keys.
+func hostedClusterSecrets(hc *hyperv1.HostedCluster) []corev1.LocalObjectReference {
+	var secrets []corev1.LocalObjectReference
+	if encryption := hc.Spec.SecretEncryption; encryption != nil && encryption.AESCBC != nil {
+		secrets = append(secrets, encryption.AESCBC.ActiveKey)
+		if encryption.AESCBC.BackupKey != nil {
+			secrets = append(secrets, *encryption.AESCBC.BackupKey)
+		}
+	}
+	return secrets
+}
+
+// bundle returns the JSON serialized SecretList of the secrets to back up, or the names of the required secrets
+// which don't exist yet.
+func (r *Reconciler) bundle(ctx context.Context, hc *hyperv1.HostedCluster) ([]byte, []string, error) {
+	controlPlaneNamespace := manifests.HostedControlPlaneNamespace(hc.Namespace, hc.Name)
+	var secrets []*corev1.Secret
+	var missing []string
+	get := func(secret *corev1.Secret, required bool) error {
+		if err := r.Get(ctx, client.ObjectKeyFromObject(secret), secret); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return fmt.Errorf("failed to get secret %s/%s: %w", secret.Namespace, secret.Name, err)
+			}
+			if required {
+				missing = append(missing, secret.Name)
+			}
+			return nil
+		}
+		secrets = append(secrets, secret)
+		return nil
+	}
+	for _, secret := range requiredControlPlaneSecrets {
+		if err := get(secret(controlPlaneNamespace), true); err != nil {
+			return nil, nil, err
+		}
+	}
+	for _, secret := range optionalControlPlaneSecrets {
+		if err := get(secret(controlPlaneNamespace), false); err != nil {
+			return nil, nil, err
+		}
+	}
+	for _, ref := range hostedClusterSecrets(hc) {
+		if err := get(&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: hc.Namespace, Name: ref.Name}}, true); err != nil {
+			return nil, nil, err
+		}
+	}
+	if len(missing) > 0 {
+		return nil, missing, nil
+	}
+
+	list := &corev1.SecretList{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "SecretList"}}
+	for _, secret := range secrets {
+		// Only the content of the secrets is kept, so the bundle can be applied to a new management cluster.
+		list.Items = append(list.Items, corev1.Secret{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+			ObjectMeta: metav1.ObjectMeta{Namespace: secret.Namespace, Name: secret.Name, Labels: secret.Labels, Annotations: secret.Annotations},
+			Type:       secret.Type,
+			Data:       secret.Data,
+		})
+	}
+	sort.Slice(list.Items, func(i, j int) bool {
+		if list.Items[i].Namespace != list.Items[j].Namespace {
+			return list.Items[i].Namespace < list.Items[j].Namespace
+		}
+		return list.Items[i].Name < list.Items[j].Name
+	})
+	bundle, err := json.Marshal(list)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to serialize secrets: %w", err)
+	}
+	return bundle, nil, nil
+}
+
+func bundleHash(bundle []byte) string {
+	sum := sha256.Sum256(bundle)
+	return hex.EncodeToString(sum[:])
+}
+
+// objectKey returns the key of the object of a bundle exported at the given time.
+func objectKey(spec *hyperv1.SecretsBackupSpec, hc *hyperv1.HostedCluster, now time.Time) string {
+	prefix := ""
+	if spec.Store.S3 != nil {
+		prefix = spec.Store.S3.KeyPrefix
+	}
+	return fmt.Sprintf("%s%s/%s/%s.json", prefix, hc.Namespace, hc.Name, now.UTC().Format("20060102T150405Z"))
+}
@@ -0,0 +1,193 @@
+package secretsbackup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/support/api"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type fakeStore struct {
+	bundles map[string][]byte
+	err     error
+	puts    int
+}
+
+func (f *fakeStore) PutBundle(_ context.Context, key string, bundle []byte) error {
+	f.puts++
+	if f.err != nil {
+		return f.err
+	}
+	if f.bundles == nil {
+		f.bundles = map[string][]byte{}
+	}
+	f.bundles[key] = bundle
+	return nil
+}
+
+func secret(namespace, name, value string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Data:       map[string][]byte{"key": []byte(value)},
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	hostedCluster := func() *hyperv1.HostedCluster {
+		return &hyperv1.HostedCluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "example", Generation: 2},
+			Spec: hyperv1.HostedClusterSpec{
+				SecretsBackup: &hyperv1.SecretsBackupSpec{
+					Store: hyperv1.SecretsBackupStore{Type: hyperv1.S3SecretsBackupStore, S3: &hyperv1.S3SecretsBackupStoreSpec{
+						Bucket:    "backups",
+						Region:    "us-east-1",
+						KeyPrefix: "hypershift/",
+						KMSKeyARN: "arn:aws:kms:us-east-1:123456789012:key/backup",
+					}},
+					Credentials: corev1.LocalObjectReference{Name: "backup-credentials"},
+					Interval:    &metav1.Duration{Duration: 6 * time.Hour},
+				},
+				SecretEncryption: &hyperv1.SecretEncryptionSpec{
+					Type:   hyperv1.AESCBC,
+					AESCBC: &hyperv1.AESCBCSpec{ActiveKey: corev1.LocalObjectReference{Name: "etcd-encryption-key"}},
+				},
+			},
+		}
+	}
+	controlPlaneSecrets := func() []client.Object {
+		return []client.Object{
+			secret("clusters-example", "root-ca", "root-ca"),
+			secret("clusters-example", "sa-signing-key", "sa-signing-key"),
+			secret("clusters-example", "etcd-signer", "etcd-signer"),
+			secret("clusters-example", "kas-secret-encryption-config", "encryption-config"),
+			secret("clusters-example", "kas-server-crt", "not backed up"),
+			secret("clusters", "etcd-encryption-key", "aescbc"),
+			secret("clusters", "backup-credentials", ""),
+		}
+	}
+	reconciler := func(c client.Client, store *fakeStore, now *time.Time) *Reconciler {
+		return &Reconciler{
+			Client: c,
+			storeForCluster: func(*hyperv1.SecretsBackupSpec, *corev1.Secret, *hyperv1.HostedCluster) (bundleStore, error) {
+				return store, nil
+			},
+			now: func() time.Time { return *now },
+		}
+	}
+	reconcile := func(g *WithT, r *Reconciler, c client.Client) (*hyperv1.HostedCluster, ctrl.Result) {
+		result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKey{Namespace: "clusters", Name: "example"}})
+		g.Expect(err).ToNot(HaveOccurred())
+		hc := &hyperv1.HostedCluster{}
+		g.Expect(c.Get(context.Background(), client.ObjectKey{Namespace: "clusters", Name: "example"}, hc)).To(Succeed())
+		return hc, result
+	}
+
+	t.Run("When the secrets are backed up it should only export them again once they change or the interval elapses", func(t *testing.T) {
+		g := NewWithT(t)
+		c := fake.NewClientBuilder().WithScheme(api.Scheme).WithObjects(append(controlPlaneSecrets(), hostedCluster())...).WithStatusSubresource(&hyperv1.HostedCluster{}).Build()
+		store := &fakeStore{}
+		current := now
+		r := reconciler(c, store, &current)
+
+		hc, result := reconcile(g, r, c)
+		g.Expect(store.puts).To(Equal(1))
+		g.Expect(result.RequeueAfter).To(Equal(changeCheckPeriod))
+		g.Expect(hc.Status.SecretsBackup).ToNot(BeNil())
+		g.Expect(hc.Status.SecretsBackup.Key).To(Equal("hypershift/clusters/example/20261015T120000Z.json"))
+		g.Expect(hc.Status.SecretsBackup.LastBackupTime.Time).To(BeTemporally("==", now))
+		condition := meta.FindStatusCondition(hc.Status.Conditions, string(hyperv1.SecretsBackedUp))
+		g.Expect(condition).ToNot(BeNil())
+		g.Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		g.Expect(condition.ObservedGeneration).To(Equal(int64(2)))
+
+		list := &corev1.SecretList{}
+		g.Expect(json.Unmarshal(store.bundles[hc.Status.SecretsBackup.Key], list)).To(Succeed())
+		var names []string
+		for _, item := range list.Items {
+			names = append(names, fmt.Sprintf("%s/%s", item.Namespace, item.Name))
+		}
+		g.Expect(names).To(Equal([]string{
+			"clusters/etcd-encryption-key",
+			"clusters-example/etcd-signer",
+			"clusters-example/kas-secret-encryption-config",
+			"clusters-example/root-ca",
+			"clusters-example/sa-signing-key",
+		}))
+
+		current = now.Add(time.Hour)
+		_, _ = reconcile(g, r, c)
+		g.Expect(store.puts).To(Equal(1))
+
+		rootCA := &corev1.Secret{}
+		g.Expect(c.Get(context.Background(), client.ObjectKey{Namespace: "clusters-example", Name: "root-ca"}, rootCA)).To(Succeed())
+		rootCA.Data["key"] = []byte("rotated")
+		g.Expect(c.Update(context.Background(), rootCA)).To(Succeed())
+		hc, _ = reconcile(g, r, c)
+		g.Expect(store.puts).To(Equal(2))
+		g.Expect(hc.Status.SecretsBackup.Key).To(Equal("hypershift/clusters/example/20261015T130000Z.json"))
+
+		current = now.Add(8 * time.Hour)
+		hc, result = reconcile(g, r, c)
+		g.Expect(store.puts).To(Equal(3))
+		g.Expect(hc.Status.SecretsBackup.LastBackupTime.Time).To(BeTemporally("==", current))
+		g.Expect(result.RequeueAfter).To(Equal(changeCheckPeriod))
+	})
+
+	t.Run("When the control plane PKI isn't created yet it should wait for it", func(t *testing.T) {
+		g := NewWithT(t)
+		c := fake.NewClientBuilder().WithScheme(api.Scheme).WithObjects(hostedCluster(), secret("clusters", "etcd-encryption-key", "aescbc")).WithStatusSubresource(&hyperv1.HostedCluster{}).Build()
+		store := &fakeStore{}
+		current := now
+		hc, result := reconcile(g, reconciler(c, store, &current), c)
+		g.Expect(store.puts).To(BeZero())
+		g.Expect(result.RequeueAfter).To(Equal(retryPeriod))
+		condition := meta.FindStatusCondition(hc.Status.Conditions, string(hyperv1.SecretsBackedUp))
+		g.Expect(condition).ToNot(BeNil())
+		g.Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		g.Expect(condition.Reason).To(Equal(hyperv1.SecretsBackupWaitingForSecretsReason))
+		g.Expect(condition.Message).To(ContainSubstring("root-ca, sa-signing-key"))
+	})
+
+	t.Run("When the bundle can't be exported it should keep the status of the last one and retry", func(t *testing.T) {
+		g := NewWithT(t)
+		hc := hostedCluster()
+		hc.Status.SecretsBackup = &hyperv1.SecretsBackupStatus{Key: "hypershift/clusters/example/20261014T120000Z.json", Hash: "previous", LastBackupTime: metav1.NewTime(now.Add(-24 * time.Hour))}
+		c := fake.NewClientBuilder().WithScheme(api.Scheme).WithObjects(append(controlPlaneSecrets(), hc)...).WithStatusSubresource(&hyperv1.HostedCluster{}).Build()
+		store := &fakeStore{err: fmt.Errorf("access denied")}
+		current := now
+		hc, result := reconcile(g, reconciler(c, store, &current), c)
+		g.Expect(result.RequeueAfter).To(Equal(retryPeriod))
+		g.Expect(hc.Status.SecretsBackup.Key).To(Equal("hypershift/clusters/example/20261014T120000Z.json"))
+		condition := meta.FindStatusCondition(hc.Status.Conditions, string(hyperv1.SecretsBackedUp))
+		g.Expect(condition).ToNot(BeNil())
+		g.Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		g.Expect(condition.Reason).To(Equal(hyperv1.SecretsBackupFailedReason))
+		g.Expect(condition.Message).To(ContainSubstring("access denied"))
+	})
+
+	t.Run("When the secrets backup is removed it should remove its status and condition", func(t *testing.T) {
+		g := NewWithT(t)
+		hc := hostedCluster()
+		hc.Spec.SecretsBackup = nil
+		hc.Status.SecretsBackup = &hyperv1.SecretsBackupStatus{Key: "key", Hash: "hash", LastBackupTime: metav1.NewTime(now)}
+		hc.Status.Conditions = []metav1.Condition{{Type: string(hyperv1.SecretsBackedUp), Status: metav1.ConditionTrue, Reason: hyperv1.AsExpectedReason, LastTransitionTime: metav1.NewTime(now)}}
+		c := fake.NewClientBuilder().WithScheme(api.Scheme).WithObjects(hc).WithStatusSubresource(&hyperv1.HostedCluster{}).Build()
+		current := now
+		hc, _ = reconcile(g, reconciler(c, &fakeStore{}, &current), c)
+		g.Expect(hc.Status.SecretsBackup).To(BeNil())
+		g.Expect(meta.FindStatusCondition(hc.Status.Conditions, string(hyperv1.SecretsBackedUp))).To(BeNil())
+	})
+}
@@ -0,0 +1,137 @@
+package secretsbackup
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	awsAccessKeyIDKey     = "aws_access_key_id"
+	awsSecretAccessKeyKey = "aws_secret_access_key"
+
+	// sealedBundleVersion is the version of the format of the sealed bundles.
+	sealedBundleVersion = "v1"
+
+	// encryptionContextKey is the key of the KMS encryption context of the data keys of the bundles, whose value is
+	// the namespace/name of the HostedCluster. The same context must be passed to decrypt them.
+	encryptionContextKey = "hypershift.openshift.io/hosted-cluster"
+)
+
+// bundleStore is an external object store bundles of control plane secrets are exported to.
+type bundleStore interface {
+	// PutBundle seals the bundle and writes it as the object of the key.
+	PutBundle(ctx context.Context, key string, bundle []byte) error
+}
+
+// newBundleStore returns the client of the store of the spec, authenticated with the credentials Secret.
+func newBundleStore(spec *hyperv1.SecretsBackupSpec, credentialsSecret *corev1.Secret, hc *hyperv1.HostedCluster) (bundleStore, error) {
+	data := credentialsSecret.Data
+	switch spec.Store.Type {
+	case hyperv1.S3SecretsBackupStore:
+		if spec.Store.S3 == nil {
+			return nil, fmt.Errorf("s3 is required with the %s store", spec.Store.Type)
+		}
+		awsSession, err := session.NewSession(aws.NewConfig().
+			WithRegion(spec.Store.S3.Region).
+			WithCredentials(credentials.NewStaticCredentials(string(data[awsAccessKeyIDKey]), string(data[awsSecretAccessKeyKey]), "")))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create aws session: %w", err)
+		}
+		return &s3Store{
+			s3Client:      s3.New(awsSession),
+			kmsClient:     kms.New(awsSession),
+			bucket:        spec.Store.S3.Bucket,
+			kmsKeyARN:     spec.Store.S3.KMSKeyARN,
+			hostedCluster: fmt.Sprintf("%s/%s", hc.Namespace, hc.Name),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported secrets backup store type %q", spec.Store.Type)
+	}
+}
+
+// sealedBundle is the format of the objects of the bundles: the bundle is encrypted with AES-256-GCM with a data key
+// generated by the KMS key, and stored along the data key encrypted by the KMS key.
+type sealedBundle struct {
+	Version       string `json:"version"`
+	HostedCluster string `json:"hostedCluster"`
+	KMSKeyARN     string `json:"kmsKeyARN"`
+	// EncryptedDataKey is the data key encrypted by the KMS key, with the hostedCluster as encryption context.
+	EncryptedDataKey []byte `json:"encryptedDataKey"`
+	Nonce            []byte `json:"nonce"`
+	Ciphertext       []byte `json:"ciphertext"`
+}
+
+// s3Store exports the bundles as S3 objects, sealed with an AWS KMS key and encrypted at rest with it.
+type s3Store struct {
+	s3Client      s3iface.S3API
+	kmsClient     kmsiface.KMSAPI
+	bucket        string
+	kmsKeyARN     string
+	hostedCluster string
+}
+
+func (s *s3Store) PutBundle(ctx context.Context, key string, bundle []byte) error {
+	sealed, err := seal(ctx, s.kmsClient, s.kmsKeyARN, s.hostedCluster, bundle)
+	if err != nil {
+		return err
+	}
+	_, err = s.s3Client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket:               aws.String(s.bucket),
+		Key:                  aws.String(key),
+		Body:                 bytes.NewReader(sealed),
+		ContentType:          aws.String("application/json"),
+		ServerSideEncryption: aws.String(s3.ServerSideEncryptionAwsKms),
+		SSEKMSKeyId:          aws.String(s.kmsKeyARN),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to put object %s: %w", key, err)
+	}
+	return nil
+}
+
+// seal encrypts the bundle with a data key generated by the KMS key.
+func seal(ctx context.Context, kmsClient kmsiface.KMSAPI, kmsKeyARN, hostedCluster string, bundle []byte) ([]byte, error) {
+	dataKey, err := kmsClient.GenerateDataKeyWithContext(ctx, &kms.GenerateDataKeyInput{
+		KeyId:             aws.String(kmsKeyARN),
+		KeySpec:           aws.String(kms.DataKeySpecAes256),
+		EncryptionContext: map[string]*string{encryptionContextKey: aws.String(hostedCluster)},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate data key: %w", err)
+	}
+	block, err := aes.NewCipher(dataKey.Plaintext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return json.Marshal(sealedBundle{
+		Version:          sealedBundleVersion,
+		HostedCluster:    hostedCluster,
+		KMSKeyARN:        kmsKeyARN,
+		EncryptedDataKey: dataKey.CiphertextBlob,
+		Nonce:            nonce,
+		Ciphertext:       gcm.Seal(nil, nonce, bundle, nil),
+	})
+}
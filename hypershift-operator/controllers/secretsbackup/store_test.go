@@ -0,0 +1,82 @@
+package secretsbackup
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/json"
+	"io"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+)
+
+var testDataKey = []byte("0123456789abcdef0123456789abcdef")
+
+type fakeKMS struct {
+	kmsiface.KMSAPI
+	input *kms.GenerateDataKeyInput
+}
+
+func (f *fakeKMS) GenerateDataKeyWithContext(_ aws.Context, input *kms.GenerateDataKeyInput, _ ...request.Option) (*kms.GenerateDataKeyOutput, error) {
+	f.input = input
+	return &kms.GenerateDataKeyOutput{Plaintext: testDataKey, CiphertextBlob: []byte("encrypted-data-key")}, nil
+}
+
+type fakeS3 struct {
+	s3iface.S3API
+	input *s3.PutObjectInput
+	body  []byte
+}
+
+func (f *fakeS3) PutObjectWithContext(_ aws.Context, input *s3.PutObjectInput, _ ...request.Option) (*s3.PutObjectOutput, error) {
+	f.input = input
+	body, err := io.ReadAll(input.Body)
+	f.body = body
+	return &s3.PutObjectOutput{}, err
+}
+
+func TestS3StorePutBundle(t *testing.T) {
+	g := NewWithT(t)
+	kmsClient := &fakeKMS{}
+	s3Client := &fakeS3{}
+	store := &s3Store{
+		s3Client:      s3Client,
+		kmsClient:     kmsClient,
+		bucket:        "backups",
+		kmsKeyARN:     "arn:aws:kms:us-east-1:123456789012:key/backup",
+		hostedCluster: "clusters/example",
+	}
+	bundle := []byte(`{"kind":"SecretList"}`)
+
+	g.Expect(store.PutBundle(context.Background(), "clusters/example/20261015T120000Z.json", bundle)).To(Succeed())
+
+	g.Expect(aws.StringValue(kmsClient.input.KeyId)).To(Equal("arn:aws:kms:us-east-1:123456789012:key/backup"))
+	g.Expect(aws.StringValueMap(kmsClient.input.EncryptionContext)).To(Equal(map[string]string{encryptionContextKey: "clusters/example"}))
+	g.Expect(aws.StringValue(s3Client.input.Bucket)).To(Equal("backups"))
+	g.Expect(aws.StringValue(s3Client.input.Key)).To(Equal("clusters/example/20261015T120000Z.json"))
+	g.Expect(aws.StringValue(s3Client.input.ServerSideEncryption)).To(Equal(s3.ServerSideEncryptionAwsKms))
+	g.Expect(aws.StringValue(s3Client.input.SSEKMSKeyId)).To(Equal("arn:aws:kms:us-east-1:123456789012:key/backup"))
+
+	sealed := &sealedBundle{}
+	g.Expect(json.Unmarshal(s3Client.body, sealed)).To(Succeed())
+	g.Expect(sealed.Version).To(Equal(sealedBundleVersion))
+	g.Expect(sealed.HostedCluster).To(Equal("clusters/example"))
+	g.Expect(sealed.EncryptedDataKey).To(Equal([]byte("encrypted-data-key")))
+	g.Expect(sealed.Ciphertext).ToNot(ContainSubstring("SecretList"))
+
+	block, err := aes.NewCipher(testDataKey)
+	g.Expect(err).ToNot(HaveOccurred())
+	gcm, err := cipher.NewGCM(block)
+	g.Expect(err).ToNot(HaveOccurred())
+	opened, err := gcm.Open(nil, sealed.Nonce, sealed.Ciphertext, nil)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(opened).To(Equal(bundle))
+}
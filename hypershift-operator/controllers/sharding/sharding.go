@@ -0,0 +1,213 @@
+package sharding
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"sync"
+	"time"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/support/util"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// nodePoolAnnotation is set by the NodePool controller on the objects it generates.
+const nodePoolAnnotation = "hypershift.openshift.io/nodePool"
+
+// Shard is the subset of HostedClusters reconciled by a HyperShift operator replica.
+// HostedClusters labelled with hyperv1.OperatorShardLabel belong to the shard with that index,
+// the others are assigned by the hash of their namespaced name.
+// The zero value and a Count of 1 own all the HostedClusters.
+type Shard struct {
+	// Count is the total number of shards.
+	Count int
+	// Index is the index of this shard, between 0 and Count-1.
+	Index int
+}
+
+// Enabled returns true if HostedClusters are split across more than one shard.
+func (s Shard) Enabled() bool {
+	return s.Count > 1
+}
+
+// Owns returns true if the HostedCluster with the given key and labels belongs to this shard.
+func (s Shard) Owns(hostedCluster types.NamespacedName, labels map[string]string) bool {
+	if !s.Enabled() {
+		return true
+	}
+	return s.Index == ShardFor(hostedCluster, labels, s.Count)
+}
+
+// OwnsHostedCluster returns true if the HostedCluster belongs to this shard.
+func (s Shard) OwnsHostedCluster(hcluster *hyperv1.HostedCluster) bool {
+	return s.Owns(types.NamespacedName{Namespace: hcluster.Namespace, Name: hcluster.Name}, hcluster.Labels)
+}
+
+// OwnsObject returns true if the HostedCluster the object belongs to is part of this shard. Objects which
+// can't be related to a HostedCluster are owned by every shard.
+func (s Shard) OwnsObject(ctx context.Context, r client.Reader, obj client.Object) bool {
+	if !s.Enabled() {
+		return true
+	}
+	if hcluster, ok := obj.(*hyperv1.HostedCluster); ok {
+		return s.OwnsHostedCluster(hcluster)
+	}
+	key, ok := hostedClusterFor(ctx, r, obj)
+	if !ok {
+		return true
+	}
+	hcluster := &hyperv1.HostedCluster{}
+	if err := r.Get(ctx, key, hcluster); err != nil {
+		// The HostedCluster labels are unknown, fall back to its hash.
+		return s.Owns(key, nil)
+	}
+	return s.OwnsHostedCluster(hcluster)
+}
+
+// Predicate returns a predicate which ignores events for objects belonging to HostedClusters of other shards.
+func (s Shard) Predicate(r client.Reader) predicate.Predicate {
+	return predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		return s.OwnsObject(context.Background(), r, obj)
+	})
+}
+
+// hostedClusterFor returns the key of the HostedCluster the object belongs to.
+func hostedClusterFor(ctx context.Context, r client.Reader, obj client.Object) (types.NamespacedName, bool) {
+	if nodePool, ok := obj.(*hyperv1.NodePool); ok {
+		return types.NamespacedName{Namespace: nodePool.Namespace, Name: nodePool.Spec.ClusterName}, true
+	}
+	annotations := obj.GetAnnotations()
+	if hostedCluster := annotations[util.HostedClusterAnnotation]; hostedCluster != "" {
+		return util.ParseNamespacedName(hostedCluster), true
+	}
+	if nodePoolName := annotations[nodePoolAnnotation]; nodePoolName != "" {
+		nodePool := &hyperv1.NodePool{}
+		if err := r.Get(ctx, util.ParseNamespacedName(nodePoolName), nodePool); err != nil {
+			return types.NamespacedName{}, false
+		}
+		return types.NamespacedName{Namespace: nodePool.Namespace, Name: nodePool.Spec.ClusterName}, true
+	}
+	return types.NamespacedName{}, false
+}
+
+// ShardFor returns the index of the shard the HostedCluster belongs to. An invalid shard label
+// is ignored so that the HostedCluster is still reconciled.
+func ShardFor(hostedCluster types.NamespacedName, labels map[string]string, count int) int {
+	if count <= 1 {
+		return 0
+	}
+	if value, ok := labels[hyperv1.OperatorShardLabel]; ok {
+		if index, err := strconv.Atoi(value); err == nil && index >= 0 && index < count {
+			return index
+		}
+	}
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(hostedCluster.String()))
+	return int(hash.Sum32() % uint32(count))
+}
+
+// LeaseName returns the name of the Lease held by the replica reconciling the shard with the given index.
+func LeaseName(index int) string {
+	return fmt.Sprintf("hypershift-operator-shard-%d", index)
+}
+
+// AcquireOptions configures the shard Leases.
+type AcquireOptions struct {
+	Namespace     string
+	Identity      string
+	Count         int
+	LeaseDuration time.Duration
+	RenewDeadline time.Duration
+	RetryPeriod   time.Duration
+}
+
+// Acquire blocks until this replica holds the Lease of one of the shards and returns it, so that every
+// replica of a single Deployment actively reconciles a different shard and extra replicas wait to
+// take over the shard of a replica that goes away.
+// The returned channel is closed when the Lease is lost, after which the replica must stop reconciling.
+func Acquire(ctx context.Context, restConfig *rest.Config, opts AcquireOptions) (Shard, <-chan struct{}, error) {
+	log := ctrl.LoggerFrom(ctx)
+	kubeClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return Shard{}, nil, fmt.Errorf("failed to create kube client: %w", err)
+	}
+
+	var (
+		lock     sync.Mutex
+		acquired = -1
+		cancels  = make([]context.CancelFunc, opts.Count)
+	)
+	acquiredCh := make(chan int, 1)
+	lost := make(chan struct{})
+	electors := make([]*leaderelection.LeaderElector, opts.Count)
+	electionCtxs := make([]context.Context, opts.Count)
+	// All electors are created before any of them is started, so that the first one to
+	// acquire its Lease can cancel the others.
+	for i := 0; i < opts.Count; i++ {
+		i := i
+		leaseLock, err := resourcelock.New(resourcelock.LeasesResourceLock, opts.Namespace, LeaseName(i),
+			kubeClient.CoreV1(), kubeClient.CoordinationV1(), resourcelock.ResourceLockConfig{Identity: opts.Identity})
+		if err != nil {
+			return Shard{}, nil, fmt.Errorf("failed to create shard lease lock: %w", err)
+		}
+		electionCtxs[i], cancels[i] = context.WithCancel(ctx)
+		elector, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+			Lock:            leaseLock,
+			LeaseDuration:   opts.LeaseDuration,
+			RenewDeadline:   opts.RenewDeadline,
+			RetryPeriod:     opts.RetryPeriod,
+			ReleaseOnCancel: true,
+			Name:            LeaseName(i),
+			Callbacks: leaderelection.LeaderCallbacks{
+				OnStartedLeading: func(context.Context) {
+					lock.Lock()
+					defer lock.Unlock()
+					if acquired >= 0 {
+						// Another shard was acquired first, release this one.
+						cancels[i]()
+						return
+					}
+					acquired = i
+					for j, cancel := range cancels {
+						if j != i {
+							cancel()
+						}
+					}
+					acquiredCh <- i
+				},
+				OnStoppedLeading: func() {
+					lock.Lock()
+					defer lock.Unlock()
+					if acquired == i {
+						close(lost)
+					}
+				},
+			},
+		})
+		if err != nil {
+			return Shard{}, nil, fmt.Errorf("failed to create shard leader elector: %w", err)
+		}
+		electors[i] = elector
+	}
+	for i := range electors {
+		go electors[i].Run(electionCtxs[i])
+	}
+
+	log.Info("Waiting to acquire a shard", "shards", opts.Count)
+	select {
+	case index := <-acquiredCh:
+		log.Info("Acquired shard", "shard", index, "shards", opts.Count)
+		return Shard{Count: opts.Count, Index: index}, lost, nil
+	case <-ctx.Done():
+		return Shard{}, nil, ctx.Err()
+	}
+}
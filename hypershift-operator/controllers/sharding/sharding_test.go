@@ -0,0 +1,177 @@
+package sharding
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/support/api"
+	"github.com/openshift/hypershift/support/util"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestShardFor(t *testing.T) {
+	key := types.NamespacedName{Namespace: "clusters", Name: "example"}
+	hashIndex := ShardFor(key, nil, 4)
+
+	testCases := []struct {
+		name     string
+		labels   map[string]string
+		count    int
+		expected int
+	}{
+		{
+			name:     "When sharding is disabled it should return the first shard",
+			labels:   map[string]string{hyperv1.OperatorShardLabel: "2"},
+			count:    1,
+			expected: 0,
+		},
+		{
+			name:     "When the HostedCluster has no shard label it should use the hash of its name",
+			count:    4,
+			expected: hashIndex,
+		},
+		{
+			name:     "When the HostedCluster has a shard label it should use it",
+			labels:   map[string]string{hyperv1.OperatorShardLabel: "3"},
+			count:    4,
+			expected: 3,
+		},
+		{
+			name:     "When the shard label is out of range it should use the hash of its name",
+			labels:   map[string]string{hyperv1.OperatorShardLabel: "4"},
+			count:    4,
+			expected: hashIndex,
+		},
+		{
+			name:     "When the shard label is not a number it should use the hash of its name",
+			labels:   map[string]string{hyperv1.OperatorShardLabel: "first"},
+			count:    4,
+			expected: hashIndex,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(ShardFor(key, tc.labels, tc.count)).To(Equal(tc.expected))
+		})
+	}
+}
+
+func TestShardFor_Distribution(t *testing.T) {
+	g := NewWithT(t)
+
+	const count = 3
+	shards := map[int]int{}
+	for _, name := range []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k", "l"} {
+		index := ShardFor(types.NamespacedName{Namespace: "clusters", Name: name}, nil, count)
+		g.Expect(index).To(BeNumerically(">=", 0))
+		g.Expect(index).To(BeNumerically("<", count))
+		shards[index]++
+	}
+	g.Expect(shards).To(HaveLen(count), "every shard should get HostedClusters")
+}
+
+func TestOwnsObject(t *testing.T) {
+	hostedCluster := &hyperv1.HostedCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "clusters",
+			Name:      "example",
+			Labels:    map[string]string{hyperv1.OperatorShardLabel: "1"},
+		},
+	}
+	nodePool := &hyperv1.NodePool{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "clusters",
+			Name:      "example-workers",
+		},
+		Spec: hyperv1.NodePoolSpec{
+			ClusterName: "example",
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(api.Scheme).WithObjects(hostedCluster, nodePool).Build()
+
+	testCases := []struct {
+		name     string
+		shard    Shard
+		obj      client.Object
+		expected bool
+	}{
+		{
+			name:     "When sharding is disabled it should own every object",
+			shard:    Shard{},
+			obj:      hostedCluster,
+			expected: true,
+		},
+		{
+			name:     "When the HostedCluster is labelled with the shard it should own it",
+			shard:    Shard{Count: 2, Index: 1},
+			obj:      hostedCluster,
+			expected: true,
+		},
+		{
+			name:     "When the HostedCluster is labelled with another shard it should not own it",
+			shard:    Shard{Count: 2, Index: 0},
+			obj:      hostedCluster,
+			expected: false,
+		},
+		{
+			name:     "When the NodePool belongs to a HostedCluster of the shard it should own it",
+			shard:    Shard{Count: 2, Index: 1},
+			obj:      nodePool,
+			expected: true,
+		},
+		{
+			name:     "When the NodePool belongs to a HostedCluster of another shard it should not own it",
+			shard:    Shard{Count: 2, Index: 0},
+			obj:      nodePool,
+			expected: false,
+		},
+		{
+			name:  "When the object is annotated with a HostedCluster of another shard it should not own it",
+			shard: Shard{Count: 2, Index: 0},
+			obj: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "clusters-example",
+					Name:        "secret",
+					Annotations: map[string]string{util.HostedClusterAnnotation: "clusters/example"},
+				},
+			},
+			expected: false,
+		},
+		{
+			name:  "When the object is annotated with a NodePool of another shard it should not own it",
+			shard: Shard{Count: 2, Index: 0},
+			obj: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "clusters-example",
+					Name:        "user-data",
+					Annotations: map[string]string{nodePoolAnnotation: "clusters/example-workers"},
+				},
+			},
+			expected: false,
+		},
+		{
+			name:  "When the object can't be related to a HostedCluster it should be owned by every shard",
+			shard: Shard{Count: 2, Index: 0},
+			obj: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "clusters",
+					Name:      "pull-secret",
+				},
+			},
+			expected: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(tc.shard.OwnsObject(context.Background(), c, tc.obj)).To(Equal(tc.expected))
+		})
+	}
+}
@@ -27,6 +27,7 @@ import (
 
 	"github.com/openshift/hypershift/hypershift-operator/controllers/manifests"
 	"github.com/openshift/hypershift/hypershift-operator/controllers/manifests/monitoring"
+	"github.com/openshift/hypershift/support/proxy"
 	"github.com/openshift/hypershift/support/upsert"
 )
 
@@ -145,7 +146,7 @@ func (r *Reconciler) reconcileTelemetryRemoteWrite(ctx context.Context, clusterI
 
 	uwmConfig := monitoring.UWMConfig()
 	if _, err := r.CreateOrUpdateProvider.CreateOrUpdate(ctx, r.Client, uwmConfig, func() error {
-		return reconcileUWMConfigContent(uwmConfig, relabelConfig)
+		return reconcileUWMConfigContent(uwmConfig, relabelConfig, proxy.FromEnv().URL())
 	}); err != nil {
 		return fmt.Errorf("failed to reconcile user workload monitoring config: %w", err)
 	}
@@ -186,7 +187,10 @@ func reconcileRemoteWriteSecret(src, dest *corev1.Secret, clusterID string) erro
 	return nil
 }
 
-func reconcileUWMConfigContent(cm *corev1.ConfigMap, relabelConfig *monv1.RelabelConfig) error {
+// reconcileUWMConfigContent adds the telemetry remote write to the user workload monitoring config. Prometheus doesn't
+// honor the proxy environment variables, the remote write goes through the egress proxy of the management cluster
+// when proxyURL is set.
+func reconcileUWMConfigContent(cm *corev1.ConfigMap, relabelConfig *monv1.RelabelConfig, proxyURL string) error {
 	content := map[string]interface{}{}
 	if contentString, exists := cm.Data["config.yaml"]; exists {
 		if err := yaml.Unmarshal([]byte(contentString), &content); err != nil {
@@ -241,6 +245,9 @@ func reconcileUWMConfigContent(cm *corev1.ConfigMap, relabelConfig *monv1.Relabe
 			MaxBackoff:        "256s",
 		},
 	}
+	if proxyURL != "" {
+		telemetryRemoteWrite.ProxyURL = proxyURL
+	}
 	if relabelConfig != nil {
 		telemetryRemoteWrite.WriteRelabelConfigs = []monv1.RelabelConfig{*relabelConfig}
 	}
@@ -84,6 +84,7 @@ func TestReconcileUWMConfigContent(t *testing.T) {
 	tests := []struct {
 		name     string
 		initial  string
+		proxyURL string
 		expected string
 	}{
 		{
@@ -102,6 +103,26 @@ func TestReconcileUWMConfigContent(t *testing.T) {
       maxSamplesPerSend: 10000
       minBackoff: 1s
     url: https://infogw.api.openshift.com/metrics/v1/receive
+`,
+		},
+		{
+			name:     "management cluster proxy should be used",
+			proxyURL: "http://proxy.example.com:3128",
+			expected: `prometheus:
+  remoteWrite:
+  - authorization:
+      credentials:
+        key: token
+        name: telemetry-remote-write
+      type: Bearer
+    proxyUrl: http://proxy.example.com:3128
+    queueConfig:
+      batchSendDeadline: 1m
+      capacity: 30000
+      maxBackoff: 256s
+      maxSamplesPerSend: 10000
+      minBackoff: 1s
+    url: https://infogw.api.openshift.com/metrics/v1/receive
 `,
 		},
 		{
@@ -207,7 +228,7 @@ prometheus:
 					"config.yaml": test.initial,
 				}
 			}
-			reconcileUWMConfigContent(cm, nil)
+			reconcileUWMConfigContent(cm, nil, test.proxyURL)
 			g.Expect(cm.Data["config.yaml"]).To(Equal(test.expected))
 		})
 	}
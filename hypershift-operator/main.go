@@ -23,6 +23,7 @@ import (
 
 	pkiconfig "github.com/openshift/hypershift/control-plane-pki-operator/config"
 	"github.com/openshift/hypershift/hypershift-operator/controllers/hostedclustersizing"
+	"github.com/openshift/hypershift/support/clusteridentity"
 	"github.com/openshift/hypershift/support/config"
 	"github.com/openshift/hypershift/support/globalconfig"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -37,13 +38,21 @@ import (
 	operatorv1 "github.com/openshift/api/operator/v1"
 	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
 	awsutil "github.com/openshift/hypershift/cmd/infra/aws/util"
+	"github.com/openshift/hypershift/hypershift-operator/controllers/azureresourcetags"
+	"github.com/openshift/hypershift/hypershift-operator/controllers/bootimage"
+	"github.com/openshift/hypershift/hypershift-operator/controllers/failedclustergc"
 	"github.com/openshift/hypershift/hypershift-operator/controllers/hostedcluster"
 	hcmetrics "github.com/openshift/hypershift/hypershift-operator/controllers/hostedcluster/metrics"
+	"github.com/openshift/hypershift/hypershift-operator/controllers/kubeapiserverprobe"
+	"github.com/openshift/hypershift/hypershift-operator/controllers/kubeconfigpublishing"
 	"github.com/openshift/hypershift/hypershift-operator/controllers/nodepool"
 	npmetrics "github.com/openshift/hypershift/hypershift-operator/controllers/nodepool/metrics"
+	"github.com/openshift/hypershift/hypershift-operator/controllers/notification"
 	"github.com/openshift/hypershift/hypershift-operator/controllers/platform/aws"
 	"github.com/openshift/hypershift/hypershift-operator/controllers/proxy"
 	"github.com/openshift/hypershift/hypershift-operator/controllers/scheduler"
+	"github.com/openshift/hypershift/hypershift-operator/controllers/secretsbackup"
+	"github.com/openshift/hypershift/hypershift-operator/controllers/sharding"
 	"github.com/openshift/hypershift/hypershift-operator/controllers/supportedversion"
 	"github.com/openshift/hypershift/hypershift-operator/controllers/uwmtelemetry"
 	kvinfra "github.com/openshift/hypershift/kubevirtexternalinfra"
@@ -51,6 +60,7 @@ import (
 	hyperapi "github.com/openshift/hypershift/support/api"
 	"github.com/openshift/hypershift/support/capabilities"
 	"github.com/openshift/hypershift/support/metrics"
+	"github.com/openshift/hypershift/support/oidc"
 	"github.com/openshift/hypershift/support/releaseinfo"
 	"github.com/openshift/hypershift/support/upsert"
 	hyperutil "github.com/openshift/hypershift/support/util"
@@ -98,6 +108,7 @@ type StartOptions struct {
 	DeploymentName                         string
 	PodName                                string
 	MetricsAddr                            string
+	PprofBindAddress                       string
 	CertDir                                string
 	EnableOCPClusterMonitoring             bool
 	EnableCIDebugOutput                    bool
@@ -107,9 +118,33 @@ type StartOptions struct {
 	OIDCStorageProviderS3BucketName        string
 	OIDCStorageProviderS3Region            string
 	OIDCStorageProviderS3Credentials       string
+	OIDCStorageProvider                    string
+	OIDCStorageProviderAzureAccount        string
+	OIDCStorageProviderAzureContainer      string
+	OIDCStorageProviderGCSBucketName       string
+	OIDCStorageProviderCredentials         string
+	OIDCStorageProviderIssuerURLBase       string
+	OIDCDiscoveryBindAddress               string
 	EnableUWMTelemetryRemoteWrite          bool
 	EnableValidatingWebhook                bool
 	EnableDedicatedRequestServingIsolation bool
+	ReleaseInfoCacheDir                    string
+	ReleaseInfoCacheTTL                    time.Duration
+	ShardCount                             int
+	ControlPlaneHardeningProfile           string
+	ControlPlaneSeccompProfile             string
+	ControlPlaneHardeningExceptions        string
+	FailedClusterGCTTL                     time.Duration
+	FailedClusterGCCleanupCloudResources   bool
+	AzureResourceTagsDenyList              []string
+	MandatoryResourceTags                  map[string]string
+	NotificationWebhookSecret              string
+	MachinePricesConfigMap                 string
+	KubeAPIServerProbeInterval             time.Duration
+	NodePoolConfigGenerationsRetained      int
+	LeaderElectionLeaseDuration            time.Duration
+	LeaderElectionRenewDeadline            time.Duration
+	LeaderElectionRetryPeriod              time.Duration
 }
 
 func NewStartCommand() *cobra.Command {
@@ -119,21 +154,28 @@ func NewStartCommand() *cobra.Command {
 	}
 
 	opts := StartOptions{
-		Namespace:                        "hypershift",
-		DeploymentName:                   "operator",
-		MetricsAddr:                      "0",
-		CertDir:                          "",
-		ControlPlaneOperatorImage:        "",
-		RegistryOverrides:                map[string]string{},
-		PrivatePlatform:                  string(hyperv1.NonePlatform),
-		OIDCStorageProviderS3Region:      "",
-		OIDCStorageProviderS3Credentials: "",
+		Namespace:                         "hypershift",
+		DeploymentName:                    "operator",
+		MetricsAddr:                       "0",
+		CertDir:                           "",
+		ControlPlaneOperatorImage:         "",
+		RegistryOverrides:                 map[string]string{},
+		PrivatePlatform:                   string(hyperv1.NonePlatform),
+		OIDCStorageProviderS3Region:       "",
+		OIDCStorageProviderS3Credentials:  "",
+		OIDCStorageProvider:               oidc.DocumentStoreS3,
+		OIDCStorageProviderAzureContainer: oidc.AzureStaticWebsiteContainer,
+		OIDCDiscoveryBindAddress:          oidc.DefaultDocumentServerBindAddress,
+		LeaderElectionLeaseDuration:       60 * time.Second,
+		LeaderElectionRenewDeadline:       40 * time.Second,
+		LeaderElectionRetryPeriod:         15 * time.Second,
 	}
 
 	cmd.Flags().StringVar(&opts.Namespace, "namespace", opts.Namespace, "The namespace this operator lives in")
 	cmd.Flags().StringVar(&opts.DeploymentName, "deployment-name", opts.DeploymentName, "Legacy flag, does nothing. Use --pod-name instead.")
 	cmd.Flags().StringVar(&opts.PodName, "pod-name", opts.PodName, "The name of the pod the operator runs in")
 	cmd.Flags().StringVar(&opts.MetricsAddr, "metrics-addr", opts.MetricsAddr, "The address the metric endpoint binds to.")
+	cmd.Flags().StringVar(&opts.PprofBindAddress, "pprof-bind-address", opts.PprofBindAddress, fmt.Sprintf("If set, the address the pprof endpoints of the operator bind to, e.g. %s to only serve them on the loopback interface of the pod", config.DefaultPprofBindAddress))
 	cmd.Flags().StringVar(&opts.CertDir, "cert-dir", opts.CertDir, "Path to the serving key and cert for manager")
 	cmd.Flags().StringVar(&opts.ControlPlaneOperatorImage, "control-plane-operator-image", opts.ControlPlaneOperatorImage, "A control plane operator image to use (defaults to match this operator if running in a deployment)")
 	cmd.Flags().BoolVar(&opts.EnableOCPClusterMonitoring, "enable-ocp-cluster-monitoring", opts.EnableOCPClusterMonitoring, "Development-only option that will make your OCP cluster unsupported: If the cluster Prometheus should be configured to scrape metrics")
@@ -143,9 +185,33 @@ func NewStartCommand() *cobra.Command {
 	cmd.Flags().StringVar(&opts.OIDCStorageProviderS3BucketName, "oidc-storage-provider-s3-bucket-name", "", "Name of the bucket in which to store the clusters OIDC discovery information. Required for AWS guest clusters")
 	cmd.Flags().StringVar(&opts.OIDCStorageProviderS3Region, "oidc-storage-provider-s3-region", opts.OIDCStorageProviderS3Region, "Region in which the OIDC bucket is located. Required for AWS guest clusters")
 	cmd.Flags().StringVar(&opts.OIDCStorageProviderS3Credentials, "oidc-storage-provider-s3-credentials", opts.OIDCStorageProviderS3Credentials, "Location of the credentials file for the OIDC bucket. Required for AWS guest clusters.")
+	cmd.Flags().StringVar(&opts.OIDCStorageProvider, "oidc-storage-provider", opts.OIDCStorageProvider, "Storage hosting the clusters OIDC discovery information (supports \"S3\", \"AzureBlob\", \"GCS\" or \"ManagementCluster\")")
+	cmd.Flags().StringVar(&opts.OIDCStorageProviderAzureAccount, "oidc-storage-provider-azure-account", opts.OIDCStorageProviderAzureAccount, "Name of the Azure storage account in which to store the clusters OIDC discovery information. Required with the AzureBlob OIDC storage provider")
+	cmd.Flags().StringVar(&opts.OIDCStorageProviderAzureContainer, "oidc-storage-provider-azure-container", opts.OIDCStorageProviderAzureContainer, "Name of the Azure storage account container in which to store the clusters OIDC discovery information, by default the container of the static website of the storage account")
+	cmd.Flags().StringVar(&opts.OIDCStorageProviderGCSBucketName, "oidc-storage-provider-gcs-bucket-name", opts.OIDCStorageProviderGCSBucketName, "Name of the GCS bucket in which to store the clusters OIDC discovery information. Required with the GCS OIDC storage provider")
+	cmd.Flags().StringVar(&opts.OIDCStorageProviderCredentials, "oidc-storage-provider-credentials", opts.OIDCStorageProviderCredentials, "Location of the credentials file of the AzureBlob or GCS OIDC storage provider: the base64 encoded storage account key, or the service account key")
+	cmd.Flags().StringVar(&opts.OIDCStorageProviderIssuerURLBase, "oidc-storage-provider-issuer-url-base", opts.OIDCStorageProviderIssuerURLBase, "Public URL the stored OIDC discovery information is served from. If set, the OIDC documents of the non-AWS HostedClusters whose issuer URL is under it are stored as well")
+	cmd.Flags().StringVar(&opts.OIDCDiscoveryBindAddress, "oidc-discovery-bind-address", opts.OIDCDiscoveryBindAddress, "The address the OIDC discovery information stored by the ManagementCluster OIDC storage provider is served on")
 	cmd.Flags().BoolVar(&opts.EnableUWMTelemetryRemoteWrite, "enable-uwm-telemetry-remote-write", opts.EnableUWMTelemetryRemoteWrite, "If true, enables a controller that ensures user workload monitoring is enabled and that it is configured to remote write telemetry metrics from control planes")
 	cmd.Flags().BoolVar(&opts.EnableValidatingWebhook, "enable-validating-webhook", false, "Enable webhook for validating hypershift API types")
 	cmd.Flags().BoolVar(&opts.EnableDedicatedRequestServingIsolation, "enable-dedicated-request-serving-isolation", true, "If true, enables scheduling of request serving components to dedicated nodes")
+	cmd.Flags().StringVar(&opts.ReleaseInfoCacheDir, "release-info-cache-dir", opts.ReleaseInfoCacheDir, "If set, release image metadata is cached by digest in this directory")
+	cmd.Flags().IntVar(&opts.ShardCount, "shard-count", 1, "Number of shards HostedClusters are split into. When greater than 1, each replica acquires a shard and reconciles only its HostedClusters, so that as many replicas as shards are active at the same time")
+	cmd.Flags().StringVar(&opts.ControlPlaneHardeningProfile, "control-plane-hardening-profile", opts.ControlPlaneHardeningProfile, "Hardening profile applied to the control plane pods of HostedClusters without the hypershift.openshift.io/control-plane-hardening-profile annotation (supports \"None\" or \"Restricted\")")
+	cmd.Flags().StringVar(&opts.ControlPlaneSeccompProfile, "control-plane-seccomp-profile", opts.ControlPlaneSeccompProfile, "Seccomp profile of the hardened control plane pods of HostedClusters without the hypershift.openshift.io/control-plane-seccomp-profile annotation: RuntimeDefault or Localhost/<path>")
+	cmd.Flags().StringVar(&opts.ControlPlaneHardeningExceptions, "control-plane-hardening-exceptions", opts.ControlPlaneHardeningExceptions, "Comma separated names of the control plane Deployments and StatefulSets not hardened, for HostedClusters without the hypershift.openshift.io/control-plane-hardening-exceptions annotation")
+	cmd.Flags().DurationVar(&opts.ReleaseInfoCacheTTL, "release-info-cache-ttl", releaseinfo.DefaultPersistentCacheTTL, "How long release image metadata cached in --release-info-cache-dir is trusted before it is refreshed")
+	cmd.Flags().DurationVar(&opts.FailedClusterGCTTL, "failed-cluster-gc-ttl", opts.FailedClusterGCTTL, "If set, HostedClusters that never became available and whose provisioning has been failing for longer than this duration are deleted")
+	cmd.Flags().BoolVar(&opts.FailedClusterGCCleanupCloudResources, "failed-cluster-gc-cleanup-cloud-resources", opts.FailedClusterGCCleanupCloudResources, "If true, the cloud infrastructure of the HostedClusters deleted because of --failed-cluster-gc-ttl is cleaned up too, otherwise it's left intact")
+	cmd.Flags().StringSliceVar(&opts.AzureResourceTagsDenyList, "azure-resource-tags-deny-list", opts.AzureResourceTagsDenyList, "Keys of the tags of the resourceTags of Azure HostedClusters which are never applied to their resources, e.g. tags reserved by the service managing the resource groups. Keys are case-insensitive")
+	cmd.Flags().StringToStringVar(&opts.MandatoryResourceTags, "mandatory-resource-tags", opts.MandatoryResourceTags, "Tags applied to the cloud resources of every AWS and Azure HostedCluster along with their identity tags, e.g. cost-center=1234,org=payments. They override the resource tags of the HostedClusters with the same key")
+	cmd.Flags().IntVar(&opts.NodePoolConfigGenerationsRetained, "nodepool-config-generations-retained", 5, "Number of most recent config generations of a NodePool whose token and user data Secrets are kept, the Secrets of older generations not used by any Machine are deleted. 0 disables the deletion")
+	cmd.Flags().StringVar(&opts.NotificationWebhookSecret, "notification-webhook-secret", opts.NotificationWebhookSecret, "If set, the name of a Secret in the operator namespace with the url of a webhook, and optionally the hmac-key to sign with, which lifecycle notifications of the HostedClusters and NodePools are POSTed to")
+	cmd.Flags().DurationVar(&opts.KubeAPIServerProbeInterval, "kube-apiserver-probe-interval", opts.KubeAPIServerProbeInterval, "If set, how often the kube-apiserver of each HostedCluster is probed with synthetic requests through its published endpoint and through konnectivity, to export its availability and latency")
+	cmd.Flags().StringVar(&opts.MachinePricesConfigMap, "machine-prices-configmap", opts.MachinePricesConfigMap, "If set, the name of a ConfigMap in the operator namespace holding the hourly prices of the machine types, used to estimate the cost of the NodePools")
+	cmd.Flags().DurationVar(&opts.LeaderElectionLeaseDuration, "leader-election-lease-duration", opts.LeaderElectionLeaseDuration, "How long the replicas not leading wait before taking over the leader and shard leases of a replica which stopped renewing them")
+	cmd.Flags().DurationVar(&opts.LeaderElectionRenewDeadline, "leader-election-renew-deadline", opts.LeaderElectionRenewDeadline, "How long the leading replica retries renewing its leases before giving up")
+	cmd.Flags().DurationVar(&opts.LeaderElectionRetryPeriod, "leader-election-retry-period", opts.LeaderElectionRetryPeriod, "How long the replicas wait between attempts to acquire or renew their leases")
 
 	cmd.Run = func(cmd *cobra.Command, args []string) {
 		ctx, cancel := context.WithCancel(ctrl.SetupSignalHandler())
@@ -158,6 +224,28 @@ func NewStartCommand() *cobra.Command {
 			os.Exit(1)
 		}
 
+		switch opts.ControlPlaneHardeningProfile {
+		case "", hyperv1.ControlPlaneHardeningProfileNone, hyperv1.ControlPlaneHardeningProfileRestricted:
+		default:
+			fmt.Printf("Unsupported control plane hardening profile: %q\n", opts.ControlPlaneHardeningProfile)
+			os.Exit(1)
+		}
+
+		if err := clusteridentity.ValidateMandatoryTags(opts.MandatoryResourceTags); err != nil {
+			fmt.Printf("Invalid mandatory resource tags: %v\n", err)
+			os.Exit(1)
+		}
+
+		if opts.ShardCount < 1 {
+			fmt.Printf("Invalid shard count: %d\n", opts.ShardCount)
+			os.Exit(1)
+		}
+
+		if opts.LeaderElectionRetryPeriod <= 0 || opts.LeaderElectionRenewDeadline <= opts.LeaderElectionRetryPeriod || opts.LeaderElectionLeaseDuration <= opts.LeaderElectionRenewDeadline {
+			fmt.Printf("Invalid leader election durations: the lease duration (%s) must be greater than the renew deadline (%s), which must be greater than the retry period (%s)\n", opts.LeaderElectionLeaseDuration, opts.LeaderElectionRenewDeadline, opts.LeaderElectionRetryPeriod)
+			os.Exit(1)
+		}
+
 		if err := run(ctx, &opts, ctrl.Log.WithName("setup")); err != nil {
 			fmt.Println(err)
 			os.Exit(1)
@@ -173,14 +261,50 @@ func run(ctx context.Context, opts *StartOptions, log logr.Logger) error {
 
 	restConfig := ctrl.GetConfigOrDie()
 	restConfig.UserAgent = "hypershift-operator-manager"
-	leaseDuration := time.Second * 60
-	renewDeadline := time.Second * 40
-	retryPeriod := time.Second * 15
+	leaseDuration := opts.LeaderElectionLeaseDuration
+	renewDeadline := opts.LeaderElectionRenewDeadline
+	retryPeriod := opts.LeaderElectionRetryPeriod
+
+	// When sharded, every replica holding a shard Lease runs the HostedCluster and NodePool controllers for the
+	// HostedClusters of its shard. The other controllers keep running only in the leader replica.
+	var shard sharding.Shard
+	if opts.ShardCount > 1 {
+		identity := opts.PodName
+		if identity == "" {
+			identity, _ = os.Hostname()
+		}
+		var lost <-chan struct{}
+		var err error
+		shard, lost, err = sharding.Acquire(ctx, restConfig, sharding.AcquireOptions{
+			Namespace:     opts.Namespace,
+			Identity:      identity,
+			Count:         opts.ShardCount,
+			LeaseDuration: leaseDuration,
+			RenewDeadline: renewDeadline,
+			RetryPeriod:   retryPeriod,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to acquire a shard: %w", err)
+		}
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(ctx)
+		defer cancel()
+		go func() {
+			select {
+			case <-lost:
+				log.Info("Lost the lease of the shard, stopping", "shard", shard.Index)
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+	}
+
 	mgr, err := ctrl.NewManager(restConfig, ctrl.Options{
 		Scheme: hyperapi.Scheme,
 		Metrics: metricsserver.Options{
 			BindAddress: opts.MetricsAddr,
 		},
+		PprofBindAddress: opts.PprofBindAddress,
 		WebhookServer: webhook.NewServer(webhook.Options{
 			Port:    9443,
 			CertDir: opts.CertDir,
@@ -293,10 +417,19 @@ func run(ctx context.Context, opts *StartOptions, log logr.Logger) error {
 		}
 	}
 
+	var releaseInfoProvider releaseinfo.Provider = &releaseinfo.RegistryClientProvider{}
+	if opts.ReleaseInfoCacheDir != "" {
+		releaseInfoProvider = &releaseinfo.PersistentCacheProvider{
+			Inner: releaseInfoProvider,
+			Dir:   opts.ReleaseInfoCacheDir,
+			TTL:   opts.ReleaseInfoCacheTTL,
+		}
+	}
+
 	releaseProviderWithOpenShiftImageRegistryOverrides := &releaseinfo.ProviderWithOpenShiftImageRegistryOverridesDecorator{
 		Delegate: &releaseinfo.RegistryMirrorProviderDecorator{
 			Delegate: &releaseinfo.CachedProvider{
-				Inner: &releaseinfo.RegistryClientProvider{},
+				Inner: releaseInfoProvider,
 				Cache: map[string]*releaseinfo.ReleaseImage{},
 			},
 			RegistryOverrides: opts.RegistryOverrides,
@@ -330,13 +463,29 @@ func run(ctx context.Context, opts *StartOptions, log logr.Logger) error {
 		MonitoringDashboards:                    monitoringDashboards,
 		CertRotationScale:                       certRotationScale,
 		EnableCVOManagementClusterMetricsAccess: enableCVOManagementClusterMetricsAccess,
+		Shard:                                   shard,
+		ControlPlaneHardening: map[string]string{
+			hyperv1.ControlPlaneHardeningProfileAnnotation:    opts.ControlPlaneHardeningProfile,
+			hyperv1.ControlPlaneSeccompProfileAnnotation:      opts.ControlPlaneSeccompProfile,
+			hyperv1.ControlPlaneHardeningExceptionsAnnotation: opts.ControlPlaneHardeningExceptions,
+		},
+		MandatoryResourceTags: opts.MandatoryResourceTags,
 	}
-	if opts.OIDCStorageProviderS3BucketName != "" {
-		awsSession := awsutil.NewSession("hypershift-operator-oidc-bucket", opts.OIDCStorageProviderS3Credentials, "", "", opts.OIDCStorageProviderS3Region)
-		awsConfig := awsutil.NewConfig()
-		s3Client := s3.New(awsSession, awsConfig)
-		hostedClusterReconciler.S3Client = s3Client
-		hostedClusterReconciler.OIDCStorageProviderS3BucketName = opts.OIDCStorageProviderS3BucketName
+	oidcDocumentStore, err := newOIDCDocumentStore(opts, mgr.GetClient())
+	if err != nil {
+		return fmt.Errorf("unable to set up the OIDC storage provider: %w", err)
+	}
+	hostedClusterReconciler.OIDCDocumentStore = oidcDocumentStore
+	hostedClusterReconciler.OIDCIssuerURLBase = strings.TrimSuffix(opts.OIDCStorageProviderIssuerURLBase, "/")
+	if opts.OIDCStorageProvider == oidc.DocumentStoreManagementCluster {
+		if err := mgr.Add(&oidc.DocumentServer{
+			Client:      mgr.GetClient(),
+			Namespace:   opts.Namespace,
+			BindAddress: opts.OIDCDiscoveryBindAddress,
+		}); err != nil {
+			return fmt.Errorf("unable to create the OIDC discovery server: %w", err)
+		}
+		log.Info("Serving the OIDC discovery documents", "address", opts.OIDCDiscoveryBindAddress)
 	}
 	if err := hostedClusterReconciler.SetupWithManager(mgr, createOrUpdate, metricsSet, opts.Namespace); err != nil {
 		return fmt.Errorf("unable to create controller: %w", err)
@@ -369,27 +518,85 @@ func run(ctx context.Context, opts *StartOptions, log logr.Logger) error {
 		}
 	}
 
-	if err := (&nodepool.NodePoolReconciler{
+	var ec2Client ec2iface.EC2API
+	if hyperv1.PlatformType(opts.PrivatePlatform) == hyperv1.AWSPlatform {
+		awsSession := awsutil.NewSession("hypershift-operator", "", "", "", "")
+		awsConfig := awsutil.NewConfig()
+		ec2Client = ec2.New(awsSession, awsConfig)
+	}
+
+	nodePoolReconciler := &nodepool.NodePoolReconciler{
 		Client:                  mgr.GetClient(),
 		ReleaseProvider:         releaseProviderWithOpenShiftImageRegistryOverrides,
 		CreateOrUpdateProvider:  createOrUpdate,
 		HypershiftOperatorImage: operatorImage,
 		ImageMetadataProvider:   &hyperutil.RegistryClientImageMetadataProvider{},
 		KubevirtInfraClients:    kvinfra.NewKubevirtInfraClientMap(),
-	}).SetupWithManager(mgr); err != nil {
+		Shard:                   shard,
+		EC2Client:               ec2Client,
+	}
+	if hyperv1.PlatformType(opts.PrivatePlatform) == hyperv1.AWSPlatform {
+		nodePoolReconciler.EC2ClientForRegion = func(region string) ec2iface.EC2API {
+			return ec2.New(awsutil.NewSession("hypershift-operator", "", "", "", region), awsutil.NewConfig())
+		}
+	}
+	if opts.MachinePricesConfigMap != "" {
+		nodePoolReconciler.PriceSource = &nodepool.ConfigMapMachinePriceSource{
+			Client:    mgr.GetClient(),
+			Namespace: opts.Namespace,
+			Name:      opts.MachinePricesConfigMap,
+		}
+	}
+	if err := nodePoolReconciler.SetupWithManager(mgr); err != nil {
 		return fmt.Errorf("unable to create controller: %w", err)
 	}
 
-	{
-		var ec2Client ec2iface.EC2API
+	if opts.NodePoolConfigGenerationsRetained > 0 {
+		if err := (&nodepool.SecretGCReconciler{
+			Client:    mgr.GetClient(),
+			Retention: opts.NodePoolConfigGenerationsRetained,
+			Shard:     shard,
+		}).SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("unable to create nodepool secret garbage collection controller: %w", err)
+		}
+	}
 
+	npmetrics.CreateAndRegisterNodePoolsMetricsCollector(mgr.GetClient(), ec2Client)
+
+	{
+		bootImageReconciler := &bootimage.Reconciler{
+			Client:          mgr.GetClient(),
+			ReleaseProvider: releaseProviderWithOpenShiftImageRegistryOverrides,
+		}
+		// AMIs missing from a release are copied with the credentials of the operator, which it only has on AWS.
 		if hyperv1.PlatformType(opts.PrivatePlatform) == hyperv1.AWSPlatform {
-			awsSession := awsutil.NewSession("hypershift-operator", "", "", "", "")
-			awsConfig := awsutil.NewConfig()
-			ec2Client = ec2.New(awsSession, awsConfig)
+			bootImageReconciler.EC2ClientForRegion = func(region string) ec2iface.EC2API {
+				return ec2.New(awsutil.NewSession("hypershift-operator", "", "", "", region), awsutil.NewConfig())
+			}
+		}
+		if err := bootImageReconciler.SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("unable to create boot image controller: %w", err)
 		}
+	}
 
-		npmetrics.CreateAndRegisterNodePoolsMetricsCollector(mgr.GetClient(), ec2Client)
+	if err := (&azureresourcetags.Reconciler{
+		Client:         mgr.GetClient(),
+		TagKeyDenyList: opts.AzureResourceTagsDenyList,
+		MandatoryTags:  opts.MandatoryResourceTags,
+	}).SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create azure resource tags controller: %w", err)
+	}
+
+	if err := (&kubeconfigpublishing.Reconciler{
+		Client: mgr.GetClient(),
+	}).SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create kubeconfig publishing controller: %w", err)
+	}
+
+	if err := (&secretsbackup.Reconciler{
+		Client: mgr.GetClient(),
+	}).SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create secrets backup controller: %w", err)
 	}
 
 	if mgmtClusterCaps.Has(capabilities.CapabilityProxy) {
@@ -400,7 +607,7 @@ func run(ctx context.Context, opts *StartOptions, log logr.Logger) error {
 
 	enableSizeTagging := os.Getenv("ENABLE_SIZE_TAGGING") == "1"
 	if enableSizeTagging {
-		if err := hostedclustersizing.SetupWithManager(ctx, mgr, operatorImage, releaseProviderWithOpenShiftImageRegistryOverrides, imageMetaDataProvider); err != nil {
+		if err := hostedclustersizing.SetupWithManager(ctx, mgr, operatorImage, releaseProviderWithOpenShiftImageRegistryOverrides, imageMetaDataProvider, createOrUpdate); err != nil {
 			return fmt.Errorf("failed to set up hosted cluster sizing operator: %w", err)
 		}
 	}
@@ -416,6 +623,43 @@ func run(ctx context.Context, opts *StartOptions, log logr.Logger) error {
 		}
 	}
 
+	// If enabled, start controller to delete HostedClusters whose provisioning failed
+	if opts.FailedClusterGCTTL > 0 {
+		if err := (&failedclustergc.Reconciler{
+			Client:                mgr.GetClient(),
+			TTL:                   opts.FailedClusterGCTTL,
+			CleanupCloudResources: opts.FailedClusterGCCleanupCloudResources,
+		}).SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("unable to create failed cluster garbage collection controller: %w", err)
+		}
+		log.Info("Failed cluster garbage collection enabled", "ttl", opts.FailedClusterGCTTL)
+	}
+
+	// If enabled, send lifecycle notifications of HostedClusters and NodePools to a webhook
+	if opts.NotificationWebhookSecret != "" {
+		if err := mgr.Add(&notification.Notifier{
+			Client:     mgr.GetAPIReader(),
+			Cache:      mgr.GetCache(),
+			Namespace:  opts.Namespace,
+			SecretName: opts.NotificationWebhookSecret,
+			Predicate:  hyperutil.PredicatesForHostedClusterAnnotationScoping(mgr.GetClient()),
+		}); err != nil {
+			return fmt.Errorf("unable to create notifier: %w", err)
+		}
+		log.Info("Lifecycle notifications enabled", "secret", opts.NotificationWebhookSecret)
+	}
+
+	// If enabled, start controller to probe the kube-apiservers of the HostedClusters
+	if opts.KubeAPIServerProbeInterval > 0 {
+		if err := (&kubeapiserverprobe.Reconciler{
+			Client:   mgr.GetClient(),
+			Interval: opts.KubeAPIServerProbeInterval,
+		}).SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("unable to create kube-apiserver probe controller: %w", err)
+		}
+		log.Info("Kube-apiserver probes enabled", "interval", opts.KubeAPIServerProbeInterval)
+	}
+
 	// Start controller to manage supported versions configmap
 	if err := supportedversion.New(mgr.GetClient(), createOrUpdate, opts.Namespace).
 		SetupWithManager(mgr); err != nil {
@@ -523,3 +767,39 @@ func run(ctx context.Context, opts *StartOptions, log logr.Logger) error {
 	log.Info("starting manager")
 	return mgr.Start(ctx)
 }
+
+// newOIDCDocumentStore returns the storage of the clusters OIDC discovery documents configured by the options, or nil
+// if none is configured.
+func newOIDCDocumentStore(opts *StartOptions, c crclient.Client) (oidc.DocumentStore, error) {
+	switch opts.OIDCStorageProvider {
+	case oidc.DocumentStoreS3:
+		if opts.OIDCStorageProviderS3BucketName == "" {
+			return nil, nil
+		}
+		awsSession := awsutil.NewSession("hypershift-operator-oidc-bucket", opts.OIDCStorageProviderS3Credentials, "", "", opts.OIDCStorageProviderS3Region)
+		awsConfig := awsutil.NewConfig()
+		return &oidc.S3DocumentStore{
+			Client: s3.New(awsSession, awsConfig),
+			Bucket: opts.OIDCStorageProviderS3BucketName,
+		}, nil
+	case oidc.DocumentStoreAzureBlob:
+		accountKey, err := os.ReadFile(opts.OIDCStorageProviderCredentials)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the Azure storage account key: %w", err)
+		}
+		return oidc.NewAzureBlobDocumentStore(opts.OIDCStorageProviderAzureAccount, opts.OIDCStorageProviderAzureContainer, accountKey)
+	case oidc.DocumentStoreGCS:
+		serviceAccountKey, err := os.ReadFile(opts.OIDCStorageProviderCredentials)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the GCS service account key: %w", err)
+		}
+		return oidc.NewGCSDocumentStore(opts.OIDCStorageProviderGCSBucketName, serviceAccountKey)
+	case oidc.DocumentStoreManagementCluster:
+		return &oidc.ConfigMapDocumentStore{
+			Client:    c,
+			Namespace: opts.Namespace,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported OIDC storage provider %q, must be one of %s, %s, %s or %s", opts.OIDCStorageProvider, oidc.DocumentStoreS3, oidc.DocumentStoreAzureBlob, oidc.DocumentStoreGCS, oidc.DocumentStoreManagementCluster)
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/openshift/hypershift/ignition-server/controllers"
 	"github.com/openshift/hypershift/pkg/version"
 	hyperapi "github.com/openshift/hypershift/support/api"
+	"github.com/openshift/hypershift/support/config"
 	"github.com/openshift/hypershift/support/releaseinfo"
 	"github.com/openshift/hypershift/support/util"
 	"github.com/prometheus/client_golang/prometheus"
@@ -42,12 +43,14 @@ var (
 	ignPathPattern                       = regexp.MustCompile("^/ignition[^/ ]*$")
 	payloadStore                         = controllers.NewPayloadStore()
 	getRequestsPerNodePool               = prometheus.NewCounterVec(prometheus.CounterOpts{Name: "ign_server_get_request"}, []string{"nodePool"})
+	attestationFailuresPerNodePool       = prometheus.NewCounterVec(prometheus.CounterOpts{Name: "ign_server_attestation_failures_total"}, []string{"nodePool"})
 	TokenSecretIgnitionReachedAnnotation = "hypershift.openshift.io/ignition-reached"
 )
 
 func init() {
 	metrics.Registry.MustRegister(
 		getRequestsPerNodePool,
+		attestationFailuresPerNodePool,
 	)
 }
 
@@ -60,6 +63,14 @@ type Options struct {
 	WorkDir             string
 	MetricsAddr         string
 	FeatureGateManifest string
+	PayloadCacheDir     string
+	// PayloadStorageConfig is the directory holding the payload storage configuration.
+	// When set, payloads are published to object storage and Machines download them through signed URLs.
+	PayloadStorageConfig string
+	PayloadURLValidity   time.Duration
+	// AttestationConfig is the directory holding the attestation configuration.
+	// When set, payloads are only handed out to instances whose platform attestation is verified.
+	AttestationConfig string
 }
 
 // This is a https server that enable us to satisfy
@@ -77,12 +88,13 @@ func NewStartCommand() *cobra.Command {
 	}
 
 	opts := Options{
-		Addr:              "0.0.0.0:9090",
-		MetricsAddr:       "0.0.0.0:8080",
-		CertFile:          "/var/run/secrets/ignition/serving-cert/tls.crt",
-		KeyFile:           "/var/run/secrets/ignition/serving-cert/tls.key",
-		WorkDir:           "/payloads",
-		RegistryOverrides: map[string]string{},
+		Addr:               "0.0.0.0:9090",
+		MetricsAddr:        "0.0.0.0:8080",
+		CertFile:           "/var/run/secrets/ignition/serving-cert/tls.crt",
+		KeyFile:            "/var/run/secrets/ignition/serving-cert/tls.key",
+		WorkDir:            "/payloads",
+		RegistryOverrides:  map[string]string{},
+		PayloadURLValidity: 10 * time.Minute,
 	}
 
 	cmd.Flags().StringVar(&opts.Addr, "addr", opts.Addr, "Listen address")
@@ -93,6 +105,10 @@ func NewStartCommand() *cobra.Command {
 	cmd.Flags().StringVar(&opts.WorkDir, "work-dir", opts.WorkDir, "Directory in which to store transient working data")
 	cmd.Flags().StringVar(&opts.MetricsAddr, "metrics-addr", opts.MetricsAddr, "The address the metric endpoint binds to.")
 	cmd.Flags().StringVar(&opts.FeatureGateManifest, "feature-gate-manifest", opts.FeatureGateManifest, "Path to a rendered featuregates.config.openshift.io/v1 file")
+	cmd.Flags().StringVar(&opts.PayloadCacheDir, "payload-cache-dir", opts.PayloadCacheDir, "Directory in which to keep generated payloads so they survive restarts. Payloads are only kept in memory if empty")
+	cmd.Flags().StringVar(&opts.PayloadStorageConfig, "payload-storage-config", opts.PayloadStorageConfig, "Directory holding the configuration of the object storage to publish payloads to. Payloads are served by the ignition server if empty")
+	cmd.Flags().DurationVar(&opts.PayloadURLValidity, "payload-url-validity", opts.PayloadURLValidity, "How long the signed URLs of published payloads are valid")
+	cmd.Flags().StringVar(&opts.AttestationConfig, "attestation-config", opts.AttestationConfig, "Directory holding the configuration of the platform attestation required from instances before handing out payloads. Attestation is not required if empty")
 
 	cmd.Run = func(cmd *cobra.Command, args []string) {
 		ctx, cancel := context.WithCancel(context.Background())
@@ -115,7 +131,7 @@ func NewStartCommand() *cobra.Command {
 
 // setUpPayloadStoreReconciler sets up manager with a TokenSecretReconciler controller
 // to keep the PayloadStore up to date.
-func setUpPayloadStoreReconciler(ctx context.Context, registryOverrides map[string]string, cloudProvider hyperv1.PlatformType, cacheDir string, metricsAddr string, featureGateManifest string) (ctrl.Manager, error) {
+func setUpPayloadStoreReconciler(ctx context.Context, registryOverrides map[string]string, cloudProvider hyperv1.PlatformType, cacheDir string, metricsAddr string, featureGateManifest string, payloadPublisher controllers.PayloadPublisher) (ctrl.Manager, error) {
 	if os.Getenv(namespaceEnvVariableName) == "" {
 		return nil, fmt.Errorf("environment variable %s is empty, this is not supported", namespaceEnvVariableName)
 	}
@@ -130,6 +146,7 @@ func setUpPayloadStoreReconciler(ctx context.Context, registryOverrides map[stri
 		Metrics: metricsserver.Options{
 			BindAddress: metricsAddr,
 		},
+		PprofBindAddress: os.Getenv(config.PprofBindAddressEnvVar),
 		Cache: cache.Options{
 			DefaultNamespaces: map[string]cache.Config{os.Getenv(namespaceEnvVariableName): {}},
 		},
@@ -150,8 +167,9 @@ func setUpPayloadStoreReconciler(ctx context.Context, registryOverrides map[stri
 	}
 
 	if err = (&controllers.TokenSecretReconciler{
-		Client:       mgr.GetClient(),
-		PayloadStore: payloadStore,
+		Client:           mgr.GetClient(),
+		PayloadStore:     payloadStore,
+		PayloadPublisher: payloadPublisher,
 		IgnitionProvider: &controllers.LocalIgnitionProvider{
 			ReleaseProvider: &releaseinfo.ProviderWithOpenShiftImageRegistryOverridesDecorator{
 				Delegate: &releaseinfo.RegistryMirrorProviderDecorator{
@@ -189,7 +207,30 @@ func run(ctx context.Context, opts Options) error {
 		return fmt.Errorf("failed to load serving cert: %w", err)
 	}
 
-	mgr, err := setUpPayloadStoreReconciler(ctx, opts.RegistryOverrides, hyperv1.PlatformType(opts.Platform), opts.WorkDir, opts.MetricsAddr, opts.FeatureGateManifest)
+	if opts.PayloadCacheDir != "" {
+		payloadStore, err = controllers.NewPersistentPayloadStore(opts.PayloadCacheDir)
+		if err != nil {
+			return fmt.Errorf("failed to set up payload cache: %w", err)
+		}
+	}
+
+	var payloadPublisher controllers.PayloadPublisher
+	if opts.PayloadStorageConfig != "" {
+		payloadPublisher, err = controllers.NewPayloadPublisherFromDir(opts.PayloadStorageConfig)
+		if err != nil {
+			return fmt.Errorf("failed to set up payload storage: %w", err)
+		}
+	}
+
+	var attestor controllers.Attestor
+	if opts.AttestationConfig != "" {
+		attestor, err = controllers.NewAttestorFromDir(opts.AttestationConfig)
+		if err != nil {
+			return fmt.Errorf("failed to set up attestation: %w", err)
+		}
+	}
+
+	mgr, err := setUpPayloadStoreReconciler(ctx, opts.RegistryOverrides, hyperv1.PlatformType(opts.Platform), opts.WorkDir, opts.MetricsAddr, opts.FeatureGateManifest, payloadPublisher)
 	if err != nil {
 		return fmt.Errorf("error setting up manager: %w", err)
 	}
@@ -235,6 +276,18 @@ func run(ctx context.Context, opts Options) error {
 			return
 		}
 
+		if attestor != nil {
+			instanceID, err := attestor.Attest(r, time.Now())
+			if err != nil {
+				log.Printf("Attestation failed: %s", err)
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				eventRecorder.Event(tokenSecret, corev1.EventTypeWarning, "GetPayloadFailed", fmt.Sprintf("Attestation failed: %s", err))
+				attestationFailuresPerNodePool.WithLabelValues(r.Header.Get("NodePool")).Inc()
+				return
+			}
+			log.Printf("Attested instance %s", instanceID)
+		}
+
 		value, ok := payloadStore.Get(string(decodedToken))
 		if !ok {
 			// We return a 5xx here to give ignition the chance to backoff and retry if the machine request happens
@@ -246,8 +299,19 @@ func run(ctx context.Context, opts Options) error {
 			return
 		}
 
+		payload := value.Payload
+		if payloadPublisher != nil && value.PublishedKey != "" {
+			// Point Ignition to the published payload, falling back to serving it if the URL can't be signed.
+			stub, err := controllers.PayloadStub(payloadPublisher, value.PublishedKey, value.Payload, opts.PayloadURLValidity)
+			if err != nil {
+				log.Printf("Failed to serve published payload, serving it directly: %s", err)
+			} else {
+				payload = stub
+			}
+		}
+
 		w.WriteHeader(http.StatusOK)
-		w.Write(value.Payload)
+		w.Write(payload)
 
 		eventRecorder.Event(tokenSecret, corev1.EventTypeNormal, "GetPayload", "")
 		getRequestsPerNodePool.WithLabelValues(r.Header.Get("NodePool")).Inc()
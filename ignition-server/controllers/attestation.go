@@ -0,0 +1,366 @@
+package controllers
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Keys of the attestation configuration, mounted from a ConfigMap into the ignition server.
+const (
+	AttestationTypeKey                 = "type"
+	AttestationCertificatesKey         = "certificates"
+	AttestationAccountKey              = "account"
+	AttestationRegionKey               = "region"
+	AttestationMaxAgeKey               = "maxAge"
+	AttestationTypeAWSInstanceIdentity = "AWSInstanceIdentity"
+	AttestationTypeAzureAttestedData   = "AzureAttestedData"
+	defaultAttestationMaxAge           = time.Hour
+	azureAttestedDataTimeFormat        = "01/02/06 15:04:05 -0700"
+	azureAttestedDataSignerDNSSuffix   = "metadata.azure.com"
+	attestationClockSkewTolerance      = 5 * time.Minute
+)
+
+// Headers of the ignition payload requests carrying the attestation of the requesting instance.
+const (
+	// AttestationDocumentHeader is the base64 encoded attested document, when it is not embedded in the signature.
+	AttestationDocumentHeader = "Attestation-Document"
+	// AttestationSignatureHeader is the base64 encoded signature of the attested document, as returned by the
+	// instance metadata service.
+	AttestationSignatureHeader = "Attestation-Signature"
+)
+
+var (
+	oidPKCS7SignedData = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 2}
+	oidMessageDigest   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 4}
+	oidSHA256          = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 1}
+)
+
+// Attestor verifies the platform attestation of the instance requesting an ignition payload, before the payload
+// is handed out. This binds payloads to instances of the expected cloud account instead of to the bearer token
+// alone.
+type Attestor interface {
+	// Attest verifies the attestation carried by the request and returns the ID of the attested instance.
+	Attest(r *http.Request, now time.Time) (string, error)
+}
+
+// NewAttestorFromDir returns the Attestor configured by the files in dir, named after the keys of the
+// attestation configuration.
+func NewAttestorFromDir(dir string) (Attestor, error) {
+	read := func(key string) string {
+		data, err := os.ReadFile(filepath.Join(dir, key))
+		if err != nil {
+			return ""
+		}
+		return strings.TrimSpace(string(data))
+	}
+
+	certificates, err := parseCertificates([]byte(read(AttestationCertificatesKey)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid attestation %s: %w", AttestationCertificatesKey, err)
+	}
+	if len(certificates) == 0 {
+		return nil, fmt.Errorf("attestation requires %s", AttestationCertificatesKey)
+	}
+	account := read(AttestationAccountKey)
+	if account == "" {
+		return nil, fmt.Errorf("attestation requires an %s", AttestationAccountKey)
+	}
+	maxAge := defaultAttestationMaxAge
+	if value := read(AttestationMaxAgeKey); value != "" {
+		maxAge, err = time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid attestation %s: %w", AttestationMaxAgeKey, err)
+		}
+	}
+
+	switch attestationType := read(AttestationTypeKey); attestationType {
+	case AttestationTypeAWSInstanceIdentity:
+		return &AWSInstanceIdentityAttestor{
+			Certificates: certificates,
+			AccountID:    account,
+			Region:       read(AttestationRegionKey),
+			MaxAge:       maxAge,
+		}, nil
+	case AttestationTypeAzureAttestedData:
+		roots := x509.NewCertPool()
+		for _, certificate := range certificates {
+			roots.AddCert(certificate)
+		}
+		return &AzureAttestedDataAttestor{
+			Roots:          roots,
+			SubscriptionID: account,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported attestation type %q, must be %s or %s", attestationType, AttestationTypeAWSInstanceIdentity, AttestationTypeAzureAttestedData)
+	}
+}
+
+func parseCertificates(data []byte) ([]*x509.Certificate, error) {
+	var certificates []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return certificates, nil
+		}
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certificates = append(certificates, certificate)
+	}
+}
+
+func attestationHeader(r *http.Request, header string) ([]byte, error) {
+	value := r.Header.Get(header)
+	if value == "" {
+		return nil, fmt.Errorf("missing %s header", header)
+	}
+	decoded, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(value), ""))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s header: %w", header, err)
+	}
+	return decoded, nil
+}
+
+// AWSInstanceIdentityAttestor verifies AWS instance identity documents, signed with the RSA-SHA256 signature
+// served by the instance metadata service at /latest/dynamic/instance-identity/signature.
+type AWSInstanceIdentityAttestor struct {
+	// Certificates are the AWS public certificates of the region, used to verify the signature.
+	Certificates []*x509.Certificate
+	AccountID    string
+	// Region, if set, must match the region of the instance.
+	Region string
+	// MaxAge is how long after the instance launch its identity document is accepted, which bounds replays.
+	MaxAge time.Duration
+}
+
+type awsInstanceIdentityDocument struct {
+	AccountID   string    `json:"accountId"`
+	Region      string    `json:"region"`
+	InstanceID  string    `json:"instanceId"`
+	PendingTime time.Time `json:"pendingTime"`
+}
+
+func (a *AWSInstanceIdentityAttestor) Attest(r *http.Request, now time.Time) (string, error) {
+	document, err := attestationHeader(r, AttestationDocumentHeader)
+	if err != nil {
+		return "", err
+	}
+	signature, err := attestationHeader(r, AttestationSignatureHeader)
+	if err != nil {
+		return "", err
+	}
+
+	verified := false
+	for _, certificate := range a.Certificates {
+		if certificate.CheckSignature(x509.SHA256WithRSA, document, signature) == nil {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return "", fmt.Errorf("instance identity document signature can't be verified")
+	}
+
+	identity := awsInstanceIdentityDocument{}
+	if err := json.Unmarshal(document, &identity); err != nil {
+		return "", fmt.Errorf("invalid instance identity document: %w", err)
+	}
+	if identity.AccountID != a.AccountID {
+		return "", fmt.Errorf("instance %s belongs to account %s, expected %s", identity.InstanceID, identity.AccountID, a.AccountID)
+	}
+	if a.Region != "" && identity.Region != a.Region {
+		return "", fmt.Errorf("instance %s is in region %s, expected %s", identity.InstanceID, identity.Region, a.Region)
+	}
+	if a.MaxAge > 0 && now.Sub(identity.PendingTime) > a.MaxAge {
+		return "", fmt.Errorf("instance %s was launched at %s, more than %s ago", identity.InstanceID, identity.PendingTime.Format(time.RFC3339), a.MaxAge)
+	}
+	return identity.InstanceID, nil
+}
+
+// AzureAttestedDataAttestor verifies Azure attested data documents, the PKCS7 signature served by the instance
+// metadata service at /metadata/attested/document.
+type AzureAttestedDataAttestor struct {
+	// Roots are the CAs the certificate signing the attested data must chain to.
+	Roots          *x509.CertPool
+	SubscriptionID string
+}
+
+type azureAttestedDataDocument struct {
+	VMID           string `json:"vmId"`
+	SubscriptionID string `json:"subscriptionId"`
+	TimeStamp      struct {
+		CreatedOn string `json:"createdOn"`
+		ExpiresOn string `json:"expiresOn"`
+	} `json:"timeStamp"`
+}
+
+func (a *AzureAttestedDataAttestor) Attest(r *http.Request, now time.Time) (string, error) {
+	signature, err := attestationHeader(r, AttestationSignatureHeader)
+	if err != nil {
+		return "", err
+	}
+	document, signer, intermediates, err := verifyPKCS7(signature)
+	if err != nil {
+		return "", fmt.Errorf("attested data signature can't be verified: %w", err)
+	}
+	if _, err := signer.Verify(x509.VerifyOptions{
+		Roots:         a.Roots,
+		Intermediates: intermediates,
+		CurrentTime:   now,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}); err != nil {
+		return "", fmt.Errorf("attested data signer can't be verified: %w", err)
+	}
+	signedByMetadataService := false
+	for _, name := range signer.DNSNames {
+		if strings.HasSuffix(name, azureAttestedDataSignerDNSSuffix) {
+			signedByMetadataService = true
+			break
+		}
+	}
+	if !signedByMetadataService {
+		return "", fmt.Errorf("attested data is not signed by the instance metadata service")
+	}
+
+	attested := azureAttestedDataDocument{}
+	if err := json.Unmarshal(document, &attested); err != nil {
+		return "", fmt.Errorf("invalid attested data: %w", err)
+	}
+	if !strings.EqualFold(attested.SubscriptionID, a.SubscriptionID) {
+		return "", fmt.Errorf("vm %s belongs to subscription %s, expected %s", attested.VMID, attested.SubscriptionID, a.SubscriptionID)
+	}
+	createdOn, err := time.Parse(azureAttestedDataTimeFormat, attested.TimeStamp.CreatedOn)
+	if err != nil {
+		return "", fmt.Errorf("invalid attested data creation time: %w", err)
+	}
+	expiresOn, err := time.Parse(azureAttestedDataTimeFormat, attested.TimeStamp.ExpiresOn)
+	if err != nil {
+		return "", fmt.Errorf("invalid attested data expiration time: %w", err)
+	}
+	if now.Add(attestationClockSkewTolerance).Before(createdOn) || now.After(expiresOn) {
+		return "", fmt.Errorf("attested data of vm %s is only valid from %s to %s", attested.VMID, createdOn.Format(time.RFC3339), expiresOn.Format(time.RFC3339))
+	}
+	return attested.VMID, nil
+}
+
+type pkcs7ContentInfo struct {
+	ContentType asn1.ObjectIdentifier
+	Content     asn1.RawValue `asn1:"explicit,optional,tag:0"`
+}
+
+type pkcs7SignedData struct {
+	Version          int
+	DigestAlgorithms []pkix.AlgorithmIdentifier `asn1:"set"`
+	ContentInfo      pkcs7ContentInfo
+	Certificates     asn1.RawValue `asn1:"optional,tag:0"`
+	CRLs             asn1.RawValue `asn1:"optional,tag:1"`
+	SignerInfos      []pkcs7Signer `asn1:"set"`
+}
+
+type pkcs7IssuerAndSerial struct {
+	Issuer       asn1.RawValue
+	SerialNumber *big.Int
+}
+
+type pkcs7Signer struct {
+	Version                   int
+	IssuerAndSerialNumber     pkcs7IssuerAndSerial
+	DigestAlgorithm           pkix.AlgorithmIdentifier
+	AuthenticatedAttributes   asn1.RawValue `asn1:"optional,tag:0"`
+	DigestEncryptionAlgorithm pkix.AlgorithmIdentifier
+	EncryptedDigest           []byte
+	UnauthenticatedAttributes asn1.RawValue `asn1:"optional,tag:1"`
+}
+
+type pkcs7Attribute struct {
+	Type   asn1.ObjectIdentifier
+	Values asn1.RawValue `asn1:"set"`
+}
+
+// verifyPKCS7 verifies the RSA signature of a PKCS7 SignedData with embedded content, and returns the content,
+// the signer certificate and the other embedded certificates. The signer certificate chain must be verified
+// separately.
+func verifyPKCS7(data []byte) ([]byte, *x509.Certificate, *x509.CertPool, error) {
+	contentInfo := pkcs7ContentInfo{}
+	if _, err := asn1.Unmarshal(data, &contentInfo); err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid pkcs7: %w", err)
+	}
+	if !contentInfo.ContentType.Equal(oidPKCS7SignedData) {
+		return nil, nil, nil, fmt.Errorf("pkcs7 content type %s is not signed data", contentInfo.ContentType)
+	}
+	signedData := pkcs7SignedData{}
+	if _, err := asn1.Unmarshal(contentInfo.Content.Bytes, &signedData); err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid pkcs7 signed data: %w", err)
+	}
+	var content []byte
+	if _, err := asn1.Unmarshal(signedData.ContentInfo.Content.Bytes, &content); err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid pkcs7 content: %w", err)
+	}
+	certificates, err := x509.ParseCertificates(signedData.Certificates.Bytes)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid pkcs7 certificates: %w", err)
+	}
+	if len(signedData.SignerInfos) != 1 {
+		return nil, nil, nil, fmt.Errorf("pkcs7 has %d signers, expected 1", len(signedData.SignerInfos))
+	}
+	signer := signedData.SignerInfos[0]
+
+	var signerCertificate *x509.Certificate
+	intermediates := x509.NewCertPool()
+	for _, certificate := range certificates {
+		if certificate.SerialNumber.Cmp(signer.IssuerAndSerialNumber.SerialNumber) == 0 && bytes.Equal(certificate.RawIssuer, signer.IssuerAndSerialNumber.Issuer.FullBytes) {
+			signerCertificate = certificate
+		} else {
+			intermediates.AddCert(certificate)
+		}
+	}
+	if signerCertificate == nil {
+		return nil, nil, nil, fmt.Errorf("pkcs7 signer certificate not found")
+	}
+
+	if !signer.DigestAlgorithm.Algorithm.Equal(oidSHA256) {
+		return nil, nil, nil, fmt.Errorf("unsupported pkcs7 digest algorithm %s", signer.DigestAlgorithm.Algorithm)
+	}
+
+	signed := content
+	if len(signer.AuthenticatedAttributes.Bytes) > 0 {
+		// The signature covers the authenticated attributes, which include the digest of the content.
+		// The authenticated attributes are signed as an explicit SET rather than with their implicit tag.
+		signed = append([]byte{0x31}, signer.AuthenticatedAttributes.FullBytes[1:]...)
+		var attributes []pkcs7Attribute
+		if _, err := asn1.UnmarshalWithParams(signed, &attributes, "set"); err != nil {
+			return nil, nil, nil, fmt.Errorf("invalid pkcs7 authenticated attributes: %w", err)
+		}
+		var messageDigest []byte
+		for _, attribute := range attributes {
+			if attribute.Type.Equal(oidMessageDigest) {
+				if _, err := asn1.Unmarshal(attribute.Values.Bytes, &messageDigest); err != nil {
+					return nil, nil, nil, fmt.Errorf("invalid pkcs7 message digest: %w", err)
+				}
+			}
+		}
+		digest := sha256.Sum256(content)
+		if !bytes.Equal(messageDigest, digest[:]) {
+			return nil, nil, nil, fmt.Errorf("pkcs7 message digest doesn't match its content")
+		}
+	}
+	if err := signerCertificate.CheckSignature(x509.SHA256WithRSA, signed, signer.EncryptedDigest); err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid pkcs7 signature: %w", err)
+	}
+	return content, signerCertificate, intermediates, nil
+}
@@ -0,0 +1,365 @@
+package controllers
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/hypershift/support/certs"
+)
+
+var (
+	oidPKCS7Data         = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 1}
+	oidPKCS7ContentType  = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 3}
+	oidPKCS1RSAEncrytion = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 1}
+)
+
+func generateCA(t *testing.T, name string) (*rsa.PrivateKey, *x509.Certificate) {
+	key, cert, err := certs.GenerateSelfSignedCertificate(&certs.CertCfg{
+		Subject:   pkix.Name{CommonName: name, OrganizationalUnit: []string{"test"}},
+		KeyUsages: x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		Validity:  certs.ValidityOneDay,
+		IsCA:      true,
+	})
+	if err != nil {
+		t.Fatalf("failed to generate CA: %v", err)
+	}
+	return key, cert
+}
+
+func attestationRequest(headers map[string][]byte) *http.Request {
+	r := &http.Request{Header: http.Header{}}
+	for header, value := range headers {
+		r.Header.Set(header, base64.StdEncoding.EncodeToString(value))
+	}
+	return r
+}
+
+func TestAWSInstanceIdentityAttestor(t *testing.T) {
+	now := time.Now()
+	awsKey, awsCert := generateCA(t, "aws")
+	otherKey, _ := generateCA(t, "other")
+
+	document := func(accountID, region string, pendingTime time.Time) []byte {
+		data, _ := json.Marshal(awsInstanceIdentityDocument{
+			AccountID:   accountID,
+			Region:      region,
+			InstanceID:  "i-0123456789abcdef0",
+			PendingTime: pendingTime,
+		})
+		return data
+	}
+	sign := func(key *rsa.PrivateKey, document []byte) []byte {
+		digest := sha256.Sum256(document)
+		signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+		if err != nil {
+			t.Fatalf("failed to sign document: %v", err)
+		}
+		return signature
+	}
+
+	testCases := []struct {
+		name          string
+		document      []byte
+		signer        *rsa.PrivateKey
+		expectedError string
+	}{
+		{
+			name:     "When the document is signed by AWS and matches the account and region it should succeed",
+			document: document("123456789012", "us-east-1", now.Add(-time.Minute)),
+			signer:   awsKey,
+		},
+		{
+			name:          "When the document is not signed by AWS it should fail",
+			document:      document("123456789012", "us-east-1", now.Add(-time.Minute)),
+			signer:        otherKey,
+			expectedError: "signature can't be verified",
+		},
+		{
+			name:          "When the instance belongs to another account it should fail",
+			document:      document("210987654321", "us-east-1", now.Add(-time.Minute)),
+			signer:        awsKey,
+			expectedError: "belongs to account 210987654321",
+		},
+		{
+			name:          "When the instance is in another region it should fail",
+			document:      document("123456789012", "eu-west-1", now.Add(-time.Minute)),
+			signer:        awsKey,
+			expectedError: "is in region eu-west-1",
+		},
+		{
+			name:          "When the instance was launched too long ago it should fail",
+			document:      document("123456789012", "us-east-1", now.Add(-2*time.Hour)),
+			signer:        awsKey,
+			expectedError: "more than 1h0m0s ago",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			attestor := &AWSInstanceIdentityAttestor{
+				Certificates: []*x509.Certificate{awsCert},
+				AccountID:    "123456789012",
+				Region:       "us-east-1",
+				MaxAge:       time.Hour,
+			}
+			instanceID, err := attestor.Attest(attestationRequest(map[string][]byte{
+				AttestationDocumentHeader:  tc.document,
+				AttestationSignatureHeader: sign(tc.signer, tc.document),
+			}), now)
+			if tc.expectedError != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tc.expectedError)))
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(instanceID).To(Equal("i-0123456789abcdef0"))
+		})
+	}
+
+	t.Run("When the signature header is missing it should fail", func(t *testing.T) {
+		g := NewWithT(t)
+		attestor := &AWSInstanceIdentityAttestor{Certificates: []*x509.Certificate{awsCert}, AccountID: "123456789012"}
+		_, err := attestor.Attest(attestationRequest(map[string][]byte{
+			AttestationDocumentHeader: document("123456789012", "us-east-1", now),
+		}), now)
+		g.Expect(err).To(MatchError(ContainSubstring("missing " + AttestationSignatureHeader)))
+	})
+}
+
+// signPKCS7 returns a PKCS7 SignedData of content with authenticated attributes, as served by the Azure
+// instance metadata service.
+func signPKCS7(t *testing.T, key *rsa.PrivateKey, signer *x509.Certificate, content []byte, chain ...*x509.Certificate) []byte {
+	marshal := func(value interface{}, params string) []byte {
+		data, err := asn1.MarshalWithParams(value, params)
+		if err != nil {
+			t.Fatalf("failed to marshal: %v", err)
+		}
+		return data
+	}
+	set := func(contents ...[]byte) asn1.RawValue {
+		value := asn1.RawValue{Class: asn1.ClassUniversal, Tag: asn1.TagSet, IsCompound: true}
+		for _, c := range contents {
+			value.Bytes = append(value.Bytes, c...)
+		}
+		return value
+	}
+	tagged := func(contents ...[]byte) asn1.RawValue {
+		value := asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true}
+		for _, c := range contents {
+			value.Bytes = append(value.Bytes, c...)
+		}
+		return value
+	}
+
+	digest := sha256.Sum256(content)
+	attributes := marshal([]pkcs7Attribute{
+		{Type: oidPKCS7ContentType, Values: set(marshal(oidPKCS7Data, ""))},
+		{Type: oidMessageDigest, Values: set(marshal(digest[:], ""))},
+	}, "set")
+	attributesDigest := sha256.Sum256(attributes)
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, attributesDigest[:])
+	if err != nil {
+		t.Fatalf("failed to sign: %v", err)
+	}
+
+	var certificates [][]byte
+	for _, certificate := range append([]*x509.Certificate{signer}, chain...) {
+		certificates = append(certificates, certificate.Raw)
+	}
+	signedData := marshal(pkcs7SignedData{
+		Version:          1,
+		DigestAlgorithms: []pkix.AlgorithmIdentifier{{Algorithm: oidSHA256}},
+		ContentInfo: pkcs7ContentInfo{
+			ContentType: oidPKCS7Data,
+			Content:     tagged(marshal(content, "")),
+		},
+		Certificates: tagged(certificates...),
+		SignerInfos: []pkcs7Signer{{
+			Version: 1,
+			IssuerAndSerialNumber: pkcs7IssuerAndSerial{
+				Issuer:       asn1.RawValue{FullBytes: signer.RawIssuer},
+				SerialNumber: signer.SerialNumber,
+			},
+			DigestAlgorithm:           pkix.AlgorithmIdentifier{Algorithm: oidSHA256},
+			AuthenticatedAttributes:   asn1.RawValue{FullBytes: append([]byte{0xa0}, attributes[1:]...)},
+			DigestEncryptionAlgorithm: pkix.AlgorithmIdentifier{Algorithm: oidPKCS1RSAEncrytion},
+			EncryptedDigest:           signature,
+		}},
+	}, "")
+	return marshal(pkcs7ContentInfo{
+		ContentType: oidPKCS7SignedData,
+		Content:     tagged(signedData),
+	}, "")
+}
+
+func TestAzureAttestedDataAttestor(t *testing.T) {
+	rootKey, rootCert := generateCA(t, "azure-root")
+	intermediateKey, intermediateCert, err := certs.GenerateSignedCertificate(rootKey, rootCert, &certs.CertCfg{
+		Subject:   pkix.Name{CommonName: "azure-intermediate", OrganizationalUnit: []string{"test"}},
+		KeyUsages: x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		Validity:  certs.ValidityOneDay,
+		IsCA:      true,
+	})
+	g := NewWithT(t)
+	g.Expect(err).ToNot(HaveOccurred())
+	signerCert := func(dnsName string) (*rsa.PrivateKey, *x509.Certificate) {
+		key, cert, err := certs.GenerateSignedCertificate(intermediateKey, intermediateCert, &certs.CertCfg{
+			Subject:      pkix.Name{CommonName: dnsName},
+			KeyUsages:    x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+			ExtKeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+			Validity:     certs.ValidityOneDay,
+			DNSNames:     []string{dnsName},
+		})
+		if err != nil {
+			t.Fatalf("failed to generate signer certificate: %v", err)
+		}
+		return key, cert
+	}
+	metadataKey, metadataCert := signerCert("eastus.metadata.azure.com")
+	otherKey, otherCert := signerCert("example.com")
+	_, untrustedRoot := generateCA(t, "untrusted")
+	// The certificates are valid from the second they were generated in, which is at or before now.
+	now := time.Now()
+
+	document := func(subscriptionID string, expiresOn time.Time) []byte {
+		attested := azureAttestedDataDocument{VMID: "02aab8a4-74ef-476e-8182-f6d2ba4166a6", SubscriptionID: subscriptionID}
+		attested.TimeStamp.CreatedOn = now.Add(-time.Minute).Format(azureAttestedDataTimeFormat)
+		attested.TimeStamp.ExpiresOn = expiresOn.Format(azureAttestedDataTimeFormat)
+		data, _ := json.Marshal(attested)
+		return data
+	}
+
+	testCases := []struct {
+		name          string
+		signature     []byte
+		roots         *x509.Certificate
+		expectedError string
+	}{
+		{
+			name:      "When the attested data is signed by the metadata service and matches the subscription it should succeed",
+			signature: signPKCS7(t, metadataKey, metadataCert, document("subscription", now.Add(time.Hour)), intermediateCert),
+			roots:     rootCert,
+		},
+		{
+			name:          "When the signer doesn't chain to the roots it should fail",
+			signature:     signPKCS7(t, metadataKey, metadataCert, document("subscription", now.Add(time.Hour)), intermediateCert),
+			roots:         untrustedRoot,
+			expectedError: "signer can't be verified",
+		},
+		{
+			name:          "When the signer is not the metadata service it should fail",
+			signature:     signPKCS7(t, otherKey, otherCert, document("subscription", now.Add(time.Hour)), intermediateCert),
+			roots:         rootCert,
+			expectedError: "not signed by the instance metadata service",
+		},
+		{
+			name:          "When the signature doesn't match the signer it should fail",
+			signature:     signPKCS7(t, otherKey, metadataCert, document("subscription", now.Add(time.Hour)), intermediateCert),
+			roots:         rootCert,
+			expectedError: "invalid pkcs7 signature",
+		},
+		{
+			name:          "When the vm belongs to another subscription it should fail",
+			signature:     signPKCS7(t, metadataKey, metadataCert, document("other", now.Add(time.Hour)), intermediateCert),
+			roots:         rootCert,
+			expectedError: "belongs to subscription other",
+		},
+		{
+			name:          "When the attested data has expired it should fail",
+			signature:     signPKCS7(t, metadataKey, metadataCert, document("subscription", now.Add(-time.Second)), intermediateCert),
+			roots:         rootCert,
+			expectedError: "is only valid from",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			roots := x509.NewCertPool()
+			roots.AddCert(tc.roots)
+			attestor := &AzureAttestedDataAttestor{Roots: roots, SubscriptionID: "subscription"}
+			vmID, err := attestor.Attest(attestationRequest(map[string][]byte{
+				AttestationSignatureHeader: tc.signature,
+			}), now)
+			if tc.expectedError != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tc.expectedError)))
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(vmID).To(Equal("02aab8a4-74ef-476e-8182-f6d2ba4166a6"))
+		})
+	}
+}
+
+func TestNewAttestorFromDir(t *testing.T) {
+	_, caCert := generateCA(t, "aws")
+
+	testCases := []struct {
+		name          string
+		config        map[string]string
+		expected      Attestor
+		expectedError string
+	}{
+		{
+			name: "When the type is AWSInstanceIdentity it should return an AWS attestor",
+			config: map[string]string{
+				AttestationTypeKey:         AttestationTypeAWSInstanceIdentity,
+				AttestationCertificatesKey: string(certs.CertToPem(caCert)),
+				AttestationAccountKey:      "123456789012",
+				AttestationRegionKey:       "us-east-1",
+				AttestationMaxAgeKey:       "30m",
+			},
+			expected: &AWSInstanceIdentityAttestor{
+				Certificates: []*x509.Certificate{caCert},
+				AccountID:    "123456789012",
+				Region:       "us-east-1",
+				MaxAge:       30 * time.Minute,
+			},
+		},
+		{
+			name: "When certificates are missing it should fail",
+			config: map[string]string{
+				AttestationTypeKey:    AttestationTypeAWSInstanceIdentity,
+				AttestationAccountKey: "123456789012",
+			},
+			expectedError: "requires certificates",
+		},
+		{
+			name: "When the type is unsupported it should fail",
+			config: map[string]string{
+				AttestationTypeKey:         "TPM",
+				AttestationCertificatesKey: string(certs.CertToPem(caCert)),
+				AttestationAccountKey:      "123456789012",
+			},
+			expectedError: "unsupported attestation type",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			dir := t.TempDir()
+			for key, value := range tc.config {
+				g.Expect(os.WriteFile(filepath.Join(dir, key), []byte(value), 0644)).To(Succeed())
+			}
+			attestor, err := NewAttestorFromDir(dir)
+			if tc.expectedError != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tc.expectedError)))
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(attestor).To(Equal(tc.expected))
+		})
+	}
+}
@@ -1,6 +1,12 @@
 package controllers
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
 	"sync"
 	"time"
 
@@ -24,15 +30,24 @@ func init() {
 // Any pair in the cache is expired once entry.expiry time is above the cache ttl.
 // The expiry time is renewed for an existing value on every Get operation.
 // Garbage collection of expired values happens on every Get operation.
+// When dir is set, the values are also written to disk in a file named after the hash of the token,
+// so that they survive restarts of the ignition server without generating the payloads again.
 type ExpiringCache struct {
 	cache map[string]*entry
 	ttl   time.Duration
+	dir   string
 	sync.RWMutex
 }
 
 type CacheValue struct {
 	Payload    []byte
 	SecretName string
+	// PullSecretHash is the hash of the pull secret the payload was generated with.
+	PullSecretHash string
+	// ConfigHash is the hash of the config the payload was generated with.
+	ConfigHash string
+	// PublishedKey is the object storage key the payload was published to, if any.
+	PublishedKey string
 }
 
 type entry struct {
@@ -44,11 +59,10 @@ func (c *ExpiringCache) Get(key string) (value CacheValue, ok bool) {
 	c.garbageCollect()
 
 	c.RLock()
-	defer c.RUnlock()
-
 	result, ok := c.cache[key]
+	c.RUnlock()
 	if !ok {
-		return CacheValue{}, false
+		return c.load(key)
 	}
 
 	return result.value, ok
@@ -64,6 +78,7 @@ func (c *ExpiringCache) Set(key string, value CacheValue) {
 		expiry: time.Now().Add(c.ttl),
 	}
 	PayloadCacheSizeTotal.Inc()
+	c.persist(key, value)
 }
 
 func (c *ExpiringCache) Delete(key string) {
@@ -71,6 +86,9 @@ func (c *ExpiringCache) Delete(key string) {
 	defer c.Unlock()
 	delete(c.cache, key)
 	PayloadCacheSizeTotal.Dec()
+	if c.dir != "" {
+		_ = os.Remove(c.path(key))
+	}
 }
 
 func (c *ExpiringCache) Keys() []string {
@@ -91,3 +109,92 @@ func (c *ExpiringCache) garbageCollect() {
 		}
 	}
 }
+
+// NewPersistentPayloadStore returns a payload store which also keeps the payloads in dir.
+// Payloads left in dir which are already expired are removed.
+func NewPersistentPayloadStore(dir string) (*ExpiringCache, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create payload cache directory: %w", err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read payload cache directory: %w", err)
+	}
+	for _, e := range entries {
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		if time.Since(info.ModTime()) > ttl {
+			_ = os.Remove(filepath.Join(dir, e.Name()))
+		}
+	}
+	store := NewPayloadStore()
+	store.dir = dir
+	return store, nil
+}
+
+// path returns the file holding the value of a token. The token itself is never written to disk.
+func (c *ExpiringCache) path(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(hash[:]))
+}
+
+// persist writes the value to disk, failures only cost a payload generation after a restart.
+func (c *ExpiringCache) persist(key string, value CacheValue) {
+	if c.dir == "" {
+		return
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return
+	}
+	path := c.path(key)
+	tmp, err := os.CreateTemp(c.dir, ".tmp-*")
+	if err != nil {
+		return
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return
+	}
+	if err := tmp.Close(); err != nil {
+		return
+	}
+	_ = os.Rename(tmp.Name(), path)
+}
+
+// load reads a value which is not in memory from disk, e.g. after a restart.
+func (c *ExpiringCache) load(key string) (CacheValue, bool) {
+	if c.dir == "" {
+		return CacheValue{}, false
+	}
+	path := c.path(key)
+	info, err := os.Stat(path)
+	if err != nil {
+		return CacheValue{}, false
+	}
+	expiry := info.ModTime().Add(c.ttl)
+	if time.Now().After(expiry) {
+		_ = os.Remove(path)
+		return CacheValue{}, false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return CacheValue{}, false
+	}
+	var value CacheValue
+	if err := json.Unmarshal(data, &value); err != nil {
+		return CacheValue{}, false
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	c.cache[key] = &entry{
+		value:  value,
+		expiry: expiry,
+	}
+	PayloadCacheSizeTotal.Inc()
+	return value, true
+}
@@ -0,0 +1,35 @@
+package controllers
+
+import (
+	"os"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestPersistentPayloadStore(t *testing.T) {
+	g := NewWithT(t)
+	dir := t.TempDir()
+
+	store, err := NewPersistentPayloadStore(dir)
+	g.Expect(err).ToNot(HaveOccurred())
+	value := CacheValue{Payload: []byte("payload"), SecretName: "token", PublishedKey: "clusters-example/payload.ign"}
+	store.Set("token", value)
+
+	files, err := os.ReadDir(dir)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(files).To(HaveLen(1))
+	g.Expect(files[0].Name()).ToNot(ContainSubstring("token"), "tokens should not be written to disk")
+
+	// A new store, e.g. after a restart, should load the values from disk.
+	restarted, err := NewPersistentPayloadStore(dir)
+	g.Expect(err).ToNot(HaveOccurred())
+	loaded, ok := restarted.Get("token")
+	g.Expect(ok).To(BeTrue())
+	g.Expect(loaded).To(Equal(value))
+
+	restarted.Delete("token")
+	files, err = os.ReadDir(dir)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(files).To(BeEmpty())
+}
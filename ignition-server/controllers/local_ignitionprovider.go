@@ -482,6 +482,12 @@ func (p *LocalIgnitionProvider) GetPayload(ctx context.Context, releaseImage str
 		if err := copyFile(filepath.Join(configDir, "image-config.yaml"), filepath.Join(mccBaseDir, "image-config.yaml")); err != nil {
 			return fmt.Errorf("failed to copy image-config.yaml: %w", err)
 		}
+		// copy the ConfigMap referenced by the image config additionalTrustedCA, so registry CAs are trusted by the nodes
+		if mcsConfig.Data["image-registry-ca-config.yaml"] != "" {
+			if err := copyFile(filepath.Join(configDir, "image-registry-ca-config.yaml"), filepath.Join(mccBaseDir, "image-registry-ca-config.yaml")); err != nil {
+				return fmt.Errorf("failed to copy image-registry-ca-config.yaml: %w", err)
+			}
+		}
 
 		args := []string{
 			"bootstrap",
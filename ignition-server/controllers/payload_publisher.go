@@ -0,0 +1,222 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	ignitionapi "github.com/coreos/ignition/v2/config/v3_2/types"
+	awsutil "github.com/openshift/hypershift/cmd/infra/aws/util"
+	"github.com/openshift/hypershift/support/azureutil"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// Keys of the payload storage configuration, mounted from a Secret into the ignition server.
+const (
+	PayloadStorageTypeKey        = "type"
+	PayloadStorageBucketKey      = "bucket"
+	PayloadStorageRegionKey      = "region"
+	PayloadStorageCredentialsKey = "credentials"
+	PayloadStorageAccountKey     = "account"
+	PayloadStorageContainerKey   = "container"
+	PayloadStorageAccountKeyKey  = "accountKey"
+	PayloadStorageTypeS3         = "S3"
+	PayloadStorageTypeAzureBlob  = "AzureBlob"
+	azureUploadValidity          = 15 * time.Minute
+	azureClockSkewTolerance      = 5 * time.Minute
+	payloadStubIgnitionVersion   = "3.2.0"
+)
+
+var (
+	PayloadPublishFailuresTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "ign_server_payload_publish_failures_total",
+	})
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		PayloadPublishFailuresTotal,
+	)
+}
+
+// PayloadPublisher publishes ignition payloads to object storage, so that Machines download them from there
+// through short-lived signed URLs instead of from the ignition server.
+type PayloadPublisher interface {
+	// Publish uploads the payload under the given key.
+	Publish(ctx context.Context, key string, payload []byte) error
+	// SignedURL returns a URL to download the object with the given key, valid for the given duration.
+	SignedURL(key string, validity time.Duration) (string, error)
+}
+
+// PayloadKey returns the object storage key of a payload. Keys are derived from the payload content,
+// so publishing the same payload from every ignition server replica is idempotent.
+func PayloadKey(namespace string, payload []byte) string {
+	hash := sha256.Sum256(payload)
+	return fmt.Sprintf("%s/%s.ign", namespace, hex.EncodeToString(hash[:]))
+}
+
+// PayloadStub returns the ignition config served in place of a published payload. It makes Ignition
+// replace it with the payload downloaded from the signed URL, after verifying its hash.
+func PayloadStub(publisher PayloadPublisher, key string, payload []byte, validity time.Duration) ([]byte, error) {
+	signedURL, err := publisher.SignedURL(key, validity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign payload URL: %w", err)
+	}
+	hash := sha512.Sum512(payload)
+	stub := ignitionapi.Config{
+		Ignition: ignitionapi.Ignition{
+			Version: payloadStubIgnitionVersion,
+			Config: ignitionapi.IgnitionConfig{
+				Replace: ignitionapi.Resource{
+					Source: aws.String(signedURL),
+					Verification: ignitionapi.Verification{
+						Hash: aws.String("sha512-" + hex.EncodeToString(hash[:])),
+					},
+				},
+			},
+		},
+	}
+	return json.Marshal(stub)
+}
+
+// NewPayloadPublisherFromDir returns the PayloadPublisher configured by the files in dir, named after the
+// keys of the payload storage configuration.
+func NewPayloadPublisherFromDir(dir string) (PayloadPublisher, error) {
+	read := func(key string) string {
+		data, err := os.ReadFile(filepath.Join(dir, key))
+		if err != nil {
+			return ""
+		}
+		return strings.TrimSpace(string(data))
+	}
+
+	switch storageType := read(PayloadStorageTypeKey); storageType {
+	case PayloadStorageTypeS3:
+		bucket := read(PayloadStorageBucketKey)
+		if bucket == "" {
+			return nil, fmt.Errorf("%s payload storage requires a %s", storageType, PayloadStorageBucketKey)
+		}
+		credentialsFile := ""
+		if read(PayloadStorageCredentialsKey) != "" {
+			credentialsFile = filepath.Join(dir, PayloadStorageCredentialsKey)
+		}
+		awsSession := awsutil.NewSession("ignition-server", credentialsFile, "", "", read(PayloadStorageRegionKey))
+		return &S3PayloadPublisher{
+			Client: s3.New(awsSession, awsutil.NewConfig()),
+			Bucket: bucket,
+		}, nil
+	case PayloadStorageTypeAzureBlob:
+		account, container := read(PayloadStorageAccountKey), read(PayloadStorageContainerKey)
+		if account == "" || container == "" {
+			return nil, fmt.Errorf("%s payload storage requires an %s and a %s", storageType, PayloadStorageAccountKey, PayloadStorageContainerKey)
+		}
+		accountKey, err := base64.StdEncoding.DecodeString(read(PayloadStorageAccountKeyKey))
+		if err != nil || len(accountKey) == 0 {
+			return nil, fmt.Errorf("%s payload storage requires a base64 encoded %s", storageType, PayloadStorageAccountKeyKey)
+		}
+		return &AzureBlobPayloadPublisher{
+			Account:    account,
+			Container:  container,
+			AccountKey: accountKey,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported payload storage type %q, must be %s or %s", storageType, PayloadStorageTypeS3, PayloadStorageTypeAzureBlob)
+	}
+}
+
+// S3PayloadPublisher publishes payloads to an S3 bucket and signs URLs with the credentials of the client.
+type S3PayloadPublisher struct {
+	Client s3iface.S3API
+	Bucket string
+}
+
+func (p *S3PayloadPublisher) Publish(ctx context.Context, key string, payload []byte) error {
+	_, err := p.Client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket:               aws.String(p.Bucket),
+		Key:                  aws.String(key),
+		Body:                 bytes.NewReader(payload),
+		ContentType:          aws.String("application/json"),
+		ServerSideEncryption: aws.String(s3.ServerSideEncryptionAes256),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upload payload to bucket %s: %w", p.Bucket, err)
+	}
+	return nil
+}
+
+func (p *S3PayloadPublisher) SignedURL(key string, validity time.Duration) (string, error) {
+	req, _ := p.Client.GetObjectRequest(&s3.GetObjectInput{
+		Bucket: aws.String(p.Bucket),
+		Key:    aws.String(key),
+	})
+	return req.Presign(validity)
+}
+
+// AzureBlobPayloadPublisher publishes payloads to an Azure Blob Storage container and signs URLs with
+// service shared access signatures derived from the storage account key.
+type AzureBlobPayloadPublisher struct {
+	Account    string
+	Container  string
+	AccountKey []byte
+	// Endpoint overrides the blob service endpoint of the storage account.
+	Endpoint   string
+	HTTPClient *http.Client
+	now        func() time.Time
+}
+
+func (p *AzureBlobPayloadPublisher) Publish(ctx context.Context, key string, payload []byte) error {
+	signedURL, err := p.signedURL(key, "cw", azureUploadValidity)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, signedURL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("x-ms-blob-type", "BlockBlob")
+	req.Header.Set("x-ms-version", azureutil.BlobSASVersion)
+	req.Header.Set("Content-Type", "application/json")
+
+	httpClient := p.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to upload payload to container %s: %w", p.Container, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("failed to upload payload to container %s: %s: %s", p.Container, resp.Status, body)
+	}
+	return nil
+}
+
+func (p *AzureBlobPayloadPublisher) SignedURL(key string, validity time.Duration) (string, error) {
+	return p.signedURL(key, "r", validity)
+}
+
+// signedURL returns the URL of the blob with a service SAS granting the given permissions.
+func (p *AzureBlobPayloadPublisher) signedURL(key, permissions string, validity time.Duration) (string, error) {
+	now := time.Now
+	if p.now != nil {
+		now = p.now
+	}
+	return azureutil.BlobServiceSASURL(p.Endpoint, p.Account, p.Container, key, permissions, p.AccountKey, now().Add(-azureClockSkewTolerance), now().Add(validity))
+}
@@ -0,0 +1,166 @@
+package controllers
+
+import (
+	"context"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	ignitionapi "github.com/coreos/ignition/v2/config/v3_2/types"
+	. "github.com/onsi/gomega"
+)
+
+type fakePayloadPublisher struct {
+	published  map[string][]byte
+	publishErr error
+}
+
+func (p *fakePayloadPublisher) Publish(ctx context.Context, key string, payload []byte) error {
+	if p.publishErr != nil {
+		return p.publishErr
+	}
+	if p.published == nil {
+		p.published = map[string][]byte{}
+	}
+	p.published[key] = payload
+	return nil
+}
+
+func (p *fakePayloadPublisher) SignedURL(key string, validity time.Duration) (string, error) {
+	return fmt.Sprintf("https://storage.example.com/%s?validity=%s", key, validity), nil
+}
+
+func TestPayloadStub(t *testing.T) {
+	g := NewWithT(t)
+
+	payload := []byte(`{"ignition":{"version":"3.2.0"}}`)
+	key := PayloadKey("clusters-example", payload)
+	g.Expect(key).To(HavePrefix("clusters-example/"))
+	g.Expect(key).To(HaveSuffix(".ign"))
+	g.Expect(PayloadKey("clusters-example", payload)).To(Equal(key), "keys should be stable across replicas")
+
+	stub, err := PayloadStub(&fakePayloadPublisher{}, key, payload, 10*time.Minute)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	config := ignitionapi.Config{}
+	g.Expect(json.Unmarshal(stub, &config)).To(Succeed())
+	hash := sha512.Sum512(payload)
+	g.Expect(config.Ignition.Version).To(Equal("3.2.0"))
+	g.Expect(*config.Ignition.Config.Replace.Source).To(Equal("https://storage.example.com/" + key + "?validity=10m0s"))
+	g.Expect(*config.Ignition.Config.Replace.Verification.Hash).To(Equal("sha512-" + hex.EncodeToString(hash[:])))
+}
+
+func TestAzureBlobPayloadPublisher(t *testing.T) {
+	g := NewWithT(t)
+
+	var uploaded []byte
+	var uploadQuery url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		g.Expect(r.Method).To(Equal(http.MethodPut))
+		g.Expect(r.URL.Path).To(Equal("/payloads/clusters-example/payload.ign"))
+		g.Expect(r.Header.Get("x-ms-blob-type")).To(Equal("BlockBlob"))
+		uploadQuery = r.URL.Query()
+		uploaded, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	publisher := &AzureBlobPayloadPublisher{
+		Account:    "account",
+		Container:  "payloads",
+		AccountKey: []byte("key"),
+		Endpoint:   server.URL,
+		HTTPClient: server.Client(),
+		now:        func() time.Time { return now },
+	}
+
+	g.Expect(publisher.Publish(context.Background(), "clusters-example/payload.ign", []byte("payload"))).To(Succeed())
+	g.Expect(uploaded).To(BeEquivalentTo("payload"))
+	g.Expect(uploadQuery.Get("sp")).To(Equal("cw"))
+
+	signedURL, err := publisher.SignedURL("clusters-example/payload.ign", 10*time.Minute)
+	g.Expect(err).ToNot(HaveOccurred())
+	parsed, err := url.Parse(signedURL)
+	g.Expect(err).ToNot(HaveOccurred())
+	query := parsed.Query()
+	g.Expect(parsed.Path).To(Equal("/payloads/clusters-example/payload.ign"))
+	g.Expect(query.Get("sp")).To(Equal("r"))
+	g.Expect(query.Get("sr")).To(Equal("b"))
+	g.Expect(query.Get("spr")).To(Equal("https"))
+	g.Expect(query.Get("st")).To(Equal("2024-01-01T11:55:00Z"))
+	g.Expect(query.Get("se")).To(Equal("2024-01-01T12:10:00Z"))
+	g.Expect(query.Get("sig")).ToNot(BeEmpty())
+	g.Expect(query.Get("sig")).ToNot(Equal(uploadQuery.Get("sig")), "signatures should depend on the permissions")
+}
+
+func TestNewPayloadPublisherFromDir(t *testing.T) {
+	testCases := []struct {
+		name          string
+		files         map[string]string
+		expectedType  PayloadPublisher
+		expectedError bool
+	}{
+		{
+			name: "When the storage is S3 it should return an S3 publisher",
+			files: map[string]string{
+				PayloadStorageTypeKey:   PayloadStorageTypeS3,
+				PayloadStorageBucketKey: "payloads",
+				PayloadStorageRegionKey: "us-east-1",
+			},
+			expectedType: &S3PayloadPublisher{},
+		},
+		{
+			name: "When the storage is Azure Blob it should return an Azure Blob publisher",
+			files: map[string]string{
+				PayloadStorageTypeKey:       PayloadStorageTypeAzureBlob,
+				PayloadStorageAccountKey:    "account",
+				PayloadStorageContainerKey:  "payloads",
+				PayloadStorageAccountKeyKey: "a2V5",
+			},
+			expectedType: &AzureBlobPayloadPublisher{},
+		},
+		{
+			name: "When the Azure Blob account key is not base64 encoded it should fail",
+			files: map[string]string{
+				PayloadStorageTypeKey:       PayloadStorageTypeAzureBlob,
+				PayloadStorageAccountKey:    "account",
+				PayloadStorageContainerKey:  "payloads",
+				PayloadStorageAccountKeyKey: "not base64",
+			},
+			expectedError: true,
+		},
+		{
+			name: "When the storage type is unknown it should fail",
+			files: map[string]string{
+				PayloadStorageTypeKey: "GCS",
+			},
+			expectedError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			dir := t.TempDir()
+			for name, content := range tc.files {
+				g.Expect(os.WriteFile(filepath.Join(dir, name), []byte(content), 0600)).To(Succeed())
+			}
+			publisher, err := NewPayloadPublisherFromDir(dir)
+			if tc.expectedError {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(publisher).To(BeAssignableToTypeOf(tc.expectedType))
+		})
+	}
+}
@@ -100,6 +100,8 @@ type TokenSecretReconciler struct {
 	client.Client
 	IgnitionProvider IgnitionProvider
 	PayloadStore     *ExpiringCache
+	// PayloadPublisher, if set, publishes the generated payloads to object storage.
+	PayloadPublisher PayloadPublisher
 }
 
 func tokenSecretAnnotationPredicate(ctx context.Context) predicate.Predicate {
@@ -221,8 +223,12 @@ func (r *TokenSecretReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, err
 	}
 
+	// A payload generated with a different pull secret or config is stale, the pull secret was rotated
+	// or the config was re-rendered, e.g. with a new SSH key.
+	pullSecretHash := string(tokenSecret.Data[TokenSecretPullSecretHashKey])
+	configHash := util.HashSimple(tokenSecret.Data[TokenSecretConfigKey])
 	token := string(tokenSecret.Data[TokenSecretTokenKey])
-	if value, ok := r.PayloadStore.Get(token); ok {
+	if value, ok := r.PayloadStore.Get(token); ok && value.PullSecretHash == pullSecretHash && value.ConfigHash == configHash {
 		log.Info("Payload found in cache")
 
 		if tokenNeedRotation(timeLived) {
@@ -238,7 +244,7 @@ func (r *TokenSecretReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	// If something else rotated the token (e.g. running in HA), we fall back to set the cache value from the old one.
 	oldToken, ok := tokenSecret.Data[TokenSecretOldTokenKey]
 	if ok {
-		if value, ok := r.PayloadStore.Get(string(oldToken)); ok {
+		if value, ok := r.PayloadStore.Get(string(oldToken)); ok && value.PullSecretHash == pullSecretHash && value.ConfigHash == configHash {
 			r.PayloadStore.Set(token, value)
 			return ctrl.Result{RequeueAfter: ttl/2 - durationDeref(timeLived)}, nil
 		}
@@ -258,7 +264,6 @@ func (r *TokenSecretReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	}
 
 	PayloadCacheMissTotal.Inc()
-	pullSecretHash := string(tokenSecret.Data[TokenSecretPullSecretHashKey])
 	hcConfigurationHash := string(tokenSecret.Data[TokenSecretHCConfigurationHashKey])
 	payload, err := func() ([]byte, error) {
 		start := time.Now()
@@ -282,12 +287,24 @@ func (r *TokenSecretReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	}
 
 	log.Info("IgnitionProvider generated payload")
-	r.PayloadStore.Set(token, CacheValue{Payload: payload, SecretName: tokenSecret.Name})
+	value := CacheValue{Payload: payload, SecretName: tokenSecret.Name, PullSecretHash: pullSecretHash, ConfigHash: configHash}
+	if r.PayloadPublisher != nil {
+		// Publishing is best effort, a payload which isn't published is served by the ignition server itself.
+		key := PayloadKey(tokenSecret.Namespace, payload)
+		if err := r.PayloadPublisher.Publish(ctx, key, payload); err != nil {
+			log.Error(err, "failed to publish payload, it will be served by the ignition server")
+			PayloadPublishFailuresTotal.Inc()
+		} else {
+			log.Info("Published payload", "key", key)
+			value.PublishedKey = key
+		}
+	}
+	r.PayloadStore.Set(token, value)
 	oldToken, ok = tokenSecret.Data[TokenSecretOldTokenKey]
 	if ok {
 		// If we got here and there's an old token e.g. ignition server pod was restarted, then we set it as well
 		// So Machines that were given that token right before the restart can succeed.
-		r.PayloadStore.Set(string(oldToken), CacheValue{Payload: payload, SecretName: tokenSecret.Name})
+		r.PayloadStore.Set(string(oldToken), value)
 	}
 
 	patch := tokenSecret.DeepCopy()
@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -173,6 +174,77 @@ func TestReconcile(t *testing.T) {
 				g.Expect(value.Payload).To(BeEquivalentTo(""))
 			},
 		},
+		{
+			name: "When the pull secret hash changed the cached payload should be regenerated",
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test",
+					Namespace: "test",
+					Annotations: map[string]string{
+						TokenSecretAnnotation:          "true",
+						TokenSecretTokenGenerationTime: metav1.Now().Format(time.RFC3339Nano),
+					},
+				},
+				Data: map[string][]byte{
+					TokenSecretTokenKey:          []byte("token"),
+					TokenSecretReleaseKey:        []byte("release"),
+					TokenSecretConfigKey:         compressedConfigBytes,
+					TokenSecretPullSecretHashKey: []byte("rotated"),
+				},
+			},
+			validation: func(t *testing.T, secret client.Object) {
+				ctx := context.Background()
+				r := TokenSecretReconciler{
+					Client:           fake.NewClientBuilder().WithObjects(secret).Build(),
+					IgnitionProvider: &fakeIgnitionProvider{},
+					PayloadStore:     NewPayloadStore(),
+				}
+				r.PayloadStore.Set("token", CacheValue{Payload: []byte("stale"), SecretName: secret.GetName(), PullSecretHash: "original"})
+				g := NewWithT(t)
+				_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(secret)})
+				g.Expect(err).ToNot(HaveOccurred())
+
+				value, found := r.PayloadStore.Get("token")
+				g.Expect(found).To(BeTrue())
+				g.Expect(value.Payload).To(BeEquivalentTo(fakePayload))
+				g.Expect(value.PullSecretHash).To(Equal("rotated"))
+			},
+		},
+		{
+			name: "When the config was re-rendered the cached payload should be regenerated",
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test",
+					Namespace: "test",
+					Annotations: map[string]string{
+						TokenSecretAnnotation:          "true",
+						TokenSecretTokenGenerationTime: metav1.Now().Format(time.RFC3339Nano),
+					},
+				},
+				Data: map[string][]byte{
+					TokenSecretTokenKey:   []byte("token"),
+					TokenSecretReleaseKey: []byte("release"),
+					TokenSecretConfigKey:  compressedConfigBytes,
+				},
+			},
+			validation: func(t *testing.T, secret client.Object) {
+				ctx := context.Background()
+				r := TokenSecretReconciler{
+					Client:           fake.NewClientBuilder().WithObjects(secret).Build(),
+					IgnitionProvider: &fakeIgnitionProvider{},
+					PayloadStore:     NewPayloadStore(),
+				}
+				r.PayloadStore.Set("token", CacheValue{Payload: []byte("stale"), SecretName: secret.GetName(), ConfigHash: "original"})
+				g := NewWithT(t)
+				_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(secret)})
+				g.Expect(err).ToNot(HaveOccurred())
+
+				value, found := r.PayloadStore.Get("token")
+				g.Expect(found).To(BeTrue())
+				g.Expect(value.Payload).To(BeEquivalentTo(fakePayload))
+				g.Expect(value.ConfigHash).To(Equal(util.HashSimple(compressedConfigBytes)))
+			},
+		},
 		{
 			name: "When a secret token ID has lived beyond 1/2 ttl it should be rotated",
 			secret: &corev1.Secret{
@@ -214,6 +286,7 @@ func TestReconcile(t *testing.T) {
 					value: CacheValue{
 						Payload:    []byte(fakePayload),
 						SecretName: secret.GetName(),
+						ConfigHash: util.HashSimple(compressedConfigBytes),
 					},
 					expiry: now.Add(-1 * time.Hour),
 				}
@@ -221,6 +294,7 @@ func TestReconcile(t *testing.T) {
 					value: CacheValue{
 						Payload:    []byte(fakePayload),
 						SecretName: secret.GetName(),
+						ConfigHash: util.HashSimple(compressedConfigBytes),
 					},
 					expiry: now.Add(ttl / 2),
 				}
@@ -600,3 +674,66 @@ func TestProcessedExpiredToken(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcilePublishesPayload(t *testing.T) {
+	compressedConfig, err := util.CompressAndEncode([]byte("compressedConfig"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name                 string
+		publisher            *fakePayloadPublisher
+		expectedPublishedKey string
+	}{
+		{
+			name:                 "When the payload is published it should cache its key",
+			publisher:            &fakePayloadPublisher{},
+			expectedPublishedKey: PayloadKey("test", []byte(fakePayload)),
+		},
+		{
+			name:                 "When the payload can't be published it should cache it without a key",
+			publisher:            &fakePayloadPublisher{publishErr: fmt.Errorf("access denied")},
+			expectedPublishedKey: "",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			ctx := context.Background()
+			token := uuid.New().String()
+			oldToken := uuid.New().String()
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test",
+					Namespace:   "test",
+					Annotations: map[string]string{TokenSecretAnnotation: "true"},
+				},
+				Data: map[string][]byte{
+					TokenSecretTokenKey:    []byte(token),
+					TokenSecretOldTokenKey: []byte(oldToken),
+					TokenSecretReleaseKey:  []byte("release"),
+					TokenSecretConfigKey:   compressedConfig.Bytes(),
+				},
+			}
+			r := TokenSecretReconciler{
+				Client:           fake.NewClientBuilder().WithObjects(secret).Build(),
+				IgnitionProvider: &fakeIgnitionProvider{},
+				PayloadStore:     NewPayloadStore(),
+				PayloadPublisher: tc.publisher,
+			}
+			_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(secret)})
+			g.Expect(err).ToNot(HaveOccurred())
+
+			for _, cachedToken := range []string{token, oldToken} {
+				value, ok := r.PayloadStore.Get(cachedToken)
+				g.Expect(ok).To(BeTrue())
+				g.Expect(value.Payload).To(BeEquivalentTo(fakePayload))
+				g.Expect(value.PublishedKey).To(Equal(tc.expectedPublishedKey))
+			}
+			if tc.expectedPublishedKey != "" {
+				g.Expect(tc.publisher.published).To(HaveKeyWithValue(tc.expectedPublishedKey, []byte(fakePayload)))
+			}
+		})
+	}
+}
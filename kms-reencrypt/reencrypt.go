@@ -0,0 +1,102 @@
+package kmsreencrypt
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/go-logr/logr"
+	"github.com/openshift/hypershift/support/config"
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/clientcmd"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// listPageSize is the number of resources listed at once, to bound the memory used with large clusters.
+const listPageSize = 500
+
+type options struct {
+	kubeconfig string
+}
+
+// NewStartCommand returns the command re-encrypting the resources encrypted by the kube-apiserver of the hosted
+// cluster, after the KMS key encrypting them was rotated.
+func NewStartCommand() *cobra.Command {
+	opts := options{}
+
+	cmd := &cobra.Command{
+		Use:          "kms-reencrypt",
+		Short:        "Re-encrypts the encrypted resources of a hosted cluster with its active KMS key",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer cancel()
+
+			return run(ctx, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.kubeconfig, "kubeconfig", "", "kubeconfig of the kube-apiserver of the hosted cluster.")
+
+	_ = cmd.MarkFlagRequired("kubeconfig")
+
+	return cmd
+}
+
+func run(ctx context.Context, opts options) error {
+	restConfig, err := clientcmd.BuildConfigFromFlags("", opts.kubeconfig)
+	if err != nil {
+		return fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+	c, err := client.New(restConfig, client.Options{})
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+	return reencrypt(ctx, c, config.KMSEncryptedObjects(), ctrl.Log.WithName("kms-reencrypt"))
+}
+
+// reencrypt rewrites every object of the resources, so the kube-apiserver stores them encrypted with the active KMS
+// key. The objects are written unchanged: objects which are modified or deleted concurrently don't need to be
+// written again.
+func reencrypt(ctx context.Context, c client.Client, resources []string, log logr.Logger) error {
+	for _, resource := range resources {
+		groupResource := schema.ParseGroupResource(resource)
+		gvk, err := c.RESTMapper().KindFor(groupResource.WithVersion(""))
+		if err != nil {
+			if meta.IsNoMatchError(err) {
+				// e.g. the OAuth APIs aren't served when the OAuth server is disabled.
+				log.Info("Skipping resource not served by the kube-apiserver", "resource", resource)
+				continue
+			}
+			return fmt.Errorf("failed to get the kind of %s: %w", resource, err)
+		}
+
+		count := 0
+		continueToken := ""
+		for {
+			list := &unstructured.UnstructuredList{}
+			list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+			if err := c.List(ctx, list, client.Limit(listPageSize), client.Continue(continueToken)); err != nil {
+				return fmt.Errorf("failed to list %s: %w", resource, err)
+			}
+			for i := range list.Items {
+				if err := c.Update(ctx, &list.Items[i]); err != nil && !apierrors.IsConflict(err) && !apierrors.IsNotFound(err) {
+					return fmt.Errorf("failed to re-encrypt %s %s: %w", resource, client.ObjectKeyFromObject(&list.Items[i]), err)
+				}
+				count++
+			}
+			if continueToken = list.GetContinue(); continueToken == "" {
+				break
+			}
+		}
+		log.Info("Re-encrypted resource", "resource", resource, "count", count)
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package kmsreencrypt
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	routev1 "github.com/openshift/api/route/v1"
+	"github.com/openshift/hypershift/support/api"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReencrypt(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "secret"}, Data: map[string][]byte{"key": []byte("value")}}
+	configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "openshift-config", Name: "config"}, Data: map[string]string{"key": "value"}}
+	route := &routev1.Route{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "route"}}
+	restMapper := meta.NewDefaultRESTMapper(nil)
+	restMapper.Add(corev1.SchemeGroupVersion.WithKind("Secret"), meta.RESTScopeNamespace)
+	restMapper.Add(corev1.SchemeGroupVersion.WithKind("ConfigMap"), meta.RESTScopeNamespace)
+	restMapper.Add(routev1.GroupVersion.WithKind("Route"), meta.RESTScopeNamespace)
+	c := fake.NewClientBuilder().WithScheme(api.Scheme).WithRESTMapper(restMapper).WithObjects(secret, configMap, route).Build()
+
+	g.Expect(reencrypt(ctx, c, []string{"secrets", "configmaps", "routes.route.openshift.io", "widgets.example.com"}, logr.Discard())).To(Succeed())
+
+	for _, obj := range []client.Object{secret, configMap, route} {
+		previousResourceVersion := obj.GetResourceVersion()
+		g.Expect(c.Get(ctx, client.ObjectKeyFromObject(obj), obj)).To(Succeed())
+		g.Expect(obj.GetResourceVersion()).ToNot(Equal(previousResourceVersion), "%T %s should be rewritten", obj, obj.GetName())
+	}
+	g.Expect(secret.Data).To(Equal(map[string][]byte{"key": []byte("value")}))
+	g.Expect(configMap.Data).To(Equal(map[string]string{"key": "value"}))
+}
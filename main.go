@@ -26,11 +26,24 @@ import (
 	"github.com/spf13/cobra"
 	"go.uber.org/zap/zapcore"
 
+	analyzecmd "github.com/openshift/hypershift/cmd/analyze"
 	"github.com/openshift/hypershift/cmd/consolelogs"
 	createcmd "github.com/openshift/hypershift/cmd/create"
+	deletecmd "github.com/openshift/hypershift/cmd/delete"
 	destroycmd "github.com/openshift/hypershift/cmd/destroy"
+	diagnosecmd "github.com/openshift/hypershift/cmd/diagnose"
 	dumpcmd "github.com/openshift/hypershift/cmd/dump"
+	exportcmd "github.com/openshift/hypershift/cmd/export"
+	exposecmd "github.com/openshift/hypershift/cmd/expose"
 	installcmd "github.com/openshift/hypershift/cmd/install"
+	instancetypescmd "github.com/openshift/hypershift/cmd/instancetypes"
+	listcmd "github.com/openshift/hypershift/cmd/list"
+	nodepoolcmd "github.com/openshift/hypershift/cmd/nodepool"
+	releasecmd "github.com/openshift/hypershift/cmd/release"
+	rotatecmd "github.com/openshift/hypershift/cmd/rotate"
+	scalecmd "github.com/openshift/hypershift/cmd/scale"
+	statuscmd "github.com/openshift/hypershift/cmd/status"
+	testcmd "github.com/openshift/hypershift/cmd/test"
 	cliversion "github.com/openshift/hypershift/cmd/version"
 	"github.com/openshift/hypershift/pkg/version"
 
@@ -64,7 +77,20 @@ func main() {
 	cmd.AddCommand(createcmd.NewCommand())
 	cmd.AddCommand(destroycmd.NewCommand())
 	cmd.AddCommand(dumpcmd.NewCommand())
+	cmd.AddCommand(exposecmd.NewCommand())
+	cmd.AddCommand(exportcmd.NewCommand())
 	cmd.AddCommand(consolelogs.NewCommand())
+	cmd.AddCommand(statuscmd.NewCommand())
+	cmd.AddCommand(testcmd.NewCommand())
+	cmd.AddCommand(releasecmd.NewCommand())
+	cmd.AddCommand(nodepoolcmd.NewCommand())
+	cmd.AddCommand(scalecmd.NewCommand())
+	cmd.AddCommand(deletecmd.NewCommand())
+	cmd.AddCommand(listcmd.NewCommand())
+	cmd.AddCommand(diagnosecmd.NewCommand())
+	cmd.AddCommand(analyzecmd.NewCommand())
+	cmd.AddCommand(instancetypescmd.NewCommand())
+	cmd.AddCommand(rotatecmd.NewCommand())
 	cmd.AddCommand(cliversion.NewVersionCommand())
 
 	sigs := make(chan os.Signal, 1)
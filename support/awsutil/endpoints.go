@@ -0,0 +1,96 @@
+package awsutil
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+)
+
+// EndpointOverridesEnvVar holds the overrides of the AWS service endpoints of the in-cluster AWS clients, as comma
+// separated service=url pairs.
+const EndpointOverridesEnvVar = "HYPERSHIFT_AWS_ENDPOINT_OVERRIDES"
+
+// EndpointResolver resolves the AWS services with an override, keyed by the endpoint ID of the service, e.g. ec2,
+// elasticloadbalancing, route53 or sts, to the URL of the override and the other services to their default endpoint.
+// The requests to an overridden endpoint are signed like the ones to the default endpoint.
+func EndpointResolver(overrides map[string]string) endpoints.Resolver {
+	return endpoints.ResolverFunc(func(service, region string, opts ...func(*endpoints.Options)) (endpoints.ResolvedEndpoint, error) {
+		resolved, err := endpoints.DefaultResolver().EndpointFor(service, region, opts...)
+		override, ok := overrides[service]
+		if !ok {
+			return resolved, err
+		}
+		if err != nil {
+			resolved = endpoints.ResolvedEndpoint{SigningRegion: region}
+		}
+		resolved.URL = override
+		return resolved, nil
+	})
+}
+
+// ValidateEndpointOverrides validates the overrides are HTTPS URLs.
+func ValidateEndpointOverrides(overrides map[string]string) error {
+	for service, override := range overrides {
+		u, err := url.Parse(override)
+		if err != nil {
+			return fmt.Errorf("invalid endpoint override for service %s: %w", service, err)
+		}
+		if u.Scheme != "https" || u.Host == "" {
+			return fmt.Errorf("invalid endpoint override for service %s: %q is not an https URL", service, override)
+		}
+	}
+	return nil
+}
+
+// ParseEndpointOverrides parses the comma separated service=url pairs of EndpointOverridesEnvVar.
+func ParseEndpointOverrides(value string) (map[string]string, error) {
+	if value == "" {
+		return nil, nil
+	}
+	overrides := map[string]string{}
+	for _, pair := range strings.Split(value, ",") {
+		service, override, ok := strings.Cut(pair, "=")
+		if !ok || service == "" {
+			return nil, fmt.Errorf("invalid endpoint override %q, expected service=url", pair)
+		}
+		overrides[service] = override
+	}
+	return overrides, ValidateEndpointOverrides(overrides)
+}
+
+// FormatEndpointOverrides formats the overrides as the comma separated service=url pairs of EndpointOverridesEnvVar.
+func FormatEndpointOverrides(overrides map[string]string) string {
+	var pairs []string
+	for service, override := range overrides {
+		pairs = append(pairs, service+"="+override)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// EndpointOverrides returns the service endpoints of a HostedCluster as overrides.
+func EndpointOverrides(serviceEndpoints []hyperv1.AWSServiceEndpoint) map[string]string {
+	if len(serviceEndpoints) == 0 {
+		return nil
+	}
+	overrides := map[string]string{}
+	for _, endpoint := range serviceEndpoints {
+		overrides[endpoint.Name] = endpoint.URL
+	}
+	return overrides
+}
+
+// ServiceEndpoints returns the overrides as the service endpoints of a HostedCluster, sorted by service.
+func ServiceEndpoints(overrides map[string]string) []hyperv1.AWSServiceEndpoint {
+	var serviceEndpoints []hyperv1.AWSServiceEndpoint
+	for service, override := range overrides {
+		serviceEndpoints = append(serviceEndpoints, hyperv1.AWSServiceEndpoint{Name: service, URL: override})
+	}
+	sort.Slice(serviceEndpoints, func(i, j int) bool { return serviceEndpoints[i].Name < serviceEndpoints[j].Name })
+	return serviceEndpoints
+}
@@ -0,0 +1,109 @@
+package awsutil
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+)
+
+func TestEndpointResolver(t *testing.T) {
+	resolver := EndpointResolver(map[string]string{
+		"ec2":     "https://ec2.example.com",
+		"route53": "https://route53.example.com",
+	})
+
+	testCases := []struct {
+		name                  string
+		service               string
+		region                string
+		expectedURL           string
+		expectedSigningRegion string
+	}{
+		{
+			name:                  "When the service is overridden it should resolve to the override",
+			service:               "ec2",
+			region:                "us-gov-west-1",
+			expectedURL:           "https://ec2.example.com",
+			expectedSigningRegion: "us-gov-west-1",
+		},
+		{
+			name:                  "When a global service is overridden it should keep signing with its global region",
+			service:               "route53",
+			region:                "eu-west-1",
+			expectedURL:           "https://route53.example.com",
+			expectedSigningRegion: "us-east-1",
+		},
+		{
+			name:                  "When the service is not overridden it should resolve to the default endpoint",
+			service:               "elasticloadbalancing",
+			region:                "eu-west-1",
+			expectedURL:           "https://elasticloadbalancing.eu-west-1.amazonaws.com",
+			expectedSigningRegion: "eu-west-1",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			resolved, err := resolver.EndpointFor(tc.service, tc.region)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(resolved.URL).To(Equal(tc.expectedURL))
+			g.Expect(resolved.SigningRegion).To(Equal(tc.expectedSigningRegion))
+		})
+	}
+}
+
+func TestParseEndpointOverrides(t *testing.T) {
+	testCases := []struct {
+		name        string
+		value       string
+		expected    map[string]string
+		expectError bool
+	}{
+		{
+			name: "When the value is empty it should return no overrides",
+		},
+		{
+			name:     "When the value has service=url pairs it should return them",
+			value:    "ec2=https://ec2.example.com,sts=https://sts.example.com",
+			expected: map[string]string{"ec2": "https://ec2.example.com", "sts": "https://sts.example.com"},
+		},
+		{
+			name:        "When a pair has no service it should fail",
+			value:       "https://ec2.example.com",
+			expectError: true,
+		},
+		{
+			name:        "When an override is not an https URL it should fail",
+			value:       "ec2=http://ec2.example.com",
+			expectError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			overrides, err := ParseEndpointOverrides(tc.value)
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(overrides).To(Equal(tc.expected))
+		})
+	}
+}
+
+func TestEndpointOverridesRoundTrip(t *testing.T) {
+	g := NewWithT(t)
+	serviceEndpoints := []hyperv1.AWSServiceEndpoint{
+		{Name: "ec2", URL: "https://ec2.example.com"},
+		{Name: "elasticloadbalancing", URL: "https://elb.example.com"},
+	}
+
+	formatted := FormatEndpointOverrides(EndpointOverrides(serviceEndpoints))
+	g.Expect(formatted).To(Equal("ec2=https://ec2.example.com,elasticloadbalancing=https://elb.example.com"))
+	overrides, err := ParseEndpointOverrides(formatted)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(ServiceEndpoints(overrides)).To(Equal(serviceEndpoints))
+}
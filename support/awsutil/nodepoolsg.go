@@ -0,0 +1,66 @@
+package awsutil
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+)
+
+const (
+	// NodePoolSecurityGroupConfigMapLabel labels the ConfigMaps the NodePool controller mirrors the dedicated
+	// security group of a NodePool into, in the control plane namespace, for the control plane operator to create
+	// the security group with the credentials of the HostedCluster. The ConfigMaps are also labeled with
+	// hyperv1.NodePoolLabel set to the name of the NodePool.
+	NodePoolSecurityGroupConfigMapLabel = "hypershift.openshift.io/nodepool-security-group"
+
+	// NodePoolSecurityGroupRulesKey is the key of the JSON encoded ingress rules in the ConfigMap.
+	NodePoolSecurityGroupRulesKey = "ingressRules"
+
+	// NodePoolSecurityGroupIDAnnotation is set on the ConfigMap by the control plane operator to the id of the
+	// security group once created.
+	NodePoolSecurityGroupIDAnnotation = "hypershift.openshift.io/security-group-id"
+
+	// NodePoolSecurityGroupTagKey tags the dedicated security groups with the name of their NodePool.
+	NodePoolSecurityGroupTagKey = "hypershift.openshift.io/nodepool"
+)
+
+// NodePoolSecurityGroupName returns the name of the security group dedicated to a NodePool.
+func NodePoolSecurityGroupName(infraID, nodePoolName string) string {
+	return fmt.Sprintf("%s-%s-sg", infraID, nodePoolName)
+}
+
+// NodePoolSGIngressRules returns the ingress permissions of the security group dedicated to a NodePool, one per
+// CIDR block of each rule.
+func NodePoolSGIngressRules(rules []hyperv1.AWSSecurityGroupIngressRule) []*ec2.IpPermission {
+	var permissions []*ec2.IpPermission
+	for _, rule := range rules {
+		protocol := string(rule.Protocol)
+		var fromPort, toPort int64
+		switch rule.Protocol {
+		case hyperv1.AWSSecurityGroupProtocolAll:
+			protocol = "-1"
+		case hyperv1.AWSSecurityGroupProtocolICMP:
+			fromPort, toPort = -1, -1
+		default:
+			fromPort, toPort = int64(aws.Int32Value(rule.FromPort)), int64(aws.Int32Value(rule.ToPort))
+		}
+		for _, cidr := range rule.CIDRBlocks {
+			ipRange := &ec2.IpRange{CidrIp: aws.String(cidr)}
+			if rule.Description != "" {
+				ipRange.Description = aws.String(rule.Description)
+			}
+			permission := &ec2.IpPermission{
+				IpProtocol: aws.String(protocol),
+				IpRanges:   []*ec2.IpRange{ipRange},
+			}
+			if rule.Protocol != hyperv1.AWSSecurityGroupProtocolAll {
+				permission.FromPort = aws.Int64(fromPort)
+				permission.ToPort = aws.Int64(toPort)
+			}
+			permissions = append(permissions, permission)
+		}
+	}
+	return permissions
+}
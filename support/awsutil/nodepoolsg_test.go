@@ -0,0 +1,59 @@
+package awsutil
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	. "github.com/onsi/gomega"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+)
+
+func TestNodePoolSGIngressRules(t *testing.T) {
+	testCases := []struct {
+		name     string
+		rule     hyperv1.AWSSecurityGroupIngressRule
+		expected []*ec2.IpPermission
+	}{
+		{
+			name: "When the rule is tcp it should allow its port range from each CIDR block",
+			rule: hyperv1.AWSSecurityGroupIngressRule{
+				Description: "postgres",
+				Protocol:    hyperv1.AWSSecurityGroupProtocolTCP,
+				FromPort:    aws.Int32(5432),
+				ToPort:      aws.Int32(5433),
+				CIDRBlocks:  []string{"10.0.0.0/16", "10.1.0.0/16"},
+			},
+			expected: []*ec2.IpPermission{
+				{IpProtocol: aws.String("tcp"), FromPort: aws.Int64(5432), ToPort: aws.Int64(5433), IpRanges: []*ec2.IpRange{{CidrIp: aws.String("10.0.0.0/16"), Description: aws.String("postgres")}}},
+				{IpProtocol: aws.String("tcp"), FromPort: aws.Int64(5432), ToPort: aws.Int64(5433), IpRanges: []*ec2.IpRange{{CidrIp: aws.String("10.1.0.0/16"), Description: aws.String("postgres")}}},
+			},
+		},
+		{
+			name: "When the rule is icmp it should allow all types",
+			rule: hyperv1.AWSSecurityGroupIngressRule{
+				Protocol:   hyperv1.AWSSecurityGroupProtocolICMP,
+				CIDRBlocks: []string{"10.0.0.0/16"},
+			},
+			expected: []*ec2.IpPermission{
+				{IpProtocol: aws.String("icmp"), FromPort: aws.Int64(-1), ToPort: aws.Int64(-1), IpRanges: []*ec2.IpRange{{CidrIp: aws.String("10.0.0.0/16")}}},
+			},
+		},
+		{
+			name: "When the rule is for all protocols it should not set ports",
+			rule: hyperv1.AWSSecurityGroupIngressRule{
+				Protocol:   hyperv1.AWSSecurityGroupProtocolAll,
+				CIDRBlocks: []string{"10.0.0.0/16"},
+			},
+			expected: []*ec2.IpPermission{
+				{IpProtocol: aws.String("-1"), IpRanges: []*ec2.IpRange{{CidrIp: aws.String("10.0.0.0/16")}}},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(NodePoolSGIngressRules([]hyperv1.AWSSecurityGroupIngressRule{tc.rule})).To(Equal(tc.expected))
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package awsutil
+
+import (
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+)
+
+// partitionForRegion returns the AWS partition of the region, e.g. aws-cn for the China regions or aws-us-gov for
+// the GovCloud regions. Unknown regions are assumed to be in the commercial aws partition.
+func partitionForRegion(region string) endpoints.Partition {
+	if partition, ok := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), region); ok {
+		return partition
+	}
+	return endpoints.AwsPartition()
+}
+
+// Partition returns the ID of the AWS partition of the region, to be used in ARNs.
+func Partition(region string) string {
+	return partitionForRegion(region).ID()
+}
+
+// DNSSuffix returns the DNS suffix of the endpoints of the AWS partition of the region, e.g. amazonaws.com.cn for
+// the China regions.
+func DNSSuffix(region string) string {
+	return partitionForRegion(region).DNSSuffix()
+}
+
+// Route53Region returns the region signing the requests to Route53, a global service, in the AWS partition of the
+// region.
+func Route53Region(region string) string {
+	switch Partition(region) {
+	case endpoints.AwsCnPartitionID:
+		return "cn-northwest-1"
+	case endpoints.AwsUsGovPartitionID:
+		return "us-gov-west-1"
+	default:
+		return "us-east-1"
+	}
+}
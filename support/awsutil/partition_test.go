@@ -0,0 +1,54 @@
+package awsutil
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestPartition(t *testing.T) {
+	testCases := []struct {
+		name                  string
+		region                string
+		expectedPartition     string
+		expectedDNSSuffix     string
+		expectedRoute53Region string
+	}{
+		{
+			name:                  "When the region is commercial it should use the aws partition",
+			region:                "eu-west-1",
+			expectedPartition:     "aws",
+			expectedDNSSuffix:     "amazonaws.com",
+			expectedRoute53Region: "us-east-1",
+		},
+		{
+			name:                  "When the region is in China it should use the aws-cn partition",
+			region:                "cn-north-1",
+			expectedPartition:     "aws-cn",
+			expectedDNSSuffix:     "amazonaws.com.cn",
+			expectedRoute53Region: "cn-northwest-1",
+		},
+		{
+			name:                  "When the region is in GovCloud it should use the aws-us-gov partition",
+			region:                "us-gov-east-1",
+			expectedPartition:     "aws-us-gov",
+			expectedDNSSuffix:     "amazonaws.com",
+			expectedRoute53Region: "us-gov-west-1",
+		},
+		{
+			name:                  "When the region is unknown it should default to the aws partition",
+			region:                "",
+			expectedPartition:     "aws",
+			expectedDNSSuffix:     "amazonaws.com",
+			expectedRoute53Region: "us-east-1",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(Partition(tc.region)).To(Equal(tc.expectedPartition))
+			g.Expect(DNSSuffix(tc.region)).To(Equal(tc.expectedDNSSuffix))
+			g.Expect(Route53Region(tc.region)).To(Equal(tc.expectedRoute53Region))
+		})
+	}
+}
@@ -10,6 +10,13 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/network/armnetwork/v5"
 )
 
+// AvailabilitySetName returns the name of the availability set of a NodePool. The cluster-api provider places the VMs
+// of a MachineDeployment which have no failure domain in an availability set named after the cluster and the
+// MachineDeployment, which are named after the infra ID and the NodePool.
+func AvailabilitySetName(infraID, nodePoolName string) string {
+	return fmt.Sprintf("%s_%s-as", infraID, nodePoolName)
+}
+
 // GetSubnetNameFromSubnetID extracts the subnet name from a subnet ID
 // Example subnet ID: /subscriptions/<subscriptionID>/resourceGroups/<resourceGroupName>/providers/Microsoft.Network/virtualNetworks/<vnetName>/subnets/<subnetName>
 func GetSubnetNameFromSubnetID(subnetID string) (string, error) {
@@ -0,0 +1,59 @@
+package azureutil
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// BlobSASVersion is the storage service version of the shared access signatures returned by BlobServiceSASURL.
+const BlobSASVersion = "2020-12-06"
+
+// BlobServiceSASURL returns the URL of a blob with a service shared access signature (SAS) signed with the storage
+// account key, granting the given permissions between start and expiry. The blob service endpoint of the storage
+// account is used when endpoint is empty.
+// https://learn.microsoft.com/en-us/rest/api/storageservices/create-service-sas
+func BlobServiceSASURL(endpoint, account, container, blob, permissions string, accountKey []byte, start, expiry time.Time) (string, error) {
+	signedStart := start.UTC().Format(time.RFC3339)
+	signedExpiry := expiry.UTC().Format(time.RFC3339)
+	stringToSign := strings.Join([]string{
+		permissions,
+		signedStart,
+		signedExpiry,
+		fmt.Sprintf("/blob/%s/%s/%s", account, container, blob),
+		"", // signed identifier
+		"", // signed IP
+		"https",
+		BlobSASVersion,
+		"b", // signed resource
+		"",  // signed snapshot time
+		"",  // signed encryption scope
+		"",  // rscc
+		"",  // rscd
+		"",  // rsce
+		"",  // rscl
+		"",  // rsct
+	}, "\n")
+	mac := hmac.New(sha256.New, accountKey)
+	if _, err := mac.Write([]byte(stringToSign)); err != nil {
+		return "", err
+	}
+
+	query := url.Values{}
+	query.Set("sv", BlobSASVersion)
+	query.Set("st", signedStart)
+	query.Set("se", signedExpiry)
+	query.Set("sr", "b")
+	query.Set("sp", permissions)
+	query.Set("spr", "https")
+	query.Set("sig", base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://%s.blob.core.windows.net", account)
+	}
+	return fmt.Sprintf("%s/%s/%s?%s", strings.TrimSuffix(endpoint, "/"), container, blob, query.Encode()), nil
+}
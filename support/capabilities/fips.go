@@ -0,0 +1,47 @@
+package capabilities
+
+import (
+	"os"
+	"runtime/debug"
+	"strings"
+)
+
+// fipsEnabledPath is the file through which the kernel reports if it runs in FIPS mode. Containers share the kernel
+// of their node, so it reports the FIPS mode of the management cluster node.
+var fipsEnabledPath = "/proc/sys/crypto/fips_enabled"
+
+// fipsCapableExperiments are the GOEXPERIMENTs of the FIPS capable Go toolchains: the upstream BoringCrypto one and
+// the OpenSSL backed one of RHEL.
+var fipsCapableExperiments = []string{"boringcrypto", "strictfipsruntime"}
+
+func isFIPSModeEnabled() bool {
+	fipsEnabled, err := os.ReadFile(fipsEnabledPath)
+	if err != nil {
+		return false
+	}
+	return strings.TrimSpace(string(fipsEnabled)) == "1"
+}
+
+func isFIPSCapableBuild() bool {
+	buildInfo, ok := debug.ReadBuildInfo()
+	if !ok {
+		return false
+	}
+	return hasFIPSCapableExperiment(buildInfo.Settings)
+}
+
+func hasFIPSCapableExperiment(settings []debug.BuildSetting) bool {
+	for _, setting := range settings {
+		if setting.Key != "GOEXPERIMENT" {
+			continue
+		}
+		for _, experiment := range strings.Split(setting.Value, ",") {
+			for _, fipsExperiment := range fipsCapableExperiments {
+				if experiment == fipsExperiment {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,78 @@
+package capabilities
+
+import (
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestIsFIPSModeEnabled(t *testing.T) {
+	testCases := []struct {
+		name     string
+		content  *string
+		expected bool
+	}{
+		{
+			name:     "When the kernel runs in FIPS mode it should be enabled",
+			content:  pointerTo("1\n"),
+			expected: true,
+		},
+		{
+			name:    "When the kernel doesn't run in FIPS mode it should not be enabled",
+			content: pointerTo("0\n"),
+		},
+		{
+			name: "When the kernel doesn't report FIPS mode it should not be enabled",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			path := filepath.Join(t.TempDir(), "fips_enabled")
+			if tc.content != nil {
+				g.Expect(os.WriteFile(path, []byte(*tc.content), 0644)).To(Succeed())
+			}
+			originalPath := fipsEnabledPath
+			fipsEnabledPath = path
+			defer func() { fipsEnabledPath = originalPath }()
+
+			g.Expect(isFIPSModeEnabled()).To(Equal(tc.expected))
+		})
+	}
+}
+
+func TestHasFIPSCapableExperiment(t *testing.T) {
+	testCases := []struct {
+		name     string
+		settings []debug.BuildSetting
+		expected bool
+	}{
+		{
+			name:     "When built with the OpenSSL backed toolchain it should be FIPS capable",
+			settings: []debug.BuildSetting{{Key: "CGO_ENABLED", Value: "1"}, {Key: "GOEXPERIMENT", Value: "strictfipsruntime"}},
+			expected: true,
+		},
+		{
+			name:     "When built with BoringCrypto among other experiments it should be FIPS capable",
+			settings: []debug.BuildSetting{{Key: "GOEXPERIMENT", Value: "loopvar,boringcrypto"}},
+			expected: true,
+		},
+		{
+			name:     "When built without a FIPS capable experiment it should not be FIPS capable",
+			settings: []debug.BuildSetting{{Key: "GOEXPERIMENT", Value: "loopvar"}, {Key: "-tags", Value: "boringcrypto"}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(hasFIPSCapableExperiment(tc.settings)).To(Equal(tc.expected))
+		})
+	}
+}
+
+func pointerTo(s string) *string {
+	return &s
+}
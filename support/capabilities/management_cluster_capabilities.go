@@ -69,6 +69,11 @@ const (
 	// CapabilityImageStream indicates if the cluster supports ImageStream
 	// image.openshift.io
 	CapabilityImageStream
+
+	// CapabilityFIPS indicates if the management cluster runs in FIPS mode and
+	// the operator binary is built with a FIPS capable crypto library, so the
+	// control plane components it manages run in FIPS mode
+	CapabilityFIPS
 )
 
 // ManagementClusterCapabilities holds all information about optional capabilities of
@@ -213,5 +218,10 @@ func DetectManagementClusterCapabilities(client discovery.ServerResourcesInterfa
 		discoveredCapabilities[CapabilityImageStream] = struct{}{}
 	}
 
+	// check for FIPS capability
+	if isFIPSModeEnabled() && isFIPSCapableBuild() {
+		discoveredCapabilities[CapabilityFIPS] = struct{}{}
+	}
+
 	return &ManagementClusterCapabilities{capabilities: discoveredCapabilities}, nil
 }
@@ -318,7 +318,8 @@ func ValidateKeyPair(pemKey, pemCertificate []byte, cfg *CertCfg, minimumRemaini
 }
 
 // ReconcileSignedCert reconciles a certificate secret using the provided config. It will
-// rotate the cert if there are less than 30 days of validity left.
+// rotate the cert if there are less than 30 days of validity left, or if the CA was regenerated
+// since the cert was signed.
 func ReconcileSignedCert(
 	secret *corev1.Secret,
 	ca *corev1.Secret,
@@ -346,6 +347,10 @@ func ReconcileSignedCert(
 		ipAddresses = append(ipAddresses, address)
 	}
 
+	// A CA hash that doesn't match the CA means the cert was signed by a previous CA, which may have
+	// been regenerated to revoke the certs it signed.
+	_, hasCAHash := secret.Annotations[CAHashAnnotation]
+	caRegenerated := hasCAHash && !HasCAHash(secret, ca, opts)
 	if !HasCAHash(secret, ca, opts) {
 		annotateWithCA(secret, ca, opts)
 	}
@@ -364,7 +369,7 @@ func ReconcileSignedCert(
 		DNSNames:     dnsNames,
 		IPAddresses:  ipAddresses,
 	}
-	if err := ValidateKeyPair(secret.Data[keyKey], secret.Data[crtKey], cfg, 30*ValidityOneDay); err == nil {
+	if err := ValidateKeyPair(secret.Data[keyKey], secret.Data[crtKey], cfg, 30*ValidityOneDay); err == nil && !caRegenerated {
 		return nil
 	}
 	certBytes, keyBytes, _, err := signCertificate(cfg, ca, opts)
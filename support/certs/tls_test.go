@@ -225,6 +225,53 @@ func TestReconcileSignedCertWithCustomCAKeys(t *testing.T) {
 	}
 }
 
+func TestReconcileSignedCertRegeneratesForNewCA(t *testing.T) {
+	t.Parallel()
+	reconcileCert := func(secret, ca *corev1.Secret) {
+		t.Helper()
+		if err := certs.ReconcileSignedCert(secret, ca, "some-cn", []string{"some-ou"}, pki.X509UsageClientAuth, corev1.TLSCertKey, corev1.TLSPrivateKeyKey, "", nil, nil); err != nil {
+			t.Fatalf("failed to reconcile cert: %v", err)
+		}
+	}
+	issuedBy := func(secret, ca *corev1.Secret) bool {
+		t.Helper()
+		cert, err := certs.PemToCertificate(secret.Data[corev1.TLSCertKey])
+		if err != nil {
+			t.Fatalf("failed to parse cert: %v", err)
+		}
+		caCert, err := certs.PemToCertificate(ca.Data[certs.CASignerCertMapKey])
+		if err != nil {
+			t.Fatalf("failed to parse CA: %v", err)
+		}
+		return cert.CheckSignatureFrom(caCert) == nil
+	}
+
+	ca := &corev1.Secret{}
+	if err := certs.ReconcileSelfSignedCA(ca, "some-cn", "some-ou"); err != nil {
+		t.Fatalf("failed to reconcile CA: %v", err)
+	}
+	certSecret := &corev1.Secret{}
+	reconcileCert(certSecret, ca)
+	original := certSecret.DeepCopy()
+
+	reconcileCert(certSecret, ca)
+	if !bytes.Equal(certSecret.Data[corev1.TLSCertKey], original.Data[corev1.TLSCertKey]) {
+		t.Error("ReconcileSignedCert regenerated the cert although the CA is unchanged")
+	}
+
+	newCA := &corev1.Secret{}
+	if err := certs.ReconcileSelfSignedCA(newCA, "some-cn", "some-ou"); err != nil {
+		t.Fatalf("failed to reconcile CA: %v", err)
+	}
+	reconcileCert(certSecret, newCA)
+	if !issuedBy(certSecret, newCA) {
+		t.Error("ReconcileSignedCert didn't regenerate the cert signed by the previous CA")
+	}
+	if !certs.HasCAHash(certSecret, newCA, &certs.CAOpts{}) {
+		t.Error("ReconcileSignedCert didn't record the hash of the new CA")
+	}
+}
+
 func TestCertChainingAttributesPresent(t *testing.T) {
 	caKey, caCert, err := certs.GenerateSelfSignedCertificate(&certs.CertCfg{
 		Subject:   pkix.Name{CommonName: "testca", OrganizationalUnit: []string{"randomorg"}},
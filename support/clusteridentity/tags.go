@@ -0,0 +1,123 @@
+// Package clusteridentity defines the identity tags HyperShift applies to the cloud resources of a cluster, on every
+// platform, so that governance tooling can find the resources of HyperShift clusters and the cluster they belong to.
+package clusteridentity
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+)
+
+const (
+	// TagKeyPrefix prefixes the keys of the identity tags. It has no slash since Azure doesn't allow them in tag keys,
+	// like the kubernetes.io_cluster.<infra ID> tag of Azure resources.
+	TagKeyPrefix = "hypershift.openshift.io_"
+
+	// InfraIDTagKey is the infra ID of the cluster.
+	InfraIDTagKey = TagKeyPrefix + "infra-id"
+	// ClusterIDTagKey is the unique ID of the cluster, spec.clusterID of the HostedCluster.
+	ClusterIDTagKey = TagKeyPrefix + "cluster-id"
+	// ClusterNameTagKey is the name of the HostedCluster.
+	ClusterNameTagKey = TagKeyPrefix + "cluster-name"
+	// OwnerTagKey is the owner of the cluster, see hyperv1.ClusterOwnerAnnotation.
+	OwnerTagKey = TagKeyPrefix + "owner"
+	// ExpiryTagKey is when the cluster is deleted because its lifetime expires, in RFC3339 format.
+	ExpiryTagKey = TagKeyPrefix + "expiry"
+)
+
+// invalidValueChars are the characters which aren't allowed in the values of the tags of every platform.
+var invalidValueChars = regexp.MustCompile(`[^0-9A-Za-z_.:/=+@-]`)
+
+// Tag is a cloud resource tag.
+type Tag struct {
+	Key   string
+	Value string
+}
+
+// Identity identifies the cluster the cloud resources belong to. Fields which aren't known yet, e.g. the cluster ID
+// when the infrastructure is created before the HostedCluster, are empty.
+type Identity struct {
+	InfraID     string
+	ClusterID   string
+	ClusterName string
+	Owner       string
+	Expiry      *time.Time
+}
+
+// ForHostedCluster returns the identity of the HostedCluster.
+func ForHostedCluster(hc *hyperv1.HostedCluster) Identity {
+	identity := Identity{
+		InfraID:     hc.Spec.InfraID,
+		ClusterID:   hc.Spec.ClusterID,
+		ClusterName: hc.Name,
+		Owner:       hc.Annotations[hyperv1.ClusterOwnerAnnotation],
+	}
+	if hc.Status.ExpirationTime != nil {
+		identity.Expiry = &hc.Status.ExpirationTime.Time
+	}
+	return identity
+}
+
+// ForInfrastructure returns the identity of a cluster whose infrastructure is created before its HostedCluster. Its
+// lifetime, if any, starts now.
+func ForInfrastructure(infraID, clusterName, owner string, lifetime time.Duration) Identity {
+	identity := Identity{
+		InfraID:     infraID,
+		ClusterName: clusterName,
+		Owner:       owner,
+	}
+	if lifetime > 0 {
+		expiry := time.Now().Add(lifetime)
+		identity.Expiry = &expiry
+	}
+	return identity
+}
+
+// Tags returns the identity tags, followed by the mandatory tags sorted by key. Identity tags whose value isn't known
+// are omitted. Characters not allowed in tag values on every platform are replaced with '_'.
+func (i Identity) Tags(mandatory map[string]string) []Tag {
+	var tags []Tag
+	add := func(key, value string) {
+		if value != "" {
+			tags = append(tags, Tag{Key: key, Value: invalidValueChars.ReplaceAllString(value, "_")})
+		}
+	}
+	add(InfraIDTagKey, i.InfraID)
+	add(ClusterIDTagKey, i.ClusterID)
+	add(ClusterNameTagKey, i.ClusterName)
+	add(OwnerTagKey, i.Owner)
+	if i.Expiry != nil {
+		add(ExpiryTagKey, i.Expiry.UTC().Format(time.RFC3339))
+	}
+
+	keys := make([]string, 0, len(mandatory))
+	for key := range mandatory {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		add(key, mandatory[key])
+	}
+	return tags
+}
+
+// ValidateMandatoryTags validates the mandatory tags every cluster is tagged with: their keys can't be identity tag
+// keys, and their keys and values must be valid on every platform.
+func ValidateMandatoryTags(tags map[string]string) error {
+	for key, value := range tags {
+		if strings.HasPrefix(key, TagKeyPrefix) {
+			return fmt.Errorf("invalid mandatory tag %s: keys starting with %s are reserved for the identity tags", key, TagKeyPrefix)
+		}
+		if key == "" || strings.ContainsAny(key, `<>%&\?/`) || invalidValueChars.MatchString(key) {
+			return fmt.Errorf("invalid mandatory tag key %q: it must only contain letters, digits and _.:=+@-", key)
+		}
+		if value == "" || invalidValueChars.MatchString(value) {
+			return fmt.Errorf("invalid value %q of mandatory tag %s: it must only contain letters, digits and _.:/=+@-", value, key)
+		}
+	}
+	return nil
+}
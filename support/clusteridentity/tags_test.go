@@ -0,0 +1,123 @@
+package clusteridentity
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestTags(t *testing.T) {
+	expiry := time.Date(2026, 10, 15, 18, 30, 0, 0, time.FixedZone("CEST", 2*60*60))
+	testCases := []struct {
+		name      string
+		hc        *hyperv1.HostedCluster
+		mandatory map[string]string
+		expected  []Tag
+	}{
+		{
+			name: "When the HostedCluster has an owner and a lifetime it should return all the identity tags",
+			hc: &hyperv1.HostedCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "example",
+					Annotations: map[string]string{hyperv1.ClusterOwnerAnnotation: "payments@example.com"},
+				},
+				Spec:   hyperv1.HostedClusterSpec{InfraID: "example-abcde", ClusterID: "2a1c0f4e-3f7b-4a5e-9d9e-6f1b2c3d4e5f"},
+				Status: hyperv1.HostedClusterStatus{ExpirationTime: &metav1.Time{Time: expiry}},
+			},
+			expected: []Tag{
+				{Key: InfraIDTagKey, Value: "example-abcde"},
+				{Key: ClusterIDTagKey, Value: "2a1c0f4e-3f7b-4a5e-9d9e-6f1b2c3d4e5f"},
+				{Key: ClusterNameTagKey, Value: "example"},
+				{Key: OwnerTagKey, Value: "payments@example.com"},
+				{Key: ExpiryTagKey, Value: "2026-10-15T16:30:00Z"},
+			},
+		},
+		{
+			name: "When identity fields aren't known it should omit their tags and append the mandatory tags sorted by key",
+			hc: &hyperv1.HostedCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "example"},
+			},
+			mandatory: map[string]string{"org": "payments", "cost-center": "1234"},
+			expected: []Tag{
+				{Key: ClusterNameTagKey, Value: "example"},
+				{Key: "cost-center", Value: "1234"},
+				{Key: "org", Value: "payments"},
+			},
+		},
+		{
+			name: "When the owner has characters not allowed in tag values it should replace them",
+			hc: &hyperv1.HostedCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "example",
+					Annotations: map[string]string{hyperv1.ClusterOwnerAnnotation: "Payments Team <payments>"},
+				},
+			},
+			expected: []Tag{
+				{Key: ClusterNameTagKey, Value: "example"},
+				{Key: OwnerTagKey, Value: "Payments_Team__payments_"},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(ForHostedCluster(tc.hc).Tags(tc.mandatory)).To(Equal(tc.expected))
+		})
+	}
+}
+
+func TestForInfrastructure(t *testing.T) {
+	g := NewWithT(t)
+	identity := ForInfrastructure("example-abcde", "example", "", 0)
+	g.Expect(identity.Expiry).To(BeNil())
+	g.Expect(identity.Tags(nil)).To(Equal([]Tag{
+		{Key: InfraIDTagKey, Value: "example-abcde"},
+		{Key: ClusterNameTagKey, Value: "example"},
+	}))
+
+	identity = ForInfrastructure("example-abcde", "example", "", 8*time.Hour)
+	g.Expect(identity.Expiry).ToNot(BeNil())
+	g.Expect(time.Until(*identity.Expiry)).To(BeNumerically("~", 8*time.Hour, time.Minute))
+}
+
+func TestValidateMandatoryTags(t *testing.T) {
+	testCases := []struct {
+		name        string
+		tags        map[string]string
+		expectedErr string
+	}{
+		{
+			name: "When the tags are valid it should succeed",
+			tags: map[string]string{"cost-center": "1234", "org:unit": "payments@example.com"},
+		},
+		{
+			name:        "When a key is an identity tag key it should fail",
+			tags:        map[string]string{OwnerTagKey: "payments"},
+			expectedErr: "reserved for the identity tags",
+		},
+		{
+			name:        "When a key has a slash it should fail",
+			tags:        map[string]string{"example.com/org": "payments"},
+			expectedErr: "invalid mandatory tag key",
+		},
+		{
+			name:        "When a value is empty it should fail",
+			tags:        map[string]string{"org": ""},
+			expectedErr: "invalid value",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := ValidateMandatoryTags(tc.tags)
+			if tc.expectedErr == "" {
+				g.Expect(err).ToNot(HaveOccurred())
+				return
+			}
+			g.Expect(err).To(MatchError(ContainSubstring(tc.expectedErr)))
+		})
+	}
+}
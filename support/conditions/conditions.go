@@ -30,6 +30,8 @@ func ExpectedHCConditions() map[hyperv1.ConditionType]metav1.ConditionStatus {
 		hyperv1.PlatformCredentialsFound:             metav1.ConditionTrue,
 		hyperv1.AWSEndpointAvailable:                 metav1.ConditionTrue,
 		hyperv1.AWSEndpointServiceAvailable:          metav1.ConditionTrue,
+		hyperv1.IngressAvailable:                     metav1.ConditionTrue,
+		hyperv1.HostedClusterHealthy:                 metav1.ConditionTrue,
 
 		hyperv1.HostedClusterProgressing:  metav1.ConditionFalse,
 		hyperv1.HostedClusterDegraded:     metav1.ConditionFalse,
@@ -49,8 +51,11 @@ func ExpectedHCConditions() map[hyperv1.ConditionType]metav1.ConditionStatus {
 		// ExternalDNSReachable could be set to ConditionUnknown if external DNS is not configured or HC is private.
 		hyperv1.ExternalDNSReachable: metav1.ConditionTrue,
 		// ValidAWSKMSConfig could be set to ConditionUnknown if KMS key/role are not configured.
-		hyperv1.ValidAWSKMSConfig:   metav1.ConditionTrue,
-		hyperv1.ValidAzureKMSConfig: metav1.ConditionTrue,
+		hyperv1.ValidAWSKMSConfig:      metav1.ConditionTrue,
+		hyperv1.ValidAzureKMSConfig:    metav1.ConditionTrue,
+		hyperv1.ValidIBMCloudKMSConfig: metav1.ConditionTrue,
+		// KMSDataReencrypted is only set if the secrets are encrypted with IBM Cloud KMS.
+		hyperv1.KMSDataReencrypted: metav1.ConditionTrue,
 	}
 }
 
@@ -0,0 +1,112 @@
+package conditions
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+// invalidCredentialsCodes are the error codes of AWS and Azure API calls that are rejected because of the
+// credentials, or their permissions.
+var invalidCredentialsCodes = map[string]bool{
+	// AWS
+	"AccessDenied":                true,
+	"AccessDeniedException":       true,
+	"AuthFailure":                 true,
+	"ExpiredToken":                true,
+	"ExpiredTokenException":       true,
+	"InvalidClientTokenId":        true,
+	"InvalidIdentityToken":        true,
+	"SignatureDoesNotMatch":       true,
+	"UnauthorizedOperation":       true,
+	"UnrecognizedClientException": true,
+	"WebIdentityErr":              true,
+	// Azure
+	"AuthenticationFailed":       true,
+	"AuthorizationFailed":        true,
+	"InvalidAuthenticationToken": true,
+	"LinkedAuthorizationFailed":  true,
+}
+
+// UnsupportedCombinationError is a validation error of a combination of options the platform, the release or the
+// management cluster doesn't support.
+type UnsupportedCombinationError struct {
+	message string
+}
+
+func (e *UnsupportedCombinationError) Error() string {
+	return e.message
+}
+
+// UnsupportedCombination returns an UnsupportedCombinationError with the formatted message.
+func UnsupportedCombination(format string, args ...interface{}) error {
+	return &UnsupportedCombinationError{message: fmt.Sprintf(format, args...)}
+}
+
+// ProviderErrorCode returns the error code of an AWS or Azure API error, or an empty string if err isn't one.
+func ProviderErrorCode(err error) string {
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) {
+		return awsErr.Code()
+	}
+	var azureErr *azcore.ResponseError
+	if errors.As(err, &azureErr) {
+		return azureErr.ErrorCode
+	}
+	return ""
+}
+
+// ReasonForError returns the condition reason for err: UnsupportedCombination for an UnsupportedCombinationError,
+// and InvalidCredentials, QuotaExceeded or InfraFailure for a cloud provider API error. The defaultReason is returned
+// for any other error. The first classified error of an aggregate determines its reason.
+func ReasonForError(err error, defaultReason string) string {
+	if err == nil {
+		return defaultReason
+	}
+	var aggregate utilerrors.Aggregate
+	if errors.As(err, &aggregate) {
+		for _, err := range aggregate.Errors() {
+			if reason := ReasonForError(err, ""); reason != "" {
+				return reason
+			}
+		}
+		return defaultReason
+	}
+
+	var unsupportedErr *UnsupportedCombinationError
+	if errors.As(err, &unsupportedErr) {
+		return hyperv1.UnsupportedCombinationReason
+	}
+	var authErr *azidentity.AuthenticationFailedError
+	if errors.As(err, &authErr) {
+		return hyperv1.InvalidCredentialsReason
+	}
+	var azureErr *azcore.ResponseError
+	if errors.As(err, &azureErr) && (azureErr.StatusCode == http.StatusUnauthorized || azureErr.StatusCode == http.StatusForbidden) {
+		return hyperv1.InvalidCredentialsReason
+	}
+
+	if code := ProviderErrorCode(err); code != "" {
+		return ReasonForProviderErrorCode(code)
+	}
+	return defaultReason
+}
+
+// ReasonForProviderErrorCode returns the condition reason for the error code of a failed AWS or Azure API call.
+func ReasonForProviderErrorCode(code string) string {
+	switch {
+	case invalidCredentialsCodes[code]:
+		return hyperv1.InvalidCredentialsReason
+	case strings.Contains(code, "LimitExceeded") || strings.Contains(code, "QuotaExceeded"):
+		return hyperv1.QuotaExceededReason
+	default:
+		return hyperv1.InfraFailureReason
+	}
+}
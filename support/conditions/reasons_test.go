@@ -0,0 +1,79 @@
+package conditions
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+func TestReasonForError(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{
+			name:     "When the error is nil it should return the default reason",
+			expected: "Default",
+		},
+		{
+			name:     "When the error is not classified it should return the default reason",
+			err:      errors.New("some error"),
+			expected: "Default",
+		},
+		{
+			name:     "When the AWS API rejects the credentials it should return InvalidCredentials",
+			err:      fmt.Errorf("failed to create endpoint: %w", awserr.New("UnauthorizedOperation", "not authorized", nil)),
+			expected: hyperv1.InvalidCredentialsReason,
+		},
+		{
+			name:     "When an AWS limit is exceeded it should return QuotaExceeded",
+			err:      awserr.New("VcpuLimitExceeded", "limit exceeded", nil),
+			expected: hyperv1.QuotaExceededReason,
+		},
+		{
+			name:     "When any other AWS API error is returned it should return InfraFailure",
+			err:      awserr.New("InvalidSubnetID.NotFound", "subnet not found", nil),
+			expected: hyperv1.InfraFailureReason,
+		},
+		{
+			name:     "When the Azure API returns forbidden it should return InvalidCredentials",
+			err:      &azcore.ResponseError{StatusCode: http.StatusForbidden, ErrorCode: "SomeCode"},
+			expected: hyperv1.InvalidCredentialsReason,
+		},
+		{
+			name:     "When an Azure quota is exceeded it should return QuotaExceeded",
+			err:      &azcore.ResponseError{StatusCode: http.StatusConflict, ErrorCode: "OperationNotAllowedQuotaExceeded"},
+			expected: hyperv1.QuotaExceededReason,
+		},
+		{
+			name:     "When the error is an unsupported combination it should return UnsupportedCombination",
+			err:      fmt.Errorf("invalid configuration: %w", UnsupportedCombination("option %s is not supported", "foo")),
+			expected: hyperv1.UnsupportedCombinationReason,
+		},
+		{
+			name:     "When an aggregate contains a classified error it should return its reason",
+			err:      utilerrors.NewAggregate([]error{errors.New("some error"), UnsupportedCombination("not supported")}),
+			expected: hyperv1.UnsupportedCombinationReason,
+		},
+		{
+			name:     "When an aggregate contains no classified error it should return the default reason",
+			err:      utilerrors.NewAggregate([]error{errors.New("some error"), errors.New("other error")}),
+			expected: "Default",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(ReasonForError(tc.err, "Default")).To(Equal(tc.expected))
+		})
+	}
+}
@@ -43,4 +43,10 @@ const (
 
 	DefaultIngressDomainEnvVar                    = "DEFAULT_INGRESS_DOMAIN"
 	EnableCVOManagementClusterMetricsAccessEnvVar = "ENABLE_CVO_MANAGEMENT_CLUSTER_METRICS_ACCESS"
+	PprofBindAddressEnvVar                        = "PPROF_BIND_ADDRESS"
+
+	// DefaultPprofBindAddress is the address the pprof endpoints of the control plane operators bind to when enabled.
+	// It's on the loopback interface so that they can only be reached by port forwarding to the pod.
+	DefaultPprofBindAddress = "127.0.0.1:6070"
+	DefaultPprofPort        = 6070
 )
@@ -2,6 +2,7 @@ package config
 
 import (
 	"strings"
+	"time"
 
 	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
 	"github.com/openshift/hypershift/support/util"
@@ -37,10 +38,13 @@ type DeploymentConfig struct {
 	Scheduling                Scheduling
 	AdditionalLabels          AdditionalLabels
 	AdditionalAnnotations     AdditionalAnnotations
+	ControlPlaneLabels        AdditionalLabels
+	ControlPlaneAnnotations   AdditionalAnnotations
 	SecurityContexts          SecurityContextSpec
 	SetDefaultSecurityContext bool
 	LivenessProbes            LivenessProbes
 	ReadinessProbes           ReadinessProbes
+	StartupProbes             StartupProbes
 	Resources                 ResourcesSpec
 	DebugDeployments          sets.String
 	ResourceRequestOverrides  ResourceOverrides
@@ -48,6 +52,17 @@ type DeploymentConfig struct {
 	RevisionHistoryLimit      int
 
 	AdditionalRequestServingNodeSelector map[string]string
+
+	// StartupBudget is how long the containers with a liveness probe may take to start before they are restarted.
+	// DefaultStartupBudget is used when it's zero.
+	StartupBudget time.Duration
+}
+
+func (c *DeploymentConfig) startupBudget() time.Duration {
+	if c.StartupBudget == 0 {
+		return DefaultStartupBudget
+	}
+	return c.StartupBudget
 }
 
 func (c *DeploymentConfig) SetContainerResourcesIfPresent(container *corev1.Container) {
@@ -130,10 +145,13 @@ func (c *DeploymentConfig) ApplyTo(deployment *appsv1.Deployment) {
 	}
 
 	c.Scheduling.ApplyTo(&deployment.Spec.Template.Spec)
+	c.applyControlPlaneMetadata(&deployment.Spec.Template.ObjectMeta, deployment.Spec.Selector)
 	c.AdditionalLabels.ApplyTo(&deployment.Spec.Template.ObjectMeta)
 	c.SecurityContexts.ApplyTo(&deployment.Spec.Template.Spec)
 	c.LivenessProbes.ApplyTo(&deployment.Spec.Template.Spec)
 	c.ReadinessProbes.ApplyTo(&deployment.Spec.Template.Spec)
+	c.StartupProbes.ApplyTo(&deployment.Spec.Template.Spec)
+	StandardizeProbes(&deployment.Spec.Template.Spec, c.startupBudget())
 	c.Resources.ApplyTo(&deployment.Spec.Template.Spec)
 	c.ResourceRequestOverrides.ApplyRequestsTo(deployment.Name, &deployment.Spec.Template.Spec)
 	c.AdditionalAnnotations.ApplyTo(&deployment.Spec.Template.ObjectMeta)
@@ -142,10 +160,13 @@ func (c *DeploymentConfig) ApplyTo(deployment *appsv1.Deployment) {
 func (c *DeploymentConfig) ApplyToDaemonSet(daemonset *appsv1.DaemonSet) {
 	// replicas is not used for DaemonSets
 	c.Scheduling.ApplyTo(&daemonset.Spec.Template.Spec)
+	c.applyControlPlaneMetadata(&daemonset.Spec.Template.ObjectMeta, daemonset.Spec.Selector)
 	c.AdditionalLabels.ApplyTo(&daemonset.Spec.Template.ObjectMeta)
 	c.SecurityContexts.ApplyTo(&daemonset.Spec.Template.Spec)
 	c.LivenessProbes.ApplyTo(&daemonset.Spec.Template.Spec)
 	c.ReadinessProbes.ApplyTo(&daemonset.Spec.Template.Spec)
+	c.StartupProbes.ApplyTo(&daemonset.Spec.Template.Spec)
+	StandardizeProbes(&daemonset.Spec.Template.Spec, c.startupBudget())
 	c.Resources.ApplyTo(&daemonset.Spec.Template.Spec)
 	c.ResourceRequestOverrides.ApplyRequestsTo(daemonset.Name, &daemonset.Spec.Template.Spec)
 	c.AdditionalAnnotations.ApplyTo(&daemonset.Spec.Template.ObjectMeta)
@@ -154,15 +175,35 @@ func (c *DeploymentConfig) ApplyToDaemonSet(daemonset *appsv1.DaemonSet) {
 func (c *DeploymentConfig) ApplyToStatefulSet(sts *appsv1.StatefulSet) {
 	sts.Spec.Replicas = pointer.Int32(int32(c.Replicas))
 	c.Scheduling.ApplyTo(&sts.Spec.Template.Spec)
+	c.applyControlPlaneMetadata(&sts.Spec.Template.ObjectMeta, sts.Spec.Selector)
 	c.AdditionalLabels.ApplyTo(&sts.Spec.Template.ObjectMeta)
 	c.SecurityContexts.ApplyTo(&sts.Spec.Template.Spec)
 	c.LivenessProbes.ApplyTo(&sts.Spec.Template.Spec)
 	c.ReadinessProbes.ApplyTo(&sts.Spec.Template.Spec)
+	c.StartupProbes.ApplyTo(&sts.Spec.Template.Spec)
+	StandardizeProbes(&sts.Spec.Template.Spec, c.startupBudget())
 	c.Resources.ApplyTo(&sts.Spec.Template.Spec)
 	c.ResourceRequestOverrides.ApplyRequestsTo(sts.Name, &sts.Spec.Template.Spec)
 	c.AdditionalAnnotations.ApplyTo(&sts.Spec.Template.ObjectMeta)
 }
 
+// applyControlPlaneMetadata sets the labels and annotations from the HostedControlPlane spec on a pod template.
+// Labels used by the workload selector are never overridden, so the workload keeps selecting its pods.
+func (c *DeploymentConfig) applyControlPlaneMetadata(podMeta *metav1.ObjectMeta, selector *metav1.LabelSelector) {
+	for key, value := range c.ControlPlaneLabels {
+		if selector != nil {
+			if _, isSelectorLabel := selector.MatchLabels[key]; isSelectorLabel {
+				continue
+			}
+		}
+		if podMeta.Labels == nil {
+			podMeta.Labels = map[string]string{}
+		}
+		podMeta.Labels[key] = value
+	}
+	c.ControlPlaneAnnotations.ApplyTo(podMeta)
+}
+
 func clusterKey(hcp *hyperv1.HostedControlPlane) string {
 	return hcp.Namespace
 }
@@ -395,6 +436,8 @@ func (c *DeploymentConfig) SetDefaults(hcp *hyperv1.HostedControlPlane, multiZon
 	c.RevisionHistoryLimit = 2
 
 	c.setLocation(hcp, multiZoneSpreadLabels)
+	c.ControlPlaneLabels = hcp.Spec.Labels
+	c.ControlPlaneAnnotations = hcp.Spec.Annotations
 	// TODO (alberto): make this private, atm is needed for the konnectivity agent daemonset.
 	c.SetReleaseImageAnnotation(util.HCPControlPlaneReleaseImage(hcp))
 }
@@ -499,3 +499,29 @@ func TestApplyTo(t *testing.T) {
 		})
 	}
 }
+
+func TestApplyControlPlaneMetadata(t *testing.T) {
+	g := NewWithT(t)
+	hcp := &hyperv1.HostedControlPlane{
+		Spec: hyperv1.HostedControlPlaneSpec{
+			Labels:      map[string]string{"cost-center": "1234", "app": "policy"},
+			Annotations: map[string]string{"policy.example.com/tier": "gold"},
+		},
+	}
+	cfg := &DeploymentConfig{}
+	cfg.SetDefaults(hcp, nil, nil)
+
+	deployment := &appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "kube-apiserver"}},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "kube-apiserver"}},
+			},
+		},
+	}
+	cfg.ApplyTo(deployment)
+
+	g.Expect(deployment.Spec.Template.Labels).To(HaveKeyWithValue("cost-center", "1234"))
+	g.Expect(deployment.Spec.Template.Labels).To(HaveKeyWithValue("app", "kube-apiserver"), "selector labels must not be overridden")
+	g.Expect(deployment.Spec.Template.Annotations).To(HaveKeyWithValue("policy.example.com/tier", "gold"))
+}
@@ -0,0 +1,267 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// ControlPlaneExtensionsKey is the key of the control plane extensions ConfigMap holding the extensions.
+	ControlPlaneExtensionsKey = "extensions.yaml"
+
+	// appliedExtensionsAnnotation records on a pod template the containers, volumes and environment variables
+	// injected by the control plane extensions, so they are removed once they are no longer configured.
+	appliedExtensionsAnnotation = "hypershift.openshift.io/applied-control-plane-extensions"
+)
+
+// ControlPlaneExtensions are the sidecars, environment variables and volumes providers inject into the control plane
+// Deployments and StatefulSets, configured by the control plane extensions ConfigMap.
+type ControlPlaneExtensions struct {
+	Workloads []WorkloadExtension `json:"workloads"`
+}
+
+// WorkloadExtension extends the pod template of the named workloads.
+type WorkloadExtension struct {
+	// Names are the names of the Deployments and StatefulSets extended.
+	Names []string `json:"names"`
+	// Sidecars are containers added to the pods.
+	Sidecars []corev1.Container `json:"sidecars,omitempty"`
+	// Volumes are volumes added to the pods, e.g. for the sidecars.
+	Volumes []corev1.Volume `json:"volumes,omitempty"`
+	// Env are environment variables added to the containers named in Containers, or to all the containers of the
+	// workload when empty. Variables a container already defines are never overridden.
+	Env        []corev1.EnvVar `json:"env,omitempty"`
+	Containers []string        `json:"containers,omitempty"`
+}
+
+type appliedExtensions struct {
+	Containers []string `json:"containers,omitempty"`
+	Volumes    []string `json:"volumes,omitempty"`
+	// Env are the names of the environment variables injected, keyed by container name.
+	Env map[string][]string `json:"env,omitempty"`
+}
+
+// ControlPlaneExtensionsFrom parses and validates the extensions of the control plane extensions ConfigMap.
+func ControlPlaneExtensionsFrom(configMap *corev1.ConfigMap) (*ControlPlaneExtensions, error) {
+	data, ok := configMap.Data[ControlPlaneExtensionsKey]
+	if !ok {
+		return nil, fmt.Errorf("the %s key is missing", ControlPlaneExtensionsKey)
+	}
+	extensions := &ControlPlaneExtensions{}
+	if err := yaml.UnmarshalStrict([]byte(data), extensions); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", ControlPlaneExtensionsKey, err)
+	}
+	if err := extensions.validate(); err != nil {
+		return nil, err
+	}
+	return extensions, nil
+}
+
+// validate enforces the guardrails of the extensions: sidecars can't run privileged or gain capabilities, volumes
+// can't mount paths of the management cluster nodes, and names injected in the same workload must be unique.
+func (e *ControlPlaneExtensions) validate() error {
+	containersByWorkload := map[string]sets.String{}
+	volumesByWorkload := map[string]sets.String{}
+	for i, workload := range e.Workloads {
+		if len(workload.Names) == 0 {
+			return fmt.Errorf("workloads[%d]: names must not be empty", i)
+		}
+		for _, name := range workload.Names {
+			if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+				return fmt.Errorf("workloads[%d]: invalid workload name %q: %v", i, name, errs)
+			}
+			if containersByWorkload[name] == nil {
+				containersByWorkload[name] = sets.NewString()
+				volumesByWorkload[name] = sets.NewString()
+			}
+		}
+		for _, sidecar := range workload.Sidecars {
+			if errs := validation.IsDNS1123Label(sidecar.Name); len(errs) > 0 {
+				return fmt.Errorf("workloads[%d]: invalid sidecar name %q: %v", i, sidecar.Name, errs)
+			}
+			if sidecar.Image == "" {
+				return fmt.Errorf("workloads[%d]: sidecar %s has no image", i, sidecar.Name)
+			}
+			if sc := sidecar.SecurityContext; sc != nil {
+				if sc.Privileged != nil && *sc.Privileged {
+					return fmt.Errorf("workloads[%d]: sidecar %s must not be privileged", i, sidecar.Name)
+				}
+				if sc.AllowPrivilegeEscalation != nil && *sc.AllowPrivilegeEscalation {
+					return fmt.Errorf("workloads[%d]: sidecar %s must not allow privilege escalation", i, sidecar.Name)
+				}
+				if sc.Capabilities != nil && len(sc.Capabilities.Add) > 0 {
+					return fmt.Errorf("workloads[%d]: sidecar %s must not add capabilities", i, sidecar.Name)
+				}
+			}
+			for _, name := range workload.Names {
+				if containersByWorkload[name].Has(sidecar.Name) {
+					return fmt.Errorf("workloads[%d]: sidecar %s is injected more than once in %s", i, sidecar.Name, name)
+				}
+				containersByWorkload[name].Insert(sidecar.Name)
+			}
+		}
+		for _, volume := range workload.Volumes {
+			if errs := validation.IsDNS1123Label(volume.Name); len(errs) > 0 {
+				return fmt.Errorf("workloads[%d]: invalid volume name %q: %v", i, volume.Name, errs)
+			}
+			if volume.HostPath != nil {
+				return fmt.Errorf("workloads[%d]: volume %s must not be a hostPath volume", i, volume.Name)
+			}
+			for _, name := range workload.Names {
+				if volumesByWorkload[name].Has(volume.Name) {
+					return fmt.Errorf("workloads[%d]: volume %s is injected more than once in %s", i, volume.Name, name)
+				}
+				volumesByWorkload[name].Insert(volume.Name)
+			}
+		}
+		for _, env := range workload.Env {
+			if env.Name == "" {
+				return fmt.Errorf("workloads[%d]: environment variables must have a name", i)
+			}
+		}
+	}
+	return nil
+}
+
+// ApplyTo injects the extensions configured for the given Deployment or StatefulSet into its pod template, after
+// removing the ones injected previously. Sidecars and volumes named like the ones of the workload itself are
+// skipped, so extensions never replace what the control plane operator sets. Other objects are left untouched.
+func (e *ControlPlaneExtensions) ApplyTo(obj client.Object) {
+	var podTemplate *corev1.PodTemplateSpec
+	switch o := obj.(type) {
+	case *appsv1.Deployment:
+		podTemplate = &o.Spec.Template
+	case *appsv1.StatefulSet:
+		podTemplate = &o.Spec.Template
+	default:
+		return
+	}
+	removeAppliedExtensions(podTemplate)
+	if e == nil {
+		return
+	}
+
+	podSpec := &podTemplate.Spec
+	applied := appliedExtensions{}
+	workloadContainers := len(podSpec.Containers)
+	for _, workload := range e.Workloads {
+		if !sets.NewString(workload.Names...).Has(obj.GetName()) {
+			continue
+		}
+		for _, env := range workload.Env {
+			for i := range podSpec.Containers[:workloadContainers] {
+				container := &podSpec.Containers[i]
+				if len(workload.Containers) > 0 && !sets.NewString(workload.Containers...).Has(container.Name) {
+					continue
+				}
+				if hasEnvVar(container, env.Name) {
+					continue
+				}
+				container.Env = append(container.Env, env)
+				if applied.Env == nil {
+					applied.Env = map[string][]string{}
+				}
+				applied.Env[container.Name] = append(applied.Env[container.Name], env.Name)
+			}
+		}
+		for _, volume := range workload.Volumes {
+			if hasVolume(podSpec, volume.Name) {
+				continue
+			}
+			podSpec.Volumes = append(podSpec.Volumes, volume)
+			applied.Volumes = append(applied.Volumes, volume.Name)
+		}
+		for _, sidecar := range workload.Sidecars {
+			if hasContainer(podSpec, sidecar.Name) {
+				continue
+			}
+			podSpec.Containers = append(podSpec.Containers, sidecar)
+			applied.Containers = append(applied.Containers, sidecar.Name)
+		}
+	}
+
+	if len(applied.Containers) == 0 && len(applied.Volumes) == 0 && len(applied.Env) == 0 {
+		return
+	}
+	value, err := json.Marshal(applied)
+	if err != nil {
+		return
+	}
+	if podTemplate.Annotations == nil {
+		podTemplate.Annotations = map[string]string{}
+	}
+	podTemplate.Annotations[appliedExtensionsAnnotation] = string(value)
+}
+
+// removeAppliedExtensions removes the containers, volumes and environment variables recorded as injected in the pod
+// template.
+func removeAppliedExtensions(podTemplate *corev1.PodTemplateSpec) {
+	value, ok := podTemplate.Annotations[appliedExtensionsAnnotation]
+	if !ok {
+		return
+	}
+	delete(podTemplate.Annotations, appliedExtensionsAnnotation)
+	applied := appliedExtensions{}
+	if err := json.Unmarshal([]byte(value), &applied); err != nil {
+		return
+	}
+
+	podSpec := &podTemplate.Spec
+	containers := sets.NewString(applied.Containers...)
+	filteredContainers := podSpec.Containers[:0]
+	for _, container := range podSpec.Containers {
+		if !containers.Has(container.Name) {
+			filteredContainers = append(filteredContainers, container)
+		}
+	}
+	podSpec.Containers = filteredContainers
+
+	volumes := sets.NewString(applied.Volumes...)
+	filteredVolumes := podSpec.Volumes[:0]
+	for _, volume := range podSpec.Volumes {
+		if !volumes.Has(volume.Name) {
+			filteredVolumes = append(filteredVolumes, volume)
+		}
+	}
+	podSpec.Volumes = filteredVolumes
+
+	for i := range podSpec.Containers {
+		container := &podSpec.Containers[i]
+		env := sets.NewString(applied.Env[container.Name]...)
+		if env.Len() == 0 {
+			continue
+		}
+		filteredEnv := container.Env[:0]
+		for _, envVar := range container.Env {
+			if !env.Has(envVar.Name) {
+				filteredEnv = append(filteredEnv, envVar)
+			}
+		}
+		container.Env = filteredEnv
+	}
+}
+
+func hasEnvVar(container *corev1.Container, name string) bool {
+	for _, env := range container.Env {
+		if env.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func hasContainer(podSpec *corev1.PodSpec, name string) bool {
+	for _, container := range podSpec.Containers {
+		if container.Name == name {
+			return true
+		}
+	}
+	return false
+}
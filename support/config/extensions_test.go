@@ -0,0 +1,180 @@
+package config
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+func TestControlPlaneExtensionsFrom(t *testing.T) {
+	testCases := []struct {
+		name          string
+		data          map[string]string
+		expected      *ControlPlaneExtensions
+		expectedError string
+	}{
+		{
+			name: "When the extensions are valid it should parse them",
+			data: map[string]string{ControlPlaneExtensionsKey: `
+workloads:
+- names: [kube-apiserver]
+  sidecars:
+  - name: audit-forwarder
+    image: example.com/audit-forwarder:latest
+  env:
+  - name: HTTP_PROXY
+    value: http://proxy:3128
+`},
+			expected: &ControlPlaneExtensions{Workloads: []WorkloadExtension{{
+				Names:    []string{"kube-apiserver"},
+				Sidecars: []corev1.Container{{Name: "audit-forwarder", Image: "example.com/audit-forwarder:latest"}},
+				Env:      []corev1.EnvVar{{Name: "HTTP_PROXY", Value: "http://proxy:3128"}},
+			}}},
+		},
+		{
+			name:          "When the extensions key is missing it should fail",
+			data:          map[string]string{},
+			expectedError: "the extensions.yaml key is missing",
+		},
+		{
+			name:          "When an unknown field is set it should fail",
+			data:          map[string]string{ControlPlaneExtensionsKey: "workloads:\n- names: [etcd]\n  initContainers: []\n"},
+			expectedError: "failed to parse extensions.yaml",
+		},
+		{
+			name:          "When a workload has no names it should fail",
+			data:          map[string]string{ControlPlaneExtensionsKey: "workloads:\n- env:\n  - name: FOO\n"},
+			expectedError: "names must not be empty",
+		},
+		{
+			name:          "When a sidecar has no image it should fail",
+			data:          map[string]string{ControlPlaneExtensionsKey: "workloads:\n- names: [etcd]\n  sidecars:\n  - name: agent\n"},
+			expectedError: "sidecar agent has no image",
+		},
+		{
+			name:          "When a sidecar is privileged it should fail",
+			data:          map[string]string{ControlPlaneExtensionsKey: "workloads:\n- names: [etcd]\n  sidecars:\n  - name: agent\n    image: agent\n    securityContext:\n      privileged: true\n"},
+			expectedError: "sidecar agent must not be privileged",
+		},
+		{
+			name:          "When a sidecar adds capabilities it should fail",
+			data:          map[string]string{ControlPlaneExtensionsKey: "workloads:\n- names: [etcd]\n  sidecars:\n  - name: agent\n    image: agent\n    securityContext:\n      capabilities:\n        add: [NET_ADMIN]\n"},
+			expectedError: "sidecar agent must not add capabilities",
+		},
+		{
+			name:          "When a volume is a hostPath volume it should fail",
+			data:          map[string]string{ControlPlaneExtensionsKey: "workloads:\n- names: [etcd]\n  volumes:\n  - name: host\n    hostPath:\n      path: /var\n"},
+			expectedError: "volume host must not be a hostPath volume",
+		},
+		{
+			name:          "When a sidecar is injected twice in a workload it should fail",
+			data:          map[string]string{ControlPlaneExtensionsKey: "workloads:\n- names: [etcd]\n  sidecars:\n  - name: agent\n    image: agent\n- names: [etcd, kube-apiserver]\n  sidecars:\n  - name: agent\n    image: agent\n"},
+			expectedError: "sidecar agent is injected more than once in etcd",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			extensions, err := ControlPlaneExtensionsFrom(&corev1.ConfigMap{Data: tc.data})
+			if tc.expectedError != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tc.expectedError)))
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(extensions).To(Equal(tc.expected))
+		})
+	}
+}
+
+func TestControlPlaneExtensionsApplyTo(t *testing.T) {
+	g := NewWithT(t)
+
+	extensions := &ControlPlaneExtensions{Workloads: []WorkloadExtension{
+		{
+			Names:    []string{"kube-apiserver"},
+			Sidecars: []corev1.Container{{Name: "audit-forwarder", Image: "audit-forwarder"}, {Name: "kube-apiserver", Image: "override"}},
+			Volumes:  []corev1.Volume{{Name: "forwarder-config", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}},
+			Env:      []corev1.EnvVar{{Name: "HTTP_PROXY", Value: "http://proxy:3128"}, {Name: "LOG_LEVEL", Value: "debug"}},
+		},
+		{
+			Names: []string{"kube-apiserver"},
+			Env:   []corev1.EnvVar{{Name: "TRACING", Value: "true"}},
+			// Only the kube-apiserver container is extended, not the injected sidecar nor the konnectivity one.
+			Containers: []string{"kube-apiserver"},
+		},
+		{
+			Names: []string{"etcd"},
+			Env:   []corev1.EnvVar{{Name: "HTTP_PROXY", Value: "http://proxy:3128"}},
+		},
+	}}
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "kube-apiserver"},
+		Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "kube-apiserver", Image: "kas", Env: []corev1.EnvVar{{Name: "LOG_LEVEL", Value: "info"}}},
+				{Name: "konnectivity-server", Image: "konnectivity"},
+			},
+		}}},
+	}
+	original := deployment.DeepCopy()
+
+	extensions.ApplyTo(deployment)
+	podSpec := deployment.Spec.Template.Spec
+	g.Expect(podSpec.Containers).To(HaveLen(3))
+	g.Expect(podSpec.Containers[0].Image).To(Equal("kas"))
+	g.Expect(podSpec.Containers[0].Env).To(Equal([]corev1.EnvVar{
+		{Name: "LOG_LEVEL", Value: "info"},
+		{Name: "HTTP_PROXY", Value: "http://proxy:3128"},
+		{Name: "TRACING", Value: "true"},
+	}))
+	g.Expect(podSpec.Containers[1].Env).To(Equal([]corev1.EnvVar{
+		{Name: "HTTP_PROXY", Value: "http://proxy:3128"},
+		{Name: "LOG_LEVEL", Value: "debug"},
+	}))
+	g.Expect(podSpec.Containers[2].Name).To(Equal("audit-forwarder"))
+	g.Expect(podSpec.Containers[2].Env).To(BeEmpty())
+	g.Expect(podSpec.Volumes).To(Equal(extensions.Workloads[0].Volumes))
+
+	// Reapplying the extensions to the mutated object must be stable.
+	applied := deployment.DeepCopy()
+	extensions.ApplyTo(deployment)
+	g.Expect(deployment).To(Equal(applied))
+
+	// Removing the extensions restores the workload, keeping the variables it defines itself.
+	var none *ControlPlaneExtensions
+	none.ApplyTo(deployment)
+	g.Expect(deployment.Spec.Template.Spec.Containers).To(HaveLen(2))
+	g.Expect(deployment.Spec.Template.Spec.Containers[0]).To(Equal(original.Spec.Template.Spec.Containers[0]))
+	g.Expect(deployment.Spec.Template.Spec.Containers[1].Env).To(BeEmpty())
+	g.Expect(deployment.Spec.Template.Spec.Volumes).To(BeEmpty())
+	g.Expect(deployment.Spec.Template.Annotations).ToNot(HaveKey(appliedExtensionsAnnotation))
+}
+
+func TestControlPlaneExtensionsApplyToOtherWorkloads(t *testing.T) {
+	g := NewWithT(t)
+
+	extensions := &ControlPlaneExtensions{Workloads: []WorkloadExtension{{
+		Names:    []string{"kube-apiserver"},
+		Sidecars: []corev1.Container{{Name: "audit-forwarder", Image: "audit-forwarder"}},
+	}}}
+
+	statefulSet := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "etcd"},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: pointer.Int32(3),
+			Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "etcd"}}}},
+		},
+	}
+	expected := statefulSet.DeepCopy()
+	extensions.ApplyTo(statefulSet)
+	g.Expect(statefulSet).To(Equal(expected))
+
+	configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "kube-apiserver"}}
+	extensions.ApplyTo(configMap)
+	g.Expect(configMap.Data).To(BeNil())
+}
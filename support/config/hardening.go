@@ -0,0 +1,166 @@
+package config
+
+import (
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+)
+
+const (
+	// hardeningTmpVolumeName is the volume mounted at /tmp in hardened containers, whose root filesystem is read only.
+	hardeningTmpVolumeName = "hardening-tmp"
+	hardeningTmpMountPath  = "/tmp"
+
+	localhostSeccompProfilePrefix = "Localhost/"
+)
+
+// ControlPlaneHardening is the hardening applied to the pods of the control plane components, configured by the
+// hardening annotations of the HostedControlPlane.
+type ControlPlaneHardening struct {
+	SeccompProfile corev1.SeccompProfile
+	Exceptions     sets.String
+}
+
+// ControlPlaneHardeningFrom returns the hardening configured by the given HostedControlPlane annotations, or nil if
+// the Restricted hardening profile isn't selected.
+func ControlPlaneHardeningFrom(annotations map[string]string) *ControlPlaneHardening {
+	if annotations[hyperv1.ControlPlaneHardeningProfileAnnotation] != hyperv1.ControlPlaneHardeningProfileRestricted {
+		return nil
+	}
+
+	hardening := &ControlPlaneHardening{
+		SeccompProfile: corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+		Exceptions:     sets.NewString(),
+	}
+	if profile := annotations[hyperv1.ControlPlaneSeccompProfileAnnotation]; strings.HasPrefix(profile, localhostSeccompProfilePrefix) {
+		hardening.SeccompProfile = corev1.SeccompProfile{
+			Type:             corev1.SeccompProfileTypeLocalhost,
+			LocalhostProfile: pointer.String(strings.TrimPrefix(profile, localhostSeccompProfilePrefix)),
+		}
+	}
+	for _, name := range strings.Split(annotations[hyperv1.ControlPlaneHardeningExceptionsAnnotation], ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			hardening.Exceptions.Insert(name)
+		}
+	}
+	return hardening
+}
+
+// ApplyTo hardens the pod template of the given Deployment, StatefulSet or CronJob, unless it is an exception.
+// Other objects are left untouched.
+func (h *ControlPlaneHardening) ApplyTo(obj client.Object) {
+	if h == nil || h.Exceptions.Has(obj.GetName()) {
+		return
+	}
+	switch o := obj.(type) {
+	case *appsv1.Deployment:
+		h.applyToPodTemplate(&o.Spec.Template)
+	case *appsv1.StatefulSet:
+		h.applyToPodTemplate(&o.Spec.Template)
+	case *batchv1.CronJob:
+		h.applyToPodTemplate(&o.Spec.JobTemplate.Spec.Template)
+	}
+}
+
+func (h *ControlPlaneHardening) applyToPodTemplate(template *corev1.PodTemplateSpec) {
+	podSpec := &template.Spec
+	if podSpec.SecurityContext == nil {
+		podSpec.SecurityContext = &corev1.PodSecurityContext{}
+	}
+	podSpec.SecurityContext.RunAsNonRoot = pointer.Bool(true)
+	podSpec.SecurityContext.SeccompProfile = h.SeccompProfile.DeepCopy()
+
+	needsTmpVolume := false
+	for _, containers := range [][]corev1.Container{podSpec.InitContainers, podSpec.Containers} {
+		for i := range containers {
+			hardenContainer(&containers[i])
+			if !hasMountAt(&containers[i], hardeningTmpMountPath) {
+				containers[i].VolumeMounts = append(containers[i].VolumeMounts, corev1.VolumeMount{
+					Name:      hardeningTmpVolumeName,
+					MountPath: hardeningTmpMountPath,
+				})
+			}
+			if hasMount(&containers[i], hardeningTmpVolumeName) {
+				needsTmpVolume = true
+			}
+		}
+	}
+
+	// Containers still need a writable /tmp with a read only root filesystem.
+	if needsTmpVolume && !hasVolume(podSpec, hardeningTmpVolumeName) {
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+			Name:         hardeningTmpVolumeName,
+			VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+		})
+	}
+	if needsTmpVolume {
+		if template.Annotations == nil {
+			template.Annotations = map[string]string{}
+		}
+		safeToEvict := template.Annotations[PodSafeToEvictLocalVolumesKey]
+		if !sets.NewString(strings.Split(safeToEvict, ",")...).Has(hardeningTmpVolumeName) {
+			if safeToEvict != "" {
+				safeToEvict += ","
+			}
+			template.Annotations[PodSafeToEvictLocalVolumesKey] = safeToEvict + hardeningTmpVolumeName
+		}
+	}
+}
+
+func hardenContainer(container *corev1.Container) {
+	if container.SecurityContext == nil {
+		container.SecurityContext = &corev1.SecurityContext{}
+	}
+	securityContext := container.SecurityContext
+	securityContext.Privileged = pointer.Bool(false)
+	securityContext.AllowPrivilegeEscalation = pointer.Bool(false)
+	securityContext.ReadOnlyRootFilesystem = pointer.Bool(true)
+
+	// The restricted Pod Security Standard only allows adding the NET_BIND_SERVICE capability.
+	var add []corev1.Capability
+	if securityContext.Capabilities != nil {
+		for _, capability := range securityContext.Capabilities.Add {
+			if capability == "NET_BIND_SERVICE" {
+				add = append(add, capability)
+			}
+		}
+	}
+	securityContext.Capabilities = &corev1.Capabilities{
+		Add:  add,
+		Drop: []corev1.Capability{"ALL"},
+	}
+}
+
+func hasMountAt(container *corev1.Container, path string) bool {
+	for _, mount := range container.VolumeMounts {
+		if mount.MountPath == path {
+			return true
+		}
+	}
+	return false
+}
+
+func hasMount(container *corev1.Container, volumeName string) bool {
+	for _, mount := range container.VolumeMounts {
+		if mount.Name == volumeName {
+			return true
+		}
+	}
+	return false
+}
+
+func hasVolume(podSpec *corev1.PodSpec, name string) bool {
+	for _, volume := range podSpec.Volumes {
+		if volume.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,131 @@
+package config
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/pointer"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+)
+
+func TestControlPlaneHardeningFrom(t *testing.T) {
+	testCases := []struct {
+		name        string
+		annotations map[string]string
+		expected    *ControlPlaneHardening
+	}{
+		{
+			name:        "When no hardening profile is selected it should not harden",
+			annotations: map[string]string{},
+		},
+		{
+			name:        "When the None hardening profile is selected it should not harden",
+			annotations: map[string]string{hyperv1.ControlPlaneHardeningProfileAnnotation: hyperv1.ControlPlaneHardeningProfileNone},
+		},
+		{
+			name:        "When the Restricted hardening profile is selected it should default to the RuntimeDefault seccomp profile",
+			annotations: map[string]string{hyperv1.ControlPlaneHardeningProfileAnnotation: hyperv1.ControlPlaneHardeningProfileRestricted},
+			expected: &ControlPlaneHardening{
+				SeccompProfile: corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+				Exceptions:     map[string]sets.Empty{},
+			},
+		},
+		{
+			name: "When a localhost seccomp profile and exceptions are set it should use them",
+			annotations: map[string]string{
+				hyperv1.ControlPlaneHardeningProfileAnnotation:    hyperv1.ControlPlaneHardeningProfileRestricted,
+				hyperv1.ControlPlaneSeccompProfileAnnotation:      "Localhost/profiles/control-plane.json",
+				hyperv1.ControlPlaneHardeningExceptionsAnnotation: "etcd, kube-apiserver,",
+			},
+			expected: &ControlPlaneHardening{
+				SeccompProfile: corev1.SeccompProfile{
+					Type:             corev1.SeccompProfileTypeLocalhost,
+					LocalhostProfile: pointer.String("profiles/control-plane.json"),
+				},
+				Exceptions: map[string]sets.Empty{"etcd": {}, "kube-apiserver": {}},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(ControlPlaneHardeningFrom(tc.annotations)).To(Equal(tc.expected))
+		})
+	}
+}
+
+func TestControlPlaneHardeningApplyTo(t *testing.T) {
+	deployment := func(name string) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						InitContainers: []corev1.Container{{Name: "init"}},
+						Containers: []corev1.Container{
+							{
+								Name: "main",
+								SecurityContext: &corev1.SecurityContext{
+									Capabilities: &corev1.Capabilities{Add: []corev1.Capability{"NET_ADMIN", "NET_BIND_SERVICE"}},
+								},
+							},
+							{
+								Name:         "sidecar",
+								VolumeMounts: []corev1.VolumeMount{{Name: "scratch", MountPath: "/tmp"}},
+							},
+						},
+						Volumes: []corev1.Volume{{Name: "scratch", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}},
+					},
+				},
+			},
+		}
+	}
+	hardening := ControlPlaneHardeningFrom(map[string]string{
+		hyperv1.ControlPlaneHardeningProfileAnnotation:    hyperv1.ControlPlaneHardeningProfileRestricted,
+		hyperv1.ControlPlaneHardeningExceptionsAnnotation: "etcd",
+	})
+
+	t.Run("When the deployment is not an exception it should harden its pods", func(t *testing.T) {
+		g := NewWithT(t)
+		hardened := deployment("kube-apiserver")
+		hardening.ApplyTo(hardened)
+		// Applying the hardening again should not change the deployment.
+		hardenedTwice := hardened.DeepCopy()
+		hardening.ApplyTo(hardenedTwice)
+		g.Expect(hardenedTwice).To(Equal(hardened))
+
+		podSpec := hardened.Spec.Template.Spec
+		g.Expect(podSpec.SecurityContext.RunAsNonRoot).To(Equal(pointer.Bool(true)))
+		g.Expect(podSpec.SecurityContext.SeccompProfile.Type).To(Equal(corev1.SeccompProfileTypeRuntimeDefault))
+		for _, container := range append(podSpec.InitContainers, podSpec.Containers...) {
+			g.Expect(container.SecurityContext.ReadOnlyRootFilesystem).To(Equal(pointer.Bool(true)), container.Name)
+			g.Expect(container.SecurityContext.AllowPrivilegeEscalation).To(Equal(pointer.Bool(false)), container.Name)
+			g.Expect(container.SecurityContext.Capabilities.Drop).To(ConsistOf(corev1.Capability("ALL")), container.Name)
+		}
+		g.Expect(podSpec.Containers[0].SecurityContext.Capabilities.Add).To(ConsistOf(corev1.Capability("NET_BIND_SERVICE")))
+
+		g.Expect(podSpec.InitContainers[0].VolumeMounts).To(ConsistOf(corev1.VolumeMount{Name: hardeningTmpVolumeName, MountPath: "/tmp"}))
+		g.Expect(podSpec.Containers[1].VolumeMounts).To(ConsistOf(corev1.VolumeMount{Name: "scratch", MountPath: "/tmp"}))
+		g.Expect(podSpec.Volumes).To(HaveLen(2))
+		g.Expect(hardened.Spec.Template.Annotations[PodSafeToEvictLocalVolumesKey]).To(Equal(hardeningTmpVolumeName))
+	})
+
+	t.Run("When the deployment is an exception it should not harden its pods", func(t *testing.T) {
+		g := NewWithT(t)
+		exception := deployment("etcd")
+		hardening.ApplyTo(exception)
+		g.Expect(exception).To(Equal(deployment("etcd")))
+	})
+
+	t.Run("When no hardening profile is selected it should not harden pods", func(t *testing.T) {
+		g := NewWithT(t)
+		notHardened := deployment("kube-apiserver")
+		ControlPlaneHardeningFrom(nil).ApplyTo(notHardened)
+		g.Expect(notHardened).To(Equal(deployment("kube-apiserver")))
+	})
+}
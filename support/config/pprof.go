@@ -0,0 +1,33 @@
+package config
+
+import (
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+)
+
+// PprofEnabledUntil returns until when the pprof endpoints are enabled by the EnablePprofUntilAnnotation of a
+// HostedCluster or HostedControlPlane, and false if they aren't enabled at the given time. A value which isn't an
+// RFC3339 time doesn't enable them.
+func PprofEnabledUntil(annotations map[string]string, now time.Time) (time.Time, bool) {
+	until, err := time.Parse(time.RFC3339, annotations[hyperv1.EnablePprofUntilAnnotation])
+	if err != nil || !now.Before(until) {
+		return time.Time{}, false
+	}
+	return until, true
+}
+
+// DeploymentAddPprofBindAddress makes the main container of the deployment serve the pprof endpoints on
+// DefaultPprofBindAddress while they are enabled by the given annotations.
+func DeploymentAddPprofBindAddress(annotations map[string]string, deployment *appsv1.Deployment) {
+	if _, enabled := PprofEnabledUntil(annotations, time.Now()); !enabled {
+		return
+	}
+	deployment.Spec.Template.Spec.Containers[0].Env = append(deployment.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{
+		Name:  PprofBindAddressEnvVar,
+		Value: DefaultPprofBindAddress,
+	})
+}
@@ -0,0 +1,77 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+)
+
+func TestPprofEnabledUntil(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name            string
+		annotations     map[string]string
+		expectedEnabled bool
+		expectedUntil   time.Time
+	}{
+		{
+			name:            "When the annotation isn't set it should not enable pprof",
+			expectedEnabled: false,
+		},
+		{
+			name:            "When the annotation is in the future it should enable pprof until then",
+			annotations:     map[string]string{hyperv1.EnablePprofUntilAnnotation: "2024-01-01T13:00:00Z"},
+			expectedEnabled: true,
+			expectedUntil:   now.Add(time.Hour),
+		},
+		{
+			name:            "When the annotation is in the past it should not enable pprof",
+			annotations:     map[string]string{hyperv1.EnablePprofUntilAnnotation: "2024-01-01T11:00:00Z"},
+			expectedEnabled: false,
+		},
+		{
+			name:            "When the annotation isn't a time it should not enable pprof",
+			annotations:     map[string]string{hyperv1.EnablePprofUntilAnnotation: "true"},
+			expectedEnabled: false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			until, enabled := PprofEnabledUntil(tc.annotations, now)
+			g.Expect(enabled).To(Equal(tc.expectedEnabled))
+			g.Expect(until.Equal(tc.expectedUntil)).To(BeTrue())
+		})
+	}
+}
+
+func TestDeploymentAddPprofBindAddress(t *testing.T) {
+	newDeployment := func() *appsv1.Deployment {
+		return &appsv1.Deployment{Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "main"}},
+		}}}}
+	}
+
+	t.Run("When pprof is enabled it should set the bind address of the main container", func(t *testing.T) {
+		g := NewWithT(t)
+		deployment := newDeployment()
+		DeploymentAddPprofBindAddress(map[string]string{
+			hyperv1.EnablePprofUntilAnnotation: time.Now().Add(time.Hour).Format(time.RFC3339),
+		}, deployment)
+		g.Expect(deployment.Spec.Template.Spec.Containers[0].Env).To(ConsistOf(corev1.EnvVar{Name: PprofBindAddressEnvVar, Value: DefaultPprofBindAddress}))
+	})
+
+	t.Run("When pprof has expired it should not set the bind address", func(t *testing.T) {
+		g := NewWithT(t)
+		deployment := newDeployment()
+		DeploymentAddPprofBindAddress(map[string]string{
+			hyperv1.EnablePprofUntilAnnotation: time.Now().Add(-time.Hour).Format(time.RFC3339),
+		}, deployment)
+		g.Expect(deployment.Spec.Template.Spec.Containers[0].Env).To(BeEmpty())
+	})
+}
@@ -1,9 +1,23 @@
 package config
 
 import (
+	"time"
+
 	corev1 "k8s.io/api/core/v1"
 )
 
+// Standard probe settings of the control plane components. They are used for the settings the probes of a component
+// leave unset, so that all components are taken out of service and restarted on the same terms.
+const (
+	DefaultProbePeriodSeconds    = 10
+	DefaultProbeTimeoutSeconds   = 5
+	DefaultProbeSuccessThreshold = 1
+	DefaultProbeFailureThreshold = 3
+
+	// DefaultStartupBudget is how long a container with a liveness probe may take to start before it's restarted.
+	DefaultStartupBudget = 5 * time.Minute
+)
+
 type LivenessProbes map[string]corev1.Probe
 
 func (p LivenessProbes) ApplyTo(podSpec *corev1.PodSpec) {
@@ -37,3 +51,67 @@ func (p ReadinessProbes) ApplyToContainer(container string, c *corev1.Container)
 		c.ReadinessProbe = &probe
 	}
 }
+
+type StartupProbes map[string]corev1.Probe
+
+func (p StartupProbes) ApplyTo(podSpec *corev1.PodSpec) {
+	for i, c := range podSpec.InitContainers {
+		p.ApplyToContainer(c.Name, &podSpec.InitContainers[i])
+	}
+	for i, c := range podSpec.Containers {
+		p.ApplyToContainer(c.Name, &podSpec.Containers[i])
+	}
+}
+
+func (p StartupProbes) ApplyToContainer(container string, c *corev1.Container) {
+	if probe, ok := p[c.Name]; ok {
+		c.StartupProbe = &probe
+	}
+}
+
+// StandardizeProbes sets the standard settings the probes of the containers leave unset. Containers with a liveness
+// probe but no startup probe get one checking the same endpoint, which gives them the larger of the startup budget
+// and the initial delay of their liveness probe to start. The liveness probe then no longer needs an initial delay,
+// so that a container that stops responding after it started is restarted without waiting for it.
+func StandardizeProbes(podSpec *corev1.PodSpec, startupBudget time.Duration) {
+	for i := range podSpec.Containers {
+		standardizeContainerProbes(&podSpec.Containers[i], startupBudget)
+	}
+}
+
+func standardizeContainerProbes(c *corev1.Container, startupBudget time.Duration) {
+	if c.LivenessProbe != nil && c.StartupProbe == nil {
+		budget := startupBudget
+		if initialDelay := time.Duration(c.LivenessProbe.InitialDelaySeconds) * time.Second; initialDelay > budget {
+			budget = initialDelay
+		}
+		c.StartupProbe = &corev1.Probe{
+			ProbeHandler:     *c.LivenessProbe.ProbeHandler.DeepCopy(),
+			TimeoutSeconds:   c.LivenessProbe.TimeoutSeconds,
+			PeriodSeconds:    DefaultProbePeriodSeconds,
+			SuccessThreshold: DefaultProbeSuccessThreshold,
+			FailureThreshold: int32((budget + DefaultProbePeriodSeconds*time.Second - 1) / (DefaultProbePeriodSeconds * time.Second)),
+		}
+		c.LivenessProbe.InitialDelaySeconds = 0
+	}
+	for _, probe := range []*corev1.Probe{c.LivenessProbe, c.ReadinessProbe, c.StartupProbe} {
+		if probe != nil {
+			defaultProbeSettings(probe)
+		}
+	}
+}
+
+func defaultProbeSettings(probe *corev1.Probe) {
+	if probe.PeriodSeconds == 0 {
+		probe.PeriodSeconds = DefaultProbePeriodSeconds
+	}
+	if probe.TimeoutSeconds == 0 {
+		probe.TimeoutSeconds = DefaultProbeTimeoutSeconds
+	}
+	if probe.SuccessThreshold == 0 {
+		probe.SuccessThreshold = DefaultProbeSuccessThreshold
+	}
+	if probe.FailureThreshold == 0 {
+		probe.FailureThreshold = DefaultProbeFailureThreshold
+	}
+}
@@ -0,0 +1,71 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestStandardizeProbes(t *testing.T) {
+	handler := corev1.ProbeHandler{
+		HTTPGet: &corev1.HTTPGetAction{Path: "healthz", Port: intstr.FromInt(8080), Scheme: corev1.URISchemeHTTPS},
+	}
+
+	testCases := []struct {
+		name      string
+		container corev1.Container
+		expected  corev1.Container
+	}{
+		{
+			name: "When a container has a liveness probe it should get a startup probe with the startup budget",
+			container: corev1.Container{
+				LivenessProbe: &corev1.Probe{ProbeHandler: handler, InitialDelaySeconds: 60, PeriodSeconds: 30, TimeoutSeconds: 10, FailureThreshold: 5},
+			},
+			expected: corev1.Container{
+				LivenessProbe: &corev1.Probe{ProbeHandler: handler, PeriodSeconds: 30, TimeoutSeconds: 10, SuccessThreshold: 1, FailureThreshold: 5},
+				StartupProbe:  &corev1.Probe{ProbeHandler: handler, PeriodSeconds: 10, TimeoutSeconds: 10, SuccessThreshold: 1, FailureThreshold: 30},
+			},
+		},
+		{
+			name: "When the liveness probe initial delay is longer than the startup budget it should be the budget of the startup probe",
+			container: corev1.Container{
+				LivenessProbe: &corev1.Probe{ProbeHandler: handler, InitialDelaySeconds: 600},
+			},
+			expected: corev1.Container{
+				LivenessProbe: &corev1.Probe{ProbeHandler: handler, PeriodSeconds: 10, TimeoutSeconds: 5, SuccessThreshold: 1, FailureThreshold: 3},
+				StartupProbe:  &corev1.Probe{ProbeHandler: handler, PeriodSeconds: 10, TimeoutSeconds: 5, SuccessThreshold: 1, FailureThreshold: 60},
+			},
+		},
+		{
+			name: "When a container has a startup probe it should be kept",
+			container: corev1.Container{
+				LivenessProbe: &corev1.Probe{ProbeHandler: handler, InitialDelaySeconds: 60},
+				StartupProbe:  &corev1.Probe{ProbeHandler: handler, FailureThreshold: 18},
+			},
+			expected: corev1.Container{
+				LivenessProbe: &corev1.Probe{ProbeHandler: handler, InitialDelaySeconds: 60, PeriodSeconds: 10, TimeoutSeconds: 5, SuccessThreshold: 1, FailureThreshold: 3},
+				StartupProbe:  &corev1.Probe{ProbeHandler: handler, PeriodSeconds: 10, TimeoutSeconds: 5, SuccessThreshold: 1, FailureThreshold: 18},
+			},
+		},
+		{
+			name: "When a container only has a readiness probe it should only get the standard settings",
+			container: corev1.Container{
+				ReadinessProbe: &corev1.Probe{ProbeHandler: handler, PeriodSeconds: 5},
+			},
+			expected: corev1.Container{
+				ReadinessProbe: &corev1.Probe{ProbeHandler: handler, PeriodSeconds: 5, TimeoutSeconds: 5, SuccessThreshold: 1, FailureThreshold: 3},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			podSpec := &corev1.PodSpec{Containers: []corev1.Container{tc.container}}
+			StandardizeProbes(podSpec, 5*time.Minute)
+			g.Expect(podSpec.Containers[0]).To(Equal(tc.expected))
+		})
+	}
+}
@@ -23,7 +23,8 @@ func ReconcileDNSConfig(dns *configv1.DNS, hcp *hyperv1.HostedControlPlane) {
 	} else {
 		dns.Spec.BaseDomain = BaseDomain(hcp)
 	}
-	if len(hcp.Spec.DNS.PublicZoneID) > 0 {
+	// No records are created in the public zone when the DNS publishing is internal
+	if len(hcp.Spec.DNS.PublicZoneID) > 0 && hcp.Spec.DNS.Publishing != hyperv1.InternalDNSPublishing {
 		dns.Spec.PublicZone = &configv1.DNSZone{
 			ID: hcp.Spec.DNS.PublicZoneID,
 		}
@@ -52,6 +52,34 @@ func TestReconcileDNSConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:           "when the DNS publishing is internal then the public zone is not set on the DNS object",
+			inputDNSConfig: DNSConfig(),
+			inputHCP: &hyperv1.HostedControlPlane{
+				ObjectMeta: v1.ObjectMeta{
+					Name: fakeHCPName,
+				},
+				Spec: hyperv1.HostedControlPlaneSpec{
+					DNS: hyperv1.DNSSpec{
+						BaseDomain:    fakeBaseDomain,
+						PrivateZoneID: fakePrivateZoneID,
+						PublicZoneID:  fakePublicZoneID,
+						Publishing:    hyperv1.InternalDNSPublishing,
+					},
+				},
+			},
+			expectedDNSConfig: &configv1.DNS{
+				ObjectMeta: v1.ObjectMeta{
+					Name: "cluster",
+				},
+				Spec: configv1.DNSSpec{
+					BaseDomain: fmt.Sprintf("%s.%s", fakeHCPName, fakeBaseDomain),
+					PrivateZone: &configv1.DNSZone{
+						ID: fakePrivateZoneID,
+					},
+				},
+			},
+		},
 		{
 			name:           "when IBM Cloud platform is used then the base domain is set to the value on the HostedControlPlane",
 			inputDNSConfig: DNSConfig(),
@@ -31,7 +31,7 @@ func ReconcileInfrastructure(infra *configv1.Infrastructure, hcp *hyperv1.Hosted
 	infra.Spec.PlatformSpec.Type = configv1.PlatformType(platformType)
 	infra.Status.APIServerInternalURL = fmt.Sprintf("https://%s:%d", apiServerAddress, apiServerPort)
 	if util.IsPrivateHCP(hcp) {
-		infra.Status.APIServerInternalURL = fmt.Sprintf("https://api.%s.hypershift.local:%d", hcp.Name, apiServerPort)
+		infra.Status.APIServerInternalURL = fmt.Sprintf("https://%s:%d", util.InternalAPIHostname(hcp.Name, hcp.Spec.DNS), apiServerPort)
 	}
 
 	infra.Status.APIServerURL = fmt.Sprintf("https://%s:%d", apiServerAddress, apiServerPort)
@@ -69,6 +69,12 @@ func ReconcileInfrastructure(infra *configv1.Infrastructure, hcp *hyperv1.Hosted
 			})
 		}
 		infra.Status.PlatformStatus.AWS.ResourceTags = tags
+		// The operators of the guest cluster reach the AWS services through the endpoints of the status, e.g. in the
+		// GovCloud and China partitions.
+		for _, endpoint := range hcp.Spec.Platform.AWS.ServiceEndpoints {
+			infra.Spec.PlatformSpec.AWS.ServiceEndpoints = append(infra.Spec.PlatformSpec.AWS.ServiceEndpoints, configv1.AWSServiceEndpoint{Name: endpoint.Name, URL: endpoint.URL})
+			infra.Status.PlatformStatus.AWS.ServiceEndpoints = append(infra.Status.PlatformStatus.AWS.ServiceEndpoints, configv1.AWSServiceEndpoint{Name: endpoint.Name, URL: endpoint.URL})
+		}
 	case hyperv1.AzurePlatform:
 		infra.Spec.CloudConfig.Name = "cloud.conf"
 		infra.Status.PlatformStatus.Azure = &configv1.AzurePlatformStatus{
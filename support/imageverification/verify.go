@@ -0,0 +1,366 @@
+// Package imageverification verifies the signatures of images against the keys trusted by a HostedCluster image
+// verification policy.
+package imageverification
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/opencontainers/go-digest"
+	corev1 "k8s.io/api/core/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	"github.com/openshift/hypershift/support/releaseinfo/registryclient"
+	"github.com/openshift/hypershift/support/thirdparty/library-go/pkg/image/reference"
+)
+
+const (
+	// cosignSignatureAnnotation is the annotation of the cosign signature layers holding the signature of the layer.
+	cosignSignatureAnnotation = "dev.cosignproject.cosign/signature"
+
+	// maxStoreSignatures is the maximum number of signatures looked up per image in a GPG signature store.
+	maxStoreSignatures = 16
+
+	signatureStoreTimeout = 30 * time.Second
+)
+
+// Verifier verifies the signatures of images according to an image verification policy.
+type Verifier struct {
+	Type            hyperv1.ImageVerificationType
+	SignatureStores []string
+
+	// CosignKeys are the public keys trusted to sign images with the Cosign type.
+	CosignKeys []crypto.PublicKey
+	// GPGKeyring holds the keys trusted to sign images with the GPG type.
+	GPGKeyring openpgp.EntityList
+
+	HTTPClient *http.Client
+
+	// fetchCosignSignatures looks the signatures of the image up in its registry, and is replaced in tests.
+	fetchCosignSignatures func(ctx context.Context, imageRef string, imageDigest digest.Digest, pullSecret []byte) ([]cosignSignature, error)
+}
+
+// NotVerifiedError is returned when an image is not signed by a trusted key, as opposed to the errors looking up the
+// image signatures which may be transient.
+type NotVerifiedError struct {
+	err error
+}
+
+func (e *NotVerifiedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *NotVerifiedError) Unwrap() error {
+	return e.err
+}
+
+// IsNotVerified returns whether the error reports an image that is not signed by a trusted key, so retrying the
+// verification won't succeed until the image or the trusted keys change.
+func IsNotVerified(err error) bool {
+	var notVerified *NotVerifiedError
+	return errors.As(err, &notVerified)
+}
+
+// cosignSignature is a cosign signature layer: the signed simple signing payload and its signature.
+type cosignSignature struct {
+	payload   []byte
+	signature []byte
+}
+
+// simpleSigningPayload is the part of the signed payload of cosign and GPG signatures identifying the signed image.
+// https://github.com/containers/image/blob/main/docs/containers-signature.5.md
+type simpleSigningPayload struct {
+	Critical struct {
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+	} `json:"critical"`
+}
+
+// NewVerifier returns a Verifier for the given image verification policy, trusting the keys of the ConfigMap it
+// references in the given namespace.
+func NewVerifier(ctx context.Context, c crclient.Client, namespace string, policy *hyperv1.ImageVerificationPolicy) (*Verifier, error) {
+	publicKeys := &corev1.ConfigMap{}
+	if err := c.Get(ctx, crclient.ObjectKey{Namespace: namespace, Name: policy.PublicKeys.Name}, publicKeys); err != nil {
+		return nil, fmt.Errorf("failed to get the image verification public keys configmap %s: %w", policy.PublicKeys.Name, err)
+	}
+
+	// Keys are parsed in a stable order so the verification errors are deterministic.
+	var names []string
+	for name := range publicKeys.Data {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	verifier := &Verifier{
+		Type:            policy.Type,
+		SignatureStores: policy.SignatureStores,
+	}
+	for _, name := range names {
+		switch policy.Type {
+		case hyperv1.ImageVerificationTypeCosign:
+			key, err := parseCosignPublicKey([]byte(publicKeys.Data[name]))
+			if err != nil {
+				return nil, fmt.Errorf("invalid public key %s in configmap %s: %w", name, publicKeys.Name, err)
+			}
+			verifier.CosignKeys = append(verifier.CosignKeys, key)
+		case hyperv1.ImageVerificationTypeGPG:
+			keyring, err := openpgp.ReadArmoredKeyRing(strings.NewReader(publicKeys.Data[name]))
+			if err != nil {
+				return nil, fmt.Errorf("invalid keyring %s in configmap %s: %w", name, publicKeys.Name, err)
+			}
+			verifier.GPGKeyring = append(verifier.GPGKeyring, keyring...)
+		default:
+			return nil, fmt.Errorf("unsupported image verification type %q", policy.Type)
+		}
+	}
+	if len(verifier.CosignKeys) == 0 && len(verifier.GPGKeyring) == 0 {
+		return nil, fmt.Errorf("the image verification public keys configmap %s has no keys", publicKeys.Name)
+	}
+	return verifier, nil
+}
+
+// Verify returns an error unless the image is signed by one of the trusted keys. The image must be referenced by
+// digest, so that the image that is pulled is the one whose signature was verified.
+func (v *Verifier) Verify(ctx context.Context, imageRef string, pullSecret []byte) error {
+	ref, err := reference.Parse(imageRef)
+	if err != nil {
+		return &NotVerifiedError{err: fmt.Errorf("failed to parse image reference %q: %w", imageRef, err)}
+	}
+	if ref.ID == "" {
+		return &NotVerifiedError{err: fmt.Errorf("image %s must be referenced by digest to verify its signature", imageRef)}
+	}
+	imageDigest, err := digest.Parse(ref.ID)
+	if err != nil {
+		return &NotVerifiedError{err: fmt.Errorf("invalid digest of image %s: %w", imageRef, err)}
+	}
+
+	switch v.Type {
+	case hyperv1.ImageVerificationTypeCosign:
+		err = v.verifyCosign(ctx, imageRef, imageDigest, pullSecret)
+	case hyperv1.ImageVerificationTypeGPG:
+		err = v.verifyGPG(ctx, imageDigest)
+	default:
+		err = fmt.Errorf("unsupported image verification type %q", v.Type)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to verify the signature of image %s: %w", imageRef, err)
+	}
+	return nil
+}
+
+func (v *Verifier) verifyCosign(ctx context.Context, imageRef string, imageDigest digest.Digest, pullSecret []byte) error {
+	fetchCosignSignatures := v.fetchCosignSignatures
+	if fetchCosignSignatures == nil {
+		fetchCosignSignatures = registryCosignSignatures
+	}
+	signatures, err := fetchCosignSignatures(ctx, imageRef, imageDigest, pullSecret)
+	if err != nil {
+		return fmt.Errorf("failed to get cosign signatures: %w", err)
+	}
+	if len(signatures) == 0 {
+		return &NotVerifiedError{err: errors.New("no cosign signatures found")}
+	}
+
+	var errs []error
+	for _, signature := range signatures {
+		if !verifiesCosignSignature(v.CosignKeys, signature) {
+			errs = append(errs, errors.New("cosign signature is not signed by a trusted key"))
+			continue
+		}
+		if err := checkPayloadDigest(signature.payload, imageDigest); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		return nil
+	}
+	return &NotVerifiedError{err: utilerrors.NewAggregate(errs)}
+}
+
+func (v *Verifier) verifyGPG(ctx context.Context, imageDigest digest.Digest) error {
+	client := v.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: signatureStoreTimeout}
+	}
+
+	// The image is only reported as not verified when all the signature stores could be read, a store that can't be
+	// read may hold a trusted signature.
+	var errs []error
+	storesRead := true
+	for _, store := range v.SignatureStores {
+		for i := 1; i <= maxStoreSignatures; i++ {
+			signatureURL := fmt.Sprintf("%s/%s=%s/signature-%d", strings.TrimSuffix(store, "/"), imageDigest.Algorithm(), imageDigest.Encoded(), i)
+			signature, found, err := getStoreSignature(ctx, client, signatureURL)
+			if err != nil {
+				errs = append(errs, err)
+				storesRead = false
+				break
+			}
+			if !found {
+				break
+			}
+			if err := v.verifyGPGSignature(signature, imageDigest); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", signatureURL, err))
+				continue
+			}
+			return nil
+		}
+	}
+	if len(errs) == 0 {
+		return &NotVerifiedError{err: errors.New("no GPG signatures found in the signature stores")}
+	}
+	if storesRead {
+		return &NotVerifiedError{err: utilerrors.NewAggregate(errs)}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+func (v *Verifier) verifyGPGSignature(signature []byte, imageDigest digest.Digest) error {
+	message, err := openpgp.ReadMessage(bytes.NewReader(signature), v.GPGKeyring, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to read GPG signature: %w", err)
+	}
+	payload, err := io.ReadAll(message.UnverifiedBody)
+	if err != nil {
+		return fmt.Errorf("failed to read GPG signature: %w", err)
+	}
+	// The signature is only checked once the body is read.
+	if !message.IsSigned || message.SignedBy == nil || message.SignatureError != nil {
+		return errors.New("GPG signature is not signed by a trusted key")
+	}
+	return checkPayloadDigest(payload, imageDigest)
+}
+
+func getStoreSignature(ctx context.Context, client *http.Client, signatureURL string) ([]byte, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, signatureURL, nil)
+	if err != nil {
+		return nil, false, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get signature %s: %w", signatureURL, err)
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound, http.StatusForbidden:
+		// Object stores answer forbidden for missing objects of public buckets.
+		return nil, false, nil
+	default:
+		return nil, false, fmt.Errorf("failed to get signature %s: %s", signatureURL, resp.Status)
+	}
+	signature, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read signature %s: %w", signatureURL, err)
+	}
+	return signature, true, nil
+}
+
+// registryCosignSignatures returns the cosign signatures of the image, stored in its repository under the
+// sha256-<digest>.sig tag.
+func registryCosignSignatures(ctx context.Context, imageRef string, imageDigest digest.Digest, pullSecret []byte) ([]cosignSignature, error) {
+	ref, err := reference.Parse(imageRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse image reference %q: %w", imageRef, err)
+	}
+	ref.ID = ""
+	ref.Tag = fmt.Sprintf("%s-%s.sig", imageDigest.Algorithm(), imageDigest.Encoded())
+	signaturesRef := ref.Exact()
+
+	manifest, err := registryclient.GetManifest(ctx, signaturesRef, pullSecret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the signatures manifest %s: %w", signaturesRef, err)
+	}
+	repo, _, err := registryclient.GetRepoSetup(ctx, signaturesRef, pullSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	var signatures []cosignSignature
+	for _, layer := range manifest.References() {
+		encodedSignature, ok := layer.Annotations[cosignSignatureAnnotation]
+		if !ok {
+			continue
+		}
+		signature, err := base64.StdEncoding.DecodeString(encodedSignature)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode signature of layer %s: %w", layer.Digest, err)
+		}
+		payload, err := repo.Blobs(ctx).Get(ctx, layer.Digest)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get signature payload %s: %w", layer.Digest, err)
+		}
+		signatures = append(signatures, cosignSignature{payload: payload, signature: signature})
+	}
+	return signatures, nil
+}
+
+func parseCosignPublicKey(data []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("failed to decode PEM public key")
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	switch key.(type) {
+	case *ecdsa.PublicKey, *rsa.PublicKey, ed25519.PublicKey:
+		return key, nil
+	default:
+		return nil, fmt.Errorf("unsupported public key type %T", key)
+	}
+}
+
+func verifiesCosignSignature(keys []crypto.PublicKey, signature cosignSignature) bool {
+	hash := sha256.Sum256(signature.payload)
+	for _, key := range keys {
+		switch k := key.(type) {
+		case *ecdsa.PublicKey:
+			if ecdsa.VerifyASN1(k, hash[:], signature.signature) {
+				return true
+			}
+		case *rsa.PublicKey:
+			if rsa.VerifyPKCS1v15(k, crypto.SHA256, hash[:], signature.signature) == nil {
+				return true
+			}
+		case ed25519.PublicKey:
+			if ed25519.Verify(k, signature.payload, signature.signature) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// checkPayloadDigest checks that the signed payload is about the image with the given digest, so a valid signature of
+// another image can't be used instead.
+func checkPayloadDigest(payload []byte, imageDigest digest.Digest) error {
+	var signed simpleSigningPayload
+	if err := json.Unmarshal(payload, &signed); err != nil {
+		return fmt.Errorf("failed to parse signed payload: %w", err)
+	}
+	if signed.Critical.Image.DockerManifestDigest != imageDigest.String() {
+		return fmt.Errorf("signature is for digest %q, not %q", signed.Critical.Image.DockerManifestDigest, imageDigest)
+	}
+	return nil
+}
@@ -0,0 +1,272 @@
+package imageverification
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+	. "github.com/onsi/gomega"
+	"github.com/opencontainers/go-digest"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+)
+
+const (
+	imageDigest = digest.Digest("sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef")
+	otherDigest = digest.Digest("sha256:fedcba9876543210fedcba9876543210fedcba9876543210fedcba9876543210")
+	imageRef    = "quay.io/openshift-release-dev/ocp-release@" + string(imageDigest)
+)
+
+func payloadFor(d digest.Digest) []byte {
+	return []byte(fmt.Sprintf(`{"critical":{"identity":{"docker-reference":"quay.io/openshift-release-dev/ocp-release"},"image":{"docker-manifest-digest":%q},"type":"cosign container image signature"},"optional":null}`, d))
+}
+
+func publicKeysConfigMap(keys map[string]string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "clusters", Name: "image-signing-keys"},
+		Data:       keys,
+	}
+}
+
+func TestVerifyCosign(t *testing.T) {
+	g := NewWithT(t)
+
+	trustedKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	g.Expect(err).ToNot(HaveOccurred())
+	untrustedKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	g.Expect(err).ToNot(HaveOccurred())
+	publicKey, err := x509.MarshalPKIXPublicKey(&trustedKey.PublicKey)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	sign := func(key *ecdsa.PrivateKey, payload []byte) cosignSignature {
+		hash := sha256.Sum256(payload)
+		signature, err := ecdsa.SignASN1(rand.Reader, key, hash[:])
+		g.Expect(err).ToNot(HaveOccurred())
+		return cosignSignature{payload: payload, signature: signature}
+	}
+
+	policy := &hyperv1.ImageVerificationPolicy{
+		Type:       hyperv1.ImageVerificationTypeCosign,
+		PublicKeys: corev1.LocalObjectReference{Name: "image-signing-keys"},
+	}
+	c := fake.NewClientBuilder().WithObjects(publicKeysConfigMap(map[string]string{
+		"cosign.pub": string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKey})),
+	})).Build()
+
+	testCases := []struct {
+		name              string
+		imageRef          string
+		signatures        []cosignSignature
+		fetchErr          error
+		expectError       bool
+		expectNotVerified bool
+	}{
+		{
+			name:       "When the image is signed by a trusted key it should be verified",
+			signatures: []cosignSignature{sign(untrustedKey, payloadFor(imageDigest)), sign(trustedKey, payloadFor(imageDigest))},
+		},
+		{
+			name:              "When the image is only signed by an untrusted key it should fail",
+			signatures:        []cosignSignature{sign(untrustedKey, payloadFor(imageDigest))},
+			expectError:       true,
+			expectNotVerified: true,
+		},
+		{
+			name:              "When the signature is for another image it should fail",
+			signatures:        []cosignSignature{sign(trustedKey, payloadFor(otherDigest))},
+			expectError:       true,
+			expectNotVerified: true,
+		},
+		{
+			name:              "When the image is not signed it should fail",
+			expectError:       true,
+			expectNotVerified: true,
+		},
+		{
+			name:              "When the image is referenced by tag it should fail",
+			imageRef:          "quay.io/openshift-release-dev/ocp-release:4.16.0-x86_64",
+			signatures:        []cosignSignature{sign(trustedKey, payloadFor(imageDigest))},
+			expectError:       true,
+			expectNotVerified: true,
+		},
+		{
+			name:        "When the signatures can't be fetched it should fail without reporting the image as not verified",
+			fetchErr:    errors.New("registry unavailable"),
+			expectError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			verifier, err := NewVerifier(context.Background(), c, "clusters", policy)
+			g.Expect(err).ToNot(HaveOccurred())
+			verifier.fetchCosignSignatures = func(_ context.Context, _ string, d digest.Digest, _ []byte) ([]cosignSignature, error) {
+				g.Expect(d).To(Equal(imageDigest))
+				return tc.signatures, tc.fetchErr
+			}
+
+			ref := imageRef
+			if tc.imageRef != "" {
+				ref = tc.imageRef
+			}
+			err = verifier.Verify(context.Background(), ref, nil)
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+			g.Expect(IsNotVerified(err)).To(Equal(tc.expectNotVerified))
+		})
+	}
+}
+
+func TestVerifyGPG(t *testing.T) {
+	g := NewWithT(t)
+
+	config := &packet.Config{DefaultHash: crypto.SHA256}
+	trustedEntity, err := openpgp.NewEntity("release signer", "", "release@example.com", config)
+	g.Expect(err).ToNot(HaveOccurred())
+	untrustedEntity, err := openpgp.NewEntity("other signer", "", "other@example.com", config)
+	g.Expect(err).ToNot(HaveOccurred())
+	keyring := &bytes.Buffer{}
+	armored, err := armor.Encode(keyring, openpgp.PublicKeyType, nil)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(trustedEntity.Serialize(armored)).To(Succeed())
+	g.Expect(armored.Close()).To(Succeed())
+
+	sign := func(entity *openpgp.Entity, payload []byte) []byte {
+		signature := &bytes.Buffer{}
+		w, err := openpgp.Sign(signature, entity, nil, config)
+		g.Expect(err).ToNot(HaveOccurred())
+		_, err = w.Write(payload)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(w.Close()).To(Succeed())
+		return signature.Bytes()
+	}
+
+	testCases := []struct {
+		name              string
+		signatures        map[string][]byte
+		storeUnavailable  bool
+		expectError       bool
+		expectNotVerified bool
+	}{
+		{
+			name: "When the image is signed by a trusted key it should be verified",
+			signatures: map[string][]byte{
+				"/sha256=" + imageDigest.Encoded() + "/signature-1": sign(untrustedEntity, payloadFor(imageDigest)),
+				"/sha256=" + imageDigest.Encoded() + "/signature-2": sign(trustedEntity, payloadFor(imageDigest)),
+			},
+		},
+		{
+			name: "When the image is only signed by an untrusted key it should fail",
+			signatures: map[string][]byte{
+				"/sha256=" + imageDigest.Encoded() + "/signature-1": sign(untrustedEntity, payloadFor(imageDigest)),
+			},
+			expectError:       true,
+			expectNotVerified: true,
+		},
+		{
+			name: "When the signature is for another image it should fail",
+			signatures: map[string][]byte{
+				"/sha256=" + imageDigest.Encoded() + "/signature-1": sign(trustedEntity, payloadFor(otherDigest)),
+			},
+			expectError:       true,
+			expectNotVerified: true,
+		},
+		{
+			name:              "When the signature store has no signatures for the image it should fail",
+			expectError:       true,
+			expectNotVerified: true,
+		},
+		{
+			name:             "When the signature store is unavailable it should fail without reporting the image as not verified",
+			storeUnavailable: true,
+			expectError:      true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			store := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tc.storeUnavailable {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				signature, ok := tc.signatures[r.URL.Path]
+				if !ok {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				_, _ = w.Write(signature)
+			}))
+			defer store.Close()
+
+			c := fake.NewClientBuilder().WithObjects(publicKeysConfigMap(map[string]string{"release.gpg": keyring.String()})).Build()
+			verifier, err := NewVerifier(context.Background(), c, "clusters", &hyperv1.ImageVerificationPolicy{
+				Type:            hyperv1.ImageVerificationTypeGPG,
+				PublicKeys:      corev1.LocalObjectReference{Name: "image-signing-keys"},
+				SignatureStores: []string{store.URL + "/"},
+			})
+			g.Expect(err).ToNot(HaveOccurred())
+
+			err = verifier.Verify(context.Background(), imageRef, nil)
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+			g.Expect(IsNotVerified(err)).To(Equal(tc.expectNotVerified))
+		})
+	}
+}
+
+func TestNewVerifier(t *testing.T) {
+	testCases := []struct {
+		name      string
+		configMap *corev1.ConfigMap
+	}{
+		{
+			name: "When the public keys configmap doesn't exist it should fail",
+		},
+		{
+			name:      "When the public keys configmap has no keys it should fail",
+			configMap: publicKeysConfigMap(nil),
+		},
+		{
+			name:      "When a public key is invalid it should fail",
+			configMap: publicKeysConfigMap(map[string]string{"cosign.pub": "not a key"}),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			builder := fake.NewClientBuilder()
+			if tc.configMap != nil {
+				builder = builder.WithObjects(tc.configMap)
+			}
+			_, err := NewVerifier(context.Background(), builder.Build(), "clusters", &hyperv1.ImageVerificationPolicy{
+				Type:       hyperv1.ImageVerificationTypeCosign,
+				PublicKeys: corev1.LocalObjectReference{Name: "image-signing-keys"},
+			})
+			g.Expect(err).To(HaveOccurred())
+		})
+	}
+}
@@ -138,7 +138,7 @@ func EtcdRelabelConfigs(set MetricsSet) []*prometheusoperatorv1.RelabelConfig {
 		return []*prometheusoperatorv1.RelabelConfig{
 			{
 				Action:       "keep",
-				Regex:        "(etcd_disk_wal_fsync_duration_seconds_bucket|etcd_mvcc_db_total_size_in_bytes|etcd_network_peer_round_trip_time_seconds_bucket|etcd_mvcc_db_total_size_in_use_in_bytes|etcd_disk_backend_commit_duration_seconds_bucket|etcd_server_leader_changes_seen_total)",
+				Regex:        "(etcd_disk_wal_fsync_duration_seconds_bucket|etcd_mvcc_db_total_size_in_bytes|etcd_network_peer_round_trip_time_seconds_bucket|etcd_mvcc_db_total_size_in_use_in_bytes|etcd_disk_backend_commit_duration_seconds_bucket|etcd_server_leader_changes_seen_total|etcd_network_client_grpc_received_bytes_total|etcd_network_client_grpc_sent_bytes_total|grpc_server_msg_received_total|grpc_server_msg_sent_total)",
 				SourceLabels: []prometheusoperatorv1.LabelName{"__name__"},
 			},
 		}
@@ -0,0 +1,187 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	ctrl "sigs.k8s.io/controller-runtime"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// DocumentStoreManagementCluster stores the OIDC discovery documents in ConfigMaps of the management cluster,
+	// from where the hypershift operator serves them, for management clusters without object storage.
+	DocumentStoreManagementCluster = "ManagementCluster"
+
+	// DefaultDocumentServerBindAddress is the default address the hypershift operator serves the OIDC documents
+	// stored in the management cluster on.
+	DefaultDocumentServerBindAddress = ":9080"
+
+	documentConfigMapPrefix = "oidc-discovery-"
+	documentConfigMapLabel  = "hypershift.openshift.io/oidc-discovery"
+)
+
+// ConfigMapDocumentStore stores documents in ConfigMaps of the management cluster: the documents of each cluster
+// are stored in the oidc-discovery-<cluster> ConfigMap of Namespace, keyed by their escaped path. The keys of the
+// documents must be <cluster>/<path>, as for the other stores.
+type ConfigMapDocumentStore struct {
+	Client    crclient.Client
+	Namespace string
+}
+
+func (s *ConfigMapDocumentStore) Put(ctx context.Context, key string, body io.ReadSeeker) error {
+	name, dataKey, err := documentConfigMapKey(key)
+	if err != nil {
+		return err
+	}
+	content, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: s.Namespace, Name: name}}
+	if _, err := ctrl.CreateOrUpdate(ctx, s.Client, cm, func() error {
+		if cm.Labels == nil {
+			cm.Labels = map[string]string{}
+		}
+		cm.Labels[documentConfigMapLabel] = "true"
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		cm.Data[dataKey] = string(content)
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to store %s in the %s/%s configmap: %w", key, s.Namespace, name, err)
+	}
+	return nil
+}
+
+func (s *ConfigMapDocumentStore) Delete(ctx context.Context, keys []string) error {
+	removed := map[string][]string{}
+	for _, key := range keys {
+		name, dataKey, err := documentConfigMapKey(key)
+		if err != nil {
+			return err
+		}
+		removed[name] = append(removed[name], dataKey)
+	}
+
+	for name, dataKeys := range removed {
+		cm := &corev1.ConfigMap{}
+		if err := s.Client.Get(ctx, crclient.ObjectKey{Namespace: s.Namespace, Name: name}, cm); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return fmt.Errorf("failed to get the %s/%s configmap: %w", s.Namespace, name, err)
+		}
+		for _, dataKey := range dataKeys {
+			delete(cm.Data, dataKey)
+		}
+		if len(cm.Data) == 0 {
+			if err := s.Client.Delete(ctx, cm); err != nil && !apierrors.IsNotFound(err) {
+				return fmt.Errorf("failed to delete the %s/%s configmap: %w", s.Namespace, name, err)
+			}
+			continue
+		}
+		if err := s.Client.Update(ctx, cm); err != nil {
+			return fmt.Errorf("failed to update the %s/%s configmap: %w", s.Namespace, name, err)
+		}
+	}
+	return nil
+}
+
+// documentConfigMapKey returns the name of the ConfigMap and the data key storing the document with the given key.
+func documentConfigMapKey(key string) (string, string, error) {
+	cluster, path, found := strings.Cut(strings.TrimPrefix(key, "/"), "/")
+	if !found || cluster == "" || path == "" {
+		return "", "", fmt.Errorf("invalid OIDC document key %q, must be <cluster>/<path>", key)
+	}
+	name := documentConfigMapPrefix + cluster
+	if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+		return "", "", fmt.Errorf("invalid OIDC document key %q: %s", key, strings.Join(errs, ", "))
+	}
+	dataKey := strings.ReplaceAll(path, "/", "_")
+	if errs := validation.IsConfigMapKey(dataKey); len(errs) > 0 {
+		return "", "", fmt.Errorf("invalid OIDC document key %q: %s", key, strings.Join(errs, ", "))
+	}
+	return name, dataKey, nil
+}
+
+// DocumentServer serves over HTTP the documents stored by a ConfigMapDocumentStore in Namespace, under the same
+// paths they were stored with. It is meant to be exposed publicly, e.g. by a Route terminating TLS, and runs on every
+// replica of the operator.
+type DocumentServer struct {
+	// Client reads the ConfigMaps, usually from the cache of the manager.
+	Client      crclient.Reader
+	Namespace   string
+	BindAddress string
+}
+
+func (s *DocumentServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	name, dataKey, err := documentConfigMapKey(r.URL.Path)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	cm := &corev1.ConfigMap{}
+	if err := s.Client.Get(r.Context(), crclient.ObjectKey{Namespace: s.Namespace, Name: name}, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			http.NotFound(w, r)
+			return
+		}
+		ctrl.LoggerFrom(r.Context()).Error(err, "Failed to get the OIDC documents", "configmap", name)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	document, ok := cm.Data[dataKey]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", documentContentType)
+	w.Header().Set("Cache-Control", DocumentCacheControl)
+	_, _ = io.WriteString(w, document)
+}
+
+// Start serves the documents until the context is done.
+func (s *DocumentServer) Start(ctx context.Context) error {
+	listener, err := net.Listen("tcp", s.BindAddress)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s to serve the OIDC documents: %w", s.BindAddress, err)
+	}
+	server := &http.Server{
+		Handler:           s,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+	if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// NeedLeaderElection makes every replica of the operator serve the documents, as the requests are load balanced
+// across them.
+func (s *DocumentServer) NeedLeaderElection() bool {
+	return false
+}
@@ -0,0 +1,107 @@
+package oidc
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestConfigMapDocumentStore(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	c := fake.NewClientBuilder().Build()
+	store := &ConfigMapDocumentStore{Client: c, Namespace: "hypershift"}
+
+	g.Expect(store.Put(ctx, "cluster/.well-known/openid-configuration", bytes.NewReader([]byte(`{"issuer":"a"}`)))).To(Succeed())
+	g.Expect(store.Put(ctx, "cluster"+JWKSURI, bytes.NewReader([]byte(`{"keys":[]}`)))).To(Succeed())
+
+	cm := &corev1.ConfigMap{}
+	g.Expect(c.Get(ctx, crclient.ObjectKey{Namespace: "hypershift", Name: "oidc-discovery-cluster"}, cm)).To(Succeed())
+	g.Expect(cm.Labels).To(HaveKeyWithValue(documentConfigMapLabel, "true"))
+	g.Expect(cm.Data).To(Equal(map[string]string{
+		".well-known_openid-configuration": `{"issuer":"a"}`,
+		"openid_v1_jwks":                   `{"keys":[]}`,
+	}))
+
+	g.Expect(store.Put(ctx, "invalid", bytes.NewReader(nil))).ToNot(Succeed())
+
+	g.Expect(store.Delete(ctx, []string{"cluster" + JWKSURI})).To(Succeed())
+	g.Expect(c.Get(ctx, crclient.ObjectKeyFromObject(cm), cm)).To(Succeed())
+	g.Expect(cm.Data).To(HaveLen(1))
+
+	g.Expect(store.Delete(ctx, []string{"cluster/.well-known/openid-configuration", "other" + JWKSURI})).To(Succeed())
+	err := c.Get(ctx, crclient.ObjectKeyFromObject(cm), cm)
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+}
+
+func TestDocumentServer(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewClientBuilder().Build()
+	store := &ConfigMapDocumentStore{Client: c, Namespace: "hypershift"}
+	if err := store.Put(ctx, "cluster"+JWKSURI, bytes.NewReader([]byte(`{"keys":[]}`))); err != nil {
+		t.Fatal(err)
+	}
+	server := &DocumentServer{Client: c, Namespace: "hypershift"}
+
+	testCases := []struct {
+		name           string
+		method         string
+		path           string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "When a stored document is requested it should be served",
+			method:         http.MethodGet,
+			path:           "/cluster" + JWKSURI,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"keys":[]}`,
+		},
+		{
+			name:           "When a document which isn't stored is requested it should not be found",
+			method:         http.MethodGet,
+			path:           "/cluster/.well-known/openid-configuration",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "When a document of an unknown cluster is requested it should not be found",
+			method:         http.MethodGet,
+			path:           "/other" + JWKSURI,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "When the path has no cluster it should not be found",
+			method:         http.MethodGet,
+			path:           "/",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "When a document is written it should not be allowed",
+			method:         http.MethodPut,
+			path:           "/cluster" + JWKSURI,
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			recorder := httptest.NewRecorder()
+			server.ServeHTTP(recorder, httptest.NewRequest(tc.method, tc.path, nil))
+			g.Expect(recorder.Code).To(Equal(tc.expectedStatus))
+			if tc.expectedStatus == http.StatusOK {
+				g.Expect(recorder.Body.String()).To(Equal(tc.expectedBody))
+				g.Expect(recorder.Header().Get("Content-Type")).To(Equal(documentContentType))
+				g.Expect(recorder.Header().Get("Cache-Control")).To(Equal(DocumentCacheControl))
+			}
+		})
+	}
+}
@@ -0,0 +1,379 @@
+package oidc
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"golang.org/x/oauth2"
+
+	"github.com/openshift/hypershift/support/azureutil"
+)
+
+// Types of the storage hosting the OIDC discovery documents.
+const (
+	DocumentStoreS3        = "S3"
+	DocumentStoreAzureBlob = "AzureBlob"
+	DocumentStoreGCS       = "GCS"
+)
+
+const (
+	// DocumentCacheControl is the Cache-Control header of the stored OIDC documents. They only change when the
+	// service account signing key of a cluster changes, so they can be cached for a little while.
+	DocumentCacheControl = "public, max-age=300"
+
+	// AzureStaticWebsiteContainer is the container of the static website of an Azure storage account.
+	AzureStaticWebsiteContainer = "$web"
+
+	documentContentType     = "application/json"
+	azureRequestValidity    = 15 * time.Minute
+	azureClockSkewTolerance = 5 * time.Minute
+	gcsEndpoint             = "https://storage.googleapis.com"
+	gcsScope                = "https://www.googleapis.com/auth/devstorage.read_write"
+	gcsTokenValidity        = time.Hour
+)
+
+// DocumentStore stores the OIDC discovery documents of the hosted clusters, where they are publicly served from.
+type DocumentStore interface {
+	// Put uploads a document under the given key.
+	Put(ctx context.Context, key string, body io.ReadSeeker) error
+	// Delete removes the documents with the given keys. Documents which don't exist are ignored.
+	Delete(ctx context.Context, keys []string) error
+}
+
+// S3DocumentStore stores documents in an S3 bucket.
+type S3DocumentStore struct {
+	Client s3iface.S3API
+	Bucket string
+}
+
+func (s *S3DocumentStore) Put(ctx context.Context, key string, body io.ReadSeeker) error {
+	_, err := s.Client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Body:         body,
+		Bucket:       aws.String(s.Bucket),
+		Key:          aws.String(key),
+		ContentType:  aws.String(documentContentType),
+		CacheControl: aws.String(DocumentCacheControl),
+	})
+	if err != nil {
+		wrapped := fmt.Errorf("failed to upload %s to the %s s3 bucket", key, s.Bucket)
+		if awsErr := awserr.Error(nil); errors.As(err, &awsErr) {
+			switch awsErr.Code() {
+			case s3.ErrCodeNoSuchBucket:
+				wrapped = fmt.Errorf("%w: %s: this could be a misconfiguration of the hypershift operator; check the --oidc-storage-provider-s3-bucket-name flag", wrapped, awsErr.Code())
+			default:
+				// Generally, the underlying message from AWS has unique per-request
+				// info not suitable for publishing as condition messages, so just
+				// return the code. If other specific error types can be handled, add
+				// new switch cases and try to provide more actionable info to the
+				// user.
+				wrapped = fmt.Errorf("%w: aws returned an error: %s", wrapped, awsErr.Code())
+			}
+		}
+		return wrapped
+	}
+	return nil
+}
+
+func (s *S3DocumentStore) Delete(ctx context.Context, keys []string) error {
+	var objectsToDelete []*s3.ObjectIdentifier
+	for _, key := range keys {
+		objectsToDelete = append(objectsToDelete, &s3.ObjectIdentifier{
+			Key: aws.String(key),
+		})
+	}
+
+	if _, err := s.Client.DeleteObjectsWithContext(ctx, &s3.DeleteObjectsInput{
+		Bucket: aws.String(s.Bucket),
+		Delete: &s3.Delete{Objects: objectsToDelete},
+	}); err != nil {
+		if awsErr := awserr.Error(nil); !errors.As(err, &awsErr) || awsErr.Code() != s3.ErrCodeNoSuchBucket {
+			return fmt.Errorf("failed to delete OIDC objects from %s S3 bucket: %w", s.Bucket, err)
+		}
+	}
+	return nil
+}
+
+// AzureBlobDocumentStore stores documents in an Azure Blob Storage container, usually the container of the static
+// website of the storage account. Requests are authorized with service shared access signatures derived from the
+// storage account key.
+type AzureBlobDocumentStore struct {
+	Account    string
+	Container  string
+	AccountKey []byte
+	// Endpoint overrides the blob service endpoint of the storage account.
+	Endpoint   string
+	HTTPClient *http.Client
+	now        func() time.Time
+}
+
+// NewAzureBlobDocumentStore returns an AzureBlobDocumentStore for the given storage account container, authorized
+// by the given base64 encoded storage account key.
+func NewAzureBlobDocumentStore(account, container string, accountKey []byte) (*AzureBlobDocumentStore, error) {
+	if account == "" {
+		return nil, errors.New("an Azure storage account is required")
+	}
+	if container == "" {
+		container = AzureStaticWebsiteContainer
+	}
+	decodedKey, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(accountKey)))
+	if err != nil || len(decodedKey) == 0 {
+		return nil, errors.New("the Azure storage account key must be base64 encoded")
+	}
+	return &AzureBlobDocumentStore{
+		Account:    account,
+		Container:  container,
+		AccountKey: decodedKey,
+	}, nil
+}
+
+func (s *AzureBlobDocumentStore) Put(ctx context.Context, key string, body io.ReadSeeker) error {
+	content, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	resp, err := s.do(ctx, http.MethodPut, key, "cw", content, map[string]string{
+		"x-ms-blob-type":          "BlockBlob",
+		"x-ms-blob-content-type":  documentContentType,
+		"x-ms-blob-cache-control": DocumentCacheControl,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upload %s to the %s container of the %s storage account: %w", key, s.Container, s.Account, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("failed to upload %s to the %s container of the %s storage account: %s", key, s.Container, s.Account, resp.Status)
+	}
+	return nil
+}
+
+func (s *AzureBlobDocumentStore) Delete(ctx context.Context, keys []string) error {
+	for _, key := range keys {
+		resp, err := s.do(ctx, http.MethodDelete, key, "d", nil, nil)
+		if err != nil {
+			return fmt.Errorf("failed to delete %s from the %s container of the %s storage account: %w", key, s.Container, s.Account, err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusNotFound {
+			return fmt.Errorf("failed to delete %s from the %s container of the %s storage account: %s", key, s.Container, s.Account, resp.Status)
+		}
+	}
+	return nil
+}
+
+func (s *AzureBlobDocumentStore) do(ctx context.Context, method, key, permissions string, body []byte, headers map[string]string) (*http.Response, error) {
+	now := time.Now
+	if s.now != nil {
+		now = s.now
+	}
+	signedURL, err := azureutil.BlobServiceSASURL(s.Endpoint, s.Account, s.Container, key, permissions, s.AccountKey, now().Add(-azureClockSkewTolerance), now().Add(azureRequestValidity))
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, signedURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("x-ms-version", azureutil.BlobSASVersion)
+	for header, value := range headers {
+		req.Header.Set(header, value)
+	}
+	return httpClientOrDefault(s.HTTPClient).Do(req)
+}
+
+// GCSDocumentStore stores documents in a Google Cloud Storage bucket. HTTPClient must authorize the requests, e.g.
+// with the token source returned by NewGCSTokenSource.
+type GCSDocumentStore struct {
+	Bucket     string
+	HTTPClient *http.Client
+	// Endpoint overrides the Cloud Storage XML API endpoint.
+	Endpoint string
+}
+
+// NewGCSDocumentStore returns a GCSDocumentStore for the given bucket, authorized by the given service account key.
+func NewGCSDocumentStore(bucket string, serviceAccountKey []byte) (*GCSDocumentStore, error) {
+	if bucket == "" {
+		return nil, errors.New("a GCS bucket is required")
+	}
+	tokenSource, err := NewGCSTokenSource(serviceAccountKey, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &GCSDocumentStore{
+		Bucket:     bucket,
+		HTTPClient: &http.Client{Transport: &oauth2.Transport{Source: oauth2.ReuseTokenSource(nil, tokenSource)}},
+	}, nil
+}
+
+func (s *GCSDocumentStore) Put(ctx context.Context, key string, body io.ReadSeeker) error {
+	content, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	resp, err := s.do(ctx, http.MethodPut, key, content, map[string]string{
+		"Content-Type":  documentContentType,
+		"Cache-Control": DocumentCacheControl,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upload %s to the %s gcs bucket: %w", key, s.Bucket, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to upload %s to the %s gcs bucket: %s", key, s.Bucket, resp.Status)
+	}
+	return nil
+}
+
+func (s *GCSDocumentStore) Delete(ctx context.Context, keys []string) error {
+	for _, key := range keys {
+		resp, err := s.do(ctx, http.MethodDelete, key, nil, nil)
+		if err != nil {
+			return fmt.Errorf("failed to delete %s from the %s gcs bucket: %w", key, s.Bucket, err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusNotFound {
+			return fmt.Errorf("failed to delete %s from the %s gcs bucket: %s", key, s.Bucket, resp.Status)
+		}
+	}
+	return nil
+}
+
+func (s *GCSDocumentStore) do(ctx context.Context, method, key string, body []byte, headers map[string]string) (*http.Response, error) {
+	endpoint := s.Endpoint
+	if endpoint == "" {
+		endpoint = gcsEndpoint
+	}
+	objectURL := fmt.Sprintf("%s/%s/%s", strings.TrimSuffix(endpoint, "/"), s.Bucket, (&url.URL{Path: key}).EscapedPath())
+	req, err := http.NewRequestWithContext(ctx, method, objectURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for header, value := range headers {
+		req.Header.Set(header, value)
+	}
+	return httpClientOrDefault(s.HTTPClient).Do(req)
+}
+
+// gcsServiceAccountKey holds the fields of a Google Cloud service account key file used to get access tokens.
+type gcsServiceAccountKey struct {
+	Type        string `json:"type"`
+	ClientEmail string `json:"client_email"`
+	PrivateKey  string `json:"private_key"`
+	TokenURI    string `json:"token_uri"`
+}
+
+// gcsTokenSource gets Cloud Storage access tokens for a service account with the OAuth 2.0 JWT bearer grant.
+// https://developers.google.com/identity/protocols/oauth2/service-account#httprest
+type gcsTokenSource struct {
+	key        gcsServiceAccountKey
+	privateKey *rsa.PrivateKey
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+// NewGCSTokenSource returns an oauth2.TokenSource of Cloud Storage access tokens for the given service account key.
+func NewGCSTokenSource(serviceAccountKey []byte, httpClient *http.Client) (oauth2.TokenSource, error) {
+	source := &gcsTokenSource{httpClient: httpClient, now: time.Now}
+	if err := json.Unmarshal(serviceAccountKey, &source.key); err != nil {
+		return nil, fmt.Errorf("failed to parse the GCS service account key: %w", err)
+	}
+	if source.key.Type != "service_account" || source.key.ClientEmail == "" || source.key.TokenURI == "" {
+		return nil, errors.New("the GCS credentials must be a service account key")
+	}
+	block, _ := pem.Decode([]byte(source.key.PrivateKey))
+	if block == nil {
+		return nil, errors.New("failed to decode the private key of the GCS service account key")
+	}
+	privateKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the private key of the GCS service account key: %w", err)
+	}
+	rsaKey, ok := privateKey.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("the private key of the GCS service account key is not an RSA key")
+	}
+	source.privateKey = rsaKey
+	return source, nil
+}
+
+func (s *gcsTokenSource) Token() (*oauth2.Token, error) {
+	now := s.now()
+	assertion, err := s.signedAssertion(now)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := httpClientOrDefault(s.httpClient).PostForm(s.key.TokenURI, url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  {assertion},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get a GCS access token: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get a GCS access token: %s", resp.Status)
+	}
+	var tokenResponse struct {
+		AccessToken string `json:"access_token"`
+		TokenType   string `json:"token_type"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResponse); err != nil {
+		return nil, fmt.Errorf("failed to parse the GCS access token: %w", err)
+	}
+	return &oauth2.Token{
+		AccessToken: tokenResponse.AccessToken,
+		TokenType:   tokenResponse.TokenType,
+		Expiry:      now.Add(time.Duration(tokenResponse.ExpiresIn) * time.Second),
+	}, nil
+}
+
+// signedAssertion returns the JWT asserting the identity of the service account, signed with its private key.
+func (s *gcsTokenSource) signedAssertion(now time.Time) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(map[string]interface{}{
+		"iss":   s.key.ClientEmail,
+		"scope": gcsScope,
+		"aud":   s.key.TokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(gcsTokenValidity).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	hash := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, s.privateKey, crypto.SHA256, hash[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign the GCS access token request: %w", err)
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+func httpClientOrDefault(client *http.Client) *http.Client {
+	if client == nil {
+		return http.DefaultClient
+	}
+	return client
+}
@@ -0,0 +1,114 @@
+package oidc
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"golang.org/x/oauth2"
+)
+
+type recordedRequest struct {
+	method string
+	path   string
+	header http.Header
+	body   string
+}
+
+// recordingServer returns a TLS server recording the requests it receives and responding with the given status to
+// each method.
+func recordingServer(t *testing.T, statuses map[string]int, requests *[]recordedRequest) *httptest.Server {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		*requests = append(*requests, recordedRequest{method: r.Method, path: r.URL.Path, header: r.Header, body: string(body)})
+		w.WriteHeader(statuses[r.Method])
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestAzureBlobDocumentStore(t *testing.T) {
+	g := NewWithT(t)
+	var requests []recordedRequest
+	server := recordingServer(t, map[string]int{http.MethodPut: http.StatusCreated, http.MethodDelete: http.StatusNotFound}, &requests)
+
+	store, err := NewAzureBlobDocumentStore("account", "", []byte(base64.StdEncoding.EncodeToString([]byte("key"))))
+	g.Expect(err).ToNot(HaveOccurred())
+	store.Endpoint = server.URL
+	store.HTTPClient = server.Client()
+
+	g.Expect(store.Put(context.Background(), "cluster/keys.json", bytes.NewReader([]byte("{}")))).To(Succeed())
+	g.Expect(store.Delete(context.Background(), []string{"cluster/keys.json"})).To(Succeed())
+
+	g.Expect(requests).To(HaveLen(2))
+	g.Expect(requests[0].method).To(Equal(http.MethodPut))
+	g.Expect(requests[0].path).To(Equal("/$web/cluster/keys.json"))
+	g.Expect(requests[0].header.Get("x-ms-blob-type")).To(Equal("BlockBlob"))
+	g.Expect(requests[0].header.Get("x-ms-blob-content-type")).To(Equal(documentContentType))
+	g.Expect(requests[0].header.Get("x-ms-blob-cache-control")).To(Equal(DocumentCacheControl))
+	g.Expect(requests[0].body).To(Equal("{}"))
+	g.Expect(requests[1].method).To(Equal(http.MethodDelete))
+
+	_, err = NewAzureBlobDocumentStore("account", "", []byte("not base64!"))
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestGCSDocumentStore(t *testing.T) {
+	g := NewWithT(t)
+
+	tokenServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		g.Expect(r.ParseForm()).To(Succeed())
+		g.Expect(r.PostForm.Get("grant_type")).To(Equal("urn:ietf:params:oauth:grant-type:jwt-bearer"))
+		g.Expect(r.PostForm.Get("assertion")).ToNot(BeEmpty())
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"token","token_type":"Bearer","expires_in":3600}`))
+	}))
+	t.Cleanup(tokenServer.Close)
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	g.Expect(err).ToNot(HaveOccurred())
+	privateKeyBytes, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	g.Expect(err).ToNot(HaveOccurred())
+	serviceAccountKey, err := json.Marshal(gcsServiceAccountKey{
+		Type:        "service_account",
+		ClientEmail: "oidc@project.iam.gserviceaccount.com",
+		PrivateKey:  string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateKeyBytes})),
+		TokenURI:    tokenServer.URL,
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	var requests []recordedRequest
+	server := recordingServer(t, map[string]int{http.MethodPut: http.StatusOK, http.MethodDelete: http.StatusNoContent}, &requests)
+
+	tokenSource, err := NewGCSTokenSource(serviceAccountKey, tokenServer.Client())
+	g.Expect(err).ToNot(HaveOccurred())
+	store := &GCSDocumentStore{
+		Bucket:     "bucket",
+		Endpoint:   server.URL,
+		HTTPClient: &http.Client{Transport: &oauth2.Transport{Source: tokenSource, Base: server.Client().Transport}},
+	}
+
+	g.Expect(store.Put(context.Background(), "cluster/.well-known/openid-configuration", bytes.NewReader([]byte("{}")))).To(Succeed())
+	g.Expect(store.Delete(context.Background(), []string{"cluster/.well-known/openid-configuration"})).To(Succeed())
+
+	g.Expect(requests).To(HaveLen(2))
+	g.Expect(requests[0].method).To(Equal(http.MethodPut))
+	g.Expect(requests[0].path).To(Equal("/bucket/cluster/.well-known/openid-configuration"))
+	g.Expect(requests[0].header.Get("Authorization")).To(Equal("Bearer token"))
+	g.Expect(requests[0].header.Get("Content-Type")).To(Equal(documentContentType))
+	g.Expect(requests[0].header.Get("Cache-Control")).To(Equal(DocumentCacheControl))
+	g.Expect(requests[1].method).To(Equal(http.MethodDelete))
+
+	_, err = NewGCSTokenSource([]byte(`{"type":"authorized_user"}`), nil)
+	g.Expect(err).To(HaveOccurred())
+}
@@ -1,37 +1,128 @@
 package proxy
 
 import (
+	"net/url"
 	"os"
 	"strings"
 
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 )
 
+// Config is an egress proxy of the management cluster.
+type Config struct {
+	HTTPProxy  string
+	HTTPSProxy string
+	NoProxy    string
+}
+
+// IsSet returns true when the config proxies any traffic.
+func (c Config) IsSet() bool {
+	return c.HTTPProxy != "" || c.HTTPSProxy != ""
+}
+
+// FromEnv returns the egress proxy the current process is configured with.
+func FromEnv() Config {
+	return configFrom(os.Getenv)
+}
+
+func configFrom(envGetter func(string) string) Config {
+	return Config{
+		HTTPProxy:  envGetter("HTTP_PROXY"),
+		HTTPSProxy: envGetter("HTTPS_PROXY"),
+		NoProxy:    envGetter("NO_PROXY"),
+	}
+}
+
+// ForHostedCluster returns the egress proxy of the control plane of a
+// HostedCluster: the proxy of the operator, unless the HostedCluster
+// overrides it with the management cluster proxy annotations.
+func ForHostedCluster(hc *hyperv1.HostedCluster) Config {
+	return forAnnotations(hc.Annotations, FromEnv())
+}
+
+// ForHostedControlPlane is ForHostedCluster for the HostedControlPlane the
+// annotations of the HostedCluster are mirrored to.
+func ForHostedControlPlane(hcp *hyperv1.HostedControlPlane) Config {
+	return forAnnotations(hcp.Annotations, FromEnv())
+}
+
+func forAnnotations(annotations map[string]string, operatorConfig Config) Config {
+	httpProxy, hasHTTPProxy := annotations[hyperv1.ManagementClusterHTTPProxyAnnotation]
+	httpsProxy, hasHTTPSProxy := annotations[hyperv1.ManagementClusterHTTPSProxyAnnotation]
+	noProxy, hasNoProxy := annotations[hyperv1.ManagementClusterNoProxyAnnotation]
+	if !hasHTTPProxy && !hasHTTPSProxy && !hasNoProxy {
+		return operatorConfig
+	}
+	return Config{
+		HTTPProxy:  httpProxy,
+		HTTPSProxy: httpsProxy,
+		NoProxy:    noProxy,
+	}
+}
+
+// URL returns the proxy of HTTPS requests, falling back to the proxy of HTTP
+// requests, for clients configured with a single proxy URL.
+func (c Config) URL() string {
+	if c.HTTPSProxy != "" {
+		return c.HTTPSProxy
+	}
+	return c.HTTPProxy
+}
+
+// SetEnvVars sets the proxy environment variables of a control plane
+// component to the egress proxy of the current process. The NO_PROXY of the
+// component is made of its additionalNoProxy destinations, the
+// kube-apiserver service and the NO_PROXY of the current process.
 func SetEnvVars(env *[]corev1.EnvVar, additionalNoProxy ...string) {
-	setEnvVars(env, os.Getenv, additionalNoProxy...)
+	SetEnvVarsFor(env, FromEnv(), additionalNoProxy...)
 }
 
 func setEnvVars(env *[]corev1.EnvVar, envGetter func(string) string, additionalNoProxy ...string) {
+	SetEnvVarsFor(env, configFrom(envGetter), additionalNoProxy...)
+}
+
+// SetEnvVarsFor is SetEnvVars for the given egress proxy.
+func SetEnvVarsFor(env *[]corev1.EnvVar, config Config, additionalNoProxy ...string) {
 	var noProxy string
-	httpProxy := envGetter("HTTP_PROXY")
-	httpsProxy := envGetter("HTTPS_PROXY")
-	if httpProxy != "" || httpsProxy != "" {
+	if config.IsSet() {
 		// De-duplicate and sort
 		additionalNoProxy = append(additionalNoProxy, "kube-apiserver")
-		if envVal := envGetter("NO_PROXY"); envVal != "" {
-			additionalNoProxy = append(additionalNoProxy, strings.Split(envVal, ",")...)
+		if config.NoProxy != "" {
+			additionalNoProxy = append(additionalNoProxy, strings.Split(config.NoProxy, ",")...)
 		}
 		s := sets.NewString(additionalNoProxy...)
+		s.Delete("")
 		noProxy = strings.Join(s.List(), ",")
 	}
 	SetEnvVarsTo(env,
-		httpProxy,
-		httpsProxy,
+		config.HTTPProxy,
+		config.HTTPSProxy,
 		noProxy,
 	)
 }
 
+// InClusterNoProxy are the NO_PROXY destinations of the components talking to
+// services of the management cluster by their fully qualified name.
+var InClusterNoProxy = []string{".svc", ".cluster.local", "localhost", "127.0.0.1"}
+
+// NoProxyHosts returns the hosts of the URLs, to exclude them from proxying.
+// Malformed URLs are ignored.
+func NoProxyHosts(urls ...string) []string {
+	var hosts []string
+	for _, rawURL := range urls {
+		for _, endpoint := range strings.Split(rawURL, ",") {
+			u, err := url.Parse(strings.TrimSpace(endpoint))
+			if err != nil || u.Hostname() == "" {
+				continue
+			}
+			hosts = append(hosts, u.Hostname())
+		}
+	}
+	return hosts
+}
+
 func SetEnvVarsTo(env *[]corev1.EnvVar, httpProxy, httpsProxy, noProxy string) {
 	if httpProxy == "" {
 		removeEnvVarIfPresent(env, "HTTP_PROXY")
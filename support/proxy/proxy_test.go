@@ -6,6 +6,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 )
 
@@ -103,3 +104,47 @@ func TestSetEnvVars(t *testing.T) {
 		})
 	}
 }
+
+func TestForAnnotations(t *testing.T) {
+	operatorConfig := Config{HTTPProxy: "http://operator", HTTPSProxy: "http://operator", NoProxy: "operator.example.com"}
+	testCases := []struct {
+		name        string
+		annotations map[string]string
+		expected    Config
+	}{
+		{
+			name:     "No override, the proxy of the operator is used",
+			expected: operatorConfig,
+		},
+		{
+			name: "Override, the proxy of the annotations is used",
+			annotations: map[string]string{
+				hyperv1.ManagementClusterHTTPSProxyAnnotation: "http://cluster",
+				hyperv1.ManagementClusterNoProxyAnnotation:    "cluster.example.com",
+			},
+			expected: Config{HTTPSProxy: "http://cluster", NoProxy: "cluster.example.com"},
+		},
+		{
+			name: "Empty override, no proxy is used",
+			annotations: map[string]string{
+				hyperv1.ManagementClusterHTTPProxyAnnotation: "",
+			},
+			expected: Config{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if diff := cmp.Diff(forAnnotations(tc.annotations, operatorConfig), tc.expected); diff != "" {
+				t.Errorf("actual config differs from expected: %s", diff)
+			}
+		})
+	}
+}
+
+func TestNoProxyHosts(t *testing.T) {
+	actual := NoProxyHosts("https://etcd-0.example.com:2379,https://etcd-1.example.com:2379", "not a url", "https://10.0.0.1:2379")
+	expected := []string{"etcd-0.example.com", "etcd-1.example.com", "10.0.0.1"}
+	if diff := cmp.Diff(actual, expected); diff != "" {
+		t.Errorf("actual hosts differ from expected: %s", diff)
+	}
+}
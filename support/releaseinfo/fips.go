@@ -0,0 +1,42 @@
+package releaseinfo
+
+import (
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// fipsArchitectures are the RHCOS architectures whose bootimages support booting in FIPS mode.
+var fipsArchitectures = sets.New("x86_64", "ppc64le", "s390x")
+
+// IsFIPSArchitecture returns true if nodes of the given RHCOS architecture can run in FIPS mode.
+func IsFIPSArchitecture(arch string) bool {
+	return fipsArchitectures.Has(arch)
+}
+
+// ValidateFIPS returns an error if the release image has no bootimages for a FIPS capable architecture, or for the
+// given RHCOS architecture when it is set.
+func (i *ReleaseImage) ValidateFIPS(arch string) error {
+	if i.StreamMetadata == nil {
+		return fmt.Errorf("release image %s has no OS metadata", i.Version())
+	}
+	if arch != "" {
+		if !IsFIPSArchitecture(arch) {
+			return fmt.Errorf("architecture %s doesn't support FIPS mode, supported architectures are: %v", arch, sets.List(fipsArchitectures))
+		}
+		if _, ok := i.StreamMetadata.Architectures[arch]; !ok {
+			return fmt.Errorf("release image %s has no bootimages for architecture %s", i.Version(), arch)
+		}
+		return nil
+	}
+	var available []string
+	for arch := range i.StreamMetadata.Architectures {
+		if IsFIPSArchitecture(arch) {
+			return nil
+		}
+		available = append(available, arch)
+	}
+	sort.Strings(available)
+	return fmt.Errorf("release image %s has no bootimages for a FIPS capable architecture, available architectures are: %v", i.Version(), available)
+}
@@ -0,0 +1,74 @@
+package releaseinfo
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	imageapi "github.com/openshift/api/image/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidateFIPS(t *testing.T) {
+	releaseImage := func(archs ...string) *ReleaseImage {
+		metadata := &CoreOSStreamMetadata{Architectures: map[string]CoreOSArchitecture{}}
+		for _, arch := range archs {
+			metadata.Architectures[arch] = CoreOSArchitecture{}
+		}
+		return &ReleaseImage{
+			ImageStream:    &imageapi.ImageStream{ObjectMeta: metav1.ObjectMeta{Name: "4.16.0"}},
+			StreamMetadata: metadata,
+		}
+	}
+
+	testCases := []struct {
+		name         string
+		releaseImage *ReleaseImage
+		arch         string
+		expectError  bool
+	}{
+		{
+			name:         "When the release image has bootimages for a FIPS capable architecture it should be valid",
+			releaseImage: releaseImage("aarch64", "x86_64"),
+		},
+		{
+			name:         "When the release image only has bootimages for architectures without FIPS support it should fail",
+			releaseImage: releaseImage("aarch64"),
+			expectError:  true,
+		},
+		{
+			name:         "When the requested FIPS capable architecture has bootimages it should be valid",
+			releaseImage: releaseImage("x86_64", "s390x"),
+			arch:         "s390x",
+		},
+		{
+			name:         "When the requested architecture doesn't support FIPS mode it should fail",
+			releaseImage: releaseImage("x86_64", "aarch64"),
+			arch:         "aarch64",
+			expectError:  true,
+		},
+		{
+			name:         "When the requested architecture has no bootimages it should fail",
+			releaseImage: releaseImage("x86_64"),
+			arch:         "ppc64le",
+			expectError:  true,
+		},
+		{
+			name: "When the release image has no OS metadata it should fail",
+			releaseImage: &ReleaseImage{
+				ImageStream: &imageapi.ImageStream{ObjectMeta: metav1.ObjectMeta{Name: "4.16.0"}},
+			},
+			expectError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := tc.releaseImage.ValidateFIPS(tc.arch)
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+		})
+	}
+}
@@ -0,0 +1,245 @@
+package releaseinfo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/openshift/hypershift/support/releaseinfo/registryclient"
+	"github.com/openshift/hypershift/support/thirdparty/library-go/pkg/image/reference"
+)
+
+const (
+	// DefaultPersistentCacheTTL is the time cached release metadata is trusted
+	// before it is refreshed from the registry.
+	DefaultPersistentCacheTTL = 24 * time.Hour
+
+	persistentCacheTagsFile = "tags.json"
+)
+
+var _ Provider = (*PersistentCacheProvider)(nil)
+
+// PersistentCacheProvider caches release image metadata keyed by the digest of
+// the release image and persists it to disk, so that metadata survives
+// restarts and registries are only queried when the cache is cold or expired.
+//
+// Release images referred to by tag are resolved to a digest first and the
+// inner provider is always queried with the digest pinned pullspec. When the
+// registry can't be reached, expired entries are served rather than failing.
+type PersistentCacheProvider struct {
+	Inner Provider
+
+	// Dir is the directory the cache is persisted to. If empty, the cache is
+	// only kept in memory.
+	Dir string
+
+	// TTL is how long cached metadata and tag resolutions are trusted.
+	// Defaults to DefaultPersistentCacheTTL.
+	TTL time.Duration
+
+	// ResolveDigest resolves the digest of an image. Defaults to
+	// registryclient.GetDigest.
+	ResolveDigest func(ctx context.Context, image string, pullSecret []byte) (digest.Digest, error)
+
+	mu      sync.Mutex
+	loaded  bool
+	tags    map[string]persistentCacheTag
+	entries map[digest.Digest]*persistentCacheEntry
+	now     func() time.Time
+}
+
+type persistentCacheTag struct {
+	Digest     digest.Digest `json:"digest"`
+	ResolvedAt time.Time     `json:"resolvedAt"`
+}
+
+type persistentCacheEntry struct {
+	Image        string        `json:"image"`
+	Digest       digest.Digest `json:"digest"`
+	FetchedAt    time.Time     `json:"fetchedAt"`
+	ReleaseImage *ReleaseImage `json:"releaseImage"`
+}
+
+func (p *PersistentCacheProvider) Lookup(ctx context.Context, image string, pullSecret []byte) (*ReleaseImage, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.load()
+
+	ref, err := reference.Parse(image)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse release image %q: %w", image, err)
+	}
+	imageDigest, err := p.resolveDigest(ctx, image, ref, pullSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := p.entries[imageDigest]
+	if entry == nil {
+		entry = p.readEntry(imageDigest)
+	}
+	if entry != nil && !p.expired(entry.FetchedAt) {
+		p.entries[imageDigest] = entry
+		return entry.ReleaseImage, nil
+	}
+
+	ref.Tag = ""
+	ref.ID = imageDigest.String()
+	releaseImage, err := p.Inner.Lookup(ctx, ref.Exact(), pullSecret)
+	if err != nil {
+		if entry != nil {
+			// Serve the expired entry, the content of a digest never changes.
+			return entry.ReleaseImage, nil
+		}
+		return nil, err
+	}
+
+	entry = &persistentCacheEntry{
+		Image:        image,
+		Digest:       imageDigest,
+		FetchedAt:    p.clock(),
+		ReleaseImage: releaseImage,
+	}
+	p.entries[imageDigest] = entry
+	if err := p.writeFile(entryFileName(imageDigest), entry); err != nil {
+		return nil, err
+	}
+	return releaseImage, nil
+}
+
+// Digest returns the digest the image points to, using the cached tag
+// resolution if there is one.
+func (p *PersistentCacheProvider) Digest(ctx context.Context, image string, pullSecret []byte) (digest.Digest, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.load()
+
+	ref, err := reference.Parse(image)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse release image %q: %w", image, err)
+	}
+	return p.resolveDigest(ctx, image, ref, pullSecret)
+}
+
+// resolveDigest returns the digest the image points to, using cached tag
+// resolutions while they are not expired or while the registry can't be reached.
+func (p *PersistentCacheProvider) resolveDigest(ctx context.Context, image string, ref reference.DockerImageReference, pullSecret []byte) (digest.Digest, error) {
+	if len(ref.ID) > 0 {
+		imageDigest, err := digest.Parse(ref.ID)
+		if err != nil {
+			return "", fmt.Errorf("invalid digest in release image %q: %w", image, err)
+		}
+		return imageDigest, nil
+	}
+
+	cached, hasCached := p.tags[image]
+	if hasCached && !p.expired(cached.ResolvedAt) {
+		return cached.Digest, nil
+	}
+
+	resolve := p.ResolveDigest
+	if resolve == nil {
+		resolve = registryclient.GetDigest
+	}
+	imageDigest, err := resolve(ctx, image, pullSecret)
+	if err != nil {
+		if hasCached {
+			return cached.Digest, nil
+		}
+		return "", fmt.Errorf("failed to resolve digest of release image %s: %w", image, err)
+	}
+
+	p.tags[image] = persistentCacheTag{Digest: imageDigest, ResolvedAt: p.clock()}
+	if err := p.writeFile(persistentCacheTagsFile, p.tags); err != nil {
+		return "", err
+	}
+	return imageDigest, nil
+}
+
+func (p *PersistentCacheProvider) load() {
+	if p.loaded {
+		return
+	}
+	p.loaded = true
+	p.tags = map[string]persistentCacheTag{}
+	p.entries = map[digest.Digest]*persistentCacheEntry{}
+	if p.Dir == "" {
+		return
+	}
+	// A corrupt tags file is ignored, tags are resolved again.
+	if data, err := os.ReadFile(filepath.Join(p.Dir, persistentCacheTagsFile)); err == nil {
+		_ = json.Unmarshal(data, &p.tags)
+	}
+}
+
+func (p *PersistentCacheProvider) readEntry(imageDigest digest.Digest) *persistentCacheEntry {
+	if p.Dir == "" {
+		return nil
+	}
+	data, err := os.ReadFile(filepath.Join(p.Dir, entryFileName(imageDigest)))
+	if err != nil {
+		return nil
+	}
+	entry := &persistentCacheEntry{}
+	if err := json.Unmarshal(data, entry); err != nil || entry.Digest != imageDigest || entry.ReleaseImage == nil {
+		return nil
+	}
+	return entry
+}
+
+// writeFile atomically replaces the named file in the cache directory.
+func (p *PersistentCacheProvider) writeFile(name string, obj interface{}) error {
+	if p.Dir == "" {
+		return nil
+	}
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return fmt.Errorf("failed to serialize release info cache file %s: %w", name, err)
+	}
+	if err := os.MkdirAll(p.Dir, 0755); err != nil && !errors.Is(err, fs.ErrExist) {
+		return fmt.Errorf("failed to create release info cache directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(p.Dir, name+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to write release info cache file %s: %w", name, err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write release info cache file %s: %w", name, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write release info cache file %s: %w", name, err)
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(p.Dir, name)); err != nil {
+		return fmt.Errorf("failed to write release info cache file %s: %w", name, err)
+	}
+	return nil
+}
+
+func (p *PersistentCacheProvider) expired(t time.Time) bool {
+	ttl := p.TTL
+	if ttl == 0 {
+		ttl = DefaultPersistentCacheTTL
+	}
+	return p.clock().Sub(t) > ttl
+}
+
+func (p *PersistentCacheProvider) clock() time.Time {
+	if p.now != nil {
+		return p.now()
+	}
+	return time.Now()
+}
+
+func entryFileName(imageDigest digest.Digest) string {
+	return strings.ReplaceAll(imageDigest.String(), ":", "-") + ".json"
+}
@@ -0,0 +1,102 @@
+package releaseinfo
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/opencontainers/go-digest"
+	imageapi "github.com/openshift/api/image/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const testReleaseDigest = digest.Digest("sha256:ac9e9b5a4ee5a8fcf2d2fb6b4c5e6b0e8a15b09a0e2c63a1f35c8df3e7ef2b10")
+
+type countingProvider struct {
+	lookups []string
+	err     error
+}
+
+func (p *countingProvider) Lookup(_ context.Context, image string, _ []byte) (*ReleaseImage, error) {
+	p.lookups = append(p.lookups, image)
+	if p.err != nil {
+		return nil, p.err
+	}
+	return &ReleaseImage{
+		ImageStream:    &imageapi.ImageStream{ObjectMeta: metav1.ObjectMeta{Name: "4.15.0"}},
+		StreamMetadata: &CoreOSStreamMetadata{Stream: "rhcos-4.15"},
+	}, nil
+}
+
+func TestPersistentCacheProvider(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	dir := t.TempDir()
+	now := time.Now()
+
+	resolutions := 0
+	var resolveErr error
+	resolve := func(context.Context, string, []byte) (digest.Digest, error) {
+		resolutions++
+		return testReleaseDigest, resolveErr
+	}
+	inner := &countingProvider{}
+	newProvider := func() *PersistentCacheProvider {
+		return &PersistentCacheProvider{
+			Inner:         inner,
+			Dir:           dir,
+			TTL:           time.Hour,
+			ResolveDigest: resolve,
+			now:           func() time.Time { return now },
+		}
+	}
+
+	provider := newProvider()
+	image := "quay.io/openshift-release-dev/ocp-release:4.15.0-x86_64"
+	releaseImage, err := provider.Lookup(ctx, image, nil)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(releaseImage.Version()).To(Equal("4.15.0"))
+	g.Expect(inner.lookups).To(Equal([]string{"quay.io/openshift-release-dev/ocp-release@" + testReleaseDigest.String()}), "inner provider should be queried by digest")
+
+	// Cached in memory
+	_, err = provider.Lookup(ctx, image, nil)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(inner.lookups).To(HaveLen(1))
+	g.Expect(resolutions).To(Equal(1))
+
+	// Persisted to disk, neither the tag nor the metadata are looked up again
+	provider = newProvider()
+	releaseImage, err = provider.Lookup(ctx, image, nil)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(releaseImage.Version()).To(Equal("4.15.0"))
+	g.Expect(releaseImage.StreamMetadata.Stream).To(Equal("rhcos-4.15"))
+	g.Expect(inner.lookups).To(HaveLen(1))
+	g.Expect(resolutions).To(Equal(1))
+
+	// Digest pinned images don't need to be resolved
+	_, err = newProvider().Lookup(ctx, "quay.io/openshift-release-dev/ocp-release@"+testReleaseDigest.String(), nil)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(resolutions).To(Equal(1))
+	g.Expect(inner.lookups).To(HaveLen(1))
+
+	// Expired entries are refreshed
+	now = now.Add(2 * time.Hour)
+	_, err = provider.Lookup(ctx, image, nil)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(resolutions).To(Equal(2))
+	g.Expect(inner.lookups).To(HaveLen(2))
+
+	// Expired entries are served when the registry can't be reached
+	now = now.Add(2 * time.Hour)
+	resolveErr = fmt.Errorf("connection refused")
+	inner.err = fmt.Errorf("connection refused")
+	releaseImage, err = newProvider().Lookup(ctx, image, nil)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(releaseImage.Version()).To(Equal("4.15.0"))
+
+	// Uncached images fail when the registry can't be reached
+	_, err = newProvider().Lookup(ctx, "quay.io/openshift-release-dev/ocp-release:4.16.0-x86_64", nil)
+	g.Expect(err).To(HaveOccurred())
+}
@@ -11,7 +11,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"runtime"
-	"strings"
+	"slices"
 
 	"github.com/docker/distribution"
 	"github.com/docker/distribution/manifest/manifestlist"
@@ -313,6 +313,32 @@ func GetManifest(ctx context.Context, imageRef string, pullSecret []byte) (distr
 	return digestsManifest, nil
 }
 
+// GetDigest resolves the digest of the manifest the imageRef points to. Image
+// references which are already pinned to a digest are not looked up.
+func GetDigest(ctx context.Context, imageRef string, pullSecret []byte) (digest.Digest, error) {
+	ref, err := reference.Parse(imageRef)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse image reference %q: %w", imageRef, err)
+	}
+	if len(ref.ID) > 0 {
+		return digest.Digest(ref.ID), nil
+	}
+
+	repo, _, err := GetRepoSetup(ctx, imageRef, pullSecret)
+	if err != nil {
+		return "", err
+	}
+	tag := ref.Tag
+	if len(tag) == 0 {
+		tag = "latest"
+	}
+	desc, err := repo.Tags(ctx).Get(ctx, tag)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve tag %s of image %s: %w", tag, imageRef, err)
+	}
+	return desc.Digest, nil
+}
+
 // IsMultiArchManifestList determines whether an image is a manifest listed image and contains manifests the following processor architectures: amd64, arm64, s390x, ppc64le
 func IsMultiArchManifestList(ctx context.Context, imageRef string, pullSecret []byte) (bool, error) {
 	srcManifest, err := GetManifest(ctx, imageRef, pullSecret)
@@ -394,29 +420,70 @@ func findMatchingManifest(ctx context.Context, imageRef string, deserializedMani
 	// Multi-arch image references look like either:
 	//	quay.io/openshift-release-dev/ocp-release@sha256:1a101ef5215da468cea8bd2eb47114e85b2b64a6b230d5882f845701f55d057f
 	//	quay.io/openshift-release-dev/ocp-release:4.11.0-0.nightly-multi-2022-07-12-131716
-	if strings.Contains(imageRef, "@sha") {
-		splitSHA := strings.Split(imageRef, "@")
-		if len(splitSHA) != 2 {
-			return "", fmt.Errorf("failed to parse imageRef %s", imageRef)
-		}
+	// The tag or digest is replaced by the digest of the matching manifest.
+	ref, err := reference.Parse(imageRef)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse imageRef %s: %w", imageRef, err)
+	}
+	if len(ref.Tag) == 0 && len(ref.ID) == 0 {
+		return "", fmt.Errorf("imageRef is an unknown format to parse, imageRef: %s", imageRef)
+	}
+	ref.Tag = ""
+	ref.ID = string(foundManifestDesc.Descriptor.Digest)
+
+	matchingManifestForArch := ref.Exact()
+	log.Info("Found matching manifest for: " + matchingManifestForArch)
+	return matchingManifestForArch, nil
+}
 
-		matchingManifestForArch := splitSHA[0] + "@" + string(foundManifestDesc.Descriptor.Digest)
-		log.Info("Found matching manifest for: " + matchingManifestForArch)
-		return matchingManifestForArch, nil
+// GetImageArchitectures returns the processor architectures an image provides: the architectures of the manifests of
+// a manifest listed image, else the architecture of the image configuration.
+func GetImageArchitectures(ctx context.Context, imageRef string, pullSecret []byte) ([]string, error) {
+	repo, ref, err := GetRepoSetup(ctx, imageRef, pullSecret)
+	if err != nil {
+		return nil, err
+	}
+	srcManifest, location, err := manifest.FirstManifest(ctx, *ref, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to obtain root manifest for %s: %w", imageRef, err)
 	}
 
-	if strings.Contains(imageRef, "ocp-release:") {
-		splitSHA := strings.Split(imageRef, ":")
-		if len(splitSHA) != 2 {
-			return "", fmt.Errorf("failed to parse imageRef %s", imageRef)
+	if location.IsList() {
+		manifests, err := repo.Manifests(ctx)
+		if err != nil {
+			return nil, err
 		}
+		listManifest, err := manifests.Get(ctx, location.ManifestList, manifest.PreferManifestList)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve manifest list %s: %w", imageRef, err)
+		}
+		deserializedManifestList, ok := listManifest.(*manifestlist.DeserializedManifestList)
+		if !ok {
+			return nil, fmt.Errorf("unexpected manifest list type %T for %s", listManifest, imageRef)
+		}
+		return manifestListArchitectures(deserializedManifestList), nil
+	}
 
-		matchingManifestForArch := splitSHA[0] + "@" + string(foundManifestDesc.Descriptor.Digest)
-		log.Info("Found matching manifest for: " + matchingManifestForArch)
-		return matchingManifestForArch, nil
+	config, _, err := manifest.ManifestToImageConfig(ctx, srcManifest, repo.Blobs(ctx), location)
+	if err != nil {
+		return nil, fmt.Errorf("failed to obtain image configuration for %s: %w", imageRef, err)
+	}
+	if len(config.Architecture) == 0 {
+		return nil, nil
 	}
+	return []string{config.Architecture}, nil
+}
 
-	return "", fmt.Errorf("imageRef is an unknown format to parse, imageRef: %s", imageRef)
+// manifestListArchitectures returns the sorted, unique processor architectures of the manifests of a manifest list.
+func manifestListArchitectures(deserializedManifestList *manifestlist.DeserializedManifestList) []string {
+	var architectures []string
+	for _, manifestDesc := range deserializedManifestList.ManifestList.Manifests {
+		if len(manifestDesc.Platform.Architecture) > 0 && !slices.Contains(architectures, manifestDesc.Platform.Architecture) {
+			architectures = append(architectures, manifestDesc.Platform.Architecture)
+		}
+	}
+	slices.Sort(architectures)
+	return architectures
 }
 
 // GetCorrectArchImage returns the appropriate image related to the system os/arch if the image reference is manifest
@@ -183,6 +183,14 @@ func TestFindMatchingManifest(t *testing.T) {
 			archToFind:               ArchitectureS390X,
 			expectedImageRef:         "quay.io/openshift-release-dev/ocp-release@sha256:be53e6c50f1c97b4b34b341fada995f1e0c6c5e8305f3f373b9356ba82cc3d22",
 		},
+		{
+			testName:                 "Find linux/arm64 in multi-arch tagged component image",
+			releaseImage:             "quay.io/hypershift/hypershift-operator:latest",
+			deserializedManifestList: deserializedManifestList2,
+			osToFind:                 LinuxOS,
+			archToFind:               ArchitectureARM64,
+			expectedImageRef:         "quay.io/hypershift/hypershift-operator@sha256:f1c97cf57c57757fcd6d4314ff4b4cc792b27b904e949b840f902c104f1acf38",
+		},
 	}
 
 	for _, tc := range tests {
@@ -195,3 +203,19 @@ func TestFindMatchingManifest(t *testing.T) {
 		})
 	}
 }
+
+func TestManifestListArchitectures(t *testing.T) {
+	g := NewWithT(t)
+
+	deserializedManifestList := &manifestlist.DeserializedManifestList{
+		ManifestList: manifestlist.ManifestList{
+			Manifests: []manifestlist.ManifestDescriptor{
+				{Platform: manifestlist.PlatformSpec{Architecture: ArchitectureARM64, OS: LinuxOS}},
+				{Platform: manifestlist.PlatformSpec{Architecture: ArchitectureAMD64, OS: LinuxOS}},
+				{Platform: manifestlist.PlatformSpec{Architecture: ArchitectureAMD64, OS: "windows"}},
+				{Platform: manifestlist.PlatformSpec{OS: LinuxOS}},
+			},
+		},
+	}
+	g.Expect(manifestListArchitectures(deserializedManifestList)).To(Equal([]string{ArchitectureAMD64, ArchitectureARM64}))
+}
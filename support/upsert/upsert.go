@@ -353,6 +353,10 @@ func defaultContainer(original, mutated *corev1.Container) {
 		defaultProbe(original.ReadinessProbe, mutated.ReadinessProbe)
 	}
 
+	if original.StartupProbe != nil && mutated.StartupProbe != nil {
+		defaultProbe(original.StartupProbe, mutated.StartupProbe)
+	}
+
 	for i := range original.Env {
 		if i >= len(mutated.Env) {
 			break
@@ -0,0 +1,40 @@
+package util
+
+import (
+	"time"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+)
+
+// DefaultDeletionForceTimeout is how long the cleanup of a deleted HostedCluster with the Force deletion policy
+// may take when its deletion policy doesn't set a force timeout.
+const DefaultDeletionForceTimeout = 30 * time.Minute
+
+// HCDeletionPolicyType returns the deletion policy type of the HostedCluster, defaulting to Delete.
+func HCDeletionPolicyType(hc *hyperv1.HostedCluster) hyperv1.DeletionPolicyType {
+	if hc.Spec.DeletionPolicy == nil || hc.Spec.DeletionPolicy.Type == "" {
+		return hyperv1.DeletionPolicyDelete
+	}
+	return hc.Spec.DeletionPolicy.Type
+}
+
+// HCDeletionAllowed returns false when the deletion policy of the HostedCluster is Protect and its deletion
+// hasn't been allowed with the allow-deletion annotation.
+func HCDeletionAllowed(hc *hyperv1.HostedCluster) bool {
+	if HCDeletionPolicyType(hc) != hyperv1.DeletionPolicyProtect {
+		return true
+	}
+	return hc.Annotations[hyperv1.AllowDeletionAnnotation] == "true"
+}
+
+// HCDeletionForceTimeout returns how long the cleanup of the deleted HostedCluster may take before the finalizers
+// blocking its deletion are removed, or zero when its deletion policy isn't Force.
+func HCDeletionForceTimeout(hc *hyperv1.HostedCluster) time.Duration {
+	if HCDeletionPolicyType(hc) != hyperv1.DeletionPolicyForce {
+		return 0
+	}
+	if hc.Spec.DeletionPolicy.ForceTimeout != nil && hc.Spec.DeletionPolicy.ForceTimeout.Duration > 0 {
+		return hc.Spec.DeletionPolicy.ForceTimeout.Duration
+	}
+	return DefaultDeletionForceTimeout
+}
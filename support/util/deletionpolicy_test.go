@@ -0,0 +1,43 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestHCDeletionForceTimeout(t *testing.T) {
+	testCases := []struct {
+		name     string
+		policy   *hyperv1.DeletionPolicy
+		expected time.Duration
+	}{
+		{
+			name: "When the deletion policy is not set it should not force the deletion",
+		},
+		{
+			name:   "When the deletion policy is Protect it should not force the deletion",
+			policy: &hyperv1.DeletionPolicy{Type: hyperv1.DeletionPolicyProtect},
+		},
+		{
+			name:     "When the deletion policy is Force without a timeout it should use the default timeout",
+			policy:   &hyperv1.DeletionPolicy{Type: hyperv1.DeletionPolicyForce},
+			expected: DefaultDeletionForceTimeout,
+		},
+		{
+			name:     "When the deletion policy is Force with a timeout it should use it",
+			policy:   &hyperv1.DeletionPolicy{Type: hyperv1.DeletionPolicyForce, ForceTimeout: &metav1.Duration{Duration: 5 * time.Minute}},
+			expected: 5 * time.Minute,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			hc := &hyperv1.HostedCluster{Spec: hyperv1.HostedClusterSpec{DeletionPolicy: tc.policy}}
+			g.Expect(HCDeletionForceTimeout(hc)).To(Equal(tc.expected))
+		})
+	}
+}
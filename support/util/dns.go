@@ -0,0 +1,43 @@
+package util
+
+import (
+	"fmt"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+)
+
+// DefaultClusterDNSDomain is the private DNS domain of the control plane
+// endpoints when the cluster doesn't set one.
+const DefaultClusterDNSDomain = "hypershift.local"
+
+// ClusterDNSDomain returns the private DNS domain of the control plane
+// endpoints of a cluster.
+func ClusterDNSDomain(dns hyperv1.DNSSpec) string {
+	if dns.ClusterDNSDomain != "" {
+		return dns.ClusterDNSDomain
+	}
+	return DefaultClusterDNSDomain
+}
+
+// InternalDNSDomain returns the private DNS zone of a cluster, the internal
+// routes and the private API server name of the cluster are published under.
+func InternalDNSDomain(name string, dns hyperv1.DNSSpec) string {
+	return fmt.Sprintf("%s.%s", name, ClusterDNSDomain(dns))
+}
+
+// InternalAPIHostname returns the private name of the API server of a cluster.
+func InternalAPIHostname(name string, dns hyperv1.DNSSpec) string {
+	return "api." + InternalDNSDomain(name, dns)
+}
+
+// IsInternalDNSHCP returns true when no public DNS records are created for
+// the hosted control plane.
+func IsInternalDNSHCP(hcp *hyperv1.HostedControlPlane) bool {
+	return hcp.Spec.DNS.Publishing == hyperv1.InternalDNSPublishing
+}
+
+// IsInternalDNSHC returns true when no public DNS records are created for the
+// hosted cluster.
+func IsInternalDNSHC(hc *hyperv1.HostedCluster) bool {
+	return hc.Spec.DNS.Publishing == hyperv1.InternalDNSPublishing
+}
@@ -0,0 +1,33 @@
+package util
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+)
+
+func TestInternalAPIHostname(t *testing.T) {
+	testCases := []struct {
+		name     string
+		dns      hyperv1.DNSSpec
+		expected string
+	}{
+		{
+			name:     "When no cluster DNS domain is set it should use hypershift.local",
+			expected: "api.example.hypershift.local",
+		},
+		{
+			name:     "When a cluster DNS domain is set it should use it",
+			dns:      hyperv1.DNSSpec{ClusterDNSDomain: "internal.example.com"},
+			expected: "api.example.internal.example.com",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(InternalAPIHostname("example", tc.dns)).To(Equal(tc.expected))
+		})
+	}
+}
@@ -44,8 +44,8 @@ func UseDedicatedDNSForKASByHC(hc *hyperv1.HostedCluster) bool {
 }
 
 func ServiceExternalDNSHostname(hcp *hyperv1.HostedControlPlane, serviceType hyperv1.ServiceType) string {
-	// external DNS hostname can only be reached when HCP is Public
-	if !IsPublicHCP(hcp) {
+	// external DNS hostname can only be reached when HCP is Public and publishes its DNS records
+	if !IsPublicHCP(hcp) || IsInternalDNSHCP(hcp) {
 		return ""
 	}
 
@@ -54,6 +54,9 @@ func ServiceExternalDNSHostname(hcp *hyperv1.HostedControlPlane, serviceType hyp
 		return ""
 	}
 
+	if service.Type == hyperv1.LoadBalancer && service.CustomDomain != nil && service.CustomDomain.Target != "" {
+		return service.CustomDomain.Hostname
+	}
 	if service.Type == hyperv1.LoadBalancer && service.LoadBalancer != nil {
 		return service.LoadBalancer.Hostname
 	}
@@ -64,8 +67,8 @@ func ServiceExternalDNSHostname(hcp *hyperv1.HostedControlPlane, serviceType hyp
 }
 
 func ServiceExternalDNSHostnameByHC(hc *hyperv1.HostedCluster, serviceType hyperv1.ServiceType) string {
-	// external DNS hostname can only be reached when HC is Public
-	if !IsPublicHC(hc) {
+	// external DNS hostname can only be reached when HC is Public and publishes its DNS records
+	if !IsPublicHC(hc) || IsInternalDNSHC(hc) {
 		return ""
 	}
 
@@ -74,6 +77,9 @@ func ServiceExternalDNSHostnameByHC(hc *hyperv1.HostedCluster, serviceType hyper
 		return ""
 	}
 
+	if service.Type == hyperv1.LoadBalancer && service.CustomDomain != nil && service.CustomDomain.Target != "" {
+		return service.CustomDomain.Hostname
+	}
 	if service.Type == hyperv1.LoadBalancer && service.LoadBalancer != nil {
 		return service.LoadBalancer.Hostname
 	}
@@ -7,9 +7,14 @@ import (
 )
 
 type FakeImageMetadataProvider struct {
-	Result *dockerv1client.DockerImageConfig
+	Result        *dockerv1client.DockerImageConfig
+	Architectures []string
 }
 
 func (f *FakeImageMetadataProvider) ImageMetadata(ctx context.Context, imageRef string, pullSecret []byte) (*dockerv1client.DockerImageConfig, error) {
 	return f.Result, nil
 }
+
+func (f *FakeImageMetadataProvider) ImageArchitectures(ctx context.Context, imageRef string, pullSecret []byte) ([]string, error) {
+	return f.Architectures, nil
+}
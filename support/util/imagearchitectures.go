@@ -0,0 +1,57 @@
+package util
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/openshift/hypershift/support/releaseinfo/registryclient"
+	"github.com/openshift/hypershift/support/thirdparty/library-go/pkg/image/reference"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// ImageArchitectures returns the processor architectures provided by a given image: the architectures of the
+// manifests of a manifest listed image, else the architecture of the image. The image is looked up in the first
+// matching registry override. The architectures of image references containing a digest are cached.
+func (r *RegistryClientImageMetadataProvider) ImageArchitectures(ctx context.Context, imageRef string, pullSecret []byte) ([]string, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	ref, err := reference.Parse(imageRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse image reference %q: %w", imageRef, err)
+	}
+	if ref.ID != "" {
+		if architectures, exists := imageArchitecturesCache.Get(ref.ID); exists {
+			return architectures.([]string), nil
+		}
+	}
+
+	lookupRef := ref
+	for source, mirrors := range r.OpenShiftImageRegistryOverrides {
+		overrideFound := false
+		for _, mirror := range mirrors {
+			mirrorRef, found, err := GetRegistryOverrides(ctx, ref, source, mirror)
+			if err != nil {
+				log.Info(fmt.Sprintf("failed to find registry override for image reference %q with source, %s, mirror %s: %s", imageRef, source, mirror, err.Error()))
+				continue
+			}
+			if found {
+				lookupRef = *mirrorRef
+				lookupRef.Tag, lookupRef.ID = ref.Tag, ref.ID
+				overrideFound = true
+				break
+			}
+		}
+		if overrideFound {
+			break
+		}
+	}
+
+	architectures, err := registryclient.GetImageArchitectures(ctx, lookupRef.Exact(), pullSecret)
+	if err != nil {
+		return nil, err
+	}
+	if ref.ID != "" {
+		imageArchitecturesCache.Add(ref.ID, architectures)
+	}
+	return architectures, nil
+}
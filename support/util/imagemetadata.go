@@ -23,11 +23,13 @@ import (
 )
 
 var (
-	imageMetadataCache = lru.New(1000)
+	imageMetadataCache      = lru.New(1000)
+	imageArchitecturesCache = lru.New(1000)
 )
 
 type ImageMetadataProvider interface {
 	ImageMetadata(ctx context.Context, imageRef string, pullSecret []byte) (*dockerv1client.DockerImageConfig, error)
+	ImageArchitectures(ctx context.Context, imageRef string, pullSecret []byte) ([]string, error)
 }
 
 type RegistryClientImageMetadataProvider struct {
@@ -0,0 +1,190 @@
+package util
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openshift/hypershift/support/releaseinfo"
+	"github.com/openshift/hypershift/support/releaseinfo/registryclient"
+	"github.com/openshift/hypershift/support/thirdparty/library-go/pkg/image/reference"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const (
+	// imageMirrorResolutionTTL is how long the resolution of an image is
+	// cached. Unreachable images are retried sooner.
+	imageMirrorResolutionTTL            = time.Hour
+	unreachableImageMirrorResolutionTTL = 5 * time.Minute
+
+	imageMirrorResolutionParallelism = 10
+)
+
+// ImageMirrorResolver resolves image references to the first reachable
+// location among the mirrors configured for them by the management cluster
+// ImageDigestMirrorSets and ImageContentSourcePolicies, and the original
+// reference. This makes the mirrors usable by components whose images are not
+// pulled by management cluster nodes, like the ones running on the data plane.
+//
+// A nil ImageMirrorResolver leaves images unchanged.
+type ImageMirrorResolver struct {
+	// OpenShiftImageRegistryOverrides maps source repositories to their mirrors.
+	OpenShiftImageRegistryOverrides map[string][]string
+
+	// CheckImage returns an error if the image can't be pulled. Defaults to
+	// fetching the image manifest.
+	CheckImage func(ctx context.Context, image string, pullSecret []byte) error
+
+	lock  sync.Mutex
+	cache map[string]imageMirrorResolution
+}
+
+type imageMirrorResolution struct {
+	image      string
+	err        error
+	resolvedAt time.Time
+}
+
+// NewImageMirrorResolver returns a resolver for the given overrides, or nil if
+// there are none.
+func NewImageMirrorResolver(openShiftImageRegistryOverrides map[string][]string) *ImageMirrorResolver {
+	if len(openShiftImageRegistryOverrides) == 0 {
+		return nil
+	}
+	return &ImageMirrorResolver{OpenShiftImageRegistryOverrides: openShiftImageRegistryOverrides}
+}
+
+// Resolve returns the first reachable mirror of the image, or the image itself
+// if it has no reachable mirror. Images without configured mirrors are returned
+// unchanged without being checked. An error is returned if neither the mirrors
+// nor the image are reachable.
+func (r *ImageMirrorResolver) Resolve(ctx context.Context, image string, pullSecret []byte) (string, error) {
+	if r == nil {
+		return image, nil
+	}
+	candidates := r.candidates(image)
+	if len(candidates) == 0 {
+		return image, nil
+	}
+
+	r.lock.Lock()
+	if r.cache == nil {
+		r.cache = map[string]imageMirrorResolution{}
+	}
+	cached, ok := r.cache[image]
+	r.lock.Unlock()
+	if ok {
+		ttl := imageMirrorResolutionTTL
+		if cached.err != nil {
+			ttl = unreachableImageMirrorResolutionTTL
+		}
+		if time.Since(cached.resolvedAt) < ttl {
+			return cached.image, cached.err
+		}
+	}
+
+	checkImage := r.CheckImage
+	if checkImage == nil {
+		checkImage = func(ctx context.Context, image string, pullSecret []byte) error {
+			_, err := registryclient.GetManifest(ctx, image, pullSecret)
+			return err
+		}
+	}
+
+	log := ctrl.LoggerFrom(ctx)
+	resolution := imageMirrorResolution{image: image, resolvedAt: time.Now()}
+	var errs []string
+	for _, candidate := range append(candidates, image) {
+		err := checkImage(ctx, candidate, pullSecret)
+		if err == nil {
+			resolution.image = candidate
+			errs = nil
+			break
+		}
+		log.V(4).Info("Image is not reachable", "image", candidate, "error", err.Error())
+		errs = append(errs, fmt.Sprintf("%s: %v", candidate, err))
+	}
+	if len(errs) > 0 {
+		resolution.err = fmt.Errorf("image %s is not reachable from any mirror: %s", image, strings.Join(errs, "; "))
+	}
+
+	r.lock.Lock()
+	r.cache[image] = resolution
+	r.lock.Unlock()
+	return resolution.image, resolution.err
+}
+
+// ResolveReleaseImage returns a copy of the release image whose component
+// images point to their first reachable mirror, along with the sorted names of
+// the components whose images could not be reached.
+func (r *ImageMirrorResolver) ResolveReleaseImage(ctx context.Context, releaseImage *releaseinfo.ReleaseImage, pullSecret []byte) (*releaseinfo.ReleaseImage, []string) {
+	if r == nil || releaseImage == nil || releaseImage.ImageStream == nil {
+		return releaseImage, nil
+	}
+
+	resolved := &releaseinfo.ReleaseImage{
+		ImageStream:    releaseImage.ImageStream.DeepCopy(),
+		StreamMetadata: releaseImage.StreamMetadata,
+	}
+
+	var (
+		wg          sync.WaitGroup
+		lock        sync.Mutex
+		unreachable []string
+	)
+	sem := make(chan struct{}, imageMirrorResolutionParallelism)
+	for i := range resolved.ImageStream.Spec.Tags {
+		tag := &resolved.ImageStream.Spec.Tags[i]
+		if tag.From == nil || tag.From.Name == "" {
+			continue
+		}
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			image, err := r.Resolve(ctx, tag.From.Name, pullSecret)
+			tag.From.Name = image
+			if err != nil {
+				lock.Lock()
+				unreachable = append(unreachable, tag.Name)
+				lock.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	sort.Strings(unreachable)
+	return resolved, unreachable
+}
+
+// candidates returns the mirror references for the image, in order of
+// preference. The longest matching source wins, as for ImageDigestMirrorSets.
+func (r *ImageMirrorResolver) candidates(image string) []string {
+	ref, err := reference.Parse(image)
+	if err != nil {
+		return nil
+	}
+	repository := ref.AsRepository().Exact()
+
+	var source string
+	for s := range r.OpenShiftImageRegistryOverrides {
+		if (repository == s || strings.HasPrefix(repository, s+"/")) && len(s) > len(source) {
+			source = s
+		}
+	}
+	if source == "" {
+		return nil
+	}
+
+	var candidates []string
+	for _, mirror := range r.OpenShiftImageRegistryOverrides[source] {
+		candidates = append(candidates, mirror+strings.TrimPrefix(image, source))
+	}
+	return candidates
+}
@@ -0,0 +1,129 @@
+package util
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	imageapi "github.com/openshift/api/image/v1"
+	"github.com/openshift/hypershift/support/releaseinfo"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestImageMirrorResolverResolve(t *testing.T) {
+	overrides := map[string][]string{
+		"quay.io/openshift-release-dev":                    {"mirror-a.example.com/ocp"},
+		"quay.io/openshift-release-dev/ocp-v4.0-art-dev":   {"mirror-b.example.com/art", "mirror-c.example.com/art"},
+		"registry.redhat.io/unrelated/ocp-v4.0-art-dev-ex": {"mirror-d.example.com/unrelated"},
+	}
+	testCases := []struct {
+		name        string
+		image       string
+		reachable   []string
+		expected    string
+		expectedErr bool
+	}{
+		{
+			name:     "When the image has no mirror it should be returned unchanged",
+			image:    "registry.example.com/foo/bar:latest",
+			expected: "registry.example.com/foo/bar:latest",
+		},
+		{
+			name:      "When the first mirror is reachable it should be used",
+			image:     "quay.io/openshift-release-dev/ocp-v4.0-art-dev:1234",
+			reachable: []string{"mirror-b.example.com/art:1234", "mirror-c.example.com/art:1234"},
+			expected:  "mirror-b.example.com/art:1234",
+		},
+		{
+			name:      "When only a later mirror is reachable it should be used",
+			image:     "quay.io/openshift-release-dev/ocp-v4.0-art-dev:1234",
+			reachable: []string{"mirror-c.example.com/art:1234"},
+			expected:  "mirror-c.example.com/art:1234",
+		},
+		{
+			name:      "When a parent repository is mirrored it should be used",
+			image:     "quay.io/openshift-release-dev/ocp-release:4.15.0",
+			reachable: []string{"mirror-a.example.com/ocp/ocp-release:4.15.0"},
+			expected:  "mirror-a.example.com/ocp/ocp-release:4.15.0",
+		},
+		{
+			name:      "When no mirror is reachable the original image should be used",
+			image:     "quay.io/openshift-release-dev/ocp-v4.0-art-dev:1234",
+			reachable: []string{"quay.io/openshift-release-dev/ocp-v4.0-art-dev:1234"},
+			expected:  "quay.io/openshift-release-dev/ocp-v4.0-art-dev:1234",
+		},
+		{
+			name:        "When nothing is reachable it should fail",
+			image:       "quay.io/openshift-release-dev/ocp-v4.0-art-dev:1234",
+			expected:    "quay.io/openshift-release-dev/ocp-v4.0-art-dev:1234",
+			expectedErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			resolver := &ImageMirrorResolver{
+				OpenShiftImageRegistryOverrides: overrides,
+				CheckImage:                      fakeCheckImage(tc.reachable...),
+			}
+			image, err := resolver.Resolve(context.Background(), tc.image, nil)
+			if tc.expectedErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+			g.Expect(image).To(Equal(tc.expected))
+		})
+	}
+}
+
+func TestImageMirrorResolverResolveReleaseImage(t *testing.T) {
+	g := NewWithT(t)
+
+	releaseImage := &releaseinfo.ReleaseImage{
+		ImageStream: &imageapi.ImageStream{
+			Spec: imageapi.ImageStreamSpec{
+				Tags: []imageapi.TagReference{
+					{Name: "konnectivity-agent", From: &corev1.ObjectReference{Name: "quay.io/openshift-release-dev/ocp-v4.0-art-dev:1"}},
+					{Name: "cli", From: &corev1.ObjectReference{Name: "quay.io/openshift-release-dev/ocp-v4.0-art-dev:2"}},
+					{Name: "etcd", From: &corev1.ObjectReference{Name: "quay.io/openshift-release-dev/ocp-v4.0-art-dev:3"}},
+				},
+			},
+		},
+	}
+	resolver := &ImageMirrorResolver{
+		OpenShiftImageRegistryOverrides: map[string][]string{
+			"quay.io/openshift-release-dev/ocp-v4.0-art-dev": {"mirror.example.com/art"},
+		},
+		CheckImage: fakeCheckImage(
+			"mirror.example.com/art:1",
+			"quay.io/openshift-release-dev/ocp-v4.0-art-dev:2",
+		),
+	}
+
+	resolved, unreachable := resolver.ResolveReleaseImage(context.Background(), releaseImage, nil)
+	g.Expect(unreachable).To(Equal([]string{"etcd"}))
+	g.Expect(resolved.ComponentImages()).To(Equal(map[string]string{
+		"konnectivity-agent": "mirror.example.com/art:1",
+		"cli":                "quay.io/openshift-release-dev/ocp-v4.0-art-dev:2",
+		"etcd":               "quay.io/openshift-release-dev/ocp-v4.0-art-dev:3",
+	}))
+	g.Expect(releaseImage.ComponentImages()["konnectivity-agent"]).To(Equal("quay.io/openshift-release-dev/ocp-v4.0-art-dev:1"), "the original release image must not be modified")
+
+	var nilResolver *ImageMirrorResolver
+	unchanged, unreachable := nilResolver.ResolveReleaseImage(context.Background(), releaseImage, nil)
+	g.Expect(unchanged).To(BeIdenticalTo(releaseImage))
+	g.Expect(unreachable).To(BeEmpty())
+}
+
+func fakeCheckImage(reachable ...string) func(context.Context, string, []byte) error {
+	return func(_ context.Context, image string, _ []byte) error {
+		for _, r := range reachable {
+			if r == image {
+				return nil
+			}
+		}
+		return fmt.Errorf("manifest unknown")
+	}
+}
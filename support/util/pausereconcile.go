@@ -3,6 +3,7 @@ package util
 import (
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -45,6 +46,20 @@ func ProcessPausedUntilField(pausedUntilField *string, now time.Time) (isPaused
 	return false, time.Duration(0), fmt.Errorf("invalid value specified in pausedUntil field: %q. Considering the resource as not paused", pausedUntilVal)
 }
 
+// HostedClusterPausedUntil returns the pausedUntil field of the HostedCluster if its pause scope covers the given
+// scope, nil otherwise. The All scope only covers itself, so consumers pausing their whole reconciliation keep
+// running while the HostedCluster is only partially paused.
+func HostedClusterPausedUntil(hc *hyperv1.HostedCluster, scope hyperv1.PauseScope) *string {
+	hcScope := hc.Spec.PauseScope
+	if hcScope == "" {
+		hcScope = hyperv1.PauseScopeAll
+	}
+	if hcScope != scope {
+		return nil
+	}
+	return hc.Spec.PausedUntil
+}
+
 // GenerateReconciliationActiveCondition will generate the resource condition that reflects the state of reconciliation
 // on the resource.
 func GenerateReconciliationActiveCondition(pausedUntilField *string, objectGeneration int64) metav1.Condition {
@@ -78,3 +93,13 @@ func GenerateReconciliationActiveCondition(pausedUntilField *string, objectGener
 		ObservedGeneration: objectGeneration,
 	}
 }
+
+// GenerateHostedClusterReconciliationActiveCondition generates the ReconciliationActive condition of a HostedCluster,
+// which is false while any scope of the HostedCluster is paused.
+func GenerateHostedClusterReconciliationActiveCondition(hc *hyperv1.HostedCluster) metav1.Condition {
+	condition := GenerateReconciliationActiveCondition(hc.Spec.PausedUntil, hc.Generation)
+	if condition.Status == metav1.ConditionFalse && hc.Spec.PauseScope != "" && hc.Spec.PauseScope != hyperv1.PauseScopeAll {
+		condition.Message = strings.Replace(condition.Message, "Reconciliation", fmt.Sprintf("Reconciliation of %s", hc.Spec.PauseScope), 1)
+	}
+	return condition
+}
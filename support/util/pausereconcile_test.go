@@ -137,3 +137,64 @@ func TestGenerateReconciliationPausedCondition(t *testing.T) {
 		})
 	}
 }
+
+func TestHostedClusterPausedUntil(t *testing.T) {
+	pausedUntil := pointer.String("true")
+	testsCases := []struct {
+		name        string
+		pauseScope  hyperv1.PauseScope
+		scope       hyperv1.PauseScope
+		expectPause bool
+	}{
+		{
+			name:        "When the pause scope is not set it should pause everything",
+			scope:       hyperv1.PauseScopeAll,
+			expectPause: true,
+		},
+		{
+			name:        "When the pause scope matches it should pause",
+			pauseScope:  hyperv1.PauseScopeNodePoolRollouts,
+			scope:       hyperv1.PauseScopeNodePoolRollouts,
+			expectPause: true,
+		},
+		{
+			name:       "When NodePool rollouts are paused it should not pause everything",
+			pauseScope: hyperv1.PauseScopeNodePoolRollouts,
+			scope:      hyperv1.PauseScopeAll,
+		},
+		{
+			name:       "When everything is paused it should not pause only control plane upgrades",
+			pauseScope: hyperv1.PauseScopeAll,
+			scope:      hyperv1.PauseScopeControlPlaneUpgrades,
+		},
+	}
+	for _, tc := range testsCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewGomegaWithT(t)
+			hc := &hyperv1.HostedCluster{Spec: hyperv1.HostedClusterSpec{PausedUntil: pausedUntil, PauseScope: tc.pauseScope}}
+			if tc.expectPause {
+				g.Expect(HostedClusterPausedUntil(hc, tc.scope)).To(Equal(pausedUntil))
+			} else {
+				g.Expect(HostedClusterPausedUntil(hc, tc.scope)).To(BeNil())
+			}
+		})
+	}
+}
+
+func TestGenerateHostedClusterReconciliationActiveCondition(t *testing.T) {
+	g := NewGomegaWithT(t)
+	hc := &hyperv1.HostedCluster{
+		ObjectMeta: metav1.ObjectMeta{Generation: 3},
+		Spec: hyperv1.HostedClusterSpec{
+			PausedUntil: pointer.String("true"),
+			PauseScope:  hyperv1.PauseScopeNodePoolRollouts,
+		},
+	}
+	g.Expect(GenerateHostedClusterReconciliationActiveCondition(hc)).To(BeEquivalentTo(metav1.Condition{
+		Type:               string(hyperv1.ReconciliationActive),
+		Status:             metav1.ConditionFalse,
+		Reason:             hyperv1.ReconciliationPausedConditionReason,
+		Message:            "Reconciliation of NodePoolRollouts paused until field removed",
+		ObservedGeneration: 3,
+	}))
+}
@@ -0,0 +1,25 @@
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// MergePullSecrets merges the registry credentials of the given
+// .dockerconfigjson pull secrets. Credentials for a registry present in more
+// than one pull secret are taken from the last one.
+func MergePullSecrets(pullSecrets ...[]byte) ([]byte, error) {
+	auths := map[string]json.RawMessage{}
+	for i, pullSecret := range pullSecrets {
+		config := struct {
+			Auths map[string]json.RawMessage `json:"auths"`
+		}{}
+		if err := json.Unmarshal(pullSecret, &config); err != nil {
+			return nil, fmt.Errorf("invalid pull secret at index %d: %w", i, err)
+		}
+		for registry, auth := range config.Auths {
+			auths[registry] = auth
+		}
+	}
+	return json.Marshal(map[string]interface{}{"auths": auths})
+}
@@ -0,0 +1,59 @@
+package util
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestMergePullSecrets(t *testing.T) {
+	testCases := []struct {
+		name        string
+		pullSecrets []string
+		expected    string
+		expectedErr bool
+	}{
+		{
+			name:        "When a single pull secret is given it should be returned",
+			pullSecrets: []string{`{"auths":{"quay.io":{"auth":"Zm9vOmJhcg=="}}}`},
+			expected:    `{"auths":{"quay.io":{"auth":"Zm9vOmJhcg=="}}}`,
+		},
+		{
+			name: "When pull secrets have different registries they should be combined",
+			pullSecrets: []string{
+				`{"auths":{"quay.io":{"auth":"Zm9vOmJhcg=="}}}`,
+				`{"auths":{"registry.example.com":{"auth":"YmF6OnF1eA==","email":"user@example.com"}}}`,
+			},
+			expected: `{"auths":{"quay.io":{"auth":"Zm9vOmJhcg=="},"registry.example.com":{"auth":"YmF6OnF1eA==","email":"user@example.com"}}}`,
+		},
+		{
+			name: "When pull secrets share a registry the last one should win",
+			pullSecrets: []string{
+				`{"auths":{"quay.io":{"auth":"Zm9vOmJhcg=="}}}`,
+				`{"auths":{"quay.io":{"auth":"YmF6OnF1eA=="}}}`,
+			},
+			expected: `{"auths":{"quay.io":{"auth":"YmF6OnF1eA=="}}}`,
+		},
+		{
+			name:        "When a pull secret is not valid JSON it should fail",
+			pullSecrets: []string{`{"auths":{"quay.io":{"auth":"Zm9vOmJhcg=="}}}`, `not-json`},
+			expectedErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			var pullSecrets [][]byte
+			for _, pullSecret := range tc.pullSecrets {
+				pullSecrets = append(pullSecrets, []byte(pullSecret))
+			}
+			merged, err := MergePullSecrets(pullSecrets...)
+			if tc.expectedErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(string(merged)).To(MatchJSON(tc.expected))
+		})
+	}
+}
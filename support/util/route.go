@@ -109,11 +109,13 @@ func ReconcileExternalRoute(route *routev1.Route, hostname string, defaultIngres
 	return nil
 }
 
-func ReconcileInternalRoute(route *routev1.Route, hcName string, serviceName string) error {
+// ReconcileInternalRoute reconciles a route of the internal DNS domain of the
+// cluster, as returned by InternalDNSDomain.
+func ReconcileInternalRoute(route *routev1.Route, internalDNSDomain string, serviceName string) error {
 	AddHCPRouteLabel(route)
 	AddInternalRouteLabel(route)
 	if route.Spec.Host == "" {
-		route.Spec.Host = fmt.Sprintf("%s.apps.%s.hypershift.local", strings.TrimSuffix(route.Name, "-internal"), hcName)
+		route.Spec.Host = fmt.Sprintf("%s.apps.%s", strings.TrimSuffix(route.Name, "-internal"), internalDNSDomain)
 	}
 	route.Spec.To = routev1.RouteTargetReference{
 		Kind: "Service",
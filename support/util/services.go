@@ -5,6 +5,7 @@ import (
 	"strings"
 	"time"
 
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
 	"github.com/openshift/hypershift/support/events"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/duration"
@@ -26,3 +27,18 @@ func CollectLBMessageIfNotProvisioned(svc *corev1.Service, messageCollector even
 
 	return message, nil
 }
+
+// ReconcileTopologyAwareRouting enables topology aware routing on an internal service of a highly available control
+// plane, so that traffic is kept in the zone of the client when the endpoints are spread evenly enough across zones,
+// which cuts cross-zone data transfer costs. Kube-proxy falls back to routing to every endpoint otherwise. Single
+// replica control planes have a single endpoint per service, so topology aware routing is disabled.
+func ReconcileTopologyAwareRouting(svc *corev1.Service, availability hyperv1.AvailabilityPolicy) {
+	if availability != hyperv1.HighlyAvailable {
+		delete(svc.Annotations, corev1.AnnotationTopologyMode)
+		return
+	}
+	if svc.Annotations == nil {
+		svc.Annotations = map[string]string{}
+	}
+	svc.Annotations[corev1.AnnotationTopologyMode] = "Auto"
+}
@@ -116,6 +116,11 @@ func TestMain(m *testing.M) {
 	flag.StringVar(&globalOpts.ManagementClusterName, "e2e.management-cluster-name", "", "Name of the management cluster's HostedCluster (required to test request serving isolation)")
 	flag.BoolVar(&globalOpts.DisablePKIReconciliation, "e2e.disable-pki-reconciliation", false, "If set, TestUpgradeControlPlane will upgrade the control plane without reconciling the pki components")
 	flag.Var(&globalOpts.configurableClusterOptions.Annotations, "e2e.annotations", "Annotations to apply to the HostedCluster (key=value). Can be specified multiple times")
+	flag.DurationVar(&globalOpts.Soak.Duration, "e2e.soak-duration", 0, "If set, TestControlPlaneSoak disrupts the control plane for this long while measuring the hosted API availability")
+	flag.DurationVar(&globalOpts.Soak.DisruptionInterval, "e2e.soak-disruption-interval", 30*time.Second, "The time between two control plane disruptions during a soak test")
+	flag.Float64Var(&globalOpts.Soak.MinAvailability, "e2e.soak-min-availability", 99, "The minimum hosted API availability percentage required for a soak test to pass")
+	flag.BoolVar(&globalOpts.Soak.DrainManagementNodes, "e2e.soak-drain-management-nodes", false, "If set, soak tests also drain the control plane pods from management cluster nodes")
+	flag.StringVar(&globalOpts.ExistingCluster, "e2e.use-existing-cluster", "", "Run the tests against an existing HostedCluster (namespace/name) instead of creating and destroying a cluster per test")
 
 	flag.Parse()
 
@@ -133,6 +138,11 @@ func TestMain(m *testing.M) {
 		os.Exit(1)
 	}
 
+	if globalOpts.ExistingCluster != "" {
+		namespace, name, _ := strings.Cut(globalOpts.ExistingCluster, "/")
+		e2eutil.UseExistingCluster(namespace, name)
+	}
+
 	os.Exit(main(m))
 }
 
@@ -153,7 +163,7 @@ func main(m *testing.M) int {
 		log.Info("tests received shutdown signal and will be cancelled")
 		cancel()
 
-		if globalOpts.Platform == hyperv1.AWSPlatform {
+		if globalOpts.Platform == hyperv1.AWSPlatform && globalOpts.ExistingCluster == "" {
 			cleanupSharedOIDCProvider()
 		}
 	}()
@@ -165,7 +175,7 @@ func main(m *testing.M) int {
 	}
 	defer alertSLOs(testContext)
 
-	if globalOpts.Platform == hyperv1.AWSPlatform {
+	if globalOpts.Platform == hyperv1.AWSPlatform && globalOpts.ExistingCluster == "" {
 		if err := setupSharedOIDCProvider(); err != nil {
 			log.Error(err, "failed to setup shared OIDC provider")
 			return -1
@@ -386,6 +396,20 @@ type options struct {
 	// If set, the UpgradeControlPlane test will upgrade control plane without
 	// reconciling PKI.
 	DisablePKIReconciliation bool
+
+	// Soak configures TestControlPlaneSoak, which only runs when a duration is set.
+	Soak soakOptions
+
+	// ExistingCluster is the namespace/name of a HostedCluster tests run
+	// against instead of creating and destroying a cluster per test.
+	ExistingCluster string
+}
+
+type soakOptions struct {
+	Duration             time.Duration
+	DisruptionInterval   time.Duration
+	MinAvailability      float64
+	DrainManagementNodes bool
 }
 
 type configurableClusterOptions struct {
@@ -578,7 +602,7 @@ func (o *options) Validate() error {
 		errs = append(errs, fmt.Errorf("latest release image is required"))
 	}
 
-	if len(o.configurableClusterOptions.BaseDomain) == 0 {
+	if len(o.configurableClusterOptions.BaseDomain) == 0 && o.ExistingCluster == "" {
 		// The KubeVirt e2e tests don't require a base domain right now.
 		//
 		// For KubeVirt, the e2e tests generate a base domain within the *.apps domain
@@ -607,6 +631,16 @@ func (o *options) Validate() error {
 		}
 	}
 
+	if o.Soak.Duration > 0 && o.Soak.DisruptionInterval <= 0 {
+		errs = append(errs, fmt.Errorf("soak disruption interval must be positive"))
+	}
+
+	if o.ExistingCluster != "" {
+		if namespace, name, ok := strings.Cut(o.ExistingCluster, "/"); !ok || namespace == "" || name == "" {
+			errs = append(errs, fmt.Errorf("existing cluster must be specified as namespace/name, got %q", o.ExistingCluster))
+		}
+	}
+
 	return apierr.NewAggregate(errs)
 }
 
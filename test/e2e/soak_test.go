@@ -0,0 +1,52 @@
+//go:build e2e
+// +build e2e
+
+package e2e
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	e2eutil "github.com/openshift/hypershift/test/e2e/util"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// TestControlPlaneSoak launches a HighlyAvailable control plane and
+// continuously disrupts it while measuring the hosted API availability. It only
+// runs when a soak duration is configured.
+func TestControlPlaneSoak(t *testing.T) {
+	if globalOpts.Soak.Duration == 0 {
+		t.Skip("soak duration is not set")
+	}
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(testContext)
+	defer cancel()
+
+	clusterOpts := globalOpts.DefaultClusterOptions(t)
+	clusterOpts.ControlPlaneAvailabilityPolicy = string(hyperv1.HighlyAvailable)
+	clusterOpts.NodePoolReplicas = 0
+
+	e2eutil.NewHypershiftTest(t, ctx, func(t *testing.T, g Gomega, mgtClient crclient.Client, hostedCluster *hyperv1.HostedCluster) {
+		soakOpts := e2eutil.SoakOptions{
+			Duration:           globalOpts.Soak.Duration,
+			DisruptionInterval: globalOpts.Soak.DisruptionInterval,
+			ProbeInterval:      time.Second,
+			Disruptions:        []e2eutil.SoakDisruptionType{e2eutil.KillControlPlanePod, e2eutil.RestartEtcdMember},
+		}
+		if globalOpts.Soak.DrainManagementNodes {
+			soakOpts.Disruptions = append(soakOpts.Disruptions, e2eutil.DrainManagementNode)
+		}
+
+		report := e2eutil.RunControlPlaneSoak(t, ctx, mgtClient, hostedCluster, soakOpts)
+		t.Logf("Hosted API availability: %.3f%% (%d/%d probes failed, longest outage %s, %d disruptions)",
+			report.Availability, report.FailedProbes, report.Probes, report.LongestOutage.Duration, len(report.Disruptions))
+		if err := e2eutil.WriteAvailabilityReport(report, globalOpts.ArtifactDir); err != nil {
+			t.Errorf("failed to write availability report: %v", err)
+		}
+		g.Expect(report.Availability).To(BeNumerically(">=", globalOpts.Soak.MinAvailability), "hosted API availability is below the required minimum")
+	}).Execute(&clusterOpts, globalOpts.Platform, globalOpts.ArtifactDir, globalOpts.ServiceAccountSigningKey)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -25,6 +26,51 @@ import (
 	crclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// PlatformFixture creates and destroys hosted clusters for a single platform.
+// Fixtures for the platforms supported by the CLI are registered by default;
+// downstream platform vendors can plug in their own with RegisterPlatformFixture.
+type PlatformFixture interface {
+	// ClusterOptions mutates the cluster creation options as necessary to deal
+	// with platform options the test caller doesn't know or care about in advance.
+	ClusterOptions(ctx context.Context, hc *hyperv1.HostedCluster, opts *core.CreateOptions) error
+
+	// CreateCluster creates the hosted cluster described by opts.
+	CreateCluster(ctx context.Context, opts *core.CreateOptions) error
+
+	// DestroyCluster destroys the hosted cluster and any infrastructure created
+	// for it. createOpts are the options the cluster was created with.
+	DestroyCluster(ctx context.Context, t *testing.T, hc *hyperv1.HostedCluster, createOpts *core.CreateOptions, opts *core.DestroyOptions) error
+}
+
+var (
+	platformFixturesLock sync.RWMutex
+	platformFixtures     = map[hyperv1.PlatformType]PlatformFixture{
+		hyperv1.AWSPlatform:      awsFixture{},
+		hyperv1.NonePlatform:     noneFixture{},
+		hyperv1.KubevirtPlatform: kubevirtFixture{},
+		hyperv1.AzurePlatform:    azureFixture{},
+		hyperv1.PowerVSPlatform:  powerVSFixture{},
+	}
+)
+
+// RegisterPlatformFixture registers the fixture used to create and destroy
+// clusters for the given platform, replacing any previously registered fixture.
+func RegisterPlatformFixture(platform hyperv1.PlatformType, fixture PlatformFixture) {
+	platformFixturesLock.Lock()
+	defer platformFixturesLock.Unlock()
+	platformFixtures[platform] = fixture
+}
+
+func platformFixtureFor(platform hyperv1.PlatformType) (PlatformFixture, error) {
+	platformFixturesLock.RLock()
+	defer platformFixturesLock.RUnlock()
+	fixture, ok := platformFixtures[platform]
+	if !ok {
+		return nil, fmt.Errorf("unsupported platform %s", platform)
+	}
+	return fixture, nil
+}
+
 // createClusterOpts mutates the cluster creation options according to the
 // cluster's platform as necessary to deal with options the test caller doesn't
 // know or care about in advance.
@@ -35,38 +81,68 @@ func createClusterOpts(ctx context.Context, client crclient.Client, hc *hyperv1.
 	opts.Name = hc.Name
 	opts.NonePlatform.ExposeThroughLoadBalancer = true
 
-	switch hc.Spec.Platform.Type {
-	case hyperv1.AWSPlatform:
-		opts.InfraID = hc.Name
-	case hyperv1.PowerVSPlatform:
-		opts.InfraID = fmt.Sprintf("%s-infra", hc.Name)
+	fixture, err := platformFixtureFor(hc.Spec.Platform.Type)
+	if err != nil {
+		return nil, err
+	}
+	if err := fixture.ClusterOptions(ctx, hc, opts); err != nil {
+		return nil, err
+	}
+
+	return opts, nil
+}
+
+// existingClusterOpts mutates the cluster creation options so they describe an
+// existing cluster which was not created by the test, allowing the validation
+// steps of the framework to run against it.
+func existingClusterOpts(ctx context.Context, client crclient.Client, hc *hyperv1.HostedCluster, opts *core.CreateOptions) (*core.CreateOptions, error) {
+	opts.Namespace = hc.Namespace
+	opts.Name = hc.Name
+	opts.InfraID = hc.Spec.InfraID
+	opts.ReleaseImage = hc.Spec.Release.Image
+	if hc.Spec.Platform.AWS != nil {
+		opts.AWSPlatform.EndpointAccess = string(hc.Spec.Platform.AWS.EndpointAccess)
 	}
 
+	nodePools := &hyperv1.NodePoolList{}
+	if err := client.List(ctx, nodePools, crclient.InNamespace(hc.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list nodepools: %w", err)
+	}
+	var replicas int32
+	for _, nodePool := range nodePools.Items {
+		if nodePool.Spec.ClusterName != hc.Name {
+			continue
+		}
+		if nodePool.Spec.Replicas != nil {
+			replicas += *nodePool.Spec.Replicas
+		} else {
+			replicas += nodePool.Status.Replicas
+		}
+	}
+	// The default cluster options configure a single zone, so the expected node
+	// count is the sum of the replicas of all the cluster's nodepools.
+	opts.NodePoolReplicas = replicas
+
 	return opts, nil
 }
 
-// createCluster calls the correct cluster create CLI function based on the
+// createCluster calls the create function of the fixture registered for the
 // cluster platform.
 func createCluster(ctx context.Context, hc *hyperv1.HostedCluster, opts *core.CreateOptions) error {
-	switch hc.Spec.Platform.Type {
-	case hyperv1.AWSPlatform:
-		return aws.CreateCluster(ctx, opts)
-	case hyperv1.NonePlatform:
-		return none.CreateCluster(ctx, opts)
-	case hyperv1.KubevirtPlatform:
-		return kubevirt.CreateCluster(ctx, opts)
-	case hyperv1.AzurePlatform:
-		return azure.CreateCluster(ctx, opts)
-	case hyperv1.PowerVSPlatform:
-		return powervs.CreateCluster(ctx, opts)
-	default:
-		return fmt.Errorf("unsupported platform %s", hc.Spec.Platform.Type)
-	}
-}
-
-// destroyCluster calls the correct cluster destroy CLI function based on the
-// cluster platform and the options used to create the cluster.
+	fixture, err := platformFixtureFor(hc.Spec.Platform.Type)
+	if err != nil {
+		return err
+	}
+	return fixture.CreateCluster(ctx, opts)
+}
+
+// destroyCluster calls the destroy function of the fixture registered for the
+// cluster platform with the options used to create the cluster.
 func destroyCluster(ctx context.Context, t *testing.T, hc *hyperv1.HostedCluster, createOpts *core.CreateOptions) error {
+	fixture, err := platformFixtureFor(hc.Spec.Platform.Type)
+	if err != nil {
+		return err
+	}
 	opts := &core.DestroyOptions{
 		Namespace:          hc.Namespace,
 		Name:               hc.Name,
@@ -74,43 +150,97 @@ func destroyCluster(ctx context.Context, t *testing.T, hc *hyperv1.HostedCluster
 		ClusterGracePeriod: 15 * time.Minute,
 		Log:                NewLogr(t),
 	}
-	switch hc.Spec.Platform.Type {
-	case hyperv1.AWSPlatform:
-		opts.AWSPlatform = core.AWSPlatformDestroyOptions{
-			BaseDomain:         createOpts.BaseDomain,
-			AWSCredentialsFile: createOpts.AWSPlatform.AWSCredentialsFile,
-			PreserveIAM:        false,
-			Region:             createOpts.AWSPlatform.Region,
-			PostDeleteAction:   validateAWSGuestResourcesDeletedFunc(ctx, t, hc.Spec.InfraID, createOpts.AWSPlatform.AWSCredentialsFile, createOpts.AWSPlatform.Region),
-		}
-		return aws.DestroyCluster(ctx, opts)
-	case hyperv1.NonePlatform, hyperv1.KubevirtPlatform:
-		return none.DestroyCluster(ctx, opts)
-	case hyperv1.AzurePlatform:
-		opts.AzurePlatform = core.AzurePlatformDestroyOptions{
-			CredentialsFile: createOpts.AzurePlatform.CredentialsFile,
-			Location:        createOpts.AzurePlatform.Location,
-		}
-		return azure.DestroyCluster(ctx, opts)
-	case hyperv1.PowerVSPlatform:
-		opts.PowerVSPlatform = core.PowerVSPlatformDestroyOptions{
-			BaseDomain:             createOpts.BaseDomain,
-			ResourceGroup:          createOpts.PowerVSPlatform.ResourceGroup,
-			Region:                 createOpts.PowerVSPlatform.Region,
-			Zone:                   createOpts.PowerVSPlatform.Zone,
-			VPCRegion:              createOpts.PowerVSPlatform.VPCRegion,
-			CloudInstanceID:        createOpts.PowerVSPlatform.CloudInstanceID,
-			CloudConnection:        createOpts.PowerVSPlatform.CloudConnection,
-			VPC:                    createOpts.PowerVSPlatform.VPC,
-			PER:                    createOpts.PowerVSPlatform.PER,
-			TransitGatewayLocation: createOpts.PowerVSPlatform.TransitGatewayLocation,
-			TransitGateway:         createOpts.PowerVSPlatform.TransitGateway,
-		}
-		return powervs.DestroyCluster(ctx, opts)
+	return fixture.DestroyCluster(ctx, t, hc, createOpts, opts)
+}
+
+type awsFixture struct{}
+
+func (awsFixture) ClusterOptions(_ context.Context, hc *hyperv1.HostedCluster, opts *core.CreateOptions) error {
+	opts.InfraID = hc.Name
+	return nil
+}
+
+func (awsFixture) CreateCluster(ctx context.Context, opts *core.CreateOptions) error {
+	return aws.CreateCluster(ctx, opts)
+}
+
+func (awsFixture) DestroyCluster(ctx context.Context, t *testing.T, hc *hyperv1.HostedCluster, createOpts *core.CreateOptions, opts *core.DestroyOptions) error {
+	opts.AWSPlatform = core.AWSPlatformDestroyOptions{
+		BaseDomain:         createOpts.BaseDomain,
+		AWSCredentialsFile: createOpts.AWSPlatform.AWSCredentialsFile,
+		PreserveIAM:        false,
+		Region:             createOpts.AWSPlatform.Region,
+		PostDeleteAction:   validateAWSGuestResourcesDeletedFunc(ctx, t, hc.Spec.InfraID, createOpts.AWSPlatform.AWSCredentialsFile, createOpts.AWSPlatform.Region),
+	}
+	return aws.DestroyCluster(ctx, opts)
+}
+
+type noneFixture struct{}
+
+func (noneFixture) ClusterOptions(context.Context, *hyperv1.HostedCluster, *core.CreateOptions) error {
+	return nil
+}
+
+func (noneFixture) CreateCluster(ctx context.Context, opts *core.CreateOptions) error {
+	return none.CreateCluster(ctx, opts)
+}
+
+func (noneFixture) DestroyCluster(ctx context.Context, _ *testing.T, _ *hyperv1.HostedCluster, _ *core.CreateOptions, opts *core.DestroyOptions) error {
+	return none.DestroyCluster(ctx, opts)
+}
+
+type kubevirtFixture struct {
+	noneFixture
+}
+
+func (kubevirtFixture) CreateCluster(ctx context.Context, opts *core.CreateOptions) error {
+	return kubevirt.CreateCluster(ctx, opts)
+}
+
+type azureFixture struct{}
+
+func (azureFixture) ClusterOptions(context.Context, *hyperv1.HostedCluster, *core.CreateOptions) error {
+	return nil
+}
+
+func (azureFixture) CreateCluster(ctx context.Context, opts *core.CreateOptions) error {
+	return azure.CreateCluster(ctx, opts)
+}
+
+func (azureFixture) DestroyCluster(ctx context.Context, _ *testing.T, _ *hyperv1.HostedCluster, createOpts *core.CreateOptions, opts *core.DestroyOptions) error {
+	opts.AzurePlatform = core.AzurePlatformDestroyOptions{
+		CredentialsFile: createOpts.AzurePlatform.CredentialsFile,
+		Location:        createOpts.AzurePlatform.Location,
+	}
+	return azure.DestroyCluster(ctx, opts)
+}
+
+type powerVSFixture struct{}
+
+func (powerVSFixture) ClusterOptions(_ context.Context, hc *hyperv1.HostedCluster, opts *core.CreateOptions) error {
+	opts.InfraID = fmt.Sprintf("%s-infra", hc.Name)
+	return nil
+}
+
+func (powerVSFixture) CreateCluster(ctx context.Context, opts *core.CreateOptions) error {
+	return powervs.CreateCluster(ctx, opts)
+}
 
-	default:
-		return fmt.Errorf("unsupported cluster platform %s", hc.Spec.Platform.Type)
+func (powerVSFixture) DestroyCluster(ctx context.Context, _ *testing.T, _ *hyperv1.HostedCluster, createOpts *core.CreateOptions, opts *core.DestroyOptions) error {
+	opts.PowerVSPlatform = core.PowerVSPlatformDestroyOptions{
+		BaseDomain:             createOpts.BaseDomain,
+		ResourceGroup:          createOpts.PowerVSPlatform.ResourceGroup,
+		Region:                 createOpts.PowerVSPlatform.Region,
+		Zone:                   createOpts.PowerVSPlatform.Zone,
+		VPCRegion:              createOpts.PowerVSPlatform.VPCRegion,
+		CloudInstanceID:        createOpts.PowerVSPlatform.CloudInstanceID,
+		CloudConnection:        createOpts.PowerVSPlatform.CloudConnection,
+		VPC:                    createOpts.PowerVSPlatform.VPC,
+		PER:                    createOpts.PowerVSPlatform.PER,
+		TransitGatewayLocation: createOpts.PowerVSPlatform.TransitGatewayLocation,
+		TransitGateway:         createOpts.PowerVSPlatform.TransitGateway,
 	}
+	return powervs.DestroyCluster(ctx, opts)
 }
 
 // validateAWSGuestResourcesDeletedFunc waits for 15min or until the guest cluster resources are gone.
@@ -35,6 +35,17 @@ type hypershiftTest struct {
 	hasBeenTornedDown bool
 }
 
+// existingCluster, when set, is the hosted cluster tests run against instead
+// of creating and destroying a cluster for each test.
+var existingCluster *crclient.ObjectKey
+
+// UseExistingCluster makes every test run against the given pre-existing
+// hosted cluster. The cluster is validated before and after each test but it
+// is never destroyed.
+func UseExistingCluster(namespace, name string) {
+	existingCluster = &crclient.ObjectKey{Namespace: namespace, Name: name}
+}
+
 func NewHypershiftTest(t *testing.T, ctx context.Context, test hypershiftTestFunc) *hypershiftTest {
 	client, err := GetClient()
 	if err != nil {
@@ -50,6 +61,11 @@ func NewHypershiftTest(t *testing.T, ctx context.Context, test hypershiftTestFun
 }
 
 func (h *hypershiftTest) Execute(opts *core.CreateOptions, platform hyperv1.PlatformType, artifactDir string, serviceAccountSigningKey []byte) {
+	if existingCluster != nil {
+		h.executeOnExistingCluster(opts, artifactDir)
+		return
+	}
+
 	// create a hypershift cluster for the test
 	hostedCluster := h.createHostedCluster(opts, platform, serviceAccountSigningKey)
 
@@ -90,6 +106,39 @@ func (h *hypershiftTest) Execute(opts *core.CreateOptions, platform hyperv1.Plat
 	}
 }
 
+// executeOnExistingCluster runs the test against the configured existing
+// cluster. Nothing is torn down, the cluster is only dumped if the test fails.
+func (h *hypershiftTest) executeOnExistingCluster(opts *core.CreateOptions, artifactDir string) {
+	hostedCluster := h.getExistingHostedCluster(opts)
+	if h.Failed() {
+		return
+	}
+	platform := hostedCluster.Spec.Platform.Type
+
+	defer func() {
+		if err := recover(); err != nil {
+			h.Errorf(string(debug.Stack()))
+		}
+
+		if h.Failed() {
+			h.summarizeHCConditions(hostedCluster, opts)
+			if err := newClusterDumper(hostedCluster, opts, artifactDir)(context.Background(), h.T, true); err != nil {
+				h.Errorf("Failed to dump cluster: %v", err)
+			}
+		}
+	}()
+
+	h.before(hostedCluster, opts, platform)
+
+	if h.test != nil && !h.Failed() {
+		h.Run("Main", func(t *testing.T) {
+			h.test(t, NewWithT(t), h.client, hostedCluster)
+		})
+	}
+
+	h.after(hostedCluster, platform)
+}
+
 // runs before each test.
 func (h *hypershiftTest) before(hostedCluster *hyperv1.HostedCluster, opts *core.CreateOptions, platform hyperv1.PlatformType) {
 	h.Run("ValidateHostedCluster", func(t *testing.T) {
@@ -277,6 +326,23 @@ func (h *hypershiftTest) createHostedCluster(opts *core.CreateOptions, platform
 	return hc
 }
 
+func (h *hypershiftTest) getExistingHostedCluster(opts *core.CreateOptions) *hyperv1.HostedCluster {
+	h.Logf("getExistingHostedCluster()")
+
+	hc := &hyperv1.HostedCluster{}
+	if err := h.client.Get(h.ctx, *existingCluster, hc); err != nil {
+		h.Errorf("failed to get existing cluster %s: %v", existingCluster, err)
+		return hc
+	}
+	if _, err := existingClusterOpts(h.ctx, h.client, hc, opts); err != nil {
+		h.Errorf("failed to generate options for existing cluster %s: %v", existingCluster, err)
+		return hc
+	}
+
+	h.Logf("Using existing hostedcluster %s/%s", hc.Namespace, hc.Name)
+	return hc
+}
+
 // NOTE: teardownHostedCluster shouldn't start any subtests with t.Run() when cleanupPhase=True, this is not a supported operation and will fail immediately
 func teardownHostedCluster(t *testing.T, ctx context.Context, hc *hyperv1.HostedCluster, client crclient.Client, opts *core.CreateOptions, artifactDir string, cleanupPhase bool) {
 	// TODO (Mulham): dumpCluster() uses testName to construc dumpDir, since we removed sub tests from this function
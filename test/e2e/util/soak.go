@@ -0,0 +1,284 @@
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	hyperv1 "github.com/openshift/hypershift/api/hypershift/v1beta1"
+	cpomanifests "github.com/openshift/hypershift/control-plane-operator/controllers/hostedcontrolplane/manifests"
+	"github.com/openshift/hypershift/hypershift-operator/controllers/manifests"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/utils/ptr"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	. "github.com/onsi/gomega"
+)
+
+// SoakDisruptionType is a kind of disruption injected into a hosted control
+// plane during a soak run.
+type SoakDisruptionType string
+
+const (
+	// KillControlPlanePod deletes a random pod of the hosted control plane.
+	KillControlPlanePod SoakDisruptionType = "KillControlPlanePod"
+	// RestartEtcdMember deletes a random etcd member pod.
+	RestartEtcdMember SoakDisruptionType = "RestartEtcdMember"
+	// DrainManagementNode cordons the management node running a random control
+	// plane pod and evicts the control plane pods from it.
+	DrainManagementNode SoakDisruptionType = "DrainManagementNode"
+)
+
+// SoakOptions configure a control plane soak run.
+type SoakOptions struct {
+	// Duration is how long disruptions are injected for.
+	Duration time.Duration
+	// DisruptionInterval is the time between two disruptions.
+	DisruptionInterval time.Duration
+	// ProbeInterval is the time between two availability probes of the hosted
+	// API server.
+	ProbeInterval time.Duration
+	// Disruptions are the kinds of disruption to pick from.
+	Disruptions []SoakDisruptionType
+}
+
+// SoakDisruption records a single injected disruption.
+type SoakDisruption struct {
+	Time   time.Time          `json:"time"`
+	Type   SoakDisruptionType `json:"type"`
+	Target string             `json:"target,omitempty"`
+	Error  string             `json:"error,omitempty"`
+}
+
+// SoakProbe records the outcome of a single availability probe.
+type SoakProbe struct {
+	Time    time.Time `json:"time"`
+	Success bool      `json:"success"`
+}
+
+// AvailabilityReport summarizes the hosted API availability observed during a
+// soak run.
+type AvailabilityReport struct {
+	Cluster       string           `json:"cluster"`
+	Start         time.Time        `json:"start"`
+	End           time.Time        `json:"end"`
+	Probes        int              `json:"probes"`
+	FailedProbes  int              `json:"failedProbes"`
+	Availability  float64          `json:"availabilityPercent"`
+	LongestOutage metav1.Duration  `json:"longestOutage"`
+	Disruptions   []SoakDisruption `json:"disruptions"`
+}
+
+// NewAvailabilityReport computes the availability of the hosted API server from
+// the probes taken during a soak run. An outage spans from the first failed
+// probe to the next successful one.
+func NewAvailabilityReport(cluster string, start, end time.Time, probes []SoakProbe, disruptions []SoakDisruption) *AvailabilityReport {
+	report := &AvailabilityReport{
+		Cluster:      cluster,
+		Start:        start,
+		End:          end,
+		Probes:       len(probes),
+		Availability: 100,
+		Disruptions:  disruptions,
+	}
+
+	var outageStart *time.Time
+	for i := range probes {
+		probe := probes[i]
+		if !probe.Success {
+			report.FailedProbes++
+			if outageStart == nil {
+				outageStart = &probe.Time
+			}
+			continue
+		}
+		if outageStart != nil {
+			if outage := probe.Time.Sub(*outageStart); outage > report.LongestOutage.Duration {
+				report.LongestOutage.Duration = outage
+			}
+			outageStart = nil
+		}
+	}
+	if outageStart != nil {
+		if outage := end.Sub(*outageStart); outage > report.LongestOutage.Duration {
+			report.LongestOutage.Duration = outage
+		}
+	}
+	if report.Probes > 0 {
+		report.Availability = float64(report.Probes-report.FailedProbes) / float64(report.Probes) * 100
+	}
+	return report
+}
+
+// WriteAvailabilityReport writes the report as JSON into the artifact directory.
+func WriteAvailabilityReport(report *AvailabilityReport, artifactDir string) error {
+	if artifactDir == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal availability report: %w", err)
+	}
+	return os.WriteFile(filepath.Join(artifactDir, "soak-availability-report.json"), data, 0644)
+}
+
+// RunControlPlaneSoak continuously disrupts the hosted control plane for the
+// configured duration while probing the hosted API server, and returns the
+// resulting availability report.
+func RunControlPlaneSoak(t *testing.T, ctx context.Context, client crclient.Client, hostedCluster *hyperv1.HostedCluster, opts SoakOptions) *AvailabilityReport {
+	g := NewWithT(t)
+
+	kubeconfig, err := WaitForGuestKubeConfig(t, ctx, client, hostedCluster)
+	g.Expect(err).NotTo(HaveOccurred(), "couldn't get kubeconfig")
+	guestConfig, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	g.Expect(err).NotTo(HaveOccurred(), "couldn't load guest kubeconfig")
+	guestConfig.Timeout = 5 * time.Second
+	guestKubeClient, err := kubernetes.NewForConfig(guestConfig)
+	g.Expect(err).NotTo(HaveOccurred(), "failed to create guest kube client")
+
+	soakCtx, cancel := context.WithTimeout(ctx, opts.Duration)
+	defer cancel()
+
+	var (
+		probesLock sync.Mutex
+		probes     []SoakProbe
+		wg         sync.WaitGroup
+	)
+	start := time.Now()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		wait.UntilWithContext(soakCtx, func(ctx context.Context) {
+			err := guestKubeClient.Discovery().RESTClient().Get().AbsPath("/readyz").Do(ctx).Error()
+			if ctx.Err() != nil {
+				// The soak ended while probing, this is not an outage.
+				return
+			}
+			probesLock.Lock()
+			defer probesLock.Unlock()
+			probes = append(probes, SoakProbe{Time: time.Now(), Success: err == nil})
+		}, opts.ProbeInterval)
+	}()
+
+	var disruptions []SoakDisruption
+	t.Logf("Disrupting the control plane every %s for %s", opts.DisruptionInterval, opts.Duration)
+	wait.UntilWithContext(soakCtx, func(ctx context.Context) {
+		disruption := disruptControlPlane(ctx, client, hostedCluster, opts.Disruptions[rand.Intn(len(opts.Disruptions))])
+		if disruption.Error != "" {
+			t.Logf("Failed to inject disruption %s: %s", disruption.Type, disruption.Error)
+		} else {
+			t.Logf("Injected disruption %s on %s", disruption.Type, disruption.Target)
+		}
+		disruptions = append(disruptions, disruption)
+	}, opts.DisruptionInterval)
+	wg.Wait()
+
+	return NewAvailabilityReport(crclient.ObjectKeyFromObject(hostedCluster).String(), start, time.Now(), probes, disruptions)
+}
+
+func disruptControlPlane(ctx context.Context, client crclient.Client, hostedCluster *hyperv1.HostedCluster, disruptionType SoakDisruptionType) SoakDisruption {
+	disruption := SoakDisruption{Time: time.Now(), Type: disruptionType}
+	controlPlaneNamespace := manifests.HostedControlPlaneNamespace(hostedCluster.Namespace, hostedCluster.Name)
+
+	var err error
+	switch disruptionType {
+	case KillControlPlanePod:
+		disruption.Target, err = deleteRandomPod(ctx, client, controlPlaneNamespace, labels.Everything())
+	case RestartEtcdMember:
+		etcdSts := cpomanifests.EtcdStatefulSet(controlPlaneNamespace)
+		if err = client.Get(ctx, crclient.ObjectKeyFromObject(etcdSts), etcdSts); err == nil {
+			disruption.Target, err = deleteRandomPod(ctx, client, controlPlaneNamespace, labels.Set(etcdSts.Spec.Selector.MatchLabels).AsSelector())
+		}
+	case DrainManagementNode:
+		disruption.Target, err = drainControlPlaneFromRandomNode(ctx, client, controlPlaneNamespace)
+	default:
+		err = fmt.Errorf("unknown disruption type %s", disruptionType)
+	}
+	if err != nil {
+		disruption.Error = err.Error()
+	}
+	return disruption
+}
+
+func deleteRandomPod(ctx context.Context, client crclient.Client, namespace string, selector labels.Selector) (string, error) {
+	pods := &corev1.PodList{}
+	if err := client.List(ctx, pods, &crclient.ListOptions{Namespace: namespace, LabelSelector: selector}); err != nil {
+		return "", fmt.Errorf("failed to list pods: %w", err)
+	}
+	if len(pods.Items) == 0 {
+		return "", fmt.Errorf("no pods found in namespace %s", namespace)
+	}
+	pod := &pods.Items[rand.Intn(len(pods.Items))]
+	if err := client.Delete(ctx, pod, &crclient.DeleteOptions{GracePeriodSeconds: ptr.To[int64](0)}); err != nil {
+		return pod.Name, fmt.Errorf("failed to delete pod %s: %w", pod.Name, err)
+	}
+	return pod.Name, nil
+}
+
+// drainControlPlaneFromRandomNode cordons the node running a random control
+// plane pod and evicts all the control plane pods running on it. Other
+// workloads of the management cluster are left untouched. The node is
+// uncordoned once the evictions have been requested.
+func drainControlPlaneFromRandomNode(ctx context.Context, client crclient.Client, namespace string) (string, error) {
+	pods := &corev1.PodList{}
+	if err := client.List(ctx, pods, crclient.InNamespace(namespace)); err != nil {
+		return "", fmt.Errorf("failed to list pods: %w", err)
+	}
+	var scheduled []corev1.Pod
+	for _, pod := range pods.Items {
+		if pod.Spec.NodeName != "" {
+			scheduled = append(scheduled, pod)
+		}
+	}
+	if len(scheduled) == 0 {
+		return "", fmt.Errorf("no scheduled pods found in namespace %s", namespace)
+	}
+	nodeName := scheduled[rand.Intn(len(scheduled))].Spec.NodeName
+
+	node := &corev1.Node{}
+	if err := client.Get(ctx, crclient.ObjectKey{Name: nodeName}, node); err != nil {
+		return nodeName, fmt.Errorf("failed to get node %s: %w", nodeName, err)
+	}
+	if err := setNodeUnschedulable(ctx, client, node, true); err != nil {
+		return nodeName, err
+	}
+	defer func() {
+		// Use a fresh context so the node is uncordoned even when the soak ends.
+		uncordonCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		_ = setNodeUnschedulable(uncordonCtx, client, node, false)
+	}()
+
+	for i := range scheduled {
+		pod := &scheduled[i]
+		if pod.Spec.NodeName != nodeName {
+			continue
+		}
+		eviction := &policyv1.Eviction{ObjectMeta: metav1.ObjectMeta{Name: pod.Name, Namespace: pod.Namespace}}
+		if err := client.SubResource("eviction").Create(ctx, pod, eviction); err != nil {
+			return nodeName, fmt.Errorf("failed to evict pod %s: %w", pod.Name, err)
+		}
+	}
+	return nodeName, nil
+}
+
+func setNodeUnschedulable(ctx context.Context, client crclient.Client, node *corev1.Node, unschedulable bool) error {
+	original := node.DeepCopy()
+	node.Spec.Unschedulable = unschedulable
+	if err := client.Patch(ctx, node, crclient.MergeFrom(original)); err != nil {
+		return fmt.Errorf("failed to set node %s unschedulable=%t: %w", node.Name, unschedulable, err)
+	}
+	return nil
+}
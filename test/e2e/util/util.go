@@ -1520,6 +1520,11 @@ func validateHostedClusterConditions(t *testing.T, ctx context.Context, client c
 			// Azure KMS is not configured
 			expectedConditions[hyperv1.ValidAzureKMSConfig] = metav1.ConditionUnknown
 		}
+	case hyperv1.IBMCloudPlatform, hyperv1.PowerVSPlatform:
+		if hostedCluster.Spec.SecretEncryption == nil || hostedCluster.Spec.SecretEncryption.KMS == nil || hostedCluster.Spec.SecretEncryption.KMS.IBMCloud == nil {
+			// IBM Cloud KMS is not configured
+			expectedConditions[hyperv1.ValidIBMCloudKMSConfig] = metav1.ConditionUnknown
+		}
 	}
 
 	kasExternalHostname := support.ServiceExternalDNSHostnameByHC(hostedCluster, hyperv1.APIServer)